@@ -196,7 +196,11 @@ func (c *Channel) SetInstalledVersion(ctx context.Context, k8sClient kubernetes.
 		return err
 	}
 
-	annotationPatch := &annotationPatch{Metadata: annotationPatchMetadata{Annotations: map[string]string{c.AnnotationName(): value}}}
+	return c.patchAnnotation(ctx, k8sClient, c.AnnotationName(), value)
+}
+
+func (c *Channel) patchAnnotation(ctx context.Context, k8sClient kubernetes.Interface, key string, value string) error {
+	annotationPatch := &annotationPatch{Metadata: annotationPatchMetadata{Annotations: map[string]string{key: value}}}
 	annotationPatchJSON, err := json.Marshal(annotationPatch)
 	if err != nil {
 		return fmt.Errorf("error building annotation patch: %v", err)
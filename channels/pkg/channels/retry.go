@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/multierr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// RetryOptions controls how addons that fail to apply are retried within a single channels run.
+type RetryOptions struct {
+	// MaxRetries is the maximum number of times a failing addon is retried.
+	MaxRetries int
+	// Backoff is the delay before the first retry; it doubles with each retry.
+	Backoff time.Duration
+}
+
+// EnsureFunc applies an addon, returning the update that was applied, if any.
+type EnsureFunc func(ctx context.Context, addon *Addon) (*AddonUpdate, error)
+
+// EnsureUpdatedWithRetry applies the addons in order, then retries the addons that failed, with backoff.
+// Addons often fail because of an addon applied later in the run, such as a webhook that is not ready yet.
+// The apply status of each addon is recorded on its namespace.
+// The updates applied are returned, along with the errors of the addons that still fail.
+func EnsureUpdatedWithRetry(ctx context.Context, k8sClient kubernetes.Interface, addons []*Addon, ensure EnsureFunc, options RetryOptions) ([]*AddonUpdate, error) {
+	var updates []*AddonUpdate
+
+	pending := addons
+	backoff := options.Backoff
+	for retry := 0; ; retry++ {
+		var failed []*Addon
+		var merr error
+
+		for _, addon := range pending {
+			update, err := ensure(ctx, addon)
+			if err != nil {
+				merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", addon.Name, err))
+				failed = append(failed, addon)
+			} else if update != nil {
+				updates = append(updates, update)
+			}
+
+			if err := recordStatus(ctx, k8sClient, addon, retry, err); err != nil {
+				klog.Warningf("failed to record the status of addon %q: %v", addon.Name, err)
+			}
+		}
+
+		if len(failed) == 0 {
+			return updates, nil
+		}
+		if retry >= options.MaxRetries {
+			return updates, merr
+		}
+
+		klog.Infof("%d addons failed to apply, retrying in %v: %v", len(failed), backoff, merr)
+		select {
+		case <-ctx.Done():
+			return updates, multierr.Append(merr, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		pending = failed
+	}
+}
+
+func recordStatus(ctx context.Context, k8sClient kubernetes.Interface, addon *Addon, retry int, applyErr error) error {
+	channel := addon.buildChannel()
+
+	status, err := channel.GetStatus(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		status = &AddonStatus{}
+	}
+
+	status.LastAttemptedManifestHash = addon.Spec.ManifestHash
+	status.RetryCount = retry
+	if applyErr != nil {
+		status.LastError = applyErr.Error()
+	} else {
+		status.LastError = ""
+		status.LastAppliedManifestHash = addon.Spec.ManifestHash
+	}
+
+	return channel.SetStatus(ctx, k8sClient, status)
+}
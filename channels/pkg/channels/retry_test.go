@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fakecertmanager "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// flakyApplier fails to apply a manifest until it has been applied a number of times.
+type flakyApplier struct {
+	failures map[string]int
+	calls    map[string]int
+}
+
+func (a *flakyApplier) Apply(ctx context.Context, data []byte) error {
+	manifest := string(data)
+	a.calls[manifest]++
+	if a.calls[manifest] <= a.failures[manifest] {
+		return fmt.Errorf("webhook for %q is not ready", manifest)
+	}
+	return nil
+}
+
+func buildRetryTestAddon(t *testing.T, name string) *Addon {
+	manifest := filepath.Join(t.TempDir(), name+".yaml")
+	if err := os.WriteFile(manifest, []byte(name), 0o644); err != nil {
+		t.Fatalf("error writing manifest: %v", err)
+	}
+	return &Addon{
+		Name:        name,
+		ChannelName: "test",
+		Spec: &api.AddonSpec{
+			Name:         fi.PtrTo(name),
+			Id:           "k8s-1.25",
+			Manifest:     fi.PtrTo("file://" + manifest),
+			ManifestHash: name + "-hash",
+		},
+	}
+}
+
+func runRetryTest(t *testing.T, failures map[string]int, options RetryOptions) (*fakekubernetes.Clientset, *flakyApplier, []*AddonUpdate, error) {
+	ctx := context.Background()
+	kubeSystem := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kube-system",
+		},
+	}
+	fakek8s := fakekubernetes.NewSimpleClientset(kubeSystem)
+	fakecm := fakecertmanager.NewSimpleClientset()
+
+	addons := []*Addon{
+		buildRetryTestAddon(t, "flaky"),
+		buildRetryTestAddon(t, "stable"),
+	}
+
+	applier := &flakyApplier{
+		failures: failures,
+		calls:    make(map[string]int),
+	}
+	ensure := func(ctx context.Context, addon *Addon) (*AddonUpdate, error) {
+		return addon.EnsureUpdated(ctx, vfs.Context, fakek8s, fakecm, &Pruner{}, applier, nil)
+	}
+
+	updates, err := EnsureUpdatedWithRetry(ctx, fakek8s, addons, ensure, options)
+	return fakek8s, applier, updates, err
+}
+
+func getRetryTestStates(t *testing.T, fakek8s *fakekubernetes.Clientset) map[string]*AddonState {
+	ns, err := fakek8s.CoreV1().Namespaces().Get(context.Background(), "kube-system", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting namespace: %v", err)
+	}
+	states := make(map[string]*AddonState)
+	for _, state := range BuildAddonStates(nil, []corev1.Namespace{*ns}) {
+		states[state.Name] = state
+	}
+	return states
+}
+
+func Test_EnsureUpdatedWithRetry(t *testing.T) {
+	// Each failed attempt applies the manifest twice, as it is applied again after pruning.
+	failures := map[string]int{"flaky": 4}
+	fakek8s, applier, updates, err := runRetryTest(t, failures, RetryOptions{MaxRetries: 3, Backoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updates) != 2 {
+		t.Errorf("expected 2 updates, got %d", len(updates))
+	}
+	if applier.calls["stable"] != 1 {
+		t.Errorf("expected the stable addon to be applied once, got %d", applier.calls["stable"])
+	}
+
+	states := getRetryTestStates(t, fakek8s)
+
+	flaky := states["flaky"]
+	if flaky == nil || flaky.Applied == nil || flaky.Status == nil {
+		t.Fatalf("expected the flaky addon to be applied with a status, got %+v", flaky)
+	}
+	if flaky.Applied.ManifestHash != "flaky-hash" {
+		t.Errorf("expected the flaky addon to be installed, got %v", flaky.Applied)
+	}
+	expected := AddonStatus{
+		LastAppliedManifestHash:   "flaky-hash",
+		LastAttemptedManifestHash: "flaky-hash",
+		RetryCount:                2,
+	}
+	if *flaky.Status != expected {
+		t.Errorf("unexpected status of the flaky addon, expected %+v, got %+v", expected, *flaky.Status)
+	}
+
+	stable := states["stable"]
+	if stable == nil || stable.Status == nil || stable.Status.RetryCount != 0 || stable.Status.Failed() {
+		t.Errorf("expected the stable addon to be applied without retries, got %+v", stable)
+	}
+}
+
+func Test_EnsureUpdatedWithRetryExhausted(t *testing.T) {
+	failures := map[string]int{"flaky": 100}
+	fakek8s, applier, updates, err := runRetryTest(t, failures, RetryOptions{MaxRetries: 1, Backoff: time.Millisecond})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if len(updates) != 1 || updates[0].Name != "stable" {
+		t.Errorf("expected only the stable addon to be updated, got %v", updates)
+	}
+	if applier.calls["flaky"] != 4 {
+		t.Errorf("expected the flaky addon to be applied 4 times, got %d", applier.calls["flaky"])
+	}
+
+	flaky := getRetryTestStates(t, fakek8s)["flaky"]
+	if flaky == nil || flaky.Status == nil {
+		t.Fatalf("expected the flaky addon to have a status, got %+v", flaky)
+	}
+	if flaky.Applied != nil {
+		t.Errorf("expected the flaky addon not to be installed, got %v", flaky.Applied)
+	}
+	if !flaky.Status.Failed() || flaky.Status.RetryCount != 1 || flaky.Status.LastAppliedManifestHash != "" {
+		t.Errorf("unexpected status of the flaky addon: %+v", *flaky.Status)
+	}
+}
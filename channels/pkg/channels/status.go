@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// StatusAnnotationPrefix is the prefix of the namespace annotations that record the apply status of each addon.
+// It differs from AnnotationPrefix so that the status is not mistaken for an installed version.
+const StatusAnnotationPrefix = "status.addons.k8s.io/"
+
+// AddonStatus records the outcome of the last channels run that applied an addon.
+type AddonStatus struct {
+	// LastAppliedManifestHash is the manifest hash of the last version that was applied successfully.
+	LastAppliedManifestHash string `json:"lastAppliedManifestHash,omitempty"`
+	// LastAttemptedManifestHash is the manifest hash of the version applied in the last run.
+	LastAttemptedManifestHash string `json:"lastAttemptedManifestHash,omitempty"`
+	// LastError is the error of the last attempt, or empty if it succeeded.
+	LastError string `json:"lastError,omitempty"`
+	// RetryCount is the number of times the addon was retried in the last run.
+	RetryCount int `json:"retryCount,omitempty"`
+}
+
+// Failed returns true if the last attempt to apply the addon failed.
+func (s *AddonStatus) Failed() bool {
+	return s.LastError != ""
+}
+
+func (c *Channel) StatusAnnotationName() string {
+	return StatusAnnotationPrefix + c.Name
+}
+
+// FindAddonStatuses returns the apply status of the addons recorded on the namespace, keyed by addon name.
+func FindAddonStatuses(ns *v1.Namespace) map[string]*AddonStatus {
+	statuses := make(map[string]*AddonStatus)
+	for k, v := range ns.Annotations {
+		if !strings.HasPrefix(k, StatusAnnotationPrefix) {
+			continue
+		}
+
+		status := &AddonStatus{}
+		if err := json.Unmarshal([]byte(v), status); err != nil {
+			klog.Warningf("failed to parse annotation %q=%q", k, v)
+			continue
+		}
+
+		name := strings.TrimPrefix(k, StatusAnnotationPrefix)
+		statuses[name] = status
+	}
+	return statuses
+}
+
+// GetStatus returns the apply status recorded for the channel, or nil if there is none.
+func (c *Channel) GetStatus(ctx context.Context, k8sClient kubernetes.Interface) (*AddonStatus, error) {
+	ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, c.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error querying namespace %q: %v", c.Namespace, err)
+	}
+
+	return FindAddonStatuses(ns)[c.Name], nil
+}
+
+// SetStatus records the apply status of the channel.
+func (c *Channel) SetStatus(ctx context.Context, k8sClient kubernetes.Interface, status *AddonStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("error encoding addon status: %v", err)
+	}
+
+	return c.patchAnnotation(ctx, k8sClient, c.StatusAnnotationName(), string(data))
+}
+
+// AddonState compares the desired version of an addon with the version applied to the cluster.
+type AddonState struct {
+	Name      string
+	Namespace string
+	// Desired is the version in the channels, or nil if the addon is no longer in the channels.
+	Desired *ChannelVersion
+	// Applied is the version installed in the cluster, or nil if the addon was never applied.
+	Applied *ChannelVersion
+	// Status is the outcome of the last attempt to apply the addon, or nil if none was recorded.
+	Status *AddonStatus
+}
+
+// NeedsUpdate returns true if the desired version of the addon has not been applied.
+func (s *AddonState) NeedsUpdate() bool {
+	if s.Desired == nil {
+		return false
+	}
+	if s.Applied == nil {
+		return true
+	}
+	return s.Desired.replaces(s.Name, s.Applied)
+}
+
+// BuildAddonStates returns the state of the addons of the menu and of the addons recorded on the namespaces,
+// sorted by namespace and name.
+func BuildAddonStates(menu *AddonMenu, namespaces []v1.Namespace) []*AddonState {
+	states := make(map[string]*AddonState)
+	get := func(namespace, name string) *AddonState {
+		key := namespace + ":" + name
+		state := states[key]
+		if state == nil {
+			state = &AddonState{Name: name, Namespace: namespace}
+			states[key] = state
+		}
+		return state
+	}
+
+	if menu != nil {
+		for _, addon := range menu.Addons {
+			get(addon.GetNamespace(), addon.Name).Desired = addon.ChannelVersion()
+		}
+	}
+
+	for i := range namespaces {
+		ns := &namespaces[i]
+		for name, version := range FindChannelVersions(ns) {
+			get(ns.Name, name).Applied = version
+		}
+		for name, status := range FindAddonStatuses(ns) {
+			get(ns.Name, name).Status = status
+		}
+	}
+
+	var result []*AddonState
+	for _, state := range states {
+		result = append(result, state)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func Test_BuildAddonStates(t *testing.T) {
+	menu := NewAddonMenu()
+	for _, name := range []string{"current", "failing", "new"} {
+		menu.Addons[name] = &Addon{
+			Name:        name,
+			ChannelName: "test",
+			Spec: &api.AddonSpec{
+				Name:         fi.PtrTo(name),
+				Id:           "k8s-1.25",
+				ManifestHash: name + "-v2",
+			},
+		}
+	}
+
+	namespaces := []corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kube-system",
+				Annotations: map[string]string{
+					"addons.k8s.io/current":        `{"channel":"test","id":"k8s-1.25","manifestHash":"current-v2","systemGeneration":1}`,
+					"addons.k8s.io/failing":        `{"channel":"test","id":"k8s-1.25","manifestHash":"failing-v1","systemGeneration":1}`,
+					"status.addons.k8s.io/failing": `{"lastAppliedManifestHash":"failing-v1","lastAttemptedManifestHash":"failing-v2","lastError":"webhook not ready","retryCount":3}`,
+					"addons.k8s.io/removed":        `{"channel":"test","id":"k8s-1.25","manifestHash":"removed-v1","systemGeneration":1}`,
+				},
+			},
+		},
+	}
+
+	states := BuildAddonStates(menu, namespaces)
+
+	grid := []struct {
+		Name        string
+		NeedsUpdate bool
+		HasDesired  bool
+		HasApplied  bool
+		LastError   string
+	}{
+		{Name: "current", HasDesired: true, HasApplied: true},
+		{Name: "failing", NeedsUpdate: true, HasDesired: true, HasApplied: true, LastError: "webhook not ready"},
+		{Name: "new", NeedsUpdate: true, HasDesired: true},
+		{Name: "removed", HasApplied: true},
+	}
+	if len(states) != len(grid) {
+		t.Fatalf("expected %d states, got %d", len(grid), len(states))
+	}
+	for i, g := range grid {
+		state := states[i]
+		if state.Name != g.Name || state.Namespace != "kube-system" {
+			t.Errorf("expected state %d to be kube-system:%s, got %s:%s", i, g.Name, state.Namespace, state.Name)
+			continue
+		}
+		if state.NeedsUpdate() != g.NeedsUpdate {
+			t.Errorf("%s: expected NeedsUpdate %v", g.Name, g.NeedsUpdate)
+		}
+		if (state.Desired != nil) != g.HasDesired || (state.Applied != nil) != g.HasApplied {
+			t.Errorf("%s: unexpected desired %v or applied %v", g.Name, state.Desired, state.Applied)
+		}
+		lastError := ""
+		if state.Status != nil {
+			lastError = state.Status.LastError
+		}
+		if lastError != g.LastError {
+			t.Errorf("%s: expected last error %q, got %q", g.Name, g.LastError, lastError)
+		}
+	}
+}
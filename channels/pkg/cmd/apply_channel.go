@@ -22,11 +22,11 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/spf13/cobra"
-	"go.uber.org/multierr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -38,21 +38,36 @@ import (
 
 type ApplyChannelOptions struct {
 	Yes bool
+
+	// MaxRetries is the maximum number of times an addon that fails to apply is retried.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry of the addons that failed to apply.
+	RetryBackoff time.Duration
+}
+
+// NewApplyChannelOptions returns the default options for applying a channel.
+func NewApplyChannelOptions() *ApplyChannelOptions {
+	return &ApplyChannelOptions{
+		MaxRetries:   3,
+		RetryBackoff: 5 * time.Second,
+	}
 }
 
 func NewCmdApplyChannel(f Factory, out io.Writer) *cobra.Command {
-	var options ApplyChannelOptions
+	options := NewApplyChannelOptions()
 
 	cmd := &cobra.Command{
 		Use:   "channel CHANNEL",
 		Short: "Applies updates from the given channel",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.TODO()
-			return RunApplyChannel(ctx, f, out, &options, args)
+			return RunApplyChannel(ctx, f, out, options, args)
 		},
 	}
 
 	cmd.Flags().BoolVar(&options.Yes, "yes", false, "Apply update")
+	cmd.Flags().IntVar(&options.MaxRetries, "max-retries", options.MaxRetries, "Maximum number of times an addon that fails to apply is retried")
+	cmd.Flags().DurationVar(&options.RetryBackoff, "retry-backoff", options.RetryBackoff, "Delay before retrying the addons that failed to apply; doubles with each retry")
 
 	return cmd
 }
@@ -98,15 +113,15 @@ func RunApplyChannel(ctx context.Context, f Factory, out io.Writer, options *App
 	channelLocation := args[0]
 
 	// menu is the expected list of addons in the cluster and their configurations.
-	menu, err := buildMenu(f.VFSContext(), kubernetesVersion, channelLocation)
+	menu, err := BuildMenu(f.VFSContext(), kubernetesVersion, channelLocation)
 	if err != nil {
 		return fmt.Errorf("cannot build the addon menu from args: %w", err)
 	}
 
-	return applyMenu(ctx, menu, f.VFSContext(), k8sClient, cmClient, dynamicClient, restMapper, options.Yes)
+	return applyMenu(ctx, menu, f.VFSContext(), k8sClient, cmClient, dynamicClient, restMapper, options)
 }
 
-func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient versioned.Interface, dynamicClient dynamic.Interface, restMapper *restmapper.DeferredDiscoveryRESTMapper, apply bool) error {
+func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient versioned.Interface, dynamicClient dynamic.Interface, restMapper *restmapper.DeferredDiscoveryRESTMapper, options *ApplyChannelOptions) error {
 	// channelVersions is the list of installed addons in the cluster.
 	// It is keyed by <namespace>:<addon name>.
 	channelVersions, err := getChannelVersions(ctx, k8sClient)
@@ -155,7 +170,7 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 		}
 	}
 
-	if !apply {
+	if !options.Yes {
 		fmt.Printf("\nMust specify --yes to update\n")
 		return nil
 	}
@@ -170,18 +185,19 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 		RESTMapper: restMapper,
 	}
 
-	var merr error
-
-	for _, needUpdate := range needUpdates {
-		update, err := needUpdate.EnsureUpdated(ctx, vfsContext, k8sClient, cmClient, pruner, applier, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name])
-		if err != nil {
-			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
-		} else if update != nil {
-			fmt.Printf("Updated %q\n", update.Name)
-		}
+	ensure := func(ctx context.Context, addon *channels.Addon) (*channels.AddonUpdate, error) {
+		return addon.EnsureUpdated(ctx, vfsContext, k8sClient, cmClient, pruner, applier, channelVersions[addon.GetNamespace()+":"+addon.Name])
+	}
+	retry := channels.RetryOptions{
+		MaxRetries: options.MaxRetries,
+		Backoff:    options.RetryBackoff,
+	}
+	updated, err := channels.EnsureUpdatedWithRetry(ctx, k8sClient, needUpdates, ensure, retry)
+	for _, update := range updated {
+		fmt.Printf("Updated %q\n", update.Name)
 	}
 
-	return merr
+	return err
 }
 
 func getUpdates(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernetes.Interface, cmClient versioned.Interface, channelVersions map[string]*channels.ChannelVersion) ([]*channels.AddonUpdate, []*channels.Addon, error) {
@@ -217,7 +233,8 @@ func getChannelVersions(ctx context.Context, k8sClient kubernetes.Interface) (ma
 	return channelVersions, nil
 }
 
-func buildMenu(vfsContext *vfs.VFSContext, kubernetesVersion semver.Version, channelLocation string) (*channels.AddonMenu, error) {
+// BuildMenu loads the addons of a channel that apply to the kubernetes version.
+func BuildMenu(vfsContext *vfs.VFSContext, kubernetesVersion semver.Version, channelLocation string) (*channels.AddonMenu, error) {
 	menu := channels.NewAddonMenu()
 
 	location, err := url.Parse(channelLocation)
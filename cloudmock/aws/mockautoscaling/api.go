@@ -30,7 +30,9 @@ type MockAutoscaling struct {
 	mutex             sync.Mutex
 	Groups            map[string]*autoscaling.Group
 	WarmPoolInstances map[string][]*autoscaling.Instance
+	WarmPools         map[string]*autoscaling.WarmPoolConfiguration
 	LifecycleHooks    map[string]*autoscaling.LifecycleHook
+	ScheduledActions  map[string]*autoscaling.ScheduledUpdateGroupAction
 }
 
 var _ autoscalingiface.AutoScalingAPI = &MockAutoscaling{}
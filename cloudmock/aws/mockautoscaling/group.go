@@ -68,14 +68,16 @@ func (m *MockAutoscaling) CreateAutoScalingGroupWithContext(ctx aws.Context, inp
 		LoadBalancerNames:                input.LoadBalancerNames,
 		MaxSize:                          input.MaxSize,
 		MinSize:                          input.MinSize,
+		MixedInstancesPolicy:             input.MixedInstancesPolicy,
 		NewInstancesProtectedFromScaleIn: input.NewInstancesProtectedFromScaleIn,
 		PlacementGroup:                   input.PlacementGroup,
 		// Status:                           input.Status,
-		SuspendedProcesses:  make([]*autoscaling.SuspendedProcess, 0),
-		TargetGroupARNs:     input.TargetGroupARNs,
-		TerminationPolicies: input.TerminationPolicies,
-		VPCZoneIdentifier:   input.VPCZoneIdentifier,
-		MaxInstanceLifetime: input.MaxInstanceLifetime,
+		SuspendedProcesses:        make([]*autoscaling.SuspendedProcess, 0),
+		TargetGroupARNs:           input.TargetGroupARNs,
+		TerminationPolicies:       input.TerminationPolicies,
+		VPCZoneIdentifier:         input.VPCZoneIdentifier,
+		MaxInstanceLifetime:       input.MaxInstanceLifetime,
+		InstanceMaintenancePolicy: input.InstanceMaintenancePolicy,
 	}
 
 	if input.LaunchTemplate != nil {
@@ -131,6 +133,14 @@ func (m *MockAutoscaling) UpdateAutoScalingGroupWithContext(ctx context.Context,
 	if request.LaunchConfigurationName != nil {
 		group.LaunchConfigurationName = request.LaunchConfigurationName
 	}
+	if request.InstanceMaintenancePolicy != nil {
+		// A policy of -1 percentages clears the policy
+		if aws.Int64Value(request.InstanceMaintenancePolicy.MinHealthyPercentage) == -1 && aws.Int64Value(request.InstanceMaintenancePolicy.MaxHealthyPercentage) == -1 {
+			group.InstanceMaintenancePolicy = nil
+		} else {
+			group.InstanceMaintenancePolicy = request.InstanceMaintenancePolicy
+		}
+	}
 	if request.LaunchTemplate != nil {
 		group.LaunchTemplate = request.LaunchTemplate
 	}
@@ -144,7 +154,7 @@ func (m *MockAutoscaling) UpdateAutoScalingGroupWithContext(ctx context.Context,
 		group.MinSize = request.MinSize
 	}
 	if request.MixedInstancesPolicy != nil {
-		group.MixedInstancesPolicy = request.MixedInstancesPolicy
+		updateMixedInstancesPolicy(group, request.MixedInstancesPolicy)
 	}
 	if request.NewInstancesProtectedFromScaleIn != nil {
 		group.NewInstancesProtectedFromScaleIn = request.NewInstancesProtectedFromScaleIn
@@ -164,6 +174,43 @@ func (m *MockAutoscaling) UpdateAutoScalingGroupWithContext(ctx context.Context,
 	return &autoscaling.UpdateAutoScalingGroupOutput{}, nil
 }
 
+// updateMixedInstancesPolicy merges the specified settings into the mixed instances policy of the group,
+// as the fields that are not set in an update keep their current values.
+func updateMixedInstancesPolicy(group *autoscaling.Group, update *autoscaling.MixedInstancesPolicy) {
+	if group.MixedInstancesPolicy == nil {
+		group.MixedInstancesPolicy = update
+		group.LaunchTemplate = nil
+		return
+	}
+	policy := group.MixedInstancesPolicy
+	if update.LaunchTemplate != nil {
+		policy.LaunchTemplate = update.LaunchTemplate
+	}
+	if d := update.InstancesDistribution; d != nil {
+		if policy.InstancesDistribution == nil {
+			policy.InstancesDistribution = &autoscaling.InstancesDistribution{}
+		}
+		if d.OnDemandAllocationStrategy != nil {
+			policy.InstancesDistribution.OnDemandAllocationStrategy = d.OnDemandAllocationStrategy
+		}
+		if d.OnDemandBaseCapacity != nil {
+			policy.InstancesDistribution.OnDemandBaseCapacity = d.OnDemandBaseCapacity
+		}
+		if d.OnDemandPercentageAboveBaseCapacity != nil {
+			policy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity = d.OnDemandPercentageAboveBaseCapacity
+		}
+		if d.SpotAllocationStrategy != nil {
+			policy.InstancesDistribution.SpotAllocationStrategy = d.SpotAllocationStrategy
+		}
+		if d.SpotInstancePools != nil {
+			policy.InstancesDistribution.SpotInstancePools = d.SpotInstancePools
+		}
+		if d.SpotMaxPrice != nil {
+			policy.InstancesDistribution.SpotMaxPrice = d.SpotMaxPrice
+		}
+	}
+}
+
 func (m *MockAutoscaling) EnableMetricsCollectionWithContext(ctx aws.Context, request *autoscaling.EnableMetricsCollectionInput, opts ...request.Option) (*autoscaling.EnableMetricsCollectionOutput, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -281,9 +328,59 @@ func (m *MockAutoscaling) TerminateInstanceInAutoScalingGroup(input *autoscaling
 	return nil, fmt.Errorf("Instance not found")
 }
 
-func (m *MockAutoscaling) DescribeAutoScalingGroupsWithContext(aws.Context, *autoscaling.DescribeAutoScalingGroupsInput, ...request.Option) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
-	klog.Fatalf("Not implemented")
-	return nil, nil
+func (m *MockAutoscaling) TerminateInstanceInAutoScalingGroupWithContext(ctx aws.Context, input *autoscaling.TerminateInstanceInAutoScalingGroupInput, options ...request.Option) (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("Mock TerminateInstanceInAutoScalingGroup %v", input)
+
+	for _, group := range m.Groups {
+		for i := range group.Instances {
+			if aws.StringValue(group.Instances[i].InstanceId) == aws.StringValue(input.InstanceId) {
+				if aws.BoolValue(input.ShouldDecrementDesiredCapacity) {
+					if aws.Int64Value(group.DesiredCapacity) <= aws.Int64Value(group.MinSize) {
+						return nil, fmt.Errorf("desired capacity of AutoScalingGroup %q cannot be decremented below its minimum size", aws.StringValue(group.AutoScalingGroupName))
+					}
+					group.DesiredCapacity = aws.Int64(aws.Int64Value(group.DesiredCapacity) - 1)
+				}
+				group.Instances = append(group.Instances[:i], group.Instances[i+1:]...)
+				return &autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("Instance not found")
+}
+
+func (m *MockAutoscaling) SetInstanceProtectionWithContext(ctx aws.Context, input *autoscaling.SetInstanceProtectionInput, options ...request.Option) (*autoscaling.SetInstanceProtectionOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("Mock SetInstanceProtection %v", input)
+
+	g := m.Groups[aws.StringValue(input.AutoScalingGroupName)]
+	if g == nil {
+		return nil, fmt.Errorf("AutoScaling Group not found")
+	}
+
+	for _, instanceID := range input.InstanceIds {
+		found := false
+		for _, instance := range g.Instances {
+			if aws.StringValue(instance.InstanceId) == aws.StringValue(instanceID) {
+				instance.ProtectedFromScaleIn = input.ProtectedFromScaleIn
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Instance %q not found in AutoScaling Group", aws.StringValue(instanceID))
+		}
+	}
+
+	return &autoscaling.SetInstanceProtectionOutput{}, nil
+}
+
+func (m *MockAutoscaling) DescribeAutoScalingGroupsWithContext(ctx aws.Context, input *autoscaling.DescribeAutoScalingGroupsInput, options ...request.Option) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	return m.DescribeAutoScalingGroups(input)
 }
 
 func (m *MockAutoscaling) DescribeAutoScalingGroupsRequest(*autoscaling.DescribeAutoScalingGroupsInput) (*request.Request, *autoscaling.DescribeAutoScalingGroupsOutput) {
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockautoscaling
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+func (m *MockAutoscaling) PutScheduledUpdateGroupActionWithContext(ctx aws.Context, input *autoscaling.PutScheduledUpdateGroupActionInput, options ...request.Option) (*autoscaling.PutScheduledUpdateGroupActionOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.Groups[aws.StringValue(input.AutoScalingGroupName)] == nil {
+		return nil, awserr.New("ValidationError", fmt.Sprintf("AutoScalingGroup name not found - %s", aws.StringValue(input.AutoScalingGroupName)), nil)
+	}
+
+	action := &autoscaling.ScheduledUpdateGroupAction{
+		AutoScalingGroupName: input.AutoScalingGroupName,
+		DesiredCapacity:      input.DesiredCapacity,
+		EndTime:              input.EndTime,
+		MaxSize:              input.MaxSize,
+		MinSize:              input.MinSize,
+		Recurrence:           input.Recurrence,
+		ScheduledActionName:  input.ScheduledActionName,
+		StartTime:            input.StartTime,
+		TimeZone:             input.TimeZone,
+	}
+
+	if m.ScheduledActions == nil {
+		m.ScheduledActions = make(map[string]*autoscaling.ScheduledUpdateGroupAction)
+	}
+	name := *input.AutoScalingGroupName + "::" + *input.ScheduledActionName
+	m.ScheduledActions[name] = action
+
+	return &autoscaling.PutScheduledUpdateGroupActionOutput{}, nil
+}
+
+func (m *MockAutoscaling) DescribeScheduledActionsPagesWithContext(ctx aws.Context, input *autoscaling.DescribeScheduledActionsInput, fn func(*autoscaling.DescribeScheduledActionsOutput, bool) bool, options ...request.Option) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	page := &autoscaling.DescribeScheduledActionsOutput{}
+	for _, action := range m.ScheduledActions {
+		if input.AutoScalingGroupName != nil && aws.StringValue(action.AutoScalingGroupName) != aws.StringValue(input.AutoScalingGroupName) {
+			continue
+		}
+		page.ScheduledUpdateGroupActions = append(page.ScheduledUpdateGroupActions, action)
+	}
+	sort.Slice(page.ScheduledUpdateGroupActions, func(i, j int) bool {
+		return aws.StringValue(page.ScheduledUpdateGroupActions[i].ScheduledActionName) < aws.StringValue(page.ScheduledUpdateGroupActions[j].ScheduledActionName)
+	})
+	fn(page, true)
+	return nil
+}
+
+func (m *MockAutoscaling) DeleteScheduledActionWithContext(ctx aws.Context, input *autoscaling.DeleteScheduledActionInput, options ...request.Option) (*autoscaling.DeleteScheduledActionOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	name := *input.AutoScalingGroupName + "::" + *input.ScheduledActionName
+	if m.ScheduledActions[name] == nil {
+		return nil, awserr.New("ValidationError", fmt.Sprintf("Scheduled action name not found - %s", aws.StringValue(input.ScheduledActionName)), nil)
+	}
+	delete(m.ScheduledActions, name)
+
+	return &autoscaling.DeleteScheduledActionOutput{}, nil
+}
@@ -17,22 +17,66 @@ limitations under the License.
 package mockautoscaling
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 )
 
 func (m *MockAutoscaling) DescribeWarmPoolWithContext(ctx aws.Context, input *autoscaling.DescribeWarmPoolInput, options ...request.Option) (*autoscaling.DescribeWarmPoolOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	instances, found := m.WarmPoolInstances[*input.AutoScalingGroupName]
-	if !found {
+	config := m.WarmPools[*input.AutoScalingGroupName]
+	if !found && config == nil {
 		return &autoscaling.DescribeWarmPoolOutput{}, nil
 	}
 	ret := &autoscaling.DescribeWarmPoolOutput{
-		Instances: instances,
+		Instances:             instances,
+		WarmPoolConfiguration: config,
 	}
 	return ret, nil
 }
 
-func (m *MockAutoscaling) DeleteWarmPool(*autoscaling.DeleteWarmPoolInput) (*autoscaling.DeleteWarmPoolOutput, error) {
+func (m *MockAutoscaling) PutWarmPoolWithContext(ctx aws.Context, input *autoscaling.PutWarmPoolInput, options ...request.Option) (*autoscaling.PutWarmPoolOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.Groups[*input.AutoScalingGroupName] == nil {
+		return nil, awserr.New("ValidationError", fmt.Sprintf("AutoScalingGroup name not found - %s", aws.StringValue(input.AutoScalingGroupName)), nil)
+	}
+
+	config := &autoscaling.WarmPoolConfiguration{
+		MaxGroupPreparedCapacity: input.MaxGroupPreparedCapacity,
+		MinSize:                  input.MinSize,
+		PoolState:                input.PoolState,
+		InstanceReusePolicy:      input.InstanceReusePolicy,
+	}
+	if config.PoolState == nil {
+		config.PoolState = aws.String(autoscaling.WarmPoolStateStopped)
+	}
+
+	if m.WarmPools == nil {
+		m.WarmPools = make(map[string]*autoscaling.WarmPoolConfiguration)
+	}
+	m.WarmPools[*input.AutoScalingGroupName] = config
+
+	return &autoscaling.PutWarmPoolOutput{}, nil
+}
+
+func (m *MockAutoscaling) DeleteWarmPool(input *autoscaling.DeleteWarmPoolInput) (*autoscaling.DeleteWarmPoolOutput, error) {
+	return m.DeleteWarmPoolWithContext(aws.BackgroundContext(), input)
+}
+
+func (m *MockAutoscaling) DeleteWarmPoolWithContext(ctx aws.Context, input *autoscaling.DeleteWarmPoolInput, options ...request.Option) (*autoscaling.DeleteWarmPoolOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.WarmPools, aws.StringValue(input.AutoScalingGroupName))
+	delete(m.WarmPoolInstances, aws.StringValue(input.AutoScalingGroupName))
+
 	return &autoscaling.DeleteWarmPoolOutput{}, nil
 }
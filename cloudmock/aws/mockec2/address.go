@@ -20,11 +20,13 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) AllocateAddressRequest(*ec2.AllocateAddressInput) (*request.Request, *ec2.AllocateAddressOutput) {
@@ -76,6 +78,7 @@ func (m *MockEC2) AllocateAddressWithId(request *ec2.AllocateAddressInput, id st
 }
 
 func (m *MockEC2) AllocateAddress(request *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
+	timings.RecordAPICall("ec2", "AllocateAddress")
 	klog.Infof("AllocateAddress: %v", request)
 	id := m.allocateId("eipalloc")
 	return m.AllocateAddressWithId(request, id)
@@ -101,8 +104,29 @@ func (m *MockEC2) AssociateAddressWithContext(aws.Context, *ec2.AssociateAddress
 	panic("Not implemented")
 }
 
-func (m *MockEC2) AssociateAddress(*ec2.AssociateAddressInput) (*ec2.AssociateAddressOutput, error) {
-	panic("Not implemented")
+func (m *MockEC2) AssociateAddress(request *ec2.AssociateAddressInput) (*ec2.AssociateAddressOutput, error) {
+	timings.RecordAPICall("ec2", "AssociateAddress")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("AssociateAddress: %v", request)
+
+	id := aws.StringValue(request.AllocationId)
+	address := m.Addresses[id]
+	if address == nil {
+		return nil, fmt.Errorf("Address %q not found", id)
+	}
+	if address.AssociationId != nil && !aws.BoolValue(request.AllowReassociation) {
+		return nil, fmt.Errorf("Address %q is already associated", id)
+	}
+
+	address.AssociationId = s(m.allocateId("eipassoc"))
+	address.InstanceId = request.InstanceId
+	address.NetworkInterfaceId = request.NetworkInterfaceId
+
+	return &ec2.AssociateAddressOutput{
+		AssociationId: address.AssociationId,
+	}, nil
 }
 
 func (m *MockEC2) DescribeAddressesRequest(*ec2.DescribeAddressesInput) (*request.Request, *ec2.DescribeAddressesOutput) {
@@ -114,6 +138,7 @@ func (m *MockEC2) DescribeAddressesWithContext(aws.Context, *ec2.DescribeAddress
 }
 
 func (m *MockEC2) DescribeAddresses(request *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeAddresses")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -145,7 +170,11 @@ func (m *MockEC2) DescribeAddresses(request *ec2.DescribeAddressesInput) (*ec2.D
 				}
 
 			default:
-				return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
+				if strings.HasPrefix(*filter.Name, "tag:") {
+					match = m.hasTag(ec2.ResourceTypeElasticIp, *address.AllocationId, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
+				}
 			}
 
 			if !match {
@@ -179,6 +208,7 @@ func (m *MockEC2) ReleaseAddressWithContext(aws.Context, *ec2.ReleaseAddressInpu
 }
 
 func (m *MockEC2) ReleaseAddress(request *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	timings.RecordAPICall("ec2", "ReleaseAddress")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -62,6 +62,14 @@ type MockEC2 struct {
 
 	NatGateways map[string]*ec2.NatGateway
 
+	PlacementGroups map[string]*ec2.PlacementGroup
+
+	TransitGatewayVpcAttachments []*ec2.TransitGatewayVpcAttachment
+
+	VpcEndpoints map[string]*ec2.VpcEndpoint
+
+	Instances map[string]*instanceInfo
+
 	idsMutex sync.Mutex
 	ids      map[string]*idAllocator
 }
@@ -110,6 +118,15 @@ func (m *MockEC2) All() map[string]interface{} {
 	for id, o := range m.NatGateways {
 		all[id] = o
 	}
+	for id, o := range m.PlacementGroups {
+		all[id] = o
+	}
+	for id, o := range m.VpcEndpoints {
+		all[id] = o
+	}
+	for id, o := range m.Instances {
+		all[id] = &o.main
+	}
 
 	return all
 }
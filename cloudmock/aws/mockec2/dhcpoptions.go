@@ -24,9 +24,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) DescribeDhcpOptions(request *ec2.DescribeDhcpOptionsInput) (*ec2.DescribeDhcpOptionsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeDhcpOptions")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -85,6 +87,7 @@ func (m *MockEC2) DescribeDhcpOptionsRequest(*ec2.DescribeDhcpOptionsInput) (*re
 }
 
 func (m *MockEC2) AssociateDhcpOptions(request *ec2.AssociateDhcpOptionsInput) (*ec2.AssociateDhcpOptionsOutput, error) {
+	timings.RecordAPICall("ec2", "AssociateDhcpOptions")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -119,6 +122,7 @@ func (m *MockEC2) AssociateDhcpOptionsRequest(*ec2.AssociateDhcpOptionsInput) (*
 }
 
 func (m *MockEC2) CreateDhcpOptions(request *ec2.CreateDhcpOptionsInput) (*ec2.CreateDhcpOptionsOutput, error) {
+	timings.RecordAPICall("ec2", "CreateDhcpOptions")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -167,6 +171,7 @@ func (m *MockEC2) CreateDhcpOptionsRequest(*ec2.CreateDhcpOptionsInput) (*reques
 }
 
 func (m *MockEC2) DeleteDhcpOptions(request *ec2.DeleteDhcpOptionsInput) (*ec2.DeleteDhcpOptionsOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteDhcpOptions")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
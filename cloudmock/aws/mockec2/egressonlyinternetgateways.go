@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) FindEgressOnlyInternetGateway(id string) *ec2.EgressOnlyInternetGateway {
@@ -60,6 +61,7 @@ func (m *MockEC2) CreateEgressOnlyInternetGatewayWithContext(aws.Context, *ec2.C
 }
 
 func (m *MockEC2) CreateEgressOnlyInternetGateway(request *ec2.CreateEgressOnlyInternetGatewayInput) (*ec2.CreateEgressOnlyInternetGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "CreateEgressOnlyInternetGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -100,6 +102,7 @@ func (m *MockEC2) DescribeEgressOnlyInternetGatewaysWithContext(aws.Context, *ec
 }
 
 func (m *MockEC2) DescribeEgressOnlyInternetGateways(request *ec2.DescribeEgressOnlyInternetGatewaysInput) (*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeEgressOnlyInternetGateways")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -116,7 +119,7 @@ func (m *MockEC2) DescribeEgressOnlyInternetGateways(request *ec2.DescribeEgress
 		for _, filter := range request.Filters {
 			match := false
 			switch *filter.Name {
-			case "internet-gateway-id":
+			case "egress-only-internet-gateway-id":
 				for _, v := range filter.Values {
 					if id == aws.StringValue(v) {
 						match = true
@@ -165,6 +168,7 @@ func (m *MockEC2) DescribeEgressOnlyInternetGateways(request *ec2.DescribeEgress
 }
 
 func (m *MockEC2) DeleteEgressOnlyInternetGateway(request *ec2.DeleteEgressOnlyInternetGatewayInput) (*ec2.DeleteEgressOnlyInternetGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteEgressOnlyInternetGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -16,13 +16,18 @@ limitations under the License.
 
 package mockec2
 
-import "github.com/aws/aws-sdk-go/service/ec2"
+import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/pkg/timings"
+)
 
 func (m *MockEC2) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeNetworkInterfaces")
 	output := &ec2.DescribeNetworkInterfacesOutput{}
 	return output, nil
 }
 
 func (m *MockEC2) DescribeNetworkInterfacesPages(*ec2.DescribeNetworkInterfacesInput, func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) error {
+	timings.RecordAPICall("ec2", "DescribeNetworkInterfaces")
 	return nil
 }
@@ -18,12 +18,14 @@ package mockec2
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) DescribeImageAttributeRequest(*ec2.DescribeImageAttributeInput) (*request.Request, *ec2.DescribeImageAttributeOutput) {
@@ -47,6 +49,7 @@ func (m *MockEC2) DescribeImagesWithContext(aws.Context, *ec2.DescribeImagesInpu
 }
 
 func (m *MockEC2) DescribeImagesPagesWithContext(ctx aws.Context, request *ec2.DescribeImagesInput, callback func(output *ec2.DescribeImagesOutput, b bool) bool, options ...request.Option) error {
+	timings.RecordAPICall("ec2", "DescribeImages")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -55,6 +58,9 @@ func (m *MockEC2) DescribeImagesPagesWithContext(ctx aws.Context, request *ec2.D
 	var images []*ec2.Image
 
 	for _, image := range m.Images {
+		if len(request.ImageIds) != 0 && !slices.Contains(aws.StringValueSlice(request.ImageIds), aws.StringValue(image.ImageId)) {
+			continue
+		}
 		matches, err := m.imageMatchesFilter(image, request.Filters)
 		if err != nil {
 			return err
@@ -17,15 +17,185 @@ limitations under the License.
 package mockec2
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
-func (m *MockEC2) DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
-	klog.Warningf("MockEc2::DescribeInstances is stub-implemented")
-	return &ec2.DescribeInstancesOutput{}, nil
+type instanceInfo struct {
+	main     ec2.Instance
+	userData *string
+}
+
+func (m *MockEC2) RunInstancesRequest(*ec2.RunInstancesInput) (*request.Request, *ec2.Reservation) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) RunInstancesWithContext(aws.Context, *ec2.RunInstancesInput, ...request.Option) (*ec2.Reservation, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) RunInstances(request *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	timings.RecordAPICall("ec2", "RunInstances")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("RunInstances: %v", request)
+
+	if aws.Int64Value(request.MinCount) != 1 || aws.Int64Value(request.MaxCount) != 1 {
+		return nil, fmt.Errorf("MockEC2 only supports launching a single instance")
+	}
+
+	id := m.allocateId("i")
+	tags := tagSpecificationsToTags(request.TagSpecifications, ec2.ResourceTypeInstance)
+
+	instance := ec2.Instance{
+		InstanceId:      s(id),
+		ImageId:         request.ImageId,
+		InstanceType:    request.InstanceType,
+		KeyName:         request.KeyName,
+		SubnetId:        request.SubnetId,
+		SourceDestCheck: aws.Bool(true),
+		State: &ec2.InstanceState{
+			Name: s(ec2.InstanceStateNameRunning),
+		},
+	}
+	for _, sg := range request.SecurityGroupIds {
+		instance.SecurityGroups = append(instance.SecurityGroups, &ec2.GroupIdentifier{GroupId: sg})
+	}
+	for _, nis := range request.NetworkInterfaces {
+		ni := &ec2.InstanceNetworkInterface{
+			PrivateIpAddress: nis.PrivateIpAddress,
+			SubnetId:         nis.SubnetId,
+		}
+		if aws.BoolValue(nis.AssociatePublicIpAddress) {
+			ni.Association = &ec2.InstanceNetworkInterfaceAssociation{
+				PublicIp: s(fmt.Sprintf("192.0.2.%d", len(m.Instances)+1)),
+			}
+		}
+		for _, sg := range nis.Groups {
+			ni.Groups = append(ni.Groups, &ec2.GroupIdentifier{GroupId: sg})
+			instance.SecurityGroups = append(instance.SecurityGroups, &ec2.GroupIdentifier{GroupId: sg})
+		}
+		instance.NetworkInterfaces = append(instance.NetworkInterfaces, ni)
+		if aws.Int64Value(nis.DeviceIndex) == 0 {
+			instance.SubnetId = nis.SubnetId
+			instance.PrivateIpAddress = nis.PrivateIpAddress
+		}
+	}
+	if subnet := m.subnets[aws.StringValue(instance.SubnetId)]; subnet != nil {
+		instance.VpcId = subnet.main.VpcId
+	}
+	if request.IamInstanceProfile != nil {
+		instance.IamInstanceProfile = &ec2.IamInstanceProfile{
+			Arn: s("arn:aws-test:iam::0000000000:instance-profile/" + aws.StringValue(request.IamInstanceProfile.Name)),
+		}
+	}
+
+	if m.Instances == nil {
+		m.Instances = make(map[string]*instanceInfo)
+	}
+	m.Instances[id] = &instanceInfo{
+		main:     instance,
+		userData: request.UserData,
+	}
+
+	m.addTags(id, tags...)
+
+	copy := instance
+	copy.Tags = tags
+	return &ec2.Reservation{
+		Instances: []*ec2.Instance{&copy},
+	}, nil
+}
+
+func (m *MockEC2) DescribeInstances(request *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeInstances")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeInstances: %v", request)
+
+	var instances []*ec2.Instance
+
+	for id, info := range m.Instances {
+		instance := &info.main
+
+		if len(request.InstanceIds) != 0 {
+			match := false
+			for _, v := range request.InstanceIds {
+				if id == aws.StringValue(v) {
+					match = true
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			match := false
+			switch *filter.Name {
+			case "instance-id":
+				for _, v := range filter.Values {
+					if id == aws.StringValue(v) {
+						match = true
+					}
+				}
+			case "instance-state-name":
+				for _, v := range filter.Values {
+					if aws.StringValue(instance.State.Name) == aws.StringValue(v) {
+						match = true
+					}
+				}
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.StringValue(instance.VpcId) == aws.StringValue(v) {
+						match = true
+					}
+				}
+			case "subnet-id":
+				for _, v := range filter.Values {
+					if aws.StringValue(instance.SubnetId) == aws.StringValue(v) {
+						match = true
+					}
+				}
+			default:
+				if strings.HasPrefix(*filter.Name, "tag:") || *filter.Name == "tag-key" {
+					match = m.hasTag(ec2.ResourceTypeInstance, id, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
+				}
+			}
+
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if !allFiltersMatch {
+			continue
+		}
+
+		copy := *instance
+		copy.Tags = m.getTags(ec2.ResourceTypeInstance, id)
+		instances = append(instances, &copy)
+	}
+
+	response := &ec2.DescribeInstancesOutput{}
+	if len(instances) != 0 {
+		response.Reservations = []*ec2.Reservation{
+			{Instances: instances},
+		}
+	}
+	return response, nil
 }
 
 func (m *MockEC2) DescribeInstancesWithContext(aws.Context, *ec2.DescribeInstancesInput, ...request.Option) (*ec2.DescribeInstancesOutput, error) {
@@ -53,11 +223,13 @@ func (m *MockEC2) DescribeInstancesPagesWithContext(aws.Context, *ec2.DescribeIn
 }
 
 func (m *MockEC2) DescribeInstanceTypes(*ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeInstanceTypes")
 	klog.Warningf("MockEc2::DescribeInstanceTypes is stub-implemented")
 	return &ec2.DescribeInstanceTypesOutput{}, nil
 }
 
 func (m *MockEC2) GetInstanceTypesFromInstanceRequirements(input *ec2.GetInstanceTypesFromInstanceRequirementsInput) (*ec2.GetInstanceTypesFromInstanceRequirementsOutput, error) {
+	timings.RecordAPICall("ec2", "GetInstanceTypesFromInstanceRequirements")
 	return &ec2.GetInstanceTypesFromInstanceRequirementsOutput{
 		InstanceTypes: []*ec2.InstanceTypeInfoFromInstanceRequirements{
 			{
@@ -66,3 +238,96 @@ func (m *MockEC2) GetInstanceTypesFromInstanceRequirements(input *ec2.GetInstanc
 		},
 	}, nil
 }
+
+func (m *MockEC2) DescribeInstanceAttributeRequest(*ec2.DescribeInstanceAttributeInput) (*request.Request, *ec2.DescribeInstanceAttributeOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) DescribeInstanceAttributeWithContext(aws.Context, *ec2.DescribeInstanceAttributeInput, ...request.Option) (*ec2.DescribeInstanceAttributeOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) DescribeInstanceAttribute(request *ec2.DescribeInstanceAttributeInput) (*ec2.DescribeInstanceAttributeOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeInstanceAttribute")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeInstanceAttribute: %v", request)
+
+	id := aws.StringValue(request.InstanceId)
+	info := m.Instances[id]
+	if info == nil {
+		return nil, fmt.Errorf("Instance %q not found", id)
+	}
+
+	response := &ec2.DescribeInstanceAttributeOutput{
+		InstanceId: request.InstanceId,
+	}
+	switch aws.StringValue(request.Attribute) {
+	case ec2.InstanceAttributeNameUserData:
+		if info.userData != nil {
+			response.UserData = &ec2.AttributeValue{Value: info.userData}
+		}
+	case ec2.InstanceAttributeNameSourceDestCheck:
+		response.SourceDestCheck = &ec2.AttributeBooleanValue{Value: info.main.SourceDestCheck}
+	default:
+		return nil, fmt.Errorf("MockEC2 does not support attribute %q", aws.StringValue(request.Attribute))
+	}
+	return response, nil
+}
+
+func (m *MockEC2) ModifyInstanceAttributeRequest(*ec2.ModifyInstanceAttributeInput) (*request.Request, *ec2.ModifyInstanceAttributeOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) ModifyInstanceAttributeWithContext(aws.Context, *ec2.ModifyInstanceAttributeInput, ...request.Option) (*ec2.ModifyInstanceAttributeOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) ModifyInstanceAttribute(request *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error) {
+	timings.RecordAPICall("ec2", "ModifyInstanceAttribute")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ModifyInstanceAttribute: %v", request)
+
+	id := aws.StringValue(request.InstanceId)
+	info := m.Instances[id]
+	if info == nil {
+		return nil, fmt.Errorf("Instance %q not found", id)
+	}
+
+	if request.SourceDestCheck != nil {
+		info.main.SourceDestCheck = request.SourceDestCheck.Value
+	} else {
+		return nil, fmt.Errorf("MockEC2 only supports modifying SourceDestCheck")
+	}
+
+	return &ec2.ModifyInstanceAttributeOutput{}, nil
+}
+
+func (m *MockEC2) TerminateInstancesRequest(*ec2.TerminateInstancesInput) (*request.Request, *ec2.TerminateInstancesOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) TerminateInstancesWithContext(aws.Context, *ec2.TerminateInstancesInput, ...request.Option) (*ec2.TerminateInstancesOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) TerminateInstances(request *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+	timings.RecordAPICall("ec2", "TerminateInstances")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("TerminateInstances: %v", request)
+
+	for _, v := range request.InstanceIds {
+		id := aws.StringValue(v)
+		if m.Instances[id] == nil {
+			return nil, fmt.Errorf("Instance %q not found", id)
+		}
+		delete(m.Instances, id)
+	}
+
+	return &ec2.TerminateInstancesOutput{}, nil
+}
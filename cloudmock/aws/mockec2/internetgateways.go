@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) FindInternetGateway(id string) *ec2.InternetGateway {
@@ -60,6 +61,7 @@ func (m *MockEC2) CreateInternetGatewayWithContext(aws.Context, *ec2.CreateInter
 }
 
 func (m *MockEC2) CreateInternetGateway(request *ec2.CreateInternetGatewayInput) (*ec2.CreateInternetGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "CreateInternetGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -95,6 +97,7 @@ func (m *MockEC2) DescribeInternetGatewaysWithContext(aws.Context, *ec2.Describe
 }
 
 func (m *MockEC2) DescribeInternetGateways(request *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeInternetGateways")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -160,6 +163,7 @@ func (m *MockEC2) DescribeInternetGateways(request *ec2.DescribeInternetGateways
 }
 
 func (m *MockEC2) AttachInternetGateway(request *ec2.AttachInternetGatewayInput) (*ec2.AttachInternetGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "AttachInternetGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -185,6 +189,7 @@ func (m *MockEC2) AttachInternetGatewayRequest(*ec2.AttachInternetGatewayInput)
 }
 
 func (m *MockEC2) DetachInternetGateway(request *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "DetachInternetGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -221,6 +226,7 @@ func (m *MockEC2) DetachInternetGatewayRequest(*ec2.DetachInternetGatewayInput)
 }
 
 func (m *MockEC2) DeleteInternetGateway(request *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteInternetGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 
 	"k8s.io/kops/pkg/pki"
 )
@@ -44,6 +45,7 @@ func (m *MockEC2) ImportKeyPairWithContext(aws.Context, *ec2.ImportKeyPairInput,
 }
 
 func (m *MockEC2) ImportKeyPair(request *ec2.ImportKeyPairInput) (*ec2.ImportKeyPairOutput, error) {
+	timings.RecordAPICall("ec2", "ImportKeyPair")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -89,6 +91,7 @@ func (m *MockEC2) CreateKeyPair(*ec2.CreateKeyPairInput) (*ec2.CreateKeyPairOutp
 }
 
 func (m *MockEC2) DescribeKeyPairs(request *ec2.DescribeKeyPairsInput) (*ec2.DescribeKeyPairsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeKeyPairs")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -148,6 +151,7 @@ func (m *MockEC2) DescribeKeyPairs(request *ec2.DescribeKeyPairsInput) (*ec2.Des
 }
 
 func (m *MockEC2) DeleteKeyPair(request *ec2.DeleteKeyPairInput) (*ec2.DeleteKeyPairOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteKeyPair")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
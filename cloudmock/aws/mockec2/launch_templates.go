@@ -19,18 +19,24 @@ package mockec2
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 type launchTemplateInfo struct {
 	data    *ec2.ResponseLaunchTemplateData
 	name    *string
 	version int
+	// staleVersions are the numbers of the versions before the latest one that have not been deleted.
+	// Only the data of the latest version is kept.
+	staleVersions []int
 }
 
 // DescribeLaunchTemplatesPages mocks the describing the launch templates
@@ -52,6 +58,7 @@ func (m *MockEC2) DescribeLaunchTemplatesPagesWithContext(ctx context.Context, r
 
 // DescribeLaunchTemplates mocks the describing the launch templates
 func (m *MockEC2) DescribeLaunchTemplates(request *ec2.DescribeLaunchTemplatesInput) (*ec2.DescribeLaunchTemplatesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeLaunchTemplates")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -96,8 +103,10 @@ func (m *MockEC2) DescribeLaunchTemplates(request *ec2.DescribeLaunchTemplatesIn
 	return o, nil
 }
 
-// DescribeLaunchTemplateVersions mocks the retrieval of launch template versions - we don't use this at the moment so we can just return the template
+// DescribeLaunchTemplateVersions mocks the retrieval of launch template versions.
+// The latest version is returned with its data; if no versions are requested, the stale versions are returned too, without data.
 func (m *MockEC2) DescribeLaunchTemplateVersions(request *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeLaunchTemplateVersions")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -110,19 +119,46 @@ func (m *MockEC2) DescribeLaunchTemplateVersions(request *ec2.DescribeLaunchTemp
 	}
 
 	for id, ltInfo := range m.LaunchTemplates {
-		if aws.StringValue(ltInfo.name) != aws.StringValue(request.LaunchTemplateName) {
+		if request.LaunchTemplateId != nil {
+			if id != aws.StringValue(request.LaunchTemplateId) {
+				continue
+			}
+		} else if aws.StringValue(ltInfo.name) != aws.StringValue(request.LaunchTemplateName) {
 			continue
 		}
 		o.LaunchTemplateVersions = append(o.LaunchTemplateVersions, &ec2.LaunchTemplateVersion{
 			DefaultVersion:     aws.Bool(true),
 			LaunchTemplateId:   aws.String(id),
 			LaunchTemplateData: ltInfo.data,
-			LaunchTemplateName: request.LaunchTemplateName,
+			LaunchTemplateName: ltInfo.name,
+			VersionNumber:      aws.Int64(int64(ltInfo.version)),
 		})
+		if len(request.Versions) == 0 {
+			for _, version := range ltInfo.staleVersions {
+				o.LaunchTemplateVersions = append(o.LaunchTemplateVersions, &ec2.LaunchTemplateVersion{
+					DefaultVersion:     aws.Bool(false),
+					LaunchTemplateId:   aws.String(id),
+					LaunchTemplateName: ltInfo.name,
+					VersionNumber:      aws.Int64(int64(version)),
+				})
+			}
+		}
 	}
 	return o, nil
 }
 
+// DescribeLaunchTemplateVersionsPages mocks the retrieval of launch template versions
+func (m *MockEC2) DescribeLaunchTemplateVersionsPages(request *ec2.DescribeLaunchTemplateVersionsInput, callback func(*ec2.DescribeLaunchTemplateVersionsOutput, bool) bool) error {
+	page, err := m.DescribeLaunchTemplateVersions(request)
+	if err != nil {
+		return err
+	}
+
+	callback(page, true)
+
+	return nil
+}
+
 // DescribeLaunchTemplateVersionsWithContext mocks the retrieval of launch template versions - we don't use this at the moment so we can just return the template
 func (m *MockEC2) DescribeLaunchTemplateVersionsWithContext(ctx context.Context, request *ec2.DescribeLaunchTemplateVersionsInput, option ...request.Option) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
 	return m.DescribeLaunchTemplateVersions(request)
@@ -130,6 +166,7 @@ func (m *MockEC2) DescribeLaunchTemplateVersionsWithContext(ctx context.Context,
 
 // CreateLaunchTemplate mocks the ec2 create launch template
 func (m *MockEC2) CreateLaunchTemplate(request *ec2.CreateLaunchTemplateInput) (*ec2.CreateLaunchTemplateOutput, error) {
+	timings.RecordAPICall("ec2", "CreateLaunchTemplate")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -160,6 +197,7 @@ func (m *MockEC2) CreateLaunchTemplate(request *ec2.CreateLaunchTemplateInput) (
 }
 
 func (m *MockEC2) CreateLaunchTemplateVersion(request *ec2.CreateLaunchTemplateVersionInput) (*ec2.CreateLaunchTemplateVersionOutput, error) {
+	timings.RecordAPICall("ec2", "CreateLaunchTemplateVersion")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -172,7 +210,16 @@ func (m *MockEC2) CreateLaunchTemplateVersion(request *ec2.CreateLaunchTemplateV
 	for id, ltInfo := range m.LaunchTemplates {
 		if aws.StringValue(ltInfo.name) == aws.StringValue(name) {
 			found = true
-			ltInfo.data = responseLaunchTemplateData(request.LaunchTemplateData)
+			data := responseLaunchTemplateData(request.LaunchTemplateData)
+			if sourceVersion := aws.StringValue(request.SourceVersion); sourceVersion != "" {
+				// We only keep the latest version
+				if sourceVersion != "$Latest" && sourceVersion != strconv.Itoa(ltInfo.version) {
+					return nil, fmt.Errorf("source version %q not found", sourceVersion)
+				}
+				data = mergeLaunchTemplateData(ltInfo.data, data)
+			}
+			ltInfo.data = data
+			ltInfo.staleVersions = append(ltInfo.staleVersions, ltInfo.version)
 			ltInfo.version++
 			ltVersion = ltInfo.version
 			ltID = id
@@ -191,6 +238,7 @@ func (m *MockEC2) CreateLaunchTemplateVersion(request *ec2.CreateLaunchTemplateV
 
 // DeleteLaunchTemplate mocks the deletion of a launch template
 func (m *MockEC2) DeleteLaunchTemplate(request *ec2.DeleteLaunchTemplateInput) (*ec2.DeleteLaunchTemplateOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteLaunchTemplate")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -210,10 +258,68 @@ func (m *MockEC2) DeleteLaunchTemplate(request *ec2.DeleteLaunchTemplateInput) (
 	return o, nil
 }
 
+// DeleteLaunchTemplateVersions mocks the deletion of launch template versions; the latest version cannot be deleted
+func (m *MockEC2) DeleteLaunchTemplateVersions(request *ec2.DeleteLaunchTemplateVersionsInput) (*ec2.DeleteLaunchTemplateVersionsOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteLaunchTemplateVersions")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("Mock DeleteLaunchTemplateVersions: %v", request)
+
+	ltInfo := m.LaunchTemplates[aws.StringValue(request.LaunchTemplateId)]
+	if ltInfo == nil {
+		return nil, fmt.Errorf("launch template %q not found", aws.StringValue(request.LaunchTemplateId))
+	}
+
+	o := &ec2.DeleteLaunchTemplateVersionsOutput{}
+	for _, v := range request.Versions {
+		version, err := strconv.Atoi(aws.StringValue(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid launch template version %q", aws.StringValue(v))
+		}
+		if version == ltInfo.version {
+			o.UnsuccessfullyDeletedLaunchTemplateVersions = append(o.UnsuccessfullyDeletedLaunchTemplateVersions, &ec2.DeleteLaunchTemplateVersionsResponseErrorItem{
+				LaunchTemplateId: request.LaunchTemplateId,
+				VersionNumber:    aws.Int64(int64(version)),
+				ResponseError: &ec2.ResponseError{
+					Code:    aws.String(ec2.LaunchTemplateErrorCodeUnexpectedError),
+					Message: aws.String("cannot delete the default version"),
+				},
+			})
+			continue
+		}
+		for i, stale := range ltInfo.staleVersions {
+			if stale == version {
+				ltInfo.staleVersions = append(ltInfo.staleVersions[:i], ltInfo.staleVersions[i+1:]...)
+				o.SuccessfullyDeletedLaunchTemplateVersions = append(o.SuccessfullyDeletedLaunchTemplateVersions, &ec2.DeleteLaunchTemplateVersionsResponseSuccessItem{
+					LaunchTemplateId: request.LaunchTemplateId,
+					VersionNumber:    aws.Int64(int64(version)),
+				})
+				break
+			}
+		}
+	}
+	return o, nil
+}
+
 func (m *MockEC2) ModifyLaunchTemplate(*ec2.ModifyLaunchTemplateInput) (*ec2.ModifyLaunchTemplateOutput, error) {
+	timings.RecordAPICall("ec2", "ModifyLaunchTemplate")
 	return &ec2.ModifyLaunchTemplateOutput{}, nil
 }
 
+// mergeLaunchTemplateData returns a copy of source where the parameters that are set in overrides are replaced.
+func mergeLaunchTemplateData(source, overrides *ec2.ResponseLaunchTemplateData) *ec2.ResponseLaunchTemplateData {
+	merged := *source
+	src := reflect.ValueOf(overrides).Elem()
+	dst := reflect.ValueOf(&merged).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if src.Type().Field(i).IsExported() && !src.Field(i).IsZero() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+	return &merged
+}
+
 func responseLaunchTemplateData(req *ec2.RequestLaunchTemplateData) *ec2.ResponseLaunchTemplateData {
 	resp := &ec2.ResponseLaunchTemplateData{
 		DisableApiTermination: req.DisableApiTermination,
@@ -265,6 +371,17 @@ func responseLaunchTemplateData(req *ec2.RequestLaunchTemplateData) *ec2.Respons
 			})
 		}
 	}
+	if req.CapacityReservationSpecification != nil {
+		resp.CapacityReservationSpecification = &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+			CapacityReservationPreference: req.CapacityReservationSpecification.CapacityReservationPreference,
+		}
+		if target := req.CapacityReservationSpecification.CapacityReservationTarget; target != nil {
+			resp.CapacityReservationSpecification.CapacityReservationTarget = &ec2.CapacityReservationTargetResponse{
+				CapacityReservationId:               target.CapacityReservationId,
+				CapacityReservationResourceGroupArn: target.CapacityReservationResourceGroupArn,
+			}
+		}
+	}
 	if req.CreditSpecification != nil {
 		resp.CreditSpecification = &ec2.CreditSpecification{CpuCredits: req.CreditSpecification.CpuCredits}
 	}
@@ -286,6 +403,13 @@ func responseLaunchTemplateData(req *ec2.RequestLaunchTemplateData) *ec2.Respons
 			},
 		}
 	}
+	if req.Placement != nil {
+		resp.Placement = &ec2.LaunchTemplatePlacement{
+			GroupName:            req.Placement.GroupName,
+			HostResourceGroupArn: req.Placement.HostResourceGroupArn,
+			Tenancy:              req.Placement.Tenancy,
+		}
+	}
 	if len(req.NetworkInterfaces) > 0 {
 		for _, x := range req.NetworkInterfaces {
 			resp.NetworkInterfaces = append(resp.NetworkInterfaces, &ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
@@ -301,6 +425,17 @@ func responseLaunchTemplateData(req *ec2.RequestLaunchTemplateData) *ec2.Respons
 				SecondaryPrivateIpAddressCount: x.SecondaryPrivateIpAddressCount,
 				SubnetId:                       x.SubnetId,
 			})
+			if x.EnaSrdSpecification != nil {
+				spec := &ec2.LaunchTemplateEnaSrdSpecification{
+					EnaSrdEnabled: x.EnaSrdSpecification.EnaSrdEnabled,
+				}
+				if x.EnaSrdSpecification.EnaSrdUdpSpecification != nil {
+					spec.EnaSrdUdpSpecification = &ec2.LaunchTemplateEnaSrdUdpSpecification{
+						EnaSrdUdpEnabled: x.EnaSrdSpecification.EnaSrdUdpSpecification.EnaSrdUdpEnabled,
+					}
+				}
+				resp.NetworkInterfaces[len(resp.NetworkInterfaces)-1].EnaSrdSpecification = spec
+			}
 		}
 	}
 	if len(req.TagSpecifications) > 0 {
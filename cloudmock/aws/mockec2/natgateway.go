@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) CreateNatGatewayWithId(request *ec2.CreateNatGatewayInput, id string) (*ec2.CreateNatGatewayOutput, error) {
@@ -71,6 +72,7 @@ func (m *MockEC2) CreateNatGatewayWithId(request *ec2.CreateNatGatewayInput, id
 }
 
 func (m *MockEC2) CreateNatGateway(request *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "CreateNatGateway")
 	klog.Infof("CreateNatGateway: %v", request)
 
 	id := m.allocateId("nat")
@@ -111,6 +113,7 @@ func (m *MockEC2) CreateNatGatewayRequest(*ec2.CreateNatGatewayInput) (*request.
 }
 
 func (m *MockEC2) DescribeNatGateways(request *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeNatGateways")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -180,6 +183,7 @@ func (m *MockEC2) DescribeNatGatewaysPagesWithContext(aws.Context, *ec2.Describe
 }
 
 func (m *MockEC2) DeleteNatGateway(request *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteNatGateway")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockec2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
+)
+
+func (m *MockEC2) CreatePlacementGroup(request *ec2.CreatePlacementGroupInput) (*ec2.CreatePlacementGroupOutput, error) {
+	timings.RecordAPICall("ec2", "CreatePlacementGroup")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreatePlacementGroup: %v", request)
+
+	name := aws.StringValue(request.GroupName)
+	for _, pg := range m.PlacementGroups {
+		if aws.StringValue(pg.GroupName) == name {
+			return nil, fmt.Errorf("placement group %q already exists", name)
+		}
+	}
+
+	id := m.allocateId("pg")
+	pg := &ec2.PlacementGroup{
+		GroupId:        aws.String(id),
+		GroupName:      request.GroupName,
+		Strategy:       request.Strategy,
+		PartitionCount: request.PartitionCount,
+		State:          aws.String(ec2.PlacementGroupStateAvailable),
+	}
+	if m.PlacementGroups == nil {
+		m.PlacementGroups = make(map[string]*ec2.PlacementGroup)
+	}
+	m.PlacementGroups[id] = pg
+
+	m.addTags(id, tagSpecificationsToTags(request.TagSpecifications, ec2.ResourceTypePlacementGroup)...)
+
+	copy := *pg
+	copy.Tags = m.getTags(ec2.ResourceTypePlacementGroup, id)
+	return &ec2.CreatePlacementGroupOutput{PlacementGroup: &copy}, nil
+}
+
+func (m *MockEC2) DescribePlacementGroups(request *ec2.DescribePlacementGroupsInput) (*ec2.DescribePlacementGroupsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribePlacementGroups")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribePlacementGroups: %v", request)
+
+	var placementGroups []*ec2.PlacementGroup
+
+	for id, pg := range m.PlacementGroups {
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			match := false
+			switch *filter.Name {
+			case "group-name":
+				for _, v := range filter.Values {
+					if aws.StringValue(pg.GroupName) == aws.StringValue(v) {
+						match = true
+					}
+				}
+			default:
+				if strings.HasPrefix(*filter.Name, "tag:") {
+					match = m.hasTag(ec2.ResourceTypePlacementGroup, id, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
+				}
+			}
+
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if !allFiltersMatch {
+			continue
+		}
+
+		copy := *pg
+		copy.Tags = m.getTags(ec2.ResourceTypePlacementGroup, id)
+		placementGroups = append(placementGroups, &copy)
+	}
+
+	return &ec2.DescribePlacementGroupsOutput{PlacementGroups: placementGroups}, nil
+}
+
+func (m *MockEC2) DeletePlacementGroup(request *ec2.DeletePlacementGroupInput) (*ec2.DeletePlacementGroupOutput, error) {
+	timings.RecordAPICall("ec2", "DeletePlacementGroup")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeletePlacementGroup: %v", request)
+
+	name := aws.StringValue(request.GroupName)
+	for id, pg := range m.PlacementGroups {
+		if aws.StringValue(pg.GroupName) == name {
+			delete(m.PlacementGroups, id)
+			return &ec2.DeletePlacementGroupOutput{}, nil
+		}
+	}
+	return nil, fmt.Errorf("placement group %q not found", name)
+}
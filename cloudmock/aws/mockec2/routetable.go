@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) AddRouteTable(rt *ec2.RouteTable) {
@@ -47,6 +48,7 @@ func (m *MockEC2) DescribeRouteTablesWithContext(aws.Context, *ec2.DescribeRoute
 }
 
 func (m *MockEC2) DescribeRouteTables(request *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeRouteTables")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -72,6 +74,12 @@ func (m *MockEC2) DescribeRouteTables(request *ec2.DescribeRouteTablesInput) (*e
 						match = true
 					}
 				}
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.StringValue(rt.VpcId) == *v {
+						match = true
+					}
+				}
 			case "association.subnet-id":
 				for _, a := range rt.Associations {
 					for _, v := range filter.Values {
@@ -103,6 +111,7 @@ func (m *MockEC2) DescribeRouteTables(request *ec2.DescribeRouteTablesInput) (*e
 }
 
 func (m *MockEC2) CreateRouteTable(request *ec2.CreateRouteTableInput) (*ec2.CreateRouteTableOutput, error) {
+	timings.RecordAPICall("ec2", "CreateRouteTable")
 	klog.Infof("CreateRouteTable: %v", request)
 
 	id := m.allocateId("rtb")
@@ -143,6 +152,7 @@ func (m *MockEC2) CreateRouteTableRequest(*ec2.CreateRouteTableInput) (*request.
 }
 
 func (m *MockEC2) CreateRoute(request *ec2.CreateRouteInput) (*ec2.CreateRouteOutput, error) {
+	timings.RecordAPICall("ec2", "CreateRoute")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -182,6 +192,7 @@ func (m *MockEC2) CreateRouteRequest(*ec2.CreateRouteInput) (*request.Request, *
 }
 
 func (m *MockEC2) DeleteRouteTable(request *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteRouteTable")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) CreateSecurityGroupRequest(*ec2.CreateSecurityGroupInput) (*request.Request, *ec2.CreateSecurityGroupOutput) {
@@ -34,6 +35,7 @@ func (m *MockEC2) CreateSecurityGroupWithContext(aws.Context, *ec2.CreateSecurit
 }
 
 func (m *MockEC2) CreateSecurityGroup(request *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	timings.RecordAPICall("ec2", "CreateSecurityGroup")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -56,6 +58,30 @@ func (m *MockEC2) CreateSecurityGroup(request *ec2.CreateSecurityGroupInput) (*e
 	}
 	m.SecurityGroups[*sg.GroupId] = sg
 
+	if request.VpcId != nil {
+		// AWS adds a rule allowing all egress traffic to security groups in a VPC
+		sg.IpPermissionsEgress = []*ec2.IpPermission{
+			{
+				IpProtocol: s("-1"),
+				IpRanges:   []*ec2.IpRange{{CidrIp: s("0.0.0.0/0")}},
+			},
+		}
+
+		if m.SecurityGroupRules == nil {
+			m.SecurityGroupRules = make(map[string]*ec2.SecurityGroupRule)
+		}
+		ruleID := m.allocateId("sgr")
+		m.SecurityGroupRules[ruleID] = &ec2.SecurityGroupRule{
+			SecurityGroupRuleId: s(ruleID),
+			GroupId:             sg.GroupId,
+			FromPort:            aws.Int64(-1),
+			ToPort:              aws.Int64(-1),
+			IsEgress:            aws.Bool(true),
+			CidrIpv4:            s("0.0.0.0/0"),
+			IpProtocol:          s("-1"),
+		}
+	}
+
 	m.addTags(id, tags...)
 
 	response := &ec2.CreateSecurityGroupOutput{
@@ -73,6 +99,7 @@ func (m *MockEC2) DeleteSecurityGroupWithContext(aws.Context, *ec2.DeleteSecurit
 }
 
 func (m *MockEC2) DeleteSecurityGroup(request *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteSecurityGroup")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -109,6 +136,7 @@ func (m *MockEC2) DescribeSecurityGroupsWithContext(aws.Context, *ec2.DescribeSe
 }
 
 func (m *MockEC2) DescribeSecurityGroups(request *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeSecurityGroups")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -191,8 +219,139 @@ func (m *MockEC2) RevokeSecurityGroupEgressWithContext(aws.Context, *ec2.RevokeS
 	panic("Not implemented")
 }
 
-func (m *MockEC2) RevokeSecurityGroupEgress(*ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
-	panic("Not implemented")
+func (m *MockEC2) RevokeSecurityGroupEgress(request *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	timings.RecordAPICall("ec2", "RevokeSecurityGroupEgress")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("RevokeSecurityGroupEgress: %v", request)
+
+	if aws.StringValue(request.GroupId) == "" {
+		return nil, fmt.Errorf("GroupId not specified")
+	}
+
+	if request.DryRun != nil {
+		klog.Fatalf("DryRun")
+	}
+
+	if request.CidrIp != nil || request.SourceSecurityGroupName != nil || request.SourceSecurityGroupOwnerId != nil {
+		klog.Fatalf("Revoking without IpPermissions or SecurityGroupRuleIds not implemented")
+	}
+
+	sg := m.SecurityGroups[*request.GroupId]
+	if sg == nil {
+		return nil, fmt.Errorf("SecurityGroup not found")
+	}
+
+	var revoke []*ec2.SecurityGroupRule
+	for _, id := range request.SecurityGroupRuleIds {
+		rule := m.SecurityGroupRules[aws.StringValue(id)]
+		if rule == nil || aws.StringValue(rule.GroupId) != *request.GroupId || !aws.BoolValue(rule.IsEgress) {
+			return nil, fmt.Errorf("SecurityGroupRule %q not found", aws.StringValue(id))
+		}
+		revoke = append(revoke, rule)
+	}
+	for _, permission := range request.IpPermissions {
+		for id, rule := range m.SecurityGroupRules {
+			if aws.StringValue(rule.GroupId) != *request.GroupId || !aws.BoolValue(rule.IsEgress) {
+				continue
+			}
+			if permissionContainsRule(permission, rule) {
+				revoke = append(revoke, m.SecurityGroupRules[id])
+			}
+		}
+	}
+
+	for _, rule := range revoke {
+		delete(m.SecurityGroupRules, aws.StringValue(rule.SecurityGroupRuleId))
+		sg.IpPermissionsEgress = removeRuleFromPermissions(sg.IpPermissionsEgress, rule)
+	}
+
+	response := &ec2.RevokeSecurityGroupEgressOutput{
+		Return: aws.Bool(true),
+	}
+	return response, nil
+}
+
+// permissionContainsRule returns true if the rule is one of the rules described by the permission.
+func permissionContainsRule(permission *ec2.IpPermission, rule *ec2.SecurityGroupRule) bool {
+	if aws.StringValue(permission.IpProtocol) != aws.StringValue(rule.IpProtocol) {
+		return false
+	}
+	fromPort, toPort := int64(-1), int64(-1)
+	if permission.FromPort != nil {
+		fromPort = *permission.FromPort
+	}
+	if permission.ToPort != nil {
+		toPort = *permission.ToPort
+	}
+	if fromPort != aws.Int64Value(rule.FromPort) || toPort != aws.Int64Value(rule.ToPort) {
+		return false
+	}
+
+	for _, ipRange := range permission.IpRanges {
+		if rule.CidrIpv4 != nil && aws.StringValue(ipRange.CidrIp) == *rule.CidrIpv4 {
+			return true
+		}
+	}
+	for _, ipRange := range permission.Ipv6Ranges {
+		if rule.CidrIpv6 != nil && aws.StringValue(ipRange.CidrIpv6) == *rule.CidrIpv6 {
+			return true
+		}
+	}
+	for _, prefixList := range permission.PrefixListIds {
+		if rule.PrefixListId != nil && aws.StringValue(prefixList.PrefixListId) == *rule.PrefixListId {
+			return true
+		}
+	}
+	for _, group := range permission.UserIdGroupPairs {
+		if rule.ReferencedGroupInfo != nil && aws.StringValue(group.GroupId) == aws.StringValue(rule.ReferencedGroupInfo.GroupId) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeRuleFromPermissions returns the permissions without the rule, dropping permissions that become empty.
+func removeRuleFromPermissions(permissions []*ec2.IpPermission, rule *ec2.SecurityGroupRule) []*ec2.IpPermission {
+	var remaining []*ec2.IpPermission
+	for _, permission := range permissions {
+		if !permissionContainsRule(permission, rule) {
+			remaining = append(remaining, permission)
+			continue
+		}
+
+		p := *permission
+		p.IpRanges = nil
+		for _, ipRange := range permission.IpRanges {
+			if rule.CidrIpv4 == nil || aws.StringValue(ipRange.CidrIp) != *rule.CidrIpv4 {
+				p.IpRanges = append(p.IpRanges, ipRange)
+			}
+		}
+		p.Ipv6Ranges = nil
+		for _, ipRange := range permission.Ipv6Ranges {
+			if rule.CidrIpv6 == nil || aws.StringValue(ipRange.CidrIpv6) != *rule.CidrIpv6 {
+				p.Ipv6Ranges = append(p.Ipv6Ranges, ipRange)
+			}
+		}
+		p.PrefixListIds = nil
+		for _, prefixList := range permission.PrefixListIds {
+			if rule.PrefixListId == nil || aws.StringValue(prefixList.PrefixListId) != *rule.PrefixListId {
+				p.PrefixListIds = append(p.PrefixListIds, prefixList)
+			}
+		}
+		p.UserIdGroupPairs = nil
+		for _, group := range permission.UserIdGroupPairs {
+			if rule.ReferencedGroupInfo == nil || aws.StringValue(group.GroupId) != aws.StringValue(rule.ReferencedGroupInfo.GroupId) {
+				p.UserIdGroupPairs = append(p.UserIdGroupPairs, group)
+			}
+		}
+
+		if len(p.IpRanges)+len(p.Ipv6Ranges)+len(p.PrefixListIds)+len(p.UserIdGroupPairs) != 0 {
+			remaining = append(remaining, &p)
+		}
+	}
+	return remaining
 }
 
 func (m *MockEC2) RevokeSecurityGroupIngressRequest(*ec2.RevokeSecurityGroupIngressInput) (*request.Request, *ec2.RevokeSecurityGroupIngressOutput) {
@@ -204,6 +363,7 @@ func (m *MockEC2) RevokeSecurityGroupIngressWithContext(aws.Context, *ec2.Revoke
 }
 
 func (m *MockEC2) RevokeSecurityGroupIngress(request *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	timings.RecordAPICall("ec2", "RevokeSecurityGroupIngress")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -240,6 +400,7 @@ func (m *MockEC2) AuthorizeSecurityGroupEgressWithContext(aws.Context, *ec2.Auth
 }
 
 func (m *MockEC2) AuthorizeSecurityGroupEgress(request *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	timings.RecordAPICall("ec2", "AuthorizeSecurityGroupEgress")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -291,8 +452,7 @@ func (m *MockEC2) AuthorizeSecurityGroupEgress(request *ec2.AuthorizeSecurityGro
 
 		for _, iprange := range permission.IpRanges {
 
-			n := len(m.SecurityGroupRules) + 1
-			id := fmt.Sprintf("sgr-%d", n)
+			id := m.allocateId("sgr")
 			rule := &ec2.SecurityGroupRule{
 				SecurityGroupRuleId: &id,
 				GroupId:             sg.GroupId,
@@ -300,6 +460,7 @@ func (m *MockEC2) AuthorizeSecurityGroupEgress(request *ec2.AuthorizeSecurityGro
 				ToPort:              permission.ToPort,
 				IsEgress:            aws.Bool(true),
 				CidrIpv4:            iprange.CidrIp,
+				Description:         iprange.Description,
 				IpProtocol:          permission.IpProtocol,
 				Tags:                tagSpecificationsToTags(request.TagSpecifications, ec2.ResourceTypeSecurityGroupRule),
 			}
@@ -315,8 +476,7 @@ func (m *MockEC2) AuthorizeSecurityGroupEgress(request *ec2.AuthorizeSecurityGro
 
 		for _, iprange := range permission.Ipv6Ranges {
 
-			n := len(m.SecurityGroupRules) + 1
-			id := fmt.Sprintf("sgr-%d", n)
+			id := m.allocateId("sgr")
 			rule := &ec2.SecurityGroupRule{
 				SecurityGroupRuleId: &id,
 				GroupId:             sg.GroupId,
@@ -324,6 +484,31 @@ func (m *MockEC2) AuthorizeSecurityGroupEgress(request *ec2.AuthorizeSecurityGro
 				ToPort:              permission.ToPort,
 				IsEgress:            aws.Bool(true),
 				CidrIpv6:            iprange.CidrIpv6,
+				Description:         iprange.Description,
+				IpProtocol:          permission.IpProtocol,
+				Tags:                tagSpecificationsToTags(request.TagSpecifications, ec2.ResourceTypeSecurityGroupRule),
+			}
+			if permission.FromPort == nil {
+				rule.FromPort = aws.Int64(int64(-1))
+			}
+			if permission.ToPort == nil {
+				rule.ToPort = aws.Int64(int64(-1))
+			}
+
+			m.SecurityGroupRules[id] = rule
+		}
+
+		for _, prefixListId := range permission.PrefixListIds {
+
+			id := m.allocateId("sgr")
+			rule := &ec2.SecurityGroupRule{
+				SecurityGroupRuleId: &id,
+				GroupId:             sg.GroupId,
+				FromPort:            permission.FromPort,
+				ToPort:              permission.ToPort,
+				IsEgress:            aws.Bool(true),
+				PrefixListId:        prefixListId.PrefixListId,
+				Description:         prefixListId.Description,
 				IpProtocol:          permission.IpProtocol,
 				Tags:                tagSpecificationsToTags(request.TagSpecifications, ec2.ResourceTypeSecurityGroupRule),
 			}
@@ -351,6 +536,7 @@ func (m *MockEC2) AuthorizeSecurityGroupIngressWithContext(aws.Context, *ec2.Aut
 }
 
 func (m *MockEC2) AuthorizeSecurityGroupIngress(request *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	timings.RecordAPICall("ec2", "AuthorizeSecurityGroupIngress")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -402,8 +588,7 @@ func (m *MockEC2) AuthorizeSecurityGroupIngress(request *ec2.AuthorizeSecurityGr
 	}
 
 	newSecurityGroupRule := func(permission *ec2.IpPermission) (string, *ec2.SecurityGroupRule) {
-		n := len(m.SecurityGroupRules) + 1
-		id := fmt.Sprintf("sgr-%d", n)
+		id := m.allocateId("sgr")
 		rule := &ec2.SecurityGroupRule{
 			SecurityGroupRuleId: &id,
 			GroupId:             sg.GroupId,
@@ -428,18 +613,21 @@ func (m *MockEC2) AuthorizeSecurityGroupIngress(request *ec2.AuthorizeSecurityGr
 		for _, iprange := range permission.IpRanges {
 			id, rule := newSecurityGroupRule(permission)
 			rule.CidrIpv4 = iprange.CidrIp
+			rule.Description = iprange.Description
 			m.SecurityGroupRules[id] = rule
 		}
 
 		for _, iprange := range permission.Ipv6Ranges {
 			id, rule := newSecurityGroupRule(permission)
 			rule.CidrIpv6 = iprange.CidrIpv6
+			rule.Description = iprange.Description
 			m.SecurityGroupRules[id] = rule
 		}
 
 		for _, prefixListId := range permission.PrefixListIds {
 			id, rule := newSecurityGroupRule(permission)
 			rule.PrefixListId = prefixListId.PrefixListId
+			rule.Description = prefixListId.Description
 			m.SecurityGroupRules[id] = rule
 
 		}
@@ -449,6 +637,7 @@ func (m *MockEC2) AuthorizeSecurityGroupIngress(request *ec2.AuthorizeSecurityGr
 			rule.ReferencedGroupInfo = &ec2.ReferencedSecurityGroup{
 				GroupId: group.GroupId,
 			}
+			rule.Description = group.Description
 			m.SecurityGroupRules[id] = rule
 		}
 	}
@@ -458,6 +647,7 @@ func (m *MockEC2) AuthorizeSecurityGroupIngress(request *ec2.AuthorizeSecurityGr
 }
 
 func (m *MockEC2) DescribeSecurityGroupRules(request *ec2.DescribeSecurityGroupRulesInput) (*ec2.DescribeSecurityGroupRulesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeSecurityGroupRules")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -480,3 +670,49 @@ func (m *MockEC2) DescribeSecurityGroupRules(request *ec2.DescribeSecurityGroupR
 		SecurityGroupRules: rules,
 	}, nil
 }
+
+func (m *MockEC2) UpdateSecurityGroupRuleDescriptionsEgress(request *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
+	timings.RecordAPICall("ec2", "UpdateSecurityGroupRuleDescriptionsEgress")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("UpdateSecurityGroupRuleDescriptionsEgress: %v", request)
+
+	if err := m.updateSecurityGroupRuleDescriptions(aws.StringValue(request.GroupId), true, request.SecurityGroupRuleDescriptions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput{Return: aws.Bool(true)}, nil
+}
+
+func (m *MockEC2) UpdateSecurityGroupRuleDescriptionsIngress(request *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	timings.RecordAPICall("ec2", "UpdateSecurityGroupRuleDescriptionsIngress")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("UpdateSecurityGroupRuleDescriptionsIngress: %v", request)
+
+	if err := m.updateSecurityGroupRuleDescriptions(aws.StringValue(request.GroupId), false, request.SecurityGroupRuleDescriptions); err != nil {
+		return nil, err
+	}
+
+	return &ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput{Return: aws.Bool(true)}, nil
+}
+
+func (m *MockEC2) updateSecurityGroupRuleDescriptions(groupID string, egress bool, descriptions []*ec2.SecurityGroupRuleDescription) error {
+	if groupID == "" {
+		return fmt.Errorf("GroupId not specified")
+	}
+	if m.SecurityGroups[groupID] == nil {
+		return fmt.Errorf("SecurityGroup not found")
+	}
+
+	for _, d := range descriptions {
+		rule := m.SecurityGroupRules[aws.StringValue(d.SecurityGroupRuleId)]
+		if rule == nil || aws.StringValue(rule.GroupId) != groupID || aws.BoolValue(rule.IsEgress) != egress {
+			return fmt.Errorf("SecurityGroupRule %q not found", aws.StringValue(d.SecurityGroupRuleId))
+		}
+		rule.Description = d.Description
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 type subnetInfo struct {
@@ -107,6 +108,7 @@ func (m *MockEC2) CreateSubnetWithId(request *ec2.CreateSubnetInput, id string)
 }
 
 func (m *MockEC2) CreateSubnet(request *ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error) {
+	timings.RecordAPICall("ec2", "CreateSubnet")
 	klog.Infof("CreateSubnet: %v", request)
 
 	id := m.allocateId("subnet")
@@ -122,6 +124,7 @@ func (m *MockEC2) DescribeSubnetsWithContext(aws.Context, *ec2.DescribeSubnetsIn
 }
 
 func (m *MockEC2) DescribeSubnets(request *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeSubnets")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -179,6 +182,7 @@ func (m *MockEC2) DescribeSubnets(request *ec2.DescribeSubnetsInput) (*ec2.Descr
 }
 
 func (m *MockEC2) AssociateRouteTable(request *ec2.AssociateRouteTableInput) (*ec2.AssociateRouteTableOutput, error) {
+	timings.RecordAPICall("ec2", "AssociateRouteTable")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -232,6 +236,7 @@ func (m *MockEC2) AssociateRouteTableRequest(*ec2.AssociateRouteTableInput) (*re
 }
 
 func (m *MockEC2) DeleteSubnet(request *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteSubnet")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -256,6 +261,7 @@ func (m *MockEC2) DeleteSubnetRequest(*ec2.DeleteSubnetInput) (*request.Request,
 }
 
 func (m *MockEC2) ModifySubnetAttribute(request *ec2.ModifySubnetAttributeInput) (*ec2.ModifySubnetAttributeOutput, error) {
+	timings.RecordAPICall("ec2", "ModifySubnetAttribute")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -272,5 +278,8 @@ func (m *MockEC2) ModifySubnetAttribute(request *ec2.ModifySubnetAttributeInput)
 	if request.PrivateDnsHostnameTypeOnLaunch != nil {
 		subnet.main.PrivateDnsNameOptionsOnLaunch.HostnameType = request.PrivateDnsHostnameTypeOnLaunch
 	}
+	if request.MapPublicIpOnLaunch != nil {
+		subnet.main.MapPublicIpOnLaunch = request.MapPublicIpOnLaunch.Value
+	}
 	return &ec2.ModifySubnetAttributeOutput{}, nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) CreateTagsRequest(*ec2.CreateTagsInput) (*request.Request, *ec2.CreateTagsOutput) {
@@ -36,6 +37,7 @@ func (m *MockEC2) CreateTagsWithContext(aws.Context, *ec2.CreateTagsInput, ...re
 }
 
 func (m *MockEC2) CreateTags(request *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	timings.RecordAPICall("ec2", "CreateTags")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -75,6 +77,12 @@ func (m *MockEC2) addTags(resourceId string, tags ...*ec2.Tag) {
 		resourceType = ec2.ResourceTypeLaunchTemplate
 	} else if strings.HasPrefix(resourceId, "key-") {
 		resourceType = ec2.ResourceTypeKeyPair
+	} else if strings.HasPrefix(resourceId, "pg-") {
+		resourceType = ec2.ResourceTypePlacementGroup
+	} else if strings.HasPrefix(resourceId, "vpce-") {
+		resourceType = ec2.ResourceTypeVpcEndpoint
+	} else if strings.HasPrefix(resourceId, "i-") {
+		resourceType = ec2.ResourceTypeInstance
 	} else {
 		klog.Fatalf("Unknown resource-type in create tags: %v", resourceId)
 	}
@@ -159,6 +167,7 @@ func (m *MockEC2) getTags(resourceType string, resourceId string) []*ec2.Tag {
 }
 
 func (m *MockEC2) DescribeTags(request *ec2.DescribeTagsInput) (*ec2.DescribeTagsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeTags")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
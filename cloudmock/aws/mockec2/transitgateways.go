@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
+)
+
+func (m *MockEC2) DescribeTransitGatewayVpcAttachments(request *ec2.DescribeTransitGatewayVpcAttachmentsInput) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeTransitGatewayVpcAttachments")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeTransitGatewayVpcAttachments: %v", request)
+
+	var attachments []*ec2.TransitGatewayVpcAttachment
+	for _, attachment := range m.TransitGatewayVpcAttachments {
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			var value string
+			switch aws.StringValue(filter.Name) {
+			case "transit-gateway-id":
+				value = aws.StringValue(attachment.TransitGatewayId)
+			case "vpc-id":
+				value = aws.StringValue(attachment.VpcId)
+			case "state":
+				value = aws.StringValue(attachment.State)
+			default:
+				return nil, fmt.Errorf("unknown filter name: %q", aws.StringValue(filter.Name))
+			}
+
+			match := false
+			for _, v := range filter.Values {
+				if aws.StringValue(v) == value {
+					match = true
+				}
+			}
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if allFiltersMatch {
+			copy := *attachment
+			attachments = append(attachments, &copy)
+		}
+	}
+
+	return &ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+		TransitGatewayVpcAttachments: attachments,
+	}, nil
+}
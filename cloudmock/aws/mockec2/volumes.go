@@ -24,9 +24,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 func (m *MockEC2) CreateVolume(request *ec2.CreateVolumeInput) (*ec2.Volume, error) {
+	timings.RecordAPICall("ec2", "CreateVolume")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -122,6 +124,7 @@ func (m *MockEC2) DescribeVolumesWithContext(aws.Context, *ec2.DescribeVolumesIn
 }
 
 func (m *MockEC2) DescribeVolumes(request *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeVolumes")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -197,6 +200,7 @@ func (m *MockEC2) DescribeVolumesModificationsRequest(*ec2.DescribeVolumesModifi
 }
 
 func (m *MockEC2) DeleteVolume(request *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteVolume")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
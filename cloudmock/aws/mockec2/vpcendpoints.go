@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockec2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
+)
+
+func (m *MockEC2) CreateVpcEndpointRequest(*ec2.CreateVpcEndpointInput) (*request.Request, *ec2.CreateVpcEndpointOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) CreateVpcEndpointWithContext(aws.Context, *ec2.CreateVpcEndpointInput, ...request.Option) (*ec2.CreateVpcEndpointOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) CreateVpcEndpoint(request *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+	timings.RecordAPICall("ec2", "CreateVpcEndpoint")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateVpcEndpoint: %v", request)
+
+	if m.Vpcs[aws.StringValue(request.VpcId)] == nil {
+		return nil, fmt.Errorf("VPC %q not found", aws.StringValue(request.VpcId))
+	}
+
+	id := m.allocateId("vpce")
+	tags := tagSpecificationsToTags(request.TagSpecifications, ec2.ResourceTypeVpcEndpoint)
+
+	endpointType := aws.StringValue(request.VpcEndpointType)
+	if endpointType == "" {
+		endpointType = ec2.VpcEndpointTypeGateway
+	}
+
+	vpce := &ec2.VpcEndpoint{
+		VpcEndpointId:   s(id),
+		VpcId:           request.VpcId,
+		ServiceName:     request.ServiceName,
+		VpcEndpointType: s(endpointType),
+		RouteTableIds:   request.RouteTableIds,
+		SubnetIds:       request.SubnetIds,
+		State:           s("available"),
+	}
+	for _, sg := range request.SecurityGroupIds {
+		vpce.Groups = append(vpce.Groups, &ec2.SecurityGroupIdentifier{GroupId: sg})
+	}
+	if endpointType == ec2.VpcEndpointTypeInterface {
+		privateDNSEnabled := true
+		if request.PrivateDnsEnabled != nil {
+			privateDNSEnabled = aws.BoolValue(request.PrivateDnsEnabled)
+		}
+		vpce.PrivateDnsEnabled = aws.Bool(privateDNSEnabled)
+	} else {
+		vpce.PrivateDnsEnabled = aws.Bool(false)
+	}
+
+	if m.VpcEndpoints == nil {
+		m.VpcEndpoints = make(map[string]*ec2.VpcEndpoint)
+	}
+	m.VpcEndpoints[id] = vpce
+
+	m.addTags(id, tags...)
+
+	copy := *vpce
+	copy.Tags = tags
+	response := &ec2.CreateVpcEndpointOutput{
+		VpcEndpoint: &copy,
+	}
+	return response, nil
+}
+
+func (m *MockEC2) DescribeVpcEndpointsRequest(*ec2.DescribeVpcEndpointsInput) (*request.Request, *ec2.DescribeVpcEndpointsOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) DescribeVpcEndpointsWithContext(aws.Context, *ec2.DescribeVpcEndpointsInput, ...request.Option) (*ec2.DescribeVpcEndpointsOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) DescribeVpcEndpoints(request *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeVpcEndpoints")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeVpcEndpoints: %v", request)
+
+	var vpcEndpoints []*ec2.VpcEndpoint
+
+	if len(request.VpcEndpointIds) != 0 {
+		request.Filters = append(request.Filters, &ec2.Filter{Name: s("vpc-endpoint-id"), Values: request.VpcEndpointIds})
+	}
+
+	for id, vpce := range m.VpcEndpoints {
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			match := false
+			switch *filter.Name {
+			case "vpc-endpoint-id":
+				for _, v := range filter.Values {
+					if id == aws.StringValue(v) {
+						match = true
+					}
+				}
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.StringValue(vpce.VpcId) == aws.StringValue(v) {
+						match = true
+					}
+				}
+			case "service-name":
+				for _, v := range filter.Values {
+					if aws.StringValue(vpce.ServiceName) == aws.StringValue(v) {
+						match = true
+					}
+				}
+			case "vpc-endpoint-type":
+				for _, v := range filter.Values {
+					if strings.EqualFold(aws.StringValue(vpce.VpcEndpointType), aws.StringValue(v)) {
+						match = true
+					}
+				}
+			default:
+				if strings.HasPrefix(*filter.Name, "tag:") {
+					match = m.hasTag(ec2.ResourceTypeVpcEndpoint, id, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
+				}
+			}
+
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if !allFiltersMatch {
+			continue
+		}
+
+		copy := *vpce
+		copy.Tags = m.getTags(ec2.ResourceTypeVpcEndpoint, id)
+		vpcEndpoints = append(vpcEndpoints, &copy)
+	}
+
+	response := &ec2.DescribeVpcEndpointsOutput{
+		VpcEndpoints: vpcEndpoints,
+	}
+
+	return response, nil
+}
+
+func (m *MockEC2) ModifyVpcEndpointRequest(*ec2.ModifyVpcEndpointInput) (*request.Request, *ec2.ModifyVpcEndpointOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) ModifyVpcEndpointWithContext(aws.Context, *ec2.ModifyVpcEndpointInput, ...request.Option) (*ec2.ModifyVpcEndpointOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) ModifyVpcEndpoint(request *ec2.ModifyVpcEndpointInput) (*ec2.ModifyVpcEndpointOutput, error) {
+	timings.RecordAPICall("ec2", "ModifyVpcEndpoint")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ModifyVpcEndpoint: %v", request)
+
+	id := aws.StringValue(request.VpcEndpointId)
+	vpce := m.VpcEndpoints[id]
+	if vpce == nil {
+		return nil, fmt.Errorf("VpcEndpoint %q not found", id)
+	}
+
+	vpce.RouteTableIds = modifyIDs(vpce.RouteTableIds, request.AddRouteTableIds, request.RemoveRouteTableIds)
+	vpce.SubnetIds = modifyIDs(vpce.SubnetIds, request.AddSubnetIds, request.RemoveSubnetIds)
+
+	var groupIDs []*string
+	for _, g := range vpce.Groups {
+		groupIDs = append(groupIDs, g.GroupId)
+	}
+	vpce.Groups = nil
+	for _, g := range modifyIDs(groupIDs, request.AddSecurityGroupIds, request.RemoveSecurityGroupIds) {
+		vpce.Groups = append(vpce.Groups, &ec2.SecurityGroupIdentifier{GroupId: g})
+	}
+
+	if request.PrivateDnsEnabled != nil {
+		vpce.PrivateDnsEnabled = request.PrivateDnsEnabled
+	}
+
+	return &ec2.ModifyVpcEndpointOutput{Return: aws.Bool(true)}, nil
+}
+
+// modifyIDs returns ids with the ids in add appended and the ids in remove removed.
+func modifyIDs(ids []*string, add []*string, remove []*string) []*string {
+	removed := make(map[string]bool)
+	for _, id := range remove {
+		removed[aws.StringValue(id)] = true
+	}
+
+	var result []*string
+	for _, id := range append(ids, add...) {
+		if !removed[aws.StringValue(id)] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (m *MockEC2) DeleteVpcEndpointsRequest(*ec2.DeleteVpcEndpointsInput) (*request.Request, *ec2.DeleteVpcEndpointsOutput) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) DeleteVpcEndpointsWithContext(aws.Context, *ec2.DeleteVpcEndpointsInput, ...request.Option) (*ec2.DeleteVpcEndpointsOutput, error) {
+	panic("Not implemented")
+}
+
+func (m *MockEC2) DeleteVpcEndpoints(request *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteVpcEndpoints")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteVpcEndpoints: %v", request)
+
+	for _, v := range request.VpcEndpointIds {
+		id := aws.StringValue(v)
+		if m.VpcEndpoints[id] == nil {
+			return nil, fmt.Errorf("VpcEndpoint %q not found", id)
+		}
+		delete(m.VpcEndpoints, id)
+	}
+
+	return &ec2.DeleteVpcEndpointsOutput{}, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 type vpcInfo struct {
@@ -86,6 +87,7 @@ func (m *MockEC2) CreateVpcWithId(request *ec2.CreateVpcInput, id string) (*ec2.
 }
 
 func (m *MockEC2) CreateVpc(request *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
+	timings.RecordAPICall("ec2", "CreateVpc")
 	klog.Infof("CreateVpc: %v", request)
 
 	if request.DryRun != nil {
@@ -106,6 +108,7 @@ func (m *MockEC2) DescribeVpcsWithContext(aws.Context, *ec2.DescribeVpcsInput, .
 }
 
 func (m *MockEC2) DescribeVpcs(request *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeVpcs")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -163,6 +166,7 @@ func (m *MockEC2) DescribeVpcAttributeWithContext(aws.Context, *ec2.DescribeVpcA
 }
 
 func (m *MockEC2) DescribeVpcAttribute(request *ec2.DescribeVpcAttributeInput) (*ec2.DescribeVpcAttributeOutput, error) {
+	timings.RecordAPICall("ec2", "DescribeVpcAttribute")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -184,6 +188,7 @@ func (m *MockEC2) DescribeVpcAttribute(request *ec2.DescribeVpcAttributeInput) (
 }
 
 func (m *MockEC2) ModifyVpcAttribute(request *ec2.ModifyVpcAttributeInput) (*ec2.ModifyVpcAttributeOutput, error) {
+	timings.RecordAPICall("ec2", "ModifyVpcAttribute")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -216,6 +221,7 @@ func (m *MockEC2) ModifyVpcAttributeRequest(*ec2.ModifyVpcAttributeInput) (*requ
 }
 
 func (m *MockEC2) DeleteVpc(request *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
+	timings.RecordAPICall("ec2", "DeleteVpc")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -240,6 +246,7 @@ func (m *MockEC2) DeleteVpcRequest(*ec2.DeleteVpcInput) (*request.Request, *ec2.
 }
 
 func (m *MockEC2) AssociateVpcCidrBlock(request *ec2.AssociateVpcCidrBlockInput) (*ec2.AssociateVpcCidrBlockOutput, error) {
+	timings.RecordAPICall("ec2", "AssociateVpcCidrBlock")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -281,6 +288,7 @@ func (m *MockEC2) AssociateVpcCidrBlock(request *ec2.AssociateVpcCidrBlockInput)
 }
 
 func (m *MockEC2) DisassociateVpcCidrBlock(request *ec2.DisassociateVpcCidrBlockInput) (*ec2.DisassociateVpcCidrBlockOutput, error) {
+	timings.RecordAPICall("ec2", "DisassociateVpcCidrBlock")
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
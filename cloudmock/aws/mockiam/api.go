@@ -35,6 +35,9 @@ type MockIAM struct {
 	OIDCProviders    map[string]*iam.GetOpenIDConnectProviderOutput
 	RolePolicies     []*rolePolicy
 	AttachedPolicies map[string][]*iam.AttachedPolicy
+
+	// ManagedPolicyDocuments holds the documents of managed policies, keyed by ARN
+	ManagedPolicyDocuments map[string]string
 }
 
 var _ iamiface.IAMAPI = &MockIAM{}
@@ -57,12 +57,15 @@ func (m *MockIAM) CreateRole(request *iam.CreateRoleInput) (*iam.CreateRoleOutpu
 		AssumeRolePolicyDocument: request.AssumeRolePolicyDocument,
 		Description:              request.Description,
 		Path:                     request.Path,
-		PermissionsBoundary: &iam.AttachedPermissionsBoundary{
-			PermissionsBoundaryArn: request.PermissionsBoundary,
-		},
-		RoleName: request.RoleName,
-		RoleId:   &roleID,
-		Tags:     request.Tags,
+		RoleName:                 request.RoleName,
+		RoleId:                   &roleID,
+		Tags:                     request.Tags,
+	}
+	if request.PermissionsBoundary != nil {
+		r.PermissionsBoundary = &iam.AttachedPermissionsBoundary{
+			PermissionsBoundaryArn:  request.PermissionsBoundary,
+			PermissionsBoundaryType: aws.String(iam.PermissionsBoundaryAttachmentTypePermissionsBoundaryPolicy),
+		}
 	}
 
 	if m.Roles == nil {
@@ -154,6 +157,41 @@ func (m *MockIAM) DeleteRoleRequest(*iam.DeleteRoleInput) (*request.Request, *ia
 	panic("Not implemented")
 }
 
+func (m *MockIAM) PutRolePermissionsBoundary(request *iam.PutRolePermissionsBoundaryInput) (*iam.PutRolePermissionsBoundaryOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("PutRolePermissionsBoundary: %v", request)
+
+	id := aws.StringValue(request.RoleName)
+	r := m.Roles[id]
+	if r == nil {
+		return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "No such entity", nil)
+	}
+	r.PermissionsBoundary = &iam.AttachedPermissionsBoundary{
+		PermissionsBoundaryArn:  request.PermissionsBoundary,
+		PermissionsBoundaryType: aws.String(iam.PermissionsBoundaryAttachmentTypePermissionsBoundaryPolicy),
+	}
+
+	return &iam.PutRolePermissionsBoundaryOutput{}, nil
+}
+
+func (m *MockIAM) DeleteRolePermissionsBoundary(request *iam.DeleteRolePermissionsBoundaryInput) (*iam.DeleteRolePermissionsBoundaryOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteRolePermissionsBoundary: %v", request)
+
+	id := aws.StringValue(request.RoleName)
+	r := m.Roles[id]
+	if r == nil {
+		return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "No such entity", nil)
+	}
+	r.PermissionsBoundary = nil
+
+	return &iam.DeleteRolePermissionsBoundaryOutput{}, nil
+}
+
 func (m *MockIAM) ListAttachedRolePolicies(input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockiam
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/util/stringorslice"
+)
+
+type simulatedPolicy struct {
+	Statement []simulatedStatement
+}
+
+type simulatedStatement struct {
+	Effect   string
+	Action   stringorslice.StringOrSlice
+	Resource stringorslice.StringOrSlice
+}
+
+// SimulatePrincipalPolicy evaluates the inline and attached policies of a role.
+// Only roles are supported as principals, and conditions are ignored.
+func (m *MockIAM) SimulatePrincipalPolicy(request *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("SimulatePrincipalPolicy: %v", request)
+
+	var role *iam.Role
+	for _, r := range m.Roles {
+		if aws.StringValue(r.Arn) == aws.StringValue(request.PolicySourceArn) {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "No such entity", nil)
+	}
+	roleName := aws.StringValue(role.RoleName)
+
+	var documents []string
+	for _, rp := range m.RolePolicies {
+		if rp.RoleName == roleName {
+			documents = append(documents, rp.PolicyDocument)
+		}
+	}
+	for _, attached := range m.AttachedPolicies[roleName] {
+		document, ok := m.ManagedPolicyDocuments[aws.StringValue(attached.PolicyArn)]
+		if !ok {
+			return nil, fmt.Errorf("managed policy %q not found", aws.StringValue(attached.PolicyArn))
+		}
+		documents = append(documents, document)
+	}
+
+	var statements []simulatedStatement
+	for _, document := range documents {
+		policy := &simulatedPolicy{}
+		if err := json.Unmarshal([]byte(document), policy); err != nil {
+			return nil, fmt.Errorf("error parsing policy document: %v", err)
+		}
+		statements = append(statements, policy.Statement...)
+	}
+
+	resources := aws.StringValueSlice(request.ResourceArns)
+	if len(resources) == 0 {
+		resources = []string{"*"}
+	}
+
+	response := &iam.SimulatePolicyResponse{}
+	for _, action := range aws.StringValueSlice(request.ActionNames) {
+		for _, resource := range resources {
+			decision := iam.PolicyEvaluationDecisionTypeImplicitDeny
+			for _, statement := range statements {
+				if !matchesAny(statement.Action.Value(), action) || !matchesAny(statement.Resource.Value(), resource) {
+					continue
+				}
+				if statement.Effect == "Deny" {
+					decision = iam.PolicyEvaluationDecisionTypeExplicitDeny
+					break
+				}
+				decision = iam.PolicyEvaluationDecisionTypeAllowed
+			}
+			response.EvaluationResults = append(response.EvaluationResults, &iam.EvaluationResult{
+				EvalActionName:   aws.String(action),
+				EvalResourceName: aws.String(resource),
+				EvalDecision:     aws.String(decision),
+			})
+		}
+	}
+
+	return response, nil
+}
+
+func (m *MockIAM) SimulatePrincipalPolicyWithContext(aws.Context, *iam.SimulatePrincipalPolicyInput, ...request.Option) (*iam.SimulatePolicyResponse, error) {
+	panic("Not implemented")
+}
+
+func (m *MockIAM) SimulatePrincipalPolicyRequest(*iam.SimulatePrincipalPolicyInput) (*request.Request, *iam.SimulatePolicyResponse) {
+	panic("Not implemented")
+}
+
+func (m *MockIAM) SimulatePrincipalPolicyPages(request *iam.SimulatePrincipalPolicyInput, callback func(*iam.SimulatePolicyResponse, bool) bool) error {
+	// For the mock, we just send everything in one page
+	page, err := m.SimulatePrincipalPolicy(request)
+	if err != nil {
+		return err
+	}
+
+	callback(page, false)
+
+	return nil
+}
+
+func (m *MockIAM) SimulatePrincipalPolicyPagesWithContext(aws.Context, *iam.SimulatePrincipalPolicyInput, func(*iam.SimulatePolicyResponse, bool) bool, ...request.Option) error {
+	panic("Not implemented")
+}
+
+// matchesAny returns true if value matches any of the IAM wildcard patterns.
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		expr := "^" + strings.ReplaceAll(strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*"), `\?`, ".") + "$"
+		if regexp.MustCompile("(?i)" + expr).MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockresourcegroupstaggingapi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+)
+
+// defaultResourcesPerPage is the page size used by GetResources when none is requested
+const defaultResourcesPerPage = 100
+
+type MockResourceGroupsTaggingAPI struct {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	mutex sync.Mutex
+
+	// TagsByArn holds the tags of every resource known to the tagging API, keyed by ARN
+	TagsByArn map[string]map[string]string
+}
+
+var _ resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI = &MockResourceGroupsTaggingAPI{}
+
+func (m *MockResourceGroupsTaggingAPI) TagResources(request *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.TagsByArn == nil {
+		m.TagsByArn = make(map[string]map[string]string)
+	}
+	for _, resourceARN := range aws.StringValueSlice(request.ResourceARNList) {
+		if _, err := arn.Parse(resourceARN); err != nil {
+			return nil, fmt.Errorf("invalid ARN %q: %w", resourceARN, err)
+		}
+		tags := m.TagsByArn[resourceARN]
+		if tags == nil {
+			tags = make(map[string]string)
+			m.TagsByArn[resourceARN] = tags
+		}
+		for k, v := range request.Tags {
+			tags[k] = aws.StringValue(v)
+		}
+	}
+
+	return &resourcegroupstaggingapi.TagResourcesOutput{}, nil
+}
+
+func (m *MockResourceGroupsTaggingAPI) GetResources(request *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var arns []string
+	for resourceARN, tags := range m.TagsByArn {
+		if !matchesTagFilters(tags, request.TagFilters) {
+			continue
+		}
+		if len(request.ResourceTypeFilters) != 0 && !matchesResourceTypeFilters(resourceARN, request.ResourceTypeFilters) {
+			continue
+		}
+		arns = append(arns, resourceARN)
+	}
+	sort.Strings(arns)
+
+	start := 0
+	if token := aws.StringValue(request.PaginationToken); token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 || n > len(arns) {
+			return nil, fmt.Errorf("invalid pagination token %q", token)
+		}
+		start = n
+	}
+	pageSize := defaultResourcesPerPage
+	if request.ResourcesPerPage != nil {
+		pageSize = int(aws.Int64Value(request.ResourcesPerPage))
+	}
+	end := start + pageSize
+	if end > len(arns) {
+		end = len(arns)
+	}
+
+	response := &resourcegroupstaggingapi.GetResourcesOutput{}
+	for _, resourceARN := range arns[start:end] {
+		mapping := &resourcegroupstaggingapi.ResourceTagMapping{
+			ResourceARN: aws.String(resourceARN),
+		}
+		for k, v := range m.TagsByArn[resourceARN] {
+			mapping.Tags = append(mapping.Tags, &resourcegroupstaggingapi.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(v),
+			})
+		}
+		response.ResourceTagMappingList = append(response.ResourceTagMappingList, mapping)
+	}
+	if end < len(arns) {
+		response.PaginationToken = aws.String(strconv.Itoa(end))
+	} else {
+		// The real API returns an empty token on the last page
+		response.PaginationToken = aws.String("")
+	}
+	return response, nil
+}
+
+func (m *MockResourceGroupsTaggingAPI) GetResourcesPages(request *resourcegroupstaggingapi.GetResourcesInput, callback func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+	input := *request
+	for {
+		page, err := m.GetResources(&input)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(page.PaginationToken) == ""
+		if !callback(page, lastPage) || lastPage {
+			return nil
+		}
+		input.PaginationToken = page.PaginationToken
+	}
+}
+
+// matchesTagFilters returns true if the tags match all of the filters; a filter without values only requires the key
+func matchesTagFilters(tags map[string]string, filters []*resourcegroupstaggingapi.TagFilter) bool {
+	for _, filter := range filters {
+		v, found := tags[aws.StringValue(filter.Key)]
+		if !found {
+			return false
+		}
+		if len(filter.Values) == 0 {
+			continue
+		}
+		matched := false
+		for _, value := range filter.Values {
+			if aws.StringValue(value) == v {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesResourceTypeFilters returns true if the ARN matches one of the "service[:resourceType]" filters
+func matchesResourceTypeFilters(resourceARN string, filters []*string) bool {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		return false
+	}
+	for _, filter := range aws.StringValueSlice(filters) {
+		service, resourceType, hasType := strings.Cut(filter, ":")
+		if service != parsed.Service {
+			continue
+		}
+		if !hasType || strings.HasPrefix(parsed.Resource, resourceType+"/") || strings.HasPrefix(parsed.Resource, resourceType+":") {
+			return true
+		}
+	}
+	return false
+}
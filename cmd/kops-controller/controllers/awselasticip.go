@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/nodelabels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// NewAWSElasticIPReconciler is the constructor for an AWSElasticIPReconciler
+func NewAWSElasticIPReconciler(mgr manager.Manager, allocationIDs map[string]string) (*AWSElasticIPReconciler, error) {
+	klog.Info("Starting aws elastic ip controller")
+
+	config := aws.NewConfig()
+	config = config.WithCredentialsChainVerboseErrors(true)
+
+	s, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("error starting new AWS session: %v", err)
+	}
+	s.Handlers.Send.PushFront(func(r *request.Request) {
+		// Log requests
+		klog.V(4).Infof("AWS API Request: %s/%s", r.ClientInfo.ServiceName, r.Operation.Name)
+	})
+
+	metadata := ec2metadata.New(s, config)
+
+	region, err := metadata.Region()
+	if err != nil {
+		return nil, fmt.Errorf("error querying ec2 metadata service (for region): %v", err)
+	}
+
+	return newAWSElasticIPReconciler(mgr.GetClient(), ec2.New(s, config.WithRegion(region)), allocationIDs), nil
+}
+
+func newAWSElasticIPReconciler(client client.Client, ec2Client ec2iface.EC2API, allocationIDs map[string]string) *AWSElasticIPReconciler {
+	return &AWSElasticIPReconciler{
+		client:        client,
+		log:           ctrl.Log.WithName("controllers").WithName("ElasticIP"),
+		ec2Client:     ec2Client,
+		allocationIDs: allocationIDs,
+	}
+}
+
+// AWSElasticIPReconciler observes control-plane Node objects, and associates the Elastic IP of their zone
+// with the instance backing the node, so that the API endpoint keeps a stable public IP when the
+// control-plane instances are replaced.
+type AWSElasticIPReconciler struct {
+	// client is the controller-runtime client
+	client client.Client
+
+	// log is a logr
+	log logr.Logger
+
+	ec2Client ec2iface.EC2API
+
+	// allocationIDs maps a zone to the allocation ID of the Elastic IP for that zone
+	allocationIDs map[string]string
+}
+
+// +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=,resources=nodes/status,verbs=patch
+// Reconcile is the main reconciler function that observes node changes.
+func (r *AWSElasticIPReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.log.WithValues("elasticip-controller", req.NamespacedName)
+
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, req.NamespacedName, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			// we'll ignore not-found errors, since they can't be fixed by an immediate
+			// requeue (we'll need to wait for a new notification), and we can get them
+			// on deleted requests.
+			return ctrl.Result{}, nil
+		}
+		klog.Warningf("unable to fetch node %s: %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if _, found := node.Labels[nodelabels.RoleLabelControlPlane20]; !found {
+		return ctrl.Result{}, nil
+	}
+
+	if node.Spec.ProviderID == "" {
+		klog.Infof("Node %q has empty provider ID", node.Name)
+		return ctrl.Result{}, nil
+	}
+
+	zone, instanceID, err := parseAWSProviderID(node.Spec.ProviderID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	allocationID := r.allocationIDs[zone]
+	if allocationID == "" {
+		return ctrl.Result{}, nil
+	}
+
+	response, err := r.ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		AllocationIds: []*string{aws.String(allocationID)},
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error describing elastic ip %q: %w", allocationID, err)
+	}
+	if len(response.Addresses) != 1 {
+		return ctrl.Result{}, fmt.Errorf("unexpected number of elastic ips for allocation id %q: %v", allocationID, len(response.Addresses))
+	}
+	address := response.Addresses[0]
+
+	associatedInstanceID := aws.StringValue(address.InstanceId)
+	if associatedInstanceID != instanceID {
+		if associatedInstanceID != "" {
+			inUse, err := r.isReadyControlPlaneInstance(ctx, associatedInstanceID)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if inUse {
+				// The instance being replaced still serves the API; try again once it is gone.
+				klog.Infof("elastic ip %q is associated with ready control-plane instance %q, not moving it to node %q yet", allocationID, associatedInstanceID, node.Name)
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+		}
+
+		klog.Infof("associating elastic ip %q with instance %q of node %q", allocationID, instanceID, node.Name)
+		if _, err := r.ec2Client.AssociateAddress(&ec2.AssociateAddressInput{
+			AllocationId:       aws.String(allocationID),
+			InstanceId:         aws.String(instanceID),
+			AllowReassociation: aws.Bool(true),
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error associating elastic ip %q with instance %q: %w", allocationID, instanceID, err)
+		}
+	}
+
+	// The cloud controller manager refreshes the node addresses eventually; we set the external IP
+	// right away, so that dns-controller points the API record at the elastic ip.
+	if err := r.patchNodeExternalIP(ctx, node, aws.StringValue(address.PublicIp)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// isReadyControlPlaneInstance returns true if a Ready control-plane node is backed by the instance.
+func (r *AWSElasticIPReconciler) isReadyControlPlaneInstance(ctx context.Context, instanceID string) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodes, client.HasLabels{nodelabels.RoleLabelControlPlane20}); err != nil {
+		return false, fmt.Errorf("error listing control-plane nodes: %w", err)
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.ProviderID == "" {
+			continue
+		}
+		_, nodeInstanceID, err := parseAWSProviderID(node.Spec.ProviderID)
+		if err != nil {
+			klog.Warningf("ignoring node %q: %v", node.Name, err)
+			continue
+		}
+		if nodeInstanceID != instanceID {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// patchNodeExternalIP sets the ExternalIP address of the node, if it is not already set to the specified value.
+func (r *AWSElasticIPReconciler) patchNodeExternalIP(ctx context.Context, node *corev1.Node, ip string) error {
+	if ip == "" {
+		return nil
+	}
+
+	var addresses []corev1.NodeAddress
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeExternalIP {
+			if address.Address == ip {
+				return nil
+			}
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+	addresses = append(addresses, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: ip})
+
+	klog.Infof("setting external ip of node %q to %q", node.Name, ip)
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Status.Addresses = addresses
+	if err := r.client.Status().Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("error patching addresses of node %q: %w", node.Name, err)
+	}
+
+	return nil
+}
+
+// parseAWSProviderID returns the zone and the instance ID from a provider ID like aws:///eu-central-1a/i-07577a7bcf3e576f2
+func parseAWSProviderID(providerID string) (string, string, error) {
+	providerURL, err := url.Parse(providerID)
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing provider ID %q: %w", providerID, err)
+	}
+	tokens := strings.Split(providerURL.Path, "/")
+	if providerURL.Scheme != "aws" || len(tokens) != 3 {
+		return "", "", fmt.Errorf("unexpected format of provider ID %q", providerID)
+	}
+	return tokens[1], tokens[2], nil
+}
+
+func (r *AWSElasticIPReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("elasticip").
+		For(&corev1.Node{}).
+		Complete(r)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/nodelabels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func buildControlPlaneNode(name string, providerID string, ready bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{nodelabels.RoleLabelControlPlane20: ""},
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: providerID,
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "172.20.1.10"},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status},
+			},
+		},
+	}
+}
+
+func buildElasticIPTest(t *testing.T, nodes ...client.Object) (*AWSElasticIPReconciler, *mockec2.MockEC2, string) {
+	cloud := &mockec2.MockEC2{}
+	response, err := cloud.AllocateAddress(&ec2.AllocateAddressInput{})
+	if err != nil {
+		t.Fatalf("error allocating address: %v", err)
+	}
+	allocationID := aws.StringValue(response.AllocationId)
+
+	kubeClient := fake.NewClientBuilder().
+		WithObjects(nodes...).
+		WithStatusSubresource(&corev1.Node{}).
+		Build()
+
+	r := newAWSElasticIPReconciler(kubeClient, cloud, map[string]string{"us-test-1a": allocationID})
+	return r, cloud, allocationID
+}
+
+func reconcileNode(t *testing.T, r *AWSElasticIPReconciler, name string) ctrl.Result {
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+	if err != nil {
+		t.Fatalf("unexpected error reconciling node %q: %v", name, err)
+	}
+	return result
+}
+
+func expectExternalIP(t *testing.T, r *AWSElasticIPReconciler, name string, expected string) {
+	node := &corev1.Node{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: name}, node); err != nil {
+		t.Fatalf("error getting node %q: %v", name, err)
+	}
+	var externalIPs []string
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeExternalIP {
+			externalIPs = append(externalIPs, address.Address)
+		}
+	}
+	if len(externalIPs) != 1 || externalIPs[0] != expected {
+		t.Errorf("unexpected external IPs for node %q: expected %q, got %v", name, expected, externalIPs)
+	}
+}
+
+func TestAWSElasticIPReconcilerAssociate(t *testing.T) {
+	r, cloud, allocationID := buildElasticIPTest(t,
+		buildControlPlaneNode("control-plane-1", "aws:///us-test-1a/i-00000000000000001", true),
+		buildControlPlaneNode("control-plane-2", "aws:///us-test-1b/i-00000000000000002", true),
+	)
+
+	reconcileNode(t, r, "control-plane-1")
+
+	address := cloud.Addresses[allocationID]
+	if aws.StringValue(address.InstanceId) != "i-00000000000000001" {
+		t.Fatalf("expected elastic ip to be associated with i-00000000000000001, got %q", aws.StringValue(address.InstanceId))
+	}
+	expectExternalIP(t, r, "control-plane-1", aws.StringValue(address.PublicIp))
+
+	// Nodes in zones without an elastic ip are left alone
+	reconcileNode(t, r, "control-plane-2")
+	if aws.StringValue(address.InstanceId) != "i-00000000000000001" {
+		t.Errorf("elastic ip unexpectedly moved to %q", aws.StringValue(address.InstanceId))
+	}
+	expectExternalIP(t, r, "control-plane-2", "203.0.113.10")
+}
+
+func TestAWSElasticIPReconcilerIgnoresWorkers(t *testing.T) {
+	worker := buildControlPlaneNode("node-1", "aws:///us-test-1a/i-00000000000000003", true)
+	worker.Labels = map[string]string{nodelabels.RoleLabelNode16: ""}
+	r, cloud, allocationID := buildElasticIPTest(t, worker)
+
+	reconcileNode(t, r, "node-1")
+
+	if cloud.Addresses[allocationID].InstanceId != nil {
+		t.Errorf("elastic ip unexpectedly associated with %q", aws.StringValue(cloud.Addresses[allocationID].InstanceId))
+	}
+	expectExternalIP(t, r, "node-1", "203.0.113.10")
+}
+
+func TestAWSElasticIPReconcilerInstanceReplacement(t *testing.T) {
+	oldNode := buildControlPlaneNode("control-plane-old", "aws:///us-test-1a/i-00000000000000001", true)
+	newNode := buildControlPlaneNode("control-plane-new", "aws:///us-test-1a/i-00000000000000004", true)
+	r, cloud, allocationID := buildElasticIPTest(t, oldNode, newNode)
+	ctx := context.TODO()
+
+	reconcileNode(t, r, "control-plane-old")
+	address := cloud.Addresses[allocationID]
+	if aws.StringValue(address.InstanceId) != "i-00000000000000001" {
+		t.Fatalf("expected elastic ip to be associated with i-00000000000000001, got %q", aws.StringValue(address.InstanceId))
+	}
+
+	// The old instance is still serving, so the elastic ip stays where it is
+	result := reconcileNode(t, r, "control-plane-new")
+	if result.RequeueAfter == 0 {
+		t.Errorf("expected a requeue while the old control-plane node is ready")
+	}
+	if aws.StringValue(address.InstanceId) != "i-00000000000000001" {
+		t.Fatalf("elastic ip unexpectedly moved to %q", aws.StringValue(address.InstanceId))
+	}
+	expectExternalIP(t, r, "control-plane-new", "203.0.113.10")
+
+	// Once the old instance is gone, the elastic ip moves to the new one
+	if err := r.client.Delete(ctx, oldNode); err != nil {
+		t.Fatalf("error deleting node: %v", err)
+	}
+	result = reconcileNode(t, r, "control-plane-new")
+	if result.RequeueAfter != 0 {
+		t.Errorf("unexpected requeue after the old control-plane node was deleted")
+	}
+	if aws.StringValue(address.InstanceId) != "i-00000000000000004" {
+		t.Fatalf("expected elastic ip to be associated with i-00000000000000004, got %q", aws.StringValue(address.InstanceId))
+	}
+	expectExternalIP(t, r, "control-plane-new", aws.StringValue(address.PublicIp))
+
+	// Reconciling again is a no-op
+	associationID := aws.StringValue(address.AssociationId)
+	reconcileNode(t, r, "control-plane-new")
+	if aws.StringValue(address.AssociationId) != associationID {
+		t.Errorf("elastic ip was unexpectedly re-associated")
+	}
+}
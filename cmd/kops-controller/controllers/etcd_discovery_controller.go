@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// NewEtcdDiscoveryReconciler is the constructor for an EtcdDiscoveryReconciler
+func NewEtcdDiscoveryReconciler(mgr manager.Manager, configMapID types.NamespacedName, members map[string]string) (*EtcdDiscoveryReconciler, error) {
+	dynamicClient, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("error building dynamic client: %v", err)
+	}
+
+	apply := func(ctx context.Context, data *managedConfigMap) error {
+		return applyConfigMap(ctx, dynamicClient, configMapID, "kops-controller.kops.k8s.io/etcd-discovery", data)
+	}
+
+	return newEtcdDiscoveryReconciler(mgr.GetClient(), members, apply), nil
+}
+
+func newEtcdDiscoveryReconciler(client client.Client, members map[string]string, apply func(ctx context.Context, data *managedConfigMap) error) *EtcdDiscoveryReconciler {
+	return &EtcdDiscoveryReconciler{
+		client:  client,
+		log:     ctrl.Log.WithName("controllers").WithName("EtcdDiscovery"),
+		members: members,
+		apply:   apply,
+	}
+}
+
+// EtcdDiscoveryReconciler observes the nodes of the instance groups that run etcd members, and publishes
+// their addresses in an /etc/hosts style ConfigMap, which etcd-manager reads when it uses static discovery.
+type EtcdDiscoveryReconciler struct {
+	// client is the controller-runtime client
+	client client.Client
+
+	// log is a logr
+	log logr.Logger
+
+	// members maps the DNS name of each etcd member to the instance group that runs it
+	members map[string]string
+
+	// apply applies the managed fields of the ConfigMap
+	apply func(ctx context.Context, data *managedConfigMap) error
+
+	// lastUpdate holds the last value we updated, to reduce spurious updates.
+	lastUpdate *managedConfigMap
+}
+
+// +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch
+
+// +kubebuilder:rbac:groups=,resources=configmaps,namespace=kube-system,resourceNames=etcd-manager-discovery,verbs=get;patch
+
+// Reconcile is the main reconciler function that observes node changes.
+func (r *EtcdDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.log.WithValues("node", req.NamespacedName)
+
+	nodeList := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodeList, client.HasLabels([]string{kops.NodeLabelInstanceGroup})); err != nil {
+		klog.Warningf("unable to list nodes: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.updateMembers(ctx, nodeList)
+}
+
+func (r *EtcdDiscoveryReconciler) updateMembers(ctx context.Context, nodeList *corev1.NodeList) error {
+	instanceGroupToAddrs := make(map[string][]string)
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if node.DeletionTimestamp != nil {
+			continue
+		}
+
+		instanceGroup := node.Labels[kops.NodeLabelInstanceGroup]
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP && address.Address != "" {
+				instanceGroupToAddrs[instanceGroup] = append(instanceGroupToAddrs[instanceGroup], address.Address)
+			}
+		}
+	}
+
+	addrToHosts := make(map[string][]string)
+	for hostname, instanceGroup := range r.members {
+		for _, addr := range instanceGroupToAddrs[instanceGroup] {
+			addrToHosts[addr] = append(addrToHosts[addr], hostname)
+		}
+	}
+
+	data := buildHostsConfigMap(addrToHosts)
+
+	if r.lastUpdate != nil && reflect.DeepEqual(r.lastUpdate, data) {
+		klog.V(8).Infof("skipping etcd discovery configmap update (unchanged): %#v", data)
+		return nil
+	}
+
+	klog.V(4).Infof("patching etcd discovery configmap: %#v", data)
+
+	if err := r.apply(ctx, data); err != nil {
+		return err
+	}
+
+	r.lastUpdate = data
+
+	return nil
+}
+
+func (r *EtcdDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		Complete(r)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kops/pkg/apis/kops"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func buildInstanceGroupNode(name string, instanceGroup string, internalIP string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kops.NodeLabelInstanceGroup: instanceGroup},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: internalIP},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+			},
+		},
+	}
+}
+
+func TestEtcdDiscoveryReconciler(t *testing.T) {
+	nodes := []client.Object{
+		buildInstanceGroupNode("etcd-a", "etcd-us-test-1a", "172.20.1.10"),
+		buildInstanceGroupNode("etcd-b", "etcd-us-test-1b", "172.20.2.10"),
+		buildInstanceGroupNode("node-a", "nodes", "172.20.1.20"),
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(nodes...).Build()
+
+	members := map[string]string{
+		"etcd-a.internal.example.com":        "etcd-us-test-1a",
+		"etcd-events-a.internal.example.com": "etcd-us-test-1a",
+		"etcd-b.internal.example.com":        "etcd-us-test-1b",
+		"etcd-c.internal.example.com":        "etcd-us-test-1c",
+	}
+
+	var applied []*managedConfigMap
+	apply := func(ctx context.Context, data *managedConfigMap) error {
+		applied = append(applied, data)
+		return nil
+	}
+
+	r := newEtcdDiscoveryReconciler(kubeClient, members, apply)
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "etcd-a"}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(applied) != 1 {
+		t.Fatalf("expected the configmap to be applied once, got %d", len(applied))
+	}
+
+	expected := "172.20.1.10\tetcd-a.internal.example.com etcd-events-a.internal.example.com\n172.20.2.10\tetcd-b.internal.example.com"
+	if hosts := applied[0].Data["hosts"]; hosts != expected {
+		t.Errorf("unexpected hosts, expected:\n%s\ngot:\n%s", expected, hosts)
+	}
+}
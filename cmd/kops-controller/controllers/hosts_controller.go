@@ -144,6 +144,26 @@ type managedConfigMap struct {
 }
 
 func (r *HostsReconciler) updateConfigMap(ctx context.Context, addrToHosts map[string][]string) error {
+	data := buildHostsConfigMap(addrToHosts)
+
+	if r.lastUpdate != nil && reflect.DeepEqual(r.lastUpdate, data) {
+		klog.V(8).Infof("skipping hosts configmap update (unchanged): %#v", data)
+		return nil
+	}
+
+	klog.V(4).Infof("patching hosts configmap: %#v", data)
+
+	if err := applyConfigMap(ctx, r.dynamicClient, r.configMapID, "kops-controller.kops.k8s.io/hosts", data); err != nil {
+		return err
+	}
+
+	r.lastUpdate = data
+
+	return nil
+}
+
+// buildHostsConfigMap builds a ConfigMap with an /etc/hosts style "hosts" key, in a consistent order.
+func buildHostsConfigMap(addrToHosts map[string][]string) *managedConfigMap {
 	var block []string
 	for addr, hosts := range addrToHosts {
 		sort.Strings(hosts)
@@ -158,14 +178,11 @@ func (r *HostsReconciler) updateConfigMap(ctx context.Context, addrToHosts map[s
 	data.APIVersion = "v1"
 	data.Kind = "ConfigMap"
 	data.Data = map[string]string{"hosts": hosts}
+	return data
+}
 
-	if r.lastUpdate != nil && reflect.DeepEqual(r.lastUpdate, data) {
-		klog.V(8).Infof("skipping hosts configmap update (unchanged): %#v", data)
-		return nil
-	}
-
-	klog.V(4).Infof("patching hosts configmap: %#v", data)
-
+// applyConfigMap applies the managed fields of the ConfigMap using server-side apply.
+func applyConfigMap(ctx context.Context, dynamicClient dynamic.Interface, configMapID types.NamespacedName, fieldManager string, data *managedConfigMap) error {
 	configmapGVR := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
 
 	patch, err := json.Marshal(data)
@@ -176,15 +193,13 @@ func (r *HostsReconciler) updateConfigMap(ctx context.Context, addrToHosts map[s
 	// It is strongly recommended for controllers to always "force" conflicts, since they might not be able to resolve or act on these conflicts.
 	force := true
 	patchOpts := metav1.PatchOptions{
-		FieldManager: "kops-controller.kops.k8s.io/hosts",
+		FieldManager: fieldManager,
 		Force:        &force,
 	}
-	if _, err := r.dynamicClient.Resource(configmapGVR).Namespace(r.configMapID.Namespace).Patch(ctx, r.configMapID.Name, types.ApplyPatchType, patch, patchOpts); err != nil {
+	if _, err := dynamicClient.Resource(configmapGVR).Namespace(configMapID.Namespace).Patch(ctx, configMapID.Name, types.ApplyPatchType, patch, patchOpts); err != nil {
 		return fmt.Errorf("failed to patch configmap: %w", err)
 	}
 
-	r.lastUpdate = data
-
 	return nil
 }
 
@@ -54,6 +54,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/yaml"
 	// +kubebuilder:scaffold:imports
@@ -111,14 +112,7 @@ func main() {
 	kubeConfig.Burst = 200
 	kubeConfig.QPS = 100
 
-	mgr, err := ctrl.NewManager(kubeConfig, ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress: metricsAddress,
-		},
-		LeaderElection:   true,
-		LeaderElectionID: "kops-controller-leader",
-	})
+	mgr, err := ctrl.NewManager(kubeConfig, managerOptions(scheme, metricsAddress, &opt))
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -135,6 +129,10 @@ func main() {
 				setupLog.Error(err, "unable to create verifier")
 				os.Exit(1)
 			}
+			if err := awsup.RegisterAWSVerifierMetrics(metrics.Registry); err != nil {
+				setupLog.Error(err, "unable to register verifier metrics")
+				os.Exit(1)
+			}
 			verifiers = append(verifiers, verifier)
 		}
 		if opt.Server.Provider.GCE != nil {
@@ -226,11 +224,21 @@ func main() {
 		}
 	}
 
+	if err := addElasticIPController(mgr, &opt); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticIPController")
+		os.Exit(1)
+	}
+
 	if err := addNodeController(mgr, vfsContext, &opt); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeController")
 		os.Exit(1)
 	}
 
+	if err := addEtcdDiscoveryController(mgr, &opt); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdDiscoveryController")
+		os.Exit(1)
+	}
+
 	if err := addGossipController(mgr, &opt); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GossipController")
 		os.Exit(1)
@@ -245,6 +253,31 @@ func main() {
 	}
 }
 
+// managerOptions builds the options for the controller manager.
+// Leader election gates the reconcilers; the bootstrap server opts out of it so that it serves on every replica.
+func managerOptions(scheme *runtime.Scheme, metricsAddress string, opt *config.Options) ctrl.Options {
+	options := ctrl.Options{
+		Scheme: scheme,
+		Metrics: metricsserver.Options{
+			BindAddress: metricsAddress,
+		},
+		LeaderElection:   true,
+		LeaderElectionID: "kops-controller-leader",
+	}
+	if opt.LeaderElection != nil {
+		if opt.LeaderElection.LeaseDuration != nil {
+			options.LeaseDuration = &opt.LeaderElection.LeaseDuration.Duration
+		}
+		if opt.LeaderElection.RenewDeadline != nil {
+			options.RenewDeadline = &opt.LeaderElection.RenewDeadline.Duration
+		}
+		if opt.LeaderElection.RetryPeriod != nil {
+			options.RetryPeriod = &opt.LeaderElection.RetryPeriod.Duration
+		}
+	}
+	return options
+}
+
 func buildScheme() (*runtime.Scheme, error) {
 	scheme := runtime.NewScheme()
 	if err := corev1.AddToScheme(scheme); err != nil {
@@ -364,6 +397,49 @@ func addGossipController(mgr manager.Manager, opt *config.Options) error {
 	return nil
 }
 
+func addEtcdDiscoveryController(mgr manager.Manager, opt *config.Options) error {
+	if opt.EtcdDiscovery == nil || len(opt.EtcdDiscovery.Members) == 0 {
+		return nil
+	}
+
+	configMapID := types.NamespacedName{
+		Namespace: "kube-system",
+		Name:      opt.EtcdDiscovery.ConfigMapName,
+	}
+
+	controller, err := controllers.NewEtcdDiscoveryReconciler(mgr, configMapID, opt.EtcdDiscovery.Members)
+	if err != nil {
+		return err
+	}
+
+	if err := controller.SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func addElasticIPController(mgr manager.Manager, opt *config.Options) error {
+	if len(opt.ControlPlaneElasticIPs) == 0 {
+		return nil
+	}
+
+	if opt.Cloud != "aws" {
+		return fmt.Errorf("elastic ips for control-plane nodes are not supported on cloud %q", opt.Cloud)
+	}
+
+	controller, err := controllers.NewAWSElasticIPReconciler(mgr, opt.ControlPlaneElasticIPs)
+	if err != nil {
+		return err
+	}
+
+	if err := controller.SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Reconciler is the interface for a standard Reconciler.
 type Reconciler interface {
 	SetupWithManager(mgr manager.Manager) error
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/server"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestManagerOptions(t *testing.T) {
+	scheme, err := buildScheme()
+	if err != nil {
+		t.Fatalf("error building scheme: %v", err)
+	}
+
+	{
+		options := managerOptions(scheme, "0", &config.Options{})
+		if !options.LeaderElection {
+			t.Errorf("expected leader election to be enabled")
+		}
+		if options.LeaderElectionID != "kops-controller-leader" {
+			t.Errorf("unexpected leader election ID %q", options.LeaderElectionID)
+		}
+		if options.LeaseDuration != nil || options.RenewDeadline != nil || options.RetryPeriod != nil {
+			t.Errorf("expected default leader election durations, got %v/%v/%v", options.LeaseDuration, options.RenewDeadline, options.RetryPeriod)
+		}
+	}
+
+	{
+		opt := &config.Options{
+			LeaderElection: &config.LeaderElectionOptions{
+				LeaseDuration: &metav1.Duration{Duration: 60 * time.Second},
+				RenewDeadline: &metav1.Duration{Duration: 40 * time.Second},
+				RetryPeriod:   &metav1.Duration{Duration: 5 * time.Second},
+			},
+		}
+		options := managerOptions(scheme, "0", opt)
+		if options.LeaseDuration == nil || *options.LeaseDuration != 60*time.Second {
+			t.Errorf("unexpected lease duration %v", options.LeaseDuration)
+		}
+		if options.RenewDeadline == nil || *options.RenewDeadline != 40*time.Second {
+			t.Errorf("unexpected renew deadline %v", options.RenewDeadline)
+		}
+		if options.RetryPeriod == nil || *options.RetryPeriod != 5*time.Second {
+			t.Errorf("unexpected retry period %v", options.RetryPeriod)
+		}
+	}
+}
+
+func TestLeaderElection(t *testing.T) {
+	t.Run("leader", func(t *testing.T) {
+		lock := &fakeLock{identity: "replica-a"}
+		bootstrap, reconciler := runManager(t, lock)
+
+		waitForStart(t, bootstrap, "bootstrap server")
+		waitForStart(t, reconciler, "reconciler")
+	})
+
+	t.Run("follower", func(t *testing.T) {
+		lock := &fakeLock{
+			identity: "replica-b",
+			record: &resourcelock.LeaderElectionRecord{
+				HolderIdentity:       "replica-a",
+				LeaseDurationSeconds: 3600,
+				AcquireTime:          metav1.Now(),
+				RenewTime:            metav1.Now(),
+			},
+		}
+		bootstrap, reconciler := runManager(t, lock)
+
+		waitForStart(t, bootstrap, "bootstrap server")
+		select {
+		case <-reconciler.started:
+			t.Fatalf("reconciler started without holding the lease")
+		case <-time.After(time.Second):
+		}
+	})
+}
+
+// runManager starts a manager, configured as kops-controller configures it,
+// with a runnable standing in for the bootstrap server and one standing in for a reconciler.
+func runManager(t *testing.T, lock resourcelock.Interface) (bootstrap, reconciler *testRunnable) {
+	scheme, err := buildScheme()
+	if err != nil {
+		t.Fatalf("error building scheme: %v", err)
+	}
+
+	opt := &config.Options{
+		LeaderElection: &config.LeaderElectionOptions{
+			LeaseDuration: &metav1.Duration{Duration: 2 * time.Second},
+			RenewDeadline: &metav1.Duration{Duration: time.Second},
+			RetryPeriod:   &metav1.Duration{Duration: 100 * time.Millisecond},
+		},
+	}
+	options := managerOptions(scheme, "0", opt)
+	options.LeaderElectionResourceLockInterface = lock
+
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:1"}, options)
+	if err != nil {
+		t.Fatalf("error building manager: %v", err)
+	}
+
+	// Controllers built with the controller-runtime builder need leader election unless they opt out.
+	bootstrap = newTestRunnable((&server.Server{}).NeedLeaderElection())
+	reconciler = newTestRunnable(true)
+	if err := mgr.Add(bootstrap); err != nil {
+		t.Fatalf("error adding bootstrap runnable: %v", err)
+	}
+	if err := mgr.Add(reconciler); err != nil {
+		t.Fatalf("error adding reconciler runnable: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- mgr.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("error from manager: %v", err)
+		}
+	})
+
+	return bootstrap, reconciler
+}
+
+func waitForStart(t *testing.T, r *testRunnable, name string) {
+	select {
+	case <-r.started:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for %s to start", name)
+	}
+}
+
+type testRunnable struct {
+	needLeaderElection bool
+	started            chan struct{}
+}
+
+func newTestRunnable(needLeaderElection bool) *testRunnable {
+	return &testRunnable{
+		needLeaderElection: needLeaderElection,
+		started:            make(chan struct{}),
+	}
+}
+
+func (r *testRunnable) Start(ctx context.Context) error {
+	close(r.started)
+	<-ctx.Done()
+	return nil
+}
+
+func (r *testRunnable) NeedLeaderElection() bool {
+	return r.needLeaderElection
+}
+
+// fakeLock is an in-memory resourcelock.Interface.
+type fakeLock struct {
+	mutex    sync.Mutex
+	identity string
+	record   *resourcelock.LeaderElectionRecord
+}
+
+var _ resourcelock.Interface = &fakeLock{}
+
+func (l *fakeLock) Get(ctx context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.record == nil {
+		return nil, nil, apierrors.NewNotFound(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, "kops-controller-leader")
+	}
+	record := *l.record
+	return &record, []byte(record.HolderIdentity), nil
+}
+
+func (l *fakeLock) Create(ctx context.Context, ler resourcelock.LeaderElectionRecord) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.record = &ler
+	return nil
+}
+
+func (l *fakeLock) Update(ctx context.Context, ler resourcelock.LeaderElectionRecord) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.record = &ler
+	return nil
+}
+
+func (l *fakeLock) RecordEvent(string) {}
+
+func (l *fakeLock) Identity() string {
+	return l.identity
+}
+
+func (l *fakeLock) Describe() string {
+	return "fake/kops-controller-leader"
+}
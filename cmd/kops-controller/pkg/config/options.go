@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
@@ -38,8 +39,18 @@ type Options struct {
 	// EnableCloudIPAM enables the cloud IPAM controller.
 	EnableCloudIPAM bool `json:"enableCloudIPAM,omitempty"`
 
+	// ControlPlaneElasticIPs maps zones to the allocation IDs of the Elastic IPs
+	// that are associated with the control-plane node in each zone (AWS only).
+	ControlPlaneElasticIPs map[string]string `json:"controlPlaneElasticIPs,omitempty"`
+
 	// Discovery configures options relating to discovery, particularly for gossip mode.
 	Discovery *DiscoveryOptions `json:"discovery,omitempty"`
+
+	// EtcdDiscovery configures publishing the addresses of etcd members, for etcd-manager static discovery.
+	EtcdDiscovery *EtcdDiscoveryOptions `json:"etcdDiscovery,omitempty"`
+
+	// LeaderElection tunes the leader election used by the reconcilers.
+	LeaderElection *LeaderElectionOptions `json:"leaderElection,omitempty"`
 }
 
 func (o *Options) PopulateDefaults() {
@@ -83,3 +94,23 @@ type DiscoveryOptions struct {
 	// Enabled specifies whether support for discovery population is enabled.
 	Enabled bool `json:"enabled"`
 }
+
+// EtcdDiscoveryOptions configures publishing the addresses of etcd members to a ConfigMap,
+// which etcd-manager reads when it discovers its peers statically instead of through volume tags.
+type EtcdDiscoveryOptions struct {
+	// ConfigMapName is the name of the ConfigMap in kube-system that holds the addresses.
+	ConfigMapName string `json:"configMapName"`
+	// Members maps the DNS name of each etcd member to the instance group that runs it.
+	Members map[string]string `json:"members"`
+}
+
+// LeaderElectionOptions tunes leader election, which gates the reconcilers but not the bootstrap server.
+// Unset durations use the controller-runtime defaults.
+type LeaderElectionOptions struct {
+	// LeaseDuration is how long non-leaders wait before trying to take over an unrenewed lease.
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewDeadline is how long the leader keeps trying to renew its lease before giving it up.
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// RetryPeriod is how long to wait between attempts to acquire or renew the lease.
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+}
@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/pki"
 	"sigs.k8s.io/yaml"
 )
@@ -50,6 +52,22 @@ func newKeystore(basePath string, cas []string) (pki.Keystore, map[string]string
 	keystore := &keystore{
 		keys: map[string]keystoreEntry{},
 	}
+
+	var keypairIDs map[string]string
+	keypairIDsBytes, err := os.ReadFile(path.Join(basePath, "keypair-ids.yaml"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading keypair-ids.yaml")
+	}
+	err = yaml.Unmarshal(keypairIDsBytes, &keypairIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing keypair-ids.yaml")
+	}
+
+	// The kubelet-ca keyset only exists once a rotation has been staged.
+	if keypairIDs[kubeletca.KeysetName] != "" && !slices.Contains(cas, kubeletca.KeysetName) {
+		cas = append(slices.Clone(cas), kubeletca.KeysetName)
+	}
+
 	for _, name := range cas {
 		certBytes, err := os.ReadFile(path.Join(basePath, name+".crt"))
 		if err != nil {
@@ -75,15 +93,5 @@ func newKeystore(basePath string, cas []string) (pki.Keystore, map[string]string
 		}
 	}
 
-	var keypairIDs map[string]string
-	keypairIDsBytes, err := os.ReadFile(path.Join(basePath, "keypair-ids.yaml"))
-	if err != nil {
-		return nil, nil, fmt.Errorf("reading keypair-ids.yaml")
-	}
-	err = yaml.Unmarshal(keypairIDsBytes, &keypairIDs)
-	if err != nil {
-		return nil, nil, fmt.Errorf("parsing keypair-ids.yaml")
-	}
-
 	return keystore, keypairIDs, nil
 }
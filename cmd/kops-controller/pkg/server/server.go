@@ -40,6 +40,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/pkg/rbac"
 	"k8s.io/kops/upup/pkg/fi"
@@ -291,6 +292,9 @@ func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *
 			Organization: []string{rbac.NodesGroup},
 		}
 	case "kubelet-server":
+		if keypairIDs[kubeletca.KeysetName] != "" {
+			issueReq.Signer = kubeletca.KeysetName
+		}
 		issueReq.Subject = pkix.Name{
 			CommonName: id.NodeName,
 		}
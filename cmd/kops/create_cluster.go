@@ -57,6 +57,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/architectures"
 )
 
 type CreateClusterOptions struct {
@@ -99,6 +100,10 @@ type CreateClusterOptions struct {
 
 	// AddonPaths specify paths to additional components that we can add to a cluster
 	AddonPaths []string
+
+	// PreflightQuotas fails the creation if the cluster would exceed a cloud quota.
+	// Without it, exceeded quotas are only reported.
+	PreflightQuotas bool
 }
 
 func (o *CreateClusterOptions) InitDefaults() {
@@ -205,6 +210,7 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to immediately create the cluster")
 	cmd.Flags().StringVar(&options.Target, "target", options.Target, fmt.Sprintf("Valid targets: %s, %s. Set this flag to %s if you want kOps to generate terraform", cloudup.TargetDirect, cloudup.TargetTerraform, cloudup.TargetTerraform))
 	cmd.RegisterFlagCompletionFunc("target", completeCreateClusterTarget(options))
+	cmd.Flags().BoolVar(&options.PreflightQuotas, "preflight-quotas", options.PreflightQuotas, "Fail if the cluster would exceed AWS service quotas, instead of only warning")
 
 	// Configuration / state location
 	if featureflag.EnableSeparateConfigBase.Enabled() {
@@ -285,6 +291,11 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringSliceVar(&options.ControlPlaneSizes, "control-plane-size", options.ControlPlaneSizes, "Machine type(s) for control-plane nodes")
 	cmd.RegisterFlagCompletionFunc("control-plane-size", completeMachineType)
 
+	cmd.Flags().StringVar(&options.Architecture, "architecture", options.Architecture, "CPU architecture of the instances: amd64, arm64 or multi (amd64 control plane with amd64 and arm64 nodes; --node-size applies to the amd64 nodes). Defaults to the architecture of the machine types")
+	cmd.RegisterFlagCompletionFunc("architecture", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(architectures.ArchitectureAmd64), string(architectures.ArchitectureArm64), cloudup.ArchitectureMulti}, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	cmd.Flags().Int32Var(&options.ControlPlaneVolumeSize, "master-volume-size", options.ControlPlaneVolumeSize, "Instance volume size (in GB) for control-plane nodes")
 	cmd.Flags().MarkDeprecated("master-volume-size", "use --control-plane-volume-size instead")
 	cmd.Flags().Int32Var(&options.ControlPlaneVolumeSize, "control-plane-volume-size", options.ControlPlaneVolumeSize, "Instance volume size (in GB) for control-plane nodes")
@@ -350,6 +361,10 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("topology", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{api.TopologyPublic, api.TopologyPrivate}, cobra.ShellCompDirectiveNoFileComp
 	})
+	cmd.Flags().StringVar(&options.Egress, "egress", options.Egress, "Egress of the private subnets: the ID of a transit gateway (tgw-...) to route traffic to instead of creating NAT gateways, or External if routing is managed outside of kOps. Only applies to private topology.")
+	cmd.RegisterFlagCompletionFunc("egress", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{api.EgressExternal}, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	// Authorization
 	cmd.Flags().StringVar(&options.Authorization, "authorization", options.Authorization, "Authorization mode: "+cloudup.AuthorizationFlagAlwaysAllow+" or "+cloudup.AuthorizationFlagRBAC)
@@ -686,7 +701,8 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 			fullInstanceGroups = append(fullInstanceGroups, fullGroup)
 		}
 
-		err = validation.DeepValidate(fullCluster, fullInstanceGroups, true, clientset.VFSContext(), nil)
+		warnings, err := validation.DeepValidateWithWarnings(fullCluster, fullInstanceGroups, true, clientset.VFSContext(), nil)
+		validation.PrintWarnings(out, warnings)
 		if err != nil {
 			return fmt.Errorf("validation of the full cluster and instance group specs failed: %w", err)
 		}
@@ -805,10 +821,16 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 		updateClusterOptions.admin = kubeconfig.DefaultKubecfgAdminLifetime
 		updateClusterOptions.ClusterName = cluster.Name
 		updateClusterOptions.CreateKubecfg = true
+		updateClusterOptions.PreflightQuotas = c.PreflightQuotas
+		updateClusterOptions.warnOnQuotas = true
 
 		// SSHPublicKey has already been mapped
 		updateClusterOptions.SSHPublicKey = ""
 
+		if err := checkImageArchitectures(ctx, f, out, cluster.Name, c.Architecture); err != nil {
+			return err
+		}
+
 		_, err := RunUpdateCluster(ctx, f, out, updateClusterOptions)
 		if err != nil {
 			return err
@@ -842,6 +864,38 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 	return nil
 }
 
+// checkImageArchitectures verifies that all the images of the cluster are published for the architectures
+// required by --architecture, so that no managed addon is scheduled on nodes it can't run on.
+func checkImageArchitectures(ctx context.Context, f *util.Factory, out io.Writer, clusterName string, architecture string) error {
+	var required []architectures.Architecture
+	switch architecture {
+	case string(architectures.ArchitectureArm64):
+		required = []architectures.Architecture{architectures.ArchitectureArm64}
+	case cloudup.ArchitectureMulti:
+		required = []architectures.Architecture{architectures.ArchitectureAmd64, architectures.ArchitectureArm64}
+	default:
+		return nil
+	}
+
+	updateClusterResults, err := RunUpdateCluster(ctx, f, out, &UpdateClusterOptions{
+		Target:      cloudup.TargetDryRun,
+		GetAssets:   true,
+		ClusterName: clusterName,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing the images of the cluster: %w", err)
+	}
+
+	missing, err := assets.FindImagesMissingArchitectures(updateClusterResults.ImageAssets, required, assets.RemoteImageArchitectures)
+	if err != nil {
+		return fmt.Errorf("error checking the architectures of the images of the cluster: %w", err)
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("the cluster configuration has been created, but these images are not published for the architectures required by --architecture %s:\n  * %s", architecture, strings.Join(missing, "\n  * "))
+	}
+	return nil
+}
+
 // parseCloudLabels takes a CSV list of key=value records and parses them into a map. Nested '='s are supported via
 // quoted strings (eg `foo="bar=baz"` parses to map[string]string{"foo":"bar=baz"}. Nested commas are not supported.
 func parseCloudLabels(s string) (map[string]string, error) {
@@ -920,7 +974,7 @@ func completeKubernetesVersion(cmd *cobra.Command, args []string, toComplete str
 		if err != nil {
 			continue
 		}
-		if kopsutil.IsKubernetesGTE(cloudup.OldestSupportedKubernetesVersion, *parsed) &&
+		if kopsutil.IsKubernetesGTE(kopsutil.OldestSupportedKubernetesVersion, *parsed) &&
 			!kopsutil.IsKubernetesGTE(tooNewVersion.String(), *parsed) {
 			versions.Insert(parsed.String())
 		}
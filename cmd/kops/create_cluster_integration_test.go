@@ -156,6 +156,11 @@ func TestCreateClusterWithINGWSpecified(t *testing.T) {
 	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/ingwspecified", "v1alpha2")
 }
 
+// TestCreateClusterWithTGWSpecified runs kops create cluster private.example.com --zones us-test-1a,us-test-1b --topology private --egress tgw-0123456789abcdef0
+func TestCreateClusterWithTGWSpecified(t *testing.T) {
+	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/tgwspecified", "v1alpha2")
+}
+
 // TestCreateClusterSharedVPC runs kops create cluster vpc.example.com --zones us-test-1a --master-zones us-test-1a --vpc vpc-12345678
 func TestCreateClusterSharedVPC(t *testing.T) {
 	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/shared_vpc", "v1alpha2")
@@ -191,6 +196,16 @@ func TestCreateClusterDifferentAMIs(t *testing.T) {
 	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/different-amis", "v1alpha2")
 }
 
+// TestCreateClusterArm64 runs kops create cluster --architecture arm64
+func TestCreateClusterArm64(t *testing.T) {
+	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/arm64", "v1alpha2")
+}
+
+// TestCreateClusterMultiArch runs kops create cluster --architecture multi
+func TestCreateClusterMultiArch(t *testing.T) {
+	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/multi-arch", "v1alpha2")
+}
+
 // TestCreateClusterKarpenter runs kops create cluster --instance-manager=karpenter
 func TestCreateClusterKarpenter(t *testing.T) {
 	runCreateClusterIntegrationTest(t, "../../tests/integration/create_cluster/karpenter", "v1alpha2")
@@ -346,3 +361,85 @@ func runCreateClusterIntegrationTest(t *testing.T, srcDir string, version string
 	actualYAML := strings.Join(yamlAll, "\n\n---\n\n")
 	golden.AssertMatchesFile(t, actualYAML, path.Join(srcDir, expectedClusterPath))
 }
+
+// TestCreateClusterValidationWarnings checks that validation warnings are printed without failing kops create cluster
+func TestCreateClusterValidationWarnings(t *testing.T) {
+	grid := []struct {
+		name             string
+		sshAccess        []string
+		expectError      bool
+		expectedWarnings []string
+	}{
+		{
+			name:      "no warnings",
+			sshAccess: []string{"0.0.0.0/0"},
+		},
+		{
+			name:      "redundant CIDR",
+			sshAccess: []string{"0.0.0.0/0", "10.0.0.0/8"},
+			expectedWarnings: []string{
+				`Warning: spec.sshAccess[1]: "10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed (RedundantCIDR)`,
+			},
+		},
+		{
+			name:        "duplicate CIDR",
+			sshAccess:   []string{"10.0.0.0/8", "10.0.0.0/8"},
+			expectError: true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			h := testutils.NewIntegrationTestHarness(t)
+			defer h.Close()
+
+			h.SetupMockAWS()
+
+			publicKeyPath := path.Join(h.TempDir, "id_rsa.pub")
+			privateKeyPath := path.Join(h.TempDir, "id_rsa")
+			if err := MakeSSHKeyPair(publicKeyPath, privateKeyPath); err != nil {
+				t.Fatalf("error making SSH keypair: %v", err)
+			}
+			publicKey, err := os.ReadFile(publicKeyPath)
+			if err != nil {
+				t.Fatalf("error reading public key %q: %v", publicKeyPath, err)
+			}
+
+			factoryOptions := &util.FactoryOptions{}
+			factoryOptions.RegistryPath = "memfs://tests"
+			factory := util.NewFactory(factoryOptions)
+
+			options := &CreateClusterOptions{}
+			options.InitDefaults()
+			options.ClusterName = "minimal.example.com"
+			options.Zones = []string{"us-test-1a"}
+			options.CloudProvider = "aws"
+			options.Networking = "cni"
+			options.KubernetesVersion = "v1.26.0"
+			options.SSHAccess = g.sshAccess
+			options.SSHPublicKeys = map[string][]byte{fi.SecretNameSSHPrimary: publicKey}
+			options.Target = ""
+
+			var stdout bytes.Buffer
+			err = RunCreateCluster(ctx, factory, &stdout, options)
+			if g.expectError && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !g.expectError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var warnings []string
+			for _, line := range strings.Split(stdout.String(), "\n") {
+				if strings.HasPrefix(line, "Warning: ") {
+					warnings = append(warnings, line)
+				}
+			}
+			if strings.Join(warnings, "\n") != strings.Join(g.expectedWarnings, "\n") {
+				t.Errorf("expected warnings %q, got %q", g.expectedWarnings, warnings)
+			}
+		})
+	}
+}
@@ -259,9 +259,10 @@ func RunCreateInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer,
 			return fmt.Errorf("unexpected object type: %T", obj)
 		}
 
-		err = validation.CrossValidateInstanceGroup(group, cluster, cloud, true).ToAggregate()
-		if err != nil {
-			return err
+		result := validation.CrossValidateInstanceGroupWithWarnings(group, cluster, cloud, true)
+		validation.PrintWarnings(out, result.Warnings)
+		if result.HasErrors() {
+			return result.Errors.ToAggregate()
 		}
 
 		ig = group
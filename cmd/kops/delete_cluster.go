@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
@@ -44,6 +45,8 @@ type DeleteClusterOptions struct {
 	External    bool
 	Unregister  bool
 	ClusterName string
+	// Discovery is the strategy used to find the cloud resources to delete
+	Discovery string
 }
 
 var (
@@ -57,13 +60,19 @@ var (
 	# The --yes option runs the command immediately.
 	kops delete cluster --name=k8s.cluster.site --yes
 
+	# Find the resources to delete with the AWS Resource Groups Tagging API,
+	# which is faster in accounts with many resources.
+	kops delete cluster --name=k8s.cluster.site --discovery=tagging --yes
+
 	`))
 
 	deleteClusterShort = i18n.T("Delete a cluster.")
 )
 
 func NewCmdDeleteCluster(f *util.Factory, out io.Writer) *cobra.Command {
-	options := &DeleteClusterOptions{}
+	options := &DeleteClusterOptions{
+		Discovery: string(resources.DiscoveryDescribe),
+	}
 
 	cmd := &cobra.Command{
 		Use:               "cluster [CLUSTER]",
@@ -73,7 +82,12 @@ func NewCmdDeleteCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		Args:              rootCommand.clusterNameArgsNoKubeconfig(&options.ClusterName),
 		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunDeleteCluster(cmd.Context(), f, out, options)
+			err := RunDeleteCluster(cmd.Context(), f, out, options)
+			// Once the cluster is deleted, there is no state store left to record the operation in
+			if !options.External && (err != nil || !options.Yes) {
+				recordOperation(cmd.Context(), f, cmd, args, options.ClusterName, err)
+			}
+			return err
 		},
 	}
 
@@ -84,6 +98,15 @@ func NewCmdDeleteCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.Region, "region", options.Region, "External cluster's cloud region")
 	cmd.RegisterFlagCompletionFunc("region", completeRegion)
 
+	cmd.Flags().StringVar(&options.Discovery, "discovery", options.Discovery, "How to find the cloud resources to delete. One of: describe, tagging (AWS only), auto (tagging, falling back to describe)")
+	cmd.RegisterFlagCompletionFunc("discovery", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var discoveries []string
+		for _, discovery := range resources.Discoveries {
+			discoveries = append(discoveries, string(discovery))
+		}
+		return discoveries, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	return cmd
 }
 
@@ -93,6 +116,11 @@ func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, optio
 		return fmt.Errorf("--name is required (for safety)")
 	}
 
+	discovery := resources.Discovery(options.Discovery)
+	if !slices.Contains(resources.Discoveries, discovery) {
+		return fmt.Errorf("unknown --discovery %q, must be one of %v", options.Discovery, resources.Discoveries)
+	}
+
 	var cloud fi.Cloud
 	var cluster *kopsapi.Cluster
 	var err error
@@ -126,7 +154,7 @@ func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, optio
 		}
 
 		klog.Info("Looking for cloud resources to delete")
-		allResources, err := resourceops.ListResources(cloud, cluster)
+		allResources, err := resourceops.ListResources(cloud, cluster, discovery)
 		if err != nil {
 			return err
 		}
@@ -133,7 +133,9 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 		},
 		ValidArgsFunction: completeInstanceOrNode(f, &options),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunDeleteInstance(cmd.Context(), f, out, &options)
+			err := RunDeleteInstance(cmd.Context(), f, out, &options)
+			recordOperation(cmd.Context(), f, cmd, args, options.ClusterName, err)
+			return err
 		},
 	}
 
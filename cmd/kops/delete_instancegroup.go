@@ -109,7 +109,9 @@ func NewCmdDeleteInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
 				}
 			}
 
-			return RunDeleteInstanceGroup(ctx, f, out, options)
+			err := RunDeleteInstanceGroup(ctx, f, out, options)
+			recordOperation(ctx, f, cmd, args, options.ClusterName, err)
+			return err
 		},
 	}
 
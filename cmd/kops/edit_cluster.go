@@ -51,6 +51,8 @@ type EditClusterOptions struct {
 	Sets []string
 	// Unsets allows unsetting values directly in the spec.
 	Unsets []string
+	// AllowKubernetesVersionSkip allows upgrading the Kubernetes version by more than one minor version.
+	AllowKubernetesVersionSkip bool
 }
 
 var (
@@ -83,7 +85,9 @@ func NewCmdEditCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
 		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunEditCluster(cmd.Context(), f, out, options)
+			err := RunEditCluster(cmd.Context(), f, out, options)
+			recordOperation(cmd.Context(), f, cmd, args, options.ClusterName, err)
+			return err
 		},
 	}
 
@@ -92,6 +96,7 @@ func NewCmdEditCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	})
 	cmd.Flags().StringSliceVar(&options.Unsets, "unset", options.Unsets, "Directly unset values in the spec")
+	cmd.Flags().BoolVar(&options.AllowKubernetesVersionSkip, "allow-kubernetes-version-skip", false, "Allow upgrading the Kubernetes version by more than one minor version")
 	cmd.RegisterFlagCompletionFunc("unset", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	})
@@ -129,7 +134,7 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 			return err
 		}
 
-		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+		failure, err := updateCluster(ctx, clientset, out, oldCluster, newCluster, instanceGroups, simple.UpdateClusterOptions{AllowKubernetesVersionSkip: options.AllowKubernetesVersionSkip})
 		if err != nil {
 			return err
 		}
@@ -235,7 +240,7 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 			continue
 		}
 
-		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+		failure, err := updateCluster(ctx, clientset, out, oldCluster, newCluster, instanceGroups, simple.UpdateClusterOptions{AllowKubernetesVersionSkip: options.AllowKubernetesVersionSkip})
 		if err != nil {
 			return preservedFile(err, file, out)
 		}
@@ -252,7 +257,7 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 	}
 }
 
-func updateCluster(ctx context.Context, clientset simple.Clientset, oldCluster, newCluster *api.Cluster, instanceGroups []*api.InstanceGroup) (string, error) {
+func updateCluster(ctx context.Context, clientset simple.Clientset, out io.Writer, oldCluster, newCluster *api.Cluster, instanceGroups []*api.InstanceGroup, options simple.UpdateClusterOptions) (string, error) {
 	cloud, err := cloudup.BuildCloud(newCluster)
 	if err != nil {
 		return "", err
@@ -269,10 +274,12 @@ func updateCluster(ctx context.Context, clientset simple.Clientset, oldCluster,
 		return fmt.Sprintf("error populating cluster spec: %s", err), nil
 	}
 
-	err = validation.DeepValidate(fullCluster, instanceGroups, true, clientset.VFSContext(), cloud)
+	warnings, err := validation.DeepValidateWithWarnings(fullCluster, instanceGroups, true, clientset.VFSContext(), cloud)
 	if err != nil {
 		return fmt.Sprintf("validation failed: %s", err), nil
 	}
+	validation.PrintWarnings(out, warnings)
+	validation.PrintWarnings(out, validation.ClusterUpdateWarnings(newCluster, oldCluster))
 
 	// Retrieve the current status of the cluster.  This will eventually be part of the cluster object.
 	status, err := cloud.FindClusterStatus(oldCluster)
@@ -281,7 +288,7 @@ func updateCluster(ctx context.Context, clientset simple.Clientset, oldCluster,
 	}
 
 	// Note we perform as much validation as we can, before writing a bad config
-	_, err = clientset.UpdateCluster(ctx, newCluster, status)
+	_, err = clientset.UpdateCluster(ctx, newCluster, status, options)
 	return "", err
 }
 
@@ -103,7 +103,9 @@ func NewCmdEditInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
 		},
 		ValidArgsFunction: completeInstanceGroup(f, nil, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunEditInstanceGroup(cmd.Context(), f, out, options)
+			err := RunEditInstanceGroup(cmd.Context(), f, out, options)
+			recordOperation(cmd.Context(), f, cmd, args, options.ClusterName, err)
+			return err
 		},
 	}
 
@@ -154,7 +156,7 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 			return err
 		}
 
-		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
+		failure, err := updateInstanceGroup(ctx, clientset, out, channel, cluster, newGroup)
 		if err != nil {
 			return err
 		}
@@ -260,7 +262,7 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 			continue
 		}
 
-		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
+		failure, err := updateInstanceGroup(ctx, clientset, out, channel, cluster, newGroup)
 		if err != nil {
 			return preservedFile(err, file, out)
 		}
@@ -277,7 +279,7 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 	}
 }
 
-func updateInstanceGroup(ctx context.Context, clientset simple.Clientset, channel *api.Channel, cluster *api.Cluster, newGroup *api.InstanceGroup) (string, error) {
+func updateInstanceGroup(ctx context.Context, clientset simple.Clientset, out io.Writer, channel *api.Channel, cluster *api.Cluster, newGroup *api.InstanceGroup) (string, error) {
 	cloud, err := cloudup.BuildCloud(cluster)
 	if err != nil {
 		return "", err
@@ -301,10 +303,11 @@ func updateInstanceGroup(ctx context.Context, clientset simple.Clientset, channe
 		return fmt.Sprintf("error populating cluster spec: %s", err), nil
 	}
 
-	err = validation.CrossValidateInstanceGroup(fullGroup, fullCluster, cloud, true).ToAggregate()
-	if err != nil {
-		return fmt.Sprintf("validation failed: %s", err), nil
+	result := validation.CrossValidateInstanceGroupWithWarnings(fullGroup, fullCluster, cloud, true)
+	if result.HasErrors() {
+		return fmt.Sprintf("validation failed: %s", result.Errors.ToAggregate()), nil
 	}
+	validation.PrintWarnings(out, result.Warnings)
 
 	// Note we perform as much validation as we can, before writing a bad config
 	_, err = clientset.InstanceGroupsFor(cluster).Update(ctx, newGroup, metav1.UpdateOptions{})
@@ -20,15 +20,19 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -49,6 +53,10 @@ var (
 
 	# export using the internal DNS name, bypassing the cloud load balancer
 	kops export kubeconfig k8s-cluster.example.com --internal
+
+	# export all clusters in the state store with admin credentials valid for 8 hours,
+	# naming each context after its cluster
+	kops export kubeconfig --all --admin=8h --context-name-template 'kops-{{.ClusterName}}'
 	`))
 
 	exportKubeconfigShort = i18n.T(`Export kubeconfig.`)
@@ -62,6 +70,9 @@ type ExportKubeconfigOptions struct {
 	user           string
 	internal       bool
 
+	// ContextNameTemplate is a template for the name of the kubectl context, such as "kops-{{.ClusterName}}"
+	ContextNameTemplate string
+
 	// UseKopsAuthenticationPlugin controls whether we should use the kOps auth helper instead of a static credential
 	UseKopsAuthenticationPlugin bool
 }
@@ -101,6 +112,7 @@ func NewCmdExportKubeconfig(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.user, "user", options.user, "Existing user in kubeconfig file to use")
 	cmd.RegisterFlagCompletionFunc("user", completeKubecfgUser)
 	cmd.Flags().BoolVar(&options.internal, "internal", options.internal, "Use the cluster's internal DNS name")
+	cmd.Flags().StringVar(&options.ContextNameTemplate, "context-name-template", options.ContextNameTemplate, "Template for the name of the kubectl context, for example 'kops-{{.ClusterName}}'")
 	cmd.Flags().BoolVar(&options.UseKopsAuthenticationPlugin, "auth-plugin", options.UseKopsAuthenticationPlugin, "Use the kOps authentication plugin")
 
 	return cmd
@@ -112,6 +124,14 @@ func RunExportKubeconfig(ctx context.Context, f *util.Factory, out io.Writer, op
 		return err
 	}
 
+	var contextName *template.Template
+	if options.ContextNameTemplate != "" {
+		contextName, err = kubeconfig.ParseContextNameTemplate(options.ContextNameTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
 	var clusterList []*kopsapi.Cluster
 	if options.all {
 		list, err := clientset.ListClusters(ctx, metav1.ListOptions{})
@@ -129,18 +149,36 @@ func RunExportKubeconfig(ctx context.Context, f *util.Factory, out io.Writer, op
 		clusterList = append(clusterList, cluster)
 	}
 
-	for _, cluster := range clusterList {
+	stores := func(cluster *kopsapi.Cluster) (fi.KeystoreReader, fi.SecretStore, fi.Cloud, error) {
 		keyStore, err := clientset.KeyStore(cluster)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
 		}
 
 		secretStore, err := clientset.SecretStore(cluster)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
 		}
 
 		cloud, err := cloudup.BuildCloud(cluster)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return keyStore, secretStore, cloud, nil
+	}
+
+	exportOptions := &kubeconfig.ExportOptions{
+		Admin:                       options.admin,
+		User:                        options.user,
+		Internal:                    options.internal,
+		KopsStateStore:              f.KopsStateStore(),
+		UseKopsAuthenticationPlugin: options.UseKopsAuthenticationPlugin,
+		ContextName:                 contextName,
+	}
+
+	if !options.all {
+		cluster := clusterList[0]
+		keyStore, secretStore, cloud, err := stores(cluster)
 		if err != nil {
 			return err
 		}
@@ -159,9 +197,31 @@ func RunExportKubeconfig(ctx context.Context, f *util.Factory, out io.Writer, op
 			return err
 		}
 
-		if err := conf.WriteKubecfg(buildPathOptions(options)); err != nil {
+		name, err := kubeconfig.ContextName(contextName, cluster.ObjectMeta.Name)
+		if err != nil {
 			return err
 		}
+		conf.RenameContext(name)
+
+		return conf.WriteKubecfg(buildPathOptions(options))
+	}
+
+	confs, skipped, err := kubeconfig.BuildKubecfgs(ctx, clusterList, stores, exportOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := kubeconfig.WriteKubecfgs(buildPathOptions(options), confs); err != nil {
+		return err
+	}
+
+	if len(skipped) != 0 {
+		var names []string
+		for _, s := range skipped {
+			names = append(names, s.ClusterName)
+			klog.V(2).Infof("skipped cluster %q: %v", s.ClusterName, s.Err)
+		}
+		klog.Warningf("Skipped %d of %d clusters whose CA certificate could not be loaded: %s", len(skipped), len(clusterList), strings.Join(names, ", "))
 	}
 
 	return nil
@@ -39,6 +39,8 @@ const (
 	OutputYaml  = "yaml"
 	OutputTable = "table"
 	OutputJSON  = "json"
+	// OutputWide is a table with additional columns, only supported by some resources
+	OutputWide = "wide"
 )
 
 func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
@@ -62,6 +64,7 @@ func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
 	})
 
 	// create subcommands
+	cmd.AddCommand(NewCmdGetAddons(f, out, options))
 	cmd.AddCommand(NewCmdGetAll(f, out, options))
 	cmd.AddCommand(NewCmdGetAssets(f, out, options))
 	cmd.AddCommand(NewCmdGetCluster(f, out, options))
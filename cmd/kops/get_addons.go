@@ -17,13 +17,224 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 
+	"github.com/blang/semver/v4"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kops/channels/pkg/channels"
+	channelscmd "k8s.io/kops/channels/pkg/cmd"
+	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
+var (
+	getAddonsLong = templates.LongDesc(i18n.T(`
+	Display the managed addons of a cluster, comparing the version in the cluster's channels
+	with the version applied to the cluster, and the error of the last attempt to apply them.`))
+
+	getAddonsExample = templates.Examples(i18n.T(`
+	# Display the managed addons of a cluster
+	kops get addons --name k8s-cluster.example.com
+	`))
+
+	getAddonsShort = i18n.T(`Display the managed addons of a cluster.`)
+)
+
+const (
+	addonStatusApplied = "Applied"
+	addonStatusPending = "Pending"
+	addonStatusFailed  = "Failed"
+	addonStatusRemoved = "Removed"
+)
+
+type renderableAddon struct {
+	Namespace      string                   `json:"namespace"`
+	Name           string                   `json:"name"`
+	Status         string                   `json:"status"`
+	DesiredVersion *channels.ChannelVersion `json:"desiredVersion,omitempty"`
+	AppliedVersion *channels.ChannelVersion `json:"appliedVersion,omitempty"`
+	LastError      string                   `json:"lastError,omitempty"`
+	RetryCount     int                      `json:"retryCount,omitempty"`
+}
+
+func NewCmdGetAddons(f *util.Factory, out io.Writer, options *GetOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "addons [CLUSTER]",
+		Aliases:           []string{"addon"},
+		Short:             getAddonsShort,
+		Long:              getAddonsLong,
+		Example:           getAddonsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetAddons(cmd.Context(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunGetAddons(ctx context.Context, f *util.Factory, out io.Writer, options *GetOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	menu, err := buildClusterAddonMenu(f, cluster)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := createK8sClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing namespaces: %w", err)
+	}
+
+	addons := asRenderableAddons(channels.BuildAddonStates(menu, namespaces.Items))
+
+	switch options.Output {
+	case OutputTable:
+		return addonStatesOutputTable(addons, out)
+	case OutputYaml:
+		y, err := yaml.Marshal(addons)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	case OutputJSON:
+		j, err := json.Marshal(addons)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+}
+
+// buildClusterAddonMenu loads the addons of the channels applied to the cluster:
+// the bootstrap channel in the state store and the channels of spec.addons.
+func buildClusterAddonMenu(f *util.Factory, cluster *api.Cluster) (*channels.AddonMenu, error) {
+	kubernetesVersion, err := semver.ParseTolerant(cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse kubernetes version %q", cluster.Spec.KubernetesVersion)
+	}
+	// Remove Pre and Patch, as they make semver comparisons impractical
+	kubernetesVersion.Pre = nil
+
+	configBase, err := f.VFSContext().BuildVfsPath(cluster.Spec.ConfigStore.Base)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing configStore.base %q: %w", cluster.Spec.ConfigStore.Base, err)
+	}
+
+	channelLocations := []string{
+		configBase.Join("addons", "bootstrap-channel.yaml").Path(),
+	}
+	for i := range cluster.Spec.Addons {
+		channelLocations = append(channelLocations, cluster.Spec.Addons[i].Manifest)
+	}
+
+	menu := channels.NewAddonMenu()
+	for _, channelLocation := range channelLocations {
+		current, err := channelscmd.BuildMenu(f.VFSContext(), kubernetesVersion, channelLocation)
+		if err != nil {
+			return nil, err
+		}
+		menu.MergeAddons(current)
+	}
+	return menu, nil
+}
+
+func asRenderableAddons(states []*channels.AddonState) []*renderableAddon {
+	var addons []*renderableAddon
+	for _, state := range states {
+		addon := &renderableAddon{
+			Namespace:      state.Namespace,
+			Name:           state.Name,
+			DesiredVersion: state.Desired,
+			AppliedVersion: state.Applied,
+		}
+		switch {
+		case state.Desired == nil:
+			addon.Status = addonStatusRemoved
+		case state.Status != nil && state.Status.Failed():
+			addon.Status = addonStatusFailed
+		case state.NeedsUpdate():
+			addon.Status = addonStatusPending
+		default:
+			addon.Status = addonStatusApplied
+		}
+		if state.Status != nil {
+			addon.LastError = state.Status.LastError
+			addon.RetryCount = state.Status.RetryCount
+		}
+		addons = append(addons, addon)
+	}
+	return addons
+}
+
+func addonStatesOutputTable(addons []*renderableAddon, out io.Writer) error {
+	manifestHash := func(version *channels.ChannelVersion) string {
+		if version == nil || version.ManifestHash == "" {
+			return "-"
+		}
+		return version.ManifestHash
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("NAMESPACE", func(a *renderableAddon) string {
+		return a.Namespace
+	})
+	t.AddColumn("NAME", func(a *renderableAddon) string {
+		return a.Name
+	})
+	t.AddColumn("STATUS", func(a *renderableAddon) string {
+		return a.Status
+	})
+	t.AddColumn("DESIRED", func(a *renderableAddon) string {
+		return manifestHash(a.DesiredVersion)
+	})
+	t.AddColumn("APPLIED", func(a *renderableAddon) string {
+		return manifestHash(a.AppliedVersion)
+	})
+	t.AddColumn("RETRIES", func(a *renderableAddon) string {
+		return fmt.Sprintf("%d", a.RetryCount)
+	})
+	t.AddColumn("LAST-ERROR", func(a *renderableAddon) string {
+		return a.LastError
+	})
+	return t.Render(addons, out, "NAMESPACE", "NAME", "STATUS", "DESIRED", "APPLIED", "RETRIES", "LAST-ERROR")
+}
+
 func addonsOutputTable(cluster *api.Cluster, addons []*unstructured.Unstructured, out io.Writer) error {
 	t := &tables.Table{}
 	t.AddColumn("NAME", func(o *unstructured.Unstructured) string {
@@ -144,7 +144,7 @@ func RunGetAll(ctx context.Context, f commandutils.Factory, out io.Writer, optio
 			return err
 		}
 		fmt.Fprintf(out, "\nInstance Groups\n")
-		err = igOutputTable(cluster, instancegroups, out)
+		err = igOutputTable(cluster, instancegroups, nil, out)
 		if err != nil {
 			return err
 		}
@@ -18,20 +18,30 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/formatter"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/util/pkg/tables"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -47,6 +57,12 @@ var (
 
 	# Save a cluster's instancegroups desired configuration to YAML file
 	kops get instancegroups --name k8s-cluster.example.com -o yaml > instancegroups-desired-config.yaml
+
+	# Show the pricing class, image age, update status, instance counts and warm pool of a cluster's instancegroups
+	kops get instancegroups --name k8s-cluster.example.com -o wide
+
+	# Get the same details as JSON
+	kops get instancegroups --name k8s-cluster.example.com -o json --details
 	`))
 
 	getInstancegroupsShort = i18n.T(`Get one or many instance groups.`)
@@ -55,6 +71,38 @@ var (
 type GetInstanceGroupsOptions struct {
 	*GetOptions
 	InstanceGroupNames []string
+
+	// Details queries the cloud for the state of the instance groups.
+	// It is implied by the wide output format.
+	Details bool
+}
+
+// instanceGroupDetails is the cloud state of an instance group
+type instanceGroupDetails struct {
+	// Pricing is the pricing class of the instances: on-demand, spot or mixed
+	Pricing string
+	// ImageAgeDays is the age of the resolved image, or nil if it is not known
+	ImageAgeDays *int
+	// CloudGroup is the cloud group of the instance group, or nil if it does not exist
+	CloudGroup *cloudinstances.CloudInstanceGroup
+	// WarmPool is the warm pool of the instance group after applying the cluster defaults, or nil if it has none
+	WarmPool *api.WarmPoolSpec
+}
+
+// renderableInstanceGroup is the json and yaml representation of an instance group with its details
+type renderableInstanceGroup struct {
+	Name             string `json:"name"`
+	Role             string `json:"role"`
+	MachineType      string `json:"machineType"`
+	MinSize          *int32 `json:"minSize,omitempty"`
+	MaxSize          *int32 `json:"maxSize,omitempty"`
+	Pricing          string `json:"pricing"`
+	ImageAgeDays     *int   `json:"imageAgeDays,omitempty"`
+	NeedsUpdate      bool   `json:"needsUpdate"`
+	CurrentInstances int    `json:"currentInstances"`
+	DesiredInstances *int   `json:"desiredInstances,omitempty"`
+
+	WarmPool *api.WarmPoolSpec `json:"warmPool,omitempty"`
 }
 
 func NewCmdGetInstanceGroups(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
@@ -85,6 +133,8 @@ func NewCmdGetInstanceGroups(f *util.Factory, out io.Writer, getOptions *GetOpti
 		},
 	}
 
+	cmd.Flags().BoolVar(&options.Details, "details", options.Details, "Include the pricing class, image age, update status, instance counts and warm pool. Implied by -o wide.")
+
 	return cmd
 }
 
@@ -121,19 +171,52 @@ func RunGetInstanceGroups(ctx context.Context, f commandutils.Factory, out io.Wr
 		singleObject = true
 	}
 
+	var details map[string]*instanceGroupDetails
+	if options.Details || options.Output == OutputWide {
+		cloud, err := cloudup.BuildCloud(cluster)
+		if err != nil {
+			return err
+		}
+
+		details, err = collectInstanceGroupDetails(cluster, cloud, instancegroups, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
 	var obj []runtime.Object
-	if options.Output != OutputTable {
+	if details == nil && (options.Output == OutputYaml || options.Output == OutputJSON) {
 		for _, c := range instancegroups {
 			obj = append(obj, c)
 		}
 	}
 
 	switch options.Output {
-	case OutputTable:
-		return igOutputTable(cluster, instancegroups, out)
+	case OutputTable, OutputWide:
+		return igOutputTable(cluster, instancegroups, details, out)
 	case OutputYaml:
+		if details != nil {
+			y, err := yaml.Marshal(asRenderableInstanceGroups(instancegroups, details))
+			if err != nil {
+				return fmt.Errorf("unable to marshal YAML: %v", err)
+			}
+			if _, err := out.Write(y); err != nil {
+				return fmt.Errorf("error writing to output: %v", err)
+			}
+			return nil
+		}
 		return fullOutputYAML(out, obj...)
 	case OutputJSON:
+		if details != nil {
+			j, err := json.MarshalIndent(asRenderableInstanceGroups(instancegroups, details), "", "  ")
+			if err != nil {
+				return fmt.Errorf("unable to marshal JSON: %v", err)
+			}
+			if _, err := out.Write(j); err != nil {
+				return fmt.Errorf("error writing to output: %v", err)
+			}
+			return nil
+		}
 		return fullOutputJSON(out, singleObject, obj...)
 	default:
 		return fmt.Errorf("unknown output format: %q", options.Output)
@@ -167,7 +250,7 @@ func filterInstanceGroupsByName(instanceGroupNames []string, list []api.Instance
 	return instancegroups, nil
 }
 
-func igOutputTable(cluster *api.Cluster, instancegroups []*api.InstanceGroup, out io.Writer) error {
+func igOutputTable(cluster *api.Cluster, instancegroups []*api.InstanceGroup, details map[string]*instanceGroupDetails, out io.Writer) error {
 	t := &tables.Table{}
 	t.AddColumn("NAME", func(c *api.InstanceGroup) string {
 		return c.ObjectMeta.Name
@@ -187,7 +270,160 @@ func igOutputTable(cluster *api.Cluster, instancegroups []*api.InstanceGroup, ou
 		return int32PointerToString(c.Spec.MaxSize)
 	})
 	// SUBNETS is not selected by default - not as useful as ZONES
-	return t.Render(instancegroups, out, "NAME", "ROLE", "MACHINETYPE", "MIN", "MAX", "ZONES")
+	columns := []string{"NAME", "ROLE", "MACHINETYPE", "MIN", "MAX", "ZONES"}
+	// unless subnets are selected by subnetSelectors, so users can see which subnets were matched
+	for _, ig := range instancegroups {
+		if len(ig.Spec.Subnets) == 0 && len(ig.Spec.SubnetSelectors) != 0 {
+			columns = []string{"NAME", "ROLE", "MACHINETYPE", "MIN", "MAX", "SUBNETS", "ZONES"}
+			break
+		}
+	}
+
+	if details != nil {
+		t.AddColumn("PRICING", func(c *api.InstanceGroup) string {
+			return details[c.ObjectMeta.Name].Pricing
+		})
+		t.AddColumn("IMAGEAGE", func(c *api.InstanceGroup) string {
+			age := details[c.ObjectMeta.Name].ImageAgeDays
+			if age == nil {
+				return "-"
+			}
+			return fmt.Sprintf("%dd", *age)
+		})
+		t.AddColumn("NEEDSUPDATE", func(c *api.InstanceGroup) string {
+			cg := details[c.ObjectMeta.Name].CloudGroup
+			if cg == nil {
+				return "-"
+			}
+			return strconv.FormatBool(len(cg.NeedUpdate) > 0)
+		})
+		t.AddColumn("INSTANCES", func(c *api.InstanceGroup) string {
+			cg := details[c.ObjectMeta.Name].CloudGroup
+			if cg == nil {
+				return "-"
+			}
+			return fmt.Sprintf("%d/%d", len(cg.Ready)+len(cg.NeedUpdate), cg.TargetSize)
+		})
+		t.AddColumn("WARMPOOL", func(c *api.InstanceGroup) string {
+			return formatWarmPool(details[c.ObjectMeta.Name].WarmPool)
+		})
+		columns = append(columns, "PRICING", "IMAGEAGE", "NEEDSUPDATE", "INSTANCES", "WARMPOOL")
+	}
+
+	return t.Render(instancegroups, out, columns...)
+}
+
+// collectInstanceGroupDetails queries the cloud for the state of the instance groups.
+// The cloud groups are listed once for the whole cluster and each distinct image is only resolved once.
+func collectInstanceGroupDetails(cluster *api.Cluster, cloud fi.Cloud, instancegroups []*api.InstanceGroup, now time.Time) (map[string]*instanceGroupDetails, error) {
+	cloudGroups, err := cloud.GetCloudGroups(cluster, instancegroups, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cloud groups: %w", err)
+	}
+
+	imageAges := make(map[string]*int)
+	details := make(map[string]*instanceGroupDetails)
+	for _, ig := range instancegroups {
+		image := ig.Spec.Image
+		age, found := imageAges[image]
+		if !found {
+			age = imageAgeDays(cloud, image, now)
+			imageAges[image] = age
+		}
+
+		details[ig.ObjectMeta.Name] = &instanceGroupDetails{
+			Pricing:      instanceGroupPricing(ig),
+			ImageAgeDays: age,
+			CloudGroup:   cloudGroups[ig.ObjectMeta.Name],
+		}
+		if aws := cluster.Spec.CloudProvider.AWS; aws != nil {
+			if warmPool := aws.WarmPool.ResolveDefaults(ig); warmPool.IsEnabled() {
+				details[ig.ObjectMeta.Name].WarmPool = warmPool
+			}
+		}
+	}
+
+	return details, nil
+}
+
+// imageAgeDays returns the age of an image in days, or nil if it is not known
+func imageAgeDays(cloud fi.Cloud, image string, now time.Time) *int {
+	awsCloud, ok := cloud.(awsup.AWSCloud)
+	if !ok || image == "" {
+		return nil
+	}
+
+	imageInfo, err := awsCloud.ResolveImage(image)
+	if err != nil {
+		klog.Warningf("unable to resolve image %q: %v", image, err)
+		return nil
+	}
+
+	created, err := time.Parse(time.RFC3339, aws.StringValue(imageInfo.CreationDate))
+	if err != nil {
+		klog.Warningf("unable to parse creation date of image %q: %v", image, err)
+		return nil
+	}
+
+	return fi.PtrTo(int(now.Sub(created).Hours() / 24))
+}
+
+// formatWarmPool returns the sizes of a warm pool, and whether instances are reused on scale in
+func formatWarmPool(warmPool *api.WarmPoolSpec) string {
+	if warmPool == nil {
+		return "-"
+	}
+	maxSize := "default"
+	if warmPool.MaxSize != nil {
+		maxSize = strconv.FormatInt(*warmPool.MaxSize, 10)
+	}
+	s := fmt.Sprintf("min=%d,max=%s", warmPool.MinSize, maxSize)
+	if fi.ValueOf(warmPool.ReuseOnScaleIn) {
+		s += ",reuse"
+	}
+	return s
+}
+
+// instanceGroupPricing returns the pricing class of the instances of an instance group
+func instanceGroupPricing(ig *api.InstanceGroup) string {
+	if policy := ig.Spec.MixedInstancesPolicy; policy != nil {
+		// AWS launches only on-demand instances above the base capacity by default
+		aboveBase := fi.ValueOf(policy.OnDemandAboveBase)
+		if policy.OnDemandAboveBase == nil || aboveBase == 100 {
+			return "on-demand"
+		}
+		if aboveBase == 0 && fi.ValueOf(policy.OnDemandBase) == 0 {
+			return "spot"
+		}
+		return "mixed"
+	}
+	if ig.Spec.MaxPrice != nil || strings.EqualFold(fi.ValueOf(ig.Spec.GCPProvisioningModel), "SPOT") {
+		return "spot"
+	}
+	return "on-demand"
+}
+
+func asRenderableInstanceGroups(instancegroups []*api.InstanceGroup, details map[string]*instanceGroupDetails) []*renderableInstanceGroup {
+	arr := make([]*renderableInstanceGroup, len(instancegroups))
+	for i, ig := range instancegroups {
+		d := details[ig.ObjectMeta.Name]
+		arr[i] = &renderableInstanceGroup{
+			Name:         ig.ObjectMeta.Name,
+			Role:         string(ig.Spec.Role),
+			MachineType:  ig.Spec.MachineType,
+			MinSize:      ig.Spec.MinSize,
+			MaxSize:      ig.Spec.MaxSize,
+			Pricing:      d.Pricing,
+			ImageAgeDays: d.ImageAgeDays,
+			WarmPool:     d.WarmPool,
+		}
+		if cg := d.CloudGroup; cg != nil {
+			arr[i].NeedsUpdate = len(cg.NeedUpdate) > 0
+			arr[i].CurrentInstances = len(cg.Ready) + len(cg.NeedUpdate)
+			arr[i].DesiredInstances = fi.PtrTo(cg.TargetSize)
+		}
+	}
+	return arr
 }
 
 func int32PointerToString(v *int32) string {
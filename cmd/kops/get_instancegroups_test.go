@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestGetInstanceGroupsWide(t *testing.T) {
+	t.Setenv("SKIP_REGION_CHECK", "1")
+
+	clusterName := "test.k8s.io"
+
+	cluster := testutils.BuildMinimalCluster(clusterName)
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	nodes.Spec.MaxPrice = fi.PtrTo("0.1")
+
+	cloud := testutils.NewIntegrationTestHarness(t).SetupMockAWS()
+	createMockAutoscalingGroup(t, cloud, "nodes."+clusterName, 2)
+
+	ctx := context.Background()
+
+	factoryOptions := &util.FactoryOptions{}
+	factoryOptions.RegistryPath = "memfs://tests"
+
+	factory := util.NewFactory(factoryOptions)
+	clientSet, err := factory.KopsClient()
+	if err != nil {
+		t.Fatalf("could not create clientset: %v", err)
+	}
+
+	cluster, err = clientSet.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("could not create cluster: %v", err)
+	}
+	_, err = clientSet.InstanceGroupsFor(cluster).Create(ctx, &nodes, v1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("could not create instance group: %v", err)
+	}
+
+	grid := []struct {
+		output           string
+		details          bool
+		expectedContains []string
+		expectedAbsent   []string
+	}{
+		{
+			output:           OutputTable,
+			expectedContains: []string{"NAME", "MACHINETYPE"},
+			expectedAbsent:   []string{"PRICING", "spot"},
+		},
+		{
+			output:           OutputWide,
+			expectedContains: []string{"PRICING", "IMAGEAGE", "NEEDSUPDATE", "INSTANCES", "spot", "false", "0/2"},
+		},
+		{
+			output:           OutputYaml,
+			expectedContains: []string{"apiVersion: kops.k8s.io/v1alpha2", "maxPrice: \"0.1\""},
+			expectedAbsent:   []string{"pricing"},
+		},
+		{
+			output:           OutputYaml,
+			details:          true,
+			expectedContains: []string{"name: nodes", "pricing: spot", "needsUpdate: false", "currentInstances: 0", "desiredInstances: 2", "imageAgeDays:"},
+			expectedAbsent:   []string{"apiVersion"},
+		},
+		{
+			output:           OutputJSON,
+			details:          true,
+			expectedContains: []string{`"name": "nodes"`, `"pricing": "spot"`, `"desiredInstances": 2`},
+		},
+	}
+
+	for _, g := range grid {
+		var stdout bytes.Buffer
+		options := &GetInstanceGroupsOptions{
+			GetOptions: &GetOptions{
+				ClusterName: clusterName,
+				Output:      g.output,
+			},
+			Details: g.details,
+		}
+		if err := RunGetInstanceGroups(ctx, factory, &stdout, options); err != nil {
+			t.Fatalf("-o %s (details=%v): unexpected error: %v", g.output, g.details, err)
+		}
+		for _, s := range g.expectedContains {
+			if !strings.Contains(stdout.String(), s) {
+				t.Errorf("-o %s (details=%v): expected output to contain %q, got:\n%s", g.output, g.details, s, stdout.String())
+			}
+		}
+		for _, s := range g.expectedAbsent {
+			if strings.Contains(stdout.String(), s) {
+				t.Errorf("-o %s (details=%v): expected output not to contain %q, got:\n%s", g.output, g.details, s, stdout.String())
+			}
+		}
+	}
+}
+
+func TestCollectInstanceGroupDetailsStaleImage(t *testing.T) {
+	cloud := testutils.NewIntegrationTestHarness(t).SetupMockAWS()
+	createMockAutoscalingGroup(t, cloud, "nodes.test.k8s.io", 3)
+
+	cluster := testutils.BuildMinimalCluster("test.k8s.io")
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	// The harness image was created on 2022-04-04
+	nodes.Spec.Image = "ami-12345678"
+	nodes.Spec.WarmPool = &kops.WarmPoolSpec{MinSize: 1, ReuseOnScaleIn: fi.PtrTo(true)}
+	bastion := testutils.BuildMinimalBastionInstanceGroup("bastion", "subnet-us-test-1a")
+	bastion.Spec.Image = "ami-12345678"
+
+	now := time.Date(2023, 4, 4, 12, 0, 0, 0, time.UTC)
+	details, err := collectInstanceGroupDetails(cluster, cloud, []*kops.InstanceGroup{&nodes, &bastion}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"nodes", "bastion"} {
+		d := details[name]
+		if d == nil {
+			t.Fatalf("expected details for instance group %q", name)
+		}
+		if age := fi.ValueOf(d.ImageAgeDays); d.ImageAgeDays == nil || age != 365 {
+			t.Errorf("expected image of %q to be 365 days old, got %v", name, d.ImageAgeDays)
+		}
+		if d.Pricing != "on-demand" {
+			t.Errorf("expected %q to be on-demand, got %q", name, d.Pricing)
+		}
+	}
+
+	if cg := details["nodes"].CloudGroup; cg == nil || cg.TargetSize != 3 {
+		t.Errorf("expected the cloud group of nodes to have a target size of 3, got %v", cg)
+	}
+	if cg := details["bastion"].CloudGroup; cg != nil {
+		t.Errorf("expected bastion to have no cloud group, got %v", cg)
+	}
+
+	if warmPool := formatWarmPool(details["nodes"].WarmPool); warmPool != "min=1,max=default,reuse" {
+		t.Errorf("unexpected warm pool of nodes: %q", warmPool)
+	}
+	if warmPool := details["bastion"].WarmPool; warmPool != nil {
+		t.Errorf("expected bastion to have no warm pool, got %v", warmPool)
+	}
+}
+
+func TestInstanceGroupOutputTableNeedsUpdate(t *testing.T) {
+	cluster := testutils.BuildMinimalCluster("test.k8s.io")
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	nodes.Spec.MachineType = "t2.medium"
+
+	cg := &cloudinstances.CloudInstanceGroup{
+		HumanName:     "nodes.test.k8s.io",
+		InstanceGroup: &nodes,
+		TargetSize:    3,
+	}
+	for i, status := range []string{cloudinstances.CloudInstanceStatusUpToDate, cloudinstances.CloudInstanceStatusNeedsUpdate} {
+		if _, err := cg.NewCloudInstance("i-"+string(rune('a'+i)), status, nil); err != nil {
+			t.Fatalf("error creating cloud instance: %v", err)
+		}
+	}
+
+	details := map[string]*instanceGroupDetails{
+		"nodes": {
+			Pricing:      "mixed",
+			ImageAgeDays: fi.PtrTo(400),
+			CloudGroup:   cg,
+		},
+	}
+
+	var stdout bytes.Buffer
+	if err := igOutputTable(cluster, []*kops.InstanceGroup{&nodes}, details, &stdout); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and a single row, got:\n%s", stdout.String())
+	}
+	expected := []string{"nodes", "Node", "t2.medium", "mixed", "400d", "true", "2/3"}
+	actual := strings.Fields(lines[1])
+	for _, e := range expected {
+		found := false
+		for _, a := range actual {
+			if a == e {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected row to contain %q, got %q", e, lines[1])
+		}
+	}
+
+	renderable := asRenderableInstanceGroups([]*kops.InstanceGroup{&nodes}, details)
+	if !renderable[0].NeedsUpdate || renderable[0].CurrentInstances != 2 || fi.ValueOf(renderable[0].DesiredInstances) != 3 {
+		t.Errorf("unexpected renderable instance group %+v", renderable[0])
+	}
+}
+
+func TestInstanceGroupPricing(t *testing.T) {
+	grid := []struct {
+		name     string
+		spec     kops.InstanceGroupSpec
+		expected string
+	}{
+		{
+			name:     "on-demand",
+			expected: "on-demand",
+		},
+		{
+			name:     "max price",
+			spec:     kops.InstanceGroupSpec{MaxPrice: fi.PtrTo("0.1")},
+			expected: "spot",
+		},
+		{
+			name:     "gcp spot",
+			spec:     kops.InstanceGroupSpec{GCPProvisioningModel: fi.PtrTo("SPOT")},
+			expected: "spot",
+		},
+		{
+			name:     "mixed instances policy defaults",
+			spec:     kops.InstanceGroupSpec{MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{}},
+			expected: "on-demand",
+		},
+		{
+			name:     "mixed instances policy all spot",
+			spec:     kops.InstanceGroupSpec{MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{OnDemandAboveBase: fi.PtrTo(int64(0))}},
+			expected: "spot",
+		},
+		{
+			name:     "mixed instances policy with on-demand base",
+			spec:     kops.InstanceGroupSpec{MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{OnDemandBase: fi.PtrTo(int64(1)), OnDemandAboveBase: fi.PtrTo(int64(0))}},
+			expected: "mixed",
+		},
+		{
+			name:     "mixed instances policy split",
+			spec:     kops.InstanceGroupSpec{MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{OnDemandAboveBase: fi.PtrTo(int64(20))}},
+			expected: "mixed",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual := instanceGroupPricing(&kops.InstanceGroup{Spec: g.spec})
+			if actual != g.expected {
+				t.Errorf("expected %q, got %q", g.expected, actual)
+			}
+		})
+	}
+}
+
+// createMockAutoscalingGroup creates an autoscaling group with a launch template in the mock cloud
+func createMockAutoscalingGroup(t *testing.T, cloud *awsup.MockAWSCloud, name string, desiredCapacity int64) {
+	t.Helper()
+
+	lt, err := cloud.EC2().CreateLaunchTemplate(&ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+		LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+			ImageId:      aws.String("ami-12345678"),
+			InstanceType: aws.String("t2.medium"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating launch template: %v", err)
+	}
+
+	_, err = cloud.Autoscaling().CreateAutoScalingGroupWithContext(context.Background(), &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		DesiredCapacity:      aws.Int64(desiredCapacity),
+		MinSize:              aws.Int64(desiredCapacity),
+		MaxSize:              aws.Int64(desiredCapacity),
+		LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateId: lt.LaunchTemplate.LaunchTemplateId,
+			Version:          aws.String("1"),
+		},
+		Tags: []*autoscaling.Tag{
+			{
+				Key:          aws.String(awsup.TagClusterName),
+				Value:        aws.String(strings.SplitN(name, ".", 2)[1]),
+				ResourceId:   aws.String(name),
+				ResourceType: aws.String("auto-scaling-group"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating autoscaling group: %v", err)
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -42,7 +44,10 @@ var (
 	kops get keypairs kubernetes-ca
 
 	# List the service-account keypairs, including distrusted ones.
-	kops get keypairs service-account --distrusted`))
+	kops get keypairs service-account --distrusted
+
+	# List all keypairs as JSON, including their expiry and the components that use them.
+	kops get keypairs -o json`))
 
 	getKeypairShort = i18n.T(`Get one or many keypairs.`)
 )
@@ -97,67 +102,55 @@ type keypairItem struct {
 	NotAfter          *time.Time `json:"notAfter,omitempty"`
 	KeyLength         *int       `json:"keyLength,omitempty"`
 	HasPrivateKey     bool       `json:"hasPrivateKey,omitempty"`
+	UsedBy            []string   `json:"usedBy,omitempty"`
 }
 
 func listKeypairs(keyStore fi.CAStore, names []string, includeDistrusted bool) ([]*keypairItem, error) {
 	var items []*keypairItem
 
-	l, err := keyStore.ListKeysets()
+	l, err := fi.ListKeypairMetadata(keyStore)
 	if err != nil {
-		return nil, fmt.Errorf("error listing Keysets: %v", err)
+		return nil, err
 	}
 
-	for name, keyset := range l {
-		if len(names) != 0 {
-			found := false
-			for _, n := range names {
-				if n == name {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+	for _, item := range l {
+		if len(names) != 0 && !slices.Contains(names, item.Keyset) {
+			continue
 		}
 
-		for _, item := range keyset.Items {
-			if includeDistrusted || (item.DistrustTimestamp == nil && item.Certificate != nil) {
-				keypair := keypairItem{
-					Name:              name,
-					ID:                item.Id,
-					DistrustTimestamp: item.DistrustTimestamp,
-					IsPrimary:         item.Id == keyset.Primary.Id,
-					HasPrivateKey:     item.PrivateKey != nil,
+		if includeDistrusted || (item.DistrustTimestamp == nil && item.Certificate != nil) {
+			keypair := keypairItem{
+				Name:              item.Keyset,
+				ID:                item.Id,
+				DistrustTimestamp: item.DistrustTimestamp,
+				IsPrimary:         item.IsPrimary,
+				HasPrivateKey:     item.HasPrivateKey,
+				UsedBy:            item.UsedBy,
+			}
+			if cert := item.Certificate; cert != nil {
+				keypair.Subject = cert.Subject.String()
+				keypair.Issuer = cert.Certificate.Issuer.String()
+				keypair.IsCA = cert.IsCA
+				{
+					t := cert.Certificate.NotBefore.UTC()
+					keypair.NotBefore = &t
 				}
-				if cert := item.Certificate; cert != nil {
-					keypair.Subject = cert.Subject.String()
-					keypair.Issuer = cert.Certificate.Issuer.String()
-					keypair.IsCA = cert.IsCA
-					{
-						t := cert.Certificate.NotBefore.UTC()
-						keypair.NotBefore = &t
-					}
-					{
-						t := cert.Certificate.NotAfter.UTC()
-						keypair.NotAfter = &t
-					}
-					{
-						var alternateNames []string
-						alternateNames = append(alternateNames, item.Certificate.Certificate.DNSNames...)
-						alternateNames = append(alternateNames, item.Certificate.Certificate.EmailAddresses...)
-						for _, ip := range item.Certificate.Certificate.IPAddresses {
-							alternateNames = append(alternateNames, ip.String())
-						}
-						sort.Strings(alternateNames)
-						keypair.AlternateNames = alternateNames
-					}
-					if rsaKey, ok := cert.PublicKey.(*rsa.PublicKey); ok {
-						keypair.KeyLength = fi.PtrTo(rsaKey.N.BitLen())
+				keypair.NotAfter = item.NotAfter()
+				{
+					var alternateNames []string
+					alternateNames = append(alternateNames, cert.Certificate.DNSNames...)
+					alternateNames = append(alternateNames, cert.Certificate.EmailAddresses...)
+					for _, ip := range cert.Certificate.IPAddresses {
+						alternateNames = append(alternateNames, ip.String())
 					}
+					sort.Strings(alternateNames)
+					keypair.AlternateNames = alternateNames
+				}
+				if rsaKey, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+					keypair.KeyLength = fi.PtrTo(rsaKey.N.BitLen())
 				}
-				items = append(items, &keypair)
 			}
+			items = append(items, &keypair)
 		}
 	}
 
@@ -228,11 +221,14 @@ func RunGetKeypairs(ctx context.Context, f commandutils.Factory, out io.Writer,
 			}
 			return ""
 		})
+		t.AddColumn("USEDBY", func(i *keypairItem) string {
+			return strings.Join(i.UsedBy, ",")
+		})
 		columnNames := []string{"NAME", "ID", "ISSUED", "EXPIRES"}
 		if options.Distrusted {
 			columnNames = append(columnNames, "DISTRUSTED")
 		}
-		columnNames = append(columnNames, "PRIMARY", "HASPRIVATE")
+		columnNames = append(columnNames, "PRIMARY", "HASPRIVATE", "USEDBY")
 		return t.Render(items, out, columnNames...)
 
 	case OutputYaml:
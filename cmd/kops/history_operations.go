@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/operationlog"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	historyOperationsLong = templates.LongDesc(i18n.T(`
+	List the operations that were run against a cluster.
+
+	kops update cluster, rolling-update cluster, edit, replace, delete instancegroup,
+	delete instance and failed runs of delete cluster record who ran them, their flags
+	(with secrets redacted) and their result in the state store.
+
+	Operations older than KOPS_OPERATIONS_RETENTION (default 8760h) are pruned
+	whenever a new operation is recorded. Set it to 0 to keep operations forever.
+	`))
+
+	historyOperationsExample = templates.Examples(i18n.T(`
+	# List the operations run against a cluster
+	kops history operations k8s-cluster.example.com
+
+	# List the failed rolling updates of the last week
+	kops history operations k8s-cluster.example.com --command "rolling-update" --result Failed --since 168h
+
+	# Remove the operations older than 30 days
+	kops history operations k8s-cluster.example.com --prune-older-than 720h
+	`))
+
+	historyOperationsShort = i18n.T(`List the operations run against a cluster.`)
+)
+
+type HistoryOperationsOptions struct {
+	ClusterName string
+	Output      string
+
+	// Command only lists operations whose command contains this string.
+	Command string
+	// Identity only lists operations run by an identity containing this string.
+	Identity string
+	// Result only lists operations with this result.
+	Result string
+	// Since only lists operations more recent than this.
+	Since time.Duration
+
+	// PruneOlderThan removes the operations older than this before listing.
+	PruneOlderThan time.Duration
+}
+
+func NewCmdHistoryOperations(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &HistoryOperationsOptions{
+		Output: OutputTable,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "operations [CLUSTER]",
+		Aliases:           []string{"operation", "ops"},
+		Short:             historyOperationsShort,
+		Long:              historyOperationsLong,
+		Example:           historyOperationsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunHistoryOperations(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "output format. One of: table, yaml, json")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&options.Command, "command", options.Command, "Only list operations whose command contains this string")
+	cmd.Flags().StringVar(&options.Identity, "identity", options.Identity, "Only list operations run by an identity containing this string")
+	cmd.Flags().StringVar(&options.Result, "result", options.Result, "Only list operations with this result. One of: "+operationlog.ResultSucceeded+", "+operationlog.ResultFailed)
+	cmd.RegisterFlagCompletionFunc("result", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{operationlog.ResultSucceeded, operationlog.ResultFailed}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().DurationVar(&options.Since, "since", options.Since, "Only list operations more recent than this duration")
+	cmd.Flags().DurationVar(&options.PruneOlderThan, "prune-older-than", options.PruneOlderThan, "Remove the operations older than this duration before listing")
+
+	return cmd
+}
+
+func RunHistoryOperations(ctx context.Context, f commandutils.Factory, out io.Writer, options *HistoryOperationsOptions) error {
+	switch options.Result {
+	case "", operationlog.ResultSucceeded, operationlog.ResultFailed:
+	default:
+		return fmt.Errorf("unknown result %q, expected %s or %s", options.Result, operationlog.ResultSucceeded, operationlog.ResultFailed)
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+	basePath, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return fmt.Errorf("finding cluster state path: %w", err)
+	}
+
+	now := time.Now()
+
+	if options.PruneOlderThan > 0 {
+		removed, err := operationlog.Prune(ctx, basePath, now.Add(-options.PruneOlderThan))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Removed %d operations older than %v\n\n", removed, options.PruneOlderThan)
+	}
+
+	all, err := operationlog.List(ctx, basePath)
+	if err != nil {
+		return err
+	}
+
+	var operations []*operationlog.Operation
+	for _, op := range all {
+		if options.Command != "" && !strings.Contains(op.Command, options.Command) {
+			continue
+		}
+		if options.Identity != "" && !strings.Contains(op.Identity, options.Identity) {
+			continue
+		}
+		if options.Result != "" && op.Result != options.Result {
+			continue
+		}
+		if options.Since > 0 && op.Timestamp.Before(now.Add(-options.Since)) {
+			continue
+		}
+		operations = append(operations, op)
+	}
+
+	switch options.Output {
+	case OutputTable:
+		if len(operations) == 0 {
+			fmt.Fprintf(out, "No operations found\n")
+			return nil
+		}
+		t := &tables.Table{}
+		t.AddColumn("TIME", func(op *operationlog.Operation) string {
+			return op.Timestamp.UTC().Format(time.RFC3339)
+		})
+		t.AddColumn("IDENTITY", func(op *operationlog.Operation) string {
+			return op.Identity
+		})
+		t.AddColumn("COMMAND", func(op *operationlog.Operation) string {
+			return strings.Join(append([]string{op.Command}, op.Args...), " ")
+		})
+		t.AddColumn("FLAGS", func(op *operationlog.Operation) string {
+			var flags []string
+			for k, v := range op.Flags {
+				flags = append(flags, "--"+k+"="+v)
+			}
+			sort.Strings(flags)
+			return strings.Join(flags, " ")
+		})
+		t.AddColumn("RESULT", func(op *operationlog.Operation) string {
+			return op.Result
+		})
+		return t.Render(operations, out, "TIME", "IDENTITY", "COMMAND", "FLAGS", "RESULT")
+
+	case OutputYaml:
+		y, err := yaml.Marshal(operations)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	case OutputJSON:
+		j, err := json.Marshal(operations)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+
+	return nil
+}
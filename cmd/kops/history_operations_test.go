@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/operationlog"
+	"k8s.io/kops/pkg/testutils"
+)
+
+func TestHistoryOperations(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "test.k8s.io"
+
+	factoryOptions := &util.FactoryOptions{}
+	factoryOptions.RegistryPath = "memfs://history-operations"
+	factory := util.NewFactory(factoryOptions)
+
+	clientSet, err := factory.KopsClient()
+	if err != nil {
+		t.Fatalf("could not create clientset: %v", err)
+	}
+	cluster := testutils.BuildMinimalCluster(clusterName)
+	created, err := clientSet.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("could not create cluster: %v", err)
+	}
+	basePath, err := clientSet.ConfigBaseFor(created)
+	if err != nil {
+		t.Fatalf("could not get config base: %v", err)
+	}
+
+	now := time.Now().UTC()
+	for _, op := range []*operationlog.Operation{
+		{Timestamp: now.Add(-50 * time.Hour), Identity: "arn:aws:iam::123456789012:user/alice", Command: "kops edit cluster", Args: []string{clusterName}, Result: operationlog.ResultSucceeded},
+		{Timestamp: now.Add(-2 * time.Hour), Identity: "arn:aws:iam::123456789012:user/bob", Command: "kops rolling-update cluster", Flags: map[string]string{"yes": "true"}, Result: operationlog.ResultFailed, Error: "validation failed"},
+		{Timestamp: now.Add(-1 * time.Hour), Identity: "arn:aws:iam::123456789012:user/alice", Command: "kops update cluster", Flags: map[string]string{"yes": "true"}, Result: operationlog.ResultSucceeded},
+	} {
+		if err := operationlog.Record(ctx, basePath, nil, op); err != nil {
+			t.Fatalf("could not record operation: %v", err)
+		}
+	}
+
+	grid := []struct {
+		name     string
+		options  HistoryOperationsOptions
+		expected []string
+	}{
+		{
+			name:     "all",
+			expected: []string{"kops edit cluster", "kops rolling-update cluster", "kops update cluster"},
+		},
+		{
+			name:     "command",
+			options:  HistoryOperationsOptions{Command: "update"},
+			expected: []string{"kops rolling-update cluster", "kops update cluster"},
+		},
+		{
+			name:     "identity",
+			options:  HistoryOperationsOptions{Identity: "user/alice"},
+			expected: []string{"kops edit cluster", "kops update cluster"},
+		},
+		{
+			name:     "result",
+			options:  HistoryOperationsOptions{Result: operationlog.ResultFailed},
+			expected: []string{"kops rolling-update cluster"},
+		},
+		{
+			name:     "since",
+			options:  HistoryOperationsOptions{Since: 24 * time.Hour},
+			expected: []string{"kops rolling-update cluster", "kops update cluster"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			options := g.options
+			options.ClusterName = clusterName
+			options.Output = OutputJSON
+			if err := RunHistoryOperations(ctx, factory, &stdout, &options); err != nil {
+				t.Fatalf("could not list operations: %v", err)
+			}
+
+			var operations []*operationlog.Operation
+			if err := json.Unmarshal(stdout.Bytes(), &operations); err != nil {
+				t.Fatalf("could not parse output %q: %v", stdout.String(), err)
+			}
+			var commands []string
+			for _, op := range operations {
+				commands = append(commands, op.Command)
+			}
+			if strings.Join(commands, ",") != strings.Join(g.expected, ",") {
+				t.Errorf("expected %v, got %v", g.expected, commands)
+			}
+		})
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var stdout bytes.Buffer
+		options := &HistoryOperationsOptions{ClusterName: clusterName, Output: OutputTable, Result: operationlog.ResultFailed}
+		if err := RunHistoryOperations(ctx, factory, &stdout, options); err != nil {
+			t.Fatalf("could not list operations: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected a header and one operation, got %q", stdout.String())
+		}
+		for _, s := range []string{"user/bob", "kops rolling-update cluster", "--yes=true", operationlog.ResultFailed} {
+			if !strings.Contains(lines[1], s) {
+				t.Errorf("expected %q in %q", s, lines[1])
+			}
+		}
+	})
+
+	t.Run("invalid result", func(t *testing.T) {
+		var stdout bytes.Buffer
+		options := &HistoryOperationsOptions{ClusterName: clusterName, Output: OutputTable, Result: "Unknown"}
+		if err := RunHistoryOperations(ctx, factory, &stdout, options); err == nil {
+			t.Errorf("expected an error for an unknown result")
+		}
+	})
+}
@@ -47,6 +47,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
 // updateClusterTestBase is added automatically to the srcDir on all
@@ -1285,10 +1286,10 @@ func (i *integrationTest) runTest(t *testing.T, ctx context.Context, h *testutil
 		sort.Strings(fileNames)
 
 		actualFilenames := strings.Join(fileNames, ",")
-		expectedFilenames := actualTFPath
+		expectedFilenames := terraform.ManifestFileName + "," + actualTFPath
 
 		if len(expectedDataFilenames) > 0 {
-			expectedFilenames = "data," + actualTFPath
+			expectedFilenames = "data," + expectedFilenames
 		}
 
 		if actualFilenames != expectedFilenames {
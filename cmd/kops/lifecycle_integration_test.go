@@ -33,6 +33,7 @@ import (
 	gcemock "k8s.io/kops/cloudmock/gce"
 	"k8s.io/kops/cmd/kops/util"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/testutils"
 	"k8s.io/kops/upup/pkg/fi"
@@ -222,7 +223,7 @@ func runLifecycleTest(h *testutils.IntegrationTestHarness, o *LifecycleTestOptio
 				t.Fatalf("error setting cluster fields: %v", err)
 			}
 
-			if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+			if err := commands.UpdateCluster(ctx, clientset, &stdout, cluster, instanceGroups, simple.UpdateClusterOptions{}); err != nil {
 				t.Fatalf("error updating cluster: %v", err)
 			}
 			updateEnsureNoChanges(ctx, t, factory, o.ClusterName, stdout)
@@ -260,7 +261,7 @@ func runLifecycleTest(h *testutils.IntegrationTestHarness, o *LifecycleTestOptio
 					t.Fatalf("error applying overrides: %v", err)
 				}
 
-				err = commands.UpdateInstanceGroup(ctx, clientset, cluster, instanceGroups, instanceGroupToUpdate)
+				err = commands.UpdateInstanceGroup(ctx, clientset, &stdout, cluster, instanceGroups, instanceGroupToUpdate)
 				if err != nil {
 					t.Fatalf("error updating instance groups: %v", err)
 				}
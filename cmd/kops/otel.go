@@ -33,6 +33,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 
 	"k8s.io/kops/pkg/otel/otlptracefile"
+	"k8s.io/kops/pkg/otel/otlptracehttp"
 )
 
 // setupOTelSDK bootstraps the OpenTelemetry pipeline.
@@ -91,6 +92,42 @@ func newResource(serviceName, serviceVersion string) (*resource.Resource, error)
 }
 
 func newTraceProvider(ctx context.Context, res *resource.Resource) (*trace.TracerProvider, error) {
+	var exporters []trace.SpanExporter
+
+	fileExporter, err := newFileTraceExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fileExporter != nil {
+		exporters = append(exporters, fileExporter)
+	}
+
+	if endpoint := os.Getenv("KOPS_OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		endpointExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("building OTLP exporter for %q: %w", endpoint, err)
+		}
+		exporters = append(exporters, endpointExporter)
+	}
+
+	// Without any exporter we leave the default no-op tracer provider in place
+	if len(exporters) == 0 {
+		return nil, nil
+	}
+
+	options := []trace.TracerProviderOption{
+		trace.WithResource(res),
+	}
+	for _, exporter := range exporters {
+		options = append(options, trace.WithBatcher(exporter,
+			// Default is 5s. Set to 1s for demonstrative purposes.
+			trace.WithBatchTimeout(time.Second)))
+	}
+	return trace.NewTracerProvider(options...), nil
+}
+
+// newFileTraceExporter returns an exporter writing to the file or directory configured in the environment, if any.
+func newFileTraceExporter(ctx context.Context) (trace.SpanExporter, error) {
 	destIsDirectory := false
 
 	dest := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_FILE")
@@ -130,16 +167,5 @@ func newTraceProvider(ctx context.Context, res *resource.Resource) (*trace.Trace
 		dest = filepath.Join(dest, filename)
 	}
 
-	traceExporter, err := otlptracefile.New(ctx, otlptracefile.WithPath(dest))
-	if err != nil {
-		return nil, err
-	}
-
-	traceProvider := trace.NewTracerProvider(
-		trace.WithBatcher(traceExporter,
-			// Default is 5s. Set to 1s for demonstrative purposes.
-			trace.WithBatchTimeout(time.Second)),
-		trace.WithResource(res),
-	)
-	return traceProvider, nil
+	return otlptracefile.New(ctx, otlptracefile.WithPath(dest))
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/operationlog"
+)
+
+// recordOperation records a run of cmd against the cluster in the operations log of the cluster.
+// Failing to record the operation only warns, so that it never fails the command itself.
+func recordOperation(ctx context.Context, f commandutils.Factory, cmd *cobra.Command, args []string, clusterName string, runErr error) {
+	if err := writeOperation(ctx, f, cmd, args, clusterName, runErr); err != nil {
+		klog.Warningf("failed to record the operation in the operations log of cluster %q: %v", clusterName, err)
+	}
+}
+
+func writeOperation(ctx context.Context, f commandutils.Factory, cmd *cobra.Command, args []string, clusterName string, runErr error) error {
+	if clusterName == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+	cluster, err := clientset.GetCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("reading cluster: %w", err)
+	}
+	basePath, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return fmt.Errorf("finding cluster state path: %w", err)
+	}
+	acl, err := acls.GetACL(ctx, basePath.Join(operationlog.PathOperations), cluster)
+	if err != nil {
+		return err
+	}
+
+	op := operationlog.NewOperation(cmd, args, operationlog.CallerIdentity(ctx, cluster), runErr)
+	return operationlog.Record(ctx, basePath, acl, op)
+}
@@ -24,9 +24,12 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/validation"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/util/pkg/text"
@@ -58,6 +61,11 @@ type ReplaceOptions struct {
 	Filenames []string
 	// Force causes any missing rescources to be created.
 	Force bool
+	// AllowKubernetesVersionSkip allows upgrading the Kubernetes version by more than one minor version.
+	AllowKubernetesVersionSkip bool
+
+	// clusterNames collects the clusters whose resources were replaced, if set.
+	clusterNames sets.Set[string]
 }
 
 // NewCmdReplace returns a new replace command
@@ -72,12 +80,18 @@ func NewCmdReplace(f *util.Factory, out io.Writer) *cobra.Command {
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunReplace(cmd.Context(), f, out, options)
+			options.clusterNames = sets.New[string]()
+			err := RunReplace(cmd.Context(), f, out, options)
+			for _, clusterName := range sets.List(options.clusterNames) {
+				recordOperation(cmd.Context(), f, cmd, args, clusterName, err)
+			}
+			return err
 		},
 	}
 	cmd.Flags().StringSliceVarP(&options.Filenames, "filename", "f", options.Filenames, "A list of one or more files separated by a comma.")
 	cmd.MarkFlagRequired("filename")
 	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "Force any changes, which will also create any non-existing resource")
+	cmd.Flags().BoolVar(&options.AllowKubernetesVersionSkip, "allow-kubernetes-version-skip", false, "Allow upgrading the Kubernetes version by more than one minor version")
 
 	return cmd
 }
@@ -127,6 +141,7 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 
 					// Check if the cluster exists already
 					clusterName := v.Name
+					c.recordCluster(clusterName)
 					cluster, err := clientset.GetCluster(ctx, clusterName)
 					if err != nil {
 						if errors.IsNotFound(err) {
@@ -150,11 +165,15 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 							return fmt.Errorf("error creating cluster: %v", err)
 						}
 					} else {
-						_, err = clientset.UpdateCluster(ctx, v, status)
+						_, err = clientset.UpdateCluster(ctx, v, status, simple.UpdateClusterOptions{AllowKubernetesVersionSkip: c.AllowKubernetesVersionSkip})
 						if err != nil {
 							return fmt.Errorf("error replacing cluster: %v", err)
 						}
+						validation.PrintWarnings(out, validation.ClusterUpdateWarnings(v, cluster))
 					}
+
+					// The clientset only returns validation errors, so we report the warnings here
+					validation.PrintWarnings(out, validation.ValidateClusterWithWarnings(v, false, vfsContext).Warnings)
 				}
 
 			case *kopsapi.InstanceGroup:
@@ -162,6 +181,7 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 				if clusterName == "" {
 					return fmt.Errorf("must specify %q label with cluster name to replace instanceGroup", kopsapi.LabelClusterName)
 				}
+				c.recordCluster(clusterName)
 				cluster, err := clientset.GetCluster(ctx, clusterName)
 				if err != nil {
 					if errors.IsNotFound(err) {
@@ -194,11 +214,13 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 						return fmt.Errorf("error replacing instanceGroup: %v", err)
 					}
 				}
+				validation.PrintWarnings(out, validation.CrossValidateInstanceGroupWithWarnings(v, cluster, nil, false).Warnings)
 			case *kopsapi.SSHCredential:
 				clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
 				if clusterName == "" {
 					return fmt.Errorf("must specify %q label with cluster name to replace SSHCredential", kopsapi.LabelClusterName)
 				}
+				c.recordCluster(clusterName)
 				if v.Spec.PublicKey == "" {
 					return fmt.Errorf("spec.PublicKey is required")
 				}
@@ -227,3 +249,9 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 
 	return nil
 }
+
+func (c *ReplaceOptions) recordCluster(clusterName string) {
+	if c.clusterNames != nil {
+		c.clusterNames.Insert(clusterName)
+	}
+}
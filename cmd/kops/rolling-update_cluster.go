@@ -35,11 +35,14 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/instancegroups"
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/pretty"
 	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/util/pkg/tables"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -89,6 +92,11 @@ var (
 		# Update only the "nodes-1a" instance group of the k8s-cluster.example.com kOps cluster.
 		kops rolling-update cluster k8s-cluster.example.com --yes \
 		  --instance-group nodes-1a
+
+		# Reboot the nodes of the k8s-cluster.example.com kOps cluster
+		# that are annotated with kops.k8s.io/reboot-required, without replacing them.
+		kops rolling-update cluster k8s-cluster.example.com --yes \
+		  --reboot-only
 		`))
 
 	rollingupdateShort = i18n.T(`Rolling update a cluster.`)
@@ -111,8 +119,13 @@ type RollingUpdateOptions struct {
 	FailOnValidate bool
 
 	// DrainTimeout is the maximum time to wait while draining a node.
+	// If not set, the drainTimeout of the instance groups is used.
 	DrainTimeout time.Duration
 
+	// JoinTimeout is the maximum time to wait for a new instance to register as a node.
+	// If not set, the joinTimeout of the instance groups is used.
+	JoinTimeout time.Duration
+
 	// PostDrainDelay is the duration of a pause after a drain operation
 	PostDrainDelay time.Duration
 
@@ -164,8 +177,6 @@ func (o *RollingUpdateOptions) InitDefaults() {
 	o.ValidationTimeout = 15 * time.Minute
 	o.ValidateCount = 2
 
-	o.DrainTimeout = 15 * time.Minute
-
 	o.RollingUpdateOptions.InitDefaults()
 }
 
@@ -181,7 +192,9 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
 		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunRollingUpdateCluster(cmd.Context(), f, out, &options)
+			err := RunRollingUpdateCluster(cmd.Context(), f, out, &options)
+			recordOperation(cmd.Context(), f, cmd, args, options.ClusterName, err)
+			return err
 		},
 	}
 
@@ -195,7 +208,12 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Perform rolling update without validating cluster status (will cause downtime)")
 
 	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for a cluster to validate")
-	cmd.Flags().DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "Maximum time to wait for a node to drain")
+	cmd.Flags().DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "Maximum time to wait for a node to drain, overriding the drainTimeout of the instance groups (defaults to 15m)")
+	cmd.Flags().StringVar((*string)(&options.DrainTimeoutPolicy), "drain-timeout-policy", string(options.DrainTimeoutPolicy), "Action when a node does not drain within the drain timeout (Fail, Skip or Force), overriding the drainTimeoutPolicy of the instance groups (defaults to Fail)")
+	cmd.RegisterFlagCompletionFunc("drain-timeout-policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(kopsapi.RollingUpdateDrainTimeoutPolicyFail), string(kopsapi.RollingUpdateDrainTimeoutPolicySkip), string(kopsapi.RollingUpdateDrainTimeoutPolicyForce)}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().DurationVar(&options.JoinTimeout, "join-timeout", options.JoinTimeout, "Maximum time to wait for a new instance to register as a node before terminating it, overriding the joinTimeout of the instance groups (AWS only)")
 	cmd.Flags().Int32Var(&options.ValidateCount, "validate-count", options.ValidateCount, "Number of times that a cluster needs to be validated after single node update")
 	cmd.Flags().DurationVar(&options.ControlPlaneInterval, "master-interval", options.ControlPlaneInterval, "Time to wait between restarting control plane nodes")
 	cmd.Flags().MarkDeprecated("master-interval", "use --control-plane-interval instead")
@@ -214,6 +232,9 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVar(&options.FailOnDrainError, "fail-on-drain-error", true, "Fail if draining a node fails")
 	cmd.Flags().BoolVar(&options.FailOnValidate, "fail-on-validate-error", true, "Fail if the cluster fails to validate")
 
+	cmd.Flags().BoolVar(&options.RebootOnly, "reboot-only", options.RebootOnly, "Reboot instances instead of replacing them. Only nodes annotated with "+instancegroups.RebootRequiredAnnotation+" are rebooted, unless --force is set (AWS only)")
+	cmd.Flags().BoolVar(&options.RebootViaSSM, "reboot-via-ssm", options.RebootViaSSM, "With --reboot-only, reboot instances from within the OS using an SSM command instead of through the EC2 API")
+
 	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		switch name {
 		case "ig", "instance-groups":
@@ -238,6 +259,18 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		return err
 	}
 
+	if options.RebootViaSSM && !options.RebootOnly {
+		return fmt.Errorf("--reboot-via-ssm can only be used with --reboot-only")
+	}
+	if options.RebootOnly && cluster.Spec.GetCloudProvider() != kopsapi.CloudProviderAWS {
+		return fmt.Errorf("--reboot-only is only supported on AWS")
+	}
+	switch options.DrainTimeoutPolicy {
+	case "", kopsapi.RollingUpdateDrainTimeoutPolicyFail, kopsapi.RollingUpdateDrainTimeoutPolicySkip, kopsapi.RollingUpdateDrainTimeoutPolicyForce:
+	default:
+		return fmt.Errorf("unsupported --drain-timeout-policy %q, must be one of Fail, Skip or Force", options.DrainTimeoutPolicy)
+	}
+
 	contextName := cluster.ObjectMeta.Name
 	clientGetter := genericclioptions.NewConfigFlags(true)
 	clientGetter.Context = &contextName
@@ -289,6 +322,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		options.DeregisterControlPlaneNodes = false
 	}
 
+	allInstanceGroups := instanceGroups
 	warnUnmatched := true
 
 	if len(options.InstanceGroups) != 0 {
@@ -365,6 +399,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		ValidationTimeout: options.ValidationTimeout,
 		ValidateCount:     int(options.ValidateCount),
 		DrainTimeout:      options.DrainTimeout,
+		JoinTimeout:       options.JoinTimeout,
 		// TODO should we expose this to the UI?
 		ValidateTickDuration:    30 * time.Second,
 		ValidateSuccessDuration: 10 * time.Second,
@@ -378,6 +413,12 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		return err
 	}
 
+	if !options.RebootOnly && (len(options.InstanceGroups) != 0 || len(options.InstanceGroupRoles) != 0) {
+		if err := checkKubeletCARotationOrder(ctx, clientset, cluster, cloud, allInstanceGroups, groups, nodes); err != nil {
+			return err
+		}
+	}
+
 	{
 		t := &tables.Table{}
 		t.AddColumn("NAME", func(r *cloudinstances.CloudInstanceGroup) string {
@@ -389,6 +430,9 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		t.AddColumn("NEEDUPDATE", func(r *cloudinstances.CloudInstanceGroup) string {
 			return strconv.Itoa(len(r.NeedUpdate))
 		})
+		t.AddColumn("NEEDREBOOT", func(r *cloudinstances.CloudInstanceGroup) string {
+			return strconv.Itoa(len(instancegroups.InstancesNeedingReboot(r, false)))
+		})
 		t.AddColumn("READY", func(r *cloudinstances.CloudInstanceGroup) string {
 			return strconv.Itoa(len(r.Ready))
 		})
@@ -421,6 +465,9 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		}
 
 		columns := []string{"NAME", "STATUS", "NEEDUPDATE", "READY", "MIN", "TARGET", "MAX"}
+		if options.RebootOnly {
+			columns = []string{"NAME", "NEEDREBOOT", "MIN", "TARGET", "MAX"}
+		}
 		if !options.CloudOnly {
 			columns = append(columns, "NODES")
 		}
@@ -432,13 +479,21 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 
 	needUpdate := false
 	for _, group := range groups {
-		if len(group.NeedUpdate) != 0 {
+		if options.RebootOnly {
+			if len(instancegroups.InstancesNeedingReboot(group, false)) != 0 {
+				needUpdate = true
+			}
+		} else if len(group.NeedUpdate) != 0 {
 			needUpdate = true
 		}
 	}
 
 	if !needUpdate && !options.Force {
-		fmt.Printf("\nNo rolling-update required.\n")
+		if options.RebootOnly {
+			fmt.Printf("\nNo reboot required.\n")
+		} else {
+			fmt.Printf("\nNo rolling-update required.\n")
+		}
 		return nil
 	}
 
@@ -459,6 +514,38 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 	return d.RollingUpdate(groups, list)
 }
 
+// checkKubeletCARotationOrder refuses to update instances outside the control plane
+// before the control plane while a kubelet-ca rotation is in progress.
+func checkKubeletCARotationOrder(ctx context.Context, clientset simple.Clientset, cluster *kopsapi.Cluster, cloud fi.Cloud, allInstanceGroups []*kopsapi.InstanceGroup, groups map[string]*cloudinstances.CloudInstanceGroup, nodes []v1.Node) error {
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		return err
+	}
+	keyset, err := keyStore.FindKeyset(ctx, kubeletca.KeysetName)
+	if err != nil {
+		return fmt.Errorf("reading keyset %q: %w", kubeletca.KeysetName, err)
+	}
+	state, err := kubeletca.CurrentState(keyset)
+	if err != nil {
+		return err
+	}
+	if !state.InProgress() {
+		return nil
+	}
+
+	var controlPlane []*kopsapi.InstanceGroup
+	for _, ig := range allInstanceGroups {
+		if ig.IsControlPlane() {
+			controlPlane = append(controlPlane, ig)
+		}
+	}
+	controlPlaneGroups, err := cloud.GetCloudGroups(cluster, controlPlane, false, nodes)
+	if err != nil {
+		return err
+	}
+	return kubeletca.CheckRollingUpdateOrder(state, groups, controlPlaneGroups)
+}
+
 func completeInstanceGroup(f commandutils.Factory, selectedInstanceGroups *[]string, selectedInstanceGroupRoles *[]string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		ctx := cmd.Context()
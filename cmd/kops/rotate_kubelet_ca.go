@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/kubeletca"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rotateKubeletCALong = templates.LongDesc(i18n.T(`
+	Rotate the CA that secures the connection between kube-apiserver and the kubelets.
+
+	The rotation is done in three phases. After each phase, the cluster must be
+	updated and rolled, control plane first, before the next phase can be applied:
+
+	* stage: a new CA is added to the trust bundles of kube-apiserver and the kubelets.
+	* promote: the new CA signs the kubelet serving certificates and the kube-apiserver
+	  kubelet client certificate.
+	* distrust: the old CA is removed from the trust bundles.
+
+	Before promote and distrust, the command checks that the configuration of every
+	instance group contains the expected trust bundle and that no instance needs to
+	be updated.
+
+	Before any phase, the command checks that every etcd cluster has a backup newer
+	than --max-backup-age, so the cluster can be restored if the rotation goes wrong.
+
+	If no phase is provided, the current state of the rotation is printed.
+	`))
+
+	rotateKubeletCAExample = templates.Examples(i18n.T(`
+	# Start a rotation of the kubelet CA.
+	kops rotate kubelet-ca --phase stage \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	kops update cluster --yes \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	kops rolling-update cluster --yes \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Show the current state of the rotation.
+	kops rotate kubelet-ca \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	rotateKubeletCAShort = i18n.T(`Rotate the kubelet CA.`)
+)
+
+type RotateKubeletCAOptions struct {
+	ClusterName string
+	Phase       string
+
+	// MaxBackupAge is the maximum age of the latest backup of each etcd cluster.
+	MaxBackupAge time.Duration
+}
+
+func (o *RotateKubeletCAOptions) InitDefaults() {
+	o.MaxBackupAge = validation.DefaultEtcdBackupMaxAge
+}
+
+// NewCmdRotateKubeletCA returns a rotate kubelet-ca command.
+func NewCmdRotateKubeletCA(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RotateKubeletCAOptions{}
+	options.InitDefaults()
+
+	var phases []string
+	for _, phase := range kubeletca.Phases {
+		phases = append(phases, string(phase))
+	}
+
+	cmd := &cobra.Command{
+		Use:     "kubelet-ca",
+		Short:   rotateKubeletCAShort,
+		Long:    rotateKubeletCALong,
+		Example: rotateKubeletCAExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 0 {
+				return fmt.Errorf("unexpected arguments: %v", args)
+			}
+
+			return nil
+		},
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRotateKubeletCA(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Phase, "phase", options.Phase, "Phase of the rotation to apply ("+strings.Join(phases, ", ")+")")
+	cmd.RegisterFlagCompletionFunc("phase", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return phases, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().DurationVar(&options.MaxBackupAge, "max-backup-age", options.MaxBackupAge, "Maximum age of the latest backup of each etcd cluster")
+
+	return cmd
+}
+
+// RunRotateKubeletCA applies a phase of a kubelet CA rotation.
+func RunRotateKubeletCA(ctx context.Context, f *util.Factory, out io.Writer, options *RotateKubeletCAOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return fmt.Errorf("getting cluster: %q: %v", options.ClusterName, err)
+	}
+
+	clientSet, err := f.KopsClient()
+	if err != nil {
+		return fmt.Errorf("getting clientset: %v", err)
+	}
+
+	keyStore, err := clientSet.KeyStore(cluster)
+	if err != nil {
+		return fmt.Errorf("getting keystore: %v", err)
+	}
+
+	keyset, err := keyStore.FindKeyset(ctx, kubeletca.KeysetName)
+	if err != nil {
+		return fmt.Errorf("reading keyset: %v", err)
+	}
+
+	state, err := kubeletca.CurrentState(keyset)
+	if err != nil {
+		return err
+	}
+
+	phase := kubeletca.Phase(options.Phase)
+	if phase == "" {
+		fmt.Fprintf(out, "%s rotation is %s\n", kubeletca.KeysetName, state)
+		return nil
+	}
+
+	if err := kubeletca.CheckTransition(state, phase); err != nil {
+		return err
+	}
+
+	if err := checkEtcdBackups(ctx, f.VFSContext(), cluster, time.Now(), options.MaxBackupAge); err != nil {
+		return fmt.Errorf("refusing to apply %s phase: %w", phase, err)
+	}
+
+	switch phase {
+	case kubeletca.PhaseStage:
+		kubernetesCA, err := keyStore.FindKeyset(ctx, fi.CertificateIDCA)
+		if err != nil {
+			return fmt.Errorf("reading keyset: %v", err)
+		}
+		keyset, err = kubeletca.Stage(ctx, kubernetesCA, keyset)
+		if err != nil {
+			return err
+		}
+
+	case kubeletca.PhasePromote:
+		if err := verifyKubeletCARollout(ctx, clientSet, cluster, keyset); err != nil {
+			return err
+		}
+		if err := kubeletca.Promote(keyset); err != nil {
+			return err
+		}
+
+	case kubeletca.PhaseDistrust:
+		if err := verifyKubeletCARollout(ctx, clientSet, cluster, keyset); err != nil {
+			return err
+		}
+		if err := kubeletca.Distrust(keyset, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if err := keyStore.StoreKeyset(ctx, kubeletca.KeysetName, keyset); err != nil {
+		return fmt.Errorf("writing keyset: %v", err)
+	}
+
+	fmt.Fprintf(out, "Applied %s phase to %s\n", phase, kubeletca.KeysetName)
+	fmt.Fprintf(out, "Run \"kops update cluster --yes\" and \"kops rolling-update cluster --yes\" to roll it out\n")
+	return nil
+}
+
+// verifyKubeletCARollout checks that the current phase of the rotation has been
+// rolled out: the configuration of every instance group trusts all the trusted
+// keypairs of the keyset and signs with its primary, and no instance runs an
+// older configuration.
+func verifyKubeletCARollout(ctx context.Context, clientSet simple.Clientset, cluster *kopsapi.Cluster, keyset *fi.Keyset) error {
+	list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	configBase, err := clientSet.VFSContext().BuildVfsPath(cluster.Spec.ConfigStore.Base)
+	if err != nil {
+		return fmt.Errorf("parsing config base %q: %v", cluster.Spec.ConfigStore.Base, err)
+	}
+
+	var instanceGroups []*kopsapi.InstanceGroup
+	for i := range list.Items {
+		ig := &list.Items[i]
+		if ig.IsBastion() {
+			continue
+		}
+		instanceGroups = append(instanceGroups, ig)
+
+		p := configBase.Join("igconfig", ig.Spec.Role.ToLowerString(), ig.Name, "nodeupconfig.yaml")
+		b, err := p.ReadFile(ctx)
+		if err != nil {
+			return fmt.Errorf("reading configuration of instance group %q: %v", ig.Name, err)
+		}
+		config := &nodeup.Config{}
+		if err := utils.YamlUnmarshal(b, config); err != nil {
+			return fmt.Errorf("parsing configuration of instance group %q: %v", ig.Name, err)
+		}
+
+		if err := kubeletca.VerifyTrustBundle(config.CAs[kubeletca.KeysetName], keyset); err != nil {
+			return fmt.Errorf("instance group %q: %v; run \"kops update cluster --yes\" first", ig.Name, err)
+		}
+		if config.KeypairIDs[kubeletca.KeysetName] != keyset.Primary.Id {
+			return fmt.Errorf("instance group %q does not sign with %s keypair %s; run \"kops update cluster --yes\" first", ig.Name, kubeletca.KeysetName, keyset.Primary.Id)
+		}
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nil)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if len(group.NeedUpdate) != 0 {
+			return fmt.Errorf("instance group %q has %d instances that need updating; run \"kops rolling-update cluster --yes\" first", group.InstanceGroup.Name, len(group.NeedUpdate))
+		}
+	}
+
+	return nil
+}
@@ -43,7 +43,7 @@ func NewCmdToolboxAddons(out io.Writer) *cobra.Command {
 		Short:   "Applies updates from the given channel",
 		Example: "kops toolbox addons apply s3://<state_store>/<cluster_name>/addons/bootstrap-channel.yaml",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return channelscmd.RunApplyChannel(ctx, f, out, &channelscmd.ApplyChannelOptions{}, args)
+			return channelscmd.RunApplyChannel(ctx, f, out, channelscmd.NewApplyChannelOptions(), args)
 		},
 	})
 	cmd.AddCommand(&cobra.Command{
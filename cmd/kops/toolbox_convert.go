@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/convert"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const toolboxConvertFormatCAPI = "capi"
+
+var (
+	toolboxConvertLong = templates.LongDesc(i18n.T(`
+	Converts the cluster and instance group specifications into the manifests of another tool.
+
+	Conversion is best-effort. Fields that could not be converted are listed in a comment
+	above the manifest generated from the object they belong to.
+
+	Currently only Cluster API (with the AWS infrastructure provider) is supported.
+	Clusters using gossip DNS cannot be converted.`))
+
+	toolboxConvertExample = templates.Examples(i18n.T(`
+	# Export the cluster as Cluster API manifests
+	kops toolbox convert --to capi --name k8s-cluster.example.com
+	`))
+
+	toolboxConvertShort = i18n.T(`Convert a cluster into the manifests of another tool`)
+)
+
+type ToolboxConvertOptions struct {
+	ClusterName string
+	To          string
+}
+
+func (o *ToolboxConvertOptions) InitDefaults() {
+	o.To = toolboxConvertFormatCAPI
+}
+
+func NewCmdToolboxConvert(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxConvertOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "convert [CLUSTER]",
+		Short:             toolboxConvertShort,
+		Long:              toolboxConvertLong,
+		Example:           toolboxConvertExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxConvert(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.To, "to", options.To, "Target format. One of: capi")
+	cmd.RegisterFlagCompletionFunc("to", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{toolboxConvertFormatCAPI}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func RunToolboxConvert(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxConvertOptions) error {
+	if options.To != toolboxConvertFormatCAPI {
+		return fmt.Errorf("unsupported target format %q, must be one of: %s", options.To, toolboxConvertFormatCAPI)
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var instanceGroups []*kops.InstanceGroup
+	for i := range list.Items {
+		instanceGroups = append(instanceGroups, &list.Items[i])
+	}
+
+	b, err := convert.ToClusterAPI(cluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(b)
+	return err
+}
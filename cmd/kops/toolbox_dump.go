@@ -127,7 +127,7 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 		return err
 	}
 
-	resourceMap, err := resourceops.ListResources(cloud, cluster)
+	resourceMap, err := resourceops.ListResources(cloud, cluster, resources.DiscoveryDescribe)
 	if err != nil {
 		return err
 	}
@@ -541,7 +541,7 @@ func decorateWithClusterAutoscalerLabels(instanceGroup *kops.InstanceGroup, clus
 	if ig.Spec.CloudLabels == nil {
 		ig.Spec.CloudLabels = make(map[string]string)
 	}
-	ig.Spec.CloudLabels["k8s.io/cluster-autoscaler/enabled"] = "1"
+	ig.Spec.CloudLabels[kops.CloudLabelClusterAutoscalerEnabled] = "1"
 	ig.Spec.CloudLabels["k8s.io/cluster-autoscaler/"+clusterName] = "1"
 	return ig
 }
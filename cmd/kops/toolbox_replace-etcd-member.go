@@ -0,0 +1,632 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/instancegroups"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/ui"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxReplaceEtcdMemberLong = templates.LongDesc(i18n.T(`
+	Replaces a single member of an etcd cluster, for example when its volume is corrupted.
+
+	The command checks that the remaining members have quorum and that a recent backup exists,
+	deletes the volume of the member, recreates it, replaces the control plane instance that
+	runs the member, and waits for the new member to rejoin the cluster.
+
+	Each step asks for confirmation unless --yes is specified. Progress is recorded in the
+	state store, so an interrupted replacement can be resumed by running the command again.`))
+
+	toolboxReplaceEtcdMemberExample = templates.Examples(i18n.T(`
+	# Replace member "a" of the main etcd cluster
+	kops toolbox replace-etcd-member --name k8s-cluster.example.com --member a
+
+	# Replace member "a" of the events etcd cluster without prompting
+	kops toolbox replace-etcd-member --name k8s-cluster.example.com --etcd-cluster events --member a --yes
+	`))
+
+	toolboxReplaceEtcdMemberShort = i18n.T(`Replace a single member of an etcd cluster`)
+)
+
+const (
+	replaceEtcdMemberStepDeleteVolume    = "delete-volume"
+	replaceEtcdMemberStepRecreateVolume  = "recreate-volume"
+	replaceEtcdMemberStepReplaceInstance = "replace-instance"
+	replaceEtcdMemberStepWaitForRejoin   = "wait-for-rejoin"
+)
+
+var replaceEtcdMemberSteps = []string{
+	replaceEtcdMemberStepDeleteVolume,
+	replaceEtcdMemberStepRecreateVolume,
+	replaceEtcdMemberStepReplaceInstance,
+	replaceEtcdMemberStepWaitForRejoin,
+}
+
+type ToolboxReplaceEtcdMemberOptions struct {
+	ClusterName string
+	EtcdCluster string
+	Member      string
+
+	// Yes skips the confirmation of each step.
+	Yes bool
+
+	// MaxBackupAge is the maximum age of the latest backup of the etcd cluster.
+	MaxBackupAge time.Duration
+
+	// WaitTimeout is how long to wait for the new member to rejoin the cluster.
+	WaitTimeout time.Duration
+}
+
+func (o *ToolboxReplaceEtcdMemberOptions) InitDefaults() {
+	o.EtcdCluster = "main"
+	o.MaxBackupAge = validation.DefaultEtcdBackupMaxAge
+	o.WaitTimeout = 30 * time.Minute
+}
+
+func NewCmdToolboxReplaceEtcdMember(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxReplaceEtcdMemberOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "replace-etcd-member [CLUSTER]",
+		Short:             toolboxReplaceEtcdMemberShort,
+		Long:              toolboxReplaceEtcdMemberLong,
+		Example:           toolboxReplaceEtcdMemberExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxReplaceEtcdMember(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.EtcdCluster, "etcd-cluster", options.EtcdCluster, "Name of the etcd cluster")
+	cmd.RegisterFlagCompletionFunc("etcd-cluster", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"main", "events"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&options.Member, "member", options.Member, "Name of the etcd member to replace")
+	cmd.RegisterFlagCompletionFunc("member", cobra.NoFileCompletions)
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Perform all steps without asking for confirmation")
+	cmd.Flags().DurationVar(&options.MaxBackupAge, "max-backup-age", options.MaxBackupAge, "Maximum age of the latest etcd backup")
+	cmd.Flags().DurationVar(&options.WaitTimeout, "wait-timeout", options.WaitTimeout, "Maximum time to wait for the new member to rejoin the cluster")
+
+	return cmd
+}
+
+// etcdMemberReplacement is the progress marker of an etcd member replacement, stored in the state store.
+type etcdMemberReplacement struct {
+	EtcdCluster    string   `json:"etcdCluster"`
+	Member         string   `json:"member"`
+	InstanceGroup  string   `json:"instanceGroup"`
+	InstanceID     string   `json:"instanceID,omitempty"`
+	NodeName       string   `json:"nodeName,omitempty"`
+	VolumeID       string   `json:"volumeID,omitempty"`
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+}
+
+func (r *etcdMemberReplacement) isCompleted(step string) bool {
+	return slices.Contains(r.CompletedSteps, step)
+}
+
+func RunToolboxReplaceEtcdMember(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxReplaceEtcdMemberOptions) error {
+	if options.Member == "" {
+		return fmt.Errorf("--member is required")
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster.Spec.GetCloudProvider() != kopsapi.CloudProviderAWS {
+		return fmt.Errorf("replace-etcd-member is only supported on AWS")
+	}
+
+	etcdCluster, member, err := findEtcdMember(cluster, options.EtcdCluster, options.Member)
+	if err != nil {
+		return err
+	}
+
+	configBase, err := registry.ConfigBase(f.VFSContext(), cluster)
+	if err != nil {
+		return err
+	}
+	markerPath := configBase.Join("replace-etcd-member", etcdCluster.Name+"-"+member.Name)
+	replacement, err := readEtcdMemberReplacement(ctx, markerPath)
+	if err != nil {
+		return err
+	}
+	if replacement == nil {
+		replacement = &etcdMemberReplacement{
+			EtcdCluster:   etcdCluster.Name,
+			Member:        member.Name,
+			InstanceGroup: fi.ValueOf(member.InstanceGroup),
+		}
+	} else {
+		fmt.Fprintf(out, "Resuming replacement of etcd member %q, completed steps: %s\n", member.Name, strings.Join(replacement.CompletedSteps, ", "))
+	}
+
+	k8sClient, host, nodes, err := getNodes(ctx, cluster, false)
+	if err != nil {
+		return err
+	}
+
+	c, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+	cloud := c.(awsup.AWSCloud)
+
+	list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var instanceGroups []*kopsapi.InstanceGroup
+	for i := range list.Items {
+		instanceGroups = append(instanceGroups, &list.Items[i])
+	}
+
+	if !replacement.isCompleted(replaceEtcdMemberStepDeleteVolume) {
+		backupStore, err := validation.EtcdBackupStore(f.VFSContext(), cluster, etcdCluster)
+		if err != nil {
+			return err
+		}
+		if err := checkReplaceEtcdMemberPreconditions(ctx, k8sClient, etcdCluster, member.Name, backupStore, time.Now(), options.MaxBackupAge); err != nil {
+			return fmt.Errorf("refusing to replace etcd member %q: %w", member.Name, err)
+		}
+		fmt.Fprintf(out, "Quorum of etcd cluster %q is healthy without member %q, and a recent backup exists\n", etcdCluster.Name, member.Name)
+
+		if replacement.InstanceID == "" {
+			if err := findEtcdMemberInstance(cloud, cluster, instanceGroups, nodes, replacement); err != nil {
+				return err
+			}
+		}
+		if replacement.VolumeID == "" {
+			status, err := cloud.FindClusterStatus(cluster)
+			if err != nil {
+				return err
+			}
+			replacement.VolumeID = findEtcdMemberVolumeID(status, etcdCluster.Name, member.Name)
+		}
+		if err := writeEtcdMemberReplacement(ctx, markerPath, replacement); err != nil {
+			return err
+		}
+	}
+
+	steps := map[string]struct {
+		description string
+		run         func() error
+	}{
+		replaceEtcdMemberStepDeleteVolume: {
+			description: fmt.Sprintf("Detach and delete volume %q of etcd member %q", replacement.VolumeID, member.Name),
+			run: func() error {
+				return deleteEtcdMemberVolume(cloud, replacement.VolumeID)
+			},
+		},
+		replaceEtcdMemberStepRecreateVolume: {
+			description: fmt.Sprintf("Update cluster %q to create a new volume for etcd member %q", cluster.Name, member.Name),
+			run: func() error {
+				updateClusterOptions := &UpdateClusterOptions{}
+				updateClusterOptions.InitDefaults()
+				updateClusterOptions.ClusterName = cluster.Name
+				updateClusterOptions.Yes = true
+				updateClusterOptions.CreateKubecfg = false
+				_, err := RunUpdateCluster(ctx, f, out, updateClusterOptions)
+				return err
+			},
+		},
+		replaceEtcdMemberStepReplaceInstance: {
+			description: fmt.Sprintf("Replace control plane instance %q of instance group %q", replacement.InstanceID, replacement.InstanceGroup),
+			run: func() error {
+				return replaceEtcdMemberInstance(ctx, cluster, cloud, clientset, k8sClient, host, list, instanceGroups, replacement)
+			},
+		},
+		replaceEtcdMemberStepWaitForRejoin: {
+			description: fmt.Sprintf("Wait for etcd member %q to rejoin and the cluster to become healthy", member.Name),
+			run: func() error {
+				return waitForEtcdMemberRejoin(ctx, cluster, cloud, k8sClient, host, list, etcdCluster, replacement, options.WaitTimeout)
+			},
+		},
+	}
+
+	for _, step := range replaceEtcdMemberSteps {
+		if replacement.isCompleted(step) {
+			fmt.Fprintf(out, "Skipping completed step %q\n", step)
+			continue
+		}
+
+		description := steps[step].description
+		if !options.Yes {
+			confirmed, err := ui.GetConfirm(&ui.ConfirmArgs{
+				Out:     out,
+				Message: description + "?",
+				Default: "no",
+			})
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintf(out, "\nStopped before step %q; run the command again to resume\n", step)
+				return nil
+			}
+		} else {
+			fmt.Fprintf(out, "%s\n", description)
+		}
+
+		if err := steps[step].run(); err != nil {
+			return fmt.Errorf("step %q failed, run the command again to resume: %w", step, err)
+		}
+		replacement.CompletedSteps = append(replacement.CompletedSteps, step)
+		if err := writeEtcdMemberReplacement(ctx, markerPath, replacement); err != nil {
+			return err
+		}
+	}
+
+	if err := markerPath.Remove(ctx); err != nil {
+		return fmt.Errorf("error removing progress marker %q: %w", markerPath, err)
+	}
+	fmt.Fprintf(out, "\nEtcd member %q of etcd cluster %q was replaced\n", member.Name, etcdCluster.Name)
+	return nil
+}
+
+// findEtcdMember returns the spec of the named etcd cluster and member.
+func findEtcdMember(cluster *kopsapi.Cluster, etcdClusterName string, memberName string) (*kopsapi.EtcdClusterSpec, *kopsapi.EtcdMemberSpec, error) {
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+		if etcdCluster.Name != etcdClusterName {
+			continue
+		}
+		for j := range etcdCluster.Members {
+			if etcdCluster.Members[j].Name == memberName {
+				return etcdCluster, &etcdCluster.Members[j], nil
+			}
+		}
+		return nil, nil, fmt.Errorf("etcd cluster %q has no member %q", etcdClusterName, memberName)
+	}
+	return nil, nil, fmt.Errorf("etcd cluster %q not found", etcdClusterName)
+}
+
+// checkReplaceEtcdMemberPreconditions returns an error unless the etcd cluster keeps quorum without the member,
+// and the backup store holds a backup no older than maxBackupAge.
+func checkReplaceEtcdMemberPreconditions(ctx context.Context, k8sClient kubernetes.Interface, etcdCluster *kopsapi.EtcdClusterSpec, memberName string, backupStore vfs.Path, now time.Time, maxBackupAge time.Duration) error {
+	health, err := getEtcdMemberHealth(ctx, k8sClient, etcdCluster)
+	if err != nil {
+		return err
+	}
+	quorum := len(etcdCluster.Members)/2 + 1
+	var healthy []string
+	for _, m := range etcdCluster.Members {
+		if m.Name != memberName && health[m.Name] != "" {
+			healthy = append(healthy, m.Name)
+		}
+	}
+	if len(healthy) < quorum {
+		return fmt.Errorf("only %d other members of etcd cluster %q are healthy (%s), but %d are needed for quorum", len(healthy), etcdCluster.Name, strings.Join(healthy, ","), quorum)
+	}
+
+	backup := &validation.EtcdBackupStatus{EtcdCluster: etcdCluster.Name, BackupStore: backupStore}
+	backup.Latest, backup.Err = validation.FindLatestEtcdBackup(ctx, backupStore)
+	return backup.Check(now, maxBackupAge)
+}
+
+// checkEtcdBackups returns an error unless the backup store of every etcd cluster holds a backup no older than maxBackupAge.
+func checkEtcdBackups(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kopsapi.Cluster, now time.Time, maxBackupAge time.Duration) error {
+	for _, backup := range validation.FindEtcdBackups(ctx, vfsContext, cluster) {
+		if err := backup.Check(now, maxBackupAge); err != nil {
+			return fmt.Errorf("etcd cluster %q: %w", backup.EtcdCluster, err)
+		}
+	}
+	return nil
+}
+
+// getEtcdMemberHealth maps each member of the etcd cluster to the node on which its etcd-manager pod is ready.
+// Members without a ready pod on a ready node are omitted.
+func getEtcdMemberHealth(ctx context.Context, k8sClient kubernetes.Interface, etcdCluster *kopsapi.EtcdClusterSpec) (map[string]string, error) {
+	pods, err := k8sClient.CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{
+		LabelSelector: "k8s-app=etcd-manager-" + etcdCluster.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing etcd-manager pods: %w", err)
+	}
+	nodes, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+
+	readyNodeGroups := make(map[string]string)
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if isConditionTrue(node.Status.Conditions, v1.NodeReady) {
+			readyNodeGroups[node.Name] = node.Labels[kopsapi.NodeLabelInstanceGroup]
+		}
+	}
+
+	health := make(map[string]string)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ig, found := readyNodeGroups[pod.Spec.NodeName]
+		if !found || !isPodReady(pod) {
+			continue
+		}
+		for _, m := range etcdCluster.Members {
+			if fi.ValueOf(m.InstanceGroup) == ig {
+				health[m.Name] = pod.Spec.NodeName
+			}
+		}
+	}
+	return health, nil
+}
+
+func isConditionTrue(conditions []v1.NodeCondition, conditionType v1.NodeConditionType) bool {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	if pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// findEtcdMemberInstance records the control plane instance that runs the etcd member.
+func findEtcdMemberInstance(cloud awsup.AWSCloud, cluster *kopsapi.Cluster, instanceGroups []*kopsapi.InstanceGroup, nodes []v1.Node, replacement *etcdMemberReplacement) error {
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nodes)
+	if err != nil {
+		return err
+	}
+	var members []*cloudinstances.CloudInstance
+	for _, group := range groups {
+		if group.InstanceGroup.Name != replacement.InstanceGroup {
+			continue
+		}
+		members = append(members, group.Ready...)
+		members = append(members, group.NeedUpdate...)
+	}
+	if len(members) != 1 {
+		return fmt.Errorf("expected exactly one instance in instance group %q, found %d", replacement.InstanceGroup, len(members))
+	}
+	replacement.InstanceID = members[0].ID
+	if members[0].Node != nil {
+		replacement.NodeName = members[0].Node.Name
+	}
+	return nil
+}
+
+// findEtcdMemberVolumeID returns the ID of the volume of the etcd member, or "" if it does not exist.
+func findEtcdMemberVolumeID(status *kopsapi.ClusterStatus, etcdClusterName string, memberName string) string {
+	if status == nil {
+		return ""
+	}
+	for _, etcdCluster := range status.EtcdClusters {
+		if etcdCluster.Name != etcdClusterName {
+			continue
+		}
+		for _, m := range etcdCluster.Members {
+			if m.Name == memberName {
+				return m.VolumeID
+			}
+		}
+	}
+	return ""
+}
+
+// deleteEtcdMemberVolume force-detaches the volume from its instance and deletes it.
+// A volume that no longer exists is treated as already deleted.
+func deleteEtcdMemberVolume(cloud awsup.AWSCloud, volumeID string) error {
+	if volumeID == "" {
+		klog.Warningf("no volume found for etcd member, skipping deletion")
+		return nil
+	}
+
+	response, err := cloud.EC2().DescribeVolumes(&ec2.DescribeVolumesInput{
+		VolumeIds: []*string{aws.String(volumeID)},
+	})
+	if err != nil {
+		if awsup.AWSErrorCode(err) == "InvalidVolume.NotFound" {
+			return nil
+		}
+		return fmt.Errorf("error describing volume %q: %w", volumeID, err)
+	}
+	if len(response.Volumes) == 0 {
+		return nil
+	}
+
+	if len(response.Volumes[0].Attachments) != 0 {
+		klog.Infof("detaching volume %q", volumeID)
+		_, err := cloud.EC2().DetachVolume(&ec2.DetachVolumeInput{
+			VolumeId: aws.String(volumeID),
+			Force:    aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("error detaching volume %q: %w", volumeID, err)
+		}
+		err = cloud.EC2().WaitUntilVolumeAvailable(&ec2.DescribeVolumesInput{
+			VolumeIds: []*string{aws.String(volumeID)},
+		})
+		if err != nil {
+			return fmt.Errorf("error waiting for volume %q to detach: %w", volumeID, err)
+		}
+	}
+
+	klog.Infof("deleting volume %q", volumeID)
+	if _, err := cloud.EC2().DeleteVolume(&ec2.DeleteVolumeInput{VolumeId: aws.String(volumeID)}); err != nil {
+		return fmt.Errorf("error deleting volume %q: %w", volumeID, err)
+	}
+	return nil
+}
+
+// replaceEtcdMemberInstance terminates the control plane instance of the etcd member using the rolling updater.
+func replaceEtcdMemberInstance(ctx context.Context, cluster *kopsapi.Cluster, cloud awsup.AWSCloud, clientset simple.Clientset, k8sClient kubernetes.Interface, host string, list *kopsapi.InstanceGroupList, instanceGroups []*kopsapi.InstanceGroup, replacement *etcdMemberReplacement) error {
+	nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nodeList.Items)
+	if err != nil {
+		return err
+	}
+
+	var cloudMember *cloudinstances.CloudInstance
+	for _, group := range groups {
+		for _, r := range append(group.Ready, group.NeedUpdate...) {
+			if r.ID == replacement.InstanceID {
+				cloudMember = r
+			}
+		}
+	}
+	if cloudMember == nil {
+		klog.Warningf("instance %q no longer exists, assuming it was already replaced", replacement.InstanceID)
+		return nil
+	}
+
+	clusterValidator, err := validation.NewClusterValidator(cluster, cloud, list, host, k8sClient)
+	if err != nil {
+		return fmt.Errorf("cannot create cluster validator: %w", err)
+	}
+
+	d := &instancegroups.RollingUpdateCluster{
+		Clientset:        clientset,
+		Cluster:          cluster,
+		Ctx:              ctx,
+		Interactive:      false,
+		Force:            true,
+		Cloud:            cloud,
+		K8sClient:        k8sClient,
+		ClusterValidator: clusterValidator,
+		FailOnDrainError: false,
+		FailOnValidate:   true,
+		ClusterName:      cluster.Name,
+		// The etcd member cannot rejoin until its replacement has booted, so we validate when waiting for it instead
+		ValidateCount:           0,
+		ValidateTickDuration:    30 * time.Second,
+		ValidateSuccessDuration: 10 * time.Second,
+	}
+	return d.UpdateSingleInstance(cloudMember, false)
+}
+
+// waitForEtcdMemberRejoin waits until every member of the etcd cluster is healthy, with the replaced member
+// running on a new node, and the cluster validates.
+func waitForEtcdMemberRejoin(ctx context.Context, cluster *kopsapi.Cluster, cloud awsup.AWSCloud, k8sClient kubernetes.Interface, host string, list *kopsapi.InstanceGroupList, etcdCluster *kopsapi.EtcdClusterSpec, replacement *etcdMemberReplacement, timeout time.Duration) error {
+	clusterValidator, err := validation.NewClusterValidator(cluster, cloud, list, host, k8sClient)
+	if err != nil {
+		return fmt.Errorf("cannot create cluster validator: %w", err)
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		health, err := getEtcdMemberHealth(ctx, k8sClient, etcdCluster)
+		if err != nil {
+			klog.Warningf("error checking etcd member health: %v", err)
+			return false, nil
+		}
+		for _, m := range etcdCluster.Members {
+			if health[m.Name] == "" {
+				klog.Infof("etcd member %q is not yet healthy", m.Name)
+				return false, nil
+			}
+		}
+		if nodeName := health[replacement.Member]; nodeName == replacement.NodeName {
+			klog.Infof("etcd member %q is still running on the old node %q", replacement.Member, nodeName)
+			return false, nil
+		}
+
+		result, err := clusterValidator.Validate()
+		if err != nil {
+			klog.Warningf("error validating cluster: %v", err)
+			return false, nil
+		}
+		if len(result.Failures) != 0 {
+			klog.Infof("cluster did not pass validation, %d failures", len(result.Failures))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("etcd member %q did not rejoin within %v: %w", replacement.Member, timeout, err)
+	}
+	return nil
+}
+
+func readEtcdMemberReplacement(ctx context.Context, p vfs.Path) (*etcdMemberReplacement, error) {
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading progress marker %q: %w", p, err)
+	}
+	replacement := &etcdMemberReplacement{}
+	if err := json.Unmarshal(data, replacement); err != nil {
+		return nil, fmt.Errorf("error parsing progress marker %q: %w", p, err)
+	}
+	return replacement, nil
+}
+
+func writeEtcdMemberReplacement(ctx context.Context, p vfs.Path, replacement *etcdMemberReplacement) error {
+	data, err := json.MarshalIndent(replacement, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := p.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		return fmt.Errorf("error writing progress marker %q: %w", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func buildTestEtcdMemberObjects(healthy ...string) []runtime.Object {
+	var objects []runtime.Object
+	for _, member := range []string{"a", "b", "c"} {
+		ready := v1.ConditionFalse
+		if fi.ArrayContains(healthy, member) {
+			ready = v1.ConditionTrue
+		}
+		nodeName := "control-plane-" + member
+		objects = append(objects,
+			&v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{kopsapi.NodeLabelInstanceGroup: "control-plane-us-test-1" + member},
+				},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				},
+			},
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "etcd-manager-main-" + nodeName,
+					Namespace: "kube-system",
+					Labels:    map[string]string{"k8s-app": "etcd-manager-main"},
+				},
+				Spec: v1.PodSpec{NodeName: nodeName},
+				Status: v1.PodStatus{
+					Phase:      v1.PodRunning,
+					Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}},
+				},
+			},
+		)
+	}
+	return objects
+}
+
+func TestCheckReplaceEtcdMemberPreconditions(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	etcdCluster := &kopsapi.EtcdClusterSpec{Name: "main"}
+	for _, member := range []string{"a", "b", "c"} {
+		etcdCluster.Members = append(etcdCluster.Members, kopsapi.EtcdMemberSpec{
+			Name:          member,
+			InstanceGroup: fi.PtrTo("control-plane-us-test-1" + member),
+		})
+	}
+
+	grid := []struct {
+		name          string
+		healthy       []string
+		backups       []string
+		expectedError string
+	}{
+		{
+			name:    "healthy with recent backup",
+			healthy: []string{"b", "c"},
+			backups: []string{"2024-04-30T12:00:00Z-000001", "2024-05-01T11:30:00Z-000002"},
+		},
+		{
+			name:          "no backup",
+			healthy:       []string{"a", "b", "c"},
+			expectedError: "no backups found",
+		},
+		{
+			name:          "only old backups",
+			healthy:       []string{"a", "b", "c"},
+			backups:       []string{"2024-04-30T12:00:00Z-000001"},
+			expectedError: "latest backup in \"memfs://backups/etcd/main\" is 24h0m0s old",
+		},
+		{
+			name:          "unhealthy quorum",
+			healthy:       []string{"a", "b"},
+			backups:       []string{"2024-05-01T11:30:00Z-000002"},
+			expectedError: "only 1 other members of etcd cluster \"main\" are healthy (b), but 2 are needed for quorum",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset(buildTestEtcdMemberObjects(g.healthy...)...)
+
+			backupStore := vfs.NewMemFSPath(vfs.NewMemFSContext(), "backups/etcd/main")
+			if err := backupStore.Join("control", "etcd-cluster-spec").WriteFile(ctx, bytes.NewReader(nil), nil); err != nil {
+				t.Fatalf("error writing control file: %v", err)
+			}
+			for _, backup := range g.backups {
+				if err := backupStore.Join(backup, "_etcd_backup.meta").WriteFile(ctx, bytes.NewReader(nil), nil); err != nil {
+					t.Fatalf("error writing backup: %v", err)
+				}
+			}
+
+			err := checkReplaceEtcdMemberPreconditions(ctx, k8sClient, etcdCluster, "a", backupStore, now, 2*time.Hour)
+			if g.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+				t.Errorf("expected error containing %q, got %v", g.expectedError, err)
+			}
+		})
+	}
+}
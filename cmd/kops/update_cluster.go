@@ -19,23 +19,38 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/cniupgrade"
+	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/quotas"
+	"k8s.io/kops/pkg/timings"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -53,6 +68,12 @@ var (
 	updateClusterExample = templates.Examples(i18n.T(`
 	# After the cluster has been edited or upgraded, update the cloud resources with:
 	kops update cluster k8s-cluster.example.com --yes --state=s3://my-state-store --yes
+
+	# Print the changes that would be made as JSON, for example to review them in CI
+	kops update cluster k8s-cluster.example.com --state=s3://my-state-store -o json
+
+	# Update only the resources of the nodes-us-east-1a instance group
+	kops update cluster k8s-cluster.example.com --state=s3://my-state-store --instance-group nodes-us-east-1a --yes
 	`))
 
 	updateClusterShort = i18n.T("Update a cluster.")
@@ -65,6 +86,10 @@ type UpdateClusterOptions struct {
 	SSHPublicKey       string
 	RunTasksOptions    fi.RunTasksOptions
 	AllowKopsDowngrade bool
+	// AllowReplacement permits changes that require replacing all the instances of an instance group.
+	AllowReplacement bool
+	// ForceOverwrite permits overwriting terraform output files that were modified since they were generated.
+	ForceOverwrite bool
 	// GetAssets is whether this is invoked from the CmdGetAssets.
 	GetAssets bool
 
@@ -80,6 +105,33 @@ type UpdateClusterOptions struct {
 	// LifecycleOverrides is a slice of taskName=lifecycle name values.  This slice is used
 	// to populate the LifecycleOverrides struct member in ApplyClusterCmd struct.
 	LifecycleOverrides []string
+
+	// PreflightQuotas checks the cluster against AWS service quotas before making any changes
+	PreflightQuotas bool
+	// warnOnQuotas reports quotas the cluster would exceed without failing
+	warnOnQuotas bool
+
+	// CreateAccessLogBucketPolicy adds the statements that API load balancer access logging needs to the bucket policy
+	CreateAccessLogBucketPolicy bool
+
+	// Timing prints how long each task and phase took, and how many cloud API calls were made
+	Timing bool
+	// TimingOut is a file to write the timings to, as JSON
+	TimingOut string
+
+	// PhasedCNIUpgrade rolls out a new version of the CNI addon in phases, instead of leaving it to the addon manager
+	PhasedCNIUpgrade bool
+
+	// Output is the format in which a dry run prints the planned changes, json or yaml.
+	// By default the changes are printed as human-readable text.
+	Output string
+
+	// InstanceGroups restricts the update to the resources of the named instance groups
+	InstanceGroups []string
+	// InstanceGroupRoles restricts the update to the resources of the instance groups with these roles
+	InstanceGroupRoles []string
+	// IgnoreClusterChanges permits updating some of the instance groups when the cluster has other changes
+	IgnoreClusterChanges bool
 }
 
 func (o *UpdateClusterOptions) InitDefaults() {
@@ -107,6 +159,7 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_, err := RunUpdateCluster(cmd.Context(), f, out, options)
+			recordOperation(cmd.Context(), f, cmd, args, options.ClusterName, err)
 			return err
 		},
 	}
@@ -124,6 +177,8 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("user", completeKubecfgUser)
 	cmd.Flags().BoolVar(&options.internal, "internal", options.internal, "Use the cluster's internal DNS name. Implies --create-kube-config")
 	cmd.Flags().BoolVar(&options.AllowKopsDowngrade, "allow-kops-downgrade", options.AllowKopsDowngrade, "Allow an older version of kOps to update the cluster than last used")
+	cmd.Flags().BoolVar(&options.AllowReplacement, "allow-replacement", options.AllowReplacement, "Allow changes that require replacing all the instances of an instance group")
+	cmd.Flags().BoolVar(&options.ForceOverwrite, "force-overwrite", options.ForceOverwrite, "Overwrite terraform output files even if they were modified since they were generated")
 	cmd.Flags().StringVar(&options.Phase, "phase", options.Phase, "Subset of tasks to run: "+strings.Join(cloudup.Phases.List(), ", "))
 	cmd.RegisterFlagCompletionFunc("phase", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return cloudup.Phases.List(), cobra.ShellCompDirectiveNoFileComp
@@ -132,6 +187,38 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	viper.BindPFlag("lifecycle-overrides", cmd.Flags().Lookup("lifecycle-overrides"))
 	viper.BindEnv("lifecycle-overrides", "KOPS_LIFECYCLE_OVERRIDES")
 	cmd.RegisterFlagCompletionFunc("lifecycle-overrides", completeLifecycleOverrides)
+	cmd.Flags().BoolVar(&options.PreflightQuotas, "preflight-quotas", options.PreflightQuotas, "Check that the cluster does not exceed AWS service quotas before making any changes")
+	cmd.Flags().BoolVar(&options.CreateAccessLogBucketPolicy, "create-access-log-bucket-policy", options.CreateAccessLogBucketPolicy, "Add the statements needed for API load balancer access logs to the S3 bucket policy")
+	cmd.Flags().BoolVar(&options.Timing, "timing", options.Timing, "Print the duration of each task and phase, and the number of cloud API calls")
+	cmd.Flags().StringVar(&options.TimingOut, "timing-out", options.TimingOut, "Write the duration of each task and phase, and the number of cloud API calls, to a JSON file")
+	cmd.MarkFlagFilename("timing-out", "json")
+	cmd.Flags().BoolVar(&options.PhasedCNIUpgrade, "phased-cni-upgrade", options.PhasedCNIUpgrade, "Roll out a new version of the Cilium or Calico addon in phases: operators first, then agents")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Print the planned changes of a dry run in a machine-readable format. One of: json, yaml")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	allRoles := make([]string, 0, len(kops.AllInstanceGroupRoles))
+	for _, r := range kops.AllInstanceGroupRoles {
+		allRoles = append(allRoles, r.ToLowerString())
+	}
+	cmd.Flags().StringSliceVar(&options.InstanceGroups, "instance-group", options.InstanceGroups, "Instance groups to update, only checking the shared resources they use (defaults to all if not specified)")
+	cmd.RegisterFlagCompletionFunc("instance-group", completeInstanceGroup(f, &options.InstanceGroups, &options.InstanceGroupRoles))
+	cmd.Flags().StringSliceVar(&options.InstanceGroupRoles, "instance-group-roles", options.InstanceGroupRoles, "Instance group roles to update ("+strings.Join(allRoles, ",")+")")
+	cmd.RegisterFlagCompletionFunc("instance-group-roles", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return sets.NewString(allRoles...).Delete(options.InstanceGroupRoles...).List(), cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVar(&options.IgnoreClusterChanges, "ignore-cluster-changes", options.IgnoreClusterChanges, "Update the selected instance groups even if the cluster has changes that would not be applied")
+
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		switch name {
+		case "ig", "instance-groups":
+			name = "instance-group"
+		case "role", "roles", "instance-group-role":
+			name = "instance-group-roles"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	return cmd
 }
@@ -188,6 +275,17 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		targetName = cloudup.TargetDryRun
 	}
 
+	if c.Output != "" {
+		if c.Output != OutputJSON && c.Output != OutputYaml {
+			return nil, fmt.Errorf("unsupported output format %q, must be one of: %s, %s", c.Output, OutputJSON, OutputYaml)
+		}
+		if !isDryrun {
+			return nil, fmt.Errorf("--output can only be used for a dry run, without --yes")
+		}
+		// The planned changes are printed on stdout, so everything else goes to stderr
+		out = os.Stderr
+	}
+
 	if c.OutDir == "" {
 		if c.Target == cloudup.TargetTerraform {
 			c.OutDir = "out/terraform"
@@ -270,6 +368,17 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		lifecycleOverrideMap[taskName] = lifecycleOverride
 	}
 
+	var targetInstanceGroups []string
+	if len(c.InstanceGroups) != 0 || len(c.InstanceGroupRoles) != 0 {
+		if c.PhasedCNIUpgrade {
+			return results, fmt.Errorf("cannot use --phased-cni-upgrade when updating some of the instance groups")
+		}
+		targetInstanceGroups, err = selectInstanceGroups(ctx, clientset, cluster, c.InstanceGroups, c.InstanceGroupRoles)
+		if err != nil {
+			return results, err
+		}
+	}
+
 	cloud, err := cloudup.BuildCloud(cluster)
 	if err != nil {
 		return nil, err
@@ -280,17 +389,54 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		Clientset:          clientset,
 		Cluster:            cluster,
 		DryRun:             isDryrun,
+		DryRunOutput:       c.Output,
 		AllowKopsDowngrade: c.AllowKopsDowngrade,
+		AllowReplacement:   c.AllowReplacement,
+		ForceOverwrite:     c.ForceOverwrite,
 		RunTasksOptions:    &c.RunTasksOptions,
 		OutDir:             c.OutDir,
 		Phase:              phase,
 		TargetName:         targetName,
 		LifecycleOverrides: lifecycleOverrideMap,
 		GetAssets:          c.GetAssets,
+
+		TargetInstanceGroups: targetInstanceGroups,
+		IgnoreClusterChanges: c.IgnoreClusterChanges,
 	}
 
-	if err := applyCmd.Run(ctx); err != nil {
-		return results, err
+	var recorder *timings.Recorder
+	if c.Timing || c.TimingOut != "" {
+		recorder = timings.NewRecorder()
+		defer timings.Start(recorder)()
+	}
+
+	if (c.PreflightQuotas || c.warnOnQuotas) && !c.GetAssets {
+		if awsCloud, ok := cloud.(awsup.AWSCloud); ok {
+			if err := checkQuotas(ctx, out, awsCloud, clientset, cluster, !c.PreflightQuotas); err != nil {
+				return results, err
+			}
+		}
+	}
+
+	if c.Target == cloudup.TargetDirect && !c.GetAssets {
+		if awsCloud, ok := cloud.(awsup.AWSCloud); ok {
+			if err := checkAccessLogBucketPolicy(ctx, out, awsCloud, cluster, c.CreateAccessLogBucketPolicy && !isDryrun); err != nil {
+				return results, err
+			}
+		}
+	}
+
+	previousIssuer := previousServiceAccountIssuer(ctx, clientset, cluster)
+
+	applyErr := applyCmd.Run(ctx)
+	if recorder != nil {
+		// Timings are also reported when the update fails, as they can explain the failure
+		if err := writeTimings(out, recorder.Summary(), c.Timing, c.TimingOut); err != nil {
+			return results, err
+		}
+	}
+	if applyErr != nil {
+		return results, applyErr
 	}
 
 	results.Target = applyCmd.Target
@@ -299,6 +445,10 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	results.FileAssets = applyCmd.FileAssets
 	results.Cluster = cluster
 
+	if !c.GetAssets {
+		printServiceAccountIssuerChange(out, previousIssuer, applyCmd.Cluster)
+	}
+
 	if isDryrun && !c.GetAssets {
 		target := applyCmd.Target.(*fi.CloudupDryRunTarget)
 		if target.HasChanges() {
@@ -348,6 +498,12 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		}
 	}
 
+	if !isDryrun && c.PhasedCNIUpgrade && c.Target == cloudup.TargetDirect {
+		if err := upgradeCNI(ctx, out, cluster, applyCmd.TaskMap); err != nil {
+			return results, err
+		}
+	}
+
 	if !isDryrun {
 		sb := new(bytes.Buffer)
 
@@ -409,6 +565,42 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	return results, nil
 }
 
+// upgradeCNI rolls out a new version of the CNI addon in phases.
+func upgradeCNI(ctx context.Context, out io.Writer, cluster *kops.Cluster, taskMap map[string]fi.CloudupTask) error {
+	upgrade, err := cniupgrade.FindUpgrade(cluster, taskMap)
+	if err != nil {
+		return err
+	}
+	if upgrade == nil {
+		klog.Warningf("phased CNI upgrades are only supported for Cilium and Calico")
+		return nil
+	}
+
+	contextName := cluster.ObjectMeta.Name
+	clientGetter := genericclioptions.NewConfigFlags(true)
+	clientGetter.Context = &contextName
+
+	config, err := clientGetter.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("cannot load kubecfg settings for %q: %w", contextName, err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("cannot build dynamic client for %q: %w", contextName, err)
+	}
+	restMapper, err := clientGetter.ToRESTMapper()
+	if err != nil {
+		return fmt.Errorf("cannot build REST mapper for %q: %w", contextName, err)
+	}
+
+	orchestrator := &cniupgrade.Orchestrator{
+		Client:     dynamicClient,
+		RESTMapper: restMapper,
+		Out:        out,
+	}
+	return orchestrator.Run(ctx, upgrade)
+}
+
 func parseLifecycle(lifecycle string) (fi.Lifecycle, error) {
 	if v, ok := fi.LifecycleNameMap[lifecycle]; ok {
 		return v, nil
@@ -416,6 +608,58 @@ func parseLifecycle(lifecycle string) (fi.Lifecycle, error) {
 	return "", fmt.Errorf("unknown lifecycle %q, available lifecycle: %s", lifecycle, strings.Join(fi.Lifecycles.List(), ","))
 }
 
+// selectInstanceGroups returns the names of the instance groups with the given names and roles.
+func selectInstanceGroups(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, names []string, roles []string) ([]string, error) {
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) != 0 {
+		var filtered []*kops.InstanceGroup
+		for _, name := range names {
+			var found *kops.InstanceGroup
+			for _, ig := range instanceGroups {
+				if ig.ObjectMeta.Name == name {
+					found = ig
+					break
+				}
+			}
+			if found == nil {
+				return nil, fmt.Errorf("InstanceGroup %q not found", name)
+			}
+			filtered = append(filtered, found)
+		}
+		instanceGroups = filtered
+	}
+
+	if len(roles) != 0 {
+		var filtered []*kops.InstanceGroup
+		for _, role := range roles {
+			r, ok := kops.ParseInstanceGroupRole(role, true)
+			if !ok {
+				return nil, fmt.Errorf("invalid instance group role %q", role)
+			}
+			for _, ig := range instanceGroups {
+				if ig.Spec.Role == r {
+					filtered = append(filtered, ig)
+				}
+			}
+		}
+		instanceGroups = filtered
+	}
+
+	if len(instanceGroups) == 0 {
+		return nil, fmt.Errorf("no instance groups match the selected names and roles")
+	}
+
+	var selected []string
+	for _, ig := range instanceGroups {
+		selected = append(selected, ig.ObjectMeta.Name)
+	}
+	return selected, nil
+}
+
 func usesBastion(instanceGroups []*kops.InstanceGroup) bool {
 	for _, ig := range instanceGroups {
 		if ig.Spec.Role == kops.InstanceGroupRoleBastion {
@@ -501,3 +745,166 @@ func completeLifecycleOverrides(cmd *cobra.Command, args []string, toComplete st
 	}
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
+
+// checkQuotas reports the AWS service quotas the cluster would exceed.
+// If warnOnly is true, the quotas are reported but don't fail the update, and neither do errors checking them.
+func checkQuotas(ctx context.Context, out io.Writer, cloud awsup.AWSCloud, clientset simple.Clientset, cluster *kops.Cluster, warnOnly bool) error {
+	violations, err := findQuotaViolations(ctx, cloud, clientset, cluster)
+	if err != nil {
+		if warnOnly {
+			klog.Warningf("unable to check AWS service quotas: %v", err)
+			return nil
+		}
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(out, "The cluster would exceed the following AWS service quotas:\n")
+	if err := quotas.PrintViolations(out, violations); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n")
+	if warnOnly {
+		fmt.Fprintf(out, "Creating the cluster may fail. Request quota increases through the Service Quotas console.\n\n")
+		return nil
+	}
+	return fmt.Errorf("cluster would exceed %d AWS service quotas", len(violations))
+}
+
+func findQuotaViolations(ctx context.Context, cloud awsup.AWSCloud, clientset simple.Clientset, cluster *kops.Cluster) ([]*quotas.Violation, error) {
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return nil, err
+	}
+	requirements, err := quotas.ComputeRequirements(cluster, instanceGroups, quotas.NewAWSVCPUCounter(cloud))
+	if err != nil {
+		return nil, err
+	}
+	client, err := quotas.NewAWSClient(cloud, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	return quotas.Check(client, requirements)
+}
+
+// checkAccessLogBucketPolicy makes sure the bucket policy allows the API load balancer to deliver its access logs.
+// Missing statements are added if create is true, otherwise they are printed so they can be added manually.
+func checkAccessLogBucketPolicy(ctx context.Context, out io.Writer, cloud awsup.AWSCloud, cluster *kops.Cluster, create bool) error {
+	lbSpec := cluster.Spec.API.LoadBalancer
+	if lbSpec == nil || !lbSpec.AccessLog.IsEnabled() || lbSpec.AccessLog.Bucket == nil {
+		return nil
+	}
+	bucket := *lbSpec.AccessLog.Bucket
+
+	accountID, partition, err := cloud.AccountInfo()
+	if err != nil {
+		return fmt.Errorf("error getting AWS account: %w", err)
+	}
+	statements := iam.AccessLogBucketPolicyStatements(lbSpec.Class, lbSpec.AccessLog, partition, cloud.Region(), accountID)
+
+	sess, err := cloud.Session()
+	if err != nil {
+		return err
+	}
+	if sess == nil {
+		// The mock cloud used in tests has no session
+		return printAccessLogBucketPolicyStatements(out, bucket, statements)
+	}
+	s3Client := s3.New(sess)
+
+	existing := ""
+	response, err := s3Client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		if awsup.AWSErrorCode(err) != "NoSuchBucketPolicy" {
+			klog.Warningf("unable to read the policy of access log bucket %q: %v", bucket, err)
+			return printAccessLogBucketPolicyStatements(out, bucket, statements)
+		}
+	} else {
+		existing = aws.StringValue(response.Policy)
+	}
+
+	policy, missing, err := iam.MergeBucketPolicy(existing, statements)
+	if err != nil {
+		return fmt.Errorf("error checking the policy of access log bucket %q: %w", bucket, err)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if !create {
+		return printAccessLogBucketPolicyStatements(out, bucket, missing)
+	}
+
+	klog.Infof("adding access log delivery statements to the policy of bucket %q", bucket)
+	if _, err := s3Client.PutBucketPolicyWithContext(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(policy),
+	}); err != nil {
+		return fmt.Errorf("error updating the policy of access log bucket %q: %w", bucket, err)
+	}
+	return nil
+}
+
+func printAccessLogBucketPolicyStatements(out io.Writer, bucket string, statements []*iam.Statement) error {
+	j, err := json.MarshalIndent(statements, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling bucket policy statements: %w", err)
+	}
+	fmt.Fprintf(out, "API load balancer access logs require the following statements in the policy of bucket %q:\n", bucket)
+	fmt.Fprintf(out, "%s\n", j)
+	fmt.Fprintf(out, "Add them to the bucket policy, or run with --create-access-log-bucket-policy to have kOps add them.\n\n")
+	return nil
+}
+
+// previousServiceAccountIssuer returns the service account issuer the cluster was last updated with, if any.
+func previousServiceAccountIssuer(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster) string {
+	fullSpecs, err := fullClusterSpecs(ctx, clientset.VFSContext(), []*kops.Cluster{cluster})
+	if err != nil {
+		klog.V(2).Infof("unable to read the cluster spec of the last update: %v", err)
+		return ""
+	}
+	if kubeAPIServer := fullSpecs[0].Spec.KubeAPIServer; kubeAPIServer != nil {
+		return fi.ValueOf(kubeAPIServer.ServiceAccountIssuer)
+	}
+	return ""
+}
+
+// printServiceAccountIssuerChange explains how to change the service account issuer without invalidating
+// the tokens issued by the previous one.
+func printServiceAccountIssuerChange(out io.Writer, previousIssuer string, cluster *kops.Cluster) {
+	kubeAPIServer := cluster.Spec.KubeAPIServer
+	if previousIssuer == "" || kubeAPIServer == nil || fi.ValueOf(kubeAPIServer.ServiceAccountIssuer) == previousIssuer {
+		return
+	}
+	if slices.Contains(kubeAPIServer.AdditionalServiceAccountIssuers, previousIssuer) {
+		fmt.Fprintf(out, "The service account issuer changes from %q to %q; tokens issued by %q are still accepted.\n", previousIssuer, *kubeAPIServer.ServiceAccountIssuer, previousIssuer)
+		fmt.Fprintf(out, "Once all service account tokens have been reissued, remove it from spec.kubeAPIServer.additionalServiceAccountIssuers.\n\n")
+		return
+	}
+	fmt.Fprintf(out, "The service account issuer changes from %q to %q, which invalidates all service account tokens issued before.\n", previousIssuer, fi.ValueOf(kubeAPIServer.ServiceAccountIssuer))
+	fmt.Fprintf(out, "To migrate without disruption, add %q to spec.kubeAPIServer.additionalServiceAccountIssuers until all tokens have been reissued.\n\n", previousIssuer)
+}
+
+// writeTimings prints the timings summary and writes it as JSON to path, if set.
+func writeTimings(out io.Writer, summary *timings.Summary, print bool, path string) error {
+	if print {
+		fmt.Fprintf(out, "\n")
+		if err := summary.Print(out); err != nil {
+			return fmt.Errorf("error printing timings: %w", err)
+		}
+		fmt.Fprintf(out, "\n")
+	}
+
+	if path != "" {
+		var b bytes.Buffer
+		if err := summary.WriteJSON(&b); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("error writing timings to %q: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	kopsutil "k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/pretty"
@@ -60,6 +61,8 @@ type UpgradeClusterOptions struct {
 	Channel     string
 	// KubernetesVersion is the k8s version to use for upgrade.
 	KubernetesVersion string
+	// AllowKubernetesVersionSkip allows upgrading the Kubernetes version by more than one minor version.
+	AllowKubernetesVersionSkip bool
 }
 
 func NewCmdUpgradeCluster(f *util.Factory, out io.Writer) *cobra.Command {
@@ -84,6 +87,7 @@ func NewCmdUpgradeCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("channel", completeChannel)
 	cmd.Flags().StringVar(&options.KubernetesVersion, "kubernetes-version", "", "Kubernetes version to use for upgrade")
 	cmd.RegisterFlagCompletionFunc("kubernetes-version", completeKubernetesVersion)
+	cmd.Flags().BoolVar(&options.AllowKubernetesVersionSkip, "allow-kubernetes-version-skip", false, "Allow upgrading the Kubernetes version by more than one minor version")
 
 	return cmd
 }
@@ -261,7 +265,7 @@ func RunUpgradeCluster(ctx context.Context, f *util.Factory, out io.Writer, opti
 		action.apply()
 	}
 
-	if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+	if err := commands.UpdateCluster(ctx, clientset, out, cluster, instanceGroups, simple.UpdateClusterOptions{AllowKubernetesVersionSkip: options.AllowKubernetesVersionSkip}); err != nil {
 		return err
 	}
 
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -51,12 +52,20 @@ var (
 		2. All worker nodes are running and have "Ready" status.
 		3. All control plane nodes have the expected pods.
 		4. All pods with a critical priority are running and have "Ready" status.
+		5. No primary keypair certificate expires soon.
+
+		It also warns when the backup store of an etcd cluster cannot be listed or holds
+		no backup newer than --etcd-backup-max-age.
 		`))
 
 	validateClusterExample = templates.Examples(i18n.T(`
 	# Validate the cluster set as the current context of the kube config.
 	# Kops will try for 10 minutes to validate the cluster 3 times.
-	kops validate cluster --wait 10m --count 3`))
+	kops validate cluster --wait 10m --count 3
+
+	# Wait for the cluster to pass validation continuously for 2 minutes,
+	# printing the result of each validation as a line of JSON.
+	kops validate cluster --wait 10m --wait-healthy-duration 2m -o json`))
 
 	validateClusterShort = i18n.T(`Validate a kOps cluster.`)
 )
@@ -68,11 +77,25 @@ type ValidateClusterOptions struct {
 	count       int
 	interval    time.Duration
 	kubeconfig  string
+
+	// waitHealthyDuration is how long the cluster must continuously pass validation
+	waitHealthyDuration time.Duration
+
+	// keypairExpiryWarning is how long before a primary certificate expires that a warning is reported
+	keypairExpiryWarning time.Duration
+	// keypairExpiryFailure is how long before a primary certificate expires that validation fails
+	keypairExpiryFailure time.Duration
+
+	// etcdBackupMaxAge is how old the latest etcd backup can be before a warning is reported
+	etcdBackupMaxAge time.Duration
 }
 
 func (o *ValidateClusterOptions) InitDefaults() {
 	o.output = OutputTable
 	o.interval = 10 * time.Second
+	o.keypairExpiryWarning = validation.DefaultKeypairExpiryWarning
+	o.keypairExpiryFailure = validation.DefaultKeypairExpiryFailure
+	o.etcdBackupMaxAge = validation.DefaultEtcdBackupMaxAge
 }
 
 func NewCmdValidateCluster(f *util.Factory, out io.Writer) *cobra.Command {
@@ -108,7 +131,11 @@ func NewCmdValidateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().DurationVar(&options.wait, "wait", options.wait, "Amount of time to wait for the cluster to become ready")
 	cmd.Flags().IntVar(&options.count, "count", options.count, "Number of consecutive successful validations required")
 	cmd.Flags().DurationVar(&options.interval, "interval", options.interval, "Time in duration to wait between validation attempts")
+	cmd.Flags().DurationVar(&options.waitHealthyDuration, "wait-healthy-duration", options.waitHealthyDuration, "Amount of time the cluster must continuously pass validation, to catch flapping components")
 	cmd.Flags().StringVar(&options.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	cmd.Flags().DurationVar(&options.keypairExpiryWarning, "keypair-expiry-warning", options.keypairExpiryWarning, "Warn when the primary certificate of a keyset expires within this duration")
+	cmd.Flags().DurationVar(&options.keypairExpiryFailure, "keypair-expiry-failure", options.keypairExpiryFailure, "Fail validation when the primary certificate of a keyset expires within this duration")
+	cmd.Flags().DurationVar(&options.etcdBackupMaxAge, "etcd-backup-max-age", options.etcdBackupMaxAge, "Warn when the latest backup of an etcd cluster is older than this duration")
 
 	return cmd
 }
@@ -148,6 +175,17 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 		return nil, fmt.Errorf("no InstanceGroup objects found")
 	}
 
+	keyStore, err := clientSet.KeyStore(cluster)
+	if err != nil {
+		return nil, err
+	}
+	keypairs, err := fi.ListKeypairMetadata(keyStore)
+	if err != nil {
+		return nil, err
+	}
+
+	etcdBackups := validation.FindEtcdBackups(ctx, f.VFSContext(), cluster)
+
 	// TODO: Refactor into util.Factory
 	contextName := cluster.ObjectMeta.Name
 	configLoadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
@@ -166,14 +204,21 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 		return nil, fmt.Errorf("cannot build kubernetes api client for %q: %v", contextName, err)
 	}
 
-	timeout := time.Now().Add(options.wait)
-
 	validator, err := validation.NewClusterValidator(cluster, cloud, list, config.Host, k8sClient)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected error creating validatior: %v", err)
 	}
 
+	return validateClusterUntilHealthy(validator, cluster, instanceGroups, keypairs, etcdBackups, out, options)
+}
+
+// validateClusterUntilHealthy validates the cluster until it passes validation options.count consecutive times
+// and for options.waitHealthyDuration, retrying for up to options.wait.
+func validateClusterUntilHealthy(validator validation.ClusterValidator, cluster *kopsapi.Cluster, instanceGroups []kopsapi.InstanceGroup, keypairs []*fi.KeypairMetadata, etcdBackups []*validation.EtcdBackupStatus, out io.Writer, options *ValidateClusterOptions) (*validation.ValidationCluster, error) {
+	timeout := time.Now().Add(options.wait)
+
 	consecutive := 0
+	var healthySince time.Time
 	for {
 		if options.wait > 0 && time.Now().After(timeout) && consecutive == 0 {
 			return nil, fmt.Errorf("wait time exceeded during validation")
@@ -182,6 +227,7 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 		result, err := validator.Validate()
 		if err != nil {
 			consecutive = 0
+			healthySince = time.Time{}
 			if options.wait > 0 {
 				klog.Warningf("(will retry): unexpected error during validation: %v", err)
 				time.Sleep(options.interval)
@@ -190,6 +236,8 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 				return nil, fmt.Errorf("unexpected error during validation: %v", err)
 			}
 		}
+		result.ValidateKeypairExpiry(keypairs, time.Now(), options.keypairExpiryWarning, options.keypairExpiryFailure)
+		result.ValidateEtcdBackups(etcdBackups, time.Now(), options.etcdBackupMaxAge)
 
 		switch options.output {
 		case OutputTable:
@@ -209,7 +257,8 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 			if err != nil {
 				return nil, fmt.Errorf("unable to marshal JSON: %v", err)
 			}
-			if _, err := out.Write(j); err != nil {
+			// Print one line of JSON per validation, so each result can be parsed separately
+			if _, err := out.Write(append(j, '\n')); err != nil {
 				return nil, fmt.Errorf("error writing to output: %v", err)
 			}
 		default:
@@ -218,6 +267,10 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 
 		if len(result.Failures) == 0 {
 			consecutive++
+			if healthySince.IsZero() {
+				healthySince = time.Now()
+			}
+			healthyFor := time.Since(healthySince)
 			if consecutive < options.count {
 				klog.Infof("(will retry): cluster passed validation %d consecutive times", consecutive)
 				if options.wait > 0 {
@@ -226,6 +279,14 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 				} else {
 					return nil, fmt.Errorf("cluster passed validation %d consecutive times", consecutive)
 				}
+			} else if healthyFor < options.waitHealthyDuration {
+				klog.Infof("(will retry): cluster has passed validation for %v of %v", healthyFor.Round(time.Second), options.waitHealthyDuration)
+				if options.wait > 0 {
+					time.Sleep(options.interval)
+					continue
+				} else {
+					return nil, fmt.Errorf("cluster has passed validation for %v of %v", healthyFor.Round(time.Second), options.waitHealthyDuration)
+				}
 			} else {
 				return result, nil
 			}
@@ -233,6 +294,7 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 			if options.wait > 0 {
 				klog.Warningf("(will retry): cluster not yet healthy")
 				consecutive = 0
+				healthySince = time.Time{}
 				time.Sleep(options.interval)
 				continue
 			} else {
@@ -289,6 +351,24 @@ func validateClusterOutputTable(result *validation.ValidationCluster, cluster *k
 		}
 	}
 
+	if len(result.Warnings) != 0 {
+		warningsTable := &tables.Table{}
+		warningsTable.AddColumn("KIND", func(e *validation.ValidationError) string {
+			return e.Kind
+		})
+		warningsTable.AddColumn("NAME", func(e *validation.ValidationError) string {
+			return e.Name
+		})
+		warningsTable.AddColumn("MESSAGE", func(e *validation.ValidationError) string {
+			return e.Message
+		})
+
+		fmt.Fprintln(out, "\nVALIDATION WARNINGS")
+		if err := warningsTable.Render(result.Warnings, out, "KIND", "NAME", "MESSAGE"); err != nil {
+			return fmt.Errorf("error rendering warnings table: %v", err)
+		}
+	}
+
 	if len(result.Failures) != 0 {
 		failuresTable := &tables.Table{}
 		failuresTable.AddColumn("KIND", func(e *validation.ValidationError) string {
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/validation"
+)
+
+// scriptedValidator returns a failing result for each validation listed in failing, and a passing result otherwise.
+type scriptedValidator struct {
+	failing map[int]bool
+	calls   int
+}
+
+func (v *scriptedValidator) Validate() (*validation.ValidationCluster, error) {
+	call := v.calls
+	v.calls++
+
+	result := &validation.ValidationCluster{
+		InstanceGroups: []*validation.ValidationInstanceGroup{
+			{Name: "nodes", Role: "node", TargetSize: 2, Instances: 2, Nodes: 2, ReadyNodes: 2},
+		},
+	}
+	if v.failing[call] {
+		result.InstanceGroups[0].ReadyNodes = 1
+		result.Failures = []*validation.ValidationError{
+			{
+				Kind:     "Node",
+				Name:     "node-b",
+				Message:  "node \"node-b\" of role \"node\" is not ready",
+				Category: validation.ValidationCategoryNodeNotReady,
+			},
+		}
+	}
+	return result, nil
+}
+
+func TestValidateClusterWaitHealthyDuration(t *testing.T) {
+	grid := []struct {
+		name                string
+		failing             map[int]bool
+		count               int
+		waitHealthyDuration time.Duration
+		expectedCalls       int
+	}{
+		{
+			name:          "healthy",
+			expectedCalls: 1,
+		},
+		{
+			name:          "healthy after failures",
+			failing:       map[int]bool{0: true, 1: true},
+			expectedCalls: 3,
+		},
+		{
+			name:          "consecutive count",
+			failing:       map[int]bool{1: true},
+			count:         2,
+			expectedCalls: 4,
+		},
+		{
+			name:                "healthy duration",
+			waitHealthyDuration: 50 * time.Millisecond,
+			expectedCalls:       6,
+		},
+		{
+			name:                "flapping restarts the healthy duration",
+			failing:             map[int]bool{2: true},
+			waitHealthyDuration: 50 * time.Millisecond,
+			expectedCalls:       9,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			options := &ValidateClusterOptions{}
+			options.InitDefaults()
+			options.output = OutputJSON
+			options.wait = time.Minute
+			options.interval = 10 * time.Millisecond
+			options.count = g.count
+			options.waitHealthyDuration = g.waitHealthyDuration
+
+			validator := &scriptedValidator{failing: g.failing}
+			cluster := &kopsapi.Cluster{}
+			var out bytes.Buffer
+			result, err := validateClusterUntilHealthy(validator, cluster, nil, nil, nil, &out, options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Failures) != 0 {
+				t.Errorf("unexpected failures: %v", result.Failures)
+			}
+			// Timing may add validations, but never remove them
+			if validator.calls < g.expectedCalls {
+				t.Errorf("expected at least %d validations, got %d", g.expectedCalls, validator.calls)
+			}
+
+			// Each validation is printed as a line of JSON
+			lines := 0
+			scanner := bufio.NewScanner(&out)
+			for scanner.Scan() {
+				var printed validation.ValidationCluster
+				if err := json.Unmarshal(scanner.Bytes(), &printed); err != nil {
+					t.Fatalf("error parsing line %d of output: %v", lines, err)
+				}
+				if printed.InstanceGroups[0].TargetSize != 2 {
+					t.Errorf("unexpected instance groups on line %d: %v", lines, printed.InstanceGroups[0])
+				}
+				if g.failing[lines] && (len(printed.Failures) != 1 || printed.Failures[0].Category != validation.ValidationCategoryNodeNotReady) {
+					t.Errorf("unexpected failures on line %d: %v", lines, printed.Failures)
+				}
+				lines++
+			}
+			if lines != validator.calls {
+				t.Errorf("expected %d lines of output, got %d", validator.calls, lines)
+			}
+		})
+	}
+}
+
+func TestValidateClusterWaitHealthyDurationWithoutWait(t *testing.T) {
+	options := &ValidateClusterOptions{}
+	options.InitDefaults()
+	options.output = OutputJSON
+	options.waitHealthyDuration = time.Minute
+
+	var out bytes.Buffer
+	_, err := validateClusterUntilHealthy(&scriptedValidator{}, &kopsapi.Cluster{}, nil, nil, nil, &out, options)
+	if err == nil {
+		t.Fatalf("expected an error when the cluster has not been healthy for long enough")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main // import "k8s.io/kops/cmd/nodeup"
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops"
 	"k8s.io/kops/nodeup/pkg/bootstrap"
+	"k8s.io/kops/nodeup/pkg/rebootrequired"
 	"k8s.io/kops/upup/pkg/fi/nodeup"
 )
 
@@ -36,7 +38,7 @@ const (
 func main() {
 	klog.InitFlags(nil)
 
-	var flagConf, flagCacheDir, gitVersion string
+	var flagConf, flagCacheDir, flagAnnotateRebootRequired, flagKubeconfig, gitVersion string
 	var flagRetries int
 	var dryrun, installSystemdUnit bool
 	target := "direct"
@@ -51,6 +53,8 @@ func main() {
 	flag.BoolVar(&dryrun, "dryrun", false, "Don't create cloud resources; just show what would be done")
 	flag.StringVar(&target, "target", target, "Target - direct, dryrun")
 	flag.BoolVar(&installSystemdUnit, "install-systemd-unit", installSystemdUnit, "If true, will install a systemd unit instead of running directly")
+	flag.StringVar(&flagAnnotateRebootRequired, "annotate-reboot-required", "", "If set, annotates the named node as needing a reboot when "+rebootrequired.MarkerFile+" exists, then exits")
+	flag.StringVar(&flagKubeconfig, "kubeconfig", "", "kubeconfig used with --annotate-reboot-required")
 
 	if dryrun {
 		target = "dryrun"
@@ -63,6 +67,13 @@ func main() {
 		klog.Exitf("--conf is required")
 	}
 
+	if flagAnnotateRebootRequired != "" {
+		if err := rebootrequired.Run(context.Background(), flagKubeconfig, flagAnnotateRebootRequired); err != nil {
+			klog.Exitf("error annotating node: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	retries := flagRetries
 
 	for {
@@ -33,6 +33,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -77,6 +78,9 @@ type NodeupModelContext struct {
 	bootstrapCerts      map[string]*nodetasks.BootstrapCert
 	bootstrapKeypairIDs map[string]string
 
+	// instanceMetadata overrides the EC2 instance metadata service, for tests
+	instanceMetadata instanceMetadata
+
 	// ConfigurationMode determines if we are prewarming an instance or running it live
 	ConfigurationMode string
 	InstanceID        string
@@ -221,6 +225,28 @@ func (c *NodeupModelContext) KubeletKubeConfig() string {
 	return "/var/lib/kubelet/kubeconfig"
 }
 
+// UseKubeletCA returns true if the kubelet-ca keyset secures the connection between kube-apiserver and the kubelets.
+func (c *NodeupModelContext) UseKubeletCA() bool {
+	return c.NodeupConfig.KeypairIDs[kubeletca.KeysetName] != ""
+}
+
+// KubeletCASigner returns the keyset that signs the kubelet serving certificates and the kube-apiserver kubelet client certificate.
+func (c *NodeupModelContext) KubeletCASigner() string {
+	if c.UseKubeletCA() {
+		return kubeletca.KeysetName
+	}
+	return fi.CertificateIDCA
+}
+
+// KubeletTrustBundle returns the certificates trusted on both ends of the connection between kube-apiserver and the kubelets.
+func (c *NodeupModelContext) KubeletTrustBundle() (fi.Resource, error) {
+	bundle, err := kubeletca.TrustBundle(c.NodeupConfig.CAs[fi.CertificateIDCA], c.NodeupConfig.CAs[kubeletca.KeysetName])
+	if err != nil {
+		return nil, fmt.Errorf("building %s trust bundle: %w", kubeletca.KeysetName, err)
+	}
+	return fi.NewStringResource(bundle), nil
+}
+
 // BuildIssuedKubeconfig generates a kubeconfig with a locally issued client certificate.
 func (c *NodeupModelContext) BuildIssuedKubeconfig(name string, subject nodetasks.PKIXName, ctx *fi.NodeupModelBuilderContext) *fi.NodeupTaskDependentResource {
 	issueCert := &nodetasks.IssueCert{
@@ -607,6 +633,48 @@ func (c *NodeupModelContext) GetMetadataLocalIP() (string, error) {
 	return internalIP, nil
 }
 
+// instanceMetadata reads values from the EC2 instance metadata service.
+type instanceMetadata interface {
+	GetMetadata(p string) (string, error)
+}
+
+// GetMetadataNodeIPs returns the addresses of the instance for each of the node address families, in order
+func (c *NodeupModelContext) GetMetadataNodeIPs() ([]string, error) {
+	if c.BootConfig.CloudProvider != kops.CloudProviderAWS {
+		return nil, fmt.Errorf("getting node IPs from metadata is not supported for cloud provider: %q", c.BootConfig.CloudProvider)
+	}
+
+	metadata := c.instanceMetadata
+	if metadata == nil {
+		sess := session.Must(session.NewSession())
+		metadata = ec2metadata.New(sess)
+	}
+
+	var nodeIPs []string
+	for _, family := range c.NodeupConfig.NodeAddressFamilies {
+		var key string
+		switch family {
+		case kops.IPFamilyIPv4:
+			key = "local-ipv4"
+		case kops.IPFamilyIPv6:
+			key = "ipv6"
+		default:
+			return nil, fmt.Errorf("unknown IP family %q", family)
+		}
+
+		ip, err := metadata.GetMetadata(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s address from ec2 metadata: %w", family, err)
+		}
+		if ip == "" {
+			return nil, fmt.Errorf("instance has no %s address", family)
+		}
+		nodeIPs = append(nodeIPs, ip)
+	}
+
+	return nodeIPs, nil
+}
+
 func (c *NodeupModelContext) findStaticManifest(key string) *nodeup.StaticManifest {
 	if c == nil || c.NodeupConfig == nil {
 		return nil
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+const journaldConfigPath = "/etc/systemd/journald.conf.d/90-kops.conf"
+
+// JournaldBuilder limits the disk space used by the systemd journal.
+type JournaldBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &JournaldBuilder{}
+
+// Build is responsible for writing the journald drop-in of the instance group.
+func (b *JournaldBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	maxUse := b.NodeupConfig.JournaldMaxUse
+	if maxUse == nil {
+		return nil
+	}
+
+	// journald reads suffixes as powers of 1024, so the size is rendered in bytes to keep the meaning of the quantity
+	contents := fmt.Sprintf("[Journal]\nSystemMaxUse=%d\n", maxUse.Value())
+
+	c.AddTask(&nodetasks.File{
+		Path:            journaldConfigPath,
+		Contents:        fi.NewStringResource(contents),
+		Type:            nodetasks.FileType_File,
+		Mode:            s("0644"),
+		OnChangeExecute: [][]string{{"systemctl", "restart", "systemd-journald.service"}},
+	})
+
+	return nil
+}
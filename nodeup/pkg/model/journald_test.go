@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+func TestJournaldBuilder(t *testing.T) {
+	grid := []struct {
+		name             string
+		maxUse           string
+		expectedContents string
+	}{
+		{
+			name: "no limit",
+		},
+		{
+			name:             "binary suffix",
+			maxUse:           "2Gi",
+			expectedContents: "[Journal]\nSystemMaxUse=2147483648\n",
+		},
+		{
+			name:             "decimal suffix",
+			maxUse:           "500M",
+			expectedContents: "[Journal]\nSystemMaxUse=500000000\n",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			config := &nodeup.Config{}
+			if g.maxUse != "" {
+				config.JournaldMaxUse = fi.PtrTo(resource.MustParse(g.maxUse))
+			}
+			b := &JournaldBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					NodeupConfig: config,
+				},
+			}
+
+			c := &fi.NodeupModelBuilderContext{
+				Tasks: make(map[string]fi.NodeupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error from Build: %v", err)
+			}
+
+			if g.maxUse == "" {
+				if len(c.Tasks) != 0 {
+					t.Fatalf("unexpected tasks without a journal limit, got %v", c.Tasks)
+				}
+				return
+			}
+
+			task, found := c.Tasks["File/"+journaldConfigPath]
+			if !found {
+				t.Fatalf("expected the journald drop-in, got tasks %v", c.Tasks)
+			}
+			file := task.(*nodetasks.File)
+			contents, err := fi.ResourceAsString(file.Contents)
+			if err != nil {
+				t.Fatalf("reading journald drop-in: %v", err)
+			}
+			if contents != g.expectedContents {
+				t.Errorf("unexpected journald drop-in, expected:\n%s\ngot:\n%s", g.expectedContents, contents)
+			}
+			expectedOnChange := [][]string{{"systemctl", "restart", "systemd-journald.service"}}
+			if !reflect.DeepEqual(file.OnChangeExecute, expectedOnChange) {
+				t.Errorf("unexpected OnChangeExecute %v", file.OnChangeExecute)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package model
 import (
 	"path/filepath"
 
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/wellknownusers"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -86,6 +87,9 @@ func (b *KopsControllerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 	if b.NodeupConfig.UseCiliumEtcd {
 		caList = append(caList, "etcd-clients-ca-cilium")
 	}
+	if b.UseKubeletCA() {
+		caList = append(caList, kubeletca.KeysetName)
+	}
 	for _, cert := range caList {
 		owner := wellknownusers.KopsControllerName
 		err := b.BuildCertificatePairTask(c, cert, pkiDir, cert, &owner, nil)
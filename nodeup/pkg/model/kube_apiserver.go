@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -77,6 +78,14 @@ func (b *KubeAPIServerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		}
 	}
 
+	if kubeAPIServer.AdvertiseAddress == "" && len(b.NodeupConfig.NodeAddressFamilies) > 0 {
+		nodeIPs, err := b.GetMetadataNodeIPs()
+		if err != nil {
+			return err
+		}
+		kubeAPIServer.AdvertiseAddress = nodeIPs[0]
+	}
+
 	b.configureOIDC(&kubeAPIServer)
 	if err := b.writeAuthenticationConfig(c, &kubeAPIServer); err != nil {
 		return err
@@ -470,8 +479,8 @@ func (b *KubeAPIServerBuilder) writeKubeletAPICertificate(c *fi.NodeupModelBuild
 
 	issueCert := &nodetasks.IssueCert{
 		Name:      "kubelet-api",
-		Signer:    fi.CertificateIDCA,
-		KeypairID: b.NodeupConfig.KeypairIDs[fi.CertificateIDCA],
+		Signer:    b.KubeletCASigner(),
+		KeypairID: b.NodeupConfig.KeypairIDs[b.KubeletCASigner()],
 		Type:      "client",
 		Subject:   nodetasks.PKIXName{CommonName: "kubelet-api"},
 	}
@@ -485,6 +494,20 @@ func (b *KubeAPIServerBuilder) writeKubeletAPICertificate(c *fi.NodeupModelBuild
 	kubeAPIServer.KubeletClientCertificate = filepath.Join(pathSrvKAPI, "kubelet-api.crt")
 	kubeAPIServer.KubeletClientKey = filepath.Join(pathSrvKAPI, "kubelet-api.key")
 
+	if b.UseKubeletCA() && kubeAPIServer.KubeletCertificateAuthority == "" {
+		bundle, err := b.KubeletTrustBundle()
+		if err != nil {
+			return err
+		}
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(pathSrvKAPI, "kubelet-ca.crt"),
+			Contents: bundle,
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0644"),
+		})
+		kubeAPIServer.KubeletCertificateAuthority = filepath.Join(pathSrvKAPI, "kubelet-ca.crt")
+	}
+
 	return nil
 }
 
@@ -573,6 +596,8 @@ func (b *KubeAPIServerBuilder) buildPod(ctx context.Context, kubeAPIServer *kops
 		return nil, fmt.Errorf("error building kube-apiserver flags: %v", err)
 	}
 
+	flags = addServiceAccountIssuerFlags(flags, kubeAPIServer.AdditionalServiceAccountIssuers)
+
 	flags = append(flags, fmt.Sprintf("--cloud-config=%s", InTreeCloudConfigFilePath))
 
 	pod := &v1.Pod{
@@ -755,3 +780,23 @@ func (b *KubeAPIServerBuilder) buildAnnotations() map[string]string {
 
 	return annotations
 }
+
+// addServiceAccountIssuerFlags adds a --service-account-issuer flag for each additional issuer.
+// The flags are sorted, but kube-apiserver signs tokens with the first issuer, so they are added after the primary one.
+func addServiceAccountIssuerFlags(flags []string, additionalIssuers []string) []string {
+	if len(additionalIssuers) == 0 {
+		return flags
+	}
+
+	var issuerFlags []string
+	for _, issuer := range additionalIssuers {
+		issuerFlags = append(issuerFlags, "--service-account-issuer="+issuer)
+	}
+
+	for i, flag := range flags {
+		if strings.HasPrefix(flag, "--service-account-issuer=") {
+			return slices.Insert(flags, i+1, issuerFlags...)
+		}
+	}
+	return append(flags, issuerFlags...)
+}
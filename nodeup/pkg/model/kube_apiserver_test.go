@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"reflect"
 	"testing"
 
 	"k8s.io/kops/pkg/apis/kops"
@@ -141,6 +142,46 @@ func Test_KubeAPIServer_BuildFlags(t *testing.T) {
 	}
 }
 
+func Test_AddServiceAccountIssuerFlags(t *testing.T) {
+	grid := []struct {
+		config   kops.KubeAPIServerConfig
+		expected []string
+	}{
+		{
+			kops.KubeAPIServerConfig{
+				ServiceAccountIssuer: fi.PtrTo("https://new.example.com"),
+			},
+			[]string{"--secure-port=0", "--service-account-issuer=https://new.example.com"},
+		},
+		{
+			kops.KubeAPIServerConfig{
+				ServiceAccountIssuer:            fi.PtrTo("https://new.example.com"),
+				AdditionalServiceAccountIssuers: []string{"https://old.example.com", "https://api.internal.example.com"},
+				ServiceAccountJWKSURI:           fi.PtrTo("https://new.example.com/openid/v1/jwks"),
+			},
+			[]string{
+				"--secure-port=0",
+				"--service-account-issuer=https://new.example.com",
+				"--service-account-issuer=https://old.example.com",
+				"--service-account-issuer=https://api.internal.example.com",
+				"--service-account-jwks-uri=https://new.example.com/openid/v1/jwks",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		flags, err := flagbuilder.BuildFlagsList(&g.config)
+		if err != nil {
+			t.Errorf("error building flags for %v: %v", g.config, err)
+			continue
+		}
+		actual := addServiceAccountIssuerFlags(flags, g.config.AdditionalServiceAccountIssuers)
+		if !reflect.DeepEqual(actual, g.expected) {
+			t.Errorf("flags did not match.  actual=%q expected=%q", actual, g.expected)
+		}
+	}
+}
+
 func TestKubeAPIServerBuilder(t *testing.T) {
 	RunGoldenTest(t, "tests/golden/minimal", "kube-apiserver", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
 		builder := KubeAPIServerBuilder{NodeupModelContext: nodeupModelContext}
@@ -72,6 +72,20 @@ func (b *KubeletBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		return fmt.Errorf("error building kubelet config: %v", err)
 	}
 
+	if b.UseKubeletCA() {
+		bundle, err := b.KubeletTrustBundle()
+		if err != nil {
+			return err
+		}
+		c.AddTask(&nodetasks.File{
+			Path:           kubeletConfig.ClientCAFile,
+			Contents:       bundle,
+			Type:           nodetasks.FileType_File,
+			Mode:           s("0644"),
+			BeforeServices: []string{"kubelet.service"},
+		})
+	}
+
 	{
 		t, err := buildKubeletComponentConfig(kubeletConfig)
 		if err != nil {
@@ -308,7 +322,13 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(kubeletConfig *kops.Kubelet
 	// We can always add this later if it is needed.
 	flags += " --cloud-config=" + InTreeCloudConfigFilePath
 
-	if b.UsesSecondaryIP() {
+	if len(b.NodeupConfig.NodeAddressFamilies) > 0 {
+		nodeIPs, err := b.GetMetadataNodeIPs()
+		if err != nil {
+			return nil, err
+		}
+		flags += " --node-ip=" + strings.Join(nodeIPs, ",")
+	} else if b.UsesSecondaryIP() {
 		localIP, err := b.GetMetadataLocalIP()
 		if err != nil {
 			return nil, err
@@ -334,7 +354,7 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(kubeletConfig *kops.Kubelet
 	flags += " --tls-cert-file=" + b.PathSrvKubernetes() + "/kubelet-server.crt"
 	flags += " --tls-private-key-file=" + b.PathSrvKubernetes() + "/kubelet-server.key"
 
-	if b.IsIPv6Only() {
+	if b.IsIPv6Only() && len(b.NodeupConfig.NodeAddressFamilies) == 0 {
 		flags += " --node-ip=::"
 	}
 
@@ -618,6 +638,10 @@ func (b *KubeletBuilder) buildKubeletConfigSpec() (*kops.KubeletConfigSpec, erro
 	c := b.NodeupConfig.KubeletConfig
 
 	c.ClientCAFile = filepath.Join(b.PathSrvKubernetes(), "ca.crt")
+	if b.UseKubeletCA() {
+		// Trusts the kube-apiserver kubelet client certificate through every phase of a kubelet-ca rotation
+		c.ClientCAFile = filepath.Join(b.PathSrvKubernetes(), "kubelet-ca.crt")
+	}
 
 	if b.NodeupConfig.Networking.AmazonVPC != nil {
 		sess := session.Must(session.NewSession())
@@ -721,7 +745,7 @@ func (b *KubeletBuilder) buildKubeletServingCertificate(c *fi.NodeupModelBuilder
 	}
 
 	if !b.HasAPIServer {
-		cert, key, err := b.GetBootstrapCert(name, fi.CertificateIDCA)
+		cert, key, err := b.GetBootstrapCert(name, b.KubeletCASigner())
 		if err != nil {
 			return err
 		}
@@ -745,8 +769,8 @@ func (b *KubeletBuilder) buildKubeletServingCertificate(c *fi.NodeupModelBuilder
 	} else {
 		issueCert := &nodetasks.IssueCert{
 			Name:      name,
-			Signer:    fi.CertificateIDCA,
-			KeypairID: b.NodeupConfig.KeypairIDs[fi.CertificateIDCA],
+			Signer:    b.KubeletCASigner(),
+			KeypairID: b.NodeupConfig.KeypairIDs[b.KubeletCASigner()],
 			Type:      "server",
 			Subject: nodetasks.PKIXName{
 				CommonName: names[0],
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,8 +49,8 @@ func TestTaintsApplied(t *testing.T) {
 	}{
 		{
 			version:      "1.28.0",
-			taints:       []string{"foo", "bar", "baz"},
-			expectTaints: []string{"foo", "bar", "baz", "node-role.kubernetes.io/control-plane=:NoSchedule"},
+			taints:       []string{"foo:NoSchedule", "bar=qux:PreferNoSchedule", "baz:NoExecute"},
+			expectTaints: []string{"foo:NoSchedule", "bar=qux:PreferNoSchedule", "baz:NoExecute", "node-role.kubernetes.io/control-plane=:NoSchedule"},
 		},
 	}
 
@@ -411,3 +412,168 @@ func Test_BuildComponentConfigFile(t *testing.T) {
 		t.Errorf("Failed to build component config file: %v", err)
 	}
 }
+
+func Test_KubeletSwap(t *testing.T) {
+	kubeletConfig := &kops.KubeletConfigSpec{
+		FailSwapOn:         fi.PtrTo(false),
+		MemorySwapBehavior: kops.SwapBehaviorLimitedSwap,
+	}
+
+	file, err := buildKubeletComponentConfig(kubeletConfig)
+	if err != nil {
+		t.Fatalf("error building kubelet component config: %v", err)
+	}
+	config, err := fi.ResourceAsString(file.Contents)
+	if err != nil {
+		t.Fatalf("error reading kubelet component config: %v", err)
+	}
+	if !strings.Contains(config, "memorySwap:\n  swapBehavior: LimitedSwap\n") {
+		t.Errorf("expected the swap behavior in the kubelet component config, got:\n%s", config)
+	}
+
+	b := &KubeletBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			BootConfig: &nodeup.BootConfig{
+				CloudProvider: kops.CloudProviderAWS,
+			},
+			NodeupConfig: &nodeup.Config{
+				KubernetesVersion: "1.30.0",
+				ContainerdConfig:  &kops.ContainerdConfig{},
+			},
+		},
+	}
+	if err := b.Init(); err != nil {
+		t.Fatalf("error initializing context: %v", err)
+	}
+	file, err = b.buildSystemdEnvironmentFile(kubeletConfig)
+	if err != nil {
+		t.Fatalf("error building kubelet environment file: %v", err)
+	}
+	contents, err := fi.ResourceAsString(file.Contents)
+	if err != nil {
+		t.Fatalf("error reading kubelet environment file: %v", err)
+	}
+	if !strings.Contains(contents, "--fail-swap-on=false") {
+		t.Errorf("expected kubelet flags to contain --fail-swap-on=false, got %q", contents)
+	}
+}
+
+// fakeInstanceMetadata serves instance metadata from a map.
+type fakeInstanceMetadata map[string]string
+
+func (m fakeInstanceMetadata) GetMetadata(p string) (string, error) {
+	v, found := m[p]
+	if !found {
+		return "", fmt.Errorf("metadata path %q not found", p)
+	}
+	return v, nil
+}
+
+func Test_KubeletNodeIPFamilies(t *testing.T) {
+	metadata := fakeInstanceMetadata{
+		"local-ipv4": "10.0.0.10",
+		"ipv6":       "2001:db8::10",
+	}
+
+	tests := []struct {
+		name              string
+		families          []string
+		nonMasqueradeCIDR string
+		expectedNodeIPs   []string
+		expectedFlag      string
+	}{
+		{
+			name:              "v4-only",
+			families:          []string{"ipv4"},
+			nonMasqueradeCIDR: "100.64.0.0/10",
+			expectedNodeIPs:   []string{"10.0.0.10"},
+			expectedFlag:      "--node-ip=10.0.0.10 ",
+		},
+		{
+			name:              "v6-only",
+			families:          []string{"ipv6"},
+			nonMasqueradeCIDR: "::/0",
+			expectedNodeIPs:   []string{"2001:db8::10"},
+			expectedFlag:      "--node-ip=2001:db8::10 ",
+		},
+		{
+			name:              "v4-primary",
+			families:          []string{"ipv4", "ipv6"},
+			nonMasqueradeCIDR: "100.64.0.0/10",
+			expectedNodeIPs:   []string{"10.0.0.10", "2001:db8::10"},
+			expectedFlag:      "--node-ip=10.0.0.10,2001:db8::10 ",
+		},
+		{
+			name:              "v6-primary",
+			families:          []string{"ipv6", "ipv4"},
+			nonMasqueradeCIDR: "::/0",
+			expectedNodeIPs:   []string{"2001:db8::10", "10.0.0.10"},
+			expectedFlag:      "--node-ip=2001:db8::10,10.0.0.10 ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &KubeletBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					BootConfig: &nodeup.BootConfig{
+						CloudProvider: kops.CloudProviderAWS,
+					},
+					NodeupConfig: &nodeup.Config{
+						KubernetesVersion:   "1.29.0",
+						ContainerdConfig:    &kops.ContainerdConfig{},
+						NodeAddressFamilies: test.families,
+						Networking: kops.NetworkingSpec{
+							NonMasqueradeCIDR: test.nonMasqueradeCIDR,
+						},
+					},
+					instanceMetadata: metadata,
+				},
+			}
+			if err := b.Init(); err != nil {
+				t.Fatalf("error initializing context: %v", err)
+			}
+
+			nodeIPs, err := b.GetMetadataNodeIPs()
+			if err != nil {
+				t.Fatalf("error getting node IPs: %v", err)
+			}
+			if fmt.Sprintf("%v", nodeIPs) != fmt.Sprintf("%v", test.expectedNodeIPs) {
+				t.Errorf("unexpected node IPs: expected %v, got %v", test.expectedNodeIPs, nodeIPs)
+			}
+
+			file, err := b.buildSystemdEnvironmentFile(&kops.KubeletConfigSpec{})
+			if err != nil {
+				t.Fatalf("error building kubelet environment file: %v", err)
+			}
+			contents, err := fi.ResourceAsString(file.Contents)
+			if err != nil {
+				t.Fatalf("error reading kubelet environment file: %v", err)
+			}
+			if !strings.Contains(contents, test.expectedFlag) {
+				t.Errorf("expected kubelet flags to contain %q, got %q", test.expectedFlag, contents)
+			}
+			if strings.Count(contents, "--node-ip=") != 1 {
+				t.Errorf("expected a single --node-ip flag, got %q", contents)
+			}
+		})
+	}
+}
+
+func Test_KubeletNodeIPFamiliesMissingAddress(t *testing.T) {
+	b := &NodeupModelContext{
+		BootConfig: &nodeup.BootConfig{
+			CloudProvider: kops.CloudProviderAWS,
+		},
+		NodeupConfig: &nodeup.Config{
+			NodeAddressFamilies: []string{"ipv4", "ipv6"},
+		},
+		instanceMetadata: fakeInstanceMetadata{
+			"local-ipv4": "10.0.0.10",
+		},
+	}
+
+	if _, err := b.GetMetadataNodeIPs(); err == nil {
+		t.Errorf("expected an error for an instance without an IPv6 address")
+	}
+}
@@ -17,10 +17,19 @@ limitations under the License.
 package model
 
 import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 )
 
+const (
+	migPartedConfigPath  = "/etc/nvidia-mig-manager/config.yaml"
+	migPartedServiceName = "nvidia-mig-parted.service"
+)
+
 // NvidiaBuilder installs the Nvidia driver and runtime.
 type NvidiaBuilder struct {
 	*NodeupModelContext
@@ -41,5 +50,50 @@ func (b *NvidiaBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		c.AddTask(&nodetasks.Package{Name: "nvidia-container-toolkit"})
 		c.AddTask(&nodetasks.Package{Name: b.NodeupConfig.NvidiaGPU.DriverPackage})
 	}
+
+	if b.NodeupConfig.GPUConfig != nil && b.NodeupConfig.GPUConfig.MIGProfile != "" {
+		b.buildMIGConfiguration(c)
+	}
 	return nil
 }
+
+// buildMIGConfiguration partitions all the GPUs into MIG devices of the configured profile before the kubelet starts.
+// The nvidia-mig-parted binary is expected to be provided by the image.
+func (b *NvidiaBuilder) buildMIGConfiguration(c *fi.NodeupModelBuilderContext) {
+	gpuConfig := b.NodeupConfig.GPUConfig
+	configName := gpuConfig.MIGConfigName()
+
+	contents := fmt.Sprintf(`version: v1
+mig-configs:
+  %s:
+  - devices: all
+    mig-enabled: true
+    mig-devices:
+      %s: %d
+`, configName, gpuConfig.MIGProfile, gpuConfig.MIGDevicesPerGPU())
+
+	c.AddTask(&nodetasks.File{
+		Path:     migPartedConfigPath,
+		Contents: fi.NewStringResource(contents),
+		Type:     nodetasks.FileType_File,
+	})
+
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Apply the NVIDIA MIG configuration")
+	manifest.Set("Unit", "Documentation", "https://github.com/NVIDIA/mig-parted")
+	manifest.Set("Unit", "Before", "kubelet.service")
+	manifest.Set("Service", "Type", "oneshot")
+	manifest.Set("Service", "RemainAfterExit", "yes")
+	manifest.Set("Service", "ExecStart", "/usr/bin/nvidia-mig-parted apply -f "+migPartedConfigPath+" -c "+configName)
+	manifest.Set("Install", "WantedBy", "multi-user.target")
+
+	manifestString := manifest.Render()
+	klog.V(8).Infof("Built service manifest %q\n%s", migPartedServiceName, manifestString)
+
+	service := &nodetasks.Service{
+		Name:       migPartedServiceName,
+		Definition: s(manifestString),
+	}
+	service.InitDefaults()
+	c.AddTask(service)
+}
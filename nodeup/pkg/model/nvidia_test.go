@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+func TestNvidiaBuilderMIGConfiguration(t *testing.T) {
+	grid := []struct {
+		name           string
+		gpuConfig      *kops.GPUConfigSpec
+		expectedConfig string
+		expectedExec   string
+	}{
+		{
+			name: "no gpu config",
+		},
+		{
+			name:      "a100 1g.5gb",
+			gpuConfig: &kops.GPUConfigSpec{MIGProfile: "1g.5gb"},
+			expectedConfig: `version: v1
+mig-configs:
+  all-1g.5gb:
+  - devices: all
+    mig-enabled: true
+    mig-devices:
+      1g.5gb: 7
+`,
+			expectedExec: "ExecStart=/usr/bin/nvidia-mig-parted apply -f /etc/nvidia-mig-manager/config.yaml -c all-1g.5gb",
+		},
+		{
+			name:      "h100 3g.40gb",
+			gpuConfig: &kops.GPUConfigSpec{MIGProfile: "3g.40gb"},
+			expectedConfig: `version: v1
+mig-configs:
+  all-3g.40gb:
+  - devices: all
+    mig-enabled: true
+    mig-devices:
+      3g.40gb: 2
+`,
+			expectedExec: "ExecStart=/usr/bin/nvidia-mig-parted apply -f /etc/nvidia-mig-manager/config.yaml -c all-3g.40gb",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			b := &NvidiaBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					Distribution: distributions.DistributionUbuntu2204,
+					NodeupConfig: &nodeup.Config{
+						GPUConfig: g.gpuConfig,
+					},
+				},
+			}
+
+			c := &fi.NodeupModelBuilderContext{
+				Tasks: make(map[string]fi.NodeupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error from Build: %v", err)
+			}
+
+			file, foundFile := c.Tasks["File/"+migPartedConfigPath]
+			service, foundService := c.Tasks["Service/"+migPartedServiceName]
+			if g.gpuConfig == nil {
+				if foundFile || foundService {
+					t.Fatalf("unexpected MIG configuration without a GPU configuration, got tasks %v", c.Tasks)
+				}
+				return
+			}
+			if !foundFile || !foundService {
+				t.Fatalf("expected the mig-parted configuration and service, got tasks %v", c.Tasks)
+			}
+
+			config, err := fi.ResourceAsString(file.(*nodetasks.File).Contents)
+			if err != nil {
+				t.Fatalf("reading mig-parted configuration: %v", err)
+			}
+			if config != g.expectedConfig {
+				t.Errorf("unexpected mig-parted configuration, expected:\n%s\ngot:\n%s", g.expectedConfig, config)
+			}
+
+			definition := fi.ValueOf(service.(*nodetasks.Service).Definition)
+			if !strings.Contains(definition, g.expectedExec) {
+				t.Errorf("expected service to contain %q, got:\n%s", g.expectedExec, definition)
+			}
+			if !strings.Contains(definition, "Before=kubelet.service") {
+				t.Errorf("expected service to run before the kubelet, got:\n%s", definition)
+			}
+		})
+	}
+}
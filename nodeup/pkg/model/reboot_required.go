@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/nodeup/pkg/rebootrequired"
+	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+const (
+	rebootRequiredUnitName = "kops-reboot-required"
+	nodeupBinaryPath       = "/opt/kops/bin/nodeup"
+)
+
+// RebootRequiredBuilder installs the units that annotate the node when the OS requests a reboot,
+// so that a reboot-only rolling update picks it up.
+type RebootRequiredBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &RebootRequiredBuilder{}
+
+// Build is responsible for watching the reboot-required marker file of Debian based distributions.
+func (b *RebootRequiredBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if !b.Distribution.IsDebianFamily() {
+		return nil
+	}
+
+	nodeName, err := b.NodeName()
+	if err != nil {
+		return err
+	}
+
+	// The service runs once each time the path unit sees the marker file, which is removed by the reboot
+	serviceManifest := &systemd.Manifest{}
+	serviceManifest.Set("Unit", "Description", "Annotate the node as needing a reboot")
+	serviceManifest.Set("Unit", "Documentation", "https://github.com/kubernetes/kops")
+	serviceManifest.Set("Unit", "After", "kubelet.service")
+	serviceManifest.Set("Service", "Type", "oneshot")
+	serviceManifest.Set("Service", "ExecStart", fmt.Sprintf("%s --annotate-reboot-required=%s --kubeconfig=%s", nodeupBinaryPath, nodeName, b.KubeletKubeConfig()))
+	serviceManifest.Set("Service", "Restart", "on-failure")
+	serviceManifest.Set("Service", "RestartSec", "60")
+
+	serviceManifestString := serviceManifest.Render()
+	klog.V(8).Infof("Built service manifest %q\n%s", rebootRequiredUnitName+".service", serviceManifestString)
+
+	service := &nodetasks.Service{
+		Name:       rebootRequiredUnitName + ".service",
+		Definition: s(serviceManifestString),
+	}
+	service.InitDefaults()
+	// The service is started by the path unit
+	service.ManageState = fi.PtrTo(false)
+	c.AddTask(service)
+
+	pathManifest := &systemd.Manifest{}
+	pathManifest.Set("Unit", "Description", "Watch for the OS requesting a reboot")
+	pathManifest.Set("Unit", "Documentation", "https://github.com/kubernetes/kops")
+	pathManifest.Set("Path", "PathExists", rebootrequired.MarkerFile)
+	pathManifest.Set("Install", "WantedBy", "multi-user.target")
+
+	pathManifestString := pathManifest.Render()
+	klog.V(8).Infof("Built path manifest %q\n%s", rebootRequiredUnitName+".path", pathManifestString)
+
+	path := &nodetasks.Service{
+		Name:       rebootRequiredUnitName + ".path",
+		Definition: s(pathManifestString),
+	}
+	path.InitDefaults()
+	c.AddTask(path)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+func TestRebootRequiredBuilder(t *testing.T) {
+	grid := []struct {
+		name         string
+		distribution distributions.Distribution
+		expectUnits  bool
+	}{
+		{
+			name:         "ubuntu",
+			distribution: distributions.DistributionUbuntu2204,
+			expectUnits:  true,
+		},
+		{
+			name:         "debian",
+			distribution: distributions.DistributionDebian12,
+			expectUnits:  true,
+		},
+		{
+			name:         "flatcar",
+			distribution: distributions.DistributionFlatcar,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			b := &RebootRequiredBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					Distribution: g.distribution,
+					NodeupConfig: &nodeup.Config{
+						KubeletConfig: kops.KubeletConfigSpec{
+							HostnameOverride: "node-1.example.com",
+						},
+					},
+				},
+			}
+
+			c := &fi.NodeupModelBuilderContext{
+				Tasks: make(map[string]fi.NodeupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error from Build: %v", err)
+			}
+
+			service, foundService := c.Tasks["Service/kops-reboot-required.service"]
+			path, foundPath := c.Tasks["Service/kops-reboot-required.path"]
+			if !g.expectUnits {
+				if len(c.Tasks) != 0 {
+					t.Fatalf("unexpected tasks for %s, got %v", g.name, c.Tasks)
+				}
+				return
+			}
+			if !foundService || !foundPath {
+				t.Fatalf("expected the reboot-required service and path units, got tasks %v", c.Tasks)
+			}
+
+			if fi.ValueOf(service.(*nodetasks.Service).ManageState) {
+				t.Errorf("expected the reboot-required service to be started by its path unit only")
+			}
+			definition := fi.ValueOf(service.(*nodetasks.Service).Definition)
+			for _, expected := range []string{
+				"Type=oneshot",
+				"ExecStart=/opt/kops/bin/nodeup --annotate-reboot-required=node-1.example.com --kubeconfig=/var/lib/kubelet/kubeconfig",
+			} {
+				if !strings.Contains(definition, expected) {
+					t.Errorf("expected service to contain %q, got:\n%s", expected, definition)
+				}
+			}
+
+			definition = fi.ValueOf(path.(*nodetasks.Service).Definition)
+			for _, expected := range []string{
+				"PathExists=/var/run/reboot-required",
+				"WantedBy=multi-user.target",
+			} {
+				if !strings.Contains(definition, expected) {
+					t.Errorf("expected path unit to contain %q, got:\n%s", expected, definition)
+				}
+			}
+		})
+	}
+}
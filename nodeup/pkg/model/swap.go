@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+const (
+	swapFilePath        = "/var/swapfile"
+	swapSetupScriptPath = "/opt/kops/bin/kops-swap-setup"
+	swapServiceName     = "kops-swap.service"
+)
+
+// SwapBuilder creates and enables the swap file of the instance group.
+type SwapBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &SwapBuilder{}
+
+// Build is responsible for enabling swap before the kubelet starts.
+func (b *SwapBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	swap := b.NodeupConfig.Swap
+	if swap == nil {
+		return nil
+	}
+
+	// The size is computed on the node, because the memory of the instance types of an instance group can differ
+	var size string
+	switch {
+	case swap.SizeMB != nil:
+		size = fmt.Sprintf("%d", *swap.SizeMB)
+	case swap.PercentOfMemory != nil:
+		size = fmt.Sprintf("$(( $(awk '/^MemTotal:/ { print $2 }' /proc/meminfo) * %d / 100 / 1024 ))", *swap.PercentOfMemory)
+	default:
+		return fmt.Errorf("swap must set either sizeMB or percentOfMemory")
+	}
+
+	script := `#!/bin/bash
+# Built by kOps - do not edit
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SWAP_FILE={{.SwapFile}}
+SIZE_MB={{.Size}}
+
+if [[ ! -f "${SWAP_FILE}" ]] || [[ "$(stat -c %s "${SWAP_FILE}")" -ne "$(( SIZE_MB * 1024 * 1024 ))" ]]; then
+  swapoff "${SWAP_FILE}" 2>/dev/null || true
+  rm -f "${SWAP_FILE}"
+  # The swap file must only be readable by root
+  (umask 077; fallocate -l "${SIZE_MB}M" "${SWAP_FILE}" || dd if=/dev/zero of="${SWAP_FILE}" bs=1M count="${SIZE_MB}")
+  chmod 0600 "${SWAP_FILE}"
+  mkswap "${SWAP_FILE}"
+fi
+
+chmod 0600 "${SWAP_FILE}"
+if ! swapon --show=NAME --noheadings | grep -qxF "${SWAP_FILE}"; then
+  swapon "${SWAP_FILE}"
+fi
+`
+	script = strings.ReplaceAll(script, "{{.SwapFile}}", swapFilePath)
+	script = strings.ReplaceAll(script, "{{.Size}}", size)
+
+	c.AddTask(&nodetasks.File{
+		Path:     swapSetupScriptPath,
+		Contents: fi.NewStringResource(script),
+		Type:     nodetasks.FileType_File,
+		Mode:     s("0755"),
+	})
+
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Enable the swap file for the kubelet")
+	manifest.Set("Unit", "Documentation", "https://github.com/kubernetes/kops")
+	manifest.Set("Unit", "Before", "kubelet.service")
+	manifest.Set("Service", "Type", "oneshot")
+	manifest.Set("Service", "RemainAfterExit", "yes")
+	manifest.Set("Service", "ExecStart", swapSetupScriptPath)
+	manifest.Set("Install", "WantedBy", "multi-user.target")
+
+	manifestString := manifest.Render()
+	klog.V(8).Infof("Built service manifest %q\n%s", swapServiceName, manifestString)
+
+	service := &nodetasks.Service{
+		Name:       swapServiceName,
+		Definition: s(manifestString),
+	}
+	service.InitDefaults()
+	c.AddTask(service)
+
+	return nil
+}
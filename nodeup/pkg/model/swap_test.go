@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+func TestSwapBuilder(t *testing.T) {
+	grid := []struct {
+		name         string
+		swap         *kops.SwapSpec
+		expectedSize string
+	}{
+		{
+			name: "no swap",
+		},
+		{
+			name:         "size",
+			swap:         &kops.SwapSpec{SizeMB: fi.PtrTo(int32(2048))},
+			expectedSize: "SIZE_MB=2048\n",
+		},
+		{
+			name:         "percent of memory",
+			swap:         &kops.SwapSpec{PercentOfMemory: fi.PtrTo(int32(25))},
+			expectedSize: "SIZE_MB=$(( $(awk '/^MemTotal:/ { print $2 }' /proc/meminfo) * 25 / 100 / 1024 ))\n",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			b := &SwapBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					NodeupConfig: &nodeup.Config{
+						Swap: g.swap,
+					},
+				},
+			}
+
+			c := &fi.NodeupModelBuilderContext{
+				Tasks: make(map[string]fi.NodeupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error from Build: %v", err)
+			}
+
+			file, foundFile := c.Tasks["File/"+swapSetupScriptPath]
+			service, foundService := c.Tasks["Service/"+swapServiceName]
+			if g.swap == nil {
+				if len(c.Tasks) != 0 {
+					t.Fatalf("unexpected tasks without swap, got %v", c.Tasks)
+				}
+				return
+			}
+			if !foundFile || !foundService {
+				t.Fatalf("expected the swap setup script and service, got tasks %v", c.Tasks)
+			}
+
+			if mode := fi.ValueOf(file.(*nodetasks.File).Mode); mode != "0755" {
+				t.Errorf("unexpected mode %q of the swap setup script", mode)
+			}
+			script, err := fi.ResourceAsString(file.(*nodetasks.File).Contents)
+			if err != nil {
+				t.Fatalf("reading swap setup script: %v", err)
+			}
+			for _, expected := range []string{
+				"SWAP_FILE=" + swapFilePath + "\n",
+				g.expectedSize,
+				"chmod 0600 \"${SWAP_FILE}\"\n",
+				"mkswap \"${SWAP_FILE}\"\n",
+				"swapon \"${SWAP_FILE}\"\n",
+			} {
+				if !strings.Contains(script, expected) {
+					t.Errorf("expected swap setup script to contain %q, got:\n%s", expected, script)
+				}
+			}
+
+			definition := fi.ValueOf(service.(*nodetasks.Service).Definition)
+			for _, expected := range []string{
+				"Before=kubelet.service",
+				"Type=oneshot",
+				"ExecStart=" + swapSetupScriptPath,
+			} {
+				if !strings.Contains(definition, expected) {
+					t.Errorf("expected service to contain %q, got:\n%s", expected, definition)
+				}
+			}
+		})
+	}
+}
+
+func TestSwapBuilderWithoutSize(t *testing.T) {
+	b := &SwapBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			NodeupConfig: &nodeup.Config{
+				Swap: &kops.SwapSpec{},
+			},
+		},
+	}
+
+	c := &fi.NodeupModelBuilderContext{
+		Tasks: make(map[string]fi.NodeupTask),
+	}
+	if err := b.Build(c); err == nil {
+		t.Fatalf("expected an error for swap without a size")
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rebootrequired
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// MarkerFile is the file created by the Debian and Ubuntu package tooling when an installed update requires a reboot.
+const MarkerFile = "/var/run/reboot-required"
+
+// Run annotates the node as needing a reboot if the marker file exists,
+// using the credentials of the kubelet kubeconfig.
+func Run(ctx context.Context, kubeconfig string, nodeName string) error {
+	if _, err := os.Stat(MarkerFile); err != nil {
+		if os.IsNotExist(err) {
+			klog.Infof("%s does not exist; node %q does not need a reboot", MarkerFile, nodeName)
+			return nil
+		}
+		return fmt.Errorf("checking %s: %w", MarkerFile, err)
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return fmt.Errorf("loading kubeconfig %q: %w", kubeconfig, err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building kubernetes client: %w", err)
+	}
+
+	return AnnotateNode(ctx, client, nodeName)
+}
+
+// AnnotateNode sets the reboot-required annotation on the node.
+func AnnotateNode(ctx context.Context, client kubernetes.Interface, nodeName string) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				kops.AnnotationNameRebootRequired: "true",
+			},
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("building patch: %w", err)
+	}
+
+	if _, err := client.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("annotating node %q: %w", nodeName, err)
+	}
+	klog.Infof("annotated node %q with %s", nodeName, kops.AnnotationNameRebootRequired)
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rebootrequired
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestAnnotateNode(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node-1",
+			Annotations: map[string]string{"existing": "value"},
+		},
+	})
+
+	if err := AnnotateNode(ctx, client, "node-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting node: %v", err)
+	}
+	if got := node.Annotations[kops.AnnotationNameRebootRequired]; got != "true" {
+		t.Errorf("expected annotation %s to be %q, got %q", kops.AnnotationNameRebootRequired, "true", got)
+	}
+	if got := node.Annotations["existing"]; got != "value" {
+		t.Errorf("expected existing annotation to be kept, got %q", got)
+	}
+}
+
+func TestAnnotateMissingNode(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	if err := AnnotateNode(context.Background(), client, "node-1"); err == nil {
+		t.Fatalf("expected an error annotating a missing node")
+	}
+}
@@ -55,6 +55,8 @@ type ClusterSpec struct {
 	Channel string `json:"channel,omitempty"`
 	// Additional addons that should be installed on the cluster
 	Addons []AddonSpec `json:"addons,omitempty"`
+	// AddonDefaults are applied to the pods of the addons managed by kOps.
+	AddonDefaults *AddonDefaultsSpec `json:"addonDefaults,omitempty"`
 	// ConfigStore configures the stores that nodes use to get their configuration.
 	ConfigStore ConfigStoreSpec `json:"configStore"`
 	// CloudProvider configures the cloud provider to use.
@@ -121,8 +123,14 @@ type ClusterSpec struct {
 	NodeProblemDetector *NodeProblemDetectorConfig `json:"nodeProblemDetector,omitempty"`
 	// MetricsServer determines the metrics server configuration.
 	MetricsServer *MetricsServerConfig `json:"metricsServer,omitempty"`
+	// Metrics configures the metrics pipeline, such as kube-state-metrics.
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
 	// CertManager determines the metrics server configuration.
 	CertManager *CertManagerConfig `json:"certManager,omitempty"`
+	// NodeIPFamilies controls the IP families of the addresses that nodes advertise, in order of preference.
+	// It determines the kubelet node IPs, the kube-apiserver advertise address and the kube-proxy bind address.
+	// Valid values are "ipv4" and "ipv6".
+	NodeIPFamilies []string `json:"nodeIPFamilies,omitempty"`
 	// Networking configures networking.
 	Networking NetworkingSpec `json:"networking,omitempty"`
 	// API controls how the Kubernetes API is exposed.
@@ -135,6 +143,8 @@ type ClusterSpec struct {
 	NodeAuthorization *NodeAuthorizationSpec `json:"nodeAuthorization,omitempty"`
 	// CloudLabels defines additional tags or labels on cloud provider resources
 	CloudLabels map[string]string `json:"cloudLabels,omitempty"`
+	// TagPolicy restricts which of the cloudLabels are applied to which types of cloud resources.
+	TagPolicy *TagPolicySpec `json:"tagPolicy,omitempty"`
 	// Hooks for custom actions e.g. on first installation
 	Hooks []HookSpec `json:"hooks,omitempty"`
 	// Assets is alternative locations for files and containers; the API under construction, will remove this comment once this API is fully functional.
@@ -160,10 +170,21 @@ type ClusterSpec struct {
 	ServiceAccountIssuerDiscovery *ServiceAccountIssuerDiscoveryConfig `json:"serviceAccountIssuerDiscovery,omitempty"`
 	// SnapshotController defines the CSI Snapshot Controller configuration.
 	SnapshotController *SnapshotControllerConfig `json:"snapshotController,omitempty"`
+	// Snapshots configures scheduled snapshots of persistent volumes.
+	Snapshots *SnapshotsSpec `json:"snapshots,omitempty"`
 	// Karpenter defines the Karpenter configuration.
 	Karpenter *KarpenterConfig `json:"karpenter,omitempty"`
+	// KopsController defines the kops-controller configuration.
+	KopsController *KopsControllerConfig `json:"kopsController,omitempty"`
 }
 
+const (
+	// IPFamilyIPv4 is a value for ClusterSpec.NodeIPFamilies selecting the IPv4 address of a node
+	IPFamilyIPv4 = "ipv4"
+	// IPFamilyIPv6 is a value for ClusterSpec.NodeIPFamilies selecting the IPv6 address of a node
+	IPFamilyIPv6 = "ipv6"
+)
+
 // ConfigStoreSpec configures the stores that nodes use to get their configuration.
 type ConfigStoreSpec struct {
 	// Base is the VFS path where we store configuration for the cluster
@@ -213,8 +234,11 @@ type AWSSpec struct {
 	PodIdentityWebhook *PodIdentityWebhookSpec `json:"podIdentityWebhook,omitempty"`
 	// WarmPool defines the default warm pool settings for instance groups.
 	WarmPool *WarmPoolSpec `json:"warmPool,omitempty"`
+	// InstanceMetadata defines the default EC2 instance metadata service options for instance groups.
+	InstanceMetadata *InstanceMetadataOptions `json:"instanceMetadata,omitempty"`
 
 	// NodeIPFamilies control the IP families reported for each node.
+	// Deprecated: Use ClusterSpec.NodeIPFamilies, which also applies to the Cloud Controller Manager.
 	NodeIPFamilies []string `json:"nodeIPFamilies,omitempty"`
 	// DisableSecurityGroupIngress disables the Cloud Controller Manager's creation
 	// of an AWS Security Group for each load balancer provisioned for a Service.
@@ -258,6 +282,13 @@ type HetznerSpec struct{}
 type ScalewaySpec struct {
 }
 
+// KopsControllerConfig configures kops-controller.
+type KopsControllerConfig struct {
+	// LeaderElection tunes the leader election used by the kops-controller reconcilers.
+	// The bootstrap server runs on every replica regardless of leadership.
+	LeaderElection *LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+}
+
 type KarpenterConfig struct {
 	Enabled       bool               `json:"enabled,omitempty"`
 	LogEncoding   string             `json:"logFormat,omitempty"`
@@ -322,6 +353,16 @@ type NodeAuthorizerSpec struct {
 	TokenTTL *metav1.Duration `json:"tokenTTL,omitempty"`
 }
 
+// AddonDefaultsSpec configures defaults for the pods of the addons managed by kOps.
+type AddonDefaultsSpec struct {
+	// Tolerations are added to the pods of every managed addon.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// RuntimeClassName is set on the pods of managed addon Deployments that do not set a runtime class.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// NodeSelector is merged into the node selector of managed addon Deployments that do not already choose their nodes.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
 // AddonSpec defines an addon that we want to install in the cluster
 type AddonSpec struct {
 	// Manifest is a path to the manifest that defines the addon
@@ -342,6 +383,8 @@ type FileAssetSpec struct {
 	IsBase64 bool `json:"isBase64,omitempty"`
 	// Mode is this file's mode and permission bits
 	Mode string `json:"mode,omitempty"`
+	// AllowSecrets allows the content to contain what looks like a secret, such as a private key.
+	AllowSecrets bool `json:"allowSecrets,omitempty"`
 }
 
 // AssetsSpec defines the privately hosted assets
@@ -386,6 +429,8 @@ type HookSpec struct {
 	// of the systemd unit, unmodified. Before and Requires are ignored when used together
 	// with this value (and validation shouldn't allow them to be set)
 	UseRawManifest bool `json:"useRawManifest,omitempty"`
+	// AllowSecrets allows the manifest and execContainer to contain what looks like a secret, such as an AWS access key.
+	AllowSecrets bool `json:"allowSecrets,omitempty"`
 }
 
 // ExecContainerAction defines an hood action
@@ -489,6 +534,15 @@ type APISpec struct {
 	AdditionalSANs []string `json:"additionalSANs,omitempty"`
 	// Access is a list of the CIDRs that can access the Kubernetes API endpoint.
 	Access []string `json:"access,omitempty"`
+	// PublicIPs configures Elastic IPs for the control-plane nodes of clusters without a load balancer (AWS only).
+	PublicIPs *APIPublicIPsSpec `json:"publicIPs,omitempty"`
+}
+
+// APIPublicIPsSpec configures stable public IPs for the control-plane nodes.
+type APIPublicIPsSpec struct {
+	// AllocationIDs maps a zone to the allocation ID of the Elastic IP that kops-controller
+	// associates with the control-plane node in that zone.
+	AllocationIDs map[string]string `json:"allocationIDs,omitempty"`
 }
 
 type DNSAccessSpec struct{}
@@ -509,7 +563,17 @@ const (
 	LoadBalancerClassNetwork LoadBalancerClass = "Network"
 )
 
+// LoadBalancerIPAddressType describes the IP address types of a load balancer (ipv4, dualstack)
+type LoadBalancerIPAddressType string
+
+const (
+	LoadBalancerIPAddressTypeIPv4      LoadBalancerIPAddressType = "ipv4"
+	LoadBalancerIPAddressTypeDualStack LoadBalancerIPAddressType = "dualstack"
+)
+
 type AccessLogSpec struct {
+	// Enabled specifies whether access logs are delivered to the bucket. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
 	// Interval is the publishing interval in minutes. This parameter is only used with classic load balancer.
 	Interval int `json:"interval,omitempty"`
 	// Bucket is the S3 bucket name to store the logs in.
@@ -518,11 +582,21 @@ type AccessLogSpec struct {
 	BucketPrefix *string `json:"bucketPrefix,omitempty"`
 }
 
+// IsEnabled returns true if access logs are configured and not explicitly disabled.
+func (a *AccessLogSpec) IsEnabled() bool {
+	return a != nil && (a.Enabled == nil || *a.Enabled)
+}
+
 var SupportedLoadBalancerClasses = []LoadBalancerClass{
 	LoadBalancerClassClassic,
 	LoadBalancerClassNetwork,
 }
 
+var SupportedLoadBalancerIPAddressTypes = []LoadBalancerIPAddressType{
+	LoadBalancerIPAddressTypeIPv4,
+	LoadBalancerIPAddressTypeDualStack,
+}
+
 // LoadBalancerSubnetSpec provides configuration for subnets used for a load balancer
 type LoadBalancerSubnetSpec struct {
 	// Name specifies the name of the cluster subnet
@@ -557,6 +631,17 @@ type LoadBalancerAccessSpec struct {
 	Subnets []LoadBalancerSubnetSpec `json:"subnets,omitempty"`
 	// AccessLog is the configuration of access logs.
 	AccessLog *AccessLogSpec `json:"accessLog,omitempty"`
+	// IPAddressType is the type of IP addresses used by the load balancer: ipv4, dualstack.
+	// Only supported by load balancers of class Network.
+	IPAddressType LoadBalancerIPAddressType `json:"ipAddressType,omitempty"`
+	// UseSecurityGroups attaches security groups to a load balancer of class Network. Defaults to true.
+	// Security groups can only be attached when the load balancer is created.
+	UseSecurityGroups *bool `json:"useSecurityGroups,omitempty"`
+}
+
+// UsesSecurityGroups returns true unless security groups are explicitly disabled for a load balancer of class Network.
+func (s *LoadBalancerAccessSpec) UsesSecurityGroups() bool {
+	return s.UseSecurityGroups == nil || *s.UseSecurityGroups
 }
 
 // KubeDNSConfig defines the kube dns configuration
@@ -626,6 +711,16 @@ const (
 	ExternalDNSProviderNone          ExternalDNSProvider = "none"
 )
 
+// ExternalDNSPolicyScope determines which Route53 hosted zones the DNS controller is allowed to change.
+type ExternalDNSPolicyScope string
+
+const (
+	// ExternalDNSPolicyScopeClusterZone only allows changes to the hosted zone of the cluster.
+	ExternalDNSPolicyScopeClusterZone ExternalDNSPolicyScope = "ClusterZone"
+	// ExternalDNSPolicyScopeAllZones allows changes to all the hosted zones of the account.
+	ExternalDNSPolicyScopeAllZones ExternalDNSPolicyScope = "AllZones"
+)
+
 // ExternalDNSConfig are options of the dns-controller
 type ExternalDNSConfig struct {
 	// WatchIngress indicates you want the dns-controller to watch and create dns entries for ingress resources.
@@ -637,6 +732,10 @@ type ExternalDNSConfig struct {
 	// 'dns-controller' will use kOps DNS Controller.
 	// 'external-dns' will use kubernetes-sigs/external-dns.
 	Provider ExternalDNSProvider `json:"provider,omitempty"`
+	// PolicyScope determines which Route53 hosted zones the DNS controller may change on AWS.
+	// 'ClusterZone' only allows the hosted zone of the cluster; 'AllZones' allows every hosted zone.
+	// Default: ClusterZone
+	PolicyScope ExternalDNSPolicyScope `json:"policyScope,omitempty"`
 }
 
 // EtcdProviderType describes etcd cluster provisioning types (Standalone, Manager)
@@ -671,6 +770,9 @@ type EtcdClusterSpec struct {
 	MemoryRequest *resource.Quantity `json:"memoryRequest,omitempty"`
 	// CPURequest specifies the cpu requests of each etcd container in the cluster.
 	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+	// AdditionalSANs are additional subject alternative names, DNS names or IP addresses,
+	// for the etcd serving certificates, e.g. to allow monitoring systems to scrape etcd metrics.
+	AdditionalSANs []string `json:"additionalSANs,omitempty"`
 }
 
 // EtcdBackupSpec describes how we want to do backups of etcd
@@ -681,6 +783,17 @@ type EtcdBackupSpec struct {
 	Image string `json:"image,omitempty"`
 }
 
+// EtcdManagerDiscoveryMode is the mechanism etcd-manager uses to discover the other members of its etcd cluster.
+type EtcdManagerDiscoveryMode string
+
+const (
+	// EtcdManagerDiscoveryModeVolumeTags discovers the members through the tags of their etcd volumes.
+	EtcdManagerDiscoveryModeVolumeTags EtcdManagerDiscoveryMode = "VolumeTags"
+	// EtcdManagerDiscoveryModeStatic discovers the members through a static seed list of their DNS names,
+	// which are resolved with the member addresses that kops-controller publishes in a ConfigMap.
+	EtcdManagerDiscoveryModeStatic EtcdManagerDiscoveryMode = "Static"
+)
+
 // EtcdManagerSpec describes how we configure the etcd manager
 type EtcdManagerSpec struct {
 	// Image is the etcd manager image to use.
@@ -701,6 +814,11 @@ type EtcdManagerSpec struct {
 	// LogLevel allows the klog library verbose log level to be set for etcd-manager. The default is 6.
 	// https://github.com/google/glog#verbose-logging
 	LogLevel *int32 `json:"logLevel,omitempty"`
+	// DiscoveryMode is how etcd-manager discovers the other members of the etcd cluster.
+	// 'VolumeTags' finds them through the tags of their volumes.
+	// 'Static' uses a seed list of member DNS names, resolved with the addresses kops-controller publishes.
+	// Default: VolumeTags, or Static on cloud providers that do not support volume tag discovery.
+	DiscoveryMode EtcdManagerDiscoveryMode `json:"discoveryMode,omitempty"`
 }
 
 // EtcdMemberSpec is a specification for a etcd member
@@ -750,6 +868,11 @@ const (
 	EgressNatInstance = "i"
 	// EgressTransitGateway means that egress configuration is using a Transit Gateway
 	EgressTransitGateway = "tgw"
+	// EgressEgressOnlyInternetGateway means that the IPv6 egress of the subnet is using an existing egress-only internet gateway.
+	// The IPv4 egress of the subnet uses a NAT gateway managed by kOps.
+	EgressEgressOnlyInternetGateway = "eigw"
+	// EgressManagedNatInstance means that egress configuration is using a NAT instance managed by kOps
+	EgressManagedNatInstance = "natInstance"
 	// EgressExternal means that egress configuration is done externally (preconfigured)
 	EgressExternal = "External"
 )
@@ -777,6 +900,10 @@ type ClusterSubnetSpec struct {
 	PublicIP string `json:"publicIP,omitempty"`
 	// AdditionalRoutes to attach to the subnet's route table
 	AdditionalRoutes []RouteSpec `json:"additionalRoutes,omitempty"`
+	// MapPublicIPOnLaunch sets whether instances launched in the subnet are assigned a public IPv4 address by default (AWS only).
+	// Only applies to public and utility subnets; if unset, the attribute of the subnet is left unchanged.
+	// The associatePublicIP of an instance group takes precedence over this setting.
+	MapPublicIPOnLaunch *bool `json:"mapPublicIPOnLaunch,omitempty"`
 }
 
 type RouteSpec struct {
@@ -799,6 +926,24 @@ type HTTPProxy struct {
 	// Password string `json:"password,omitempty"`
 }
 
+// TagPolicySpec restricts which of the cloudLabels are applied to which types of cloud resources.
+// Tags that kOps itself sets are never filtered.
+type TagPolicySpec struct {
+	// Rules are the tag policy rules. A tag is not applied to a resource if any rule matching the tag excludes it.
+	Rules []TagPolicyRule `json:"rules,omitempty"`
+}
+
+// TagPolicyRule restricts the resource types that a set of tags is applied to.
+// Resource types are named after the kOps tasks that manage them, for example SecurityGroup or LaunchTemplate.
+type TagPolicyRule struct {
+	// Tags are the keys of the cloudLabels the rule applies to. "*" matches all cloudLabels.
+	Tags []string `json:"tags,omitempty"`
+	// Include lists the resource types the tags are applied to. If set, the tags are not applied to any other resource type.
+	Include []string `json:"include,omitempty"`
+	// Exclude lists the resource types the tags are not applied to.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
 // TargetSpec allows for specifying target config in an extensible way
 type TargetSpec struct {
 	Terraform *TerraformSpec `json:"terraform,omitempty"`
@@ -814,10 +959,27 @@ type TerraformSpec struct {
 	ProviderExtraConfig map[string]string `json:"providerExtraConfig,omitempty"`
 	// FilesProviderExtraConfig contains key/value pairs to add to the terraform provider block used for managed files
 	FilesProviderExtraConfig map[string]string `json:"filesProviderExtraConfig,omitempty"`
+	// ProviderAlias is the alias of the main terraform provider block, which all generated resources then reference
+	ProviderAlias string `json:"providerAlias,omitempty"`
+	// AssumeRole is the IAM role the main AWS terraform provider assumes
+	AssumeRole *TerraformAssumeRoleSpec `json:"assumeRole,omitempty"`
+	// DefaultTags are tags the main AWS terraform provider adds to all resources
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+}
+
+// TerraformAssumeRoleSpec is the IAM role assumed by a terraform provider
+type TerraformAssumeRoleSpec struct {
+	// RoleARN is the ARN of the role to assume
+	RoleARN string `json:"roleARN,omitempty"`
+	// SessionName is the session name to use when assuming the role
+	SessionName string `json:"sessionName,omitempty"`
+	// ExternalID is the external identifier to use when assuming the role
+	ExternalID string `json:"externalID,omitempty"`
 }
 
 func (t *TerraformSpec) IsEmpty() bool {
-	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0
+	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0 &&
+		t.ProviderAlias == "" && t.AssumeRole == nil && len(t.DefaultTags) == 0
 }
 
 // FillDefaults populates default values.
@@ -1030,8 +1192,57 @@ type RollingUpdate struct {
 	// nodes.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// SurgeMode is how the extra nodes are created on AWS autoscaling groups.
+	// With "Detach" the old instances are detached from the autoscaling group, which launches their replacements.
+	// With "ScaleOut" the desired capacity of the autoscaling group is raised and the new instances are protected
+	// from scale in, so the old instances stay in the group, and behind its load balancers, until they are drained.
+	// Defaults to "Detach".
+	// +optional
+	SurgeMode RollingUpdateSurgeMode `json:"surgeMode,omitempty"`
+	// DrainTimeout is the maximum amount of time to wait for a node to drain,
+	// for example when a PodDisruptionBudget does not allow its pods to be evicted.
+	// Defaults to 15 minutes. The --drain-timeout flag of rolling-update overrides it.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// DrainTimeoutPolicy is what happens to a node that does not drain within the DrainTimeout.
+	// With "Fail" the rolling update fails, with "Skip" the node is left cordoned and is not replaced,
+	// and with "Force" the remaining pods are deleted, ignoring their PodDisruptionBudgets, and the node is replaced.
+	// Defaults to "Fail". The --drain-timeout-policy flag of rolling-update overrides it.
+	// +optional
+	DrainTimeoutPolicy RollingUpdateDrainTimeoutPolicy `json:"drainTimeoutPolicy,omitempty"`
+	// JoinTimeout is the maximum amount of time to wait for an instance launched by the rolling update
+	// to register as a node. An instance that does not join within the timeout is terminated and the
+	// rolling update fails, reporting the console output of the instance. Only supported on AWS.
+	// Defaults to waiting until the cluster validation times out. The --join-timeout flag of rolling-update overrides it.
+	// +optional
+	JoinTimeout *metav1.Duration `json:"joinTimeout,omitempty"`
+	// RetryJoin replaces an instance that did not join within the JoinTimeout once more before failing the rolling update.
+	// +optional
+	RetryJoin *bool `json:"retryJoin,omitempty"`
 }
 
+// RollingUpdateSurgeMode is how extra nodes are created when surging a rolling update
+type RollingUpdateSurgeMode string
+
+const (
+	// RollingUpdateSurgeModeDetach surges by detaching old instances from their autoscaling group
+	RollingUpdateSurgeModeDetach RollingUpdateSurgeMode = "Detach"
+	// RollingUpdateSurgeModeScaleOut surges by raising the desired capacity of the autoscaling group
+	RollingUpdateSurgeModeScaleOut RollingUpdateSurgeMode = "ScaleOut"
+)
+
+// RollingUpdateDrainTimeoutPolicy is what happens to a node that does not drain within the drain timeout
+type RollingUpdateDrainTimeoutPolicy string
+
+const (
+	// RollingUpdateDrainTimeoutPolicyFail fails the rolling update
+	RollingUpdateDrainTimeoutPolicyFail RollingUpdateDrainTimeoutPolicy = "Fail"
+	// RollingUpdateDrainTimeoutPolicySkip leaves the node cordoned and continues with the next node
+	RollingUpdateDrainTimeoutPolicySkip RollingUpdateDrainTimeoutPolicy = "Skip"
+	// RollingUpdateDrainTimeoutPolicyForce deletes the remaining pods without eviction and replaces the node
+	RollingUpdateDrainTimeoutPolicyForce RollingUpdateDrainTimeoutPolicy = "Force"
+)
+
 type PackagesConfig struct {
 	// HashAmd64 overrides the hash for the AMD64 package.
 	HashAmd64 *string `json:"hashAmd64,omitempty"`
@@ -1054,6 +1265,9 @@ type WarmPoolSpec struct {
 	// EnableLifecyleHook determines if an ASG lifecycle hook will be added ensuring that nodeup runs to completion.
 	// Note that the metadata API must be protected from arbitrary Pods when this is enabled.
 	EnableLifecycleHook bool `json:"enableLifecycleHook,omitempty"`
+	// ReuseOnScaleIn returns instances to the warm pool when the instance group scales in,
+	// instead of terminating them.
+	ReuseOnScaleIn *bool `json:"reuseOnScaleIn,omitempty"`
 }
 
 func (in *WarmPoolSpec) IsEnabled() bool {
@@ -1086,5 +1300,8 @@ func (in *WarmPoolSpec) ResolveDefaults(ig *InstanceGroup) *WarmPoolSpec {
 	if !spec.EnableLifecycleHook {
 		spec.EnableLifecycleHook = in.EnableLifecycleHook
 	}
+	if spec.ReuseOnScaleIn == nil {
+		spec.ReuseOnScaleIn = in.ReuseOnScaleIn
+	}
 	return &spec
 }
@@ -494,6 +494,11 @@ type KubeAPIServerConfig struct {
 	// in "iss" claim of issued tokens. This value is a string or URI.
 	ServiceAccountIssuer *string `json:"serviceAccountIssuer,omitempty" flag:"service-account-issuer"`
 
+	// AdditionalServiceAccountIssuers are identifiers of service account token issuers that are accepted
+	// in addition to serviceAccountIssuer, which keeps signing the tokens. They allow the issuer to be changed
+	// without invalidating the tokens issued before.
+	AdditionalServiceAccountIssuers []string `json:"additionalServiceAccountIssuers,omitempty" flag:"-"`
+
 	// ServiceAccountJWKSURI overrides the path for the jwks document; this is useful when we are republishing the service account discovery information elsewhere.
 	ServiceAccountJWKSURI *string `json:"serviceAccountJWKSURI,omitempty" flag:"service-account-jwks-uri"`
 
@@ -503,6 +508,14 @@ type KubeAPIServerConfig struct {
 	// defaults to a single element list containing the issuer URL.
 	APIAudiences []string `json:"apiAudiences,omitempty" flag:"api-audiences"`
 
+	// ServiceAccountMaxTokenExpiration is the maximum validity duration of the tokens issued by the service account token issuer.
+	// Tokens requested with a longer validity are issued with this validity instead. Must be between 1h and 720h.
+	ServiceAccountMaxTokenExpiration *metav1.Duration `json:"serviceAccountMaxTokenExpiration,omitempty" flag:"service-account-max-token-expiration"`
+
+	// ServiceAccountExtendedTokenExpiration extends the validity of projected service account tokens during token generation,
+	// which helps clients transition from legacy tokens to bound tokens. Enabled by default.
+	ServiceAccountExtendedTokenExpiration *bool `json:"serviceAccountExtendedTokenExpiration,omitempty" flag:"service-account-extend-token-expiration"`
+
 	// CPURequest, cpu request compute resource for api server. Defaults to "150m"
 	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
 	// CPULimit, cpu limit compute resource for api server e.g. "500m"
@@ -931,6 +944,25 @@ type SnapshotControllerConfig struct {
 	InstallDefaultClass bool `json:"installDefaultClass,omitempty"`
 }
 
+// SnapshotsSpec configures scheduled snapshots of persistent volumes.
+type SnapshotsSpec struct {
+	// Image is the container image of the snapshot scheduler.
+	Image string `json:"image,omitempty"`
+	// Schedule configures when snapshots are taken and how many are kept.
+	Schedule *SnapshotScheduleSpec `json:"schedule,omitempty"`
+}
+
+// SnapshotScheduleSpec configures when persistent volumes labeled for backup are snapshotted.
+type SnapshotScheduleSpec struct {
+	// Cron is the schedule in cron format, for example "0 2 * * *".
+	Cron string `json:"cron,omitempty"`
+	// Retention is the number of snapshots kept for each volume.
+	Retention int32 `json:"retention,omitempty"`
+	// Namespaces are the namespaces whose labeled persistent volume claims are snapshotted.
+	// Default: default
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
 // NodeTerminationHandlerSpec determines the node termination handler configuration.
 type NodeTerminationHandlerSpec struct {
 	// Enabled enables the node termination handler.
@@ -1087,6 +1119,33 @@ type MetricsServerConfig struct {
 	Insecure *bool `json:"insecure,omitempty"`
 }
 
+// MetricsSpec configures the metrics pipeline of the cluster.
+type MetricsSpec struct {
+	// KubeStateMetrics configures kube-state-metrics.
+	KubeStateMetrics *KubeStateMetricsConfig `json:"kubeStateMetrics,omitempty"`
+}
+
+// KubeStateMetricsConfig determines the kube-state-metrics configuration.
+type KubeStateMetricsConfig struct {
+	// Enabled enables kube-state-metrics.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image is the container image used.
+	Image *string `json:"image,omitempty"`
+	// Resources are the resources that metrics are exported for.
+	// Only these resources can be listed and watched by kube-state-metrics.
+	// Default: the workload, node, namespace, service and storage resources.
+	Resources []string `json:"resources,omitempty"`
+	// MetricLabelsAllowlist lists the Kubernetes labels exported as metric labels, as resource=[label,...] entries.
+	// The label "*" exports all the labels of the resource.
+	MetricLabelsAllowlist []string `json:"metricLabelsAllowlist,omitempty"`
+	// ClusterNetworkOnly only exposes kube-state-metrics on the cluster network, through a ClusterIP service.
+	// Default: true
+	ClusterNetworkOnly *bool `json:"clusterNetworkOnly,omitempty"`
+	// ServiceAnnotations are added to the LoadBalancer service exposing kube-state-metrics when ClusterNetworkOnly is false.
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+}
+
 // CertManagerConfig determines the cert manager configuration.
 type CertManagerConfig struct {
 	// Enabled enables the cert manager.
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+import (
+	"testing"
+)
+
+func TestInstanceGroup_AssociatesPublicIP(t *testing.T) {
+	tests := []struct {
+		name                string
+		subnetType          SubnetType
+		associatePublicIP   *bool
+		mapPublicIPOnLaunch *bool
+		expected            bool
+	}{
+		{
+			name:       "public subnet defaults to a public IP",
+			subnetType: SubnetTypePublic,
+			expected:   true,
+		},
+		{
+			name:                "subnet disables public IPs",
+			subnetType:          SubnetTypeUtility,
+			mapPublicIPOnLaunch: boolptr(false),
+			expected:            false,
+		},
+		{
+			name:                "instance group overrides subnet",
+			subnetType:          SubnetTypePublic,
+			associatePublicIP:   boolptr(true),
+			mapPublicIPOnLaunch: boolptr(false),
+			expected:            true,
+		},
+		{
+			name:                "instance group disables public IPs",
+			subnetType:          SubnetTypePublic,
+			associatePublicIP:   boolptr(false),
+			mapPublicIPOnLaunch: boolptr(true),
+			expected:            false,
+		},
+		{
+			name:              "private subnet never has public IPs",
+			subnetType:        SubnetTypePrivate,
+			associatePublicIP: boolptr(true),
+			expected:          false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ig := &InstanceGroup{Spec: InstanceGroupSpec{AssociatePublicIP: tc.associatePublicIP}}
+			subnet := &ClusterSubnetSpec{Type: tc.subnetType, MapPublicIPOnLaunch: tc.mapPublicIPOnLaunch}
+			if actual := ig.AssociatesPublicIP(subnet); actual != tc.expected {
+				t.Errorf("AssociatesPublicIP() = %v, want %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInstanceGroup_ManagedByClusterAutoscaler(t *testing.T) {
+	tests := []struct {
+		name        string
+		autoscale   *bool
+		cloudLabels map[string]string
+		expected    bool
+	}{
+		{
+			name:     "not managed by default",
+			expected: false,
+		},
+		{
+			name:      "autoscale enabled",
+			autoscale: boolptr(true),
+			expected:  true,
+		},
+		{
+			name:        "discovery cloud label",
+			cloudLabels: map[string]string{CloudLabelClusterAutoscalerEnabled: "1"},
+			expected:    true,
+		},
+		{
+			name:        "autoscale disabled overrides cloud label",
+			autoscale:   boolptr(false),
+			cloudLabels: map[string]string{CloudLabelClusterAutoscalerEnabled: "1"},
+			expected:    false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ig := &InstanceGroup{Spec: InstanceGroupSpec{Autoscale: tc.autoscale, CloudLabels: tc.cloudLabels}}
+			if actual := ig.ManagedByClusterAutoscaler(); actual != tc.expected {
+				t.Errorf("ManagedByClusterAutoscaler() = %v, want %v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func boolptr(v bool) *bool {
+	return &v
+}
@@ -23,6 +23,9 @@ const (
 	// AnnotationValueManagementImported is the annotation value that indicates a cluster was imported, typically as part of an upgrade
 	AnnotationValueManagementImported = "imported"
 
+	// AnnotationNameRebootRequired is the node annotation that marks a node as needing a reboot, for example because a kernel update was installed
+	AnnotationNameRebootRequired = "kops.k8s.io/reboot-required"
+
 	// UpdatePolicyAutomatic is a value for ClusterSpec.UpdatePolicy and InstanceGroup.UpdatePolicy indicating that upgrades are performed automatically
 	UpdatePolicyAutomatic = "automatic"
 
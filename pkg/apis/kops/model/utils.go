@@ -36,7 +36,7 @@ func FindSubnet(c *kops.Cluster, subnetName string) *kops.ClusterSubnetSpec {
 // FindZonesForInstanceGroup computes the zones for an instance group, which are the zones directly declared in the InstanceGroup, or the subnet zones
 func FindZonesForInstanceGroup(c *kops.Cluster, ig *kops.InstanceGroup) ([]string, error) {
 	zones := sets.NewString(ig.Spec.Zones...)
-	for _, subnetName := range ig.Spec.Subnets {
+	for _, subnetName := range ig.ResolvedSubnets(c) {
 		subnet := FindSubnet(c, subnetName)
 		if subnet == nil {
 			return nil, fmt.Errorf("cannot find subnet %q (declared in instance group %q, not found in cluster)", subnetName, ig.ObjectMeta.Name)
@@ -25,6 +25,13 @@ import (
 	"k8s.io/klog/v2"
 )
 
+const (
+	// OldestSupportedKubernetesVersion is the oldest kubernetes version that is supported in kOps.
+	OldestSupportedKubernetesVersion = "1.24.0"
+	// OldestRecommendedKubernetesVersion is the oldest kubernetes version that is not deprecated in kOps.
+	OldestRecommendedKubernetesVersion = "1.26.0"
+)
+
 var versionURLPattern = regexp.MustCompile(`/v1\.([\d]+)\.`)
 
 func ParseKubernetesVersion(version string) (*semver.Version, error) {
@@ -288,6 +288,15 @@ func Convert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *kops
 			return err
 		}
 	}
+	if in.InstanceMetadata != nil {
+		if out.CloudProvider.AWS == nil {
+			return field.Forbidden(field.NewPath("spec", "instanceMetadata"), "instance metadata only supported on AWS")
+		}
+		out.CloudProvider.AWS.InstanceMetadata = &kops.InstanceMetadataOptions{}
+		if err := autoConvert_v1alpha2_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(in.InstanceMetadata, out.CloudProvider.AWS.InstanceMetadata, s); err != nil {
+			return err
+		}
+	}
 	if in.PodIdentityWebhook != nil {
 		if out.CloudProvider.AWS == nil {
 			return field.Forbidden(field.NewPath("spec", "podIdentityWebhook"), "pod identity webhook supports only AWS")
@@ -338,6 +347,19 @@ func Convert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *kops
 	} else {
 		out.Networking.EgressProxy = nil
 	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.Networking.VPCEndpoints
+		*out = make([]kops.VPCEndpointSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Networking.VPCEndpoints = nil
+	}
+	out.Networking.NATInstanceType = in.NATInstanceType
+	out.Networking.NATInstanceImage = in.NATInstanceImage
 	if in.IsolateMasters != nil {
 		in, out := &in.IsolateMasters, &out.Networking.IsolateControlPlane
 		*out = new(bool)
@@ -478,6 +500,12 @@ func Convert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec, out
 				return err
 			}
 		}
+		if aws.InstanceMetadata != nil {
+			out.InstanceMetadata = &InstanceMetadataOptions{}
+			if err := autoConvert_kops_InstanceMetadataOptions_To_v1alpha2_InstanceMetadataOptions(aws.InstanceMetadata, out.InstanceMetadata, s); err != nil {
+				return err
+			}
+		}
 		if aws.PodIdentityWebhook != nil {
 			out.PodIdentityWebhook = &PodIdentityWebhookSpec{}
 			if err := autoConvert_kops_PodIdentityWebhookSpec_To_v1alpha2_PodIdentityWebhookSpec(aws.PodIdentityWebhook, out.PodIdentityWebhook, s); err != nil {
@@ -576,6 +604,19 @@ func Convert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec, out
 	} else {
 		out.EgressProxy = nil
 	}
+	if in.Networking.VPCEndpoints != nil {
+		in, out := &in.Networking.VPCEndpoints, &out.VPCEndpoints
+		*out = make([]VPCEndpointSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.VPCEndpoints = nil
+	}
+	out.NATInstanceType = in.Networking.NATInstanceType
+	out.NATInstanceImage = in.Networking.NATInstanceImage
 	if in.Networking.IsolateControlPlane != nil {
 		in, out := &in.Networking.IsolateControlPlane, &out.IsolateMasters
 		*out = new(bool)
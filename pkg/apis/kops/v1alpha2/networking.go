@@ -18,6 +18,7 @@ package v1alpha2
 
 import (
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NetworkingSpec allows selection and configuration of a networking plugin
@@ -29,11 +30,18 @@ type NetworkingSpec struct {
 	TagSubnets             *bool               `json:"-"`
 	Topology               *TopologySpec       `json:"-"`
 	EgressProxy            *EgressProxySpec    `json:"-"`
+	VPCEndpoints           []VPCEndpointSpec   `json:"-"`
+	NATInstanceType        string              `json:"-"`
+	NATInstanceImage       string              `json:"-"`
 	NonMasqueradeCIDR      string              `json:"-"`
 	PodCIDR                string              `json:"-"`
 	ServiceClusterIPRange  string              `json:"-"`
 	IsolateControlPlane    *bool               `json:"-"`
 
+	// DefaultComponentNetworkPolicies installs NetworkPolicies restricting access to the kube-system components
+	// that kOps manages. It requires a networking plugin that enforces NetworkPolicy.
+	DefaultComponentNetworkPolicies *bool `json:"defaultComponentNetworkPolicies,omitempty"`
+
 	Classic    *ClassicNetworkingSpec    `json:"classic,omitempty"`
 	Kubenet    *KubenetNetworkingSpec    `json:"kubenet,omitempty"`
 	External   *ExternalNetworkingSpec   `json:"external,omitempty"`
@@ -57,6 +65,28 @@ func (s *NetworkingSpec) IsEmpty() bool {
 		s.Romana == nil && s.AmazonVPC == nil && s.Cilium == nil && s.LyftVPC == nil && s.GCP == nil
 }
 
+// VPCEndpointType is the type of a VPC endpoint.
+type VPCEndpointType string
+
+const (
+	// VPCEndpointTypeGateway is a gateway endpoint, which is added as a route to the route tables managed by kOps.
+	VPCEndpointTypeGateway VPCEndpointType = "Gateway"
+	// VPCEndpointTypeInterface is an interface endpoint, which creates a network interface in each of its subnets.
+	VPCEndpointTypeInterface VPCEndpointType = "Interface"
+)
+
+// VPCEndpointSpec configures a VPC endpoint managed by kOps.
+type VPCEndpointSpec struct {
+	// ServiceName is the name of the endpoint service, such as com.amazonaws.us-east-1.s3.
+	ServiceName string `json:"serviceName,omitempty"`
+	// Type is the type of the endpoint, either Gateway or Interface.
+	Type VPCEndpointType `json:"type,omitempty"`
+	// Subnets are the names of the cluster subnets an Interface endpoint is placed in.
+	Subnets []string `json:"subnets,omitempty"`
+	// PrivateDNS associates a private hosted zone with an Interface endpoint. Default: true.
+	PrivateDNS *bool `json:"privateDNS,omitempty"`
+}
+
 // ClassicNetworkingSpec is the specification of classic networking mode, integrated into kubernetes.
 // Support been removed since Kubernetes 1.4.
 type ClassicNetworkingSpec struct{}
@@ -215,6 +245,10 @@ type CalicoNetworkingSpec struct {
 	// WireguardEnabled enables WireGuard encryption for all on-the-wire pod-to-pod traffic
 	// (default: false)
 	WireguardEnabled bool `json:"wireguardEnabled,omitempty"`
+	// UpgradeMaxUnavailable is the maximum number of calico-node pods that can be unavailable
+	// while the DaemonSet is updated to a new version.
+	// (default: 1)
+	UpgradeMaxUnavailable *intstr.IntOrString `json:"upgradeMaxUnavailable,omitempty"`
 }
 
 // CanalNetworkingSpec declares that we want Canal networking
@@ -283,6 +317,31 @@ type AmazonVPCNetworkingSpec struct {
 	InitImage string `json:"initImageName,omitempty"`
 	// Env is a list of environment variables to set in the container.
 	Env []EnvVar `json:"env,omitempty"`
+	// NetworkPolicyAgent configures the network policy agent, which enforces NetworkPolicy using eBPF.
+	NetworkPolicyAgent *AmazonVPCNetworkPolicyAgentSpec `json:"networkPolicyAgent,omitempty"`
+	// PrefixTargets enables prefix delegation and configures how many prefixes and addresses are kept warm on each node.
+	PrefixTargets *AmazonVPCPrefixTargetsSpec `json:"prefixTargets,omitempty"`
+}
+
+// AmazonVPCNetworkPolicyAgentSpec configures the Amazon VPC CNI network policy agent.
+type AmazonVPCNetworkPolicyAgentSpec struct {
+	// Enabled runs the network policy agent in the aws-node DaemonSet.
+	// Requires Kubernetes 1.25 and Amazon VPC CNI v1.14.0 or later.
+	Enabled *bool `json:"enabled,omitempty"`
+	// ImageName is the container image name to use for the agent.
+	Image string `json:"imageName,omitempty"`
+	// EnableCloudWatchLogs sends the policy decision logs of the agent to CloudWatch Logs.
+	EnableCloudWatchLogs *bool `json:"enableCloudWatchLogs,omitempty"`
+}
+
+// AmazonVPCPrefixTargetsSpec configures the warm pool of prefix delegation.
+type AmazonVPCPrefixTargetsSpec struct {
+	// WarmPrefixTarget is the number of free /28 prefixes to keep attached to each node (WARM_PREFIX_TARGET).
+	WarmPrefixTarget *int32 `json:"warmPrefixTarget,omitempty"`
+	// WarmIPTarget is the number of free IP addresses to keep available on each node (WARM_IP_TARGET).
+	WarmIPTarget *int32 `json:"warmIPTarget,omitempty"`
+	// MinimumIPTarget is the minimum number of IP addresses to keep allocated to each node (MINIMUM_IP_TARGET).
+	MinimumIPTarget *int32 `json:"minimumIPTarget,omitempty"`
 }
 
 const CiliumIpamEni = "eni"
@@ -620,6 +679,11 @@ type CiliumNetworkingSpec struct {
 
 	// Ingress specifies the configuration for Cilium Ingress settings.
 	Ingress *CiliumIngressSpec `json:"ingress,omitempty"`
+
+	// UpgradeMaxUnavailable is the maximum number of Cilium agent pods that can be unavailable
+	// while the DaemonSet is updated to a new version.
+	// When unset, agent pods are only replaced when their node is replaced by a rolling update.
+	UpgradeMaxUnavailable *intstr.IntOrString `json:"upgradeMaxUnavailable,omitempty"`
 }
 
 // CiliumIngressSpec configures Cilium Ingress settings.
@@ -34,6 +34,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*APIPublicIPsSpec)(nil), (*kops.APIPublicIPsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_APIPublicIPsSpec_To_kops_APIPublicIPsSpec(a.(*APIPublicIPsSpec), b.(*kops.APIPublicIPsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.APIPublicIPsSpec)(nil), (*APIPublicIPsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_APIPublicIPsSpec_To_v1alpha2_APIPublicIPsSpec(a.(*kops.APIPublicIPsSpec), b.(*APIPublicIPsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*APISpec)(nil), (*kops.APISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_APISpec_To_kops_APISpec(a.(*APISpec), b.(*kops.APISpec), scope)
 	}); err != nil {
@@ -94,6 +104,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AddonDefaultsSpec)(nil), (*kops.AddonDefaultsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonDefaultsSpec_To_kops_AddonDefaultsSpec(a.(*AddonDefaultsSpec), b.(*kops.AddonDefaultsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonDefaultsSpec)(nil), (*AddonDefaultsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonDefaultsSpec_To_v1alpha2_AddonDefaultsSpec(a.(*kops.AddonDefaultsSpec), b.(*AddonDefaultsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AddonSpec)(nil), (*kops.AddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AddonSpec_To_kops_AddonSpec(a.(*AddonSpec), b.(*kops.AddonSpec), scope)
 	}); err != nil {
@@ -114,6 +134,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AmazonVPCNetworkPolicyAgentSpec)(nil), (*kops.AmazonVPCNetworkPolicyAgentSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AmazonVPCNetworkPolicyAgentSpec_To_kops_AmazonVPCNetworkPolicyAgentSpec(a.(*AmazonVPCNetworkPolicyAgentSpec), b.(*kops.AmazonVPCNetworkPolicyAgentSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AmazonVPCNetworkPolicyAgentSpec)(nil), (*AmazonVPCNetworkPolicyAgentSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AmazonVPCNetworkPolicyAgentSpec_To_v1alpha2_AmazonVPCNetworkPolicyAgentSpec(a.(*kops.AmazonVPCNetworkPolicyAgentSpec), b.(*AmazonVPCNetworkPolicyAgentSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AmazonVPCNetworkingSpec)(nil), (*kops.AmazonVPCNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AmazonVPCNetworkingSpec_To_kops_AmazonVPCNetworkingSpec(a.(*AmazonVPCNetworkingSpec), b.(*kops.AmazonVPCNetworkingSpec), scope)
 	}); err != nil {
@@ -124,6 +154,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AmazonVPCPrefixTargetsSpec)(nil), (*kops.AmazonVPCPrefixTargetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AmazonVPCPrefixTargetsSpec_To_kops_AmazonVPCPrefixTargetsSpec(a.(*AmazonVPCPrefixTargetsSpec), b.(*kops.AmazonVPCPrefixTargetsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AmazonVPCPrefixTargetsSpec)(nil), (*AmazonVPCPrefixTargetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AmazonVPCPrefixTargetsSpec_To_v1alpha2_AmazonVPCPrefixTargetsSpec(a.(*kops.AmazonVPCPrefixTargetsSpec), b.(*AmazonVPCPrefixTargetsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AssetsSpec)(nil), (*kops.AssetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(a.(*AssetsSpec), b.(*kops.AssetsSpec), scope)
 	}); err != nil {
@@ -464,6 +504,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCEInstanceGroupSpec)(nil), (*kops.GCEInstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GCEInstanceGroupSpec_To_kops_GCEInstanceGroupSpec(a.(*GCEInstanceGroupSpec), b.(*kops.GCEInstanceGroupSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCEInstanceGroupSpec)(nil), (*GCEInstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCEInstanceGroupSpec_To_v1alpha2_GCEInstanceGroupSpec(a.(*kops.GCEInstanceGroupSpec), b.(*GCEInstanceGroupSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GCPNetworkingSpec)(nil), (*kops.GCPNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_GCPNetworkingSpec_To_kops_GCPNetworkingSpec(a.(*GCPNetworkingSpec), b.(*kops.GCPNetworkingSpec), scope)
 	}); err != nil {
@@ -474,6 +524,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GPUConfigSpec)(nil), (*kops.GPUConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GPUConfigSpec_To_kops_GPUConfigSpec(a.(*GPUConfigSpec), b.(*kops.GPUConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GPUConfigSpec)(nil), (*GPUConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GPUConfigSpec_To_v1alpha2_GPUConfigSpec(a.(*kops.GPUConfigSpec), b.(*GPUConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GossipConfig)(nil), (*kops.GossipConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_GossipConfig_To_kops_GossipConfig(a.(*GossipConfig), b.(*kops.GossipConfig), scope)
 	}); err != nil {
@@ -554,6 +614,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceGroupPlacementSpec)(nil), (*kops.InstanceGroupPlacementSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InstanceGroupPlacementSpec_To_kops_InstanceGroupPlacementSpec(a.(*InstanceGroupPlacementSpec), b.(*kops.InstanceGroupPlacementSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InstanceGroupPlacementSpec)(nil), (*InstanceGroupPlacementSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InstanceGroupPlacementSpec_To_v1alpha2_InstanceGroupPlacementSpec(a.(*kops.InstanceGroupPlacementSpec), b.(*InstanceGroupPlacementSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InstanceMaintenancePolicySpec)(nil), (*kops.InstanceMaintenancePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(a.(*InstanceMaintenancePolicySpec), b.(*kops.InstanceMaintenancePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InstanceMaintenancePolicySpec)(nil), (*InstanceMaintenancePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha2_InstanceMaintenancePolicySpec(a.(*kops.InstanceMaintenancePolicySpec), b.(*InstanceMaintenancePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceMetadataOptions)(nil), (*kops.InstanceMetadataOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(a.(*InstanceMetadataOptions), b.(*kops.InstanceMetadataOptions), scope)
 	}); err != nil {
@@ -644,6 +724,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KopsControllerConfig)(nil), (*kops.KopsControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(a.(*KopsControllerConfig), b.(*kops.KopsControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KopsControllerConfig)(nil), (*KopsControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(a.(*kops.KopsControllerConfig), b.(*KopsControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KubeAPIServerConfig)(nil), (*kops.KubeAPIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(a.(*KubeAPIServerConfig), b.(*kops.KubeAPIServerConfig), scope)
 	}); err != nil {
@@ -694,6 +784,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubeStateMetricsConfig)(nil), (*kops.KubeStateMetricsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KubeStateMetricsConfig_To_kops_KubeStateMetricsConfig(a.(*KubeStateMetricsConfig), b.(*kops.KubeStateMetricsConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KubeStateMetricsConfig)(nil), (*KubeStateMetricsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KubeStateMetricsConfig_To_v1alpha2_KubeStateMetricsConfig(a.(*kops.KubeStateMetricsConfig), b.(*KubeStateMetricsConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KubeletConfigSpec)(nil), (*kops.KubeletConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_KubeletConfigSpec_To_kops_KubeletConfigSpec(a.(*KubeletConfigSpec), b.(*kops.KubeletConfigSpec), scope)
 	}); err != nil {
@@ -794,6 +894,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MetricsSpec)(nil), (*kops.MetricsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MetricsSpec_To_kops_MetricsSpec(a.(*MetricsSpec), b.(*kops.MetricsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MetricsSpec)(nil), (*MetricsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MetricsSpec_To_v1alpha2_MetricsSpec(a.(*kops.MetricsSpec), b.(*MetricsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MinMaxSpec)(nil), (*kops.MinMaxSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_MinMaxSpec_To_kops_MinMaxSpec(a.(*MinMaxSpec), b.(*kops.MinMaxSpec), scope)
 	}); err != nil {
@@ -834,6 +944,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkInterfacesSpec)(nil), (*kops.NetworkInterfacesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NetworkInterfacesSpec_To_kops_NetworkInterfacesSpec(a.(*NetworkInterfacesSpec), b.(*kops.NetworkInterfacesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NetworkInterfacesSpec)(nil), (*NetworkInterfacesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NetworkInterfacesSpec_To_v1alpha2_NetworkInterfacesSpec(a.(*kops.NetworkInterfacesSpec), b.(*NetworkInterfacesSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkingSpec)(nil), (*kops.NetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_NetworkingSpec_To_kops_NetworkingSpec(a.(*NetworkingSpec), b.(*kops.NetworkingSpec), scope)
 	}); err != nil {
@@ -1084,6 +1204,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ScheduledScalingSpec)(nil), (*kops.ScheduledScalingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ScheduledScalingSpec_To_kops_ScheduledScalingSpec(a.(*ScheduledScalingSpec), b.(*kops.ScheduledScalingSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ScheduledScalingSpec)(nil), (*ScheduledScalingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ScheduledScalingSpec_To_v1alpha2_ScheduledScalingSpec(a.(*kops.ScheduledScalingSpec), b.(*ScheduledScalingSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceAccountExternalPermission)(nil), (*kops.ServiceAccountExternalPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ServiceAccountExternalPermission_To_kops_ServiceAccountExternalPermission(a.(*ServiceAccountExternalPermission), b.(*kops.ServiceAccountExternalPermission), scope)
 	}); err != nil {
@@ -1114,6 +1244,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotScheduleSpec)(nil), (*kops.SnapshotScheduleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SnapshotScheduleSpec_To_kops_SnapshotScheduleSpec(a.(*SnapshotScheduleSpec), b.(*kops.SnapshotScheduleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SnapshotScheduleSpec)(nil), (*SnapshotScheduleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SnapshotScheduleSpec_To_v1alpha2_SnapshotScheduleSpec(a.(*kops.SnapshotScheduleSpec), b.(*SnapshotScheduleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotsSpec)(nil), (*kops.SnapshotsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SnapshotsSpec_To_kops_SnapshotsSpec(a.(*SnapshotsSpec), b.(*kops.SnapshotsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SnapshotsSpec)(nil), (*SnapshotsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SnapshotsSpec_To_v1alpha2_SnapshotsSpec(a.(*kops.SnapshotsSpec), b.(*SnapshotsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubnetSelectorSpec)(nil), (*kops.SubnetSelectorSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SubnetSelectorSpec_To_kops_SubnetSelectorSpec(a.(*SubnetSelectorSpec), b.(*kops.SubnetSelectorSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SubnetSelectorSpec)(nil), (*SubnetSelectorSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SubnetSelectorSpec_To_v1alpha2_SubnetSelectorSpec(a.(*kops.SubnetSelectorSpec), b.(*SubnetSelectorSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SwapSpec)(nil), (*kops.SwapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SwapSpec_To_kops_SwapSpec(a.(*SwapSpec), b.(*kops.SwapSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SwapSpec)(nil), (*SwapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SwapSpec_To_v1alpha2_SwapSpec(a.(*kops.SwapSpec), b.(*SwapSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TagPolicyRule)(nil), (*kops.TagPolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TagPolicyRule_To_kops_TagPolicyRule(a.(*TagPolicyRule), b.(*kops.TagPolicyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TagPolicyRule)(nil), (*TagPolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TagPolicyRule_To_v1alpha2_TagPolicyRule(a.(*kops.TagPolicyRule), b.(*TagPolicyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TagPolicySpec)(nil), (*kops.TagPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TagPolicySpec_To_kops_TagPolicySpec(a.(*TagPolicySpec), b.(*kops.TagPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TagPolicySpec)(nil), (*TagPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TagPolicySpec_To_v1alpha2_TagPolicySpec(a.(*kops.TagPolicySpec), b.(*TagPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
 	}); err != nil {
@@ -1124,6 +1314,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TerraformAssumeRoleSpec)(nil), (*kops.TerraformAssumeRoleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TerraformAssumeRoleSpec_To_kops_TerraformAssumeRoleSpec(a.(*TerraformAssumeRoleSpec), b.(*kops.TerraformAssumeRoleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformAssumeRoleSpec)(nil), (*TerraformAssumeRoleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformAssumeRoleSpec_To_v1alpha2_TerraformAssumeRoleSpec(a.(*kops.TerraformAssumeRoleSpec), b.(*TerraformAssumeRoleSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TerraformSpec)(nil), (*kops.TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(a.(*TerraformSpec), b.(*kops.TerraformSpec), scope)
 	}); err != nil {
@@ -1144,6 +1344,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VPCEndpointSpec)(nil), (*kops.VPCEndpointSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec(a.(*VPCEndpointSpec), b.(*kops.VPCEndpointSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.VPCEndpointSpec)(nil), (*VPCEndpointSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec(a.(*kops.VPCEndpointSpec), b.(*VPCEndpointSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
 	}); err != nil {
@@ -1257,6 +1467,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_APIPublicIPsSpec_To_kops_APIPublicIPsSpec(in *APIPublicIPsSpec, out *kops.APIPublicIPsSpec, s conversion.Scope) error {
+	out.AllocationIDs = in.AllocationIDs
+	return nil
+}
+
+// Convert_v1alpha2_APIPublicIPsSpec_To_kops_APIPublicIPsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_APIPublicIPsSpec_To_kops_APIPublicIPsSpec(in *APIPublicIPsSpec, out *kops.APIPublicIPsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_APIPublicIPsSpec_To_kops_APIPublicIPsSpec(in, out, s)
+}
+
+func autoConvert_kops_APIPublicIPsSpec_To_v1alpha2_APIPublicIPsSpec(in *kops.APIPublicIPsSpec, out *APIPublicIPsSpec, s conversion.Scope) error {
+	out.AllocationIDs = in.AllocationIDs
+	return nil
+}
+
+// Convert_kops_APIPublicIPsSpec_To_v1alpha2_APIPublicIPsSpec is an autogenerated conversion function.
+func Convert_kops_APIPublicIPsSpec_To_v1alpha2_APIPublicIPsSpec(in *kops.APIPublicIPsSpec, out *APIPublicIPsSpec, s conversion.Scope) error {
+	return autoConvert_kops_APIPublicIPsSpec_To_v1alpha2_APIPublicIPsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_APISpec_To_kops_APISpec(in *APISpec, out *kops.APISpec, s conversion.Scope) error {
 	if in.DNS != nil {
 		in, out := &in.DNS, &out.DNS
@@ -1279,6 +1509,15 @@ func autoConvert_v1alpha2_APISpec_To_kops_APISpec(in *APISpec, out *kops.APISpec
 	out.PublicName = in.PublicName
 	out.AdditionalSANs = in.AdditionalSANs
 	out.Access = in.Access
+	if in.PublicIPs != nil {
+		in, out := &in.PublicIPs, &out.PublicIPs
+		*out = new(kops.APIPublicIPsSpec)
+		if err := Convert_v1alpha2_APIPublicIPsSpec_To_kops_APIPublicIPsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PublicIPs = nil
+	}
 	return nil
 }
 
@@ -1309,6 +1548,15 @@ func autoConvert_kops_APISpec_To_v1alpha2_APISpec(in *kops.APISpec, out *APISpec
 	out.PublicName = in.PublicName
 	out.AdditionalSANs = in.AdditionalSANs
 	out.Access = in.Access
+	if in.PublicIPs != nil {
+		in, out := &in.PublicIPs, &out.PublicIPs
+		*out = new(APIPublicIPsSpec)
+		if err := Convert_kops_APIPublicIPsSpec_To_v1alpha2_APIPublicIPsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PublicIPs = nil
+	}
 	return nil
 }
 
@@ -1440,6 +1688,7 @@ func Convert_kops_AcceleratorConfig_To_v1alpha2_AcceleratorConfig(in *kops.Accel
 }
 
 func autoConvert_v1alpha2_AccessLogSpec_To_kops_AccessLogSpec(in *AccessLogSpec, out *kops.AccessLogSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
 	out.Interval = in.Interval
 	out.Bucket = in.Bucket
 	out.BucketPrefix = in.BucketPrefix
@@ -1452,6 +1701,7 @@ func Convert_v1alpha2_AccessLogSpec_To_kops_AccessLogSpec(in *AccessLogSpec, out
 }
 
 func autoConvert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in *kops.AccessLogSpec, out *AccessLogSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
 	out.Interval = in.Interval
 	out.Bucket = in.Bucket
 	out.BucketPrefix = in.BucketPrefix
@@ -1463,6 +1713,30 @@ func Convert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in *kops.AccessLogSpec
 	return autoConvert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AddonDefaultsSpec_To_kops_AddonDefaultsSpec(in *AddonDefaultsSpec, out *kops.AddonDefaultsSpec, s conversion.Scope) error {
+	out.Tolerations = in.Tolerations
+	out.RuntimeClassName = in.RuntimeClassName
+	out.NodeSelector = in.NodeSelector
+	return nil
+}
+
+// Convert_v1alpha2_AddonDefaultsSpec_To_kops_AddonDefaultsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AddonDefaultsSpec_To_kops_AddonDefaultsSpec(in *AddonDefaultsSpec, out *kops.AddonDefaultsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonDefaultsSpec_To_kops_AddonDefaultsSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonDefaultsSpec_To_v1alpha2_AddonDefaultsSpec(in *kops.AddonDefaultsSpec, out *AddonDefaultsSpec, s conversion.Scope) error {
+	out.Tolerations = in.Tolerations
+	out.RuntimeClassName = in.RuntimeClassName
+	out.NodeSelector = in.NodeSelector
+	return nil
+}
+
+// Convert_kops_AddonDefaultsSpec_To_v1alpha2_AddonDefaultsSpec is an autogenerated conversion function.
+func Convert_kops_AddonDefaultsSpec_To_v1alpha2_AddonDefaultsSpec(in *kops.AddonDefaultsSpec, out *AddonDefaultsSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonDefaultsSpec_To_v1alpha2_AddonDefaultsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
 	return nil
@@ -1501,6 +1775,30 @@ func Convert_kops_AlwaysAllowAuthorizationSpec_To_v1alpha2_AlwaysAllowAuthorizat
 	return autoConvert_kops_AlwaysAllowAuthorizationSpec_To_v1alpha2_AlwaysAllowAuthorizationSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AmazonVPCNetworkPolicyAgentSpec_To_kops_AmazonVPCNetworkPolicyAgentSpec(in *AmazonVPCNetworkPolicyAgentSpec, out *kops.AmazonVPCNetworkPolicyAgentSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.EnableCloudWatchLogs = in.EnableCloudWatchLogs
+	return nil
+}
+
+// Convert_v1alpha2_AmazonVPCNetworkPolicyAgentSpec_To_kops_AmazonVPCNetworkPolicyAgentSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AmazonVPCNetworkPolicyAgentSpec_To_kops_AmazonVPCNetworkPolicyAgentSpec(in *AmazonVPCNetworkPolicyAgentSpec, out *kops.AmazonVPCNetworkPolicyAgentSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AmazonVPCNetworkPolicyAgentSpec_To_kops_AmazonVPCNetworkPolicyAgentSpec(in, out, s)
+}
+
+func autoConvert_kops_AmazonVPCNetworkPolicyAgentSpec_To_v1alpha2_AmazonVPCNetworkPolicyAgentSpec(in *kops.AmazonVPCNetworkPolicyAgentSpec, out *AmazonVPCNetworkPolicyAgentSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.EnableCloudWatchLogs = in.EnableCloudWatchLogs
+	return nil
+}
+
+// Convert_kops_AmazonVPCNetworkPolicyAgentSpec_To_v1alpha2_AmazonVPCNetworkPolicyAgentSpec is an autogenerated conversion function.
+func Convert_kops_AmazonVPCNetworkPolicyAgentSpec_To_v1alpha2_AmazonVPCNetworkPolicyAgentSpec(in *kops.AmazonVPCNetworkPolicyAgentSpec, out *AmazonVPCNetworkPolicyAgentSpec, s conversion.Scope) error {
+	return autoConvert_kops_AmazonVPCNetworkPolicyAgentSpec_To_v1alpha2_AmazonVPCNetworkPolicyAgentSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AmazonVPCNetworkingSpec_To_kops_AmazonVPCNetworkingSpec(in *AmazonVPCNetworkingSpec, out *kops.AmazonVPCNetworkingSpec, s conversion.Scope) error {
 	out.Image = in.Image
 	out.InitImage = in.InitImage
@@ -1515,6 +1813,24 @@ func autoConvert_v1alpha2_AmazonVPCNetworkingSpec_To_kops_AmazonVPCNetworkingSpe
 	} else {
 		out.Env = nil
 	}
+	if in.NetworkPolicyAgent != nil {
+		in, out := &in.NetworkPolicyAgent, &out.NetworkPolicyAgent
+		*out = new(kops.AmazonVPCNetworkPolicyAgentSpec)
+		if err := Convert_v1alpha2_AmazonVPCNetworkPolicyAgentSpec_To_kops_AmazonVPCNetworkPolicyAgentSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkPolicyAgent = nil
+	}
+	if in.PrefixTargets != nil {
+		in, out := &in.PrefixTargets, &out.PrefixTargets
+		*out = new(kops.AmazonVPCPrefixTargetsSpec)
+		if err := Convert_v1alpha2_AmazonVPCPrefixTargetsSpec_To_kops_AmazonVPCPrefixTargetsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrefixTargets = nil
+	}
 	return nil
 }
 
@@ -1537,6 +1853,24 @@ func autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpe
 	} else {
 		out.Env = nil
 	}
+	if in.NetworkPolicyAgent != nil {
+		in, out := &in.NetworkPolicyAgent, &out.NetworkPolicyAgent
+		*out = new(AmazonVPCNetworkPolicyAgentSpec)
+		if err := Convert_kops_AmazonVPCNetworkPolicyAgentSpec_To_v1alpha2_AmazonVPCNetworkPolicyAgentSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkPolicyAgent = nil
+	}
+	if in.PrefixTargets != nil {
+		in, out := &in.PrefixTargets, &out.PrefixTargets
+		*out = new(AmazonVPCPrefixTargetsSpec)
+		if err := Convert_kops_AmazonVPCPrefixTargetsSpec_To_v1alpha2_AmazonVPCPrefixTargetsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrefixTargets = nil
+	}
 	return nil
 }
 
@@ -1545,6 +1879,30 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AmazonVPCPrefixTargetsSpec_To_kops_AmazonVPCPrefixTargetsSpec(in *AmazonVPCPrefixTargetsSpec, out *kops.AmazonVPCPrefixTargetsSpec, s conversion.Scope) error {
+	out.WarmPrefixTarget = in.WarmPrefixTarget
+	out.WarmIPTarget = in.WarmIPTarget
+	out.MinimumIPTarget = in.MinimumIPTarget
+	return nil
+}
+
+// Convert_v1alpha2_AmazonVPCPrefixTargetsSpec_To_kops_AmazonVPCPrefixTargetsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AmazonVPCPrefixTargetsSpec_To_kops_AmazonVPCPrefixTargetsSpec(in *AmazonVPCPrefixTargetsSpec, out *kops.AmazonVPCPrefixTargetsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AmazonVPCPrefixTargetsSpec_To_kops_AmazonVPCPrefixTargetsSpec(in, out, s)
+}
+
+func autoConvert_kops_AmazonVPCPrefixTargetsSpec_To_v1alpha2_AmazonVPCPrefixTargetsSpec(in *kops.AmazonVPCPrefixTargetsSpec, out *AmazonVPCPrefixTargetsSpec, s conversion.Scope) error {
+	out.WarmPrefixTarget = in.WarmPrefixTarget
+	out.WarmIPTarget = in.WarmIPTarget
+	out.MinimumIPTarget = in.MinimumIPTarget
+	return nil
+}
+
+// Convert_kops_AmazonVPCPrefixTargetsSpec_To_v1alpha2_AmazonVPCPrefixTargetsSpec is an autogenerated conversion function.
+func Convert_kops_AmazonVPCPrefixTargetsSpec_To_v1alpha2_AmazonVPCPrefixTargetsSpec(in *kops.AmazonVPCPrefixTargetsSpec, out *AmazonVPCPrefixTargetsSpec, s conversion.Scope) error {
+	return autoConvert_kops_AmazonVPCPrefixTargetsSpec_To_v1alpha2_AmazonVPCPrefixTargetsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(in *AssetsSpec, out *kops.AssetsSpec, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
@@ -1816,6 +2174,7 @@ func autoConvert_v1alpha2_CalicoNetworkingSpec_To_kops_CalicoNetworkingSpec(in *
 	out.TyphaReplicas = in.TyphaReplicas
 	out.VXLANMode = in.VXLANMode
 	out.WireguardEnabled = in.WireguardEnabled
+	out.UpgradeMaxUnavailable = in.UpgradeMaxUnavailable
 	return nil
 }
 
@@ -1852,6 +2211,7 @@ func autoConvert_kops_CalicoNetworkingSpec_To_v1alpha2_CalicoNetworkingSpec(in *
 	out.TyphaReplicas = in.TyphaReplicas
 	out.VXLANMode = in.VXLANMode
 	out.WireguardEnabled = in.WireguardEnabled
+	out.UpgradeMaxUnavailable = in.UpgradeMaxUnavailable
 	return nil
 }
 
@@ -2077,6 +2437,7 @@ func autoConvert_v1alpha2_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *
 	} else {
 		out.Ingress = nil
 	}
+	out.UpgradeMaxUnavailable = in.UpgradeMaxUnavailable
 	return nil
 }
 
@@ -2148,6 +2509,7 @@ func autoConvert_kops_CiliumNetworkingSpec_To_v1alpha2_CiliumNetworkingSpec(in *
 	} else {
 		out.Ingress = nil
 	}
+	out.UpgradeMaxUnavailable = in.UpgradeMaxUnavailable
 	return nil
 }
 
@@ -2416,6 +2778,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonDefaults != nil {
+		in, out := &in.AddonDefaults, &out.AddonDefaults
+		*out = new(kops.AddonDefaultsSpec)
+		if err := Convert_v1alpha2_AddonDefaultsSpec_To_kops_AddonDefaultsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AddonDefaults = nil
+	}
 	out.ConfigStore = in.ConfigStore
 	// INFO: in.ConfigBase opted out of conversion generation
 	out.CloudProvider = in.CloudProvider
@@ -2460,6 +2831,9 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	out.SSHAccess = in.SSHAccess
 	out.NodePortAccess = in.NodePortAccess
 	// INFO: in.EgressProxy opted out of conversion generation
+	// INFO: in.VPCEndpoints opted out of conversion generation
+	// INFO: in.NATInstanceType opted out of conversion generation
+	// INFO: in.NATInstanceImage opted out of conversion generation
 	out.SSHKeyName = in.SSHKeyName
 	// INFO: in.KubernetesAPIAccess opted out of conversion generation
 	// INFO: in.IsolateMasters opted out of conversion generation
@@ -2625,6 +2999,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.MetricsServer = nil
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(kops.MetricsSpec)
+		if err := Convert_v1alpha2_MetricsSpec_To_kops_MetricsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Metrics = nil
+	}
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
 		*out = new(kops.CertManagerConfig)
@@ -2634,6 +3017,7 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.CertManager = nil
 	}
+	out.NodeIPFamilies = in.NodeIPFamilies
 	// INFO: in.AWSLoadBalancerController opted out of conversion generation
 	// INFO: in.LegacyNetworking opted out of conversion generation
 	if err := Convert_v1alpha2_NetworkingSpec_To_kops_NetworkingSpec(&in.Networking, &out.Networking, s); err != nil {
@@ -2671,6 +3055,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 		out.NodeAuthorization = nil
 	}
 	out.CloudLabels = in.CloudLabels
+	if in.TagPolicy != nil {
+		in, out := &in.TagPolicy, &out.TagPolicy
+		*out = new(kops.TagPolicySpec)
+		if err := Convert_v1alpha2_TagPolicySpec_To_kops_TagPolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TagPolicy = nil
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]kops.HookSpec, len(*in))
@@ -2732,6 +3125,7 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 		out.ClusterAutoscaler = nil
 	}
 	// INFO: in.WarmPool opted out of conversion generation
+	// INFO: in.InstanceMetadata opted out of conversion generation
 	if in.ServiceAccountIssuerDiscovery != nil {
 		in, out := &in.ServiceAccountIssuerDiscovery, &out.ServiceAccountIssuerDiscovery
 		*out = new(kops.ServiceAccountIssuerDiscoveryConfig)
@@ -2750,6 +3144,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.SnapshotController = nil
 	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(kops.SnapshotsSpec)
+		if err := Convert_v1alpha2_SnapshotsSpec_To_kops_SnapshotsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Snapshots = nil
+	}
 	if in.Karpenter != nil {
 		in, out := &in.Karpenter, &out.Karpenter
 		*out = new(kops.KarpenterConfig)
@@ -2759,6 +3162,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Karpenter = nil
 	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(kops.KopsControllerConfig)
+		if err := Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
 	// INFO: in.PodIdentityWebhook opted out of conversion generation
 	return nil
 }
@@ -2776,6 +3188,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonDefaults != nil {
+		in, out := &in.AddonDefaults, &out.AddonDefaults
+		*out = new(AddonDefaultsSpec)
+		if err := Convert_kops_AddonDefaultsSpec_To_v1alpha2_AddonDefaultsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AddonDefaults = nil
+	}
 	out.ConfigStore = in.ConfigStore
 	out.CloudProvider = in.CloudProvider
 	if in.GossipConfig != nil {
@@ -2964,6 +3385,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.MetricsServer = nil
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		if err := Convert_kops_MetricsSpec_To_v1alpha2_MetricsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Metrics = nil
+	}
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
 		*out = new(CertManagerConfig)
@@ -2973,6 +3403,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.CertManager = nil
 	}
+	out.NodeIPFamilies = in.NodeIPFamilies
 	if err := Convert_kops_NetworkingSpec_To_v1alpha2_NetworkingSpec(&in.Networking, &out.Networking, s); err != nil {
 		return err
 	}
@@ -3007,6 +3438,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 		out.NodeAuthorization = nil
 	}
 	out.CloudLabels = in.CloudLabels
+	if in.TagPolicy != nil {
+		in, out := &in.TagPolicy, &out.TagPolicy
+		*out = new(TagPolicySpec)
+		if err := Convert_kops_TagPolicySpec_To_v1alpha2_TagPolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TagPolicy = nil
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]HookSpec, len(*in))
@@ -3084,6 +3524,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.SnapshotController = nil
 	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(SnapshotsSpec)
+		if err := Convert_kops_SnapshotsSpec_To_v1alpha2_SnapshotsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Snapshots = nil
+	}
 	if in.Karpenter != nil {
 		in, out := &in.Karpenter, &out.Karpenter
 		*out = new(KarpenterConfig)
@@ -3093,6 +3542,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Karpenter = nil
 	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(KopsControllerConfig)
+		if err := Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
 	return nil
 }
 
@@ -3117,6 +3575,7 @@ func autoConvert_v1alpha2_ClusterSubnetSpec_To_kops_ClusterSubnetSpec(in *Cluste
 	} else {
 		out.AdditionalRoutes = nil
 	}
+	out.MapPublicIPOnLaunch = in.MapPublicIPOnLaunch
 	return nil
 }
 
@@ -3146,6 +3605,7 @@ func autoConvert_kops_ClusterSubnetSpec_To_v1alpha2_ClusterSubnetSpec(in *kops.C
 	} else {
 		out.AdditionalRoutes = nil
 	}
+	out.MapPublicIPOnLaunch = in.MapPublicIPOnLaunch
 	return nil
 }
 
@@ -3612,6 +4072,7 @@ func autoConvert_v1alpha2_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdCluste
 	}
 	out.MemoryRequest = in.MemoryRequest
 	out.CPURequest = in.CPURequest
+	out.AdditionalSANs = in.AdditionalSANs
 	return nil
 }
 
@@ -3658,6 +4119,7 @@ func autoConvert_kops_EtcdClusterSpec_To_v1alpha2_EtcdClusterSpec(in *kops.EtcdC
 	}
 	out.MemoryRequest = in.MemoryRequest
 	out.CPURequest = in.CPURequest
+	out.AdditionalSANs = in.AdditionalSANs
 	return nil
 }
 
@@ -3684,6 +4146,7 @@ func autoConvert_v1alpha2_EtcdManagerSpec_To_kops_EtcdManagerSpec(in *EtcdManage
 	out.DiscoveryPollInterval = in.DiscoveryPollInterval
 	out.ListenMetricsURLs = in.ListenMetricsURLs
 	out.LogLevel = in.LogLevel
+	out.DiscoveryMode = kops.EtcdManagerDiscoveryMode(in.DiscoveryMode)
 	return nil
 }
 
@@ -3710,6 +4173,7 @@ func autoConvert_kops_EtcdManagerSpec_To_v1alpha2_EtcdManagerSpec(in *kops.EtcdM
 	out.DiscoveryPollInterval = in.DiscoveryPollInterval
 	out.ListenMetricsURLs = in.ListenMetricsURLs
 	out.LogLevel = in.LogLevel
+	out.DiscoveryMode = EtcdManagerDiscoveryMode(in.DiscoveryMode)
 	return nil
 }
 
@@ -3781,6 +4245,7 @@ func autoConvert_v1alpha2_ExternalDNSConfig_To_kops_ExternalDNSConfig(in *Extern
 	out.WatchIngress = in.WatchIngress
 	out.WatchNamespace = in.WatchNamespace
 	out.Provider = kops.ExternalDNSProvider(in.Provider)
+	out.PolicyScope = kops.ExternalDNSPolicyScope(in.PolicyScope)
 	return nil
 }
 
@@ -3788,6 +4253,7 @@ func autoConvert_kops_ExternalDNSConfig_To_v1alpha2_ExternalDNSConfig(in *kops.E
 	out.WatchIngress = in.WatchIngress
 	out.WatchNamespace = in.WatchNamespace
 	out.Provider = ExternalDNSProvider(in.Provider)
+	out.PolicyScope = ExternalDNSPolicyScope(in.PolicyScope)
 	return nil
 }
 
@@ -3824,6 +4290,7 @@ func autoConvert_v1alpha2_FileAssetSpec_To_kops_FileAssetSpec(in *FileAssetSpec,
 	out.Content = in.Content
 	out.IsBase64 = in.IsBase64
 	out.Mode = in.Mode
+	out.AllowSecrets = in.AllowSecrets
 	return nil
 }
 
@@ -3847,6 +4314,7 @@ func autoConvert_kops_FileAssetSpec_To_v1alpha2_FileAssetSpec(in *kops.FileAsset
 	out.Content = in.Content
 	out.IsBase64 = in.IsBase64
 	out.Mode = in.Mode
+	out.AllowSecrets = in.AllowSecrets
 	return nil
 }
 
@@ -3878,6 +4346,30 @@ func Convert_kops_FlannelNetworkingSpec_To_v1alpha2_FlannelNetworkingSpec(in *ko
 	return autoConvert_kops_FlannelNetworkingSpec_To_v1alpha2_FlannelNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_GCEInstanceGroupSpec_To_kops_GCEInstanceGroupSpec(in *GCEInstanceGroupSpec, out *kops.GCEInstanceGroupSpec, s conversion.Scope) error {
+	out.LocalSSDCount = in.LocalSSDCount
+	out.LocalSSDInterface = in.LocalSSDInterface
+	out.BootDiskType = in.BootDiskType
+	return nil
+}
+
+// Convert_v1alpha2_GCEInstanceGroupSpec_To_kops_GCEInstanceGroupSpec is an autogenerated conversion function.
+func Convert_v1alpha2_GCEInstanceGroupSpec_To_kops_GCEInstanceGroupSpec(in *GCEInstanceGroupSpec, out *kops.GCEInstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GCEInstanceGroupSpec_To_kops_GCEInstanceGroupSpec(in, out, s)
+}
+
+func autoConvert_kops_GCEInstanceGroupSpec_To_v1alpha2_GCEInstanceGroupSpec(in *kops.GCEInstanceGroupSpec, out *GCEInstanceGroupSpec, s conversion.Scope) error {
+	out.LocalSSDCount = in.LocalSSDCount
+	out.LocalSSDInterface = in.LocalSSDInterface
+	out.BootDiskType = in.BootDiskType
+	return nil
+}
+
+// Convert_kops_GCEInstanceGroupSpec_To_v1alpha2_GCEInstanceGroupSpec is an autogenerated conversion function.
+func Convert_kops_GCEInstanceGroupSpec_To_v1alpha2_GCEInstanceGroupSpec(in *kops.GCEInstanceGroupSpec, out *GCEInstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_kops_GCEInstanceGroupSpec_To_v1alpha2_GCEInstanceGroupSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_GCPNetworkingSpec_To_kops_GCPNetworkingSpec(in *GCPNetworkingSpec, out *kops.GCPNetworkingSpec, s conversion.Scope) error {
 	return nil
 }
@@ -3896,12 +4388,32 @@ func Convert_kops_GCPNetworkingSpec_To_v1alpha2_GCPNetworkingSpec(in *kops.GCPNe
 	return autoConvert_kops_GCPNetworkingSpec_To_v1alpha2_GCPNetworkingSpec(in, out, s)
 }
 
-func autoConvert_v1alpha2_GossipConfig_To_kops_GossipConfig(in *GossipConfig, out *kops.GossipConfig, s conversion.Scope) error {
-	out.Protocol = in.Protocol
-	out.Listen = in.Listen
-	out.Secret = in.Secret
-	if in.Secondary != nil {
-		in, out := &in.Secondary, &out.Secondary
+func autoConvert_v1alpha2_GPUConfigSpec_To_kops_GPUConfigSpec(in *GPUConfigSpec, out *kops.GPUConfigSpec, s conversion.Scope) error {
+	out.MIGProfile = in.MIGProfile
+	return nil
+}
+
+// Convert_v1alpha2_GPUConfigSpec_To_kops_GPUConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha2_GPUConfigSpec_To_kops_GPUConfigSpec(in *GPUConfigSpec, out *kops.GPUConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GPUConfigSpec_To_kops_GPUConfigSpec(in, out, s)
+}
+
+func autoConvert_kops_GPUConfigSpec_To_v1alpha2_GPUConfigSpec(in *kops.GPUConfigSpec, out *GPUConfigSpec, s conversion.Scope) error {
+	out.MIGProfile = in.MIGProfile
+	return nil
+}
+
+// Convert_kops_GPUConfigSpec_To_v1alpha2_GPUConfigSpec is an autogenerated conversion function.
+func Convert_kops_GPUConfigSpec_To_v1alpha2_GPUConfigSpec(in *kops.GPUConfigSpec, out *GPUConfigSpec, s conversion.Scope) error {
+	return autoConvert_kops_GPUConfigSpec_To_v1alpha2_GPUConfigSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_GossipConfig_To_kops_GossipConfig(in *GossipConfig, out *kops.GossipConfig, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Listen = in.Listen
+	out.Secret = in.Secret
+	if in.Secondary != nil {
+		in, out := &in.Secondary, &out.Secondary
 		*out = new(kops.GossipConfigSecondary)
 		if err := Convert_v1alpha2_GossipConfigSecondary_To_kops_GossipConfigSecondary(*in, *out, s); err != nil {
 			return err
@@ -4009,6 +4521,7 @@ func autoConvert_v1alpha2_HookSpec_To_kops_HookSpec(in *HookSpec, out *kops.Hook
 	}
 	out.Manifest = in.Manifest
 	out.UseRawManifest = in.UseRawManifest
+	out.AllowSecrets = in.AllowSecrets
 	return nil
 }
 
@@ -4037,6 +4550,7 @@ func autoConvert_kops_HookSpec_To_v1alpha2_HookSpec(in *kops.HookSpec, out *Hook
 	}
 	out.Manifest = in.Manifest
 	out.UseRawManifest = in.UseRawManifest
+	out.AllowSecrets = in.AllowSecrets
 	return nil
 }
 
@@ -4198,6 +4712,30 @@ func Convert_kops_InstanceGroupList_To_v1alpha2_InstanceGroupList(in *kops.Insta
 	return autoConvert_kops_InstanceGroupList_To_v1alpha2_InstanceGroupList(in, out, s)
 }
 
+func autoConvert_v1alpha2_InstanceGroupPlacementSpec_To_kops_InstanceGroupPlacementSpec(in *InstanceGroupPlacementSpec, out *kops.InstanceGroupPlacementSpec, s conversion.Scope) error {
+	out.Strategy = kops.InstanceGroupPlacementStrategy(in.Strategy)
+	out.PartitionCount = in.PartitionCount
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_InstanceGroupPlacementSpec_To_kops_InstanceGroupPlacementSpec is an autogenerated conversion function.
+func Convert_v1alpha2_InstanceGroupPlacementSpec_To_kops_InstanceGroupPlacementSpec(in *InstanceGroupPlacementSpec, out *kops.InstanceGroupPlacementSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InstanceGroupPlacementSpec_To_kops_InstanceGroupPlacementSpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceGroupPlacementSpec_To_v1alpha2_InstanceGroupPlacementSpec(in *kops.InstanceGroupPlacementSpec, out *InstanceGroupPlacementSpec, s conversion.Scope) error {
+	out.Strategy = InstanceGroupPlacementStrategy(in.Strategy)
+	out.PartitionCount = in.PartitionCount
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_InstanceGroupPlacementSpec_To_v1alpha2_InstanceGroupPlacementSpec is an autogenerated conversion function.
+func Convert_kops_InstanceGroupPlacementSpec_To_v1alpha2_InstanceGroupPlacementSpec(in *kops.InstanceGroupPlacementSpec, out *InstanceGroupPlacementSpec, s conversion.Scope) error {
+	return autoConvert_kops_InstanceGroupPlacementSpec_To_v1alpha2_InstanceGroupPlacementSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *InstanceGroupSpec, out *kops.InstanceGroupSpec, s conversion.Scope) error {
 	out.Manager = kops.InstanceManager(in.Manager)
 	out.Role = kops.InstanceGroupRole(in.Role)
@@ -4239,6 +4777,17 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.VolumeMounts = nil
 	}
 	out.Subnets = in.Subnets
+	if in.SubnetSelectors != nil {
+		in, out := &in.SubnetSelectors, &out.SubnetSelectors
+		*out = make([]kops.SubnetSelectorSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_SubnetSelectorSpec_To_kops_SubnetSelectorSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SubnetSelectors = nil
+	}
 	out.Zones = in.Zones
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
@@ -4270,6 +4819,7 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(kops.KubeletConfigSpec)
@@ -4290,6 +4840,15 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.MixedInstancesPolicy = nil
 	}
 	out.CapacityRebalance = in.CapacityRebalance
+	if in.InstanceMaintenancePolicy != nil {
+		in, out := &in.InstanceMaintenancePolicy, &out.InstanceMaintenancePolicy
+		*out = new(kops.InstanceMaintenancePolicySpec)
+		if err := Convert_v1alpha2_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMaintenancePolicy = nil
+	}
 	if in.AdditionalUserData != nil {
 		in, out := &in.AdditionalUserData, &out.AdditionalUserData
 		*out = make([]kops.UserData, len(*in))
@@ -4324,6 +4883,7 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.IAM = nil
 	}
 	out.SecurityGroupOverride = in.SecurityGroupOverride
+	out.NodePortAccess = in.NodePortAccess
 	out.InstanceProtection = in.InstanceProtection
 	out.SysctlParameters = in.SysctlParameters
 	if in.RollingUpdate != nil {
@@ -4346,6 +4906,26 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.InstanceMetadata = nil
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(kops.InstanceGroupPlacementSpec)
+		if err := Convert_v1alpha2_InstanceGroupPlacementSpec_To_kops_InstanceGroupPlacementSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Placement = nil
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = new(kops.NetworkInterfacesSpec)
+		if err := Convert_v1alpha2_NetworkInterfacesSpec_To_kops_NetworkInterfacesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkInterfaces = nil
+	}
+	out.CapacityReservationID = in.CapacityReservationID
+	out.CapacityReservationResourceGroupARN = in.CapacityReservationResourceGroupARN
 	out.UpdatePolicy = in.UpdatePolicy
 	if in.WarmPool != nil {
 		in, out := &in.WarmPool, &out.WarmPool
@@ -4356,6 +4936,18 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.WarmPool = nil
 	}
+	if in.ScheduledScaling != nil {
+		in, out := &in.ScheduledScaling, &out.ScheduledScaling
+		*out = make([]kops.ScheduledScalingSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ScheduledScalingSpec_To_kops_ScheduledScalingSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ScheduledScaling = nil
+	}
+	out.KubernetesVersion = in.KubernetesVersion
 	if in.Containerd != nil {
 		in, out := &in.Containerd, &out.Containerd
 		*out = new(kops.ContainerdConfig)
@@ -4377,8 +4969,36 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.GuestAccelerators = nil
 	}
+	if in.GPUConfig != nil {
+		in, out := &in.GPUConfig, &out.GPUConfig
+		*out = new(kops.GPUConfigSpec)
+		if err := Convert_v1alpha2_GPUConfigSpec_To_kops_GPUConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GPUConfig = nil
+	}
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(kops.SwapSpec)
+		if err := Convert_v1alpha2_SwapSpec_To_kops_SwapSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Swap = nil
+	}
+	out.JournaldMaxUse = in.JournaldMaxUse
 	out.MaxInstanceLifetime = in.MaxInstanceLifetime
 	out.GCPProvisioningModel = in.GCPProvisioningModel
+	if in.GCE != nil {
+		in, out := &in.GCE, &out.GCE
+		*out = new(kops.GCEInstanceGroupSpec)
+		if err := Convert_v1alpha2_GCEInstanceGroupSpec_To_kops_GCEInstanceGroupSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCE = nil
+	}
 	return nil
 }
 
@@ -4415,6 +5035,17 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 		out.VolumeMounts = nil
 	}
 	out.Subnets = in.Subnets
+	if in.SubnetSelectors != nil {
+		in, out := &in.SubnetSelectors, &out.SubnetSelectors
+		*out = make([]SubnetSelectorSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SubnetSelectorSpec_To_v1alpha2_SubnetSelectorSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SubnetSelectors = nil
+	}
 	out.Zones = in.Zones
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
@@ -4446,6 +5077,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(KubeletConfigSpec)
@@ -4466,6 +5098,15 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 		out.MixedInstancesPolicy = nil
 	}
 	out.CapacityRebalance = in.CapacityRebalance
+	if in.InstanceMaintenancePolicy != nil {
+		in, out := &in.InstanceMaintenancePolicy, &out.InstanceMaintenancePolicy
+		*out = new(InstanceMaintenancePolicySpec)
+		if err := Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha2_InstanceMaintenancePolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMaintenancePolicy = nil
+	}
 	if in.AdditionalUserData != nil {
 		in, out := &in.AdditionalUserData, &out.AdditionalUserData
 		*out = make([]UserData, len(*in))
@@ -4500,6 +5141,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 		out.IAM = nil
 	}
 	out.SecurityGroupOverride = in.SecurityGroupOverride
+	out.NodePortAccess = in.NodePortAccess
 	out.InstanceProtection = in.InstanceProtection
 	out.SysctlParameters = in.SysctlParameters
 	if in.RollingUpdate != nil {
@@ -4522,6 +5164,26 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.InstanceMetadata = nil
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(InstanceGroupPlacementSpec)
+		if err := Convert_kops_InstanceGroupPlacementSpec_To_v1alpha2_InstanceGroupPlacementSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Placement = nil
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = new(NetworkInterfacesSpec)
+		if err := Convert_kops_NetworkInterfacesSpec_To_v1alpha2_NetworkInterfacesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkInterfaces = nil
+	}
+	out.CapacityReservationID = in.CapacityReservationID
+	out.CapacityReservationResourceGroupARN = in.CapacityReservationResourceGroupARN
 	out.UpdatePolicy = in.UpdatePolicy
 	if in.WarmPool != nil {
 		in, out := &in.WarmPool, &out.WarmPool
@@ -4532,6 +5194,18 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.WarmPool = nil
 	}
+	if in.ScheduledScaling != nil {
+		in, out := &in.ScheduledScaling, &out.ScheduledScaling
+		*out = make([]ScheduledScalingSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ScheduledScalingSpec_To_v1alpha2_ScheduledScalingSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ScheduledScaling = nil
+	}
+	out.KubernetesVersion = in.KubernetesVersion
 	if in.Containerd != nil {
 		in, out := &in.Containerd, &out.Containerd
 		*out = new(ContainerdConfig)
@@ -4553,14 +5227,65 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.GuestAccelerators = nil
 	}
+	if in.GPUConfig != nil {
+		in, out := &in.GPUConfig, &out.GPUConfig
+		*out = new(GPUConfigSpec)
+		if err := Convert_kops_GPUConfigSpec_To_v1alpha2_GPUConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GPUConfig = nil
+	}
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(SwapSpec)
+		if err := Convert_kops_SwapSpec_To_v1alpha2_SwapSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Swap = nil
+	}
+	out.JournaldMaxUse = in.JournaldMaxUse
 	out.MaxInstanceLifetime = in.MaxInstanceLifetime
 	out.GCPProvisioningModel = in.GCPProvisioningModel
+	if in.GCE != nil {
+		in, out := &in.GCE, &out.GCE
+		*out = new(GCEInstanceGroupSpec)
+		if err := Convert_kops_GCEInstanceGroupSpec_To_v1alpha2_GCEInstanceGroupSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCE = nil
+	}
+	return nil
+}
+
+func autoConvert_v1alpha2_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(in *InstanceMaintenancePolicySpec, out *kops.InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	out.MinHealthyPercentage = in.MinHealthyPercentage
+	out.MaxHealthyPercentage = in.MaxHealthyPercentage
+	return nil
+}
+
+// Convert_v1alpha2_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec is an autogenerated conversion function.
+func Convert_v1alpha2_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(in *InstanceMaintenancePolicySpec, out *kops.InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceMaintenancePolicySpec_To_v1alpha2_InstanceMaintenancePolicySpec(in *kops.InstanceMaintenancePolicySpec, out *InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	out.MinHealthyPercentage = in.MinHealthyPercentage
+	out.MaxHealthyPercentage = in.MaxHealthyPercentage
 	return nil
 }
 
+// Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha2_InstanceMaintenancePolicySpec is an autogenerated conversion function.
+func Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha2_InstanceMaintenancePolicySpec(in *kops.InstanceMaintenancePolicySpec, out *InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_InstanceMaintenancePolicySpec_To_v1alpha2_InstanceMaintenancePolicySpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(in *InstanceMetadataOptions, out *kops.InstanceMetadataOptions, s conversion.Scope) error {
 	out.HTTPPutResponseHopLimit = in.HTTPPutResponseHopLimit
 	out.HTTPTokens = in.HTTPTokens
+	out.AllowDowngrade = in.AllowDowngrade
 	return nil
 }
 
@@ -4572,6 +5297,7 @@ func Convert_v1alpha2_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(in
 func autoConvert_kops_InstanceMetadataOptions_To_v1alpha2_InstanceMetadataOptions(in *kops.InstanceMetadataOptions, out *InstanceMetadataOptions, s conversion.Scope) error {
 	out.HTTPPutResponseHopLimit = in.HTTPPutResponseHopLimit
 	out.HTTPTokens = in.HTTPTokens
+	out.AllowDowngrade = in.AllowDowngrade
 	return nil
 }
 
@@ -4599,6 +5325,18 @@ func autoConvert_v1alpha2_InstanceRequirementsSpec_To_kops_InstanceRequirementsS
 	} else {
 		out.Memory = nil
 	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(kops.MinMaxSpec)
+		if err := Convert_v1alpha2_MinMaxSpec_To_kops_MinMaxSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GPU = nil
+	}
+	out.BurstablePerformance = in.BurstablePerformance
+	out.AllowedInstanceTypes = in.AllowedInstanceTypes
+	out.ExcludedInstanceTypes = in.ExcludedInstanceTypes
 	return nil
 }
 
@@ -4626,6 +5364,18 @@ func autoConvert_kops_InstanceRequirementsSpec_To_v1alpha2_InstanceRequirementsS
 	} else {
 		out.Memory = nil
 	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(MinMaxSpec)
+		if err := Convert_kops_MinMaxSpec_To_v1alpha2_MinMaxSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GPU = nil
+	}
+	out.BurstablePerformance = in.BurstablePerformance
+	out.AllowedInstanceTypes = in.AllowedInstanceTypes
+	out.ExcludedInstanceTypes = in.ExcludedInstanceTypes
 	return nil
 }
 
@@ -4848,6 +5598,42 @@ func Convert_kops_KopeioNetworkingSpec_To_v1alpha2_KopeioNetworkingSpec(in *kops
 	return autoConvert_kops_KopeioNetworkingSpec_To_v1alpha2_KopeioNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(in *KopsControllerConfig, out *kops.KopsControllerConfig, s conversion.Scope) error {
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = new(kops.LeaderElectionConfiguration)
+		if err := Convert_v1alpha2_LeaderElectionConfiguration_To_kops_LeaderElectionConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LeaderElection = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig is an autogenerated conversion function.
+func Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(in *KopsControllerConfig, out *kops.KopsControllerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(in, out, s)
+}
+
+func autoConvert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(in *kops.KopsControllerConfig, out *KopsControllerConfig, s conversion.Scope) error {
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = new(LeaderElectionConfiguration)
+		if err := Convert_kops_LeaderElectionConfiguration_To_v1alpha2_LeaderElectionConfiguration(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LeaderElection = nil
+	}
+	return nil
+}
+
+// Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig is an autogenerated conversion function.
+func Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(in *kops.KopsControllerConfig, out *KopsControllerConfig, s conversion.Scope) error {
+	return autoConvert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *KubeAPIServerConfig, out *kops.KubeAPIServerConfig, s conversion.Scope) error {
 	out.Image = in.Image
 	out.DisableBasicAuth = in.DisableBasicAuth
@@ -4940,8 +5726,11 @@ func autoConvert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.ServiceAccountKeyFile = in.ServiceAccountKeyFile
 	out.ServiceAccountSigningKeyFile = in.ServiceAccountSigningKeyFile
 	out.ServiceAccountIssuer = in.ServiceAccountIssuer
+	out.AdditionalServiceAccountIssuers = in.AdditionalServiceAccountIssuers
 	out.ServiceAccountJWKSURI = in.ServiceAccountJWKSURI
 	out.APIAudiences = in.APIAudiences
+	out.ServiceAccountMaxTokenExpiration = in.ServiceAccountMaxTokenExpiration
+	out.ServiceAccountExtendedTokenExpiration = in.ServiceAccountExtendedTokenExpiration
 	out.CPURequest = in.CPURequest
 	out.CPULimit = in.CPULimit
 	out.MemoryRequest = in.MemoryRequest
@@ -5053,8 +5842,11 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha2_KubeAPIServerConfig(in *ko
 	out.ServiceAccountKeyFile = in.ServiceAccountKeyFile
 	out.ServiceAccountSigningKeyFile = in.ServiceAccountSigningKeyFile
 	out.ServiceAccountIssuer = in.ServiceAccountIssuer
+	out.AdditionalServiceAccountIssuers = in.AdditionalServiceAccountIssuers
 	out.ServiceAccountJWKSURI = in.ServiceAccountJWKSURI
 	out.APIAudiences = in.APIAudiences
+	out.ServiceAccountMaxTokenExpiration = in.ServiceAccountMaxTokenExpiration
+	out.ServiceAccountExtendedTokenExpiration = in.ServiceAccountExtendedTokenExpiration
 	out.CPURequest = in.CPURequest
 	out.CPULimit = in.CPULimit
 	out.MemoryRequest = in.MemoryRequest
@@ -5420,6 +6212,36 @@ func Convert_kops_KubeSchedulerConfig_To_v1alpha2_KubeSchedulerConfig(in *kops.K
 	return autoConvert_kops_KubeSchedulerConfig_To_v1alpha2_KubeSchedulerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_KubeStateMetricsConfig_To_kops_KubeStateMetricsConfig(in *KubeStateMetricsConfig, out *kops.KubeStateMetricsConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.Resources = in.Resources
+	out.MetricLabelsAllowlist = in.MetricLabelsAllowlist
+	out.ClusterNetworkOnly = in.ClusterNetworkOnly
+	out.ServiceAnnotations = in.ServiceAnnotations
+	return nil
+}
+
+// Convert_v1alpha2_KubeStateMetricsConfig_To_kops_KubeStateMetricsConfig is an autogenerated conversion function.
+func Convert_v1alpha2_KubeStateMetricsConfig_To_kops_KubeStateMetricsConfig(in *KubeStateMetricsConfig, out *kops.KubeStateMetricsConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KubeStateMetricsConfig_To_kops_KubeStateMetricsConfig(in, out, s)
+}
+
+func autoConvert_kops_KubeStateMetricsConfig_To_v1alpha2_KubeStateMetricsConfig(in *kops.KubeStateMetricsConfig, out *KubeStateMetricsConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.Resources = in.Resources
+	out.MetricLabelsAllowlist = in.MetricLabelsAllowlist
+	out.ClusterNetworkOnly = in.ClusterNetworkOnly
+	out.ServiceAnnotations = in.ServiceAnnotations
+	return nil
+}
+
+// Convert_kops_KubeStateMetricsConfig_To_v1alpha2_KubeStateMetricsConfig is an autogenerated conversion function.
+func Convert_kops_KubeStateMetricsConfig_To_v1alpha2_KubeStateMetricsConfig(in *kops.KubeStateMetricsConfig, out *KubeStateMetricsConfig, s conversion.Scope) error {
+	return autoConvert_kops_KubeStateMetricsConfig_To_v1alpha2_KubeStateMetricsConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_KubeletConfigSpec_To_kops_KubeletConfigSpec(in *KubeletConfigSpec, out *kops.KubeletConfigSpec, s conversion.Scope) error {
 	out.APIServers = in.APIServers
 	out.AnonymousAuth = in.AnonymousAuth
@@ -5722,6 +6544,8 @@ func autoConvert_v1alpha2_LoadBalancerAccessSpec_To_kops_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.IPAddressType = kops.LoadBalancerIPAddressType(in.IPAddressType)
+	out.UseSecurityGroups = in.UseSecurityGroups
 	return nil
 }
 
@@ -5760,6 +6584,8 @@ func autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha2_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.IPAddressType = LoadBalancerIPAddressType(in.IPAddressType)
+	out.UseSecurityGroups = in.UseSecurityGroups
 	return nil
 }
 
@@ -5886,6 +6712,42 @@ func Convert_kops_MetricsServerConfig_To_v1alpha2_MetricsServerConfig(in *kops.M
 	return autoConvert_kops_MetricsServerConfig_To_v1alpha2_MetricsServerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_MetricsSpec_To_kops_MetricsSpec(in *MetricsSpec, out *kops.MetricsSpec, s conversion.Scope) error {
+	if in.KubeStateMetrics != nil {
+		in, out := &in.KubeStateMetrics, &out.KubeStateMetrics
+		*out = new(kops.KubeStateMetricsConfig)
+		if err := Convert_v1alpha2_KubeStateMetricsConfig_To_kops_KubeStateMetricsConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KubeStateMetrics = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_MetricsSpec_To_kops_MetricsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_MetricsSpec_To_kops_MetricsSpec(in *MetricsSpec, out *kops.MetricsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MetricsSpec_To_kops_MetricsSpec(in, out, s)
+}
+
+func autoConvert_kops_MetricsSpec_To_v1alpha2_MetricsSpec(in *kops.MetricsSpec, out *MetricsSpec, s conversion.Scope) error {
+	if in.KubeStateMetrics != nil {
+		in, out := &in.KubeStateMetrics, &out.KubeStateMetrics
+		*out = new(KubeStateMetricsConfig)
+		if err := Convert_kops_KubeStateMetricsConfig_To_v1alpha2_KubeStateMetricsConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KubeStateMetrics = nil
+	}
+	return nil
+}
+
+// Convert_kops_MetricsSpec_To_v1alpha2_MetricsSpec is an autogenerated conversion function.
+func Convert_kops_MetricsSpec_To_v1alpha2_MetricsSpec(in *kops.MetricsSpec, out *MetricsSpec, s conversion.Scope) error {
+	return autoConvert_kops_MetricsSpec_To_v1alpha2_MetricsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_MinMaxSpec_To_kops_MinMaxSpec(in *MinMaxSpec, out *kops.MinMaxSpec, s conversion.Scope) error {
 	out.Max = in.Max
 	out.Min = in.Min
@@ -6000,6 +6862,28 @@ func Convert_kops_NTPConfig_To_v1alpha2_NTPConfig(in *kops.NTPConfig, out *NTPCo
 	return autoConvert_kops_NTPConfig_To_v1alpha2_NTPConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_NetworkInterfacesSpec_To_kops_NetworkInterfacesSpec(in *NetworkInterfacesSpec, out *kops.NetworkInterfacesSpec, s conversion.Scope) error {
+	out.ENASRDEnabled = in.ENASRDEnabled
+	out.ENASRDUDPEnabled = in.ENASRDUDPEnabled
+	return nil
+}
+
+// Convert_v1alpha2_NetworkInterfacesSpec_To_kops_NetworkInterfacesSpec is an autogenerated conversion function.
+func Convert_v1alpha2_NetworkInterfacesSpec_To_kops_NetworkInterfacesSpec(in *NetworkInterfacesSpec, out *kops.NetworkInterfacesSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NetworkInterfacesSpec_To_kops_NetworkInterfacesSpec(in, out, s)
+}
+
+func autoConvert_kops_NetworkInterfacesSpec_To_v1alpha2_NetworkInterfacesSpec(in *kops.NetworkInterfacesSpec, out *NetworkInterfacesSpec, s conversion.Scope) error {
+	out.ENASRDEnabled = in.ENASRDEnabled
+	out.ENASRDUDPEnabled = in.ENASRDUDPEnabled
+	return nil
+}
+
+// Convert_kops_NetworkInterfacesSpec_To_v1alpha2_NetworkInterfacesSpec is an autogenerated conversion function.
+func Convert_kops_NetworkInterfacesSpec_To_v1alpha2_NetworkInterfacesSpec(in *kops.NetworkInterfacesSpec, out *NetworkInterfacesSpec, s conversion.Scope) error {
+	return autoConvert_kops_NetworkInterfacesSpec_To_v1alpha2_NetworkInterfacesSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_NetworkingSpec_To_kops_NetworkingSpec(in *NetworkingSpec, out *kops.NetworkingSpec, s conversion.Scope) error {
 	out.NetworkID = in.NetworkID
 	out.NetworkCIDR = in.NetworkCIDR
@@ -6034,10 +6918,24 @@ func autoConvert_v1alpha2_NetworkingSpec_To_kops_NetworkingSpec(in *NetworkingSp
 	} else {
 		out.EgressProxy = nil
 	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.VPCEndpoints
+		*out = make([]kops.VPCEndpointSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.VPCEndpoints = nil
+	}
+	out.NATInstanceType = in.NATInstanceType
+	out.NATInstanceImage = in.NATInstanceImage
 	out.NonMasqueradeCIDR = in.NonMasqueradeCIDR
 	out.PodCIDR = in.PodCIDR
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.IsolateControlPlane = in.IsolateControlPlane
+	out.DefaultComponentNetworkPolicies = in.DefaultComponentNetworkPolicies
 	if in.Classic != nil {
 		in, out := &in.Classic, &out.Classic
 		*out = new(kops.ClassicNetworkingSpec)
@@ -6215,10 +7113,24 @@ func autoConvert_kops_NetworkingSpec_To_v1alpha2_NetworkingSpec(in *kops.Network
 	} else {
 		out.EgressProxy = nil
 	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.VPCEndpoints
+		*out = make([]VPCEndpointSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.VPCEndpoints = nil
+	}
+	out.NATInstanceType = in.NATInstanceType
+	out.NATInstanceImage = in.NATInstanceImage
 	out.NonMasqueradeCIDR = in.NonMasqueradeCIDR
 	out.PodCIDR = in.PodCIDR
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.IsolateControlPlane = in.IsolateControlPlane
+	out.DefaultComponentNetworkPolicies = in.DefaultComponentNetworkPolicies
 	if in.Classic != nil {
 		in, out := &in.Classic, &out.Classic
 		*out = new(ClassicNetworkingSpec)
@@ -6972,6 +7884,11 @@ func autoConvert_v1alpha2_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	out.SurgeMode = kops.RollingUpdateSurgeMode(in.SurgeMode)
+	out.DrainTimeout = in.DrainTimeout
+	out.DrainTimeoutPolicy = kops.RollingUpdateDrainTimeoutPolicy(in.DrainTimeoutPolicy)
+	out.JoinTimeout = in.JoinTimeout
+	out.RetryJoin = in.RetryJoin
 	return nil
 }
 
@@ -6984,6 +7901,11 @@ func autoConvert_kops_RollingUpdate_To_v1alpha2_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	out.SurgeMode = RollingUpdateSurgeMode(in.SurgeMode)
+	out.DrainTimeout = in.DrainTimeout
+	out.DrainTimeoutPolicy = RollingUpdateDrainTimeoutPolicy(in.DrainTimeoutPolicy)
+	out.JoinTimeout = in.JoinTimeout
+	out.RetryJoin = in.RetryJoin
 	return nil
 }
 
@@ -7162,6 +8084,32 @@ func Convert_kops_SSHCredentialSpec_To_v1alpha2_SSHCredentialSpec(in *kops.SSHCr
 	return autoConvert_kops_SSHCredentialSpec_To_v1alpha2_SSHCredentialSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_ScheduledScalingSpec_To_kops_ScheduledScalingSpec(in *ScheduledScalingSpec, out *kops.ScheduledScalingSpec, s conversion.Scope) error {
+	out.Cron = in.Cron
+	out.MinSize = in.MinSize
+	out.MaxSize = in.MaxSize
+	out.TimeZone = in.TimeZone
+	return nil
+}
+
+// Convert_v1alpha2_ScheduledScalingSpec_To_kops_ScheduledScalingSpec is an autogenerated conversion function.
+func Convert_v1alpha2_ScheduledScalingSpec_To_kops_ScheduledScalingSpec(in *ScheduledScalingSpec, out *kops.ScheduledScalingSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ScheduledScalingSpec_To_kops_ScheduledScalingSpec(in, out, s)
+}
+
+func autoConvert_kops_ScheduledScalingSpec_To_v1alpha2_ScheduledScalingSpec(in *kops.ScheduledScalingSpec, out *ScheduledScalingSpec, s conversion.Scope) error {
+	out.Cron = in.Cron
+	out.MinSize = in.MinSize
+	out.MaxSize = in.MaxSize
+	out.TimeZone = in.TimeZone
+	return nil
+}
+
+// Convert_kops_ScheduledScalingSpec_To_v1alpha2_ScheduledScalingSpec is an autogenerated conversion function.
+func Convert_kops_ScheduledScalingSpec_To_v1alpha2_ScheduledScalingSpec(in *kops.ScheduledScalingSpec, out *ScheduledScalingSpec, s conversion.Scope) error {
+	return autoConvert_kops_ScheduledScalingSpec_To_v1alpha2_ScheduledScalingSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_ServiceAccountExternalPermission_To_kops_ServiceAccountExternalPermission(in *ServiceAccountExternalPermission, out *kops.ServiceAccountExternalPermission, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Namespace = in.Namespace
@@ -7248,6 +8196,178 @@ func Convert_kops_SnapshotControllerConfig_To_v1alpha2_SnapshotControllerConfig(
 	return autoConvert_kops_SnapshotControllerConfig_To_v1alpha2_SnapshotControllerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_SnapshotScheduleSpec_To_kops_SnapshotScheduleSpec(in *SnapshotScheduleSpec, out *kops.SnapshotScheduleSpec, s conversion.Scope) error {
+	out.Cron = in.Cron
+	out.Retention = in.Retention
+	out.Namespaces = in.Namespaces
+	return nil
+}
+
+// Convert_v1alpha2_SnapshotScheduleSpec_To_kops_SnapshotScheduleSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SnapshotScheduleSpec_To_kops_SnapshotScheduleSpec(in *SnapshotScheduleSpec, out *kops.SnapshotScheduleSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SnapshotScheduleSpec_To_kops_SnapshotScheduleSpec(in, out, s)
+}
+
+func autoConvert_kops_SnapshotScheduleSpec_To_v1alpha2_SnapshotScheduleSpec(in *kops.SnapshotScheduleSpec, out *SnapshotScheduleSpec, s conversion.Scope) error {
+	out.Cron = in.Cron
+	out.Retention = in.Retention
+	out.Namespaces = in.Namespaces
+	return nil
+}
+
+// Convert_kops_SnapshotScheduleSpec_To_v1alpha2_SnapshotScheduleSpec is an autogenerated conversion function.
+func Convert_kops_SnapshotScheduleSpec_To_v1alpha2_SnapshotScheduleSpec(in *kops.SnapshotScheduleSpec, out *SnapshotScheduleSpec, s conversion.Scope) error {
+	return autoConvert_kops_SnapshotScheduleSpec_To_v1alpha2_SnapshotScheduleSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_SnapshotsSpec_To_kops_SnapshotsSpec(in *SnapshotsSpec, out *kops.SnapshotsSpec, s conversion.Scope) error {
+	out.Image = in.Image
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(kops.SnapshotScheduleSpec)
+		if err := Convert_v1alpha2_SnapshotScheduleSpec_To_kops_SnapshotScheduleSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_SnapshotsSpec_To_kops_SnapshotsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SnapshotsSpec_To_kops_SnapshotsSpec(in *SnapshotsSpec, out *kops.SnapshotsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SnapshotsSpec_To_kops_SnapshotsSpec(in, out, s)
+}
+
+func autoConvert_kops_SnapshotsSpec_To_v1alpha2_SnapshotsSpec(in *kops.SnapshotsSpec, out *SnapshotsSpec, s conversion.Scope) error {
+	out.Image = in.Image
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(SnapshotScheduleSpec)
+		if err := Convert_kops_SnapshotScheduleSpec_To_v1alpha2_SnapshotScheduleSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schedule = nil
+	}
+	return nil
+}
+
+// Convert_kops_SnapshotsSpec_To_v1alpha2_SnapshotsSpec is an autogenerated conversion function.
+func Convert_kops_SnapshotsSpec_To_v1alpha2_SnapshotsSpec(in *kops.SnapshotsSpec, out *SnapshotsSpec, s conversion.Scope) error {
+	return autoConvert_kops_SnapshotsSpec_To_v1alpha2_SnapshotsSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_SubnetSelectorSpec_To_kops_SubnetSelectorSpec(in *SubnetSelectorSpec, out *kops.SubnetSelectorSpec, s conversion.Scope) error {
+	out.Type = kops.SubnetType(in.Type)
+	out.Zones = in.Zones
+	return nil
+}
+
+// Convert_v1alpha2_SubnetSelectorSpec_To_kops_SubnetSelectorSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SubnetSelectorSpec_To_kops_SubnetSelectorSpec(in *SubnetSelectorSpec, out *kops.SubnetSelectorSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SubnetSelectorSpec_To_kops_SubnetSelectorSpec(in, out, s)
+}
+
+func autoConvert_kops_SubnetSelectorSpec_To_v1alpha2_SubnetSelectorSpec(in *kops.SubnetSelectorSpec, out *SubnetSelectorSpec, s conversion.Scope) error {
+	out.Type = SubnetType(in.Type)
+	out.Zones = in.Zones
+	return nil
+}
+
+// Convert_kops_SubnetSelectorSpec_To_v1alpha2_SubnetSelectorSpec is an autogenerated conversion function.
+func Convert_kops_SubnetSelectorSpec_To_v1alpha2_SubnetSelectorSpec(in *kops.SubnetSelectorSpec, out *SubnetSelectorSpec, s conversion.Scope) error {
+	return autoConvert_kops_SubnetSelectorSpec_To_v1alpha2_SubnetSelectorSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_SwapSpec_To_kops_SwapSpec(in *SwapSpec, out *kops.SwapSpec, s conversion.Scope) error {
+	out.SizeMB = in.SizeMB
+	out.PercentOfMemory = in.PercentOfMemory
+	out.Behavior = in.Behavior
+	return nil
+}
+
+// Convert_v1alpha2_SwapSpec_To_kops_SwapSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SwapSpec_To_kops_SwapSpec(in *SwapSpec, out *kops.SwapSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SwapSpec_To_kops_SwapSpec(in, out, s)
+}
+
+func autoConvert_kops_SwapSpec_To_v1alpha2_SwapSpec(in *kops.SwapSpec, out *SwapSpec, s conversion.Scope) error {
+	out.SizeMB = in.SizeMB
+	out.PercentOfMemory = in.PercentOfMemory
+	out.Behavior = in.Behavior
+	return nil
+}
+
+// Convert_kops_SwapSpec_To_v1alpha2_SwapSpec is an autogenerated conversion function.
+func Convert_kops_SwapSpec_To_v1alpha2_SwapSpec(in *kops.SwapSpec, out *SwapSpec, s conversion.Scope) error {
+	return autoConvert_kops_SwapSpec_To_v1alpha2_SwapSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_TagPolicyRule_To_kops_TagPolicyRule(in *TagPolicyRule, out *kops.TagPolicyRule, s conversion.Scope) error {
+	out.Tags = in.Tags
+	out.Include = in.Include
+	out.Exclude = in.Exclude
+	return nil
+}
+
+// Convert_v1alpha2_TagPolicyRule_To_kops_TagPolicyRule is an autogenerated conversion function.
+func Convert_v1alpha2_TagPolicyRule_To_kops_TagPolicyRule(in *TagPolicyRule, out *kops.TagPolicyRule, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TagPolicyRule_To_kops_TagPolicyRule(in, out, s)
+}
+
+func autoConvert_kops_TagPolicyRule_To_v1alpha2_TagPolicyRule(in *kops.TagPolicyRule, out *TagPolicyRule, s conversion.Scope) error {
+	out.Tags = in.Tags
+	out.Include = in.Include
+	out.Exclude = in.Exclude
+	return nil
+}
+
+// Convert_kops_TagPolicyRule_To_v1alpha2_TagPolicyRule is an autogenerated conversion function.
+func Convert_kops_TagPolicyRule_To_v1alpha2_TagPolicyRule(in *kops.TagPolicyRule, out *TagPolicyRule, s conversion.Scope) error {
+	return autoConvert_kops_TagPolicyRule_To_v1alpha2_TagPolicyRule(in, out, s)
+}
+
+func autoConvert_v1alpha2_TagPolicySpec_To_kops_TagPolicySpec(in *TagPolicySpec, out *kops.TagPolicySpec, s conversion.Scope) error {
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]kops.TagPolicyRule, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_TagPolicyRule_To_kops_TagPolicyRule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Rules = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_TagPolicySpec_To_kops_TagPolicySpec is an autogenerated conversion function.
+func Convert_v1alpha2_TagPolicySpec_To_kops_TagPolicySpec(in *TagPolicySpec, out *kops.TagPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TagPolicySpec_To_kops_TagPolicySpec(in, out, s)
+}
+
+func autoConvert_kops_TagPolicySpec_To_v1alpha2_TagPolicySpec(in *kops.TagPolicySpec, out *TagPolicySpec, s conversion.Scope) error {
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]TagPolicyRule, len(*in))
+		for i := range *in {
+			if err := Convert_kops_TagPolicyRule_To_v1alpha2_TagPolicyRule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Rules = nil
+	}
+	return nil
+}
+
+// Convert_kops_TagPolicySpec_To_v1alpha2_TagPolicySpec is an autogenerated conversion function.
+func Convert_kops_TagPolicySpec_To_v1alpha2_TagPolicySpec(in *kops.TagPolicySpec, out *TagPolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_TagPolicySpec_To_v1alpha2_TagPolicySpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_TargetSpec_To_kops_TargetSpec(in *TargetSpec, out *kops.TargetSpec, s conversion.Scope) error {
 	if in.Terraform != nil {
 		in, out := &in.Terraform, &out.Terraform
@@ -7284,9 +8404,44 @@ func Convert_kops_TargetSpec_To_v1alpha2_TargetSpec(in *kops.TargetSpec, out *Ta
 	return autoConvert_kops_TargetSpec_To_v1alpha2_TargetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_TerraformAssumeRoleSpec_To_kops_TerraformAssumeRoleSpec(in *TerraformAssumeRoleSpec, out *kops.TerraformAssumeRoleSpec, s conversion.Scope) error {
+	out.RoleARN = in.RoleARN
+	out.SessionName = in.SessionName
+	out.ExternalID = in.ExternalID
+	return nil
+}
+
+// Convert_v1alpha2_TerraformAssumeRoleSpec_To_kops_TerraformAssumeRoleSpec is an autogenerated conversion function.
+func Convert_v1alpha2_TerraformAssumeRoleSpec_To_kops_TerraformAssumeRoleSpec(in *TerraformAssumeRoleSpec, out *kops.TerraformAssumeRoleSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TerraformAssumeRoleSpec_To_kops_TerraformAssumeRoleSpec(in, out, s)
+}
+
+func autoConvert_kops_TerraformAssumeRoleSpec_To_v1alpha2_TerraformAssumeRoleSpec(in *kops.TerraformAssumeRoleSpec, out *TerraformAssumeRoleSpec, s conversion.Scope) error {
+	out.RoleARN = in.RoleARN
+	out.SessionName = in.SessionName
+	out.ExternalID = in.ExternalID
+	return nil
+}
+
+// Convert_kops_TerraformAssumeRoleSpec_To_v1alpha2_TerraformAssumeRoleSpec is an autogenerated conversion function.
+func Convert_kops_TerraformAssumeRoleSpec_To_v1alpha2_TerraformAssumeRoleSpec(in *kops.TerraformAssumeRoleSpec, out *TerraformAssumeRoleSpec, s conversion.Scope) error {
+	return autoConvert_kops_TerraformAssumeRoleSpec_To_v1alpha2_TerraformAssumeRoleSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out *kops.TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.ProviderAlias = in.ProviderAlias
+	if in.AssumeRole != nil {
+		in, out := &in.AssumeRole, &out.AssumeRole
+		*out = new(kops.TerraformAssumeRoleSpec)
+		if err := Convert_v1alpha2_TerraformAssumeRoleSpec_To_kops_TerraformAssumeRoleSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AssumeRole = nil
+	}
+	out.DefaultTags = in.DefaultTags
 	return nil
 }
 
@@ -7298,6 +8453,17 @@ func Convert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out
 func autoConvert_kops_TerraformSpec_To_v1alpha2_TerraformSpec(in *kops.TerraformSpec, out *TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.ProviderAlias = in.ProviderAlias
+	if in.AssumeRole != nil {
+		in, out := &in.AssumeRole, &out.AssumeRole
+		*out = new(TerraformAssumeRoleSpec)
+		if err := Convert_kops_TerraformAssumeRoleSpec_To_v1alpha2_TerraformAssumeRoleSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AssumeRole = nil
+	}
+	out.DefaultTags = in.DefaultTags
 	return nil
 }
 
@@ -7341,6 +8507,7 @@ func autoConvert_v1alpha2_UserData_To_kops_UserData(in *UserData, out *kops.User
 	out.Name = in.Name
 	out.Type = in.Type
 	out.Content = in.Content
+	out.AllowSecrets = in.AllowSecrets
 	return nil
 }
 
@@ -7353,6 +8520,7 @@ func autoConvert_kops_UserData_To_v1alpha2_UserData(in *kops.UserData, out *User
 	out.Name = in.Name
 	out.Type = in.Type
 	out.Content = in.Content
+	out.AllowSecrets = in.AllowSecrets
 	return nil
 }
 
@@ -7361,6 +8529,32 @@ func Convert_kops_UserData_To_v1alpha2_UserData(in *kops.UserData, out *UserData
 	return autoConvert_kops_UserData_To_v1alpha2_UserData(in, out, s)
 }
 
+func autoConvert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec(in *VPCEndpointSpec, out *kops.VPCEndpointSpec, s conversion.Scope) error {
+	out.ServiceName = in.ServiceName
+	out.Type = kops.VPCEndpointType(in.Type)
+	out.Subnets = in.Subnets
+	out.PrivateDNS = in.PrivateDNS
+	return nil
+}
+
+// Convert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec is an autogenerated conversion function.
+func Convert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec(in *VPCEndpointSpec, out *kops.VPCEndpointSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VPCEndpointSpec_To_kops_VPCEndpointSpec(in, out, s)
+}
+
+func autoConvert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec(in *kops.VPCEndpointSpec, out *VPCEndpointSpec, s conversion.Scope) error {
+	out.ServiceName = in.ServiceName
+	out.Type = VPCEndpointType(in.Type)
+	out.Subnets = in.Subnets
+	out.PrivateDNS = in.PrivateDNS
+	return nil
+}
+
+// Convert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec is an autogenerated conversion function.
+func Convert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec(in *kops.VPCEndpointSpec, out *VPCEndpointSpec, s conversion.Scope) error {
+	return autoConvert_kops_VPCEndpointSpec_To_v1alpha2_VPCEndpointSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpec, out *kops.VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
 	out.Filesystem = in.Filesystem
@@ -7427,6 +8621,7 @@ func autoConvert_v1alpha2_WarmPoolSpec_To_kops_WarmPoolSpec(in *WarmPoolSpec, ou
 	out.MinSize = in.MinSize
 	out.MaxSize = in.MaxSize
 	out.EnableLifecycleHook = in.EnableLifecycleHook
+	out.ReuseOnScaleIn = in.ReuseOnScaleIn
 	return nil
 }
 
@@ -7439,6 +8634,7 @@ func autoConvert_kops_WarmPoolSpec_To_v1alpha2_WarmPoolSpec(in *kops.WarmPoolSpe
 	out.MinSize = in.MinSize
 	out.MaxSize = in.MaxSize
 	out.EnableLifecycleHook = in.EnableLifecycleHook
+	out.ReuseOnScaleIn = in.ReuseOnScaleIn
 	return nil
 }
 
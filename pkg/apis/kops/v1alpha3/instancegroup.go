@@ -78,6 +78,9 @@ type InstanceGroupSpec struct {
 	VolumeMounts []VolumeMountSpec `json:"volumeMounts,omitempty"`
 	// Subnets is the names of the Subnets (as specified in the Cluster) where machines in this instance group should be placed
 	Subnets []string `json:"subnets,omitempty"`
+	// SubnetSelectors selects the subnets of the cluster where machines in this instance group should be placed, instead of listing them in subnets.
+	// A subnet is selected if it matches any of the selectors.
+	SubnetSelectors []SubnetSelectorSpec `json:"subnetSelectors,omitempty"`
 	// Zones is the names of the Zones where machines in this instance group should be placed
 	// This is needed for regional subnets (e.g. GCE), to restrict placement to particular zones
 	Zones []string `json:"zones,omitempty"`
@@ -99,9 +102,11 @@ type InstanceGroupSpec struct {
 	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 	// FileAssets is a collection of file assets for this instance group
 	FileAssets []FileAssetSpec `json:"fileAssets,omitempty"`
-	// Describes the tenancy of this instance group. Can be either default or dedicated.
+	// Describes the tenancy of this instance group. Can be default, dedicated or host.
 	// Currently only applies to AWS.
 	Tenancy string `json:"tenancy,omitempty"`
+	// HostResourceGroupARN is the ARN of a host resource group in which to launch the instances, when the tenancy is host (AWS Only)
+	HostResourceGroupARN *string `json:"hostResourceGroupARN,omitempty"`
 	// Kubelet overrides kubelet config from the ClusterSpec
 	Kubelet *KubeletConfigSpec `json:"kubelet,omitempty"`
 	// Taints indicates the kubernetes taints for nodes in this instance group
@@ -110,6 +115,9 @@ type InstanceGroupSpec struct {
 	MixedInstancesPolicy *MixedInstancesPolicySpec `json:"mixedInstancesPolicy,omitempty"`
 	// CapacityRebalance makes ASGs proactively replace spot instances when the ASG receives a rebalance recommendation (AWS Only).
 	CapacityRebalance *bool `json:"capacityRebalance,omitempty"`
+	// InstanceMaintenancePolicy controls the healthy capacity of the instance group while its instances are replaced
+	// by instance refreshes and Availability Zone rebalancing (AWS Only).
+	InstanceMaintenancePolicy *InstanceMaintenancePolicySpec `json:"instanceMaintenancePolicy,omitempty"`
 	// AdditionalUserData is any additional user-data to be passed to the host
 	AdditionalUserData []UserData `json:"additionalUserData,omitempty"`
 	// SuspendProcesses disables the listed Scaling Policies
@@ -122,6 +130,9 @@ type InstanceGroupSpec struct {
 	IAM *IAMProfileSpec `json:"iam,omitempty"`
 	// SecurityGroupOverride overrides the default security group created by Kops for this IG (AWS only).
 	SecurityGroupOverride *string `json:"securityGroupOverride,omitempty"`
+	// NodePortAccess is a list of the CIDRs that can access the node ports range of the instances in this group (AWS only).
+	// The range is kubeAPIServer.serviceNodePortRange, 30000-32767 by default.
+	NodePortAccess []string `json:"nodePortAccess,omitempty"`
 	// InstanceProtection makes new instances in an autoscaling group protected from scale in
 	InstanceProtection *bool `json:"instanceProtection,omitempty"`
 	// SysctlParameters will configure kernel parameters using sysctl(8). When
@@ -137,6 +148,14 @@ type InstanceGroupSpec struct {
 	CompressUserData *bool `json:"compressUserData,omitempty"`
 	// InstanceMetadata defines the EC2 instance metadata service options (AWS Only)
 	InstanceMetadata *InstanceMetadataOptions `json:"instanceMetadata,omitempty"`
+	// Placement places the instances in an EC2 placement group, to spread them across distinct hardware (AWS Only)
+	Placement *InstanceGroupPlacementSpec `json:"placement,omitempty"`
+	// NetworkInterfaces configures the primary network interface of the instances (AWS Only)
+	NetworkInterfaces *NetworkInterfacesSpec `json:"networkInterfaces,omitempty"`
+	// CapacityReservationID is the ID of an EC2 Capacity Reservation the instances are launched into (AWS Only)
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+	// CapacityReservationResourceGroupARN is the ARN of a resource group of EC2 Capacity Reservations the instances are launched into (AWS Only)
+	CapacityReservationResourceGroupARN *string `json:"capacityReservationResourceGroupARN,omitempty"`
 	// UpdatePolicy determines the policy for applying upgrades automatically.
 	// If specified, this value overrides a value specified in the Cluster's "spec.updatePolicy" field.
 	// Valid values:
@@ -145,12 +164,24 @@ type InstanceGroupSpec struct {
 	UpdatePolicy *string `json:"updatePolicy,omitempty"`
 	// WarmPool configures an ASG warm pool for the instance group
 	WarmPool *WarmPoolSpec `json:"warmPool,omitempty"`
+	// ScheduledScaling changes the size of the instance group at recurring times (AWS only).
+	ScheduledScaling []ScheduledScalingSpec `json:"scheduledScaling,omitempty"`
+	// KubernetesVersion overrides the version of the Kubernetes node binaries (kubelet and kubectl) of the instance group,
+	// for example to canary a new version before upgrading the cluster. It must be within the supported version skew
+	// of the control plane. Not supported for control plane or apiserver instance groups.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
 	// Containerd specifies override configuration for instance group
 	Containerd *ContainerdConfig `json:"containerd,omitempty"`
 	// Packages specifies additional packages to be installed.
 	Packages []string `json:"packages,omitempty"`
 	// GuestAccelerators configures additional accelerators
 	GuestAccelerators []AcceleratorConfig `json:"guestAccelerators,omitempty"`
+	// GPUConfig configures the GPUs of the instances.
+	GPUConfig *GPUConfigSpec `json:"gpuConfig,omitempty"`
+	// Swap configures a swap file on the instances. Not supported for control plane instance groups.
+	Swap *SwapSpec `json:"swap,omitempty"`
+	// JournaldMaxUse limits the disk space used by the systemd journal on the instances (SystemMaxUse of journald).
+	JournaldMaxUse *resource.Quantity `json:"journaldMaxUse,omitempty"`
 	// MaxInstanceLifetime to the maximum amount of time, in seconds, that an instance can be in service.
 	// Value expected must be in form of duration ("ms", "s", "m", "h")
 	MaxInstanceLifetime *metav1.Duration `json:"maxInstanceLifetime,omitempty"`
@@ -159,6 +190,8 @@ type InstanceGroupSpec struct {
 	//   'STANDARD': (default) standard provisioning with user controlled run time, no discounts
 	//   'SPOT': heavily discounted, no guaranteed run time.
 	GCPProvisioningModel *string `json:"gcpProvisioningModel,omitempty"`
+	// GCE configures GCE specific options of the instances (GCE only).
+	GCE *GCEInstanceGroupSpec `json:"gce,omitempty"`
 }
 
 // InstanceRootVolumeSpec specifies options for an instance's root volume.
@@ -187,8 +220,79 @@ type InstanceMetadataOptions struct {
 	// HTTPTokens is the state of token usage for the instance metadata requests.
 	// If the parameter is not specified in the request, the default state is "required".
 	HTTPTokens *string `json:"httpTokens,omitempty"`
+	// AllowDowngrade allows an instance group to set httpTokens to "optional"
+	// when the cluster default requires tokens. Only used on instance groups.
+	AllowDowngrade *bool `json:"allowDowngrade,omitempty"`
 }
 
+// InstanceMaintenancePolicySpec defines the healthy capacity of an autoscaling group while its instances are replaced (AWS Only)
+type InstanceMaintenancePolicySpec struct {
+	// MinHealthyPercentage is the percentage of the desired capacity that must remain healthy, from 0 to 100.
+	MinHealthyPercentage *int32 `json:"minHealthyPercentage,omitempty"`
+	// MaxHealthyPercentage is the percentage of the desired capacity that may be running, from 100 to 200.
+	MaxHealthyPercentage *int32 `json:"maxHealthyPercentage,omitempty"`
+}
+
+// InstanceGroupPlacementSpec defines the EC2 placement group of an instance group (AWS Only)
+type InstanceGroupPlacementSpec struct {
+	// Strategy is the placement strategy: spread places each instance on distinct hardware,
+	// partition spreads the instances across partitions that do not share hardware,
+	// and cluster packs the instances close together in a single availability zone.
+	Strategy InstanceGroupPlacementStrategy `json:"strategy,omitempty"`
+	// PartitionCount is the number of partitions, from 1 to 7, when the strategy is partition.
+	PartitionCount *int32 `json:"partitionCount,omitempty"`
+	// Name is the name of an existing placement group to use instead of creating one.
+	// kOps does not create, modify or delete a placement group referenced by name.
+	Name string `json:"name,omitempty"`
+}
+
+// NetworkInterfacesSpec configures the primary network interface of the instances of an instance group (AWS Only)
+type NetworkInterfacesSpec struct {
+	// ENASRDEnabled enables ENA Express, which uses the AWS Scalable Reliable Datagram (SRD) protocol for TCP traffic.
+	ENASRDEnabled *bool `json:"enaSrdEnabled,omitempty"`
+	// ENASRDUDPEnabled enables ENA Express for UDP traffic. Requires enaSrdEnabled.
+	ENASRDUDPEnabled *bool `json:"enaSrdUdpEnabled,omitempty"`
+}
+
+// GCEInstanceGroupSpec configures the instances of an instance group on GCE.
+type GCEInstanceGroupSpec struct {
+	// LocalSSDCount is the number of 375 GB local SSDs attached to each instance.
+	// The first one is used for the containerd data directory.
+	LocalSSDCount *int32 `json:"localSSDCount,omitempty"`
+	// LocalSSDInterface is the interface of the local SSDs: NVME (default) or SCSI.
+	LocalSSDInterface string `json:"localSSDInterface,omitempty"`
+	// BootDiskType is the type of the boot disk, such as pd-balanced or hyperdisk-balanced.
+	// It cannot be combined with rootVolume.type.
+	BootDiskType string `json:"bootDiskType,omitempty"`
+}
+
+// GPUConfigSpec configures the GPUs of an instance group.
+type GPUConfigSpec struct {
+	// MIGProfile is the NVIDIA Multi-Instance GPU profile that all the GPUs of the instances are partitioned into, e.g. "1g.10gb".
+	MIGProfile string `json:"migProfile,omitempty"`
+}
+
+// SubnetSelectorSpec selects subnets of the cluster by type and zone.
+type SubnetSelectorSpec struct {
+	// Type selects the subnets of this type: Private, Public or DualStack.
+	Type SubnetType `json:"type,omitempty"`
+	// Zones selects the subnets in these zones. All zones are selected if empty.
+	Zones []string `json:"zones,omitempty"`
+}
+
+// SwapSpec configures the swap file of an instance group.
+type SwapSpec struct {
+	// SizeMB is the size of the swap file, in megabytes.
+	SizeMB *int32 `json:"sizeMB,omitempty"`
+	// PercentOfMemory is the size of the swap file, as a percentage of the memory of the instance.
+	PercentOfMemory *int32 `json:"percentOfMemory,omitempty"`
+	// Behavior defines how swap is used by container workloads: LimitedSwap (the default) or NoSwap.
+	Behavior string `json:"behavior,omitempty"`
+}
+
+// InstanceGroupPlacementStrategy is the strategy of an EC2 placement group
+type InstanceGroupPlacementStrategy string
+
 // MixedInstancesPolicySpec defines the specification for an autoscaling group backed by a ec2 fleet
 type MixedInstancesPolicySpec struct {
 	// Instances is a list of instance types which we are willing to run in the EC2 fleet
@@ -218,6 +322,14 @@ type MixedInstancesPolicySpec struct {
 type InstanceRequirementsSpec struct {
 	CPU    *MinMaxSpec `json:"cpu,omitempty"`
 	Memory *MinMaxSpec `json:"memory,omitempty"`
+	// GPU is the number of GPUs of the instance types. Set max to 0 to exclude instance types with accelerators.
+	GPU *MinMaxSpec `json:"gpu,omitempty"`
+	// BurstablePerformance indicates whether burstable performance instance types are "included" (the default), "excluded" or "required".
+	BurstablePerformance *string `json:"burstablePerformance,omitempty"`
+	// AllowedInstanceTypes limits the instance types to those matching one of these patterns, such as "m5.*" or "c6*".
+	AllowedInstanceTypes []string `json:"allowedInstanceTypes,omitempty"`
+	// ExcludedInstanceTypes excludes the instance types matching one of these patterns, such as "t2.*" or "*.metal".
+	ExcludedInstanceTypes []string `json:"excludedInstanceTypes,omitempty"`
 }
 
 type MinMaxSpec struct {
@@ -225,6 +337,18 @@ type MinMaxSpec struct {
 	Min *resource.Quantity `json:"min,omitempty"`
 }
 
+// ScheduledScalingSpec sets the size of an instance group at recurring times
+type ScheduledScalingSpec struct {
+	// Cron is the recurring schedule, in the five-field cron format: minute hour day-of-month month day-of-week.
+	Cron string `json:"cron,omitempty"`
+	// MinSize is the minimum size of the instance group from the scheduled time on.
+	MinSize *int32 `json:"minSize,omitempty"`
+	// MaxSize is the maximum size of the instance group from the scheduled time on.
+	MaxSize *int32 `json:"maxSize,omitempty"`
+	// TimeZone is the IANA time zone of the schedule, such as "Europe/Paris". The default is UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // UserData defines a user-data section
 type UserData struct {
 	// Name is the name of the user-data
@@ -233,6 +357,9 @@ type UserData struct {
 	Type string `json:"type,omitempty"`
 	// Content is the user-data content
 	Content string `json:"content,omitempty"`
+	// AllowSecrets allows the content to contain what looks like a secret, such as an AWS access key.
+	// User data can be read by anyone allowed to describe the instances or their launch templates.
+	AllowSecrets bool `json:"allowSecrets,omitempty"`
 }
 
 // VolumeSpec defined the spec for an additional volume attached to the instance group
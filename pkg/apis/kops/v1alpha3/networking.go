@@ -18,6 +18,7 @@ package v1alpha3
 
 import (
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"k8s.io/kops/pkg/apis/kops"
 )
@@ -47,6 +48,14 @@ type NetworkingSpec struct {
 	Topology *TopologySpec `json:"topology,omitempty"`
 	// HTTPProxy defines connection information to support use of a private cluster behind an forward HTTP Proxy
 	EgressProxy *EgressProxySpec `json:"egressProxy,omitempty"`
+	// VPCEndpoints are the VPC endpoints that kOps creates in the network (AWS only).
+	VPCEndpoints []VPCEndpointSpec `json:"vpcEndpoints,omitempty"`
+	// NATInstanceType is the machine type of the NAT instances kOps creates for subnets with egress natInstance (AWS only).
+	// Default: t3.nano.
+	NATInstanceType string `json:"natInstanceType,omitempty"`
+	// NATInstanceImage is the image of the NAT instances kOps creates for subnets with egress natInstance (AWS only).
+	// Default: the latest Amazon Linux 2023 image for x86_64.
+	NATInstanceImage string `json:"natInstanceImage,omitempty"`
 
 	// NonMasqueradeCIDR is the CIDR for the internal k8s network (for pod IPs)
 	// It cannot overlap ServiceClusterIPRange
@@ -63,6 +72,9 @@ type NetworkingSpec struct {
 	//  * run kube-proxy on the master
 	//  * enable debugging handlers on the master, so kubectl logs works
 	IsolateControlPlane *bool `json:"isolateControlPlane,omitempty"`
+	// DefaultComponentNetworkPolicies installs NetworkPolicies restricting access to the kube-system components
+	// that kOps manages. It requires a networking plugin that enforces NetworkPolicy.
+	DefaultComponentNetworkPolicies *bool `json:"defaultComponentNetworkPolicies,omitempty"`
 
 	// The following specify the selection and configuration of a networking plugin.
 	// Exactly one of the fields must be non-null.
@@ -84,6 +96,28 @@ type NetworkingSpec struct {
 	GCP        *GCPNetworkingSpec          `json:"gcp,omitempty"`
 }
 
+// VPCEndpointType is the type of a VPC endpoint.
+type VPCEndpointType string
+
+const (
+	// VPCEndpointTypeGateway is a gateway endpoint, which is added as a route to the route tables managed by kOps.
+	VPCEndpointTypeGateway VPCEndpointType = "Gateway"
+	// VPCEndpointTypeInterface is an interface endpoint, which creates a network interface in each of its subnets.
+	VPCEndpointTypeInterface VPCEndpointType = "Interface"
+)
+
+// VPCEndpointSpec configures a VPC endpoint managed by kOps.
+type VPCEndpointSpec struct {
+	// ServiceName is the name of the endpoint service, such as com.amazonaws.us-east-1.s3.
+	ServiceName string `json:"serviceName,omitempty"`
+	// Type is the type of the endpoint, either Gateway or Interface.
+	Type VPCEndpointType `json:"type,omitempty"`
+	// Subnets are the names of the cluster subnets an Interface endpoint is placed in.
+	Subnets []string `json:"subnets,omitempty"`
+	// PrivateDNS associates a private hosted zone with an Interface endpoint. Default: true.
+	PrivateDNS *bool `json:"privateDNS,omitempty"`
+}
+
 // KubenetNetworkingSpec is the specification for kubenet networking, largely integrated but intended to replace classic
 type KubenetNetworkingSpec struct{}
 
@@ -232,6 +266,10 @@ type CalicoNetworkingSpec struct {
 	// WireguardEnabled enables WireGuard encryption for all on-the-wire pod-to-pod traffic
 	// (default: false)
 	WireguardEnabled bool `json:"wireguardEnabled,omitempty"`
+	// UpgradeMaxUnavailable is the maximum number of calico-node pods that can be unavailable
+	// while the DaemonSet is updated to a new version.
+	// (default: 1)
+	UpgradeMaxUnavailable *intstr.IntOrString `json:"upgradeMaxUnavailable,omitempty"`
 }
 
 // CanalNetworkingSpec declares that we want Canal networking
@@ -288,6 +326,31 @@ type AmazonVPCNetworkingSpec struct {
 	InitImage string `json:"initImage,omitempty"`
 	// Env is a list of environment variables to set in the container.
 	Env []EnvVar `json:"env,omitempty"`
+	// NetworkPolicyAgent configures the network policy agent, which enforces NetworkPolicy using eBPF.
+	NetworkPolicyAgent *AmazonVPCNetworkPolicyAgentSpec `json:"networkPolicyAgent,omitempty"`
+	// PrefixTargets enables prefix delegation and configures how many prefixes and addresses are kept warm on each node.
+	PrefixTargets *AmazonVPCPrefixTargetsSpec `json:"prefixTargets,omitempty"`
+}
+
+// AmazonVPCNetworkPolicyAgentSpec configures the Amazon VPC CNI network policy agent.
+type AmazonVPCNetworkPolicyAgentSpec struct {
+	// Enabled runs the network policy agent in the aws-node DaemonSet.
+	// Requires Kubernetes 1.25 and Amazon VPC CNI v1.14.0 or later.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image is the container image name to use for the agent.
+	Image string `json:"image,omitempty"`
+	// EnableCloudWatchLogs sends the policy decision logs of the agent to CloudWatch Logs.
+	EnableCloudWatchLogs *bool `json:"enableCloudWatchLogs,omitempty"`
+}
+
+// AmazonVPCPrefixTargetsSpec configures the warm pool of prefix delegation.
+type AmazonVPCPrefixTargetsSpec struct {
+	// WarmPrefixTarget is the number of free /28 prefixes to keep attached to each node (WARM_PREFIX_TARGET).
+	WarmPrefixTarget *int32 `json:"warmPrefixTarget,omitempty"`
+	// WarmIPTarget is the number of free IP addresses to keep available on each node (WARM_IP_TARGET).
+	WarmIPTarget *int32 `json:"warmIPTarget,omitempty"`
+	// MinimumIPTarget is the minimum number of IP addresses to keep allocated to each node (MINIMUM_IP_TARGET).
+	MinimumIPTarget *int32 `json:"minimumIPTarget,omitempty"`
 }
 
 type CiliumEncryptionType string
@@ -462,6 +525,11 @@ type CiliumNetworkingSpec struct {
 
 	// Ingress specifies the configuration for Cilium Ingress settings.
 	Ingress *CiliumIngressSpec `json:"ingress,omitempty"`
+
+	// UpgradeMaxUnavailable is the maximum number of Cilium agent pods that can be unavailable
+	// while the DaemonSet is updated to a new version.
+	// When unset, agent pods are only replaced when their node is replaced by a rolling update.
+	UpgradeMaxUnavailable *intstr.IntOrString `json:"upgradeMaxUnavailable,omitempty"`
 }
 
 // CiliumIngressSpec configures Cilium Ingress settings.
@@ -19,15 +19,21 @@ package validation
 import (
 	"fmt"
 	"net"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	awsIam "github.com/aws/aws-sdk-go/service/iam"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
@@ -51,10 +57,20 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 			allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslCertificate"), "sslCertificate requires a network load balancer. See https://github.com/kubernetes/kops/blob/master/permalinks/acm_nlb.md"))
 		}
 		allErrs = append(allErrs, awsValidateSSLPolicy(lbPath.Child("sslPolicy"), lbSpec)...)
+		allErrs = append(allErrs, awsValidateAccessLog(lbPath.Child("accessLog"), lbSpec)...)
+		if c.UsesNoneDNS() && lbSpec.CrossZoneLoadBalancing != nil && !*lbSpec.CrossZoneLoadBalancing {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("crossZoneLoadBalancing"), "cross-zone load balancing is always enabled for clusters with topology.dns.type=None"))
+		}
 		allErrs = append(allErrs, awsValidateLoadBalancerSubnets(lbPath.Child("subnets"), c.Spec)...)
+		allErrs = append(allErrs, awsValidateLoadBalancerIPAddressType(lbPath, c.Spec)...)
+		allErrs = append(allErrs, awsValidateLoadBalancerSecurityGroups(lbPath, lbSpec)...)
 	}
 
 	allErrs = append(allErrs, awsValidateEBSCSIDriver(c)...)
+	allErrs = append(allErrs, awsValidatePrefixLists(c)...)
+	allErrs = append(allErrs, awsValidateAccessLists(c)...)
+	allErrs = append(allErrs, awsValidateSubnetZones(c)...)
+	allErrs = append(allErrs, awsValidateAPIPublicIPs(c)...)
 
 	if c.Spec.Authentication != nil && c.Spec.Authentication.AWS != nil {
 		allErrs = append(allErrs, awsValidateIAMAuthenticator(field.NewPath("spec", "authentication", "aws"), c.Spec.Authentication.AWS)...)
@@ -63,6 +79,218 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 	return allErrs
 }
 
+// elasticIPAllocationIDRegex matches the allocation IDs of Elastic IPs, such as "eipalloc-0123456789abcdef0"
+var elasticIPAllocationIDRegex = regexp.MustCompile(`^eipalloc-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// awsValidateAPIPublicIPs checks the Elastic IPs that kops-controller associates with the control-plane nodes.
+func awsValidateAPIPublicIPs(c *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	publicIPs := c.Spec.API.PublicIPs
+	if publicIPs == nil {
+		return allErrs
+	}
+	fieldPath := field.NewPath("spec", "api", "publicIPs")
+
+	if c.Spec.API.LoadBalancer != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "publicIPs cannot be used when the API uses a load balancer"))
+	}
+	if len(publicIPs.AllocationIDs) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("allocationIDs"), "at least one zone must have an Elastic IP"))
+	}
+
+	publicZones := sets.NewString()
+	for _, subnet := range c.Spec.Networking.Subnets {
+		if subnet.Type == kops.SubnetTypePublic {
+			publicZones.Insert(subnet.Zone)
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, zone := range sets.StringKeySet(publicIPs.AllocationIDs).List() {
+		allocationID := publicIPs.AllocationIDs[zone]
+		zonePath := fieldPath.Child("allocationIDs").Key(zone)
+		if !elasticIPAllocationIDRegex.MatchString(allocationID) {
+			allErrs = append(allErrs, field.Invalid(zonePath, allocationID, "must be an Elastic IP allocation ID such as eipalloc-0123456789abcdef0"))
+		}
+		if !publicZones.Has(zone) {
+			allErrs = append(allErrs, field.Forbidden(zonePath, fmt.Sprintf("zone %q has no public subnet for the control-plane nodes", zone)))
+		}
+		if other, found := seen[allocationID]; found {
+			allErrs = append(allErrs, field.Duplicate(zonePath, fmt.Sprintf("%s is already used by zone %q", allocationID, other)))
+		}
+		seen[allocationID] = zone
+	}
+
+	return allErrs
+}
+
+// prefixListIDRegex matches the IDs of managed prefix lists, such as "pl-0123456789abcdef0"
+var prefixListIDRegex = regexp.MustCompile(`^pl-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// awsValidatePrefixLists checks the managed prefix lists used in place of CIDRs in the access lists.
+func awsValidatePrefixLists(cluster *kops.Cluster) (allErrs field.ErrorList) {
+	accessLists := []struct {
+		fieldPath *field.Path
+		entries   []string
+	}{
+		{field.NewPath("spec", "sshAccess"), cluster.Spec.SSHAccess},
+		{field.NewPath("spec", "api", "access"), cluster.Spec.API.Access},
+		{field.NewPath("spec", "nodePortAccess"), cluster.Spec.NodePortAccess},
+	}
+	for _, accessList := range accessLists {
+		for i, entry := range accessList.entries {
+			if strings.HasPrefix(entry, "pl-") && !prefixListIDRegex.MatchString(entry) {
+				allErrs = append(allErrs, field.Invalid(accessList.fieldPath.Index(i), entry, "must be a prefix list ID such as pl-0123456789abcdef0"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// awsClusterHasIPv6 returns true if the network of the cluster has IPv6 addresses.
+// VPCs managed by kOps are always associated with an Amazon-provided IPv6 CIDR block.
+func awsClusterHasIPv6(c *kops.Cluster) bool {
+	if c.Spec.IsIPv6Only() || c.Spec.Networking.NetworkID == "" {
+		return true
+	}
+	for _, subnet := range c.Spec.Networking.Subnets {
+		if subnet.IPv6CIDR != "" || subnet.Type == kops.SubnetTypeDualStack {
+			return true
+		}
+	}
+	return false
+}
+
+// awsValidateAccessLists checks the CIDRs of the access lists for duplicates and for IPv6 CIDRs in clusters without IPv6.
+func awsValidateAccessLists(cluster *kops.Cluster) (allErrs field.ErrorList) {
+	accessLists := []struct {
+		fieldPath *field.Path
+		entries   []string
+	}{
+		{field.NewPath("spec", "sshAccess"), cluster.Spec.SSHAccess},
+		{field.NewPath("spec", "api", "access"), cluster.Spec.API.Access},
+		{field.NewPath("spec", "nodePortAccess"), cluster.Spec.NodePortAccess},
+	}
+	hasIPv6 := awsClusterHasIPv6(cluster)
+	for _, accessList := range accessLists {
+		allErrs = append(allErrs, awsValidateAccessList(accessList.fieldPath, accessList.entries, hasIPv6)...)
+	}
+	return allErrs
+}
+
+// awsValidateAccessList checks a list of CIDRs and prefix list IDs that are allowed to access the cluster.
+func awsValidateAccessList(fieldPath *field.Path, entries []string, hasIPv6 bool) (allErrs field.ErrorList) {
+	seen := sets.New[string]()
+	for i, entry := range entries {
+		key := entry
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			key = cidr.String()
+			if cidr.IP.To4() == nil && !hasIPv6 {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i), fmt.Sprintf("IPv6 CIDR %q cannot be used in a cluster without IPv6", entry)))
+			}
+		}
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Index(i), entry))
+		}
+		seen.Insert(key)
+	}
+	return allErrs
+}
+
+// accessListRedundancyWarnings returns warnings for CIDRs that are already covered by an allow-all CIDR of the same access list.
+// They are only warnings, as the redundant entries are harmless apart from creating additional security group rules.
+func accessListRedundancyWarnings(fieldPath *field.Path, entries []string) []*Warning {
+	allowAllIPv4 := slices.Contains(entries, "0.0.0.0/0")
+	allowAllIPv6 := slices.Contains(entries, "::/0")
+
+	var warnings []*Warning
+	for i, entry := range entries {
+		if entry == "0.0.0.0/0" || entry == "::/0" {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(entry)
+		if err != nil {
+			continue
+		}
+		if cidr.IP.To4() != nil && allowAllIPv4 {
+			warnings = append(warnings, &Warning{Field: fieldPath.Index(i), Code: WarningCodeRedundantCIDR, Detail: fmt.Sprintf("%q is redundant, as %q is allowed", entry, "0.0.0.0/0")})
+		} else if cidr.IP.To4() == nil && allowAllIPv6 {
+			warnings = append(warnings, &Warning{Field: fieldPath.Index(i), Code: WarningCodeRedundantCIDR, Detail: fmt.Sprintf("%q is redundant, as %q is allowed", entry, "::/0")})
+		}
+	}
+	return warnings
+}
+
+// awsValidateSubnetZones checks the zones of the subnets, which can be availability zones or Local Zones.
+// NAT gateways cannot be created in Local Zones, so the egress of their private subnets is routed
+// through the NAT gateway of an availability zone.
+func awsValidateSubnetZones(cluster *kops.Cluster) (allErrs field.ErrorList) {
+	fieldPath := field.NewPath("spec", "networking", "subnets")
+
+	haveNATGateway := false
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		if subnet.Type != kops.SubnetTypePrivate && subnet.Type != kops.SubnetTypeDualStack {
+			continue
+		}
+		if awsup.IsAvailabilityZone(subnet.Zone) && (subnet.Egress == "" || strings.HasPrefix(subnet.Egress, "nat-") || strings.HasPrefix(subnet.Egress, "eipalloc-") || strings.HasPrefix(subnet.Egress, "eigw-")) {
+			haveNATGateway = true
+		}
+	}
+
+	for i, subnet := range cluster.Spec.Networking.Subnets {
+		if subnet.Zone != "" && !awsup.IsAvailabilityZone(subnet.Zone) && !awsup.IsLocalZone(subnet.Zone) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("zone"), subnet.Zone, "must be an availability zone such as us-east-1a or a Local Zone such as us-east-1-bos-1a"))
+			continue
+		}
+		if !awsup.IsLocalZone(subnet.Zone) || (subnet.Type != kops.SubnetTypePrivate && subnet.Type != kops.SubnetTypeDualStack) {
+			continue
+		}
+		if strings.HasPrefix(subnet.Egress, "nat-") || strings.HasPrefix(subnet.Egress, "eipalloc-") {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("egress"), fmt.Sprintf("NAT gateways are not supported in Local Zone %q", subnet.Zone)))
+		} else if (subnet.Egress == "" || strings.HasPrefix(subnet.Egress, "eigw-")) && subnet.ID == "" && !haveNATGateway {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("egress"), fmt.Sprintf("private subnets in Local Zone %q require a private subnet with a NAT gateway in an availability zone", subnet.Zone)))
+		}
+	}
+	return allErrs
+}
+
+// awsValidateLocalZones checks that control plane instance groups are not placed in Local Zones,
+// and that the machine types of the instance group are offered in its Local Zones.
+func awsValidateLocalZones(ig *kops.InstanceGroup, cluster *kops.Cluster, cloud awsup.AWSCloud) (allErrs field.ErrorList) {
+	zones := make(map[string]string)
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		zones[subnet.Name] = subnet.Zone
+	}
+
+	for i, subnetName := range ig.Spec.Subnets {
+		zone := zones[subnetName]
+		if !awsup.IsLocalZone(zone) {
+			continue
+		}
+
+		if ig.Spec.Role == kops.InstanceGroupRoleControlPlane {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "subnets").Index(i), fmt.Sprintf("control plane instance groups and their etcd members cannot be placed in Local Zone %q", zone)))
+			continue
+		}
+
+		if cloud == nil {
+			continue
+		}
+		offered, err := cloud.InstanceTypesOfferedInZone(zone)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(field.NewPath("spec", "subnets").Index(i), err))
+			continue
+		}
+		for _, instanceType := range awsInstanceGroupMachineTypes(ig) {
+			if !offered.Has(instanceType) {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "machineType"), fmt.Sprintf("machine type %q is not offered in Local Zone %q", instanceType, zone)))
+			}
+		}
+	}
+	return allErrs
+}
+
 func awsValidateEBSCSIDriver(cluster *kops.Cluster) (allErrs field.ErrorList) {
 	c := cluster.Spec
 
@@ -78,7 +306,11 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 
 	allErrs = append(allErrs, awsValidateAdditionalSecurityGroups(field.NewPath("spec", "additionalSecurityGroups"), ig.Spec.AdditionalSecurityGroups)...)
 
-	allErrs = append(allErrs, awsValidateInstanceTypeAndImage(field.NewPath(ig.GetName(), "spec", "machineType"), field.NewPath(ig.GetName(), "spec", "image"), ig.Spec.MachineType, ig.Spec.Image, cloud)...)
+	allErrs = append(allErrs, awsValidateInstanceTypes(field.NewPath(ig.GetName(), "spec", "machineType"), ig.Spec.MachineType, cloud)...)
+
+	allErrs = append(allErrs, awsValidateImageArchitecture(field.NewPath(ig.GetName(), "spec", "image"), ig, cloud)...)
+
+	allErrs = append(allErrs, awsValidateMaxPrice(field.NewPath(ig.GetName(), "spec", "maxPrice"), ig)...)
 
 	allErrs = append(allErrs, awsValidateSpotDurationInMinute(field.NewPath(ig.GetName(), "spec", "spotDurationInMinutes"), ig)...)
 
@@ -92,6 +324,44 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 		allErrs = append(allErrs, awsValidateInstanceMetadata(field.NewPath("spec", "instanceMetadata"), ig.Spec.InstanceMetadata)...)
 	}
 
+	if ig.Spec.Placement != nil {
+		allErrs = append(allErrs, awsValidatePlacement(field.NewPath("spec", "placement"), ig, cloud)...)
+	}
+
+	if ig.Spec.InstanceMaintenancePolicy != nil {
+		allErrs = append(allErrs, awsValidateInstanceMaintenancePolicy(field.NewPath("spec", "instanceMaintenancePolicy"), ig.Spec.InstanceMaintenancePolicy)...)
+	}
+
+	if ig.Spec.CapacityReservationID != nil || ig.Spec.CapacityReservationResourceGroupARN != nil {
+		allErrs = append(allErrs, awsValidateCapacityReservation(field.NewPath("spec"), ig)...)
+	}
+
+	if ig.Spec.Tenancy == ec2.TenancyHost || ig.Spec.HostResourceGroupARN != nil {
+		allErrs = append(allErrs, awsValidateHostTenancy(field.NewPath("spec"), ig)...)
+	}
+
+	if ig.Spec.NetworkInterfaces != nil {
+		allErrs = append(allErrs, awsValidateNetworkInterfaces(field.NewPath("spec", "networkInterfaces"), ig, cloud)...)
+	}
+
+	if ig.Spec.GPUConfig != nil && ig.Spec.GPUConfig.MIGProfile != "" {
+		allErrs = append(allErrs, awsValidateGPUConfig(field.NewPath("spec", "gpuConfig"), ig, cloud)...)
+	}
+
+	if ig.Spec.RootVolume != nil {
+		rootVolume := ig.Spec.RootVolume
+		allErrs = append(allErrs, awsValidateVolume(field.NewPath(ig.GetName(), "spec", "rootVolume"), fi.ValueOf(rootVolume.Type), int64(fi.ValueOf(rootVolume.Size)), int32PtrToInt64(rootVolume.IOPS), int32PtrToInt64(rootVolume.Throughput))...)
+	}
+
+	for i, volume := range ig.Spec.Volumes {
+		volumePath := field.NewPath(ig.GetName(), "spec", "volumes").Index(i)
+		if _, found := awsVolumeTypeLimits[volume.Type]; volume.Type != "" && !found {
+			allErrs = append(allErrs, field.NotSupported(volumePath.Child("type"), volume.Type, sets.List(sets.KeySet(awsVolumeTypeLimits))))
+			continue
+		}
+		allErrs = append(allErrs, awsValidateVolume(volumePath, volume.Type, volume.Size, volume.IOPS, volume.Throughput)...)
+	}
+
 	if ig.Spec.CPUCredits != nil {
 		allErrs = append(allErrs, awsValidateCPUCredits(field.NewPath("spec"), &ig.Spec, cloud)...)
 	}
@@ -103,6 +373,114 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 	return allErrs
 }
 
+// awsValidateTransitGatewayEgress checks that the transit gateways used for the egress of subnets
+// are attached to the VPC, as routes to a transit gateway can only be created in attached VPCs.
+func awsValidateTransitGatewayEgress(c *kops.Cluster, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	vpcID := c.Spec.Networking.NetworkID
+	if vpcID == "" {
+		// The VPC is created by kOps, so it cannot have been attached yet
+		return allErrs
+	}
+
+	attached := make(map[string]bool)
+	for i, subnet := range c.Spec.Networking.Subnets {
+		if !strings.HasPrefix(subnet.Egress, kops.EgressTransitGateway+"-") {
+			continue
+		}
+		fieldPath := field.NewPath("spec", "networking", "subnets").Index(i).Child("egress")
+
+		tgwID := subnet.Egress
+		if _, found := attached[tgwID]; !found {
+			response, err := cloud.EC2().DescribeTransitGatewayVpcAttachments(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+				Filters: []*ec2.Filter{
+					awsup.NewEC2Filter("transit-gateway-id", tgwID),
+					awsup.NewEC2Filter("vpc-id", vpcID),
+					awsup.NewEC2Filter("state", ec2.TransitGatewayAttachmentStateAvailable, ec2.TransitGatewayAttachmentStateModifying),
+				},
+			})
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fieldPath, fmt.Errorf("error describing attachments of transit gateway %q: %w", tgwID, err)))
+				continue
+			}
+			attached[tgwID] = len(response.TransitGatewayVpcAttachments) != 0
+		}
+		if !attached[tgwID] {
+			allErrs = append(allErrs, field.Invalid(fieldPath, tgwID, fmt.Sprintf("transit gateway must be attached to VPC %q", vpcID)))
+		}
+	}
+
+	return allErrs
+}
+
+// awsValidateIAMProfilePermissions checks that the role of an existing instance profile
+// is allowed to perform the actions that instances need in order to join the cluster.
+func awsValidateIAMProfilePermissions(fieldPath *field.Path, ig *kops.InstanceGroup, cluster *kops.Cluster, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	profileARN := fi.ValueOf(ig.Spec.IAM.Profile)
+	parsedARN, err := arn.Parse(profileARN)
+	if err != nil {
+		// Reported by validateInstanceProfile
+		return allErrs
+	}
+	profileName := parsedARN.Resource[strings.LastIndex(parsedARN.Resource, "/")+1:]
+
+	response, err := cloud.IAM().GetInstanceProfile(&awsIam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(profileName),
+	})
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath, profileARN, fmt.Sprintf("error getting instance profile: %v", err)))
+	}
+	if len(response.InstanceProfile.Roles) == 0 {
+		return append(allErrs, field.Invalid(fieldPath, profileARN, "instance profile has no role"))
+	}
+	role := response.InstanceProfile.Roles[0]
+
+	permissions, err := iam.BootstrapPermissions(cluster, ig.Spec.Role, parsedARN.Partition)
+	if err != nil {
+		return append(allErrs, field.InternalError(fieldPath, err))
+	}
+
+	// Simulate the actions in one request per resource
+	var resources []string
+	actionsByResource := make(map[string][]string)
+	for _, permission := range permissions {
+		if _, found := actionsByResource[permission.Resource]; !found {
+			resources = append(resources, permission.Resource)
+		}
+		actionsByResource[permission.Resource] = append(actionsByResource[permission.Resource], permission.Action)
+	}
+
+	var missing []string
+	for _, resource := range resources {
+		request := &awsIam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: role.Arn,
+			ActionNames:     aws.StringSlice(actionsByResource[resource]),
+			ResourceArns:    aws.StringSlice([]string{resource}),
+		}
+		err := cloud.IAM().SimulatePrincipalPolicyPages(request, func(page *awsIam.SimulatePolicyResponse, lastPage bool) bool {
+			for _, result := range page.EvaluationResults {
+				if aws.StringValue(result.EvalDecision) != awsIam.PolicyEvaluationDecisionTypeAllowed {
+					missing = append(missing, aws.StringValue(result.EvalActionName)+" on "+resource)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return append(allErrs, field.Invalid(fieldPath, profileARN, fmt.Sprintf("error simulating policies of role %q: %v", aws.StringValue(role.RoleName), err)))
+		}
+	}
+
+	if len(missing) > 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, profileARN,
+			fmt.Sprintf("role %q is not allowed to perform actions required by %s instances: %s", aws.StringValue(role.RoleName), ig.Spec.Role, strings.Join(missing, ", "))))
+	}
+
+	return allErrs
+}
+
 func awsValidateMaximumInstanceLifetime(fieldPath *field.Path, maxInstanceLifetime *metav1.Duration) field.ErrorList {
 	allErrs := field.ErrorList{}
 	const minMaxInstanceLifetime = 86400
@@ -132,6 +510,268 @@ func awsValidateInstanceMetadata(fieldPath *field.Path, instanceMetadata *kops.I
 	return allErrs
 }
 
+// awsValidateInstanceGroupInstanceMetadata validates the instance metadata options of an instance group against the cluster default.
+func awsValidateInstanceGroupInstanceMetadata(fieldPath *field.Path, ig *kops.InstanceGroup, clusterDefault *kops.InstanceMetadataOptions) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	instanceMetadata := ig.Spec.InstanceMetadata
+	if instanceMetadata == nil || clusterDefault == nil {
+		return allErrs
+	}
+
+	if fi.ValueOf(clusterDefault.HTTPTokens) == "required" && fi.ValueOf(instanceMetadata.HTTPTokens) == "optional" && !fi.ValueOf(instanceMetadata.AllowDowngrade) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("httpTokens"), "the cluster requires tokens for instance metadata requests; set allowDowngrade to make them optional"))
+	}
+
+	return allErrs
+}
+
+// awsValidateInstanceGroupWarmPool validates the warm pool of an instance group, after applying the cluster defaults.
+func awsValidateInstanceGroupWarmPool(fieldPath *field.Path, ig *kops.InstanceGroup, warmPool *kops.WarmPoolSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if warmPool.IsEnabled() {
+		if ig.Spec.Role != kops.InstanceGroupRoleNode && ig.Spec.Role != kops.InstanceGroupRoleAPIServer {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "warm pool only allowed on instance groups with role Node or APIServer"))
+		}
+		if policy := ig.Spec.MixedInstancesPolicy; policy != nil {
+			// AWS launches only on-demand instances above the base capacity by default
+			if policy.OnDemandAboveBase != nil && *policy.OnDemandAboveBase == 0 && fi.ValueOf(policy.OnDemandBase) == 0 {
+				allErrs = append(allErrs, field.Forbidden(fieldPath, "warm pool cannot be used with a mixed instances policy that only launches spot instances"))
+			} else {
+				allErrs = append(allErrs, field.Forbidden(fieldPath, "warm pool cannot be combined with a mixed instances policy"))
+			}
+		}
+		if ig.Spec.MaxPrice != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "warm pool cannot be used with spot instances"))
+		}
+	} else if ig.Spec.WarmPool != nil {
+		if ig.Spec.WarmPool.EnableLifecycleHook {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enableLifecycleHook"), "lifecycle hook can only be enabled when the warm pool is enabled"))
+		}
+		if fi.ValueOf(ig.Spec.WarmPool.ReuseOnScaleIn) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("reuseOnScaleIn"), "instances can only be reused on scale in when the warm pool is enabled"))
+		}
+	}
+
+	if warmPool.MaxSize != nil {
+		if *warmPool.MaxSize < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxSize"), *warmPool.MaxSize, "warm pool maxSize cannot be negative"))
+		} else if warmPool.MinSize > *warmPool.MaxSize {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxSize"), *warmPool.MaxSize, "warm pool maxSize cannot be set to lower than minSize"))
+		}
+	}
+	if warmPool.MinSize < 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("minSize"), warmPool.MinSize, "warm pool minSize cannot be negative"))
+	}
+
+	return allErrs
+}
+
+func awsValidateInstanceMaintenancePolicy(fieldPath *field.Path, policy *kops.InstanceMaintenancePolicySpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.MinHealthyPercentage == nil {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("minHealthyPercentage"), "minHealthyPercentage must be set"))
+	} else if *policy.MinHealthyPercentage < 0 || *policy.MinHealthyPercentage > 100 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("minHealthyPercentage"), *policy.MinHealthyPercentage, "minHealthyPercentage must be a value between 0 and 100"))
+	}
+
+	if policy.MaxHealthyPercentage == nil {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("maxHealthyPercentage"), "maxHealthyPercentage must be set"))
+	} else if *policy.MaxHealthyPercentage < 100 || *policy.MaxHealthyPercentage > 200 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxHealthyPercentage"), *policy.MaxHealthyPercentage, "maxHealthyPercentage must be a value between 100 and 200"))
+	}
+
+	// The ranges ensure that minHealthyPercentage is not greater than maxHealthyPercentage,
+	// but EC2 Auto Scaling also limits the difference between them.
+	if len(allErrs) == 0 && *policy.MaxHealthyPercentage-*policy.MinHealthyPercentage > 100 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxHealthyPercentage"), *policy.MaxHealthyPercentage, "maxHealthyPercentage must not exceed minHealthyPercentage by more than 100"))
+	}
+
+	return allErrs
+}
+
+func awsValidatePlacement(fieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+	placement := ig.Spec.Placement
+
+	if placement.Strategy == "" {
+		// The strategy of an existing placement group is optional, it is only used for validation
+		if placement.Name == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("strategy"), "placement strategy must be set"))
+		}
+	} else {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("strategy"), &placement.Strategy, kops.SupportedInstanceGroupPlacementStrategies)...)
+	}
+
+	if placement.PartitionCount != nil {
+		if placement.Name != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("partitionCount"), "partitionCount cannot be set for an existing placement group"))
+		} else if placement.Strategy != kops.InstanceGroupPlacementStrategyPartition {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("partitionCount"), "partitionCount can only be set when the placement strategy is partition"))
+		} else if *placement.PartitionCount < 1 || *placement.PartitionCount > 7 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("partitionCount"), *placement.PartitionCount, "partitionCount must be a value between 1 and 7"))
+		}
+	}
+
+	if cloud != nil && placement.Strategy != "" {
+		for _, instanceType := range awsInstanceGroupMachineTypes(ig) {
+			info, err := cloud.DescribeInstanceType(instanceType)
+			if err != nil {
+				// Reported by awsValidateInstanceTypes.
+				continue
+			}
+			if info.PlacementGroupInfo != nil && !slices.Contains(aws.StringValueSlice(info.PlacementGroupInfo.SupportedStrategies), string(placement.Strategy)) {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("strategy"), fmt.Sprintf("machine type %q does not support the %s placement strategy", instanceType, placement.Strategy)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// capacityReservationIDRegex matches the IDs of EC2 Capacity Reservations, such as "cr-0123456789abcdef0"
+var capacityReservationIDRegex = regexp.MustCompile(`^cr-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+func awsValidateCapacityReservation(fieldPath *field.Path, ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ig.Spec.CapacityReservationID != nil && ig.Spec.CapacityReservationResourceGroupARN != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("capacityReservationResourceGroupARN"), "capacityReservationResourceGroupARN cannot be set together with capacityReservationID"))
+	}
+
+	if ig.Spec.CapacityReservationID != nil {
+		if id := *ig.Spec.CapacityReservationID; !capacityReservationIDRegex.MatchString(id) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("capacityReservationID"), id, "must be a capacity reservation ID such as cr-0123456789abcdef0"))
+		}
+	}
+
+	if ig.Spec.CapacityReservationResourceGroupARN != nil {
+		groupARN := *ig.Spec.CapacityReservationResourceGroupARN
+		parsedARN, err := arn.Parse(groupARN)
+		if err != nil || parsedARN.Service != "resource-groups" || !strings.HasPrefix(parsedARN.Resource, "group/") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("capacityReservationResourceGroupARN"), groupARN,
+				"must be a resource group ARN such as arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"))
+		}
+	}
+
+	// Capacity reservations are only used by on-demand instances
+	if ig.Spec.MaxPrice != nil && ig.Spec.MixedInstancesPolicy == nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("maxPrice"), "spot instances cannot be launched into a capacity reservation"))
+	}
+	if ig.Spec.SpotDurationInMinutes != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("spotDurationInMinutes"), "spot instances cannot be launched into a capacity reservation"))
+	}
+	if policy := ig.Spec.MixedInstancesPolicy; policy != nil && policy.OnDemandAboveBase != nil && *policy.OnDemandAboveBase < 100 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("mixedInstancesPolicy", "onDemandAboveBase"), "spot instances cannot be launched into a capacity reservation"))
+	}
+
+	return allErrs
+}
+
+func awsValidateHostTenancy(fieldPath *field.Path, ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ig.Spec.HostResourceGroupARN != nil {
+		if ig.Spec.Tenancy != ec2.TenancyHost {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("hostResourceGroupARN"), "hostResourceGroupARN requires the host tenancy"))
+		}
+		groupARN := *ig.Spec.HostResourceGroupARN
+		parsedARN, err := arn.Parse(groupARN)
+		if err != nil || parsedARN.Service != "resource-groups" || !strings.HasPrefix(parsedARN.Resource, "group/") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("hostResourceGroupARN"), groupARN,
+				"must be a resource group ARN such as arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"))
+		}
+	}
+
+	// Dedicated hosts only run on-demand instances
+	if ig.Spec.Tenancy == ec2.TenancyHost {
+		if ig.Spec.MaxPrice != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("maxPrice"), "spot instances cannot be launched on dedicated hosts"))
+		}
+		if ig.Spec.SpotDurationInMinutes != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("spotDurationInMinutes"), "spot instances cannot be launched on dedicated hosts"))
+		}
+		if ig.Spec.MixedInstancesPolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("mixedInstancesPolicy"), "mixed instances policies cannot be used with the host tenancy"))
+		}
+	}
+
+	return allErrs
+}
+
+// awsVolumeLimits are the IOPS and throughput that can be provisioned for an EBS volume type.
+// A zero maxIOPS or maxThroughput means that the volume type does not support provisioning it.
+type awsVolumeLimits struct {
+	minIOPS, maxIOPS             int64
+	maxIOPSPerGiB                int64
+	minThroughput, maxThroughput int64
+}
+
+// awsVolumeTypeLimits are the limits of the EBS volume types that instance groups can use.
+var awsVolumeTypeLimits = map[string]awsVolumeLimits{
+	ec2.VolumeTypeStandard: {},
+	ec2.VolumeTypeGp2:      {},
+	ec2.VolumeTypeGp3:      {minIOPS: 3000, maxIOPS: 16000, maxIOPSPerGiB: 500, minThroughput: 125, maxThroughput: 1000},
+	ec2.VolumeTypeIo1:      {minIOPS: 100, maxIOPS: 64000, maxIOPSPerGiB: 50},
+	ec2.VolumeTypeIo2:      {minIOPS: 100, maxIOPS: 256000, maxIOPSPerGiB: 1000},
+	ec2.VolumeTypeSt1:      {},
+	ec2.VolumeTypeSc1:      {},
+}
+
+// awsVolumeGp3ThroughputPerIOPS is the maximum ratio of throughput in MiB/s to provisioned IOPS of gp3 volumes.
+const awsVolumeGp3ThroughputPerIOPS = 0.25
+
+// awsValidateVolume checks that the IOPS and throughput of an EBS volume can be provisioned for its type and size.
+// The volume type defaults to gp3, and a zero size is not checked against the IOPS.
+func awsValidateVolume(fieldPath *field.Path, volumeType string, size int64, iops *int64, throughput *int64) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if volumeType == "" {
+		volumeType = ec2.VolumeTypeGp3
+	}
+	limits, found := awsVolumeTypeLimits[volumeType]
+	if !found {
+		return allErrs
+	}
+
+	if iops != nil {
+		if limits.maxIOPS == 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iops"), fmt.Sprintf("IOPS cannot be set for %s volumes", volumeType)))
+		} else if *iops < limits.minIOPS || *iops > limits.maxIOPS {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("iops"), *iops, fmt.Sprintf("IOPS must be between %d and %d for %s volumes", limits.minIOPS, limits.maxIOPS, volumeType)))
+		} else if maxIOPS := max(size*limits.maxIOPSPerGiB, limits.minIOPS); size > 0 && *iops > maxIOPS {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("iops"), *iops, fmt.Sprintf("IOPS must be at most %d IOPS per GiB for %s volumes, which is %d for %d GiB", limits.maxIOPSPerGiB, volumeType, maxIOPS, size)))
+		}
+	}
+
+	if throughput != nil {
+		if limits.maxThroughput == 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("throughput"), fmt.Sprintf("throughput cannot be set for %s volumes", volumeType)))
+		} else if *throughput < limits.minThroughput || *throughput > limits.maxThroughput {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("throughput"), *throughput, fmt.Sprintf("throughput must be between %d and %d MiB/s for %s volumes", limits.minThroughput, limits.maxThroughput, volumeType)))
+		} else {
+			provisionedIOPS := limits.minIOPS
+			if iops != nil {
+				provisionedIOPS = *iops
+			}
+			if float64(*throughput) > float64(provisionedIOPS)*awsVolumeGp3ThroughputPerIOPS {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("throughput"), *throughput, fmt.Sprintf("throughput must be at most %v MiB/s per IOPS for %s volumes, which is %v MiB/s for %d IOPS", awsVolumeGp3ThroughputPerIOPS, volumeType, float64(provisionedIOPS)*awsVolumeGp3ThroughputPerIOPS, provisionedIOPS)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func int32PtrToInt64(v *int32) *int64 {
+	if v == nil {
+		return nil
+	}
+	return fi.PtrTo(int64(*v))
+}
+
 func awsValidateAdditionalSecurityGroups(fieldPath *field.Path, groups []string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -174,43 +814,188 @@ func awsValidateSecurityGroupOverride(fieldPath *field.Path, lbSpec *kops.LoadBa
 	return allErrs
 }
 
-func awsValidateInstanceTypeAndImage(instanceTypeFieldPath *field.Path, imageFieldPath *field.Path, instanceTypes string, image string, cloud awsup.AWSCloud) field.ErrorList {
+func awsValidateInstanceTypes(instanceTypeFieldPath *field.Path, instanceTypes string, cloud awsup.AWSCloud) field.ErrorList {
 	if cloud == nil || instanceTypes == "" {
 		return nil
 	}
 
 	allErrs := field.ErrorList{}
 
+	// Spotinst uses the instance type field to keep a "," separated list of instance types
+	for _, instanceType := range strings.Split(instanceTypes, ",") {
+		if _, err := cloud.DescribeInstanceType(instanceType); err != nil {
+			allErrs = append(allErrs, field.Invalid(instanceTypeFieldPath, instanceTypes, fmt.Sprintf("machine type %q is invalid: %v", instanceType, err)))
+		}
+	}
+
+	return allErrs
+}
+
+// awsValidateImageArchitecture checks that the image of an instance group can run on all the instance types it may launch.
+func awsValidateImageArchitecture(imageFieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	var instanceTypes []string
+	if ig.Spec.MachineType != "" {
+		instanceTypes = append(instanceTypes, strings.Split(ig.Spec.MachineType, ",")...)
+	}
+	if ig.Spec.MixedInstancesPolicy != nil {
+		for _, instances := range ig.Spec.MixedInstancesPolicy.Instances {
+			instanceTypes = append(instanceTypes, strings.Split(instances, ",")...)
+		}
+	}
+	if cloud == nil || len(instanceTypes) == 0 {
+		return nil
+	}
+
+	image := ig.Spec.Image
 	imageInfo, err := cloud.ResolveImage(image)
 	if err != nil {
-		return append(allErrs, field.Invalid(imageFieldPath, image,
-			fmt.Sprintf("specified image %q is invalid: %s", image, err)))
+		return field.ErrorList{field.Invalid(imageFieldPath, image, fmt.Sprintf("specified image %q is invalid: %s", image, err))}
 	}
 	imageArch := fi.ValueOf(imageInfo.Architecture)
+	if imageArch == "" {
+		// Without an architecture there is nothing to compare against.
+		return nil
+	}
 
-	// Spotinst uses the instance type field to keep a "," separated list of instance types
-	for _, instanceType := range strings.Split(instanceTypes, ",") {
-		machineInfo, err := cloud.DescribeInstanceType(instanceType)
-		if err != nil {
-			allErrs = append(allErrs, field.Invalid(instanceTypeFieldPath, instanceTypes, fmt.Sprintf("machine type %q is invalid: %v", instanceType, err)))
+	var mismatched []string
+	seen := sets.New[string]()
+	for _, instanceType := range instanceTypes {
+		if seen.Has(instanceType) {
 			continue
 		}
+		seen.Insert(instanceType)
 
-		found := false
-		if machineInfo != nil && machineInfo.ProcessorInfo != nil {
-			for _, machineArch := range machineInfo.ProcessorInfo.SupportedArchitectures {
-				if imageArch == fi.ValueOf(machineArch) {
-					found = true
-				}
-			}
+		// Instance types that cannot be described are reported by awsValidateInstanceTypes.
+		architectures := awsInstanceTypeArchitectures(cloud, instanceType)
+		if architectures.Len() != 0 && !architectures.Has(imageArch) {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%s)", instanceType, strings.Join(sets.List(architectures), ",")))
+		}
+	}
+	if len(mismatched) != 0 {
+		return field.ErrorList{field.Invalid(imageFieldPath, image,
+			fmt.Sprintf("image architecture %q does not match machine types %s", imageArch, strings.Join(mismatched, ", ")))}
+	}
+
+	return nil
+}
+
+// awsMIGProfiles are the MIG profiles supported by the GPUs of EC2 instance types, by GPU name and memory in MiB.
+var awsMIGProfiles = map[string][]string{
+	// p4d
+	"A100/40960": {"1g.5gb", "2g.10gb", "3g.20gb", "4g.20gb", "7g.40gb"},
+	// p4de
+	"A100/81920": {"1g.10gb", "2g.20gb", "3g.40gb", "4g.40gb", "7g.80gb"},
+	// p5
+	"H100/81920": {"1g.10gb", "2g.20gb", "3g.40gb", "4g.40gb", "7g.80gb"},
+	// p5e, p5en
+	"H200/143360": {"1g.18gb", "2g.35gb", "3g.71gb", "4g.71gb", "7g.141gb"},
+}
+
+// awsValidateGPUConfig checks that the GPUs of all the instance types of an instance group support the MIG profile.
+func awsValidateGPUConfig(fieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	if cloud == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	migProfile := ig.Spec.GPUConfig.MIGProfile
+	for _, instanceType := range awsInstanceGroupMachineTypes(ig) {
+		info, err := cloud.DescribeInstanceType(instanceType)
+		if err != nil {
+			// Reported by awsValidateInstanceTypes.
+			continue
 		}
-		if !found {
-			var machineArch []string
-			if machineInfo != nil && machineInfo.ProcessorInfo != nil && machineInfo.ProcessorInfo.SupportedArchitectures != nil {
-				machineArch = fi.StringSliceValue(machineInfo.ProcessorInfo.SupportedArchitectures)
+		if info.GpuInfo == nil || len(info.GpuInfo.Gpus) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("migProfile"), fmt.Sprintf("MIG profiles can only be set on GPU machine types, %q has no GPUs", instanceType)))
+			continue
+		}
+		for _, gpu := range info.GpuInfo.Gpus {
+			var memory int64
+			if gpu.MemoryInfo != nil {
+				memory = fi.ValueOf(gpu.MemoryInfo.SizeInMiB)
+			}
+			profiles, found := awsMIGProfiles[fmt.Sprintf("%s/%d", fi.ValueOf(gpu.Name), memory)]
+			if !found {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("migProfile"), fmt.Sprintf("the %s GPUs of machine type %q do not support MIG", fi.ValueOf(gpu.Name), instanceType)))
+			} else if !slices.Contains(profiles, migProfile) {
+				allErrs = append(allErrs, field.NotSupported(fieldPath.Child("migProfile"), migProfile, profiles))
 			}
-			allErrs = append(allErrs, field.Invalid(instanceTypeFieldPath, instanceTypes,
-				fmt.Sprintf("machine type architecture %q does not match image architecture %q", strings.Join(machineArch, ","), imageArch)))
+		}
+	}
+
+	return allErrs
+}
+
+// awsInstanceGroupMachineTypes returns the distinct machine types an instance group can launch, including those of its mixed instances policy.
+func awsInstanceGroupMachineTypes(ig *kops.InstanceGroup) []string {
+	var instanceTypes []string
+	if ig.Spec.MachineType != "" {
+		instanceTypes = append(instanceTypes, strings.Split(ig.Spec.MachineType, ",")...)
+	}
+	if ig.Spec.MixedInstancesPolicy != nil {
+		for _, instances := range ig.Spec.MixedInstancesPolicy.Instances {
+			instanceTypes = append(instanceTypes, strings.Split(instances, ",")...)
+		}
+	}
+
+	var distinct []string
+	seen := sets.New[string]()
+	for _, instanceType := range instanceTypes {
+		if !seen.Has(instanceType) {
+			seen.Insert(instanceType)
+			distinct = append(distinct, instanceType)
+		}
+	}
+	return distinct
+}
+
+func awsValidateNetworkInterfaces(fieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+	spec := ig.Spec.NetworkInterfaces
+
+	if fi.ValueOf(spec.ENASRDUDPEnabled) && !fi.ValueOf(spec.ENASRDEnabled) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enaSrdUdpEnabled"), "ENA Express for UDP traffic requires enaSrdEnabled"))
+	}
+
+	if !fi.ValueOf(spec.ENASRDEnabled) || cloud == nil {
+		return allErrs
+	}
+
+	for _, instanceType := range awsInstanceGroupMachineTypes(ig) {
+		info, err := cloud.DescribeInstanceType(instanceType)
+		if err != nil {
+			// Reported by awsValidateInstanceTypes.
+			continue
+		}
+		if info.NetworkInfo == nil || !fi.ValueOf(info.NetworkInfo.EnaSrdSupported) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enaSrdEnabled"), fmt.Sprintf("machine type %q does not support ENA Express", instanceType)))
+		}
+	}
+
+	return allErrs
+}
+
+// spotPriceRegex matches the decimal prices accepted by EC2, such as "0.05"
+var spotPriceRegex = regexp.MustCompile(`^([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+func awsValidateMaxPrice(fieldPath *field.Path, ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ig.Spec.MaxPrice == nil {
+		return allErrs
+	}
+
+	maxPrice := *ig.Spec.MaxPrice
+	if !spotPriceRegex.MatchString(maxPrice) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, maxPrice, "must be a decimal number of US dollars per hour, such as \"0.05\""))
+	} else if price, err := strconv.ParseFloat(maxPrice, 64); err != nil || price <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, maxPrice, "must be greater than zero"))
+	}
+
+	// Without a mixed instances policy, maxPrice makes the instance group use spot instances.
+	// With one, it only applies to the spot capacity, which is none unless onDemandAboveBase is lowered.
+	if policy := ig.Spec.MixedInstancesPolicy; policy != nil && ig.Spec.SpotDurationInMinutes == nil {
+		if policy.OnDemandAboveBase == nil || *policy.OnDemandAboveBase >= 100 {
+			klog.Warningf("%s has no effect, as the mixed instances policy of instance group %q launches only on-demand instances; set onDemandAboveBase below 100 to use spot instances", fieldPath, ig.GetName())
 		}
 	}
 
@@ -247,11 +1032,12 @@ func awsValidateMixedInstancesPolicy(path *field.Path, spec *kops.MixedInstances
 	}
 
 	hasGPU := mainMachineTypeInfo.GPU
+	mainArchitectures := awsInstanceTypeArchitectures(cloud, ig.Spec.MachineType)
 
 	// @step: check the instance types are valid
 	for i, instanceTypes := range spec.Instances {
 		fld := path.Child("instances").Index(i)
-		errs = append(errs, awsValidateInstanceTypeAndImage(path.Child("instances").Index(i), path.Child("image"), instanceTypes, ig.Spec.Image, cloud)...)
+		errs = append(errs, awsValidateInstanceTypes(fld, instanceTypes, cloud)...)
 
 		for _, instanceType := range strings.Split(instanceTypes, ",") {
 			machineTypeInfo, err := awsup.GetMachineTypeInfo(cloud, instanceType)
@@ -262,10 +1048,23 @@ func awsValidateMixedInstancesPolicy(path *field.Path, spec *kops.MixedInstances
 			if machineTypeInfo.GPU != hasGPU {
 				errs = append(errs, field.Forbidden(fld, "Cannot mix GPU and non-GPU machine types in the same Instance Group"))
 			}
+			architectures := awsInstanceTypeArchitectures(cloud, instanceType)
+			if mainArchitectures.Len() != 0 && architectures.Len() != 0 && !mainArchitectures.HasAny(architectures.UnsortedList()...) {
+				errs = append(errs, field.Forbidden(fld, fmt.Sprintf("Cannot mix machine type %q (%s) with instance type %q (%s) in the same Instance Group, as they do not share an architecture",
+					ig.Spec.MachineType, strings.Join(sets.List(mainArchitectures), ","), instanceType, strings.Join(sets.List(architectures), ","))))
+			}
 		}
 
 	}
 
+	if spec.InstanceRequirements != nil {
+		fld := path.Child("instanceRequirements")
+		if len(spec.Instances) > 0 {
+			errs = append(errs, field.Forbidden(fld, "instanceRequirements cannot be used together with instances"))
+		}
+		errs = append(errs, awsValidateInstanceRequirements(fld, spec.InstanceRequirements)...)
+	}
+
 	if spec.OnDemandBase != nil {
 		if fi.ValueOf(spec.OnDemandBase) < 0 {
 			errs = append(errs, field.Invalid(path.Child("onDemandBase"), spec.OnDemandBase, "cannot be less than zero"))
@@ -289,6 +1088,54 @@ func awsValidateMixedInstancesPolicy(path *field.Path, spec *kops.MixedInstances
 	return errs
 }
 
+// awsValidateInstanceRequirements validates the attributes that select the instance types of a mixed instances policy
+func awsValidateInstanceRequirements(path *field.Path, spec *kops.InstanceRequirementsSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, awsValidateMinMax(path.Child("cpu"), spec.CPU)...)
+	errs = append(errs, awsValidateMinMax(path.Child("memory"), spec.Memory)...)
+	errs = append(errs, awsValidateMinMax(path.Child("gpu"), spec.GPU)...)
+	errs = append(errs, IsValidValue(path.Child("burstablePerformance"), spec.BurstablePerformance, ec2.BurstablePerformance_Values())...)
+
+	if len(spec.AllowedInstanceTypes) > 0 && len(spec.ExcludedInstanceTypes) > 0 {
+		errs = append(errs, field.Forbidden(path.Child("excludedInstanceTypes"), "excludedInstanceTypes cannot be used together with allowedInstanceTypes"))
+	}
+
+	return errs
+}
+
+func awsValidateMinMax(path *field.Path, spec *kops.MinMaxSpec) field.ErrorList {
+	var errs field.ErrorList
+	if spec == nil {
+		return errs
+	}
+
+	if spec.Min != nil && spec.Min.Sign() < 0 {
+		errs = append(errs, field.Invalid(path.Child("min"), spec.Min.String(), "cannot be less than zero"))
+	}
+	if spec.Max != nil && spec.Max.Sign() < 0 {
+		errs = append(errs, field.Invalid(path.Child("max"), spec.Max.String(), "cannot be less than zero"))
+	}
+	if spec.Min != nil && spec.Max != nil && spec.Min.Cmp(*spec.Max) > 0 {
+		errs = append(errs, field.Invalid(path.Child("min"), spec.Min.String(), fmt.Sprintf("cannot be greater than max (%s)", spec.Max.String())))
+	}
+	return errs
+}
+
+// awsInstanceTypeArchitectures returns the architectures supported by an instance type,
+// or an empty set if they cannot be determined.
+func awsInstanceTypeArchitectures(cloud awsup.AWSCloud, instanceType string) sets.Set[string] {
+	architectures := sets.New[string]()
+	info, err := cloud.DescribeInstanceType(instanceType)
+	if err != nil || info == nil || info.ProcessorInfo == nil {
+		return architectures
+	}
+	for _, architecture := range info.ProcessorInfo.SupportedArchitectures {
+		architectures.Insert(fi.ValueOf(architecture))
+	}
+	return architectures
+}
+
 func awsValidateTopologyDNS(fieldPath *field.Path, c *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -314,6 +1161,96 @@ func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSp
 	return allErrs
 }
 
+// s3BucketNameRegex matches the naming rules for S3 buckets, except for the ones checked separately
+var s3BucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func awsValidateAccessLog(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	accessLog := spec.AccessLog
+	if !accessLog.IsEnabled() {
+		return allErrs
+	}
+
+	if spec.Class != "" && !slices.Contains(kops.SupportedLoadBalancerClasses, spec.Class) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("access logs are not supported with load balancer class %q", spec.Class)))
+	}
+
+	bucket := fi.ValueOf(accessLog.Bucket)
+	if bucket == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("bucket"), "bucket must be specified when access logs are enabled"))
+	} else if !s3BucketNameRegex.MatchString(bucket) || strings.Contains(bucket, "..") || net.ParseIP(bucket) != nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("bucket"), bucket, "must be a valid S3 bucket name"))
+	}
+
+	if strings.HasPrefix(fi.ValueOf(accessLog.BucketPrefix), "/") || strings.HasSuffix(fi.ValueOf(accessLog.BucketPrefix), "/") {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("bucketPrefix"), *accessLog.BucketPrefix, "must not start or end with a slash"))
+	}
+
+	if accessLog.Interval != 0 {
+		if spec.Class == kops.LoadBalancerClassNetwork {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("interval"), "interval is only supported with Classic Load Balancer"))
+		} else if accessLog.Interval != 5 && accessLog.Interval != 60 {
+			allErrs = append(allErrs, field.NotSupported(fieldPath.Child("interval"), accessLog.Interval, []string{"5", "60"}))
+		}
+	}
+
+	return allErrs
+}
+
+// awsValidateLoadBalancerIPAddressType checks that a dualstack API load balancer is a NLB using only subnets with IPv6 CIDRs.
+func awsValidateLoadBalancerIPAddressType(fieldPath *field.Path, spec kops.ClusterSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	lbSpec := spec.API.LoadBalancer
+	if lbSpec.IPAddressType == "" {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, IsValidValue(fieldPath.Child("ipAddressType"), &lbSpec.IPAddressType, kops.SupportedLoadBalancerIPAddressTypes)...)
+
+	if lbSpec.IPAddressType != kops.LoadBalancerIPAddressTypeDualStack {
+		return allErrs
+	}
+
+	if lbSpec.Class != kops.LoadBalancerClassNetwork {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("ipAddressType"), "dualstack requires a load balancer of class Network"))
+	}
+
+	for i, subnet := range lbSpec.Subnets {
+		for _, clusterSubnet := range spec.Networking.Subnets {
+			if subnet.Name == clusterSubnet.Name && clusterSubnet.IPv6CIDR == "" {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("subnets").Index(i).Child("name"), fmt.Sprintf("subnet %q must have an IPv6 CIDR for a dualstack load balancer", subnet.Name)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// awsValidateLoadBalancerSecurityGroups checks the settings that depend on security groups being attached to the API load balancer.
+func awsValidateLoadBalancerSecurityGroups(fieldPath *field.Path, lbSpec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if lbSpec.Class == kops.LoadBalancerClassClassic {
+		if lbSpec.UseSecurityGroups != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("useSecurityGroups"), "useSecurityGroups is only supported with load balancer class Network"))
+		}
+		return allErrs
+	}
+
+	if !lbSpec.UsesSecurityGroups() {
+		if lbSpec.SecurityGroupOverride != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("securityGroupOverride"), "securityGroupOverride requires useSecurityGroups"))
+		}
+		if len(lbSpec.AdditionalSecurityGroups) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("additionalSecurityGroups"), "additionalSecurityGroups requires useSecurityGroups"))
+		}
+	}
+
+	return allErrs
+}
+
 func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -412,7 +1349,19 @@ func awsValidateAdditionalRoutes(fieldPath *field.Path, routes []kops.RouteSpec,
 		routeCIDR, errs := parseCIDR(f.Child("cidr"), r.CIDR)
 		allErrs = append(allErrs, errs...)
 		if routeCIDR != nil {
+			isIPv6 := routeCIDR.IP.To4() == nil
+			if isIPv6 && strings.HasPrefix(r.Target, "nat-") {
+				allErrs = append(allErrs, field.Invalid(f.Child("cidr"), r.CIDR, "NAT gateway routes must have an IPv4 destination"))
+			}
+			if !isIPv6 && strings.HasPrefix(r.Target, "eigw-") {
+				allErrs = append(allErrs, field.Invalid(f.Child("cidr"), r.CIDR, "egress-only internet gateway routes must have an IPv6 destination"))
+			}
+
 			for _, clusterNet := range networkCIDRs {
+				// Only compare against network CIDRs of the same address family
+				if (clusterNet.IP.To4() == nil) != isIPv6 {
+					continue
+				}
 				if clusterNet.Contains(routeCIDR.IP) && strings.HasPrefix(r.Target, "pcx-") {
 					allErrs = append(allErrs, field.Forbidden(f.Child("target"), "target is more specific than a network CIDR block. This route can target only an interface or an instance."))
 				}
@@ -437,3 +1386,62 @@ func awsValidateAdditionalRoutes(fieldPath *field.Path, routes []kops.RouteSpec,
 
 	return allErrs
 }
+
+func awsValidateVPCEndpoints(fieldPath *field.Path, networking *kops.NetworkingSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	clusterSubnets := make(map[string]kops.ClusterSubnetSpec)
+	for _, subnet := range networking.Subnets {
+		clusterSubnets[subnet.Name] = subnet
+	}
+
+	endpoints := sets.New[string]()
+	for i, endpoint := range networking.VPCEndpoints {
+		f := fieldPath.Index(i)
+
+		if endpoint.ServiceName == "" {
+			allErrs = append(allErrs, field.Required(f.Child("serviceName"), ""))
+		}
+		if endpoint.Type == "" {
+			allErrs = append(allErrs, field.Required(f.Child("type"), ""))
+		} else {
+			allErrs = append(allErrs, IsValidValue(f.Child("type"), &endpoint.Type, kops.SupportedVPCEndpointTypes)...)
+		}
+
+		key := string(endpoint.Type) + "/" + endpoint.ServiceName
+		if endpoints.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(f.Child("serviceName"), endpoint.ServiceName))
+		}
+		endpoints.Insert(key)
+
+		switch endpoint.Type {
+		case kops.VPCEndpointTypeGateway:
+			if len(endpoint.Subnets) > 0 {
+				allErrs = append(allErrs, field.Forbidden(f.Child("subnets"), "subnets can only be specified for Interface endpoints"))
+			}
+			if endpoint.PrivateDNS != nil {
+				allErrs = append(allErrs, field.Forbidden(f.Child("privateDNS"), "privateDNS can only be specified for Interface endpoints"))
+			}
+
+		case kops.VPCEndpointTypeInterface:
+			if len(endpoint.Subnets) == 0 {
+				allErrs = append(allErrs, field.Required(f.Child("subnets"), "Interface endpoints require at least one subnet"))
+			}
+			zones := make(map[string]string)
+			for j, name := range endpoint.Subnets {
+				subnet, found := clusterSubnets[name]
+				if !found {
+					allErrs = append(allErrs, field.NotFound(f.Child("subnets").Index(j), name))
+					continue
+				}
+				if other, found := zones[subnet.Zone]; found {
+					allErrs = append(allErrs, field.Invalid(f.Child("subnets").Index(j), name, fmt.Sprintf("subnet is in the same zone as subnet %q; Interface endpoints support one subnet per zone", other)))
+					continue
+				}
+				zones[subnet.Zone] = name
+			}
+		}
+	}
+
+	return allErrs
+}
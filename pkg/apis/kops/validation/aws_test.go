@@ -17,13 +17,17 @@ limitations under the License.
 package validation
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cloudmock/aws/mockiam"
 
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -86,6 +90,351 @@ func TestAWSValidateEBSCSIDriver(t *testing.T) {
 	}
 }
 
+func TestAWSValidatePrefixLists(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				SSHAccess:      []string{"pl-12345678", "10.0.0.0/8"},
+				API:            kops.APISpec{Access: []string{"pl-0123456789abcdef0"}},
+				NodePortAccess: []string{"0.0.0.0/0"},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				SSHAccess:      []string{"pl-1234"},
+				API:            kops.APISpec{Access: []string{"0.0.0.0/0", "pl-0123456789ABCDEF0"}},
+				NodePortAccess: []string{"pl-"},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.sshAccess[0]",
+				"Invalid value::spec.api.access[1]",
+				"Invalid value::spec.nodePortAccess[0]",
+			},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: g.Input,
+		}
+		errs := awsValidatePrefixLists(cluster)
+
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateAccessLists(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				SSHAccess:      []string{"0.0.0.0/0", "::/0"},
+				API:            kops.APISpec{Access: []string{"10.0.0.0/8", "2001:db8::/32", "pl-0123456789abcdef0"}},
+				NodePortAccess: []string{"192.168.0.0/16"},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				SSHAccess:      []string{"10.0.0.0/8", "10.0.0.0/8"},
+				API:            kops.APISpec{Access: []string{"pl-0123456789abcdef0", "10.1.2.3/8", "pl-0123456789abcdef0"}},
+				NodePortAccess: []string{"2001:db8::/32", "2001:0db8::/32"},
+			},
+			ExpectedErrors: []string{
+				"Duplicate value::spec.sshAccess[1]",
+				"Duplicate value::spec.api.access[2]",
+				"Duplicate value::spec.nodePortAccess[1]",
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{
+					NetworkID: "vpc-12345678",
+					Subnets:   []kops.ClusterSubnetSpec{{Name: "us-test-1a", Type: kops.SubnetTypePublic}},
+				},
+				SSHAccess:      []string{"0.0.0.0/0", "::/0"},
+				API:            kops.APISpec{Access: []string{"0.0.0.0/0"}},
+				NodePortAccess: []string{"2001:db8::/32"},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.sshAccess[1]",
+				"Forbidden::spec.nodePortAccess[0]",
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{
+					NetworkID: "vpc-12345678",
+					Subnets:   []kops.ClusterSubnetSpec{{Name: "us-test-1a", Type: kops.SubnetTypePublic, IPv6CIDR: "2001:db8:0:1::/64"}},
+				},
+				SSHAccess: []string{"0.0.0.0/0", "::/0"},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{
+					NetworkID:         "vpc-12345678",
+					NonMasqueradeCIDR: "::/0",
+				},
+				SSHAccess: []string{"::/0"},
+			},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: g.Input,
+		}
+		errs := awsValidateAccessLists(cluster)
+
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAccessListRedundancyWarnings(t *testing.T) {
+	grid := []struct {
+		Input            []string
+		ExpectedWarnings []string
+	}{
+		{
+			Input: []string{"0.0.0.0/0", "::/0"},
+		},
+		{
+			Input: []string{"10.0.0.0/8", "2001:db8::/32", "pl-0123456789abcdef0"},
+		},
+		{
+			Input: []string{"10.0.0.0/8", "0.0.0.0/0", "2001:db8::/32", "pl-0123456789abcdef0"},
+			ExpectedWarnings: []string{
+				`spec.sshAccess[0]: "10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed`,
+			},
+		},
+		{
+			Input: []string{"::/0", "10.0.0.0/8", "2001:db8::/32"},
+			ExpectedWarnings: []string{
+				`spec.sshAccess[2]: "2001:db8::/32" is redundant, as "::/0" is allowed`,
+			},
+		},
+	}
+	for _, g := range grid {
+		var warnings []string
+		for _, warning := range accessListRedundancyWarnings(field.NewPath("spec", "sshAccess"), g.Input) {
+			if warning.Code != WarningCodeRedundantCIDR {
+				t.Errorf("%v: unexpected warning code %q", g.Input, warning.Code)
+			}
+			warnings = append(warnings, warning.String())
+		}
+		if !reflect.DeepEqual(warnings, g.ExpectedWarnings) {
+			t.Errorf("%v: expected warnings %q, got %q", g.Input, g.ExpectedWarnings, warnings)
+		}
+	}
+}
+
+func TestAWSValidateSubnetZones(t *testing.T) {
+	grid := []struct {
+		Subnets        []kops.ClusterSubnetSpec
+		ExpectedErrors []string
+	}{
+		{
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate},
+				{Name: "utility-us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypeUtility},
+				{Name: "us-east-1-bos-1a", Zone: "us-east-1-bos-1a", Type: kops.SubnetTypePrivate},
+				{Name: "us-east-1-mia-1a", Zone: "us-east-1-mia-1a", Type: kops.SubnetTypePublic},
+			},
+		},
+		{
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-east-1", Zone: "us-east-1", Type: kops.SubnetTypePublic},
+				{Name: "us-east-1-wl1-bos-wlz-1", Zone: "us-east-1-wl1-bos-wlz-1", Type: kops.SubnetTypePublic},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.networking.subnets[0].zone",
+				"Invalid value::spec.networking.subnets[1].zone",
+			},
+		},
+		{
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePublic},
+				{Name: "us-east-1-bos-1a", Zone: "us-east-1-bos-1a", Type: kops.SubnetTypePrivate},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.networking.subnets[1].egress"},
+		},
+		{
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate, Egress: "tgw-0123456789abcdef0"},
+				{Name: "us-east-1-bos-1a", Zone: "us-east-1-bos-1a", Type: kops.SubnetTypePrivate, Egress: "tgw-0123456789abcdef0"},
+			},
+		},
+		{
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate},
+				{Name: "us-east-1-bos-1a", Zone: "us-east-1-bos-1a", Type: kops.SubnetTypePrivate, Egress: "nat-0123456789abcdef0"},
+				{Name: "us-east-1-bos-1b", Zone: "us-east-1-bos-1b", Type: kops.SubnetTypePrivate, Egress: "eipalloc-0123456789abcdef0"},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.networking.subnets[1].egress",
+				"Forbidden::spec.networking.subnets[2].egress",
+			},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{Subnets: g.Subnets},
+			},
+		}
+		errs := awsValidateSubnetZones(cluster)
+
+		testErrors(t, g.Subnets, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateAPIPublicIPs(t *testing.T) {
+	subnets := []kops.ClusterSubnetSpec{
+		{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePublic},
+		{Name: "us-east-1b", Zone: "us-east-1b", Type: kops.SubnetTypePublic},
+		{Name: "us-east-1c", Zone: "us-east-1c", Type: kops.SubnetTypePrivate},
+	}
+	grid := []struct {
+		Description    string
+		API            kops.APISpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "one Elastic IP per zone",
+			API: kops.APISpec{
+				PublicIPs: &kops.APIPublicIPsSpec{
+					AllocationIDs: map[string]string{
+						"us-east-1a": "eipalloc-0123456789abcdef0",
+						"us-east-1b": "eipalloc-01234567",
+					},
+				},
+			},
+		},
+		{
+			Description: "with a load balancer",
+			API: kops.APISpec{
+				LoadBalancer: &kops.LoadBalancerAccessSpec{Type: kops.LoadBalancerTypePublic},
+				PublicIPs: &kops.APIPublicIPsSpec{
+					AllocationIDs: map[string]string{"us-east-1a": "eipalloc-0123456789abcdef0"},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.api.publicIPs"},
+		},
+		{
+			Description:    "without allocation IDs",
+			API:            kops.APISpec{PublicIPs: &kops.APIPublicIPsSpec{}},
+			ExpectedErrors: []string{"Required value::spec.api.publicIPs.allocationIDs"},
+		},
+		{
+			Description: "invalid allocation ID",
+			API: kops.APISpec{
+				PublicIPs: &kops.APIPublicIPsSpec{
+					AllocationIDs: map[string]string{"us-east-1a": "203.0.113.10"},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.api.publicIPs.allocationIDs[us-east-1a]"},
+		},
+		{
+			Description: "zone without a public subnet",
+			API: kops.APISpec{
+				PublicIPs: &kops.APIPublicIPsSpec{
+					AllocationIDs: map[string]string{
+						"us-east-1c": "eipalloc-0123456789abcdef0",
+						"us-east-1d": "eipalloc-0123456789abcdef1",
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.api.publicIPs.allocationIDs[us-east-1c]",
+				"Forbidden::spec.api.publicIPs.allocationIDs[us-east-1d]",
+			},
+		},
+		{
+			Description: "Elastic IP used by two zones",
+			API: kops.APISpec{
+				PublicIPs: &kops.APIPublicIPsSpec{
+					AllocationIDs: map[string]string{
+						"us-east-1a": "eipalloc-0123456789abcdef0",
+						"us-east-1b": "eipalloc-0123456789abcdef0",
+					},
+				},
+			},
+			ExpectedErrors: []string{"Duplicate value::spec.api.publicIPs.allocationIDs[us-east-1b]"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					API:        g.API,
+					Networking: kops.NetworkingSpec{Subnets: subnets},
+				},
+			}
+			errs := awsValidateAPIPublicIPs(cluster)
+
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func TestAWSValidateLocalZones(t *testing.T) {
+	grid := []struct {
+		Role           kops.InstanceGroupRole
+		MachineType    string
+		Subnets        []string
+		ExpectedErrors []string
+	}{
+		{
+			Role:        kops.InstanceGroupRoleNode,
+			MachineType: "m5.large",
+			Subnets:     []string{"us-east-1a", "us-east-1-bos-1a"},
+		},
+		{
+			Role:        kops.InstanceGroupRoleNode,
+			MachineType: "c4.large",
+			Subnets:     []string{"us-east-1a"},
+		},
+		{
+			Role:           kops.InstanceGroupRoleNode,
+			MachineType:    "c4.large",
+			Subnets:        []string{"us-east-1a", "us-east-1-bos-1a"},
+			ExpectedErrors: []string{"Forbidden::spec.machineType"},
+		},
+		{
+			Role:           kops.InstanceGroupRoleControlPlane,
+			MachineType:    "m5.large",
+			Subnets:        []string{"us-east-1-bos-1a"},
+			ExpectedErrors: []string{"Forbidden::spec.subnets[0]"},
+		},
+	}
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			Networking: kops.NetworkingSpec{
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate},
+					{Name: "us-east-1-bos-1a", Zone: "us-east-1-bos-1a", Type: kops.SubnetTypePrivate},
+				},
+			},
+		},
+	}
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	for _, g := range grid {
+		ig := &kops.InstanceGroup{
+			Spec: kops.InstanceGroupSpec{
+				Role:        g.Role,
+				MachineType: g.MachineType,
+				Subnets:     g.Subnets,
+			},
+		}
+		errs := awsValidateLocalZones(ig, cluster, cloud)
+
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
 func TestValidateInstanceGroupSpec(t *testing.T) {
 	grid := []struct {
 		Input          kops.InstanceGroupSpec
@@ -155,7 +504,52 @@ func TestValidateInstanceGroupSpec(t *testing.T) {
 				Image:       "ami-073c8c0760395aab8",
 			},
 			ExpectedErrors: []string{
-				"Invalid value::test-nodes.spec.machineType",
+				"Invalid value::test-nodes.spec.image",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "t2.micro",
+				Image:       "ami-00000000000000000",
+			},
+			ExpectedErrors: []string{
+				"Invalid value::test-nodes.spec.image",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "t2.micro",
+				Image:       "ami-0c0ffee0c0ffee000",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MaxPrice: fi.PtrTo("0.05"),
+			},
+			ExpectedErrors: []string{},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MaxPrice: fi.PtrTo("0,05"),
+			},
+			ExpectedErrors: []string{
+				"Invalid value::test-nodes.spec.maxPrice",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MaxPrice: fi.PtrTo("1e-2"),
+			},
+			ExpectedErrors: []string{
+				"Invalid value::test-nodes.spec.maxPrice",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MaxPrice: fi.PtrTo("0.0"),
+			},
+			ExpectedErrors: []string{
+				"Invalid value::test-nodes.spec.maxPrice",
 			},
 		},
 		{
@@ -247,6 +641,14 @@ func TestValidateInstanceGroupSpec(t *testing.T) {
 		RootDeviceName: aws.String("/dev/xvda"),
 		Architecture:   aws.String("x86_64"),
 	})
+	// An image that does not report its architecture
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-0c0ffee0c0ffee000"),
+		Name:           aws.String("custom"),
+		OwnerId:        aws.String("123456789012"),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
 
 	for _, g := range grid {
 		ig := &kops.InstanceGroup{
@@ -292,7 +694,41 @@ func TestMixedInstancePolicies(t *testing.T) {
 					},
 				},
 			},
-			ExpectedErrors: []string{"Invalid value::spec.mixedInstancesPolicy.instances[0]"},
+			ExpectedErrors: []string{
+				"Invalid value::test-nodes.spec.image",
+				"Forbidden::spec.mixedInstancesPolicy.instances[0]",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m6g.large",
+				Image:       "ami-0a1b2c3d4e5f6a7b8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{
+						"m6g.large",
+						"m6g.xlarge",
+						"a1.large",
+					},
+				},
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m6g.large",
+				Image:       "ami-0a1b2c3d4e5f6a7b8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{
+						"m6g.large",
+						"m5.large",
+						"t2.medium",
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::test-nodes.spec.image",
+				"Forbidden::spec.mixedInstancesPolicy.instances[1]",
+				"Forbidden::spec.mixedInstancesPolicy.instances[2]",
+			},
 		},
 		{
 			Input: kops.InstanceGroupSpec{
@@ -335,19 +771,91 @@ func TestMixedInstancePolicies(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Invalid value::spec.mixedInstancesPolicy.onDemandAboveBase"},
 		},
-	}
-	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
-	mockEC2 := &mockec2.MockEC2{}
-	cloud.MockEC2 = mockEC2
-
-	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
-		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
-		ImageId:        aws.String("ami-073c8c0760395aab8"),
-		Name:           aws.String("focal"),
-		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
-		RootDeviceName: aws.String("/dev/xvda"),
-		Architecture:   aws.String("x86_64"),
-	})
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						CPU:                  &kops.MinMaxSpec{Min: quantity("2"), Max: quantity("8")},
+						Memory:               &kops.MinMaxSpec{Min: quantity("4Gi"), Max: quantity("32Gi")},
+						GPU:                  &kops.MinMaxSpec{Max: quantity("0")},
+						BurstablePerformance: fi.PtrTo("excluded"),
+						AllowedInstanceTypes: []string{"m5*", "c5*"},
+					},
+				},
+			},
+			ExpectedErrors: nil,
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{
+						"m4.large",
+					},
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						CPU: &kops.MinMaxSpec{Min: quantity("2")},
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.mixedInstancesPolicy.instanceRequirements"},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						CPU:    &kops.MinMaxSpec{Min: quantity("8"), Max: quantity("2")},
+						Memory: &kops.MinMaxSpec{Min: quantity("-1Gi")},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.mixedInstancesPolicy.instanceRequirements.cpu.min",
+				"Invalid value::spec.mixedInstancesPolicy.instanceRequirements.memory.min",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						BurstablePerformance:  fi.PtrTo("sometimes"),
+						AllowedInstanceTypes:  []string{"m5*"},
+						ExcludedInstanceTypes: []string{"m5.metal"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::spec.mixedInstancesPolicy.instanceRequirements.burstablePerformance",
+				"Forbidden::spec.mixedInstancesPolicy.instanceRequirements.excludedInstanceTypes",
+			},
+		},
+	}
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-0a1b2c3d4e5f6a7b8"),
+		Name:           aws.String("focal-arm64"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("arm64"),
+	})
 
 	for _, g := range grid {
 		ig := &kops.InstanceGroup{
@@ -362,7 +870,873 @@ func TestMixedInstancePolicies(t *testing.T) {
 	}
 }
 
-func TestInstanceMetadataOptions(t *testing.T) {
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func TestInstanceMetadataOptions(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	tests := []struct {
+		ig       *kops.InstanceGroup
+		expected []string
+	}{
+		{
+			ig: &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: kops.InstanceGroupSpec{
+					Role: "Node",
+					InstanceMetadata: &kops.InstanceMetadataOptions{
+						HTTPPutResponseHopLimit: fi.PtrTo(int64(1)),
+						HTTPTokens:              fi.PtrTo("abc"),
+					},
+					MachineType: "t3.medium",
+				},
+			},
+			expected: []string{"Unsupported value::spec.instanceMetadata.httpTokens"},
+		},
+		{
+			ig: &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: kops.InstanceGroupSpec{
+					Role: "Node",
+					InstanceMetadata: &kops.InstanceMetadataOptions{
+						HTTPPutResponseHopLimit: fi.PtrTo(int64(-1)),
+						HTTPTokens:              fi.PtrTo("required"),
+					},
+					MachineType: "t3.medium",
+				},
+			},
+			expected: []string{"Invalid value::spec.instanceMetadata.httpPutResponseHopLimit"},
+		},
+	}
+
+	for _, test := range tests {
+		errs := ValidateInstanceGroup(test.ig, cloud, true)
+		testErrors(t, test.ig.ObjectMeta.Name, errs, test.expected)
+	}
+}
+
+func TestAWSValidatePlacement(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	grid := []struct {
+		name        string
+		machineType string
+		placement   *kops.InstanceGroupPlacementSpec
+		expected    []string
+	}{
+		{
+			name:      "spread",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategySpread},
+		},
+		{
+			name:      "partition",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategyPartition, PartitionCount: fi.PtrTo(int32(7))},
+		},
+		{
+			name:      "partition without count",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategyPartition},
+		},
+		{
+			name:      "cluster",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategyCluster},
+		},
+		{
+			name:      "missing strategy",
+			placement: &kops.InstanceGroupPlacementSpec{},
+			expected:  []string{"Required value::spec.placement.strategy"},
+		},
+		{
+			name:      "unknown strategy",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: "anti-affinity"},
+			expected:  []string{"Unsupported value::spec.placement.strategy"},
+		},
+		{
+			name:      "too few partitions",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategyPartition, PartitionCount: fi.PtrTo(int32(0))},
+			expected:  []string{"Invalid value::spec.placement.partitionCount"},
+		},
+		{
+			name:      "too many partitions",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategyPartition, PartitionCount: fi.PtrTo(int32(8))},
+			expected:  []string{"Invalid value::spec.placement.partitionCount"},
+		},
+		{
+			name:      "partitions without partition strategy",
+			placement: &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategySpread, PartitionCount: fi.PtrTo(int32(2))},
+			expected:  []string{"Forbidden::spec.placement.partitionCount"},
+		},
+		{
+			name:        "cluster with unsupported machine type",
+			machineType: "t3.medium,t2.micro",
+			placement:   &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategyCluster},
+			expected:    []string{"Forbidden::spec.placement.strategy"},
+		},
+		{
+			name:        "spread with burstable machine type",
+			machineType: "t2.micro",
+			placement:   &kops.InstanceGroupPlacementSpec{Strategy: kops.InstanceGroupPlacementStrategySpread},
+		},
+		{
+			name:      "existing placement group",
+			placement: &kops.InstanceGroupPlacementSpec{Name: "shared-pg"},
+		},
+		{
+			name:      "existing placement group with strategy",
+			placement: &kops.InstanceGroupPlacementSpec{Name: "shared-pg", Strategy: kops.InstanceGroupPlacementStrategyCluster},
+		},
+		{
+			name:      "existing placement group with partitions",
+			placement: &kops.InstanceGroupPlacementSpec{Name: "shared-pg", Strategy: kops.InstanceGroupPlacementStrategyPartition, PartitionCount: fi.PtrTo(int32(2))},
+			expected:  []string{"Forbidden::spec.placement.partitionCount"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			machineType := g.machineType
+			if machineType == "" {
+				machineType = "t3.medium"
+			}
+			ig := &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: kops.InstanceGroupSpec{
+					Role:        "Node",
+					Image:       "ami-073c8c0760395aab8",
+					MachineType: machineType,
+					Placement:   g.placement,
+				},
+			}
+			errs := ValidateInstanceGroup(ig, cloud, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateInstanceMaintenancePolicy(t *testing.T) {
+	grid := []struct {
+		name     string
+		policy   *kops.InstanceMaintenancePolicySpec
+		expected []string
+	}{
+		{
+			name:   "launch before terminate",
+			policy: &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(100)), MaxHealthyPercentage: fi.PtrTo(int32(110))},
+		},
+		{
+			name:   "terminate and launch",
+			policy: &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(90)), MaxHealthyPercentage: fi.PtrTo(int32(100))},
+		},
+		{
+			name:   "widest range",
+			policy: &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(100)), MaxHealthyPercentage: fi.PtrTo(int32(200))},
+		},
+		{
+			name:     "missing percentages",
+			policy:   &kops.InstanceMaintenancePolicySpec{},
+			expected: []string{"Required value::spec.instanceMaintenancePolicy.minHealthyPercentage", "Required value::spec.instanceMaintenancePolicy.maxHealthyPercentage"},
+		},
+		{
+			name:     "min too low",
+			policy:   &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(-1)), MaxHealthyPercentage: fi.PtrTo(int32(100))},
+			expected: []string{"Invalid value::spec.instanceMaintenancePolicy.minHealthyPercentage"},
+		},
+		{
+			name:     "min too high",
+			policy:   &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(101)), MaxHealthyPercentage: fi.PtrTo(int32(150))},
+			expected: []string{"Invalid value::spec.instanceMaintenancePolicy.minHealthyPercentage"},
+		},
+		{
+			name:     "max too low",
+			policy:   &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(50)), MaxHealthyPercentage: fi.PtrTo(int32(99))},
+			expected: []string{"Invalid value::spec.instanceMaintenancePolicy.maxHealthyPercentage"},
+		},
+		{
+			name:     "max too high",
+			policy:   &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(100)), MaxHealthyPercentage: fi.PtrTo(int32(201))},
+			expected: []string{"Invalid value::spec.instanceMaintenancePolicy.maxHealthyPercentage"},
+		},
+		{
+			name:     "range too wide",
+			policy:   &kops.InstanceMaintenancePolicySpec{MinHealthyPercentage: fi.PtrTo(int32(50)), MaxHealthyPercentage: fi.PtrTo(int32(200))},
+			expected: []string{"Invalid value::spec.instanceMaintenancePolicy.maxHealthyPercentage"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			errs := awsValidateInstanceMaintenancePolicy(field.NewPath("spec", "instanceMaintenancePolicy"), g.policy)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateWarmPool(t *testing.T) {
+	grid := []struct {
+		name                 string
+		clusterWarmPool      *kops.WarmPoolSpec
+		warmPool             *kops.WarmPoolSpec
+		role                 kops.InstanceGroupRole
+		maxPrice             *string
+		mixedInstancesPolicy *kops.MixedInstancesPolicySpec
+		expected             []string
+	}{
+		{
+			name:     "enabled",
+			warmPool: &kops.WarmPoolSpec{MinSize: 1, MaxSize: fi.PtrTo(int64(2)), EnableLifecycleHook: true},
+		},
+		{
+			name:            "enabled by cluster",
+			clusterWarmPool: &kops.WarmPoolSpec{},
+		},
+		{
+			name:            "disabled by instance group",
+			clusterWarmPool: &kops.WarmPoolSpec{EnableLifecycleHook: true},
+			warmPool:        &kops.WarmPoolSpec{MaxSize: fi.PtrTo(int64(0))},
+			maxPrice:        fi.PtrTo("0.1"),
+		},
+		{
+			name:     "bastion",
+			warmPool: &kops.WarmPoolSpec{},
+			role:     kops.InstanceGroupRoleBastion,
+			expected: []string{"Forbidden::spec.warmPool"},
+		},
+		{
+			name:     "spot",
+			warmPool: &kops.WarmPoolSpec{},
+			maxPrice: fi.PtrTo("0.1"),
+			expected: []string{"Forbidden::spec.warmPool"},
+		},
+		{
+			name:                 "spot only mixed instances policy",
+			warmPool:             &kops.WarmPoolSpec{},
+			mixedInstancesPolicy: &kops.MixedInstancesPolicySpec{OnDemandAboveBase: fi.PtrTo(int64(0))},
+			expected:             []string{"Forbidden::spec.warmPool"},
+		},
+		{
+			name:                 "mixed instances policy",
+			clusterWarmPool:      &kops.WarmPoolSpec{},
+			mixedInstancesPolicy: &kops.MixedInstancesPolicySpec{OnDemandBase: fi.PtrTo(int64(1))},
+			expected:             []string{"Forbidden::spec.warmPool"},
+		},
+		{
+			name:     "max size lower than min size",
+			warmPool: &kops.WarmPoolSpec{MinSize: 3, MaxSize: fi.PtrTo(int64(2))},
+			expected: []string{"Invalid value::spec.warmPool.maxSize"},
+		},
+		{
+			name:     "negative min size",
+			warmPool: &kops.WarmPoolSpec{MinSize: -1},
+			expected: []string{"Invalid value::spec.warmPool.minSize"},
+		},
+		{
+			name:     "lifecycle hook without warm pool",
+			warmPool: &kops.WarmPoolSpec{MaxSize: fi.PtrTo(int64(0)), EnableLifecycleHook: true},
+			expected: []string{"Forbidden::spec.warmPool.enableLifecycleHook"},
+		},
+		{
+			name:     "reuse on scale in",
+			warmPool: &kops.WarmPoolSpec{MinSize: 1, ReuseOnScaleIn: fi.PtrTo(true)},
+		},
+		{
+			name:     "reuse on scale in without warm pool",
+			warmPool: &kops.WarmPoolSpec{MaxSize: fi.PtrTo(int64(0)), ReuseOnScaleIn: fi.PtrTo(true)},
+			expected: []string{"Forbidden::spec.warmPool.reuseOnScaleIn"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{
+							WarmPool: g.clusterWarmPool,
+						},
+					},
+				},
+			}
+
+			ig := createMinimalInstanceGroup()
+			if g.role != "" {
+				ig.Spec.Role = g.role
+			}
+			ig.Spec.WarmPool = g.warmPool
+			ig.Spec.MaxPrice = g.maxPrice
+			ig.Spec.MixedInstancesPolicy = g.mixedInstancesPolicy
+
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateInstanceMetadataDefault(t *testing.T) {
+	grid := []struct {
+		name                    string
+		clusterInstanceMetadata *kops.InstanceMetadataOptions
+		instanceMetadata        *kops.InstanceMetadataOptions
+		expected                []string
+	}{
+		{
+			name:             "no cluster default",
+			instanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("optional")},
+		},
+		{
+			name:                    "inherited",
+			clusterInstanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required")},
+		},
+		{
+			name:                    "override hop limit",
+			clusterInstanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required")},
+			instanceMetadata:        &kops.InstanceMetadataOptions{HTTPPutResponseHopLimit: fi.PtrTo(int64(3))},
+		},
+		{
+			name:                    "downgrade",
+			clusterInstanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required")},
+			instanceMetadata:        &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("optional")},
+			expected:                []string{"Forbidden::spec.instanceMetadata.httpTokens"},
+		},
+		{
+			name:                    "allowed downgrade",
+			clusterInstanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required")},
+			instanceMetadata:        &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("optional"), AllowDowngrade: fi.PtrTo(true)},
+		},
+		{
+			name:                    "optional cluster default",
+			clusterInstanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("optional")},
+			instanceMetadata:        &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("optional")},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{
+							InstanceMetadata: g.clusterInstanceMetadata,
+						},
+					},
+				},
+			}
+
+			ig := createMinimalInstanceGroup()
+			ig.Spec.InstanceMetadata = g.instanceMetadata
+
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateClusterInstanceMetadata(t *testing.T) {
+	grid := []struct {
+		name             string
+		instanceMetadata *kops.InstanceMetadataOptions
+		expected         []string
+	}{
+		{
+			name:             "required",
+			instanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required"), HTTPPutResponseHopLimit: fi.PtrTo(int64(2))},
+		},
+		{
+			name:             "invalid tokens",
+			instanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("abc")},
+			expected:         []string{"Unsupported value::spec.cloudProvider.aws.instanceMetadata.httpTokens"},
+		},
+		{
+			name:             "invalid hop limit",
+			instanceMetadata: &kops.InstanceMetadataOptions{HTTPPutResponseHopLimit: fi.PtrTo(int64(65))},
+			expected:         []string{"Invalid value::spec.cloudProvider.aws.instanceMetadata.httpPutResponseHopLimit"},
+		},
+		{
+			name:             "allow downgrade",
+			instanceMetadata: &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required"), AllowDowngrade: fi.PtrTo(true)},
+			expected:         []string{"Forbidden::spec.cloudProvider.aws.instanceMetadata.allowDowngrade"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			awsSpec := &kops.AWSSpec{
+				InstanceMetadata: g.instanceMetadata,
+			}
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: awsSpec,
+					},
+				},
+			}
+
+			errs := validateAWS(cluster, awsSpec, field.NewPath("spec", "cloudProvider", "aws"))
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateGPUConfig(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	grid := []struct {
+		name        string
+		machineType string
+		gpuConfig   *kops.GPUConfigSpec
+		expected    []string
+	}{
+		{
+			name:        "a100",
+			machineType: "p4d.24xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "1g.5gb"},
+		},
+		{
+			name:        "h100",
+			machineType: "p5.48xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "3g.40gb"},
+		},
+		{
+			name:        "profile of other gpu",
+			machineType: "p4d.24xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "3g.40gb"},
+			expected:    []string{"Unsupported value::spec.gpuConfig.migProfile"},
+		},
+		{
+			name:        "mixed machine types",
+			machineType: "p4d.24xlarge,p5.48xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "7g.80gb"},
+			expected:    []string{"Unsupported value::spec.gpuConfig.migProfile"},
+		},
+		{
+			name:        "gpu without mig",
+			machineType: "g4dn.xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "1g.5gb"},
+			expected:    []string{"Forbidden::spec.gpuConfig.migProfile"},
+		},
+		{
+			name:        "no gpu",
+			machineType: "t3.medium",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "1g.5gb"},
+			expected:    []string{"Forbidden::spec.gpuConfig.migProfile"},
+		},
+		{
+			name:        "invalid profile",
+			machineType: "p4d.24xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{MIGProfile: "5g.25gb"},
+			expected:    []string{"Invalid value::spec.gpuConfig.migProfile", "Unsupported value::spec.gpuConfig.migProfile"},
+		},
+		{
+			name:        "missing profile",
+			machineType: "p4d.24xlarge",
+			gpuConfig:   &kops.GPUConfigSpec{},
+			expected:    []string{"Required value::spec.gpuConfig.migProfile"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: kops.InstanceGroupSpec{
+					Role:        "Node",
+					Image:       "ami-073c8c0760395aab8",
+					MachineType: g.machineType,
+					GPUConfig:   g.gpuConfig,
+				},
+			}
+			errs := ValidateInstanceGroup(ig, cloud, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateNetworkInterfaces(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	grid := []struct {
+		name                 string
+		machineType          string
+		mixedInstancesPolicy *kops.MixedInstancesPolicySpec
+		networkInterfaces    *kops.NetworkInterfacesSpec
+		expected             []string
+	}{
+		{
+			name:              "ena express",
+			machineType:       "c6in.32xlarge",
+			networkInterfaces: &kops.NetworkInterfacesSpec{ENASRDEnabled: fi.PtrTo(true), ENASRDUDPEnabled: fi.PtrTo(true)},
+		},
+		{
+			name:              "ena express disabled on unsupported machine type",
+			machineType:       "t3.medium",
+			networkInterfaces: &kops.NetworkInterfacesSpec{ENASRDEnabled: fi.PtrTo(false)},
+		},
+		{
+			name:              "unsupported machine type",
+			machineType:       "t3.medium",
+			networkInterfaces: &kops.NetworkInterfacesSpec{ENASRDEnabled: fi.PtrTo(true)},
+			expected:          []string{"Forbidden::spec.networkInterfaces.enaSrdEnabled"},
+		},
+		{
+			name:        "unsupported machine type in mixed instances policy",
+			machineType: "c6in.32xlarge",
+			mixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+				Instances: []string{"c6in.32xlarge", "t3.medium"},
+			},
+			networkInterfaces: &kops.NetworkInterfacesSpec{ENASRDEnabled: fi.PtrTo(true)},
+			expected:          []string{"Forbidden::spec.networkInterfaces.enaSrdEnabled"},
+		},
+		{
+			name:              "udp without ena express",
+			machineType:       "c6in.32xlarge",
+			networkInterfaces: &kops.NetworkInterfacesSpec{ENASRDUDPEnabled: fi.PtrTo(true)},
+			expected:          []string{"Forbidden::spec.networkInterfaces.enaSrdUdpEnabled"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: kops.InstanceGroupSpec{
+					Role:                 "Node",
+					Image:                "ami-073c8c0760395aab8",
+					MachineType:          g.machineType,
+					MixedInstancesPolicy: g.mixedInstancesPolicy,
+					NetworkInterfaces:    g.networkInterfaces,
+				},
+			}
+			errs := ValidateInstanceGroup(ig, cloud, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateCapacityReservation(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	grid := []struct {
+		name     string
+		spec     kops.InstanceGroupSpec
+		expected []string
+	}{
+		{
+			name: "reservation ID",
+			spec: kops.InstanceGroupSpec{CapacityReservationID: fi.PtrTo("cr-0123456789abcdef0")},
+		},
+		{
+			name: "short reservation ID",
+			spec: kops.InstanceGroupSpec{CapacityReservationID: fi.PtrTo("cr-01234567")},
+		},
+		{
+			name: "resource group ARN",
+			spec: kops.InstanceGroupSpec{CapacityReservationResourceGroupARN: fi.PtrTo("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations")},
+		},
+		{
+			name:     "invalid reservation ID",
+			spec:     kops.InstanceGroupSpec{CapacityReservationID: fi.PtrTo("0123456789abcdef0")},
+			expected: []string{"Invalid value::spec.capacityReservationID"},
+		},
+		{
+			name:     "invalid resource group ARN",
+			spec:     kops.InstanceGroupSpec{CapacityReservationResourceGroupARN: fi.PtrTo("arn:aws:iam::123456789012:role/my-reservations")},
+			expected: []string{"Invalid value::spec.capacityReservationResourceGroupARN"},
+		},
+		{
+			name: "reservation ID and resource group ARN",
+			spec: kops.InstanceGroupSpec{
+				CapacityReservationID:               fi.PtrTo("cr-0123456789abcdef0"),
+				CapacityReservationResourceGroupARN: fi.PtrTo("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"),
+			},
+			expected: []string{"Forbidden::spec.capacityReservationResourceGroupARN"},
+		},
+		{
+			name: "spot instances",
+			spec: kops.InstanceGroupSpec{
+				CapacityReservationID: fi.PtrTo("cr-0123456789abcdef0"),
+				MaxPrice:              fi.PtrTo("0.05"),
+			},
+			expected: []string{"Forbidden::spec.maxPrice"},
+		},
+		{
+			name: "spot instances in mixed instances policy",
+			spec: kops.InstanceGroupSpec{
+				CapacityReservationID: fi.PtrTo("cr-0123456789abcdef0"),
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances:         []string{"t3.medium", "t3a.medium"},
+					OnDemandAboveBase: fi.PtrTo(int64(50)),
+				},
+			},
+			expected: []string{"Forbidden::spec.mixedInstancesPolicy.onDemandAboveBase"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: g.spec,
+			}
+			ig.Spec.Role = "Node"
+			ig.Spec.Image = "ami-073c8c0760395aab8"
+			ig.Spec.MachineType = "t3.medium"
+			errs := ValidateInstanceGroup(ig, cloud, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateHostTenancy(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	grid := []struct {
+		name     string
+		spec     kops.InstanceGroupSpec
+		expected []string
+	}{
+		{
+			name: "host tenancy",
+			spec: kops.InstanceGroupSpec{Tenancy: "host"},
+		},
+		{
+			name: "host resource group",
+			spec: kops.InstanceGroupSpec{
+				Tenancy:              "host",
+				HostResourceGroupARN: fi.PtrTo("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+			},
+		},
+		{
+			name:     "host resource group without host tenancy",
+			spec:     kops.InstanceGroupSpec{HostResourceGroupARN: fi.PtrTo("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts")},
+			expected: []string{"Forbidden::spec.hostResourceGroupARN"},
+		},
+		{
+			name: "host resource group with dedicated tenancy",
+			spec: kops.InstanceGroupSpec{
+				Tenancy:              "dedicated",
+				HostResourceGroupARN: fi.PtrTo("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+			},
+			expected: []string{"Forbidden::spec.hostResourceGroupARN"},
+		},
+		{
+			name: "invalid host resource group ARN",
+			spec: kops.InstanceGroupSpec{
+				Tenancy:              "host",
+				HostResourceGroupARN: fi.PtrTo("arn:aws:ec2:us-east-1:123456789012:dedicated-host/h-0123456789abcdef0"),
+			},
+			expected: []string{"Invalid value::spec.hostResourceGroupARN"},
+		},
+		{
+			name: "spot instances",
+			spec: kops.InstanceGroupSpec{
+				Tenancy:  "host",
+				MaxPrice: fi.PtrTo("0.05"),
+			},
+			expected: []string{"Forbidden::spec.maxPrice"},
+		},
+		{
+			name: "spot blocks",
+			spec: kops.InstanceGroupSpec{
+				Tenancy:               "host",
+				SpotDurationInMinutes: fi.PtrTo(int64(60)),
+			},
+			expected: []string{"Forbidden::spec.spotDurationInMinutes"},
+		},
+		{
+			name: "mixed instances policy",
+			spec: kops.InstanceGroupSpec{
+				Tenancy: "host",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances:         []string{"t3.medium", "t3a.medium"},
+					OnDemandAboveBase: fi.PtrTo(int64(100)),
+				},
+			},
+			expected: []string{"Forbidden::spec.mixedInstancesPolicy"},
+		},
+		{
+			name: "spot instances with dedicated tenancy",
+			spec: kops.InstanceGroupSpec{
+				Tenancy:  "dedicated",
+				MaxPrice: fi.PtrTo("0.05"),
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "some-ig",
+				},
+				Spec: g.spec,
+			}
+			ig.Spec.Role = "Node"
+			ig.Spec.Image = "ami-073c8c0760395aab8"
+			ig.Spec.MachineType = "t3.medium"
+			errs := ValidateInstanceGroup(ig, cloud, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateTransitGatewayEgress(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.TransitGatewayVpcAttachments = []*ec2.TransitGatewayVpcAttachment{
+		{
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+			TransitGatewayId:           aws.String("tgw-0123456789abcdef0"),
+			VpcId:                      aws.String("vpc-12345678"),
+			State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+		},
+		{
+			TransitGatewayAttachmentId: aws.String("tgw-attach-2"),
+			TransitGatewayId:           aws.String("tgw-00000000000000000"),
+			VpcId:                      aws.String("vpc-12345678"),
+			State:                      aws.String(ec2.TransitGatewayAttachmentStateDeleted),
+		},
+	}
+
+	grid := []struct {
+		name      string
+		networkID string
+		egress    string
+		expected  []string
+	}{
+		{
+			name:      "attached",
+			networkID: "vpc-12345678",
+			egress:    "tgw-0123456789abcdef0",
+		},
+		{
+			name:      "attachment deleted",
+			networkID: "vpc-12345678",
+			egress:    "tgw-00000000000000000",
+			expected:  []string{"Invalid value::spec.networking.subnets[0].egress"},
+		},
+		{
+			name:      "attached to another vpc",
+			networkID: "vpc-87654321",
+			egress:    "tgw-0123456789abcdef0",
+			expected:  []string{"Invalid value::spec.networking.subnets[0].egress"},
+		},
+		{
+			name:   "vpc created by kops",
+			egress: "tgw-0123456789abcdef0",
+		},
+		{
+			name:      "nat gateway",
+			networkID: "vpc-12345678",
+			egress:    "nat-0123456789abcdef0",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					Networking: kops.NetworkingSpec{
+						NetworkID: g.networkID,
+						Subnets: []kops.ClusterSubnetSpec{
+							{Name: "us-east-1a", Type: kops.SubnetTypePrivate, Egress: g.egress},
+						},
+					},
+				},
+			}
+			errs := awsValidateTransitGatewayEgress(cluster, cloud)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateVolumes(t *testing.T) {
 	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
 
 	mockEC2 := &mockec2.MockEC2{}
@@ -377,47 +1751,119 @@ func TestInstanceMetadataOptions(t *testing.T) {
 		Architecture:   aws.String("x86_64"),
 	})
 
-	tests := []struct {
-		ig       *kops.InstanceGroup
-		expected []string
+	grid := []struct {
+		name       string
+		rootVolume *kops.InstanceRootVolumeSpec
+		volumes    []kops.VolumeSpec
+		expected   []string
 	}{
 		{
-			ig: &kops.InstanceGroup{
-				ObjectMeta: v1.ObjectMeta{
-					Name: "some-ig",
-				},
-				Spec: kops.InstanceGroupSpec{
-					Role: "Node",
-					InstanceMetadata: &kops.InstanceMetadataOptions{
-						HTTPPutResponseHopLimit: fi.PtrTo(int64(1)),
-						HTTPTokens:              fi.PtrTo("abc"),
-					},
-					MachineType: "t3.medium",
-				},
+			name:       "gp3 defaults",
+			rootVolume: &kops.InstanceRootVolumeSpec{Size: fi.PtrTo(int32(64))},
+		},
+		{
+			name:       "gp3 provisioned",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("gp3"), Size: fi.PtrTo(int32(64)), IOPS: fi.PtrTo(int32(16000)), Throughput: fi.PtrTo(int32(1000))},
+		},
+		{
+			name:       "gp3 too many IOPS",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("gp3"), IOPS: fi.PtrTo(int32(80000))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.iops"},
+		},
+		{
+			name:       "gp3 too many IOPS for size",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("gp3"), Size: fi.PtrTo(int32(8)), IOPS: fi.PtrTo(int32(5000))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.iops"},
+		},
+		{
+			name:       "gp3 too little throughput",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("gp3"), Throughput: fi.PtrTo(int32(100))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.throughput"},
+		},
+		{
+			name:       "gp3 too much throughput",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("gp3"), IOPS: fi.PtrTo(int32(16000)), Throughput: fi.PtrTo(int32(1001))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.throughput"},
+		},
+		{
+			name:       "gp3 too much throughput for IOPS",
+			rootVolume: &kops.InstanceRootVolumeSpec{Throughput: fi.PtrTo(int32(1000))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.throughput"},
+		},
+		{
+			name:       "gp2 IOPS and throughput",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("gp2"), IOPS: fi.PtrTo(int32(3000)), Throughput: fi.PtrTo(int32(125))},
+			expected: []string{
+				"Forbidden::test-nodes.spec.rootVolume.iops",
+				"Forbidden::test-nodes.spec.rootVolume.throughput",
 			},
-			expected: []string{"Unsupported value::spec.instanceMetadata.httpTokens"},
 		},
 		{
-			ig: &kops.InstanceGroup{
+			name:       "io1 IOPS for size",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("io1"), Size: fi.PtrTo(int32(100)), IOPS: fi.PtrTo(int32(5000))},
+		},
+		{
+			name:       "io1 too many IOPS for size",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("io1"), Size: fi.PtrTo(int32(100)), IOPS: fi.PtrTo(int32(5001))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.iops"},
+		},
+		{
+			name:       "io2 too many IOPS for size",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("io2"), Size: fi.PtrTo(int32(20)), IOPS: fi.PtrTo(int32(20001))},
+			expected:   []string{"Invalid value::test-nodes.spec.rootVolume.iops"},
+		},
+		{
+			name:       "io2 throughput",
+			rootVolume: &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("io2"), IOPS: fi.PtrTo(int32(1000)), Throughput: fi.PtrTo(int32(500))},
+			expected:   []string{"Forbidden::test-nodes.spec.rootVolume.throughput"},
+		},
+		{
+			name: "valid volumes",
+			volumes: []kops.VolumeSpec{
+				{Device: "/dev/xvdd", Size: 500, Type: "st1"},
+				{Device: "/dev/xvde", Size: 100, Type: "io2", IOPS: fi.PtrTo(int64(64000))},
+				{Device: "/dev/xvdf", Size: 20},
+			},
+		},
+		{
+			name: "unsupported volume type",
+			volumes: []kops.VolumeSpec{
+				{Device: "/dev/xvdd", Size: 20, Type: "gp4"},
+			},
+			expected: []string{"Unsupported value::test-nodes.spec.volumes[0].type"},
+		},
+		{
+			name: "invalid volumes",
+			volumes: []kops.VolumeSpec{
+				{Device: "/dev/xvdd", Size: 500, Type: "sc1", IOPS: fi.PtrTo(int64(500))},
+				{Device: "/dev/xvde", Size: 100, Type: "io1", IOPS: fi.PtrTo(int64(50))},
+				{Device: "/dev/xvdf", Size: 20, Type: "gp3", Throughput: fi.PtrTo(int64(2000))},
+			},
+			expected: []string{
+				"Forbidden::test-nodes.spec.volumes[0].iops",
+				"Invalid value::test-nodes.spec.volumes[1].iops",
+				"Invalid value::test-nodes.spec.volumes[2].throughput",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
 				ObjectMeta: v1.ObjectMeta{
-					Name: "some-ig",
+					Name: "test-nodes",
 				},
 				Spec: kops.InstanceGroupSpec{
-					Role: "Node",
-					InstanceMetadata: &kops.InstanceMetadataOptions{
-						HTTPPutResponseHopLimit: fi.PtrTo(int64(-1)),
-						HTTPTokens:              fi.PtrTo("required"),
-					},
+					Role:        "Node",
+					Image:       "ami-073c8c0760395aab8",
 					MachineType: "t3.medium",
+					RootVolume:  g.rootVolume,
+					Volumes:     g.volumes,
 				},
-			},
-			expected: []string{"Invalid value::spec.instanceMetadata.httpPutResponseHopLimit"},
-		},
-	}
-
-	for _, test := range tests {
-		errs := ValidateInstanceGroup(test.ig, cloud, true)
-		testErrors(t, test.ig.ObjectMeta.Name, errs, test.expected)
+			}
+			errs := awsValidateInstanceGroup(ig, cloud)
+			testErrors(t, g.name, errs, g.expected)
+		})
 	}
 }
 
@@ -524,87 +1970,395 @@ func TestLoadBalancerSubnets(t *testing.T) {
 					AllocationID:       nil,
 				},
 			},
-			expected: []string{"Invalid value::spec.api.loadBalancer.subnets[0].privateIPv4Address"},
+			expected: []string{"Invalid value::spec.api.loadBalancer.subnets[0].privateIPv4Address"},
+		},
+		{ // invalid class - with privateIPv4Address, no allocationID
+			class:          fi.PtrTo(string(kops.LoadBalancerClassClassic)),
+			clusterSubnets: []string{"a", "b", "c"},
+			lbSubnets: []kops.LoadBalancerSubnetSpec{
+				{
+					Name:               "a",
+					PrivateIPv4Address: fi.PtrTo("10.0.0.10"),
+					AllocationID:       nil,
+				},
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].privateIPv4Address"},
+		},
+		{ // invalid class - no privateIPv4Address, with allocationID
+			class:          fi.PtrTo(string(kops.LoadBalancerClassClassic)),
+			clusterSubnets: []string{"a", "b", "c"},
+			lbSubnets: []kops.LoadBalancerSubnetSpec{
+				{
+					Name:               "a",
+					PrivateIPv4Address: nil,
+					AllocationID:       fi.PtrTo("eipalloc-222ghi789"),
+				},
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].allocationID"},
+		},
+		{ // invalid type external for private IP
+			lbType:         fi.PtrTo(string(kops.LoadBalancerTypePublic)),
+			clusterSubnets: []string{"a", "b", "c"},
+			lbSubnets: []kops.LoadBalancerSubnetSpec{
+				{
+					Name:               "a",
+					PrivateIPv4Address: fi.PtrTo("10.0.0.10"),
+					AllocationID:       nil,
+				},
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].privateIPv4Address"},
+		},
+		{ // invalid type Internal for public IP
+			lbType:         fi.PtrTo(string(kops.LoadBalancerTypeInternal)),
+			clusterSubnets: []string{"a", "b", "c"},
+			lbSubnets: []kops.LoadBalancerSubnetSpec{
+				{
+					Name:               "a",
+					PrivateIPv4Address: nil,
+					AllocationID:       fi.PtrTo("eipalloc-222ghi789"),
+				},
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].allocationID"},
+		},
+	}
+
+	for _, test := range tests {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class: kops.LoadBalancerClassNetwork,
+						Type:  kops.LoadBalancerTypeInternal,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		if test.class != nil {
+			cluster.Spec.API.LoadBalancer.Class = kops.LoadBalancerClass(*test.class)
+		}
+		if test.lbType != nil {
+			cluster.Spec.API.LoadBalancer.Type = kops.LoadBalancerType(*test.lbType)
+		}
+		for _, s := range test.clusterSubnets {
+			cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets, kops.ClusterSubnetSpec{
+				Name: s,
+				CIDR: cidr,
+			})
+		}
+		cluster.Spec.API.LoadBalancer.Subnets = test.lbSubnets
+		errs := awsValidateCluster(&cluster, true)
+		testErrors(t, test, errs, test.expected)
+	}
+}
+
+func TestAWSValidateAccessLog(t *testing.T) {
+	tests := []struct {
+		class     kops.LoadBalancerClass
+		accessLog *kops.AccessLogSpec
+		expected  []string
+	}{
+		{ // not configured
+			class: kops.LoadBalancerClassClassic,
+		},
+		{ // valid classic
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket:       fi.PtrTo("access-logs.example.com"),
+				BucketPrefix: fi.PtrTo("api/logs"),
+				Interval:     5,
+			},
+		},
+		{ // valid network
+			class: kops.LoadBalancerClassNetwork,
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("access-logs"),
+			},
+		},
+		{ // disabled without bucket
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Enabled: fi.PtrTo(false),
+			},
+		},
+		{ // missing bucket
+			class:     kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{},
+			expected:  []string{"Required value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{ // uppercase bucket
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("Access-Logs"),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{ // short bucket
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("ab"),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{ // IP address bucket
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("192.168.1.1"),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{ // consecutive dots in bucket
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("access..logs"),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{ // prefix with slash
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket:       fi.PtrTo("access-logs"),
+				BucketPrefix: fi.PtrTo("/api"),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucketPrefix"},
+		},
+		{ // unsupported interval
+			class: kops.LoadBalancerClassClassic,
+			accessLog: &kops.AccessLogSpec{
+				Bucket:   fi.PtrTo("access-logs"),
+				Interval: 10,
+			},
+			expected: []string{"Unsupported value::spec.api.loadBalancer.accessLog.interval"},
+		},
+		{ // interval with network
+			class: kops.LoadBalancerClassNetwork,
+			accessLog: &kops.AccessLogSpec{
+				Bucket:   fi.PtrTo("access-logs"),
+				Interval: 60,
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.accessLog.interval"},
+		},
+		{ // unsupported class
+			class: kops.LoadBalancerClass("Application"),
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("access-logs"),
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.accessLog"},
+		},
+		{ // unsupported class with disabled access logs
+			class: kops.LoadBalancerClass("Application"),
+			accessLog: &kops.AccessLogSpec{
+				Enabled: fi.PtrTo(false),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		spec := &kops.LoadBalancerAccessSpec{
+			Class:     test.class,
+			AccessLog: test.accessLog,
+		}
+		errs := awsValidateAccessLog(field.NewPath("spec", "api", "loadBalancer", "accessLog"), spec)
+		testErrors(t, test, errs, test.expected)
+	}
+}
+
+func TestAWSValidateCrossZoneLoadBalancing(t *testing.T) {
+	tests := []struct {
+		name                   string
+		dns                    kops.DNSType
+		crossZoneLoadBalancing *bool
+		expected               []string
+	}{
+		{
+			name: "none dns default",
+			dns:  kops.DNSTypeNone,
+		},
+		{
+			name:                   "none dns enabled",
+			dns:                    kops.DNSTypeNone,
+			crossZoneLoadBalancing: fi.PtrTo(true),
+		},
+		{
+			name:                   "none dns disabled",
+			dns:                    kops.DNSTypeNone,
+			crossZoneLoadBalancing: fi.PtrTo(false),
+			expected:               []string{"Forbidden::spec.api.loadBalancer.crossZoneLoadBalancing"},
+		},
+		{
+			name:                   "public dns disabled",
+			dns:                    kops.DNSTypePublic,
+			crossZoneLoadBalancing: fi.PtrTo(false),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := kops.Cluster{
+				Spec: kops.ClusterSpec{
+					API: kops.APISpec{
+						LoadBalancer: &kops.LoadBalancerAccessSpec{
+							Class:                  kops.LoadBalancerClassNetwork,
+							Type:                   kops.LoadBalancerTypePublic,
+							CrossZoneLoadBalancing: test.crossZoneLoadBalancing,
+						},
+					},
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					Networking: kops.NetworkingSpec{
+						NonMasqueradeCIDR: "100.64.0.0/10",
+						Topology: &kops.TopologySpec{
+							DNS: test.dns,
+						},
+					},
+				},
+			}
+			errs := awsValidateCluster(&cluster, true)
+			testErrors(t, test.name, errs, test.expected)
+		})
+	}
+}
+
+func TestAWSValidateLoadBalancerIPAddressType(t *testing.T) {
+	tests := []struct {
+		name          string
+		class         kops.LoadBalancerClass
+		ipAddressType kops.LoadBalancerIPAddressType
+		lbSubnets     []string
+		expected      []string
+	}{
+		{
+			name:  "unset",
+			class: kops.LoadBalancerClassClassic,
+		},
+		{
+			name:          "ipv4 classic",
+			class:         kops.LoadBalancerClassClassic,
+			ipAddressType: kops.LoadBalancerIPAddressTypeIPv4,
+		},
+		{
+			name:          "unsupported",
+			class:         kops.LoadBalancerClassNetwork,
+			ipAddressType: "ipv6",
+			expected:      []string{"Unsupported value::spec.api.loadBalancer.ipAddressType"},
+		},
+		{
+			name:          "dualstack network",
+			class:         kops.LoadBalancerClassNetwork,
+			ipAddressType: kops.LoadBalancerIPAddressTypeDualStack,
+		},
+		{
+			name:          "dualstack classic",
+			class:         kops.LoadBalancerClassClassic,
+			ipAddressType: kops.LoadBalancerIPAddressTypeDualStack,
+			expected:      []string{"Forbidden::spec.api.loadBalancer.ipAddressType"},
+		},
+		{
+			name:          "dualstack subnets with IPv6",
+			class:         kops.LoadBalancerClassNetwork,
+			ipAddressType: kops.LoadBalancerIPAddressTypeDualStack,
+			lbSubnets:     []string{"utility-a", "utility-b"},
+		},
+		{
+			name:          "dualstack subnet without IPv6",
+			class:         kops.LoadBalancerClassNetwork,
+			ipAddressType: kops.LoadBalancerIPAddressTypeDualStack,
+			lbSubnets:     []string{"utility-a", "utility-c"},
+			expected:      []string{"Forbidden::spec.api.loadBalancer.subnets[1].name"},
+		},
+		{
+			name:          "ipv4 subnet without IPv6",
+			class:         kops.LoadBalancerClassNetwork,
+			ipAddressType: kops.LoadBalancerIPAddressTypeIPv4,
+			lbSubnets:     []string{"utility-c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:         test.class,
+						Type:          kops.LoadBalancerTypePublic,
+						IPAddressType: test.ipAddressType,
+					},
+				},
+				Networking: kops.NetworkingSpec{
+					Subnets: []kops.ClusterSubnetSpec{
+						{Name: "utility-a", Type: kops.SubnetTypeUtility, CIDR: "10.0.0.0/24", IPv6CIDR: "/64#1"},
+						{Name: "utility-b", Type: kops.SubnetTypeUtility, CIDR: "10.0.1.0/24", IPv6CIDR: "/64#2"},
+						{Name: "utility-c", Type: kops.SubnetTypeUtility, CIDR: "10.0.2.0/24"},
+					},
+				},
+			}
+			for _, name := range test.lbSubnets {
+				spec.API.LoadBalancer.Subnets = append(spec.API.LoadBalancer.Subnets, kops.LoadBalancerSubnetSpec{Name: name})
+			}
+			errs := awsValidateLoadBalancerIPAddressType(field.NewPath("spec", "api", "loadBalancer"), spec)
+			testErrors(t, test.name, errs, test.expected)
+		})
+	}
+}
+
+func TestAWSValidateLoadBalancerSecurityGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     kops.LoadBalancerAccessSpec
+		expected []string
+	}{
+		{
+			name: "network default",
+			spec: kops.LoadBalancerAccessSpec{
+				Class:                 kops.LoadBalancerClassNetwork,
+				SecurityGroupOverride: fi.PtrTo("sg-12345678"),
+			},
 		},
-		{ // invalid class - with privateIPv4Address, no allocationID
-			class:          fi.PtrTo(string(kops.LoadBalancerClassClassic)),
-			clusterSubnets: []string{"a", "b", "c"},
-			lbSubnets: []kops.LoadBalancerSubnetSpec{
-				{
-					Name:               "a",
-					PrivateIPv4Address: fi.PtrTo("10.0.0.10"),
-					AllocationID:       nil,
-				},
+		{
+			name: "network without security groups",
+			spec: kops.LoadBalancerAccessSpec{
+				Class:             kops.LoadBalancerClassNetwork,
+				UseSecurityGroups: fi.PtrTo(false),
 			},
-			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].privateIPv4Address"},
 		},
-		{ // invalid class - no privateIPv4Address, with allocationID
-			class:          fi.PtrTo(string(kops.LoadBalancerClassClassic)),
-			clusterSubnets: []string{"a", "b", "c"},
-			lbSubnets: []kops.LoadBalancerSubnetSpec{
-				{
-					Name:               "a",
-					PrivateIPv4Address: nil,
-					AllocationID:       fi.PtrTo("eipalloc-222ghi789"),
-				},
+		{
+			name: "network without security groups with override",
+			spec: kops.LoadBalancerAccessSpec{
+				Class:                    kops.LoadBalancerClassNetwork,
+				UseSecurityGroups:        fi.PtrTo(false),
+				SecurityGroupOverride:    fi.PtrTo("sg-12345678"),
+				AdditionalSecurityGroups: []string{"sg-87654321"},
+			},
+			expected: []string{
+				"Forbidden::spec.api.loadBalancer.securityGroupOverride",
+				"Forbidden::spec.api.loadBalancer.additionalSecurityGroups",
 			},
-			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].allocationID"},
 		},
-		{ // invalid type external for private IP
-			lbType:         fi.PtrTo(string(kops.LoadBalancerTypePublic)),
-			clusterSubnets: []string{"a", "b", "c"},
-			lbSubnets: []kops.LoadBalancerSubnetSpec{
-				{
-					Name:               "a",
-					PrivateIPv4Address: fi.PtrTo("10.0.0.10"),
-					AllocationID:       nil,
-				},
+		{
+			name: "classic",
+			spec: kops.LoadBalancerAccessSpec{
+				Class:                 kops.LoadBalancerClassClassic,
+				SecurityGroupOverride: fi.PtrTo("sg-12345678"),
 			},
-			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].privateIPv4Address"},
 		},
-		{ // invalid type Internal for public IP
-			lbType:         fi.PtrTo(string(kops.LoadBalancerTypeInternal)),
-			clusterSubnets: []string{"a", "b", "c"},
-			lbSubnets: []kops.LoadBalancerSubnetSpec{
-				{
-					Name:               "a",
-					PrivateIPv4Address: nil,
-					AllocationID:       fi.PtrTo("eipalloc-222ghi789"),
-				},
+		{
+			name: "classic with flag",
+			spec: kops.LoadBalancerAccessSpec{
+				Class:             kops.LoadBalancerClassClassic,
+				UseSecurityGroups: fi.PtrTo(true),
 			},
-			expected: []string{"Forbidden::spec.api.loadBalancer.subnets[0].allocationID"},
+			expected: []string{"Forbidden::spec.api.loadBalancer.useSecurityGroups"},
 		},
 	}
 
 	for _, test := range tests {
-		cluster := kops.Cluster{
-			Spec: kops.ClusterSpec{
-				API: kops.APISpec{
-					LoadBalancer: &kops.LoadBalancerAccessSpec{
-						Class: kops.LoadBalancerClassNetwork,
-						Type:  kops.LoadBalancerTypeInternal,
-					},
-				},
-				CloudProvider: kops.CloudProviderSpec{
-					AWS: &kops.AWSSpec{},
-				},
-			},
-		}
-		if test.class != nil {
-			cluster.Spec.API.LoadBalancer.Class = kops.LoadBalancerClass(*test.class)
-		}
-		if test.lbType != nil {
-			cluster.Spec.API.LoadBalancer.Type = kops.LoadBalancerType(*test.lbType)
-		}
-		for _, s := range test.clusterSubnets {
-			cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets, kops.ClusterSubnetSpec{
-				Name: s,
-				CIDR: cidr,
-			})
-		}
-		cluster.Spec.API.LoadBalancer.Subnets = test.lbSubnets
-		errs := awsValidateCluster(&cluster, true)
-		testErrors(t, test, errs, test.expected)
+		t.Run(test.name, func(t *testing.T) {
+			errs := awsValidateLoadBalancerSecurityGroups(field.NewPath("spec", "api", "loadBalancer"), &test.spec)
+			testErrors(t, test.name, errs, test.expected)
+		})
 	}
 }
 
@@ -681,6 +2435,192 @@ func TestAWSAuthentication(t *testing.T) {
 	}
 }
 
+func TestAWSValidateTopologyDNS(t *testing.T) {
+	tests := []struct {
+		name           string
+		lbClass        kops.LoadBalancerClass
+		ipv6           bool
+		nodeIPFamilies []string
+		expected       []string
+	}{
+		{
+			name:    "ipv4",
+			lbClass: kops.LoadBalancerClassNetwork,
+		},
+		{
+			name:           "ipv6-only",
+			lbClass:        kops.LoadBalancerClassNetwork,
+			ipv6:           true,
+			nodeIPFamilies: []string{"ipv6"},
+		},
+		{
+			name:           "dual-stack",
+			lbClass:        kops.LoadBalancerClassNetwork,
+			ipv6:           true,
+			nodeIPFamilies: []string{"ipv6", "ipv4"},
+		},
+		{
+			name:     "classic load balancer",
+			lbClass:  kops.LoadBalancerClassClassic,
+			ipv6:     true,
+			expected: []string{"Forbidden::spec.api.loadBalancer.type"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := kops.Cluster{
+				Spec: kops.ClusterSpec{
+					API: kops.APISpec{
+						LoadBalancer: &kops.LoadBalancerAccessSpec{
+							Class: test.lbClass,
+							Type:  kops.LoadBalancerTypePublic,
+						},
+					},
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					NodeIPFamilies: test.nodeIPFamilies,
+					Networking: kops.NetworkingSpec{
+						NonMasqueradeCIDR: "100.64.0.0/10",
+						Topology: &kops.TopologySpec{
+							DNS: kops.DNSTypeNone,
+						},
+					},
+				},
+			}
+			if test.ipv6 {
+				cluster.Spec.Networking.NonMasqueradeCIDR = "::/0"
+			}
+			errs := awsValidateCluster(&cluster, true)
+			testErrors(t, test.name, errs, test.expected)
+		})
+	}
+}
+
+func TestAWSValidateIAMProfilePermissions(t *testing.T) {
+	const allowBootstrap = `{
+  "Statement": [
+    {"Effect": "Allow", "Action": ["ec2:Describe*", "ec2:AttachVolume"], "Resource": "*"},
+    {"Effect": "Allow", "Action": "s3:Get*", "Resource": "arn:aws:s3:::state-store/*"}
+  ]
+}`
+	const allowEC2Only = `{
+  "Statement": [
+    {"Effect": "Allow", "Action": "ec2:Describe*", "Resource": "*"}
+  ]
+}`
+	const denyDescribeRegions = `{
+  "Statement": [
+    {"Effect": "Allow", "Action": "*", "Resource": "*"},
+    {"Effect": "Deny", "Action": "ec2:DescribeRegions", "Resource": "*"}
+  ]
+}`
+
+	tests := []struct {
+		name     string
+		role     kops.InstanceGroupRole
+		profile  string
+		policy   string
+		managed  bool
+		expected []string
+	}{
+		{
+			name:    "node with inline policy",
+			role:    kops.InstanceGroupRoleNode,
+			profile: "arn:aws:iam::123456789012:instance-profile/node",
+			policy:  allowBootstrap,
+		},
+		{
+			name:    "control plane with managed policy",
+			role:    kops.InstanceGroupRoleControlPlane,
+			profile: "arn:aws:iam::123456789012:instance-profile/path/node",
+			policy:  allowBootstrap,
+			managed: true,
+		},
+		{
+			name:     "missing state store access",
+			role:     kops.InstanceGroupRoleControlPlane,
+			profile:  "arn:aws:iam::123456789012:instance-profile/node",
+			policy:   allowEC2Only,
+			expected: []string{"Invalid value::spec.iam.profile"},
+		},
+		{
+			name:     "explicit deny",
+			role:     kops.InstanceGroupRoleNode,
+			profile:  "arn:aws:iam::123456789012:instance-profile/node",
+			policy:   denyDescribeRegions,
+			expected: []string{"Invalid value::spec.iam.profile"},
+		},
+		{
+			name:    "bastion needs no permissions",
+			role:    kops.InstanceGroupRoleBastion,
+			profile: "arn:aws:iam::123456789012:instance-profile/node",
+		},
+		{
+			name:     "profile does not exist",
+			role:     kops.InstanceGroupRoleNode,
+			profile:  "arn:aws:iam::123456789012:instance-profile/unknown",
+			policy:   allowBootstrap,
+			expected: []string{"Invalid value::spec.iam.profile"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			role := &iam.Role{
+				RoleName: aws.String("node-role"),
+				Arn:      aws.String("arn:aws:iam::123456789012:role/node-role"),
+			}
+			mockIAM := &mockiam.MockIAM{
+				Roles: map[string]*iam.Role{"node-role": role},
+				InstanceProfiles: map[string]*iam.InstanceProfile{
+					"node": {
+						InstanceProfileName: aws.String("node"),
+						Roles:               []*iam.Role{role},
+					},
+				},
+			}
+			if test.managed {
+				policyARN := "arn:aws:iam::123456789012:policy/bootstrap"
+				mockIAM.AttachedPolicies = map[string][]*iam.AttachedPolicy{
+					"node-role": {{PolicyArn: aws.String(policyARN)}},
+				}
+				mockIAM.ManagedPolicyDocuments = map[string]string{policyARN: test.policy}
+			} else if test.policy != "" {
+				if _, err := mockIAM.PutRolePolicy(&iam.PutRolePolicyInput{
+					RoleName:       role.RoleName,
+					PolicyName:     aws.String("bootstrap"),
+					PolicyDocument: aws.String(test.policy),
+				}); err != nil {
+					t.Fatalf("error creating role policy: %v", err)
+				}
+			}
+
+			cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+			cloud.MockIAM = mockIAM
+
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					ConfigStore: kops.ConfigStoreSpec{
+						Base: "s3://state-store/cluster.example.com",
+					},
+				},
+			}
+			ig := &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					Role: test.role,
+					IAM: &kops.IAMProfileSpec{
+						Profile: fi.PtrTo(test.profile),
+					},
+				},
+			}
+			errs := awsValidateIAMProfilePermissions(field.NewPath("spec", "iam", "profile"), ig, cluster, cloud)
+			testErrors(t, test.name, errs, test.expected)
+		})
+	}
+}
+
 func TestAWSAdditionalRoutes(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -750,12 +2690,70 @@ func TestAWSAdditionalRoutes(t *testing.T) {
 			name:        "valid egress only internet gateway",
 			clusterCIDR: "100.64.0.0/10",
 			subnetType:  kops.SubnetTypePrivate,
+			route: []kops.RouteSpec{
+				{
+					CIDR:   "2600:1f18::/32",
+					Target: "eigw-abcdef",
+				},
+			},
+		},
+		{
+			name:        "valid IPv6 transit gateway",
+			clusterCIDR: "100.64.0.0/10",
+			subnetType:  kops.SubnetTypePrivate,
+			route: []kops.RouteSpec{
+				{
+					CIDR:   "2600:1f18::/32",
+					Target: "tgw-abcdef",
+				},
+			},
+		},
+		{
+			name:        "valid IPv6 pcx",
+			clusterCIDR: "100.64.0.0/10",
+			subnetType:  kops.SubnetTypePrivate,
+			route: []kops.RouteSpec{
+				{
+					CIDR:   "::/0",
+					Target: "pcx-abcdef",
+				},
+			},
+		},
+		{
+			name:        "IPv6 nat",
+			clusterCIDR: "100.64.0.0/10",
+			subnetType:  kops.SubnetTypePrivate,
+			route: []kops.RouteSpec{
+				{
+					CIDR:   "2600:1f18::/32",
+					Target: "nat-abcdef",
+				},
+			},
+			expected: []string{"Invalid value::spec.networking.subnets[0].additionalRoutes[0].cidr"},
+		},
+		{
+			name:        "IPv4 egress only internet gateway",
+			clusterCIDR: "100.64.0.0/10",
+			subnetType:  kops.SubnetTypePrivate,
 			route: []kops.RouteSpec{
 				{
 					CIDR:   "10.0.0.0/8",
 					Target: "eigw-abcdef",
 				},
 			},
+			expected: []string{"Invalid value::spec.networking.subnets[0].additionalRoutes[0].cidr"},
+		},
+		{
+			name:        "bad IPv6 cidr",
+			clusterCIDR: "100.64.0.0/10",
+			subnetType:  kops.SubnetTypePrivate,
+			route: []kops.RouteSpec{
+				{
+					CIDR:   "2600:1f18::1/32",
+					Target: "eigw-abcdef",
+				},
+			},
+			expected: []string{"Invalid value::spec.networking.subnets[0].additionalRoutes[0].cidr"},
 		},
 		{
 			name:        "bad cluster cidr",
@@ -887,3 +2885,123 @@ func TestAWSAdditionalRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSVPCEndpoints(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloudProvider kops.CloudProviderSpec
+		endpoints     []kops.VPCEndpointSpec
+		expected      []string
+	}{
+		{
+			name: "valid gateway",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeGateway},
+			},
+		},
+		{
+			name: "valid interface",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.ecr.api", Type: kops.VPCEndpointTypeInterface, Subnets: []string{"us-east-1a", "us-east-1b"}, PrivateDNS: fi.PtrTo(true)},
+			},
+		},
+		{
+			name: "same service as gateway and interface",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeGateway},
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeInterface, Subnets: []string{"us-east-1a"}},
+			},
+		},
+		{
+			name: "missing service name and type",
+			endpoints: []kops.VPCEndpointSpec{
+				{},
+			},
+			expected: []string{
+				"Required value::spec.networking.vpcEndpoints[0].serviceName",
+				"Required value::spec.networking.vpcEndpoints[0].type",
+			},
+		},
+		{
+			name: "unsupported type",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: "GatewayLoadBalancer"},
+			},
+			expected: []string{"Unsupported value::spec.networking.vpcEndpoints[0].type"},
+		},
+		{
+			name: "duplicate endpoint",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeGateway},
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeGateway},
+			},
+			expected: []string{"Duplicate value::spec.networking.vpcEndpoints[1].serviceName"},
+		},
+		{
+			name: "gateway with subnets and private DNS",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeGateway, Subnets: []string{"us-east-1a"}, PrivateDNS: fi.PtrTo(true)},
+			},
+			expected: []string{
+				"Forbidden::spec.networking.vpcEndpoints[0].subnets",
+				"Forbidden::spec.networking.vpcEndpoints[0].privateDNS",
+			},
+		},
+		{
+			name: "interface without subnets",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.ecr.api", Type: kops.VPCEndpointTypeInterface},
+			},
+			expected: []string{"Required value::spec.networking.vpcEndpoints[0].subnets"},
+		},
+		{
+			name: "interface with undeclared subnet",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.ecr.api", Type: kops.VPCEndpointTypeInterface, Subnets: []string{"us-east-1a", "us-east-1c"}},
+			},
+			expected: []string{"Not found::spec.networking.vpcEndpoints[0].subnets[1]"},
+		},
+		{
+			name: "interface with two subnets in one zone",
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.ecr.api", Type: kops.VPCEndpointTypeInterface, Subnets: []string{"us-east-1a", "utility-us-east-1a"}},
+			},
+			expected: []string{"Invalid value::spec.networking.vpcEndpoints[0].subnets[1]"},
+		},
+		{
+			name: "not AWS",
+			cloudProvider: kops.CloudProviderSpec{
+				GCE: &kops.GCESpec{},
+			},
+			endpoints: []kops.VPCEndpointSpec{
+				{ServiceName: "com.amazonaws.us-east-1.s3", Type: kops.VPCEndpointTypeGateway},
+			},
+			expected: []string{"Forbidden::spec.networking.vpcEndpoints"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cloudProvider := test.cloudProvider
+			if cloudProvider.GCE == nil {
+				cloudProvider.AWS = &kops.AWSSpec{}
+			}
+			cluster := kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: cloudProvider,
+					Networking: kops.NetworkingSpec{
+						Subnets: []kops.ClusterSubnetSpec{
+							{Name: "us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypePrivate},
+							{Name: "us-east-1b", Zone: "us-east-1b", Type: kops.SubnetTypePrivate},
+							{Name: "utility-us-east-1a", Zone: "us-east-1a", Type: kops.SubnetTypeUtility},
+						},
+						VPCEndpoints: test.endpoints,
+					},
+				},
+			}
+			errs := validateNetworking(&cluster, &cluster.Spec.Networking, field.NewPath("spec", "networking"), false, &cloudProviderConstraints{})
+			errs = append(errs, validateCloudProviderCapabilities(clusterCapabilities, &cluster.Spec, cluster.Spec.GetCloudProvider(), field.NewPath("spec"))...)
+			testErrors(t, test, errs, test.expected)
+		})
+	}
+}
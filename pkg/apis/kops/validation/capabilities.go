@@ -0,0 +1,357 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// cloudProviderCapability declares the cloud providers that implement a spec field.
+// Setting the field for any other cloud provider is rejected, instead of being silently ignored.
+type cloudProviderCapability[T any] struct {
+	// path is the path of the field, relative to the spec
+	path []string
+	// isSet returns true if the field is set
+	isSet func(spec *T) bool
+	// isSetAt is used instead of isSet for a field of the elements of the list at path.
+	// It returns the indexes of the elements that set the field.
+	isSetAt func(spec *T) []int
+	// elementField is the name of the field within the elements of the list at path
+	elementField string
+	// clouds are the cloud providers that implement the field
+	clouds []kops.CloudProviderID
+}
+
+var awsOnly = []kops.CloudProviderID{kops.CloudProviderAWS}
+
+var gceOnly = []kops.CloudProviderID{kops.CloudProviderGCE}
+
+// clusterCapabilities lists the cluster spec fields that are only implemented by some cloud providers.
+var clusterCapabilities = []cloudProviderCapability[kops.ClusterSpec]{
+	{
+		path: []string{"api", "loadBalancer", "accessLog"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.AccessLog != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "additionalSecurityGroups"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.AdditionalSecurityGroups != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "class"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.Class != ""
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "crossZoneLoadBalancing"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.CrossZoneLoadBalancing != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "idleTimeoutSeconds"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.IdleTimeoutSeconds != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "securityGroupOverride"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.SecurityGroupOverride != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "sslCertificate"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.SSLCertificate != ""
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"api", "loadBalancer", "sslPolicy"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.API.LoadBalancer != nil && spec.API.LoadBalancer.SSLPolicy != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"api", "publicIPs"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return spec.API.PublicIPs != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"externalPolicies"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return len(spec.ExternalPolicies) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"networking", "natInstanceImage"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return spec.Networking.NATInstanceImage != "" },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"networking", "natInstanceType"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return spec.Networking.NATInstanceType != "" },
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"networking", "subnets"},
+		isSetAt: func(spec *kops.ClusterSpec) []int {
+			var indexes []int
+			for i, subnet := range spec.Networking.Subnets {
+				if subnet.MapPublicIPOnLaunch != nil {
+					indexes = append(indexes, i)
+				}
+			}
+			return indexes
+		},
+		elementField: "mapPublicIPOnLaunch",
+		clouds:       awsOnly,
+	},
+	{
+		path:   []string{"networking", "tagSubnets"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return spec.Networking.TagSubnets != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"networking", "vpcEndpoints"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return len(spec.Networking.VPCEndpoints) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"nodeIPFamilies"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return len(spec.NodeIPFamilies) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"rollingUpdate", "surgeMode"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return isScaleOut(spec.RollingUpdate) },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"snapshots"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return spec.Snapshots != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"tagPolicy"},
+		isSet:  func(spec *kops.ClusterSpec) bool { return spec.TagPolicy != nil },
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"target", "terraform", "assumeRole"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.Target != nil && spec.Target.Terraform != nil && spec.Target.Terraform.AssumeRole != nil
+		},
+		clouds: awsOnly,
+	},
+	{
+		path: []string{"target", "terraform", "defaultTags"},
+		isSet: func(spec *kops.ClusterSpec) bool {
+			return spec.Target != nil && spec.Target.Terraform != nil && len(spec.Target.Terraform.DefaultTags) > 0
+		},
+		clouds: awsOnly,
+	},
+}
+
+// instanceGroupCapabilities lists the instance group spec fields that are only implemented by some cloud providers.
+var instanceGroupCapabilities = []cloudProviderCapability[kops.InstanceGroupSpec]{
+	{
+		path:   []string{"capacityRebalance"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.CapacityRebalance != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"capacityReservationID"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.CapacityReservationID != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"capacityReservationResourceGroupARN"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.CapacityReservationResourceGroupARN != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"cpuCredits"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.CPUCredits != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"detailedInstanceMonitoring"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.DetailedInstanceMonitoring != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"externalLoadBalancers"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return len(spec.ExternalLoadBalancers) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"gce"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.GCE != nil },
+		clouds: gceOnly,
+	},
+	{
+		path:   []string{"gcpProvisioningModel"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.GCPProvisioningModel != nil },
+		clouds: gceOnly,
+	},
+	{
+		path:   []string{"guestAccelerators"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return len(spec.GuestAccelerators) > 0 },
+		clouds: gceOnly,
+	},
+	{
+		path:   []string{"hostResourceGroupARN"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.HostResourceGroupARN != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"instanceInterruptionBehavior"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.InstanceInterruptionBehavior != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"instanceMaintenancePolicy"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.InstanceMaintenancePolicy != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"instanceMetadata"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.InstanceMetadata != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"instanceProtection"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.InstanceProtection != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"maxInstanceLifetime"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.MaxInstanceLifetime != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"maxPrice"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.MaxPrice != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"mixedInstancesPolicy"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.MixedInstancesPolicy != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"networkInterfaces"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.NetworkInterfaces != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"nodePortAccess"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return len(spec.NodePortAccess) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"placement"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.Placement != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"rollingUpdate", "surgeMode"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return isScaleOut(spec.RollingUpdate) },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"scheduledScaling"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return len(spec.ScheduledScaling) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"securityGroupOverride"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.SecurityGroupOverride != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"spotDurationInMinutes"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.SpotDurationInMinutes != nil },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"suspendProcesses"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return len(spec.SuspendProcesses) > 0 },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"tenancy"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.Tenancy != "" },
+		clouds: awsOnly,
+	},
+	{
+		path:   []string{"warmPool"},
+		isSet:  func(spec *kops.InstanceGroupSpec) bool { return spec.WarmPool != nil },
+		clouds: awsOnly,
+	},
+}
+
+// validateCloudProviderCapabilities rejects the fields of the spec that are set but not implemented by the cloud provider.
+func validateCloudProviderCapabilities[T any](capabilities []cloudProviderCapability[T], spec *T, cloudProvider kops.CloudProviderID, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudProvider == "" {
+		// A missing cloud provider is reported by the cloud provider validation
+		return allErrs
+	}
+
+	for _, capability := range capabilities {
+		path := fldPath.Child(capability.path[0], capability.path[1:]...)
+		var setPaths []*field.Path
+		if capability.isSetAt != nil {
+			for _, i := range capability.isSetAt(spec) {
+				setPaths = append(setPaths, path.Index(i).Child(capability.elementField))
+			}
+		} else if capability.isSet(spec) {
+			setPaths = append(setPaths, path)
+		}
+		if len(setPaths) == 0 || slices.Contains(capability.clouds, cloudProvider) {
+			continue
+		}
+		for _, setPath := range setPaths {
+			allErrs = append(allErrs, field.Forbidden(setPath, fmt.Sprintf("%s is not supported for cloud provider %s", setPath, cloudProvider)))
+		}
+	}
+
+	return allErrs
+}
+
+// isScaleOut returns true if the rolling update surges by scaling out the autoscaling group.
+func isScaleOut(rollingUpdate *kops.RollingUpdate) bool {
+	return rollingUpdate != nil && rollingUpdate.SurgeMode == kops.RollingUpdateSurgeModeScaleOut
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestClusterCloudProviderCapabilities(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloudProvider kops.CloudProviderID
+		spec          kops.ClusterSpec
+		expected      []string
+	}{
+		{
+			name:          "publicIPs on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec: kops.ClusterSpec{
+				API: kops.APISpec{PublicIPs: &kops.APIPublicIPsSpec{}},
+			},
+		},
+		{
+			name:          "publicIPs on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec: kops.ClusterSpec{
+				API: kops.APISpec{PublicIPs: &kops.APIPublicIPsSpec{}},
+			},
+			expected: []string{"Forbidden::spec.api.publicIPs"},
+		},
+		{
+			name:          "vpcEndpoints on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{VPCEndpoints: []kops.VPCEndpointSpec{{ServiceName: "com.amazonaws.us-east-1.s3"}}},
+			},
+		},
+		{
+			name:          "vpcEndpoints on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{VPCEndpoints: []kops.VPCEndpointSpec{{ServiceName: "com.amazonaws.us-east-1.s3"}}},
+			},
+			expected: []string{"Forbidden::spec.networking.vpcEndpoints"},
+		},
+		{
+			name:          "NAT instance on azure",
+			cloudProvider: kops.CloudProviderAzure,
+			spec: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{NATInstanceType: "t3.micro", NATInstanceImage: "ami-12345678"},
+			},
+			expected: []string{
+				"Forbidden::spec.networking.natInstanceImage",
+				"Forbidden::spec.networking.natInstanceType",
+			},
+		},
+		{
+			name:          "externalPolicies on openstack",
+			cloudProvider: kops.CloudProviderOpenstack,
+			spec: kops.ClusterSpec{
+				ExternalPolicies: map[string][]string{"node": {"arn:aws:iam::123456789000:policy/test-policy"}},
+			},
+			expected: []string{"Forbidden::spec.externalPolicies"},
+		},
+		{
+			name:          "tagSubnets on digitalocean",
+			cloudProvider: kops.CloudProviderDO,
+			spec: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{TagSubnets: fi.PtrTo(false)},
+			},
+			expected: []string{"Forbidden::spec.networking.tagSubnets"},
+		},
+		{
+			name:          "loadBalancer options on openstack",
+			cloudProvider: kops.CloudProviderOpenstack,
+			spec: kops.ClusterSpec{
+				API: kops.APISpec{LoadBalancer: &kops.LoadBalancerAccessSpec{
+					Class:     kops.LoadBalancerClassNetwork,
+					AccessLog: &kops.AccessLogSpec{},
+				}},
+			},
+			expected: []string{
+				"Forbidden::spec.api.loadBalancer.accessLog",
+				"Forbidden::spec.api.loadBalancer.class",
+			},
+		},
+		{
+			name:          "mapPublicIPOnLaunch on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec: kops.ClusterSpec{
+				Networking: kops.NetworkingSpec{Subnets: []kops.ClusterSubnetSpec{
+					{Name: "a", Type: kops.SubnetTypePrivate},
+					{Name: "utility-a", Type: kops.SubnetTypeUtility, MapPublicIPOnLaunch: fi.PtrTo(true)},
+				}},
+			},
+			expected: []string{"Forbidden::spec.networking.subnets[1].mapPublicIPOnLaunch"},
+		},
+		{
+			name:          "terraform assumeRole and defaultTags on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec: kops.ClusterSpec{
+				Target: &kops.TargetSpec{Terraform: &kops.TerraformSpec{
+					AssumeRole:  &kops.TerraformAssumeRoleSpec{RoleARN: "arn:aws:iam::123456789012:role/kops"},
+					DefaultTags: map[string]string{"team": "platform"},
+				}},
+			},
+			expected: []string{
+				"Forbidden::spec.target.terraform.assumeRole",
+				"Forbidden::spec.target.terraform.defaultTags",
+			},
+		},
+		{
+			name:          "AWS options on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec: kops.ClusterSpec{
+				NodeIPFamilies: []string{kops.IPFamilyIPv4},
+				RollingUpdate:  &kops.RollingUpdate{SurgeMode: kops.RollingUpdateSurgeModeScaleOut},
+				Snapshots:      &kops.SnapshotsSpec{},
+				TagPolicy:      &kops.TagPolicySpec{},
+			},
+			expected: []string{
+				"Forbidden::spec.nodeIPFamilies",
+				"Forbidden::spec.rollingUpdate.surgeMode",
+				"Forbidden::spec.snapshots",
+				"Forbidden::spec.tagPolicy",
+			},
+		},
+		{
+			name:          "AWS options on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec: kops.ClusterSpec{
+				API: kops.APISpec{LoadBalancer: &kops.LoadBalancerAccessSpec{
+					Class:     kops.LoadBalancerClassNetwork,
+					AccessLog: &kops.AccessLogSpec{},
+				}},
+				Networking: kops.NetworkingSpec{Subnets: []kops.ClusterSubnetSpec{
+					{Name: "utility-a", Type: kops.SubnetTypeUtility, MapPublicIPOnLaunch: fi.PtrTo(true)},
+				}},
+				NodeIPFamilies: []string{kops.IPFamilyIPv4},
+				RollingUpdate:  &kops.RollingUpdate{SurgeMode: kops.RollingUpdateSurgeModeScaleOut},
+				Snapshots:      &kops.SnapshotsSpec{},
+				TagPolicy:      &kops.TagPolicySpec{},
+				Target: &kops.TargetSpec{Terraform: &kops.TerraformSpec{
+					DefaultTags: map[string]string{"team": "platform"},
+				}},
+			},
+		},
+		{
+			name: "no cloud provider",
+			spec: kops.ClusterSpec{
+				API: kops.APISpec{PublicIPs: &kops.APIPublicIPsSpec{}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateCloudProviderCapabilities(clusterCapabilities, &test.spec, test.cloudProvider, field.NewPath("spec"))
+			testErrors(t, test, errs, test.expected)
+		})
+	}
+}
+
+func TestInstanceGroupCloudProviderCapabilities(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloudProvider kops.CloudProviderID
+		spec          kops.InstanceGroupSpec
+		expected      []string
+	}{
+		{
+			name:          "warmPool on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec:          kops.InstanceGroupSpec{WarmPool: &kops.WarmPoolSpec{}},
+		},
+		{
+			name:          "warmPool on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec:          kops.InstanceGroupSpec{WarmPool: &kops.WarmPoolSpec{}},
+			expected:      []string{"Forbidden::spec.warmPool"},
+		},
+		{
+			name:          "mixedInstancesPolicy on azure",
+			cloudProvider: kops.CloudProviderAzure,
+			spec:          kops.InstanceGroupSpec{MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{}},
+			expected:      []string{"Forbidden::spec.mixedInstancesPolicy"},
+		},
+		{
+			name:          "instanceMetadata on openstack",
+			cloudProvider: kops.CloudProviderOpenstack,
+			spec:          kops.InstanceGroupSpec{InstanceMetadata: &kops.InstanceMetadataOptions{}},
+			expected:      []string{"Forbidden::spec.instanceMetadata"},
+		},
+		{
+			name:          "maxPrice and spot duration on hetzner",
+			cloudProvider: kops.CloudProviderHetzner,
+			spec:          kops.InstanceGroupSpec{MaxPrice: fi.PtrTo("0.1"), SpotDurationInMinutes: fi.PtrTo(int64(60))},
+			expected: []string{
+				"Forbidden::spec.maxPrice",
+				"Forbidden::spec.spotDurationInMinutes",
+			},
+		},
+		{
+			name:          "suspendProcesses on digitalocean",
+			cloudProvider: kops.CloudProviderDO,
+			spec:          kops.InstanceGroupSpec{SuspendProcesses: []string{"AZRebalance"}},
+			expected:      []string{"Forbidden::spec.suspendProcesses"},
+		},
+		{
+			name:          "tenancy on scaleway",
+			cloudProvider: kops.CloudProviderScaleway,
+			spec:          kops.InstanceGroupSpec{Tenancy: "dedicated"},
+			expected:      []string{"Forbidden::spec.tenancy"},
+		},
+		{
+			name:          "surgeMode ScaleOut on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec:          kops.InstanceGroupSpec{RollingUpdate: &kops.RollingUpdate{SurgeMode: kops.RollingUpdateSurgeModeScaleOut}},
+			expected:      []string{"Forbidden::spec.rollingUpdate.surgeMode"},
+		},
+		{
+			name:          "gce options on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec:          kops.InstanceGroupSpec{GCE: &kops.GCEInstanceGroupSpec{}, GuestAccelerators: []kops.AcceleratorConfig{{AcceleratorCount: 1}}},
+		},
+		{
+			name:          "gce options on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec:          kops.InstanceGroupSpec{GCE: &kops.GCEInstanceGroupSpec{}, GuestAccelerators: []kops.AcceleratorConfig{{AcceleratorCount: 1}}},
+			expected: []string{
+				"Forbidden::spec.gce",
+				"Forbidden::spec.guestAccelerators",
+			},
+		},
+		{
+			name:          "gcpProvisioningModel on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec:          kops.InstanceGroupSpec{GCPProvisioningModel: fi.PtrTo("SPOT")},
+			expected:      []string{"Forbidden::spec.gcpProvisioningModel"},
+		},
+		{
+			name:          "placement and nodePortAccess on gce",
+			cloudProvider: kops.CloudProviderGCE,
+			spec:          kops.InstanceGroupSpec{Placement: &kops.InstanceGroupPlacementSpec{}, NodePortAccess: []string{"10.0.0.0/8"}},
+			expected: []string{
+				"Forbidden::spec.placement",
+				"Forbidden::spec.nodePortAccess",
+			},
+		},
+		{
+			name:          "AWS options on aws",
+			cloudProvider: kops.CloudProviderAWS,
+			spec: kops.InstanceGroupSpec{
+				WarmPool:             &kops.WarmPoolSpec{},
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{},
+				InstanceMetadata:     &kops.InstanceMetadataOptions{},
+				CapacityRebalance:    fi.PtrTo(true),
+				Tenancy:              "dedicated",
+				RollingUpdate:        &kops.RollingUpdate{SurgeMode: kops.RollingUpdateSurgeModeScaleOut},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateCloudProviderCapabilities(instanceGroupCapabilities, &test.spec, test.cloudProvider, field.NewPath("spec"))
+			testErrors(t, test, errs, test.expected)
+		})
+	}
+}
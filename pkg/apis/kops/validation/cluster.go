@@ -20,13 +20,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	kopsbase "k8s.io/kops"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
-func ValidateClusterUpdate(obj *kops.Cluster, status *kops.ClusterStatus, old *kops.Cluster, vfsContext *vfs.VFSContext) field.ErrorList {
+// ValidateClusterUpdate checks that the cluster can be changed from old to obj.
+// If allowKubernetesVersionSkip is true, the Kubernetes version can be upgraded by more than one minor version.
+func ValidateClusterUpdate(obj *kops.Cluster, status *kops.ClusterStatus, old *kops.Cluster, vfsContext *vfs.VFSContext, allowKubernetesVersionSkip bool) field.ErrorList {
 	allErrs := ValidateCluster(obj, false, vfsContext)
 
 	// Validate etcd cluster changes
@@ -55,11 +60,90 @@ func ValidateClusterUpdate(obj *kops.Cluster, status *kops.ClusterStatus, old *k
 		}
 	}
 
+	// Validate kubernetes version changes
+	if obj.Spec.KubernetesVersion != old.Spec.KubernetesVersion {
+		fp := field.NewPath("spec", "kubernetesVersion")
+		allErrs = append(allErrs, validateKubernetesVersionUpdate(fp, obj.Spec.KubernetesVersion, old.Spec.KubernetesVersion, allowKubernetesVersionSkip)...)
+	}
+
 	allErrs = append(allErrs, validateClusterCloudLabels(obj, field.NewPath("spec", "cloudLabels"))...)
 
 	return allErrs
 }
 
+// validateKubernetesVersionUpdate enforces the upstream version skew policy:
+// the control plane can only be upgraded one minor version at a time, and cannot be downgraded to an earlier minor version.
+func validateKubernetesVersionUpdate(fp *field.Path, newVersion string, oldVersion string, allowSkip bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if newVersion == "" || oldVersion == "" {
+		return allErrs
+	}
+	newParsed, err := util.ParseKubernetesVersion(newVersion)
+	if err != nil {
+		// The new version is checked by ValidateCluster
+		return allErrs
+	}
+	oldParsed, err := util.ParseKubernetesVersion(oldVersion)
+	if err != nil {
+		return allErrs
+	}
+
+	if newParsed.Major != oldParsed.Major {
+		allErrs = append(allErrs, field.Forbidden(fp, fmt.Sprintf("cannot change major version from %d to %d", oldParsed.Major, newParsed.Major)))
+		return allErrs
+	}
+
+	if newParsed.Minor < oldParsed.Minor {
+		allErrs = append(allErrs, field.Forbidden(fp, fmt.Sprintf("downgrading from %d.%d to %d.%d is not supported", oldParsed.Major, oldParsed.Minor, newParsed.Major, newParsed.Minor)))
+	} else if newParsed.Minor > oldParsed.Minor+1 && !allowSkip {
+		allErrs = append(allErrs, field.Forbidden(fp, fmt.Sprintf("upgrading from %d.%d to %d.%d skips minor versions; upgrade one minor version at a time (use --allow-kubernetes-version-skip to override)",
+			oldParsed.Major, oldParsed.Minor, newParsed.Major, newParsed.Minor)))
+	}
+
+	return allErrs
+}
+
+// ClusterUpdateWarnings returns the warnings about changing the cluster from old to obj.
+func ClusterUpdateWarnings(obj *kops.Cluster, old *kops.Cluster) []*Warning {
+	var warnings []*Warning
+
+	if obj.Spec.KubernetesVersion != old.Spec.KubernetesVersion {
+		if warning := kubernetesVersionSupportWarning(obj.Spec.KubernetesVersion, kopsbase.KOPS_RELEASE_VERSION); warning != "" {
+			warnings = append(warnings, &Warning{
+				Field:  field.NewPath("spec", "kubernetesVersion"),
+				Code:   WarningCodeUnsupportedKubernetesVersion,
+				Detail: warning,
+			})
+		}
+	}
+
+	return warnings
+}
+
+// kubernetesVersionSupportWarning returns a warning if the kubernetes version is outside the range supported by the given version of kOps.
+func kubernetesVersionSupportWarning(kubernetesVersion string, kopsVersion string) string {
+	parsed, err := util.ParseKubernetesVersion(kubernetesVersion)
+	if err != nil {
+		return ""
+	}
+
+	if !util.IsKubernetesGTE(util.OldestSupportedKubernetesVersion, *parsed) {
+		return fmt.Sprintf("kubernetes version %s is older than the oldest version supported by kOps %s (%s)", kubernetesVersion, kopsVersion, util.OldestSupportedKubernetesVersion)
+	}
+
+	kopsParsed, err := semver.ParseTolerant(kopsVersion)
+	if err != nil {
+		return ""
+	}
+	tooNewVersion := semver.Version{Major: kopsParsed.Major, Minor: kopsParsed.Minor + 1}
+	if util.IsKubernetesGTE(tooNewVersion.String(), *parsed) {
+		return fmt.Sprintf("kubernetes version %s is newer than the newest version supported by kOps %s; upgrading kOps is recommended", kubernetesVersion, kopsVersion)
+	}
+
+	return ""
+}
+
 func validateEtcdClusterUpdate(fp *field.Path, obj kops.EtcdClusterSpec, status *kops.ClusterStatus, old kops.EtcdClusterSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
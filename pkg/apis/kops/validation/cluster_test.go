@@ -19,6 +19,7 @@ package validation
 import (
 	"testing"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/upup/pkg/fi"
 
 	"k8s.io/kops/pkg/apis/kops"
@@ -159,3 +160,138 @@ func TestValidEtcdChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateKubernetesVersionUpdate(t *testing.T) {
+	grid := []struct {
+		Description    string
+		OldVersion     string
+		NewVersion     string
+		AllowSkip      bool
+		ExpectedErrors []string
+	}{
+		{
+			Description: "patch upgrade",
+			OldVersion:  "1.28.1",
+			NewVersion:  "1.28.3",
+		},
+		{
+			Description: "patch downgrade",
+			OldVersion:  "1.28.3",
+			NewVersion:  "1.28.1",
+		},
+		{
+			Description: "minor upgrade",
+			OldVersion:  "1.27.8",
+			NewVersion:  "1.28.0",
+		},
+		{
+			Description:    "minor version skip",
+			OldVersion:     "1.26.5",
+			NewVersion:     "1.29.0",
+			ExpectedErrors: []string{"Forbidden::spec.kubernetesVersion"},
+		},
+		{
+			Description: "minor version skip with override",
+			OldVersion:  "1.26.5",
+			NewVersion:  "1.29.0",
+			AllowSkip:   true,
+		},
+		{
+			Description:    "minor downgrade",
+			OldVersion:     "1.28.0",
+			NewVersion:     "1.27.8",
+			ExpectedErrors: []string{"Forbidden::spec.kubernetesVersion"},
+		},
+		{
+			Description:    "minor downgrade with override",
+			OldVersion:     "1.28.0",
+			NewVersion:     "1.27.8",
+			AllowSkip:      true,
+			ExpectedErrors: []string{"Forbidden::spec.kubernetesVersion"},
+		},
+		{
+			Description:    "major change",
+			OldVersion:     "1.28.0",
+			NewVersion:     "2.0.0",
+			ExpectedErrors: []string{"Forbidden::spec.kubernetesVersion"},
+		},
+		{
+			Description: "version from url",
+			OldVersion:  "https://example.com/kubernetes/release/v1.27.2/",
+			NewVersion:  "1.28.0",
+		},
+		{
+			Description: "no previous version",
+			NewVersion:  "1.28.0",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			errs := validateKubernetesVersionUpdate(field.NewPath("spec", "kubernetesVersion"), g.NewVersion, g.OldVersion, g.AllowSkip)
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func TestKubernetesVersionSupportWarning(t *testing.T) {
+	grid := []struct {
+		KubernetesVersion string
+		KopsVersion       string
+		ExpectWarning     bool
+	}{
+		{
+			KubernetesVersion: "1.28.3",
+			KopsVersion:       "1.29.0-alpha.3",
+		},
+		{
+			KubernetesVersion: "1.29.0",
+			KopsVersion:       "1.29.0-alpha.3",
+		},
+		{
+			KubernetesVersion: "1.30.0",
+			KopsVersion:       "1.29.0-alpha.3",
+			ExpectWarning:     true,
+		},
+		{
+			KubernetesVersion: "1.30.0-alpha.1",
+			KopsVersion:       "1.29.0",
+			ExpectWarning:     true,
+		},
+		{
+			KubernetesVersion: "1.23.17",
+			KopsVersion:       "1.29.0",
+			ExpectWarning:     true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.KubernetesVersion+"/"+g.KopsVersion, func(t *testing.T) {
+			warning := kubernetesVersionSupportWarning(g.KubernetesVersion, g.KopsVersion)
+			if g.ExpectWarning && warning == "" {
+				t.Errorf("expected a warning")
+			}
+			if !g.ExpectWarning && warning != "" {
+				t.Errorf("unexpected warning: %s", warning)
+			}
+		})
+	}
+}
+
+func TestClusterUpdateWarnings(t *testing.T) {
+	old := &kops.Cluster{Spec: kops.ClusterSpec{KubernetesVersion: "1.23.16"}}
+
+	unchanged := &kops.Cluster{Spec: kops.ClusterSpec{KubernetesVersion: "1.23.16"}}
+	if warnings := ClusterUpdateWarnings(unchanged, old); len(warnings) != 0 {
+		t.Errorf("unexpected warnings for an unchanged version: %v", warnings)
+	}
+
+	changed := &kops.Cluster{Spec: kops.ClusterSpec{KubernetesVersion: "1.23.17"}}
+	warnings := ClusterUpdateWarnings(changed, old)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	if warnings[0].Code != WarningCodeUnsupportedKubernetesVersion || warnings[0].Field.String() != "spec.kubernetesVersion" {
+		t.Errorf("unexpected warning: %s (%s)", warnings[0], warnings[0].Code)
+	}
+}
@@ -17,11 +17,50 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 )
 
+// gceLocalSSDCounts are the numbers of local SSDs that can be attached to the instances of a machine family.
+// Families without an entry are not restricted, except for those in gceNoLocalSSDFamilies.
+var gceLocalSSDCounts = map[string][]int32{
+	"n1":  {1, 2, 3, 4, 5, 6, 7, 8, 16, 24},
+	"n2":  {1, 2, 4, 8, 16, 24},
+	"n2d": {1, 2, 4, 8, 16, 24},
+	"c2":  {1, 2, 4, 8},
+	"c2d": {1, 2, 4, 8},
+	"a2":  {1, 2, 4, 8},
+}
+
+// gceNoLocalSSDFamilies are the machine families that local SSDs cannot be attached to.
+// The -lssd machine types of the third generation families come with a fixed number of local SSDs instead.
+var gceNoLocalSSDFamilies = []string{"e2", "t2a", "t2d", "c3", "c3d", "c4", "n4"}
+
+// gceHyperdiskFamilies are the machine families that support hyperdisk-balanced boot disks.
+var gceHyperdiskFamilies = []string{"a3", "c3", "c3d", "c4", "h3", "m3", "n4", "z3"}
+
+// gceHyperdiskOnlyFamilies are the machine families that only support hyperdisk boot disks.
+var gceHyperdiskOnlyFamilies = []string{"c4", "n4"}
+
+var gceBootDiskTypes = []string{"pd-standard", "pd-balanced", "pd-ssd", "pd-extreme", "hyperdisk-balanced"}
+
+// gceMachineFamily returns the machine family of a GCE machine type, such as n2 for n2-standard-4.
+func gceMachineFamily(machineType string) string {
+	family := strings.SplitN(machineType, "-", 2)[0]
+	if family == "custom" {
+		// Custom machine types without a family prefix are N1 machine types
+		return "n1"
+	}
+	return family
+}
+
 func gceValidateCluster(c *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -46,3 +85,52 @@ func gceValidateInstanceGroup(ig *kops.InstanceGroup, cloud gce.GCECloud) field.
 	}
 	return allErrs
 }
+
+func gceValidateInstanceGroupSpec(fieldPath *field.Path, ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	spec := ig.Spec.GCE
+	family := gceMachineFamily(ig.Spec.MachineType)
+
+	if spec.LocalSSDCount != nil {
+		count := *spec.LocalSSDCount
+		if count < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("localSSDCount"), count, "must not be negative"))
+		} else if count > 0 && ig.Spec.MachineType != "" {
+			if slices.Contains(gceNoLocalSSDFamilies, family) {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("localSSDCount"), fmt.Sprintf("local SSDs cannot be attached to machine type %q", ig.Spec.MachineType)))
+			} else if counts, found := gceLocalSSDCounts[family]; found && !slices.Contains(counts, count) {
+				var supported []string
+				for _, c := range counts {
+					supported = append(supported, strconv.Itoa(int(c)))
+				}
+				allErrs = append(allErrs, field.NotSupported(fieldPath.Child("localSSDCount"), count, supported))
+			}
+		}
+	}
+
+	if spec.LocalSSDInterface != "" {
+		if fi.ValueOf(spec.LocalSSDCount) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("localSSDInterface"), "localSSDInterface requires localSSDCount"))
+		}
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("localSSDInterface"), &spec.LocalSSDInterface, []string{"NVME", "SCSI"})...)
+	}
+
+	if spec.BootDiskType != "" {
+		if ig.Spec.RootVolume != nil && ig.Spec.RootVolume.Type != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("bootDiskType"), "bootDiskType cannot be combined with rootVolume.type"))
+		}
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("bootDiskType"), &spec.BootDiskType, gceBootDiskTypes)...)
+
+		if ig.Spec.MachineType != "" {
+			isHyperdisk := strings.HasPrefix(spec.BootDiskType, "hyperdisk-")
+			if isHyperdisk && !slices.Contains(gceHyperdiskFamilies, family) {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("bootDiskType"), fmt.Sprintf("machine type %q does not support %s boot disks", ig.Spec.MachineType, spec.BootDiskType)))
+			}
+			if !isHyperdisk && slices.Contains(gceHyperdiskOnlyFamilies, family) {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("bootDiskType"), fmt.Sprintf("machine type %q only supports hyperdisk boot disks", ig.Spec.MachineType)))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestGCEValidateInstanceGroupSpec(t *testing.T) {
+	grid := []struct {
+		name        string
+		machineType string
+		rootVolume  *kops.InstanceRootVolumeSpec
+		gce         *kops.GCEInstanceGroupSpec
+		expected    []string
+	}{
+		{
+			name:        "local ssds",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(2)), LocalSSDInterface: "NVME"},
+		},
+		{
+			name:        "local ssds on custom machine type",
+			machineType: "custom-4-8192",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(3)), LocalSSDInterface: "SCSI"},
+		},
+		{
+			name:        "unsupported local ssd count",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(3))},
+			expected:    []string{"Unsupported value::spec.gce.localSSDCount"},
+		},
+		{
+			name:        "negative local ssd count",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(-1))},
+			expected:    []string{"Invalid value::spec.gce.localSSDCount"},
+		},
+		{
+			name:        "local ssds on family without local ssds",
+			machineType: "e2-standard-4",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(1))},
+			expected:    []string{"Forbidden::spec.gce.localSSDCount"},
+		},
+		{
+			name:        "invalid local ssd interface",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(1)), LocalSSDInterface: "IDE"},
+			expected:    []string{"Unsupported value::spec.gce.localSSDInterface"},
+		},
+		{
+			name:        "local ssd interface without local ssds",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{LocalSSDInterface: "NVME"},
+			expected:    []string{"Forbidden::spec.gce.localSSDInterface"},
+		},
+		{
+			name:        "pd-balanced boot disk",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{BootDiskType: "pd-balanced"},
+		},
+		{
+			name:        "hyperdisk boot disk",
+			machineType: "c3-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{BootDiskType: "hyperdisk-balanced"},
+		},
+		{
+			name:        "hyperdisk boot disk on unsupported family",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{BootDiskType: "hyperdisk-balanced"},
+			expected:    []string{"Forbidden::spec.gce.bootDiskType"},
+		},
+		{
+			name:        "persistent disk boot disk on hyperdisk only family",
+			machineType: "n4-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{BootDiskType: "pd-balanced"},
+			expected:    []string{"Forbidden::spec.gce.bootDiskType"},
+		},
+		{
+			name:        "invalid boot disk type",
+			machineType: "n2-standard-8",
+			gce:         &kops.GCEInstanceGroupSpec{BootDiskType: "local-ssd"},
+			expected:    []string{"Unsupported value::spec.gce.bootDiskType"},
+		},
+		{
+			name:        "boot disk type with root volume type",
+			machineType: "n2-standard-8",
+			rootVolume:  &kops.InstanceRootVolumeSpec{Type: fi.PtrTo("pd-ssd")},
+			gce:         &kops.GCEInstanceGroupSpec{BootDiskType: "pd-balanced"},
+			expected:    []string{"Forbidden::spec.gce.bootDiskType"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			ig.Spec.MachineType = g.machineType
+			ig.Spec.RootVolume = g.rootVolume
+			ig.Spec.GCE = g.gce
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestCrossValidateGCEInstanceGroupSpec(t *testing.T) {
+	ig := createMinimalInstanceGroup()
+	ig.Spec.MachineType = "n2-standard-8"
+	ig.Spec.GCE = &kops.GCEInstanceGroupSpec{LocalSSDCount: fi.PtrTo(int32(1))}
+
+	for _, cloudProvider := range []kops.CloudProviderSpec{
+		{GCE: &kops.GCESpec{}},
+		{AWS: &kops.AWSSpec{}},
+	} {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				CloudProvider: cloudProvider,
+			},
+		}
+		var expected []string
+		if cloudProvider.GCE == nil {
+			expected = []string{"Forbidden::spec.gce"}
+		}
+		errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+		testErrors(t, string(cluster.Spec.GetCloudProvider()), errs, expected)
+	}
+}
@@ -18,13 +18,19 @@ package validation
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"k8s.io/kops/pkg/nodeidentity/aws"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"k8s.io/kops/pkg/apis/kops"
@@ -46,7 +52,7 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	case "":
 		allErrs = append(allErrs, field.Required(field.NewPath("spec", "role"), "Role must be set"))
 	case kops.InstanceGroupRoleControlPlane:
-		if len(g.Spec.Subnets) == 0 {
+		if len(g.Spec.Subnets) == 0 && len(g.Spec.SubnetSelectors) == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec", "subnets"), "controlPlane InstanceGroup must specify at least one Subnet"))
 		}
 		if fi.ValueOf(g.Spec.MinSize) > 1 {
@@ -70,6 +76,10 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "tenancy"), &g.Spec.Tenancy, ec2.Tenancy_Values())...)
 	}
 
+	if len(g.Spec.SubnetSelectors) != 0 {
+		allErrs = append(allErrs, validateSubnetSelectors(g, field.NewPath("spec", "subnetSelectors"))...)
+	}
+
 	if strict && g.Spec.Manager == kops.InstanceManagerCloudGroup {
 		if g.Spec.MaxSize == nil {
 			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "maxSize"), "maxSize must be set"))
@@ -109,8 +119,8 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, validateFileAssetSpec(&g.Spec.FileAssets[i], field.NewPath("spec", "fileAssets").Index(i))...)
 	}
 
-	for _, UserDataInfo := range g.Spec.AdditionalUserData {
-		allErrs = append(allErrs, validateExtraUserData(&UserDataInfo)...)
+	for i := range g.Spec.AdditionalUserData {
+		allErrs = append(allErrs, validateExtraUserData(&g.Spec.AdditionalUserData[i], field.NewPath("spec", "additionalUserData").Index(i))...)
 	}
 
 	// @step: iterate and check the volume specs
@@ -154,6 +164,10 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, validateRollingUpdate(g.Spec.RollingUpdate, field.NewPath("spec", "rollingUpdate"), g.Spec.Role == kops.InstanceGroupRoleControlPlane)...)
 	}
 
+	if len(g.Spec.ScheduledScaling) > 0 {
+		allErrs = append(allErrs, validateScheduledScaling(g.Spec.ScheduledScaling, field.NewPath("spec", "scheduledScaling"))...)
+	}
+
 	if g.Spec.NodeLabels != nil {
 		allErrs = append(allErrs, validateNodeLabels(g.Spec.NodeLabels, field.NewPath("spec", "nodeLabels"))...)
 	}
@@ -162,6 +176,26 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, validateIGCloudLabels(g, field.NewPath("spec", "cloudLabels"))...)
 	}
 
+	if g.Spec.GPUConfig != nil {
+		allErrs = append(allErrs, validateGPUConfig(g.Spec.GPUConfig, field.NewPath("spec", "gpuConfig"))...)
+	}
+
+	if g.Spec.Swap != nil {
+		allErrs = append(allErrs, validateSwap(g, field.NewPath("spec", "swap"))...)
+	}
+
+	if g.Spec.Kubelet != nil {
+		allErrs = append(allErrs, validateKubeletContainerLogs(g.Spec.Kubelet, field.NewPath("spec", "kubelet"))...)
+	}
+
+	if g.Spec.JournaldMaxUse != nil && g.Spec.JournaldMaxUse.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "journaldMaxUse"), g.Spec.JournaldMaxUse.String(), "must be positive"))
+	}
+
+	if g.Spec.GCE != nil {
+		allErrs = append(allErrs, gceValidateInstanceGroupSpec(field.NewPath("spec", "gce"), g)...)
+	}
+
 	if cloud != nil {
 		switch cloud.ProviderID() {
 		case kops.CloudProviderAWS:
@@ -182,10 +216,7 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	taintKeys := sets.NewString()
 	for i, taint := range g.Spec.Taints {
 		path := field.NewPath("spec", "taints").Index(i)
-		_, err := util.ParseTaint(taint)
-		if err != nil {
-			allErrs = append(allErrs, field.Invalid(path, taint, "invalid taint value"))
-		}
+		allErrs = append(allErrs, validateTaint(taint, path)...)
 		if taintKeys.Has(taint) {
 			allErrs = append(allErrs, field.Duplicate(path, taint))
 		} else {
@@ -196,7 +227,71 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	return allErrs
 }
 
+// validateScheduledScaling checks the schedules of an instance group
+func validateScheduledScaling(schedules []kops.ScheduledScalingSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := make(map[string]bool)
+	for i, schedule := range schedules {
+		path := fldPath.Index(i)
+
+		if schedule.Cron == "" {
+			allErrs = append(allErrs, field.Required(path.Child("cron"), "a schedule must be specified"))
+		} else if strings.HasPrefix(schedule.Cron, "@") {
+			// AWS Auto Scaling does not support the predefined schedules
+			allErrs = append(allErrs, field.Invalid(path.Child("cron"), schedule.Cron, "must be a five field cron schedule"))
+		} else if err := validateCronSchedule(schedule.Cron); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("cron"), schedule.Cron, err.Error()))
+		}
+
+		if schedule.TimeZone != "" {
+			if _, err := time.LoadLocation(schedule.TimeZone); err != nil || schedule.TimeZone == "Local" {
+				allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), schedule.TimeZone, "must be an IANA time zone name"))
+			}
+		}
+
+		if schedule.MinSize == nil && schedule.MaxSize == nil {
+			allErrs = append(allErrs, field.Required(path, "at least one of minSize and maxSize must be set"))
+		}
+		if schedule.MinSize != nil && *schedule.MinSize < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("minSize"), *schedule.MinSize, "minSize cannot be negative"))
+		}
+		if schedule.MaxSize != nil && *schedule.MaxSize < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxSize"), *schedule.MaxSize, "maxSize cannot be negative"))
+		}
+		if schedule.MinSize != nil && schedule.MaxSize != nil && *schedule.MaxSize < *schedule.MinSize {
+			allErrs = append(allErrs, field.Forbidden(path.Child("maxSize"), "maxSize must be greater than or equal to minSize"))
+		}
+
+		// Two schedules that fire at the same time leave the size of the instance group to chance
+		key := strings.Join(strings.Fields(schedule.Cron), " ") + " " + schedule.TimeZone
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("cron"), schedule.Cron))
+		}
+		seen[key] = true
+	}
+
+	return allErrs
+}
+
 // validateVolumeSpec is responsible for checking a volume spec is ok
+func validateSubnetSelectors(g *kops.InstanceGroup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(g.Spec.Subnets) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "subnetSelectors cannot be used together with subnets"))
+	}
+
+	for i := range g.Spec.SubnetSelectors {
+		selector := &g.Spec.SubnetSelectors[i]
+		if selector.Type != "" {
+			allErrs = append(allErrs, IsValidValue(fldPath.Index(i).Child("type"), &selector.Type, []kops.SubnetType{kops.SubnetTypePrivate, kops.SubnetTypePublic, kops.SubnetTypeDualStack})...)
+		}
+	}
+
+	return allErrs
+}
+
 func validateVolumeSpec(path *field.Path, v kops.VolumeSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -230,9 +325,29 @@ func validateVolumeMountSpec(path *field.Path, spec kops.VolumeMountSpec) field.
 
 // CrossValidateInstanceGroup performs validation of the instance group, including that it is consistent with the Cluster
 // It calls ValidateInstanceGroup, so all that validation is included.
+// It only returns the errors; in strict mode the warnings are logged.
+// Use CrossValidateInstanceGroupWithWarnings to report the warnings separately.
 func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cloud fi.Cloud, strict bool) field.ErrorList {
+	result := CrossValidateInstanceGroupWithWarnings(g, cluster, cloud, strict)
+	if strict {
+		logWarnings(result.Warnings)
+	}
+	return result.Errors
+}
+
+// CrossValidateInstanceGroupWithWarnings is like CrossValidateInstanceGroup, but returns both the errors and the warnings.
+func CrossValidateInstanceGroupWithWarnings(g *kops.InstanceGroup, cluster *kops.Cluster, cloud fi.Cloud, strict bool) *ValidationResult {
+	return &ValidationResult{
+		Errors:   crossValidateInstanceGroup(g, cluster, cloud, strict),
+		Warnings: instanceGroupWarnings(g, cluster),
+	}
+}
+
+func crossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cloud fi.Cloud, strict bool) field.ErrorList {
 	allErrs := ValidateInstanceGroup(g, cloud, strict)
 
+	allErrs = append(allErrs, validateCloudProviderCapabilities(instanceGroupCapabilities, &g.Spec, cluster.Spec.GetCloudProvider(), field.NewPath("spec"))...)
+
 	if g.Spec.Role == kops.InstanceGroupRoleControlPlane {
 		allErrs = append(allErrs, ValidateControlPlaneInstanceGroup(g, cluster)...)
 	}
@@ -261,33 +376,90 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		}
 	}
 
-	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
-		if g.Spec.RootVolume != nil && g.Spec.RootVolume.Type != nil {
-			allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "rootVolume", "type"), g.Spec.RootVolume.Type, []string{"standard", "gp3", "gp2", "io1", "io2"})...)
-		}
+	if len(g.Spec.Subnets) == 0 && len(g.Spec.SubnetSelectors) != 0 && len(g.ResolvedSubnets(cluster)) == 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "subnetSelectors"), g.Spec.SubnetSelectors, "subnetSelectors do not match any subnet of the cluster"))
+	}
 
-		warmPool := cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(g)
-		if warmPool.MaxSize == nil || *warmPool.MaxSize != 0 {
-			if g.Spec.Role != kops.InstanceGroupRoleNode && g.Spec.Role != kops.InstanceGroupRoleAPIServer {
-				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pool only allowed on instance groups with role Node or APIServer"))
+	if g.Spec.Placement != nil && cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "placement"), "placement cannot be used with instance groups managed by Karpenter"))
+		} else if g.Spec.Placement.Strategy == kops.InstanceGroupPlacementStrategyCluster {
+			// A cluster placement group lives in a single availability zone
+			zones := sets.NewString()
+			for _, subnetName := range g.ResolvedSubnets(cluster) {
+				for _, subnet := range cluster.Spec.Networking.Subnets {
+					if subnet.Name == subnetName && subnet.Zone != "" {
+						zones.Insert(subnet.Zone)
+					}
+				}
 			}
-			if g.Spec.MixedInstancesPolicy != nil {
-				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pool cannot be combined with a mixed instances policy"))
-			}
-			if g.Spec.MaxPrice != nil {
-				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pool cannot be used with spot instances"))
+			zones.Insert(g.Spec.Zones...)
+			if zones.Len() > 1 {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "placement", "strategy"), fmt.Sprintf("cluster placement cannot be used with an instance group spanning multiple zones (%s)", strings.Join(zones.List(), ", "))))
 			}
 		}
-		if warmPool.MaxSize != nil {
-			if *warmPool.MaxSize < 0 {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "warmPool", "maxSize"), *warmPool.MaxSize, "warm pool maxSize cannot be negative"))
-			} else if warmPool.MinSize > *warmPool.MaxSize {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "warmPool", "maxSize"), *warmPool.MaxSize, "warm pool maxSize cannot be set to lower than minSize"))
+	}
+
+	if g.Spec.RollingUpdate != nil && g.Spec.Role != kops.InstanceGroupRoleControlPlane {
+		allErrs = append(allErrs, crossValidateRollingUpdate(cluster, g, field.NewPath("spec", "rollingUpdate"))...)
+	}
+
+	if len(g.Spec.ScheduledScaling) > 0 {
+		fldPath := field.NewPath("spec", "scheduledScaling")
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "scheduled scaling cannot be used with instance groups managed by Karpenter"))
+		}
+		if g.Spec.Role == kops.InstanceGroupRoleControlPlane {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "scheduled scaling cannot be used with control plane instance groups"))
+		}
+	}
+
+	if len(g.Spec.NodePortAccess) > 0 {
+		fldPath := field.NewPath("spec", "nodePortAccess")
+		if g.Spec.Role != kops.InstanceGroupRoleNode {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "node port access is only supported on instance groups with role Node"))
+		}
+		for i, cidr := range g.Spec.NodePortAccess {
+			if strings.HasPrefix(cidr, "pl-") {
+				if !prefixListIDRegex.MatchString(cidr) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr, "must be a prefix list ID such as pl-0123456789abcdef0"))
+				}
+			} else {
+				allErrs = append(allErrs, validateCIDR(fldPath.Index(i), cidr)...)
 			}
 		}
-		if warmPool.MinSize < 0 {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "warmPool", "minSize"), warmPool.MinSize, "warm pool minSize cannot be negative"))
+		if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+			allErrs = append(allErrs, awsValidateAccessList(fldPath, g.Spec.NodePortAccess, awsClusterHasIPv6(cluster))...)
+		}
+	}
+
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		var awsCloud awsup.AWSCloud
+		if cloud != nil {
+			awsCloud = cloud.(awsup.AWSCloud)
 		}
+		allErrs = append(allErrs, awsValidateLocalZones(g, cluster, awsCloud)...)
+	}
+
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		if g.Spec.RootVolume != nil && g.Spec.RootVolume.Type != nil {
+			allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "rootVolume", "type"), g.Spec.RootVolume.Type, []string{"standard", "gp3", "gp2", "io1", "io2"})...)
+		}
+
+		if strict && cloud != nil && g.Spec.IAM != nil && g.Spec.IAM.Profile != nil {
+			allErrs = append(allErrs, awsValidateIAMProfilePermissions(field.NewPath("spec", "iam", "profile"), g, cluster, cloud.(awsup.AWSCloud))...)
+		}
+
+		allErrs = append(allErrs, awsValidateInstanceGroupWarmPool(field.NewPath("spec", "warmPool"), g, cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(g))...)
+		allErrs = append(allErrs, awsValidateInstanceGroupInstanceMetadata(field.NewPath("spec", "instanceMetadata"), g, cluster.Spec.CloudProvider.AWS.InstanceMetadata)...)
+	}
+
+	if g.Spec.KubernetesVersion != "" {
+		allErrs = append(allErrs, validateInstanceGroupKubernetesVersion(g, cluster, field.NewPath("spec", "kubernetesVersion"))...)
+	}
+
+	if g.Spec.Swap != nil {
+		allErrs = append(allErrs, crossValidateSwap(g, cluster, field.NewPath("spec", "swap"))...)
 	}
 
 	if g.Spec.Containerd != nil {
@@ -297,6 +469,47 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 	return allErrs
 }
 
+// maxNodeVersionSkew is the number of minor versions the kubelet may be older than the control plane.
+const maxNodeVersionSkew = 3
+
+// validateInstanceGroupKubernetesVersion enforces the upstream version skew policy for the
+// node binaries of an instance group: they must not be newer than the control plane
+// and at most maxNodeVersionSkew minor versions older.
+func validateInstanceGroupKubernetesVersion(g *kops.InstanceGroup, cluster *kops.Cluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if g.HasAPIServer() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "kubernetesVersion cannot be overridden for instance groups running the apiserver"))
+		return allErrs
+	}
+
+	nodeVersion, err := util.ParseKubernetesVersion(g.Spec.KubernetesVersion)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, g.Spec.KubernetesVersion, "unable to determine kubernetes version"))
+		return allErrs
+	}
+	controlPlaneVersion, err := util.ParseKubernetesVersion(cluster.Spec.KubernetesVersion)
+	if err != nil {
+		// The cluster version is validated with the cluster
+		return allErrs
+	}
+
+	if nodeVersion.Major != controlPlaneVersion.Major {
+		allErrs = append(allErrs, field.Invalid(fldPath, g.Spec.KubernetesVersion, fmt.Sprintf("major version must match the cluster kubernetesVersion %s", cluster.Spec.KubernetesVersion)))
+		return allErrs
+	}
+	// Only compare the release versions, ignoring pre-releases
+	nodeVersion.Pre, nodeVersion.Build = nil, nil
+	controlPlaneVersion.Pre, controlPlaneVersion.Build = nil, nil
+	if nodeVersion.GT(*controlPlaneVersion) {
+		allErrs = append(allErrs, field.Invalid(fldPath, g.Spec.KubernetesVersion, fmt.Sprintf("must not be newer than the cluster kubernetesVersion %s", cluster.Spec.KubernetesVersion)))
+	} else if controlPlaneVersion.Minor > nodeVersion.Minor+maxNodeVersionSkew {
+		allErrs = append(allErrs, field.Invalid(fldPath, g.Spec.KubernetesVersion, fmt.Sprintf("must be at most %d minor versions older than the cluster kubernetesVersion %s", maxNodeVersionSkew, cluster.Spec.KubernetesVersion)))
+	}
+
+	return allErrs
+}
+
 func ValidateControlPlaneInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for _, etcd := range cluster.Spec.EtcdClusters {
@@ -325,9 +538,8 @@ var validUserDataTypes = []string{
 	"text/cloud-boothook",
 }
 
-func validateExtraUserData(userData *kops.UserData) field.ErrorList {
+func validateExtraUserData(userData *kops.UserData, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	fieldPath := field.NewPath("additionalUserData")
 
 	if userData.Name == "" {
 		allErrs = append(allErrs, field.Required(fieldPath.Child("name"), "field must be set"))
@@ -339,6 +551,10 @@ func validateExtraUserData(userData *kops.UserData) field.ErrorList {
 
 	allErrs = append(allErrs, IsValidValue(fieldPath.Child("type"), &userData.Type, validUserDataTypes)...)
 
+	if !userData.AllowSecrets {
+		allErrs = append(allErrs, validateNoSecrets(userData.Content, fieldPath.Child("content"))...)
+	}
+
 	return allErrs
 }
 
@@ -357,12 +573,103 @@ func validateInstanceProfile(v *kops.IAMProfileSpec, fldPath *field.Path) field.
 	return allErrs
 }
 
+// kubeletNodeLabels are the well-known node labels that the kubelet and the cloud provider set themselves.
+var kubeletNodeLabels = sets.NewString(
+	corev1.LabelHostname,
+	corev1.LabelTopologyZone,
+	corev1.LabelTopologyRegion,
+	corev1.LabelFailureDomainBetaZone,
+	corev1.LabelFailureDomainBetaRegion,
+	corev1.LabelInstanceType,
+	corev1.LabelInstanceTypeStable,
+	corev1.LabelOSStable,
+	corev1.LabelArchStable,
+	"beta.kubernetes.io/os",
+	"beta.kubernetes.io/arch",
+)
+
+// allowedNodeLabelNamespaces are the namespaces under kubernetes.io and k8s.io that node labels can use:
+// the ones the kubelet may set on its own node, and the ones kops-controller sets for it.
+var allowedNodeLabelNamespaces = []string{
+	corev1.LabelNamespaceSuffixKubelet,
+	corev1.LabelNamespaceSuffixNode,
+	corev1.LabelNamespaceNodeRestriction,
+	"node-role.kubernetes.io",
+	"kops.k8s.io",
+}
+
 func validateNodeLabels(labels map[string]string, fldPath *field.Path) (allErrs field.ErrorList) {
+	var keys []string
 	for key := range labels {
-		if strings.Count(key, "/") > 1 {
-			allErrs = append(allErrs, field.Invalid(fldPath, key, "Node label may only contain a single slash"))
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := fldPath.Key(key)
+		for _, msg := range utilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(path, key, msg))
+		}
+		for _, msg := range utilvalidation.IsValidLabelValue(labels[key]) {
+			allErrs = append(allErrs, field.Invalid(path, labels[key], msg))
 		}
+
+		if kubeletNodeLabels.Has(key) {
+			allErrs = append(allErrs, field.Forbidden(path, "label is set by the kubelet or the cloud provider"))
+		} else if isRestrictedNodeLabel(key) {
+			allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("labels in the kubernetes.io and k8s.io namespaces are restricted, except under %s", strings.Join(allowedNodeLabelNamespaces, ", "))))
+		}
+	}
+	return allErrs
+}
+
+// isRestrictedNodeLabel returns true if the label is in the kubernetes.io or k8s.io namespaces,
+// but not in one of the namespaces that node labels can use.
+func isRestrictedNodeLabel(key string) bool {
+	namespace, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	if !isLabelNamespace(namespace, "kubernetes.io") && !isLabelNamespace(namespace, "k8s.io") {
+		return false
+	}
+	for _, allowed := range allowedNodeLabelNamespaces {
+		if isLabelNamespace(namespace, allowed) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLabelNamespace returns true if namespace is parent or one of its subdomains.
+func isLabelNamespace(namespace string, parent string) bool {
+	return namespace == parent || strings.HasSuffix(namespace, "."+parent)
+}
+
+// validTaintEffects are the effects that a node taint can have.
+var validTaintEffects = []string{
+	string(corev1.TaintEffectNoSchedule),
+	string(corev1.TaintEffectPreferNoSchedule),
+	string(corev1.TaintEffectNoExecute),
+}
+
+// validateTaint checks that a taint is of the form key[=value]:effect, like kubectl taint expects.
+func validateTaint(taint string, fldPath *field.Path) (allErrs field.ErrorList) {
+	parsed, err := util.ParseTaint(taint)
+	if err != nil || parsed["effect"] == "" {
+		return append(allErrs, field.Invalid(fldPath, taint, "taint must be of the form key[=value]:effect"))
+	}
+
+	for _, msg := range utilvalidation.IsQualifiedName(parsed["key"]) {
+		allErrs = append(allErrs, field.Invalid(fldPath, taint, fmt.Sprintf("invalid key %q: %s", parsed["key"], msg)))
 	}
+	for _, msg := range utilvalidation.IsValidLabelValue(parsed["value"]) {
+		allErrs = append(allErrs, field.Invalid(fldPath, taint, fmt.Sprintf("invalid value %q: %s", parsed["value"], msg)))
+	}
+	if !slices.Contains(validTaintEffects, parsed["effect"]) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, parsed["effect"], validTaintEffects))
+	}
+
 	return allErrs
 }
 
@@ -389,6 +696,94 @@ func validateIGCloudLabels(ig *kops.InstanceGroup, fldPath *field.Path) (allErrs
 	return allErrs
 }
 
+func validateGPUConfig(gpuConfig *kops.GPUConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if gpuConfig.MIGProfile == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("migProfile"), ""))
+	} else if gpuConfig.MIGDevicesPerGPU() == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("migProfile"), gpuConfig.MIGProfile, "must be a MIG profile of the form <slices>g.<memory>gb with 1, 2, 3, 4 or 7 slices, e.g. 1g.10gb"))
+	}
+
+	return allErrs
+}
+
+// validateSwap checks the swap file configuration of an instance group
+func validateSwap(g *kops.InstanceGroup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	swap := g.Spec.Swap
+
+	if g.IsControlPlane() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "swap cannot be used with control plane instance groups"))
+	}
+
+	switch {
+	case swap.SizeMB == nil && swap.PercentOfMemory == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "one of sizeMB and percentOfMemory must be set"))
+	case swap.SizeMB != nil && swap.PercentOfMemory != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("percentOfMemory"), "only one of sizeMB and percentOfMemory can be set"))
+	case swap.SizeMB != nil && *swap.SizeMB <= 0:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sizeMB"), *swap.SizeMB, "must be greater than zero"))
+	case swap.PercentOfMemory != nil && (*swap.PercentOfMemory <= 0 || *swap.PercentOfMemory > 100):
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("percentOfMemory"), *swap.PercentOfMemory, "must be between 1 and 100"))
+	}
+
+	if swap.Behavior != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("behavior"), &swap.Behavior, []string{kops.SwapBehaviorLimitedSwap, kops.SwapBehaviorNoSwap})...)
+	}
+
+	if kubelet := g.Spec.Kubelet; kubelet != nil {
+		kubeletPath := field.NewPath("spec", "kubelet")
+		if fi.ValueOf(kubelet.FailSwapOn) {
+			allErrs = append(allErrs, field.Forbidden(kubeletPath.Child("failSwapOn"), "failSwapOn cannot be enabled on instance groups with swap"))
+		}
+		if kubelet.MemorySwapBehavior != "" && kubelet.MemorySwapBehavior != swap.ResolvedBehavior() {
+			allErrs = append(allErrs, field.Invalid(kubeletPath.Child("memorySwapBehavior"), kubelet.MemorySwapBehavior, "must match spec.swap.behavior"))
+		}
+	}
+
+	return allErrs
+}
+
+// crossValidateSwap checks that the Kubernetes version and the kubelet feature gates of an instance group support swap
+func crossValidateSwap(g *kops.InstanceGroup, cluster *kops.Cluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	kubernetesVersion := cluster.Spec.KubernetesVersion
+	if g.Spec.KubernetesVersion != "" {
+		kubernetesVersion = g.Spec.KubernetesVersion
+	}
+	version, err := util.ParseKubernetesVersion(kubernetesVersion)
+	if err != nil {
+		// The versions are validated on their own
+		return allErrs
+	}
+	version.Pre, version.Build = nil, nil
+
+	if version.LT(semver.MustParse("1.28.0")) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "swap requires Kubernetes 1.28 or later"))
+		return allErrs
+	}
+
+	// The NodeSwap feature gate is only enabled by default from Kubernetes 1.30
+	nodeSwap := ""
+	if cluster.Spec.Kubelet != nil {
+		nodeSwap = cluster.Spec.Kubelet.FeatureGates["NodeSwap"]
+	}
+	if g.Spec.Kubelet != nil && g.Spec.Kubelet.FeatureGates["NodeSwap"] != "" {
+		nodeSwap = g.Spec.Kubelet.FeatureGates["NodeSwap"]
+	}
+	if nodeSwap == "false" || (nodeSwap != "true" && version.LT(semver.MustParse("1.30.0"))) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("swap requires the NodeSwap kubelet feature gate, which is not enabled on Kubernetes %s", kubernetesVersion)))
+	}
+
+	if g.Spec.Swap.Behavior == kops.SwapBehaviorNoSwap && version.LT(semver.MustParse("1.30.0")) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("behavior"), "the NoSwap behavior requires Kubernetes 1.30 or later"))
+	}
+
+	return allErrs
+}
+
 func validateExternalLoadBalancer(lb *kops.LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
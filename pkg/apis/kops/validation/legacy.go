@@ -27,13 +27,32 @@ import (
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/util/subnet"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
 // legacy contains validation functions that don't match the apimachinery style
 
-// ValidateCluster is responsible for checking the validity of the Cluster spec
+// ValidateCluster is responsible for checking the validity of the Cluster spec.
+// It only returns the errors; in strict mode the warnings are logged.
+// Use ValidateClusterWithWarnings to report the warnings separately.
 func ValidateCluster(c *kops.Cluster, strict bool, vfsContext *vfs.VFSContext) field.ErrorList {
+	result := ValidateClusterWithWarnings(c, strict, vfsContext)
+	if strict {
+		logWarnings(result.Warnings)
+	}
+	return result.Errors
+}
+
+// ValidateClusterWithWarnings checks the validity of the Cluster spec, and returns both the errors and the warnings.
+func ValidateClusterWithWarnings(c *kops.Cluster, strict bool, vfsContext *vfs.VFSContext) *ValidationResult {
+	return &ValidationResult{
+		Errors:   validateCluster(c, strict, vfsContext),
+		Warnings: clusterWarnings(c),
+	}
+}
+
+func validateCluster(c *kops.Cluster, strict bool, vfsContext *vfs.VFSContext) field.ErrorList {
 	fieldSpec := field.NewPath("spec")
 	allErrs := field.ErrorList{}
 
@@ -243,14 +262,34 @@ func validateServiceAccountIssuerDiscovery(c *kops.Cluster, said *kops.ServiceAc
 	return allErrs
 }
 
-// DeepValidate is responsible for validating the instancegroups within the cluster spec
+// DeepValidate is responsible for validating the instancegroups within the cluster spec.
+// It only returns the errors; in strict mode the warnings are logged.
+// Use DeepValidateWithWarnings to report the warnings separately.
 func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vfsContext *vfs.VFSContext, cloud fi.Cloud) error {
-	if errs := ValidateCluster(c, strict, vfsContext); len(errs) != 0 {
-		return errs.ToAggregate()
+	warnings, err := DeepValidateWithWarnings(c, groups, strict, vfsContext, cloud)
+	if strict {
+		logWarnings(warnings)
+	}
+	return err
+}
+
+// DeepValidateWithWarnings is like DeepValidate, but also returns the warnings about the cluster and its instance groups.
+// The warnings found so far are returned even if validation fails.
+func DeepValidateWithWarnings(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vfsContext *vfs.VFSContext, cloud fi.Cloud) ([]*Warning, error) {
+	result := ValidateClusterWithWarnings(c, strict, vfsContext)
+	warnings := result.Warnings
+	if result.HasErrors() {
+		return warnings, result.Errors.ToAggregate()
+	}
+
+	if strict && cloud != nil && cloud.ProviderID() == kops.CloudProviderAWS {
+		if errs := awsValidateTransitGatewayEgress(c, cloud.(awsup.AWSCloud)); len(errs) != 0 {
+			return warnings, errs.ToAggregate()
+		}
 	}
 
 	if len(groups) == 0 {
-		return fmt.Errorf("must configure at least one InstanceGroup")
+		return warnings, fmt.Errorf("must configure at least one InstanceGroup")
 	}
 
 	controlPlaneGroupCount := 0
@@ -264,15 +303,17 @@ func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vf
 	}
 
 	if controlPlaneGroupCount == 0 {
-		return fmt.Errorf("must configure at least one ControlPlane InstanceGroup")
+		return warnings, fmt.Errorf("must configure at least one ControlPlane InstanceGroup")
 	}
 
 	if nodeGroupCount == 0 {
-		return fmt.Errorf("must configure at least one Node InstanceGroup")
+		return warnings, fmt.Errorf("must configure at least one Node InstanceGroup")
 	}
 
 	for _, g := range groups {
-		errs := CrossValidateInstanceGroup(g, c, cloud, strict)
+		result := CrossValidateInstanceGroupWithWarnings(g, c, cloud, strict)
+		warnings = append(warnings, result.Warnings...)
+		errs := result.Errors
 
 		// Additional cloud-specific validation rules
 		if c.Spec.GetCloudProvider() != kops.CloudProviderAWS && len(g.Spec.Volumes) > 0 {
@@ -280,11 +321,11 @@ func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vf
 		}
 
 		if len(errs) != 0 {
-			return errs.ToAggregate()
+			return warnings, errs.ToAggregate()
 		}
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func isExperimentalClusterDNS(k *kops.KubeletConfigSpec, dns *kops.KubeDNSConfig) bool {
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model/defaults"
+)
+
+// WarningCode identifies the kind of a validation warning.
+type WarningCode string
+
+const (
+	// WarningCodeRedundantCIDR is used for access list CIDRs that are covered by an allow-all CIDR.
+	WarningCodeRedundantCIDR WarningCode = "RedundantCIDR"
+	// WarningCodeShortTokenExpiration is used for service account token expirations that clients may not handle.
+	WarningCodeShortTokenExpiration WarningCode = "ShortTokenExpiration"
+	// WarningCodeLegacyServiceAccountTokens is used when secret-based service account tokens are still auto-created.
+	WarningCodeLegacyServiceAccountTokens WarningCode = "LegacyServiceAccountTokens"
+	// WarningCodeNodePublicIP is used for nodes that get public IPs in a cluster with an internal API load balancer.
+	WarningCodeNodePublicIP WarningCode = "NodePublicIP"
+	// WarningCodeEvictionThreshold is used for absolute disk eviction thresholds that are large compared to the root volume.
+	WarningCodeEvictionThreshold WarningCode = "EvictionThreshold"
+	// WarningCodeUnsupportedKubernetesVersion is used for Kubernetes versions outside the range supported by this version of kOps.
+	WarningCodeUnsupportedKubernetesVersion WarningCode = "UnsupportedKubernetesVersion"
+)
+
+// maxEvictionThresholdPercentOfDisk is the share of the root volume above which an absolute disk eviction threshold is warned about.
+const maxEvictionThresholdPercentOfDisk = 20
+
+// Warning is a validation finding that is likely to cause problems, but does not prevent the configuration from being applied.
+type Warning struct {
+	// Field is the path of the field the warning is about, if any
+	Field *field.Path
+	// Code identifies the kind of warning
+	Code WarningCode
+	// Detail is the human-readable description of the warning
+	Detail string
+}
+
+// String returns the field path and the detail of the warning.
+func (w *Warning) String() string {
+	if w.Field == nil {
+		return w.Detail
+	}
+	return fmt.Sprintf("%s: %s", w.Field, w.Detail)
+}
+
+// ValidationResult holds the errors and the warnings found by validation.
+// Only errors should cause the validated configuration to be rejected.
+type ValidationResult struct {
+	Errors   field.ErrorList
+	Warnings []*Warning
+}
+
+// HasErrors returns true if validation found any errors.
+func (r *ValidationResult) HasErrors() bool {
+	return len(r.Errors) != 0
+}
+
+// PrintWarnings writes the warnings to out, one per line, so they stand out from errors and other output.
+func PrintWarnings(out io.Writer, warnings []*Warning) {
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "Warning: %s (%s)\n", warning, warning.Code)
+	}
+}
+
+// logWarnings logs the warnings, for the callers of the validation functions that only return errors.
+func logWarnings(warnings []*Warning) {
+	for _, warning := range warnings {
+		klog.Warning(warning.String())
+	}
+}
+
+// clusterWarnings returns the warnings about the cluster spec.
+func clusterWarnings(c *kops.Cluster) []*Warning {
+	var warnings []*Warning
+
+	warnings = append(warnings, serviceAccountTokenWarnings(c)...)
+
+	if c.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		accessLists := []struct {
+			fieldPath *field.Path
+			entries   []string
+		}{
+			{field.NewPath("spec", "sshAccess"), c.Spec.SSHAccess},
+			{field.NewPath("spec", "api", "access"), c.Spec.API.Access},
+			{field.NewPath("spec", "nodePortAccess"), c.Spec.NodePortAccess},
+		}
+		for _, accessList := range accessLists {
+			warnings = append(warnings, accessListRedundancyWarnings(accessList.fieldPath, accessList.entries)...)
+		}
+	}
+
+	return warnings
+}
+
+// instanceGroupWarnings returns the warnings about the instance group spec.
+func instanceGroupWarnings(g *kops.InstanceGroup, cluster *kops.Cluster) []*Warning {
+	var warnings []*Warning
+
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && len(g.Spec.NodePortAccess) > 0 {
+		warnings = append(warnings, accessListRedundancyWarnings(field.NewPath("spec", "nodePortAccess"), g.Spec.NodePortAccess)...)
+	}
+
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		warnings = append(warnings, nodePublicIPWarnings(g, cluster)...)
+	}
+
+	warnings = append(warnings, evictionThresholdWarnings(g, cluster)...)
+
+	return warnings
+}
+
+// evictionThresholdWarnings warns about absolute nodefs and imagefs hard eviction thresholds that exceed
+// maxEvictionThresholdPercentOfDisk of the root volume of the instance group.
+// Such thresholds keep the kubelet evicting pods, or reserve much of the disk for nothing.
+func evictionThresholdWarnings(g *kops.InstanceGroup, cluster *kops.Cluster) []*Warning {
+	var evictionHard *string
+	fieldPath := field.NewPath("spec", "rootVolume", "size")
+	switch {
+	case g.Spec.Kubelet != nil && g.Spec.Kubelet.EvictionHard != nil:
+		evictionHard = g.Spec.Kubelet.EvictionHard
+		fieldPath = field.NewPath("spec", "kubelet", "evictionHard")
+	case g.IsControlPlane() && cluster.Spec.ControlPlaneKubelet != nil && cluster.Spec.ControlPlaneKubelet.EvictionHard != nil:
+		evictionHard = cluster.Spec.ControlPlaneKubelet.EvictionHard
+	case cluster.Spec.Kubelet != nil:
+		evictionHard = cluster.Spec.Kubelet.EvictionHard
+	}
+	if evictionHard == nil {
+		return nil
+	}
+
+	var size int32
+	if g.Spec.RootVolume != nil && g.Spec.RootVolume.Size != nil {
+		size = *g.Spec.RootVolume.Size
+	} else {
+		defaultSize, err := defaults.DefaultInstanceGroupVolumeSize(g.Spec.Role)
+		if err != nil {
+			return nil
+		}
+		size = defaultSize
+	}
+	if size <= 0 {
+		return nil
+	}
+	disk := resource.NewQuantity(int64(size)<<30, resource.BinarySI)
+	maxThreshold := disk.Value() * maxEvictionThresholdPercentOfDisk / 100
+
+	var warnings []*Warning
+	for _, threshold := range strings.Split(*evictionHard, ",") {
+		signal, value, found := strings.Cut(strings.TrimSpace(threshold), "<")
+		if !found || (signal != "nodefs.available" && signal != "imagefs.available") || strings.HasSuffix(value, "%") {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			// Invalid thresholds are left to the kubelet
+			continue
+		}
+		if q.Value() > maxThreshold {
+			warnings = append(warnings, &Warning{
+				Field:  fieldPath,
+				Code:   WarningCodeEvictionThreshold,
+				Detail: fmt.Sprintf("the %s eviction threshold of %s is more than %d%% of the %s root volume; use a percentage or a smaller threshold", signal, value, maxEvictionThresholdPercentOfDisk, disk),
+			})
+		}
+	}
+	return warnings
+}
+
+// nodePublicIPWarnings warns about nodes that get public IPs when the API is only reachable through an internal load balancer.
+// This is usually left over from a public topology, exposing the nodes for no reason.
+func nodePublicIPWarnings(g *kops.InstanceGroup, cluster *kops.Cluster) []*Warning {
+	if g.Spec.Role != kops.InstanceGroupRoleNode {
+		return nil
+	}
+	if cluster.Spec.API.LoadBalancer == nil || cluster.Spec.API.LoadBalancer.Type != kops.LoadBalancerTypeInternal {
+		return nil
+	}
+
+	for _, subnetName := range g.Spec.Subnets {
+		for i := range cluster.Spec.Networking.Subnets {
+			subnet := &cluster.Spec.Networking.Subnets[i]
+			if subnet.Name != subnetName || !g.AssociatesPublicIP(subnet) {
+				continue
+			}
+			return []*Warning{{
+				Field:  field.NewPath("spec", "associatePublicIP"),
+				Code:   WarningCodeNodePublicIP,
+				Detail: fmt.Sprintf("nodes in subnet %q get public IPs, but the cluster API uses an internal load balancer; set associatePublicIP to false or mapPublicIPOnLaunch of the subnet to false", subnet.Name),
+			}}
+		}
+	}
+	return nil
+}
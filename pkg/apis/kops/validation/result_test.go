@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestPrintWarnings(t *testing.T) {
+	warnings := []*Warning{
+		{
+			Field:  field.NewPath("spec", "sshAccess").Index(1),
+			Code:   WarningCodeRedundantCIDR,
+			Detail: `"10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed`,
+		},
+		{
+			Code:   WarningCodeLegacyServiceAccountTokens,
+			Detail: "secret-based service account tokens are still auto-created",
+		},
+	}
+
+	var out bytes.Buffer
+	PrintWarnings(&out, warnings)
+
+	expected := `Warning: spec.sshAccess[1]: "10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed (RedundantCIDR)
+Warning: secret-based service account tokens are still auto-created (LegacyServiceAccountTokens)
+`
+	if out.String() != expected {
+		t.Errorf("unexpected output, expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestDeepValidateWithWarnings(t *testing.T) {
+	grid := []struct {
+		name             string
+		sshAccess        []string
+		nodePortAccess   []string
+		internalAPI      bool
+		associatePublic  *bool
+		expectError      bool
+		expectedWarnings []string
+	}{
+		{
+			name:      "no warnings",
+			sshAccess: []string{"0.0.0.0/0"},
+		},
+		{
+			name:           "warnings do not fail validation",
+			sshAccess:      []string{"0.0.0.0/0", "10.0.0.0/8"},
+			nodePortAccess: []string{"0.0.0.0/0", "192.168.0.0/16"},
+			expectedWarnings: []string{
+				`spec.sshAccess[1]: "10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed`,
+				`spec.nodePortAccess[1]: "192.168.0.0/16" is redundant, as "0.0.0.0/0" is allowed`,
+			},
+		},
+		{
+			name:        "warnings are returned with errors",
+			sshAccess:   []string{"0.0.0.0/0", "10.0.0.0/8", "10.0.0.0/8"},
+			expectError: true,
+			expectedWarnings: []string{
+				`spec.sshAccess[1]: "10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed`,
+				`spec.sshAccess[2]: "10.0.0.0/8" is redundant, as "0.0.0.0/0" is allowed`,
+			},
+		},
+		{
+			name:        "public node IPs with an internal API",
+			sshAccess:   []string{"0.0.0.0/0"},
+			internalAPI: true,
+			expectedWarnings: []string{
+				`spec.associatePublicIP: nodes in subnet "subnet-us-test-1a" get public IPs, but the cluster API uses an internal load balancer; set associatePublicIP to false or mapPublicIPOnLaunch of the subnet to false`,
+			},
+		},
+		{
+			name:            "private node IPs with an internal API",
+			sshAccess:       []string{"0.0.0.0/0"},
+			internalAPI:     true,
+			associatePublic: fi.PtrTo(false),
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := testutils.BuildMinimalCluster("warnings.example.com")
+			cluster.Spec.SSHAccess = g.sshAccess
+			if g.internalAPI {
+				cluster.Spec.API.LoadBalancer = &kops.LoadBalancerAccessSpec{Class: kops.LoadBalancerClassNetwork, Type: kops.LoadBalancerTypeInternal}
+				cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets, kops.ClusterSubnetSpec{
+					Name: "private-us-test-1a", Zone: "us-test-1a", CIDR: "172.20.32.0/19", Type: kops.SubnetTypePrivate,
+				})
+			}
+
+			master := testutils.BuildMinimalMasterInstanceGroup("subnet-us-test-1a")
+			node := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+			node.Spec.NodePortAccess = g.nodePortAccess
+			node.Spec.AssociatePublicIP = g.associatePublic
+
+			warnings, err := DeepValidateWithWarnings(cluster, []*kops.InstanceGroup{&master, &node}, false, nil, nil)
+			if g.expectError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !g.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for _, warning := range warnings {
+				actual = append(actual, warning.String())
+			}
+			if !reflect.DeepEqual(actual, g.expectedWarnings) {
+				t.Errorf("expected warnings %q, got %q", g.expectedWarnings, actual)
+			}
+		})
+	}
+}
+
+func TestEvictionThresholdWarnings(t *testing.T) {
+	grid := []struct {
+		name                string
+		role                kops.InstanceGroupRole
+		clusterEvictionHard *string
+		igEvictionHard      *string
+		rootVolumeSize      *int32
+		expectedWarnings    []string
+	}{
+		{
+			name:                "percentages",
+			role:                kops.InstanceGroupRoleNode,
+			clusterEvictionHard: fi.PtrTo("memory.available<100Mi,nodefs.available<10%,nodefs.inodesFree<5%,imagefs.available<10%,imagefs.inodesFree<5%"),
+		},
+		{
+			name:                "small absolute thresholds",
+			role:                kops.InstanceGroupRoleNode,
+			clusterEvictionHard: fi.PtrTo("memory.available<100Mi,nodefs.available<10Gi,imagefs.available<25Gi"),
+		},
+		{
+			name:                "large absolute thresholds on the default root volume",
+			role:                kops.InstanceGroupRoleNode,
+			clusterEvictionHard: fi.PtrTo("memory.available<100Mi,nodefs.available<30Gi,imagefs.available<26Gi"),
+			expectedWarnings: []string{
+				"spec.rootVolume.size: the nodefs.available eviction threshold of 30Gi is more than 20% of the 128Gi root volume; use a percentage or a smaller threshold",
+				"spec.rootVolume.size: the imagefs.available eviction threshold of 26Gi is more than 20% of the 128Gi root volume; use a percentage or a smaller threshold",
+			},
+		},
+		{
+			name:                "absolute threshold on a small root volume",
+			role:                kops.InstanceGroupRoleNode,
+			clusterEvictionHard: fi.PtrTo("nodefs.available<10Gi"),
+			rootVolumeSize:      fi.PtrTo(int32(40)),
+			expectedWarnings: []string{
+				"spec.rootVolume.size: the nodefs.available eviction threshold of 10Gi is more than 20% of the 40Gi root volume; use a percentage or a smaller threshold",
+			},
+		},
+		{
+			name:                "instance group override",
+			role:                kops.InstanceGroupRoleNode,
+			clusterEvictionHard: fi.PtrTo("nodefs.available<30Gi"),
+			igEvictionHard:      fi.PtrTo("nodefs.available<10%,imagefs.available<20G"),
+			rootVolumeSize:      fi.PtrTo(int32(64)),
+			expectedWarnings: []string{
+				"spec.kubelet.evictionHard: the imagefs.available eviction threshold of 20G is more than 20% of the 64Gi root volume; use a percentage or a smaller threshold",
+			},
+		},
+		{
+			name:                "control plane default root volume",
+			role:                kops.InstanceGroupRoleControlPlane,
+			clusterEvictionHard: fi.PtrTo("nodefs.available<20Gi"),
+			expectedWarnings: []string{
+				"spec.rootVolume.size: the nodefs.available eviction threshold of 20Gi is more than 20% of the 64Gi root volume; use a percentage or a smaller threshold",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{}
+			cluster.Spec.Kubelet = &kops.KubeletConfigSpec{EvictionHard: g.clusterEvictionHard}
+
+			ig := &kops.InstanceGroup{}
+			ig.Spec.Role = g.role
+			if g.igEvictionHard != nil {
+				ig.Spec.Kubelet = &kops.KubeletConfigSpec{EvictionHard: g.igEvictionHard}
+			}
+			if g.rootVolumeSize != nil {
+				ig.Spec.RootVolume = &kops.InstanceRootVolumeSpec{Size: g.rootVolumeSize}
+			}
+
+			var actual []string
+			for _, warning := range evictionThresholdWarnings(ig, cluster) {
+				if warning.Code != WarningCodeEvictionThreshold {
+					t.Errorf("unexpected code %q", warning.Code)
+				}
+				actual = append(actual, warning.String())
+			}
+			if !reflect.DeepEqual(actual, g.expectedWarnings) {
+				t.Errorf("expected warnings %q, got %q", g.expectedWarnings, actual)
+			}
+		})
+	}
+}
@@ -17,19 +17,26 @@ limitations under the License.
 package validation
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/blang/semver/v4"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -38,12 +45,19 @@ import (
 	"k8s.io/kops/pkg/util/subnet"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/secretscan"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 )
 
+// legacyServiceAccountTokenFeatureGate stops the auto-creation of secret-based service account tokens when enabled.
+const legacyServiceAccountTokenFeatureGate = "LegacyServiceAccountTokenNoAutoGeneration"
+
 func newValidateCluster(cluster *kops.Cluster, strict bool) field.ErrorList {
 	allErrs := validation.ValidateObjectMeta(&cluster.ObjectMeta, false, validation.NameIsDNSSubdomain, field.NewPath("metadata"))
 
@@ -79,6 +93,8 @@ func newValidateCluster(cluster *kops.Cluster, strict bool) field.ErrorList {
 func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *field.Path, strict bool) field.ErrorList {
 	allErrs, providerConstraints := validateCloudProvider(c, &spec.CloudProvider, fieldPath.Child("cloudProvider"))
 
+	allErrs = append(allErrs, validateCloudProviderCapabilities(clusterCapabilities, spec, spec.GetCloudProvider(), fieldPath)...)
+
 	// SSHAccess
 	for i, cidr := range spec.SSHAccess {
 		if strings.HasPrefix(cidr, "pl-") {
@@ -115,6 +131,18 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 	// UpdatePolicy
 	allErrs = append(allErrs, IsValidValue(fieldPath.Child("updatePolicy"), spec.UpdatePolicy, []string{kops.UpdatePolicyAutomatic, kops.UpdatePolicyExternal})...)
 
+	if spec.TagPolicy != nil {
+		allErrs = append(allErrs, validateTagPolicy(spec.TagPolicy, fieldPath.Child("tagPolicy"))...)
+	}
+
+	if spec.AddonDefaults != nil {
+		allErrs = append(allErrs, validateAddonDefaults(spec.AddonDefaults, fieldPath.Child("addonDefaults"))...)
+	}
+
+	if len(spec.NodeIPFamilies) > 0 {
+		allErrs = append(allErrs, validateNodeIPFamilies(c, spec.NodeIPFamilies, fieldPath.Child("nodeIPFamilies"), strict)...)
+	}
+
 	// Hooks
 	for i := range spec.Hooks {
 		allErrs = append(allErrs, validateHookSpec(&spec.Hooks[i], fieldPath.Child("hooks").Index(i))...)
@@ -164,10 +192,22 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateMetricsServer(c, spec.MetricsServer, fieldPath.Child("metricsServer"))...)
 	}
 
+	if spec.Metrics != nil && spec.Metrics.KubeStateMetrics != nil {
+		allErrs = append(allErrs, validateKubeStateMetrics(spec.Metrics.KubeStateMetrics, fieldPath.Child("metrics", "kubeStateMetrics"))...)
+	}
+
 	if spec.SnapshotController != nil {
 		allErrs = append(allErrs, validateSnapshotController(c, spec.SnapshotController, fieldPath.Child("snapshotController"))...)
 	}
 
+	if spec.Snapshots != nil {
+		allErrs = append(allErrs, validateSnapshots(c, spec.Snapshots, fieldPath.Child("snapshots"))...)
+	}
+
+	if spec.KopsController != nil {
+		allErrs = append(allErrs, validateKopsController(spec.KopsController, fieldPath.Child("kopsController"))...)
+	}
+
 	// IAM additional policies
 	for k, v := range spec.AdditionalPolicies {
 		allErrs = append(allErrs, validateAdditionalPolicy(k, v, fieldPath.Child("additionalPolicies"))...)
@@ -218,38 +258,12 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 
 	if spec.RollingUpdate != nil {
 		allErrs = append(allErrs, validateRollingUpdate(spec.RollingUpdate, fieldPath.Child("rollingUpdate"), false)...)
+		allErrs = append(allErrs, crossValidateRollingUpdate(c, nil, fieldPath.Child("rollingUpdate"))...)
 	}
 
 	if spec.API.LoadBalancer != nil {
 		lbSpec := spec.API.LoadBalancer
 		lbPath := fieldPath.Child("api", "loadBalancer")
-		if spec.GetCloudProvider() != kops.CloudProviderAWS {
-			if lbSpec.Class != "" {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("class"), "class is only supported on AWS"))
-			}
-			if lbSpec.IdleTimeoutSeconds != nil {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("idleTimeoutSeconds"), "idleTimeoutSeconds is only supported on AWS"))
-			}
-			if lbSpec.SecurityGroupOverride != nil {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("securityGroupOverride"), "securityGroupOverride is only supported on AWS"))
-			}
-			if lbSpec.AdditionalSecurityGroups != nil {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("additionalSecurityGroups"), "additionalSecurityGroups is only supported on AWS"))
-			}
-			if lbSpec.SSLCertificate != "" {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslCertificate"), "sslCertificate is only supported on AWS"))
-			}
-			if lbSpec.SSLPolicy != nil {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslPolicy"), "sslPolicy is only supported on AWS"))
-			}
-			if lbSpec.CrossZoneLoadBalancing != nil {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("crossZoneLoadBalancing"), "crossZoneLoadBalancing is only supported on AWS"))
-			}
-			if lbSpec.AccessLog != nil {
-				allErrs = append(allErrs, field.Forbidden(lbPath.Child("accessLog"), "accessLog is only supported on AWS"))
-			}
-		}
-
 		if lbSpec.Type == kops.LoadBalancerTypeInternal {
 			var hasPrivate bool
 			for _, subnet := range spec.Networking.Subnets {
@@ -273,11 +287,19 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iam", "legacy"), "legacy IAM permissions are no longer supported"))
 		}
 
+		if spec.IAM.PermissionsBoundary != nil {
+			allErrs = append(allErrs, validatePermissionsBoundary(*spec.IAM.PermissionsBoundary, fieldPath.Child("iam", "permissionsBoundary"))...)
+		}
+
 		if len(spec.IAM.ServiceAccountExternalPermissions) > 0 {
 			allErrs = append(allErrs, validateSAExternalPermissions(spec.IAM.ServiceAccountExternalPermissions, fieldPath.Child("iam", "serviceAccountExternalPermissions"))...)
 		}
 	}
 
+	if spec.Target != nil && spec.Target.Terraform != nil {
+		allErrs = append(allErrs, validateTerraformTarget(spec.Target.Terraform, fieldPath.Child("target", "terraform"))...)
+	}
+
 	if spec.Karpenter != nil && spec.Karpenter.Enabled {
 		fldPath := fieldPath.Child("karpenter", "enabled")
 		if !fi.ValueOf(spec.IAM.UseServiceAccountExternalPermissions) {
@@ -396,6 +418,13 @@ func validateAWS(c *kops.Cluster, aws *kops.AWSSpec, path *field.Path) (allErrs
 		allErrs = append(allErrs, validateWarmPool(aws.WarmPool, path.Child("warmPool"))...)
 	}
 
+	if aws.InstanceMetadata != nil {
+		allErrs = append(allErrs, awsValidateInstanceMetadata(path.Child("instanceMetadata"), aws.InstanceMetadata)...)
+		if aws.InstanceMetadata.AllowDowngrade != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("instanceMetadata", "allowDowngrade"), "allowDowngrade can only be set on instance groups"))
+		}
+	}
+
 	if aws.PodIdentityWebhook != nil && aws.PodIdentityWebhook.Enabled {
 		allErrs = append(allErrs, validatePodIdentityWebhook(c, aws.PodIdentityWebhook, path.Child("podIdentityWebhook"))...)
 	}
@@ -436,6 +465,9 @@ func validateSAExternalPermissions(externalPermissions []kops.ServiceAccountExte
 		if len(aws.PolicyARNs) > 0 && aws.InlinePolicy != "" {
 			allErrs = append(allErrs, field.Forbidden(ap, "cannot set both inlinePolicy and policyARN"))
 		}
+		if aws.InlinePolicy != "" {
+			allErrs = append(allErrs, validatePolicyStatements(aws.InlinePolicy, ap.Child("inlinePolicy"))...)
+		}
 	}
 	return allErrs
 }
@@ -517,6 +549,164 @@ func validateTopology(c *kops.Cluster, topology *kops.TopologySpec, fieldPath *f
 	return allErrs
 }
 
+func validateTagPolicy(policy *kops.TagPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, rule := range policy.Rules {
+		rulePath := fldPath.Child("rules").Index(i)
+		if len(rule.Tags) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath.Child("tags"), "rule must list at least one tag"))
+		}
+		for j, tag := range rule.Tags {
+			if tag == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("tags").Index(j), "tag key must not be empty"))
+			}
+		}
+		if len(rule.Include) == 0 && len(rule.Exclude) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath, "rule must set include or exclude"))
+		}
+		for j := range rule.Include {
+			allErrs = append(allErrs, IsValidValue(rulePath.Child("include").Index(j), &rule.Include[j], awsup.TagPolicyResourceTypes)...)
+		}
+		for j := range rule.Exclude {
+			allErrs = append(allErrs, IsValidValue(rulePath.Child("exclude").Index(j), &rule.Exclude[j], awsup.TagPolicyResourceTypes)...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateAddonDefaults(spec *kops.AddonDefaultsSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	for i := range spec.Tolerations {
+		allErrs = append(allErrs, validateToleration(&spec.Tolerations[i], fldPath.Child("tolerations").Index(i))...)
+	}
+
+	if spec.RuntimeClassName != nil {
+		for _, msg := range validation.NameIsDNSSubdomain(*spec.RuntimeClassName, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("runtimeClassName"), *spec.RuntimeClassName, msg))
+		}
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(spec.NodeSelector, fldPath.Child("nodeSelector"))...)
+
+	return allErrs
+}
+
+// validateToleration checks a toleration the same way the API server does for pods.
+func validateToleration(toleration *corev1.Toleration, fldPath *field.Path) (allErrs field.ErrorList) {
+	if toleration.Key != "" {
+		for _, msg := range utilvalidation.IsQualifiedName(toleration.Key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("key"), toleration.Key, msg))
+		}
+	} else if toleration.Operator != corev1.TolerationOpExists {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("operator"), toleration.Operator, "operator must be Exists when key is empty"))
+	}
+
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("effect"), toleration.Effect, "effect must be NoExecute when tolerationSeconds is set"))
+	}
+
+	switch toleration.Operator {
+	case corev1.TolerationOpEqual, "":
+		for _, msg := range utilvalidation.IsValidLabelValue(toleration.Value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), toleration.Value, msg))
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), toleration.Value, "value must be empty when operator is Exists"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operator"), toleration.Operator, []string{string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)}))
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("effect"), toleration.Effect, []string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)}))
+	}
+
+	return allErrs
+}
+
+func validateNodeIPFamilies(c *kops.Cluster, families []string, fldPath *field.Path, strict bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.Spec.CloudProvider.AWS != nil && len(c.Spec.CloudProvider.AWS.NodeIPFamilies) > 0 {
+		// The deprecated cloud provider field would otherwise silently disagree with the node addresses
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudProvider", "aws", "nodeIPFamilies"), "is deprecated and cannot be combined with spec.nodeIPFamilies"))
+	}
+
+	if len(families) > 2 {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(families), 2))
+	}
+
+	seen := make(map[string]bool)
+	for i, family := range families {
+		allErrs = append(allErrs, IsValidValue(fldPath.Index(i), &family, []string{kops.IPFamilyIPv4, kops.IPFamilyIPv6})...)
+		if seen[family] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), family))
+		}
+		seen[family] = true
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	// The primary node address must be of the same family as the pod network
+	if c.Spec.IsIPv6Only() && families[0] != kops.IPFamilyIPv6 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Index(0), "IPv6 clusters must list ipv6 first"))
+	} else if !c.Spec.IsIPv6Only() && families[0] != kops.IPFamilyIPv4 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Index(0), "IPv4 clusters must list ipv4 first"))
+	}
+
+	if len(families) > 1 && c.IsKubernetesLT("1.29") {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "dual-stack node addresses require Kubernetes 1.29 or later"))
+	}
+
+	if seen[kops.IPFamilyIPv6] && !c.Spec.IsIPv6Only() {
+		if name := ipv6UnsupportedNetworking(&c.Spec.Networking); name != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("%s networking does not support IPv6 node addresses", name)))
+		}
+	}
+
+	// Every subnet that hosts nodes must provide an address of each family.
+	// CIDRs are assigned when the cluster is populated, so this can only be checked in strict mode.
+	if strict {
+		for _, subnet := range c.Spec.Networking.Subnets {
+			if subnet.Type == kops.SubnetTypeUtility || subnet.ID != "" {
+				continue
+			}
+			if seen[kops.IPFamilyIPv4] && subnet.CIDR == "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("ipv4 requires subnet %q to have an IPv4 CIDR", subnet.Name)))
+			}
+			if seen[kops.IPFamilyIPv6] && subnet.IPv6CIDR == "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("ipv6 requires subnet %q to have an IPv6 CIDR", subnet.Name)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ipv6UnsupportedNetworking returns the name of the networking provider if it does not support IPv6 addresses.
+func ipv6UnsupportedNetworking(networking *kops.NetworkingSpec) string {
+	switch {
+	case networking.Kubenet != nil:
+		return "kubenet"
+	case networking.Kopeio != nil:
+		return "kopeio"
+	case networking.KubeRouter != nil:
+		return "kube-router"
+	case networking.AmazonVPC != nil:
+		return "amazon-vpc-routed-eni"
+	case networking.Flannel != nil:
+		return "flannel"
+	case networking.Canal != nil:
+		return "canal"
+	}
+	return ""
+}
+
 func validateSubnets(c *kops.ClusterSpec, subnets []kops.ClusterSubnetSpec, fieldPath *field.Path, strict bool, providerConstraints *cloudProviderConstraints, networkCIDRs []*net.IPNet, podCIDR, serviceClusterIPRange *net.IPNet) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -596,7 +786,8 @@ func validateSubnet(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fie
 	} else {
 		subnetCIDR, errs := parseCIDR(fieldPath.Child("cidr"), subnetSpec.CIDR)
 		allErrs = append(allErrs, errs...)
-		if len(networkCIDRs) > 0 && subnetCIDR != nil {
+		// Shared subnets may be in a CIDR block of the VPC that isn't declared in the cluster spec
+		if len(networkCIDRs) > 0 && subnetCIDR != nil && subnetSpec.ID == "" {
 			found := false
 			for _, networkCIDR := range networkCIDRs {
 				if subnet.BelongsTo(networkCIDR, subnetCIDR) {
@@ -626,13 +817,28 @@ func validateSubnet(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fie
 
 	if subnetSpec.Egress != "" {
 		egressType := strings.Split(subnetSpec.Egress, "-")[0]
-		if egressType != kops.EgressNatGateway && egressType != kops.EgressElasticIP && egressType != kops.EgressNatInstance && egressType != kops.EgressExternal && egressType != kops.EgressTransitGateway {
+		if egressType != kops.EgressNatGateway && egressType != kops.EgressElasticIP && egressType != kops.EgressNatInstance && egressType != kops.EgressManagedNatInstance && egressType != kops.EgressExternal && egressType != kops.EgressTransitGateway && egressType != kops.EgressEgressOnlyInternetGateway {
 			allErrs = append(allErrs, field.Invalid(fieldPath.Child("egress"), subnetSpec.Egress,
-				"egress must be of type NAT Gateway, NAT Gateway with existing ElasticIP, NAT EC2 Instance, Transit Gateway, or External"))
+				"egress must be of type NAT Gateway, NAT Gateway with existing ElasticIP, NAT EC2 Instance, NAT Instance managed by kOps, Transit Gateway, Egress-only Internet Gateway, or External"))
 		}
 		if subnetSpec.Egress != kops.EgressExternal && subnetSpec.Type != "DualStack" && subnetSpec.Type != "Private" && (subnetSpec.IPv6CIDR == "" || subnetSpec.Type != "Public") {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("egress"), "egress can only be specified for private or IPv6-capable public subnets"))
 		}
+		if egressType == kops.EgressEgressOnlyInternetGateway {
+			allErrs = append(allErrs, validateEgressOnlyInternetGatewayEgress(fieldPath.Child("egress"), c, subnetSpec)...)
+		}
+		if subnetSpec.Egress == kops.EgressManagedNatInstance {
+			allErrs = append(allErrs, validateManagedNatInstanceEgress(fieldPath.Child("egress"), c, subnetSpec)...)
+		}
+	}
+
+	if subnetSpec.MapPublicIPOnLaunch != nil {
+		fldPath := fieldPath.Child("mapPublicIPOnLaunch")
+		if subnetSpec.Type != kops.SubnetTypePublic && subnetSpec.Type != kops.SubnetTypeUtility {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "mapPublicIPOnLaunch can only be specified for public or utility subnets"))
+		} else if subnetSpec.ID != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "mapPublicIPOnLaunch cannot be specified for shared subnets"))
+		}
 	}
 
 	allErrs = append(allErrs, IsValidValue(fieldPath.Child("type"), &subnetSpec.Type, []kops.SubnetType{
@@ -666,6 +872,56 @@ func validateSubnet(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fie
 	return allErrs
 }
 
+// egressOnlyInternetGatewayIDRegex matches the IDs of egress-only internet gateways, such as "eigw-0123456789abcdef0"
+var egressOnlyInternetGatewayIDRegex = regexp.MustCompile(`^eigw-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// validateEgressOnlyInternetGatewayEgress checks a subnet egress that references an existing egress-only internet gateway.
+func validateEgressOnlyInternetGatewayEgress(fieldPath *field.Path, c *kops.ClusterSpec, subnetSpec *kops.ClusterSubnetSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "egress-only internet gateways are only supported on AWS"))
+		return allErrs
+	}
+	if !egressOnlyInternetGatewayIDRegex.MatchString(subnetSpec.Egress) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, subnetSpec.Egress, "egress-only internet gateway ID must be of the form eigw-0123456789abcdef0"))
+	}
+	if subnetSpec.Type != kops.SubnetTypePrivate && subnetSpec.Type != kops.SubnetTypeDualStack {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "egress-only internet gateways can only be used by private subnets"))
+	} else if subnetSpec.IPv6CIDR == "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "egress-only internet gateways can only be used by subnets with an ipv6CIDR"))
+	}
+
+	return allErrs
+}
+
+// validateManagedNatInstanceEgress checks a subnet egress that uses a NAT instance managed by kOps.
+func validateManagedNatInstanceEgress(fieldPath *field.Path, c *kops.ClusterSpec, subnetSpec *kops.ClusterSubnetSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "NAT instances managed by kOps are only supported on AWS"))
+		return allErrs
+	}
+	if subnetSpec.Type != kops.SubnetTypePrivate {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "NAT instances managed by kOps can only be used by private subnets"))
+	} else if c.IsIPv6Only() || subnetSpec.CIDR == "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "NAT instances managed by kOps cannot be used by IPv6-only subnets"))
+	}
+
+	haveUtilitySubnet := false
+	for _, subnet := range c.Networking.Subnets {
+		if subnet.Zone == subnetSpec.Zone && subnet.Type == kops.SubnetTypeUtility {
+			haveUtilitySubnet = true
+		}
+	}
+	if !haveUtilitySubnet {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("NAT instances managed by kOps require a utility subnet in zone %q to host the instance", subnetSpec.Zone)))
+	}
+
+	return allErrs
+}
+
 // validateFileAssetSpec is responsible for checking a FileAssetSpec is ok
 func validateFileAssetSpec(v *kops.FileAssetSpec, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -677,6 +933,16 @@ func validateFileAssetSpec(v *kops.FileAssetSpec, fieldPath *field.Path) field.E
 		allErrs = append(allErrs, field.Required(fieldPath.Child("content"), ""))
 	}
 
+	if !v.AllowSecrets {
+		content := v.Content
+		if v.IsBase64 {
+			if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
+				content = string(decoded)
+			}
+		}
+		allErrs = append(allErrs, validateNoSecrets(content, fieldPath.Child("content"))...)
+	}
+
 	return allErrs
 }
 
@@ -712,6 +978,31 @@ func validateHookSpec(v *kops.HookSpec, fieldPath *field.Path) field.ErrorList {
 		allErrs = append(allErrs, validateExecContainerAction(v.ExecContainer, fieldPath.Child("execContainer"))...)
 	}
 
+	if !v.AllowSecrets {
+		allErrs = append(allErrs, validateNoSecrets(v.Manifest, fieldPath.Child("manifest"))...)
+		if v.ExecContainer != nil {
+			for i, arg := range v.ExecContainer.Command {
+				allErrs = append(allErrs, validateNoSecrets(arg, fieldPath.Child("execContainer", "command").Index(i))...)
+			}
+			for _, k := range sets.List(sets.KeySet(v.ExecContainer.Environment)) {
+				allErrs = append(allErrs, validateNoSecrets(v.ExecContainer.Environment[k], fieldPath.Child("execContainer", "environment").Key(k))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateNoSecrets forbids content that looks like it contains secrets, as the content
+// ends up readable by anyone with access to the instances or the state store.
+// The error names the kind of secret and its line, but not the secret itself.
+func validateNoSecrets(content string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, match := range secretscan.Find(content) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("appears to contain a %s on line %d; remove it or set allowSecrets", match.Pattern, match.Line)))
+	}
+
 	return allErrs
 }
 
@@ -772,6 +1063,13 @@ func validateKubeAPIServer(v *kops.KubeAPIServerConfig, c *kops.Cluster, fldPath
 		}
 	}
 
+	if v.ServiceAccountMaxTokenExpiration != nil {
+		d := v.ServiceAccountMaxTokenExpiration.Duration
+		if d < time.Hour || d > 30*24*time.Hour {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccountMaxTokenExpiration"), v.ServiceAccountMaxTokenExpiration.Duration.String(), "must be between 1h and 720h"))
+		}
+	}
+
 	if v.AuthorizationMode != nil {
 		if strings.Contains(*v.AuthorizationMode, "Webhook") {
 			if v.AuthorizationWebhookConfigFile == nil {
@@ -819,6 +1117,23 @@ func validateKubeAPIServer(v *kops.KubeAPIServerConfig, c *kops.Cluster, fldPath
 		}
 	}
 
+	if len(v.AdditionalServiceAccountIssuers) > 0 {
+		issuers := sets.NewString()
+		if v.ServiceAccountIssuer != nil {
+			issuers.Insert(*v.ServiceAccountIssuer)
+		}
+		for i, issuer := range v.AdditionalServiceAccountIssuers {
+			fld := fldPath.Child("additionalServiceAccountIssuers").Index(i)
+			if u, err := url.Parse(issuer); err != nil || u.Scheme != "https" || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+				allErrs = append(allErrs, field.Invalid(fld, issuer, "must be an https URL without query or fragment"))
+			}
+			if issuers.Has(issuer) {
+				allErrs = append(allErrs, field.Duplicate(fld, issuer))
+			}
+			issuers.Insert(issuer)
+		}
+	}
+
 	if v.ServiceClusterIPRange != c.Spec.Networking.ServiceClusterIPRange {
 		if strict || v.ServiceClusterIPRange != "" {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("serviceClusterIPRange"), "kubeAPIServer serviceClusterIPRange did not match cluster serviceClusterIPRange"))
@@ -828,6 +1143,40 @@ func validateKubeAPIServer(v *kops.KubeAPIServerConfig, c *kops.Cluster, fldPath
 	return allErrs
 }
 
+// serviceAccountTokenWarnings returns warnings about service account token settings that are valid but likely to cause problems.
+func serviceAccountTokenWarnings(c *kops.Cluster) []*Warning {
+	var warnings []*Warning
+
+	if c.Spec.KubeAPIServer != nil && c.Spec.KubeAPIServer.ServiceAccountMaxTokenExpiration != nil {
+		d := c.Spec.KubeAPIServer.ServiceAccountMaxTokenExpiration.Duration
+		if d >= time.Hour && d < 24*time.Hour {
+			warnings = append(warnings, &Warning{
+				Field:  field.NewPath("spec", "kubeAPIServer", "serviceAccountMaxTokenExpiration"),
+				Code:   WarningCodeShortTokenExpiration,
+				Detail: fmt.Sprintf("is set to %s; clients that do not refresh projected service account tokens will fail once their token expires", d),
+			})
+		}
+	}
+
+	if version, err := util.ParseKubernetesVersion(c.Spec.KubernetesVersion); err == nil && util.IsKubernetesGTE("1.24", *version) && !util.IsKubernetesGTE("1.27", *version) {
+		var fieldPath *field.Path
+		if c.Spec.KubeAPIServer != nil && c.Spec.KubeAPIServer.FeatureGates[legacyServiceAccountTokenFeatureGate] == "false" {
+			fieldPath = field.NewPath("spec", "kubeAPIServer", "featureGates").Key(legacyServiceAccountTokenFeatureGate)
+		} else if c.Spec.KubeControllerManager != nil && c.Spec.KubeControllerManager.FeatureGates[legacyServiceAccountTokenFeatureGate] == "false" {
+			fieldPath = field.NewPath("spec", "kubeControllerManager", "featureGates").Key(legacyServiceAccountTokenFeatureGate)
+		}
+		if fieldPath != nil {
+			warnings = append(warnings, &Warning{
+				Field:  fieldPath,
+				Code:   WarningCodeLegacyServiceAccountTokens,
+				Detail: fmt.Sprintf("the %s feature gate is disabled, so secret-based service account tokens are still auto-created; this is deprecated and cannot be disabled as of Kubernetes 1.27", legacyServiceAccountTokenFeatureGate),
+			})
+		}
+	}
+
+	return warnings
+}
+
 func validateKubeControllerManager(v *kops.KubeControllerManagerConfig, c *kops.Cluster, fldPath *field.Path, strict bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -938,9 +1287,29 @@ func validateKubelet(k *kops.KubeletConfigSpec, c *kops.Cluster, kubeletPath *fi
 		}
 
 		if k.MemorySwapBehavior != "" {
-			allErrs = append(allErrs, IsValidValue(kubeletPath.Child("memorySwapBehavior"), &k.MemorySwapBehavior, []string{"LimitedSwap", "UnlimitedSwap"})...)
+			allErrs = append(allErrs, IsValidValue(kubeletPath.Child("memorySwapBehavior"), &k.MemorySwapBehavior, []string{"LimitedSwap", "NoSwap", "UnlimitedSwap"})...)
+		}
+
+		allErrs = append(allErrs, validateKubeletContainerLogs(k, kubeletPath)...)
+	}
+	return allErrs
+}
+
+// validateKubeletContainerLogs checks the container log rotation settings of the kubelet
+func validateKubeletContainerLogs(k *kops.KubeletConfigSpec, kubeletPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if k.ContainerLogMaxSize != "" {
+		if q, err := resource.ParseQuantity(k.ContainerLogMaxSize); err != nil {
+			allErrs = append(allErrs, field.Invalid(kubeletPath.Child("containerLogMaxSize"), k.ContainerLogMaxSize, fmt.Sprintf("must be a quantity: %v", err)))
+		} else if q.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(kubeletPath.Child("containerLogMaxSize"), k.ContainerLogMaxSize, "must be positive"))
 		}
 	}
+	if k.ContainerLogMaxFiles != nil && *k.ContainerLogMaxFiles < 2 {
+		allErrs = append(allErrs, field.Invalid(kubeletPath.Child("containerLogMaxFiles"), *k.ContainerLogMaxFiles, "must be at least 2"))
+	}
+
 	return allErrs
 }
 
@@ -979,14 +1348,30 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalNetworkCIDRs"), fmt.Sprintf("%s doesn't support additionalNetworkCIDRs", c.GetCloudProvider())))
 	} else {
 		for i, cidr := range v.AdditionalNetworkCIDRs {
-			networkCIDR, errs := parseCIDR(fldPath.Child("additionalNetworkCIDRs").Index(i), cidr)
+			cidrPath := fldPath.Child("additionalNetworkCIDRs").Index(i)
+			networkCIDR, errs := parseCIDR(cidrPath, cidr)
 			allErrs = append(allErrs, errs...)
 			if networkCIDR != nil {
+				// Overlapping CIDR blocks can't be associated with the same VPC
+				for _, other := range networkCIDRs {
+					if other.String() == networkCIDR.String() {
+						allErrs = append(allErrs, field.Duplicate(cidrPath, cidr))
+						break
+					}
+					if subnet.Overlap(other, networkCIDR) {
+						allErrs = append(allErrs, field.Invalid(cidrPath, cidr, fmt.Sprintf("additionalNetworkCIDR must not overlap %q", other)))
+						break
+					}
+				}
 				networkCIDRs = append(networkCIDRs, networkCIDR)
 			}
 		}
 	}
 
+	if len(v.VPCEndpoints) > 0 && c.GetCloudProvider() == kops.CloudProviderAWS {
+		allErrs = append(allErrs, awsValidateVPCEndpoints(fldPath.Child("vpcEndpoints"), v)...)
+	}
+
 	var nonMasqueradeCIDRs []*net.IPNet
 	{
 		if v.NonMasqueradeCIDR == "" {
@@ -1170,6 +1555,7 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("amazonVPC"), "amazon-vpc-routed-eni networking does not support IPv6"))
 		}
 
+		allErrs = append(allErrs, validateNetworkingAmazonVPC(cluster, v.AmazonVPC, fldPath.Child("amazonVPC"))...)
 	}
 
 	if v.Cilium != nil {
@@ -1193,6 +1579,14 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 		allErrs = append(allErrs, validateNetworkingGCP(c, v.GCP, fldPath.Child("gcp"))...)
 	}
 
+	if fi.ValueOf(v.DefaultComponentNetworkPolicies) {
+		if v.Calico == nil && v.Canal == nil && v.Cilium == nil && v.KubeRouter == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("defaultComponentNetworkPolicies"), "defaultComponentNetworkPolicies requires a networking option that enforces NetworkPolicy (calico, canal, cilium or kubeRouter)"))
+		} else if cluster.Spec.IsIPv6Only() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("defaultComponentNetworkPolicies"), "defaultComponentNetworkPolicies is not supported for IPv6 clusters"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -1242,6 +1636,64 @@ func validateNetworkingCanal(c *kops.Cluster, v *kops.CanalNetworkingSpec, fldPa
 	return allErrs
 }
 
+func validateNetworkingAmazonVPC(cluster *kops.Cluster, v *kops.AmazonVPCNetworkingSpec, fldPath *field.Path) field.ErrorList {
+	c := &cluster.Spec
+	allErrs := field.ErrorList{}
+
+	if v.NetworkPolicyAgent != nil && fi.ValueOf(v.NetworkPolicyAgent.Enabled) {
+		enabledFld := fldPath.Child("networkPolicyAgent", "enabled")
+		if cluster.IsKubernetesLT("1.25") {
+			allErrs = append(allErrs, field.Forbidden(enabledFld, "the network policy agent requires Kubernetes 1.25 or later"))
+		}
+		if version := amazonVPCImageVersion(v.Image); version != nil && version.LT(semver.MustParse("1.14.0")) {
+			allErrs = append(allErrs, field.Forbidden(enabledFld, fmt.Sprintf("the network policy agent requires Amazon VPC CNI v1.14.0 or later, image %q is v%s", v.Image, version)))
+		}
+		if c.Networking.Calico != nil {
+			allErrs = append(allErrs, field.Forbidden(enabledFld, "the network policy agent cannot be used together with calico, NetworkPolicy would be enforced twice"))
+		}
+	}
+
+	if v.PrefixTargets != nil {
+		prefixFld := fldPath.Child("prefixTargets")
+		for _, target := range []struct {
+			name  string
+			value *int32
+		}{
+			{"warmPrefixTarget", v.PrefixTargets.WarmPrefixTarget},
+			{"warmIPTarget", v.PrefixTargets.WarmIPTarget},
+			{"minimumIPTarget", v.PrefixTargets.MinimumIPTarget},
+		} {
+			if target.value != nil && *target.value < 0 {
+				allErrs = append(allErrs, field.Invalid(prefixFld.Child(target.name), *target.value, target.name+" cannot be negative"))
+			}
+		}
+
+		for i, env := range v.Env {
+			switch env.Name {
+			case "ENABLE_PREFIX_DELEGATION", "WARM_PREFIX_TARGET", "WARM_IP_TARGET", "MINIMUM_IP_TARGET":
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("env").Index(i), fmt.Sprintf("%s cannot be set in env together with prefixTargets", env.Name)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// amazonVPCImageVersion returns the version from the tag of an Amazon VPC CNI image, or nil if it isn't a version.
+func amazonVPCImageVersion(image string) *semver.Version {
+	i := strings.LastIndex(image, ":")
+	if i == -1 || strings.Contains(image[i:], "/") {
+		return nil
+	}
+	version, err := semver.ParseTolerant(image[i+1:])
+	if err != nil {
+		return nil
+	}
+	version.Pre = nil
+	version.Build = nil
+	return &version
+}
+
 func validateNetworkingCilium(cluster *kops.Cluster, v *kops.CiliumNetworkingSpec, fldPath *field.Path) field.ErrorList {
 	c := &cluster.Spec
 	allErrs := field.ErrorList{}
@@ -1351,6 +1803,10 @@ func validateNetworkingCilium(cluster *kops.Cluster, v *kops.CiliumNetworkingSpe
 		}
 	}
 
+	if v.UpgradeMaxUnavailable != nil {
+		allErrs = append(allErrs, validateUpgradeMaxUnavailable(v.UpgradeMaxUnavailable, fldPath.Child("upgradeMaxUnavailable"))...)
+	}
+
 	return allErrs
 }
 
@@ -1377,14 +1833,23 @@ func validateAdditionalPolicy(role string, policy string, fldPath *field.Path) f
 	}
 	allErrs = append(allErrs, IsValidValue(fldPath, &role, valid)...)
 
+	allErrs = append(allErrs, validatePolicyStatements(policy, fldPath.Key(role))...)
+
+	return allErrs
+}
+
+// validatePolicyStatements verifies that the policy is a JSON list of IAM policy statements.
+func validatePolicyStatements(policy string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	statements, err := iam.ParseStatements(policy)
 	if err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Key(role), policy, "policy was not valid JSON: "+err.Error()))
+		allErrs = append(allErrs, field.Invalid(fldPath, policy, "policy was not valid JSON: "+err.Error()))
 	}
 
 	// Trivial validation of policy, mostly to make sure it isn't some other random object
 	for i, statement := range statements {
-		fldEffect := fldPath.Key(role).Index(i).Child("Effect")
+		fldEffect := fldPath.Index(i).Child("Effect")
 		if statement.Effect == "" {
 			allErrs = append(allErrs, field.Required(fldEffect, "Effect must be specified for IAM policy"))
 		} else {
@@ -1415,6 +1880,56 @@ func validateExternalPolicies(role string, policies []string, fldPath *field.Pat
 	return allErrs
 }
 
+var awsAccountIDRegex = regexp.MustCompile(`^[0-9]{12}$`)
+
+// validatePermissionsBoundary checks that the permissions boundary is the ARN of an IAM policy.
+func validatePermissionsBoundary(boundary string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	parsedARN, err := arn.Parse(boundary)
+	// AWS managed policies use "aws" instead of an account ID
+	validAccount := awsAccountIDRegex.MatchString(parsedARN.AccountID) || parsedARN.AccountID == "aws"
+	if err != nil || parsedARN.Service != "iam" || parsedARN.Region != "" || !validAccount ||
+		!strings.HasPrefix(parsedARN.Resource, "policy/") || parsedARN.Resource == "policy/" {
+		allErrs = append(allErrs, field.Invalid(fldPath, boundary,
+			"must be a valid IAM policy ARN such as arn:aws:iam::123456789012:policy/KopsExampleBoundary"))
+	}
+
+	return allErrs
+}
+
+// terraformIdentifierRegex matches the names terraform allows for provider aliases
+var terraformIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+func validateTerraformTarget(terraform *kops.TerraformSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if terraform.ProviderAlias != "" {
+		if !terraformIdentifierRegex.MatchString(terraform.ProviderAlias) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("providerAlias"), terraform.ProviderAlias,
+				"must start with a letter or underscore and contain only letters, digits, underscores and dashes"))
+		} else if terraform.ProviderAlias == "files" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("providerAlias"), terraform.ProviderAlias,
+				"alias is reserved for the provider used for managed files"))
+		}
+		if _, found := terraform.ProviderExtraConfig["alias"]; found {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("providerExtraConfig", "alias"), "alias cannot be set when providerAlias is set"))
+		}
+	}
+
+	if terraform.AssumeRole != nil {
+		roleARN := terraform.AssumeRole.RoleARN
+		if roleARN == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("assumeRole", "roleARN"), "roleARN must be set"))
+		} else if parsedARN, err := arn.Parse(roleARN); err != nil || parsedARN.Service != "iam" || !strings.HasPrefix(parsedARN.Resource, "role/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("assumeRole", "roleARN"), roleARN,
+				"must be a valid IAM role ARN such as arn:aws:iam::123456789012:role/KopsTerraform"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateEtcdClusterSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1433,6 +1948,58 @@ func validateEtcdClusterSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPa
 	for i, m := range spec.Members {
 		allErrs = append(allErrs, validateEtcdMemberSpec(m, fieldPath.Child("etcdMembers").Index(i))...)
 	}
+	allErrs = append(allErrs, validateEtcdAdditionalSANs(spec.AdditionalSANs, fieldPath.Child("additionalSANs"))...)
+	if spec.Manager != nil {
+		allErrs = append(allErrs, validateEtcdManagerSpec(spec, c, fieldPath)...)
+	}
+
+	return allErrs
+}
+
+// validateEtcdManagerSpec checks the etcd-manager options of the etcd cluster.
+func validateEtcdManagerSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := fieldPath.Child("manager", "discoveryMode")
+	allErrs = append(allErrs, IsValidValue(fldPath, &spec.Manager.DiscoveryMode, []kops.EtcdManagerDiscoveryMode{"", kops.EtcdManagerDiscoveryModeVolumeTags, kops.EtcdManagerDiscoveryModeStatic})...)
+
+	switch spec.Manager.DiscoveryMode {
+	case kops.EtcdManagerDiscoveryModeVolumeTags:
+		if cloudProvider := c.Spec.GetCloudProvider(); cloudProvider != "" && !components.SupportsEtcdVolumeTagDiscovery(cloudProvider) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("volume tag discovery is not supported for cloud provider %s; use %q instead", cloudProvider, kops.EtcdManagerDiscoveryModeStatic)))
+		}
+	case kops.EtcdManagerDiscoveryModeStatic:
+		// The member names are part of the DNS names of the static seed list
+		for i, member := range spec.Members {
+			for _, msg := range utilvalidation.IsDNS1123Label(member.Name) {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("etcdMembers").Index(i).Child("name"), member.Name, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateEtcdAdditionalSANs checks that the additional SANs are IP addresses or DNS names, optionally wildcards.
+func validateEtcdAdditionalSANs(sans []string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.New[string]()
+	for i, san := range sans {
+		fldPath := fieldPath.Index(i)
+		if seen.Has(san) {
+			allErrs = append(allErrs, field.Duplicate(fldPath, san))
+			continue
+		}
+		seen.Insert(san)
+
+		if net.ParseIP(san) != nil {
+			continue
+		}
+		if errs := utilvalidation.IsDNS1123Subdomain(strings.TrimPrefix(san, "*.")); len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, san, "must be an IP address or a DNS name: "+strings.Join(errs, ", ")))
+		}
+	}
 
 	return allErrs
 }
@@ -1594,9 +2161,25 @@ func validateNetworkingCalico(c *kops.ClusterSpec, v *kops.CalicoNetworkingSpec,
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("wireguardEnabled"), `WireGuard is not supported on IPv6 clusters`))
 	}
 
+	if v.UpgradeMaxUnavailable != nil {
+		allErrs = append(allErrs, validateUpgradeMaxUnavailable(v.UpgradeMaxUnavailable, fldPath.Child("upgradeMaxUnavailable"))...)
+	}
+
 	return allErrs
 }
 
+// validateUpgradeMaxUnavailable checks the maxUnavailable of a CNI DaemonSet, which must let at least one pod be updated at a time.
+func validateUpgradeMaxUnavailable(maxUnavailable *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, 100, true)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, maxUnavailable, fmt.Sprintf("Unable to parse: %v", err))}
+	}
+	if unavailable < 1 {
+		return field.ErrorList{field.Invalid(fldPath, maxUnavailable, "Must be at least 1 or 1%")}
+	}
+	return nil
+}
+
 func validateCalicoAutoDetectionMethod(fldPath *field.Path, runtime string, version int) field.ErrorList {
 	validationError := field.ErrorList{}
 
@@ -1808,6 +2391,59 @@ func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Pat
 			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot be zero if maxUnavailable is zero"))
 		}
 	}
+	if rollingUpdate.SurgeMode != "" {
+		allErrs = append(allErrs, IsValidValue(fldpath.Child("surgeMode"), &rollingUpdate.SurgeMode, []kops.RollingUpdateSurgeMode{kops.RollingUpdateSurgeModeDetach, kops.RollingUpdateSurgeModeScaleOut})...)
+	}
+	if rollingUpdate.DrainTimeout != nil && rollingUpdate.DrainTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("drainTimeout"), rollingUpdate.DrainTimeout.Duration.String(), "must be greater than zero"))
+	}
+	if rollingUpdate.DrainTimeoutPolicy != "" {
+		allErrs = append(allErrs, IsValidValue(fldpath.Child("drainTimeoutPolicy"), &rollingUpdate.DrainTimeoutPolicy, []kops.RollingUpdateDrainTimeoutPolicy{kops.RollingUpdateDrainTimeoutPolicyFail, kops.RollingUpdateDrainTimeoutPolicySkip, kops.RollingUpdateDrainTimeoutPolicyForce})...)
+	}
+	if rollingUpdate.JoinTimeout != nil && rollingUpdate.JoinTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("joinTimeout"), rollingUpdate.JoinTimeout.Duration.String(), "must be greater than zero"))
+	}
+	return allErrs
+}
+
+// crossValidateRollingUpdate validates the rolling update settings of an instance group, or of the cluster if ig is nil,
+// against the cloud provider. The settings of the instance group override those of the cluster.
+func crossValidateRollingUpdate(cluster *kops.Cluster, ig *kops.InstanceGroup, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	rollingUpdate := kops.RollingUpdate{}
+	if ig != nil && ig.Spec.RollingUpdate != nil {
+		rollingUpdate = *ig.Spec.RollingUpdate
+	}
+	if def := cluster.Spec.RollingUpdate; def != nil {
+		if rollingUpdate.MaxUnavailable == nil {
+			rollingUpdate.MaxUnavailable = def.MaxUnavailable
+		}
+		if rollingUpdate.MaxSurge == nil {
+			rollingUpdate.MaxSurge = def.MaxSurge
+		}
+		if rollingUpdate.SurgeMode == "" {
+			rollingUpdate.SurgeMode = def.SurgeMode
+		}
+	}
+
+	canSurge := cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && !featureflag.Spotinst.Enabled()
+	if ig != nil && ig.Spec.Manager == kops.InstanceManagerKarpenter {
+		canSurge = false
+	}
+
+	// maxSurge defaults to zero where instance groups cannot surge
+	if !canSurge && rollingUpdate.MaxSurge == nil {
+		if u := rollingUpdate.MaxUnavailable; u != nil && u.Type == intstr.Int && u.IntVal == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxUnavailable"), "Cannot be zero if maxSurge is zero, which is the default for this instance group"))
+		}
+	}
+
+	// ScaleOut on other cloud providers is rejected by the cloud provider capabilities
+	if rollingUpdate.SurgeMode == kops.RollingUpdateSurgeModeScaleOut && !canSurge && cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fldpath.Child("surgeMode"), "ScaleOut is only supported for autoscaling groups"))
+	}
+
 	return allErrs
 }
 
@@ -1853,6 +2489,7 @@ func validateClusterAutoscaler(cluster *kops.Cluster, spec *kops.ClusterAutoscal
 
 func validateExternalDNS(cluster *kops.Cluster, spec *kops.ExternalDNSConfig, fldPath *field.Path) (allErrs field.ErrorList) {
 	allErrs = append(allErrs, IsValidValue(fldPath.Child("provider"), &spec.Provider, []kops.ExternalDNSProvider{"", kops.ExternalDNSProviderDNSController, kops.ExternalDNSProviderExternalDNS, kops.ExternalDNSProviderNone})...)
+	allErrs = append(allErrs, IsValidValue(fldPath.Child("policyScope"), &spec.PolicyScope, []kops.ExternalDNSPolicyScope{"", kops.ExternalDNSPolicyScopeClusterZone, kops.ExternalDNSPolicyScopeAllZones})...)
 
 	if spec.WatchNamespace != "" {
 		if spec.WatchNamespace != "kube-system" {
@@ -1879,6 +2516,57 @@ func validateMetricsServer(cluster *kops.Cluster, spec *kops.MetricsServerConfig
 	return allErrs
 }
 
+// metricLabelsAllowlistEntry matches a resource=[label,...] entry of the kube-state-metrics metric labels allowlist
+var metricLabelsAllowlistEntry = regexp.MustCompile(`^([a-z]+)=\[(.*)\]$`)
+
+func validateKubeStateMetrics(spec *kops.KubeStateMetricsConfig, fldPath *field.Path) (allErrs field.ErrorList) {
+	resources := sets.New(spec.Resources...)
+	for i, resource := range spec.Resources {
+		if _, found := components.KubeStateMetricsResourceAPIGroups[resource]; !found {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("resources").Index(i), resource, sets.List(sets.KeySet(components.KubeStateMetricsResourceAPIGroups))))
+		}
+	}
+	if len(spec.Resources) == 0 {
+		resources = sets.New(components.DefaultKubeStateMetricsResources...)
+	}
+
+	allowlisted := sets.New[string]()
+	for i, entry := range spec.MetricLabelsAllowlist {
+		entryPath := fldPath.Child("metricLabelsAllowlist").Index(i)
+		match := metricLabelsAllowlistEntry.FindStringSubmatch(entry)
+		if match == nil {
+			allErrs = append(allErrs, field.Invalid(entryPath, entry, "must be of the form resource=[label,...]"))
+			continue
+		}
+		resource, labels := match[1], match[2]
+		if !resources.Has(resource) {
+			allErrs = append(allErrs, field.Invalid(entryPath, entry, fmt.Sprintf("metrics are not exported for resource %q", resource)))
+		}
+		if allowlisted.Has(resource) {
+			allErrs = append(allErrs, field.Duplicate(entryPath, entry))
+		}
+		allowlisted.Insert(resource)
+		if labels == "" {
+			allErrs = append(allErrs, field.Invalid(entryPath, entry, "must list at least one label"))
+			continue
+		}
+		for _, label := range strings.Split(labels, ",") {
+			if label == "*" {
+				continue
+			}
+			for _, msg := range utilvalidation.IsQualifiedName(label) {
+				allErrs = append(allErrs, field.Invalid(entryPath, entry, fmt.Sprintf("invalid label %q: %s", label, msg)))
+			}
+		}
+	}
+
+	if (spec.ClusterNetworkOnly == nil || *spec.ClusterNetworkOnly) && len(spec.ServiceAnnotations) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("serviceAnnotations"), "service annotations can only be set when clusterNetworkOnly is false"))
+	}
+
+	return allErrs
+}
+
 func validateNodeTerminationHandler(cluster *kops.Cluster, spec *kops.NodeTerminationHandlerSpec, fldPath *field.Path) (allErrs field.ErrorList) {
 	if spec.IsQueueMode() {
 		if spec.EnableSpotInterruptionDraining != nil && !*spec.EnableSpotInterruptionDraining {
@@ -1925,6 +2613,12 @@ func validateWarmPool(warmPool *kops.WarmPoolSpec, fldPath *field.Path) (allErrs
 	if warmPool.MinSize < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("minSize"), warmPool.MinSize, "warm pool minSize cannot be negative"))
 	}
+	if warmPool.EnableLifecycleHook && !warmPool.IsEnabled() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableLifecycleHook"), "lifecycle hook can only be enabled when the warm pool is enabled"))
+	}
+	if fi.ValueOf(warmPool.ReuseOnScaleIn) && !warmPool.IsEnabled() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("reuseOnScaleIn"), "instances can only be reused on scale in when the warm pool is enabled"))
+	}
 	return allErrs
 }
 
@@ -1937,6 +2631,170 @@ func validateSnapshotController(cluster *kops.Cluster, spec *kops.SnapshotContro
 	return allErrs
 }
 
+func validateSnapshots(cluster *kops.Cluster, spec *kops.SnapshotsSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	if aws := cluster.Spec.CloudProvider.AWS; aws != nil && aws.EBSCSIDriver != nil && aws.EBSCSIDriver.Enabled != nil && !*aws.EBSCSIDriver.Enabled {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "scheduled snapshots require that the AWS EBS CSI driver is enabled"))
+	}
+	if cluster.Spec.SnapshotController == nil || !fi.ValueOf(cluster.Spec.SnapshotController.Enabled) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "scheduled snapshots require that the snapshot controller is enabled"))
+	}
+
+	schedule := spec.Schedule
+	if schedule == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("schedule"), "schedule must be specified"))
+		return allErrs
+	}
+	if err := validateCronSchedule(schedule.Cron); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule", "cron"), schedule.Cron, err.Error()))
+	}
+	if schedule.Retention < 1 || schedule.Retention > 1000 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule", "retention"), schedule.Retention, "must be between 1 and 1000"))
+	}
+	namespaces := sets.NewString()
+	for i, namespace := range schedule.Namespaces {
+		namespacePath := fldPath.Child("schedule", "namespaces").Index(i)
+		for _, msg := range utilvalidation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(namespacePath, namespace, msg))
+		}
+		if namespaces.Has(namespace) {
+			allErrs = append(allErrs, field.Duplicate(namespacePath, namespace))
+		}
+		namespaces.Insert(namespace)
+	}
+
+	return allErrs
+}
+
+func validateKopsController(spec *kops.KopsControllerConfig, fldPath *field.Path) (allErrs field.ErrorList) {
+	leaderElection := spec.LeaderElection
+	if leaderElection == nil {
+		return allErrs
+	}
+	fldPath = fldPath.Child("leaderElection")
+
+	// The reconcilers must only run on one replica at a time, and the lock is fixed by the kops-controller RBAC.
+	if leaderElection.LeaderElect != nil && !*leaderElection.LeaderElect {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("leaderElect"), "kops-controller requires leader election"))
+	}
+	if leaderElection.LeaderElectResourceLock != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("leaderElectResourceLock"), "kops-controller does not support changing the leader election lock"))
+	}
+	if leaderElection.LeaderElectResourceName != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("leaderElectResourceName"), "kops-controller does not support changing the leader election lock"))
+	}
+	if leaderElection.LeaderElectResourceNamespace != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("leaderElectResourceNamespace"), "kops-controller does not support changing the leader election lock"))
+	}
+
+	durations := []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{"leaderElectLeaseDuration", leaderElection.LeaderElectLeaseDuration},
+		{"leaderElectRenewDeadlineDuration", leaderElection.LeaderElectRenewDeadlineDuration},
+		{"leaderElectRetryPeriod", leaderElection.LeaderElectRetryPeriod},
+	}
+	for _, d := range durations {
+		if d.value != nil && d.value.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(d.name), d.value.Duration.String(), "must be greater than zero"))
+		}
+	}
+	if len(allErrs) != 0 {
+		return allErrs
+	}
+
+	// Unset durations fall back to the controller-runtime defaults.
+	leaseDuration := 15 * time.Second
+	renewDeadline := 10 * time.Second
+	retryPeriod := 2 * time.Second
+	if leaderElection.LeaderElectLeaseDuration != nil {
+		leaseDuration = leaderElection.LeaderElectLeaseDuration.Duration
+	}
+	if leaderElection.LeaderElectRenewDeadlineDuration != nil {
+		renewDeadline = leaderElection.LeaderElectRenewDeadlineDuration.Duration
+	}
+	if leaderElection.LeaderElectRetryPeriod != nil {
+		retryPeriod = leaderElection.LeaderElectRetryPeriod.Duration
+	}
+	if renewDeadline >= leaseDuration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("leaderElectRenewDeadlineDuration"), renewDeadline.String(), fmt.Sprintf("must be less than the lease duration (%v)", leaseDuration)))
+	}
+	if retryPeriod >= renewDeadline {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("leaderElectRetryPeriod"), retryPeriod.String(), fmt.Sprintf("must be less than the renew deadline (%v)", renewDeadline)))
+	}
+
+	return allErrs
+}
+
+// cronFields are the names and bounds of the fields of a cron schedule
+var cronFields = []struct {
+	name     string
+	min, max int
+	names    []string
+}{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// validateCronSchedule checks a standard five field cron schedule, or one of the predefined schedules such as @daily.
+func validateCronSchedule(schedule string) error {
+	switch schedule {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return nil
+	case "":
+		return fmt.Errorf("must be specified")
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("must have %d fields, found %d", len(cronFields), len(fields))
+	}
+	for i, value := range fields {
+		cronField := cronFields[i]
+		for _, item := range strings.Split(value, ",") {
+			rangePart, step, hasStep := strings.Cut(item, "/")
+			if hasStep {
+				if n, err := strconv.Atoi(step); err != nil || n < 1 {
+					return fmt.Errorf("invalid step %q in %s field", step, cronField.name)
+				}
+			}
+			if rangePart == "*" {
+				continue
+			}
+			start, end, isRange := strings.Cut(rangePart, "-")
+			bounds := []string{start}
+			if isRange {
+				bounds = append(bounds, end)
+			}
+			var values []int
+			for _, bound := range bounds {
+				n, ok := parseCronValue(bound, cronField.min, cronField.names)
+				if !ok || n < cronField.min || n > cronField.max {
+					return fmt.Errorf("invalid value %q in %s field", bound, cronField.name)
+				}
+				values = append(values, n)
+			}
+			if isRange && values[0] > values[1] {
+				return fmt.Errorf("invalid range %q in %s field", rangePart, cronField.name)
+			}
+		}
+	}
+	return nil
+}
+
+func parseCronValue(s string, min int, names []string) (int, bool) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return min + i, true
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
 func validatePodIdentityWebhook(cluster *kops.Cluster, spec *kops.PodIdentityWebhookSpec, fldPath *field.Path) (allErrs field.ErrorList) {
 	if spec != nil && spec.Enabled {
 		if !components.IsCertManagerEnabled(cluster) {
@@ -18,9 +18,11 @@ package validation
 
 import (
 	"net"
+	"strings"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -158,6 +160,17 @@ func TestValidateSubnets(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Invalid value::subnets[0].cidr"},
 		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", CIDR: "172.16.0.0/24", Type: kops.SubnetTypePublic},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].cidr"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", ID: "subnet-1", CIDR: "172.16.0.0/24", Type: kops.SubnetTypePublic},
+			},
+		},
 		{
 			Input: []kops.ClusterSubnetSpec{
 				{Name: "a", IPv6CIDR: "2001:db8::/56", Type: kops.SubnetTypePublic},
@@ -187,6 +200,73 @@ func TestValidateSubnets(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Invalid value::subnets[0].cidr"},
 		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", IPv6CIDR: "2001:db8::/64", Type: kops.SubnetTypePrivate, Egress: "eigw-0123456789abcdef0"},
+			},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", IPv6CIDR: "2001:db8::/64", Type: kops.SubnetTypePrivate, Egress: "eigw-0123"},
+			},
+			ExpectedErrors: []string{"Invalid value::subnets[0].egress"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", IPv6CIDR: "2001:db8::/64", Type: kops.SubnetTypePublic, Egress: "eigw-0123456789abcdef0"},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].egress"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePrivate, Egress: "eigw-0123456789abcdef0"},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].egress"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "us-east-1a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePrivate, Egress: "natInstance"},
+				{Name: "utility-a", Zone: "us-east-1a", CIDR: "10.0.1.0/24", Type: kops.SubnetTypeUtility},
+			},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "us-east-1a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePrivate, Egress: "natInstance"},
+				{Name: "utility-b", Zone: "us-east-1b", CIDR: "10.0.1.0/24", Type: kops.SubnetTypeUtility},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].egress"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "us-east-1a", IPv6CIDR: "2001:db8::/64", Type: kops.SubnetTypePrivate, Egress: "natInstance"},
+				{Name: "utility-a", Zone: "us-east-1a", CIDR: "10.0.1.0/24", Type: kops.SubnetTypeUtility},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].egress"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "us-east-1a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypeUtility, Egress: "natInstance"},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].egress"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePublic, MapPublicIPOnLaunch: fi.PtrTo(false)},
+				{Name: "utility-a", CIDR: "10.0.1.0/24", Type: kops.SubnetTypeUtility, MapPublicIPOnLaunch: fi.PtrTo(true)},
+			},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePrivate, MapPublicIPOnLaunch: fi.PtrTo(false)},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].mapPublicIPOnLaunch"},
+		},
+		{
+			Input: []kops.ClusterSubnetSpec{
+				{Name: "a", ID: "subnet-a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePublic, MapPublicIPOnLaunch: fi.PtrTo(false)},
+			},
+			ExpectedErrors: []string{"Forbidden::subnets[0].mapPublicIPOnLaunch"},
+		},
 	}
 	for _, g := range grid {
 		cluster := &kops.ClusterSpec{
@@ -310,6 +390,49 @@ func TestValidateKubeAPIServer(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Unsupported value::KubeAPIServer.logFormat"},
 		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				ServiceAccountIssuer:            fi.PtrTo("https://new.example.com"),
+				AdditionalServiceAccountIssuers: []string{"https://old.example.com", "https://api.internal.example.com"},
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				ServiceAccountIssuer:            fi.PtrTo("https://new.example.com"),
+				AdditionalServiceAccountIssuers: []string{"old.example.com", "https://old.example.com?a=b"},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::KubeAPIServer.additionalServiceAccountIssuers[0]",
+				"Invalid value::KubeAPIServer.additionalServiceAccountIssuers[1]",
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				ServiceAccountIssuer:            fi.PtrTo("https://new.example.com"),
+				AdditionalServiceAccountIssuers: []string{"https://old.example.com", "https://new.example.com", "https://old.example.com"},
+			},
+			ExpectedErrors: []string{
+				"Duplicate value::KubeAPIServer.additionalServiceAccountIssuers[1]",
+				"Duplicate value::KubeAPIServer.additionalServiceAccountIssuers[2]",
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				ServiceAccountMaxTokenExpiration: &metav1.Duration{Duration: 48 * time.Hour},
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				ServiceAccountMaxTokenExpiration: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			ExpectedErrors: []string{"Invalid value::KubeAPIServer.serviceAccountMaxTokenExpiration"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				ServiceAccountMaxTokenExpiration: &metav1.Duration{Duration: 31 * 24 * time.Hour},
+			},
+			ExpectedErrors: []string{"Invalid value::KubeAPIServer.serviceAccountMaxTokenExpiration"},
+		},
 	}
 	for _, g := range grid {
 		if g.Cluster == nil {
@@ -341,6 +464,81 @@ func TestValidateKubeAPIServer(t *testing.T) {
 	}
 }
 
+func TestServiceAccountTokenWarnings(t *testing.T) {
+	grid := []struct {
+		name             string
+		spec             kops.ClusterSpec
+		expectedWarnings []string
+	}{
+		{
+			name: "defaults",
+			spec: kops.ClusterSpec{KubernetesVersion: "1.25.0"},
+		},
+		{
+			name: "long max token expiration",
+			spec: kops.ClusterSpec{
+				KubernetesVersion: "1.25.0",
+				KubeAPIServer: &kops.KubeAPIServerConfig{
+					ServiceAccountMaxTokenExpiration: &metav1.Duration{Duration: 48 * time.Hour},
+				},
+			},
+		},
+		{
+			name: "short max token expiration",
+			spec: kops.ClusterSpec{
+				KubernetesVersion: "1.25.0",
+				KubeAPIServer: &kops.KubeAPIServerConfig{
+					ServiceAccountMaxTokenExpiration: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+			expectedWarnings: []string{"serviceAccountMaxTokenExpiration: is set to 2h0m0s"},
+		},
+		{
+			name: "legacy token auto-creation on kube-controller-manager",
+			spec: kops.ClusterSpec{
+				KubernetesVersion: "1.25.0",
+				KubeControllerManager: &kops.KubeControllerManagerConfig{
+					FeatureGates: map[string]string{"LegacyServiceAccountTokenNoAutoGeneration": "false"},
+				},
+			},
+			expectedWarnings: []string{"LegacyServiceAccountTokenNoAutoGeneration feature gate is disabled"},
+		},
+		{
+			name: "legacy token auto-creation on kube-apiserver",
+			spec: kops.ClusterSpec{
+				KubernetesVersion: "1.24.0",
+				KubeAPIServer: &kops.KubeAPIServerConfig{
+					FeatureGates: map[string]string{"LegacyServiceAccountTokenNoAutoGeneration": "false"},
+				},
+			},
+			expectedWarnings: []string{"LegacyServiceAccountTokenNoAutoGeneration feature gate is disabled"},
+		},
+		{
+			name: "legacy token auto-creation before deprecation",
+			spec: kops.ClusterSpec{
+				KubernetesVersion: "1.23.0",
+				KubeControllerManager: &kops.KubeControllerManagerConfig{
+					FeatureGates: map[string]string{"LegacyServiceAccountTokenNoAutoGeneration": "false"},
+				},
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			warnings := serviceAccountTokenWarnings(&kops.Cluster{Spec: g.spec})
+			if len(warnings) != len(g.expectedWarnings) {
+				t.Fatalf("expected %d warnings, got %q", len(g.expectedWarnings), warnings)
+			}
+			for i, expected := range g.expectedWarnings {
+				if !strings.Contains(warnings[i].String(), expected) {
+					t.Errorf("expected warning %q to contain %q", warnings[i], expected)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateKubeControllermanager(t *testing.T) {
 	grid := []struct {
 		Input          kops.KubeControllerManagerConfig
@@ -453,173 +651,474 @@ func Test_Validate_Networking_Flannel(t *testing.T) {
 	}
 }
 
-func Test_Validate_AdditionalPolicies(t *testing.T) {
+func Test_Validate_DefaultComponentNetworkPolicies(t *testing.T) {
 	grid := []struct {
-		Input          map[string]string
+		Input          kops.NetworkingSpec
 		ExpectedErrors []string
 	}{
 		{
-			Input: map[string]string{},
-		},
-		{
-			Input: map[string]string{
-				"control-plane": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ], "Effect": "Allow" } ]`,
+			Input: kops.NetworkingSpec{
+				Calico:                          &kops.CalicoNetworkingSpec{},
+				DefaultComponentNetworkPolicies: fi.PtrTo(true),
 			},
 		},
 		{
-			Input: map[string]string{
-				"notarole": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ], "Effect": "Allow" } ]`,
+			Input: kops.NetworkingSpec{
+				Cilium:                          &kops.CiliumNetworkingSpec{Version: "v1.14.5"},
+				DefaultComponentNetworkPolicies: fi.PtrTo(true),
 			},
-			ExpectedErrors: []string{"Unsupported value::spec.additionalPolicies"},
 		},
 		{
-			Input: map[string]string{
-				"control-plane": `badjson`,
+			Input: kops.NetworkingSpec{
+				Kubenet:                         &kops.KubenetNetworkingSpec{},
+				DefaultComponentNetworkPolicies: fi.PtrTo(false),
 			},
-			ExpectedErrors: []string{"Invalid value::spec.additionalPolicies[control-plane]"},
 		},
 		{
-			Input: map[string]string{
-				"control-plane": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ] } ]`,
+			Input: kops.NetworkingSpec{
+				Kubenet:                         &kops.KubenetNetworkingSpec{},
+				DefaultComponentNetworkPolicies: fi.PtrTo(true),
 			},
-			ExpectedErrors: []string{"Required value::spec.additionalPolicies[control-plane][0].Effect"},
+			ExpectedErrors: []string{"Forbidden::networking.defaultComponentNetworkPolicies"},
 		},
 		{
-			Input: map[string]string{
-				"control-plane": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ], "Effect": "allow" } ]`,
+			Input: kops.NetworkingSpec{
+				CNI:                             &kops.CNINetworkingSpec{},
+				DefaultComponentNetworkPolicies: fi.PtrTo(true),
 			},
-			ExpectedErrors: []string{"Unsupported value::spec.additionalPolicies[control-plane][0].Effect"},
+			ExpectedErrors: []string{"Forbidden::networking.defaultComponentNetworkPolicies"},
 		},
 	}
 	for _, g := range grid {
-		clusterSpec := &kops.ClusterSpec{
-			KubernetesVersion:  "1.17.0",
-			AdditionalPolicies: g.Input,
-			CloudProvider: kops.CloudProviderSpec{
-				AWS: &kops.AWSSpec{},
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: "1.27.0",
+				Networking:        g.Input,
 			},
-			Networking: kops.NetworkingSpec{
-				NetworkCIDR:           "10.10.0.0/16",
-				NonMasqueradeCIDR:     "100.64.0.0/10",
-				PodCIDR:               "100.96.0.0/11",
-				ServiceClusterIPRange: "100.64.0.0/13",
-				Subnets: []kops.ClusterSubnetSpec{
-					{
-						Name: "subnet1",
-						Type: kops.SubnetTypePublic,
-						CIDR: "10.10.10.0/24",
-					},
-				},
+		}
+		cluster.Spec.Networking.NetworkCIDR = "10.0.0.0/16"
+		cluster.Spec.Networking.NonMasqueradeCIDR = "100.64.0.0/10"
+		cluster.Spec.Networking.PodCIDR = "100.96.0.0/11"
+		cluster.Spec.Networking.ServiceClusterIPRange = "100.64.0.0/13"
+		cluster.Spec.Networking.Subnets = []kops.ClusterSubnetSpec{
+			{
+				Name: "a",
+				CIDR: "10.0.0.0/24",
+				Type: kops.SubnetTypePublic,
 			},
-			EtcdClusters: []kops.EtcdClusterSpec{
-				{
-					Name: "main",
-					Members: []kops.EtcdMemberSpec{
+		}
+
+		errs := validateNetworking(cluster, &cluster.Spec.Networking, field.NewPath("networking"), true, &cloudProviderConstraints{})
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AdditionalNetworkCIDRs(t *testing.T) {
+	grid := []struct {
+		Input          []string
+		ExpectedErrors []string
+	}{
+		{
+			Input: []string{"10.1.0.0/16", "10.2.0.0/16"},
+		},
+		{
+			Input:          []string{"10.0.128.0/20"},
+			ExpectedErrors: []string{"Invalid value::networking.additionalNetworkCIDRs[0]"},
+		},
+		{
+			Input:          []string{"10.0.0.0/16"},
+			ExpectedErrors: []string{"Duplicate value::networking.additionalNetworkCIDRs[0]"},
+		},
+		{
+			Input:          []string{"10.1.0.0/16", "10.1.0.0/16"},
+			ExpectedErrors: []string{"Duplicate value::networking.additionalNetworkCIDRs[1]"},
+		},
+		{
+			Input:          []string{"10.0.0.0/8"},
+			ExpectedErrors: []string{"Invalid value::networking.additionalNetworkCIDRs[0]"},
+		},
+		{
+			Input:          []string{"10.1.0.0/16", "10.1.64.0/18"},
+			ExpectedErrors: []string{"Invalid value::networking.additionalNetworkCIDRs[1]"},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: "1.27.0",
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+				Networking: kops.NetworkingSpec{
+					NetworkCIDR:            "10.0.0.0/16",
+					AdditionalNetworkCIDRs: g.Input,
+					NonMasqueradeCIDR:      "100.64.0.0/10",
+					PodCIDR:                "100.96.0.0/11",
+					ServiceClusterIPRange:  "100.64.0.0/13",
+					Subnets: []kops.ClusterSubnetSpec{
 						{
-							Name:          "us-test-1a",
-							InstanceGroup: fi.PtrTo("master-us-test-1a"),
+							Name: "a",
+							CIDR: "10.0.0.0/24",
+							Type: kops.SubnetTypePublic,
 						},
 					},
 				},
 			},
 		}
-		errs := validateClusterSpec(clusterSpec, &kops.Cluster{Spec: *clusterSpec}, field.NewPath("spec"), true)
+
+		errs := validateNetworking(cluster, &cluster.Spec.Networking, field.NewPath("networking"), true, &cloudProviderConstraints{})
 		testErrors(t, g.Input, errs, g.ExpectedErrors)
 	}
 }
 
-type caliInput struct {
-	Cluster *kops.ClusterSpec
-	Calico  *kops.CalicoNetworkingSpec
-}
-
-func Test_Validate_Calico(t *testing.T) {
+func Test_Validate_AddonDefaults(t *testing.T) {
 	grid := []struct {
-		Description    string
-		Input          caliInput
+		Input          kops.AddonDefaultsSpec
 		ExpectedErrors []string
 	}{
 		{
-			Description: "empty specs",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{},
-			},
-		},
-		{
-			Description: "positive Typha replica count",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					TyphaReplicas: 3,
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "platform", Effect: corev1.TaintEffectNoSchedule},
+					{Operator: corev1.TolerationOpExists},
+					{Key: "node.kubernetes.io/unreachable", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: fi.PtrTo(int64(300))},
 				},
+				RuntimeClassName: fi.PtrTo("gvisor"),
+				NodeSelector:     map[string]string{"dedicated": "platform"},
 			},
 		},
 		{
-			Description: "negative Typha replica count",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					TyphaReplicas: -1,
-				},
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{{Key: "not a key", Value: "platform"}},
 			},
-			ExpectedErrors: []string{"Invalid value::calico.typhaReplicas"},
+			ExpectedErrors: []string{"Invalid value::addonDefaults.tolerations[0].key"},
 		},
 		{
-			Description: "with etcd version",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{},
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{{Value: "platform"}},
 			},
+			ExpectedErrors: []string{"Invalid value::addonDefaults.tolerations[0].operator"},
 		},
 		{
-			Description: "IPv4 autodetection method",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					IPv4AutoDetectionMethod: "first-found",
-				},
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "platform"}},
 			},
+			ExpectedErrors: []string{"Invalid value::addonDefaults.tolerations[0].value"},
 		},
 		{
-			Description: "IPv6 autodetection method",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					IPv6AutoDetectionMethod: "first-found",
-				},
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: "In", Value: "platform"}},
 			},
+			ExpectedErrors: []string{"Unsupported value::addonDefaults.tolerations[0].operator"},
 		},
 		{
-			Description: "IPv4 autodetection method with parameter",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					IPv4AutoDetectionMethod: "can-reach=8.8.8.8",
-				},
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Value: "platform", Effect: "NoRun"}},
 			},
+			ExpectedErrors: []string{"Unsupported value::addonDefaults.tolerations[0].effect"},
 		},
 		{
-			Description: "IPv6 autodetection method with parameter",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					IPv6AutoDetectionMethod: "can-reach=2001:4860:4860::8888",
-				},
+			Input: kops.AddonDefaultsSpec{
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Value: "platform", Effect: corev1.TaintEffectNoSchedule, TolerationSeconds: fi.PtrTo(int64(300))}},
 			},
+			ExpectedErrors: []string{"Invalid value::addonDefaults.tolerations[0].effect"},
 		},
 		{
-			Description: "invalid IPv4 autodetection method",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					IPv4AutoDetectionMethod: "bogus",
-				},
+			Input: kops.AddonDefaultsSpec{
+				RuntimeClassName: fi.PtrTo("Not_Valid"),
 			},
-			ExpectedErrors: []string{"Invalid value::calico.ipv4AutoDetectionMethod"},
+			ExpectedErrors: []string{"Invalid value::addonDefaults.runtimeClassName"},
 		},
 		{
-			Description: "invalid IPv6 autodetection method",
-			Input: caliInput{
-				Calico: &kops.CalicoNetworkingSpec{
-					IPv6AutoDetectionMethod: "bogus",
-				},
+			Input: kops.AddonDefaultsSpec{
+				NodeSelector: map[string]string{"dedicated": "not a value"},
 			},
-			ExpectedErrors: []string{"Invalid value::calico.ipv6AutoDetectionMethod"},
+			ExpectedErrors: []string{"Invalid value::addonDefaults.nodeSelector"},
 		},
-		{
-			Description: "invalid IPv6 autodetection method missing parameter",
+	}
+	for _, g := range grid {
+		errs := validateAddonDefaults(&g.Input, field.NewPath("addonDefaults"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_PermissionsBoundary(t *testing.T) {
+	grid := []struct {
+		Boundary       string
+		ExpectedErrors []string
+	}{
+		{
+			Boundary: "arn:aws:iam::123456789012:policy/boundaries",
+		},
+		{
+			Boundary: "arn:aws-us-gov:iam::123456789012:policy/org/boundaries",
+		},
+		{
+			Boundary:       "boundaries",
+			ExpectedErrors: []string{"Invalid value::spec.iam.permissionsBoundary"},
+		},
+		{
+			Boundary:       "arn:aws:iam::123456789012:role/boundaries",
+			ExpectedErrors: []string{"Invalid value::spec.iam.permissionsBoundary"},
+		},
+		{
+			Boundary:       "arn:aws:iam::123456789012:policy/",
+			ExpectedErrors: []string{"Invalid value::spec.iam.permissionsBoundary"},
+		},
+		{
+			Boundary: "arn:aws:iam::aws:policy/PowerUserAccess",
+		},
+		{
+			Boundary:       "arn:aws:iam::1234:policy/boundaries",
+			ExpectedErrors: []string{"Invalid value::spec.iam.permissionsBoundary"},
+		},
+		{
+			Boundary:       "arn:aws:s3:::123456789012:policy/boundaries",
+			ExpectedErrors: []string{"Invalid value::spec.iam.permissionsBoundary"},
+		},
+	}
+	for _, g := range grid {
+		errs := validatePermissionsBoundary(g.Boundary, field.NewPath("spec", "iam", "permissionsBoundary"))
+		testErrors(t, g.Boundary, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_TerraformTarget(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Terraform      kops.TerraformSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "alias",
+			Terraform:   kops.TerraformSpec{ProviderAlias: "cluster"},
+		},
+		{
+			Description:    "invalid alias",
+			Terraform:      kops.TerraformSpec{ProviderAlias: "aws.cluster"},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.providerAlias"},
+		},
+		{
+			Description:    "reserved alias",
+			Terraform:      kops.TerraformSpec{ProviderAlias: "files"},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.providerAlias"},
+		},
+		{
+			Description: "alias in extra config",
+			Terraform: kops.TerraformSpec{
+				ProviderAlias:       "cluster",
+				ProviderExtraConfig: map[string]string{"alias": "other"},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.target.terraform.providerExtraConfig.alias"},
+		},
+		{
+			Description: "assume role",
+			Terraform: kops.TerraformSpec{
+				AssumeRole:  &kops.TerraformAssumeRoleSpec{RoleARN: "arn:aws:iam::123456789012:role/kops", SessionName: "kops"},
+				DefaultTags: map[string]string{"team": "platform"},
+			},
+		},
+		{
+			Description:    "assume role without ARN",
+			Terraform:      kops.TerraformSpec{AssumeRole: &kops.TerraformAssumeRoleSpec{SessionName: "kops"}},
+			ExpectedErrors: []string{"Required value::spec.target.terraform.assumeRole.roleARN"},
+		},
+		{
+			Description:    "assume role with policy ARN",
+			Terraform:      kops.TerraformSpec{AssumeRole: &kops.TerraformAssumeRoleSpec{RoleARN: "arn:aws:iam::123456789012:policy/kops"}},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.assumeRole.roleARN"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateTerraformTarget(&g.Terraform, field.NewPath("spec", "target", "terraform"))
+		testErrors(t, g.Description, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AdditionalPolicies(t *testing.T) {
+	grid := []struct {
+		Input          map[string]string
+		ExpectedErrors []string
+	}{
+		{
+			Input: map[string]string{},
+		},
+		{
+			Input: map[string]string{
+				"control-plane": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ], "Effect": "Allow" } ]`,
+			},
+		},
+		{
+			Input: map[string]string{
+				"notarole": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ], "Effect": "Allow" } ]`,
+			},
+			ExpectedErrors: []string{"Unsupported value::spec.additionalPolicies"},
+		},
+		{
+			Input: map[string]string{
+				"control-plane": `badjson`,
+			},
+			ExpectedErrors: []string{"Invalid value::spec.additionalPolicies[control-plane]"},
+		},
+		{
+			Input: map[string]string{
+				"control-plane": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ] } ]`,
+			},
+			ExpectedErrors: []string{"Required value::spec.additionalPolicies[control-plane][0].Effect"},
+		},
+		{
+			Input: map[string]string{
+				"control-plane": `[ { "Action": [ "s3:GetObject" ], "Resource": [ "*" ], "Effect": "allow" } ]`,
+			},
+			ExpectedErrors: []string{"Unsupported value::spec.additionalPolicies[control-plane][0].Effect"},
+		},
+	}
+	for _, g := range grid {
+		clusterSpec := &kops.ClusterSpec{
+			KubernetesVersion:  "1.17.0",
+			AdditionalPolicies: g.Input,
+			CloudProvider: kops.CloudProviderSpec{
+				AWS: &kops.AWSSpec{},
+			},
+			Networking: kops.NetworkingSpec{
+				NetworkCIDR:           "10.10.0.0/16",
+				NonMasqueradeCIDR:     "100.64.0.0/10",
+				PodCIDR:               "100.96.0.0/11",
+				ServiceClusterIPRange: "100.64.0.0/13",
+				Subnets: []kops.ClusterSubnetSpec{
+					{
+						Name: "subnet1",
+						Type: kops.SubnetTypePublic,
+						CIDR: "10.10.10.0/24",
+					},
+				},
+			},
+			EtcdClusters: []kops.EtcdClusterSpec{
+				{
+					Name: "main",
+					Members: []kops.EtcdMemberSpec{
+						{
+							Name:          "us-test-1a",
+							InstanceGroup: fi.PtrTo("master-us-test-1a"),
+						},
+					},
+				},
+			},
+		}
+		errs := validateClusterSpec(clusterSpec, &kops.Cluster{Spec: *clusterSpec}, field.NewPath("spec"), true)
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+type caliInput struct {
+	Cluster *kops.ClusterSpec
+	Calico  *kops.CalicoNetworkingSpec
+}
+
+func Test_Validate_Calico(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Input          caliInput
+		ExpectedErrors []string
+	}{
+		{
+			Description: "empty specs",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{},
+			},
+		},
+		{
+			Description: "positive Typha replica count",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					TyphaReplicas: 3,
+				},
+			},
+		},
+		{
+			Description: "negative Typha replica count",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					TyphaReplicas: -1,
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::calico.typhaReplicas"},
+		},
+		{
+			Description: "upgrade max unavailable",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					UpgradeMaxUnavailable: intStr(intstr.FromInt(2)),
+				},
+			},
+		},
+		{
+			Description: "zero upgrade max unavailable",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					UpgradeMaxUnavailable: intStr(intstr.FromString("0%")),
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::calico.upgradeMaxUnavailable"},
+		},
+		{
+			Description: "with etcd version",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{},
+			},
+		},
+		{
+			Description: "IPv4 autodetection method",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					IPv4AutoDetectionMethod: "first-found",
+				},
+			},
+		},
+		{
+			Description: "IPv6 autodetection method",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					IPv6AutoDetectionMethod: "first-found",
+				},
+			},
+		},
+		{
+			Description: "IPv4 autodetection method with parameter",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					IPv4AutoDetectionMethod: "can-reach=8.8.8.8",
+				},
+			},
+		},
+		{
+			Description: "IPv6 autodetection method with parameter",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					IPv6AutoDetectionMethod: "can-reach=2001:4860:4860::8888",
+				},
+			},
+		},
+		{
+			Description: "invalid IPv4 autodetection method",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					IPv4AutoDetectionMethod: "bogus",
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::calico.ipv4AutoDetectionMethod"},
+		},
+		{
+			Description: "invalid IPv6 autodetection method",
+			Input: caliInput{
+				Calico: &kops.CalicoNetworkingSpec{
+					IPv6AutoDetectionMethod: "bogus",
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::calico.ipv6AutoDetectionMethod"},
+		},
+		{
+			Description: "invalid IPv6 autodetection method missing parameter",
 			Input: caliInput{
 				Calico: &kops.CalicoNetworkingSpec{
 					IPv6AutoDetectionMethod: "interface=",
@@ -910,33 +1409,152 @@ func Test_Validate_Calico(t *testing.T) {
 	}
 }
 
-func Test_Validate_Cilium(t *testing.T) {
+func Test_Validate_AmazonVPC(t *testing.T) {
 	grid := []struct {
-		Cilium         kops.CiliumNetworkingSpec
-		Spec           kops.ClusterSpec
-		ExpectedErrors []string
+		AmazonVPC         kops.AmazonVPCNetworkingSpec
+		KubernetesVersion string
+		Calico            bool
+		ExpectedErrors    []string
 	}{
 		{
-			Cilium: kops.CiliumNetworkingSpec{},
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{},
 		},
 		{
-			Cilium: kops.CiliumNetworkingSpec{
-				IPAM: "crd",
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled:              fi.PtrTo(true),
+					EnableCloudWatchLogs: fi.PtrTo(true),
+				},
 			},
 		},
 		{
-			Cilium: kops.CiliumNetworkingSpec{
-				IPAM: "eni",
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				Image: "602401143452.dkr.ecr.us-west-2.amazonaws.com/amazon-k8s-cni:v1.14.1",
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled: fi.PtrTo(true),
+				},
 			},
-			Spec: kops.ClusterSpec{
-				CloudProvider: kops.CloudProviderSpec{
-					AWS: &kops.AWSSpec{},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				Image: "registry.example.com:5000/amazon-k8s-cni",
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled: fi.PtrTo(true),
 				},
 			},
 		},
 		{
-			Cilium: kops.CiliumNetworkingSpec{
-				ClusterID: 253,
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				Image: "602401143452.dkr.ecr.us-west-2.amazonaws.com/amazon-k8s-cni:v1.13.4",
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled: fi.PtrTo(true),
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::amazonVPC.networkPolicyAgent.enabled"},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				Image: "602401143452.dkr.ecr.us-west-2.amazonaws.com/amazon-k8s-cni:v1.13.4",
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled: fi.PtrTo(false),
+				},
+			},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled: fi.PtrTo(true),
+				},
+			},
+			KubernetesVersion: "1.24.0",
+			ExpectedErrors:    []string{"Forbidden::amazonVPC.networkPolicyAgent.enabled"},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				NetworkPolicyAgent: &kops.AmazonVPCNetworkPolicyAgentSpec{
+					Enabled: fi.PtrTo(true),
+				},
+			},
+			Calico:         true,
+			ExpectedErrors: []string{"Forbidden::amazonVPC.networkPolicyAgent.enabled"},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				PrefixTargets: &kops.AmazonVPCPrefixTargetsSpec{
+					WarmPrefixTarget: fi.PtrTo(int32(2)),
+					WarmIPTarget:     fi.PtrTo(int32(0)),
+					MinimumIPTarget:  fi.PtrTo(int32(16)),
+				},
+			},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				PrefixTargets: &kops.AmazonVPCPrefixTargetsSpec{
+					WarmIPTarget: fi.PtrTo(int32(-1)),
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::amazonVPC.prefixTargets.warmIPTarget"},
+		},
+		{
+			AmazonVPC: kops.AmazonVPCNetworkingSpec{
+				Env: []kops.EnvVar{
+					{Name: "AWS_VPC_K8S_CNI_LOGLEVEL", Value: "INFO"},
+					{Name: "WARM_PREFIX_TARGET", Value: "2"},
+				},
+				PrefixTargets: &kops.AmazonVPCPrefixTargetsSpec{
+					WarmPrefixTarget: fi.PtrTo(int32(1)),
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::amazonVPC.env[1]"},
+		},
+	}
+	for _, g := range grid {
+		if g.KubernetesVersion == "" {
+			g.KubernetesVersion = "1.28.0"
+		}
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: g.KubernetesVersion,
+				Networking: kops.NetworkingSpec{
+					AmazonVPC: &g.AmazonVPC,
+				},
+			},
+		}
+		if g.Calico {
+			cluster.Spec.Networking.Calico = &kops.CalicoNetworkingSpec{}
+		}
+		errs := validateNetworkingAmazonVPC(cluster, &g.AmazonVPC, field.NewPath("amazonVPC"))
+		testErrors(t, g.AmazonVPC, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_Cilium(t *testing.T) {
+	grid := []struct {
+		Cilium         kops.CiliumNetworkingSpec
+		Spec           kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Cilium: kops.CiliumNetworkingSpec{},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				IPAM: "crd",
+			},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				IPAM: "eni",
+			},
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				ClusterID: 253,
 			},
 		},
 		{
@@ -1053,6 +1671,23 @@ func Test_Validate_Cilium(t *testing.T) {
 				},
 			},
 		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				UpgradeMaxUnavailable: intStr(intstr.FromString("10%")),
+			},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				UpgradeMaxUnavailable: intStr(intstr.FromInt(0)),
+			},
+			ExpectedErrors: []string{"Invalid value::cilium.upgradeMaxUnavailable"},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				UpgradeMaxUnavailable: intStr(intstr.FromString("all")),
+			},
+			ExpectedErrors: []string{"Invalid value::cilium.upgradeMaxUnavailable"},
+		},
 	}
 	for _, g := range grid {
 		g.Spec.Networking = kops.NetworkingSpec{
@@ -1198,6 +1833,52 @@ func Test_Validate_RollingUpdate(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Forbidden::testField.maxSurge"},
 		},
+		{
+			Input: kops.RollingUpdate{
+				SurgeMode: kops.RollingUpdateSurgeModeDetach,
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				SurgeMode: kops.RollingUpdateSurgeModeScaleOut,
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				SurgeMode: "Sideways",
+			},
+			ExpectedErrors: []string{"Unsupported value::testField.surgeMode"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				DrainTimeout:       &metav1.Duration{Duration: 5 * time.Minute},
+				DrainTimeoutPolicy: kops.RollingUpdateDrainTimeoutPolicySkip,
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				DrainTimeout: &metav1.Duration{Duration: 0},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.drainTimeout"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				DrainTimeoutPolicy: "Wait",
+			},
+			ExpectedErrors: []string{"Unsupported value::testField.drainTimeoutPolicy"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				JoinTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				RetryJoin:   fi.PtrTo(true),
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				JoinTimeout: &metav1.Duration{Duration: -time.Minute},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.joinTimeout"},
+		},
 	}
 	for _, g := range grid {
 		errs := validateRollingUpdate(&g.Input, field.NewPath("testField"), g.OnMasterIG)
@@ -1205,204 +1886,815 @@ func Test_Validate_RollingUpdate(t *testing.T) {
 	}
 }
 
-func intStr(i intstr.IntOrString) *intstr.IntOrString {
-	return &i
-}
+func Test_CrossValidate_RollingUpdate(t *testing.T) {
+	grid := []struct {
+		Description    string
+		CloudProvider  kops.CloudProviderSpec
+		Cluster        *kops.RollingUpdate
+		InstanceGroup  *kops.RollingUpdate
+		Manager        kops.InstanceManager
+		ExpectedErrors []string
+	}{
+		{
+			Description:   "aws with zero maxUnavailable uses the default surge",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			InstanceGroup: &kops.RollingUpdate{
+				MaxUnavailable: intStr(intstr.FromInt(0)),
+			},
+		},
+		{
+			Description:   "gce with zero maxUnavailable and no surge",
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			InstanceGroup: &kops.RollingUpdate{
+				MaxUnavailable: intStr(intstr.FromInt(0)),
+			},
+			ExpectedErrors: []string{"Forbidden::spec.rollingUpdate.maxUnavailable"},
+		},
+		{
+			Description:   "gce with zero maxUnavailable from the cluster and no surge",
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Cluster: &kops.RollingUpdate{
+				MaxUnavailable: intStr(intstr.FromInt(0)),
+			},
+			InstanceGroup:  &kops.RollingUpdate{},
+			ExpectedErrors: []string{"Forbidden::spec.rollingUpdate.maxUnavailable"},
+		},
+		{
+			Description:   "gce with zero maxUnavailable and surge",
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			InstanceGroup: &kops.RollingUpdate{
+				MaxUnavailable: intStr(intstr.FromInt(0)),
+				MaxSurge:       intStr(intstr.FromInt(1)),
+			},
+		},
+		{
+			Description:   "karpenter with zero maxUnavailable and no surge",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			InstanceGroup: &kops.RollingUpdate{
+				MaxUnavailable: intStr(intstr.FromInt(0)),
+			},
+			Manager:        kops.InstanceManagerKarpenter,
+			ExpectedErrors: []string{"Forbidden::spec.rollingUpdate.maxUnavailable"},
+		},
+		{
+			Description:   "aws with scale out",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			InstanceGroup: &kops.RollingUpdate{
+				SurgeMode: kops.RollingUpdateSurgeModeScaleOut,
+			},
+		},
+		{
+			Description:   "aws with scale out from the cluster",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Cluster: &kops.RollingUpdate{
+				SurgeMode: kops.RollingUpdateSurgeModeScaleOut,
+			},
+			InstanceGroup: &kops.RollingUpdate{},
+		},
+		{
+			Description:   "karpenter with scale out",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			InstanceGroup: &kops.RollingUpdate{
+				SurgeMode: kops.RollingUpdateSurgeModeScaleOut,
+			},
+			Manager:        kops.InstanceManagerKarpenter,
+			ExpectedErrors: []string{"Forbidden::spec.rollingUpdate.surgeMode"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.CloudProvider,
+					RollingUpdate: g.Cluster,
+				},
+			}
+			ig := &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					Manager:       g.Manager,
+					RollingUpdate: g.InstanceGroup,
+				},
+			}
+			errs := crossValidateRollingUpdate(cluster, ig, field.NewPath("spec", "rollingUpdate"))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func intStr(i intstr.IntOrString) *intstr.IntOrString {
+	return &i
+}
+
+func Test_Validate_NodeLocalDNS(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				KubeProxy: &kops.KubeProxyConfig{
+					ProxyMode: "iptables",
+				},
+				KubeDNS: &kops.KubeDNSConfig{
+					Provider: "CoreDNS",
+					NodeLocalDNS: &kops.NodeLocalDNSConfig{
+						Enabled: fi.PtrTo(true),
+					},
+				},
+			},
+			ExpectedErrors: []string{},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Kubelet: &kops.KubeletConfigSpec{
+					ClusterDNS: "100.64.0.10",
+				},
+				KubeProxy: &kops.KubeProxyConfig{
+					ProxyMode: "ipvs",
+				},
+				KubeDNS: &kops.KubeDNSConfig{
+					Provider: "CoreDNS",
+					NodeLocalDNS: &kops.NodeLocalDNSConfig{
+						Enabled: fi.PtrTo(true),
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.kubelet.clusterDNS"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Kubelet: &kops.KubeletConfigSpec{
+					ClusterDNS: "100.64.0.10",
+				},
+				KubeProxy: &kops.KubeProxyConfig{
+					ProxyMode: "ipvs",
+				},
+				KubeDNS: &kops.KubeDNSConfig{
+					Provider: "CoreDNS",
+					NodeLocalDNS: &kops.NodeLocalDNSConfig{
+						Enabled: fi.PtrTo(true),
+					},
+				},
+				Networking: kops.NetworkingSpec{
+					Cilium: &kops.CiliumNetworkingSpec{},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.kubelet.clusterDNS"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Kubelet: &kops.KubeletConfigSpec{
+					ClusterDNS: "169.254.20.10",
+				},
+				KubeProxy: &kops.KubeProxyConfig{
+					ProxyMode: "iptables",
+				},
+				KubeDNS: &kops.KubeDNSConfig{
+					Provider: "CoreDNS",
+					NodeLocalDNS: &kops.NodeLocalDNSConfig{
+						Enabled: fi.PtrTo(true),
+						LocalIP: "169.254.20.10",
+					},
+				},
+				Networking: kops.NetworkingSpec{
+					Cilium: &kops.CiliumNetworkingSpec{},
+				},
+			},
+			ExpectedErrors: []string{},
+		},
+	}
+
+	for _, g := range grid {
+		errs := validateNodeLocalDNS(&g.Input, field.NewPath("spec"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_CloudConfiguration(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Input          kops.CloudConfiguration
+		CloudProvider  kops.CloudProviderSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "neither",
+			Input:       kops.CloudConfiguration{},
+		},
+		{
+			Description: "all false",
+			Input: kops.CloudConfiguration{
+				ManageStorageClasses: fi.PtrTo(false),
+			},
+		},
+		{
+			Description: "all true",
+			Input: kops.CloudConfiguration{
+				ManageStorageClasses: fi.PtrTo(true),
+			},
+		},
+		{
+			Description: "os false",
+			Input:       kops.CloudConfiguration{},
+			CloudProvider: kops.CloudProviderSpec{
+				Openstack: &kops.OpenstackSpec{
+					BlockStorage: &kops.OpenstackBlockStorageConfig{
+						CreateStorageClass: fi.PtrTo(false),
+					},
+				},
+			},
+		},
+		{
+			Description: "os true",
+			Input:       kops.CloudConfiguration{},
+			CloudProvider: kops.CloudProviderSpec{
+				Openstack: &kops.OpenstackSpec{
+					BlockStorage: &kops.OpenstackBlockStorageConfig{
+						CreateStorageClass: fi.PtrTo(true),
+					},
+				},
+			},
+		},
+		{
+			Description: "all false, os false",
+			Input: kops.CloudConfiguration{
+				ManageStorageClasses: fi.PtrTo(false),
+			},
+			CloudProvider: kops.CloudProviderSpec{
+				Openstack: &kops.OpenstackSpec{
+					BlockStorage: &kops.OpenstackBlockStorageConfig{
+						CreateStorageClass: fi.PtrTo(false),
+					},
+				},
+			},
+		},
+		{
+			Description: "all false, os true",
+			Input: kops.CloudConfiguration{
+				ManageStorageClasses: fi.PtrTo(false),
+			},
+			CloudProvider: kops.CloudProviderSpec{
+				Openstack: &kops.OpenstackSpec{
+					BlockStorage: &kops.OpenstackBlockStorageConfig{
+						CreateStorageClass: fi.PtrTo(true),
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::cloudConfig.manageStorageClasses"},
+		},
+		{
+			Description: "all true, os false",
+			Input: kops.CloudConfiguration{
+				ManageStorageClasses: fi.PtrTo(true),
+			},
+			CloudProvider: kops.CloudProviderSpec{
+				Openstack: &kops.OpenstackSpec{
+					BlockStorage: &kops.OpenstackBlockStorageConfig{
+						CreateStorageClass: fi.PtrTo(false),
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::cloudConfig.manageStorageClasses"},
+		},
+		{
+			Description: "all true, os true",
+			Input: kops.CloudConfiguration{
+				ManageStorageClasses: fi.PtrTo(true),
+			},
+			CloudProvider: kops.CloudProviderSpec{
+				Openstack: &kops.OpenstackSpec{
+					BlockStorage: &kops.OpenstackBlockStorageConfig{
+						CreateStorageClass: fi.PtrTo(true),
+					},
+				},
+			},
+		},
+	}
+
+	for _, g := range grid {
+		fldPath := field.NewPath("cloudConfig")
+		t.Run(g.Description, func(t *testing.T) {
+			spec := &kops.ClusterSpec{
+				CloudProvider: g.CloudProvider,
+			}
+			errs := validateCloudConfiguration(&g.Input, spec, fldPath)
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_ExternalDNSPolicyScope(t *testing.T) {
+	grid := []struct {
+		PolicyScope    kops.ExternalDNSPolicyScope
+		ExpectedErrors []string
+	}{
+		{
+			PolicyScope: "",
+		},
+		{
+			PolicyScope: kops.ExternalDNSPolicyScopeClusterZone,
+		},
+		{
+			PolicyScope: kops.ExternalDNSPolicyScopeAllZones,
+		},
+		{
+			PolicyScope:    "SomeZones",
+			ExpectedErrors: []string{"Unsupported value::externalDNS.policyScope"},
+		},
+	}
+
+	for _, g := range grid {
+		spec := &kops.ExternalDNSConfig{
+			PolicyScope: g.PolicyScope,
+		}
+		errs := validateExternalDNS(&kops.Cluster{}, spec, field.NewPath("externalDNS"))
+		testErrors(t, g.PolicyScope, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_NodeIPFamilies(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Families       []string
+		IPv6           bool
+		Version        string
+		Flannel        bool
+		NoIPv6CIDR     bool
+		AWSFamilies    []string
+		ExpectedErrors []string
+	}{
+		{
+			Description: "v4-only",
+			Families:    []string{"ipv4"},
+		},
+		{
+			Description: "v4-primary",
+			Families:    []string{"ipv4", "ipv6"},
+		},
+		{
+			Description: "v6-only",
+			Families:    []string{"ipv6"},
+			IPv6:        true,
+		},
+		{
+			Description: "v6-primary",
+			Families:    []string{"ipv6", "ipv4"},
+			IPv6:        true,
+		},
+		{
+			Description:    "unknown family",
+			Families:       []string{"ipv5"},
+			ExpectedErrors: []string{"Unsupported value::spec.nodeIPFamilies[0]"},
+		},
+		{
+			Description:    "duplicate family",
+			Families:       []string{"ipv4", "ipv4"},
+			ExpectedErrors: []string{"Duplicate value::spec.nodeIPFamilies[1]"},
+		},
+		{
+			Description:    "too many families",
+			Families:       []string{"ipv4", "ipv6", "ipv4"},
+			ExpectedErrors: []string{"Too many::spec.nodeIPFamilies", "Duplicate value::spec.nodeIPFamilies[2]"},
+		},
+		{
+			Description:    "IPv6 first in IPv4 cluster",
+			Families:       []string{"ipv6", "ipv4"},
+			ExpectedErrors: []string{"Forbidden::spec.nodeIPFamilies[0]"},
+		},
+		{
+			Description:    "IPv4 first in IPv6 cluster",
+			Families:       []string{"ipv4", "ipv6"},
+			IPv6:           true,
+			ExpectedErrors: []string{"Forbidden::spec.nodeIPFamilies[0]"},
+		},
+		{
+			Description:    "dual-stack on old Kubernetes",
+			Families:       []string{"ipv4", "ipv6"},
+			Version:        "1.28.0",
+			ExpectedErrors: []string{"Forbidden::spec.nodeIPFamilies"},
+		},
+		{
+			Description:    "CNI without IPv6 support",
+			Families:       []string{"ipv4", "ipv6"},
+			Flannel:        true,
+			ExpectedErrors: []string{"Forbidden::spec.nodeIPFamilies"},
+		},
+		{
+			Description: "CNI without IPv6 support, IPv4 only",
+			Families:    []string{"ipv4"},
+			Flannel:     true,
+		},
+		{
+			Description:    "subnet without IPv6 CIDR",
+			Families:       []string{"ipv4", "ipv6"},
+			NoIPv6CIDR:     true,
+			ExpectedErrors: []string{"Forbidden::spec.nodeIPFamilies"},
+		},
+		{
+			Description:    "deprecated cloud provider field also set",
+			Families:       []string{"ipv4"},
+			AWSFamilies:    []string{"ipv4"},
+			ExpectedErrors: []string{"Forbidden::spec.cloudProvider.aws.nodeIPFamilies"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					KubernetesVersion: "1.29.0",
+					NodeIPFamilies:    g.Families,
+					Networking: kops.NetworkingSpec{
+						NonMasqueradeCIDR: "100.64.0.0/10",
+						Subnets: []kops.ClusterSubnetSpec{
+							{
+								Name:     "us-east-1a",
+								Type:     kops.SubnetTypePrivate,
+								CIDR:     "10.0.0.0/24",
+								IPv6CIDR: "2001:db8::/64",
+							},
+							{
+								Name: "utility-us-east-1a",
+								Type: kops.SubnetTypeUtility,
+								CIDR: "10.0.1.0/24",
+							},
+						},
+						Calico: &kops.CalicoNetworkingSpec{},
+					},
+				},
+			}
+			cluster.Spec.CloudProvider.AWS = &kops.AWSSpec{NodeIPFamilies: g.AWSFamilies}
+			if g.Version != "" {
+				cluster.Spec.KubernetesVersion = g.Version
+			}
+			if g.IPv6 {
+				cluster.Spec.Networking.NonMasqueradeCIDR = "::/0"
+			}
+			if g.Flannel {
+				cluster.Spec.Networking.Calico = nil
+				cluster.Spec.Networking.Flannel = &kops.FlannelNetworkingSpec{}
+			}
+			if g.NoIPv6CIDR {
+				cluster.Spec.Networking.Subnets[0].IPv6CIDR = ""
+			}
+
+			errs := validateNodeIPFamilies(cluster, g.Families, field.NewPath("spec", "nodeIPFamilies"), true)
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_TagPolicy(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Rules          []kops.TagPolicyRule
+		ExpectedErrors []string
+	}{
+		{
+			Description: "valid",
+			Rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"map-migrated"},
+					Include: []string{"LaunchTemplate", "EBSVolume"},
+				},
+				{
+					Tags:    []string{"*"},
+					Exclude: []string{"Subnet"},
+				},
+			},
+		},
+		{
+			Description: "launch template scopes",
+			Rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"map-migrated"},
+					Include: []string{"LaunchTemplate/instance"},
+					Exclude: []string{"LaunchTemplate/volume"},
+				},
+			},
+		},
+		{
+			Description: "unknown resource types",
+			Rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"map-migrated"},
+					Include: []string{"Instances"},
+					Exclude: []string{"NLB"},
+				},
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::spec.tagPolicy.rules[0].include[0]",
+				"Unsupported value::spec.tagPolicy.rules[0].exclude[0]",
+			},
+		},
+		{
+			Description: "no tags",
+			Rules: []kops.TagPolicyRule{
+				{
+					Exclude: []string{"Subnet"},
+				},
+			},
+			ExpectedErrors: []string{"Required value::spec.tagPolicy.rules[0].tags"},
+		},
+		{
+			Description: "empty tag",
+			Rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{""},
+					Exclude: []string{"Subnet"},
+				},
+			},
+			ExpectedErrors: []string{"Required value::spec.tagPolicy.rules[0].tags[0]"},
+		},
+		{
+			Description: "no resource types",
+			Rules: []kops.TagPolicyRule{
+				{
+					Tags: []string{"map-migrated"},
+				},
+			},
+			ExpectedErrors: []string{"Required value::spec.tagPolicy.rules[0]"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			policy := &kops.TagPolicySpec{Rules: g.Rules}
+			errs := validateTagPolicy(policy, field.NewPath("spec", "tagPolicy"))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_Snapshots(t *testing.T) {
+	grid := []struct {
+		Description          string
+		Schedule             *kops.SnapshotScheduleSpec
+		NoSnapshotController bool
+		EBSCSIDriverDisabled bool
+		ExpectedErrors       []string
+	}{
+		{
+			Description: "valid",
+			Schedule:    &kops.SnapshotScheduleSpec{Cron: "0 2 * * *", Retention: 7, Namespaces: []string{"default", "apps"}},
+		},
+		{
+			Description: "complex cron",
+			Schedule:    &kops.SnapshotScheduleSpec{Cron: "*/15 0-6,22-23 1,15 jan-jun MON-FRI", Retention: 1000},
+		},
+		{
+			Description: "predefined cron",
+			Schedule:    &kops.SnapshotScheduleSpec{Cron: "@daily", Retention: 1},
+		},
+		{
+			Description:    "no schedule",
+			ExpectedErrors: []string{"Required value::spec.snapshots.schedule"},
+		},
+		{
+			Description:    "missing cron",
+			Schedule:       &kops.SnapshotScheduleSpec{Retention: 7},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.cron"},
+		},
+		{
+			Description:    "too few cron fields",
+			Schedule:       &kops.SnapshotScheduleSpec{Cron: "0 2 * *", Retention: 7},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.cron"},
+		},
+		{
+			Description:    "cron value out of range",
+			Schedule:       &kops.SnapshotScheduleSpec{Cron: "0 24 * * *", Retention: 7},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.cron"},
+		},
+		{
+			Description:    "cron invalid step",
+			Schedule:       &kops.SnapshotScheduleSpec{Cron: "*/0 * * * *", Retention: 7},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.cron"},
+		},
+		{
+			Description:    "cron reversed range",
+			Schedule:       &kops.SnapshotScheduleSpec{Cron: "0 6-2 * * *", Retention: 7},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.cron"},
+		},
+		{
+			Description:    "zero retention",
+			Schedule:       &kops.SnapshotScheduleSpec{Cron: "@hourly"},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.retention"},
+		},
+		{
+			Description:    "too much retention",
+			Schedule:       &kops.SnapshotScheduleSpec{Cron: "@hourly", Retention: 1001},
+			ExpectedErrors: []string{"Invalid value::spec.snapshots.schedule.retention"},
+		},
+		{
+			Description: "invalid namespaces",
+			Schedule:    &kops.SnapshotScheduleSpec{Cron: "@hourly", Retention: 7, Namespaces: []string{"Apps", "default", "default"}},
+			ExpectedErrors: []string{
+				"Invalid value::spec.snapshots.schedule.namespaces[0]",
+				"Duplicate value::spec.snapshots.schedule.namespaces[2]",
+			},
+		},
+		{
+			Description:          "snapshot controller disabled",
+			Schedule:             &kops.SnapshotScheduleSpec{Cron: "@hourly", Retention: 7},
+			NoSnapshotController: true,
+			ExpectedErrors:       []string{"Forbidden::spec.snapshots"},
+		},
+		{
+			Description:          "EBS CSI driver disabled",
+			Schedule:             &kops.SnapshotScheduleSpec{Cron: "@hourly", Retention: 7},
+			EBSCSIDriverDisabled: true,
+			ExpectedErrors:       []string{"Forbidden::spec.snapshots"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					SnapshotController: &kops.SnapshotControllerConfig{
+						Enabled: fi.PtrTo(true),
+					},
+				},
+			}
+			if g.NoSnapshotController {
+				cluster.Spec.SnapshotController = nil
+			}
+			if g.EBSCSIDriverDisabled {
+				cluster.Spec.CloudProvider.AWS.EBSCSIDriver = &kops.EBSCSIDriverSpec{Enabled: fi.PtrTo(false)}
+			}
+			spec := &kops.SnapshotsSpec{Schedule: g.Schedule}
+			errs := validateSnapshots(cluster, spec, field.NewPath("spec", "snapshots"))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_KopsController(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration {
+		return &metav1.Duration{Duration: d}
+	}
 
-func Test_Validate_NodeLocalDNS(t *testing.T) {
 	grid := []struct {
-		Input          kops.ClusterSpec
+		Description    string
+		LeaderElection *kops.LeaderElectionConfiguration
 		ExpectedErrors []string
 	}{
 		{
-			Input: kops.ClusterSpec{
-				KubeProxy: &kops.KubeProxyConfig{
-					ProxyMode: "iptables",
-				},
-				KubeDNS: &kops.KubeDNSConfig{
-					Provider: "CoreDNS",
-					NodeLocalDNS: &kops.NodeLocalDNSConfig{
-						Enabled: fi.PtrTo(true),
-					},
-				},
+			Description: "defaults",
+		},
+		{
+			Description: "tuned",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElect:                      fi.PtrTo(true),
+				LeaderElectLeaseDuration:         duration(60 * time.Second),
+				LeaderElectRenewDeadlineDuration: duration(40 * time.Second),
+				LeaderElectRetryPeriod:           duration(5 * time.Second),
 			},
-			ExpectedErrors: []string{},
 		},
 		{
-			Input: kops.ClusterSpec{
-				Kubelet: &kops.KubeletConfigSpec{
-					ClusterDNS: "100.64.0.10",
-				},
-				KubeProxy: &kops.KubeProxyConfig{
-					ProxyMode: "ipvs",
-				},
-				KubeDNS: &kops.KubeDNSConfig{
-					Provider: "CoreDNS",
-					NodeLocalDNS: &kops.NodeLocalDNSConfig{
-						Enabled: fi.PtrTo(true),
-					},
-				},
+			Description: "longer lease only",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElectLeaseDuration: duration(30 * time.Second),
 			},
-			ExpectedErrors: []string{"Forbidden::spec.kubelet.clusterDNS"},
 		},
 		{
-			Input: kops.ClusterSpec{
-				Kubelet: &kops.KubeletConfigSpec{
-					ClusterDNS: "100.64.0.10",
-				},
-				KubeProxy: &kops.KubeProxyConfig{
-					ProxyMode: "ipvs",
-				},
-				KubeDNS: &kops.KubeDNSConfig{
-					Provider: "CoreDNS",
-					NodeLocalDNS: &kops.NodeLocalDNSConfig{
-						Enabled: fi.PtrTo(true),
-					},
-				},
-				Networking: kops.NetworkingSpec{
-					Cilium: &kops.CiliumNetworkingSpec{},
-				},
+			Description: "leader election disabled",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElect: fi.PtrTo(false),
 			},
-			ExpectedErrors: []string{"Forbidden::spec.kubelet.clusterDNS"},
+			ExpectedErrors: []string{"Forbidden::spec.kopsController.leaderElection.leaderElect"},
 		},
 		{
-			Input: kops.ClusterSpec{
-				Kubelet: &kops.KubeletConfigSpec{
-					ClusterDNS: "169.254.20.10",
-				},
-				KubeProxy: &kops.KubeProxyConfig{
-					ProxyMode: "iptables",
-				},
-				KubeDNS: &kops.KubeDNSConfig{
-					Provider: "CoreDNS",
-					NodeLocalDNS: &kops.NodeLocalDNSConfig{
-						Enabled: fi.PtrTo(true),
-						LocalIP: "169.254.20.10",
-					},
-				},
-				Networking: kops.NetworkingSpec{
-					Cilium: &kops.CiliumNetworkingSpec{},
-				},
+			Description: "custom lock",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElectResourceLock:      fi.PtrTo("configmaps"),
+				LeaderElectResourceName:      fi.PtrTo("my-lock"),
+				LeaderElectResourceNamespace: fi.PtrTo("default"),
 			},
-			ExpectedErrors: []string{},
+			ExpectedErrors: []string{
+				"Forbidden::spec.kopsController.leaderElection.leaderElectResourceLock",
+				"Forbidden::spec.kopsController.leaderElection.leaderElectResourceName",
+				"Forbidden::spec.kopsController.leaderElection.leaderElectResourceNamespace",
+			},
+		},
+		{
+			Description: "zero retry period",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElectRetryPeriod: duration(0),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.kopsController.leaderElection.leaderElectRetryPeriod"},
+		},
+		{
+			Description: "renew deadline not less than lease",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElectRenewDeadlineDuration: duration(15 * time.Second),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.kopsController.leaderElection.leaderElectRenewDeadlineDuration"},
+		},
+		{
+			Description: "retry period not less than renew deadline",
+			LeaderElection: &kops.LeaderElectionConfiguration{
+				LeaderElectRetryPeriod: duration(10 * time.Second),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.kopsController.leaderElection.leaderElectRetryPeriod"},
 		},
 	}
 
 	for _, g := range grid {
-		errs := validateNodeLocalDNS(&g.Input, field.NewPath("spec"))
-		testErrors(t, g.Input, errs, g.ExpectedErrors)
+		t.Run(g.Description, func(t *testing.T) {
+			spec := &kops.KopsControllerConfig{LeaderElection: g.LeaderElection}
+			errs := validateKopsController(spec, field.NewPath("spec", "kopsController"))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
 	}
 }
 
-func Test_Validate_CloudConfiguration(t *testing.T) {
+func Test_Validate_KubeStateMetrics(t *testing.T) {
 	grid := []struct {
 		Description    string
-		Input          kops.CloudConfiguration
-		CloudProvider  kops.CloudProviderSpec
+		Spec           kops.KubeStateMetricsConfig
 		ExpectedErrors []string
 	}{
 		{
-			Description: "neither",
-			Input:       kops.CloudConfiguration{},
+			Description: "default resources",
+			Spec: kops.KubeStateMetricsConfig{
+				MetricLabelsAllowlist: []string{"pods=[app,app.kubernetes.io/name]", "namespaces=[*]"},
+			},
 		},
 		{
-			Description: "all false",
-			Input: kops.CloudConfiguration{
-				ManageStorageClasses: fi.PtrTo(false),
+			Description: "explicit resources",
+			Spec: kops.KubeStateMetricsConfig{
+				Resources:             []string{"pods", "secrets"},
+				MetricLabelsAllowlist: []string{"secrets=[team]"},
 			},
 		},
 		{
-			Description: "all true",
-			Input: kops.CloudConfiguration{
-				ManageStorageClasses: fi.PtrTo(true),
+			Description: "unsupported resource",
+			Spec: kops.KubeStateMetricsConfig{
+				Resources: []string{"pods", "widgets"},
 			},
+			ExpectedErrors: []string{"Unsupported value::spec.metrics.kubeStateMetrics.resources[1]"},
 		},
 		{
-			Description: "os false",
-			Input:       kops.CloudConfiguration{},
-			CloudProvider: kops.CloudProviderSpec{
-				Openstack: &kops.OpenstackSpec{
-					BlockStorage: &kops.OpenstackBlockStorageConfig{
-						CreateStorageClass: fi.PtrTo(false),
-					},
-				},
+			Description: "malformed allowlist entry",
+			Spec: kops.KubeStateMetricsConfig{
+				MetricLabelsAllowlist: []string{"pods=app", "pods[app]", "=[app]"},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[0]",
+				"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[1]",
+				"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[2]",
 			},
 		},
 		{
-			Description: "os true",
-			Input:       kops.CloudConfiguration{},
-			CloudProvider: kops.CloudProviderSpec{
-				Openstack: &kops.OpenstackSpec{
-					BlockStorage: &kops.OpenstackBlockStorageConfig{
-						CreateStorageClass: fi.PtrTo(true),
-					},
-				},
+			Description: "allowlist for resource not exported",
+			Spec: kops.KubeStateMetricsConfig{
+				Resources:             []string{"pods"},
+				MetricLabelsAllowlist: []string{"nodes=[zone]"},
 			},
+			ExpectedErrors: []string{"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[0]"},
 		},
 		{
-			Description: "all false, os false",
-			Input: kops.CloudConfiguration{
-				ManageStorageClasses: fi.PtrTo(false),
+			Description: "invalid labels",
+			Spec: kops.KubeStateMetricsConfig{
+				MetricLabelsAllowlist: []string{"pods=[app,-bad]", "nodes=[]", "services=[a,,b]"},
 			},
-			CloudProvider: kops.CloudProviderSpec{
-				Openstack: &kops.OpenstackSpec{
-					BlockStorage: &kops.OpenstackBlockStorageConfig{
-						CreateStorageClass: fi.PtrTo(false),
-					},
-				},
+			ExpectedErrors: []string{
+				"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[0]",
+				"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[1]",
+				"Invalid value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[2]",
 			},
 		},
 		{
-			Description: "all false, os true",
-			Input: kops.CloudConfiguration{
-				ManageStorageClasses: fi.PtrTo(false),
-			},
-			CloudProvider: kops.CloudProviderSpec{
-				Openstack: &kops.OpenstackSpec{
-					BlockStorage: &kops.OpenstackBlockStorageConfig{
-						CreateStorageClass: fi.PtrTo(true),
-					},
-				},
+			Description: "duplicate allowlist resource",
+			Spec: kops.KubeStateMetricsConfig{
+				MetricLabelsAllowlist: []string{"pods=[app]", "pods=[team]"},
 			},
-			ExpectedErrors: []string{"Forbidden::cloudConfig.manageStorageClasses"},
+			ExpectedErrors: []string{"Duplicate value::spec.metrics.kubeStateMetrics.metricLabelsAllowlist[1]"},
 		},
 		{
-			Description: "all true, os false",
-			Input: kops.CloudConfiguration{
-				ManageStorageClasses: fi.PtrTo(true),
-			},
-			CloudProvider: kops.CloudProviderSpec{
-				Openstack: &kops.OpenstackSpec{
-					BlockStorage: &kops.OpenstackBlockStorageConfig{
-						CreateStorageClass: fi.PtrTo(false),
-					},
-				},
+			Description: "service annotations on cluster network",
+			Spec: kops.KubeStateMetricsConfig{
+				ServiceAnnotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
 			},
-			ExpectedErrors: []string{"Forbidden::cloudConfig.manageStorageClasses"},
+			ExpectedErrors: []string{"Forbidden::spec.metrics.kubeStateMetrics.serviceAnnotations"},
 		},
 		{
-			Description: "all true, os true",
-			Input: kops.CloudConfiguration{
-				ManageStorageClasses: fi.PtrTo(true),
-			},
-			CloudProvider: kops.CloudProviderSpec{
-				Openstack: &kops.OpenstackSpec{
-					BlockStorage: &kops.OpenstackBlockStorageConfig{
-						CreateStorageClass: fi.PtrTo(true),
-					},
-				},
+			Description: "service annotations on load balancer",
+			Spec: kops.KubeStateMetricsConfig{
+				ClusterNetworkOnly: fi.PtrTo(false),
+				ServiceAnnotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
 			},
 		},
 	}
 
 	for _, g := range grid {
-		fldPath := field.NewPath("cloudConfig")
 		t.Run(g.Description, func(t *testing.T) {
-			spec := &kops.ClusterSpec{
-				CloudProvider: g.CloudProvider,
-			}
-			errs := validateCloudConfiguration(&g.Input, spec, fldPath)
-			testErrors(t, g.Input, errs, g.ExpectedErrors)
+			errs := validateKubeStateMetrics(&g.Spec, field.NewPath("spec", "metrics", "kubeStateMetrics"))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
 		})
 	}
 }
@@ -1483,6 +2775,47 @@ func TestValidateSAExternalPermissions(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Required value::iam.serviceAccountExternalPermissions[/MySA].namespace"},
 		},
+		{
+			Description: "Valid inline policy",
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+					AWS: &kops.AWSPermission{
+						InlinePolicy: `[{"Effect": "Allow", "Action": "sqs:ReceiveMessage", "Resource": "arn:aws:sqs:us-east-1:123456789012:my-queue"}]`,
+					},
+				},
+			},
+		},
+		{
+			Description: "Inline policy is not valid JSON",
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+					AWS: &kops.AWSPermission{
+						InlinePolicy: `[{"Effect": "Allow", "Action": "sqs:ReceiveMessage"`,
+					},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::iam.serviceAccountExternalPermissions[MyNS/MySA].aws.inlinePolicy"},
+		},
+		{
+			Description: "Inline policy with invalid effect",
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+					AWS: &kops.AWSPermission{
+						InlinePolicy: `[{"Action": "sqs:ReceiveMessage", "Resource": "*"}, {"Effect": "Permit", "Action": "sqs:DeleteMessage", "Resource": "*"}]`,
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::iam.serviceAccountExternalPermissions[MyNS/MySA].aws.inlinePolicy[0].Effect",
+				"Unsupported value::iam.serviceAccountExternalPermissions[MyNS/MySA].aws.inlinePolicy[1].Effect",
+			},
+		},
 	}
 
 	for _, g := range grid {
@@ -1659,3 +2992,107 @@ func Test_Validate_NriConfig(t *testing.T) {
 		testErrors(t, g.Input.Containerd, errs, g.ExpectedErrors)
 	}
 }
+
+func Test_Validate_EtcdAdditionalSANs(t *testing.T) {
+	grid := []struct {
+		SANs           []string
+		ExpectedErrors []string
+	}{
+		{
+			SANs: []string{"etcd.example.com", "*.etcd.example.com", "10.0.0.10", "fd00::10"},
+		},
+		{
+			SANs:           []string{"etcd_metrics.example.com"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].additionalSANs[0]"},
+		},
+		{
+			SANs:           []string{"etcd.example.com", "https://etcd.example.com"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].additionalSANs[1]"},
+		},
+		{
+			SANs:           []string{"10.0.0.10", "10.0.0.10"},
+			ExpectedErrors: []string{"Duplicate value::etcdClusters[0].additionalSANs[1]"},
+		},
+	}
+
+	for _, g := range grid {
+		errs := validateEtcdAdditionalSANs(g.SANs, field.NewPath("etcdClusters").Index(0).Child("additionalSANs"))
+		testErrors(t, g.SANs, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_EtcdManagerDiscoveryMode(t *testing.T) {
+	grid := []struct {
+		DiscoveryMode  kops.EtcdManagerDiscoveryMode
+		MemberName     string
+		ExpectedErrors []string
+	}{
+		{
+			MemberName: "us-test-1a",
+		},
+		{
+			DiscoveryMode: kops.EtcdManagerDiscoveryModeVolumeTags,
+			MemberName:    "us-test-1a",
+		},
+		{
+			DiscoveryMode: kops.EtcdManagerDiscoveryModeStatic,
+			MemberName:    "us-test-1a",
+		},
+		{
+			DiscoveryMode:  kops.EtcdManagerDiscoveryModeStatic,
+			MemberName:     "us_test_1a",
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].etcdMembers[0].name"},
+		},
+		{
+			DiscoveryMode:  "Gossip",
+			MemberName:     "us-test-1a",
+			ExpectedErrors: []string{"Unsupported value::etcdClusters[0].manager.discoveryMode"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			},
+		}
+		spec := kops.EtcdClusterSpec{
+			Name:    "main",
+			Members: []kops.EtcdMemberSpec{{Name: g.MemberName, InstanceGroup: fi.PtrTo("master-us-test-1a")}},
+			Manager: &kops.EtcdManagerSpec{DiscoveryMode: g.DiscoveryMode},
+		}
+		errs := validateEtcdManagerSpec(spec, cluster, field.NewPath("etcdClusters").Index(0))
+		testErrors(t, g.DiscoveryMode, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_WarmPool(t *testing.T) {
+	grid := []struct {
+		WarmPool       *kops.WarmPoolSpec
+		ExpectedErrors []string
+	}{
+		{
+			WarmPool: &kops.WarmPoolSpec{MinSize: 1, EnableLifecycleHook: true},
+		},
+		{
+			WarmPool:       &kops.WarmPoolSpec{MinSize: 2, MaxSize: fi.PtrTo(int64(1))},
+			ExpectedErrors: []string{"Invalid value::spec.cloudProvider.aws.warmPool.maxSize"},
+		},
+		{
+			WarmPool:       &kops.WarmPoolSpec{MaxSize: fi.PtrTo(int64(0)), EnableLifecycleHook: true},
+			ExpectedErrors: []string{"Forbidden::spec.cloudProvider.aws.warmPool.enableLifecycleHook"},
+		},
+		{
+			WarmPool: &kops.WarmPoolSpec{MinSize: 1, ReuseOnScaleIn: fi.PtrTo(true)},
+		},
+		{
+			WarmPool:       &kops.WarmPoolSpec{MaxSize: fi.PtrTo(int64(0)), ReuseOnScaleIn: fi.PtrTo(true)},
+			ExpectedErrors: []string{"Forbidden::spec.cloudProvider.aws.warmPool.reuseOnScaleIn"},
+		},
+	}
+
+	for _, g := range grid {
+		errs := validateWarmPool(g.WarmPool, field.NewPath("spec", "cloudProvider", "aws", "warmPool"))
+		testErrors(t, g.WarmPool, errs, g.ExpectedErrors)
+	}
+}
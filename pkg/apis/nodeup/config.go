@@ -17,9 +17,12 @@ limitations under the License.
 package nodeup
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/util/pkg/architectures"
@@ -101,6 +104,14 @@ type Config struct {
 	DNSZone string `json:",omitempty"`
 	// NvidiaGPU contains the configuration for nvidia
 	NvidiaGPU *kops.NvidiaGPUConfig `json:",omitempty"`
+	// GPUConfig contains the configuration of the GPUs of the instance group.
+	GPUConfig *kops.GPUConfigSpec `json:",omitempty"`
+	// Swap contains the configuration of the swap file of the instance group.
+	Swap *kops.SwapSpec `json:",omitempty"`
+	// JournaldMaxUse limits the disk space used by the systemd journal.
+	JournaldMaxUse *resource.Quantity `json:",omitempty"`
+	// NodeAddressFamilies are the IP families of the addresses the node advertises, in order of preference.
+	NodeAddressFamilies []string `json:",omitempty"`
 
 	// AWS-specific
 	// DisableSecurityGroupIngress disables the Cloud Controller Manager's creation
@@ -148,7 +159,7 @@ type BootConfig struct {
 	ConfigBase *string `json:",omitempty"`
 	// ConfigServer holds the configuration for the configuration server.
 	ConfigServer *ConfigServerOptions `json:",omitempty"`
-	// APIServerIPs is the API server IP addresses.
+	// APIServerIPs is the API server IP addresses, with those of the IP family preferred by the node first.
 	// This field is used for adding an alias for api.internal. in /etc/hosts, when Topology.DNS.Type == DNSTypeNone.
 	APIServerIPs []string `json:",omitempty"`
 	// ClusterName is the name of the cluster.
@@ -159,6 +170,9 @@ type BootConfig struct {
 	InstanceGroupRole kops.InstanceGroupRole
 	// NodeupConfigHash holds a secure hash of the nodeup.Config.
 	NodeupConfigHash string
+	// BootConfigHash is set when the boot config did not fit in the user-data and was written to the state store,
+	// in which case only the fields needed to find it are set here. It holds a secure hash of the stored boot config.
+	BootConfigHash string `json:",omitempty"`
 }
 
 type ConfigServerOptions struct {
@@ -210,6 +224,9 @@ type ControlPlaneConfig struct {
 	KubeControllerManager kops.KubeControllerManagerConfig
 	// KubeScheduler is the configuration for the kube-scheduler.
 	KubeScheduler kops.KubeSchedulerConfig
+	// EtcdAdditionalSANs are the additional subject alternative names of the etcd server and peer certificates, by etcd cluster name.
+	// They are issued by etcd-manager; the SANs are included here so that changing them replaces the control plane nodes.
+	EtcdAdditionalSANs map[string][]string `json:",omitempty"`
 }
 
 func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Config, *BootConfig) {
@@ -218,9 +235,14 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 	clusterHooks := filterHooks(cluster.Spec.Hooks, instanceGroup.Spec.Role)
 	igHooks := filterHooks(instanceGroup.Spec.Hooks, instanceGroup.Spec.Role)
 
+	kubernetesVersion := cluster.Spec.KubernetesVersion
+	if instanceGroup.Spec.KubernetesVersion != "" {
+		kubernetesVersion = instanceGroup.Spec.KubernetesVersion
+	}
+
 	config := Config{
 		ClusterName:       cluster.ObjectMeta.Name,
-		KubernetesVersion: cluster.Spec.KubernetesVersion,
+		KubernetesVersion: kubernetesVersion,
 		CAs:               map[string]string{},
 		KeypairIDs:        map[string]string{},
 		Networking: kops.NetworkingSpec{
@@ -251,7 +273,20 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		config.NvidiaGPU = buildNvidiaConfig(cluster, instanceGroup)
 	}
 
+	if instanceGroup.Spec.GPUConfig != nil && instanceGroup.Spec.GPUConfig.MIGProfile != "" {
+		config.GPUConfig = instanceGroup.Spec.GPUConfig
+	}
+
+	if instanceGroup.Spec.Swap != nil {
+		config.Swap = instanceGroup.Spec.Swap
+	}
+
+	if instanceGroup.Spec.JournaldMaxUse != nil {
+		config.JournaldMaxUse = instanceGroup.Spec.JournaldMaxUse
+	}
+
 	config.KubeProxy = buildKubeProxy(cluster, instanceGroup)
+	config.NodeAddressFamilies = cluster.Spec.NodeIPFamilies
 
 	if cluster.Spec.NTP != nil && cluster.Spec.NTP.Managed != nil && !*cluster.Spec.NTP.Managed {
 		config.NTPUnmanaged = true
@@ -268,6 +303,9 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 			config.DisableSecurityGroupIngress = aws.DisableSecurityGroupIngress
 			config.ElbSecurityGroup = aws.ElbSecurityGroup
 			config.NodeIPFamilies = aws.NodeIPFamilies
+			if len(cluster.Spec.NodeIPFamilies) > 0 {
+				config.NodeIPFamilies = cluster.Spec.NodeIPFamilies
+			}
 		}
 	}
 
@@ -285,6 +323,10 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		config.Multizone = gce.Multizone
 		config.NodeTags = gce.NodeTags
 		config.NodeInstancePrefix = gce.NodeInstancePrefix
+
+		if instanceGroup.Spec.GCE != nil && instanceGroup.Spec.GCE.LocalSSDCount != nil {
+			config.VolumeMounts = append(slices.Clone(config.VolumeMounts), buildGCELocalSSDVolumeMounts(instanceGroup.Spec.GCE)...)
+		}
 	}
 
 	config.Openstack = cluster.Spec.CloudProvider.Openstack
@@ -380,6 +422,15 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 			KubeControllerManager: *cluster.Spec.KubeControllerManager,
 			KubeScheduler:         *cluster.Spec.KubeScheduler,
 		}
+		for _, etcdCluster := range cluster.Spec.EtcdClusters {
+			if len(etcdCluster.AdditionalSANs) == 0 {
+				continue
+			}
+			if config.ControlPlaneConfig.EtcdAdditionalSANs == nil {
+				config.ControlPlaneConfig.EtcdAdditionalSANs = make(map[string][]string)
+			}
+			config.ControlPlaneConfig.EtcdAdditionalSANs[etcdCluster.Name] = etcdCluster.AdditionalSANs
+		}
 	}
 
 	if len(instanceGroup.Spec.SysctlParameters) > 0 {
@@ -427,6 +478,28 @@ func buildNvidiaConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup)
 }
 
 // buildkubeProxy builds the kube-proxy configuration for an instance group.
+// buildGCELocalSSDVolumeMounts formats and mounts the local SSDs of GCE instances.
+// The first local SSD holds the containerd data directory, the others are mounted under /mnt/disks.
+func buildGCELocalSSDVolumeMounts(spec *kops.GCEInstanceGroupSpec) []kops.VolumeMountSpec {
+	var volumeMounts []kops.VolumeMountSpec
+	for i := 0; i < int(*spec.LocalSSDCount); i++ {
+		device := fmt.Sprintf("/dev/disk/by-id/google-local-nvme-ssd-%d", i)
+		if spec.LocalSSDInterface == "SCSI" {
+			device = fmt.Sprintf("/dev/disk/by-id/google-local-ssd-%d", i)
+		}
+		path := "/var/lib/containerd"
+		if i > 0 {
+			path = fmt.Sprintf("/mnt/disks/local-ssd-%d", i)
+		}
+		volumeMounts = append(volumeMounts, kops.VolumeMountSpec{
+			Device:     device,
+			Filesystem: "ext4",
+			Path:       path,
+		})
+	}
+	return volumeMounts
+}
+
 func buildKubeProxy(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) *kops.KubeProxyConfig {
 	config := &kops.KubeProxyConfig{}
 	if cluster.Spec.KubeProxy != nil {
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+func TestNewConfigEtcdAdditionalSANs(t *testing.T) {
+	newCluster := func(additionalSANs ...string) *kops.Cluster {
+		return &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				EtcdClusters: []kops.EtcdClusterSpec{
+					{Name: "main", AdditionalSANs: additionalSANs},
+					{Name: "events"},
+				},
+				KubeAPIServer:         &kops.KubeAPIServerConfig{},
+				KubeControllerManager: &kops.KubeControllerManagerConfig{},
+				KubeScheduler:         &kops.KubeSchedulerConfig{},
+			},
+		}
+	}
+	controlPlane := &kops.InstanceGroup{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleControlPlane}}
+	node := &kops.InstanceGroup{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode}}
+
+	config, _ := NewConfig(newCluster("etcd.example.com"), controlPlane)
+	expected := map[string][]string{"main": {"etcd.example.com"}}
+	if !reflect.DeepEqual(config.ControlPlaneConfig.EtcdAdditionalSANs, expected) {
+		t.Errorf("expected %v, got %v", expected, config.ControlPlaneConfig.EtcdAdditionalSANs)
+	}
+
+	// Changing the SANs changes the configuration of the control plane, so that its certificates are reissued by a rolling update.
+	before, _ := NewConfig(newCluster(), controlPlane)
+	after, _ := NewConfig(newCluster("etcd.example.com"), controlPlane)
+	if yamlString(t, before) == yamlString(t, after) {
+		t.Errorf("expected the control plane configuration to change with the additional SANs")
+	}
+
+	before, _ = NewConfig(newCluster(), node)
+	after, _ = NewConfig(newCluster("etcd.example.com"), node)
+	if yamlString(t, before) != yamlString(t, after) {
+		t.Errorf("expected the node configuration not to change with the additional SANs")
+	}
+}
+
+func TestNewConfigGCELocalSSDs(t *testing.T) {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			CloudProvider:         kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			KubeAPIServer:         &kops.KubeAPIServerConfig{},
+			KubeControllerManager: &kops.KubeControllerManagerConfig{},
+			KubeScheduler:         &kops.KubeSchedulerConfig{},
+		},
+	}
+
+	one, two := int32(1), int32(2)
+	grid := []struct {
+		name     string
+		gce      *kops.GCEInstanceGroupSpec
+		expected []kops.VolumeMountSpec
+	}{
+		{
+			name: "no local ssds",
+		},
+		{
+			name: "nvme",
+			gce:  &kops.GCEInstanceGroupSpec{LocalSSDCount: &two, LocalSSDInterface: "NVME"},
+			expected: []kops.VolumeMountSpec{
+				{Device: "/data/device", Path: "/data"},
+				{Device: "/dev/disk/by-id/google-local-nvme-ssd-0", Filesystem: "ext4", Path: "/var/lib/containerd"},
+				{Device: "/dev/disk/by-id/google-local-nvme-ssd-1", Filesystem: "ext4", Path: "/mnt/disks/local-ssd-1"},
+			},
+		},
+		{
+			name: "scsi",
+			gce:  &kops.GCEInstanceGroupSpec{LocalSSDCount: &one, LocalSSDInterface: "SCSI"},
+			expected: []kops.VolumeMountSpec{
+				{Device: "/data/device", Path: "/data"},
+				{Device: "/dev/disk/by-id/google-local-ssd-0", Filesystem: "ext4", Path: "/var/lib/containerd"},
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					Role:         kops.InstanceGroupRoleNode,
+					VolumeMounts: []kops.VolumeMountSpec{{Device: "/data/device", Path: "/data"}},
+					GCE:          g.gce,
+				},
+			}
+			config, _ := NewConfig(cluster, ig)
+			expected := g.expected
+			if expected == nil {
+				expected = ig.Spec.VolumeMounts
+			}
+			if !reflect.DeepEqual(config.VolumeMounts, expected) {
+				t.Errorf("expected volume mounts %v, got %v", expected, config.VolumeMounts)
+			}
+			if len(ig.Spec.VolumeMounts) != 1 {
+				t.Errorf("expected the volume mounts of the instance group not to be modified, got %v", ig.Spec.VolumeMounts)
+			}
+		})
+	}
+}
+
+func TestNewConfigNodeIPFamilies(t *testing.T) {
+	grid := []struct {
+		name        string
+		families    []string
+		awsFamilies []string
+		expected    []string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "cluster spec",
+			families: []string{"ipv4", "ipv6"},
+			expected: []string{"ipv4", "ipv6"},
+		},
+		{
+			name:        "deprecated cloud provider field",
+			awsFamilies: []string{"ipv6", "ipv4"},
+			expected:    []string{"ipv6", "ipv4"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider:         kops.CloudProviderSpec{AWS: &kops.AWSSpec{NodeIPFamilies: g.awsFamilies}},
+					NodeIPFamilies:        g.families,
+					KubeAPIServer:         &kops.KubeAPIServerConfig{},
+					KubeControllerManager: &kops.KubeControllerManagerConfig{},
+					KubeScheduler:         &kops.KubeSchedulerConfig{},
+				},
+			}
+			controlPlane := &kops.InstanceGroup{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleControlPlane}}
+
+			config, _ := NewConfig(cluster, controlPlane)
+			if !reflect.DeepEqual(config.NodeIPFamilies, g.expected) {
+				t.Errorf("expected cloud config node IP families %v, got %v", g.expected, config.NodeIPFamilies)
+			}
+			if !reflect.DeepEqual(config.NodeAddressFamilies, g.families) {
+				t.Errorf("expected node address families %v, got %v", g.families, config.NodeAddressFamilies)
+			}
+		})
+	}
+}
+
+func yamlString(t *testing.T, config *Config) string {
+	t.Helper()
+
+	b, err := utils.YamlMarshal(config)
+	if err != nil {
+		t.Fatalf("marshaling configuration: %v", err)
+	}
+	return string(b)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"k8s.io/kops/util/pkg/architectures"
+)
+
+// ImageArchitecturesFunc returns the linux architectures an image is published for.
+type ImageArchitecturesFunc func(image string) ([]architectures.Architecture, error)
+
+// RemoteImageArchitectures returns the linux architectures an image is published for,
+// using the manifest list of the image or, for a single platform image, its config.
+func RemoteImageArchitectures(image string) ([]architectures.Architecture, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("parsing reference %q: %v", image, err)
+	}
+
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %q: %v", image, err)
+	}
+
+	var result []architectures.Architecture
+	switch desc.MediaType {
+	case types.OCIImageIndex, types.DockerManifestList:
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return nil, fmt.Errorf("reading index of %q: %v", image, err)
+		}
+		manifest, err := idx.IndexManifest()
+		if err != nil {
+			return nil, fmt.Errorf("reading index manifest of %q: %v", image, err)
+		}
+		for _, m := range manifest.Manifests {
+			if m.Platform != nil && m.Platform.OS == "linux" {
+				result = append(result, architectures.Architecture(m.Platform.Architecture))
+			}
+		}
+	default:
+		// Assume anything else is an image, since some registries don't set mediaTypes properly.
+		img, err := desc.Image()
+		if err != nil {
+			return nil, fmt.Errorf("reading image %q: %v", image, err)
+		}
+		config, err := img.ConfigFile()
+		if err != nil {
+			return nil, fmt.Errorf("reading config of %q: %v", image, err)
+		}
+		result = append(result, architectures.Architecture(config.Architecture))
+	}
+
+	return result, nil
+}
+
+// FindImagesMissingArchitectures returns a description of every image that is not published
+// for all the required architectures, sorted by image.
+func FindImagesMissingArchitectures(images []*ImageAsset, required []architectures.Architecture, imageArchitectures ImageArchitecturesFunc) ([]string, error) {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, image := range images {
+		location := image.DownloadLocation
+		if seen[location] {
+			continue
+		}
+		seen[location] = true
+
+		published, err := imageArchitectures(location)
+		if err != nil {
+			return nil, err
+		}
+
+		var notPublished []string
+		for _, arch := range required {
+			found := false
+			for _, p := range published {
+				if p == arch {
+					found = true
+					break
+				}
+			}
+			if !found {
+				notPublished = append(notPublished, string(arch))
+			}
+		}
+		if len(notPublished) != 0 {
+			missing = append(missing, fmt.Sprintf("%s (missing %s)", location, strings.Join(notPublished, ", ")))
+		}
+	}
+
+	sort.Strings(missing)
+	return missing, nil
+}
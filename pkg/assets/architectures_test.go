@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/util/pkg/architectures"
+)
+
+func TestFindImagesMissingArchitectures(t *testing.T) {
+	published := map[string][]architectures.Architecture{
+		"registry.k8s.io/kube-proxy:v1.29.0":    {architectures.ArchitectureAmd64, architectures.ArchitectureArm64},
+		"registry.k8s.io/amd64-only:v1.0.0":     {architectures.ArchitectureAmd64},
+		"registry.example.com/arm64-only:1.0.0": {architectures.ArchitectureArm64},
+	}
+	lookups := 0
+	imageArchitectures := func(image string) ([]architectures.Architecture, error) {
+		lookups++
+		archs, found := published[image]
+		if !found {
+			return nil, fmt.Errorf("image %q not found", image)
+		}
+		return archs, nil
+	}
+
+	images := []*ImageAsset{
+		{DownloadLocation: "registry.k8s.io/kube-proxy:v1.29.0"},
+		{DownloadLocation: "registry.k8s.io/amd64-only:v1.0.0"},
+		{DownloadLocation: "registry.example.com/arm64-only:1.0.0"},
+		{DownloadLocation: "registry.k8s.io/amd64-only:v1.0.0"},
+	}
+
+	grid := []struct {
+		Required []architectures.Architecture
+		Expected []string
+	}{
+		{
+			Required: []architectures.Architecture{architectures.ArchitectureAmd64},
+			Expected: []string{"registry.example.com/arm64-only:1.0.0 (missing amd64)"},
+		},
+		{
+			Required: []architectures.Architecture{architectures.ArchitectureArm64},
+			Expected: []string{"registry.k8s.io/amd64-only:v1.0.0 (missing arm64)"},
+		},
+		{
+			Required: []architectures.Architecture{architectures.ArchitectureAmd64, architectures.ArchitectureArm64},
+			Expected: []string{
+				"registry.example.com/arm64-only:1.0.0 (missing amd64)",
+				"registry.k8s.io/amd64-only:v1.0.0 (missing arm64)",
+			},
+		},
+	}
+	for _, g := range grid {
+		lookups = 0
+		missing, err := FindImagesMissingArchitectures(images, g.Required, imageArchitectures)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(missing, g.Expected) {
+			t.Errorf("required %v: expected %v, got %v", g.Required, g.Expected, missing)
+		}
+		if lookups != 3 {
+			t.Errorf("expected every image to be looked up once, got %d lookups", lookups)
+		}
+	}
+
+	_, err := FindImagesMissingArchitectures([]*ImageAsset{{DownloadLocation: "registry.k8s.io/unknown:v1.0.0"}}, []architectures.Architecture{architectures.ArchitectureArm64}, imageArchitectures)
+	if err == nil {
+		t.Errorf("expected an error for an image that can't be looked up")
+	}
+}
@@ -75,13 +75,13 @@ func (c *RESTClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster
 }
 
 // UpdateCluster implements the UpdateCluster method of Clientset for a kubernetes-API state store
-func (c *RESTClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus) (*kops.Cluster, error) {
+func (c *RESTClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus, options simple.UpdateClusterOptions) (*kops.Cluster, error) {
 	klog.Warningf("validating cluster update client side; needs to move to server")
 	old, err := c.GetCluster(ctx, cluster.Name)
 	if err != nil {
 		return nil, err
 	}
-	if err := validation.ValidateClusterUpdate(cluster, status, old, c.VFSContext()).ToAggregate(); err != nil {
+	if err := validation.ValidateClusterUpdate(cluster, status, old, c.VFSContext(), options.AllowKubernetesVersionSkip).ToAggregate(); err != nil {
 		return nil, err
 	}
 
@@ -27,6 +27,12 @@ import (
 	"k8s.io/kops/util/pkg/vfs"
 )
 
+// UpdateClusterOptions are the options for updating a cluster.
+type UpdateClusterOptions struct {
+	// AllowKubernetesVersionSkip allows upgrading the Kubernetes version by more than one minor version.
+	AllowKubernetesVersionSkip bool
+}
+
 type Clientset interface {
 	// VFSContext returns a VFSContext.
 	VFSContext() *vfs.VFSContext
@@ -38,7 +44,7 @@ type Clientset interface {
 	CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error)
 
 	// UpdateCluster updates a cluster
-	UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus) (*kops.Cluster, error)
+	UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus, options UpdateClusterOptions) (*kops.Cluster, error)
 
 	// ListClusters returns all clusters
 	ListClusters(ctx context.Context, options metav1.ListOptions) (*kops.ClusterList, error)
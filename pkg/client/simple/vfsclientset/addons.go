@@ -90,7 +90,7 @@ func (c *vfsAddonsClient) Replace(addons kubemanifest.ObjectList) error {
 
 	rs := bytes.NewReader(b)
 	if err := configPath.WriteFile(ctx, rs, acl); err != nil {
-		return fmt.Errorf("error writing addons file %s: %v", configPath, err)
+		return fmt.Errorf("error writing addons file %s: %v", configPath, vfs.ExplainStateStoreError(ctx, c.basePath, err))
 	}
 
 	return nil
@@ -104,7 +104,7 @@ func (c *vfsAddonsClient) List(ctx context.Context) (kubemanifest.ObjectList, er
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("error reading addons file %s: %v", configPath, err)
+		return nil, fmt.Errorf("error reading addons file %s: %v", configPath, vfs.ExplainStateStoreError(ctx, c.basePath, err))
 	}
 
 	objects, err := kubemanifest.LoadObjectsFrom(b)
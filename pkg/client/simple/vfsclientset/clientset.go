@@ -57,8 +57,8 @@ func (c *VFSClientset) GetCluster(ctx context.Context, name string) (*kops.Clust
 }
 
 // UpdateCluster implements the UpdateCluster method of simple.Clientset for a VFS-backed state store
-func (c *VFSClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus) (*kops.Cluster, error) {
-	return c.clusters().Update(cluster, status)
+func (c *VFSClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus, options simple.UpdateClusterOptions) (*kops.Cluster, error) {
+	return c.clusters().Update(cluster, status, options)
 }
 
 // CreateCluster implements the CreateCluster method of simple.Clientset for a VFS-backed state store
@@ -178,6 +178,9 @@ func DeleteAllClusterState(ctx context.Context, basePath vfs.Path) error {
 		if strings.HasPrefix(relativePath, "manifests/") {
 			continue
 		}
+		if strings.HasPrefix(relativePath, "operations/") {
+			continue
+		}
 		// TODO: offer an option _not_ to delete backups?
 		if strings.HasPrefix(relativePath, "backups/") {
 			continue
@@ -17,8 +17,12 @@ limitations under the License.
 package vfsclientset
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -78,3 +82,40 @@ func TestSSHCredentialStoreOnOwnCFS(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, actual)
 	}
 }
+
+// unusableStatePath is a state store path whose reads fail, and whose preflight explains why.
+type unusableStatePath struct {
+	*vfs.MemFSPath
+}
+
+func (p *unusableStatePath) Join(relativePath ...string) vfs.Path {
+	return &unusableStatePath{MemFSPath: p.MemFSPath.Join(relativePath...).(*vfs.MemFSPath)}
+}
+
+func (p *unusableStatePath) ReadFile(ctx context.Context) ([]byte, error) {
+	return nil, errors.New("AccessDenied: Access Denied")
+}
+
+func (p *unusableStatePath) PreflightStateStore(ctx context.Context) error {
+	return &vfs.StateStoreError{Reason: vfs.StateStoreBucketNotFound, Path: "s3://my-bucket", Bucket: "my-bucket"}
+}
+
+func TestExplainStateStoreErrors(t *testing.T) {
+	ctx := context.TODO()
+	clientset := &VFSClientset{
+		vfsContext: vfs.Context,
+		basePath:   &unusableStatePath{MemFSPath: vfs.NewMemFSPath(vfs.NewMemFSContext(), "state")},
+	}
+	cluster := &kops.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"}}
+	expected := `bucket "my-bucket" does not exist`
+
+	if _, err := clientset.GetCluster(ctx, cluster.Name); err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected cluster error containing %q, got %v", expected, err)
+	}
+	if _, err := clientset.InstanceGroupsFor(cluster).Get(ctx, "nodes", metav1.GetOptions{}); err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected instance group error containing %q, got %v", expected, err)
+	}
+	if _, err := clientset.AddonsFor(cluster).List(ctx); err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected addons error containing %q, got %v", expected, err)
+	}
+}
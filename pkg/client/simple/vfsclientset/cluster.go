@@ -34,6 +34,7 @@ import (
 	api "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/registry"
 	"k8s.io/kops/pkg/apis/kops/validation"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -122,7 +123,7 @@ func (r *ClusterVFS) Create(c *api.Cluster) (*api.Cluster, error) {
 	return c, nil
 }
 
-func (r *ClusterVFS) Update(c *api.Cluster, status *api.ClusterStatus) (*api.Cluster, error) {
+func (r *ClusterVFS) Update(c *api.Cluster, status *api.ClusterStatus, options simple.UpdateClusterOptions) (*api.Cluster, error) {
 	ctx := context.TODO()
 
 	clusterName := c.ObjectMeta.Name
@@ -139,7 +140,7 @@ func (r *ClusterVFS) Update(c *api.Cluster, status *api.ClusterStatus) (*api.Clu
 		return nil, errors.NewNotFound(schema.GroupResource{Group: api.GroupName, Resource: "Cluster"}, clusterName)
 	}
 
-	if err := validation.ValidateClusterUpdate(c, status, old, r.vfsContext).ToAggregate(); err != nil {
+	if err := validation.ValidateClusterUpdate(c, status, old, r.vfsContext, options.AllowKubernetesVersionSkip).ToAggregate(); err != nil {
 		return nil, err
 	}
 
@@ -162,7 +163,7 @@ func (r *ClusterVFS) Update(c *api.Cluster, status *api.ClusterStatus) (*api.Clu
 func (r *ClusterVFS) listNames(ctx context.Context) ([]string, error) {
 	paths, err := r.basePath.ReadTree(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error reading state store: %v", err)
+		return nil, fmt.Errorf("error reading state store: %v", r.explainError(ctx, err))
 	}
 
 	var keys []string
@@ -121,7 +121,7 @@ func (c *commonVFS) readConfig(ctx context.Context, configPath vfs.Path) (runtim
 		if os.IsNotExist(err) {
 			return nil, err
 		}
-		return nil, fmt.Errorf("error reading %s: %v", configPath, err)
+		return nil, fmt.Errorf("error reading %s: %v", configPath, c.explainError(ctx, err))
 	}
 
 	object, _, err := kopscodecs.Decode(data, nil)
@@ -148,7 +148,7 @@ func (c *commonVFS) writeConfig(ctx context.Context, cluster *kops.Cluster, conf
 				if os.IsNotExist(err) {
 					return fmt.Errorf("cannot update configuration file %s: does not exist", configPath)
 				}
-				return fmt.Errorf("error checking if configuration file %s exists already: %v", configPath, err)
+				return fmt.Errorf("error checking if configuration file %s exists already: %v", configPath, c.explainError(ctx, err))
 			}
 		default:
 			return fmt.Errorf("unknown write option: %q", writeOption)
@@ -171,7 +171,7 @@ func (c *commonVFS) writeConfig(ctx context.Context, cluster *kops.Cluster, conf
 			klog.Warningf("failed to create file as already exists: %v", configPath)
 			return err
 		}
-		return fmt.Errorf("error writing configuration file %s: %v", configPath, err)
+		return fmt.Errorf("error writing configuration file %s: %v", configPath, c.explainError(ctx, err))
 	}
 	return nil
 }
@@ -209,7 +209,7 @@ func (c *commonVFS) delete(ctx context.Context, name string, options metav1.Dele
 		if os.IsNotExist(err) {
 			return nil
 		}
-		return fmt.Errorf("error deleting %s configuration %q: %v", c.kind, name, err)
+		return fmt.Errorf("error deleting %s configuration %q: %v", c.kind, name, c.explainError(ctx, err))
 	}
 	return nil
 }
@@ -217,7 +217,7 @@ func (c *commonVFS) delete(ctx context.Context, name string, options metav1.Dele
 func (c *commonVFS) listNames(ctx context.Context) ([]string, error) {
 	keys, err := listChildNames(ctx, c.basePath)
 	if err != nil {
-		return nil, fmt.Errorf("error listing %s in state store: %v", c.kind, err)
+		return nil, fmt.Errorf("error listing %s in state store: %v", c.kind, c.explainError(ctx, err))
 	}
 
 	// Seems to be an assumption in k8s APIs that items are always returned sorted
@@ -226,6 +226,12 @@ func (c *commonVFS) listNames(ctx context.Context) ([]string, error) {
 	return keys, nil
 }
 
+// explainError is called after an operation on the state store failed with err, and returns
+// why the state store cannot be used when that is the cause.
+func (c *commonVFS) explainError(ctx context.Context, err error) error {
+	return vfs.ExplainStateStoreError(ctx, c.basePath, err)
+}
+
 func (c *commonVFS) readAll(ctx context.Context, items interface{}) (interface{}, error) {
 	sliceValue := reflect.ValueOf(items)
 	sliceType := reflect.TypeOf(items)
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cniupgrade
+
+import (
+	"fmt"
+
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"sigs.k8s.io/yaml"
+)
+
+// FindUpgrade returns the new version of the CNI addon of a cluster from the tasks built by kops update cluster,
+// or nil if the CNI of the cluster is not upgraded in phases.
+func FindUpgrade(cluster *kops.Cluster, taskMap map[string]fi.CloudupTask) (*Upgrade, error) {
+	profile, maxUnavailable := ProfileFor(&cluster.Spec.Networking)
+	if profile == nil {
+		return nil, nil
+	}
+
+	files := make(map[string]*fitasks.ManagedFile)
+	for _, task := range taskMap {
+		if file, ok := task.(*fitasks.ManagedFile); ok && file.Location != nil {
+			files[*file.Location] = file
+		}
+	}
+
+	if files["addons/bootstrap-channel.yaml"] == nil {
+		// The addons are not built in every phase.
+		return nil, nil
+	}
+	channel, err := readManagedFile(files, "addons/bootstrap-channel.yaml")
+	if err != nil {
+		return nil, err
+	}
+	addons := &channelsapi.Addons{}
+	if err := yaml.Unmarshal(channel, addons); err != nil {
+		return nil, fmt.Errorf("error parsing bootstrap channel: %w", err)
+	}
+
+	for _, addon := range addons.Spec.Addons {
+		if fi.ValueOf(addon.Name) != profile.Addon {
+			continue
+		}
+		manifest, err := readManagedFile(files, "addons/"+fi.ValueOf(addon.Manifest))
+		if err != nil {
+			return nil, err
+		}
+		return &Upgrade{
+			Profile:        profile,
+			Addon:          addon,
+			Manifest:       manifest,
+			MaxUnavailable: maxUnavailable,
+		}, nil
+	}
+	return nil, nil
+}
+
+func readManagedFile(files map[string]*fitasks.ManagedFile, location string) ([]byte, error) {
+	file := files[location]
+	if file == nil {
+		return nil, fmt.Errorf("managed file %q not found", location)
+	}
+	b, err := fi.ResourceAsBytes(file.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("error reading managed file %q: %w", location, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cniupgrade rolls out a new version of a CNI addon in phases.
+//
+// The addon manager (channels) applies every object of an addon at once, which can take down the pod network
+// when the agents start before the components they depend on have been upgraded.
+// The orchestrator takes the upgrade over from the addon manager: it runs preflight checks,
+// applies the operators and waits for them to be ready, and only then updates the agent DaemonSet.
+package cniupgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/pkg/applylib/applyset"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// UpgradeAnnotation marks the namespace of an addon while the orchestrator upgrades it.
+// It lets a later run resume an upgrade that did not complete.
+const UpgradeAnnotation = "kops.k8s.io/cni-upgrade"
+
+var (
+	crdGVR        = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	configMapGVR  = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	namespaceGVR  = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	daemonSetGVR  = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}
+)
+
+// Orchestrator upgrades CNI addons in phases.
+type Orchestrator struct {
+	Client     dynamic.Interface
+	RESTMapper meta.RESTMapper
+	// Out receives the progress of the upgrade.
+	Out io.Writer
+	// PollInterval is how often the rollout of a component is checked.
+	PollInterval time.Duration
+	// Timeout is how long to wait for a component to be rolled out.
+	Timeout time.Duration
+}
+
+// Upgrade is a new version of a CNI addon.
+type Upgrade struct {
+	Profile *Profile
+	// Addon is the addon as listed in the bootstrap channel.
+	Addon *channelsapi.AddonSpec
+	// Manifest is the manifest of the new version.
+	Manifest []byte
+	// MaxUnavailable is the maxUnavailable the agent DaemonSet is updated with, if set.
+	MaxUnavailable *intstr.IntOrString
+}
+
+// Run upgrades the addon if the cluster does not run the new version yet.
+// Addons that are not installed yet are left to the addon manager.
+func (o *Orchestrator) Run(ctx context.Context, upgrade *Upgrade) error {
+	profile := upgrade.Profile
+
+	objects, err := kubemanifest.LoadObjectsFrom(upgrade.Manifest)
+	if err != nil {
+		return fmt.Errorf("error parsing %s manifest: %w", profile.Addon, err)
+	}
+
+	channel := &channels.Channel{Namespace: addonNamespace(upgrade.Addon), Name: profile.Addon}
+	installed, inProgress, err := o.installedVersion(ctx, channel)
+	if err != nil {
+		return err
+	}
+	if installed == nil {
+		fmt.Fprintf(o.Out, "%s is not installed yet; the addon manager will install it\n", profile.Name)
+		return nil
+	}
+	if !inProgress && installed.Id == upgrade.Addon.Id && installed.ManifestHash == upgrade.Addon.ManifestHash {
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "Upgrading %s in phases\n", profile.Name)
+
+	// Recording the new version stops the addon manager from applying it all at once while we upgrade.
+	version := &channels.ChannelVersion{
+		Channel:          installed.Channel,
+		Id:               upgrade.Addon.Id,
+		ManifestHash:     upgrade.Addon.ManifestHash,
+		SystemGeneration: channels.CurrentSystemGeneration,
+	}
+	if err := o.setInstalledVersion(ctx, channel, version, true); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Running preflight checks\n")
+	if err := o.preflight(ctx, profile, objects); err != nil {
+		return fmt.Errorf("preflight checks failed; the new version of %s has not been applied and will not be until a kops update cluster succeeds:\n%w", profile.Name, err)
+	}
+
+	var operators kubemanifest.ObjectList
+	for _, name := range profile.Operators {
+		if operator := findObject(objects, "Deployment", profile.Namespace, name); operator != nil {
+			operators = append(operators, operator)
+		}
+	}
+	agent := findObject(objects, "DaemonSet", profile.Namespace, profile.Agent)
+	if agent == nil {
+		return fmt.Errorf("%s manifest has no DaemonSet %s/%s", profile.Addon, profile.Namespace, profile.Agent)
+	}
+	var others kubemanifest.ObjectList
+	for _, object := range objects {
+		if object != agent && findObject(operators, object.Kind(), object.GetNamespace(), object.GetName()) == nil {
+			others = append(others, object)
+		}
+	}
+
+	fmt.Fprintf(o.Out, "Phase 1/3: applying configuration and custom resource definitions\n")
+	if err := o.apply(ctx, others); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Phase 2/3: updating operators\n")
+	for _, operator := range operators {
+		if err := o.apply(ctx, kubemanifest.ObjectList{operator}); err != nil {
+			return err
+		}
+		if err := o.waitForRollout(ctx, deploymentGVR, operator, deploymentRolloutStatus); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(o.Out, "Phase 3/3: updating agents\n")
+	if upgrade.MaxUnavailable != nil {
+		if err := setMaxUnavailable(agent, upgrade.MaxUnavailable); err != nil {
+			return err
+		}
+	}
+	if err := o.apply(ctx, kubemanifest.ObjectList{agent}); err != nil {
+		return err
+	}
+	strategy, _, _ := unstructured.NestedString(agent.ToUnstructured().Object, "spec", "updateStrategy", "type")
+	if strategy == "OnDelete" {
+		fmt.Fprintf(o.Out, "DaemonSet %s/%s is updated on delete; its pods run the new version once their nodes are replaced by kops rolling-update cluster\n", profile.Namespace, profile.Agent)
+	} else if err := o.waitForRollout(ctx, daemonSetGVR, agent, daemonSetRolloutStatus); err != nil {
+		return err
+	}
+
+	// Hand the addon back to the addon manager. The older SystemGeneration makes it apply the manifest once more,
+	// which changes nothing, and prune the objects that the new version no longer has.
+	version.SystemGeneration = 0
+	if err := o.setInstalledVersion(ctx, channel, version, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "%s upgrade complete\n", profile.Name)
+	return nil
+}
+
+// installedVersion returns the version of the addon that the addon manager recorded, and whether
+// an upgrade of the addon was started but did not complete.
+func (o *Orchestrator) installedVersion(ctx context.Context, channel *channels.Channel) (*channels.ChannelVersion, bool, error) {
+	ns, err := o.Client.Resource(namespaceGVR).Get(ctx, channel.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("error querying namespace %q: %w", channel.Namespace, err)
+	}
+
+	annotations := ns.GetAnnotations()
+	inProgress := annotations[UpgradeAnnotation] == channel.Name
+	value, found := annotations[channel.AnnotationName()]
+	if !found {
+		return nil, inProgress, nil
+	}
+	version, err := channels.ParseChannelVersion(value)
+	if err != nil {
+		return nil, false, err
+	}
+	return version, inProgress, nil
+}
+
+// setInstalledVersion records the version of the addon, and marks whether it is being upgraded.
+func (o *Orchestrator) setInstalledVersion(ctx context.Context, channel *channels.Channel, version *channels.ChannelVersion, inProgress bool) error {
+	value, err := version.Encode()
+	if err != nil {
+		return err
+	}
+
+	annotations := map[string]interface{}{
+		channel.AnnotationName(): value,
+		UpgradeAnnotation:        nil,
+	}
+	if inProgress {
+		annotations[UpgradeAnnotation] = channel.Name
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = o.Client.Resource(namespaceGVR).Patch(ctx, channel.Namespace, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("error annotating namespace %q: %w", channel.Namespace, err)
+	}
+	return nil
+}
+
+// apply applies objects to the cluster, the way the addon manager does.
+func (o *Orchestrator) apply(ctx context.Context, objects kubemanifest.ObjectList) error {
+	force := true
+	s, err := applyset.New(applyset.Options{
+		RESTMapper: o.RESTMapper,
+		Client:     o.Client,
+		PatchOptions: metav1.PatchOptions{
+			FieldManager: "kops",
+			Force:        &force,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var applyableObjects []applyset.ApplyableObject
+	for _, object := range objects {
+		applyableObjects = append(applyableObjects, object)
+	}
+	if err := s.SetDesiredObjects(applyableObjects); err != nil {
+		return err
+	}
+
+	results, err := s.ApplyOnce(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to apply objects: %w", err)
+	}
+	if !results.AllApplied() {
+		return fmt.Errorf("not all objects were applied")
+	}
+	return nil
+}
+
+// rolloutStatus reports whether a workload runs the new version on all its pods, with a description of its progress.
+type rolloutStatus func(u *unstructured.Unstructured) (bool, string)
+
+// waitForRollout waits until a workload runs the new version on all its pods, reporting its progress as it changes.
+func (o *Orchestrator) waitForRollout(ctx context.Context, gvr schema.GroupVersionResource, object *kubemanifest.Object, status rolloutStatus) error {
+	pollInterval := o.PollInterval
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Minute
+	}
+
+	name := object.Kind() + " " + object.GetNamespace() + "/" + object.GetName()
+	var last string
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		u, err := o.Client.Resource(gvr).Namespace(object.GetNamespace()).Get(ctx, object.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting %s: %w", name, err)
+		}
+		done, message := status(u)
+		if message != last {
+			fmt.Fprintf(o.Out, "  %s: %s\n", name, message)
+			last = message
+		}
+		return done, nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) {
+			return fmt.Errorf("timed out waiting for %s to roll out: %s", name, last)
+		}
+		return err
+	}
+	return nil
+}
+
+// deploymentRolloutStatus follows the checks of kubectl rollout status.
+func deploymentRolloutStatus(u *unstructured.Unstructured) (bool, string) {
+	observedGeneration, _, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if observedGeneration < u.GetGeneration() {
+		return false, "waiting for the update to be observed"
+	}
+
+	replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if !found {
+		replicas = 1
+	}
+	updated, _, _ := unstructured.NestedInt64(u.Object, "status", "updatedReplicas")
+	total, _, _ := unstructured.NestedInt64(u.Object, "status", "replicas")
+	available, _, _ := unstructured.NestedInt64(u.Object, "status", "availableReplicas")
+	switch {
+	case updated < replicas:
+		return false, fmt.Sprintf("%d of %d replicas updated", updated, replicas)
+	case total > updated:
+		return false, fmt.Sprintf("%d old replicas pending termination", total-updated)
+	case available < updated:
+		return false, fmt.Sprintf("%d of %d updated replicas available", available, updated)
+	}
+	return true, fmt.Sprintf("%d of %d updated replicas available", available, updated)
+}
+
+// daemonSetRolloutStatus follows the checks of kubectl rollout status.
+func daemonSetRolloutStatus(u *unstructured.Unstructured) (bool, string) {
+	observedGeneration, _, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if observedGeneration < u.GetGeneration() {
+		return false, "waiting for the update to be observed"
+	}
+
+	desired, _, _ := unstructured.NestedInt64(u.Object, "status", "desiredNumberScheduled")
+	updated, _, _ := unstructured.NestedInt64(u.Object, "status", "updatedNumberScheduled")
+	available, _, _ := unstructured.NestedInt64(u.Object, "status", "numberAvailable")
+	switch {
+	case updated < desired:
+		return false, fmt.Sprintf("%d of %d pods updated", updated, desired)
+	case available < desired:
+		return false, fmt.Sprintf("%d of %d updated pods available", available, desired)
+	}
+	return true, fmt.Sprintf("%d of %d updated pods available", available, desired)
+}
+
+// setMaxUnavailable makes a DaemonSet replace the given number of pods at a time.
+func setMaxUnavailable(daemonSet *kubemanifest.Object, maxUnavailable *intstr.IntOrString) error {
+	var value interface{} = maxUnavailable.StrVal
+	if maxUnavailable.Type == intstr.Int {
+		value = int64(maxUnavailable.IntVal)
+	}
+	strategy := map[string]interface{}{
+		"type": "RollingUpdate",
+		"rollingUpdate": map[string]interface{}{
+			"maxUnavailable": value,
+		},
+	}
+	return unstructured.SetNestedField(daemonSet.ToUnstructured().Object, strategy, "spec", "updateStrategy")
+}
+
+func findObject(objects kubemanifest.ObjectList, kind, namespace, name string) *kubemanifest.Object {
+	for _, object := range objects {
+		if object.Kind() == kind && object.GetNamespace() == namespace && object.GetName() == name {
+			return object
+		}
+	}
+	return nil
+}
+
+func addonNamespace(addon *channelsapi.AddonSpec) string {
+	if addon.Namespace != nil {
+		return *addon.Namespace
+	}
+	return "kube-system"
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cniupgrade
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const ciliumManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cilium-config
+  namespace: kube-system
+data:
+  routing-mode: tunnel
+  tunnel-protocol: vxlan
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cilium
+  namespace: kube-system
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: cilium
+  namespace: kube-system
+spec:
+  updateStrategy:
+    type: OnDelete
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cilium-operator
+  namespace: kube-system
+spec:
+  replicas: 2
+`
+
+const calicoManifest = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: ippools.crd.projectcalico.org
+spec:
+  versions:
+  - name: v1
+    served: true
+    storage: true
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: calico-config
+  namespace: kube-system
+data:
+  calico_backend: vxlan
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: calico-node
+  namespace: kube-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: calico-kube-controllers
+  namespace: kube-system
+`
+
+// fakeCluster simulates the rollouts of a cluster with four nodes on a fake dynamic client.
+type fakeCluster struct {
+	client *fake.FakeDynamicClient
+	// events records the objects that were applied and rolled out, in order.
+	events []string
+}
+
+func newFakeCluster(t *testing.T, addon string, manifestHash string, objects ...string) *fakeCluster {
+	var runtimeObjects []runtime.Object
+	for _, s := range objects {
+		u := &unstructured.Unstructured{}
+		if err := u.UnmarshalJSON([]byte(s)); err != nil {
+			t.Fatalf("error parsing object: %v", err)
+		}
+		runtimeObjects = append(runtimeObjects, u)
+	}
+
+	version := &channels.ChannelVersion{
+		Channel:          fi.PtrTo("s3://bucket/cluster.example.com/addons/bootstrap-channel.yaml"),
+		Id:               "k8s-1.16",
+		ManifestHash:     manifestHash,
+		SystemGeneration: channels.CurrentSystemGeneration,
+	}
+	value, err := version.Encode()
+	if err != nil {
+		t.Fatalf("error encoding version: %v", err)
+	}
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName("kube-system")
+	ns.SetAnnotations(map[string]string{"addons.k8s.io/" + addon: value})
+	runtimeObjects = append(runtimeObjects, ns)
+
+	c := &fakeCluster{client: fake.NewSimpleDynamicClient(runtime.NewScheme(), runtimeObjects...)}
+	c.client.PrependReactor("patch", "*", c.apply)
+	c.client.PrependReactor("get", "deployments", c.rollOut)
+	c.client.PrependReactor("get", "daemonsets", c.rollOut)
+	return c
+}
+
+// apply stores server-side applied objects; a new spec starts a rollout.
+func (c *fakeCluster) apply(action clienttesting.Action) (bool, runtime.Object, error) {
+	patch := action.(clienttesting.PatchAction)
+	if patch.GetPatchType() != types.ApplyPatchType {
+		return false, nil, nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+		return true, nil, err
+	}
+	c.events = append(c.events, "apply "+obj.GetKind()+" "+obj.GetName())
+
+	tracker := c.client.Tracker()
+	gvr, ns := action.GetResource(), action.GetNamespace()
+	existing, err := tracker.Get(gvr, ns, obj.GetName())
+	if apierrors.IsNotFound(err) {
+		obj.SetGeneration(1)
+		return true, obj, tracker.Create(gvr, obj, ns)
+	}
+	if err != nil {
+		return true, nil, err
+	}
+
+	status, _, _ := unstructured.NestedMap(existing.(*unstructured.Unstructured).Object, "status")
+	delete(status, "updatedReplicas")
+	delete(status, "updatedNumberScheduled")
+	if err := unstructured.SetNestedMap(obj.Object, status, "status"); err != nil {
+		return true, nil, err
+	}
+	obj.SetGeneration(existing.(*unstructured.Unstructured).GetGeneration() + 1)
+	return true, obj, tracker.Update(gvr, obj, ns)
+}
+
+// rollOut advances the rollout of a workload each time it is read.
+// Deployments replace one replica at a time, DaemonSets replace maxUnavailable pods at a time.
+func (c *fakeCluster) rollOut(action clienttesting.Action) (bool, runtime.Object, error) {
+	get := action.(clienttesting.GetAction)
+	tracker := c.client.Tracker()
+	gvr, ns := action.GetResource(), action.GetNamespace()
+	existing, err := tracker.Get(gvr, ns, get.GetName())
+	if err != nil {
+		return true, nil, err
+	}
+	u := existing.(*unstructured.Unstructured).DeepCopy()
+
+	statusField, step, total := "updatedReplicas", int64(1), int64(1)
+	if replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas"); found {
+		total = replicas
+	}
+	if u.GetKind() == "DaemonSet" {
+		statusField, total = "updatedNumberScheduled", 4
+		maxUnavailable, _, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "updateStrategy", "rollingUpdate", "maxUnavailable")
+		if v, ok := maxUnavailable.(int64); ok {
+			step = v
+		}
+	}
+
+	updated, _, _ := unstructured.NestedInt64(u.Object, "status", statusField)
+	if updated < total {
+		updated = min(updated+step, total)
+		if updated == total {
+			c.events = append(c.events, "rolled out "+u.GetKind()+" "+u.GetName())
+		}
+	}
+	status := map[string]interface{}{
+		"observedGeneration":     u.GetGeneration(),
+		statusField:              updated,
+		"replicas":               total,
+		"availableReplicas":      updated,
+		"desiredNumberScheduled": total,
+		"numberAvailable":        updated,
+	}
+	if err := unstructured.SetNestedMap(u.Object, status, "status"); err != nil {
+		return true, nil, err
+	}
+	return true, u, tracker.Update(gvr, u, ns)
+}
+
+func (c *fakeCluster) namespaceAnnotations(t *testing.T) map[string]string {
+	ns, err := c.client.Tracker().Get(namespaceGVR, "", "kube-system")
+	if err != nil {
+		t.Fatalf("error getting namespace: %v", err)
+	}
+	return ns.(*unstructured.Unstructured).GetAnnotations()
+}
+
+func newOrchestrator(c *fakeCluster, out *bytes.Buffer) *Orchestrator {
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ServiceAccount"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}, meta.RESTScopeRoot)
+
+	return &Orchestrator{
+		Client:       c.client,
+		RESTMapper:   restMapper,
+		Out:          out,
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+	}
+}
+
+func ciliumCRD(name string) string {
+	return `{"apiVersion": "apiextensions.k8s.io/v1", "kind": "CustomResourceDefinition", "metadata": {"name": "` + name + `"},
+		"spec": {"versions": [{"name": "v2", "served": true, "storage": true}]}, "status": {"storedVersions": ["v2"]}}`
+}
+
+func TestPhasedRollout(t *testing.T) {
+	c := newFakeCluster(t, "networking.cilium.io", "old",
+		`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cilium-config", "namespace": "kube-system"}, "data": {"tunnel": "vxlan"}}`,
+		ciliumCRD("ciliumclusterwidenetworkpolicies.cilium.io"),
+		ciliumCRD("ciliumendpoints.cilium.io"),
+		ciliumCRD("ciliumidentities.cilium.io"),
+		ciliumCRD("ciliumnetworkpolicies.cilium.io"),
+		ciliumCRD("ciliumnodes.cilium.io"),
+	)
+	var out bytes.Buffer
+	upgrade := &Upgrade{
+		Profile:        Cilium,
+		Addon:          &channelsapi.AddonSpec{Name: fi.PtrTo("networking.cilium.io"), Id: "k8s-1.16", ManifestHash: "new"},
+		Manifest:       []byte(ciliumManifest),
+		MaxUnavailable: intstrPtr(intstr.FromInt(2)),
+	}
+	if err := newOrchestrator(c, &out).Run(context.Background(), upgrade); err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out.String())
+	}
+
+	expectedEvents := []string{
+		"apply ConfigMap cilium-config",
+		"apply ServiceAccount cilium",
+		"apply Deployment cilium-operator",
+		"rolled out Deployment cilium-operator",
+		"apply DaemonSet cilium",
+		"rolled out DaemonSet cilium",
+	}
+	if strings.Join(c.events, "\n") != strings.Join(expectedEvents, "\n") {
+		t.Errorf("unexpected events\nactual:\n%s\nexpected:\n%s", strings.Join(c.events, "\n"), strings.Join(expectedEvents, "\n"))
+	}
+
+	expectedOutput := `Upgrading Cilium in phases
+Running preflight checks
+Phase 1/3: applying configuration and custom resource definitions
+Phase 2/3: updating operators
+  Deployment kube-system/cilium-operator: 1 of 2 replicas updated
+  Deployment kube-system/cilium-operator: 2 of 2 updated replicas available
+Phase 3/3: updating agents
+  DaemonSet kube-system/cilium: 2 of 4 pods updated
+  DaemonSet kube-system/cilium: 4 of 4 updated pods available
+Cilium upgrade complete
+`
+	if out.String() != expectedOutput {
+		t.Errorf("unexpected output\nactual:\n%s\nexpected:\n%s", out.String(), expectedOutput)
+	}
+
+	annotations := c.namespaceAnnotations(t)
+	if _, found := annotations[UpgradeAnnotation]; found {
+		t.Errorf("upgrade annotation was not removed")
+	}
+	version, err := channels.ParseChannelVersion(annotations["addons.k8s.io/networking.cilium.io"])
+	if err != nil {
+		t.Fatalf("error parsing version: %v", err)
+	}
+	if version.ManifestHash != "new" || version.SystemGeneration != 0 {
+		t.Errorf("addon was not handed back to the addon manager: %v", version)
+	}
+
+	// The cluster now runs the new version, so there is nothing left to do.
+	c.events = nil
+	out.Reset()
+	if err := newOrchestrator(c, &out).Run(context.Background(), upgrade); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.events) != 0 || out.Len() != 0 {
+		t.Errorf("unexpected upgrade of an up to date addon: %v\n%s", c.events, out.String())
+	}
+}
+
+func TestFailedPreflight(t *testing.T) {
+	c := newFakeCluster(t, "networking.projectcalico.org", "old",
+		`{"apiVersion": "apiextensions.k8s.io/v1", "kind": "CustomResourceDefinition", "metadata": {"name": "ippools.crd.projectcalico.org"},
+			"spec": {"versions": [{"name": "v1", "served": true, "storage": true}, {"name": "v1beta1", "served": true, "storage": false}]},
+			"status": {"storedVersions": ["v1beta1", "v1"]}}`,
+		`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "calico-config", "namespace": "kube-system"}, "data": {"calico_backend": "bird"}}`,
+	)
+	var out bytes.Buffer
+	upgrade := &Upgrade{
+		Profile:  Calico,
+		Addon:    &channelsapi.AddonSpec{Name: fi.PtrTo("networking.projectcalico.org"), Id: "k8s-1.25", ManifestHash: "new"},
+		Manifest: []byte(calicoManifest),
+	}
+	err := newOrchestrator(c, &out).Run(context.Background(), upgrade)
+	if err == nil {
+		t.Fatalf("expected preflight checks to fail")
+	}
+	for _, expected := range []string{
+		`CustomResourceDefinition "ippools.crd.projectcalico.org" has objects stored as v1beta1, which the new version does not serve`,
+		`ConfigMap kube-system/calico-config changes calico_backend from "bird" to "vxlan"`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got: %v", expected, err)
+		}
+	}
+	if len(c.events) != 0 {
+		t.Errorf("objects were applied despite failed preflight checks: %v", c.events)
+	}
+
+	// The addon stays claimed, so that the addon manager does not apply it, and the next run resumes the upgrade.
+	annotations := c.namespaceAnnotations(t)
+	if annotations[UpgradeAnnotation] != "networking.projectcalico.org" {
+		t.Errorf("addon was not claimed: %v", annotations)
+	}
+	version, err := channels.ParseChannelVersion(annotations["addons.k8s.io/networking.projectcalico.org"])
+	if err != nil {
+		t.Fatalf("error parsing version: %v", err)
+	}
+	if version.ManifestHash != "new" || version.SystemGeneration != channels.CurrentSystemGeneration {
+		t.Errorf("unexpected claimed version: %v", version)
+	}
+	channel := &channels.Channel{Namespace: "kube-system", Name: "networking.projectcalico.org"}
+	if _, inProgress, err := newOrchestrator(c, &out).installedVersion(context.Background(), channel); err != nil || !inProgress {
+		t.Errorf("upgrade is not resumed by the next run: %v", err)
+	}
+}
+
+func TestMigrateCiliumTunnel(t *testing.T) {
+	grid := []struct {
+		running  map[string]string
+		desired  map[string]string
+		expected string
+	}{
+		{
+			running: map[string]string{"routing-mode": "native"},
+			desired: map[string]string{"routing-mode": "native"},
+		},
+		{
+			running: map[string]string{"tunnel": "disabled"},
+			desired: map[string]string{"routing-mode": "native"},
+		},
+		{
+			running:  map[string]string{"tunnel": "disabled"},
+			desired:  map[string]string{"routing-mode": "tunnel", "tunnel-protocol": "vxlan"},
+			expected: `running configuration has tunnel="disabled", which requires routing-mode="native", but the new configuration has routing-mode="tunnel"`,
+		},
+		{
+			running:  map[string]string{"tunnel": "geneve"},
+			desired:  map[string]string{"routing-mode": "tunnel", "tunnel-protocol": "vxlan"},
+			expected: `running configuration has tunnel="geneve", which requires tunnel-protocol="geneve", but the new configuration has tunnel-protocol="vxlan"`,
+		},
+	}
+	for _, g := range grid {
+		err := migrateCiliumTunnel(g.running, g.desired)
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != g.expected {
+			t.Errorf("unexpected result for %v -> %v: %q, expected %q", g.running, g.desired, actual, g.expected)
+		}
+	}
+}
+
+// Ensures findObject matches on kind, namespace and name.
+func TestFindObject(t *testing.T) {
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(ciliumManifest))
+	if err != nil {
+		t.Fatalf("error parsing manifest: %v", err)
+	}
+	if findObject(objects, "DaemonSet", "kube-system", "cilium") == nil {
+		t.Errorf("DaemonSet not found")
+	}
+	if findObject(objects, "DaemonSet", "default", "cilium") != nil {
+		t.Errorf("object found in the wrong namespace")
+	}
+}
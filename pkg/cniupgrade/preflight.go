@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cniupgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// preflight checks that the new version of the addon can be rolled out in phases.
+// It reports every problem it finds, so they can be fixed in one go.
+func (o *Orchestrator) preflight(ctx context.Context, profile *Profile, objects kubemanifest.ObjectList) error {
+	var errs []error
+
+	for _, object := range objects {
+		if object.Kind() != "CustomResourceDefinition" {
+			continue
+		}
+		if err := o.checkStoredVersions(ctx, object); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, crd := range profile.RequiredCRDs {
+		if findObject(objects, "CustomResourceDefinition", "", crd.Name) != nil {
+			continue
+		}
+		if err := o.checkCRDServesVersion(ctx, crd); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := o.checkConfig(ctx, profile, objects); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkStoredVersions checks that a CRD of the manifest still serves every version that objects
+// are stored as in the cluster, as those objects could no longer be read otherwise.
+func (o *Orchestrator) checkStoredVersions(ctx context.Context, object *kubemanifest.Object) error {
+	name := object.GetName()
+	existing, err := o.Client.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting CustomResourceDefinition %q: %w", name, err)
+	}
+
+	storedVersions, _, _ := unstructured.NestedStringSlice(existing.Object, "status", "storedVersions")
+	served := servedVersions(object.ToUnstructured().Object)
+	for _, version := range storedVersions {
+		if !slices.Contains(served, version) {
+			return fmt.Errorf("CustomResourceDefinition %q has objects stored as %s, which the new version does not serve; migrate them to one of %v first", name, version, served)
+		}
+	}
+	return nil
+}
+
+// checkCRDServesVersion checks that a CRD registered by the running components serves a version.
+func (o *Orchestrator) checkCRDServesVersion(ctx context.Context, crd CRDVersion) error {
+	existing, err := o.Client.Resource(crdGVR).Get(ctx, crd.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("CustomResourceDefinition %q is not present", crd.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting CustomResourceDefinition %q: %w", crd.Name, err)
+	}
+	if !slices.Contains(servedVersions(existing.Object), crd.Version) {
+		return fmt.Errorf("CustomResourceDefinition %q does not serve %s", crd.Name, crd.Version)
+	}
+	return nil
+}
+
+// checkConfig checks that the new configuration keeps the datapath of the running configuration.
+func (o *Orchestrator) checkConfig(ctx context.Context, profile *Profile, objects kubemanifest.ObjectList) error {
+	object := findObject(objects, "ConfigMap", profile.Namespace, profile.ConfigMap)
+	if object == nil {
+		return nil
+	}
+	desired, _, _ := unstructured.NestedStringMap(object.ToUnstructured().Object, "data")
+
+	existing, err := o.Client.Resource(configMapGVR).Namespace(profile.Namespace).Get(ctx, profile.ConfigMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting ConfigMap %s/%s: %w", profile.Namespace, profile.ConfigMap, err)
+	}
+	running, _, _ := unstructured.NestedStringMap(existing.Object, "data")
+
+	var errs []error
+	for _, key := range profile.DatapathKeys {
+		value, found := running[key]
+		if found && desired[key] != value {
+			errs = append(errs, fmt.Errorf("ConfigMap %s/%s changes %s from %q to %q, which cannot be rolled out one node at a time; update the cluster without a phased CNI upgrade", profile.Namespace, profile.ConfigMap, key, value, desired[key]))
+		}
+	}
+	for _, migration := range profile.Migrations {
+		if err := migration(running, desired); err != nil {
+			errs = append(errs, fmt.Errorf("ConfigMap %s/%s: %w", profile.Namespace, profile.ConfigMap, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// servedVersions returns the versions a CustomResourceDefinition serves.
+func servedVersions(crd map[string]interface{}) []string {
+	versions, _, _ := unstructured.NestedSlice(crd, "spec", "versions")
+	var served []string
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(version, "name")
+		isServed, _, _ := unstructured.NestedBool(version, "served")
+		if isServed {
+			served = append(served, name)
+		}
+	}
+	return served
+}
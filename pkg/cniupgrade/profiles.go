@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cniupgrade
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// Profile describes how the components of a CNI addon are upgraded.
+type Profile struct {
+	// Name is the name of the CNI, as shown in the command output.
+	Name string
+	// Addon is the name of the addon in the bootstrap channel.
+	Addon string
+	// Namespace is the namespace the components run in.
+	Namespace string
+	// ConfigMap is the ConfigMap holding the agent configuration.
+	ConfigMap string
+	// Operators are the Deployments that must run the new version before the agents are updated.
+	// Deployments that are not in the manifest are skipped.
+	Operators []string
+	// Agent is the DaemonSet running the agent on every node.
+	Agent string
+	// RequiredCRDs are the custom resource definitions the new version relies on that are
+	// registered by the running components rather than shipped in the manifest.
+	RequiredCRDs []CRDVersion
+	// DatapathKeys are the configuration keys that select the datapath.
+	// Changing them while the agents are updated one node at a time breaks traffic between
+	// pods on updated and not yet updated nodes.
+	DatapathKeys []string
+	// Migrations check that settings of the running configuration carry over to the new configuration schema.
+	Migrations []ConfigMigration
+}
+
+// CRDVersion is a version of a custom resource definition.
+type CRDVersion struct {
+	Name    string
+	Version string
+}
+
+// ConfigMigration checks that the new configuration preserves a setting of the running configuration,
+// whose key was replaced by a later version.
+type ConfigMigration func(running, desired map[string]string) error
+
+// Cilium upgrades the Cilium operator before the Cilium agents.
+var Cilium = &Profile{
+	Name:      "Cilium",
+	Addon:     "networking.cilium.io",
+	Namespace: "kube-system",
+	ConfigMap: "cilium-config",
+	Operators: []string{"cilium-operator"},
+	Agent:     "cilium",
+	// The Cilium operator registers the CRDs when it starts, so the new agents find them in place.
+	RequiredCRDs: []CRDVersion{
+		{Name: "ciliumclusterwidenetworkpolicies.cilium.io", Version: "v2"},
+		{Name: "ciliumendpoints.cilium.io", Version: "v2"},
+		{Name: "ciliumidentities.cilium.io", Version: "v2"},
+		{Name: "ciliumnetworkpolicies.cilium.io", Version: "v2"},
+		{Name: "ciliumnodes.cilium.io", Version: "v2"},
+	},
+	DatapathKeys: []string{"ipam", "routing-mode", "tunnel-protocol"},
+	Migrations:   []ConfigMigration{migrateCiliumTunnel},
+}
+
+// Calico upgrades calico-kube-controllers and Typha before calico-node.
+var Calico = &Profile{
+	Name:         "Calico",
+	Addon:        "networking.projectcalico.org",
+	Namespace:    "kube-system",
+	ConfigMap:    "calico-config",
+	Operators:    []string{"calico-kube-controllers", "calico-typha"},
+	Agent:        "calico-node",
+	DatapathKeys: []string{"calico_backend"},
+}
+
+// ProfileFor returns the upgrade profile of the CNI of a cluster and the maxUnavailable of its agent DaemonSet,
+// or nil if the CNI is not upgraded in phases.
+func ProfileFor(networking *kops.NetworkingSpec) (*Profile, *intstr.IntOrString) {
+	switch {
+	case networking.Cilium != nil:
+		return Cilium, networking.Cilium.UpgradeMaxUnavailable
+	case networking.Calico != nil:
+		maxUnavailable := networking.Calico.UpgradeMaxUnavailable
+		if maxUnavailable == nil {
+			// Matches the default of the calico-node DaemonSet in the manifest.
+			maxUnavailable = intstrPtr(intstr.FromInt(1))
+		}
+		return Calico, maxUnavailable
+	}
+	return nil, nil
+}
+
+// migrateCiliumTunnel checks that the "tunnel" setting, replaced by "routing-mode" and "tunnel-protocol"
+// in Cilium 1.14, selects the same datapath in the new configuration.
+func migrateCiliumTunnel(running, desired map[string]string) error {
+	tunnel, found := running["tunnel"]
+	if !found {
+		return nil
+	}
+
+	routingMode, tunnelProtocol := "tunnel", tunnel
+	if tunnel == "disabled" {
+		routingMode, tunnelProtocol = "native", ""
+	}
+	if desired["routing-mode"] != routingMode {
+		return fmt.Errorf("running configuration has tunnel=%q, which requires routing-mode=%q, but the new configuration has routing-mode=%q", tunnel, routingMode, desired["routing-mode"])
+	}
+	if tunnelProtocol != "" && desired["tunnel-protocol"] != tunnelProtocol {
+		return fmt.Errorf("running configuration has tunnel=%q, which requires tunnel-protocol=%q, but the new configuration has tunnel-protocol=%q", tunnel, tunnelProtocol, desired["tunnel-protocol"])
+	}
+	return nil
+}
+
+func intstrPtr(v intstr.IntOrString) *intstr.IntOrString {
+	return &v
+}
@@ -19,6 +19,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
@@ -28,8 +29,9 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 )
 
-// UpdateCluster writes the updated cluster to the state store, after performing validation
-func UpdateCluster(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) error {
+// UpdateCluster writes the updated cluster to the state store, after performing validation.
+// Validation warnings are printed to out.
+func UpdateCluster(ctx context.Context, clientset simple.Clientset, out io.Writer, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, options simple.UpdateClusterOptions) error {
 	cloud, err := cloudup.BuildCloud(cluster)
 	if err != nil {
 		return err
@@ -46,7 +48,8 @@ func UpdateCluster(ctx context.Context, clientset simple.Clientset, cluster *kop
 		return err
 	}
 
-	err = validation.DeepValidate(fullCluster, instanceGroups, true, clientset.VFSContext(), nil)
+	warnings, err := validation.DeepValidateWithWarnings(fullCluster, instanceGroups, true, clientset.VFSContext(), nil)
+	validation.PrintWarnings(out, warnings)
 	if err != nil {
 		return err
 	}
@@ -57,8 +60,14 @@ func UpdateCluster(ctx context.Context, clientset simple.Clientset, cluster *kop
 		return err
 	}
 
+	old, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		return err
+	}
+	validation.PrintWarnings(out, validation.ClusterUpdateWarnings(cluster, old))
+
 	// Note we perform as much validation as we can, before writing a bad config
-	_, err = clientset.UpdateCluster(ctx, cluster, status)
+	_, err = clientset.UpdateCluster(ctx, cluster, status, options)
 	if err != nil {
 		return err
 	}
@@ -66,8 +75,9 @@ func UpdateCluster(ctx context.Context, clientset simple.Clientset, cluster *kop
 	return nil
 }
 
-// UpdateInstanceGroup writes the updated instance group to the state store after performing validation
-func UpdateInstanceGroup(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, allInstanceGroups []*kops.InstanceGroup, instanceGroupToUpdate *kops.InstanceGroup) error {
+// UpdateInstanceGroup writes the updated instance group to the state store after performing validation.
+// Validation warnings are printed to out.
+func UpdateInstanceGroup(ctx context.Context, clientset simple.Clientset, out io.Writer, cluster *kops.Cluster, allInstanceGroups []*kops.InstanceGroup, instanceGroupToUpdate *kops.InstanceGroup) error {
 	cloud, err := cloudup.BuildCloud(cluster)
 	if err != nil {
 		return err
@@ -84,9 +94,10 @@ func UpdateInstanceGroup(ctx context.Context, clientset simple.Clientset, cluste
 		return err
 	}
 
-	err = validation.CrossValidateInstanceGroup(instanceGroupToUpdate, fullCluster, cloud, false).ToAggregate()
-	if err != nil {
-		return err
+	result := validation.CrossValidateInstanceGroupWithWarnings(instanceGroupToUpdate, fullCluster, cloud, false)
+	validation.PrintWarnings(out, result.Warnings)
+	if result.HasErrors() {
+		return result.Errors.ToAggregate()
 	}
 
 	// Validation was successful so commit the changed instance group.
@@ -426,7 +426,7 @@ func buildBootstrapData(ctx context.Context, clientset simple.Clientset, cluster
 	}
 
 	assets := make(map[architectures.Architecture][]*mirrors.MirroredAsset)
-	configBuilder, err := cloudup.NewNodeUpConfigBuilder(cluster, assetBuilder, assets, encryptionConfigSecretHash)
+	configBuilder, err := cloudup.NewNodeUpConfigBuilder(cluster, assetBuilder, assets, nil, encryptionConfigSecretHash)
 	if err != nil {
 		return nil, err
 	}
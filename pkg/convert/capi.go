@@ -0,0 +1,453 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package convert translates kOps Cluster and InstanceGroup specifications into the manifests of other tools.
+package convert
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	autoscalerMinSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size"
+	autoscalerMaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
+// manifest is an object to be emitted, together with the comment lines that precede it.
+type manifest struct {
+	comments []string
+	object   interface{}
+}
+
+// ToClusterAPI converts a cluster and its instance groups into Cluster API manifests, on a best-effort basis.
+// Every kOps field that could not be mapped is listed in a comment block above the manifest
+// generated from the object it belongs to.
+func ToClusterAPI(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) ([]byte, error) {
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return nil, fmt.Errorf("only AWS clusters can be converted to Cluster API, cluster uses %q", cluster.Spec.GetCloudProvider())
+	}
+	if cluster.UsesLegacyGossip() {
+		return nil, fmt.Errorf("cluster %q uses gossip DNS, which Cluster API does not support; migrate the cluster to topology.dns.type=None or a DNS zone first", cluster.Name)
+	}
+
+	region, err := awsup.FindRegion(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterUnmapped, err := unmappedFields(&cluster.Spec, clusterFieldMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := cluster.Name
+	version := cluster.Spec.KubernetesVersion
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	infrastructureCluster := &awsCluster{
+		APIVersion: capaAPIVersion,
+		Kind:       "AWSCluster",
+		Metadata:   objectMeta{Name: clusterName},
+		Spec: awsClusterSpec{
+			Region:         region,
+			SSHKeyName:     cluster.Spec.SSHKeyName,
+			AdditionalTags: cluster.Spec.CloudLabels,
+		},
+	}
+	if cluster.Spec.Networking.NetworkID != "" || cluster.Spec.Networking.NetworkCIDR != "" {
+		infrastructureCluster.Spec.Network.VPC = &vpcSpec{
+			ID:        cluster.Spec.Networking.NetworkID,
+			CidrBlock: cluster.Spec.Networking.NetworkCIDR,
+		}
+	}
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		infrastructureCluster.Spec.Network.Subnets = append(infrastructureCluster.Spec.Network.Subnets, subnetSpec{
+			ID:               subnet.ID,
+			CidrBlock:        subnet.CIDR,
+			AvailabilityZone: subnet.Zone,
+			IsPublic:         subnet.Type == kops.SubnetTypePublic || subnet.Type == kops.SubnetTypeUtility,
+		})
+	}
+	if lb := cluster.Spec.API.LoadBalancer; lb != nil {
+		spec := &awsLoadBalancerSpec{
+			CrossZone: fi.ValueOf(lb.CrossZoneLoadBalancing),
+		}
+		switch lb.Type {
+		case kops.LoadBalancerTypePublic:
+			spec.Scheme = "internet-facing"
+		case kops.LoadBalancerTypeInternal:
+			spec.Scheme = "internal"
+		}
+		switch lb.Class {
+		case kops.LoadBalancerClassClassic:
+			spec.LoadBalancerType = "classic"
+		case kops.LoadBalancerClassNetwork:
+			spec.LoadBalancerType = "nlb"
+		}
+		infrastructureCluster.Spec.ControlPlaneLoadBalancer = spec
+	}
+	if cluster.Spec.Networking.Topology != nil && cluster.Spec.Networking.Topology.Bastion != nil {
+		infrastructureCluster.Spec.Bastion = &bastion{Enabled: true}
+	}
+
+	capiClusterObject := &capiCluster{
+		APIVersion: capiAPIVersion,
+		Kind:       "Cluster",
+		Metadata:   objectMeta{Name: clusterName},
+		Spec: capiClusterSpec{
+			ControlPlaneRef: &objectReference{
+				APIVersion: capiControlPlaneVersion,
+				Kind:       "KubeadmControlPlane",
+				Name:       clusterName + "-control-plane",
+			},
+			InfrastructureRef: &objectReference{
+				APIVersion: capaAPIVersion,
+				Kind:       "AWSCluster",
+				Name:       clusterName,
+			},
+		},
+	}
+	if cluster.Spec.Networking.PodCIDR != "" || cluster.Spec.Networking.ServiceClusterIPRange != "" {
+		capiClusterObject.Spec.ClusterNetwork = &clusterNetwork{}
+		if cluster.Spec.Networking.PodCIDR != "" {
+			capiClusterObject.Spec.ClusterNetwork.Pods = &networkRanges{CIDRBlocks: []string{cluster.Spec.Networking.PodCIDR}}
+		}
+		if cluster.Spec.Networking.ServiceClusterIPRange != "" {
+			capiClusterObject.Spec.ClusterNetwork.Services = &networkRanges{CIDRBlocks: []string{cluster.Spec.Networking.ServiceClusterIPRange}}
+		}
+	}
+
+	clusterManifest := &manifest{object: capiClusterObject}
+	infrastructureManifest := &manifest{
+		object:   infrastructureCluster,
+		comments: unmappedComment("Cluster "+clusterName, clusterUnmapped),
+	}
+	manifests := []*manifest{clusterManifest, infrastructureManifest}
+
+	var controlPlaneGroups []*kops.InstanceGroup
+	var nodeManifests []*manifest
+	for _, ig := range instanceGroups {
+		switch ig.Spec.Role {
+		case kops.InstanceGroupRoleControlPlane:
+			controlPlaneGroups = append(controlPlaneGroups, ig)
+
+		case kops.InstanceGroupRoleNode:
+			igManifests, err := convertNodeInstanceGroup(cluster, ig, version)
+			if err != nil {
+				return nil, err
+			}
+			nodeManifests = append(nodeManifests, igManifests...)
+
+		default:
+			// Bastions are managed by the AWSCluster, and there is no equivalent for API server nodes
+			infrastructureManifest.comments = append(infrastructureManifest.comments,
+				fmt.Sprintf("# InstanceGroup %s with role %s could not be converted", ig.Name, ig.Spec.Role))
+		}
+	}
+
+	if len(controlPlaneGroups) == 0 {
+		return nil, fmt.Errorf("cluster %q has no control plane instance groups", clusterName)
+	}
+	controlPlaneManifests, err := convertControlPlaneInstanceGroups(cluster, controlPlaneGroups, version)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, controlPlaneManifests...)
+	manifests = append(manifests, nodeManifests...)
+
+	var b bytes.Buffer
+	for i, m := range manifests {
+		if i != 0 {
+			b.WriteString("\n---\n\n")
+		}
+		for _, comment := range m.comments {
+			b.WriteString(comment + "\n")
+		}
+		y, err := yaml.Marshal(m.object)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing manifest: %w", err)
+		}
+		b.Write(y)
+	}
+	return b.Bytes(), nil
+}
+
+// convertControlPlaneInstanceGroups builds a KubeadmControlPlane spanning all control plane instance groups.
+// Cluster API spreads control plane machines across failure domains itself, so the machine template
+// is taken from the first instance group.
+func convertControlPlaneInstanceGroups(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, version string) ([]*manifest, error) {
+	name := cluster.Name + "-control-plane"
+
+	machine, unmapped, err := convertMachineSpec(cluster, instanceGroups[0])
+	if err != nil {
+		return nil, err
+	}
+	comments := unmappedComment("InstanceGroup "+instanceGroups[0].Name, unmapped)
+
+	var replicas int32
+	for i, ig := range instanceGroups {
+		replicas += fi.ValueOf(ig.Spec.MinSize)
+		if i != 0 {
+			comments = append(comments, fmt.Sprintf("# InstanceGroup %s only contributes replicas, the machine template is taken from InstanceGroup %s", ig.Name, instanceGroups[0].Name))
+		}
+	}
+
+	template := &manifest{
+		comments: comments,
+		object: &awsMachineTemplate{
+			APIVersion: capaAPIVersion,
+			Kind:       "AWSMachineTemplate",
+			Metadata:   objectMeta{Name: name},
+			Spec: awsMachineTemplateSpec{
+				Template: awsMachineTemplateResource{Spec: machine},
+			},
+		},
+	}
+
+	nodeRegistration := nodeRegistrationOptions{
+		Name:             "{{ ds.meta_data.local_hostname }}",
+		KubeletExtraArgs: map[string]string{"cloud-provider": "external"},
+	}
+	controlPlane := &manifest{
+		object: &kubeadmControlPlane{
+			APIVersion: capiControlPlaneVersion,
+			Kind:       "KubeadmControlPlane",
+			Metadata:   objectMeta{Name: name},
+			Spec: kubeadmControlPlaneSpec{
+				Replicas: fi.PtrTo(replicas),
+				Version:  version,
+				MachineTemplate: kubeadmControlPlaneTemplate{
+					InfrastructureRef: objectReference{
+						APIVersion: capaAPIVersion,
+						Kind:       "AWSMachineTemplate",
+						Name:       name,
+					},
+				},
+				KubeadmConfigSpec: kubeadmConfigSpec{
+					InitConfiguration: &kubeadmNodeConfiguration{NodeRegistration: nodeRegistration},
+					JoinConfiguration: &kubeadmNodeConfiguration{NodeRegistration: nodeRegistration},
+				},
+			},
+		},
+	}
+
+	return []*manifest{controlPlane, template}, nil
+}
+
+// convertNodeInstanceGroup builds the AWSMachineTemplate, KubeadmConfigTemplate and MachineDeployment for a node instance group.
+func convertNodeInstanceGroup(cluster *kops.Cluster, ig *kops.InstanceGroup, version string) ([]*manifest, error) {
+	name := ig.Name + "." + cluster.Name
+
+	machine, unmapped, err := convertMachineSpec(cluster, ig)
+	if err != nil {
+		return nil, err
+	}
+
+	var failureDomain *string
+	if len(ig.Spec.Subnets) == 1 {
+		for _, subnet := range cluster.Spec.Networking.Subnets {
+			if subnet.Name == ig.Spec.Subnets[0] {
+				failureDomain = fi.PtrTo(subnet.Zone)
+			}
+		}
+	} else if len(ig.Spec.Subnets) > 1 {
+		// A MachineDeployment targets a single failure domain
+		unmapped = append(unmapped, "subnets")
+	}
+
+	nodeRegistration := nodeRegistrationOptions{
+		Name:             "{{ ds.meta_data.local_hostname }}",
+		KubeletExtraArgs: map[string]string{"cloud-provider": "external"},
+	}
+	if len(ig.Spec.NodeLabels) > 0 {
+		var labels []string
+		for k, v := range ig.Spec.NodeLabels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		nodeRegistration.KubeletExtraArgs["node-labels"] = strings.Join(labels, ",")
+	}
+	for _, s := range ig.Spec.Taints {
+		t, err := util.ParseTaint(s)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing taint %q of InstanceGroup %q: %w", s, ig.Name, err)
+		}
+		nodeRegistration.Taints = append(nodeRegistration.Taints, taint{
+			Key:    t["key"],
+			Value:  t["value"],
+			Effect: t["effect"],
+		})
+	}
+
+	annotations := make(map[string]string)
+	if ig.Spec.MinSize != nil {
+		annotations[autoscalerMinSizeAnnotation] = fmt.Sprintf("%d", *ig.Spec.MinSize)
+	}
+	if ig.Spec.MaxSize != nil {
+		annotations[autoscalerMaxSizeAnnotation] = fmt.Sprintf("%d", *ig.Spec.MaxSize)
+	}
+
+	var machineVersion *string
+	if version != "" {
+		machineVersion = fi.PtrTo(version)
+	}
+
+	return []*manifest{
+		{
+			comments: unmappedComment("InstanceGroup "+ig.Name, unmapped),
+			object: &awsMachineTemplate{
+				APIVersion: capaAPIVersion,
+				Kind:       "AWSMachineTemplate",
+				Metadata:   objectMeta{Name: name},
+				Spec: awsMachineTemplateSpec{
+					Template: awsMachineTemplateResource{Spec: machine},
+				},
+			},
+		},
+		{
+			object: &kubeadmConfigTemplate{
+				APIVersion: capiBootstrapAPIVersion,
+				Kind:       "KubeadmConfigTemplate",
+				Metadata:   objectMeta{Name: name},
+				Spec: kubeadmConfigTemplateSpec{
+					Template: kubeadmConfigTemplateResource{
+						Spec: kubeadmConfigSpec{
+							JoinConfiguration: &kubeadmNodeConfiguration{NodeRegistration: nodeRegistration},
+						},
+					},
+				},
+			},
+		},
+		{
+			object: &machineDeployment{
+				APIVersion: capiAPIVersion,
+				Kind:       "MachineDeployment",
+				Metadata: objectMeta{
+					Name:        name,
+					Annotations: annotations,
+				},
+				Spec: machineDeploymentSpec{
+					ClusterName: cluster.Name,
+					Replicas:    ig.Spec.MinSize,
+					Template: machineTemplate{
+						Spec: machineSpec{
+							ClusterName:   cluster.Name,
+							Version:       machineVersion,
+							FailureDomain: failureDomain,
+							Bootstrap: bootstrap{
+								ConfigRef: &objectReference{
+									APIVersion: capiBootstrapAPIVersion,
+									Kind:       "KubeadmConfigTemplate",
+									Name:       name,
+								},
+							},
+							InfrastructureRef: objectReference{
+								APIVersion: capaAPIVersion,
+								Kind:       "AWSMachineTemplate",
+								Name:       name,
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// convertMachineSpec maps the machine related fields of an instance group.
+// It returns the fields that could not be mapped.
+func convertMachineSpec(cluster *kops.Cluster, ig *kops.InstanceGroup) (awsMachineSpec, []string, error) {
+	unmapped, err := unmappedFields(&ig.Spec, instanceGroupFieldMappings)
+	if err != nil {
+		return awsMachineSpec{}, nil, err
+	}
+
+	machine := awsMachineSpec{
+		InstanceType:   ig.Spec.MachineType,
+		SSHKeyName:     cluster.Spec.SSHKeyName,
+		PublicIP:       ig.Spec.AssociatePublicIP,
+		AdditionalTags: ig.Spec.CloudLabels,
+		Tenancy:        ig.Spec.Tenancy,
+	}
+
+	// Cluster API only references images by ID
+	if strings.HasPrefix(ig.Spec.Image, "ami-") {
+		machine.AMI = &amiReference{ID: ig.Spec.Image}
+	} else if ig.Spec.Image != "" {
+		unmapped = append(unmapped, "image")
+	}
+
+	if ig.Spec.IAM != nil && ig.Spec.IAM.Profile != nil {
+		profile := *ig.Spec.IAM.Profile
+		machine.IAMInstanceProfile = profile[strings.LastIndex(profile, "/")+1:]
+	}
+
+	for _, sg := range ig.Spec.AdditionalSecurityGroups {
+		machine.AdditionalSecurityGroups = append(machine.AdditionalSecurityGroups, awsResourceReference{ID: sg})
+	}
+
+	if rv := ig.Spec.RootVolume; rv != nil {
+		if rv.Size != nil {
+			machine.RootVolume = &volume{
+				Size:          int64(fi.ValueOf(rv.Size)),
+				Type:          fi.ValueOf(rv.Type),
+				IOPS:          int64(fi.ValueOf(rv.IOPS)),
+				Throughput:    int64(fi.ValueOf(rv.Throughput)),
+				Encrypted:     rv.Encryption,
+				EncryptionKey: fi.ValueOf(rv.EncryptionKey),
+			}
+		} else {
+			// Cluster API requires the size of a custom root volume
+			unmapped = append(unmapped, "rootVolume")
+		}
+	}
+
+	if ig.Spec.MaxPrice != nil {
+		machine.SpotMarketOptions = &spotMarketOptions{MaxPrice: ig.Spec.MaxPrice}
+	}
+
+	if md := ig.Spec.InstanceMetadata; md != nil {
+		machine.InstanceMetadataOptions = &instanceMetadataOptions{
+			HTTPTokens:              fi.ValueOf(md.HTTPTokens),
+			HTTPPutResponseHopLimit: fi.ValueOf(md.HTTPPutResponseHopLimit),
+		}
+	}
+
+	sort.Strings(unmapped)
+	return machine, unmapped, nil
+}
+
+func unmappedComment(source string, fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	lines := []string{fmt.Sprintf("# The following fields of %s could not be converted:", source)}
+	for _, field := range fields {
+		lines = append(lines, "#   spec."+field)
+	}
+	return lines
+}
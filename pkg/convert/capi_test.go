@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/upup/pkg/fi"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+func buildPrivateCluster() *kops.Cluster {
+	return &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "private.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider: kops.CloudProviderSpec{
+				AWS: &kops.AWSSpec{},
+			},
+			KubernetesVersion: "1.28.3",
+			SSHKeyName:        fi.PtrTo("admin"),
+			CloudLabels:       map[string]string{"team": "platform"},
+			API: kops.APISpec{
+				LoadBalancer: &kops.LoadBalancerAccessSpec{
+					Type:                   kops.LoadBalancerTypeInternal,
+					Class:                  kops.LoadBalancerClassNetwork,
+					CrossZoneLoadBalancing: fi.PtrTo(true),
+				},
+			},
+			Networking: kops.NetworkingSpec{
+				NetworkCIDR:           "172.20.0.0/16",
+				NonMasqueradeCIDR:     "100.64.0.0/10",
+				PodCIDR:               "100.96.0.0/11",
+				ServiceClusterIPRange: "100.64.0.0/13",
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "us-test-1a", Zone: "us-test-1a", CIDR: "172.20.32.0/19", Type: kops.SubnetTypePrivate},
+					{Name: "us-test-1b", Zone: "us-test-1b", CIDR: "172.20.64.0/19", Type: kops.SubnetTypePrivate},
+					{Name: "utility-us-test-1a", Zone: "us-test-1a", CIDR: "172.20.0.0/22", Type: kops.SubnetTypeUtility},
+				},
+				Topology: &kops.TopologySpec{
+					DNS:     kops.DNSTypeNone,
+					Bastion: &kops.BastionSpec{},
+				},
+				Calico: &kops.CalicoNetworkingSpec{},
+			},
+		},
+	}
+}
+
+func buildInstanceGroup(name string, role kops.InstanceGroupRole, subnets ...string) *kops.InstanceGroup {
+	return &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kops.InstanceGroupSpec{
+			Role:        role,
+			Image:       "ami-12345678",
+			MachineType: "m5.large",
+			MinSize:     fi.PtrTo(int32(1)),
+			MaxSize:     fi.PtrTo(int32(1)),
+			Subnets:     subnets,
+		},
+	}
+}
+
+func TestToClusterAPI(t *testing.T) {
+	grid := []struct {
+		name           string
+		cluster        *kops.Cluster
+		instanceGroups func() []*kops.InstanceGroup
+	}{
+		{
+			name:    "private",
+			cluster: buildPrivateCluster(),
+			instanceGroups: func() []*kops.InstanceGroup {
+				controlPlane := buildInstanceGroup("control-plane-us-test-1a", kops.InstanceGroupRoleControlPlane, "us-test-1a")
+				controlPlane.Spec.RootVolume = &kops.InstanceRootVolumeSpec{
+					Size:       fi.PtrTo(int32(64)),
+					Type:       fi.PtrTo("gp3"),
+					Encryption: fi.PtrTo(true),
+				}
+				controlPlane.Spec.InstanceMetadata = &kops.InstanceMetadataOptions{
+					HTTPTokens:              fi.PtrTo("required"),
+					HTTPPutResponseHopLimit: fi.PtrTo(int64(1)),
+				}
+				nodes := buildInstanceGroup("nodes-us-test-1a", kops.InstanceGroupRoleNode, "us-test-1a")
+				nodes.Spec.MinSize = fi.PtrTo(int32(2))
+				nodes.Spec.MaxSize = fi.PtrTo(int32(5))
+				nodes.Spec.NodeLabels = map[string]string{"kops.k8s.io/instancegroup": "nodes-us-test-1a", "example.com/tier": "web"}
+				nodes.Spec.Taints = []string{"dedicated=web:NoSchedule"}
+				nodes.Spec.IAM = &kops.IAMProfileSpec{Profile: fi.PtrTo("arn:aws:iam::123456789012:instance-profile/nodes")}
+				nodes.Spec.AdditionalUserData = []kops.UserData{{Name: "extra.sh", Type: "text/x-shellscript", Content: "#!/bin/sh"}}
+				bastion := buildInstanceGroup("bastions", kops.InstanceGroupRoleBastion, "utility-us-test-1a")
+				return []*kops.InstanceGroup{controlPlane, nodes, bastion}
+			},
+		},
+		{
+			name:    "mixed_instances",
+			cluster: buildPrivateCluster(),
+			instanceGroups: func() []*kops.InstanceGroup {
+				controlPlaneA := buildInstanceGroup("control-plane-us-test-1a", kops.InstanceGroupRoleControlPlane, "us-test-1a")
+				controlPlaneB := buildInstanceGroup("control-plane-us-test-1b", kops.InstanceGroupRoleControlPlane, "us-test-1b")
+				controlPlaneB.Spec.MachineType = "m5.xlarge"
+				nodes := buildInstanceGroup("nodes", kops.InstanceGroupRoleNode, "us-test-1a", "us-test-1b")
+				nodes.Spec.Image = "099720109477/ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-20231117"
+				nodes.Spec.MaxPrice = fi.PtrTo("0.1")
+				nodes.Spec.MixedInstancesPolicy = &kops.MixedInstancesPolicySpec{
+					Instances:              []string{"m5.large", "m5a.large"},
+					OnDemandAboveBase:      fi.PtrTo(int64(0)),
+					SpotAllocationStrategy: fi.PtrTo("capacity-optimized"),
+				}
+				return []*kops.InstanceGroup{controlPlaneA, controlPlaneB, nodes}
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual, err := ToClusterAPI(g.cluster, g.instanceGroups())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for i, document := range strings.Split(string(actual), "\n---\n") {
+				if err := roundTripClusterAPI([]byte(document)); err != nil {
+					t.Errorf("document %d: %v", i, err)
+				}
+			}
+
+			golden.AssertMatchesFile(t, string(actual), filepath.Join("tests", g.name+".yaml"))
+		})
+	}
+}
+
+// roundTripClusterAPI decodes a document into the Cluster API type of its kind, rejecting
+// unknown fields, and checks that encoding it again keeps every field of the document.
+// The AWS infrastructure kinds are only checked to be valid YAML, because the provider
+// types are not vendored.
+func roundTripClusterAPI(document []byte) error {
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(document, &obj); err != nil {
+		return fmt.Errorf("not valid YAML: %w", err)
+	}
+
+	var typed interface{}
+	switch obj["apiVersion"] {
+	case capaAPIVersion:
+		return nil
+	case clusterv1.GroupVersion.String():
+		switch obj["kind"] {
+		case "Cluster":
+			typed = &clusterv1.Cluster{}
+		case "MachineDeployment":
+			typed = &clusterv1.MachineDeployment{}
+		}
+	case bootstrapv1.GroupVersion.String():
+		if obj["kind"] == "KubeadmConfigTemplate" {
+			typed = &bootstrapv1.KubeadmConfigTemplate{}
+		}
+	case controlplanev1.GroupVersion.String():
+		if obj["kind"] == "KubeadmControlPlane" {
+			typed = &controlplanev1.KubeadmControlPlane{}
+		}
+	}
+	if typed == nil {
+		return fmt.Errorf("unexpected kind %v/%v", obj["apiVersion"], obj["kind"])
+	}
+
+	if err := yaml.UnmarshalStrict(document, typed); err != nil {
+		return fmt.Errorf("decoding %v: %w", obj["kind"], err)
+	}
+	encoded, err := yaml.Marshal(typed)
+	if err != nil {
+		return fmt.Errorf("encoding %v: %w", obj["kind"], err)
+	}
+	var roundTripped map[string]interface{}
+	if err := yaml.Unmarshal(encoded, &roundTripped); err != nil {
+		return fmt.Errorf("decoding encoded %v: %w", obj["kind"], err)
+	}
+	if path := missingField(obj, roundTripped, ""); path != "" {
+		return fmt.Errorf("%v field %s does not round trip", obj["kind"], path)
+	}
+	return nil
+}
+
+// missingField returns the path of the first field of expected that is missing or different in actual.
+func missingField(expected, actual interface{}, path string) string {
+	expectedMap, ok := expected.(map[string]interface{})
+	if !ok {
+		if !reflect.DeepEqual(expected, actual) {
+			return path
+		}
+		return ""
+	}
+	actualMap, ok := actual.(map[string]interface{})
+	if !ok {
+		return path
+	}
+	for key, value := range expectedMap {
+		if missing := missingField(value, actualMap[key], path+"."+key); missing != "" {
+			return missing
+		}
+	}
+	return ""
+}
+
+func TestToClusterAPIRejectsGossip(t *testing.T) {
+	cluster := buildPrivateCluster()
+	cluster.Name = "gossip.k8s.local"
+	cluster.Spec.Networking.Topology.DNS = kops.DNSTypePublic
+
+	_, err := ToClusterAPI(cluster, []*kops.InstanceGroup{
+		buildInstanceGroup("control-plane-us-test-1a", kops.InstanceGroupRoleControlPlane, "us-test-1a"),
+	})
+	if err == nil {
+		t.Fatalf("expected error converting gossip cluster")
+	}
+	if !strings.Contains(err.Error(), "gossip") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnmappedFields(t *testing.T) {
+	spec := &kops.InstanceGroupSpec{
+		MachineType: "m5.large",
+		RootVolume:  &kops.InstanceRootVolumeSpec{Size: fi.PtrTo(int32(20)), Optimization: fi.PtrTo(true)},
+		Manager:     kops.InstanceManagerKarpenter,
+	}
+
+	actual, err := unmappedFields(spec, instanceGroupFieldMappings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "manager,rootVolume.optimization"
+	if strings.Join(actual, ",") != expected {
+		t.Errorf("expected %q, got %q", expected, strings.Join(actual, ","))
+	}
+}
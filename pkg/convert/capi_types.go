@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+// The types below are the subset of the Cluster API (cluster.x-k8s.io/v1beta1) and
+// Cluster API Provider AWS (infrastructure.cluster.x-k8s.io/v1beta2) types that the converter emits.
+// They are kept deliberately small so that kOps does not depend on the Cluster API modules.
+
+const (
+	capiAPIVersion          = "cluster.x-k8s.io/v1beta1"
+	capiBootstrapAPIVersion = "bootstrap.cluster.x-k8s.io/v1beta1"
+	capiControlPlaneVersion = "controlplane.cluster.x-k8s.io/v1beta1"
+	capaAPIVersion          = "infrastructure.cluster.x-k8s.io/v1beta2"
+)
+
+type objectMeta struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type objectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+type capiCluster struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   objectMeta      `json:"metadata"`
+	Spec       capiClusterSpec `json:"spec"`
+}
+
+type capiClusterSpec struct {
+	ClusterNetwork    *clusterNetwork  `json:"clusterNetwork,omitempty"`
+	ControlPlaneRef   *objectReference `json:"controlPlaneRef,omitempty"`
+	InfrastructureRef *objectReference `json:"infrastructureRef,omitempty"`
+}
+
+type clusterNetwork struct {
+	Pods     *networkRanges `json:"pods,omitempty"`
+	Services *networkRanges `json:"services,omitempty"`
+}
+
+type networkRanges struct {
+	CIDRBlocks []string `json:"cidrBlocks"`
+}
+
+type awsCluster struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Metadata   objectMeta     `json:"metadata"`
+	Spec       awsClusterSpec `json:"spec"`
+}
+
+type awsClusterSpec struct {
+	Region                   string               `json:"region,omitempty"`
+	SSHKeyName               *string              `json:"sshKeyName,omitempty"`
+	Network                  networkSpec          `json:"network,omitempty"`
+	ControlPlaneLoadBalancer *awsLoadBalancerSpec `json:"controlPlaneLoadBalancer,omitempty"`
+	Bastion                  *bastion             `json:"bastion,omitempty"`
+	AdditionalTags           map[string]string    `json:"additionalTags,omitempty"`
+}
+
+type networkSpec struct {
+	VPC     *vpcSpec     `json:"vpc,omitempty"`
+	Subnets []subnetSpec `json:"subnets,omitempty"`
+}
+
+type vpcSpec struct {
+	ID        string `json:"id,omitempty"`
+	CidrBlock string `json:"cidrBlock,omitempty"`
+}
+
+type subnetSpec struct {
+	ID               string `json:"id,omitempty"`
+	CidrBlock        string `json:"cidrBlock,omitempty"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+	IsPublic         bool   `json:"isPublic"`
+}
+
+type awsLoadBalancerSpec struct {
+	Scheme           string `json:"scheme,omitempty"`
+	LoadBalancerType string `json:"loadBalancerType,omitempty"`
+	CrossZone        bool   `json:"crossZoneLoadBalancing,omitempty"`
+}
+
+type bastion struct {
+	Enabled bool `json:"enabled"`
+}
+
+type awsMachineTemplate struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Metadata   objectMeta             `json:"metadata"`
+	Spec       awsMachineTemplateSpec `json:"spec"`
+}
+
+type awsMachineTemplateSpec struct {
+	Template awsMachineTemplateResource `json:"template"`
+}
+
+type awsMachineTemplateResource struct {
+	Spec awsMachineSpec `json:"spec"`
+}
+
+type awsMachineSpec struct {
+	InstanceType             string                   `json:"instanceType"`
+	AMI                      *amiReference            `json:"ami,omitempty"`
+	IAMInstanceProfile       string                   `json:"iamInstanceProfile,omitempty"`
+	SSHKeyName               *string                  `json:"sshKeyName,omitempty"`
+	PublicIP                 *bool                    `json:"publicIP,omitempty"`
+	AdditionalSecurityGroups []awsResourceReference   `json:"additionalSecurityGroups,omitempty"`
+	AdditionalTags           map[string]string        `json:"additionalTags,omitempty"`
+	RootVolume               *volume                  `json:"rootVolume,omitempty"`
+	SpotMarketOptions        *spotMarketOptions       `json:"spotMarketOptions,omitempty"`
+	InstanceMetadataOptions  *instanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+	Tenancy                  string                   `json:"tenancy,omitempty"`
+}
+
+type amiReference struct {
+	ID string `json:"id"`
+}
+
+type awsResourceReference struct {
+	ID string `json:"id"`
+}
+
+type volume struct {
+	Size          int64  `json:"size"`
+	Type          string `json:"type,omitempty"`
+	IOPS          int64  `json:"iops,omitempty"`
+	Throughput    int64  `json:"throughput,omitempty"`
+	Encrypted     *bool  `json:"encrypted,omitempty"`
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+}
+
+type spotMarketOptions struct {
+	MaxPrice *string `json:"maxPrice,omitempty"`
+}
+
+type instanceMetadataOptions struct {
+	HTTPTokens              string `json:"httpTokens,omitempty"`
+	HTTPPutResponseHopLimit int64  `json:"httpPutResponseHopLimit,omitempty"`
+}
+
+type kubeadmControlPlane struct {
+	APIVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Metadata   objectMeta              `json:"metadata"`
+	Spec       kubeadmControlPlaneSpec `json:"spec"`
+}
+
+type kubeadmControlPlaneSpec struct {
+	Replicas          *int32                      `json:"replicas,omitempty"`
+	Version           string                      `json:"version"`
+	MachineTemplate   kubeadmControlPlaneTemplate `json:"machineTemplate"`
+	KubeadmConfigSpec kubeadmConfigSpec           `json:"kubeadmConfigSpec"`
+}
+
+type kubeadmControlPlaneTemplate struct {
+	InfrastructureRef objectReference `json:"infrastructureRef"`
+}
+
+type kubeadmConfigTemplate struct {
+	APIVersion string                    `json:"apiVersion"`
+	Kind       string                    `json:"kind"`
+	Metadata   objectMeta                `json:"metadata"`
+	Spec       kubeadmConfigTemplateSpec `json:"spec"`
+}
+
+type kubeadmConfigTemplateSpec struct {
+	Template kubeadmConfigTemplateResource `json:"template"`
+}
+
+type kubeadmConfigTemplateResource struct {
+	Spec kubeadmConfigSpec `json:"spec"`
+}
+
+type kubeadmConfigSpec struct {
+	InitConfiguration *kubeadmNodeConfiguration `json:"initConfiguration,omitempty"`
+	JoinConfiguration *kubeadmNodeConfiguration `json:"joinConfiguration,omitempty"`
+}
+
+type kubeadmNodeConfiguration struct {
+	NodeRegistration nodeRegistrationOptions `json:"nodeRegistration"`
+}
+
+type nodeRegistrationOptions struct {
+	Name             string            `json:"name,omitempty"`
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+	Taints           []taint           `json:"taints,omitempty"`
+}
+
+type taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+type machineDeployment struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Metadata   objectMeta            `json:"metadata"`
+	Spec       machineDeploymentSpec `json:"spec"`
+}
+
+type machineDeploymentSpec struct {
+	ClusterName string          `json:"clusterName"`
+	Replicas    *int32          `json:"replicas,omitempty"`
+	Selector    labelSelector   `json:"selector"`
+	Template    machineTemplate `json:"template"`
+}
+
+type labelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+type machineTemplate struct {
+	Spec machineSpec `json:"spec"`
+}
+
+type machineSpec struct {
+	ClusterName       string          `json:"clusterName"`
+	Version           *string         `json:"version,omitempty"`
+	FailureDomain     *string         `json:"failureDomain,omitempty"`
+	Bootstrap         bootstrap       `json:"bootstrap"`
+	InfrastructureRef objectReference `json:"infrastructureRef"`
+}
+
+type bootstrap struct {
+	ConfigRef *objectReference `json:"configRef,omitempty"`
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fieldMapping records how a kOps field is translated.
+// Path is the JSON path of the field below spec; elements of lists are denoted by "[]".
+// Target is the field it is mapped to, or empty if the field has no meaning outside of kOps.
+type fieldMapping struct {
+	Path   string
+	Target string
+}
+
+// clusterFieldMappings lists the Cluster fields that the Cluster API converter translates.
+var clusterFieldMappings = []fieldMapping{
+	{Path: "api.loadBalancer.type", Target: "AWSCluster spec.controlPlaneLoadBalancer.scheme"},
+	{Path: "api.loadBalancer.class", Target: "AWSCluster spec.controlPlaneLoadBalancer.loadBalancerType"},
+	{Path: "api.loadBalancer.crossZoneLoadBalancing", Target: "AWSCluster spec.controlPlaneLoadBalancer.crossZoneLoadBalancing"},
+	{Path: "cloudLabels", Target: "AWSCluster spec.additionalTags"},
+	{Path: "cloudProvider.aws", Target: "AWSCluster"},
+	{Path: "configStore", Target: ""},
+	{Path: "kubernetesVersion", Target: "KubeadmControlPlane spec.version, MachineDeployment spec.template.spec.version"},
+	{Path: "networking.networkCIDR", Target: "AWSCluster spec.network.vpc.cidrBlock"},
+	{Path: "networking.networkID", Target: "AWSCluster spec.network.vpc.id"},
+	{Path: "networking.nonMasqueradeCIDR", Target: ""},
+	{Path: "networking.podCIDR", Target: "Cluster spec.clusterNetwork.pods.cidrBlocks"},
+	{Path: "networking.serviceClusterIPRange", Target: "Cluster spec.clusterNetwork.services.cidrBlocks"},
+	{Path: "networking.subnets[].cidr", Target: "AWSCluster spec.network.subnets[].cidrBlock"},
+	{Path: "networking.subnets[].id", Target: "AWSCluster spec.network.subnets[].id"},
+	{Path: "networking.subnets[].name", Target: ""},
+	{Path: "networking.subnets[].type", Target: "AWSCluster spec.network.subnets[].isPublic"},
+	{Path: "networking.subnets[].zone", Target: "AWSCluster spec.network.subnets[].availabilityZone"},
+	{Path: "networking.topology.bastion", Target: "AWSCluster spec.bastion.enabled"},
+	{Path: "networking.topology.dns", Target: ""},
+	{Path: "sshKeyName", Target: "AWSCluster spec.sshKeyName"},
+}
+
+// instanceGroupFieldMappings lists the InstanceGroup fields that the Cluster API converter translates.
+var instanceGroupFieldMappings = []fieldMapping{
+	{Path: "additionalSecurityGroups", Target: "AWSMachineTemplate spec.template.spec.additionalSecurityGroups"},
+	{Path: "associatePublicIP", Target: "AWSMachineTemplate spec.template.spec.publicIP"},
+	{Path: "cloudLabels", Target: "AWSMachineTemplate spec.template.spec.additionalTags"},
+	{Path: "iam.profile", Target: "AWSMachineTemplate spec.template.spec.iamInstanceProfile"},
+	{Path: "image", Target: "AWSMachineTemplate spec.template.spec.ami.id"},
+	{Path: "instanceMetadata.httpPutResponseHopLimit", Target: "AWSMachineTemplate spec.template.spec.instanceMetadataOptions.httpPutResponseHopLimit"},
+	{Path: "instanceMetadata.httpTokens", Target: "AWSMachineTemplate spec.template.spec.instanceMetadataOptions.httpTokens"},
+	{Path: "machineType", Target: "AWSMachineTemplate spec.template.spec.instanceType"},
+	{Path: "maxPrice", Target: "AWSMachineTemplate spec.template.spec.spotMarketOptions.maxPrice"},
+	{Path: "maxSize", Target: "MachineDeployment cluster-autoscaler max size annotation"},
+	{Path: "minSize", Target: "MachineDeployment spec.replicas, KubeadmControlPlane spec.replicas"},
+	{Path: "nodeLabels", Target: "KubeadmConfigTemplate nodeRegistration.kubeletExtraArgs.node-labels"},
+	{Path: "role", Target: ""},
+	{Path: "rootVolume.encryption", Target: "AWSMachineTemplate spec.template.spec.rootVolume.encrypted"},
+	{Path: "rootVolume.encryptionKey", Target: "AWSMachineTemplate spec.template.spec.rootVolume.encryptionKey"},
+	{Path: "rootVolume.iops", Target: "AWSMachineTemplate spec.template.spec.rootVolume.iops"},
+	{Path: "rootVolume.size", Target: "AWSMachineTemplate spec.template.spec.rootVolume.size"},
+	{Path: "rootVolume.throughput", Target: "AWSMachineTemplate spec.template.spec.rootVolume.throughput"},
+	{Path: "rootVolume.type", Target: "AWSMachineTemplate spec.template.spec.rootVolume.type"},
+	{Path: "subnets", Target: "MachineDeployment spec.template.spec.failureDomain"},
+	{Path: "taints", Target: "KubeadmConfigTemplate nodeRegistration.taints"},
+	{Path: "tenancy", Target: "AWSMachineTemplate spec.template.spec.tenancy"},
+}
+
+// unmappedFields returns the paths of all fields set in spec that are not covered by the mappings.
+func unmappedFields(spec interface{}, mappings []fieldMapping) ([]string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing spec: %w", err)
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+
+	mapped := make(map[string]bool)
+	for _, mapping := range mappings {
+		mapped[mapping.Path] = true
+	}
+
+	found := make(map[string]bool)
+	collectUnmappedFields("", values, mapped, found)
+
+	var paths []string
+	for path := range found {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func collectUnmappedFields(path string, value interface{}, mapped map[string]bool, found map[string]bool) {
+	if mapped[path] {
+		return
+	}
+
+	hasMappedChildren := false
+	for p := range mapped {
+		if path == "" || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[].") {
+			hasMappedChildren = true
+			break
+		}
+	}
+	if !hasMappedChildren {
+		// An empty object still matters, for example "calico: {}" selects the networking plugin
+		if _, isObject := value.(map[string]interface{}); isObject || !isEmptyValue(value) {
+			found[path] = true
+		}
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			collectUnmappedFields(childPath, child, mapped, found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectUnmappedFields(path+"[]", child, mapped, found)
+		}
+	default:
+		if !isEmptyValue(value) {
+			found[path] = true
+		}
+	}
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -227,6 +227,19 @@ func TestBuildAPIServerFlags(t *testing.T) {
 			},
 			Expected: "--event-ttl=3h0m0s --secure-port=0",
 		},
+		{
+			Config: &kops.KubeAPIServerConfig{
+				ServiceAccountMaxTokenExpiration: &metav1.Duration{Duration: 48 * time.Hour},
+			},
+			Expected: "--secure-port=0 --service-account-max-token-expiration=48h0m0s",
+		},
+		{
+			Config: &kops.KubeAPIServerConfig{
+				ServiceAccountMaxTokenExpiration:      &metav1.Duration{Duration: 2 * time.Hour},
+				ServiceAccountExtendedTokenExpiration: fi.PtrTo(false),
+			},
+			Expected: "--secure-port=0 --service-account-extend-token-expiration=false --service-account-max-token-expiration=2h0m0s",
+		},
 	}
 
 	for _, test := range grid {
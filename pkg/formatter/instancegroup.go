@@ -30,7 +30,7 @@ type InstanceGroupRenderFunction func(ig *kops.InstanceGroup) string
 // RenderInstanceGroupSubnets renders the subnet names for an InstanceGroup
 func RenderInstanceGroupSubnets(cluster *kops.Cluster) InstanceGroupRenderFunction {
 	return func(ig *kops.InstanceGroup) string {
-		return strings.Join(ig.Spec.Subnets, ",")
+		return strings.Join(ig.ResolvedSubnets(cluster), ",")
 	}
 }
 
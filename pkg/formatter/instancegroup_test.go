@@ -63,6 +63,16 @@ func TestRenderInstanceGroupZones(t *testing.T) {
 			},
 			expected: "",
 		},
+		{
+			ig: &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					SubnetSelectors: []kops.SubnetSelectorSpec{
+						{Zones: []string{"subnet1zone", "subnet3zone"}},
+					},
+				},
+			},
+			expected: "subnet1zone,subnet3zone",
+		},
 	}
 	for _, g := range grid {
 		f := RenderInstanceGroupZones(cluster)
@@ -75,7 +85,18 @@ func TestRenderInstanceGroupZones(t *testing.T) {
 }
 
 func TestRenderInstanceGroupSubnets(t *testing.T) {
-	cluster := &kops.Cluster{}
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			Networking: kops.NetworkingSpec{
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "private1", Zone: "zone1", Type: kops.SubnetTypePrivate},
+					{Name: "utility1", Zone: "zone1", Type: kops.SubnetTypeUtility},
+					{Name: "private2", Zone: "zone2", Type: kops.SubnetTypePrivate},
+					{Name: "public2", Zone: "zone2", Type: kops.SubnetTypePublic},
+				},
+			},
+		},
+	}
 	grid := []struct {
 		ig       *kops.InstanceGroup
 		expected string
@@ -96,6 +117,27 @@ func TestRenderInstanceGroupSubnets(t *testing.T) {
 			},
 			expected: "subnet1,subnet2",
 		},
+		{
+			ig: &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					SubnetSelectors: []kops.SubnetSelectorSpec{
+						{Type: kops.SubnetTypePrivate},
+					},
+				},
+			},
+			expected: "private1,private2",
+		},
+		{
+			ig: &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					SubnetSelectors: []kops.SubnetSelectorSpec{
+						{Type: kops.SubnetTypePrivate, Zones: []string{"zone1"}},
+						{Type: kops.SubnetTypePublic},
+					},
+				},
+			},
+			expected: "private1,public2",
+		},
 	}
 	for _, g := range grid {
 		f := RenderInstanceGroupSubnets(cluster)
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/drain"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// runNodeDrain evicts or deletes the pods of a node. It is replaced in tests.
+var runNodeDrain = drain.RunNodeDrain
+
+// errNodeSkipped is returned when a node that did not drain within the drain timeout is skipped,
+// so that it is neither terminated nor rebooted.
+var errNodeSkipped = errors.New("node skipped after drain timeout")
+
+// drainSettings returns the drain timeout and drain timeout policy of the group of an instance,
+// with the overrides of the rolling update applied.
+func (c *RollingUpdateCluster) drainSettings(u *cloudinstances.CloudInstance) (time.Duration, api.RollingUpdateDrainTimeoutPolicy) {
+	group := &api.InstanceGroup{}
+	if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
+		group = u.CloudInstanceGroup.InstanceGroup
+	}
+	settings := resolveSettings(c.Cluster, group, 0)
+
+	timeout := settings.DrainTimeout.Duration
+	if c.DrainTimeout > 0 {
+		timeout = c.DrainTimeout
+	}
+	policy := settings.DrainTimeoutPolicy
+	if c.Options.DrainTimeoutPolicy != "" {
+		policy = c.Options.DrainTimeoutPolicy
+	}
+	return timeout, policy
+}
+
+// handleDrainTimeout applies the drain timeout policy to a node that did not drain within the timeout.
+// It returns nil if the node was drained by deleting its remaining pods, errNodeSkipped if the node is skipped,
+// or an error naming the PodDisruptionBudgets that blocked the drain.
+func (c *RollingUpdateCluster) handleDrainTimeout(helper *drain.Helper, nodeName string, timeout time.Duration, policy api.RollingUpdateDrainTimeoutPolicy, drainErr error) error {
+	reason := fmt.Sprintf("node %q did not drain within %s", nodeName, timeout)
+	if blocking := c.blockingPodDisruptionBudgets(helper, nodeName); len(blocking) > 0 {
+		reason += fmt.Sprintf(", blocked by PodDisruptionBudgets %s", strings.Join(blocking, ", "))
+	}
+
+	switch policy {
+	case api.RollingUpdateDrainTimeoutPolicySkip:
+		klog.Warningf("Skipping node %q, which is left cordoned until the next rolling update: %s", nodeName, reason)
+		return errNodeSkipped
+
+	case api.RollingUpdateDrainTimeoutPolicyForce:
+		klog.Warningf("Deleting the remaining pods of node %q without eviction: %s", nodeName, reason)
+		force := *helper
+		force.DisableEviction = true
+		if err := runNodeDrain(&force, nodeName); err != nil {
+			return fmt.Errorf("error deleting the remaining pods of node %q: %w", nodeName, err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%s: %w", reason, drainErr)
+	}
+}
+
+// blockingPodDisruptionBudgets returns the PodDisruptionBudgets that allow no disruptions
+// and select pods remaining on a node, as "namespace/name".
+func (c *RollingUpdateCluster) blockingPodDisruptionBudgets(helper *drain.Helper, nodeName string) []string {
+	list, errs := helper.GetPodsForDeletion(nodeName)
+	if list == nil {
+		klog.Warningf("failed to list the pods of node %q: %v", nodeName, errs)
+		return nil
+	}
+
+	budgets := make(map[string][]policyv1.PodDisruptionBudget)
+	blocking := sets.New[string]()
+	for _, pod := range list.Pods() {
+		namespaceBudgets, found := budgets[pod.Namespace]
+		if !found {
+			result, err := c.K8sClient.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(c.Ctx, metav1.ListOptions{})
+			if err != nil {
+				klog.Warningf("failed to list PodDisruptionBudgets in namespace %q: %v", pod.Namespace, err)
+			} else {
+				namespaceBudgets = result.Items
+			}
+			budgets[pod.Namespace] = namespaceBudgets
+		}
+
+		for _, pdb := range namespaceBudgets {
+			if pdb.Status.DisruptionsAllowed > 0 {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				continue
+			}
+			if selector.Matches(labels.Set(pod.Labels)) {
+				blocking.Insert(pdb.Namespace + "/" + pdb.Name)
+			}
+		}
+	}
+	return sets.List(blocking)
+}
@@ -19,6 +19,7 @@ package instancegroups
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -27,6 +28,9 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -98,7 +102,19 @@ func promptInteractive(upgradedHostID, upgradedHostName string) (stopPrompting b
 }
 
 // RollingUpdate performs a rolling update on a list of instances.
-func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.CloudInstanceGroup, sleepAfterTerminate time.Duration) (err error) {
+func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, group *cloudinstances.CloudInstanceGroup, sleepAfterTerminate time.Duration) (err error) {
+	_, span := tracer.Start(ctx, "RollingUpdateCluster::rollingUpdateInstanceGroup", trace.WithAttributes(
+		attribute.String("instancegroup", group.InstanceGroup.Name),
+		attribute.String("role", string(group.InstanceGroup.Spec.Role)),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	isBastion := group.InstanceGroup.IsBastion()
 	// Do not need a k8s client if you are doing cloudonly.
 	if c.K8sClient == nil && !c.CloudOnly {
@@ -107,15 +123,28 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 
 	noneReady := len(group.Ready) == 0
 	numInstances := len(group.Ready) + len(group.NeedUpdate)
-	update := group.NeedUpdate
-	if c.Force {
-		update = append(update, group.Ready...)
+	var update []*cloudinstances.CloudInstance
+	if c.Options.RebootOnly {
+		// Rebooted instances keep their configuration, so the group is as healthy as it was before
+		noneReady = false
+		update = InstancesNeedingReboot(group, c.Force)
+	} else {
+		update = group.NeedUpdate
+		if c.Force {
+			update = append(update, group.Ready...)
+		}
 	}
 
 	if len(update) == 0 {
 		return nil
 	}
 
+	if len(group.InstanceGroup.Spec.ScheduledScaling) > 0 && group.TargetSize == 0 {
+		// Replacing the instances would fight the scheduled action that scaled the group down
+		klog.Infof("Skipping instance group %q, which a scheduled action has scaled to zero; its instances are terminated by the autoscaling group", group.InstanceGroup.Name)
+		return nil
+	}
+
 	if isBastion {
 		klog.V(3).Info("Not validating the cluster as instance is a bastion.")
 	} else if err = c.maybeValidate("", 1, group); err != nil {
@@ -146,6 +175,11 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 
 	settings := resolveSettings(c.Cluster, group.InstanceGroup, numInstances)
 
+	joins, err := c.newJoinTracker(group, settings)
+	if err != nil {
+		return err
+	}
+
 	runningDrains := 0
 	maxSurge := settings.MaxSurge.IntValue()
 
@@ -160,6 +194,15 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 		maxSurge = 0
 	}
 
+	// Rebooting does not replace instances, so there is nothing to surge
+	if c.Options.RebootOnly && maxSurge != 0 {
+		maxSurge = 0
+		maxConcurrency = settings.MaxUnavailable.IntValue()
+		if maxConcurrency == 0 {
+			maxConcurrency = 1
+		}
+	}
+
 	if group.InstanceGroup.Spec.Role == api.InstanceGroupRoleControlPlane && maxSurge != 0 {
 		// Control plane nodes are incapable of surging because they rely on registering themselves through
 		// the local apiserver. That apiserver depends on the local etcd, which relies on being
@@ -180,7 +223,33 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 
 	update = prioritizeUpdate(update)
 
-	if maxSurge > 0 && !c.CloudOnly {
+	var surge *scaleOut
+	if maxSurge > 0 && !c.CloudOnly && settings.SurgeMode == api.RollingUpdateSurgeModeScaleOut {
+		// Scaling out is only undone by terminating instances
+		if *settings.DrainAndTerminate {
+			surge, err = c.scaleOutInstanceGroup(group, maxSurge, len(update))
+			if surge != nil {
+				defer func() {
+					if err != nil {
+						klog.Warningf("Group %q was left scaled out; its desired capacity and the scale-in protection of its new instances may need to be reset", group.HumanName)
+						return
+					}
+					err = surge.restore(c.Ctx)
+				}()
+			}
+			if err != nil {
+				return err
+			}
+
+			// Wait for the minimum interval
+			klog.Infof("waiting for %v after scaling out", sleepAfterTerminate)
+			time.Sleep(sleepAfterTerminate)
+
+			if err = c.waitForJoinAndValidate(" after scaling out", joins, group); err != nil {
+				return err
+			}
+		}
+	} else if maxSurge > 0 && !c.CloudOnly {
 		skippedNodes := 0
 		for numSurge := 1; numSurge <= maxSurge; numSurge++ {
 			u := update[len(update)-numSurge-skippedNodes]
@@ -203,7 +272,7 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 					klog.Infof("waiting for %v after detaching instance", sleepAfterTerminate)
 					time.Sleep(sleepAfterTerminate)
 
-					if err := c.maybeValidate(" after detaching instance", c.ValidateCount, group); err != nil {
+					if err := c.waitForJoinAndValidate(" after detaching instance", joins, group); err != nil {
 						return err
 					}
 					noneReady = false
@@ -221,7 +290,11 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 
 	for uIdx, u := range update {
 		go func(m *cloudinstances.CloudInstance) {
-			terminateChan <- c.drainTerminateAndWait(m, sleepAfterTerminate)
+			if c.Options.RebootOnly {
+				terminateChan <- c.drainRebootAndWait(m, sleepAfterTerminate)
+			} else {
+				terminateChan <- c.drainTerminateAndWait(m, sleepAfterTerminate, surge)
+			}
 		}(u)
 		runningDrains++
 
@@ -237,7 +310,7 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 			return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
 		}
 
-		err = c.maybeValidate(" after terminating instance", c.ValidateCount, group)
+		err = c.waitForJoinAndValidate(" after terminating instance", joins, group)
 		if err != nil {
 			return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
 		}
@@ -283,7 +356,7 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 			}
 		}
 
-		err = c.maybeValidate(" after terminating instance", c.ValidateCount, group)
+		err = c.waitForJoinAndValidate(" after terminating instance", joins, group)
 		if err != nil {
 			return err
 		}
@@ -412,7 +485,7 @@ func (c *RollingUpdateCluster) patchExcludeFromLB(node *corev1.Node) error {
 	return err
 }
 
-func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInstance, sleepAfterTerminate time.Duration) error {
+func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInstance, sleepAfterTerminate time.Duration, surge *scaleOut) error {
 	instanceID := u.ID
 
 	nodeName := ""
@@ -430,7 +503,9 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 		if u.Node != nil {
 			klog.Infof("Draining the node: %q.", nodeName)
 
-			if err := c.drainNode(u); err != nil {
+			if err := c.drainNode(u); errors.Is(err, errNodeSkipped) {
+				return nil
+			} else if err != nil {
 				if c.FailOnDrainError {
 					return fmt.Errorf("failed to drain node %q: %v", nodeName, err)
 				}
@@ -455,7 +530,7 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 		}
 	}
 
-	if err := c.deleteInstance(u); err != nil {
+	if err := c.deleteInstance(u, surge); err != nil {
 		klog.Errorf("error deleting instance %q, node %q: %v", instanceID, nodeName, err)
 		return err
 	}
@@ -618,7 +693,8 @@ func (c *RollingUpdateCluster) detachInstance(u *cloudinstances.CloudInstance) e
 }
 
 // deleteInstance deletes an Cloud Instance.
-func (c *RollingUpdateCluster) deleteInstance(u *cloudinstances.CloudInstance) error {
+// If the group was scaled out, the surge is given back as the last instances are deleted.
+func (c *RollingUpdateCluster) deleteInstance(u *cloudinstances.CloudInstance, surge *scaleOut) error {
 	id := u.ID
 	nodeName := ""
 	if u.Node != nil {
@@ -630,7 +706,13 @@ func (c *RollingUpdateCluster) deleteInstance(u *cloudinstances.CloudInstance) e
 		klog.Infof("Stopping instance %q, in group %q (this may take a while).", id, u.CloudInstanceGroup.HumanName)
 	}
 
-	if err := c.Cloud.DeleteInstance(u); err != nil {
+	var err error
+	if surge != nil {
+		err = surge.terminateInstance(c.Ctx, u)
+	} else {
+		err = c.Cloud.DeleteInstance(u)
+	}
+	if err != nil {
 		if nodeName != "" {
 			return fmt.Errorf("error deleting instance %q, node %q: %v", id, nodeName, err)
 		}
@@ -654,6 +736,8 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		return fmt.Errorf("node name not set")
 	}
 
+	timeout, policy := c.drainSettings(u)
+
 	helper := &drain.Helper{
 		Ctx:                 c.Ctx,
 		Client:              c.K8sClient,
@@ -662,7 +746,7 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		IgnoreAllDaemonSets: true,
 		Out:                 os.Stdout,
 		ErrOut:              os.Stderr,
-		Timeout:             c.DrainTimeout,
+		Timeout:             timeout,
 
 		// We want to proceed even when pods are using emptyDir volumes
 		DeleteEmptyDirData: true,
@@ -683,7 +767,10 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 	}
 
 	shouldDeregister := true
-	if !c.Options.DeregisterControlPlaneNodes {
+	if c.Options.RebootOnly {
+		// Rebooted instances would not be registered with the load balancers again
+		shouldDeregister = false
+	} else if !c.Options.DeregisterControlPlaneNodes {
 		if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
 			role := u.CloudInstanceGroup.InstanceGroup.Spec.Role
 			switch role {
@@ -700,11 +787,21 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		}
 	}
 
-	if err := drain.RunNodeDrain(helper, u.Node.Name); err != nil {
+	drainCtx, cancel := context.WithTimeout(c.Ctx, timeout)
+	defer cancel()
+	drainHelper := *helper
+	drainHelper.Ctx = drainCtx
+
+	if err := runNodeDrain(&drainHelper, u.Node.Name); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
-		return fmt.Errorf("error draining node: %v", err)
+		if !errors.Is(drainCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("error draining node: %v", err)
+		}
+		if err := c.handleDrainTimeout(helper, u.Node.Name, timeout, policy, err); err != nil {
+			return err
+		}
 	}
 
 	if c.PostDrainDelay > 0 {
@@ -746,5 +843,5 @@ func (c *RollingUpdateCluster) UpdateSingleInstance(cloudMember *cloudinstances.
 		}
 	}
 
-	return c.drainTerminateAndWait(cloudMember, 0)
+	return c.drainTerminateAndWait(cloudMember, 0, nil)
 }
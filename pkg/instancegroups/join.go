@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// consoleOutputLines is the number of lines at the end of the console output included in a JoinTimeoutError.
+const consoleOutputLines = 50
+
+// JoinTimeoutError represents an instance launched during the rolling update
+// that did not register as a node within the join timeout.
+type JoinTimeoutError struct {
+	InstanceID string
+	Group      string
+	Timeout    time.Duration
+	// ConsoleOutput is the end of the console output of the instance, if it could be retrieved.
+	ConsoleOutput string
+}
+
+func (e *JoinTimeoutError) Error() string {
+	msg := fmt.Sprintf("instance %q in group %q did not register as a node within %s and was terminated", e.InstanceID, e.Group, e.Timeout)
+	if e.ConsoleOutput == "" {
+		return msg + "; its console output is not available"
+	}
+	return msg + "; the end of its console output was:\n" + e.ConsoleOutput
+}
+
+// Is checks that a given error is a JoinTimeoutError.
+func (e *JoinTimeoutError) Is(err error) bool {
+	_, ok := err.(*JoinTimeoutError)
+	return ok
+}
+
+// joinTracker follows the instances an autoscaling group launches during a rolling update
+// until they register as nodes, and replaces the instances that never do.
+type joinTracker struct {
+	cloud     awsup.AWSCloud
+	groupName string
+	timeout   time.Duration
+	retry     bool
+
+	// known are the instances that existed before the rolling update, that joined or that were terminated.
+	known sets.Set[string]
+	// firstSeen is when each instance that has not joined yet was first seen in the group.
+	firstSeen map[string]time.Time
+	// retried is set once an instance that did not join has been replaced.
+	retried bool
+}
+
+// newJoinTracker returns a joinTracker for a group, or nil if instances joining is not checked for the group.
+func (c *RollingUpdateCluster) newJoinTracker(group *cloudinstances.CloudInstanceGroup, settings api.RollingUpdate) (*joinTracker, error) {
+	timeout := c.JoinTimeout
+	if timeout == 0 && settings.JoinTimeout != nil {
+		timeout = settings.JoinTimeout.Duration
+	}
+	if timeout == 0 || c.CloudOnly || c.Options.RebootOnly || c.K8sClient == nil {
+		return nil, nil
+	}
+	// Bastions never register as nodes and Karpenter replaces nodes on its own
+	if group.InstanceGroup.IsBastion() || group.InstanceGroup.Spec.Manager == api.InstanceManagerKarpenter {
+		return nil, nil
+	}
+	cloud, ok := c.Cloud.(awsup.AWSCloud)
+	if !ok {
+		klog.Warningf("Not checking that the new instances of group %q join the cluster, which is only supported on AWS", group.HumanName)
+		return nil, nil
+	}
+	if _, ok := group.Raw.(*autoscaling.Group); !ok {
+		return nil, nil
+	}
+
+	asg, err := describeAutoscalingGroup(c.Ctx, cloud, group.HumanName)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &joinTracker{
+		cloud:     cloud,
+		groupName: group.HumanName,
+		timeout:   timeout,
+		retry:     *settings.RetryJoin,
+		known:     sets.New[string](),
+		firstSeen: make(map[string]time.Time),
+	}
+	for _, instance := range asg.Instances {
+		t.known.Insert(aws.StringValue(instance.InstanceId))
+	}
+	return t, nil
+}
+
+// waitForJoinAndValidate waits for the new instances of a group to register as nodes, then validates the cluster.
+func (c *RollingUpdateCluster) waitForJoinAndValidate(operation string, joins *joinTracker, group *cloudinstances.CloudInstanceGroup) error {
+	if joins != nil {
+		if err := c.waitForJoin(joins); err != nil {
+			return err
+		}
+	}
+	return c.maybeValidate(operation, c.ValidateCount, group)
+}
+
+// waitForJoin waits until the group has launched its desired capacity and all its new instances are registered as nodes.
+// An instance that does not join within the timeout is terminated, so that the group launches a replacement.
+// Unless the tracker may retry once, a JoinTimeoutError is then returned.
+func (c *RollingUpdateCluster) waitForJoin(t *joinTracker) error {
+	start := time.Now()
+	for {
+		asg, err := describeAutoscalingGroup(c.Ctx, t.cloud, t.groupName)
+		if err != nil {
+			return err
+		}
+
+		nodes, err := c.K8sClient.CoreV1().Nodes().List(c.Ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing nodes: %w", err)
+		}
+		nodeMap := cloudinstances.GetNodeMap(nodes.Items, c.Cluster)
+
+		now := time.Now()
+		active := 0
+		pending := 0
+		for _, instance := range asg.Instances {
+			id := aws.StringValue(instance.InstanceId)
+			state := aws.StringValue(instance.LifecycleState)
+			if strings.HasPrefix(state, "Terminating") || strings.HasPrefix(state, "Detach") || strings.HasPrefix(state, "Standby") {
+				continue
+			}
+			active++
+			if t.known.Has(id) {
+				continue
+			}
+
+			if node := nodeMap[id]; node != nil {
+				klog.Infof("Instance %q joined the cluster as node %q.", id, node.Name)
+				t.known.Insert(id)
+				delete(t.firstSeen, id)
+				continue
+			}
+
+			firstSeen, found := t.firstSeen[id]
+			if !found {
+				klog.Infof("Waiting up to %s for instance %q to join the cluster.", t.timeout, id)
+				t.firstSeen[id] = now
+				firstSeen = now
+			}
+			if now.Sub(firstSeen) < t.timeout {
+				pending++
+				continue
+			}
+
+			if err := c.replaceUnjoinedInstance(t, id); err != nil {
+				return err
+			}
+			pending++
+		}
+
+		if pending == 0 {
+			if int64(active) >= aws.Int64Value(asg.DesiredCapacity) {
+				return nil
+			}
+			if now.Sub(start) >= t.timeout {
+				klog.Warningf("Group %q has %d of %d instances after %s; leaving it to cluster validation", t.groupName, active, aws.Int64Value(asg.DesiredCapacity), t.timeout)
+				return nil
+			}
+		}
+
+		time.Sleep(c.ValidateTickDuration)
+	}
+}
+
+// replaceUnjoinedInstance terminates an instance that did not join within the timeout, letting the group replace it.
+// It returns a JoinTimeoutError unless the tracker may still retry.
+func (c *RollingUpdateCluster) replaceUnjoinedInstance(t *joinTracker, id string) error {
+	consoleOutput := c.getConsoleOutput(t.cloud, id)
+
+	klog.Warningf("Instance %q in group %q did not join the cluster within %s, terminating it.", id, t.groupName, t.timeout)
+	if _, err := t.cloud.EC2().TerminateInstances(&ec2.TerminateInstancesInput{
+		InstanceIds: []*string{aws.String(id)},
+	}); err != nil && awsup.AWSErrorCode(err) != "InvalidInstanceID.NotFound" {
+		return fmt.Errorf("error terminating instance %q, which did not join the cluster: %w", id, err)
+	}
+	t.known.Insert(id)
+	delete(t.firstSeen, id)
+
+	if t.retry && !t.retried {
+		t.retried = true
+		klog.Warningf("Waiting for the replacement of instance %q to join the cluster; its console output was:\n%s", id, consoleOutput)
+		return nil
+	}
+
+	return &JoinTimeoutError{
+		InstanceID:    id,
+		Group:         t.groupName,
+		Timeout:       t.timeout,
+		ConsoleOutput: consoleOutput,
+	}
+}
+
+// getConsoleOutput returns the last lines of the console output of an instance, or "" if it is not available.
+func (c *RollingUpdateCluster) getConsoleOutput(cloud awsup.AWSCloud, id string) string {
+	response, err := cloud.EC2().GetConsoleOutputWithContext(c.Ctx, &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(id),
+	})
+	if err != nil {
+		klog.Warningf("error getting the console output of instance %q: %v", id, err)
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(response.Output))
+	if err != nil {
+		klog.Warningf("error decoding the console output of instance %q: %v", id, err)
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(string(decoded), "\n"), "\n")
+	if len(lines) > consoleOutputLines {
+		lines = lines[len(lines)-consoleOutputLines:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/drain"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// RebootRequiredAnnotation is the node annotation that marks a node as needing a reboot,
+// for example because a kernel update was installed.
+// Nodes with this annotation are rebooted by a reboot-only rolling update.
+// nodeup sets it on Debian and Ubuntu nodes when the OS requests a reboot.
+const RebootRequiredAnnotation = kops.AnnotationNameRebootRequired
+
+// InstancesNeedingReboot returns the instances of the group whose node is annotated as needing a reboot.
+// If force is set, all running instances are returned.
+func InstancesNeedingReboot(group *cloudinstances.CloudInstanceGroup, force bool) []*cloudinstances.CloudInstance {
+	var instances []*cloudinstances.CloudInstance
+	for _, list := range [][]*cloudinstances.CloudInstance{group.NeedUpdate, group.Ready} {
+		for _, instance := range list {
+			if instance.State == cloudinstances.WarmPool {
+				// Warm pool instances are stopped, they will boot with any installed updates
+				continue
+			}
+			if force {
+				instances = append(instances, instance)
+			} else if instance.Node != nil && instance.Node.Annotations[RebootRequiredAnnotation] != "" {
+				instances = append(instances, instance)
+			}
+		}
+	}
+	return instances
+}
+
+// drainRebootAndWait drains the node of an instance, reboots the instance, and waits for the node to rejoin the cluster.
+// It is the reboot-only counterpart of drainTerminateAndWait.
+func (c *RollingUpdateCluster) drainRebootAndWait(u *cloudinstances.CloudInstance, sleepAfterReboot time.Duration) error {
+	instanceID := u.ID
+
+	nodeName := ""
+	bootID := ""
+	wasExcludedFromLB := false
+	if u.Node != nil {
+		nodeName = u.Node.Name
+		bootID = u.Node.Status.NodeInfo.BootID
+		_, wasExcludedFromLB = u.Node.Labels[corev1.LabelNodeExcludeBalancers]
+	}
+
+	manageNode := !u.CloudInstanceGroup.InstanceGroup.IsBastion() && !c.CloudOnly && u.Node != nil
+
+	if manageNode {
+		klog.Infof("Draining the node: %q.", nodeName)
+
+		if err := c.drainNode(u); errors.Is(err, errNodeSkipped) {
+			return nil
+		} else if err != nil {
+			if c.FailOnDrainError {
+				return fmt.Errorf("failed to drain node %q: %v", nodeName, err)
+			}
+			klog.Infof("Ignoring error draining node %q: %v", nodeName, err)
+		}
+	} else if c.CloudOnly {
+		klog.Warning("Not draining cluster nodes as 'cloudonly' flag is set.")
+	}
+
+	if err := c.rebootInstance(u); err != nil {
+		klog.Errorf("error rebooting instance %q, node %q: %v", instanceID, nodeName, err)
+		return err
+	}
+
+	// Wait for the minimum interval
+	klog.Infof("waiting for %v after rebooting instance", sleepAfterReboot)
+	time.Sleep(sleepAfterReboot)
+
+	if !manageNode {
+		return nil
+	}
+
+	if err := c.waitForNodeReboot(nodeName, bootID); err != nil {
+		return err
+	}
+
+	klog.Infof("Uncordoning the node: %q.", nodeName)
+	if err := c.uncordonRebootedNode(nodeName, wasExcludedFromLB); err != nil {
+		return fmt.Errorf("failed to uncordon node %q: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// rebootInstance reboots a cloud instance, either through the EC2 API or from within the OS using SSM.
+func (c *RollingUpdateCluster) rebootInstance(u *cloudinstances.CloudInstance) error {
+	cloud, ok := c.Cloud.(awsup.AWSCloud)
+	if !ok {
+		return fmt.Errorf("rebooting instances is only supported on AWS")
+	}
+
+	id := u.ID
+	klog.Infof("Rebooting instance %q, in group %q.", id, u.CloudInstanceGroup.HumanName)
+
+	if c.Options.RebootViaSSM {
+		request := &ssm.SendCommandInput{
+			DocumentName: aws.String("AWS-RunShellScript"),
+			InstanceIds:  []*string{aws.String(id)},
+			Comment:      aws.String("kops rolling-update --reboot-only"),
+			Parameters: map[string][]*string{
+				"commands": {aws.String("systemctl reboot")},
+			},
+		}
+		if _, err := cloud.SSM().SendCommand(request); err != nil {
+			return fmt.Errorf("error rebooting instance %q through SSM: %w", id, err)
+		}
+		return nil
+	}
+
+	request := &ec2.RebootInstancesInput{
+		InstanceIds: []*string{aws.String(id)},
+	}
+	if _, err := cloud.EC2().RebootInstances(request); err != nil {
+		return fmt.Errorf("error rebooting instance %q: %w", id, err)
+	}
+	return nil
+}
+
+// waitForNodeReboot waits for the node to report a new boot ID and become ready again.
+func (c *RollingUpdateCluster) waitForNodeReboot(nodeName string, bootID string) error {
+	ctx, cancel := context.WithTimeout(c.Ctx, c.ValidationTimeout)
+	defer cancel()
+
+	if bootID == "" {
+		klog.Warningf("boot ID of node %q is not known, only waiting for the node to be ready", nodeName)
+	}
+
+	for {
+		node, err := c.K8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			klog.Infof("Unable to get node %q, will retry in %q: %v.", nodeName, c.ValidateTickDuration, err)
+		} else if bootID != "" && node.Status.NodeInfo.BootID == bootID {
+			klog.Infof("Node %q has not rebooted yet, will retry in %q.", nodeName, c.ValidateTickDuration)
+		} else if !isNodeReady(node) {
+			klog.Infof("Node %q is not ready, will retry in %q.", nodeName, c.ValidateTickDuration)
+		} else {
+			klog.Infof("Node %q rejoined the cluster.", nodeName)
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("node %q did not rejoin the cluster within %s", nodeName, c.ValidationTimeout)
+		}
+		time.Sleep(c.ValidateTickDuration)
+	}
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// uncordonRebootedNode makes a rebooted node schedulable again, and removes the markers added for the rolling update.
+func (c *RollingUpdateCluster) uncordonRebootedNode(nodeName string, keepExcludedFromLB bool) error {
+	node, err := c.K8sClient.CoreV1().Nodes().Get(c.Ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	helper := &drain.Helper{
+		Ctx:    c.Ctx,
+		Client: c.K8sClient,
+		Out:    os.Stdout,
+		ErrOut: os.Stderr,
+	}
+	if err := drain.RunCordonOrUncordon(helper, node, false); err != nil {
+		return fmt.Errorf("error uncordoning node: %w", err)
+	}
+
+	oldData, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	var taints []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		if taint.Key != rollingUpdateTaintKey {
+			taints = append(taints, taint)
+		}
+	}
+	node.Spec.Taints = taints
+	if !keepExcludedFromLB {
+		delete(node.Labels, corev1.LabelNodeExcludeBalancers)
+	}
+	delete(node.Annotations, RebootRequiredAnnotation)
+
+	newData, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	patchBytes, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, node)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.K8sClient.CoreV1().Nodes().Patch(c.Ctx, node.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -27,6 +27,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/kops/pkg/client/simple"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	api "k8s.io/kops/pkg/apis/kops"
@@ -81,8 +83,13 @@ type RollingUpdateCluster struct {
 	ValidateCount int
 
 	// DrainTimeout is the maximum amount of time to wait while draining a node.
+	// If set, it overrides the drainTimeout of the instance groups.
 	DrainTimeout time.Duration
 
+	// JoinTimeout is the maximum amount of time to wait for a new instance to register as a node.
+	// If set, it overrides the joinTimeout of the instance groups.
+	JoinTimeout time.Duration
+
 	// Options holds user-specified options
 	Options RollingUpdateOptions
 }
@@ -91,6 +98,16 @@ type RollingUpdateOptions struct {
 	// DeregisterControlPlaneNodes controls if we deregister control plane instances from load balacners etc before draining/terminating.
 	// When a cluster only has a single apiserver, we don't want to do this, as we can't drain after deregistering it.
 	DeregisterControlPlaneNodes bool
+
+	// RebootOnly reboots instances in place instead of replacing them.
+	// Only instances whose node has the RebootRequiredAnnotation are rebooted, unless Force is set.
+	RebootOnly bool
+	// RebootViaSSM reboots instances from within the OS using an SSM command, instead of through the EC2 API.
+	RebootViaSSM bool
+
+	// DrainTimeoutPolicy is what happens to a node that does not drain within the drain timeout.
+	// If set, it overrides the drainTimeoutPolicy of the instance groups.
+	DrainTimeoutPolicy api.RollingUpdateDrainTimeoutPolicy
 }
 
 func (o *RollingUpdateOptions) InitDefaults() {
@@ -107,6 +124,9 @@ func (*RollingUpdateCluster) AdjustNeedUpdate(groups map[string]*cloudinstances.
 
 // RollingUpdate performs a rolling update on a K8s Cluster.
 func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.CloudInstanceGroup, instanceGroups *api.InstanceGroupList) error {
+	ctx, span := tracer.Start(c.Ctx, "RollingUpdateCluster::RollingUpdate", trace.WithAttributes(attribute.String("cluster", c.ClusterName)))
+	defer span.End()
+
 	if len(groups) == 0 {
 		klog.Info("Cloud Instance Group length is zero. Not doing a rolling-update.")
 		return nil
@@ -147,7 +167,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 
 				defer wg.Done()
 
-				err := c.rollingUpdateInstanceGroup(bastionGroups[k], c.BastionInterval)
+				err := c.rollingUpdateInstanceGroup(ctx, bastionGroups[k], c.BastionInterval)
 
 				resultsMutex.Lock()
 				results[k] = err
@@ -172,7 +192,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		// and we don't want to roll all the control-plane nodes at the same time.  See issue #284
 
 		for _, k := range sortGroups(masterGroups) {
-			err := c.rollingUpdateInstanceGroup(masterGroups[k], c.MasterInterval)
+			err := c.rollingUpdateInstanceGroup(ctx, masterGroups[k], c.MasterInterval)
 			// Do not continue update if control-plane node(s) failed; cluster is potentially in an unhealthy state.
 			if err != nil {
 				return fmt.Errorf("control-plane node not healthy after update, stopping rolling-update: %q", err)
@@ -187,7 +207,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(apiServerGroups) {
-			err := c.rollingUpdateInstanceGroup(apiServerGroups[k], c.NodeInterval)
+			err := c.rollingUpdateInstanceGroup(ctx, apiServerGroups[k], c.NodeInterval)
 			results[k] = err
 			if err != nil {
 				klog.Errorf("failed to roll InstanceGroup %q: %v", k, err)
@@ -212,7 +232,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(nodeGroups) {
-			err := c.rollingUpdateInstanceGroup(nodeGroups[k], c.NodeInterval)
+			err := c.rollingUpdateInstanceGroup(ctx, nodeGroups[k], c.NodeInterval)
 			results[k] = err
 			if err != nil {
 				klog.Errorf("failed to roll InstanceGroup %q: %v", k, err)
@@ -249,7 +269,8 @@ func sortGroups(groupMap map[string]*cloudinstances.CloudInstanceGroup) []string
 //
 // For example, if a cluster is unable to be validated by the deadline, then it
 // is unlikely that it will validate on the next instance roll, so an early exit as a
-// warning to the user is more appropriate.
+// warning to the user is more appropriate. Likewise, an instance that never joins the
+// cluster is likely to be followed by others failing the same way.
 func isExitableError(err error) bool {
-	return stderrors.Is(err, &ValidationTimeoutError{})
+	return stderrors.Is(err, &ValidationTimeoutError{}) || stderrors.Is(err, &JoinTimeoutError{})
 }
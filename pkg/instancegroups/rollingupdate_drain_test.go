@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubectl/pkg/drain"
+
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const stuckNodeName = "node-1a.local"
+
+// drainTest fakes draining nodes, where the pods of the stuck node cannot be evicted.
+type drainTest struct {
+	mutex   sync.Mutex
+	drained []string
+	forced  []string
+}
+
+func (d *drainTest) runNodeDrain(helper *drain.Helper, nodeName string) error {
+	if nodeName == stuckNodeName && !helper.DisableEviction {
+		<-helper.Ctx.Done()
+		return fmt.Errorf("error when evicting pods/%q -n %q: global timeout reached: %v", "web", "default", helper.Timeout)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if helper.DisableEviction {
+		d.forced = append(d.forced, nodeName)
+		return nil
+	}
+	d.drained = append(d.drained, nodeName)
+	return nil
+}
+
+func getDrainTestSetup(t *testing.T, policy kopsapi.RollingUpdateDrainTimeoutPolicy) (*RollingUpdateCluster, *awsup.MockAWSCloud, map[string]*cloudinstances.CloudInstanceGroup, *drainTest) {
+	c, cloud := getTestSetup()
+	c.FailOnDrainError = true
+	c.DrainTimeout = 10 * time.Millisecond
+	c.Options.DrainTimeoutPolicy = policy
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+
+	fakeClient := c.K8sClient.(*fake.Clientset)
+	_ = fakeClient.Tracker().Add(&v1.Pod{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web"},
+		},
+		Spec: v1.PodSpec{
+			NodeName: stuckNodeName,
+		},
+	})
+	_ = fakeClient.Tracker().Add(&policyv1.PodDisruptionBudget{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &v1meta.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: 0,
+		},
+	})
+	_ = fakeClient.Tracker().Add(&policyv1.PodDisruptionBudget{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      "other",
+			Namespace: "default",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &v1meta.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+		},
+	})
+
+	d := &drainTest{}
+	runNodeDrain = d.runNodeDrain
+	t.Cleanup(func() {
+		runNodeDrain = drain.RunNodeDrain
+	})
+
+	return c, cloud, groups, d
+}
+
+func TestRollingUpdateDrainTimeoutFail(t *testing.T) {
+	c, cloud, groups, d := getDrainTestSetup(t, kopsapi.RollingUpdateDrainTimeoutPolicyFail)
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	if assert.Error(t, err, "rolling update") {
+		assert.Contains(t, err.Error(), "blocked by PodDisruptionBudgets default/web:")
+		assert.NotContains(t, err.Error(), "default/other")
+	}
+
+	assert.Empty(t, d.forced, "forced drains")
+	assertGroupInstanceCount(t, cloud, "node-1", 3-len(d.drained))
+}
+
+func TestRollingUpdateDrainTimeoutSkip(t *testing.T) {
+	c, cloud, groups, d := getDrainTestSetup(t, kopsapi.RollingUpdateDrainTimeoutPolicySkip)
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Len(t, d.drained, 2, "drained nodes")
+	assert.Empty(t, d.forced, "forced drains")
+	assertGroupInstanceCount(t, cloud, "node-1", 1)
+	asgGroups, _ := cloud.Autoscaling().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String("node-1")},
+	})
+	for _, group := range asgGroups.AutoScalingGroups {
+		for _, instance := range group.Instances {
+			assert.Equal(t, "node-1a", aws.StringValue(instance.InstanceId), "remaining instance")
+		}
+	}
+}
+
+func TestRollingUpdateDrainTimeoutForce(t *testing.T) {
+	c, cloud, groups, d := getDrainTestSetup(t, kopsapi.RollingUpdateDrainTimeoutPolicyForce)
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Len(t, d.drained, 2, "drained nodes")
+	assert.Equal(t, []string{stuckNodeName}, d.forced, "forced drains")
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+}
+
+func TestRollingUpdateDrainTimeoutFromInstanceGroup(t *testing.T) {
+	c, cloud, groups, d := getDrainTestSetup(t, "")
+	c.DrainTimeout = 0
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		DrainTimeout:       &v1meta.Duration{Duration: 10 * time.Millisecond},
+		DrainTimeoutPolicy: kopsapi.RollingUpdateDrainTimeoutPolicyForce,
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Equal(t, []string{stuckNodeName}, d.forced, "forced drains")
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+}
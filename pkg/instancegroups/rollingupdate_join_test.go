@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// joinTestEC2 replaces terminated instances like an autoscaling group would.
+// The replacements register as nodes only if join returns true for them.
+type joinTestEC2 struct {
+	ec2iface.EC2API
+	cloud     awsup.AWSCloud
+	k8sClient *fake.Clientset
+	join      func(replacement int) bool
+
+	mutex        sync.Mutex
+	terminated   []string
+	replacements []string
+}
+
+func (e *joinTestEC2) TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	groups, err := e.cloud.Autoscaling().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{})
+	if err != nil {
+		return nil, err
+	}
+	groupNames := make(map[string]string)
+	for _, group := range groups.AutoScalingGroups {
+		for _, instance := range group.Instances {
+			groupNames[aws.StringValue(instance.InstanceId)] = aws.StringValue(group.AutoScalingGroupName)
+		}
+	}
+
+	output, err := e.EC2API.TerminateInstances(input)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range input.InstanceIds {
+		e.terminated = append(e.terminated, *id)
+		groupName, found := groupNames[*id]
+		if !found {
+			continue
+		}
+
+		replacement := fmt.Sprintf("%s-replacement-%d", groupName, len(e.replacements))
+		if e.join(len(e.replacements)) {
+			_ = e.k8sClient.Tracker().Add(&v1.Node{
+				ObjectMeta: v1meta.ObjectMeta{Name: replacement + ".local"},
+				Spec:       v1.NodeSpec{ProviderID: "aws:///us-east-1a/" + replacement},
+			})
+		}
+		e.replacements = append(e.replacements, replacement)
+		if _, err := e.cloud.Autoscaling().AttachInstances(&autoscaling.AttachInstancesInput{
+			AutoScalingGroupName: aws.String(groupName),
+			InstanceIds:          []*string{aws.String(replacement)},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+func (e *joinTestEC2) GetConsoleOutputWithContext(ctx context.Context, input *ec2.GetConsoleOutputInput, options ...request.Option) (*ec2.GetConsoleOutputOutput, error) {
+	output := fmt.Sprintf("booting %s\ncontainerd: invalid configuration\n", *input.InstanceId)
+	return &ec2.GetConsoleOutputOutput{
+		InstanceId: input.InstanceId,
+		Output:     aws.String(base64.StdEncoding.EncodeToString([]byte(output))),
+	}, nil
+}
+
+func getJoinTestSetup(retry bool, join func(replacement int) bool) (*RollingUpdateCluster, *joinTestEC2, map[string]*cloudinstances.CloudInstanceGroup) {
+	c, cloud := getTestSetup()
+	c.JoinTimeout = 10 * time.Millisecond
+
+	mock := &joinTestEC2{
+		EC2API:    cloud.MockEC2,
+		cloud:     cloud,
+		k8sClient: c.K8sClient.(*fake.Clientset),
+		join:      join,
+	}
+	cloud.MockEC2 = mock
+
+	zero := intstr.FromInt(0)
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxSurge:  &zero,
+		RetryJoin: fi.PtrTo(retry),
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+	return c, mock, groups
+}
+
+func TestRollingUpdateJoin(t *testing.T) {
+	c, mock, groups := getJoinTestSetup(false, func(int) bool { return true })
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Equal(t, []string{"node-1a", "node-1b", "node-1c"}, mock.terminated, "terminated instances")
+	assertGroupInstanceCount(t, c.Cloud.(awsup.AWSCloud), "node-1", 3)
+}
+
+func TestRollingUpdateNeverJoins(t *testing.T) {
+	c, mock, groups := getJoinTestSetup(false, func(int) bool { return false })
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.Error(t, err, "rolling update")
+
+	var joinErr *JoinTimeoutError
+	if assert.True(t, errors.As(err, &joinErr), "JoinTimeoutError") {
+		assert.Equal(t, "node-1-replacement-0", joinErr.InstanceID, "instance ID")
+		assert.Equal(t, "node-1", joinErr.Group, "group")
+		assert.Equal(t, "booting node-1-replacement-0\ncontainerd: invalid configuration", joinErr.ConsoleOutput, "console output")
+	}
+	assert.True(t, isExitableError(err), "exitable error")
+
+	// The rolling update stops after the first replacement, which is terminated
+	assert.Equal(t, []string{"node-1a", "node-1-replacement-0"}, mock.terminated, "terminated instances")
+}
+
+func TestRollingUpdateNeverJoinsRetry(t *testing.T) {
+	c, mock, groups := getJoinTestSetup(true, func(replacement int) bool { return replacement != 0 })
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Equal(t, []string{"node-1a", "node-1-replacement-0", "node-1b", "node-1c"}, mock.terminated, "terminated instances")
+	assertGroupInstanceCount(t, c.Cloud.(awsup.AWSCloud), "node-1", 3)
+}
+
+func TestRollingUpdateNeverJoinsAfterRetry(t *testing.T) {
+	c, mock, groups := getJoinTestSetup(true, func(int) bool { return false })
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+
+	var joinErr *JoinTimeoutError
+	if assert.True(t, errors.As(err, &joinErr), "JoinTimeoutError") {
+		assert.Equal(t, "node-1-replacement-1", joinErr.InstanceID, "instance ID")
+	}
+	assert.Equal(t, []string{"node-1a", "node-1-replacement-0", "node-1-replacement-1"}, mock.terminated, "terminated instances")
+}
+
+func TestRollingUpdateJoinTimeoutFromSpec(t *testing.T) {
+	c, mock, groups := getJoinTestSetup(false, func(int) bool { return false })
+	c.JoinTimeout = 0
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		JoinTimeout: &v1meta.Duration{Duration: 10 * time.Millisecond},
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.True(t, errors.Is(err, &JoinTimeoutError{}), "JoinTimeoutError")
+	assert.Len(t, mock.terminated, 2, "terminated instances")
+}
+
+func TestRollingUpdateJoinTimeoutDisabled(t *testing.T) {
+	c, mock, groups := getJoinTestSetup(false, func(int) bool { return false })
+	c.JoinTimeout = 0
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+	assert.Len(t, mock.terminated, 3, "terminated instances")
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// rebootTest simulates instances that rejoin the cluster with a new boot ID after being rebooted.
+type rebootTest struct {
+	ec2iface.EC2API
+	t         *testing.T
+	k8sClient *fake.Clientset
+	// stuck makes rebooted nodes keep their boot ID, as if the reboot never happened
+	stuck bool
+
+	mutex    sync.Mutex
+	rebooted []string
+}
+
+func (m *rebootTest) RebootInstances(input *ec2.RebootInstancesInput) (*ec2.RebootInstancesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, id := range input.InstanceIds {
+		m.rebooted = append(m.rebooted, aws.StringValue(id))
+		if m.stuck {
+			continue
+		}
+
+		node, err := m.k8sClient.CoreV1().Nodes().Get(context.TODO(), aws.StringValue(id)+".local", v1meta.GetOptions{})
+		if err != nil {
+			// Bastions have no node
+			continue
+		}
+		node.Status.NodeInfo.BootID = "after-reboot"
+		if err := m.k8sClient.Tracker().Update(v1.SchemeGroupVersion.WithResource("nodes"), node, ""); err != nil {
+			m.t.Errorf("error updating node %q: %v", node.Name, err)
+		}
+	}
+
+	return &ec2.RebootInstancesOutput{}, nil
+}
+
+func (m *rebootTest) TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+	m.t.Errorf("unexpected termination of instances %v", aws.StringValueSlice(input.InstanceIds))
+	return &ec2.TerminateInstancesOutput{}, nil
+}
+
+func getRebootTestSetup(t *testing.T) (*RollingUpdateCluster, *awsup.MockAWSCloud, *rebootTest) {
+	c, cloud := getTestSetup()
+	c.Options.RebootOnly = true
+	c.ValidationTimeout = time.Second
+
+	rebootTest := &rebootTest{
+		EC2API:    cloud.MockEC2,
+		t:         t,
+		k8sClient: c.K8sClient.(*fake.Clientset),
+	}
+	cloud.MockEC2 = rebootTest
+
+	return c, cloud, rebootTest
+}
+
+// prepareNodesForReboot sets the status kubelet would report, and marks the first needReboot nodes of the group as needing a reboot.
+func prepareNodesForReboot(t *testing.T, c *RollingUpdateCluster, group *cloudinstances.CloudInstanceGroup, needReboot int) {
+	k8sClient := c.K8sClient.(*fake.Clientset)
+	for i, instance := range group.Ready {
+		if instance.Node == nil {
+			continue
+		}
+		node := instance.Node
+		node.Status.NodeInfo.BootID = "before-reboot"
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+		if i < needReboot {
+			node.Annotations = map[string]string{RebootRequiredAnnotation: "true"}
+		}
+		if err := k8sClient.Tracker().Update(v1.SchemeGroupVersion.WithResource("nodes"), node, ""); err != nil {
+			t.Fatalf("error updating node %q: %v", node.Name, err)
+		}
+	}
+}
+
+func assertNodeRestored(t *testing.T, c *RollingUpdateCluster, nodeName string) {
+	node, err := c.K8sClient.CoreV1().Nodes().Get(context.TODO(), nodeName, v1meta.GetOptions{})
+	if !assert.NoError(t, err, "getting node") {
+		return
+	}
+	assert.False(t, node.Spec.Unschedulable, "%s unschedulable", nodeName)
+	assert.Empty(t, node.Spec.Taints, "%s taints", nodeName)
+	assert.NotContains(t, node.Labels, v1.LabelNodeExcludeBalancers, "%s labels", nodeName)
+	assert.NotContains(t, node.Annotations, RebootRequiredAnnotation, "%s annotations", nodeName)
+}
+
+func TestRollingUpdateRebootOnly(t *testing.T) {
+	c, cloud, rebootTest := getRebootTestSetup(t)
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 0)
+	prepareNodesForReboot(t, c, groups["node-1"], 2)
+
+	assert.Len(t, InstancesNeedingReboot(groups["node-1"], false), 2, "instances needing reboot")
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Equal(t, []string{"node-1a", "node-1b"}, rebootTest.rebooted, "rebooted instances")
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+	assertNodeRestored(t, c, "node-1a.local")
+	assertNodeRestored(t, c, "node-1b.local")
+}
+
+func TestRollingUpdateRebootOnlyNothingToReboot(t *testing.T) {
+	c, cloud, rebootTest := getRebootTestSetup(t)
+	c.ClusterValidator = &assertNotCalledClusterValidator{T: t}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	// Instances that need replacing are not rebooted
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Empty(t, rebootTest.rebooted, "rebooted instances")
+}
+
+func TestRollingUpdateRebootOnlyForce(t *testing.T) {
+	c, cloud, rebootTest := getRebootTestSetup(t)
+	c.Force = true
+
+	groups := getGroups(c.K8sClient, cloud)
+	for _, group := range groups {
+		prepareNodesForReboot(t, c, group, 0)
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Len(t, rebootTest.rebooted, 9, "rebooted instances")
+	// Bastions first, then the control plane, then nodes
+	assert.Equal(t, "bastion-1a", rebootTest.rebooted[0])
+	assert.ElementsMatch(t, []string{"master-1a", "master-1b"}, rebootTest.rebooted[1:3])
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+	assertGroupInstanceCount(t, cloud, "node-2", 3)
+	assertGroupInstanceCount(t, cloud, "master-1", 2)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 1)
+	assertNodeRestored(t, c, "master-1a.local")
+	assertNodeRestored(t, c, "node-2c.local")
+}
+
+func TestRollingUpdateRebootOnlyNodeDoesNotRejoin(t *testing.T) {
+	c, cloud, rebootTest := getRebootTestSetup(t)
+	c.ValidationTimeout = 10 * time.Millisecond
+	rebootTest.stuck = true
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 0)
+	prepareNodesForReboot(t, c, groups["node-1"], 3)
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.Error(t, err, "rolling update")
+
+	assert.Equal(t, []string{"node-1a"}, rebootTest.rebooted, "rebooted instances")
+}
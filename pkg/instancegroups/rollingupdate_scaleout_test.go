@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// scaleOutTestAutoscaling launches instances when the desired capacity of a group is raised,
+// and checks that the surge happens before any instance is terminated.
+type scaleOutTestAutoscaling struct {
+	autoscalingiface.AutoScalingAPI
+	t *testing.T
+
+	mutex       sync.Mutex
+	launched    []string
+	protected   map[string]bool
+	decrements  int
+	detached    int
+	maxSizeSeen int64
+}
+
+func (m *scaleOutTestAutoscaling) UpdateAutoScalingGroupWithContext(ctx context.Context, input *autoscaling.UpdateAutoScalingGroupInput, options ...request.Option) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	output, err := m.AutoScalingAPI.UpdateAutoScalingGroupWithContext(ctx, input, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MaxSize != nil && *input.MaxSize > m.maxSizeSeen {
+		m.maxSizeSeen = *input.MaxSize
+	}
+
+	if input.DesiredCapacity == nil {
+		return output, nil
+	}
+
+	groups, err := m.AutoScalingAPI.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{input.AutoScalingGroupName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	group := groups.AutoScalingGroups[0]
+	assert.LessOrEqual(m.t, *input.DesiredCapacity, *group.MaxSize, "desired capacity within the maximum size")
+
+	var instanceIDs []*string
+	for i := int64(len(group.Instances)); i < *input.DesiredCapacity; i++ {
+		id := fmt.Sprintf("%s-surge-%d", *input.AutoScalingGroupName, len(m.launched))
+		m.launched = append(m.launched, id)
+		instanceIDs = append(instanceIDs, aws.String(id))
+	}
+	if _, err := m.AutoScalingAPI.AttachInstances(&autoscaling.AttachInstancesInput{
+		AutoScalingGroupName: input.AutoScalingGroupName,
+		InstanceIds:          instanceIDs,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Attached instances have no lifecycle state in the mock
+	groups, _ = m.AutoScalingAPI.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{input.AutoScalingGroupName},
+	})
+	for _, instance := range groups.AutoScalingGroups[0].Instances {
+		instance.LifecycleState = aws.String(autoscaling.LifecycleStateInService)
+	}
+
+	return output, nil
+}
+
+func (m *scaleOutTestAutoscaling) SetInstanceProtectionWithContext(ctx context.Context, input *autoscaling.SetInstanceProtectionInput, options ...request.Option) (*autoscaling.SetInstanceProtectionOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, id := range input.InstanceIds {
+		m.protected[*id] = *input.ProtectedFromScaleIn
+	}
+	return m.AutoScalingAPI.SetInstanceProtectionWithContext(ctx, input, options...)
+}
+
+func (m *scaleOutTestAutoscaling) TerminateInstanceInAutoScalingGroupWithContext(ctx context.Context, input *autoscaling.TerminateInstanceInAutoScalingGroupInput, options ...request.Option) (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	assert.NotEmpty(m.t, m.launched, "scaled out before terminating")
+	for _, id := range m.launched {
+		assert.True(m.t, m.protected[id], "instance %s protected before terminating", id)
+	}
+	assert.False(m.t, m.protected[*input.InstanceId], "terminated instance %s is not a surge instance", *input.InstanceId)
+	if aws.BoolValue(input.ShouldDecrementDesiredCapacity) {
+		m.decrements++
+	}
+	return m.AutoScalingAPI.TerminateInstanceInAutoScalingGroupWithContext(ctx, input, options...)
+}
+
+func (m *scaleOutTestAutoscaling) DetachInstancesWithContext(ctx context.Context, input *autoscaling.DetachInstancesInput, options ...request.Option) (*autoscaling.DetachInstancesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.detached += len(input.InstanceIds)
+	return &autoscaling.DetachInstancesOutput{}, nil
+}
+
+func getScaleOutTestSetup(t *testing.T, maxSurge intstr.IntOrString) (*RollingUpdateCluster, *awsup.MockAWSCloud, *scaleOutTestAutoscaling) {
+	c, cloud := getTestSetup()
+
+	mock := &scaleOutTestAutoscaling{
+		AutoScalingAPI: cloud.MockAutoscaling,
+		t:              t,
+		protected:      make(map[string]bool),
+	}
+	cloud.MockAutoscaling = mock
+
+	c.Cluster.Spec.RollingUpdate = &kops.RollingUpdate{
+		MaxSurge:  &maxSurge,
+		SurgeMode: kops.RollingUpdateSurgeModeScaleOut,
+	}
+
+	return c, cloud, mock
+}
+
+func assertScaledIn(t *testing.T, cloud awsup.AWSCloud, groupName string, desiredCapacity int64, maxSize int64) {
+	groups, err := cloud.Autoscaling().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(groupName)},
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, groups.AutoScalingGroups, 1) {
+		return
+	}
+	group := groups.AutoScalingGroups[0]
+	assert.Equal(t, desiredCapacity, aws.Int64Value(group.DesiredCapacity), "desired capacity")
+	assert.Equal(t, maxSize, aws.Int64Value(group.MaxSize), "maximum size")
+	for _, instance := range group.Instances {
+		assert.False(t, aws.BoolValue(instance.ProtectedFromScaleIn), "instance %s protected from scale-in", aws.StringValue(instance.InstanceId))
+	}
+}
+
+func TestRollingUpdateScaleOut(t *testing.T) {
+	c, cloud, mock := getScaleOutTestSetup(t, intstr.FromInt(2))
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kops.InstanceGroupRoleNode, 3, 3)
+
+	err := c.RollingUpdate(groups, &kops.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Len(t, mock.launched, 2, "instances launched by scaling out")
+	assert.Equal(t, 2, mock.decrements, "terminations decrementing the desired capacity")
+	assert.Equal(t, 0, mock.detached, "instances detached")
+	assert.Equal(t, int64(0), mock.maxSizeSeen, "maximum size raised")
+
+	// The mock does not replace the instance terminated without decrementing
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+	assertScaledIn(t, cloud, "node-1", 3, 5)
+}
+
+func TestRollingUpdateScaleOutPercentage(t *testing.T) {
+	c, cloud, mock := getScaleOutTestSetup(t, intstr.FromString("50%"))
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kops.InstanceGroupRoleNode, 4, 4)
+
+	err := c.RollingUpdate(groups, &kops.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Len(t, mock.launched, 2, "instances launched by scaling out")
+	assert.Equal(t, 2, mock.decrements, "terminations decrementing the desired capacity")
+
+	assertScaledIn(t, cloud, "node-1", 4, 5)
+}
+
+func TestRollingUpdateScaleOutAboveMaxSize(t *testing.T) {
+	c, cloud, mock := getScaleOutTestSetup(t, intstr.FromInt(3))
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kops.InstanceGroupRoleNode, 4, 3)
+
+	err := c.RollingUpdate(groups, &kops.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Len(t, mock.launched, 3, "instances launched by scaling out")
+	assert.Equal(t, 3, mock.decrements, "terminations decrementing the desired capacity")
+	assert.Equal(t, int64(7), mock.maxSizeSeen, "maximum size raised")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 4)
+	assertScaledIn(t, cloud, "node-1", 4, 5)
+}
+
+func TestRollingUpdateScaleOutIgnoredForMaster(t *testing.T) {
+	c, cloud, mock := getScaleOutTestSetup(t, intstr.FromInt(2))
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "master-1", kops.InstanceGroupRoleControlPlane, 2, 2)
+
+	err := c.RollingUpdate(groups, &kops.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Empty(t, mock.launched, "instances launched by scaling out")
+	assert.Equal(t, 0, mock.decrements, "terminations decrementing the desired capacity")
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+}
+
+func TestRollingUpdateScaleOutDetachByDefault(t *testing.T) {
+	c, cloud, mock := getScaleOutTestSetup(t, intstr.FromInt(2))
+	c.Cluster.Spec.RollingUpdate.SurgeMode = ""
+	cloud.MockEC2 = &ec2IgnoreTags{EC2API: cloud.MockEC2}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kops.InstanceGroupRoleNode, 3, 3)
+
+	err := c.RollingUpdate(groups, &kops.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Empty(t, mock.launched, "instances launched by scaling out")
+	assert.Equal(t, 2, mock.detached, "instances detached")
+}
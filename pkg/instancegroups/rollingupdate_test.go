@@ -934,6 +934,29 @@ func TestRollingUpdateDisabled(t *testing.T) {
 	assertGroupInstanceCount(t, cloud, "bastion-1", 1)
 }
 
+func TestRollingUpdateSkipsGroupScaledToZeroBySchedule(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.CloudOnly = true
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	groups["node-1"].InstanceGroup.Spec.ScheduledScaling = []kopsapi.ScheduledScalingSpec{
+		{Cron: "0 20 * * *", MinSize: fi.PtrTo(int32(0)), MaxSize: fi.PtrTo(int32(0))},
+	}
+	groups["node-1"].TargetSize = 0
+	groups["node-2"].InstanceGroup.Spec.ScheduledScaling = []kopsapi.ScheduledScalingSpec{
+		{Cron: "0 20 * * *", MinSize: fi.PtrTo(int32(0)), MaxSize: fi.PtrTo(int32(0))},
+	}
+	groups["node-2"].TargetSize = 3
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 0)
+}
+
 type disabledSurgeTest struct {
 	autoscalingiface.AutoScalingAPI
 	t           *testing.T
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// scaleOut tracks the instances added to an autoscaling group when surging by raising its desired capacity.
+type scaleOut struct {
+	cloud     awsup.AWSCloud
+	groupName string
+
+	// originalMaxSize is the maximum size of the group before scaling out, if it had to be raised.
+	originalMaxSize *int64
+	// instanceIDs are the instances launched by scaling out, which are protected from scale-in.
+	instanceIDs []*string
+
+	mutex sync.Mutex
+	// plainTerminations is the number of instances that can still be terminated without
+	// decrementing the desired capacity; the instances terminated after these give back the surge.
+	plainTerminations int
+}
+
+// scaleOutInstanceGroup surges an AWS autoscaling group by raising its desired capacity, waits for the new
+// instances to be in service and protects them from scale-in.
+// The last surge instances terminated by the rolling update decrement the desired capacity again.
+func (c *RollingUpdateCluster) scaleOutInstanceGroup(group *cloudinstances.CloudInstanceGroup, surge int, numUpdate int) (*scaleOut, error) {
+	cloud, ok := c.Cloud.(awsup.AWSCloud)
+	if !ok {
+		return nil, fmt.Errorf("scaling out instance groups is only supported on AWS")
+	}
+	if _, ok := group.Raw.(*autoscaling.Group); !ok {
+		return nil, fmt.Errorf("group %q is not an autoscaling group and cannot be scaled out", group.HumanName)
+	}
+
+	ctx := c.Ctx
+	groupName := group.HumanName
+
+	asg, err := describeAutoscalingGroup(ctx, cloud, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, instance := range asg.Instances {
+		existing[aws.StringValue(instance.InstanceId)] = true
+	}
+
+	s := &scaleOut{
+		cloud:             cloud,
+		groupName:         groupName,
+		plainTerminations: numUpdate - surge,
+	}
+
+	desiredCapacity := aws.Int64Value(asg.DesiredCapacity) + int64(surge)
+	request := &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(groupName),
+		DesiredCapacity:      aws.Int64(desiredCapacity),
+	}
+	if desiredCapacity > aws.Int64Value(asg.MaxSize) {
+		s.originalMaxSize = asg.MaxSize
+		request.MaxSize = aws.Int64(desiredCapacity)
+	}
+
+	klog.Infof("Scaling out group %q by %d instances to a desired capacity of %d.", groupName, surge, desiredCapacity)
+	if _, err := cloud.Autoscaling().UpdateAutoScalingGroupWithContext(ctx, request); err != nil {
+		return nil, fmt.Errorf("error scaling out group %q: %w", groupName, err)
+	}
+
+	deadline := time.Now().Add(c.ValidationTimeout)
+	for {
+		asg, err := describeAutoscalingGroup(ctx, cloud, groupName)
+		if err != nil {
+			return s, err
+		}
+
+		s.instanceIDs = nil
+		for _, instance := range asg.Instances {
+			if !existing[aws.StringValue(instance.InstanceId)] && aws.StringValue(instance.LifecycleState) == autoscaling.LifecycleStateInService {
+				s.instanceIDs = append(s.instanceIDs, instance.InstanceId)
+			}
+		}
+		if len(s.instanceIDs) >= surge {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return s, fmt.Errorf("only %d of %d instances added to group %q were in service within %s", len(s.instanceIDs), surge, groupName, c.ValidationTimeout)
+		}
+		klog.Infof("%d of %d instances added to group %q are in service, will retry in %q.", len(s.instanceIDs), surge, groupName, c.ValidateTickDuration)
+		time.Sleep(c.ValidateTickDuration)
+	}
+
+	if err := s.setInstanceProtection(ctx, true); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// terminateInstance terminates an instance being replaced. Once the instances that the group should replace
+// have been terminated, the remaining instances are terminated by decrementing the desired capacity.
+func (s *scaleOut) terminateInstance(ctx context.Context, u *cloudinstances.CloudInstance) error {
+	s.mutex.Lock()
+	decrement := s.plainTerminations <= 0
+	if !decrement {
+		s.plainTerminations--
+	}
+	s.mutex.Unlock()
+
+	if !decrement {
+		return s.cloud.DeleteInstance(u)
+	}
+
+	klog.Infof("Terminating instance %q and decrementing the desired capacity of group %q.", u.ID, s.groupName)
+	request := &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(u.ID),
+		ShouldDecrementDesiredCapacity: aws.Bool(true),
+	}
+	if _, err := s.cloud.Autoscaling().TerminateInstanceInAutoScalingGroupWithContext(ctx, request); err != nil {
+		return fmt.Errorf("error terminating instance %q: %w", u.ID, err)
+	}
+	return nil
+}
+
+// restore removes the scale-in protection of the added instances and restores the maximum size of the group.
+func (s *scaleOut) restore(ctx context.Context) error {
+	if err := s.setInstanceProtection(ctx, false); err != nil {
+		return err
+	}
+
+	if s.originalMaxSize != nil {
+		request := &autoscaling.UpdateAutoScalingGroupInput{
+			AutoScalingGroupName: aws.String(s.groupName),
+			MaxSize:              s.originalMaxSize,
+		}
+		if _, err := s.cloud.Autoscaling().UpdateAutoScalingGroupWithContext(ctx, request); err != nil {
+			return fmt.Errorf("error restoring the maximum size of group %q: %w", s.groupName, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *scaleOut) setInstanceProtection(ctx context.Context, protected bool) error {
+	if len(s.instanceIDs) == 0 {
+		return nil
+	}
+
+	request := &autoscaling.SetInstanceProtectionInput{
+		AutoScalingGroupName: aws.String(s.groupName),
+		InstanceIds:          s.instanceIDs,
+		ProtectedFromScaleIn: aws.Bool(protected),
+	}
+	if _, err := s.cloud.Autoscaling().SetInstanceProtectionWithContext(ctx, request); err != nil {
+		return fmt.Errorf("error setting the scale-in protection of instances in group %q: %w", s.groupName, err)
+	}
+	return nil
+}
+
+func describeAutoscalingGroup(ctx context.Context, cloud awsup.AWSCloud, name string) (*autoscaling.Group, error) {
+	response, err := cloud.Autoscaling().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing group %q: %w", name, err)
+	}
+	if len(response.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("group %q not found", name)
+	}
+	return response.AutoScalingGroups[0], nil
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package instancegroups
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"k8s.io/kops/pkg/apis/kops"
@@ -24,6 +27,9 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 )
 
+// defaultDrainTimeout is the maximum amount of time to wait for a node to drain, if not specified.
+const defaultDrainTimeout = 15 * time.Minute
+
 func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstances int) kops.RollingUpdate {
 	rollingUpdate := kops.RollingUpdate{}
 	if group.Spec.RollingUpdate != nil {
@@ -40,12 +46,43 @@ func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstan
 		if rollingUpdate.MaxSurge == nil {
 			rollingUpdate.MaxSurge = def.MaxSurge
 		}
+		if rollingUpdate.SurgeMode == "" {
+			rollingUpdate.SurgeMode = def.SurgeMode
+		}
+		if rollingUpdate.DrainTimeout == nil {
+			rollingUpdate.DrainTimeout = def.DrainTimeout
+		}
+		if rollingUpdate.DrainTimeoutPolicy == "" {
+			rollingUpdate.DrainTimeoutPolicy = def.DrainTimeoutPolicy
+		}
+		if rollingUpdate.JoinTimeout == nil {
+			rollingUpdate.JoinTimeout = def.JoinTimeout
+		}
+		if rollingUpdate.RetryJoin == nil {
+			rollingUpdate.RetryJoin = def.RetryJoin
+		}
 	}
 
 	if rollingUpdate.DrainAndTerminate == nil {
 		rollingUpdate.DrainAndTerminate = fi.PtrTo(true)
 	}
 
+	if rollingUpdate.SurgeMode == "" {
+		rollingUpdate.SurgeMode = kops.RollingUpdateSurgeModeDetach
+	}
+
+	if rollingUpdate.DrainTimeout == nil {
+		rollingUpdate.DrainTimeout = &metav1.Duration{Duration: defaultDrainTimeout}
+	}
+
+	if rollingUpdate.DrainTimeoutPolicy == "" {
+		rollingUpdate.DrainTimeoutPolicy = kops.RollingUpdateDrainTimeoutPolicyFail
+	}
+
+	if rollingUpdate.RetryJoin == nil {
+		rollingUpdate.RetryJoin = fi.PtrTo(false)
+	}
+
 	if rollingUpdate.MaxSurge == nil {
 		val := intstr.FromInt(0)
 		if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && !featureflag.Spotinst.Enabled() && group.Spec.Manager != kops.InstanceManagerKarpenter {
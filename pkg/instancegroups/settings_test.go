@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/kops/pkg/apis/kops"
 )
@@ -47,6 +49,16 @@ func TestSettings(t *testing.T) {
 			defaultValue:    intstr.FromInt(0),
 			nonDefaultValue: intstr.FromInt(2),
 		},
+		{
+			name:            "DrainTimeout",
+			defaultValue:    metav1.Duration{Duration: 15 * time.Minute},
+			nonDefaultValue: metav1.Duration{Duration: 5 * time.Minute},
+		},
+		{
+			name:            "RetryJoin",
+			defaultValue:    false,
+			nonDefaultValue: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			defaultCluster := &kops.RollingUpdate{}
@@ -19,6 +19,7 @@ package kubeconfig
 import (
 	"context"
 	"crypto/x509/pkix"
+	"errors"
 	"fmt"
 	"os/user"
 	"sort"
@@ -33,6 +34,9 @@ import (
 
 const DefaultKubecfgAdminLifetime = 18 * time.Hour
 
+// ErrCANotLoaded is returned by BuildKubecfg when the cluster CA certificate could not be loaded from the keystore.
+var ErrCANotLoaded = errors.New("unable to load CA certificate")
+
 func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.KeystoreReader, secretStore fi.SecretStore, cloud fi.Cloud, admin time.Duration, configUser string, internal bool, kopsStateStore string, useKopsAuthenticationPlugin bool) (*KubeconfigBuilder, error) {
 	clusterName := cluster.ObjectMeta.Name
 
@@ -92,15 +96,15 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 	if cluster.Spec.API.LoadBalancer == nil || cluster.Spec.API.LoadBalancer.SSLCertificate == "" || cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork || internal {
 		keySet, err := keyStore.FindKeyset(ctx, fi.CertificateIDCA)
 		if err != nil {
-			return nil, fmt.Errorf("error fetching CA keypair: %v", err)
+			return nil, fmt.Errorf("%w: error fetching CA keypair: %w", ErrCANotLoaded, err)
 		}
 		if keySet != nil {
 			b.CACerts, err = keySet.ToCertificateBytes()
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("%w: %w", ErrCANotLoaded, err)
 			}
 		} else {
-			return nil, fmt.Errorf("cannot find CA certificate")
+			return nil, fmt.Errorf("%w: cannot find CA certificate", ErrCANotLoaded)
 		}
 	}
 
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// ContextNameData is the data available to a context name template.
+type ContextNameData struct {
+	// ClusterName is the name of the kOps cluster.
+	ClusterName string
+}
+
+// ParseContextNameTemplate parses a template used to name the kubectl context of a cluster, such as "kops-{{.ClusterName}}".
+func ParseContextNameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("context-name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing context name template %q: %w", text, err)
+	}
+	if _, err := ContextName(tmpl, "example.k8s.local"); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// ContextName renders the context name for a cluster.
+// If tmpl is nil, the cluster name is used.
+func ContextName(tmpl *template.Template, clusterName string) (string, error) {
+	if tmpl == nil {
+		return clusterName, nil
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, &ContextNameData{ClusterName: clusterName}); err != nil {
+		return "", fmt.Errorf("executing context name template: %w", err)
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("context name template %q produced an empty name", tmpl.Root.String())
+	}
+	return b.String(), nil
+}
+
+// RenameContext changes the context name of the kubeconfig.
+// The cluster entry and the admin user follow the context name.
+func (b *KubeconfigBuilder) RenameContext(name string) {
+	if b.User == b.Context {
+		b.User = name
+	}
+	b.Context = name
+}
+
+// ClusterStoresFunc returns the stores needed to build the kubeconfig for a cluster.
+type ClusterStoresFunc func(cluster *kops.Cluster) (fi.KeystoreReader, fi.SecretStore, fi.Cloud, error)
+
+// ExportOptions holds the options for building the kubeconfigs of several clusters.
+type ExportOptions struct {
+	// Admin is the lifetime of the admin credential issued for each cluster; zero means no admin credential.
+	Admin time.Duration
+	// User is an existing user in the kubeconfig to use for every cluster.
+	User string
+	// Internal uses the internal DNS name of each cluster.
+	Internal bool
+	// KopsStateStore is the state store passed to the authentication plugin.
+	KopsStateStore string
+	// UseKopsAuthenticationPlugin controls whether we should use the kOps auth helper instead of a static credential.
+	UseKopsAuthenticationPlugin bool
+	// ContextName names the context of each cluster; if nil, the cluster name is used.
+	ContextName *template.Template
+}
+
+// SkippedCluster records a cluster that was left out of the export.
+type SkippedCluster struct {
+	ClusterName string
+	Err         error
+}
+
+// BuildKubecfgs builds the kubeconfig of every cluster.
+// Clusters whose CA certificate can't be loaded are skipped and returned, any other error stops the export.
+func BuildKubecfgs(ctx context.Context, clusters []*kops.Cluster, stores ClusterStoresFunc, options *ExportOptions) ([]*KubeconfigBuilder, []SkippedCluster, error) {
+	var builders []*KubeconfigBuilder
+	var skipped []SkippedCluster
+
+	for _, cluster := range clusters {
+		clusterName := cluster.ObjectMeta.Name
+
+		keyStore, secretStore, cloud, err := stores(cluster)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cluster %q: %w", clusterName, err)
+		}
+
+		b, err := BuildKubecfg(ctx, cluster, keyStore, secretStore, cloud, options.Admin, options.User, options.Internal, options.KopsStateStore, options.UseKopsAuthenticationPlugin)
+		if err != nil {
+			if errors.Is(err, ErrCANotLoaded) {
+				skipped = append(skipped, SkippedCluster{ClusterName: clusterName, Err: err})
+				continue
+			}
+			return nil, nil, fmt.Errorf("cluster %q: %w", clusterName, err)
+		}
+
+		contextName, err := ContextName(options.ContextName, clusterName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cluster %q: %w", clusterName, err)
+		}
+		b.RenameContext(contextName)
+
+		builders = append(builders, b)
+	}
+
+	return builders, skipped, nil
+}
+
+// WriteKubecfgs merges the kubeconfigs into the kubeconfig file in a single write.
+// Contexts, clusters and users that don't belong to the exported clusters are preserved,
+// as is the current context, unless it is unset or no longer exists.
+func WriteKubecfgs(configAccess clientcmd.ConfigAccess, builders []*KubeconfigBuilder) error {
+	if len(builders) == 0 {
+		return nil
+	}
+
+	config, err := configAccess.GetStartingConfig()
+	if err != nil {
+		return fmt.Errorf("error reading kubeconfig: %v", err)
+	}
+
+	if config == nil {
+		config = &clientcmdapi.Config{}
+	}
+
+	for _, b := range builders {
+		if err := b.mergeInto(config); err != nil {
+			return fmt.Errorf("context %q: %w", b.Context, err)
+		}
+	}
+
+	if config.Contexts[config.CurrentContext] == nil {
+		config.CurrentContext = builders[0].Context
+	}
+
+	if err := clientcmd.ModifyConfig(configAccess, *config, true); err != nil {
+		return err
+	}
+
+	fmt.Printf("kOps has exported kubectl contexts for %d clusters, the current context is %s\n", len(builders), config.CurrentContext)
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestContextName(t *testing.T) {
+	grid := []struct {
+		template string
+		expected string
+		err      bool
+	}{
+		{
+			template: "kops-{{.ClusterName}}",
+			expected: "kops-example.k8s.local",
+		},
+		{
+			template: "{{.ClusterName}}-admin",
+			expected: "example.k8s.local-admin",
+		},
+		{
+			template: "{{.Unknown}}",
+			err:      true,
+		},
+		{
+			template: "{{ if false }}x{{ end }}",
+			err:      true,
+		},
+		{
+			template: "{{.ClusterName",
+			err:      true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.template, func(t *testing.T) {
+			tmpl, err := ParseContextNameTemplate(g.template)
+			if g.err {
+				if err == nil {
+					t.Fatalf("expected error parsing %q", g.template)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual, err := ContextName(tmpl, "example.k8s.local")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != g.expected {
+				t.Errorf("expected %q, got %q", g.expected, actual)
+			}
+		})
+	}
+
+	actual, err := ContextName(nil, "example.k8s.local")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != "example.k8s.local" {
+		t.Errorf("expected the cluster name without a template, got %q", actual)
+	}
+}
+
+func TestExportAllPreservesUnrelatedEntries(t *testing.T) {
+	originalPKIDefaultPrivateKeySize := pki.DefaultPrivateKeySize
+	pki.DefaultPrivateKeySize = 512
+	defer func() {
+		pki.DefaultPrivateKeySize = originalPKIDefaultPrivateKeySize
+	}()
+
+	ctx := context.TODO()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+
+	existing := clientcmdapi.NewConfig()
+	existing.Clusters["other"] = &clientcmdapi.Cluster{Server: "https://other.example.com"}
+	existing.AuthInfos["other-user"] = &clientcmdapi.AuthInfo{Token: "other-token"}
+	existing.AuthInfos["kops-first.example.com"] = &clientcmdapi.AuthInfo{Username: "kept", Password: "kept"}
+	existing.Contexts["other"] = &clientcmdapi.Context{Cluster: "other", AuthInfo: "other-user", Namespace: "team"}
+	existing.CurrentContext = "other"
+	if err := clientcmd.WriteToFile(*existing, kubeconfigPath); err != nil {
+		t.Fatalf("writing kubeconfig: %v", err)
+	}
+
+	clusters := []*kops.Cluster{
+		buildMinimalCluster("first.example.com", "api.first.example.com", false, false),
+		buildMinimalCluster("second.example.com", "api.second.example.com", false, false),
+		buildMinimalCluster("broken.example.com", "api.broken.example.com", false, false),
+	}
+
+	stores := func(cluster *kops.Cluster) (fi.KeystoreReader, fi.SecretStore, fi.Cloud, error) {
+		keyStore := fakeKeyStore{
+			FindKeysetFn: func(name string) (*fi.Keyset, error) {
+				if cluster.ObjectMeta.Name == "broken.example.com" {
+					return nil, nil
+				}
+				return fakeKeyset(), nil
+			},
+		}
+		return keyStore, nil, fakeStatusCloud{}, nil
+	}
+
+	contextName, err := ParseContextNameTemplate("kops-{{.ClusterName}}")
+	if err != nil {
+		t.Fatalf("parsing template: %v", err)
+	}
+
+	options := &ExportOptions{
+		Admin:       time.Hour,
+		ContextName: contextName,
+	}
+	builders, skipped, err := BuildKubecfgs(ctx, clusters, stores, options)
+	if err != nil {
+		t.Fatalf("BuildKubecfgs: %v", err)
+	}
+
+	if len(skipped) != 1 || skipped[0].ClusterName != "broken.example.com" || !errors.Is(skipped[0].Err, ErrCANotLoaded) {
+		t.Fatalf("expected broken.example.com to be skipped for its CA, got %v", skipped)
+	}
+
+	pathOptions := clientcmd.NewDefaultPathOptions()
+	pathOptions.GlobalFile = kubeconfigPath
+	pathOptions.EnvVar = ""
+	pathOptions.GlobalFileSubpath = ""
+	if err := WriteKubecfgs(pathOptions, builders); err != nil {
+		t.Fatalf("WriteKubecfgs: %v", err)
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("loading kubeconfig: %v", err)
+	}
+
+	if config.CurrentContext != "other" {
+		t.Errorf("expected current context to be preserved, got %q", config.CurrentContext)
+	}
+	if diff := cmp.Diff(config.Contexts["other"], existing.Contexts["other"], cmp.FilterPath(isLocationOfOrigin, cmp.Ignore()), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unrelated context changed (+got, -want): %s", diff)
+	}
+	if diff := cmp.Diff(config.Clusters["other"], existing.Clusters["other"], cmp.FilterPath(isLocationOfOrigin, cmp.Ignore()), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unrelated cluster changed (+got, -want): %s", diff)
+	}
+	if diff := cmp.Diff(config.AuthInfos["other-user"], existing.AuthInfos["other-user"], cmp.FilterPath(isLocationOfOrigin, cmp.Ignore()), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unrelated user changed (+got, -want): %s", diff)
+	}
+
+	for _, name := range []string{"kops-first.example.com", "kops-second.example.com"} {
+		context := config.Contexts[name]
+		if context == nil {
+			t.Errorf("expected context %q to be exported", name)
+			continue
+		}
+		if context.Cluster != name || context.AuthInfo != name {
+			t.Errorf("expected context %q to use cluster and user %q, got %q and %q", name, name, context.Cluster, context.AuthInfo)
+		}
+		if config.Clusters[name] == nil || len(config.Clusters[name].CertificateAuthorityData) == 0 {
+			t.Errorf("expected cluster %q with CA data", name)
+		}
+		user := config.AuthInfos[name]
+		if user == nil || len(user.ClientCertificateData) == 0 || len(user.ClientKeyData) == 0 {
+			t.Errorf("expected admin credential for user %q", name)
+		}
+	}
+
+	if user := config.AuthInfos["kops-first.example.com"]; user != nil && (user.Username != "kept" || user.Password != "kept") {
+		t.Errorf("expected existing basic auth of user kops-first.example.com to be kept, got %q/%q", user.Username, user.Password)
+	}
+
+	if config.Contexts["kops-broken.example.com"] != nil || config.Contexts["broken.example.com"] != nil {
+		t.Errorf("expected no context for the skipped cluster")
+	}
+}
+
+func isLocationOfOrigin(p cmp.Path) bool {
+	return p.Last().String() == ".LocationOfOrigin"
+}
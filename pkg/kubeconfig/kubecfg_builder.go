@@ -88,6 +88,23 @@ func (b *KubeconfigBuilder) WriteKubecfg(configAccess clientcmd.ConfigAccess) er
 		config = &clientcmdapi.Config{}
 	}
 
+	if err := b.mergeInto(config); err != nil {
+		return err
+	}
+
+	config.CurrentContext = b.Context
+
+	if err := clientcmd.ModifyConfig(configAccess, *config, true); err != nil {
+		return err
+	}
+
+	fmt.Printf("kOps has set your kubectl context to %s\n", b.Context)
+	return nil
+}
+
+// mergeInto adds or updates the cluster, user and context entries for b in config.
+// Entries that belong to other contexts are left untouched.
+func (b *KubeconfigBuilder) mergeInto(config *clientcmdapi.Config) error {
 	{
 		cluster := config.Clusters[b.Context]
 		if cluster == nil {
@@ -199,12 +216,5 @@ func (b *KubeconfigBuilder) WriteKubecfg(configAccess clientcmd.ConfigAccess) er
 		config.Contexts[b.Context] = context
 	}
 
-	config.CurrentContext = b.Context
-
-	if err := clientcmd.ModifyConfig(configAccess, *config, true); err != nil {
-		return err
-	}
-
-	fmt.Printf("kOps has set your kubectl context to %s\n", b.Context)
 	return nil
 }
@@ -0,0 +1,310 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeletca implements the staged rotation of the CA that secures the
+// connection between kube-apiserver and the kubelets.
+//
+// The kubelet-ca keyset is optional. While it does not exist, kubelet serving
+// certificates and the apiserver's kubelet client certificate are signed by
+// kubernetes-ca. A rotation goes through three phases, each followed by an
+// update and a rolling update of the cluster, control plane first:
+//
+//   - stage: a new CA is added to the trust bundles, but is not used for signing.
+//   - promote: the new CA becomes the signer, while the old CA is still trusted.
+//   - distrust: the old CA is removed from the trust bundles.
+package kubeletca
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// KeysetName is the name of the keyset holding the kubelet CA.
+const KeysetName = "kubelet-ca"
+
+// Phase is a step of a kubelet CA rotation.
+type Phase string
+
+const (
+	// PhaseStage adds a new CA to the trust bundles without signing with it.
+	PhaseStage Phase = "stage"
+	// PhasePromote makes the staged CA the signer.
+	PhasePromote Phase = "promote"
+	// PhaseDistrust removes the CAs older than the signer from the trust bundles.
+	PhaseDistrust Phase = "distrust"
+)
+
+// Phases are the phases of a rotation, in the order they must be applied.
+var Phases = []Phase{PhaseStage, PhasePromote, PhaseDistrust}
+
+// State is the rotation state of the kubelet-ca keyset.
+type State string
+
+const (
+	// StateIdle means that no rotation is in progress.
+	StateIdle State = "Idle"
+	// StateStaged means that a new CA is trusted, but not yet used for signing.
+	StateStaged State = "Staged"
+	// StatePromoted means that the new CA is used for signing and an older CA is still trusted.
+	StatePromoted State = "Promoted"
+)
+
+// InProgress returns true if the cluster must be rolled control plane first.
+func (s State) InProgress() bool {
+	return s == StateStaged || s == StatePromoted
+}
+
+// CurrentState returns the rotation state of the kubelet-ca keyset, which may be nil.
+func CurrentState(keyset *fi.Keyset) (State, error) {
+	if keyset == nil {
+		return StateIdle, nil
+	}
+	if keyset.Primary == nil {
+		return "", fmt.Errorf("keyset %q has no primary keypair", KeysetName)
+	}
+	if keyset.Primary.DistrustTimestamp != nil {
+		return "", fmt.Errorf("primary keypair %s of keyset %q is distrusted", keyset.Primary.Id, KeysetName)
+	}
+
+	older, newer := false, false
+	for id, item := range keyset.Items {
+		if id == keyset.Primary.Id || item.DistrustTimestamp != nil {
+			continue
+		}
+		if fi.KeysetItemIdOlder(id, keyset.Primary.Id) {
+			older = true
+		} else {
+			newer = true
+		}
+	}
+
+	switch {
+	case newer:
+		return StateStaged, nil
+	case older:
+		return StatePromoted, nil
+	default:
+		return StateIdle, nil
+	}
+}
+
+// CheckTransition returns an error if phase cannot be applied in state.
+func CheckTransition(state State, phase Phase) error {
+	var expected State
+	switch phase {
+	case PhaseStage:
+		expected = StateIdle
+	case PhasePromote:
+		expected = StateStaged
+	case PhaseDistrust:
+		expected = StatePromoted
+	default:
+		return fmt.Errorf("unknown phase %q", phase)
+	}
+	if state != expected {
+		return fmt.Errorf("cannot %s the %s while it is %s, expected %s", phase, KeysetName, state, expected)
+	}
+	return nil
+}
+
+// Stage adds a new CA to the kubelet-ca keyset, without making it primary.
+// If the keyset does not exist yet, it is seeded with the primary keypair of
+// kubernetes-ca, so that existing certificates remain trusted.
+func Stage(ctx context.Context, kubernetesCA *fi.Keyset, keyset *fi.Keyset) (*fi.Keyset, error) {
+	state, err := CurrentState(keyset)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckTransition(state, PhaseStage); err != nil {
+		return nil, err
+	}
+
+	if keyset == nil {
+		if kubernetesCA == nil || kubernetesCA.Primary == nil || kubernetesCA.Primary.PrivateKey == nil {
+			return nil, fmt.Errorf("keyset %q has no primary keypair to seed %q from", fi.CertificateIDCA, KeysetName)
+		}
+		keyset, err = fi.NewKeyset(kubernetesCA.Primary.Certificate, kubernetesCA.Primary.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generating private key: %w", err)
+	}
+	serial := pki.BuildPKISerial(time.Now().UnixNano())
+	cert, _, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Type:       "ca",
+		Subject:    pkix.Name{CommonName: KeysetName, SerialNumber: serial.String()},
+		Serial:     serial,
+		PrivateKey: privateKey,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("issuing certificate: %w", err)
+	}
+
+	if _, err := keyset.AddItem(cert, privateKey, false); err != nil {
+		return nil, err
+	}
+	return keyset, nil
+}
+
+// Promote makes the newest trusted keypair of the kubelet-ca keyset primary.
+func Promote(keyset *fi.Keyset) error {
+	state, err := CurrentState(keyset)
+	if err != nil {
+		return err
+	}
+	if err := CheckTransition(state, PhasePromote); err != nil {
+		return err
+	}
+
+	newest := keyset.Primary
+	for _, item := range keyset.Items {
+		if item.DistrustTimestamp == nil && item.PrivateKey != nil && item.Certificate != nil && fi.KeysetItemIdOlder(newest.Id, item.Id) {
+			newest = item
+		}
+	}
+	if newest == keyset.Primary {
+		return fmt.Errorf("keyset %q has no staged keypair with a private key", KeysetName)
+	}
+	keyset.Primary = newest
+	return nil
+}
+
+// Distrust distrusts the keypairs of the kubelet-ca keyset that are older than the primary.
+func Distrust(keyset *fi.Keyset, now time.Time) error {
+	state, err := CurrentState(keyset)
+	if err != nil {
+		return err
+	}
+	if err := CheckTransition(state, PhaseDistrust); err != nil {
+		return err
+	}
+
+	for id, item := range keyset.Items {
+		if item.DistrustTimestamp == nil && fi.KeysetItemIdOlder(id, keyset.Primary.Id) {
+			item.DistrustTimestamp = &now
+		}
+	}
+	return nil
+}
+
+// CheckRollingUpdateOrder returns an error if, while a rotation is in progress,
+// groups would update instances outside the control plane although a control
+// plane group that is not part of groups still needs updating. Nodes rolled
+// before the control plane would ask kops-controller for certificates signed by
+// a keypair it does not know about yet.
+func CheckRollingUpdateOrder(state State, groups map[string]*cloudinstances.CloudInstanceGroup, controlPlaneGroups map[string]*cloudinstances.CloudInstanceGroup) error {
+	if !state.InProgress() {
+		return nil
+	}
+
+	var pending []string
+	for name, group := range controlPlaneGroups {
+		if _, selected := groups[name]; !selected && len(group.NeedUpdate) != 0 {
+			pending = append(pending, group.InstanceGroup.Name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Strings(pending)
+
+	for _, group := range groups {
+		if group.InstanceGroup.Spec.Role != kops.InstanceGroupRoleControlPlane && len(group.NeedUpdate) != 0 {
+			return fmt.Errorf("%s rotation is %s: control plane instance groups %v must be updated before instance group %q", KeysetName, state, pending, group.InstanceGroup.Name)
+		}
+	}
+	return nil
+}
+
+// TrustBundle concatenates the PEM certificates of bundles, dropping duplicates
+// and preserving the order in which they first appear.
+func TrustBundle(bundles ...string) (string, error) {
+	var out bytes.Buffer
+	seen := make(map[string]bool)
+	for _, bundle := range bundles {
+		blocks, err := parseCertificates(bundle)
+		if err != nil {
+			return "", err
+		}
+		for _, block := range blocks {
+			if seen[string(block.Bytes)] {
+				continue
+			}
+			seen[string(block.Bytes)] = true
+			if err := pem.Encode(&out, block); err != nil {
+				return "", err
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+// VerifyTrustBundle returns an error if bundle does not contain every trusted
+// certificate of keyset.
+func VerifyTrustBundle(bundle string, keyset *fi.Keyset) error {
+	blocks, err := parseCertificates(bundle)
+	if err != nil {
+		return err
+	}
+	present := make(map[string]bool)
+	for _, block := range blocks {
+		present[string(block.Bytes)] = true
+	}
+
+	for id, item := range keyset.Items {
+		if item.DistrustTimestamp != nil || item.Certificate == nil {
+			continue
+		}
+		if !present[string(item.Certificate.Certificate.Raw)] {
+			return fmt.Errorf("trust bundle is missing %s keypair %s", KeysetName, id)
+		}
+	}
+	return nil
+}
+
+func parseCertificates(bundle string) ([]*pem.Block, error) {
+	var blocks []*pem.Block
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q in trust bundle", block.Type)
+		}
+		blocks = append(blocks, &pem.Block{Type: block.Type, Bytes: block.Bytes})
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, fmt.Errorf("trust bundle contains data that is not PEM encoded")
+	}
+	return blocks, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apicatalog checks that manifests only use the built-in APIs that a Kubernetes version serves.
+package apicatalog
+
+//go:generate go run ./gen --out catalog.yaml
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kops/pkg/kubemanifest"
+	"sigs.k8s.io/yaml"
+)
+
+// Catalog lists the lifecycle of the built-in APIs that are not generally available.
+// Generally available APIs are served by every supported Kubernetes version, so they are not listed.
+type Catalog struct {
+	APIs []API `json:"apis"`
+}
+
+// API is the lifecycle of a kind of a built-in API group version.
+type API struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	// Introduced is the Kubernetes minor version that first served the API.
+	Introduced string `json:"introduced,omitempty"`
+	// Deprecated is the Kubernetes minor version that deprecated the API.
+	Deprecated string `json:"deprecated,omitempty"`
+	// Removed is the Kubernetes minor version that stopped serving the API.
+	Removed string `json:"removed,omitempty"`
+	// Replacement is the API to use instead, if there is one.
+	Replacement *GroupVersionKind `json:"replacement,omitempty"`
+}
+
+// GroupVersionKind identifies a kind of an API group version.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+func (g *GroupVersionKind) String() string {
+	return schema.GroupVersion{Group: g.Group, Version: g.Version}.String() + " " + g.Kind
+}
+
+//go:embed catalog.yaml
+var catalogYAML []byte
+
+var (
+	defaultCatalog     *Catalog
+	defaultCatalogErr  error
+	defaultCatalogOnce sync.Once
+)
+
+// Default returns the catalog generated from the Kubernetes API modules kOps is built with.
+func Default() (*Catalog, error) {
+	defaultCatalogOnce.Do(func() {
+		defaultCatalog, defaultCatalogErr = Parse(catalogYAML)
+	})
+	return defaultCatalog, defaultCatalogErr
+}
+
+// Parse parses a catalog.
+func Parse(b []byte) (*Catalog, error) {
+	catalog := &Catalog{}
+	if err := yaml.UnmarshalStrict(b, catalog); err != nil {
+		return nil, fmt.Errorf("error parsing API catalog: %w", err)
+	}
+	for _, api := range catalog.APIs {
+		for _, v := range []string{api.Introduced, api.Deprecated, api.Removed} {
+			if _, err := parseMinorVersion(v); err != nil {
+				return nil, fmt.Errorf("invalid version for %s %s: %w", api.Version, api.Kind, err)
+			}
+		}
+	}
+	return catalog, nil
+}
+
+// Find returns the lifecycle of a kind, or nil if it is not listed.
+func (c *Catalog) Find(gvk schema.GroupVersionKind) *API {
+	for i := range c.APIs {
+		api := &c.APIs[i]
+		if api.Group == gvk.Group && api.Version == gvk.Version && api.Kind == gvk.Kind {
+			return api
+		}
+	}
+	return nil
+}
+
+// Validate checks that a Kubernetes version serves the APIs of all the objects.
+// Kinds that are not listed in the catalog, such as custom resources, are assumed to be served.
+func (c *Catalog) Validate(objects kubemanifest.ObjectList, kubernetesVersion semver.Version) error {
+	version := semver.Version{Major: kubernetesVersion.Major, Minor: kubernetesVersion.Minor}
+
+	var errs []error
+	for _, object := range objects {
+		gvk := object.GroupVersionKind()
+		api := c.Find(gvk)
+		if api == nil {
+			continue
+		}
+
+		name := object.GetName()
+		if namespace := object.GetNamespace(); namespace != "" {
+			name = namespace + "/" + name
+		}
+
+		removed, _ := parseMinorVersion(api.Removed)
+		introduced, _ := parseMinorVersion(api.Introduced)
+		var reason string
+		switch {
+		case removed != nil && version.GTE(*removed):
+			reason = fmt.Sprintf("which was removed in Kubernetes %s", api.Removed)
+		case introduced != nil && version.LT(*introduced):
+			reason = fmt.Sprintf("which is not served until Kubernetes %s", api.Introduced)
+		default:
+			continue
+		}
+		msg := fmt.Sprintf("%s %q uses %s, %s", gvk.Kind, name, gvk.GroupVersion(), reason)
+		if api.Replacement != nil {
+			msg += fmt.Sprintf("; use %s instead", api.Replacement)
+		}
+		errs = append(errs, errors.New(msg))
+	}
+	return errors.Join(errs...)
+}
+
+func parseMinorVersion(s string) (*semver.Version, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := semver.Parse(s + ".0")
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
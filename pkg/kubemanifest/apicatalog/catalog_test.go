@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apicatalog
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	catalog, err := Default()
+	if err != nil {
+		t.Fatalf("error loading catalog: %v", err)
+	}
+
+	api := catalog.Find(schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"})
+	if api == nil {
+		t.Fatalf("policy/v1beta1 PodDisruptionBudget not found in catalog")
+	}
+	if api.Removed != "1.25" {
+		t.Errorf("unexpected removal of policy/v1beta1 PodDisruptionBudget: %q", api.Removed)
+	}
+	if api.Replacement == nil || api.Replacement.String() != "policy/v1 PodDisruptionBudget" {
+		t.Errorf("unexpected replacement of policy/v1beta1 PodDisruptionBudget: %v", api.Replacement)
+	}
+
+	if api := catalog.Find(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}); api != nil {
+		t.Errorf("generally available apps/v1 Deployment should not be in the catalog")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	catalog, err := Default()
+	if err != nil {
+		t.Fatalf("error loading catalog: %v", err)
+	}
+
+	b, err := os.ReadFile("testdata/outdated.yaml")
+	if err != nil {
+		t.Fatalf("error reading manifest: %v", err)
+	}
+	objects, err := kubemanifest.LoadObjectsFrom(b)
+	if err != nil {
+		t.Fatalf("error parsing manifest: %v", err)
+	}
+
+	grid := []struct {
+		kubernetesVersion string
+		expected          []string
+	}{
+		{
+			kubernetesVersion: "1.21.14",
+		},
+		{
+			kubernetesVersion: "1.22.0",
+			expected: []string{
+				`CustomResourceDefinition "widgets.example.com" uses apiextensions.k8s.io/v1beta1, which was removed in Kubernetes 1.22; use apiextensions.k8s.io/v1 CustomResourceDefinition instead`,
+			},
+		},
+		{
+			kubernetesVersion: "1.28.3",
+			expected: []string{
+				`PodDisruptionBudget "kube-system/outdated" uses policy/v1beta1, which was removed in Kubernetes 1.25; use policy/v1 PodDisruptionBudget instead`,
+				`CronJob "kube-system/outdated" uses batch/v1beta1, which was removed in Kubernetes 1.25; use batch/v1 CronJob instead`,
+				`CustomResourceDefinition "widgets.example.com" uses apiextensions.k8s.io/v1beta1, which was removed in Kubernetes 1.22; use apiextensions.k8s.io/v1 CustomResourceDefinition instead`,
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.kubernetesVersion, func(t *testing.T) {
+			err := catalog.Validate(objects, semver.MustParse(g.kubernetesVersion))
+			if len(g.expected) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if actual := strings.Split(err.Error(), "\n"); strings.Join(actual, "\n") != strings.Join(g.expected, "\n") {
+				t.Errorf("unexpected errors\nactual:\n%s\nexpected:\n%s", strings.Join(actual, "\n"), strings.Join(g.expected, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateIntroduced(t *testing.T) {
+	catalog := &Catalog{
+		APIs: []API{
+			{Group: "example.k8s.io", Version: "v1beta1", Kind: "Example", Introduced: "1.28"},
+		},
+	}
+	objects := kubemanifest.ObjectList{
+		kubemanifest.NewObject(map[string]interface{}{
+			"apiVersion": "example.k8s.io/v1beta1",
+			"kind":       "Example",
+			"metadata":   map[string]interface{}{"name": "example"},
+		}),
+	}
+
+	if err := catalog.Validate(objects, semver.MustParse("1.28.0")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := catalog.Validate(objects, semver.MustParse("1.27.9"))
+	expected := `Example "example" uses example.k8s.io/v1beta1, which is not served until Kubernetes 1.28`
+	if err == nil || err.Error() != expected {
+		t.Errorf("unexpected error %v, expected %q", err, expected)
+	}
+}
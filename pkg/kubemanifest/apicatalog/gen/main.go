@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// gen builds the API catalog from the prerelease lifecycle metadata that upstream generates for the built-in APIs.
+// It reads the zz_generated.prerelease-lifecycle.go files of the k8s.io/api and k8s.io/apiextensions-apiserver
+// modules that kOps depends on, so the catalog follows the Kubernetes dependencies when they are updated.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/kubemanifest/apicatalog"
+	"sigs.k8s.io/yaml"
+)
+
+// modules are the modules holding the built-in APIs that addons use.
+var modules = []string{
+	"k8s.io/api",
+	"k8s.io/apiextensions-apiserver",
+}
+
+const lifecycleFile = "zz_generated.prerelease-lifecycle.go"
+
+func main() {
+	out := flag.String("out", "catalog.yaml", "file to write the catalog to")
+	flag.Parse()
+
+	b, err := generate()
+	if err != nil {
+		klog.Fatalf("%v", err)
+	}
+	if err := os.WriteFile(*out, b, 0o644); err != nil {
+		klog.Fatalf("error writing %s: %v", *out, err)
+	}
+}
+
+// generate builds the catalog of the modules used by the current build.
+func generate() ([]byte, error) {
+	catalog := &apicatalog.Catalog{}
+	var sources []string
+	for _, module := range modules {
+		dir, version, err := findModule(module)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, module+" "+version)
+
+		apis, err := readModule(dir)
+		if err != nil {
+			return nil, fmt.Errorf("error reading API lifecycles from %s: %w", module, err)
+		}
+		catalog.APIs = append(catalog.APIs, apis...)
+	}
+
+	sort.Slice(catalog.APIs, func(i, j int) bool {
+		a, b := catalog.APIs[i], catalog.APIs[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Kind < b.Kind
+	})
+
+	y, err := yaml.Marshal(catalog)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling catalog: %w", err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Code generated by pkg/kubemanifest/apicatalog/gen from %s. DO NOT EDIT.\n", strings.Join(sources, " and "))
+	b.Write(y)
+	return b.Bytes(), nil
+}
+
+// findModule returns the directory and version of a module used by the current build.
+func findModule(module string) (string, string, error) {
+	cmd := exec.Command("go", "list", "-mod=mod", "-m", "-f", "{{.Dir}} {{.Version}}", module)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("error finding module %s: %w", module, err)
+	}
+	dir, version, found := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if !found || dir == "" {
+		return "", "", fmt.Errorf("module %s is not downloaded", module)
+	}
+	return dir, version, nil
+}
+
+// readModule reads the lifecycle of the APIs of every package of a module.
+func readModule(dir string) ([]apicatalog.API, error) {
+	var apis []apicatalog.API
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != lifecycleFile {
+			return nil
+		}
+		packageAPIs, err := readPackage(filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", path, err)
+		}
+		apis = append(apis, packageAPIs...)
+		return nil
+	})
+	return apis, err
+}
+
+// readPackage reads the lifecycle of the APIs of an API group version package.
+func readPackage(dir string) ([]apicatalog.API, error) {
+	fset := token.NewFileSet()
+
+	register, err := parser.ParseFile(fset, filepath.Join(dir, "register.go"), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	group, err := findGroupName(register)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle, err := parser.ParseFile(fset, filepath.Join(dir, lifecycleFile), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	version := lifecycle.Name.Name
+
+	apis := make(map[string]*apicatalog.API)
+	for _, decl := range lifecycle.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+		star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		kind := star.X.(*ast.Ident).Name
+		if strings.HasSuffix(kind, "List") {
+			continue
+		}
+		api := apis[kind]
+		if api == nil {
+			api = &apicatalog.API{Group: group, Version: version, Kind: kind}
+			apis[kind] = api
+		}
+
+		result := returnedExprs(fn)
+		switch fn.Name.Name {
+		case "APILifecycleIntroduced":
+			api.Introduced, err = minorVersion(result)
+		case "APILifecycleDeprecated":
+			api.Deprecated, err = minorVersion(result)
+		case "APILifecycleRemoved":
+			api.Removed, err = minorVersion(result)
+		case "APILifecycleReplacement":
+			api.Replacement, err = groupVersionKind(result)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s.%s: %w", kind, fn.Name.Name, err)
+		}
+	}
+
+	var list []apicatalog.API
+	for _, api := range apis {
+		list = append(list, *api)
+	}
+	return list, nil
+}
+
+// findGroupName returns the value of the GroupName constant of a package.
+func findGroupName(file *ast.File) (string, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if name.Name == "GroupName" && i < len(value.Values) {
+					return stringLiteral(value.Values[i])
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("GroupName not found")
+}
+
+func returnedExprs(fn *ast.FuncDecl) []ast.Expr {
+	for _, stmt := range fn.Body.List {
+		if ret, ok := stmt.(*ast.ReturnStmt); ok {
+			return ret.Results
+		}
+	}
+	return nil
+}
+
+// minorVersion reads a "return major, minor" statement as a "major.minor" version.
+func minorVersion(exprs []ast.Expr) (string, error) {
+	if len(exprs) != 2 {
+		return "", fmt.Errorf("expected major and minor versions")
+	}
+	var parts []string
+	for _, expr := range exprs {
+		lit, ok := expr.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return "", fmt.Errorf("expected an integer literal")
+		}
+		parts = append(parts, lit.Value)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// groupVersionKind reads a "return schema.GroupVersionKind{...}" statement.
+func groupVersionKind(exprs []ast.Expr) (*apicatalog.GroupVersionKind, error) {
+	if len(exprs) != 1 {
+		return nil, fmt.Errorf("expected a GroupVersionKind")
+	}
+	lit, ok := exprs[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, fmt.Errorf("expected a GroupVersionKind literal")
+	}
+	gvk := &apicatalog.GroupVersionKind{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, fmt.Errorf("expected keyed GroupVersionKind fields")
+		}
+		value, err := stringLiteral(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch kv.Key.(*ast.Ident).Name {
+		case "Group":
+			gvk.Group = value
+		case "Version":
+			gvk.Version = value
+		case "Kind":
+			gvk.Kind = value
+		}
+	}
+	return gvk, nil
+}
+
+func stringLiteral(expr ast.Expr) (string, error) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", fmt.Errorf("expected a string literal")
+	}
+	return strconv.Unquote(lit.Value)
+}
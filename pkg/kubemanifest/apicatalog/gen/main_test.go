@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/testutils/golden"
+)
+
+// TestCatalogUpToDate checks that the catalog matches the Kubernetes API modules kOps depends on.
+func TestCatalogUpToDate(t *testing.T) {
+	b, err := generate()
+	if err != nil {
+		t.Fatalf("error generating catalog: %v", err)
+	}
+	golden.AssertMatchesFile(t, string(b), "../catalog.yaml")
+}
@@ -180,18 +180,23 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 			LoadBalancerName: fi.PtrTo(loadBalancerName),
 			CLBName:          fi.PtrTo("api." + b.ClusterName()),
-			SecurityGroups: []*awstasks.SecurityGroup{
-				b.LinkToELBSecurityGroup("api"),
-			},
-			SubnetMappings: nlbSubnetMappings,
-			Listeners:      nlbListeners,
-			TargetGroups:   make([]*awstasks.TargetGroup, 0),
+			SubnetMappings:   nlbSubnetMappings,
+			Listeners:        nlbListeners,
+			TargetGroups:     make([]*awstasks.TargetGroup, 0),
 
 			Tags:         tags,
 			ForAPIServer: true,
 			VPC:          b.LinkToVPC(),
 			Type:         fi.PtrTo("network"),
 		}
+		if lbSpec.IPAddressType != "" {
+			nlb.IpAddressType = fi.PtrTo(string(lbSpec.IPAddressType))
+		}
+		if lbSpec.UsesSecurityGroups() {
+			nlb.SecurityGroups = []*awstasks.SecurityGroup{
+				b.LinkToELBSecurityGroup("api"),
+			}
+		}
 
 		clb = &awstasks.ClassicLoadBalancer{
 			Name:      fi.PtrTo("api." + b.ClusterName()),
@@ -249,13 +254,15 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			return fmt.Errorf("unknown load balancer Type: %q", lbSpec.Type)
 		}
 
-		if lbSpec.AccessLog != nil {
+		if lbSpec.AccessLog.IsEnabled() {
 			clb.AccessLog = &awstasks.ClassicLoadBalancerAccessLog{
-				EmitInterval:   fi.PtrTo(int64(lbSpec.AccessLog.Interval)),
 				Enabled:        fi.PtrTo(true),
 				S3BucketName:   lbSpec.AccessLog.Bucket,
 				S3BucketPrefix: lbSpec.AccessLog.BucketPrefix,
 			}
+			if lbSpec.AccessLog.Interval != 0 {
+				clb.AccessLog.EmitInterval = fi.PtrTo(int64(lbSpec.AccessLog.Interval))
+			}
 			nlb.AccessLog = &awstasks.NetworkLoadBalancerAccessLog{
 				Enabled:        fi.PtrTo(true),
 				S3BucketName:   lbSpec.AccessLog.Bucket,
@@ -382,6 +389,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv4-api-elb-egress"),
+				Description:   fi.PtrTo("IPv4 egress from API load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				CIDR:          fi.PtrTo("0.0.0.0/0"),
 				Egress:        fi.PtrTo(true),
@@ -392,6 +400,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv6-api-elb-egress"),
+				Description:   fi.PtrTo("IPv6 egress from API load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				IPv6CIDR:      fi.PtrTo("::/0"),
 				Egress:        fi.PtrTo(true),
@@ -407,6 +416,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			{
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo("https-api-elb-" + cidr),
+					Description:   fi.PtrTo("Kubernetes API from API access CIDR to load balancer"),
 					Lifecycle:     b.SecurityLifecycle,
 					FromPort:      fi.PtrTo(int64(443)),
 					Protocol:      fi.PtrTo("tcp"),
@@ -421,6 +431,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			{
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo("icmpv6-pmtu-api-elb-" + cidr),
+					Description:   fi.PtrTo("ICMPv6 path MTU discovery to API load balancer"),
 					Lifecycle:     b.SecurityLifecycle,
 					FromPort:      fi.PtrTo(int64(-1)),
 					Protocol:      fi.PtrTo("icmpv6"),
@@ -435,6 +446,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			{
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo("icmp-pmtu-api-elb-" + cidr),
+					Description:   fi.PtrTo("ICMP path MTU discovery to API load balancer"),
 					Lifecycle:     b.SecurityLifecycle,
 					FromPort:      fi.PtrTo(int64(3)),
 					Protocol:      fi.PtrTo("icmp"),
@@ -459,6 +471,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			suffix := nodeGroup.Suffix
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("node%s-to-elb", suffix)),
+				Description:   fi.PtrTo("Nodes to API load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: lbSG,
 				SourceGroup:   nodeGroup.Task,
@@ -478,6 +491,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			// Allow access to control plane on secondary port through NLB
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("tcp-api-cp%s", suffix)),
+				Description:   fi.PtrTo("Kubernetes API secondary port from load balancer to control plane"),
 				Lifecycle:     b.SecurityLifecycle,
 				FromPort:      fi.PtrTo(int64(8443)),
 				Protocol:      fi.PtrTo("tcp"),
@@ -500,7 +514,9 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			}
 			c.EnsureTask(t)
 			clb.SecurityGroups = append(clb.SecurityGroups, t)
-			nlb.SecurityGroups = append(nlb.SecurityGroups, t)
+			if lbSpec.UsesSecurityGroups() {
+				nlb.SecurityGroups = append(nlb.SecurityGroups, t)
+			}
 		}
 	}
 
@@ -510,6 +526,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			suffix := masterGroup.Suffix
 			c.AddTask(&awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("https-elb-to-master%s", suffix)),
+				Description:   fi.PtrTo("Kubernetes API from load balancer to control plane"),
 				Lifecycle:     b.SecurityLifecycle,
 				FromPort:      fi.PtrTo(int64(443)),
 				Protocol:      fi.PtrTo("tcp"),
@@ -519,6 +536,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			})
 			c.AddTask(&awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("icmp-pmtu-elb-to-cp%s", suffix)),
+				Description:   fi.PtrTo("ICMP path MTU discovery from API load balancer to control plane"),
 				Lifecycle:     b.SecurityLifecycle,
 				FromPort:      fi.PtrTo(int64(3)),
 				Protocol:      fi.PtrTo("icmp"),
@@ -528,6 +546,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			})
 			c.AddTask(&awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("icmp-pmtu-cp%s-to-elb", suffix)),
+				Description:   fi.PtrTo("ICMP path MTU discovery from control plane to API load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				FromPort:      fi.PtrTo(int64(3)),
 				Protocol:      fi.PtrTo("icmp"),
@@ -538,6 +557,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			if b.Cluster.UsesNoneDNS() {
 				c.AddTask(&awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("kops-controller-elb-to-cp%s", suffix)),
+					Description:   fi.PtrTo("kops-controller from load balancer to control plane"),
 					Lifecycle:     b.SecurityLifecycle,
 					FromPort:      fi.PtrTo(int64(wellknownports.KopsControllerPort)),
 					Protocol:      fi.PtrTo("tcp"),
@@ -549,6 +569,43 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 	}
 
+	// A NLB without security groups preserves the client IP, so the control plane must allow
+	// the API access CIDRs directly, as well as the health checks from within the VPC
+	if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork && !lbSpec.UsesSecurityGroups() {
+		ports := []int64{443}
+		if lbSpec.SSLCertificate != "" {
+			ports = append(ports, 8443)
+		}
+		if b.Cluster.UsesNoneDNS() {
+			ports = append(ports, wellknownports.KopsControllerPort)
+		}
+
+		cidrs := sets.New(b.Cluster.Spec.API.Access...)
+		if b.Cluster.Spec.Networking.NetworkCIDR != "" {
+			cidrs.Insert(b.Cluster.Spec.Networking.NetworkCIDR)
+		}
+		cidrs.Insert(b.Cluster.Spec.Networking.AdditionalNetworkCIDRs...)
+
+		for _, masterGroup := range masterGroups {
+			suffix := masterGroup.Suffix
+			for _, port := range ports {
+				for _, cidr := range sets.List(cidrs) {
+					t := &awstasks.SecurityGroupRule{
+						Name:          fi.PtrTo(fmt.Sprintf("tcp-%d-nlb-cp%s-%s", port, suffix, cidr)),
+						Description:   fi.PtrTo("Traffic through the API load balancer to control plane"),
+						Lifecycle:     b.SecurityLifecycle,
+						FromPort:      fi.PtrTo(port),
+						Protocol:      fi.PtrTo("tcp"),
+						SecurityGroup: masterGroup.Task,
+						ToPort:        fi.PtrTo(port),
+					}
+					t.SetCidrOrPrefix(cidr)
+					c.AddTask(t)
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
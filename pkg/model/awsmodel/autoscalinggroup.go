@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
@@ -58,6 +59,8 @@ type AutoscalingGroupModelBuilder struct {
 	Lifecycle              fi.Lifecycle
 	SecurityLifecycle      fi.Lifecycle
 	Cluster                *kops.Cluster
+	// AllowReplacement permits changes that require replacing all the instances of a group
+	AllowReplacement bool
 }
 
 var _ fi.CloudupModelBuilder = &AutoscalingGroupModelBuilder{}
@@ -87,6 +90,12 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 				return err
 			}
 			tsk.LaunchTemplate = task
+			if len(ig.Spec.ScheduledScaling) > 0 {
+				tsk.ScheduledScaling = fi.PtrTo(true)
+			}
+			if ig.ManagedByClusterAutoscaler() {
+				tsk.AutoscalerManaged = fi.PtrTo(true)
+			}
 			c.AddTask(tsk)
 
 			warmPool := b.Cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(ig)
@@ -101,12 +110,38 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 			if warmPool.IsEnabled() {
 				warmPoolTask.MinSize = warmPool.MinSize
 				warmPoolTask.MaxSize = warmPool.MaxSize
+				warmPoolTask.ReuseOnScaleIn = fi.PtrTo(fi.ValueOf(warmPool.ReuseOnScaleIn))
 				tsk.WarmPool = warmPoolTask
 			} else {
 				tsk.WarmPool = nil
 			}
 			c.AddTask(warmPoolTask)
 
+			// The task is added even without schedules, so that the schedules that were removed get deleted
+			scheduledScalingTask := &awstasks.ScheduledScaling{
+				Name:             &name,
+				Lifecycle:        b.Lifecycle,
+				AutoscalingGroup: b.LinkToAutoscalingGroup(ig),
+			}
+			for i, schedule := range ig.Spec.ScheduledScaling {
+				action := &awstasks.ScheduledAction{
+					Name:       fi.PtrTo(fmt.Sprintf("%s%d", awstasks.ScheduledActionPrefix, i)),
+					Recurrence: fi.PtrTo(schedule.Cron),
+				}
+				if schedule.MinSize != nil {
+					action.MinSize = fi.PtrTo(int64(*schedule.MinSize))
+				}
+				if schedule.MaxSize != nil {
+					action.MaxSize = fi.PtrTo(int64(*schedule.MaxSize))
+				}
+				if schedule.TimeZone != "" {
+					action.TimeZone = fi.PtrTo(schedule.TimeZone)
+				}
+				scheduledScalingTask.Actions = append(scheduledScalingTask.Actions, action)
+			}
+			sort.Sort(awstasks.OrderScheduledActionsByName(scheduledScalingTask.Actions))
+			c.AddTask(scheduledScalingTask)
+
 			hookName := "kops-warmpool"
 			name := fmt.Sprintf("%s-%s", hookName, ig.GetName())
 			enableHook := warmPool.IsEnabled() && warmPool.EnableLifecycleHook
@@ -184,25 +219,35 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 	}
 
 	lt := &awstasks.LaunchTemplate{
-		Name:                         fi.PtrTo(name),
-		Lifecycle:                    b.Lifecycle,
-		CPUCredits:                   fi.PtrTo(fi.ValueOf(ig.Spec.CPUCredits)),
-		HTTPPutResponseHopLimit:      fi.PtrTo(int64(1)),
-		HTTPTokens:                   fi.PtrTo(ec2.LaunchTemplateHttpTokensStateRequired),
-		HTTPProtocolIPv6:             fi.PtrTo(ec2.LaunchTemplateInstanceMetadataProtocolIpv6Disabled),
-		IAMInstanceProfile:           link,
-		ImageID:                      fi.PtrTo(ig.Spec.Image),
-		InstanceInterruptionBehavior: ig.Spec.InstanceInterruptionBehavior,
-		InstanceMonitoring:           fi.PtrTo(false),
-		IPv6AddressCount:             fi.PtrTo(int64(0)),
-		RootVolumeIops:               fi.PtrTo(int64(0)),
-		RootVolumeSize:               fi.PtrTo(int64(rootVolumeSize)),
-		RootVolumeType:               fi.PtrTo(rootVolumeType),
-		RootVolumeEncryption:         fi.PtrTo(rootVolumeEncryption),
-		RootVolumeKmsKey:             fi.PtrTo(rootVolumeKmsKey),
-		SecurityGroups:               securityGroups,
-		Tags:                         tags,
-		UserData:                     userData,
+		Name:                                fi.PtrTo(name),
+		Lifecycle:                           b.Lifecycle,
+		CapacityReservationID:               fi.PtrTo(fi.ValueOf(ig.Spec.CapacityReservationID)),
+		CapacityReservationResourceGroupARN: fi.PtrTo(fi.ValueOf(ig.Spec.CapacityReservationResourceGroupARN)),
+		CPUCredits:                          fi.PtrTo(fi.ValueOf(ig.Spec.CPUCredits)),
+		ENASRDEnabled:                       fi.PtrTo(false),
+		ENASRDUDPEnabled:                    fi.PtrTo(false),
+		HTTPPutResponseHopLimit:             fi.PtrTo(int64(1)),
+		HTTPTokens:                          fi.PtrTo(ec2.LaunchTemplateHttpTokensStateRequired),
+		HTTPProtocolIPv6:                    fi.PtrTo(ec2.LaunchTemplateInstanceMetadataProtocolIpv6Disabled),
+		IAMInstanceProfile:                  link,
+		ImageID:                             fi.PtrTo(ig.Spec.Image),
+		InstanceMonitoring:                  fi.PtrTo(false),
+		IPv6AddressCount:                    fi.PtrTo(int64(0)),
+		RootVolumeIops:                      fi.PtrTo(int64(0)),
+		RootVolumeSize:                      fi.PtrTo(int64(rootVolumeSize)),
+		RootVolumeType:                      fi.PtrTo(rootVolumeType),
+		RootVolumeEncryption:                fi.PtrTo(rootVolumeEncryption),
+		RootVolumeKmsKey:                    fi.PtrTo(rootVolumeKmsKey),
+		SecurityGroups:                      securityGroups,
+		Tags:                                tags,
+		UserData:                            userData,
+	}
+	if b.AllowReplacement {
+		lt.AllowReplacement = fi.PtrTo(true)
+	}
+	if ig.Spec.NetworkInterfaces != nil {
+		lt.ENASRDEnabled = fi.PtrTo(fi.ValueOf(ig.Spec.NetworkInterfaces.ENASRDEnabled))
+		lt.ENASRDUDPEnabled = fi.PtrTo(fi.ValueOf(ig.Spec.NetworkInterfaces.ENASRDUDPEnabled))
 	}
 	if ig.Spec.RootVolume != nil {
 		lt.RootVolumeIops = fi.PtrTo(int64(fi.ValueOf(ig.Spec.RootVolume.IOPS)))
@@ -223,10 +268,7 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 		// @step: check if we can add a public ip to this subnet
 		switch subnets[0].Type {
 		case kops.SubnetTypePublic, kops.SubnetTypeUtility:
-			lt.AssociatePublicIP = fi.PtrTo(true)
-			if ig.Spec.AssociatePublicIP != nil {
-				lt.AssociatePublicIP = ig.Spec.AssociatePublicIP
-			}
+			lt.AssociatePublicIP = fi.PtrTo(ig.AssociatesPublicIP(subnets[0]))
 		case kops.SubnetTypeDualStack, kops.SubnetTypePrivate:
 			lt.AssociatePublicIP = fi.PtrTo(false)
 		}
@@ -328,15 +370,39 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 	//   when you configure an Auto Scaling group with a mixed instances policy.
 	if ig.Spec.MixedInstancesPolicy == nil && ig.Spec.MaxPrice != nil {
 		lt.SpotPrice = ig.Spec.MaxPrice
+		// The spot options only exist in the launch template of spot instances; setting them
+		// for on-demand instances would be reported as a change on every update.
+		lt.SpotDurationInMinutes = ig.Spec.SpotDurationInMinutes
+		lt.InstanceInterruptionBehavior = ig.Spec.InstanceInterruptionBehavior
 	} else {
 		lt.SpotPrice = fi.PtrTo("")
 	}
-	if ig.Spec.SpotDurationInMinutes != nil {
-		lt.SpotDurationInMinutes = ig.Spec.SpotDurationInMinutes
-	}
 
 	if ig.Spec.Tenancy != "" {
 		lt.Tenancy = fi.PtrTo(ig.Spec.Tenancy)
+		lt.HostResourceGroupARN = ig.Spec.HostResourceGroupARN
+	}
+
+	if ig.Spec.Placement != nil && ig.Spec.Placement.Name != "" {
+		// Instance groups can share an existing placement group
+		lt.PlacementGroup = &awstasks.PlacementGroup{
+			Name:      fi.PtrTo(ig.Spec.Placement.Name),
+			Lifecycle: b.Lifecycle,
+			Shared:    fi.PtrTo(true),
+		}
+		c.EnsureTask(lt.PlacementGroup)
+	} else if ig.Spec.Placement != nil {
+		placementGroup := &awstasks.PlacementGroup{
+			Name:      fi.PtrTo(name),
+			Lifecycle: b.Lifecycle,
+			Strategy:  fi.PtrTo(string(ig.Spec.Placement.Strategy)),
+			Tags:      b.CloudTags(name, false),
+		}
+		if ig.Spec.Placement.PartitionCount != nil {
+			placementGroup.PartitionCount = fi.PtrTo(int64(*ig.Spec.Placement.PartitionCount))
+		}
+		c.AddTask(placementGroup)
+		lt.PlacementGroup = placementGroup
 	}
 
 	return lt, nil
@@ -357,6 +423,10 @@ func (b *AutoscalingGroupModelBuilder) buildSecurityGroups(c *fi.CloudupModelBui
 
 	securityGroups := []*awstasks.SecurityGroup{sgLink}
 
+	if len(ig.Spec.NodePortAccess) > 0 {
+		securityGroups = append(securityGroups, b.LinkToNodePortSecurityGroup(ig))
+	}
+
 	if ig.HasAPIServer() &&
 		b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork {
 		for _, id := range b.Cluster.Spec.API.LoadBalancer.AdditionalSecurityGroups {
@@ -406,6 +476,9 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 
 		InstanceProtection: fi.PtrTo(false),
 	}
+	if b.AllowReplacement {
+		t.AllowReplacement = fi.PtrTo(true)
+	}
 
 	minSize := fi.PtrTo(int64(1))
 	maxSize := fi.PtrTo(int64(1))
@@ -544,6 +617,25 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 			} else {
 				ir.MemoryMin = fi.PtrTo(int64(0))
 			}
+
+			gpu := spec.InstanceRequirements.GPU
+			if gpu != nil {
+				if gpu.Max != nil {
+					gpuMax, _ := gpu.Max.AsInt64()
+					ir.GPUMax = &gpuMax
+				}
+				if gpu.Min != nil {
+					gpuMin, _ := gpu.Min.AsInt64()
+					ir.GPUMin = &gpuMin
+				}
+			}
+
+			ir.BurstablePerformance = spec.InstanceRequirements.BurstablePerformance
+			if ir.BurstablePerformance == nil {
+				ir.BurstablePerformance = fi.PtrTo(autoscaling.BurstablePerformanceIncluded)
+			}
+			ir.AllowedInstanceTypes = spec.InstanceRequirements.AllowedInstanceTypes
+			ir.ExcludedInstanceTypes = spec.InstanceRequirements.ExcludedInstanceTypes
 			t.InstanceRequirements = ir
 		}
 
@@ -567,5 +659,13 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 	} else {
 		t.MaxInstanceLifetime = fi.PtrTo(int64(0))
 	}
+
+	if policy := ig.Spec.InstanceMaintenancePolicy; policy != nil && policy.MinHealthyPercentage != nil && policy.MaxHealthyPercentage != nil {
+		t.MinHealthyPercentage = fi.PtrTo(int64(*policy.MinHealthyPercentage))
+		t.MaxHealthyPercentage = fi.PtrTo(int64(*policy.MaxHealthyPercentage))
+	} else {
+		t.MinHealthyPercentage = fi.PtrTo(int64(-1))
+		t.MaxHealthyPercentage = fi.PtrTo(int64(-1))
+	}
 	return t, nil
 }
@@ -77,6 +77,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv4-bastion-egress" + src.Suffix),
+				Description:   fi.PtrTo("IPv4 egress from bastions"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: src.Task,
 				Egress:        fi.PtrTo(true),
@@ -87,6 +88,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv6-bastion-egress" + src.Suffix),
+				Description:   fi.PtrTo("IPv6 egress from bastions"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: src.Task,
 				Egress:        fi.PtrTo(true),
@@ -125,6 +127,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		for _, dest := range masterGroups {
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("bastion-to-master-ssh" + JoinSuffixes(src, dest)),
+				Description:   fi.PtrTo("SSH from bastions to control plane"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: dest.Task,
 				SourceGroup:   src.Task,
@@ -141,6 +144,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		for _, dest := range nodeGroups {
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("bastion-to-node-ssh" + JoinSuffixes(src, dest)),
+				Description:   fi.PtrTo("SSH from bastions to nodes"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: dest.Task,
 				SourceGroup:   src.Task,
@@ -171,6 +175,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv4-bastion-elb-egress"),
+				Description:   fi.PtrTo("IPv4 egress from bastion load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				CIDR:          fi.PtrTo("0.0.0.0/0"),
 				Egress:        fi.PtrTo(true),
@@ -181,6 +186,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv6-bastion-elb-egress"),
+				Description:   fi.PtrTo("IPv6 egress from bastion load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				IPv6CIDR:      fi.PtrTo("::/0"),
 				Egress:        fi.PtrTo(true),
@@ -232,6 +238,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("ssh-nlb-%s", cidr)),
+				Description:   fi.PtrTo("SSH from admin CIDR to bastion load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: lbSG,
 				Protocol:      fi.PtrTo("tcp"),
@@ -246,6 +253,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("icmpv6-pmtu-ssh-nlb-" + cidr),
+				Description:   fi.PtrTo("ICMPv6 path MTU discovery to bastion load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				FromPort:      fi.PtrTo(int64(-1)),
 				Protocol:      fi.PtrTo("icmpv6"),
@@ -260,6 +268,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("icmp-pmtu-ssh-nlb-" + cidr),
+				Description:   fi.PtrTo("ICMP path MTU discovery to bastion load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				FromPort:      fi.PtrTo(int64(3)),
 				Protocol:      fi.PtrTo("icmp"),
@@ -279,6 +288,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			suffix := bastionGroup.Suffix
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("ssh-to-bastion%s", suffix)),
+				Description:   fi.PtrTo("SSH from load balancer to bastions"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: bastionGroup.Task,
 				SourceGroup:   lbSG,
@@ -292,6 +302,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			suffix := bastionGroup.Suffix
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("icmp-to-bastion%s", suffix)),
+				Description:   fi.PtrTo("ICMP path MTU discovery from load balancer to bastions"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: bastionGroup.Task,
 				SourceGroup:   lbSG,
@@ -305,6 +316,7 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			suffix := bastionGroup.Suffix
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo(fmt.Sprintf("icmp-from-bastion%s", suffix)),
+				Description:   fi.PtrTo("ICMP path MTU discovery from bastions to load balancer"),
 				Lifecycle:     b.SecurityLifecycle,
 				SecurityGroup: lbSG,
 				SourceGroup:   bastionGroup.Task,
@@ -347,13 +359,9 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			Listeners:    nlbListeners,
 			TargetGroups: make([]*awstasks.TargetGroup, 0),
 
-			Tags:          tags,
-			VPC:           b.LinkToVPC(),
-			Type:          fi.PtrTo("network"),
-			IpAddressType: fi.PtrTo("ipv4"),
-		}
-		if useIPv6ForBastion(b) {
-			nlb.IpAddressType = fi.PtrTo("dualstack")
+			Tags: tags,
+			VPC:  b.LinkToVPC(),
+			Type: fi.PtrTo("network"),
 		}
 		// Set the NLB Scheme according to load balancer Type
 		switch bastionLoadBalancerType {
@@ -430,22 +438,6 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	return nil
 }
 
-func useIPv6ForBastion(b *BastionModelBuilder) bool {
-	for _, ig := range b.InstanceGroups {
-		for _, igSubnetName := range ig.Spec.Subnets {
-			for _, clusterSubnet := range b.Cluster.Spec.Networking.Subnets {
-				if igSubnetName != clusterSubnet.Name {
-					continue
-				}
-				if clusterSubnet.IPv6CIDR != "" {
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
-
 // Choose between subnets in a zone.
 // We have already applied the rules to match internal subnets to internal NLBs and vice-versa for public-facing NLBs.
 // For internal NLBs: we prefer the control-plane subnets
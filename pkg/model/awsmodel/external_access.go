@@ -18,6 +18,7 @@ package awsmodel
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -64,6 +65,7 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 				suffix := masterGroup.Suffix
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("ssh-external-to-master-%s%s", sshAccess, suffix)),
+					Description:   fi.PtrTo("SSH from admin CIDR to control plane"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: masterGroup.Task,
 					Protocol:      fi.PtrTo("tcp"),
@@ -78,6 +80,7 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 				suffix := nodeGroup.Suffix
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("ssh-external-to-node-%s%s", sshAccess, suffix)),
+					Description:   fi.PtrTo("SSH from admin CIDR to nodes"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: nodeGroup.Task,
 					Protocol:      fi.PtrTo("tcp"),
@@ -101,6 +104,7 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 			{
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("nodeport-tcp-external-to-node-%s%s", nodePortAccess, suffix)),
+					Description:   fi.PtrTo("TCP NodePorts from node port access CIDR"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: nodeGroup.Task,
 					Protocol:      fi.PtrTo("tcp"),
@@ -113,6 +117,7 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 			{
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("nodeport-udp-external-to-node-%s%s", nodePortAccess, suffix)),
+					Description:   fi.PtrTo("UDP NodePorts from node port access CIDR"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: nodeGroup.Task,
 					Protocol:      fi.PtrTo("udp"),
@@ -125,6 +130,14 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 		}
 	}
 
+	for _, ig := range b.InstanceGroups {
+		if len(ig.Spec.NodePortAccess) > 0 {
+			if err := b.buildInstanceGroupNodePortAccess(c, ig); err != nil {
+				return err
+			}
+		}
+	}
+
 	if !b.UseLoadBalancerForAPI() {
 		// Configuration for the master, when not using a Loadbalancer (ELB)
 		// We expect that either the IP address is published, or DNS is set up to point to the IPs
@@ -136,6 +149,7 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 				suffix := masterGroup.Suffix
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("https-external-to-master-%s%s", apiAccess, suffix)),
+					Description:   fi.PtrTo("Kubernetes API from API access CIDR to control plane"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: masterGroup.Task,
 					Protocol:      fi.PtrTo("tcp"),
@@ -150,3 +164,41 @@ func (b *ExternalAccessModelBuilder) Build(c *fi.CloudupModelBuilderContext) err
 
 	return nil
 }
+
+// buildInstanceGroupNodePortAccess opens the node ports of a single instance group to its NodePortAccess CIDRs.
+// The rules live in a security group dedicated to the instance group,
+// because the default node security group is shared by all the node instance groups.
+func (b *ExternalAccessModelBuilder) buildInstanceGroupNodePortAccess(c *fi.CloudupModelBuilderContext, ig *kops.InstanceGroup) error {
+	nodePortRange, err := b.NodePortRange()
+	if err != nil {
+		return err
+	}
+
+	name := b.NodePortSecurityGroupName(ig)
+	sg := &awstasks.SecurityGroup{
+		Name:        fi.PtrTo(name),
+		Lifecycle:   b.Lifecycle,
+		VPC:         b.LinkToVPC(),
+		Description: fi.PtrTo(fmt.Sprintf("Security group for NodePorts of instance group %s", ig.ObjectMeta.Name)),
+		Tags:        b.CloudTags(name, false),
+	}
+	c.AddTask(sg)
+
+	for _, nodePortAccess := range ig.Spec.NodePortAccess {
+		for _, protocol := range []string{"tcp", "udp"} {
+			t := &awstasks.SecurityGroupRule{
+				Name:          fi.PtrTo(fmt.Sprintf("nodeport-%s-external-to-%s-%s", protocol, ig.ObjectMeta.Name, nodePortAccess)),
+				Description:   fi.PtrTo(fmt.Sprintf("%s NodePorts from node port access CIDR", strings.ToUpper(protocol))),
+				Lifecycle:     b.Lifecycle,
+				SecurityGroup: sg,
+				Protocol:      fi.PtrTo(protocol),
+				FromPort:      fi.PtrTo(int64(nodePortRange.Base)),
+				ToPort:        fi.PtrTo(int64(nodePortRange.Base + nodePortRange.Size - 1)),
+			}
+			t.SetCidrOrPrefix(nodePortAccess)
+			c.AddTask(t)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsmodel
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+func TestInstanceGroupNodePortAccess(t *testing.T) {
+	cluster := buildMinimalCluster()
+	cluster.Spec.KubeAPIServer = &kops.KubeAPIServerConfig{
+		ServiceNodePortRange: "31000-31999",
+	}
+
+	ingress := buildNodeInstanceGroup("subnet-us-test-1a")
+	ingress.ObjectMeta.Name = "ingress"
+	ingress.Spec.NodePortAccess = []string{"10.0.0.0/8", "pl-0123456789abcdef0"}
+	nodes := buildNodeInstanceGroup("subnet-us-test-1a")
+
+	b := &ExternalAccessModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				InstanceGroups:  []*kops.InstanceGroup{ingress, nodes},
+			},
+		},
+		Lifecycle: fi.LifecycleSync,
+	}
+
+	c := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	if err := b.Build(c); err != nil {
+		t.Fatalf("error from Build: %v", err)
+	}
+
+	sg, ok := c.Tasks["SecurityGroup/nodeport-ingress.testcluster.test.com"].(*awstasks.SecurityGroup)
+	if !ok {
+		t.Fatalf("expected a node port security group for the ingress instance group, got tasks %v", c.Tasks)
+	}
+
+	var nodePortRules []string
+	for key, task := range c.Tasks {
+		rule, ok := task.(*awstasks.SecurityGroupRule)
+		if !ok || !strings.HasPrefix(fi.ValueOf(rule.Name), "nodeport-") {
+			continue
+		}
+		nodePortRules = append(nodePortRules, key)
+
+		if rule.SecurityGroup != sg {
+			t.Errorf("expected node port rule %q to be on the ingress security group, got %q", key, fi.ValueOf(rule.SecurityGroup.Name))
+		}
+		if fi.ValueOf(rule.FromPort) != 31000 || fi.ValueOf(rule.ToPort) != 31999 {
+			t.Errorf("expected node port rule %q to use the configured range, got %d-%d", key, fi.ValueOf(rule.FromPort), fi.ValueOf(rule.ToPort))
+		}
+	}
+	sort.Strings(nodePortRules)
+
+	expected := []string{
+		"SecurityGroupRule/nodeport-tcp-external-to-ingress-10.0.0.0/8",
+		"SecurityGroupRule/nodeport-tcp-external-to-ingress-pl-0123456789abcdef0",
+		"SecurityGroupRule/nodeport-udp-external-to-ingress-10.0.0.0/8",
+		"SecurityGroupRule/nodeport-udp-external-to-ingress-pl-0123456789abcdef0",
+	}
+	if strings.Join(nodePortRules, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected node port rules, expected %v, got %v", expected, nodePortRules)
+	}
+
+	if _, found := c.Tasks["SecurityGroup/nodeport-nodes.testcluster.test.com"]; found {
+		t.Errorf("expected no node port security group for the nodes instance group")
+	}
+}
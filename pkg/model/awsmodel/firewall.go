@@ -19,6 +19,7 @@ package awsmodel
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
@@ -78,6 +79,7 @@ func (b *FirewallModelBuilder) buildNodeRules(c *fi.CloudupModelBuilderContext)
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv4-node-egress" + src.Suffix),
+				Description:   fi.PtrTo("IPv4 egress from nodes"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: src.Task,
 				Egress:        fi.PtrTo(true),
@@ -88,6 +90,7 @@ func (b *FirewallModelBuilder) buildNodeRules(c *fi.CloudupModelBuilderContext)
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv6-node-egress" + src.Suffix),
+				Description:   fi.PtrTo("IPv6 egress from nodes"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: src.Task,
 				Egress:        fi.PtrTo(true),
@@ -102,6 +105,7 @@ func (b *FirewallModelBuilder) buildNodeRules(c *fi.CloudupModelBuilderContext)
 
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("all-node-to-node" + suffix),
+				Description:   fi.PtrTo("All traffic between nodes"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: dest.Task,
 				SourceGroup:   src.Task,
@@ -169,6 +173,7 @@ func (b *FirewallModelBuilder) applyNodeToMasterBlockSpecificPorts(c *fi.Cloudup
 			for _, r := range udpRanges {
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("node-to-master-udp-%d-%d%s", r.From, r.To, suffix)),
+					Description:   fi.PtrTo("UDP from nodes to control plane"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: masterGroup.Task,
 					SourceGroup:   nodeGroup.Task,
@@ -181,6 +186,7 @@ func (b *FirewallModelBuilder) applyNodeToMasterBlockSpecificPorts(c *fi.Cloudup
 			for _, r := range tcpRanges {
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("node-to-master-tcp-%d-%d%s", r.From, r.To, suffix)),
+					Description:   fi.PtrTo("TCP from nodes to control plane, except etcd"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: masterGroup.Task,
 					SourceGroup:   nodeGroup.Task,
@@ -202,6 +208,7 @@ func (b *FirewallModelBuilder) applyNodeToMasterBlockSpecificPorts(c *fi.Cloudup
 
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo(fmt.Sprintf("node-to-master-protocol-%s%s", name, suffix)),
+					Description:   fi.PtrTo(fmt.Sprintf("%s from nodes to control plane", strings.ToUpper(name))),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: masterGroup.Task,
 					SourceGroup:   nodeGroup.Task,
@@ -221,6 +228,7 @@ func (b *FirewallModelBuilder) applyNodeToMasterBlockSpecificPorts(c *fi.Cloudup
 
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo("all-nodes-to-master" + suffix),
+					Description:   fi.PtrTo("All traffic from nodes to control plane"),
 					Lifecycle:     b.Lifecycle,
 					SecurityGroup: dest.Task,
 					SourceGroup:   src.Task,
@@ -247,6 +255,7 @@ func (b *FirewallModelBuilder) buildMasterRules(c *fi.CloudupModelBuilderContext
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv4-master-egress" + src.Suffix),
+				Description:   fi.PtrTo("IPv4 egress from control plane"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: src.Task,
 				Egress:        fi.PtrTo(true),
@@ -257,6 +266,7 @@ func (b *FirewallModelBuilder) buildMasterRules(c *fi.CloudupModelBuilderContext
 		{
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("ipv6-master-egress" + src.Suffix),
+				Description:   fi.PtrTo("IPv6 egress from control plane"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: src.Task,
 				Egress:        fi.PtrTo(true),
@@ -271,6 +281,7 @@ func (b *FirewallModelBuilder) buildMasterRules(c *fi.CloudupModelBuilderContext
 
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("all-master-to-master" + suffix),
+				Description:   fi.PtrTo("All traffic between control plane instances"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: dest.Task,
 				SourceGroup:   src.Task,
@@ -284,6 +295,7 @@ func (b *FirewallModelBuilder) buildMasterRules(c *fi.CloudupModelBuilderContext
 
 			t := &awstasks.SecurityGroupRule{
 				Name:          fi.PtrTo("all-master-to-node" + suffix),
+				Description:   fi.PtrTo("All traffic from control plane to nodes"),
 				Lifecycle:     b.Lifecycle,
 				SecurityGroup: dest.Task,
 				SourceGroup:   src.Task,
@@ -293,7 +293,10 @@ func (b *IAMModelBuilder) buildIAMTasks(role iam.Subject, iamName string, c *fi.
 				Name:      fi.PtrTo(iamName),
 				Lifecycle: b.Lifecycle,
 				Shared:    fi.PtrTo(shared),
-				Tags:      b.CloudTags(iamName, shared),
+			}
+			// Shared instance profiles are managed externally, so we never tag them
+			if !shared {
+				iamInstanceProfile.Tags = b.CloudTags(iamName, false)
 			}
 			c.AddTask(iamInstanceProfile)
 		}
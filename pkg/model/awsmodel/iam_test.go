@@ -20,8 +20,14 @@ import (
 	"reflect"
 	"testing"
 
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/testutils"
 	"k8s.io/kops/pkg/util/stringorslice"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 )
 
 func TestIAMServiceEC2(t *testing.T) {
@@ -126,3 +132,52 @@ func Test_formatAWSIAMStatement(t *testing.T) {
 		})
 	}
 }
+
+func TestIAMRolesPermissionsBoundary(t *testing.T) {
+	boundary := "arn:aws-test:iam::123456789012:policy/boundaries"
+
+	cluster := testutils.BuildMinimalCluster("boundary.example.com")
+	cluster.Spec.IAM = &kops.IAMSpec{PermissionsBoundary: fi.PtrTo(boundary)}
+	cluster.Spec.KubeAPIServer = &kops.KubeAPIServerConfig{ServiceAccountIssuer: fi.PtrTo("https://discovery.example.com/boundary.example.com")}
+
+	b := &IAMModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{
+					Cluster:      cluster,
+					AWSAccountID: "123456789012",
+					AWSPartition: "aws-test",
+				},
+			},
+		},
+		Lifecycle: fi.LifecycleSync,
+		Cluster:   cluster,
+	}
+
+	c := &fi.CloudupModelBuilderContext{Tasks: make(map[string]fi.CloudupTask)}
+	if err := b.buildIAMTasks(&iam.NodeRoleNode{}, "nodes.boundary.example.com", c, false); err != nil {
+		t.Fatalf("error building node role tasks: %v", err)
+	}
+	serviceAccount := &iam.GenericServiceAccount{
+		NamespacedName: types.NamespacedName{Namespace: "kube-system", Name: "aws-load-balancer-controller"},
+		Policy:         iam.NewPolicy(b.ClusterName(), b.AWSPartition),
+	}
+	if _, err := b.BuildServiceAccountRoleTasks(serviceAccount, c); err != nil {
+		t.Fatalf("error building service account role tasks: %v", err)
+	}
+
+	roles := 0
+	for key, task := range c.Tasks {
+		role, ok := task.(*awstasks.IAMRole)
+		if !ok {
+			continue
+		}
+		roles++
+		if fi.ValueOf(role.PermissionsBoundary) != boundary {
+			t.Errorf("expected %s to have permissions boundary %q, got %q", key, boundary, fi.ValueOf(role.PermissionsBoundary))
+		}
+	}
+	if roles != 2 {
+		t.Errorf("expected 2 roles, got %d", roles)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsmodel
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+const (
+	// defaultNATInstanceType is the machine type of NAT instances managed by kOps, if not specified
+	defaultNATInstanceType = "t3.nano"
+	// defaultNATInstanceImage is the image of NAT instances managed by kOps, if not specified
+	defaultNATInstanceImage = "ssm:/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64"
+)
+
+// natInstanceUserData configures an Amazon Linux 2023 instance to masquerade the traffic it forwards.
+const natInstanceUserData = `#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+echo "net.ipv4.ip_forward = 1" > /etc/sysctl.d/90-kops-nat.conf
+sysctl -p /etc/sysctl.d/90-kops-nat.conf
+
+dnf install -y iptables-services
+systemctl enable --now iptables
+
+interface=$(ip route show default | awk '{print $5; exit}')
+iptables -t nat -A POSTROUTING -o "${interface}" -j MASQUERADE
+iptables -F FORWARD
+service iptables save
+`
+
+// buildNATInstanceSecurityGroup creates the security group of the NAT instances managed by kOps,
+// which accepts traffic from the VPC and sends it anywhere.
+func (b *NetworkModelBuilder) buildNATInstanceSecurityGroup(c *fi.CloudupModelBuilderContext) *awstasks.SecurityGroup {
+	name := "nat." + b.ClusterName()
+	sg := &awstasks.SecurityGroup{
+		Name:        fi.PtrTo(name),
+		Lifecycle:   b.Lifecycle,
+		VPC:         b.LinkToVPC(),
+		Description: fi.PtrTo("Security group for NAT instances"),
+		Tags:        b.CloudTags(name, false),
+	}
+	c.AddTask(sg)
+
+	cidrs := []string{b.Cluster.Spec.Networking.NetworkCIDR}
+	cidrs = append(cidrs, b.Cluster.Spec.Networking.AdditionalNetworkCIDRs...)
+	for _, cidr := range cidrs {
+		if cidr == "" {
+			continue
+		}
+		AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+			Lifecycle:     b.Lifecycle,
+			Description:   fi.PtrTo("All traffic from the VPC to NAT instances"),
+			SecurityGroup: sg,
+			CIDR:          fi.PtrTo(cidr),
+		})
+	}
+	AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+		Lifecycle:     b.Lifecycle,
+		Description:   fi.PtrTo("IPv4 egress from NAT instances"),
+		SecurityGroup: sg,
+		Egress:        fi.PtrTo(true),
+		CIDR:          fi.PtrTo("0.0.0.0/0"),
+	})
+
+	return sg
+}
+
+// buildNATInstance creates a NAT instance managed by kOps in the public subnet of a zone.
+func (b *NetworkModelBuilder) buildNATInstance(c *fi.CloudupModelBuilderContext, zone string, subnet *awstasks.Subnet, sg *awstasks.SecurityGroup) *awstasks.Instance {
+	instanceType := b.Cluster.Spec.Networking.NATInstanceType
+	if instanceType == "" {
+		instanceType = defaultNATInstanceType
+	}
+	image := b.Cluster.Spec.Networking.NATInstanceImage
+	if image == "" {
+		image = defaultNATInstanceImage
+	}
+
+	name := zone + "." + b.ClusterName()
+	in := &awstasks.Instance{
+		Name:              fi.PtrTo(name),
+		Lifecycle:         b.Lifecycle,
+		Subnet:            subnet,
+		ImageID:           fi.PtrTo(image),
+		InstanceType:      fi.PtrTo(instanceType),
+		SecurityGroups:    []*awstasks.SecurityGroup{sg},
+		AssociatePublicIP: fi.PtrTo(true),
+		// NAT instances forward traffic that is neither from nor to themselves
+		SourceDestCheck: fi.PtrTo(false),
+		UserData:        fi.NewStringResource(natInstanceUserData),
+		Shared:          fi.PtrTo(false),
+		Tags:            b.CloudTags(name, false),
+	}
+	c.AddTask(in)
+
+	return in
+}
@@ -19,6 +19,7 @@ package awsmodel
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	aws "k8s.io/cloud-provider-aws/pkg/providers/v1"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/utils"
 )
 
 // NetworkModelBuilder configures network objects
@@ -141,7 +143,8 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	}
 
 	allSubnetsUnmanaged := true
-	allPrivateSubnetsUnmanaged := true
+	// needEgressOnlyInternetGateway is set if a managed private subnet routes IPv6 through the egress-only internet gateway of the cluster
+	needEgressOnlyInternetGateway := false
 	allSubnetsShared := true
 	allSubnetsSharedInZone := make(map[string]bool)
 	for i := range b.Cluster.Spec.Networking.Subnets {
@@ -160,11 +163,16 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		if !isUnmanaged(subnetSpec) {
 			allSubnetsUnmanaged = false
 			if subnetSpec.Type == kops.SubnetTypeDualStack || subnetSpec.Type == kops.SubnetTypePrivate {
-				allPrivateSubnetsUnmanaged = false
+				if !strings.HasPrefix(subnetSpec.Egress, kops.EgressEgressOnlyInternetGateway+"-") {
+					needEgressOnlyInternetGateway = true
+				}
 			}
 		}
 	}
 
+	// ownedRouteTables are the route tables managed by kOps, which Gateway VPC endpoints are added to
+	var ownedRouteTables []*awstasks.RouteTable
+
 	// We always have a public route table, though for private networks it is only used for NGWs and ELBs
 	var publicRouteTable *awstasks.RouteTable
 	var igw *awstasks.InternetGateway
@@ -196,6 +204,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Shared: fi.PtrTo(sharedRouteTable),
 			}
 			c.AddTask(publicRouteTable)
+			ownedRouteTables = append(ownedRouteTables, publicRouteTable)
 
 			// TODO: Validate when allSubnetsShared
 			c.AddTask(&awstasks.Route{
@@ -321,6 +330,9 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		if subnetSpec.ID != "" {
 			subnet.ID = fi.PtrTo(subnetSpec.ID)
 		}
+		if !sharedSubnet && (subnetSpec.Type == kops.SubnetTypePublic || subnetSpec.Type == kops.SubnetTypeUtility) {
+			subnet.MapPublicIPOnLaunch = subnetSpec.MapPublicIPOnLaunch
+		}
 		c.AddTask(subnet)
 
 		switch subnetSpec.Type {
@@ -381,7 +393,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	// The instances in the private subnet can access the IPv6 Internet by
 	// using an egress-only internet gateway.
 	var eigw *awstasks.EgressOnlyInternetGateway
-	if !allPrivateSubnetsUnmanaged && b.IsIPv6Only() {
+	if needEgressOnlyInternetGateway && b.IsIPv6Only() {
 		eigw = &awstasks.EgressOnlyInternetGateway{
 			Name:      fi.PtrTo(b.ClusterName()),
 			Lifecycle: b.Lifecycle,
@@ -392,23 +404,46 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		c.AddTask(eigw)
 	}
 
-	for zone, info := range infoByZone {
+	// Availability zones come first, so the egress of Local Zones can be routed through their NAT gateways
+	var zones []string
+	for zone := range infoByZone {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		if awsup.IsLocalZone(zones[i]) != awsup.IsLocalZone(zones[j]) {
+			return !awsup.IsLocalZone(zones[i])
+		}
+		return zones[i] < zones[j]
+	})
+
+	// parentNATGateway is the NAT gateway used for the egress of Local Zones, which don't support NAT gateways
+	var parentNATGateway *awstasks.NatGateway
+
+	// natInstanceSG is the security group shared by the NAT instances managed by kOps
+	var natInstanceSG *awstasks.SecurityGroup
+
+	for _, zone := range zones {
+		info := infoByZone[zone]
 		if len(info.NATSubnets) == 0 {
 			continue
 		}
 
+		localZone := awsup.IsLocalZone(zone)
+
 		var egressSubnet *awstasks.Subnet
 		var egressRouteTable *awstasks.RouteTable
-		var err error
-		if info.HavePrivateSubnet {
-			egressSubnet, err = b.LinkToUtilitySubnetInZone(zone)
-			egressRouteTable = b.LinkToPrivateRouteTableInZone(zone)
-		} else {
-			egressSubnet, err = b.LinkToPublicSubnetInZone(zone)
-			egressRouteTable = b.LinkToPublicRouteTableInZone(zone)
-		}
-		if err != nil {
-			return err
+		if !localZone {
+			var err error
+			if info.HavePrivateSubnet {
+				egressSubnet, err = b.LinkToUtilitySubnetInZone(zone)
+				egressRouteTable = b.LinkToPrivateRouteTableInZone(zone)
+			} else {
+				egressSubnet, err = b.LinkToPublicSubnetInZone(zone)
+				egressRouteTable = b.LinkToPublicRouteTableInZone(zone)
+			}
+			if err != nil {
+				return err
+			}
 		}
 
 		egress := info.NATSubnets[0].Egress
@@ -435,10 +470,31 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			}
 		}
 
+		// An existing egress-only internet gateway only routes IPv6, so the IPv4 egress uses a NAT gateway managed by kOps
+		zoneEIGW := eigw
+		if strings.HasPrefix(egress, kops.EgressEgressOnlyInternetGateway+"-") {
+			zoneEIGW = &awstasks.EgressOnlyInternetGateway{
+				Name:      fi.PtrTo(egress),
+				Lifecycle: b.Lifecycle,
+				ID:        fi.PtrTo(egress),
+				Shared:    fi.PtrTo(true),
+			}
+			c.EnsureTask(zoneEIGW)
+			egress = ""
+		}
+
 		var ngw *awstasks.NatGateway
 		var tgwID *string
 		var in *awstasks.Instance
-		if egress != "" {
+		if localZone && (egress == "" || strings.HasPrefix(egress, "nat-") || strings.HasPrefix(egress, "eipalloc-")) {
+			if egress != "" {
+				return fmt.Errorf("NAT gateways are not supported in Local Zone %q", zone)
+			}
+			if parentNATGateway == nil {
+				return fmt.Errorf("cannot route the egress of Local Zone %q without a NAT gateway in an availability zone", zone)
+			}
+			ngw = parentNATGateway
+		} else if egress != "" {
 			if strings.HasPrefix(egress, "nat-") {
 
 				ngw = &awstasks.NatGateway{
@@ -487,6 +543,19 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				}
 
 				c.EnsureTask(in)
+			} else if egress == kops.EgressManagedNatInstance {
+				natInstanceSubnet := egressSubnet
+				if localZone {
+					var err error
+					natInstanceSubnet, err = b.LinkToUtilitySubnetInZone(zone)
+					if err != nil {
+						return err
+					}
+				}
+				if natInstanceSG == nil {
+					natInstanceSG = b.buildNATInstanceSecurityGroup(c)
+				}
+				in = b.buildNATInstance(c, zone, natInstanceSubnet, natInstanceSG)
 			} else if strings.HasPrefix(egress, "tgw-") {
 				tgwID = &egress
 			} else if egress == "External" {
@@ -533,6 +602,10 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			c.AddTask(ngw)
 		}
 
+		if parentNATGateway == nil && !localZone {
+			parentNATGateway = ngw
+		}
+
 		if info.HavePrivateSubnet {
 			// Private Route Table
 			//
@@ -550,6 +623,9 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Tags:   routeTableTags,
 			}
 			c.AddTask(rt)
+			if !routeTableShared {
+				ownedRouteTables = append(ownedRouteTables, rt)
+			}
 
 			// Private Routes
 			//
@@ -588,14 +664,15 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 					NatGateway:       ngw,
 					TransitGatewayID: tgwID,
 				})
-
+			}
+			if zoneEIGW != nil {
 				// Route IPv6 to the Egress-only Internet Gateway.
 				c.AddTask(&awstasks.Route{
 					Name:                      fi.PtrTo("private-" + zone + "-::/0"),
 					Lifecycle:                 b.Lifecycle,
 					IPv6CIDR:                  fi.PtrTo("::/0"),
 					RouteTable:                rt,
-					EgressOnlyInternetGateway: eigw,
+					EgressOnlyInternetGateway: zoneEIGW,
 				})
 			}
 
@@ -633,6 +710,9 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Tags:   routeTableTags,
 			}
 			c.AddTask(rt)
+			if !routeTableShared {
+				ownedRouteTables = append(ownedRouteTables, rt)
+			}
 
 			// Routes for the public route table.
 			c.AddTask(&awstasks.Route{
@@ -663,6 +743,45 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 	}
 
+	for _, endpointSpec := range b.Cluster.Spec.Networking.VPCEndpoints {
+		name := strings.ToLower(string(endpointSpec.Type)) + "-" + endpointSpec.ServiceName + "." + b.ClusterName()
+		t := &awstasks.VPCEndpoint{
+			Name:            fi.PtrTo(name),
+			Lifecycle:       b.Lifecycle,
+			VPC:             b.LinkToVPC(),
+			ServiceName:     fi.PtrTo(endpointSpec.ServiceName),
+			VPCEndpointType: fi.PtrTo(string(endpointSpec.Type)),
+			Tags:            b.CloudTags(name, false),
+		}
+
+		switch endpointSpec.Type {
+		case kops.VPCEndpointTypeGateway:
+			if len(ownedRouteTables) == 0 {
+				klog.Warningf("no route tables are managed by kOps, so the Gateway VPC endpoint for %q is not added to any route table", endpointSpec.ServiceName)
+			}
+			t.RouteTables = ownedRouteTables
+
+		case kops.VPCEndpointTypeInterface:
+			for _, subnetName := range endpointSpec.Subnets {
+				subnetSpec := b.FindSubnet(subnetName)
+				if subnetSpec == nil {
+					return fmt.Errorf("subnet %q of the VPC endpoint for %q not found", subnetName, endpointSpec.ServiceName)
+				}
+				t.Subnets = append(t.Subnets, b.LinkToSubnet(subnetSpec))
+			}
+			t.SecurityGroups = []*awstasks.SecurityGroup{b.LinkToSecurityGroup(kops.InstanceGroupRoleNode)}
+			t.PrivateDNSEnabled = fi.PtrTo(true)
+			if endpointSpec.PrivateDNS != nil {
+				t.PrivateDNSEnabled = fi.PtrTo(*endpointSpec.PrivateDNS)
+			}
+
+		default:
+			return fmt.Errorf("unsupported type %q for the VPC endpoint for %q", endpointSpec.Type, endpointSpec.ServiceName)
+		}
+
+		c.AddTask(t)
+	}
+
 	return nil
 }
 
@@ -671,9 +790,13 @@ func addAdditionalRoutes(routes []kops.RouteSpec, sbName string, rt *awstasks.Ro
 		t := &awstasks.Route{
 			Name:       fi.PtrTo(sbName + "." + r.CIDR),
 			Lifecycle:  lf,
-			CIDR:       fi.PtrTo(r.CIDR),
 			RouteTable: rt,
 		}
+		if utils.IsIPv6CIDR(r.CIDR) {
+			t.IPv6CIDR = fi.PtrTo(r.CIDR)
+		} else {
+			t.CIDR = fi.PtrTo(r.CIDR)
+		}
 		if strings.HasPrefix(r.Target, "pcx-") {
 			t.VPCPeeringConnectionID = fi.PtrTo(r.Target)
 			c.AddTask(t)
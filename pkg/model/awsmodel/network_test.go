@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsmodel
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+func buildPrivateCluster(egress string) *kops.Cluster {
+	cluster := buildMinimalCluster()
+	cluster.Spec.Networking.Subnets = []kops.ClusterSubnetSpec{
+		{Name: "us-test-1a", Zone: "us-test-1a", CIDR: "172.20.32.0/19", Type: kops.SubnetTypePrivate, Egress: egress},
+		{Name: "us-test-1b", Zone: "us-test-1b", CIDR: "172.20.64.0/19", Type: kops.SubnetTypePrivate, Egress: egress},
+		{Name: "utility-us-test-1a", Zone: "us-test-1a", CIDR: "172.20.0.0/22", Type: kops.SubnetTypeUtility},
+		{Name: "utility-us-test-1b", Zone: "us-test-1b", CIDR: "172.20.4.0/22", Type: kops.SubnetTypeUtility},
+	}
+	return cluster
+}
+
+func buildNetworkTasks(t *testing.T, cluster *kops.Cluster) map[string]fi.CloudupTask {
+	b := NetworkModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+			},
+		},
+		Lifecycle: fi.LifecycleSync,
+	}
+
+	c := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	if err := b.Build(c); err != nil {
+		t.Fatalf("error from Build: %v", err)
+	}
+	return c.Tasks
+}
+
+func TestNetworkTransitGatewayEgress(t *testing.T) {
+	tasks := buildNetworkTasks(t, buildPrivateCluster("tgw-0123456789abcdef0"))
+
+	for name, task := range tasks {
+		switch task.(type) {
+		case *awstasks.NatGateway, *awstasks.ElasticIP:
+			t.Errorf("unexpected task %q for egress through a transit gateway", name)
+		}
+	}
+
+	for _, zone := range []string{"us-test-1a", "us-test-1b"} {
+		name := "Route/private-" + zone + "-0.0.0.0/0"
+		route, ok := tasks[name].(*awstasks.Route)
+		if !ok {
+			t.Fatalf("task %q not found", name)
+		}
+		if fi.ValueOf(route.TransitGatewayID) != "tgw-0123456789abcdef0" {
+			t.Errorf("expected route %q to target the transit gateway, got %q", name, fi.ValueOf(route.TransitGatewayID))
+		}
+		if route.NatGateway != nil {
+			t.Errorf("expected route %q not to target a NAT gateway", name)
+		}
+	}
+}
+
+func TestNetworkExternalEgress(t *testing.T) {
+	tasks := buildNetworkTasks(t, buildPrivateCluster(kops.EgressExternal))
+
+	for name, task := range tasks {
+		switch task.(type) {
+		case *awstasks.NatGateway, *awstasks.ElasticIP, *awstasks.Route:
+			if name != "Route/0.0.0.0/0" && name != "Route/::/0" {
+				t.Errorf("unexpected task %q for external egress", name)
+			}
+		}
+	}
+}
+
+func TestNetworkNatGatewayEgress(t *testing.T) {
+	tasks := buildNetworkTasks(t, buildPrivateCluster(""))
+
+	for _, zone := range []string{"us-test-1a", "us-test-1b"} {
+		name := "NatGateway/" + zone + ".testcluster.test.com"
+		if _, ok := tasks[name].(*awstasks.NatGateway); !ok {
+			t.Errorf("task %q not found", name)
+		}
+	}
+}
+
+func TestNetworkLocalZoneEgress(t *testing.T) {
+	cluster := buildPrivateCluster("")
+	cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets,
+		kops.ClusterSubnetSpec{Name: "us-test-1-lax-1a", Zone: "us-test-1-lax-1a", CIDR: "172.20.96.0/19", Type: kops.SubnetTypePrivate},
+	)
+	tasks := buildNetworkTasks(t, cluster)
+
+	for name, task := range tasks {
+		switch task.(type) {
+		case *awstasks.NatGateway, *awstasks.ElasticIP:
+			if strings.HasPrefix(name, "NatGateway/us-test-1-lax-1a") || strings.HasPrefix(name, "ElasticIP/us-test-1-lax-1a") {
+				t.Errorf("unexpected task %q in the Local Zone", name)
+			}
+		}
+	}
+
+	name := "Route/private-us-test-1-lax-1a-0.0.0.0/0"
+	route, ok := tasks[name].(*awstasks.Route)
+	if !ok {
+		t.Fatalf("task %q not found", name)
+	}
+	if route.NatGateway == nil || fi.ValueOf(route.NatGateway.Name) != "us-test-1a.testcluster.test.com" {
+		t.Errorf("expected route %q to target the NAT gateway of us-test-1a, got %v", name, route.NatGateway)
+	}
+	if fi.ValueOf(route.RouteTable.Name) != "private-us-test-1-lax-1a.testcluster.test.com" {
+		t.Errorf("expected route %q to be in the route table of the Local Zone, got %q", name, fi.ValueOf(route.RouteTable.Name))
+	}
+	if _, ok := tasks["RouteTableAssociation/private-us-test-1-lax-1a.testcluster.test.com"]; !ok {
+		t.Errorf("expected the Local Zone subnet to be associated with its route table")
+	}
+}
+
+func TestNetworkEgressOnlyInternetGatewayEgress(t *testing.T) {
+	cluster := buildPrivateCluster("eigw-0123456789abcdef0")
+	for i := range cluster.Spec.Networking.Subnets {
+		cluster.Spec.Networking.Subnets[i].IPv6CIDR = "/64#" + strings.Repeat("1", i+1)
+	}
+	tasks := buildNetworkTasks(t, cluster)
+
+	eigw, ok := tasks["EgressOnlyInternetGateway/eigw-0123456789abcdef0"].(*awstasks.EgressOnlyInternetGateway)
+	if !ok {
+		t.Fatalf("task for the existing egress-only internet gateway not found")
+	}
+	if fi.ValueOf(eigw.ID) != "eigw-0123456789abcdef0" || !fi.ValueOf(eigw.Shared) {
+		t.Errorf("expected a shared egress-only internet gateway with the configured ID, got ID=%q Shared=%v", fi.ValueOf(eigw.ID), fi.ValueOf(eigw.Shared))
+	}
+	if _, ok := tasks["EgressOnlyInternetGateway/testcluster.test.com"]; ok {
+		t.Errorf("unexpected egress-only internet gateway managed by kOps")
+	}
+
+	for _, zone := range []string{"us-test-1a", "us-test-1b"} {
+		name := "Route/private-" + zone + "-::/0"
+		route, ok := tasks[name].(*awstasks.Route)
+		if !ok {
+			t.Fatalf("task %q not found", name)
+		}
+		if route.EgressOnlyInternetGateway == nil || fi.ValueOf(route.EgressOnlyInternetGateway.ID) != "eigw-0123456789abcdef0" {
+			t.Errorf("expected route %q to target the existing egress-only internet gateway", name)
+		}
+
+		name = "Route/private-" + zone + "-0.0.0.0/0"
+		route, ok = tasks[name].(*awstasks.Route)
+		if !ok {
+			t.Fatalf("task %q not found", name)
+		}
+		if route.NatGateway == nil {
+			t.Errorf("expected route %q to target a NAT gateway", name)
+		}
+	}
+}
+
+func TestNetworkManagedNatInstanceEgress(t *testing.T) {
+	cluster := buildPrivateCluster(kops.EgressManagedNatInstance)
+	cluster.Spec.Networking.NATInstanceType = "t3.micro"
+	tasks := buildNetworkTasks(t, cluster)
+
+	for name, task := range tasks {
+		switch task.(type) {
+		case *awstasks.NatGateway, *awstasks.ElasticIP:
+			t.Errorf("unexpected task %q for egress through a NAT instance", name)
+		}
+	}
+
+	if _, ok := tasks["SecurityGroup/nat.testcluster.test.com"].(*awstasks.SecurityGroup); !ok {
+		t.Errorf("security group for the NAT instances not found")
+	}
+
+	for _, zone := range []string{"us-test-1a", "us-test-1b"} {
+		name := "Instance/" + zone + ".testcluster.test.com"
+		in, ok := tasks[name].(*awstasks.Instance)
+		if !ok {
+			t.Fatalf("task %q not found", name)
+		}
+		if fi.ValueOf(in.InstanceType) != "t3.micro" {
+			t.Errorf("expected instance %q to be of type t3.micro, got %q", name, fi.ValueOf(in.InstanceType))
+		}
+		if fi.ValueOf(in.ImageID) != defaultNATInstanceImage {
+			t.Errorf("expected instance %q to use the default image, got %q", name, fi.ValueOf(in.ImageID))
+		}
+		if in.SourceDestCheck == nil || fi.ValueOf(in.SourceDestCheck) {
+			t.Errorf("expected instance %q to have SourceDestCheck disabled", name)
+		}
+		if fi.ValueOf(in.Subnet.Name) != "utility-"+zone+".testcluster.test.com" {
+			t.Errorf("expected instance %q to be in the utility subnet, got %q", name, fi.ValueOf(in.Subnet.Name))
+		}
+
+		name = "Route/private-" + zone + "-0.0.0.0/0"
+		route, ok := tasks[name].(*awstasks.Route)
+		if !ok {
+			t.Fatalf("task %q not found", name)
+		}
+		if route.Instance != in {
+			t.Errorf("expected route %q to target the NAT instance of %s", name, zone)
+		}
+	}
+}
@@ -29,8 +29,10 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/model/resources"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 	"k8s.io/kops/upup/pkg/fi/fitasks"
@@ -39,6 +41,9 @@ import (
 	"k8s.io/kops/util/pkg/mirrors"
 )
 
+// userDataOffloadThreshold is the size above which the boot config is moved from the user-data to the state store.
+const userDataOffloadThreshold = awstasks.MaxUserDataSize * 3 / 4
+
 type NodeUpConfigBuilder interface {
 	BuildConfig(ig *kops.InstanceGroup, apiserverAdditionalIPs []string, keysets map[string]*fi.Keyset) (*nodeup.Config, *nodeup.BootConfig, error)
 }
@@ -66,12 +71,18 @@ type BootstrapScript struct {
 
 	// nodeupConfig contains the nodeup config.
 	nodeupConfig fi.CloudupTaskDependentResource
+
+	// bootConfig contains the boot config, when it does not fit in the user-data.
+	bootConfig fi.CloudupTaskDependentResource
+	// bootConfigFile writes the boot config to the state store, or removes it when it fits in the user-data.
+	bootConfigFile *fitasks.ManagedFile
 }
 
 var (
 	_ fi.CloudupTask            = &BootstrapScript{}
 	_ fi.HasName                = &BootstrapScript{}
 	_ fi.CloudupHasDependencies = &BootstrapScript{}
+	_ fi.HasLifecycle           = &BootstrapScript{}
 )
 
 // kubeEnv returns the boot config for the instance group
@@ -105,6 +116,16 @@ func (b *BootstrapScript) kubeEnv(ig *kops.InstanceGroup, c *fi.CloudupContext)
 		}
 		keysets[name] = keyset
 	}
+	if !ig.IsBastion() && c.T.Keystore != nil {
+		// The kubelet-ca keyset only exists once a rotation has been staged.
+		keyset, err := c.T.Keystore.FindKeyset(c.Context(), kubeletca.KeysetName)
+		if err != nil {
+			return nil, fmt.Errorf("reading keyset %q: %w", kubeletca.KeysetName, err)
+		}
+		if keyset != nil {
+			keysets[kubeletca.KeysetName] = keyset
+		}
+	}
 	config, bootConfig, err := b.builder.NodeUpConfigBuilder.BuildConfig(ig, alternateNames, keysets)
 	if err != nil {
 		return nil, err
@@ -277,6 +298,21 @@ func (b *BootstrapScriptBuilder) ResourceNodeUp(c *fi.CloudupModelBuilderContext
 		Location:  fi.PtrTo("igconfig/" + ig.Spec.Role.ToLowerString() + "/" + ig.Name + "/nodeupconfig.yaml"),
 		Contents:  &task.nodeupConfig,
 	})
+
+	if b.Cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		task.bootConfig.Task = task
+		task.bootConfigFile = &fitasks.ManagedFile{
+			Name:      fi.PtrTo("bootconfig-" + ig.Name),
+			Lifecycle: b.Lifecycle,
+			Location:  fi.PtrTo("igconfig/" + ig.Spec.Role.ToLowerString() + "/" + ig.Name + "/bootconfig.yaml"),
+			Contents:  &task.bootConfig,
+		}
+		c.AddTask(task.bootConfigFile)
+
+		// The user-data may reference the boot config, so it must be written before instances are launched.
+		task.resource.Task = task.bootConfigFile
+	}
+
 	return &task.resource, nil
 }
 
@@ -284,6 +320,19 @@ func (b *BootstrapScript) GetName() *string {
 	return &b.Name
 }
 
+// InstanceGroupName returns the name of the instance group that the script is for.
+func (b *BootstrapScript) InstanceGroupName() string {
+	return b.ig.Name
+}
+
+func (b *BootstrapScript) GetLifecycle() fi.Lifecycle {
+	return b.Lifecycle
+}
+
+func (b *BootstrapScript) SetLifecycle(lifecycle fi.Lifecycle) {
+	b.Lifecycle = lifecycle
+}
+
 func (b *BootstrapScript) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
 	var deps []fi.CloudupTask
 
@@ -349,27 +398,64 @@ func (b *BootstrapScript) Run(c *fi.CloudupContext) error {
 
 	nodeupScript.CloudProvider = string(c.T.Cluster.Spec.GetCloudProvider())
 
-	nodeupScriptResource, err := nodeupScript.Build()
+	userData, err := b.buildUserData(&nodeupScript)
 	if err != nil {
 		return err
 	}
 
-	b.resource.Resource = fi.FunctionToResource(func() ([]byte, error) {
-		nodeupScript, err := fi.ResourceAsString(nodeupScriptResource)
-		if err != nil {
-			return nil, err
-		}
+	if b.bootConfigFile != nil {
+		if len(userData) > userDataOffloadThreshold && bootConfig.ConfigServer == nil && bootConfig.ConfigBase != nil {
+			klog.Infof("user-data for instance group %q is %d bytes, close to the limit of %d bytes; moving its boot config to the state store", b.ig.Name, len(userData), awstasks.MaxUserDataSize)
 
-		awsUserData, err := resources.AWSMultipartMIME(nodeupScript, b.ig)
-		if err != nil {
-			return nil, err
+			// The nodeup config is already read from the state store; move the boot config there too,
+			// leaving only what nodeup needs to find and verify it.
+			bootConfigData, err := utils.YamlMarshal(bootConfig)
+			if err != nil {
+				return fmt.Errorf("error converting boot config to yaml: %w", err)
+			}
+			sum256 := sha256.Sum256(bootConfigData)
+			nodeupScript.BootConfig = &nodeup.BootConfig{
+				CloudProvider:     bootConfig.CloudProvider,
+				ConfigBase:        bootConfig.ConfigBase,
+				ClusterName:       bootConfig.ClusterName,
+				InstanceGroupName: bootConfig.InstanceGroupName,
+				InstanceGroupRole: bootConfig.InstanceGroupRole,
+				BootConfigHash:    base64.StdEncoding.EncodeToString(sum256[:]),
+			}
+
+			userData, err = b.buildUserData(&nodeupScript)
+			if err != nil {
+				return err
+			}
+			b.bootConfig.Resource = fi.NewBytesResource(bootConfigData)
+		} else {
+			// Remove the boot config if it was written while the user-data was larger.
+			b.bootConfigFile.Contents = nil
 		}
+	}
 
-		return []byte(awsUserData), nil
-	})
+	// Fail early rather than at instance launch.
+	if c.T.Cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && len(userData) > awstasks.MaxUserDataSize {
+		return fmt.Errorf("user-data for instance group %q is %d bytes, which exceeds the limit of %d bytes; reduce additionalUserData or enable compressUserData", b.ig.Name, len(userData), awstasks.MaxUserDataSize)
+	}
+
+	b.resource.Resource = fi.NewBytesResource([]byte(userData))
 	return nil
 }
 
+// buildUserData renders the nodeup script and combines it with the additionalUserData of the instance group.
+func (b *BootstrapScript) buildUserData(nodeupScript *resources.NodeUpScript) (string, error) {
+	nodeupScriptResource, err := nodeupScript.Build()
+	if err != nil {
+		return "", err
+	}
+	script, err := fi.ResourceAsString(nodeupScriptResource)
+	if err != nil {
+		return "", err
+	}
+	return resources.AWSMultipartMIME(script, b.ig)
+}
+
 func (b *BootstrapScript) createProxyEnv(ps *kops.EgressProxySpec) (string, error) {
 	var buffer bytes.Buffer
 
@@ -17,6 +17,8 @@ limitations under the License.
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"testing"
@@ -29,7 +31,9 @@ import (
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/testutils/golden"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/hashing"
 	"k8s.io/kops/util/pkg/mirrors"
@@ -69,11 +73,13 @@ func Test_ProxyFunc(t *testing.T) {
 }
 
 type nodeupConfigBuilder struct {
-	cluster *kops.Cluster
+	cluster    *kops.Cluster
+	configBase *string
 }
 
 func (n *nodeupConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAdditionalIPs []string, keysets map[string]*fi.Keyset) (*nodeup.Config, *nodeup.BootConfig, error) {
 	config, bootConfig := nodeup.NewConfig(n.cluster, ig)
+	bootConfig.ConfigBase = n.configBase
 	return config, bootConfig, nil
 }
 
@@ -137,50 +143,7 @@ func TestBootstrapUserData(t *testing.T) {
 	for i, x := range cs {
 		cluster := makeTestCluster(x.HookSpecRoles, x.FileAssetSpecRoles)
 		group := makeTestInstanceGroup(x.Role, x.HookSpecRoles, x.FileAssetSpecRoles)
-		c := &fi.CloudupModelBuilderContext{
-			Tasks: make(map[string]fi.CloudupTask),
-		}
-
-		caTask := &fitasks.Keypair{
-			Name:    fi.PtrTo(fi.CertificateIDCA),
-			Subject: "cn=kubernetes",
-			Type:    "ca",
-		}
-		c.AddTask(caTask)
-		for _, keypair := range []string{
-			"apiserver-aggregator-ca",
-			"etcd-clients-ca",
-			"etcd-manager-ca-events",
-			"etcd-manager-ca-main",
-			"etcd-peers-ca-events",
-			"etcd-peers-ca-main",
-			"service-account",
-		} {
-			task := &fitasks.Keypair{
-				Name:    fi.PtrTo(keypair),
-				Subject: "cn=" + keypair,
-				Type:    "ca",
-			}
-			c.AddTask(task)
-		}
-
-		bs := &BootstrapScriptBuilder{
-			KopsModelContext: &KopsModelContext{
-				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
-				InstanceGroups:  []*kops.InstanceGroup{group},
-			},
-			NodeUpConfigBuilder: &nodeupConfigBuilder{cluster: cluster},
-			NodeUpAssets: map[architectures.Architecture]*mirrors.MirroredAsset{
-				architectures.ArchitectureAmd64: {
-					Locations: []string{"nodeup-amd64-1", "nodeup-amd64-2"},
-					Hash:      hashing.MustFromString("833723369ad345a88dd85d61b1e77336d56e61b864557ded71b92b6e34158e6a"),
-				},
-				architectures.ArchitectureArm64: {
-					Locations: []string{"nodeup-arm64-1", "nodeup-arm64-2"},
-					Hash:      hashing.MustFromString("e525c28a65ff0ce4f95f9e730195b4e67fdcb15ceb1f36b5ad6921a8a4490c71"),
-				},
-			},
-		}
+		c, bs := newTestBootstrapScriptBuilder(cluster, group)
 
 		res, err := bs.ResourceNodeUp(c, group)
 		if err != nil {
@@ -211,6 +174,184 @@ func TestBootstrapUserData(t *testing.T) {
 	}
 }
 
+func newTestBootstrapScriptBuilder(cluster *kops.Cluster, group *kops.InstanceGroup) (*fi.CloudupModelBuilderContext, *BootstrapScriptBuilder) {
+	c := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+
+	caTask := &fitasks.Keypair{
+		Name:    fi.PtrTo(fi.CertificateIDCA),
+		Subject: "cn=kubernetes",
+		Type:    "ca",
+	}
+	c.AddTask(caTask)
+	for _, keypair := range []string{
+		"apiserver-aggregator-ca",
+		"etcd-clients-ca",
+		"etcd-manager-ca-events",
+		"etcd-manager-ca-main",
+		"etcd-peers-ca-events",
+		"etcd-peers-ca-main",
+		"service-account",
+	} {
+		task := &fitasks.Keypair{
+			Name:    fi.PtrTo(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		}
+		c.AddTask(task)
+	}
+
+	bs := &BootstrapScriptBuilder{
+		KopsModelContext: &KopsModelContext{
+			IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+			InstanceGroups:  []*kops.InstanceGroup{group},
+		},
+		NodeUpConfigBuilder: &nodeupConfigBuilder{cluster: cluster},
+		NodeUpAssets: map[architectures.Architecture]*mirrors.MirroredAsset{
+			architectures.ArchitectureAmd64: {
+				Locations: []string{"nodeup-amd64-1", "nodeup-amd64-2"},
+				Hash:      hashing.MustFromString("833723369ad345a88dd85d61b1e77336d56e61b864557ded71b92b6e34158e6a"),
+			},
+			architectures.ArchitectureArm64: {
+				Locations: []string{"nodeup-arm64-1", "nodeup-arm64-2"},
+				Hash:      hashing.MustFromString("e525c28a65ff0ce4f95f9e730195b4e67fdcb15ceb1f36b5ad6921a8a4490c71"),
+			},
+		},
+	}
+
+	return c, bs
+}
+
+func TestBootstrapUserDataTooLarge(t *testing.T) {
+	roles := []kops.InstanceGroupRole{"Node"}
+	cluster := makeTestCluster(roles, roles)
+	group := makeTestInstanceGroup("Node", roles, roles)
+	group.Spec.AdditionalUserData = []kops.UserData{
+		{
+			Name:    "large.sh",
+			Type:    "text/x-shellscript",
+			Content: "#!/bin/sh\n" + strings.Repeat("echo padding\n", 2000),
+		},
+	}
+
+	c, bs := newTestBootstrapScriptBuilder(cluster, group)
+	bs.NodeUpConfigBuilder.(*nodeupConfigBuilder).configBase = fi.PtrTo("memfs://tests/testcluster")
+	_, err := bs.ResourceNodeUp(c, group)
+	require.NoError(t, err, "creating nodeup resource")
+
+	err = c.Tasks["BootstrapScript/testIG"].Run(&fi.CloudupContext{T: fi.CloudupSubContext{Cluster: cluster}})
+	require.ErrorContains(t, err, `user-data for instance group "testIG" is`)
+}
+
+func TestBootstrapUserDataOffload(t *testing.T) {
+	grid := []struct {
+		name          string
+		paddingLines  int
+		expectOffload bool
+	}{
+		{
+			name:         "below threshold",
+			paddingLines: 10,
+		},
+		{
+			name:          "above threshold",
+			paddingLines:  700,
+			expectOffload: true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			roles := []kops.InstanceGroupRole{"Node"}
+			cluster := makeTestCluster(roles, roles)
+			group := makeTestInstanceGroup("Node", roles, roles)
+			group.Spec.AdditionalUserData = []kops.UserData{
+				{
+					Name:    "padding.sh",
+					Type:    "text/x-shellscript",
+					Content: "#!/bin/sh\n" + strings.Repeat("echo padding\n", g.paddingLines),
+				},
+			}
+
+			c, bs := newTestBootstrapScriptBuilder(cluster, group)
+			bs.NodeUpConfigBuilder.(*nodeupConfigBuilder).configBase = fi.PtrTo("memfs://tests/testcluster")
+			res, err := bs.ResourceNodeUp(c, group)
+			require.NoError(t, err, "creating nodeup resource")
+
+			require.Contains(t, c.Tasks, "ManagedFile/bootconfig-testIG")
+			bootConfigFile := c.Tasks["ManagedFile/bootconfig-testIG"].(*fitasks.ManagedFile)
+			require.Equal(t, "igconfig/node/testIG/bootconfig.yaml", fi.ValueOf(bootConfigFile.Location))
+			require.Contains(t, fi.FindTaskDependencies(c.Tasks)["ManagedFile/bootconfig-testIG"], "BootstrapScript/testIG")
+			require.Equal(t, bootConfigFile, res.(*fi.CloudupTaskDependentResource).Task, "user-data should depend on the boot config file")
+
+			err = c.Tasks["BootstrapScript/testIG"].Run(&fi.CloudupContext{T: fi.CloudupSubContext{Cluster: cluster}})
+			require.NoError(t, err, "running task")
+
+			userData, err := fi.ResourceAsString(res)
+			require.NoError(t, err, "rendering user-data")
+			require.LessOrEqual(t, len(userData), awstasks.MaxUserDataSize)
+
+			if !g.expectOffload {
+				require.Nil(t, bootConfigFile.Contents, "boot config file should be removed when not offloaded")
+				require.NotContains(t, userData, "BootConfigHash")
+				return
+			}
+			stored, err := fi.ResourceAsBytes(bootConfigFile.Contents)
+			require.NoError(t, err, "reading offloaded boot config")
+
+			var bootConfig nodeup.BootConfig
+			require.NoError(t, utils.YamlUnmarshal(stored, &bootConfig))
+			require.Equal(t, "testIG", bootConfig.InstanceGroupName)
+			require.NotEmpty(t, bootConfig.NodeupConfigHash)
+			require.Empty(t, bootConfig.BootConfigHash)
+
+			// The user-data only references the stored boot config.
+			sum256 := sha256.Sum256(stored)
+			stub := strings.Join([]string{
+				"BootConfigHash: " + base64.StdEncoding.EncodeToString(sum256[:]),
+				"CloudProvider: aws",
+				"ConfigBase: memfs://tests/testcluster",
+				"InstanceGroupName: testIG",
+				"InstanceGroupRole: Node",
+				"NodeupConfigHash: \"\"",
+			}, "\n")
+			require.Contains(t, userData, stub)
+			require.NotContains(t, userData, "NodeupConfigHash: "+bootConfig.NodeupConfigHash)
+		})
+	}
+}
+
+// TestBootstrapUserDataEtcdAdditionalSANs checks that adding etcd additionalSANs to an existing cluster changes the
+// user-data of the control plane, so that the nodes are replaced and etcd-manager reissues its certificates.
+func TestBootstrapUserDataEtcdAdditionalSANs(t *testing.T) {
+	roles := []kops.InstanceGroupRole{"ControlPlane"}
+
+	render := func(additionalSANs []string) (string, string) {
+		cluster := makeTestCluster(roles, roles)
+		cluster.Spec.EtcdClusters[0].AdditionalSANs = additionalSANs
+		group := makeTestInstanceGroup("ControlPlane", roles, roles)
+		c, bs := newTestBootstrapScriptBuilder(cluster, group)
+
+		res, err := bs.ResourceNodeUp(c, group)
+		require.NoError(t, err, "creating nodeup resource")
+		err = c.Tasks["BootstrapScript/testIG"].Run(&fi.CloudupContext{T: fi.CloudupSubContext{Cluster: cluster}})
+		require.NoError(t, err, "running task")
+
+		userData, err := fi.ResourceAsString(res)
+		require.NoError(t, err, "rendering user-data")
+		nodeupConfig, err := fi.ResourceAsString(c.Tasks["ManagedFile/nodeupconfig-testIG"].(*fitasks.ManagedFile).Contents)
+		require.NoError(t, err, "rendering nodeup config")
+		return userData, nodeupConfig
+	}
+
+	existingUserData, existingNodeupConfig := render(nil)
+	userData, nodeupConfig := render([]string{"etcd-main.example.com", "10.0.0.10"})
+
+	require.NotContains(t, existingNodeupConfig, "EtcdAdditionalSANs")
+	require.Contains(t, nodeupConfig, "EtcdAdditionalSANs:\n    main:\n    - etcd-main.example.com\n    - 10.0.0.10\n")
+	require.NotEqual(t, existingUserData, userData, "the control plane user-data should change, so that the nodes are replaced")
+}
+
 func makeTestCluster(hookSpecRoles []kops.InstanceGroupRole, fileAssetSpecRoles []kops.InstanceGroupRole) *kops.Cluster {
 	return &kops.Cluster{
 		Spec: kops.ClusterSpec{
@@ -22,7 +22,8 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 )
 
-// ServiceAccount represents the service-account used by the dns-controller.
+// ServiceAccount represents the service-account used by the EBS CSI driver controller.
+// The csi-snapshotter sidecar runs in the controller pod, so the snapshot permissions are granted here too.
 // It implements iam.Subject to get AWS IAM permissions.
 type ServiceAccount struct{}
 
@@ -34,7 +35,7 @@ func (r *ServiceAccount) BuildAWSPolicy(b *iam.PolicyBuilder) (*iam.Policy, erro
 	p := iam.NewPolicy(clusterName, b.Partition)
 
 	addSnapshotControllerPermissions := b.Cluster.Spec.SnapshotController != nil && fi.ValueOf(b.Cluster.Spec.SnapshotController.Enabled)
-	iam.AddAWSEBSCSIDriverPermissions(p, addSnapshotControllerPermissions)
+	iam.AddAWSEBSCSIDriverPermissions(p, addSnapshotControllerPermissions, b.Cluster.Spec.Snapshots != nil)
 
 	return p, nil
 }
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonmanifests
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// SkipAddonDefaultsAnnotation opts a Deployment or DaemonSet of an addon out of spec.addonDefaults.
+const SkipAddonDefaultsAnnotation = "addon.kops.k8s.io/skip-defaults"
+
+// applyAddonDefaults applies spec.addonDefaults to the pod templates of the addon.
+// Tolerations are added to every pod template. The runtime class and node selector are only set on Deployments,
+// because DaemonSets run node agents that must be scheduled on every node, and never override the addon's own choice.
+func applyAddonDefaults(defaults *kops.AddonDefaultsSpec, objects kubemanifest.ObjectList) error {
+	if defaults == nil {
+		return nil
+	}
+
+	for _, object := range objects {
+		if !hasPodSpecTemplate(object) {
+			continue
+		}
+
+		meta := &metav1.ObjectMeta{}
+		if err := object.Reparse(meta, "metadata"); err != nil {
+			return fmt.Errorf("failed to parse metadata of %s %q: %w", object.Kind(), object.GetName(), err)
+		}
+		if meta.Annotations[SkipAddonDefaultsAnnotation] == "true" {
+			continue
+		}
+
+		podSpec := &corev1.PodSpec{}
+		if err := object.Reparse(podSpec, "spec", "template", "spec"); err != nil {
+			return fmt.Errorf("failed to parse spec.template.spec from %s %q: %w", object.Kind(), object.GetName(), err)
+		}
+
+		changed := false
+		for i := range defaults.Tolerations {
+			toleration := &defaults.Tolerations[i]
+			if !hasToleration(podSpec.Tolerations, toleration) {
+				podSpec.Tolerations = append(podSpec.Tolerations, *toleration)
+				changed = true
+			}
+		}
+
+		// Pods on the host network are control plane components that cannot run in a sandboxed runtime
+		if object.Kind() == "Deployment" && !podSpec.HostNetwork {
+			if defaults.RuntimeClassName != nil && podSpec.RuntimeClassName == nil {
+				podSpec.RuntimeClassName = defaults.RuntimeClassName
+				changed = true
+			}
+			if len(defaults.NodeSelector) != 0 && !choosesNodes(podSpec) {
+				if podSpec.NodeSelector == nil {
+					podSpec.NodeSelector = make(map[string]string)
+				}
+				for k, v := range defaults.NodeSelector {
+					if _, found := podSpec.NodeSelector[k]; !found {
+						podSpec.NodeSelector[k] = v
+						changed = true
+					}
+				}
+			}
+		}
+
+		if !changed {
+			continue
+		}
+		if err := object.Set(podSpec, "spec", "template", "spec"); err != nil {
+			return fmt.Errorf("failed to set object: %w", err)
+		}
+	}
+	return nil
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration *corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+// choosesNodes returns true if the pod already selects nodes by anything other than their OS or architecture,
+// for example addons that must run on the control plane.
+func choosesNodes(podSpec *corev1.PodSpec) bool {
+	for k := range podSpec.NodeSelector {
+		if k != corev1.LabelOSStable && k != corev1.LabelArchStable {
+			return true
+		}
+	}
+	return podSpec.Affinity != nil && podSpec.Affinity.NodeAffinity != nil && podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil
+}
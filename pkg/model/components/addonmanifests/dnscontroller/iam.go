@@ -32,7 +32,7 @@ func (r *ServiceAccount) BuildAWSPolicy(b *iam.PolicyBuilder) (*iam.Policy, erro
 	clusterName := b.Cluster.ObjectMeta.Name
 	p := iam.NewPolicy(clusterName, b.Partition)
 
-	iam.AddDNSControllerPermissions(b, p)
+	iam.AddDNSServiceAccountPermissions(b, p)
 
 	return p, nil
 }
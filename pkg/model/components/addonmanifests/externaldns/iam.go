@@ -33,7 +33,7 @@ func (r *ServiceAccount) BuildAWSPolicy(b *iam.PolicyBuilder) (*iam.Policy, erro
 		Version: iam.PolicyDefaultVersion,
 	}
 
-	iam.AddDNSControllerPermissions(b, p)
+	iam.AddDNSServiceAccountPermissions(b, p)
 
 	return p, nil
 }
@@ -25,6 +25,7 @@ import (
 	addonsapi "k8s.io/kops/channels/pkg/api"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/pkg/kubemanifest/apicatalog"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/components/addonmanifests/dnscontroller"
 	"k8s.io/kops/pkg/model/iam"
@@ -44,12 +45,20 @@ func RemapAddonManifest(addon *addonsapi.AddonSpec, context *model.KopsModelCont
 			return nil, err
 		}
 
+		if err := validateAPIs(context, objects); err != nil {
+			return nil, fmt.Errorf("addon %q is not compatible with Kubernetes %s: %w", name, context.KubernetesVersion(), err)
+		}
+
 		if name == "dns-controller.addons.k8s.io" {
 			if err := dnscontroller.Remap(context, addon, objects); err != nil {
 				return nil, err
 			}
 		}
 
+		if err := applyAddonDefaults(context.Cluster.Spec.AddonDefaults, objects); err != nil {
+			return nil, fmt.Errorf("failed to apply addon defaults to %q: %w", name, err)
+		}
+
 		err = addLabels(addon, objects)
 		if err != nil {
 			return nil, fmt.Errorf("failed to annotate %q: %w", name, err)
@@ -78,6 +87,16 @@ func RemapAddonManifest(addon *addonsapi.AddonSpec, context *model.KopsModelCont
 	return manifest, nil
 }
 
+// validateAPIs checks that the Kubernetes version of the cluster serves the APIs used by the addon,
+// so that addons using removed APIs fail the update instead of failing when channels applies them.
+func validateAPIs(context *model.KopsModelContext, objects kubemanifest.ObjectList) error {
+	catalog, err := apicatalog.Default()
+	if err != nil {
+		return err
+	}
+	return catalog.Validate(objects, context.KubernetesVersion())
+}
+
 func addServiceAccountRole(context *model.KopsModelContext, objects kubemanifest.ObjectList, serviceAccounts map[string]iam.Subject) error {
 	if !context.UseServiceAccountExternalPermissions() {
 		return nil
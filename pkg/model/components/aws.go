@@ -35,7 +35,8 @@ func (b *AWSOptionsBuilder) BuildOptions(o interface{}) error {
 		return nil
 	}
 
-	if clusterSpec.IsIPv6Only() && len(aws.NodeIPFamilies) == 0 {
+	// spec.nodeIPFamilies takes precedence over the deprecated cloud provider field, so there is nothing to default
+	if clusterSpec.IsIPv6Only() && len(aws.NodeIPFamilies) == 0 && len(clusterSpec.NodeIPFamilies) == 0 {
 		aws.NodeIPFamilies = []string{"ipv6", "ipv4"}
 	}
 
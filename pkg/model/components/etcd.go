@@ -43,7 +43,28 @@ func (b *EtcdOptionsBuilder) BuildOptions(o interface{}) error {
 			// We run the k8s-recommended versions of etcd
 			c.Version = DefaultEtcd3Version_1_22
 		}
+
+		// etcd-manager can only find its peers through volume tags if the cloud provider supports them
+		if !SupportsEtcdVolumeTagDiscovery(spec.GetCloudProvider()) {
+			if c.Manager == nil {
+				c.Manager = &kops.EtcdManagerSpec{}
+			}
+			if c.Manager.DiscoveryMode == "" {
+				c.Manager.DiscoveryMode = kops.EtcdManagerDiscoveryModeStatic
+			}
+		}
 	}
 
 	return nil
 }
+
+// SupportsEtcdVolumeTagDiscovery returns true if etcd-manager can discover its peers through the tags of their volumes on the cloud provider.
+func SupportsEtcdVolumeTagDiscovery(cloudProvider kops.CloudProviderID) bool {
+	switch cloudProvider {
+	case kops.CloudProviderAWS, kops.CloudProviderAzure, kops.CloudProviderDO, kops.CloudProviderGCE,
+		kops.CloudProviderHetzner, kops.CloudProviderOpenstack, kops.CloudProviderScaleway:
+		return true
+	default:
+		return false
+	}
+}
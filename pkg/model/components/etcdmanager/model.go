@@ -530,6 +530,15 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 		}
 	}
 
+	if etcdCluster.Manager != nil && etcdCluster.Manager.DiscoveryMode == kops.EtcdManagerDiscoveryModeStatic {
+		// The volumes are still found through their tags, but the peers are found through the seed list
+		config.DiscoveryProvider = "static"
+		for _, member := range etcdCluster.Members {
+			config.DiscoverySeed = append(config.DiscoverySeed, MemberDNSName(b.Cluster.Name, etcdCluster, member))
+		}
+		config.DiscoveryConfigMap = "kube-system/" + DiscoveryConfigMapName
+	}
+
 	args, err := flagbuilder.BuildFlagsList(config)
 	if err != nil {
 		return nil, err
@@ -568,6 +577,14 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 
 	container.Env = envMap.ToEnvVars()
 
+	if len(etcdCluster.AdditionalSANs) > 0 {
+		// etcd-manager adds these to the server and peer certificates it issues for etcd
+		container.Env = append(container.Env, v1.EnvVar{
+			Name:  "ETCD_MANAGER_ADDITIONAL_SANS",
+			Value: strings.Join(etcdCluster.AdditionalSANs, ","),
+		})
+	}
+
 	if etcdCluster.Manager != nil {
 		if etcdCluster.Manager.BackupRetentionDays != nil {
 			envVar := v1.EnvVar{
@@ -648,6 +665,27 @@ type config struct {
 	VolumeNameTag         string   `flag:"volume-name-tag"`
 	DNSSuffix             string   `flag:"dns-suffix"`
 	NetworkCIDR           *string  `flag:"network-cidr"`
+
+	// DiscoveryProvider overrides how etcd-manager discovers its peers, which is through the volumes by default
+	DiscoveryProvider string `flag:"discovery-provider"`
+	// DiscoverySeed lists the DNS names of the peers, for static discovery
+	DiscoverySeed []string `flag:"discovery-seed,repeat"`
+	// DiscoveryConfigMap is the namespace/name of the ConfigMap holding the addresses of the peers, for static discovery
+	DiscoveryConfigMap string `flag:"discovery-configmap"`
+}
+
+// DiscoveryConfigMapName is the name of the ConfigMap in kube-system where kops-controller publishes
+// the addresses of the etcd members, for static discovery.
+const DiscoveryConfigMapName = "etcd-manager-discovery"
+
+// MemberDNSName returns the DNS name etcd-manager uses for a member of the etcd cluster.
+// It matches the name etcd-manager builds from the etcd cluster name and the dns-suffix flag.
+func MemberDNSName(clusterName string, etcdCluster kops.EtcdClusterSpec, member kops.EtcdMemberSpec) string {
+	name := "etcd-" + etcdCluster.Name
+	if etcdCluster.Name == "main" {
+		name = "etcd"
+	}
+	return name + "-" + member.Name + ".internal." + clusterName
 }
 
 // SelectorForCluster returns the selector that should be used to select our pods (from services)
@@ -37,6 +37,8 @@ func Test_RunEtcdManagerBuilder(t *testing.T) {
 		"tests/interval",
 		"tests/proxy",
 		"tests/overwrite_settings",
+		"tests/static_discovery",
+		"tests/additional_sans",
 	}
 	for _, basedir := range tests {
 		basedir := basedir
@@ -62,6 +62,14 @@ func (b *KubeProxyOptionsBuilder) BuildOptions(o interface{}) error {
 	// * dns is set up by dns-controller
 	// * dns-controller talks to the API using the kube-proxy configured kubernetes service
 
+	if config.BindAddress == "" && len(clusterSpec.NodeIPFamilies) > 0 {
+		if clusterSpec.NodeIPFamilies[0] == kops.IPFamilyIPv6 {
+			config.BindAddress = "::"
+		} else {
+			config.BindAddress = "0.0.0.0"
+		}
+	}
+
 	if config.ClusterCIDR == nil {
 		if b.needsClusterCIDR(clusterSpec) {
 			config.ClusterCIDR = fi.PtrTo(clusterSpec.KubeControllerManager.ClusterCIDR)
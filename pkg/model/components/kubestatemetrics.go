@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"sort"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// KubeStateMetricsResourceAPIGroups maps the resources kube-state-metrics can export metrics for to their API group.
+var KubeStateMetricsResourceAPIGroups = map[string]string{
+	"certificatesigningrequests":      "certificates.k8s.io",
+	"configmaps":                      "",
+	"cronjobs":                        "batch",
+	"daemonsets":                      "apps",
+	"deployments":                     "apps",
+	"endpoints":                       "",
+	"horizontalpodautoscalers":        "autoscaling",
+	"ingresses":                       "networking.k8s.io",
+	"jobs":                            "batch",
+	"leases":                          "coordination.k8s.io",
+	"limitranges":                     "",
+	"mutatingwebhookconfigurations":   "admissionregistration.k8s.io",
+	"namespaces":                      "",
+	"networkpolicies":                 "networking.k8s.io",
+	"nodes":                           "",
+	"persistentvolumeclaims":          "",
+	"persistentvolumes":               "",
+	"poddisruptionbudgets":            "policy",
+	"pods":                            "",
+	"replicasets":                     "apps",
+	"replicationcontrollers":          "",
+	"resourcequotas":                  "",
+	"secrets":                         "",
+	"services":                        "",
+	"statefulsets":                    "apps",
+	"storageclasses":                  "storage.k8s.io",
+	"validatingwebhookconfigurations": "admissionregistration.k8s.io",
+	"volumeattachments":               "storage.k8s.io",
+}
+
+// DefaultKubeStateMetricsResources are the resources that kube-state-metrics exports metrics for by default.
+// Secrets and configmaps are left out so that kube-state-metrics cannot read them unless asked to.
+var DefaultKubeStateMetricsResources = []string{
+	"cronjobs",
+	"daemonsets",
+	"deployments",
+	"horizontalpodautoscalers",
+	"jobs",
+	"namespaces",
+	"nodes",
+	"persistentvolumeclaims",
+	"persistentvolumes",
+	"poddisruptionbudgets",
+	"pods",
+	"replicasets",
+	"services",
+	"statefulsets",
+}
+
+// KubeStateMetricsOptionsBuilder adds options for kube-state-metrics to the model.
+type KubeStateMetricsOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &KubeStateMetricsOptionsBuilder{}
+
+func (b *KubeStateMetricsOptionsBuilder) BuildOptions(o interface{}) error {
+	clusterSpec := o.(*kops.ClusterSpec)
+	if clusterSpec.Metrics == nil || clusterSpec.Metrics.KubeStateMetrics == nil {
+		return nil
+	}
+	ksm := clusterSpec.Metrics.KubeStateMetrics
+
+	if ksm.Enabled == nil {
+		ksm.Enabled = fi.PtrTo(false)
+	}
+
+	if ksm.Image == nil {
+		ksm.Image = fi.PtrTo("registry.k8s.io/kube-state-metrics/kube-state-metrics:v2.10.1")
+	}
+
+	if len(ksm.Resources) == 0 {
+		ksm.Resources = append([]string(nil), DefaultKubeStateMetricsResources...)
+	}
+
+	if ksm.ClusterNetworkOnly == nil {
+		ksm.ClusterNetworkOnly = fi.PtrTo(true)
+	}
+
+	return nil
+}
+
+// KubeStateMetricsAPIGroups groups the resources exported by kube-state-metrics by API group,
+// so that its ClusterRole only grants access to those resources.
+func KubeStateMetricsAPIGroups(resources []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, resource := range resources {
+		group, found := KubeStateMetricsResourceAPIGroups[resource]
+		if !found {
+			continue
+		}
+		groups[group] = append(groups[group], resource)
+	}
+	for _, groupResources := range groups {
+		sort.Strings(groupResources)
+	}
+	return groups
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// SnapshotsOptionsBuilder adds options for scheduled volume snapshots to the model
+type SnapshotsOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &SnapshotsOptionsBuilder{}
+
+func (b *SnapshotsOptionsBuilder) BuildOptions(o interface{}) error {
+	c := o.(*kops.ClusterSpec).Snapshots
+	if c == nil {
+		return nil
+	}
+
+	if c.Image == "" {
+		c.Image = "quay.io/backube/snapscheduler:3.4.0"
+	}
+
+	if c.Schedule != nil && len(c.Schedule.Namespaces) == 0 {
+		c.Schedule.Namespaces = []string{"default"}
+	}
+
+	return nil
+}
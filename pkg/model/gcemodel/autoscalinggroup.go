@@ -74,6 +74,9 @@ func (b *AutoscalingGroupModelBuilder) buildInstanceTemplate(c *fi.CloudupModelB
 					return nil, err
 				}
 			}
+			if ig.Spec.GCE != nil && ig.Spec.GCE.BootDiskType != "" {
+				volumeType = ig.Spec.GCE.BootDiskType
+			}
 			if volumeType == "" {
 				volumeType = DefaultVolumeType
 			}
@@ -110,6 +113,15 @@ func (b *AutoscalingGroupModelBuilder) buildInstanceTemplate(c *fi.CloudupModelB
 				},
 			}
 
+			t.LocalSSDCount = fi.PtrTo(int64(0))
+			if ig.Spec.GCE != nil && fi.ValueOf(ig.Spec.GCE.LocalSSDCount) > 0 {
+				t.LocalSSDCount = fi.PtrTo(int64(fi.ValueOf(ig.Spec.GCE.LocalSSDCount)))
+				t.LocalSSDInterface = fi.PtrTo(ig.Spec.GCE.LocalSSDInterface)
+				if ig.Spec.GCE.LocalSSDInterface == "" {
+					t.LocalSSDInterface = fi.PtrTo("NVME")
+				}
+			}
+
 			// Use "user-data" instead of "startup-script", for compatibility with cloud-init
 			if startupScript != nil {
 				t.Metadata["user-data"] = startupScript
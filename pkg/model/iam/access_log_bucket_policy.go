@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/util/stringorslice"
+)
+
+// elbAccountIDs are the accounts that deliver Classic Load Balancer access logs, by region.
+// Regions that are not listed deliver the logs through the log delivery service principal.
+// https://docs.aws.amazon.com/elasticloadbalancing/latest/classic/enable-access-logs.html
+var elbAccountIDs = map[string]string{
+	"us-east-1":      "127311923021",
+	"us-east-2":      "033677994240",
+	"us-west-1":      "027434742980",
+	"us-west-2":      "797873946194",
+	"af-south-1":     "098369216593",
+	"ap-east-1":      "754344448648",
+	"ap-southeast-3": "589379963580",
+	"ap-south-1":     "718504428378",
+	"ap-northeast-3": "383597477331",
+	"ap-northeast-2": "600734575887",
+	"ap-southeast-1": "114774131450",
+	"ap-southeast-2": "783225319266",
+	"ap-northeast-1": "582318560864",
+	"ca-central-1":   "985666609251",
+	"eu-central-1":   "054676820928",
+	"eu-west-1":      "156460612806",
+	"eu-west-2":      "652711504416",
+	"eu-south-1":     "635631232127",
+	"eu-west-3":      "009996457667",
+	"eu-north-1":     "897822967062",
+	"me-south-1":     "076674570225",
+	"sa-east-1":      "507241528517",
+	"us-gov-west-1":  "048591011584",
+	"us-gov-east-1":  "190560391635",
+	"cn-north-1":     "638102146993",
+	"cn-northwest-1": "037604701340",
+}
+
+const (
+	// elbLogDeliveryService delivers Classic Load Balancer access logs in regions without an ELB account
+	elbLogDeliveryService = "logdelivery.elasticloadbalancing.amazonaws.com"
+	// logDeliveryService delivers Network Load Balancer access logs
+	logDeliveryService = "delivery.logs.amazonaws.com"
+)
+
+// AccessLogBucketPolicyStatements returns the bucket policy statements that allow
+// the API load balancer to deliver its access logs to the configured bucket.
+func AccessLogBucketPolicyStatements(class kops.LoadBalancerClass, accessLog *kops.AccessLogSpec, partition, region, accountID string) []*Statement {
+	if !accessLog.IsEnabled() || accessLog.Bucket == nil {
+		return nil
+	}
+
+	bucketARN := fmt.Sprintf("arn:%s:s3:::%s", partition, *accessLog.Bucket)
+	prefix := ""
+	if accessLog.BucketPrefix != nil && *accessLog.BucketPrefix != "" {
+		prefix = *accessLog.BucketPrefix + "/"
+	}
+	objectsARN := fmt.Sprintf("%s/%sAWSLogs/%s/*", bucketARN, prefix, accountID)
+
+	if class == kops.LoadBalancerClassNetwork {
+		return []*Statement{
+			{
+				Effect:    StatementEffectAllow,
+				Principal: Principal{Service: logDeliveryService},
+				Action:    stringorslice.String("s3:PutObject"),
+				Resource:  stringorslice.String(objectsARN),
+				Condition: Condition{
+					"StringEquals": map[string]string{
+						"s3:x-amz-acl":      "bucket-owner-full-control",
+						"aws:SourceAccount": accountID,
+					},
+				},
+			},
+			{
+				Effect:    StatementEffectAllow,
+				Principal: Principal{Service: logDeliveryService},
+				Action:    stringorslice.String("s3:GetBucketAcl"),
+				Resource:  stringorslice.String(bucketARN),
+				Condition: Condition{
+					"StringEquals": map[string]string{
+						"aws:SourceAccount": accountID,
+					},
+				},
+			},
+		}
+	}
+
+	principal := Principal{Service: elbLogDeliveryService}
+	if elbAccountID, found := elbAccountIDs[region]; found {
+		principal = Principal{AWS: fmt.Sprintf("arn:%s:iam::%s:root", partition, elbAccountID)}
+	}
+	return []*Statement{
+		{
+			Effect:    StatementEffectAllow,
+			Principal: principal,
+			Action:    stringorslice.String("s3:PutObject"),
+			Resource:  stringorslice.String(objectsARN),
+		},
+	}
+}
+
+// MergeBucketPolicy adds the statements that are missing from an existing bucket policy.
+// It returns the merged policy and the statements that were missing,
+// or an empty policy if nothing is missing. An empty existing policy is treated as having no statements.
+func MergeBucketPolicy(existing string, statements []*Statement) (string, []*Statement, error) {
+	policy := map[string]interface{}{}
+	if strings.TrimSpace(existing) != "" {
+		if err := json.Unmarshal([]byte(existing), &policy); err != nil {
+			return "", nil, fmt.Errorf("error parsing bucket policy: %w", err)
+		}
+	}
+
+	var existingStatements []interface{}
+	switch v := policy["Statement"].(type) {
+	case nil:
+	case []interface{}:
+		existingStatements = v
+	case map[string]interface{}:
+		existingStatements = []interface{}{v}
+	default:
+		return "", nil, fmt.Errorf("unexpected Statement in bucket policy: %v", v)
+	}
+
+	var missing []*Statement
+	for _, statement := range statements {
+		s, err := toGenericJSON(statement)
+		if err != nil {
+			return "", nil, err
+		}
+		found := false
+		for _, existingStatement := range existingStatements {
+			if statementsEquivalent(s, existingStatement) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, statement)
+			existingStatements = append(existingStatements, s)
+		}
+	}
+	if len(missing) == 0 {
+		return "", nil, nil
+	}
+
+	if _, found := policy["Version"]; !found {
+		policy["Version"] = PolicyDefaultVersion
+	}
+	policy["Statement"] = existingStatements
+
+	j, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", nil, fmt.Errorf("error marshaling bucket policy to JSON: %w", err)
+	}
+	return string(j), missing, nil
+}
+
+func toGenericJSON(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling to JSON: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+	return generic, nil
+}
+
+// statementsEquivalent compares two statements ignoring their Sid,
+// treating single values the same as single element lists and ignoring the order of lists.
+func statementsEquivalent(l, r interface{}) bool {
+	lm, ok := l.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	rm, ok := r.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(normalizePolicyValue(withoutSid(lm)), normalizePolicyValue(withoutSid(rm)))
+}
+
+func withoutSid(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k == "Sid" {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func normalizePolicyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			out[k] = normalizePolicyValue(value)
+		}
+		return out
+	case []interface{}:
+		var values []string
+		for _, value := range v {
+			s, ok := value.(string)
+			if !ok {
+				return v
+			}
+			values = append(values, s)
+		}
+		sort.Strings(values)
+		return values
+	case string:
+		return []string{v}
+	default:
+		return v
+	}
+}
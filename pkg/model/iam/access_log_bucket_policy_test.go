@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestAccessLogBucketPolicyStatements(t *testing.T) {
+	grid := []struct {
+		name      string
+		class     kops.LoadBalancerClass
+		region    string
+		accessLog *kops.AccessLogSpec
+		expected  string
+	}{
+		{
+			name:   "disabled",
+			class:  kops.LoadBalancerClassClassic,
+			region: "us-east-1",
+			accessLog: &kops.AccessLogSpec{
+				Enabled: fi.PtrTo(false),
+				Bucket:  fi.PtrTo("access-logs"),
+			},
+			expected: `null`,
+		},
+		{
+			name:   "classic with ELB account",
+			class:  kops.LoadBalancerClassClassic,
+			region: "us-east-1",
+			accessLog: &kops.AccessLogSpec{
+				Bucket:       fi.PtrTo("access-logs"),
+				BucketPrefix: fi.PtrTo("api"),
+			},
+			expected: `[{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::127311923021:root"},"Resource":"arn:aws:s3:::access-logs/api/AWSLogs/123456789012/*"}]`,
+		},
+		{
+			name:   "classic with log delivery service",
+			class:  kops.LoadBalancerClassClassic,
+			region: "ap-southeast-4",
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("access-logs"),
+			},
+			expected: `[{"Action":"s3:PutObject","Effect":"Allow","Principal":{"Service":"logdelivery.elasticloadbalancing.amazonaws.com"},"Resource":"arn:aws:s3:::access-logs/AWSLogs/123456789012/*"}]`,
+		},
+		{
+			name:   "network",
+			class:  kops.LoadBalancerClassNetwork,
+			region: "us-east-1",
+			accessLog: &kops.AccessLogSpec{
+				Bucket: fi.PtrTo("access-logs"),
+			},
+			expected: `[{"Action":"s3:PutObject","Condition":{"StringEquals":{"aws:SourceAccount":"123456789012","s3:x-amz-acl":"bucket-owner-full-control"}},"Effect":"Allow","Principal":{"Service":"delivery.logs.amazonaws.com"},"Resource":"arn:aws:s3:::access-logs/AWSLogs/123456789012/*"},` +
+				`{"Action":"s3:GetBucketAcl","Condition":{"StringEquals":{"aws:SourceAccount":"123456789012"}},"Effect":"Allow","Principal":{"Service":"delivery.logs.amazonaws.com"},"Resource":"arn:aws:s3:::access-logs"}]`,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			statements := AccessLogBucketPolicyStatements(g.class, g.accessLog, "aws", g.region, "123456789012")
+			actual, err := json.Marshal(statements)
+			if err != nil {
+				t.Fatalf("error marshaling statements: %v", err)
+			}
+			if string(actual) != g.expected {
+				t.Errorf("unexpected statements:\nexpected: %s\nactual:   %s", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergeBucketPolicy(t *testing.T) {
+	statements := AccessLogBucketPolicyStatements(kops.LoadBalancerClassClassic, &kops.AccessLogSpec{Bucket: fi.PtrTo("access-logs")}, "aws", "us-east-1", "123456789012")
+
+	grid := []struct {
+		name             string
+		existing         string
+		expectedMissing  int
+		expectedPolicy   string
+		expectedParseErr bool
+	}{
+		{
+			name:            "no policy",
+			existing:        "",
+			expectedMissing: 1,
+			expectedPolicy:  `{"Statement":[{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::127311923021:root"},"Resource":"arn:aws:s3:::access-logs/AWSLogs/123456789012/*"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name:            "unrelated statement",
+			existing:        `{"Version":"2012-10-17","Statement":{"Sid":"Other","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::access-logs/public/*"}}`,
+			expectedMissing: 1,
+			expectedPolicy:  `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"arn:aws:s3:::access-logs/public/*","Sid":"Other"},{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::127311923021:root"},"Resource":"arn:aws:s3:::access-logs/AWSLogs/123456789012/*"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name:     "already present",
+			existing: `{"Version":"2012-10-17","Statement":[{"Sid":"ELBLogs","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::127311923021:root"},"Action":["s3:PutObject"],"Resource":"arn:aws:s3:::access-logs/AWSLogs/123456789012/*"}]}`,
+		},
+		{
+			name:             "invalid policy",
+			existing:         `{`,
+			expectedParseErr: true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			policy, missing, err := MergeBucketPolicy(g.existing, statements)
+			if g.expectedParseErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(missing) != g.expectedMissing {
+				t.Errorf("expected %d missing statements, got %d", g.expectedMissing, len(missing))
+			}
+			if g.expectedPolicy == "" {
+				if policy != "" {
+					t.Errorf("expected no policy, got %s", policy)
+				}
+				return
+			}
+			var compacted map[string]interface{}
+			if err := json.Unmarshal([]byte(policy), &compacted); err != nil {
+				t.Fatalf("error parsing merged policy: %v", err)
+			}
+			actual, _ := json.Marshal(compacted)
+			if string(actual) != g.expectedPolicy {
+				t.Errorf("unexpected policy:\nexpected: %s\nactual:   %s", g.expectedPolicy, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// BootstrapPermission is an IAM action that an instance needs to be allowed to perform
+// on a resource in order to bootstrap and join the cluster.
+type BootstrapPermission struct {
+	Action   string
+	Resource string
+}
+
+// BootstrapPermissions returns the minimal set of permissions that instances with the given role
+// need in order to join the cluster. It is used to verify instance profiles that are managed
+// outside of kOps, and intentionally excludes permissions needed only by optional addons.
+//
+//   - all roles except bastions describe their instance and region during nodeup
+//   - control-plane instances additionally attach and discover etcd volumes
+//   - roles that read the state store need s3:GetObject on the readable state paths
+func BootstrapPermissions(cluster *kops.Cluster, role kops.InstanceGroupRole, partition string) ([]BootstrapPermission, error) {
+	var subject Subject
+	switch role {
+	case kops.InstanceGroupRoleControlPlane:
+		subject = &NodeRoleMaster{}
+	case kops.InstanceGroupRoleAPIServer:
+		subject = &NodeRoleAPIServer{}
+	case kops.InstanceGroupRoleNode:
+		subject = &NodeRoleNode{}
+	case kops.InstanceGroupRoleBastion:
+		// Bastion hosts currently don't require any specific permissions.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown instance group role %q", role)
+	}
+
+	var permissions []BootstrapPermission
+	for _, action := range []string{"ec2:DescribeInstances", "ec2:DescribeInstanceTypes", "ec2:DescribeRegions"} {
+		permissions = append(permissions, BootstrapPermission{Action: action, Resource: "*"})
+	}
+
+	if role == kops.InstanceGroupRoleControlPlane {
+		for _, action := range []string{"ec2:AttachVolume", "ec2:DescribeVolumes"} {
+			permissions = append(permissions, BootstrapPermission{Action: action, Resource: "*"})
+		}
+	}
+
+	if role == kops.InstanceGroupRoleNode && cluster.UsesNoneDNS() {
+		return permissions, nil
+	}
+
+	statePaths, err := ReadableStatePaths(cluster, subject)
+	if err != nil {
+		return nil, err
+	}
+	if len(statePaths) == 0 || cluster.Spec.ConfigStore.Base == "" {
+		return permissions, nil
+	}
+
+	configBase, err := vfs.Context.BuildVfsPath(cluster.Spec.ConfigStore.Base)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse VFS path %q: %v", cluster.Spec.ConfigStore.Base, err)
+	}
+	if s3Path, ok := configBase.(*vfs.S3Path); ok {
+		for _, statePath := range statePaths {
+			key := strings.TrimPrefix(strings.TrimSuffix(s3Path.Key(), "/")+statePath, "/")
+			permissions = append(permissions, BootstrapPermission{
+				Action:   "s3:GetObject",
+				Resource: fmt.Sprintf("arn:%s:s3:::%s/%s", partition, s3Path.Bucket(), key),
+			})
+		}
+	}
+
+	return permissions, nil
+}
@@ -278,6 +278,7 @@ func (s *Statement) MarshalJSON() ([]byte, error) {
 }
 
 type Principal struct {
+	AWS       string `json:",omitempty"`
 	Federated string `json:",omitempty"`
 	Service   string `json:",omitempty"`
 }
@@ -364,7 +365,7 @@ func (r *NodeRoleAPIServer) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	}
 
 	if b.Cluster.Spec.Networking.AmazonVPC != nil {
-		addAmazonVPCCNIPermissions(p)
+		addAmazonVPCCNIPermissions(p, b.Cluster.Spec.Networking.AmazonVPC)
 	}
 
 	if b.Cluster.Spec.Networking.Cilium != nil && b.Cluster.Spec.Networking.Cilium.IPAM == kops.CiliumIpamEni {
@@ -391,6 +392,10 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 		addKopsControllerIPAMPermissions(p)
 	}
 
+	if b.Cluster.Spec.API.PublicIPs != nil {
+		addKopsControllerElasticIPPermissions(p)
+	}
+
 	var err error
 	if p, err = b.AddS3Permissions(p); err != nil {
 		return nil, fmt.Errorf("failed to generate AWS IAM S3 access statements: %v", err)
@@ -404,7 +409,7 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	if !b.UseServiceAccountExternalPermisssions {
 		esc := b.Cluster.Spec.SnapshotController != nil &&
 			fi.ValueOf(b.Cluster.Spec.SnapshotController.Enabled)
-		AddAWSEBSCSIDriverPermissions(p, esc)
+		AddAWSEBSCSIDriverPermissions(p, esc, b.Cluster.Spec.Snapshots != nil)
 
 		AddCCMPermissions(p, b.Cluster.Spec.Networking.Kubenet != nil)
 
@@ -429,7 +434,7 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	}
 
 	if b.Cluster.Spec.Networking.AmazonVPC != nil {
-		addAmazonVPCCNIPermissions(p)
+		addAmazonVPCCNIPermissions(p, b.Cluster.Spec.Networking.AmazonVPC)
 	}
 
 	if b.Cluster.Spec.Networking.Cilium != nil && b.Cluster.Spec.Networking.Cilium.IPAM == kops.CiliumIpamEni {
@@ -461,7 +466,7 @@ func (r *NodeRoleNode) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	}
 
 	if b.Cluster.Spec.Networking.AmazonVPC != nil {
-		addAmazonVPCCNIPermissions(p)
+		addAmazonVPCCNIPermissions(p, b.Cluster.Spec.Networking.AmazonVPC)
 	}
 
 	if b.Cluster.Spec.Networking.Calico != nil && b.Cluster.Spec.Networking.Calico.AWSSrcDstCheck != "DoNothing" && !b.Cluster.Spec.IsIPv6Only() {
@@ -786,6 +791,13 @@ func (b *PolicyBuilder) addNodeupPermissions(p *Policy, enableHookSupport bool)
 	}
 }
 
+func addKopsControllerElasticIPPermissions(p *Policy) {
+	p.unconditionalAction.Insert(
+		"ec2:DescribeAddresses",
+		"ec2:AssociateAddress",
+	)
+}
+
 func addKopsControllerIPAMPermissions(p *Policy) {
 	p.unconditionalAction.Insert(
 		"ec2:DescribeNetworkInterfaces",
@@ -1000,13 +1012,10 @@ func AddClusterAutoscalerPermissions(p *Policy, useStaticInstanceList bool) {
 }
 
 // AddAWSEBSCSIDriverPermissions appens policy statements that the AWS EBS CSI Driver needs to operate.
-func AddAWSEBSCSIDriverPermissions(p *Policy, appendSnapshotPermissions bool) {
+// If clusterVolumeSnapshotsOnly is true, only volumes tagged with the cluster name can be snapshotted.
+func AddAWSEBSCSIDriverPermissions(p *Policy, appendSnapshotPermissions bool, clusterVolumeSnapshotsOnly bool) {
 	addKMSIAMPolicies(p)
 
-	if appendSnapshotPermissions {
-		addSnapshotPersmissions(p)
-	}
-
 	p.unconditionalAction.Insert(
 		"ec2:DescribeAccountAttributes",    // aws.go
 		"ec2:DescribeInstances",            // aws.go
@@ -1032,17 +1041,52 @@ func AddAWSEBSCSIDriverPermissions(p *Policy, appendSnapshotPermissions bool) {
 			"snapshot",
 		},
 	)
+
+	if appendSnapshotPermissions {
+		addSnapshotPersmissions(p, clusterVolumeSnapshotsOnly)
+	}
 }
 
-func addSnapshotPersmissions(p *Policy) {
+func addSnapshotPersmissions(p *Policy, clusterVolumesOnly bool) {
 	p.unconditionalAction.Insert(
-		"ec2:CreateSnapshot",
 		"ec2:DescribeAvailabilityZones",
 		"ec2:DescribeSnapshots",
 	)
 	p.clusterTaggedAction.Insert(
 		"ec2:DeleteSnapshot",
 	)
+
+	if !clusterVolumesOnly {
+		p.unconditionalAction.Insert("ec2:CreateSnapshot")
+		return
+	}
+
+	// CreateSnapshot is authorized against both the source volume and the new snapshot,
+	// so the request tag condition that allows it on any resource would also allow any volume.
+	// Instead, the volume must belong to the cluster and the snapshot must be created with the cluster tag.
+	p.clusterTaggedCreateAction.Delete("ec2:CreateSnapshot")
+	p.Statement = append(p.Statement,
+		&Statement{
+			Effect:   StatementEffectAllow,
+			Action:   stringorslice.String("ec2:CreateSnapshot"),
+			Resource: stringorslice.String(fmt.Sprintf("arn:%s:ec2:*:*:volume/*", p.partition)),
+			Condition: Condition{
+				"StringEquals": map[string]string{
+					"aws:ResourceTag/KubernetesCluster": p.clusterName,
+				},
+			},
+		},
+		&Statement{
+			Effect:   StatementEffectAllow,
+			Action:   stringorslice.String("ec2:CreateSnapshot"),
+			Resource: stringorslice.String(fmt.Sprintf("arn:%s:ec2:*::snapshot/*", p.partition)),
+			Condition: Condition{
+				"StringEquals": map[string]string{
+					"aws:RequestTag/KubernetesCluster": p.clusterName,
+				},
+			},
+		},
+	)
 }
 
 // AddDNSControllerPermissions adds IAM permissions used by the dns-controller.
@@ -1053,17 +1097,53 @@ func AddDNSControllerPermissions(b *PolicyBuilder, p *Policy) {
 		return
 	}
 
+	addRoute53Permissions(b, p, hostedZoneResource(b))
+}
+
+// AddDNSServiceAccountPermissions adds IAM permissions used by the service account of the dns-controller or external-dns.
+// Unlike the instance role, the service account always needs Route53 permissions,
+// so we fall back to all hosted zones if the hosted zone of the cluster cannot be resolved.
+func AddDNSServiceAccountPermissions(b *PolicyBuilder, p *Policy) {
+	if !b.Cluster.PublishesDNSRecords() {
+		return
+	}
+
+	if b.HostedZoneID == "" && policyScope(b) != kops.ExternalDNSPolicyScopeAllZones {
+		klog.Warningf("unable to resolve the hosted zone of cluster %q, allowing DNS changes to all hosted zones", b.Cluster.ObjectMeta.Name)
+	}
+
+	addRoute53Permissions(b, p, hostedZoneResource(b))
+}
+
+// policyScope returns the scope of the Route53 permissions, as configured in the cluster spec.
+func policyScope(b *PolicyBuilder) kops.ExternalDNSPolicyScope {
+	if b.Cluster.Spec.ExternalDNS == nil || b.Cluster.Spec.ExternalDNS.PolicyScope == "" {
+		return kops.ExternalDNSPolicyScopeClusterZone
+	}
+	return b.Cluster.Spec.ExternalDNS.PolicyScope
+}
+
+// hostedZoneResource returns the ARN of the hosted zones the DNS controller is allowed to change.
+func hostedZoneResource(b *PolicyBuilder) string {
+	if b.HostedZoneID == "" || policyScope(b) == kops.ExternalDNSPolicyScopeAllZones {
+		return fmt.Sprintf("arn:%v:route53:::hostedzone/*", b.Partition)
+	}
+
 	// TODO: Route53 currently not supported in China, need to check and fail/return
 	// Remove /hostedzone/ prefix (if present)
 	hostedZoneID := strings.TrimPrefix(b.HostedZoneID, "/")
 	hostedZoneID = strings.TrimPrefix(hostedZoneID, "hostedzone/")
 
+	return fmt.Sprintf("arn:%v:route53:::hostedzone/%v", b.Partition, hostedZoneID)
+}
+
+func addRoute53Permissions(b *PolicyBuilder, p *Policy, hostedZoneResource string) {
 	p.Statement = append(p.Statement, &Statement{
 		Effect: StatementEffectAllow,
 		Action: stringorslice.Of("route53:ChangeResourceRecordSets",
 			"route53:ListResourceRecordSets",
 			"route53:GetHostedZone"),
-		Resource: stringorslice.Slice([]string{fmt.Sprintf("arn:%v:route53:::hostedzone/%v", b.Partition, hostedZoneID)}),
+		Resource: stringorslice.Slice([]string{hostedZoneResource}),
 	})
 
 	p.Statement = append(p.Statement, &Statement{
@@ -1147,7 +1227,18 @@ func addCiliumEniPermissions(p *Policy) {
 	)
 }
 
-func addAmazonVPCCNIPermissions(p *Policy) {
+func addAmazonVPCCNIPermissions(p *Policy, amazonVPC *kops.AmazonVPCNetworkingSpec) {
+	if agent := amazonVPC.NetworkPolicyAgent; agent != nil && fi.ValueOf(agent.Enabled) && fi.ValueOf(agent.EnableCloudWatchLogs) {
+		// The network policy agent sends the policy decision logs to CloudWatch Logs
+		p.unconditionalAction.Insert(
+			"logs:CreateLogGroup",
+			"logs:CreateLogStream",
+			"logs:DescribeLogGroups",
+			"logs:DescribeLogStreams",
+			"logs:PutLogEvents",
+		)
+	}
+
 	p.unconditionalAction.Insert(
 		"ec2:AssignPrivateIpAddresses",
 		"ec2:AttachNetworkInterface",
@@ -18,6 +18,9 @@ package iam
 
 import (
 	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -100,6 +103,9 @@ func TestPolicyGeneration(t *testing.T) {
 		Gossip                 bool
 		Role                   Subject
 		AllowContainerRegistry bool
+		Snapshots              bool
+		HostedZoneID           string
+		PolicyScope            kops.ExternalDNSPolicyScope
 		Policy                 string
 	}{
 		{
@@ -124,6 +130,22 @@ func TestPolicyGeneration(t *testing.T) {
 			AllowContainerRegistry: true,
 			Policy:                 "tests/iam_builder_master_gossip_ecr.json",
 		},
+		{
+			Role:      &NodeRoleMaster{},
+			Snapshots: true,
+			Policy:    "tests/iam_builder_master_snapshots.json",
+		},
+		{
+			Role:         &NodeRoleMaster{},
+			HostedZoneID: "/hostedzone/Z1234567890ABC",
+			Policy:       "tests/iam_builder_master_hosted_zone.json",
+		},
+		{
+			Role:         &NodeRoleMaster{},
+			HostedZoneID: "/hostedzone/Z1234567890ABC",
+			PolicyScope:  kops.ExternalDNSPolicyScopeAllZones,
+			Policy:       "tests/iam_builder_master_hosted_zone_all_zones.json",
+		},
 		{
 			Role:                   &NodeRoleNode{},
 			AllowContainerRegistry: false,
@@ -215,8 +237,16 @@ func TestPolicyGeneration(t *testing.T) {
 					},
 				},
 			},
-			Role:      x.Role,
-			Partition: "aws-test",
+			Role:         x.Role,
+			Partition:    "aws-test",
+			HostedZoneID: x.HostedZoneID,
+		}
+		if x.PolicyScope != "" {
+			b.Cluster.Spec.ExternalDNS = &kops.ExternalDNSConfig{PolicyScope: x.PolicyScope}
+		}
+		if x.Snapshots {
+			b.Cluster.Spec.SnapshotController = &kops.SnapshotControllerConfig{Enabled: fi.PtrTo(true)}
+			b.Cluster.Spec.Snapshots = &kops.SnapshotsSpec{}
 		}
 		if x.Gossip {
 			b.Cluster.SetName("iam-builder-test.k8s.local")
@@ -240,6 +270,56 @@ func TestPolicyGeneration(t *testing.T) {
 	}
 }
 
+func TestDNSServiceAccountPermissions(t *testing.T) {
+	grid := []struct {
+		HostedZoneID string
+		PolicyScope  kops.ExternalDNSPolicyScope
+		Expected     string
+	}{
+		{
+			HostedZoneID: "/hostedzone/Z1234567890ABC",
+			Expected:     "arn:aws-test:route53:::hostedzone/Z1234567890ABC",
+		},
+		{
+			HostedZoneID: "Z1234567890ABC",
+			PolicyScope:  kops.ExternalDNSPolicyScopeClusterZone,
+			Expected:     "arn:aws-test:route53:::hostedzone/Z1234567890ABC",
+		},
+		{
+			HostedZoneID: "/hostedzone/Z1234567890ABC",
+			PolicyScope:  kops.ExternalDNSPolicyScopeAllZones,
+			Expected:     "arn:aws-test:route53:::hostedzone/*",
+		},
+		{
+			HostedZoneID: "",
+			Expected:     "arn:aws-test:route53:::hostedzone/*",
+		},
+	}
+
+	for _, x := range grid {
+		cluster := testutils.BuildMinimalCluster("dns.example.com")
+		if x.PolicyScope != "" {
+			cluster.Spec.ExternalDNS = &kops.ExternalDNSConfig{PolicyScope: x.PolicyScope}
+		}
+		b := &PolicyBuilder{
+			Cluster:      cluster,
+			Partition:    "aws-test",
+			HostedZoneID: x.HostedZoneID,
+		}
+		p := NewPolicy(cluster.ObjectMeta.Name, b.Partition)
+		AddDNSServiceAccountPermissions(b, p)
+
+		if len(p.Statement) == 0 {
+			t.Errorf("expected Route53 statements for hosted zone %q", x.HostedZoneID)
+			continue
+		}
+		resource := p.Statement[0].Resource.Value()
+		if len(resource) != 1 || resource[0] != x.Expected {
+			t.Errorf("unexpected hosted zone resource for hosted zone %q and scope %q: expected %q, got %v", x.HostedZoneID, x.PolicyScope, x.Expected, resource)
+		}
+	}
+}
+
 func TestEmptyPolicy(t *testing.T) {
 	role := &GenericServiceAccount{
 		NamespacedName: types.NamespacedName{
@@ -268,3 +348,204 @@ func TestEmptyPolicy(t *testing.T) {
 		t.Errorf("empty policy should result in empty string, but was %q", policy)
 	}
 }
+
+// TestAWSLoadBalancerControllerPolicyScoping checks that the only EC2 and ELB actions that are
+// granted on all resources without a condition are read-only ones.
+func TestAWSLoadBalancerControllerPolicyScoping(t *testing.T) {
+	p := NewPolicy("scoping.example.com", "aws")
+	AddAWSLoadbalancerControllerPermissions(p, false, false, false)
+
+	if _, err := p.AsJSON(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, statement := range p.Statement {
+		if len(statement.Condition) != 0 || len(statement.Resource.Value()) != 1 || statement.Resource.Value()[0] != "*" {
+			continue
+		}
+		for _, action := range statement.Action.Value() {
+			service, name, _ := strings.Cut(action, ":")
+			if service != "ec2" && service != "elasticloadbalancing" {
+				continue
+			}
+			if !strings.HasPrefix(name, "Describe") {
+				t.Errorf("action %q is granted on all resources without a cluster tag condition", action)
+			}
+		}
+	}
+}
+
+// simulatedRequest is an API request for simulatePolicy.
+type simulatedRequest struct {
+	Action string
+	// Resources are the ARNs the request is authorized against, with their tags
+	Resources   map[string]map[string]string
+	RequestTags map[string]string
+	// CreateAction is the action that creates the resource being tagged, for ec2:CreateTags
+	CreateAction string
+}
+
+// simulatePolicy returns true if the policy allows the action on every resource of the request.
+// It supports the subset of the IAM policy language that kOps generates.
+func simulatePolicy(t *testing.T, policyJSON string, request simulatedRequest) bool {
+	t.Helper()
+
+	var policy struct {
+		Statement []struct {
+			Effect    string
+			Action    stringorslice.StringOrSlice
+			Resource  stringorslice.StringOrSlice
+			Condition map[string]map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+		t.Fatalf("parsing policy: %v", err)
+	}
+
+	matches := func(patterns []string, value string) bool {
+		for _, pattern := range patterns {
+			expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+			if regexp.MustCompile(expression).MatchString(value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for arn, resourceTags := range request.Resources {
+		contextValue := func(key string) (string, bool) {
+			if tag, found := strings.CutPrefix(key, "aws:ResourceTag/"); found {
+				v, ok := resourceTags[tag]
+				return v, ok
+			}
+			if tag, found := strings.CutPrefix(key, "aws:RequestTag/"); found {
+				v, ok := request.RequestTags[tag]
+				return v, ok
+			}
+			if key == "ec2:CreateAction" {
+				return request.CreateAction, request.CreateAction != ""
+			}
+			t.Fatalf("unsupported condition key %q", key)
+			return "", false
+		}
+
+		allowed := false
+		for _, statement := range policy.Statement {
+			if statement.Effect != "Allow" || !matches(statement.Action.Value(), request.Action) || !matches(statement.Resource.Value(), arn) {
+				continue
+			}
+			conditionsMet := true
+			for operator, conditions := range statement.Condition {
+				for key, expected := range conditions {
+					actual, present := contextValue(key)
+					switch operator {
+					case "StringEquals":
+						var values []string
+						switch expected := expected.(type) {
+						case string:
+							values = []string{expected}
+						case []interface{}:
+							for _, v := range expected {
+								values = append(values, v.(string))
+							}
+						}
+						if !present || !slices.Contains(values, actual) {
+							conditionsMet = false
+						}
+					case "Null":
+						if (expected == "true") == present {
+							conditionsMet = false
+						}
+					default:
+						t.Fatalf("unsupported condition operator %q", operator)
+					}
+				}
+			}
+			if conditionsMet {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSnapshotPermissions(t *testing.T) {
+	clusterName := "snapshots.example.com"
+	clusterVolume := map[string]string{"KubernetesCluster": clusterName}
+	otherVolume := map[string]string{"KubernetesCluster": "other.example.com"}
+	clusterTags := map[string]string{"KubernetesCluster": clusterName}
+
+	grid := []struct {
+		name               string
+		clusterVolumesOnly bool
+		volumeTags         map[string]string
+		requestTags        map[string]string
+		expected           bool
+	}{
+		{
+			name:               "cluster volume",
+			clusterVolumesOnly: true,
+			volumeTags:         clusterVolume,
+			requestTags:        clusterTags,
+			expected:           true,
+		},
+		{
+			name:               "volume of another cluster",
+			clusterVolumesOnly: true,
+			volumeTags:         otherVolume,
+			requestTags:        clusterTags,
+		},
+		{
+			name:               "snapshot without cluster tag",
+			clusterVolumesOnly: true,
+			volumeTags:         clusterVolume,
+		},
+		{
+			name:        "any volume without the restriction",
+			volumeTags:  otherVolume,
+			requestTags: clusterTags,
+			expected:    true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			p := NewPolicy(clusterName, "aws")
+			AddAWSEBSCSIDriverPermissions(p, true, g.clusterVolumesOnly)
+
+			policyJSON, err := p.AsJSON()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			request := simulatedRequest{
+				Action: "ec2:CreateSnapshot",
+				Resources: map[string]map[string]string{
+					"arn:aws:ec2:us-east-1:123456789012:volume/vol-1234": g.volumeTags,
+					"arn:aws:ec2:us-east-1::snapshot/snap-1234":          nil,
+				},
+				RequestTags: g.requestTags,
+			}
+			if allowed := simulatePolicy(t, policyJSON, request); allowed != g.expected {
+				t.Errorf("expected CreateSnapshot allowed to be %v, got %v", g.expected, allowed)
+			}
+
+			// The snapshot is tagged as part of its creation
+			tagRequest := simulatedRequest{
+				Action: "ec2:CreateTags",
+				Resources: map[string]map[string]string{
+					"arn:aws:ec2:us-east-1::snapshot/snap-1234": nil,
+				},
+				RequestTags:  clusterTags,
+				CreateAction: "CreateSnapshot",
+			}
+			if !simulatePolicy(t, policyJSON, tagRequest) {
+				t.Errorf("expected tagging the new snapshot to be allowed")
+			}
+		})
+	}
+}
@@ -25,7 +25,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"sort"
+	"strings"
 
 	"gopkg.in/square/go-jose.v2"
 	"k8s.io/klog/v2"
@@ -72,14 +74,39 @@ func (b *IssuerDiscoveryModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		SigningKey: skTask,
 	}
 
-	discovery, err := buildDiscoveryJSON(*b.Cluster.Spec.KubeAPIServer.ServiceAccountIssuer)
+	if err := b.publishDiscovery(ctx, c, keys, said.DiscoveryStore, *b.Cluster.Spec.KubeAPIServer.ServiceAccountIssuer, ""); err != nil {
+		return err
+	}
+
+	// Keep publishing the documents of the issuers that are accepted during a migration,
+	// so that the tokens they issued can still be verified.
+	for i, issuer := range b.Cluster.Spec.KubeAPIServer.AdditionalServiceAccountIssuers {
+		discoveryStorePath, ok := discoveryStoreForIssuer(issuer)
+		if !ok {
+			klog.Infof("not publishing the discovery documents of service account issuer %q, which is not served from a bucket", issuer)
+			continue
+		}
+		if discoveryStorePath == strings.TrimSuffix(said.DiscoveryStore, "/") {
+			klog.Warningf("service account issuer %q is served from the discovery store of the primary issuer; tokens issued by it can no longer be verified", issuer)
+			continue
+		}
+		if err := b.publishDiscovery(ctx, c, keys, discoveryStorePath, issuer, fmt.Sprintf("additional-issuer-%d-", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishDiscovery publishes the discovery documents of an issuer in a discovery store.
+func (b *IssuerDiscoveryModelBuilder) publishDiscovery(ctx context.Context, c *fi.CloudupModelBuilderContext, keys *OIDCKeys, discoveryStorePath string, issuer string, namePrefix string) error {
+	discovery, err := buildDiscoveryJSON(issuer)
 	if err != nil {
 		return err
 	}
 
 	var publicFileACL *bool
 
-	discoveryStorePath := b.Cluster.Spec.ServiceAccountIssuerDiscovery.DiscoveryStore
 	discoveryStore, err := vfs.Context.BuildVfsPath(discoveryStorePath)
 	if err != nil {
 		return fmt.Errorf("building VFS path for %q: %w", discoveryStorePath, err)
@@ -91,7 +118,7 @@ func (b *IssuerDiscoveryModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		if err != nil {
 			return err
 		}
-		if discoveryStoreURL == fi.ValueOf(b.Cluster.Spec.KubeAPIServer.ServiceAccountIssuer) {
+		if discoveryStoreURL == issuer {
 			// Using Amazon S3 static website hosting requires public access
 			isPublic, err := discoveryStore.IsBucketPublic(ctx)
 			if err != nil {
@@ -109,7 +136,7 @@ func (b *IssuerDiscoveryModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		if err != nil {
 			return err
 		}
-		if discoveryStoreURL == fi.ValueOf(b.Cluster.Spec.KubeAPIServer.ServiceAccountIssuer) {
+		if discoveryStoreURL == issuer {
 			// Using Google Cloud Storage requires public access
 			isPublic, err := discoveryStore.IsBucketPublic(ctx)
 			if err != nil {
@@ -134,7 +161,7 @@ func (b *IssuerDiscoveryModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		Contents:  keys,
 		Lifecycle: b.Lifecycle,
 		Location:  fi.PtrTo("openid/v1/jwks"),
-		Name:      fi.PtrTo("keys.json"),
+		Name:      fi.PtrTo(namePrefix + "keys.json"),
 		Base:      fi.PtrTo(discoveryStorePath),
 		PublicACL: publicFileACL,
 	}
@@ -144,7 +171,7 @@ func (b *IssuerDiscoveryModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		Contents:  fi.NewBytesResource(discovery),
 		Lifecycle: b.Lifecycle,
 		Location:  fi.PtrTo(".well-known/openid-configuration"),
-		Name:      fi.PtrTo("discovery.json"),
+		Name:      fi.PtrTo(namePrefix + "discovery.json"),
 		Base:      fi.PtrTo(discoveryStorePath),
 		PublicACL: publicFileACL,
 	}
@@ -153,6 +180,39 @@ func (b *IssuerDiscoveryModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 	return nil
 }
 
+// discoveryStoreForIssuer returns the discovery store that serves the documents of an issuer,
+// if the issuer is the URL of an S3 or Google Cloud Storage bucket.
+func discoveryStoreForIssuer(issuer string) (string, bool) {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Scheme != "https" {
+		return "", false
+	}
+	key := strings.Trim(u.Path, "/")
+
+	host := u.Host
+	switch {
+	case host == "storage.googleapis.com":
+		bucket, key, _ := strings.Cut(key, "/")
+		if bucket == "" {
+			return "", false
+		}
+		return strings.TrimSuffix("gs://"+bucket+"/"+key, "/"), true
+
+	case strings.HasPrefix(host, "s3.dualstack.") && strings.HasSuffix(host, ".amazonaws.com"):
+		bucket, key, _ := strings.Cut(key, "/")
+		if bucket == "" {
+			return "", false
+		}
+		return strings.TrimSuffix("s3://"+bucket+"/"+key, "/"), true
+
+	case strings.Contains(host, ".s3.") && strings.HasSuffix(host, ".amazonaws.com"):
+		bucket, _, _ := strings.Cut(host, ".s3.")
+		return strings.TrimSuffix("s3://"+bucket+"/"+key, "/"), true
+	}
+
+	return "", false
+}
+
 func buildDiscoveryJSON(issuerURL string) ([]byte, error) {
 	d := oidcDiscovery{
 		Issuer:                issuerURL,
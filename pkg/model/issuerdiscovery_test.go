@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+)
+
+func Test_DiscoveryStoreForIssuer(t *testing.T) {
+	grid := []struct {
+		issuer   string
+		expected string
+	}{
+		{
+			issuer:   "https://discovery-bucket.s3.us-east-1.amazonaws.com/cluster.example.com",
+			expected: "s3://discovery-bucket/cluster.example.com",
+		},
+		{
+			issuer:   "https://discovery-bucket.s3.us-east-1.amazonaws.com",
+			expected: "s3://discovery-bucket",
+		},
+		{
+			issuer:   "https://s3.dualstack.us-east-1.amazonaws.com/discovery-bucket/cluster.example.com",
+			expected: "s3://discovery-bucket/cluster.example.com",
+		},
+		{
+			issuer:   "https://storage.googleapis.com/discovery-bucket/cluster.example.com",
+			expected: "gs://discovery-bucket/cluster.example.com",
+		},
+		{
+			issuer: "https://api.internal.cluster.example.com",
+		},
+		{
+			issuer: "https://storage.googleapis.com",
+		},
+		{
+			issuer: "http://discovery-bucket.s3.us-east-1.amazonaws.com/cluster.example.com",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.issuer, func(t *testing.T) {
+			actual, ok := discoveryStoreForIssuer(g.issuer)
+			if ok != (g.expected != "") {
+				t.Fatalf("unexpected result for %q: %q, %v", g.issuer, actual, ok)
+			}
+			if actual != g.expected {
+				t.Errorf("expected %q, got %q", g.expected, actual)
+			}
+		})
+	}
+}
@@ -52,6 +52,17 @@ func (b *KopsModelContext) LinkToSecurityGroup(role kops.InstanceGroupRole) *aws
 	return &awstasks.SecurityGroup{Name: &name}
 }
 
+// NodePortSecurityGroupName returns the name of the security group that opens the node ports of an instance group
+func (b *KopsModelContext) NodePortSecurityGroupName(ig *kops.InstanceGroup) string {
+	return "nodeport-" + ig.ObjectMeta.Name + "." + b.ClusterName()
+}
+
+// LinkToNodePortSecurityGroup creates a task link to the node port security group of an instance group
+func (b *KopsModelContext) LinkToNodePortSecurityGroup(ig *kops.InstanceGroup) *awstasks.SecurityGroup {
+	name := b.NodePortSecurityGroupName(ig)
+	return &awstasks.SecurityGroup{Name: &name}
+}
+
 // AutoscalingGroupName derives the autoscaling group name for us
 func (b *KopsModelContext) AutoscalingGroupName(ig *kops.InstanceGroup) string {
 	switch ig.Spec.Role {
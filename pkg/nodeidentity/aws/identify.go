@@ -19,6 +19,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,6 +45,9 @@ const (
 	// The expiration time of nodeidentity.Info cache.
 	cacheTTL           = 60 * time.Minute
 	KarpenterNodeLabel = "karpenter.sh/"
+	// PlacementPartitionLabel is the node label holding the partition of the node in a partition placement group,
+	// so that topology spread constraints can spread pods across partitions.
+	PlacementPartitionLabel = "kops.k8s.io/placement-partition"
 )
 
 // nodeIdentifier identifies a node from EC2
@@ -141,6 +145,9 @@ func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*
 	if instance.InstanceLifecycle != nil {
 		labels[fmt.Sprintf("node-role.kubernetes.io/%s-worker", *instance.InstanceLifecycle)] = "true"
 	}
+	if instance.Placement != nil && instance.Placement.PartitionNumber != nil {
+		labels[PlacementPartitionLabel] = strconv.FormatInt(*instance.Placement.PartitionNumber, 10)
+	}
 
 	info := &nodeidentity.Info{
 		InstanceID: instanceID,
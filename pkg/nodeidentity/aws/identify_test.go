@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// stubEC2 returns a single instance from DescribeInstances
+type stubEC2 struct {
+	ec2iface.EC2API
+
+	instance *ec2.Instance
+}
+
+func (s *stubEC2) DescribeInstances(request *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{s.instance}}},
+	}, nil
+}
+
+func TestIdentifyNodeLabels(t *testing.T) {
+	grid := []struct {
+		name     string
+		instance *ec2.Instance
+		expected map[string]string
+	}{
+		{
+			name: "on-demand",
+			instance: &ec2.Instance{
+				Placement: &ec2.Placement{AvailabilityZone: aws.String("us-test-1a")},
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "spot with node template labels",
+			instance: &ec2.Instance{
+				InstanceLifecycle: aws.String("spot"),
+				Tags: []*ec2.Tag{
+					{Key: aws.String(ClusterAutoscalerNodeTemplateLabel + "example.com/pool"), Value: aws.String("blue")},
+					{Key: aws.String(CloudTagInstanceGroupName), Value: aws.String("nodes")},
+				},
+			},
+			expected: map[string]string{
+				"node-role.kubernetes.io/spot-worker": "true",
+				"example.com/pool":                    "blue",
+			},
+		},
+		{
+			name: "partition placement group",
+			instance: &ec2.Instance{
+				Placement: &ec2.Placement{
+					AvailabilityZone: aws.String("us-test-1a"),
+					GroupName:        aws.String("nodes.minimal.example.com"),
+					PartitionNumber:  aws.Int64(3),
+				},
+			},
+			expected: map[string]string{
+				PlacementPartitionLabel: "3",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			g.instance.InstanceId = aws.String("i-0123456789abcdef0")
+			g.instance.State = &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)}
+
+			identifier := &nodeIdentifier{ec2Client: &stubEC2{instance: g.instance}}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec:       corev1.NodeSpec{ProviderID: "aws:///us-test-1a/i-0123456789abcdef0"},
+			}
+
+			info, err := identifier.IdentifyNode(context.TODO(), node)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(info.Labels, g.expected) {
+				t.Errorf("unexpected labels: expected %v, got %v", g.expected, info.Labels)
+			}
+		})
+	}
+}
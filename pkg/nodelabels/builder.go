@@ -29,6 +29,9 @@ const (
 	RoleLabelNode16      = "node-role.kubernetes.io/node"
 
 	RoleLabelControlPlane20 = "node-role.kubernetes.io/control-plane"
+
+	// LabelMIGConfig is the label selecting the mig-parted configuration of the GPUs of a node
+	LabelMIGConfig = "nvidia.com/mig.config"
 )
 
 // BuildNodeLabels returns the node labels for the specified instance group
@@ -93,6 +96,13 @@ func BuildNodeLabels(cluster *api.Cluster, instanceGroup *api.InstanceGroup) (ma
 		}
 	}
 
+	if instanceGroup.Spec.GPUConfig != nil && instanceGroup.Spec.GPUConfig.MIGProfile != "" {
+		if nodeLabels == nil {
+			nodeLabels = make(map[string]string)
+		}
+		nodeLabels[LabelMIGConfig] = instanceGroup.Spec.GPUConfig.MIGConfigName()
+	}
+
 	for k, v := range instanceGroup.Spec.NodeLabels {
 		if nodeLabels == nil {
 			nodeLabels = make(map[string]string)
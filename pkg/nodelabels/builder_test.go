@@ -108,6 +108,26 @@ func TestBuildNodeLabels(t *testing.T) {
 				"node3":         "override3",
 			},
 		},
+		{
+			name: "MIGProfile",
+			cluster: &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					KubernetesVersion: "v1.24.0",
+				},
+			},
+			ig: &kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					Role: kops.InstanceGroupRoleNode,
+					GPUConfig: &kops.GPUConfigSpec{
+						MIGProfile: "1g.10gb",
+					},
+				},
+			},
+			expected: map[string]string{
+				RoleLabelNode16: "",
+				LabelMIGConfig:  "all-1g.10gb",
+			},
+		},
 	}
 
 	for _, test := range tests {
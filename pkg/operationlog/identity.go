@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operationlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/user"
+	"time"
+
+	"cloud.google.com/go/compute/metadata"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"golang.org/x/oauth2/google"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// identityTimeout bounds how long we wait for the cloud to tell us who we are.
+const identityTimeout = 10 * time.Second
+
+// CallerIdentity returns who is running kops against the cluster.
+// This is the AWS or GCP identity when it can be determined, and the local OS user otherwise.
+func CallerIdentity(ctx context.Context, cluster *kops.Cluster) string {
+	ctx, cancel := context.WithTimeout(ctx, identityTimeout)
+	defer cancel()
+
+	var identity string
+	var err error
+	switch cluster.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS:
+		identity, err = awsCallerIdentity(ctx, cluster)
+	case kops.CloudProviderGCE:
+		identity, err = gcpCallerIdentity(ctx)
+	}
+	if err != nil {
+		klog.V(2).Infof("unable to determine the cloud identity, using the OS user: %v", err)
+	}
+	if identity != "" {
+		return identity
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		klog.V(2).Infof("unable to determine the OS user: %v", err)
+		return "unknown"
+	}
+	return u.Username
+}
+
+func awsCallerIdentity(ctx context.Context, cluster *kops.Cluster) (string, error) {
+	region, err := awsup.FindRegion(cluster)
+	if err != nil {
+		return "", err
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *aws.NewConfig().WithRegion(region),
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating AWS session: %w", err)
+	}
+	response, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("getting AWS caller identity: %w", err)
+	}
+	return aws.StringValue(response.Arn), nil
+}
+
+func gcpCallerIdentity(ctx context.Context) (string, error) {
+	credentials, err := google.FindDefaultCredentials(ctx)
+	if err != nil {
+		return "", fmt.Errorf("finding GCP credentials: %w", err)
+	}
+
+	// Service account keys include the email of the account
+	if len(credentials.JSON) != 0 {
+		var key struct {
+			ClientEmail string `json:"client_email"`
+		}
+		if err := json.Unmarshal(credentials.JSON, &key); err == nil && key.ClientEmail != "" {
+			return key.ClientEmail, nil
+		}
+	}
+
+	// Without a key file we may be running with the service account of a GCE instance
+	if metadata.OnGCE() {
+		email, err := metadata.Email("default")
+		if err != nil {
+			return "", fmt.Errorf("getting GCE service account: %w", err)
+		}
+		return email, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operationlog records the kops operations run against a cluster in the state store.
+//
+// Every operation is written to its own object under operations/ in the cluster state path,
+// so recording never rewrites what was recorded before. Objects older than the retention are pruned.
+package operationlog
+
+import (
+	"bytes"
+	"context"
+	crypto_rand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// PathOperations is the path, relative to the cluster state path, where operations are recorded.
+	PathOperations = "operations"
+
+	// ResultSucceeded is the result of an operation that completed without error.
+	ResultSucceeded = "Succeeded"
+	// ResultFailed is the result of an operation that returned an error.
+	ResultFailed = "Failed"
+
+	// DefaultRetention is how long operations are kept when KOPS_OPERATIONS_RETENTION is not set.
+	DefaultRetention = 365 * 24 * time.Hour
+
+	// Redacted replaces the values of flags that may contain secrets.
+	Redacted = "REDACTED"
+
+	retentionEnvVar = "KOPS_OPERATIONS_RETENTION"
+	timestampFormat = "20060102T150405.000000000Z"
+	objectExtension = ".jsonl"
+)
+
+// sensitiveFlagWords are the words that mark a flag, or a key=value element of a flag, as possibly containing a secret.
+var sensitiveFlagWords = []string{"password", "secret", "token", "credential", "private-key", "access-key", "api-key"}
+
+// Operation is a single entry in the operations log of a cluster.
+type Operation struct {
+	// Timestamp is when the operation finished.
+	Timestamp time.Time `json:"timestamp"`
+	// Identity is who ran the operation.
+	Identity string `json:"identity"`
+	// Command is the kops command that was run, such as "kops update cluster".
+	Command string `json:"command"`
+	// Args are the positional arguments of the command.
+	Args []string `json:"args,omitempty"`
+	// Flags are the flags that were set on the command line, with secrets redacted.
+	Flags map[string]string `json:"flags,omitempty"`
+	// Result is either Succeeded or Failed.
+	Result string `json:"result"`
+	// Error is the error returned by a failed operation.
+	Error string `json:"error,omitempty"`
+}
+
+// NewOperation builds the operation for a run of cmd that returned err.
+func NewOperation(cmd *cobra.Command, args []string, identity string, err error) *Operation {
+	op := &Operation{
+		Timestamp: time.Now().UTC(),
+		Identity:  identity,
+		Command:   cmd.CommandPath(),
+		Args:      args,
+		Flags:     RedactFlags(cmd.Flags()),
+		Result:    ResultSucceeded,
+	}
+	if err != nil {
+		op.Result = ResultFailed
+		op.Error = err.Error()
+	}
+	return op
+}
+
+// RedactFlags returns the flags that were set, replacing the values that may contain secrets.
+// For list flags, only the key=value elements with a sensitive key are redacted.
+func RedactFlags(flags *pflag.FlagSet) map[string]string {
+	values := make(map[string]string)
+	flags.Visit(func(flag *pflag.Flag) {
+		if isSensitive(flag.Name) {
+			values[flag.Name] = Redacted
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			var elements []string
+			for _, element := range slice.GetSlice() {
+				if k, _, found := strings.Cut(element, "="); found && isSensitive(k) {
+					element = k + "=" + Redacted
+				}
+				elements = append(elements, element)
+			}
+			values[flag.Name] = strings.Join(elements, ",")
+			return
+		}
+		values[flag.Name] = flag.Value.String()
+	})
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveFlagWords {
+		if strings.Contains(name, word) || strings.Contains(name, strings.ReplaceAll(word, "-", "")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Record writes op to the operations log under basePath, then prunes the operations that are older than the retention.
+func Record(ctx context.Context, basePath vfs.Path, acl vfs.ACL, op *Operation) error {
+	b, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("serializing operation: %w", err)
+	}
+	b = append(b, '\n')
+
+	suffix := make([]byte, 4)
+	if _, err := crypto_rand.Read(suffix); err != nil {
+		return fmt.Errorf("generating operation name: %w", err)
+	}
+	name := op.Timestamp.UTC().Format(timestampFormat) + "-" + hex.EncodeToString(suffix) + objectExtension
+
+	p := basePath.Join(PathOperations, name)
+	if err := p.CreateFile(ctx, bytes.NewReader(b), acl); err != nil {
+		return fmt.Errorf("writing operation to %s: %w", p, err)
+	}
+
+	if retention := Retention(); retention != 0 {
+		if _, err := Prune(ctx, basePath, op.Timestamp.Add(-retention)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Retention returns how long operations are kept, from KOPS_OPERATIONS_RETENTION.
+// A retention of 0 keeps operations forever.
+func Retention() time.Duration {
+	s := os.Getenv(retentionEnvVar)
+	if s == "" {
+		return DefaultRetention
+	}
+	retention, err := time.ParseDuration(s)
+	if err != nil || retention < 0 {
+		klog.Warningf("ignoring invalid %s %q, using the default of %v", retentionEnvVar, s, DefaultRetention)
+		return DefaultRetention
+	}
+	return retention
+}
+
+// Prune removes the operations recorded before the given time, returning how many were removed.
+func Prune(ctx context.Context, basePath vfs.Path, before time.Time) (int, error) {
+	paths, err := listObjects(basePath)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, p := range paths {
+		timestamp, ok := parseObjectTimestamp(p.Base())
+		if !ok || !timestamp.Before(before) {
+			continue
+		}
+		if err := p.Remove(ctx); err != nil {
+			return removed, fmt.Errorf("removing operation %s: %w", p, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// List returns the operations recorded under basePath, oldest first.
+func List(ctx context.Context, basePath vfs.Path) ([]*Operation, error) {
+	paths, err := listObjects(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []*Operation
+	for _, p := range paths {
+		if !strings.HasSuffix(p.Base(), objectExtension) {
+			continue
+		}
+		b, err := p.ReadFile(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Pruned since we listed it
+				continue
+			}
+			return nil, fmt.Errorf("reading operation %s: %w", p, err)
+		}
+		for _, line := range bytes.Split(b, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			op := &Operation{}
+			if err := json.Unmarshal(line, op); err != nil {
+				return nil, fmt.Errorf("parsing operation %s: %w", p, err)
+			}
+			operations = append(operations, op)
+		}
+	}
+
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].Timestamp.Before(operations[j].Timestamp)
+	})
+	return operations, nil
+}
+
+func listObjects(basePath vfs.Path) ([]vfs.Path, error) {
+	paths, err := basePath.Join(PathOperations).ReadDir()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing operations: %w", err)
+	}
+	return paths, nil
+}
+
+func parseObjectTimestamp(name string) (time.Time, bool) {
+	s, _, found := strings.Cut(name, "-")
+	if !found {
+		return time.Time{}, false
+	}
+	timestamp, err := time.Parse(timestampFormat, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operationlog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestRedactFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("yes", false, "")
+	flags.String("target", "direct", "")
+	flags.String("password", "", "")
+	flags.String("docker-registry-token", "", "")
+	flags.String("ssh-public-key", "", "")
+	flags.StringSlice("set", nil, "")
+	flags.String("unset", "", "")
+
+	if err := flags.Parse([]string{
+		"--yes",
+		"--password=hunter2",
+		"--docker-registry-token=abc",
+		"--ssh-public-key=~/.ssh/id_rsa.pub",
+		"--set=spec.kubernetesVersion=1.28.0",
+		"--set=spec.authentication.oidc.clientSecret=hunter2",
+	}); err != nil {
+		t.Fatalf("parsing flags: %v", err)
+	}
+
+	expected := map[string]string{
+		"yes":                   "true",
+		"password":              Redacted,
+		"docker-registry-token": Redacted,
+		"ssh-public-key":        "~/.ssh/id_rsa.pub",
+		"set":                   "spec.kubernetesVersion=1.28.0,spec.authentication.oidc.clientSecret=" + Redacted,
+	}
+	actual := RedactFlags(flags)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected flags\nexpected: %v\nactual:   %v", expected, actual)
+	}
+}
+
+func TestNewOperation(t *testing.T) {
+	cmd := &cobra.Command{Use: "cluster"}
+	parent := &cobra.Command{Use: "update"}
+	parent.AddCommand(cmd)
+	cmd.Flags().Bool("yes", false, "")
+	if err := cmd.Flags().Parse([]string{"--yes"}); err != nil {
+		t.Fatalf("parsing flags: %v", err)
+	}
+
+	op := NewOperation(cmd, []string{"test.k8s.io"}, "alice", errors.New("boom"))
+	if op.Command != "update cluster" {
+		t.Errorf("unexpected command %q", op.Command)
+	}
+	if op.Result != ResultFailed || op.Error != "boom" {
+		t.Errorf("unexpected result %q with error %q", op.Result, op.Error)
+	}
+	if op.Flags["yes"] != "true" {
+		t.Errorf("unexpected flags %v", op.Flags)
+	}
+
+	op = NewOperation(cmd, nil, "alice", nil)
+	if op.Result != ResultSucceeded || op.Error != "" {
+		t.Errorf("unexpected result %q with error %q", op.Result, op.Error)
+	}
+}
+
+func TestRecordListPrune(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(retentionEnvVar, "720h")
+
+	basePath := vfs.NewMemFSPath(vfs.NewMemFSContext(), "cluster")
+
+	operations, err := List(ctx, basePath)
+	if err != nil {
+		t.Fatalf("listing empty log: %v", err)
+	}
+	if len(operations) != 0 {
+		t.Fatalf("expected no operations, got %d", len(operations))
+	}
+
+	now := time.Now().UTC()
+
+	// Record an old operation as if the retention had been longer
+	t.Setenv(retentionEnvVar, "0")
+	if err := Record(ctx, basePath, nil, &Operation{Timestamp: now.Add(-1000 * time.Hour), Identity: "alice", Command: "kops edit cluster", Result: ResultSucceeded}); err != nil {
+		t.Fatalf("recording operation: %v", err)
+	}
+
+	t.Setenv(retentionEnvVar, "720h")
+	for _, op := range []*Operation{
+		{Timestamp: now.Add(-3 * time.Hour), Identity: "bob", Command: "kops rolling-update cluster", Result: ResultFailed, Error: "boom"},
+		{Timestamp: now.Add(-1 * time.Hour), Identity: "alice", Command: "kops update cluster", Result: ResultSucceeded},
+		{Timestamp: now.Add(-2 * time.Hour), Identity: "carol", Command: "kops edit instancegroup", Result: ResultSucceeded},
+	} {
+		if err := Record(ctx, basePath, nil, op); err != nil {
+			t.Fatalf("recording operation: %v", err)
+		}
+	}
+
+	operations, err = List(ctx, basePath)
+	if err != nil {
+		t.Fatalf("listing operations: %v", err)
+	}
+	var commands []string
+	for _, op := range operations {
+		commands = append(commands, op.Command)
+	}
+	expected := []string{"kops rolling-update cluster", "kops edit instancegroup", "kops update cluster"}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Fatalf("expected the operation older than the retention to be pruned and the rest sorted\nexpected: %v\nactual:   %v", expected, commands)
+	}
+	if operations[0].Error != "boom" {
+		t.Errorf("expected the error to be recorded, got %q", operations[0].Error)
+	}
+
+	if _, err := Prune(ctx, basePath, now.Add(-90*time.Minute)); err != nil {
+		t.Fatalf("pruning operations: %v", err)
+	}
+	operations, err = List(ctx, basePath)
+	if err != nil {
+		t.Fatalf("listing operations: %v", err)
+	}
+	if len(operations) != 1 || operations[0].Command != "kops update cluster" {
+		t.Errorf("unexpected operations after pruning: %v", operations)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	grid := map[string]time.Duration{
+		"":        DefaultRetention,
+		"0":       0,
+		"168h":    168 * time.Hour,
+		"invalid": DefaultRetention,
+		"-1h":     DefaultRetention,
+	}
+	for value, expected := range grid {
+		t.Setenv(retentionEnvVar, value)
+		if actual := Retention(); actual != expected {
+			t.Errorf("retention %q: expected %v, got %v", value, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlptracehttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// client sends traces to an OTLP receiver, using protobuf over HTTP.
+type client struct {
+	cfg Config
+
+	mutex      sync.RWMutex
+	httpClient *http.Client
+	tracesURL  string
+}
+
+var _ otlptrace.Client = (*client)(nil)
+
+// newClient constructs a client.
+func newClient(opts ...Option) *client {
+	var cfg Config
+	for _, option := range opts {
+		option(&cfg)
+	}
+	if cfg.timeout == 0 {
+		cfg.timeout = defaultTimeout
+	}
+
+	c := &client{
+		cfg: cfg,
+	}
+
+	return c
+}
+
+// Start implements otlptrace.Client.
+func (c *client) Start(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.httpClient != nil {
+		return fmt.Errorf("already started")
+	}
+
+	tracesURL, err := buildTracesURL(c.cfg.endpoint)
+	if err != nil {
+		return err
+	}
+	c.tracesURL = tracesURL
+
+	// We don't use http.DefaultClient, which may itself be instrumented; we don't want to trace our exports.
+	c.httpClient = &http.Client{
+		Transport: http.DefaultTransport,
+		Timeout:   c.cfg.timeout,
+	}
+
+	return nil
+}
+
+// buildTracesURL returns the URL of the traces path of the OTLP/HTTP receiver at endpoint.
+func buildTracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing OTLP endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("OTLP endpoint %q must be an http or https URL", endpoint)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("OTLP endpoint %q must include a host", endpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/traces"
+	return u.String(), nil
+}
+
+// Stop implements otlptrace.Client.
+func (c *client) Stop(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+		c.httpClient = nil
+	}
+
+	return nil
+}
+
+var errShutdown = errors.New("the client is shutdown")
+
+// UploadTraces implements otlptrace.Client.
+func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.httpClient == nil {
+		return errShutdown
+	}
+
+	body, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling traces: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tracesURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-protobuf")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending traces to %q: %w", c.tracesURL, err)
+	}
+	defer response.Body.Close()
+
+	// Read the body so the connection can be reused
+	if _, err := io.Copy(io.Discard, response.Body); err != nil {
+		return fmt.Errorf("reading response from %q: %w", c.tracesURL, err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response sending traces to %q: %s", c.tracesURL, response.Status)
+	}
+
+	return nil
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Client.
+func (c *client) MarshalLog() interface{} {
+	return struct {
+		Type     string
+		Endpoint string
+	}{
+		Type:     "otlptracehttp",
+		Endpoint: c.cfg.endpoint,
+	}
+}
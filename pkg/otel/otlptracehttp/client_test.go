@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlptracehttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUploadTraces(t *testing.T) {
+	var received []*coltracepb.ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/x-protobuf" {
+			t.Errorf("unexpected content type %q", contentType)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading body: %v", err)
+		}
+		request := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, request); err != nil {
+			t.Errorf("error parsing body: %v", err)
+		}
+		received = append(received, request)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := newClient(WithEndpoint(server.URL + "/"))
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting client: %v", err)
+	}
+
+	spans := []*tracepb.ResourceSpans{
+		{
+			ScopeSpans: []*tracepb.ScopeSpans{
+				{Spans: []*tracepb.Span{{Name: "RunTasks"}}},
+			},
+		},
+	}
+	if err := c.UploadTraces(ctx, spans); err != nil {
+		t.Fatalf("error uploading traces: %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(received))
+	}
+	if name := received[0].ResourceSpans[0].ScopeSpans[0].Spans[0].Name; name != "RunTasks" {
+		t.Errorf("expected span RunTasks, got %q", name)
+	}
+
+	if err := c.Stop(ctx); err != nil {
+		t.Fatalf("error stopping client: %v", err)
+	}
+	if err := c.UploadTraces(ctx, spans); err != errShutdown {
+		t.Errorf("expected error %v after stop, got %v", errShutdown, err)
+	}
+}
+
+func TestUploadTracesErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := newClient(WithEndpoint(server.URL))
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("error starting client: %v", err)
+	}
+	defer c.Stop(ctx)
+
+	if err := c.UploadTraces(ctx, nil); err == nil {
+		t.Errorf("expected an error for a failed export")
+	}
+}
+
+func TestBuildTracesURL(t *testing.T) {
+	grid := []struct {
+		endpoint    string
+		expected    string
+		expectError bool
+	}{
+		{endpoint: "http://localhost:4318", expected: "http://localhost:4318/v1/traces"},
+		{endpoint: "https://collector.example.com/otlp/", expected: "https://collector.example.com/otlp/v1/traces"},
+		{endpoint: "localhost:4318", expectError: true},
+		{endpoint: "grpc://localhost:4317", expectError: true},
+	}
+
+	for _, g := range grid {
+		actual, err := buildTracesURL(g.endpoint)
+		if g.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %q", g.endpoint)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", g.endpoint, err)
+			continue
+		}
+		if actual != g.expected {
+			t.Errorf("expected %q for %q, got %q", g.expected, g.endpoint, actual)
+		}
+	}
+}
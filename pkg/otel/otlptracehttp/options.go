@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlptracehttp
+
+import "time"
+
+// Option applies an option to the Config.
+type Option func(cfg *Config)
+
+type Config struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+// WithEndpoint sets the base URL of the OTLP/HTTP receiver, such as http://localhost:4318.
+// Traces are sent to the /v1/traces path below it.
+func WithEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.endpoint = endpoint
+	}
+}
+
+// WithTimeout sets the timeout of each export request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.timeout = timeout
+	}
+}
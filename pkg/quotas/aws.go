@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotas
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// awsClient reads quotas from Service Quotas, and usage from the EC2 and ELB APIs.
+// Resources tagged as belonging to the cluster are not counted as used,
+// because they are already part of the cluster's requirements.
+type awsClient struct {
+	cloud         awsup.AWSCloud
+	serviceQuotas servicequotasiface.ServiceQuotasAPI
+	clusterName   string
+
+	vcpuUsage map[Quota]int64
+}
+
+var _ Client = &awsClient{}
+
+// accountAttributeVPCMaxElasticIPs is the account attribute holding the Elastic IP limit
+const accountAttributeVPCMaxElasticIPs = "vpc-max-elastic-ips"
+
+// NewAWSClient builds a quota client for the cloud's region.
+func NewAWSClient(cloud awsup.AWSCloud, clusterName string) (Client, error) {
+	sess, err := cloud.Session()
+	if err != nil {
+		return nil, err
+	}
+	return &awsClient{
+		cloud:         cloud,
+		serviceQuotas: servicequotas.New(sess),
+		clusterName:   clusterName,
+	}, nil
+}
+
+// NewAWSVCPUCounter returns a VCPUCounter that looks up instance types in the cloud's region.
+func NewAWSVCPUCounter(cloud awsup.AWSCloud) VCPUCounter {
+	return func(instanceType string) (int64, error) {
+		info, err := cloud.DescribeInstanceType(instanceType)
+		if err != nil {
+			return 0, err
+		}
+		if info.VCpuInfo == nil || info.VCpuInfo.DefaultVCpus == nil {
+			return 0, fmt.Errorf("vCPUs of instance type %q are unknown", instanceType)
+		}
+		return aws.Int64Value(info.VCpuInfo.DefaultVCpus), nil
+	}
+}
+
+func (c *awsClient) Limit(quota Quota) (int64, error) {
+	if quota == QuotaElasticIPs {
+		return c.elasticIPLimit()
+	}
+
+	response, err := c.serviceQuotas.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(quota.ServiceCode),
+		QuotaCode:   aws.String(quota.QuotaCode),
+	})
+	if err == nil {
+		return int64(aws.Float64Value(response.Quota.Value)), nil
+	}
+	if awsup.AWSErrorCode(err) != servicequotas.ErrCodeNoSuchResourceException {
+		return 0, err
+	}
+
+	// Quotas that were never changed only have their default value
+	defaultResponse, err := c.serviceQuotas.GetAWSDefaultServiceQuota(&servicequotas.GetAWSDefaultServiceQuotaInput{
+		ServiceCode: aws.String(quota.ServiceCode),
+		QuotaCode:   aws.String(quota.QuotaCode),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(aws.Float64Value(defaultResponse.Quota.Value)), nil
+}
+
+func (c *awsClient) elasticIPLimit() (int64, error) {
+	response, err := c.cloud.EC2().DescribeAccountAttributes(&ec2.DescribeAccountAttributesInput{
+		AttributeNames: aws.StringSlice([]string{accountAttributeVPCMaxElasticIPs}),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error describing account attributes: %w", err)
+	}
+	for _, attribute := range response.AccountAttributes {
+		if aws.StringValue(attribute.AttributeName) != accountAttributeVPCMaxElasticIPs {
+			continue
+		}
+		for _, value := range attribute.AttributeValues {
+			return strconv.ParseInt(aws.StringValue(value.AttributeValue), 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("account attribute %q not found", accountAttributeVPCMaxElasticIPs)
+}
+
+func (c *awsClient) Usage(quota Quota) (int64, error) {
+	switch quota {
+	case QuotaVPCs:
+		return c.vpcUsage()
+	case QuotaElasticIPs:
+		return c.elasticIPUsage()
+	case QuotaNetworkLoadBalancers:
+		return c.networkLoadBalancerUsage()
+	}
+
+	if c.vcpuUsage == nil {
+		usage, err := c.instanceVCPUUsage()
+		if err != nil {
+			return 0, err
+		}
+		c.vcpuUsage = usage
+	}
+	return c.vcpuUsage[quota], nil
+}
+
+func (c *awsClient) ownedByCluster(tags map[string]string) bool {
+	if tags[awsup.TagClusterName] == c.clusterName {
+		return true
+	}
+	_, found := tags[awsup.TagNameClusterOwnershipPrefix+c.clusterName]
+	return found
+}
+
+func ec2TagMap(tags []*ec2.Tag) map[string]string {
+	m := make(map[string]string)
+	for _, tag := range tags {
+		m[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return m
+}
+
+func (c *awsClient) vpcUsage() (int64, error) {
+	var usage int64
+	err := c.cloud.EC2().DescribeVpcsPages(&ec2.DescribeVpcsInput{}, func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+		for _, vpc := range page.Vpcs {
+			if !c.ownedByCluster(ec2TagMap(vpc.Tags)) {
+				usage++
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error describing VPCs: %w", err)
+	}
+	return usage, nil
+}
+
+func (c *awsClient) elasticIPUsage() (int64, error) {
+	response, err := c.cloud.EC2().DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{awsup.NewEC2Filter("domain", ec2.DomainTypeVpc)},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error describing Elastic IPs: %w", err)
+	}
+	var usage int64
+	for _, address := range response.Addresses {
+		if !c.ownedByCluster(ec2TagMap(address.Tags)) {
+			usage++
+		}
+	}
+	return usage, nil
+}
+
+func (c *awsClient) networkLoadBalancerUsage() (int64, error) {
+	var arns []string
+	err := c.cloud.ELBV2().DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+		for _, lb := range page.LoadBalancers {
+			if aws.StringValue(lb.Type) == elbv2.LoadBalancerTypeEnumNetwork {
+				arns = append(arns, aws.StringValue(lb.LoadBalancerArn))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error describing load balancers: %w", err)
+	}
+
+	var usage int64
+	// DescribeTags accepts at most 20 load balancers
+	for start := 0; start < len(arns); start += 20 {
+		end := start + 20
+		if end > len(arns) {
+			end = len(arns)
+		}
+		tagMap, err := c.cloud.DescribeELBV2Tags(arns[start:end])
+		if err != nil {
+			return 0, fmt.Errorf("error describing load balancer tags: %w", err)
+		}
+		for _, arn := range arns[start:end] {
+			tags := make(map[string]string)
+			for _, tag := range tagMap[arn] {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			if !c.ownedByCluster(tags) {
+				usage++
+			}
+		}
+	}
+	return usage, nil
+}
+
+func (c *awsClient) instanceVCPUUsage() (map[Quota]int64, error) {
+	usage := make(map[Quota]int64)
+	request := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{awsup.NewEC2Filter("instance-state-name", ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning)},
+	}
+	err := c.cloud.EC2().DescribeInstancesPages(request, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if c.ownedByCluster(ec2TagMap(instance.Tags)) || instance.CpuOptions == nil {
+					continue
+				}
+				spot := aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot
+				quota, found := VCPUQuota(aws.StringValue(instance.InstanceType), spot)
+				if !found {
+					continue
+				}
+				usage[quota] += aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing instances: %w", err)
+	}
+	return usage, nil
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotas
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// Quota identifies an AWS service quota
+type Quota struct {
+	// ServiceCode is the Service Quotas code of the service, for example "ec2"
+	ServiceCode string
+	// QuotaCode is the Service Quotas code of the quota, for example "L-1216C47A"
+	QuotaCode string
+	// Description is a human readable name of the quota
+	Description string
+}
+
+var (
+	QuotaVPCs                 = Quota{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", Description: "VPCs per Region"}
+	QuotaElasticIPs           = Quota{ServiceCode: "ec2", QuotaCode: "L-0263D0A3", Description: "EC2-VPC Elastic IPs"}
+	QuotaNetworkLoadBalancers = Quota{ServiceCode: "elasticloadbalancing", QuotaCode: "L-69A177A2", Description: "Network Load Balancers per Region"}
+
+	QuotaOnDemandStandardVCPUs = Quota{ServiceCode: "ec2", QuotaCode: "L-1216C47A", Description: "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances vCPUs"}
+	QuotaOnDemandGVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-DB2E81BA", Description: "Running On-Demand G and VT instances vCPUs"}
+	QuotaOnDemandPVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-417A185B", Description: "Running On-Demand P instances vCPUs"}
+	QuotaOnDemandXVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-7295265B", Description: "Running On-Demand X instances vCPUs"}
+	QuotaOnDemandFVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-74FC7D96", Description: "Running On-Demand F instances vCPUs"}
+	QuotaOnDemandInfVCPUs      = Quota{ServiceCode: "ec2", QuotaCode: "L-1945791B", Description: "Running On-Demand Inf instances vCPUs"}
+
+	QuotaSpotStandardVCPUs = Quota{ServiceCode: "ec2", QuotaCode: "L-34B43A08", Description: "All Standard (A, C, D, H, I, M, R, T, Z) Spot Instance Requests vCPUs"}
+	QuotaSpotGVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-3819A6DF", Description: "All G and VT Spot Instance Requests vCPUs"}
+	QuotaSpotPVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-7212CCBC", Description: "All P Spot Instance Requests vCPUs"}
+	QuotaSpotXVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-E3A00192", Description: "All X Spot Instance Requests vCPUs"}
+	QuotaSpotFVCPUs        = Quota{ServiceCode: "ec2", QuotaCode: "L-88CF9481", Description: "All F Spot Instance Requests vCPUs"}
+	QuotaSpotInfVCPUs      = Quota{ServiceCode: "ec2", QuotaCode: "L-B5D1601B", Description: "All Inf Spot Instance Requests vCPUs"}
+)
+
+// vcpuQuotas maps the instance family classes to their on-demand and spot vCPU quotas
+var vcpuQuotas = map[string][2]Quota{
+	"standard": {QuotaOnDemandStandardVCPUs, QuotaSpotStandardVCPUs},
+	"g":        {QuotaOnDemandGVCPUs, QuotaSpotGVCPUs},
+	"p":        {QuotaOnDemandPVCPUs, QuotaSpotPVCPUs},
+	"x":        {QuotaOnDemandXVCPUs, QuotaSpotXVCPUs},
+	"f":        {QuotaOnDemandFVCPUs, QuotaSpotFVCPUs},
+	"inf":      {QuotaOnDemandInfVCPUs, QuotaSpotInfVCPUs},
+}
+
+// VCPUQuota returns the vCPU quota that applies to an instance type, or false if it is not known.
+func VCPUQuota(instanceType string, spot bool) (Quota, bool) {
+	family := strings.ToLower(instanceType)
+	if i := strings.IndexAny(family, "0123456789.-"); i >= 0 {
+		family = family[:i]
+	}
+
+	var class string
+	switch {
+	case family == "inf":
+		class = "inf"
+	case family == "vt":
+		class = "g"
+	case family == "dl" || family == "trn" || family == "hpc" || family == "mac" || family == "u" || family == "":
+		// These families have quotas of their own, which we don't check
+		return Quota{}, false
+	case strings.ContainsRune("acdhimrtz", rune(family[0])):
+		class = "standard"
+	default:
+		class = family[:1]
+	}
+
+	quotas, found := vcpuQuotas[class]
+	if !found {
+		return Quota{}, false
+	}
+	if spot {
+		return quotas[1], true
+	}
+	return quotas[0], true
+}
+
+// Requirements holds the amount of each quota a cluster needs
+type Requirements map[Quota]int64
+
+// VCPUCounter returns the number of vCPUs of an instance type
+type VCPUCounter func(instanceType string) (int64, error)
+
+// ComputeRequirements computes the resources counted against quotas that the cluster needs.
+// Instance groups are assumed to scale up to their maximum size.
+func ComputeRequirements(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, vcpus VCPUCounter) (Requirements, error) {
+	requirements := Requirements{}
+
+	if !cluster.SharedVPC() {
+		requirements[QuotaVPCs]++
+	}
+
+	if eips := natGatewayElasticIPs(cluster); eips > 0 {
+		requirements[QuotaElasticIPs] += eips
+	}
+
+	if cluster.Spec.API.LoadBalancer != nil && cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork {
+		requirements[QuotaNetworkLoadBalancers]++
+	}
+
+	for _, ig := range instanceGroups {
+		if ig.Spec.Manager == kops.InstanceManagerKarpenter {
+			continue
+		}
+		if err := addInstanceGroupVCPUs(requirements, ig, vcpus); err != nil {
+			return nil, err
+		}
+	}
+
+	return requirements, nil
+}
+
+// natGatewayElasticIPs returns the number of Elastic IPs allocated for the NAT gateways kOps creates.
+// kOps creates a NAT gateway in each zone with private subnets, unless the subnets egress through
+// existing resources or use an existing Elastic IP.
+func natGatewayElasticIPs(cluster *kops.Cluster) int64 {
+	firstNATSubnet := make(map[string]*kops.ClusterSubnetSpec)
+	var zones []string
+	for i := range cluster.Spec.Networking.Subnets {
+		subnet := &cluster.Spec.Networking.Subnets[i]
+		if subnet.ID != "" || subnet.Egress == kops.EgressExternal {
+			continue
+		}
+		switch subnet.Type {
+		case kops.SubnetTypePrivate, kops.SubnetTypeDualStack:
+		case kops.SubnetTypePublic:
+			if !cluster.Spec.IsIPv6Only() || subnet.IPv6CIDR == "" {
+				continue
+			}
+		default:
+			continue
+		}
+		if _, found := firstNATSubnet[subnet.Zone]; !found {
+			firstNATSubnet[subnet.Zone] = subnet
+			zones = append(zones, subnet.Zone)
+		}
+	}
+
+	var eips int64
+	for _, zone := range zones {
+		subnet := firstNATSubnet[zone]
+		if subnet.Egress == "" && subnet.PublicIP == "" {
+			eips++
+		}
+	}
+	return eips
+}
+
+// addInstanceGroupVCPUs adds the vCPUs of the instance group at its maximum size.
+// Instance groups with several instance types count the largest type for all instances.
+func addInstanceGroupVCPUs(requirements Requirements, ig *kops.InstanceGroup, vcpus VCPUCounter) error {
+	size := int64(1)
+	if ig.Spec.MaxSize != nil {
+		size = int64(*ig.Spec.MaxSize)
+	} else if ig.Spec.MinSize != nil {
+		size = int64(*ig.Spec.MinSize)
+	}
+	if size <= 0 {
+		return nil
+	}
+
+	instanceTypes := []string{ig.Spec.MachineType}
+	if ig.Spec.MixedInstancesPolicy != nil && len(ig.Spec.MixedInstancesPolicy.Instances) != 0 {
+		instanceTypes = ig.Spec.MixedInstancesPolicy.Instances
+	}
+
+	var largestType string
+	var largestVCPUs int64
+	for _, instanceType := range instanceTypes {
+		if instanceType == "" {
+			continue
+		}
+		n, err := vcpus(instanceType)
+		if err != nil {
+			return fmt.Errorf("error getting vCPUs of instance type %q for instance group %q: %w", instanceType, ig.Name, err)
+		}
+		if n > largestVCPUs {
+			largestType = instanceType
+			largestVCPUs = n
+		}
+	}
+	if largestType == "" {
+		return nil
+	}
+
+	onDemand, spot := instanceGroupLifecycleCounts(ig, size)
+	for _, c := range []struct {
+		count int64
+		spot  bool
+	}{{onDemand, false}, {spot, true}} {
+		if c.count == 0 {
+			continue
+		}
+		quota, found := VCPUQuota(largestType, c.spot)
+		if !found {
+			klog.V(2).Infof("not checking vCPU quota of instance type %q for instance group %q", largestType, ig.Name)
+			continue
+		}
+		requirements[quota] += c.count * largestVCPUs
+	}
+	return nil
+}
+
+// instanceGroupLifecycleCounts returns the number of on-demand and spot instances in an instance group of the given size.
+func instanceGroupLifecycleCounts(ig *kops.InstanceGroup, size int64) (int64, int64) {
+	if policy := ig.Spec.MixedInstancesPolicy; policy != nil {
+		base := fi.ValueOf(policy.OnDemandBase)
+		if base > size {
+			base = size
+		}
+		aboveBase := int64(100)
+		if policy.OnDemandAboveBase != nil {
+			aboveBase = *policy.OnDemandAboveBase
+		}
+		onDemand := base + ((size-base)*aboveBase+99)/100
+		return onDemand, size - onDemand
+	}
+	if ig.Spec.MaxPrice != nil {
+		return 0, size
+	}
+	return size, 0
+}
+
+// Client reads the limits and the current usage of quotas
+type Client interface {
+	// Limit returns the current value of the quota
+	Limit(quota Quota) (int64, error)
+	// Usage returns the amount of the quota used by resources that don't belong to the cluster
+	Usage(quota Quota) (int64, error)
+}
+
+// Violation is a quota that the cluster would exceed
+type Violation struct {
+	Quota    Quota
+	Limit    int64
+	Usage    int64
+	Required int64
+}
+
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s (%s): limit %d, in use %d, required %d", v.Quota.Description, v.Quota.QuotaCode, v.Limit, v.Usage, v.Required)
+}
+
+// Check returns the quotas that the requirements would exceed, sorted by quota code.
+func Check(client Client, requirements Requirements) ([]*Violation, error) {
+	var violations []*Violation
+	for quota, required := range requirements {
+		if required <= 0 {
+			continue
+		}
+		limit, err := client.Limit(quota)
+		if err != nil {
+			return nil, fmt.Errorf("error getting limit of quota %q: %w", quota.Description, err)
+		}
+		usage, err := client.Usage(quota)
+		if err != nil {
+			return nil, fmt.Errorf("error getting usage of quota %q: %w", quota.Description, err)
+		}
+		if usage+required > limit {
+			violations = append(violations, &Violation{
+				Quota:    quota,
+				Limit:    limit,
+				Usage:    usage,
+				Required: required,
+			})
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Quota.QuotaCode < violations[j].Quota.QuotaCode
+	})
+	return violations, nil
+}
+
+// PrintViolations writes a table of the quotas that would be exceeded.
+func PrintViolations(out io.Writer, violations []*Violation) error {
+	w := tabwriter.NewWriter(out, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(w, "QUOTA\tCODE\tLIMIT\tIN USE\tREQUIRED\n")
+	for _, v := range violations {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", v.Quota.Description, v.Quota.QuotaCode, v.Limit, v.Usage, v.Required)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotas
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+var testVCPUs = map[string]int64{
+	"t3.medium":  2,
+	"m5.large":   2,
+	"m5.xlarge":  4,
+	"m5.2xlarge": 8,
+	"c5.4xlarge": 16,
+	"g4dn.large": 4,
+}
+
+func testVCPUCounter(instanceType string) (int64, error) {
+	n, found := testVCPUs[instanceType]
+	if !found {
+		return 0, fmt.Errorf("unknown instance type %q", instanceType)
+	}
+	return n, nil
+}
+
+// stubClient is a Client with fixed limits and usage
+type stubClient struct {
+	limits map[Quota]int64
+	usage  map[Quota]int64
+}
+
+func (c *stubClient) Limit(quota Quota) (int64, error) {
+	limit, found := c.limits[quota]
+	if !found {
+		return 0, fmt.Errorf("no limit for %q", quota.QuotaCode)
+	}
+	return limit, nil
+}
+
+func (c *stubClient) Usage(quota Quota) (int64, error) {
+	return c.usage[quota], nil
+}
+
+func testCluster() *kops.Cluster {
+	return &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			Networking: kops.NetworkingSpec{
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "us-test-1a", Zone: "us-test-1a", Type: kops.SubnetTypePrivate},
+					{Name: "us-test-1b", Zone: "us-test-1b", Type: kops.SubnetTypePrivate},
+					{Name: "us-test-1c", Zone: "us-test-1c", Type: kops.SubnetTypePrivate},
+					{Name: "utility-us-test-1a", Zone: "us-test-1a", Type: kops.SubnetTypeUtility},
+					{Name: "utility-us-test-1b", Zone: "us-test-1b", Type: kops.SubnetTypeUtility},
+					{Name: "utility-us-test-1c", Zone: "us-test-1c", Type: kops.SubnetTypeUtility},
+				},
+			},
+		},
+	}
+}
+
+func TestComputeRequirementsElasticIPs(t *testing.T) {
+	grid := []struct {
+		name     string
+		egress   []string
+		publicIP string
+		expected int64
+	}{
+		{
+			name:     "NAT gateway per zone",
+			egress:   []string{"", "", ""},
+			expected: 3,
+		},
+		{
+			name:     "single shared NAT gateway",
+			egress:   []string{"nat-0123456789abcdef0", "nat-0123456789abcdef0", "nat-0123456789abcdef0"},
+			expected: 0,
+		},
+		{
+			name:     "existing Elastic IPs",
+			egress:   []string{"eipalloc-0123456789abcdef0", "eipalloc-0123456789abcdef1", ""},
+			expected: 1,
+		},
+		{
+			name:     "existing public IP",
+			egress:   []string{"", "", ""},
+			publicIP: "203.0.113.10",
+			expected: 2,
+		},
+		{
+			name:     "external egress",
+			egress:   []string{kops.EgressExternal, kops.EgressExternal, kops.EgressExternal},
+			expected: 0,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := testCluster()
+			for i, egress := range g.egress {
+				cluster.Spec.Networking.Subnets[i].Egress = egress
+			}
+			cluster.Spec.Networking.Subnets[0].PublicIP = g.publicIP
+
+			requirements, err := ComputeRequirements(cluster, nil, testVCPUCounter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requirements[QuotaElasticIPs] != g.expected {
+				t.Errorf("expected %d Elastic IPs, got %d", g.expected, requirements[QuotaElasticIPs])
+			}
+		})
+	}
+}
+
+func TestComputeRequirementsVCPUs(t *testing.T) {
+	cluster := testCluster()
+	cluster.Spec.Networking.NetworkID = "vpc-12345678"
+	cluster.Spec.API.LoadBalancer = &kops.LoadBalancerAccessSpec{Class: kops.LoadBalancerClassNetwork}
+
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "control-plane"},
+			Spec: kops.InstanceGroupSpec{
+				MachineType: "m5.large",
+				MinSize:     fi.PtrTo(int32(3)),
+				MaxSize:     fi.PtrTo(int32(3)),
+			},
+		},
+		{
+			// The largest instance type counts for all instances, half of which are spot
+			ObjectMeta: metav1.ObjectMeta{Name: "mixed"},
+			Spec: kops.InstanceGroupSpec{
+				MachineType: "m5.large",
+				MinSize:     fi.PtrTo(int32(2)),
+				MaxSize:     fi.PtrTo(int32(10)),
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances:         []string{"m5.xlarge", "c5.4xlarge", "m5.2xlarge"},
+					OnDemandBase:      fi.PtrTo(int64(2)),
+					OnDemandAboveBase: fi.PtrTo(int64(50)),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "spot"},
+			Spec: kops.InstanceGroupSpec{
+				MachineType: "t3.medium",
+				MaxSize:     fi.PtrTo(int32(4)),
+				MaxPrice:    fi.PtrTo("0.05"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+			Spec: kops.InstanceGroupSpec{
+				MachineType: "g4dn.large",
+				MinSize:     fi.PtrTo(int32(2)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "karpenter"},
+			Spec: kops.InstanceGroupSpec{
+				Manager:     kops.InstanceManagerKarpenter,
+				MachineType: "c5.4xlarge",
+				MaxSize:     fi.PtrTo(int32(100)),
+			},
+		},
+	}
+
+	requirements, err := ComputeRequirements(cluster, instanceGroups, testVCPUCounter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Requirements{
+		QuotaElasticIPs:           3,
+		QuotaNetworkLoadBalancers: 1,
+		// 3 * 2 for the control plane, (2 + 4) * 16 for the mixed instance group
+		QuotaOnDemandStandardVCPUs: 102,
+		// 4 * 16 for the mixed instance group, 4 * 2 for the spot instance group
+		QuotaSpotStandardVCPUs: 72,
+		QuotaOnDemandGVCPUs:    8,
+	}
+	if !reflect.DeepEqual(requirements, expected) {
+		t.Errorf("unexpected requirements:\nexpected: %v\nactual:   %v", expected, requirements)
+	}
+}
+
+func TestComputeRequirementsUnknownInstanceType(t *testing.T) {
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+			Spec: kops.InstanceGroupSpec{
+				MachineType: "z9.huge",
+			},
+		},
+	}
+	if _, err := ComputeRequirements(testCluster(), instanceGroups, testVCPUCounter); err == nil {
+		t.Errorf("expected error for unknown instance type")
+	}
+}
+
+func TestVCPUQuota(t *testing.T) {
+	grid := []struct {
+		instanceType string
+		spot         bool
+		expected     Quota
+		found        bool
+	}{
+		{instanceType: "m5.large", expected: QuotaOnDemandStandardVCPUs, found: true},
+		{instanceType: "t4g.micro", spot: true, expected: QuotaSpotStandardVCPUs, found: true},
+		{instanceType: "is4gen.large", expected: QuotaOnDemandStandardVCPUs, found: true},
+		{instanceType: "g5.xlarge", expected: QuotaOnDemandGVCPUs, found: true},
+		{instanceType: "vt1.3xlarge", spot: true, expected: QuotaSpotGVCPUs, found: true},
+		{instanceType: "p4d.24xlarge", expected: QuotaOnDemandPVCPUs, found: true},
+		{instanceType: "x2idn.16xlarge", expected: QuotaOnDemandXVCPUs, found: true},
+		{instanceType: "f1.2xlarge", expected: QuotaOnDemandFVCPUs, found: true},
+		{instanceType: "inf2.xlarge", spot: true, expected: QuotaSpotInfVCPUs, found: true},
+		{instanceType: "dl1.24xlarge"},
+		{instanceType: "trn1.2xlarge"},
+		{instanceType: "mac1.metal"},
+		{instanceType: "u-6tb1.metal"},
+	}
+
+	for _, g := range grid {
+		t.Run(g.instanceType, func(t *testing.T) {
+			quota, found := VCPUQuota(g.instanceType, g.spot)
+			if found != g.found {
+				t.Fatalf("expected found=%v, got %v", g.found, found)
+			}
+			if quota != g.expected {
+				t.Errorf("expected quota %v, got %v", g.expected, quota)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	client := &stubClient{
+		limits: map[Quota]int64{
+			QuotaVPCs:                  5,
+			QuotaElasticIPs:            5,
+			QuotaOnDemandStandardVCPUs: 32,
+		},
+		usage: map[Quota]int64{
+			QuotaVPCs:                  4,
+			QuotaElasticIPs:            4,
+			QuotaOnDemandStandardVCPUs: 20,
+		},
+	}
+	requirements := Requirements{
+		QuotaVPCs:                  1,
+		QuotaElasticIPs:            3,
+		QuotaOnDemandStandardVCPUs: 16,
+	}
+
+	violations, err := Check(client, requirements)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []*Violation{
+		{Quota: QuotaElasticIPs, Limit: 5, Usage: 4, Required: 3},
+		{Quota: QuotaOnDemandStandardVCPUs, Limit: 32, Usage: 20, Required: 16},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("unexpected violations:\nexpected: %v\nactual:   %v", expected, violations)
+	}
+
+	var out bytes.Buffer
+	if err := PrintViolations(&out, violations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedOutput := "" +
+		"QUOTA                                                                  CODE       LIMIT IN USE REQUIRED\n" +
+		"EC2-VPC Elastic IPs                                                    L-0263D0A3 5     4      3\n" +
+		"Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances vCPUs L-1216C47A 32    20     16\n"
+	if out.String() != expectedOutput {
+		t.Errorf("unexpected output:\nexpected: %q\nactual:   %q", expectedOutput, out.String())
+	}
+}
+
+func TestCheckLimitError(t *testing.T) {
+	client := &stubClient{}
+	if _, err := Check(client, Requirements{QuotaVPCs: 1}); err == nil {
+		t.Errorf("expected error when the limit is unknown")
+	}
+}
@@ -43,6 +43,7 @@ import (
 
 const (
 	TypeAutoscalingLaunchConfig = "autoscaling-config"
+	TypeLaunchTemplateVersion   = "launch-template-version"
 	TypeNatGateway              = "nat-gateway"
 	TypeElasticIp               = "elastic-ip"
 	TypeEventBridgeRule         = "eventbridge-rule"
@@ -52,6 +53,15 @@ const (
 
 type listFn func(fi.Cloud, string, string) ([]*resources.Resource, error)
 
+// lister finds resources of a cluster
+type lister struct {
+	fn listFn
+	// taggedTypes are the types of the resources found by fn that the Resource Groups Tagging API reports.
+	// With tagging discovery, fn is skipped when none of those resources are tagged for the cluster.
+	// Listers of resources that the tagging API does not report leave it empty, and always run.
+	taggedTypes []string
+}
+
 func ListResourcesAWS(cloud awsup.AWSCloud, clusterInfo resources.ClusterInfo) (map[string]*resources.Resource, error) {
 	clusterName := clusterInfo.Name
 	clusterUsesNoneDNS := clusterInfo.UsesNoneDNS
@@ -60,46 +70,65 @@ func ListResourcesAWS(cloud awsup.AWSCloud, clusterInfo resources.ClusterInfo) (
 
 	// These are the functions that are used for looking up
 	// cluster resources by their tags.
-	listFunctions := []listFn{
+	listers := []lister{
 		// EC2
-		ListAutoScalingGroups,
-		ListInstances,
-		ListKeypairs,
-		ListSecurityGroups,
-		ListVolumes,
+		{fn: ListAutoScalingGroups},
+		{fn: ListInstances, taggedTypes: []string{ec2.ResourceTypeInstance}},
+		{fn: ListKeypairs},
+		{fn: ListPlacementGroups, taggedTypes: []string{"placement-group"}},
+		{fn: ListSecurityGroups, taggedTypes: []string{ec2.ResourceTypeSecurityGroup}},
+		{fn: ListVolumes, taggedTypes: []string{"volume"}},
 		// EC2 VPC
-		ListDhcpOptions,
-		ListInternetGateways,
-		ListEgressOnlyInternetGateways,
-		ListRouteTables,
-		ListSubnets,
-		ListENIs,
+		{fn: ListDhcpOptions, taggedTypes: []string{"dhcp-options"}},
+		{fn: ListInternetGateways, taggedTypes: []string{"internet-gateway"}},
+		{fn: ListEgressOnlyInternetGateways, taggedTypes: []string{"egress-only-internet-gateway"}},
+		{fn: ListVPCEndpoints, taggedTypes: []string{ec2.ResourceTypeVpcEndpoint}},
+		{fn: ListRouteTables, taggedTypes: []string{ec2.ResourceTypeRouteTable}},
+		{fn: ListSubnets, taggedTypes: []string{ec2.ResourceTypeSubnet}},
+		{fn: ListElasticIPs, taggedTypes: []string{TypeElasticIp}},
+		{fn: ListENIs, taggedTypes: []string{ec2.ResourceTypeNetworkInterface}},
 		// ELBs
-		ListELBs,
-		ListELBV2s,
-		ListTargetGroups,
+		{fn: ListELBs, taggedTypes: []string{TypeLoadBalancer}},
+		{fn: ListELBV2s, taggedTypes: []string{TypeLoadBalancer}},
+		{fn: ListTargetGroups, taggedTypes: []string{TypeTargetGroup}},
 		// IAM
-		ListIAMInstanceProfiles,
-		ListIAMRoles,
-		ListIAMOIDCProviders,
+		{fn: ListIAMInstanceProfiles},
+		{fn: ListIAMRoles},
+		{fn: ListIAMOIDCProviders},
 		// SQS
-		ListSQSQueues,
+		{fn: ListSQSQueues},
 		// EventBridge
-		ListEventBridgeRules,
+		{fn: ListEventBridgeRules},
 	}
 
 	if !dns.IsGossipClusterName(clusterName) && !clusterUsesNoneDNS {
 		// Route 53
-		listFunctions = append(listFunctions, ListRoute53Records)
+		listers = append(listers, lister{fn: ListRoute53Records})
 	}
 
 	if featureflag.Spotinst.Enabled() {
 		// Spotinst resources
-		listFunctions = append(listFunctions, ListSpotinstResources)
+		listers = append(listers, lister{fn: ListSpotinstResources})
+	}
+
+	var tagged taggedResources
+	switch clusterInfo.Discovery {
+	case "", resources.DiscoveryDescribe:
+	case resources.DiscoveryTagging, resources.DiscoveryAuto:
+		var err error
+		tagged, err = findTaggedResources(cloud, clusterName)
+		if err != nil {
+			if clusterInfo.Discovery == resources.DiscoveryTagging {
+				return nil, err
+			}
+			klog.Warningf("falling back to describe discovery: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown discovery %q", clusterInfo.Discovery)
 	}
 
 	var vpcID string
-	{
+	if tagged == nil || tagged.hasAny([]string{ec2.ResourceTypeVpc}) {
 		r, err := ListVPCs(cloud, clusterName)
 		if err != nil {
 			return nil, err
@@ -111,11 +140,18 @@ func ListResourcesAWS(cloud awsup.AWSCloud, clusterInfo resources.ClusterInfo) (
 		}
 	}
 
-	for _, fn := range listFunctions {
-		rt, err := fn(cloud, vpcID, clusterName)
+	for _, l := range listers {
+		if tagged != nil && len(l.taggedTypes) != 0 && !tagged.hasAny(l.taggedTypes) {
+			klog.V(2).Infof("Skipping listing of %v, as none are tagged", l.taggedTypes)
+			continue
+		}
+		rt, err := l.fn(cloud, vpcID, clusterName)
 		if err != nil {
 			return nil, err
 		}
+		if tagged != nil {
+			tagged.verify(l.taggedTypes, rt)
+		}
 		for _, t := range rt {
 			resourceTrackers[t.Type+":"+t.ID] = t
 		}
@@ -713,6 +749,57 @@ func ListKeypairs(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resou
 	return resourceTrackers, nil
 }
 
+func DeletePlacementGroup(cloud fi.Cloud, r *resources.Resource) error {
+	c := cloud.(awsup.AWSCloud)
+
+	name := r.Name
+
+	klog.V(2).Infof("Deleting EC2 PlacementGroup %q", name)
+	request := &ec2.DeletePlacementGroupInput{
+		GroupName: &name,
+	}
+	_, err := c.EC2().DeletePlacementGroup(request)
+	if err != nil {
+		if awsup.AWSErrorCode(err) == "InvalidPlacementGroup.Unknown" {
+			klog.V(2).Infof("Got InvalidPlacementGroup.Unknown error deleting PlacementGroup %q; will treat as already-deleted", name)
+			return nil
+		} else if IsDependencyViolation(err) {
+			return err
+		}
+		return fmt.Errorf("error deleting PlacementGroup %q: %v", name, err)
+	}
+	return nil
+}
+
+func ListPlacementGroups(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(awsup.AWSCloud)
+
+	klog.V(2).Infof("Listing EC2 PlacementGroups")
+	request := &ec2.DescribePlacementGroupsInput{
+		Filters: BuildEC2Filters(cloud),
+	}
+	response, err := c.EC2().DescribePlacementGroups(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing PlacementGroups: %v", err)
+	}
+
+	var resourceTrackers []*resources.Resource
+
+	for _, pg := range response.PlacementGroups {
+		resourceTracker := &resources.Resource{
+			Name:    aws.StringValue(pg.GroupName),
+			ID:      aws.StringValue(pg.GroupId),
+			Type:    "placement-group",
+			Deleter: DeletePlacementGroup,
+			Shared:  HasSharedTag(ec2.ResourceTypePlacementGroup+":"+aws.StringValue(pg.GroupId), pg.Tags, clusterName),
+		}
+
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func DeleteSubnet(cloud fi.Cloud, tracker *resources.Resource) error {
 	c := cloud.(awsup.AWSCloud)
 
@@ -1199,6 +1286,87 @@ func DescribeEgressOnlyInternetGateways(cloud fi.Cloud) ([]*ec2.EgressOnlyIntern
 	return gateways, nil
 }
 
+func DumpVPCEndpoint(op *resources.DumpOperation, r *resources.Resource) error {
+	data := make(map[string]interface{})
+	data["id"] = r.ID
+	data["type"] = r.Type
+	data["raw"] = r.Obj
+	op.Dump.Resources = append(op.Dump.Resources, data)
+	return nil
+}
+
+func DeleteVPCEndpoint(cloud fi.Cloud, r *resources.Resource) error {
+	c := cloud.(awsup.AWSCloud)
+
+	id := r.ID
+
+	klog.V(2).Infof("Deleting EC2 VPCEndpoint %q", id)
+	request := &ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: []*string{&id},
+	}
+	response, err := c.EC2().DeleteVpcEndpoints(request)
+	if err != nil {
+		if IsDependencyViolation(err) {
+			return err
+		}
+		return fmt.Errorf("error deleting VPCEndpoint %q: %v", id, err)
+	}
+	for _, u := range response.Unsuccessful {
+		if u.Error != nil && aws.StringValue(u.Error.Code) == "InvalidVpcEndpoint.NotFound" {
+			klog.Infof("VPC endpoint %q not found; assuming already deleted", id)
+			continue
+		}
+		return fmt.Errorf("error deleting VPCEndpoint %q: %v", id, u.Error)
+	}
+
+	return nil
+}
+
+func ListVPCEndpoints(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(awsup.AWSCloud)
+
+	klog.V(2).Infof("Listing EC2 VPCEndpoints")
+	request := &ec2.DescribeVpcEndpointsInput{
+		Filters: BuildEC2Filters(cloud),
+	}
+	response, err := c.EC2().DescribeVpcEndpoints(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPCEndpoints: %v", err)
+	}
+
+	var resourceTrackers []*resources.Resource
+
+	for _, o := range response.VpcEndpoints {
+		id := aws.StringValue(o.VpcEndpointId)
+		resourceTracker := &resources.Resource{
+			Name:    FindName(o.Tags),
+			ID:      id,
+			Type:    ec2.ResourceTypeVpcEndpoint,
+			Obj:     o,
+			Dumper:  DumpVPCEndpoint,
+			Deleter: DeleteVPCEndpoint,
+			Shared:  HasSharedTag(ec2.ResourceTypeVpcEndpoint+":"+id, o.Tags, clusterName),
+		}
+
+		var blocks []string
+		blocks = append(blocks, "vpc:"+aws.StringValue(o.VpcId))
+		for _, rt := range o.RouteTableIds {
+			blocks = append(blocks, ec2.ResourceTypeRouteTable+":"+aws.StringValue(rt))
+		}
+		for _, subnet := range o.SubnetIds {
+			blocks = append(blocks, "subnet:"+aws.StringValue(subnet))
+		}
+		for _, sg := range o.Groups {
+			blocks = append(blocks, "security-group:"+aws.StringValue(sg.GroupId))
+		}
+		resourceTracker.Blocks = blocks
+
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func DeleteAutoScalingGroup(cloud fi.Cloud, r *resources.Resource) error {
 	ctx := context.TODO()
 
@@ -1287,6 +1455,7 @@ func FindAutoScalingLaunchTemplates(cloud fi.Cloud, clusterName string) ([]*reso
 				ID:      aws.StringValue(lt.LaunchTemplateId),
 				Type:    TypeAutoscalingLaunchConfig,
 				Deleter: DeleteAutoScalingGroupLaunchTemplate,
+				Obj:     lt,
 			})
 		}
 		return true
@@ -1295,7 +1464,16 @@ func FindAutoScalingLaunchTemplates(cloud fi.Cloud, clusterName string) ([]*reso
 		return nil, fmt.Errorf("error listing AutoScaling LaunchTemplates: %v", err)
 	}
 
-	return list, nil
+	var versions []*resources.Resource
+	for _, r := range list {
+		v, err := findLaunchTemplateVersions(c, r.Obj.(*ec2.LaunchTemplate))
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v...)
+	}
+
+	return append(list, versions...), nil
 }
 
 func FindNatGateways(cloud fi.Cloud, routeTables map[string]*resources.Resource, clusterName string) ([]*resources.Resource, error) {
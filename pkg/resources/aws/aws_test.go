@@ -150,6 +150,20 @@ func TestListIAMInstanceProfiles(t *testing.T) {
 		}
 	}
 
+	// Shared instance profiles must never be deleted
+	{
+		name := "shared-profile"
+		c.InstanceProfiles[name] = &iam.InstanceProfile{
+			InstanceProfileName: &name,
+			Tags: []*iam.Tag{
+				{
+					Key:   &ownershipTagKey,
+					Value: fi.PtrTo("shared"),
+				},
+			},
+		}
+	}
+
 	// This is a special entity that will appear in list, but not in get
 	{
 		name := "__no_entity__." + clusterName
@@ -342,6 +356,179 @@ func TestSharedVolume(t *testing.T) {
 	}
 }
 
+func TestListEgressOnlyInternetGateways(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	clusterName := "me.example.com"
+	ownershipTagKey := "kubernetes.io/cluster/" + clusterName
+
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	sharedGateway, err := c.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: aws.String("vpc-1234"),
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeEgressOnlyInternetGateway, map[string]string{
+			ownershipTagKey: "shared",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error creating egress-only internet gateway: %v", err)
+	}
+
+	ownedGateway, err := c.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: aws.String("vpc-1234"),
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeEgressOnlyInternetGateway, map[string]string{
+			ownershipTagKey: "owned",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error creating egress-only internet gateway: %v", err)
+	}
+
+	resourceTrackers, err := ListEgressOnlyInternetGateways(cloud, "", clusterName)
+	if err != nil {
+		t.Fatalf("error listing egress-only internet gateways: %v", err)
+	}
+	if len(resourceTrackers) != 2 {
+		t.Fatalf("expected 2 egress-only internet gateways, got %d", len(resourceTrackers))
+	}
+	for _, rt := range resourceTrackers {
+		if rt.ID == *sharedGateway.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId && !rt.Shared {
+			t.Fatalf("expected Shared: true, got: %v", rt.Shared)
+		}
+		if rt.ID == *ownedGateway.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId && rt.Shared {
+			t.Fatalf("expected Shared: false, got: %v", rt.Shared)
+		}
+	}
+}
+
+func TestListElasticIPs(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	clusterName := "me.example.com"
+	ownershipTagKey := "kubernetes.io/cluster/" + clusterName
+
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// An unassociated Elastic IP, as left behind by a NAT gateway that was replaced
+	ownedAddress, err := c.AllocateAddress(&ec2.AllocateAddressInput{
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeElasticIp, map[string]string{
+			ownershipTagKey: "owned",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error allocating address: %v", err)
+	}
+
+	_, err = c.AllocateAddress(&ec2.AllocateAddressInput{
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeElasticIp, map[string]string{
+			ownershipTagKey: "shared",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error allocating address: %v", err)
+	}
+
+	_, err = c.AllocateAddress(&ec2.AllocateAddressInput{
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeElasticIp, map[string]string{
+			"kubernetes.io/cluster/other.example.com": "owned",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error allocating address: %v", err)
+	}
+
+	resourceTrackers, err := ListElasticIPs(cloud, "", clusterName)
+	if err != nil {
+		t.Fatalf("error listing elastic IPs: %v", err)
+	}
+	if len(resourceTrackers) != 1 {
+		t.Fatalf("expected 1 elastic IP, got %d", len(resourceTrackers))
+	}
+	rt := resourceTrackers[0]
+	if rt.ID != aws.StringValue(ownedAddress.AllocationId) || rt.Type != TypeElasticIp || rt.Shared {
+		t.Fatalf("unexpected elastic IP resource: %+v", rt)
+	}
+
+	if err := rt.Deleter(cloud, rt); err != nil {
+		t.Fatalf("error deleting elastic IP: %v", err)
+	}
+	if c.Addresses[aws.StringValue(ownedAddress.AllocationId)] != nil {
+		t.Fatalf("expected elastic IP %q to be released", aws.StringValue(ownedAddress.AllocationId))
+	}
+}
+
+func TestFindAutoScalingLaunchTemplateVersions(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	clusterName := "me.example.com"
+	ownershipTagKey := "kubernetes.io/cluster/" + clusterName
+
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	created, err := c.CreateLaunchTemplate(&ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String("nodes.me.example.com"),
+		LaunchTemplateData: &ec2.RequestLaunchTemplateData{InstanceType: aws.String("t3.medium")},
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeLaunchTemplate, map[string]string{
+			ownershipTagKey: "owned",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error creating launch template: %v", err)
+	}
+	ltID := aws.StringValue(created.LaunchTemplate.LaunchTemplateId)
+
+	// Seed the versions left behind by rolling updates
+	for i := 0; i < 3; i++ {
+		if _, err := c.CreateLaunchTemplateVersion(&ec2.CreateLaunchTemplateVersionInput{
+			LaunchTemplateName: aws.String("nodes.me.example.com"),
+			LaunchTemplateData: &ec2.RequestLaunchTemplateData{InstanceType: aws.String("t3.large")},
+		}); err != nil {
+			t.Fatalf("error creating launch template version: %v", err)
+		}
+	}
+
+	resourceTrackers, err := FindAutoScalingLaunchTemplates(cloud, clusterName)
+	if err != nil {
+		t.Fatalf("error finding launch templates: %v", err)
+	}
+
+	var versions []*resources.Resource
+	var ids []string
+	for _, rt := range resourceTrackers {
+		ids = append(ids, rt.Type+":"+rt.ID)
+		if rt.Type == TypeLaunchTemplateVersion {
+			versions = append(versions, rt)
+			if !reflect.DeepEqual(rt.Blocks, []string{TypeAutoscalingLaunchConfig + ":" + ltID}) {
+				t.Errorf("expected version %q to block the launch template, got %v", rt.ID, rt.Blocks)
+			}
+		}
+	}
+	sort.Strings(ids)
+	expected := []string{
+		TypeAutoscalingLaunchConfig + ":" + ltID,
+		TypeLaunchTemplateVersion + ":" + ltID + ":1",
+		TypeLaunchTemplateVersion + ":" + ltID + ":2",
+		TypeLaunchTemplateVersion + ":" + ltID + ":3",
+	}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected resources %v, got %v", expected, ids)
+	}
+
+	if err := versions[0].GroupDeleter(cloud, versions); err != nil {
+		t.Fatalf("error deleting launch template versions: %v", err)
+	}
+
+	remaining, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(ltID),
+	})
+	if err != nil {
+		t.Fatalf("error describing launch template versions: %v", err)
+	}
+	if len(remaining.LaunchTemplateVersions) != 1 || aws.Int64Value(remaining.LaunchTemplateVersions[0].VersionNumber) != 4 {
+		t.Fatalf("expected only the default version to remain, got %v", remaining.LaunchTemplateVersions)
+	}
+}
+
 func TestMatchesElbTags(t *testing.T) {
 	tc := []struct {
 		tags     map[string]string
@@ -17,12 +17,41 @@ limitations under the License.
 package aws
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
 
 	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
 
+// ListElasticIPs finds the Elastic IPs owned by the cluster (by tag), whether or not they are associated.
+// Elastic IPs of NAT gateways that kOps replaced are otherwise left behind, as they are no longer referenced by a subnet.
+func ListElasticIPs(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(awsup.AWSCloud)
+
+	klog.V(2).Infof("Listing EC2 Elastic IPs owned by the cluster")
+	request := &ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			awsup.NewEC2Filter("tag:kubernetes.io/cluster/"+clusterName, "owned"),
+		},
+	}
+	response, err := c.EC2().DescribeAddresses(request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing addresses: %v", err)
+	}
+
+	var resourceTrackers []*resources.Resource
+	for _, address := range response.Addresses {
+		resourceTrackers = append(resourceTrackers, buildElasticIPResource(address, false, clusterName))
+	}
+
+	return resourceTrackers, nil
+}
+
 func buildElasticIPResource(address *ec2.Address, forceShared bool, clusterName string) *resources.Resource {
 	name := aws.StringValue(address.PublicIp)
 	if name == "" {
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// maxLaunchTemplateVersionsPerDelete is the maximum number of versions that can be deleted by a single DeleteLaunchTemplateVersions call.
+const maxLaunchTemplateVersionsPerDelete = 200
+
+// findLaunchTemplateVersions returns the versions of the launch template other than the default version.
+// Rolling updates leave behind a version per change; they are deleted before the launch template itself.
+func findLaunchTemplateVersions(cloud awsup.AWSCloud, lt *ec2.LaunchTemplate) ([]*resources.Resource, error) {
+	ltID := aws.StringValue(lt.LaunchTemplateId)
+	ltName := aws.StringValue(lt.LaunchTemplateName)
+
+	request := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: lt.LaunchTemplateId,
+	}
+
+	var list []*resources.Resource
+	err := cloud.EC2().DescribeLaunchTemplateVersionsPages(request, func(p *ec2.DescribeLaunchTemplateVersionsOutput, lastPage bool) bool {
+		for _, version := range p.LaunchTemplateVersions {
+			if aws.BoolValue(version.DefaultVersion) {
+				continue
+			}
+			versionNumber := strconv.FormatInt(aws.Int64Value(version.VersionNumber), 10)
+			list = append(list, &resources.Resource{
+				Name:         ltName + ":" + versionNumber,
+				ID:           ltID + ":" + versionNumber,
+				Type:         TypeLaunchTemplateVersion,
+				GroupKey:     ltID,
+				GroupDeleter: DeleteLaunchTemplateVersions,
+				Blocks:       []string{TypeAutoscalingLaunchConfig + ":" + ltID},
+				Obj:          version,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing versions of LaunchTemplate %q: %w", ltID, err)
+	}
+
+	return list, nil
+}
+
+// DeleteLaunchTemplateVersions deletes versions of a launch template; all the trackers must belong to the same launch template.
+func DeleteLaunchTemplateVersions(cloud fi.Cloud, trackers []*resources.Resource) error {
+	c := cloud.(awsup.AWSCloud)
+
+	if len(trackers) == 0 {
+		return nil
+	}
+	ltID := trackers[0].GroupKey
+
+	var versions []*string
+	for _, t := range trackers {
+		version := t.Obj.(*ec2.LaunchTemplateVersion)
+		versions = append(versions, aws.String(strconv.FormatInt(aws.Int64Value(version.VersionNumber), 10)))
+	}
+
+	klog.V(2).Infof("Deleting %d versions of EC2 LaunchTemplate %q", len(versions), ltID)
+
+	for len(versions) > 0 {
+		chunk := versions
+		if len(chunk) > maxLaunchTemplateVersionsPerDelete {
+			chunk = chunk[:maxLaunchTemplateVersionsPerDelete]
+		}
+		versions = versions[len(chunk):]
+
+		response, err := c.EC2().DeleteLaunchTemplateVersions(&ec2.DeleteLaunchTemplateVersionsInput{
+			LaunchTemplateId: aws.String(ltID),
+			Versions:         chunk,
+		})
+		if err != nil {
+			if awsup.AWSErrorCode(err) == "InvalidLaunchTemplateId.NotFound" {
+				klog.V(2).Infof("Got InvalidLaunchTemplateId.NotFound error deleting versions of LaunchTemplate %q; will treat as already-deleted", ltID)
+				return nil
+			}
+			return fmt.Errorf("error deleting versions of LaunchTemplate %q: %w", ltID, err)
+		}
+		for _, item := range response.UnsuccessfullyDeletedLaunchTemplateVersions {
+			if item.ResponseError != nil && aws.StringValue(item.ResponseError.Code) == ec2.LaunchTemplateErrorCodeLaunchTemplateVersionDoesNotExist {
+				continue
+			}
+			return fmt.Errorf("error deleting version %d of LaunchTemplate %q: %v", aws.Int64Value(item.VersionNumber), ltID, item.ResponseError)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// taggingResourceTypes maps the resource types reported by the Resource Groups Tagging API,
+// in the "service:resourceType" form of its ResourceTypeFilters, to the types of our resources.
+// Resources the tagging API does not report (autoscaling groups, IAM, Route53 records, keypairs, ...)
+// are always found with describe calls.
+var taggingResourceTypes = map[string]string{
+	"ec2:dhcp-options":                  "dhcp-options",
+	"ec2:egress-only-internet-gateway":  "egress-only-internet-gateway",
+	"ec2:elastic-ip":                    TypeElasticIp,
+	"ec2:instance":                      ec2.ResourceTypeInstance,
+	"ec2:internet-gateway":              "internet-gateway",
+	"ec2:network-interface":             ec2.ResourceTypeNetworkInterface,
+	"ec2:placement-group":               "placement-group",
+	"ec2:route-table":                   ec2.ResourceTypeRouteTable,
+	"ec2:security-group":                ec2.ResourceTypeSecurityGroup,
+	"ec2:subnet":                        ec2.ResourceTypeSubnet,
+	"ec2:volume":                        "volume",
+	"ec2:vpc":                           ec2.ResourceTypeVpc,
+	"ec2:vpc-endpoint":                  ec2.ResourceTypeVpcEndpoint,
+	"elasticloadbalancing:loadbalancer": TypeLoadBalancer,
+	"elasticloadbalancing:targetgroup":  TypeTargetGroup,
+}
+
+// taggedResources holds the identifiers reported by the tagging API for each type of resource.
+// Both the ARN and the resource ID from the ARN are recorded,
+// because some resources are identified by their ARN and others by their ID or name.
+type taggedResources map[string]sets.String
+
+// findTaggedResources queries the Resource Groups Tagging API for the resources tagged for the cluster.
+func findTaggedResources(cloud awsup.AWSCloud, clusterName string) (taggedResources, error) {
+	var resourceTypeFilters []string
+	for t := range taggingResourceTypes {
+		resourceTypeFilters = append(resourceTypeFilters, t)
+	}
+	sort.Strings(resourceTypeFilters)
+
+	// Tag filters are ANDed, so we query for the legacy and the ownership tag separately
+	tagFilters := []*resourcegroupstaggingapi.TagFilter{
+		{Key: aws.String(awsup.TagClusterName), Values: aws.StringSlice([]string{clusterName})},
+		{Key: aws.String(awsup.TagNameClusterOwnershipPrefix + clusterName)},
+	}
+
+	tagged := make(taggedResources)
+	klog.V(2).Infof("Listing resources tagged for cluster %q", clusterName)
+	for _, tagFilter := range tagFilters {
+		request := &resourcegroupstaggingapi.GetResourcesInput{
+			ResourceTypeFilters: aws.StringSlice(resourceTypeFilters),
+			TagFilters:          []*resourcegroupstaggingapi.TagFilter{tagFilter},
+		}
+		err := cloud.ResourceGroupsTagging().GetResourcesPages(request, func(p *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, mapping := range p.ResourceTagMappingList {
+				tagged.add(aws.StringValue(mapping.ResourceARN))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing tagged resources: %w", err)
+		}
+	}
+
+	return tagged, nil
+}
+
+// add records the resource with the specified ARN, ignoring resource types we do not list.
+func (t taggedResources) add(resourceARN string) {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		klog.Warningf("ignoring tagged resource with invalid ARN %q: %v", resourceARN, err)
+		return
+	}
+
+	resourceType, resourceID, found := strings.Cut(parsed.Resource, "/")
+	if !found {
+		return
+	}
+	listedType := taggingResourceTypes[parsed.Service+":"+resourceType]
+	if listedType == "" {
+		return
+	}
+	if t[listedType] == nil {
+		t[listedType] = sets.NewString()
+	}
+	t[listedType].Insert(resourceARN, resourceID)
+}
+
+// hasAny returns true if any resource of the specified types is tagged.
+func (t taggedResources) hasAny(resourceTypes []string) bool {
+	for _, resourceType := range resourceTypes {
+		if t[resourceType].Len() != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// verify logs the resources of the specified types that the tagging API did not report.
+// Those are still returned, as the tagging API is only eventually consistent with the services.
+func (t taggedResources) verify(resourceTypes []string, found []*resources.Resource) {
+	types := sets.NewString(resourceTypes...)
+	for _, r := range found {
+		if !types.Has(r.Type) {
+			continue
+		}
+		if !t[r.Type].Has(r.ID) && !t[r.Type].Has(r.Name) {
+			klog.Warningf("%s %q was not reported by the tagging API", r.Type, r.ID)
+		}
+	}
+}
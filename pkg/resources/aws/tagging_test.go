@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	"k8s.io/kops/cloudmock/aws/mockelbv2"
+	"k8s.io/kops/cloudmock/aws/mockeventbridge"
+	"k8s.io/kops/cloudmock/aws/mockiam"
+	"k8s.io/kops/cloudmock/aws/mockresourcegroupstaggingapi"
+	"k8s.io/kops/cloudmock/aws/mocksqs"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// failingTaggingAPI is a Resource Groups Tagging API that cannot be used, e.g. because of missing permissions
+type failingTaggingAPI struct {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+}
+
+func (f *failingTaggingAPI) GetResourcesPages(*resourcegroupstaggingapi.GetResourcesInput, func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+	return fmt.Errorf("AccessDeniedException")
+}
+
+// unusedELB is an ELB API that fails any listing, for checking that untagged resource types are not listed
+type unusedELB struct {
+	elbiface.ELBAPI
+}
+
+func (u *unusedELB) DescribeLoadBalancersPages(*elb.DescribeLoadBalancersInput, func(*elb.DescribeLoadBalancersOutput, bool) bool) error {
+	return fmt.Errorf("unexpected listing of ELBs")
+}
+
+// buildTaggedCluster builds a mock cloud holding a cluster's resources, recorded in the tagging API as well
+func buildTaggedCluster(t *testing.T, clusterName string) *awsup.MockAWSCloud {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{}
+	cloud.MockELB = &mockelb.MockELB{}
+	lbs := &mockelbv2.MockELBV2{EC2: c}
+	cloud.MockELBV2 = lbs
+	cloud.MockIAM = &mockiam.MockIAM{}
+	cloud.MockSQS = &mocksqs.MockSQS{}
+	cloud.MockEventBridge = &mockeventbridge.MockEventBridge{}
+	tagging := &mockresourcegroupstaggingapi.MockResourceGroupsTaggingAPI{}
+	cloud.MockResourceGroupsTagging = tagging
+
+	clusterTags := map[string]string{
+		awsup.TagClusterName:                              clusterName,
+		awsup.TagNameClusterOwnershipPrefix + clusterName: "owned",
+	}
+	otherTags := map[string]string{
+		awsup.TagClusterName: "other.example.com",
+		awsup.TagNameClusterOwnershipPrefix + "other.example.com": "owned",
+	}
+
+	tagResource := func(arn string, tags map[string]string) {
+		if _, err := tagging.TagResources(&resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: aws.StringSlice([]string{arn}),
+			Tags:            aws.StringMap(tags),
+		}); err != nil {
+			t.Fatalf("error tagging %q: %v", arn, err)
+		}
+	}
+	ec2ARN := func(resourceType, id string) string {
+		return "arn:aws-test:ec2:us-east-1:000000000000:" + resourceType + "/" + id
+	}
+
+	vpc, err := c.CreateVpcWithId(&ec2.CreateVpcInput{
+		CidrBlock:         aws.String("172.20.0.0/16"),
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeVpc, clusterTags),
+	}, "vpc-1")
+	if err != nil {
+		t.Fatalf("error creating vpc: %v", err)
+	}
+	vpcID := aws.StringValue(vpc.Vpc.VpcId)
+	tagResource(ec2ARN("vpc", vpcID), clusterTags)
+
+	for _, subnet := range []struct {
+		id   string
+		tags map[string]string
+	}{
+		{id: "subnet-1", tags: clusterTags},
+		{id: "subnet-other", tags: otherTags},
+	} {
+		if _, err := c.CreateSubnetWithId(&ec2.CreateSubnetInput{
+			VpcId:             aws.String(vpcID),
+			CidrBlock:         aws.String("172.20.32.0/19"),
+			TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeSubnet, subnet.tags),
+		}, subnet.id); err != nil {
+			t.Fatalf("error creating subnet: %v", err)
+		}
+		tagResource(ec2ARN("subnet", subnet.id), subnet.tags)
+	}
+
+	sg, err := c.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		GroupName:         aws.String("nodes." + clusterName),
+		VpcId:             aws.String(vpcID),
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeSecurityGroup, clusterTags),
+	})
+	if err != nil {
+		t.Fatalf("error creating security group: %v", err)
+	}
+	tagResource(ec2ARN("security-group", aws.StringValue(sg.GroupId)), clusterTags)
+
+	instance, err := c.RunInstances(&ec2.RunInstancesInput{
+		MinCount:          aws.Int64(1),
+		MaxCount:          aws.Int64(1),
+		SubnetId:          aws.String("subnet-1"),
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeInstance, clusterTags),
+	})
+	if err != nil {
+		t.Fatalf("error creating instance: %v", err)
+	}
+	tagResource(ec2ARN("instance", aws.StringValue(instance.Instances[0].InstanceId)), clusterTags)
+
+	volume, err := c.CreateVolume(&ec2.CreateVolumeInput{
+		AvailabilityZone:  aws.String("us-east-1a"),
+		TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeVolume, clusterTags),
+	})
+	if err != nil {
+		t.Fatalf("error creating volume: %v", err)
+	}
+	tagResource(ec2ARN("volume", aws.StringValue(volume.VolumeId)), clusterTags)
+
+	tg, err := lbs.CreateTargetGroup(&elbv2.CreateTargetGroupInput{
+		Name:  aws.String("tcp-" + clusterName),
+		VpcId: aws.String(vpcID),
+		Tags:  awsup.ELBv2Tags(clusterTags),
+	})
+	if err != nil {
+		t.Fatalf("error creating target group: %v", err)
+	}
+	tagResource(aws.StringValue(tg.TargetGroups[0].TargetGroupArn), clusterTags)
+
+	// The tagging API can still report resources that have been deleted
+	tagResource(ec2ARN("instance", "i-deleted"), clusterTags)
+
+	return cloud
+}
+
+func listResourceKeys(t *testing.T, cloud awsup.AWSCloud, clusterInfo resources.ClusterInfo) []string {
+	resourceTrackers, err := ListResourcesAWS(cloud, clusterInfo)
+	if err != nil {
+		t.Fatalf("error listing resources with %q discovery: %v", clusterInfo.Discovery, err)
+	}
+	var keys []string
+	for k := range resourceTrackers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestListResourcesAWSDiscovery(t *testing.T) {
+	clusterName := "me.example.com"
+	mockCloud := buildTaggedCluster(t, clusterName)
+	cloud := mockCloud.WithTags(map[string]string{awsup.TagClusterName: clusterName})
+
+	clusterInfo := resources.ClusterInfo{
+		Name:        clusterName,
+		UsesNoneDNS: true,
+		Discovery:   resources.DiscoveryDescribe,
+	}
+	expected := listResourceKeys(t, cloud, clusterInfo)
+	for _, prefix := range []string{"instance:", "security-group:", "subnet:subnet-1", "target-group:", "volume:", "vpc:vpc-1"} {
+		found := false
+		for _, k := range expected {
+			if strings.HasPrefix(k, prefix) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected describe discovery to find %q, got %v", prefix, expected)
+		}
+	}
+
+	for _, discovery := range []resources.Discovery{resources.DiscoveryTagging, resources.DiscoveryAuto} {
+		clusterInfo.Discovery = discovery
+		actual := listResourceKeys(t, cloud, clusterInfo)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%q discovery found different resources than describe discovery\nexpected=%v\nactual=%v", discovery, expected, actual)
+		}
+	}
+}
+
+func TestListResourcesAWSTaggingSkipsUntaggedTypes(t *testing.T) {
+	clusterName := "me.example.com"
+	mockCloud := buildTaggedCluster(t, clusterName)
+	// No load balancer is tagged, so the ELBs must not be listed
+	mockCloud.MockELB = &unusedELB{}
+	cloud := mockCloud.WithTags(map[string]string{awsup.TagClusterName: clusterName})
+
+	clusterInfo := resources.ClusterInfo{
+		Name:        clusterName,
+		UsesNoneDNS: true,
+		Discovery:   resources.DiscoveryTagging,
+	}
+	if _, err := ListResourcesAWS(cloud, clusterInfo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clusterInfo.Discovery = resources.DiscoveryDescribe
+	if _, err := ListResourcesAWS(cloud, clusterInfo); err == nil {
+		t.Fatalf("expected describe discovery to list the ELBs")
+	}
+}
+
+func TestListResourcesAWSTaggingFailure(t *testing.T) {
+	clusterName := "me.example.com"
+	mockCloud := buildTaggedCluster(t, clusterName)
+	cloud := mockCloud.WithTags(map[string]string{awsup.TagClusterName: clusterName})
+
+	clusterInfo := resources.ClusterInfo{
+		Name:        clusterName,
+		UsesNoneDNS: true,
+		Discovery:   resources.DiscoveryDescribe,
+	}
+	expected := listResourceKeys(t, cloud, clusterInfo)
+
+	mockCloud.MockResourceGroupsTagging = &failingTaggingAPI{}
+	cloud = mockCloud.WithTags(map[string]string{awsup.TagClusterName: clusterName})
+
+	clusterInfo.Discovery = resources.DiscoveryTagging
+	if _, err := ListResourcesAWS(cloud, clusterInfo); err == nil {
+		t.Fatalf("expected tagging discovery to fail when the tagging API cannot be used")
+	}
+
+	clusterInfo.Discovery = resources.DiscoveryAuto
+	actual := listResourceKeys(t, cloud, clusterInfo)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("auto discovery did not fall back to describe discovery\nexpected=%v\nactual=%v", expected, actual)
+	}
+}
@@ -16,9 +16,26 @@ limitations under the License.
 
 package resources
 
+// Discovery is the strategy used to find the cloud resources of a cluster
+type Discovery string
+
+const (
+	// DiscoveryDescribe lists every type of resource with the describe calls of its service
+	DiscoveryDescribe Discovery = "describe"
+	// DiscoveryTagging seeds the resource list from the cloud's tagging API, only describing the types of resource it reports
+	DiscoveryTagging Discovery = "tagging"
+	// DiscoveryAuto uses tagging discovery, falling back to describe discovery if the tagging API cannot be used
+	DiscoveryAuto Discovery = "auto"
+)
+
+// Discoveries lists the supported discovery strategies
+var Discoveries = []Discovery{DiscoveryDescribe, DiscoveryTagging, DiscoveryAuto}
+
 type ClusterInfo struct {
 	Name        string
 	UsesNoneDNS bool
+	// Discovery is the strategy used to find resources; the empty value means DiscoveryDescribe
+	Discovery Discovery
 	// Azure specific
 	AzureResourceGroupName   string
 	AzureResourceGroupShared bool
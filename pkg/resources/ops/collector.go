@@ -38,11 +38,17 @@ import (
 	cloudscaleway "k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 )
 
-// ListResources collects the resources from the specified cloud
-func ListResources(cloud fi.Cloud, cluster *kops.Cluster) (map[string]*resources.Resource, error) {
+// ListResources collects the resources from the specified cloud, finding them with the specified discovery
+func ListResources(cloud fi.Cloud, cluster *kops.Cluster, discovery resources.Discovery) (map[string]*resources.Resource, error) {
 	clusterInfo := resources.ClusterInfo{
 		Name:        cluster.Name,
 		UsesNoneDNS: cluster.UsesNoneDNS(),
+		Discovery:   discovery,
+	}
+
+	// Only AWS has a tagging API; auto discovery falls back to describe discovery everywhere else
+	if discovery == resources.DiscoveryTagging && cloud.ProviderID() != kops.CloudProviderAWS {
+		return nil, fmt.Errorf("discovery %q is not supported on %q", discovery, cloud.ProviderID())
 	}
 
 	switch cloud.ProviderID() {
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretscan detects obvious secrets in content that ends up readable
+// outside of the cluster, such as the user data of instances.
+// The patterns favour a low rate of false positives over completeness.
+package secretscan
+
+import (
+	"regexp"
+	"strings"
+)
+
+type pattern struct {
+	// name describes the kind of secret, for example "AWS access key ID"
+	name   string
+	regexp *regexp.Regexp
+}
+
+var patterns = []pattern{
+	{
+		name:   "AWS access key ID",
+		regexp: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		name:   "AWS secret access key",
+		regexp: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+]{40}\b`),
+	},
+	{
+		name:   "private key",
+		regexp: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----(?s:.*?)(?:-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----|\z)`),
+	},
+	{
+		name:   "bearer token",
+		regexp: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]{20,}=*`),
+	},
+	{
+		name:   "GitHub token",
+		regexp: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`),
+	},
+}
+
+// Match is a secret found in some content.
+type Match struct {
+	// Pattern describes the kind of secret, for example "AWS access key ID".
+	Pattern string
+	// Line is the line of the content on which the secret starts, counting from 1.
+	Line int
+}
+
+// Find returns the secrets found in content, in the order of the patterns.
+// The matched text itself is deliberately not returned, so that it cannot leak into errors.
+func Find(content string) []Match {
+	var matches []Match
+	for _, p := range patterns {
+		for _, loc := range p.regexp.FindAllStringIndex(content, -1) {
+			matches = append(matches, Match{
+				Pattern: p.name,
+				Line:    strings.Count(content[:loc[0]], "\n") + 1,
+			})
+		}
+	}
+	return matches
+}
+
+// Redact replaces the secrets found in content with a placeholder naming the kind of secret.
+func Redact(content string) string {
+	for _, p := range patterns {
+		content = p.regexp.ReplaceAllLiteralString(content, "[redacted "+p.name+"]")
+	}
+	return content
+}
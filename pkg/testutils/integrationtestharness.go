@@ -265,16 +265,23 @@ func (h *IntegrationTestHarness) SetupMockAWS() *awsup.MockAWSCloud {
 		Name: aws.String("my-external-tg-3"),
 	})
 
-	mockIAM.CreateRole(&iam.CreateRoleInput{
-		RoleName: aws.String("kops-custom-node-role"),
-	})
-	mockIAM.CreateInstanceProfile(&iam.CreateInstanceProfileInput{
-		InstanceProfileName: aws.String("kops-custom-node-role"),
-	})
-	mockIAM.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
-		InstanceProfileName: aws.String("kops-custom-node-role"),
-		RoleName:            aws.String("kops-custom-node-role"),
-	})
+	for _, name := range []string{"kops-custom-master-role", "kops-custom-node-role"} {
+		mockIAM.CreateRole(&iam.CreateRoleInput{
+			RoleName: aws.String(name),
+		})
+		mockIAM.PutRolePolicy(&iam.PutRolePolicyInput{
+			RoleName:       aws.String(name),
+			PolicyName:     aws.String(name),
+			PolicyDocument: aws.String(`{"Statement": [{"Effect": "Allow", "Action": "*", "Resource": "*"}]}`),
+		})
+		mockIAM.CreateInstanceProfile(&iam.CreateInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+		})
+		mockIAM.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			RoleName:            aws.String(name),
+		})
+	}
 
 	return cloud
 }
@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package timings records how long tasks and phases take, and how many cloud API calls are made,
+// so that performance regressions can be spotted.
+package timings
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// Recorder collects timings and API call counts. It is safe for concurrent use.
+type Recorder struct {
+	mutex    sync.Mutex
+	phases   map[string]*Timing
+	tasks    map[string]*Timing
+	apiCalls map[apiCallKey]int64
+}
+
+type apiCallKey struct {
+	service   string
+	operation string
+}
+
+// Timing is the total wall time of a task or phase, which can run several times.
+type Timing struct {
+	Name     string        `json:"name"`
+	Count    int64         `json:"count"`
+	Duration time.Duration `json:"durationNanoseconds"`
+}
+
+// APICallCount is the number of calls made to a cloud API operation.
+type APICallCount struct {
+	Service   string `json:"service"`
+	Operation string `json:"operation"`
+	Count     int64  `json:"count"`
+}
+
+// Summary is a snapshot of a Recorder, sorted by decreasing duration or count.
+type Summary struct {
+	Phases   []Timing       `json:"phases"`
+	Tasks    []Timing       `json:"tasks"`
+	APICalls []APICallCount `json:"apiCalls"`
+}
+
+// active is the Recorder that the Record functions report to; recording is disabled when it is nil.
+var active atomic.Pointer[Recorder]
+
+// NewRecorder builds an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		phases:   make(map[string]*Timing),
+		tasks:    make(map[string]*Timing),
+		apiCalls: make(map[apiCallKey]int64),
+	}
+}
+
+// Start makes r the recorder that the Record functions report to, and returns a function that stops recording.
+func Start(r *Recorder) func() {
+	active.Store(r)
+	return func() {
+		active.CompareAndSwap(r, nil)
+	}
+}
+
+// Enabled returns true if a recorder is active.
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// RecordPhase adds the wall time of a phase to the active recorder, if any.
+func RecordPhase(name string, d time.Duration) {
+	if r := active.Load(); r != nil {
+		r.RecordPhase(name, d)
+	}
+}
+
+// StartPhase starts timing a phase, and returns a function that records its wall time.
+func StartPhase(name string) func() {
+	start := time.Now()
+	return func() {
+		RecordPhase(name, time.Since(start))
+	}
+}
+
+// RecordTask adds the wall time of a task to the active recorder, if any.
+func RecordTask(name string, d time.Duration) {
+	if r := active.Load(); r != nil {
+		r.RecordTask(name, d)
+	}
+}
+
+// RecordAPICall counts a call to a cloud API operation in the active recorder, if any.
+func RecordAPICall(service, operation string) {
+	if r := active.Load(); r != nil {
+		r.RecordAPICall(service, operation)
+	}
+}
+
+// RecordPhase adds the wall time of a phase.
+func (r *Recorder) RecordPhase(name string, d time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	addTiming(r.phases, name, d)
+}
+
+// RecordTask adds the wall time of a task.
+func (r *Recorder) RecordTask(name string, d time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	addTiming(r.tasks, name, d)
+}
+
+// RecordAPICall counts a call to a cloud API operation.
+func (r *Recorder) RecordAPICall(service, operation string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.apiCalls[apiCallKey{service: service, operation: operation}]++
+}
+
+func addTiming(timings map[string]*Timing, name string, d time.Duration) {
+	t := timings[name]
+	if t == nil {
+		t = &Timing{Name: name}
+		timings[name] = t
+	}
+	t.Count++
+	t.Duration += d
+}
+
+// APICalls returns the number of calls made to each operation of a service.
+func (r *Recorder) APICalls(service string) map[string]int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	calls := make(map[string]int64)
+	for k, n := range r.apiCalls {
+		if k.service == service {
+			calls[k.operation] = n
+		}
+	}
+	return calls
+}
+
+// Summary returns the recorded data, with the slowest tasks and phases and the most called operations first.
+func (r *Recorder) Summary() *Summary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	s := &Summary{
+		Phases: sortedTimings(r.phases),
+		Tasks:  sortedTimings(r.tasks),
+	}
+	for k, n := range r.apiCalls {
+		s.APICalls = append(s.APICalls, APICallCount{Service: k.service, Operation: k.operation, Count: n})
+	}
+	sort.Slice(s.APICalls, func(i, j int) bool {
+		if s.APICalls[i].Count != s.APICalls[j].Count {
+			return s.APICalls[i].Count > s.APICalls[j].Count
+		}
+		if s.APICalls[i].Service != s.APICalls[j].Service {
+			return s.APICalls[i].Service < s.APICalls[j].Service
+		}
+		return s.APICalls[i].Operation < s.APICalls[j].Operation
+	})
+	return s
+}
+
+func sortedTimings(timings map[string]*Timing) []Timing {
+	var sorted []Timing
+	for _, t := range timings {
+		sorted = append(sorted, *t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Duration != sorted[j].Duration {
+			return sorted[i].Duration > sorted[j].Duration
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// Print writes the summary as tables, separated by blank lines.
+func (s *Summary) Print(out io.Writer) error {
+	var sections []func(w io.Writer)
+	for _, section := range []struct {
+		title   string
+		timings []Timing
+	}{
+		{title: "PHASE", timings: s.Phases},
+		{title: "TASK", timings: s.Tasks},
+	} {
+		if len(section.timings) == 0 {
+			continue
+		}
+		section := section
+		sections = append(sections, func(w io.Writer) {
+			fmt.Fprintf(w, "%s\tRUNS\tDURATION\n", section.title)
+			for _, t := range section.timings {
+				fmt.Fprintf(w, "%s\t%d\t%v\n", t.Name, t.Count, t.Duration.Round(time.Millisecond))
+			}
+		})
+	}
+	if len(s.APICalls) != 0 {
+		sections = append(sections, func(w io.Writer) {
+			fmt.Fprintf(w, "API CALL\tCOUNT\n")
+			for _, c := range s.APICalls {
+				fmt.Fprintf(w, "%s.%s\t%d\n", c.Service, c.Operation, c.Count)
+			}
+		})
+	}
+
+	for i, section := range sections {
+		if i != 0 {
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+		section(w)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the summary as JSON.
+func (s *Summary) WriteJSON(out io.Writer) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling timings to JSON: %w", err)
+	}
+	if _, err := out.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("error writing timings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timings
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordWithoutRecorder(t *testing.T) {
+	if Enabled() {
+		t.Fatalf("expected no active recorder")
+	}
+	// Must not panic
+	RecordAPICall("ec2", "DescribeInstances")
+	RecordTask("task", time.Second)
+	StartPhase("phase")()
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	r := NewRecorder()
+	stop := Start(r)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				RecordAPICall("ec2", "DescribeInstances")
+				RecordTask("task", time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	stop()
+
+	// Not recorded, the recorder was stopped
+	RecordAPICall("ec2", "DescribeInstances")
+
+	if calls := r.APICalls("ec2"); calls["DescribeInstances"] != 1000 {
+		t.Errorf("expected 1000 calls, got %v", calls)
+	}
+	summary := r.Summary()
+	expected := []Timing{{Name: "task", Count: 1000, Duration: time.Second}}
+	if !reflect.DeepEqual(summary.Tasks, expected) {
+		t.Errorf("unexpected task timings: %v", summary.Tasks)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	r := NewRecorder()
+	r.RecordPhase("RunTasks", 3*time.Second)
+	r.RecordPhase("BuildTasks", 500*time.Millisecond)
+	r.RecordTask("SecurityGroup/nodes", time.Second)
+	r.RecordTask("SecurityGroup/nodes", 2*time.Second)
+	r.RecordTask("VPC/main", 1500*time.Millisecond)
+	r.RecordAPICall("ec2", "DescribeVpcs")
+	r.RecordAPICall("ec2", "DescribeSecurityGroups")
+	r.RecordAPICall("ec2", "DescribeSecurityGroups")
+	r.RecordAPICall("iam", "GetRole")
+
+	summary := r.Summary()
+
+	var out bytes.Buffer
+	if err := summary.Print(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "" +
+		"PHASE      RUNS DURATION\n" +
+		"RunTasks   1    3s\n" +
+		"BuildTasks 1    500ms\n" +
+		"\n" +
+		"TASK                RUNS DURATION\n" +
+		"SecurityGroup/nodes 2    3s\n" +
+		"VPC/main            1    1.5s\n" +
+		"\n" +
+		"API CALL                   COUNT\n" +
+		"ec2.DescribeSecurityGroups 2\n" +
+		"ec2.DescribeVpcs           1\n" +
+		"iam.GetRole                1\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output:\nexpected:\n%s\nactual:\n%s", expected, out.String())
+	}
+
+	var b bytes.Buffer
+	if err := summary.WriteJSON(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Summary
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("error parsing JSON: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, summary) {
+		t.Errorf("JSON did not round trip:\nexpected: %v\nactual:   %v", summary, decoded)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/urls"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// DefaultEtcdBackupMaxAge is how old the latest backup of an etcd cluster can be before it is reported.
+// etcd-manager takes a backup every 15 minutes.
+const DefaultEtcdBackupMaxAge = 2 * time.Hour
+
+// EtcdBackupStatus is the latest backup of an etcd cluster found in its backup store.
+type EtcdBackupStatus struct {
+	// EtcdCluster is the name of the etcd cluster
+	EtcdCluster string
+	// BackupStore is the path where etcd-manager writes the backups of the etcd cluster
+	BackupStore vfs.Path
+	// Latest is the time of the most recent backup, or the zero time if there is none
+	Latest time.Time
+	// Err is set if the backup store could not be listed
+	Err error
+}
+
+// Check returns an error if the backup store could not be listed, holds no backup,
+// or its latest backup is older than maxAge.
+func (s *EtcdBackupStatus) Check(now time.Time, maxAge time.Duration) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	if s.Latest.IsZero() {
+		return fmt.Errorf("no backups found in %q", s.BackupStore)
+	}
+	if age := now.Sub(s.Latest); age > maxAge {
+		return fmt.Errorf("latest backup in %q is %v old (taken at %s), older than %v", s.BackupStore, age.Round(time.Minute), s.Latest.Format(time.RFC3339), maxAge)
+	}
+	return nil
+}
+
+// FindEtcdBackups finds the latest backup of each etcd cluster of the cluster.
+// Backup stores that cannot be listed are reported in the Err of their status.
+func FindEtcdBackups(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster) []*EtcdBackupStatus {
+	var backups []*EtcdBackupStatus
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+		status := &EtcdBackupStatus{EtcdCluster: etcdCluster.Name}
+		status.BackupStore, status.Err = EtcdBackupStore(vfsContext, cluster, etcdCluster)
+		if status.Err == nil {
+			status.Latest, status.Err = FindLatestEtcdBackup(ctx, status.BackupStore)
+		}
+		backups = append(backups, status)
+	}
+	return backups
+}
+
+// EtcdBackupStore returns the path where etcd-manager writes the backups of the etcd cluster.
+func EtcdBackupStore(vfsContext *vfs.VFSContext, cluster *kops.Cluster, etcdCluster *kops.EtcdClusterSpec) (vfs.Path, error) {
+	backupStore := ""
+	if etcdCluster.Backups != nil {
+		backupStore = etcdCluster.Backups.BackupStore
+	}
+	if backupStore == "" {
+		backupStore = urls.Join(cluster.Spec.ConfigStore.Base, "backups", "etcd", etcdCluster.Name)
+	}
+	p, err := vfsContext.BuildVfsPath(backupStore)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing etcd backup store %q: %w", backupStore, err)
+	}
+	return p, nil
+}
+
+// FindLatestEtcdBackup returns the time of the most recent backup in the backup store, or the zero time if there is none.
+// etcd-manager names each backup directory after the time it was taken, e.g. 2024-01-02T03:04:05Z-000001.
+// The backup store is only listed, never written.
+func FindLatestEtcdBackup(ctx context.Context, backupStore vfs.Path) (time.Time, error) {
+	files, err := backupStore.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("listing backups in %q: %w", backupStore, err)
+	}
+
+	var latest time.Time
+	for _, file := range files {
+		relativePath, err := vfs.RelativePath(backupStore, file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		name, _, found := strings.Cut(relativePath, "/")
+		if !found {
+			continue
+		}
+		i := strings.LastIndex(name, "-")
+		if i == -1 {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, name[:i])
+		if err != nil {
+			klog.V(4).Infof("ignoring %q in backup store, not a backup", name)
+			continue
+		}
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest, nil
+}
+
+// ValidateEtcdBackups adds a warning for each etcd cluster whose backup store cannot be listed,
+// holds no backup, or whose latest backup is older than maxAge.
+// Backups are only warned about, because a new cluster has none until etcd-manager takes the first one.
+func (v *ValidationCluster) ValidateEtcdBackups(backups []*EtcdBackupStatus, now time.Time, maxAge time.Duration) {
+	for _, backup := range backups {
+		if err := backup.Check(now, maxAge); err != nil {
+			v.Warnings = append(v.Warnings, &ValidationError{
+				Kind:     "EtcdBackup",
+				Name:     backup.EtcdCluster,
+				Message:  fmt.Sprintf("etcd cluster %q is not backed up: %v", backup.EtcdCluster, err),
+				Category: ValidationCategoryEtcdBackup,
+			})
+		}
+	}
+}
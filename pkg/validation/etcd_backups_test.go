@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func writeTestEtcdBackups(t *testing.T, ctx context.Context, vfsContext *vfs.VFSContext, backupStore string, backups ...string) {
+	p, err := vfsContext.BuildVfsPath(backupStore)
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	// etcd-manager also keeps its control files in the backup store
+	if err := p.Join("control", "etcd-cluster-spec").WriteFile(ctx, bytes.NewReader(nil), nil); err != nil {
+		t.Fatalf("error writing control file: %v", err)
+	}
+	for _, backup := range backups {
+		for _, file := range []string{"_etcd_backup.meta", "etcd.backup.gz"} {
+			if err := p.Join(backup, file).WriteFile(ctx, bytes.NewReader(nil), nil); err != nil {
+				t.Fatalf("error writing backup: %v", err)
+			}
+		}
+	}
+}
+
+func Test_ValidateEtcdBackups(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	vfsContext := vfs.NewTestingVFSContext()
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			ConfigStore: kops.ConfigStoreSpec{Base: "memfs://clusters.example.com/test"},
+			EtcdClusters: []kops.EtcdClusterSpec{
+				{Name: "main"},
+				{Name: "events", Backups: &kops.EtcdBackupSpec{BackupStore: "memfs://backups/events"}},
+				{Name: "cilium"},
+			},
+		},
+	}
+
+	// main has a fresh backup, events only stale ones, and cilium none
+	writeTestEtcdBackups(t, ctx, vfsContext, "memfs://clusters.example.com/test/backups/etcd/main", "2024-04-30T12:00:00Z-000001", "2024-05-01T11:45:00Z-000002")
+	writeTestEtcdBackups(t, ctx, vfsContext, "memfs://backups/events", "2024-04-30T08:00:00Z-000001", "2024-04-30T09:00:00Z-000002")
+
+	backups := FindEtcdBackups(ctx, vfsContext, cluster)
+	if len(backups) != 3 {
+		t.Fatalf("expected a backup status for each etcd cluster, got %v", backups)
+	}
+	for _, backup := range backups {
+		if backup.Err != nil {
+			t.Errorf("unexpected error listing backups of %q: %v", backup.EtcdCluster, backup.Err)
+		}
+	}
+	assert.Equal(t, time.Date(2024, 5, 1, 11, 45, 0, 0, time.UTC), backups[0].Latest, "latest backup of main")
+	assert.Equal(t, time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC), backups[1].Latest, "latest backup of events")
+	assert.True(t, backups[2].Latest.IsZero(), "latest backup of cilium")
+
+	v := &ValidationCluster{}
+	v.ValidateEtcdBackups(backups, now, DefaultEtcdBackupMaxAge)
+
+	assert.Empty(t, v.Failures, "failures")
+	assert.Equal(t, []*ValidationError{
+		{
+			Kind:     "EtcdBackup",
+			Name:     "events",
+			Message:  "etcd cluster \"events\" is not backed up: latest backup in \"memfs://backups/events\" is 27h0m0s old (taken at 2024-04-30T09:00:00Z), older than 2h0m0s",
+			Category: ValidationCategoryEtcdBackup,
+		},
+		{
+			Kind:     "EtcdBackup",
+			Name:     "cilium",
+			Message:  "etcd cluster \"cilium\" is not backed up: no backups found in \"memfs://clusters.example.com/test/backups/etcd/cilium\"",
+			Category: ValidationCategoryEtcdBackup,
+		},
+	}, v.Warnings, "warnings")
+}
+
+func Test_FindLatestEtcdBackupIsReadOnly(t *testing.T) {
+	ctx := context.TODO()
+
+	vfsContext := vfs.NewTestingVFSContext()
+	writeTestEtcdBackups(t, ctx, vfsContext, "memfs://backups/main", "2024-05-01T11:45:00Z-000001")
+
+	// memfs creates the nodes of a path when it is built, so we build all the paths before listing the files
+	var backupStores []vfs.Path
+	for _, p := range []string{"memfs://backups", "memfs://backups/main", "memfs://backups/missing"} {
+		path, err := vfsContext.BuildVfsPath(p)
+		if err != nil {
+			t.Fatalf("error building path: %v", err)
+		}
+		backupStores = append(backupStores, path)
+	}
+	listFiles := func() []string {
+		files, err := backupStores[0].ReadTree(ctx)
+		if err != nil {
+			t.Fatalf("error listing backup store: %v", err)
+		}
+		var paths []string
+		for _, file := range files {
+			paths = append(paths, file.Path())
+		}
+		return paths
+	}
+
+	before := listFiles()
+	for _, backupStore := range backupStores[1:] {
+		if _, err := FindLatestEtcdBackup(ctx, backupStore); err != nil {
+			t.Fatalf("unexpected error listing %q: %v", backupStore, err)
+		}
+	}
+	assert.ElementsMatch(t, before, listFiles(), "files in the backup store")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const (
+	// DefaultKeypairExpiryWarning is how long before the primary certificate of a keyset expires that validation warns about it
+	DefaultKeypairExpiryWarning = 90 * 24 * time.Hour
+	// DefaultKeypairExpiryFailure is how long before the primary certificate of a keyset expires that validation fails
+	DefaultKeypairExpiryFailure = 14 * 24 * time.Hour
+)
+
+// ValidateKeypairExpiry adds a warning for each keyset whose primary certificate expires within warnWithin of now,
+// and a failure instead if it expires within failWithin.
+func (v *ValidationCluster) ValidateKeypairExpiry(keypairs []*fi.KeypairMetadata, now time.Time, warnWithin, failWithin time.Duration) {
+	for _, keypair := range keypairs {
+		if !keypair.IsPrimary || keypair.DistrustTimestamp != nil {
+			continue
+		}
+		notAfter := keypair.NotAfter()
+		if notAfter == nil {
+			continue
+		}
+
+		remaining := notAfter.Sub(now)
+		if remaining >= warnWithin && remaining >= failWithin {
+			continue
+		}
+
+		var message string
+		if remaining <= 0 {
+			message = fmt.Sprintf("primary certificate of keyset %q expired on %s", keypair.Keyset, notAfter.Format("2006-01-02"))
+		} else {
+			message = fmt.Sprintf("primary certificate of keyset %q expires on %s, in %d days", keypair.Keyset, notAfter.Format("2006-01-02"), int(remaining.Hours()/24))
+		}
+		if len(keypair.UsedBy) != 0 {
+			message += fmt.Sprintf("; it is used by %s", strings.Join(keypair.UsedBy, ", "))
+		}
+		message += ". Rotate it with \"kops create keypair\" and \"kops promote keypair\"."
+
+		failure := &ValidationError{
+			Kind:     "Keypair",
+			Name:     keypair.Keyset,
+			Message:  message,
+			Category: ValidationCategoryKeypairExpiry,
+		}
+		if remaining < failWithin {
+			v.addError(failure)
+		} else {
+			v.Warnings = append(v.Warnings, failure)
+		}
+	}
+}
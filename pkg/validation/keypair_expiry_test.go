@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func testKeypair(keyset string, primary bool, notAfter time.Time) *fi.KeypairMetadata {
+	return &fi.KeypairMetadata{
+		Keyset:      keyset,
+		Id:          "1",
+		IsPrimary:   primary,
+		Certificate: &pki.Certificate{Certificate: &x509.Certificate{NotAfter: notAfter}},
+		UsedBy:      fi.KeysetConsumers(keyset),
+	}
+}
+
+func Test_ValidateKeypairExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	distrusted := testKeypair("apiserver-aggregator-ca", true, now.Add(-day))
+	distrusted.DistrustTimestamp = &now
+
+	keypairs := []*fi.KeypairMetadata{
+		testKeypair("kubernetes-ca", true, now.Add(3650*day)),
+		testKeypair("service-account", true, now.Add(60*day)),
+		testKeypair("etcd-manager-ca-main", true, now.Add(10*day)),
+		testKeypair("etcd-clients-ca", true, now.Add(-2*day)),
+		// A secondary keypair that is about to expire does not matter once the primary is rotated
+		testKeypair("etcd-peers-ca-main", false, now.Add(day)),
+		distrusted,
+		{Keyset: "no-certificate", Id: "1", IsPrimary: true},
+	}
+
+	v := &ValidationCluster{}
+	v.ValidateKeypairExpiry(keypairs, now, DefaultKeypairExpiryWarning, DefaultKeypairExpiryFailure)
+
+	assert.Equal(t, []*ValidationError{
+		{
+			Kind:     "Keypair",
+			Name:     "service-account",
+			Message:  "primary certificate of keyset \"service-account\" expires on 2024-07-31, in 60 days; it is used by kube-apiserver, kube-controller-manager. Rotate it with \"kops create keypair\" and \"kops promote keypair\".",
+			Category: ValidationCategoryKeypairExpiry,
+		},
+	}, v.Warnings, "warnings")
+	assert.Equal(t, []*ValidationError{
+		{
+			Kind:     "Keypair",
+			Name:     "etcd-manager-ca-main",
+			Message:  "primary certificate of keyset \"etcd-manager-ca-main\" expires on 2024-06-11, in 10 days; it is used by etcd-manager. Rotate it with \"kops create keypair\" and \"kops promote keypair\".",
+			Category: ValidationCategoryKeypairExpiry,
+		},
+		{
+			Kind:     "Keypair",
+			Name:     "etcd-clients-ca",
+			Message:  "primary certificate of keyset \"etcd-clients-ca\" expired on 2024-05-30; it is used by etcd-manager, kube-apiserver. Rotate it with \"kops create keypair\" and \"kops promote keypair\".",
+			Category: ValidationCategoryKeypairExpiry,
+		},
+	}, v.Failures, "failures")
+}
+
+func Test_ValidateKeypairExpiryWindows(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	keypairs := []*fi.KeypairMetadata{
+		testKeypair("kubernetes-ca", true, now.Add(60*24*time.Hour)),
+	}
+
+	v := &ValidationCluster{}
+	v.ValidateKeypairExpiry(keypairs, now, 30*24*time.Hour, 7*24*time.Hour)
+	assert.Empty(t, v.Warnings, "warnings")
+	assert.Empty(t, v.Failures, "failures")
+
+	v = &ValidationCluster{}
+	v.ValidateKeypairExpiry(keypairs, now, 30*24*time.Hour, 90*24*time.Hour)
+	assert.Empty(t, v.Warnings, "warnings")
+	assert.Len(t, v.Failures, 1, "failures")
+}
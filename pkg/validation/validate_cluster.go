@@ -40,15 +40,45 @@ import (
 // ValidationCluster uses a cluster to validate.
 type ValidationCluster struct {
 	Failures []*ValidationError `json:"failures,omitempty"`
+	// Warnings are problems that will need attention, but do not fail validation
+	Warnings []*ValidationError `json:"warnings,omitempty"`
 
 	Nodes []*ValidationNode `json:"nodes,omitempty"`
+	// InstanceGroups are the target and actual sizes of the instance groups
+	InstanceGroups []*ValidationInstanceGroup `json:"instanceGroups,omitempty"`
 }
 
+// ValidationCategory identifies the check that reported a ValidationError
+type ValidationCategory string
+
+const (
+	// ValidationCategoryDNS reports that the API DNS record has not been updated from the placeholder address
+	ValidationCategoryDNS ValidationCategory = "DNS"
+	// ValidationCategoryInstanceGroupMissing reports an instance group that is missing from the cloud provider
+	ValidationCategoryInstanceGroupMissing ValidationCategory = "InstanceGroupMissing"
+	// ValidationCategoryInstanceGroupUnderReplicated reports an instance group with fewer instances than its target size
+	ValidationCategoryInstanceGroupUnderReplicated ValidationCategory = "InstanceGroupUnderReplicated"
+	// ValidationCategoryMachineNotJoined reports an instance that has not registered as a node
+	ValidationCategoryMachineNotJoined ValidationCategory = "MachineNotJoined"
+	// ValidationCategoryNodeNotReady reports a node that is not ready
+	ValidationCategoryNodeNotReady ValidationCategory = "NodeNotReady"
+	// ValidationCategoryControlPlanePodMissing reports a control plane node missing one of its static pods
+	ValidationCategoryControlPlanePodMissing ValidationCategory = "ControlPlanePodMissing"
+	// ValidationCategorySystemPodFailing reports a system-critical pod that is pending, in an unknown phase or not ready
+	ValidationCategorySystemPodFailing ValidationCategory = "SystemPodFailing"
+	// ValidationCategoryKeypairExpiry reports a primary keypair certificate that expires soon
+	ValidationCategoryKeypairExpiry ValidationCategory = "KeypairExpiry"
+	// ValidationCategoryEtcdBackup reports an etcd cluster whose backup store cannot be listed or has no recent backup
+	ValidationCategoryEtcdBackup ValidationCategory = "EtcdBackup"
+)
+
 // ValidationError holds a validation failure
 type ValidationError struct {
 	Kind    string `json:"type,omitempty"`
 	Name    string `json:"name,omitempty"`
 	Message string `json:"message,omitempty"`
+	// Category identifies the check that reported the failure
+	Category ValidationCategory `json:"category,omitempty"`
 	// The InstanceGroup field is used to indicate which instance group this validation error is coming from
 	InstanceGroup *kops.InstanceGroup `json:"instanceGroup,omitempty"`
 }
@@ -79,6 +109,22 @@ type ValidationNode struct {
 	Status   v1.ConditionStatus `json:"status,omitempty"`
 }
 
+// ValidationInstanceGroup holds the target and actual sizes of an instance group
+type ValidationInstanceGroup struct {
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"`
+	// TargetSize is the number of instances the cloud provider is asked to run
+	TargetSize int `json:"targetSize"`
+	// Instances is the number of instances, not counting detached instances
+	Instances int `json:"instances"`
+	// Nodes is the number of those instances that registered as nodes
+	Nodes int `json:"nodes"`
+	// ReadyNodes is the number of those nodes that are ready
+	ReadyNodes int `json:"readyNodes"`
+	// Missing is true if the instance group is missing from the cloud provider
+	Missing bool `json:"missing,omitempty"`
+}
+
 // hasPlaceHolderIP checks if the API DNS has been updated.
 func hasPlaceHolderIP(host string) (string, error) {
 	apiAddr, err := url.Parse(host)
@@ -146,9 +192,10 @@ func (v *clusterValidatorImpl) Validate() (*ValidationCluster, error) {
 				"  The protokube container and %[1]v deployment logs may contain more diagnostic information."+
 				"  Etcd and the API DNS entries must be updated for a kops Kubernetes cluster to start.", dnsProvider, hasPlaceHolderIPAddress)
 			validation.addError(&ValidationError{
-				Kind:    "dns",
-				Name:    "apiserver",
-				Message: message,
+				Kind:     "dns",
+				Name:     "apiserver",
+				Message:  message,
+				Category: ValidationCategoryDNS,
 			})
 			return validation, nil
 		}
@@ -227,6 +274,7 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 				Kind:          "Pod",
 				Name:          pod.Namespace + "/" + pod.Name,
 				Message:       fmt.Sprintf("%s pod %q is pending", priority, pod.Name),
+				Category:      ValidationCategorySystemPodFailing,
 				InstanceGroup: podNode,
 			})
 			return nil
@@ -236,6 +284,7 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 				Kind:          "Pod",
 				Name:          pod.Namespace + "/" + pod.Name,
 				Message:       fmt.Sprintf("%s pod %q is unknown phase", priority, pod.Name),
+				Category:      ValidationCategorySystemPodFailing,
 				InstanceGroup: podNode,
 			})
 			return nil
@@ -251,6 +300,7 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 				Kind:          "Pod",
 				Name:          pod.Namespace + "/" + pod.Name,
 				Message:       fmt.Sprintf("%s pod %q is not ready (%s)", priority, pod.Name, strings.Join(notready, ",")),
+				Category:      ValidationCategorySystemPodFailing,
 				InstanceGroup: podNode,
 			})
 		}
@@ -266,6 +316,7 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 				Kind:          "Node",
 				Name:          node,
 				Message:       fmt.Sprintf("control-plane node %q is missing %s pod", node, app),
+				Category:      ValidationCategoryControlPlanePodMissing,
 				InstanceGroup: nodeInstanceGroupMapping[node],
 			})
 		}
@@ -285,12 +336,26 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 		allMembers = append(allMembers, cloudGroup.NeedUpdate...)
 
 		groupsSeen[cloudGroup.InstanceGroup.Name] = true
+		group := &ValidationInstanceGroup{
+			Name:       cloudGroup.InstanceGroup.Name,
+			Role:       cloudGroup.InstanceGroup.Spec.Role.ToLowerString(),
+			TargetSize: cloudGroup.TargetSize,
+		}
+		v.InstanceGroups = append(v.InstanceGroups, group)
+
 		numNodes := 0
 		for _, m := range allMembers {
 			if m.Status != cloudinstances.CloudInstanceStatusDetached {
 				numNodes++
+				if m.Node != nil {
+					group.Nodes++
+					if isNodeReady(m.Node) {
+						group.ReadyNodes++
+					}
+				}
 			}
 		}
+		group.Instances = numNodes
 		if numNodes < cloudGroup.TargetSize {
 			v.addError(&ValidationError{
 				Kind: "InstanceGroup",
@@ -299,6 +364,7 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 					cloudGroup.InstanceGroup.Name,
 					numNodes,
 					cloudGroup.TargetSize),
+				Category:      ValidationCategoryInstanceGroupUnderReplicated,
 				InstanceGroup: cloudGroup.InstanceGroup,
 			})
 		}
@@ -325,6 +391,7 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 						Kind:          "Machine",
 						Name:          member.ID,
 						Message:       fmt.Sprintf("machine %q has not yet joined cluster", member.ID),
+						Category:      ValidationCategoryMachineNotJoined,
 						InstanceGroup: cloudGroup.InstanceGroup,
 					})
 				}
@@ -361,6 +428,7 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 						Kind:          "Node",
 						Name:          node.Name,
 						Message:       fmt.Sprintf("node %q of role %q is not ready", node.Name, n.Role),
+						Category:      ValidationCategoryNodeNotReady,
 						InstanceGroup: cloudGroup.InstanceGroup,
 					})
 				}
@@ -379,10 +447,19 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 				Kind:          "InstanceGroup",
 				Name:          ig.Name,
 				Message:       fmt.Sprintf("InstanceGroup %q is missing from the cloud provider", ig.Name),
+				Category:      ValidationCategoryInstanceGroupMissing,
 				InstanceGroup: ig,
 			})
+			v.InstanceGroups = append(v.InstanceGroups, &ValidationInstanceGroup{
+				Name:    ig.Name,
+				Role:    ig.Spec.Role.ToLowerString(),
+				Missing: true,
+			})
 		}
 	}
+	sort.Slice(v.InstanceGroups, func(i, j int) bool {
+		return v.InstanceGroups[i].Name < v.InstanceGroups[j].Name
+	})
 
 	return readyNodes, nodeInstanceGroupMapping
 }
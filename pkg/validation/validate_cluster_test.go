@@ -165,10 +165,14 @@ func Test_ValidateCloudGroupMissing(t *testing.T) {
 			Kind:          "InstanceGroup",
 			Name:          "node-1",
 			Message:       "InstanceGroup \"node-1\" is missing from the cloud provider",
+			Category:      ValidationCategoryInstanceGroupMissing,
 			InstanceGroup: &instanceGroups[0],
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
+	assert.Equal(t, []*ValidationInstanceGroup{
+		{Name: "node-1", Role: "node", Missing: true},
+	}, v.InstanceGroups)
 }
 
 func Test_ValidateNodesNotEnough(t *testing.T) {
@@ -219,10 +223,14 @@ func Test_ValidateNodesNotEnough(t *testing.T) {
 			Kind:          "InstanceGroup",
 			Name:          "node-1",
 			Message:       "InstanceGroup \"node-1\" did not have enough nodes 2 vs 3",
+			Category:      ValidationCategoryInstanceGroupUnderReplicated,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
+	assert.Equal(t, []*ValidationInstanceGroup{
+		{Name: "node-1", Role: "node", TargetSize: 3, Instances: 2, Nodes: 2, ReadyNodes: 2},
+	}, v.InstanceGroups)
 }
 
 func Test_ValidateDetachedNodesDontCount(t *testing.T) {
@@ -274,10 +282,14 @@ func Test_ValidateDetachedNodesDontCount(t *testing.T) {
 			Kind:          "InstanceGroup",
 			Name:          "node-1",
 			Message:       "InstanceGroup \"node-1\" did not have enough nodes 1 vs 2",
+			Category:      ValidationCategoryInstanceGroupUnderReplicated,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
+	assert.Equal(t, []*ValidationInstanceGroup{
+		{Name: "node-1", Role: "node", TargetSize: 2, Instances: 1, Nodes: 1, ReadyNodes: 1},
+	}, v.InstanceGroups)
 }
 
 func Test_ValidateNodeNotReady(t *testing.T) {
@@ -328,10 +340,14 @@ func Test_ValidateNodeNotReady(t *testing.T) {
 			Kind:          "Node",
 			Name:          "node-1b",
 			Message:       "node \"node-1b\" of role \"node\" is not ready",
+			Category:      ValidationCategoryNodeNotReady,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
+	assert.Equal(t, []*ValidationInstanceGroup{
+		{Name: "node-1", Role: "node", TargetSize: 2, Instances: 2, Nodes: 2, ReadyNodes: 1},
+	}, v.InstanceGroups)
 }
 
 func Test_ValidateMastersNotEnough(t *testing.T) {
@@ -382,6 +398,7 @@ func Test_ValidateMastersNotEnough(t *testing.T) {
 			Kind:          "InstanceGroup",
 			Name:          "master-1",
 			Message:       "InstanceGroup \"master-1\" did not have enough nodes 2 vs 3",
+			Category:      ValidationCategoryInstanceGroupUnderReplicated,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		}, v.Failures[0]) {
 		printDebug(t, v)
@@ -436,6 +453,7 @@ func Test_ValidateMasterNotReady(t *testing.T) {
 			Kind:          "Node",
 			Name:          "master-1b",
 			Message:       "node \"master-1b\" of role \"control-plane\" is not ready",
+			Category:      ValidationCategoryNodeNotReady,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		}, v.Failures[0]) {
 		printDebug(t, v)
@@ -524,6 +542,7 @@ func Test_ValidateMasterStaticPods(t *testing.T) {
 			Kind:          "Node",
 			Name:          "master-1c",
 			Message:       "node \"master-1c\" of role \"control-plane\" is not ready",
+			Category:      ValidationCategoryNodeNotReady,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		},
 	}
@@ -556,6 +575,7 @@ func Test_ValidateMasterStaticPods(t *testing.T) {
 			Kind:          "Node",
 			Name:          "master-1b",
 			Message:       "control-plane node \"master-1b\" is missing " + pod + " pod",
+			Category:      ValidationCategoryControlPlanePodMissing,
 			InstanceGroup: groups["node-1"].InstanceGroup,
 		})
 	}
@@ -744,6 +764,7 @@ func Test_ValidatePodFailure(t *testing.T) {
 							Kind:          "Pod",
 							Name:          fmt.Sprintf("%s/pod1", namespace),
 							Message:       fmt.Sprintf("system-%s-critical pod \"pod1\" is %s", priority, tc.expected),
+							Category:      ValidationCategorySystemPodFailing,
 							InstanceGroup: podInstanceGroup,
 						}
 
@@ -901,3 +922,39 @@ func Test_ValidateDetachedNodesNotValidated(t *testing.T) {
 		printDebug(t, v)
 	}
 }
+
+func Test_ValidateInstanceGroupsSorted(t *testing.T) {
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	for _, name := range []string{"node-b", "node-a"} {
+		groups[name] = &cloudinstances.CloudInstanceGroup{
+			InstanceGroup: &kopsapi.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: kopsapi.InstanceGroupSpec{
+					Role: kopsapi.InstanceGroupRoleNode,
+				},
+			},
+			MinSize:    1,
+			TargetSize: 1,
+			Ready: []*cloudinstances.CloudInstance{
+				{
+					ID: "i-" + name,
+				},
+			},
+		}
+	}
+
+	v, err := testValidate(t, groups, nil)
+	require.NoError(t, err)
+	if !assert.Len(t, v.Failures, 2) {
+		printDebug(t, v)
+	}
+	for _, failure := range v.Failures {
+		assert.Equal(t, ValidationCategoryMachineNotJoined, failure.Category, "category of %q", failure.Name)
+	}
+	assert.Equal(t, []*ValidationInstanceGroup{
+		{Name: "node-a", Role: "node", TargetSize: 1, Instances: 1},
+		{Name: "node-b", Role: "node", TargetSize: 1, Instances: 1},
+	}, v.InstanceGroups)
+}
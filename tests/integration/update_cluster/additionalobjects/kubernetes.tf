@@ -769,6 +769,7 @@ resource "aws_security_group" "nodes-additionalobjects-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-additionalobjects-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-additionalobjects-example-com.id
@@ -778,6 +779,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-a
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-additionalobjects-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-additionalobjects-example-com.id
@@ -787,6 +789,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-add
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-additionalobjects-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-additionalobjects-example-com.id
@@ -795,6 +798,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-masters-additionalobjects-example-com" {
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -804,6 +808,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-masters-additi
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-nodes-additionalobjects-example-com" {
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -813,6 +818,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-nodes-addition
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-masters-additionalobjects-example-com" {
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -823,6 +829,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-masters-addi
 
 resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-additionalobjects-example-com.id
@@ -831,6 +838,7 @@ resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-e
 }
 
 resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -840,6 +848,7 @@ resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-e
 }
 
 resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-ingress-all-0to0-masters-additionalobjects-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-additionalobjects-example-com.id
@@ -849,6 +858,7 @@ resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-i
 }
 
 resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-ingress-all-0to0-nodes-additionalobjects-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-additionalobjects-example-com.id
@@ -859,6 +869,7 @@ resource "aws_security_group_rule" "from-masters-additionalobjects-example-com-i
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-additionalobjects-example-com.id
@@ -867,6 +878,7 @@ resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -876,6 +888,7 @@ resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ingress-all-0to0-nodes-additionalobjects-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-additionalobjects-example-com.id
@@ -885,6 +898,7 @@ resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ingress-tcp-1to2379-masters-additionalobjects-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-additionalobjects-example-com.id
@@ -894,6 +908,7 @@ resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ingress-tcp-2382to4000-masters-additionalobjects-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-additionalobjects-example-com.id
@@ -903,6 +918,7 @@ resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ingress-tcp-4003to65535-masters-additionalobjects-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-additionalobjects-example-com.id
@@ -912,6 +928,7 @@ resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-additionalobjects-example-com-ingress-udp-1to65535-masters-additionalobjects-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-additionalobjects-example-com.id
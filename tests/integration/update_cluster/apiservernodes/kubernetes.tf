@@ -946,6 +946,7 @@ resource "aws_security_group" "nodes-minimal-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -955,6 +956,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-minimal-example-com.id
@@ -964,6 +966,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-min
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -973,6 +976,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -981,6 +985,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -990,6 +995,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all-0to0-masters-minimal-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -999,6 +1005,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all-0to0-nodes-minimal-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-example-com.id
@@ -1009,6 +1016,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-minimal-example-com.id
@@ -1017,6 +1025,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1026,6 +1035,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-all-0to0-nodes-minimal-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-example-com.id
@@ -1035,6 +1045,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-1to2379-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1044,6 +1055,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-1
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-2382to4000-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1053,6 +1065,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-2
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-4003to65535-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1062,6 +1075,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-4
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-udp-1to65535-masters-minimal-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
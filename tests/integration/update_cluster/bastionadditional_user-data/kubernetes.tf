@@ -771,6 +771,7 @@ resource "aws_launch_template" "nodes-bastionuserdata-example-com" {
 resource "aws_lb" "bastion-bastionuserdata-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-bastionuserdata-e-4grhsv"
   security_groups                  = [aws_security_group.bastion-elb-bastionuserdata-example-com.id]
@@ -1111,6 +1112,7 @@ resource "aws_security_group" "nodes-bastionuserdata-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-bastionuserdata-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-bastionuserdata-example-com.id
@@ -1120,6 +1122,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-bastionuserdata-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-bastionuserdata-example-com.id
@@ -1129,6 +1132,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-bastionuserdata-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-bastionuserdata-example-com.id
@@ -1138,6 +1142,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-bastionuserdata-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-bastionuserdata-example-com.id
@@ -1146,6 +1151,7 @@ resource "aws_security_group_rule" "from-api-elb-bastionuserdata-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-api-elb-bastionuserdata-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1156,6 +1162,7 @@ resource "aws_security_group_rule" "from-api-elb-bastionuserdata-example-com-egr
 
 resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-bastionuserdata-example-com.id
@@ -1164,6 +1171,7 @@ resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1173,6 +1181,7 @@ resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-ingress-icmp-3to4-bastion-elb-bastionuserdata-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-bastionuserdata-example-com.id
@@ -1182,6 +1191,7 @@ resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-ingress-tcp-22to22-masters-bastionuserdata-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1191,6 +1201,7 @@ resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-ingress-tcp-22to22-nodes-bastionuserdata-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-bastionuserdata-example-com.id
@@ -1201,6 +1212,7 @@ resource "aws_security_group_rule" "from-bastion-bastionuserdata-example-com-ing
 
 resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-bastionuserdata-example-com.id
@@ -1209,6 +1221,7 @@ resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1218,6 +1231,7 @@ resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com-ingress-icmp-3to4-bastion-bastionuserdata-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-bastionuserdata-example-com.id
@@ -1227,6 +1241,7 @@ resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com-ingress-tcp-22to22-bastion-bastionuserdata-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-bastionuserdata-example-com.id
@@ -1237,6 +1252,7 @@ resource "aws_security_group_rule" "from-bastion-elb-bastionuserdata-example-com
 
 resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1245,6 +1261,7 @@ resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1254,6 +1271,7 @@ resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-ingress-all-0to0-masters-bastionuserdata-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1263,6 +1281,7 @@ resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-ingress-all-0to0-nodes-bastionuserdata-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-bastionuserdata-example-com.id
@@ -1273,6 +1292,7 @@ resource "aws_security_group_rule" "from-masters-bastionuserdata-example-com-ing
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-bastionuserdata-example-com.id
@@ -1281,6 +1301,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1290,6 +1311,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingress-all-0to0-nodes-bastionuserdata-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-bastionuserdata-example-com.id
@@ -1299,6 +1321,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingress-tcp-1to2379-masters-bastionuserdata-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1308,6 +1331,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingress-tcp-2382to4000-masters-bastionuserdata-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1317,6 +1341,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingress-tcp-4003to65535-masters-bastionuserdata-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1326,6 +1351,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingress-udp-1to65535-masters-bastionuserdata-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1335,6 +1361,7 @@ resource "aws_security_group_rule" "from-nodes-bastionuserdata-example-com-ingre
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1345,6 +1372,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-bastionuserdata-example-com.id
@@ -1353,6 +1381,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-bastionuserdata-example-com.id
@@ -1362,6 +1391,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-bastionuserdata-example-com.id
@@ -1372,6 +1402,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-bastionuserdata-example-com.id
@@ -1381,6 +1412,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-bastionuserdata-example-com.id
@@ -166,6 +166,9 @@ resource "aws_autoscaling_group" "nodes-cas-priority-expander-example-com" {
     id      = aws_launch_template.nodes-cas-priority-expander-example-com.id
     version = aws_launch_template.nodes-cas-priority-expander-example-com.latest_version
   }
+  lifecycle {
+    ignore_changes = [desired_capacity]
+  }
   max_instance_lifetime = 0
   max_size              = 2
   metrics_granularity   = "1Minute"
@@ -216,6 +219,9 @@ resource "aws_autoscaling_group" "nodes-high-priority-cas-priority-expander-exam
     id      = aws_launch_template.nodes-high-priority-cas-priority-expander-example-com.id
     version = aws_launch_template.nodes-high-priority-cas-priority-expander-example-com.latest_version
   }
+  lifecycle {
+    ignore_changes = [desired_capacity]
+  }
   max_instance_lifetime = 0
   max_size              = 2
   metrics_granularity   = "1Minute"
@@ -266,6 +272,9 @@ resource "aws_autoscaling_group" "nodes-low-priority-cas-priority-expander-examp
     id      = aws_launch_template.nodes-low-priority-cas-priority-expander-example-com.id
     version = aws_launch_template.nodes-low-priority-cas-priority-expander-example-com.latest_version
   }
+  lifecycle {
+    ignore_changes = [desired_capacity]
+  }
   max_instance_lifetime = 0
   max_size              = 2
   metrics_granularity   = "1Minute"
@@ -1039,6 +1048,7 @@ resource "aws_security_group" "nodes-cas-priority-expander-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-cas-priority-expander-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1048,6 +1058,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-c
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-cas-priority-expander-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-cas-priority-expander-example-com.id
@@ -1057,6 +1068,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-cas
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-cas-priority-expander-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1066,6 +1078,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1074,6 +1087,7 @@ resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1083,6 +1097,7 @@ resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-com-ingress-all-0to0-masters-cas-priority-expander-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1092,6 +1107,7 @@ resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-com-ingress-all-0to0-nodes-cas-priority-expander-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-cas-priority-expander-example-com.id
@@ -1102,6 +1118,7 @@ resource "aws_security_group_rule" "from-masters-cas-priority-expander-example-c
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-cas-priority-expander-example-com.id
@@ -1110,6 +1127,7 @@ resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1119,6 +1137,7 @@ resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-ingress-all-0to0-nodes-cas-priority-expander-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-cas-priority-expander-example-com.id
@@ -1128,6 +1147,7 @@ resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-ingress-tcp-1to2379-masters-cas-priority-expander-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1137,6 +1157,7 @@ resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-ingress-tcp-2382to4000-masters-cas-priority-expander-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1146,6 +1167,7 @@ resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-ingress-tcp-4003to65535-masters-cas-priority-expander-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -1155,6 +1177,7 @@ resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-cas-priority-expander-example-com-ingress-udp-1to65535-masters-cas-priority-expander-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-cas-priority-expander-example-com.id
@@ -463,6 +463,11 @@ resource "aws_launch_template" "master-us-test-1a-masters-complex-example-com" {
     device_name  = "/dev/sdc"
     virtual_name = "ephemeral0"
   }
+  capacity_reservation_specification {
+    capacity_reservation_target {
+      capacity_reservation_id = "cr-0123456789abcdef0"
+    }
+  }
   iam_instance_profile {
     name = aws_iam_instance_profile.masters-complex-example-com.id
   }
@@ -636,6 +641,7 @@ resource "aws_lb" "api-complex-example-com" {
   }
   enable_cross_zone_load_balancing = true
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "api-complex-example-com-vd3t5n"
   security_groups                  = ["sg-exampleid5", "sg-exampleid6", aws_security_group.api-elb-complex-example-com.id]
@@ -1011,6 +1017,7 @@ resource "aws_security_group" "nodes-complex-example-com" {
 }
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-complex-example-com" {
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   prefix_list_ids   = ["pl-66666666"]
   protocol          = "tcp"
@@ -1020,6 +1027,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-c
 }
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-complex-example-com" {
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   prefix_list_ids   = ["pl-66666666"]
   protocol          = "tcp"
@@ -1029,6 +1037,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-com
 }
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-complex-example-com" {
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   prefix_list_ids   = ["pl-44444444"]
   protocol          = "tcp"
@@ -1039,6 +1048,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-1-1-1-0--24-ingress-tcp-443to443-api-elb-complex-example-com" {
   cidr_blocks       = ["1.1.1.0/24"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-complex-example-com.id
@@ -1048,6 +1058,7 @@ resource "aws_security_group_rule" "from-1-1-1-0--24-ingress-tcp-443to443-api-el
 
 resource "aws_security_group_rule" "from-1-1-1-1--32-ingress-tcp-22to22-masters-complex-example-com" {
   cidr_blocks       = ["1.1.1.1/32"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-complex-example-com.id
@@ -1057,6 +1068,7 @@ resource "aws_security_group_rule" "from-1-1-1-1--32-ingress-tcp-22to22-masters-
 
 resource "aws_security_group_rule" "from-1-1-1-1--32-ingress-tcp-22to22-nodes-complex-example-com" {
   cidr_blocks       = ["1.1.1.1/32"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-complex-example-com.id
@@ -1066,6 +1078,7 @@ resource "aws_security_group_rule" "from-1-1-1-1--32-ingress-tcp-22to22-nodes-co
 
 resource "aws_security_group_rule" "from-api-elb-complex-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-complex-example-com.id
@@ -1074,6 +1087,7 @@ resource "aws_security_group_rule" "from-api-elb-complex-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-api-elb-complex-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1084,6 +1098,7 @@ resource "aws_security_group_rule" "from-api-elb-complex-example-com-egress-all-
 
 resource "aws_security_group_rule" "from-masters-complex-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-complex-example-com.id
@@ -1092,6 +1107,7 @@ resource "aws_security_group_rule" "from-masters-complex-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-complex-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1101,6 +1117,7 @@ resource "aws_security_group_rule" "from-masters-complex-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-complex-example-com-ingress-all-0to0-masters-complex-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1110,6 +1127,7 @@ resource "aws_security_group_rule" "from-masters-complex-example-com-ingress-all
 }
 
 resource "aws_security_group_rule" "from-masters-complex-example-com-ingress-all-0to0-nodes-complex-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-complex-example-com.id
@@ -1120,6 +1138,7 @@ resource "aws_security_group_rule" "from-masters-complex-example-com-ingress-all
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-complex-example-com.id
@@ -1128,6 +1147,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1137,6 +1157,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-all-0to0-nodes-complex-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-complex-example-com.id
@@ -1146,6 +1167,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-tcp-1to2379-masters-complex-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1155,6 +1177,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-tcp-1
 }
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-tcp-2382to4000-masters-complex-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1164,6 +1187,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-tcp-2
 }
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-tcp-4003to65535-masters-complex-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1173,6 +1197,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-tcp-4
 }
 
 resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-udp-1to65535-masters-complex-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1182,6 +1207,7 @@ resource "aws_security_group_rule" "from-nodes-complex-example-com-ingress-udp-1
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1192,6 +1218,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-1-1-1-0--24" {
   cidr_blocks       = ["1.1.1.0/24"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-complex-example-com.id
@@ -1200,6 +1227,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-1-1-1-0--24" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-pl-44444444" {
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   prefix_list_ids   = ["pl-44444444"]
   protocol          = "icmp"
@@ -1209,6 +1237,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-pl-44444444" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-complex-example-com.id
@@ -1218,6 +1247,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -1227,6 +1257,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 }
 
 resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-pl-44444444" {
+  description       = "ICMPv6 path MTU discovery to API load balancer"
   from_port         = -1
   prefix_list_ids   = ["pl-44444444"]
   protocol          = "icmpv6"
@@ -1237,6 +1268,7 @@ resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-pl-44444444" {
 
 resource "aws_security_group_rule" "nodeport-tcp-external-to-node-1-2-3-4--32" {
   cidr_blocks       = ["1.2.3.4/32"]
+  description       = "TCP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-complex-example-com.id
@@ -1246,6 +1278,7 @@ resource "aws_security_group_rule" "nodeport-tcp-external-to-node-1-2-3-4--32" {
 
 resource "aws_security_group_rule" "nodeport-tcp-external-to-node-10-20-30-0--24" {
   cidr_blocks       = ["10.20.30.0/24"]
+  description       = "TCP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-complex-example-com.id
@@ -1255,6 +1288,7 @@ resource "aws_security_group_rule" "nodeport-tcp-external-to-node-10-20-30-0--24
 
 resource "aws_security_group_rule" "nodeport-udp-external-to-node-1-2-3-4--32" {
   cidr_blocks       = ["1.2.3.4/32"]
+  description       = "UDP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "udp"
   security_group_id = aws_security_group.nodes-complex-example-com.id
@@ -1264,6 +1298,7 @@ resource "aws_security_group_rule" "nodeport-udp-external-to-node-1-2-3-4--32" {
 
 resource "aws_security_group_rule" "nodeport-udp-external-to-node-10-20-30-0--24" {
   cidr_blocks       = ["10.20.30.0/24"]
+  description       = "UDP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "udp"
   security_group_id = aws_security_group.nodes-complex-example-com.id
@@ -1272,6 +1307,7 @@ resource "aws_security_group_rule" "nodeport-udp-external-to-node-10-20-30-0--24
 }
 
 resource "aws_security_group_rule" "tcp-api-cp" {
+  description              = "Kubernetes API secondary port from load balancer to control plane"
   from_port                = 8443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-complex-example-com.id
@@ -741,6 +741,7 @@ resource "aws_security_group" "nodes-compress-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-compress-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-compress-example-com.id
@@ -750,6 +751,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-c
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-compress-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-compress-example-com.id
@@ -759,6 +761,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-com
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-compress-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-compress-example-com.id
@@ -768,6 +771,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-compress-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-compress-example-com.id
@@ -776,6 +780,7 @@ resource "aws_security_group_rule" "from-masters-compress-example-com-egress-all
 }
 
 resource "aws_security_group_rule" "from-masters-compress-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -785,6 +790,7 @@ resource "aws_security_group_rule" "from-masters-compress-example-com-egress-all
 }
 
 resource "aws_security_group_rule" "from-masters-compress-example-com-ingress-all-0to0-masters-compress-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-compress-example-com.id
@@ -794,6 +800,7 @@ resource "aws_security_group_rule" "from-masters-compress-example-com-ingress-al
 }
 
 resource "aws_security_group_rule" "from-masters-compress-example-com-ingress-all-0to0-nodes-compress-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-compress-example-com.id
@@ -804,6 +811,7 @@ resource "aws_security_group_rule" "from-masters-compress-example-com-ingress-al
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-compress-example-com.id
@@ -812,6 +820,7 @@ resource "aws_security_group_rule" "from-nodes-compress-example-com-egress-all-0
 }
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -821,6 +830,7 @@ resource "aws_security_group_rule" "from-nodes-compress-example-com-egress-all-0
 }
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-all-0to0-nodes-compress-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-compress-example-com.id
@@ -830,6 +840,7 @@ resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-all-
 }
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-tcp-1to2379-masters-compress-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-compress-example-com.id
@@ -839,6 +850,7 @@ resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-tcp-
 }
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-tcp-2382to4000-masters-compress-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-compress-example-com.id
@@ -848,6 +860,7 @@ resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-tcp-
 }
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-tcp-4003to65535-masters-compress-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-compress-example-com.id
@@ -857,6 +870,7 @@ resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-tcp-
 }
 
 resource "aws_security_group_rule" "from-nodes-compress-example-com-ingress-udp-1to65535-masters-compress-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-compress-example-com.id
@@ -753,6 +753,7 @@ resource "aws_security_group" "nodes-containerd-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-containerd-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-containerd-example-com.id
@@ -762,6 +763,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-c
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-containerd-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-containerd-example-com.id
@@ -771,6 +773,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-con
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-containerd-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-containerd-example-com.id
@@ -780,6 +783,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-containerd-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-containerd-example-com.id
@@ -788,6 +792,7 @@ resource "aws_security_group_rule" "from-masters-containerd-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-masters-containerd-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -797,6 +802,7 @@ resource "aws_security_group_rule" "from-masters-containerd-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-masters-containerd-example-com-ingress-all-0to0-masters-containerd-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-containerd-example-com.id
@@ -806,6 +812,7 @@ resource "aws_security_group_rule" "from-masters-containerd-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-masters-containerd-example-com-ingress-all-0to0-nodes-containerd-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-containerd-example-com.id
@@ -816,6 +823,7 @@ resource "aws_security_group_rule" "from-masters-containerd-example-com-ingress-
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-containerd-example-com.id
@@ -824,6 +832,7 @@ resource "aws_security_group_rule" "from-nodes-containerd-example-com-egress-all
 }
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -833,6 +842,7 @@ resource "aws_security_group_rule" "from-nodes-containerd-example-com-egress-all
 }
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-all-0to0-nodes-containerd-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-containerd-example-com.id
@@ -842,6 +852,7 @@ resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-tcp-1to2379-masters-containerd-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-containerd-example-com.id
@@ -851,6 +862,7 @@ resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-tc
 }
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-tcp-2382to4000-masters-containerd-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-containerd-example-com.id
@@ -860,6 +872,7 @@ resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-tc
 }
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-tcp-4003to65535-masters-containerd-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-containerd-example-com.id
@@ -869,6 +882,7 @@ resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-tc
 }
 
 resource "aws_security_group_rule" "from-nodes-containerd-example-com-ingress-udp-1to65535-masters-containerd-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-containerd-example-com.id
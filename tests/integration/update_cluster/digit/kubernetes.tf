@@ -845,6 +845,7 @@ resource "aws_security_group" "nodes-123-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-123-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-123-example-com.id
@@ -854,6 +855,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-1
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-123-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-123-example-com.id
@@ -863,6 +865,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-123
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-123-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-123-example-com.id
@@ -872,6 +875,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-123-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-123-example-com.id
@@ -880,6 +884,7 @@ resource "aws_security_group_rule" "from-masters-123-example-com-egress-all-0to0
 }
 
 resource "aws_security_group_rule" "from-masters-123-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -889,6 +894,7 @@ resource "aws_security_group_rule" "from-masters-123-example-com-egress-all-0to0
 }
 
 resource "aws_security_group_rule" "from-masters-123-example-com-ingress-all-0to0-masters-123-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-123-example-com.id
@@ -898,6 +904,7 @@ resource "aws_security_group_rule" "from-masters-123-example-com-ingress-all-0to
 }
 
 resource "aws_security_group_rule" "from-masters-123-example-com-ingress-all-0to0-nodes-123-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-123-example-com.id
@@ -908,6 +915,7 @@ resource "aws_security_group_rule" "from-masters-123-example-com-ingress-all-0to
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-123-example-com.id
@@ -916,6 +924,7 @@ resource "aws_security_group_rule" "from-nodes-123-example-com-egress-all-0to0-0
 }
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -925,6 +934,7 @@ resource "aws_security_group_rule" "from-nodes-123-example-com-egress-all-0to0-_
 }
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-all-0to0-nodes-123-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-123-example-com.id
@@ -934,6 +944,7 @@ resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-all-0to0-
 }
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-tcp-1to2379-masters-123-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-123-example-com.id
@@ -943,6 +954,7 @@ resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-tcp-1to23
 }
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-tcp-2382to4000-masters-123-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-123-example-com.id
@@ -952,6 +964,7 @@ resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-tcp-2382t
 }
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-tcp-4003to65535-masters-123-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-123-example-com.id
@@ -961,6 +974,7 @@ resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-tcp-4003t
 }
 
 resource "aws_security_group_rule" "from-nodes-123-example-com-ingress-udp-1to65535-masters-123-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-123-example-com.id
@@ -1135,6 +1135,7 @@ resource "aws_security_group" "nodes-existing-iam-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-existing-iam-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-existing-iam-example-com.id
@@ -1144,6 +1145,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-existing-iam-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-existing-iam-example-com.id
@@ -1153,6 +1155,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-exi
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-existing-iam-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-existing-iam-example-com.id
@@ -1162,6 +1165,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-existing-iam-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-existing-iam-example-com.id
@@ -1170,6 +1174,7 @@ resource "aws_security_group_rule" "from-masters-existing-iam-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-masters-existing-iam-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1179,6 +1184,7 @@ resource "aws_security_group_rule" "from-masters-existing-iam-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-masters-existing-iam-example-com-ingress-all-0to0-masters-existing-iam-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-existing-iam-example-com.id
@@ -1188,6 +1194,7 @@ resource "aws_security_group_rule" "from-masters-existing-iam-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-masters-existing-iam-example-com-ingress-all-0to0-nodes-existing-iam-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-existing-iam-example-com.id
@@ -1198,6 +1205,7 @@ resource "aws_security_group_rule" "from-masters-existing-iam-example-com-ingres
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-existing-iam-example-com.id
@@ -1206,6 +1214,7 @@ resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1215,6 +1224,7 @@ resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-all-0to0-nodes-existing-iam-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-existing-iam-example-com.id
@@ -1224,6 +1234,7 @@ resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-tcp-1to2379-masters-existing-iam-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existing-iam-example-com.id
@@ -1233,6 +1244,7 @@ resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-tcp-2382to4000-masters-existing-iam-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existing-iam-example-com.id
@@ -1242,6 +1254,7 @@ resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-tcp-4003to65535-masters-existing-iam-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existing-iam-example-com.id
@@ -1251,6 +1264,7 @@ resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-existing-iam-example-com-ingress-udp-1to65535-masters-existing-iam-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-existing-iam-example-com.id
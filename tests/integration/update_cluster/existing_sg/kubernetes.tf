@@ -1249,6 +1249,7 @@ resource "aws_security_group" "masters-existingsg-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-existingsg-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-existingsg-example-com.id
@@ -1258,6 +1259,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-sg-master-1a-ControlPlane" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = "sg-master-1a"
@@ -1267,6 +1269,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-sg-master
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-sg-master-1b-ControlPlane" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = "sg-master-1b"
@@ -1276,6 +1279,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-sg-master
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-sg-nodes-Node" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = "sg-nodes"
@@ -1285,6 +1289,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-sg-nodes-
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-existingsg-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = "sg-elb"
@@ -1294,6 +1299,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-api-elb-existingsg-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = "sg-elb"
@@ -1302,6 +1308,7 @@ resource "aws_security_group_rule" "from-api-elb-existingsg-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-api-elb-existingsg-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1312,6 +1319,7 @@ resource "aws_security_group_rule" "from-api-elb-existingsg-example-com-egress-a
 
 resource "aws_security_group_rule" "from-masters-existingsg-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-existingsg-example-com.id
@@ -1320,6 +1328,7 @@ resource "aws_security_group_rule" "from-masters-existingsg-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-masters-existingsg-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1329,6 +1338,7 @@ resource "aws_security_group_rule" "from-masters-existingsg-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-all-0to0-masters-existingsg-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1338,6 +1348,7 @@ resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-all-0to0-sg-master-1a-ControlPlane" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-master-1a"
@@ -1347,6 +1358,7 @@ resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-all-0to0-sg-master-1b-ControlPlane" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-master-1b"
@@ -1356,6 +1368,7 @@ resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-all-0to0-sg-nodes-Node" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-nodes"
@@ -1366,6 +1379,7 @@ resource "aws_security_group_rule" "from-masters-existingsg-example-com-ingress-
 
 resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = "sg-master-1a"
@@ -1374,6 +1388,7 @@ resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1383,6 +1398,7 @@ resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0to0-masters-existingsg-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1392,6 +1408,7 @@ resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0to0-sg-master-1a-ControlPlane" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-master-1a"
@@ -1401,6 +1418,7 @@ resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0to0-sg-master-1b-ControlPlane" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-master-1b"
@@ -1410,6 +1428,7 @@ resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0to0-sg-nodes-Node" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-nodes"
@@ -1420,6 +1439,7 @@ resource "aws_security_group_rule" "from-sg-master-1a-ControlPlane-ingress-all-0
 
 resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = "sg-master-1b"
@@ -1428,6 +1448,7 @@ resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1437,6 +1458,7 @@ resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0to0-masters-existingsg-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1446,6 +1468,7 @@ resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0to0-sg-master-1a-ControlPlane" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-master-1a"
@@ -1455,6 +1478,7 @@ resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0to0-sg-master-1b-ControlPlane" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-master-1b"
@@ -1464,6 +1488,7 @@ resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0to0-sg-nodes-Node" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-nodes"
@@ -1474,6 +1499,7 @@ resource "aws_security_group_rule" "from-sg-master-1b-ControlPlane-ingress-all-0
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = "sg-nodes"
@@ -1482,6 +1508,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-egress-all-0to0-0-0-0-0--
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1491,6 +1518,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-egress-all-0to0-__--0" {
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-all-0to0-sg-nodes-Node" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = "sg-nodes"
@@ -1500,6 +1528,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-all-0to0-sg-nodes
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-1to2379-masters-existingsg-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1509,6 +1538,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-1to2379-maste
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-1to2379-sg-master-1a-ControlPlane" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = "sg-master-1a"
@@ -1518,6 +1548,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-1to2379-sg-ma
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-1to2379-sg-master-1b-ControlPlane" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = "sg-master-1b"
@@ -1527,6 +1558,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-1to2379-sg-ma
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-2382to4000-masters-existingsg-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1536,6 +1568,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-2382to4000-ma
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-2382to4000-sg-master-1a-ControlPlane" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = "sg-master-1a"
@@ -1545,6 +1578,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-2382to4000-sg
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-2382to4000-sg-master-1b-ControlPlane" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = "sg-master-1b"
@@ -1554,6 +1588,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-2382to4000-sg
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-4003to65535-masters-existingsg-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1563,6 +1598,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-4003to65535-m
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-4003to65535-sg-master-1a-ControlPlane" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = "sg-master-1a"
@@ -1572,6 +1608,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-4003to65535-s
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-4003to65535-sg-master-1b-ControlPlane" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = "sg-master-1b"
@@ -1581,6 +1618,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-tcp-4003to65535-s
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-udp-1to65535-masters-existingsg-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1590,6 +1628,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-udp-1to65535-mast
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-udp-1to65535-sg-master-1a-ControlPlane" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = "sg-master-1a"
@@ -1599,6 +1638,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-udp-1to65535-sg-m
 }
 
 resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-udp-1to65535-sg-master-1b-ControlPlane" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = "sg-master-1b"
@@ -1608,6 +1648,7 @@ resource "aws_security_group_rule" "from-sg-nodes-Node-ingress-udp-1to65535-sg-m
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1617,6 +1658,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 }
 
 resource "aws_security_group_rule" "https-elb-to-master-sg-master-1a" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = "sg-master-1a"
@@ -1626,6 +1668,7 @@ resource "aws_security_group_rule" "https-elb-to-master-sg-master-1a" {
 }
 
 resource "aws_security_group_rule" "https-elb-to-master-sg-master-1b" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = "sg-master-1b"
@@ -1636,6 +1679,7 @@ resource "aws_security_group_rule" "https-elb-to-master-sg-master-1b" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = "sg-elb"
@@ -1644,6 +1688,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-sg-master-1a-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = "sg-elb"
@@ -1653,6 +1698,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-sg-master-1a-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-sg-master-1b-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = "sg-elb"
@@ -1662,6 +1708,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-sg-master-1b-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = "sg-elb"
@@ -1671,6 +1718,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-existingsg-example-com.id
@@ -1680,6 +1728,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp-sg-master-1a" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = "sg-master-1a"
@@ -1689,6 +1738,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp-sg-master-1a" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp-sg-master-1b" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = "sg-master-1b"
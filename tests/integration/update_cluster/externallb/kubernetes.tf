@@ -757,6 +757,7 @@ resource "aws_security_group" "nodes-externallb-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-externallb-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-externallb-example-com.id
@@ -766,6 +767,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-externallb-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-externallb-example-com.id
@@ -775,6 +777,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-ext
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-externallb-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-externallb-example-com.id
@@ -784,6 +787,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-externallb-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-externallb-example-com.id
@@ -792,6 +796,7 @@ resource "aws_security_group_rule" "from-masters-externallb-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-masters-externallb-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -801,6 +806,7 @@ resource "aws_security_group_rule" "from-masters-externallb-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-masters-externallb-example-com-ingress-all-0to0-masters-externallb-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-externallb-example-com.id
@@ -810,6 +816,7 @@ resource "aws_security_group_rule" "from-masters-externallb-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-masters-externallb-example-com-ingress-all-0to0-nodes-externallb-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-externallb-example-com.id
@@ -820,6 +827,7 @@ resource "aws_security_group_rule" "from-masters-externallb-example-com-ingress-
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-externallb-example-com.id
@@ -828,6 +836,7 @@ resource "aws_security_group_rule" "from-nodes-externallb-example-com-egress-all
 }
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -837,6 +846,7 @@ resource "aws_security_group_rule" "from-nodes-externallb-example-com-egress-all
 }
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-all-0to0-nodes-externallb-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-externallb-example-com.id
@@ -846,6 +856,7 @@ resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-tcp-1to2379-masters-externallb-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externallb-example-com.id
@@ -855,6 +866,7 @@ resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-tc
 }
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-tcp-2382to4000-masters-externallb-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externallb-example-com.id
@@ -864,6 +876,7 @@ resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-tc
 }
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-tcp-4003to65535-masters-externallb-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externallb-example-com.id
@@ -873,6 +886,7 @@ resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-tc
 }
 
 resource "aws_security_group_rule" "from-nodes-externallb-example-com-ingress-udp-1to65535-masters-externallb-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-externallb-example-com.id
@@ -355,6 +355,12 @@ resource "aws_ebs_volume" "us-test-1a-etcd-main-externalpolicies-example-com" {
 }
 
 resource "aws_elb" "api-externalpolicies-example-com" {
+  access_logs {
+    bucket        = "access-logs-example"
+    bucket_prefix = "api"
+    enabled       = true
+    interval      = 5
+  }
   connection_draining         = true
   connection_draining_timeout = 300
   cross_zone_load_balancing   = false
@@ -892,6 +898,7 @@ resource "aws_security_group" "nodes-externalpolicies-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-externalpolicies-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-externalpolicies-example-com.id
@@ -901,6 +908,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-externalpolicies-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-externalpolicies-example-com.id
@@ -910,6 +918,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-ext
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-externalpolicies-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-externalpolicies-example-com.id
@@ -919,6 +928,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-api-elb-externalpolicies-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-externalpolicies-example-com.id
@@ -927,6 +937,7 @@ resource "aws_security_group_rule" "from-api-elb-externalpolicies-example-com-eg
 }
 
 resource "aws_security_group_rule" "from-api-elb-externalpolicies-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -937,6 +948,7 @@ resource "aws_security_group_rule" "from-api-elb-externalpolicies-example-com-eg
 
 resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-externalpolicies-example-com.id
@@ -945,6 +957,7 @@ resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-eg
 }
 
 resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -954,6 +967,7 @@ resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-eg
 }
 
 resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-ingress-all-0to0-masters-externalpolicies-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -963,6 +977,7 @@ resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-in
 }
 
 resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-ingress-all-0to0-nodes-externalpolicies-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-externalpolicies-example-com.id
@@ -973,6 +988,7 @@ resource "aws_security_group_rule" "from-masters-externalpolicies-example-com-in
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-externalpolicies-example-com.id
@@ -981,6 +997,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -990,6 +1007,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingress-all-0to0-nodes-externalpolicies-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-externalpolicies-example-com.id
@@ -999,6 +1017,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingress-tcp-1to2379-masters-externalpolicies-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -1008,6 +1027,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingress-tcp-2382to4000-masters-externalpolicies-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -1017,6 +1037,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingress-tcp-4003to65535-masters-externalpolicies-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -1026,6 +1047,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingress-udp-1to65535-masters-externalpolicies-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -1035,6 +1057,7 @@ resource "aws_security_group_rule" "from-nodes-externalpolicies-example-com-ingr
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -1045,6 +1068,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-externalpolicies-example-com.id
@@ -1053,6 +1077,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-externalpolicies-example-com.id
@@ -1062,6 +1087,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-externalpolicies-example-com.id
@@ -1072,6 +1098,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "nodeport-tcp-external-to-node-1-2-3-4--32" {
   cidr_blocks       = ["1.2.3.4/32"]
+  description       = "TCP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-externalpolicies-example-com.id
@@ -1081,6 +1108,7 @@ resource "aws_security_group_rule" "nodeport-tcp-external-to-node-1-2-3-4--32" {
 
 resource "aws_security_group_rule" "nodeport-tcp-external-to-node-10-20-30-0--24" {
   cidr_blocks       = ["10.20.30.0/24"]
+  description       = "TCP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-externalpolicies-example-com.id
@@ -1090,6 +1118,7 @@ resource "aws_security_group_rule" "nodeport-tcp-external-to-node-10-20-30-0--24
 
 resource "aws_security_group_rule" "nodeport-udp-external-to-node-1-2-3-4--32" {
   cidr_blocks       = ["1.2.3.4/32"]
+  description       = "UDP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "udp"
   security_group_id = aws_security_group.nodes-externalpolicies-example-com.id
@@ -1099,6 +1128,7 @@ resource "aws_security_group_rule" "nodeport-udp-external-to-node-1-2-3-4--32" {
 
 resource "aws_security_group_rule" "nodeport-udp-external-to-node-10-20-30-0--24" {
   cidr_blocks       = ["10.20.30.0/24"]
+  description       = "UDP NodePorts from node port access CIDR"
   from_port         = 28000
   protocol          = "udp"
   security_group_id = aws_security_group.nodes-externalpolicies-example-com.id
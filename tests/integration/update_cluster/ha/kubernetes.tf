@@ -1207,6 +1207,7 @@ resource "aws_security_group" "nodes-ha-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-ha-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-ha-example-com.id
@@ -1216,6 +1217,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-h
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-ha-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-ha-example-com.id
@@ -1225,6 +1227,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-ha-
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-ha-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-ha-example-com.id
@@ -1234,6 +1237,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-ha-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-ha-example-com.id
@@ -1242,6 +1246,7 @@ resource "aws_security_group_rule" "from-masters-ha-example-com-egress-all-0to0-
 }
 
 resource "aws_security_group_rule" "from-masters-ha-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1251,6 +1256,7 @@ resource "aws_security_group_rule" "from-masters-ha-example-com-egress-all-0to0-
 }
 
 resource "aws_security_group_rule" "from-masters-ha-example-com-ingress-all-0to0-masters-ha-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-ha-example-com.id
@@ -1260,6 +1266,7 @@ resource "aws_security_group_rule" "from-masters-ha-example-com-ingress-all-0to0
 }
 
 resource "aws_security_group_rule" "from-masters-ha-example-com-ingress-all-0to0-nodes-ha-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-ha-example-com.id
@@ -1270,6 +1277,7 @@ resource "aws_security_group_rule" "from-masters-ha-example-com-ingress-all-0to0
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-ha-example-com.id
@@ -1278,6 +1286,7 @@ resource "aws_security_group_rule" "from-nodes-ha-example-com-egress-all-0to0-0-
 }
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1287,6 +1296,7 @@ resource "aws_security_group_rule" "from-nodes-ha-example-com-egress-all-0to0-__
 }
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-all-0to0-nodes-ha-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-ha-example-com.id
@@ -1296,6 +1306,7 @@ resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-all-0to0-n
 }
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-tcp-1to2379-masters-ha-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-ha-example-com.id
@@ -1305,6 +1316,7 @@ resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-tcp-1to237
 }
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-tcp-2382to4000-masters-ha-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-ha-example-com.id
@@ -1314,6 +1326,7 @@ resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-tcp-2382to
 }
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-tcp-4003to65535-masters-ha-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-ha-example-com.id
@@ -1323,6 +1336,7 @@ resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-tcp-4003to
 }
 
 resource "aws_security_group_rule" "from-nodes-ha-example-com-ingress-udp-1to65535-masters-ha-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-ha-example-com.id
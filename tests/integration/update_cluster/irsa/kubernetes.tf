@@ -888,6 +888,7 @@ resource "aws_security_group" "nodes-minimal-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -897,6 +898,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-minimal-example-com.id
@@ -906,6 +908,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-min
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -915,6 +918,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -923,6 +927,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -932,6 +937,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all-0to0-masters-minimal-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -941,6 +947,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all-0to0-nodes-minimal-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-example-com.id
@@ -951,6 +958,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-minimal-example-com.id
@@ -959,6 +967,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -968,6 +977,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-all-0to0-nodes-minimal-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-example-com.id
@@ -977,6 +987,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-1to2379-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -986,6 +997,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-1
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-2382to4000-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -995,6 +1007,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-2
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-4003to65535-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1004,6 +1017,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-4
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-udp-1to65535-masters-minimal-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -801,6 +801,7 @@ resource "aws_security_group" "nodes-many-addons-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-many-addons-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-many-addons-example-com.id
@@ -810,6 +811,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-many-addons-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-many-addons-example-com.id
@@ -819,6 +821,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-man
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-many-addons-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-many-addons-example-com.id
@@ -828,6 +831,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-many-addons-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-many-addons-example-com.id
@@ -836,6 +840,7 @@ resource "aws_security_group_rule" "from-masters-many-addons-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-masters-many-addons-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -845,6 +850,7 @@ resource "aws_security_group_rule" "from-masters-many-addons-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-masters-many-addons-example-com-ingress-all-0to0-masters-many-addons-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-many-addons-example-com.id
@@ -854,6 +860,7 @@ resource "aws_security_group_rule" "from-masters-many-addons-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-masters-many-addons-example-com-ingress-all-0to0-nodes-many-addons-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-many-addons-example-com.id
@@ -864,6 +871,7 @@ resource "aws_security_group_rule" "from-masters-many-addons-example-com-ingress
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-many-addons-example-com.id
@@ -872,6 +880,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -881,6 +890,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-all-0to0-masters-many-addons-example-com" {
+  description              = "All traffic from nodes to control plane"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-many-addons-example-com.id
@@ -890,6 +900,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-all-0to0-nodes-many-addons-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-many-addons-example-com.id
@@ -899,6 +910,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-tcp-1to2379-masters-many-addons-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-many-addons-example-com.id
@@ -908,6 +920,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-tcp-2382to4000-masters-many-addons-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-many-addons-example-com.id
@@ -917,6 +930,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-tcp-4003to65535-masters-many-addons-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-many-addons-example-com.id
@@ -926,6 +940,7 @@ resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-many-addons-example-com-ingress-udp-1to65535-masters-many-addons-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-many-addons-example-com.id
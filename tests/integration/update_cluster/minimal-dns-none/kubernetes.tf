@@ -570,6 +570,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
 resource "aws_lb" "api-minimal-example-com" {
   enable_cross_zone_load_balancing = true
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "api-minimal-example-com-gecgf7"
   security_groups                  = [aws_security_group.api-elb-minimal-example-com.id]
@@ -849,6 +850,7 @@ resource "aws_security_group" "nodes-minimal-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -858,6 +860,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-minimal-example-com.id
@@ -867,6 +870,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-min
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-minimal-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-minimal-example-com.id
@@ -875,6 +879,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-masters-minimal-example-com" {
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -884,6 +889,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-masters-minima
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-nodes-minimal-example-com" {
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -893,6 +899,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-nodes-minimal-
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-api-elb-minimal-example-com" {
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -903,6 +910,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-api-elb-mini
 
 resource "aws_security_group_rule" "from-api-elb-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-minimal-example-com.id
@@ -911,6 +919,7 @@ resource "aws_security_group_rule" "from-api-elb-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-api-elb-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -921,6 +930,7 @@ resource "aws_security_group_rule" "from-api-elb-minimal-example-com-egress-all-
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-minimal-example-com.id
@@ -929,6 +939,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -938,6 +949,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all-0to0-masters-minimal-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -947,6 +959,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all-0to0-nodes-minimal-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-example-com.id
@@ -957,6 +970,7 @@ resource "aws_security_group_rule" "from-masters-minimal-example-com-ingress-all
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-minimal-example-com.id
@@ -965,6 +979,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -974,6 +989,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-all-0to0-nodes-minimal-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-example-com.id
@@ -983,6 +999,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-1to2379-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -992,6 +1009,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-1
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-2382to4000-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1001,6 +1019,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-2
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-4003to65535-masters-minimal-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1010,6 +1029,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-tcp-4
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-udp-1to65535-masters-minimal-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1019,6 +1039,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-example-com-ingress-udp-1
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1029,6 +1050,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-minimal-example-com.id
@@ -1037,6 +1059,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-minimal-example-com.id
@@ -1046,6 +1069,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1055,6 +1079,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 }
 
 resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
+  description       = "ICMPv6 path MTU discovery to API load balancer"
   from_port         = -1
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "icmpv6"
@@ -1064,6 +1089,7 @@ resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
 }
 
 resource "aws_security_group_rule" "kops-controller-elb-to-cp" {
+  description              = "kops-controller from load balancer to control plane"
   from_port                = 3988
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-example-com.id
@@ -1073,6 +1099,7 @@ resource "aws_security_group_rule" "kops-controller-elb-to-cp" {
 }
 
 resource "aws_security_group_rule" "node-to-elb" {
+  description              = "Nodes to API load balancer"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.api-elb-minimal-example-com.id
@@ -1016,6 +1016,7 @@ resource "aws_security_group" "nodes-minimal-ipv6-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-minimal-ipv6-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1025,6 +1026,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-minimal-ipv6-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-minimal-ipv6-example-com.id
@@ -1034,6 +1036,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-min
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-minimal-ipv6-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-minimal-ipv6-example-com.id
@@ -1042,6 +1045,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-masters-minimal-ipv6-example-com" {
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -1051,6 +1055,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-masters-minima
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-nodes-minimal-ipv6-example-com" {
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -1060,6 +1065,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-nodes-minimal-
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-api-elb-minimal-ipv6-example-com" {
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -1070,6 +1076,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-api-elb-mini
 
 resource "aws_security_group_rule" "from-api-elb-minimal-ipv6-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-minimal-ipv6-example-com.id
@@ -1078,6 +1085,7 @@ resource "aws_security_group_rule" "from-api-elb-minimal-ipv6-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-api-elb-minimal-ipv6-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1088,6 +1096,7 @@ resource "aws_security_group_rule" "from-api-elb-minimal-ipv6-example-com-egress
 
 resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1096,6 +1105,7 @@ resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1105,6 +1115,7 @@ resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-ingress-all-0to0-masters-minimal-ipv6-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1114,6 +1125,7 @@ resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-ingress-all-0to0-nodes-minimal-ipv6-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-ipv6-example-com.id
@@ -1124,6 +1136,7 @@ resource "aws_security_group_rule" "from-masters-minimal-ipv6-example-com-ingres
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-minimal-ipv6-example-com.id
@@ -1132,6 +1145,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1141,6 +1155,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-4-0to0-masters-minimal-ipv6-example-com" {
+  description              = "IPIP from nodes to control plane"
   from_port                = 0
   protocol                 = "4"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1150,6 +1165,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-all-0to0-nodes-minimal-ipv6-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-ipv6-example-com.id
@@ -1159,6 +1175,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-tcp-1to2379-masters-minimal-ipv6-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1168,6 +1185,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-tcp-2382to4000-masters-minimal-ipv6-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1177,6 +1195,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-tcp-4003to65535-masters-minimal-ipv6-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1186,6 +1205,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-udp-1to65535-masters-minimal-ipv6-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1195,6 +1215,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-ipv6-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1205,6 +1226,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-minimal-ipv6-example-com.id
@@ -1213,6 +1235,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-minimal-ipv6-example-com.id
@@ -1222,6 +1245,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-minimal-ipv6-example-com.id
@@ -1231,6 +1255,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 }
 
 resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
+  description       = "ICMPv6 path MTU discovery to API load balancer"
   from_port         = -1
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "icmpv6"
@@ -773,6 +773,7 @@ resource "aws_security_group" "nodes-minimal-warmpool-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-minimal-warmpool-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -782,6 +783,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-minimal-warmpool-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-minimal-warmpool-example-com.id
@@ -791,6 +793,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-min
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-minimal-warmpool-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -800,6 +803,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -808,6 +812,7 @@ resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-eg
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -817,6 +822,7 @@ resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-eg
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-ingress-all-0to0-masters-minimal-warmpool-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -826,6 +832,7 @@ resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-in
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-ingress-all-0to0-nodes-minimal-warmpool-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-warmpool-example-com.id
@@ -836,6 +843,7 @@ resource "aws_security_group_rule" "from-masters-minimal-warmpool-example-com-in
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-minimal-warmpool-example-com.id
@@ -844,6 +852,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -853,6 +862,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingress-all-0to0-nodes-minimal-warmpool-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-warmpool-example-com.id
@@ -862,6 +872,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingress-tcp-1to2379-masters-minimal-warmpool-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -871,6 +882,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingress-tcp-2382to4000-masters-minimal-warmpool-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -880,6 +892,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingress-tcp-4003to65535-masters-minimal-warmpool-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -889,6 +902,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-warmpool-example-com-ingress-udp-1to65535-masters-minimal-warmpool-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-minimal-warmpool-example-com.id
@@ -874,6 +874,7 @@ resource "aws_security_group" "nodes-minimal-k8s-local" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-minimal-k8s-local" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-k8s-local.id
@@ -883,6 +884,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-minimal-k8s-local" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-minimal-k8s-local.id
@@ -892,6 +894,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-min
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-minimal-k8s-local" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-minimal-k8s-local.id
@@ -901,6 +904,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-minimal-k8s-local-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-minimal-k8s-local.id
@@ -909,6 +913,7 @@ resource "aws_security_group_rule" "from-masters-minimal-k8s-local-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-k8s-local-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -918,6 +923,7 @@ resource "aws_security_group_rule" "from-masters-minimal-k8s-local-egress-all-0t
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-k8s-local-ingress-all-0to0-masters-minimal-k8s-local" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-minimal-k8s-local.id
@@ -927,6 +933,7 @@ resource "aws_security_group_rule" "from-masters-minimal-k8s-local-ingress-all-0
 }
 
 resource "aws_security_group_rule" "from-masters-minimal-k8s-local-ingress-all-0to0-nodes-minimal-k8s-local" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-k8s-local.id
@@ -937,6 +944,7 @@ resource "aws_security_group_rule" "from-masters-minimal-k8s-local-ingress-all-0
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-minimal-k8s-local.id
@@ -945,6 +953,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-egress-all-0to0
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -954,6 +963,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-egress-all-0to0
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-all-0to0-nodes-minimal-k8s-local" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-minimal-k8s-local.id
@@ -963,6 +973,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-all-0to
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-tcp-1to2379-masters-minimal-k8s-local" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-k8s-local.id
@@ -972,6 +983,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-tcp-1to
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-tcp-2382to4000-masters-minimal-k8s-local" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-k8s-local.id
@@ -981,6 +993,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-tcp-238
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-tcp-4003to65535-masters-minimal-k8s-local" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-minimal-k8s-local.id
@@ -990,6 +1003,7 @@ resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-tcp-400
 }
 
 resource "aws_security_group_rule" "from-nodes-minimal-k8s-local-ingress-udp-1to65535-masters-minimal-k8s-local" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-minimal-k8s-local.id
@@ -1225,6 +1225,7 @@ resource "aws_security_group" "nodes-mixedinstances-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-mixedinstances-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-mixedinstances-example-com.id
@@ -1234,6 +1235,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-m
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-mixedinstances-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-mixedinstances-example-com.id
@@ -1243,6 +1245,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-mix
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-mixedinstances-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-mixedinstances-example-com.id
@@ -1252,6 +1255,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-mixedinstances-example-com.id
@@ -1260,6 +1264,7 @@ resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1269,6 +1274,7 @@ resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-ingress-all-0to0-masters-mixedinstances-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-mixedinstances-example-com.id
@@ -1278,6 +1284,7 @@ resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-ingress-all-0to0-nodes-mixedinstances-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-mixedinstances-example-com.id
@@ -1288,6 +1295,7 @@ resource "aws_security_group_rule" "from-masters-mixedinstances-example-com-ingr
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-mixedinstances-example-com.id
@@ -1296,6 +1304,7 @@ resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1305,6 +1314,7 @@ resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingress-all-0to0-nodes-mixedinstances-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-mixedinstances-example-com.id
@@ -1314,6 +1324,7 @@ resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingress-tcp-1to2379-masters-mixedinstances-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-mixedinstances-example-com.id
@@ -1323,6 +1334,7 @@ resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingress-tcp-2382to4000-masters-mixedinstances-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-mixedinstances-example-com.id
@@ -1332,6 +1344,7 @@ resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingress-tcp-4003to65535-masters-mixedinstances-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-mixedinstances-example-com.id
@@ -1341,6 +1354,7 @@ resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-mixedinstances-example-com-ingress-udp-1to65535-masters-mixedinstances-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-mixedinstances-example-com.id
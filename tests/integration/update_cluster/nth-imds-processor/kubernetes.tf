@@ -661,6 +661,7 @@ resource "aws_security_group" "nodes-nthimdsprocessor-longclustername-example-co
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-nthimdsprocessor-longclustername-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -670,6 +671,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-n
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-nthimdsprocessor-longclustername-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-nthimdsprocessor-longclustername-example-com.id
@@ -679,6 +681,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-nth
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-nthimdsprocessor-longclustername-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -688,6 +691,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclustername-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -696,6 +700,7 @@ resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclusternam
 }
 
 resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclustername-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -705,6 +710,7 @@ resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclusternam
 }
 
 resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclustername-example-com-ingress-all-0to0-masters-nthimdsprocessor-longclustername-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -714,6 +720,7 @@ resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclusternam
 }
 
 resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclustername-example-com-ingress-all-0to0-nodes-nthimdsprocessor-longclustername-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-nthimdsprocessor-longclustername-example-com.id
@@ -724,6 +731,7 @@ resource "aws_security_group_rule" "from-masters-nthimdsprocessor-longclusternam
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-nthimdsprocessor-longclustername-example-com.id
@@ -732,6 +740,7 @@ resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-
 }
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -741,6 +750,7 @@ resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-
 }
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-ingress-all-0to0-nodes-nthimdsprocessor-longclustername-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-nthimdsprocessor-longclustername-example-com.id
@@ -750,6 +760,7 @@ resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-
 }
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-ingress-tcp-1to2379-masters-nthimdsprocessor-longclustername-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -759,6 +770,7 @@ resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-
 }
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-ingress-tcp-2382to4000-masters-nthimdsprocessor-longclustername-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -768,6 +780,7 @@ resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-
 }
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-ingress-tcp-4003to65535-masters-nthimdsprocessor-longclustername-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
@@ -777,6 +790,7 @@ resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-
 }
 
 resource "aws_security_group_rule" "from-nodes-nthimdsprocessor-longclustername-example-com-ingress-udp-1to65535-masters-nthimdsprocessor-longclustername-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-nthimdsprocessor-longclustername-example-com.id
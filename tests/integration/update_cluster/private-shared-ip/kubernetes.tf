@@ -752,6 +752,7 @@ resource "aws_launch_template" "nodes-private-shared-ip-example-com" {
 resource "aws_lb" "bastion-private-shared-ip-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-private-shared-ip-eepmph"
   security_groups                  = [aws_security_group.bastion-elb-private-shared-ip-example-com.id]
@@ -1084,6 +1085,7 @@ resource "aws_security_group" "nodes-private-shared-ip-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-private-shared-ip-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-private-shared-ip-example-com.id
@@ -1093,6 +1095,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-private-shared-ip-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-private-shared-ip-example-com.id
@@ -1102,6 +1105,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-private-shared-ip-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-private-shared-ip-example-com.id
@@ -1111,6 +1115,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-private-shared-ip-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-private-shared-ip-example-com.id
@@ -1119,6 +1124,7 @@ resource "aws_security_group_rule" "from-api-elb-private-shared-ip-example-com-e
 }
 
 resource "aws_security_group_rule" "from-api-elb-private-shared-ip-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1129,6 +1135,7 @@ resource "aws_security_group_rule" "from-api-elb-private-shared-ip-example-com-e
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-private-shared-ip-example-com.id
@@ -1137,6 +1144,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1146,6 +1154,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-com-ingress-icmp-3to4-bastion-private-shared-ip-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-private-shared-ip-example-com.id
@@ -1155,6 +1164,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-com-ingress-tcp-22to22-bastion-private-shared-ip-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-private-shared-ip-example-com.id
@@ -1165,6 +1175,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-ip-example-c
 
 resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-private-shared-ip-example-com.id
@@ -1173,6 +1184,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1182,6 +1194,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-ingress-icmp-3to4-bastion-elb-private-shared-ip-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-private-shared-ip-example-com.id
@@ -1191,6 +1204,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-i
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-ingress-tcp-22to22-masters-private-shared-ip-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1200,6 +1214,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-i
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-ingress-tcp-22to22-nodes-private-shared-ip-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-private-shared-ip-example-com.id
@@ -1210,6 +1225,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-ip-example-com-i
 
 resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1218,6 +1234,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-e
 }
 
 resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1227,6 +1244,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-e
 }
 
 resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-ingress-all-0to0-masters-private-shared-ip-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1236,6 +1254,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-i
 }
 
 resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-ingress-all-0to0-nodes-private-shared-ip-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-private-shared-ip-example-com.id
@@ -1246,6 +1265,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-ip-example-com-i
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-private-shared-ip-example-com.id
@@ -1254,6 +1274,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1263,6 +1284,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ingress-all-0to0-nodes-private-shared-ip-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-private-shared-ip-example-com.id
@@ -1272,6 +1294,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ingress-tcp-1to2379-masters-private-shared-ip-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1281,6 +1304,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ingress-tcp-2382to4000-masters-private-shared-ip-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1290,6 +1314,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ingress-tcp-4003to65535-masters-private-shared-ip-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1299,6 +1324,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ingress-udp-1to65535-masters-private-shared-ip-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1308,6 +1334,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-ip-example-com-ing
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1318,6 +1345,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-private-shared-ip-example-com.id
@@ -1326,6 +1354,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-private-shared-ip-example-com.id
@@ -1335,6 +1364,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-private-shared-ip-example-com.id
@@ -1345,6 +1375,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-private-shared-ip-example-com.id
@@ -1354,6 +1385,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-private-shared-ip-example-com.id
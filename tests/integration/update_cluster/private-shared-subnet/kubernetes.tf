@@ -747,6 +747,7 @@ resource "aws_launch_template" "nodes-private-shared-subnet-example-com" {
 resource "aws_lb" "bastion-private-shared-subnet-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-private-shared-su-5ol32q"
   security_groups                  = [aws_security_group.bastion-elb-private-shared-subnet-example-com.id]
@@ -1021,6 +1022,7 @@ resource "aws_security_group" "nodes-private-shared-subnet-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-private-shared-subnet-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-private-shared-subnet-example-com.id
@@ -1030,6 +1032,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-private-shared-subnet-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-private-shared-subnet-example-com.id
@@ -1039,6 +1042,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-private-shared-subnet-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-private-shared-subnet-example-com.id
@@ -1048,6 +1052,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-private-shared-subnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-private-shared-subnet-example-com.id
@@ -1056,6 +1061,7 @@ resource "aws_security_group_rule" "from-api-elb-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-api-elb-private-shared-subnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1066,6 +1072,7 @@ resource "aws_security_group_rule" "from-api-elb-private-shared-subnet-example-c
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-private-shared-subnet-example-com.id
@@ -1074,6 +1081,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-examp
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1083,6 +1091,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-examp
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-example-com-ingress-icmp-3to4-bastion-private-shared-subnet-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-private-shared-subnet-example-com.id
@@ -1092,6 +1101,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-examp
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-example-com-ingress-tcp-22to22-bastion-private-shared-subnet-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-private-shared-subnet-example-com.id
@@ -1102,6 +1112,7 @@ resource "aws_security_group_rule" "from-bastion-elb-private-shared-subnet-examp
 
 resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-private-shared-subnet-example-com.id
@@ -1110,6 +1121,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1119,6 +1131,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-com-ingress-icmp-3to4-bastion-elb-private-shared-subnet-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-private-shared-subnet-example-com.id
@@ -1128,6 +1141,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-com-ingress-tcp-22to22-masters-private-shared-subnet-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1137,6 +1151,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-com-ingress-tcp-22to22-nodes-private-shared-subnet-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-private-shared-subnet-example-com.id
@@ -1147,6 +1162,7 @@ resource "aws_security_group_rule" "from-bastion-private-shared-subnet-example-c
 
 resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1155,6 +1171,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1164,6 +1181,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-com-ingress-all-0to0-masters-private-shared-subnet-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1173,6 +1191,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-com-ingress-all-0to0-nodes-private-shared-subnet-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-private-shared-subnet-example-com.id
@@ -1183,6 +1202,7 @@ resource "aws_security_group_rule" "from-masters-private-shared-subnet-example-c
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-private-shared-subnet-example-com.id
@@ -1191,6 +1211,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1200,6 +1221,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-ingress-all-0to0-nodes-private-shared-subnet-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-private-shared-subnet-example-com.id
@@ -1209,6 +1231,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-ingress-tcp-1to2379-masters-private-shared-subnet-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1218,6 +1241,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-ingress-tcp-2382to4000-masters-private-shared-subnet-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1227,6 +1251,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-ingress-tcp-4003to65535-masters-private-shared-subnet-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1236,6 +1261,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com-ingress-udp-1to65535-masters-private-shared-subnet-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1245,6 +1271,7 @@ resource "aws_security_group_rule" "from-nodes-private-shared-subnet-example-com
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1255,6 +1282,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-private-shared-subnet-example-com.id
@@ -1263,6 +1291,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-private-shared-subnet-example-com.id
@@ -1272,6 +1301,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-private-shared-subnet-example-com.id
@@ -1282,6 +1312,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-private-shared-subnet-example-com.id
@@ -1291,6 +1322,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-private-shared-subnet-example-com.id
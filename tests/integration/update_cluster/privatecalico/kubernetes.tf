@@ -766,6 +766,7 @@ resource "aws_launch_template" "nodes-privatecalico-example-com" {
 resource "aws_lb" "bastion-privatecalico-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privatecalico-exa-hocohm"
   security_groups                  = [aws_security_group.bastion-elb-privatecalico-example-com.id]
@@ -1114,6 +1115,7 @@ resource "aws_security_group" "nodes-privatecalico-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privatecalico-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatecalico-example-com.id
@@ -1123,6 +1125,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privatecalico-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privatecalico-example-com.id
@@ -1132,6 +1135,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privatecalico-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatecalico-example-com.id
@@ -1140,6 +1144,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-bastion-elb-privatecalico-example-com" {
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -1149,6 +1154,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-22to22-bastion-elb-pr
 }
 
 resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-api-elb-privatecalico-example-com" {
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "tcp"
@@ -1159,6 +1165,7 @@ resource "aws_security_group_rule" "from-__--0-ingress-tcp-443to443-api-elb-priv
 
 resource "aws_security_group_rule" "from-api-elb-privatecalico-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privatecalico-example-com.id
@@ -1167,6 +1174,7 @@ resource "aws_security_group_rule" "from-api-elb-privatecalico-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-api-elb-privatecalico-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1177,6 +1185,7 @@ resource "aws_security_group_rule" "from-api-elb-privatecalico-example-com-egres
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privatecalico-example-com.id
@@ -1185,6 +1194,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1194,6 +1204,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-ingress-icmp-3to4-bastion-privatecalico-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privatecalico-example-com.id
@@ -1203,6 +1214,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-i
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-ingress-tcp-22to22-bastion-privatecalico-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privatecalico-example-com.id
@@ -1213,6 +1225,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecalico-example-com-i
 
 resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privatecalico-example-com.id
@@ -1221,6 +1234,7 @@ resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1230,6 +1244,7 @@ resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-ingress-icmp-3to4-bastion-elb-privatecalico-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privatecalico-example-com.id
@@ -1239,6 +1254,7 @@ resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-ingress-tcp-22to22-masters-privatecalico-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1248,6 +1264,7 @@ resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-ingress-tcp-22to22-nodes-privatecalico-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privatecalico-example-com.id
@@ -1258,6 +1275,7 @@ resource "aws_security_group_rule" "from-bastion-privatecalico-example-com-ingre
 
 resource "aws_security_group_rule" "from-masters-privatecalico-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privatecalico-example-com.id
@@ -1266,6 +1284,7 @@ resource "aws_security_group_rule" "from-masters-privatecalico-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatecalico-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1275,6 +1294,7 @@ resource "aws_security_group_rule" "from-masters-privatecalico-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatecalico-example-com-ingress-all-0to0-masters-privatecalico-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1284,6 +1304,7 @@ resource "aws_security_group_rule" "from-masters-privatecalico-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-masters-privatecalico-example-com-ingress-all-0to0-nodes-privatecalico-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatecalico-example-com.id
@@ -1294,6 +1315,7 @@ resource "aws_security_group_rule" "from-masters-privatecalico-example-com-ingre
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privatecalico-example-com.id
@@ -1302,6 +1324,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1311,6 +1334,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress-4-0to0-masters-privatecalico-example-com" {
+  description              = "IPIP from nodes to control plane"
   from_port                = 0
   protocol                 = "4"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1320,6 +1344,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress-all-0to0-nodes-privatecalico-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatecalico-example-com.id
@@ -1329,6 +1354,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress-tcp-1to2379-masters-privatecalico-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1338,6 +1364,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress-tcp-2382to4000-masters-privatecalico-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1347,6 +1374,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress-tcp-4003to65535-masters-privatecalico-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1356,6 +1384,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress-udp-1to65535-masters-privatecalico-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1365,6 +1394,7 @@ resource "aws_security_group_rule" "from-nodes-privatecalico-example-com-ingress
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1375,6 +1405,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privatecalico-example-com.id
@@ -1383,6 +1414,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privatecalico-example-com.id
@@ -1392,6 +1424,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privatecalico-example-com.id
@@ -1402,6 +1435,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatecalico-example-com.id
@@ -1411,6 +1445,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatecalico-example-com.id
@@ -1419,6 +1454,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
 }
 
 resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
+  description       = "ICMPv6 path MTU discovery to API load balancer"
   from_port         = -1
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "icmpv6"
@@ -1428,6 +1464,7 @@ resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
 }
 
 resource "aws_security_group_rule" "icmpv6-pmtu-ssh-nlb-__--0" {
+  description       = "ICMPv6 path MTU discovery to bastion load balancer"
   from_port         = -1
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "icmpv6"
@@ -770,6 +770,7 @@ resource "aws_launch_template" "nodes-privatecilium-example-com" {
 resource "aws_lb" "bastion-privatecilium-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privatecilium-exa-l2ms01"
   security_groups                  = [aws_security_group.bastion-elb-privatecilium-example-com.id]
@@ -1110,6 +1111,7 @@ resource "aws_security_group" "nodes-privatecilium-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privatecilium-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1119,6 +1121,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privatecilium-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1128,6 +1131,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privatecilium-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1137,6 +1141,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1145,6 +1150,7 @@ resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1155,6 +1161,7 @@ resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egres
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1163,6 +1170,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1172,6 +1180,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-ingress-icmp-3to4-bastion-privatecilium-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privatecilium-example-com.id
@@ -1181,6 +1190,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-i
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-ingress-tcp-22to22-bastion-privatecilium-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privatecilium-example-com.id
@@ -1191,6 +1201,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-i
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privatecilium-example-com.id
@@ -1199,6 +1210,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1208,6 +1220,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingress-icmp-3to4-bastion-elb-privatecilium-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1217,6 +1230,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingress-tcp-22to22-masters-privatecilium-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1226,6 +1240,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingress-tcp-22to22-nodes-privatecilium-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privatecilium-example-com.id
@@ -1236,6 +1251,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingre
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privatecilium-example-com.id
@@ -1244,6 +1260,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1253,6 +1270,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingress-all-0to0-masters-privatecilium-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1262,6 +1280,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingress-all-0to0-nodes-privatecilium-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatecilium-example-com.id
@@ -1272,6 +1291,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingre
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privatecilium-example-com.id
@@ -1280,6 +1300,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1289,6 +1310,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-all-0to0-nodes-privatecilium-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatecilium-example-com.id
@@ -1298,6 +1320,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-tcp-1to2379-masters-privatecilium-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1307,6 +1330,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-tcp-2382to4000-masters-privatecilium-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1316,6 +1340,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-tcp-4003to65535-masters-privatecilium-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1325,6 +1350,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-udp-1to65535-masters-privatecilium-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1334,6 +1360,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1344,6 +1371,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1352,6 +1380,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1361,6 +1390,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1371,6 +1401,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1380,6 +1411,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
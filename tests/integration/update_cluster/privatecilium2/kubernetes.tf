@@ -770,6 +770,7 @@ resource "aws_launch_template" "nodes-privatecilium-example-com" {
 resource "aws_lb" "bastion-privatecilium-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privatecilium-exa-l2ms01"
   security_groups                  = [aws_security_group.bastion-elb-privatecilium-example-com.id]
@@ -1126,6 +1127,7 @@ resource "aws_security_group" "nodes-privatecilium-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privatecilium-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1135,6 +1137,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privatecilium-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1144,6 +1147,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privatecilium-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1153,6 +1157,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1161,6 +1166,7 @@ resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1171,6 +1177,7 @@ resource "aws_security_group_rule" "from-api-elb-privatecilium-example-com-egres
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1179,6 +1186,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1188,6 +1196,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-ingress-icmp-3to4-bastion-privatecilium-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privatecilium-example-com.id
@@ -1197,6 +1206,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-i
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-ingress-tcp-22to22-bastion-privatecilium-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privatecilium-example-com.id
@@ -1207,6 +1217,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatecilium-example-com-i
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privatecilium-example-com.id
@@ -1215,6 +1226,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1224,6 +1236,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingress-icmp-3to4-bastion-elb-privatecilium-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1233,6 +1246,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingress-tcp-22to22-masters-privatecilium-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1242,6 +1256,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingress-tcp-22to22-nodes-privatecilium-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privatecilium-example-com.id
@@ -1252,6 +1267,7 @@ resource "aws_security_group_rule" "from-bastion-privatecilium-example-com-ingre
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privatecilium-example-com.id
@@ -1260,6 +1276,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1269,6 +1286,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingress-all-0to0-masters-privatecilium-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1278,6 +1296,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingress-all-0to0-nodes-privatecilium-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatecilium-example-com.id
@@ -1288,6 +1307,7 @@ resource "aws_security_group_rule" "from-masters-privatecilium-example-com-ingre
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privatecilium-example-com.id
@@ -1296,6 +1316,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1305,6 +1326,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-all-0to0-nodes-privatecilium-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatecilium-example-com.id
@@ -1314,6 +1336,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-tcp-1to2379-masters-privatecilium-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1323,6 +1346,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-tcp-2382to4000-masters-privatecilium-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1332,6 +1356,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-tcp-4003to65535-masters-privatecilium-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1341,6 +1366,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress-udp-1to65535-masters-privatecilium-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1350,6 +1376,7 @@ resource "aws_security_group_rule" "from-nodes-privatecilium-example-com-ingress
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1360,6 +1387,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1368,6 +1396,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privatecilium-example-com.id
@@ -1377,6 +1406,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privatecilium-example-com.id
@@ -1387,6 +1417,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
@@ -1396,6 +1427,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatecilium-example-com.id
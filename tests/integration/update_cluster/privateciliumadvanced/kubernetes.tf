@@ -787,6 +787,7 @@ resource "aws_launch_template" "nodes-privateciliumadvanced-example-com" {
 resource "aws_lb" "bastion-privateciliumadvanced-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privateciliumadva-0jni40"
   security_groups                  = [aws_security_group.bastion-elb-privateciliumadvanced-example-com.id]
@@ -1143,6 +1144,7 @@ resource "aws_security_group" "nodes-privateciliumadvanced-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privateciliumadvanced-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privateciliumadvanced-example-com.id
@@ -1152,6 +1154,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privateciliumadvanced-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privateciliumadvanced-example-com.id
@@ -1161,6 +1164,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privateciliumadvanced-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privateciliumadvanced-example-com.id
@@ -1170,6 +1174,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privateciliumadvanced-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privateciliumadvanced-example-com.id
@@ -1178,6 +1183,7 @@ resource "aws_security_group_rule" "from-api-elb-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-api-elb-privateciliumadvanced-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1188,6 +1194,7 @@ resource "aws_security_group_rule" "from-api-elb-privateciliumadvanced-example-c
 
 resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privateciliumadvanced-example-com.id
@@ -1196,6 +1203,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-examp
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1205,6 +1213,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-examp
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-example-com-ingress-icmp-3to4-bastion-privateciliumadvanced-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privateciliumadvanced-example-com.id
@@ -1214,6 +1223,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-examp
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-example-com-ingress-tcp-22to22-bastion-privateciliumadvanced-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privateciliumadvanced-example-com.id
@@ -1224,6 +1234,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateciliumadvanced-examp
 
 resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privateciliumadvanced-example-com.id
@@ -1232,6 +1243,7 @@ resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1241,6 +1253,7 @@ resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-com-ingress-icmp-3to4-bastion-elb-privateciliumadvanced-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privateciliumadvanced-example-com.id
@@ -1250,6 +1263,7 @@ resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-com-ingress-tcp-22to22-masters-privateciliumadvanced-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1259,6 +1273,7 @@ resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-com-ingress-tcp-22to22-nodes-privateciliumadvanced-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privateciliumadvanced-example-com.id
@@ -1269,6 +1284,7 @@ resource "aws_security_group_rule" "from-bastion-privateciliumadvanced-example-c
 
 resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1277,6 +1293,7 @@ resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1286,6 +1303,7 @@ resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-com-ingress-all-0to0-masters-privateciliumadvanced-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1295,6 +1313,7 @@ resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-c
 }
 
 resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-com-ingress-all-0to0-nodes-privateciliumadvanced-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privateciliumadvanced-example-com.id
@@ -1305,6 +1324,7 @@ resource "aws_security_group_rule" "from-masters-privateciliumadvanced-example-c
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privateciliumadvanced-example-com.id
@@ -1313,6 +1333,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1322,6 +1343,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-ingress-all-0to0-nodes-privateciliumadvanced-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privateciliumadvanced-example-com.id
@@ -1331,6 +1353,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-ingress-tcp-1to2379-masters-privateciliumadvanced-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1340,6 +1363,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-ingress-tcp-2383to4000-masters-privateciliumadvanced-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2383
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1349,6 +1373,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-ingress-tcp-4003to65535-masters-privateciliumadvanced-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1358,6 +1383,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com-ingress-udp-1to65535-masters-privateciliumadvanced-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1367,6 +1393,7 @@ resource "aws_security_group_rule" "from-nodes-privateciliumadvanced-example-com
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1377,6 +1404,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privateciliumadvanced-example-com.id
@@ -1385,6 +1413,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privateciliumadvanced-example-com.id
@@ -1394,6 +1423,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privateciliumadvanced-example-com.id
@@ -1404,6 +1434,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privateciliumadvanced-example-com.id
@@ -1413,6 +1444,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privateciliumadvanced-example-com.id
@@ -850,6 +850,7 @@ resource "aws_launch_template" "nodes-privatedns1-example-com" {
 resource "aws_lb" "bastion-privatedns1-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privatedns1-examp-mbgbef"
   security_groups                  = [aws_security_group.bastion-elb-privatedns1-example-com.id]
@@ -1207,6 +1208,7 @@ resource "aws_security_group" "nodes-privatedns1-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privatedns1-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatedns1-example-com.id
@@ -1216,6 +1218,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privatedns1-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privatedns1-example-com.id
@@ -1225,6 +1228,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privatedns1-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatedns1-example-com.id
@@ -1234,6 +1238,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privatedns1-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privatedns1-example-com.id
@@ -1242,6 +1247,7 @@ resource "aws_security_group_rule" "from-api-elb-privatedns1-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-api-elb-privatedns1-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1252,6 +1258,7 @@ resource "aws_security_group_rule" "from-api-elb-privatedns1-example-com-egress-
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privatedns1-example-com.id
@@ -1260,6 +1267,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1269,6 +1277,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-ingress-icmp-3to4-bastion-privatedns1-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privatedns1-example-com.id
@@ -1278,6 +1287,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-ingress-tcp-22to22-bastion-privatedns1-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privatedns1-example-com.id
@@ -1288,6 +1298,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns1-example-com-ing
 
 resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privatedns1-example-com.id
@@ -1296,6 +1307,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1305,6 +1317,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-ingress-icmp-3to4-bastion-elb-privatedns1-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privatedns1-example-com.id
@@ -1314,6 +1327,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-ingress-tcp-22to22-masters-privatedns1-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1323,6 +1337,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-ingress-tcp-22to22-nodes-privatedns1-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privatedns1-example-com.id
@@ -1333,6 +1348,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns1-example-com-ingress
 
 resource "aws_security_group_rule" "from-masters-privatedns1-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privatedns1-example-com.id
@@ -1341,6 +1357,7 @@ resource "aws_security_group_rule" "from-masters-privatedns1-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-masters-privatedns1-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1350,6 +1367,7 @@ resource "aws_security_group_rule" "from-masters-privatedns1-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-masters-privatedns1-example-com-ingress-all-0to0-masters-privatedns1-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1359,6 +1377,7 @@ resource "aws_security_group_rule" "from-masters-privatedns1-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-masters-privatedns1-example-com-ingress-all-0to0-nodes-privatedns1-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatedns1-example-com.id
@@ -1369,6 +1388,7 @@ resource "aws_security_group_rule" "from-masters-privatedns1-example-com-ingress
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privatedns1-example-com.id
@@ -1377,6 +1397,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1386,6 +1407,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-all-0to0-nodes-privatedns1-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatedns1-example-com.id
@@ -1395,6 +1417,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-tcp-1to2379-masters-privatedns1-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1404,6 +1427,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-tcp-2382to4000-masters-privatedns1-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1413,6 +1437,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-tcp-4003to65535-masters-privatedns1-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1422,6 +1447,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-udp-1to65535-masters-privatedns1-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1431,6 +1457,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns1-example-com-ingress-u
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1441,6 +1468,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privatedns1-example-com.id
@@ -1449,6 +1477,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privatedns1-example-com.id
@@ -1458,6 +1487,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privatedns1-example-com.id
@@ -1468,6 +1498,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatedns1-example-com.id
@@ -1477,6 +1508,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatedns1-example-com.id
@@ -761,6 +761,7 @@ resource "aws_launch_template" "nodes-privatedns2-example-com" {
 resource "aws_lb" "bastion-privatedns2-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privatedns2-examp-e704o2"
   security_groups                  = [aws_security_group.bastion-elb-privatedns2-example-com.id]
@@ -1093,6 +1094,7 @@ resource "aws_security_group" "nodes-privatedns2-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privatedns2-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatedns2-example-com.id
@@ -1102,6 +1104,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privatedns2-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privatedns2-example-com.id
@@ -1111,6 +1114,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privatedns2-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatedns2-example-com.id
@@ -1120,6 +1124,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privatedns2-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privatedns2-example-com.id
@@ -1128,6 +1133,7 @@ resource "aws_security_group_rule" "from-api-elb-privatedns2-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-api-elb-privatedns2-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1138,6 +1144,7 @@ resource "aws_security_group_rule" "from-api-elb-privatedns2-example-com-egress-
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privatedns2-example-com.id
@@ -1146,6 +1153,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1155,6 +1163,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-egr
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-ingress-icmp-3to4-bastion-privatedns2-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privatedns2-example-com.id
@@ -1164,6 +1173,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-ing
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-ingress-tcp-22to22-bastion-privatedns2-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privatedns2-example-com.id
@@ -1174,6 +1184,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatedns2-example-com-ing
 
 resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privatedns2-example-com.id
@@ -1182,6 +1193,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1191,6 +1203,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-ingress-icmp-3to4-bastion-elb-privatedns2-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privatedns2-example-com.id
@@ -1200,6 +1213,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-ingress-tcp-22to22-masters-privatedns2-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1209,6 +1223,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-ingress-tcp-22to22-nodes-privatedns2-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privatedns2-example-com.id
@@ -1219,6 +1234,7 @@ resource "aws_security_group_rule" "from-bastion-privatedns2-example-com-ingress
 
 resource "aws_security_group_rule" "from-masters-privatedns2-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privatedns2-example-com.id
@@ -1227,6 +1243,7 @@ resource "aws_security_group_rule" "from-masters-privatedns2-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-masters-privatedns2-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1236,6 +1253,7 @@ resource "aws_security_group_rule" "from-masters-privatedns2-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-masters-privatedns2-example-com-ingress-all-0to0-masters-privatedns2-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1245,6 +1263,7 @@ resource "aws_security_group_rule" "from-masters-privatedns2-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-masters-privatedns2-example-com-ingress-all-0to0-nodes-privatedns2-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatedns2-example-com.id
@@ -1255,6 +1274,7 @@ resource "aws_security_group_rule" "from-masters-privatedns2-example-com-ingress
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privatedns2-example-com.id
@@ -1263,6 +1283,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1272,6 +1293,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-all-0to0-nodes-privatedns2-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatedns2-example-com.id
@@ -1281,6 +1303,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-tcp-1to2379-masters-privatedns2-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1290,6 +1313,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-tcp-2382to4000-masters-privatedns2-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1299,6 +1323,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-tcp-4003to65535-masters-privatedns2-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1308,6 +1333,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-udp-1to65535-masters-privatedns2-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1317,6 +1343,7 @@ resource "aws_security_group_rule" "from-nodes-privatedns2-example-com-ingress-u
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1327,6 +1354,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privatedns2-example-com.id
@@ -1335,6 +1363,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privatedns2-example-com.id
@@ -1344,6 +1373,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privatedns2-example-com.id
@@ -1354,6 +1384,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatedns2-example-com.id
@@ -1363,6 +1394,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatedns2-example-com.id
@@ -770,6 +770,7 @@ resource "aws_launch_template" "nodes-privateflannel-example-com" {
 resource "aws_lb" "bastion-privateflannel-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privateflannel-ex-753531"
   security_groups                  = [aws_security_group.bastion-elb-privateflannel-example-com.id]
@@ -1118,6 +1119,7 @@ resource "aws_security_group" "nodes-privateflannel-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privateflannel-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privateflannel-example-com.id
@@ -1127,6 +1129,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privateflannel-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privateflannel-example-com.id
@@ -1136,6 +1139,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privateflannel-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privateflannel-example-com.id
@@ -1145,6 +1149,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privateflannel-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privateflannel-example-com.id
@@ -1153,6 +1158,7 @@ resource "aws_security_group_rule" "from-api-elb-privateflannel-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-api-elb-privateflannel-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1163,6 +1169,7 @@ resource "aws_security_group_rule" "from-api-elb-privateflannel-example-com-egre
 
 resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privateflannel-example-com.id
@@ -1171,6 +1178,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1180,6 +1188,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-ingress-icmp-3to4-bastion-privateflannel-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privateflannel-example-com.id
@@ -1189,6 +1198,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-ingress-tcp-22to22-bastion-privateflannel-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privateflannel-example-com.id
@@ -1199,6 +1209,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privateflannel-example-com-
 
 resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privateflannel-example-com.id
@@ -1207,6 +1218,7 @@ resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1216,6 +1228,7 @@ resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-ingress-icmp-3to4-bastion-elb-privateflannel-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privateflannel-example-com.id
@@ -1225,6 +1238,7 @@ resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-ingress-tcp-22to22-masters-privateflannel-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1234,6 +1248,7 @@ resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-ingress-tcp-22to22-nodes-privateflannel-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privateflannel-example-com.id
@@ -1244,6 +1259,7 @@ resource "aws_security_group_rule" "from-bastion-privateflannel-example-com-ingr
 
 resource "aws_security_group_rule" "from-masters-privateflannel-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privateflannel-example-com.id
@@ -1252,6 +1268,7 @@ resource "aws_security_group_rule" "from-masters-privateflannel-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-masters-privateflannel-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1261,6 +1278,7 @@ resource "aws_security_group_rule" "from-masters-privateflannel-example-com-egre
 }
 
 resource "aws_security_group_rule" "from-masters-privateflannel-example-com-ingress-all-0to0-masters-privateflannel-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1270,6 +1288,7 @@ resource "aws_security_group_rule" "from-masters-privateflannel-example-com-ingr
 }
 
 resource "aws_security_group_rule" "from-masters-privateflannel-example-com-ingress-all-0to0-nodes-privateflannel-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privateflannel-example-com.id
@@ -1280,6 +1299,7 @@ resource "aws_security_group_rule" "from-masters-privateflannel-example-com-ingr
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privateflannel-example-com.id
@@ -1288,6 +1308,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1297,6 +1318,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingress-all-0to0-nodes-privateflannel-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privateflannel-example-com.id
@@ -1306,6 +1328,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingress-tcp-1to2379-masters-privateflannel-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1315,6 +1338,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingress-tcp-2382to4000-masters-privateflannel-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1324,6 +1348,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingress-tcp-4003to65535-masters-privateflannel-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1333,6 +1358,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingress-udp-1to65535-masters-privateflannel-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1342,6 +1368,7 @@ resource "aws_security_group_rule" "from-nodes-privateflannel-example-com-ingres
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1352,6 +1379,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privateflannel-example-com.id
@@ -1360,6 +1388,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privateflannel-example-com.id
@@ -1369,6 +1398,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privateflannel-example-com.id
@@ -1379,6 +1409,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privateflannel-example-com.id
@@ -1388,6 +1419,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privateflannel-example-com.id
@@ -776,6 +776,7 @@ resource "aws_launch_template" "nodes-privatekopeio-example-com" {
 resource "aws_lb" "bastion-privatekopeio-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-privatekopeio-exa-d8ef8e"
   security_groups                  = [aws_security_group.bastion-elb-privatekopeio-example-com.id]
@@ -1135,6 +1136,7 @@ resource "aws_security_group" "nodes-privatekopeio-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-privatekopeio-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1144,6 +1146,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-privatekopeio-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-privatekopeio-example-com.id
@@ -1153,6 +1156,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-privatekopeio-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1162,6 +1166,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-172-20-8-0--22-ingress-tcp-22to22-bastion-elb-privatekopeio-example-com" {
   cidr_blocks       = ["172.20.8.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1171,6 +1176,7 @@ resource "aws_security_group_rule" "from-172-20-8-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-privatekopeio-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-privatekopeio-example-com.id
@@ -1179,6 +1185,7 @@ resource "aws_security_group_rule" "from-api-elb-privatekopeio-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-api-elb-privatekopeio-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1189,6 +1196,7 @@ resource "aws_security_group_rule" "from-api-elb-privatekopeio-example-com-egres
 
 resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1197,6 +1205,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1206,6 +1215,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-e
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-ingress-icmp-3to4-bastion-privatekopeio-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-privatekopeio-example-com.id
@@ -1215,6 +1225,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-i
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-ingress-tcp-22to22-bastion-privatekopeio-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-privatekopeio-example-com.id
@@ -1225,6 +1236,7 @@ resource "aws_security_group_rule" "from-bastion-elb-privatekopeio-example-com-i
 
 resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-privatekopeio-example-com.id
@@ -1233,6 +1245,7 @@ resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1242,6 +1255,7 @@ resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-ingress-icmp-3to4-bastion-elb-privatekopeio-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1251,6 +1265,7 @@ resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-ingress-tcp-22to22-masters-privatekopeio-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1260,6 +1275,7 @@ resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-ingress-tcp-22to22-nodes-privatekopeio-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-privatekopeio-example-com.id
@@ -1270,6 +1286,7 @@ resource "aws_security_group_rule" "from-bastion-privatekopeio-example-com-ingre
 
 resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-privatekopeio-example-com.id
@@ -1278,6 +1295,7 @@ resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1287,6 +1305,7 @@ resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-ingress-all-0to0-masters-privatekopeio-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1296,6 +1315,7 @@ resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-ingress-all-0to0-nodes-privatekopeio-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatekopeio-example-com.id
@@ -1306,6 +1326,7 @@ resource "aws_security_group_rule" "from-masters-privatekopeio-example-com-ingre
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-privatekopeio-example-com.id
@@ -1314,6 +1335,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1323,6 +1345,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-egress-
 }
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress-all-0to0-nodes-privatekopeio-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-privatekopeio-example-com.id
@@ -1332,6 +1355,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress-tcp-1to2379-masters-privatekopeio-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1341,6 +1365,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress-tcp-2382to4000-masters-privatekopeio-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1350,6 +1375,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress-tcp-4003to65535-masters-privatekopeio-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1359,6 +1385,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress
 }
 
 resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress-udp-1to65535-masters-privatekopeio-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1368,6 +1395,7 @@ resource "aws_security_group_rule" "from-nodes-privatekopeio-example-com-ingress
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1378,6 +1406,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-privatekopeio-example-com.id
@@ -1386,6 +1415,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-privatekopeio-example-com.id
@@ -1395,6 +1425,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-privatekopeio-example-com.id
@@ -1405,6 +1436,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1414,6 +1446,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -1423,6 +1456,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-8-0--22" {
   cidr_blocks       = ["172.20.8.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-privatekopeio-example-com.id
@@ -717,6 +717,7 @@ resource "aws_security_group" "nodes-sharedsubnet-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-sharedsubnet-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-sharedsubnet-example-com.id
@@ -726,6 +727,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-s
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-sharedsubnet-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-sharedsubnet-example-com.id
@@ -735,6 +737,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-sha
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-sharedsubnet-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-sharedsubnet-example-com.id
@@ -744,6 +747,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-sharedsubnet-example-com.id
@@ -752,6 +756,7 @@ resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -761,6 +766,7 @@ resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-egress
 }
 
 resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-ingress-all-0to0-masters-sharedsubnet-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-sharedsubnet-example-com.id
@@ -770,6 +776,7 @@ resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-ingres
 }
 
 resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-ingress-all-0to0-nodes-sharedsubnet-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-sharedsubnet-example-com.id
@@ -780,6 +787,7 @@ resource "aws_security_group_rule" "from-masters-sharedsubnet-example-com-ingres
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-sharedsubnet-example-com.id
@@ -788,6 +796,7 @@ resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -797,6 +806,7 @@ resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-egress-a
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-all-0to0-nodes-sharedsubnet-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-sharedsubnet-example-com.id
@@ -806,6 +816,7 @@ resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-tcp-1to2379-masters-sharedsubnet-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-sharedsubnet-example-com.id
@@ -815,6 +826,7 @@ resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-tcp-2382to4000-masters-sharedsubnet-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-sharedsubnet-example-com.id
@@ -824,6 +836,7 @@ resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-tcp-4003to65535-masters-sharedsubnet-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-sharedsubnet-example-com.id
@@ -833,6 +846,7 @@ resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedsubnet-example-com-ingress-udp-1to65535-masters-sharedsubnet-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-sharedsubnet-example-com.id
@@ -744,6 +744,7 @@ resource "aws_security_group" "nodes-sharedvpc-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-sharedvpc-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to control plane"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-sharedvpc-example-com.id
@@ -753,6 +754,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-masters-s
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-sharedvpc-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to nodes"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.nodes-sharedvpc-example-com.id
@@ -762,6 +764,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-nodes-sha
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters-sharedvpc-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to control plane"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.masters-sharedvpc-example-com.id
@@ -771,6 +774,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-masters
 
 resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-sharedvpc-example-com.id
@@ -779,6 +783,7 @@ resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -788,6 +793,7 @@ resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-ingress-all-0to0-masters-sharedvpc-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-sharedvpc-example-com.id
@@ -797,6 +803,7 @@ resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-ingress-a
 }
 
 resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-ingress-all-0to0-nodes-sharedvpc-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-sharedvpc-example-com.id
@@ -807,6 +814,7 @@ resource "aws_security_group_rule" "from-masters-sharedvpc-example-com-ingress-a
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-sharedvpc-example-com.id
@@ -815,6 +823,7 @@ resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -824,6 +833,7 @@ resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-all-0to0-nodes-sharedvpc-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-sharedvpc-example-com.id
@@ -833,6 +843,7 @@ resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-all
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-tcp-1to2379-masters-sharedvpc-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-sharedvpc-example-com.id
@@ -842,6 +853,7 @@ resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-tcp
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-tcp-2382to4000-masters-sharedvpc-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-sharedvpc-example-com.id
@@ -851,6 +863,7 @@ resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-tcp
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-tcp-4003to65535-masters-sharedvpc-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-sharedvpc-example-com.id
@@ -860,6 +873,7 @@ resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-tcp
 }
 
 resource "aws_security_group_rule" "from-nodes-sharedvpc-example-com-ingress-udp-1to65535-masters-sharedvpc-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-sharedvpc-example-com.id
@@ -752,6 +752,7 @@ resource "aws_launch_template" "nodes-unmanaged-example-com" {
 resource "aws_lb" "bastion-unmanaged-example-com" {
   enable_cross_zone_load_balancing = false
   internal                         = false
+  ip_address_type                  = "ipv4"
   load_balancer_type               = "network"
   name                             = "bastion-unmanaged-example-d7bn3d"
   security_groups                  = [aws_security_group.bastion-elb-unmanaged-example-com.id]
@@ -1029,6 +1030,7 @@ resource "aws_security_group" "nodes-unmanaged-example-com" {
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-elb-unmanaged-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1038,6 +1040,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-22to22-bastion-e
 
 resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb-unmanaged-example-com" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "Kubernetes API from API access CIDR to load balancer"
   from_port         = 443
   protocol          = "tcp"
   security_group_id = aws_security_group.api-elb-unmanaged-example-com.id
@@ -1047,6 +1050,7 @@ resource "aws_security_group_rule" "from-0-0-0-0--0-ingress-tcp-443to443-api-elb
 
 resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-bastion-elb-unmanaged-example-com" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1056,6 +1060,7 @@ resource "aws_security_group_rule" "from-172-20-4-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-172-20-8-0--22-ingress-tcp-22to22-bastion-elb-unmanaged-example-com" {
   cidr_blocks       = ["172.20.8.0/22"]
+  description       = "SSH from admin CIDR to bastion load balancer"
   from_port         = 22
   protocol          = "tcp"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1065,6 +1070,7 @@ resource "aws_security_group_rule" "from-172-20-8-0--22-ingress-tcp-22to22-basti
 
 resource "aws_security_group_rule" "from-api-elb-unmanaged-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from API load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.api-elb-unmanaged-example-com.id
@@ -1073,6 +1079,7 @@ resource "aws_security_group_rule" "from-api-elb-unmanaged-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-api-elb-unmanaged-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from API load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1083,6 +1090,7 @@ resource "aws_security_group_rule" "from-api-elb-unmanaged-example-com-egress-al
 
 resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastion load balancer"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1091,6 +1099,7 @@ resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastion load balancer"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1100,6 +1109,7 @@ resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-egres
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-ingress-icmp-3to4-bastion-unmanaged-example-com" {
+  description              = "ICMP path MTU discovery from load balancer to bastions"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-unmanaged-example-com.id
@@ -1109,6 +1119,7 @@ resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-ingre
 }
 
 resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-ingress-tcp-22to22-bastion-unmanaged-example-com" {
+  description              = "SSH from load balancer to bastions"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.bastion-unmanaged-example-com.id
@@ -1119,6 +1130,7 @@ resource "aws_security_group_rule" "from-bastion-elb-unmanaged-example-com-ingre
 
 resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from bastions"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.bastion-unmanaged-example-com.id
@@ -1127,6 +1139,7 @@ resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from bastions"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1136,6 +1149,7 @@ resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-ingress-icmp-3to4-bastion-elb-unmanaged-example-com" {
+  description              = "ICMP path MTU discovery from bastions to load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1145,6 +1159,7 @@ resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-ingress-i
 }
 
 resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-ingress-tcp-22to22-masters-unmanaged-example-com" {
+  description              = "SSH from bastions to control plane"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1154,6 +1169,7 @@ resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-ingress-t
 }
 
 resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-ingress-tcp-22to22-nodes-unmanaged-example-com" {
+  description              = "SSH from bastions to nodes"
   from_port                = 22
   protocol                 = "tcp"
   security_group_id        = aws_security_group.nodes-unmanaged-example-com.id
@@ -1164,6 +1180,7 @@ resource "aws_security_group_rule" "from-bastion-unmanaged-example-com-ingress-t
 
 resource "aws_security_group_rule" "from-masters-unmanaged-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from control plane"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.masters-unmanaged-example-com.id
@@ -1172,6 +1189,7 @@ resource "aws_security_group_rule" "from-masters-unmanaged-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-masters-unmanaged-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from control plane"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1181,6 +1199,7 @@ resource "aws_security_group_rule" "from-masters-unmanaged-example-com-egress-al
 }
 
 resource "aws_security_group_rule" "from-masters-unmanaged-example-com-ingress-all-0to0-masters-unmanaged-example-com" {
+  description              = "All traffic between control plane instances"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1190,6 +1209,7 @@ resource "aws_security_group_rule" "from-masters-unmanaged-example-com-ingress-a
 }
 
 resource "aws_security_group_rule" "from-masters-unmanaged-example-com-ingress-all-0to0-nodes-unmanaged-example-com" {
+  description              = "All traffic from control plane to nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-unmanaged-example-com.id
@@ -1200,6 +1220,7 @@ resource "aws_security_group_rule" "from-masters-unmanaged-example-com-ingress-a
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-egress-all-0to0-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "IPv4 egress from nodes"
   from_port         = 0
   protocol          = "-1"
   security_group_id = aws_security_group.nodes-unmanaged-example-com.id
@@ -1208,6 +1229,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-egress-all-0to0-__--0" {
+  description       = "IPv6 egress from nodes"
   from_port         = 0
   ipv6_cidr_blocks  = ["::/0"]
   protocol          = "-1"
@@ -1217,6 +1239,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-egress-all-
 }
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-all-0to0-nodes-unmanaged-example-com" {
+  description              = "All traffic between nodes"
   from_port                = 0
   protocol                 = "-1"
   security_group_id        = aws_security_group.nodes-unmanaged-example-com.id
@@ -1226,6 +1249,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-all
 }
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-tcp-1to2379-masters-unmanaged-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 1
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1235,6 +1259,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-tcp
 }
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-tcp-2382to4000-masters-unmanaged-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 2382
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1244,6 +1269,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-tcp
 }
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-tcp-4003to65535-masters-unmanaged-example-com" {
+  description              = "TCP from nodes to control plane, except etcd"
   from_port                = 4003
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1253,6 +1279,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-tcp
 }
 
 resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-udp-1to65535-masters-unmanaged-example-com" {
+  description              = "UDP from nodes to control plane"
   from_port                = 1
   protocol                 = "udp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1262,6 +1289,7 @@ resource "aws_security_group_rule" "from-nodes-unmanaged-example-com-ingress-udp
 }
 
 resource "aws_security_group_rule" "https-elb-to-master" {
+  description              = "Kubernetes API from load balancer to control plane"
   from_port                = 443
   protocol                 = "tcp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1272,6 +1300,7 @@ resource "aws_security_group_rule" "https-elb-to-master" {
 
 resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to API load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.api-elb-unmanaged-example-com.id
@@ -1280,6 +1309,7 @@ resource "aws_security_group_rule" "icmp-pmtu-api-elb-0-0-0-0--0" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
+  description              = "ICMP path MTU discovery from control plane to API load balancer"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.api-elb-unmanaged-example-com.id
@@ -1289,6 +1319,7 @@ resource "aws_security_group_rule" "icmp-pmtu-cp-to-elb" {
 }
 
 resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
+  description              = "ICMP path MTU discovery from API load balancer to control plane"
   from_port                = 3
   protocol                 = "icmp"
   security_group_id        = aws_security_group.masters-unmanaged-example-com.id
@@ -1299,6 +1330,7 @@ resource "aws_security_group_rule" "icmp-pmtu-elb-to-cp" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
   cidr_blocks       = ["0.0.0.0/0"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1308,6 +1340,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-0-0-0-0--0" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
   cidr_blocks       = ["172.20.4.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -1317,6 +1350,7 @@ resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-4-0--22" {
 
 resource "aws_security_group_rule" "icmp-pmtu-ssh-nlb-172-20-8-0--22" {
   cidr_blocks       = ["172.20.8.0/22"]
+  description       = "ICMP path MTU discovery to bastion load balancer"
   from_port         = 3
   protocol          = "icmp"
   security_group_id = aws_security_group.bastion-elb-unmanaged-example-com.id
@@ -43,6 +43,7 @@ import (
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/pkg/kubeletca"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/awsmodel"
@@ -58,6 +59,7 @@ import (
 	"k8s.io/kops/pkg/model/openstackmodel"
 	"k8s.io/kops/pkg/model/scalewaymodel"
 	"k8s.io/kops/pkg/templates"
+	"k8s.io/kops/pkg/timings"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/models"
 	"k8s.io/kops/upup/pkg/fi"
@@ -74,16 +76,12 @@ import (
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/hashing"
 	"k8s.io/kops/util/pkg/mirrors"
+	"k8s.io/kops/util/pkg/reflectutils"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
 const (
 	starline = "*********************************************************************************"
-
-	// OldestSupportedKubernetesVersion is the oldest kubernetes version that is supported in kOps.
-	OldestSupportedKubernetesVersion = "1.24.0"
-	// OldestRecommendedKubernetesVersion is the oldest kubernetes version that is not deprecated in kOps.
-	OldestRecommendedKubernetesVersion = "1.26.0"
 )
 
 // TerraformCloudProviders is the list of cloud providers with terraform target support
@@ -119,14 +117,28 @@ type ApplyClusterCmd struct {
 	//  url with hash: <hex>@http://... or <hex>@https://...
 	Assets map[architectures.Architecture][]*mirrors.MirroredAsset
 
+	// InstanceGroupAssets replace the Assets for the instance groups that override
+	// the kubernetesVersion or the containerd version, by instance group name.
+	InstanceGroupAssets map[string]map[architectures.Architecture][]*mirrors.MirroredAsset
+
 	Clientset simple.Clientset
 
 	// DryRun is true if this is only a dry run
 	DryRun bool
 
+	// DryRunOutput is the format in which a dry run reports the planned changes: "json" or "yaml",
+	// or empty for human-readable text. Other messages go to stderr when it is set.
+	DryRunOutput string
+
 	// AllowKopsDowngrade permits applying with a kops version older than what was last used to apply to the cluster.
 	AllowKopsDowngrade bool
 
+	// AllowReplacement permits changes that require replacing all the instances of an instance group.
+	AllowReplacement bool
+
+	// ForceOverwrite permits overwriting terraform output files that were modified since they were generated.
+	ForceOverwrite bool
+
 	// RunTasksOptions defines parameters for task execution, e.g. retry interval
 	RunTasksOptions *fi.RunTasksOptions
 
@@ -144,6 +156,14 @@ type ApplyClusterCmd struct {
 	// GetAssets is whether this is called just to obtain the list of assets.
 	GetAssets bool
 
+	// TargetInstanceGroups restricts the update to the tasks of the named instance groups,
+	// only checking the shared resources they depend on. All instance groups are updated when empty.
+	TargetInstanceGroups []string
+
+	// IgnoreClusterChanges permits targeting instance groups when the cluster has changes that
+	// would not be applied.
+	IgnoreClusterChanges bool
+
 	// TaskMap is the map of tasks that we built (output)
 	TaskMap map[string]fi.CloudupTask
 
@@ -171,6 +191,9 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		if c.Cloud.ProviderID() == kops.CloudProviderDO && !featureflag.DOTerraform.Enabled() {
 			return fmt.Errorf("DO Terraform requires the DOTerraform feature flag to be enabled")
 		}
+		if len(c.TargetInstanceGroups) != 0 {
+			return fmt.Errorf("targeting instance groups is not supported with the terraform target")
+		}
 	}
 	if c.InstanceGroups == nil {
 		list, err := c.Clientset.InstanceGroupsFor(c.Cluster).List(ctx, metav1.ListOptions{})
@@ -250,7 +273,9 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	}
 
 	assetBuilder := assets.NewAssetBuilder(c.Clientset.VFSContext(), c.Cluster.Spec.Assets, c.Cluster.Spec.KubernetesVersion, c.GetAssets)
+	stopPhase := timings.StartPhase("PopulateClusterSpec")
 	err = c.upgradeSpecs(ctx, assetBuilder)
+	stopPhase()
 	if err != nil {
 		return err
 	}
@@ -298,7 +323,8 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 
 	cloud := c.Cloud
 
-	err = validation.DeepValidate(c.Cluster, c.InstanceGroups, true, c.Clientset.VFSContext(), cloud)
+	warnings, err := validation.DeepValidateWithWarnings(c.Cluster, c.InstanceGroups, true, c.Clientset.VFSContext(), cloud)
+	validation.PrintWarnings(c.messageOut(), warnings)
 	if err != nil {
 		return err
 	}
@@ -516,7 +542,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		cloud:            cloud,
 	}
 
-	configBuilder, err := NewNodeUpConfigBuilder(cluster, assetBuilder, c.Assets, encryptionConfigSecretHash)
+	configBuilder, err := NewNodeUpConfigBuilder(cluster, assetBuilder, c.Assets, c.InstanceGroupAssets, encryptionConfigSecretHash)
 	if err != nil {
 		return err
 	}
@@ -601,6 +627,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 				Lifecycle:              clusterLifecycle,
 				SecurityLifecycle:      securityLifecycle,
 				Cluster:                cluster,
+				AllowReplacement:       c.AllowReplacement,
 			}
 
 			if featureflag.Spotinst.Enabled() {
@@ -705,11 +732,41 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 			return fmt.Errorf("unknown cloudprovider %q", cluster.Spec.GetCloudProvider())
 		}
 	}
+	stopPhase = timings.StartPhase("BuildTasks")
 	c.TaskMap, err = l.BuildTasks(ctx, c.LifecycleOverrides)
+	stopPhase()
 	if err != nil {
 		return fmt.Errorf("error building tasks: %v", err)
 	}
 
+	var tagPolicyRemovals map[string][]string
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		tagPolicyRemovals = applyTagPolicy(cluster, c.InstanceGroups, c.TaskMap)
+	}
+
+	// The dependencies of the tasks, when they are found before the tasks are run
+	var dependencies map[string][]string
+	if len(c.TargetInstanceGroups) != 0 {
+		if !c.IgnoreClusterChanges {
+			changed, err := hasPendingClusterChanges(ctx, configBase, c.TaskMap)
+			if err != nil {
+				return err
+			}
+			if changed {
+				return fmt.Errorf("the cluster has changes that would not be applied when targeting instance groups; update the whole cluster, or use --ignore-cluster-changes")
+			}
+		}
+
+		dependencies = fi.FindTaskDependencies(c.TaskMap)
+		targets, err := targetInstanceGroups(c.TaskMap, dependencies, c.TargetInstanceGroups)
+		if err != nil {
+			return err
+		}
+		if !c.GetAssets {
+			printInstanceGroupTargets(c.messageOut(), c.TargetInstanceGroups, targets)
+		}
+	}
+
 	var target fi.CloudupTarget
 	shouldPrecreateDNS := true
 
@@ -737,6 +794,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	case TargetTerraform:
 		outDir := c.OutDir
 		tf := terraform.NewTerraformTarget(cloud, project, outDir, cluster.Spec.Target)
+		tf.ForceOverwrite = c.ForceOverwrite
 
 		// We include a few "util" variables in the TF output
 		if err := tf.AddOutputVariable("region", terraformWriter.LiteralFromStringValue(cloud.Region())); err != nil {
@@ -765,10 +823,15 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 
 	case TargetDryRun:
 		var out io.Writer = os.Stdout
+		messageOut := c.messageOut()
 		if c.GetAssets {
 			out = io.Discard
+			messageOut = io.Discard
 		}
-		target = fi.NewCloudupDryRunTarget(assetBuilder, out)
+		printTagPolicyRemovals(messageOut, tagPolicyRemovals)
+		dryRunTarget := fi.NewCloudupDryRunTarget(assetBuilder, out)
+		dryRunTarget.SetOutputFormat(c.DryRunOutput)
+		target = dryRunTarget
 
 		// Avoid making changes on a dry-run
 		shouldPrecreateDNS = false
@@ -778,8 +841,11 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	}
 	c.Target = target
 
-	if target.DefaultCheckExisting() {
+	// Deletions are not limited to the targeted instance groups
+	if target.DefaultCheckExisting() && len(c.TargetInstanceGroups) == 0 {
+		stopPhase = timings.StartPhase("FindDeletions")
 		c.TaskMap, err = l.FindDeletions(cloud, c.LifecycleOverrides)
+		stopPhase()
 		if err != nil {
 			return fmt.Errorf("error finding deletions: %w", err)
 		}
@@ -796,8 +862,17 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	} else {
 		options.InitDefaults()
 	}
+	options.Dependencies = dependencies
+
+	// Share identical listings between the Find calls of the tasks in this run
+	if awsCloud, ok := cloud.(awsup.AWSCloud); ok {
+		stopFindCache := awsCloud.StartFindCache()
+		defer stopFindCache()
+	}
 
+	stopPhase = timings.StartPhase("RunTasks")
 	err = context.RunTasks(options)
+	stopPhase()
 	if err != nil {
 		return fmt.Errorf("error running tasks: %v", err)
 	}
@@ -806,13 +881,15 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		shouldPrecreateDNS = false
 	}
 
-	if shouldPrecreateDNS && clusterLifecycle != fi.LifecycleIgnore {
+	if shouldPrecreateDNS && clusterLifecycle != fi.LifecycleIgnore && len(c.TargetInstanceGroups) == 0 {
 		if err := precreateDNS(ctx, cluster, cloud); err != nil {
 			klog.Warningf("unable to pre-create DNS records - cluster startup may be slower: %v", err)
 		}
 	}
 
+	stopPhase = timings.StartPhase("FinishTarget")
 	err = target.Finish(c.TaskMap) // This will finish the apply, and print the changes
+	stopPhase()
 	if err != nil {
 		return fmt.Errorf("error closing target: %v", err)
 	}
@@ -823,6 +900,14 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+// messageOut returns where to print messages for the user, keeping stdout machine-readable when a dry run output format is set
+func (c *ApplyClusterCmd) messageOut() io.Writer {
+	if c.DryRunOutput != "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 // upgradeSpecs ensures that fields are fully populated / defaulted
 func (c *ApplyClusterCmd) upgradeSpecs(ctx context.Context, assetBuilder *assets.AssetBuilder) error {
 	fullCluster, err := PopulateClusterSpec(ctx, c.Clientset, c.Cluster, c.InstanceGroups, c.Cloud, assetBuilder)
@@ -942,23 +1027,23 @@ func (c *ApplyClusterCmd) validateKubernetesVersion() error {
 		}
 	}
 
-	if !util.IsKubernetesGTE(OldestSupportedKubernetesVersion, *parsed) {
+	if !util.IsKubernetesGTE(util.OldestSupportedKubernetesVersion, *parsed) {
 		fmt.Printf("This version of Kubernetes is no longer supported; upgrading Kubernetes is required\n")
 		fmt.Printf("\n")
-		fmt.Printf("More information: %s\n", buildPermalink("upgrade_k8s", OldestRecommendedKubernetesVersion))
+		fmt.Printf("More information: %s\n", buildPermalink("upgrade_k8s", util.OldestRecommendedKubernetesVersion))
 		fmt.Printf("\n")
 		fmt.Printf("%s\n", starline)
 		fmt.Printf("\n")
 		return fmt.Errorf("kubernetes upgrade is required")
 	}
-	if !util.IsKubernetesGTE(OldestRecommendedKubernetesVersion, *parsed) && !c.GetAssets {
+	if !util.IsKubernetesGTE(util.OldestRecommendedKubernetesVersion, *parsed) && !c.GetAssets {
 		fmt.Printf("\n")
 		fmt.Printf("%s\n", starline)
 		fmt.Printf("\n")
 		fmt.Printf("Kops support for this Kubernetes version is deprecated and will be removed in a future release.\n")
 		fmt.Printf("\n")
 		fmt.Printf("Upgrading Kubernetes is recommended\n")
-		fmt.Printf("More information: %s\n", buildPermalink("upgrade_k8s", OldestRecommendedKubernetesVersion))
+		fmt.Printf("More information: %s\n", buildPermalink("upgrade_k8s", util.OldestRecommendedKubernetesVersion))
 		fmt.Printf("\n")
 		fmt.Printf("%s\n", starline)
 		fmt.Printf("\n")
@@ -1029,55 +1114,109 @@ func (c *ApplyClusterCmd) validateKubernetesVersion() error {
 
 // addFileAssets adds the file assets within the assetBuilder
 func (c *ApplyClusterCmd) addFileAssets(assetBuilder *assets.AssetBuilder) error {
+	fileAssets, err := buildFileAssets(c.Cluster, c.InstanceGroups, assetBuilder)
+	if err != nil {
+		return err
+	}
+	c.Assets = fileAssets
+
+	c.InstanceGroupAssets = make(map[string]map[architectures.Architecture][]*mirrors.MirroredAsset)
+	for _, ig := range c.InstanceGroups {
+		igCluster := clusterForInstanceGroup(c.Cluster, ig)
+		if igCluster == c.Cluster {
+			continue
+		}
+		igAssets, err := buildFileAssets(igCluster, c.InstanceGroups, assetBuilder)
+		if err != nil {
+			return fmt.Errorf("error building assets for instance group %q: %w", ig.ObjectMeta.Name, err)
+		}
+		c.InstanceGroupAssets[ig.ObjectMeta.Name] = igAssets
+	}
+
+	c.NodeUpAssets = make(map[architectures.Architecture]*mirrors.MirroredAsset)
+	for _, arch := range architectures.GetSupported() {
+		asset, err := NodeUpAsset(assetBuilder, arch)
+		if err != nil {
+			return err
+		}
+		c.NodeUpAssets[arch] = asset
+	}
+
+	return nil
+}
+
+// clusterForInstanceGroup returns the cluster as seen by the nodes of an instance group,
+// with the kubernetesVersion and the containerd version overridden by the instance group.
+// It returns the cluster itself if the instance group overrides neither.
+func clusterForInstanceGroup(cluster *kops.Cluster, ig *kops.InstanceGroup) *kops.Cluster {
+	containerd := ig.Spec.Containerd
+	overridesContainerd := containerd != nil && (containerd.Version != nil || containerd.Packages != nil || containerd.Runc != nil)
+	if ig.Spec.KubernetesVersion == "" && !overridesContainerd {
+		return cluster
+	}
+
+	igCluster := cluster.DeepCopy()
+	if ig.Spec.KubernetesVersion != "" {
+		igCluster.Spec.KubernetesVersion = ig.Spec.KubernetesVersion
+	}
+	if overridesContainerd {
+		config := igCluster.Spec.Containerd
+		reflectutils.JSONMergeStruct(&config, containerd)
+		igCluster.Spec.Containerd = config
+	}
+	return igCluster
+}
+
+// buildFileAssets returns the file assets of the nodes of the cluster, for each architecture
+func buildFileAssets(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, assetBuilder *assets.AssetBuilder) (map[architectures.Architecture][]*mirrors.MirroredAsset, error) {
 	var baseURL string
-	if components.IsBaseURL(c.Cluster.Spec.KubernetesVersion) {
-		baseURL = c.Cluster.Spec.KubernetesVersion
+	if components.IsBaseURL(cluster.Spec.KubernetesVersion) {
+		baseURL = cluster.Spec.KubernetesVersion
 	} else {
-		baseURL = "https://dl.k8s.io/release/v" + c.Cluster.Spec.KubernetesVersion
+		baseURL = "https://dl.k8s.io/release/v" + cluster.Spec.KubernetesVersion
 	}
 
-	c.Assets = make(map[architectures.Architecture][]*mirrors.MirroredAsset)
-	c.NodeUpAssets = make(map[architectures.Architecture]*mirrors.MirroredAsset)
+	fileAssets := make(map[architectures.Architecture][]*mirrors.MirroredAsset)
 	for _, arch := range architectures.GetSupported() {
-		c.Assets[arch] = []*mirrors.MirroredAsset{}
+		fileAssets[arch] = []*mirrors.MirroredAsset{}
 
 		k8sAssetsNames := []string{
 			fmt.Sprintf("/bin/linux/%s/kubelet", arch),
 			fmt.Sprintf("/bin/linux/%s/kubectl", arch),
 		}
 
-		if needsMounterAsset(c.Cluster, c.InstanceGroups) {
+		if needsMounterAsset(cluster, instanceGroups) {
 			k8sAssetsNames = append(k8sAssetsNames, fmt.Sprintf("/bin/linux/%s/mounter", arch))
 		}
 
 		for _, an := range k8sAssetsNames {
 			k, err := url.Parse(baseURL)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			k.Path = path.Join(k.Path, an)
 
 			u, hash, err := assetBuilder.RemapFileAndSHA(k)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(u, hash))
+			fileAssets[arch] = append(fileAssets[arch], mirrors.BuildMirroredAsset(u, hash))
 		}
 
-		kubernetesVersion, _ := util.ParseKubernetesVersion(c.Cluster.Spec.KubernetesVersion)
+		kubernetesVersion, _ := util.ParseKubernetesVersion(cluster.Spec.KubernetesVersion)
 
-		cloudProvider := c.Cluster.Spec.GetCloudProvider()
+		cloudProvider := cluster.Spec.GetCloudProvider()
 		if ok := apiModel.UseExternalKubeletCredentialProvider(*kubernetesVersion, cloudProvider); ok {
 			switch cloudProvider {
 			case kops.CloudProviderGCE:
-				binaryLocation := c.Cluster.Spec.CloudProvider.GCE.BinariesLocation
+				binaryLocation := cluster.Spec.CloudProvider.GCE.BinariesLocation
 				if binaryLocation == nil {
 					binaryLocation = fi.PtrTo("https://storage.googleapis.com/k8s-staging-cloud-provider-gcp/auth-provider-gcp")
 				}
 				// VALID FOR 60 DAYS WE REALLY NEED TO MERGE https://github.com/kubernetes/cloud-provider-gcp/pull/601 and CUT A RELEASE
 				k, err := url.Parse(fmt.Sprintf("%s/linux-%s/v20231005-providersv0.27.1-65-g8fbe8d27", *binaryLocation, arch))
 				if err != nil {
-					return err
+					return nil, err
 				}
 
 				hashes := map[architectures.Architecture]string{
@@ -1086,67 +1225,61 @@ func (c *ApplyClusterCmd) addFileAssets(assetBuilder *assets.AssetBuilder) error
 				}
 				hash, err := hashing.FromString(hashes[arch])
 				if err != nil {
-					return fmt.Errorf("unable to parse auth-provider-gcp binary asset hash %q: %v", hashes[arch], err)
+					return nil, fmt.Errorf("unable to parse auth-provider-gcp binary asset hash %q: %v", hashes[arch], err)
 				}
 				u, err := assetBuilder.RemapFileAndSHAValue(k, hashes[arch])
 				if err != nil {
-					return err
+					return nil, err
 				}
 
-				c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(u, hash))
+				fileAssets[arch] = append(fileAssets[arch], mirrors.BuildMirroredAsset(u, hash))
 			case kops.CloudProviderAWS:
-				binaryLocation := c.Cluster.Spec.CloudProvider.AWS.BinariesLocation
+				binaryLocation := cluster.Spec.CloudProvider.AWS.BinariesLocation
 				if binaryLocation == nil {
 					binaryLocation = fi.PtrTo("https://artifacts.k8s.io/binaries/cloud-provider-aws/v1.27.1")
 				}
 
 				k, err := url.Parse(fmt.Sprintf("%s/linux/%s/ecr-credential-provider-linux-%s", *binaryLocation, arch, arch))
 				if err != nil {
-					return err
+					return nil, err
 				}
 				u, hash, err := assetBuilder.RemapFileAndSHA(k)
 				if err != nil {
-					return err
+					return nil, err
 				}
 
-				c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(u, hash))
+				fileAssets[arch] = append(fileAssets[arch], mirrors.BuildMirroredAsset(u, hash))
 			}
 		}
 
 		{
-			cniAsset, cniAssetHash, err := findCNIAssets(c.Cluster, assetBuilder, arch)
+			cniAsset, cniAssetHash, err := findCNIAssets(cluster, assetBuilder, arch)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(cniAsset, cniAssetHash))
+			fileAssets[arch] = append(fileAssets[arch], mirrors.BuildMirroredAsset(cniAsset, cniAssetHash))
 		}
 
-		if c.Cluster.Spec.Containerd == nil || !c.Cluster.Spec.Containerd.SkipInstall {
-			containerdAssetUrl, containerdAssetHash, err := findContainerdAsset(c.Cluster, assetBuilder, arch)
+		if cluster.Spec.Containerd == nil || !cluster.Spec.Containerd.SkipInstall {
+			containerdAssetUrl, containerdAssetHash, err := findContainerdAsset(cluster, assetBuilder, arch)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if containerdAssetUrl != nil && containerdAssetHash != nil {
-				c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(containerdAssetUrl, containerdAssetHash))
+				fileAssets[arch] = append(fileAssets[arch], mirrors.BuildMirroredAsset(containerdAssetUrl, containerdAssetHash))
 			}
 
-			runcAssetUrl, runcAssetHash, err := findRuncAsset(c.Cluster, assetBuilder, arch)
+			runcAssetUrl, runcAssetHash, err := findRuncAsset(cluster, assetBuilder, arch)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if runcAssetUrl != nil && runcAssetHash != nil {
-				c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(runcAssetUrl, runcAssetHash))
+				fileAssets[arch] = append(fileAssets[arch], mirrors.BuildMirroredAsset(runcAssetUrl, runcAssetHash))
 			}
 		}
-
-		asset, err := NodeUpAsset(assetBuilder, arch)
-		if err != nil {
-			return err
-		}
-		c.NodeUpAssets[arch] = asset
 	}
 
-	return nil
+	return fileAssets, nil
 }
 
 // buildPermalink returns a link to our "permalink docs", to further explain an error message
@@ -1184,6 +1317,8 @@ type nodeUpConfigBuilder struct {
 	//  raw url: http://... or https://...
 	//  url with hash: <hex>@http://... or <hex>@https://...
 	assets map[architectures.Architecture][]*mirrors.MirroredAsset
+	// instanceGroupAssets replace the assets for some instance groups, by instance group name.
+	instanceGroupAssets map[string]map[architectures.Architecture][]*mirrors.MirroredAsset
 
 	assetBuilder               *assets.AssetBuilder
 	channels                   []string
@@ -1196,7 +1331,7 @@ type nodeUpConfigBuilder struct {
 	encryptionConfigSecretHash string
 }
 
-func NewNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBuilder, assets map[architectures.Architecture][]*mirrors.MirroredAsset, encryptionConfigSecretHash string) (model.NodeUpConfigBuilder, error) {
+func NewNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBuilder, assets map[architectures.Architecture][]*mirrors.MirroredAsset, instanceGroupAssets map[string]map[architectures.Architecture][]*mirrors.MirroredAsset, encryptionConfigSecretHash string) (model.NodeUpConfigBuilder, error) {
 	configBase, err := vfs.Context.BuildVfsPath(cluster.Spec.ConfigStore.Base)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing configStore.base %q: %v", cluster.Spec.ConfigStore.Base, err)
@@ -1332,6 +1467,7 @@ func NewNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBui
 	configBuilder := nodeUpConfigBuilder{
 		assetBuilder:               assetBuilder,
 		assets:                     assets,
+		instanceGroupAssets:        instanceGroupAssets,
 		channels:                   channels,
 		configBase:                 configBase,
 		cluster:                    cluster,
@@ -1345,6 +1481,69 @@ func NewNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBui
 	return &configBuilder, nil
 }
 
+// findControlPlaneIPs returns the IP addresses that nodes can use to reach the API server and kops-controller,
+// with the addresses of the IP family preferred by the nodes first.
+func findControlPlaneIPs(cluster *kops.Cluster, apiserverAdditionalIPs []string) ([]string, error) {
+	var controlPlaneIPs []string
+	switch cluster.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS, kops.CloudProviderHetzner, kops.CloudProviderOpenstack:
+		// Use a private IP address that belongs to the cluster network CIDR (some additional addresses may be FQDNs or public IPs)
+		var networkCIDRs []*net.IPNet
+		for _, networkCIDR := range append(cluster.Spec.Networking.AdditionalNetworkCIDRs, cluster.Spec.Networking.NetworkCIDR) {
+			_, cidr, err := net.ParseCIDR(networkCIDR)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse network CIDR %q: %w", networkCIDR, err)
+			}
+			networkCIDRs = append(networkCIDRs, cidr)
+		}
+		for _, subnet := range cluster.Spec.Networking.Subnets {
+			// Subnets may only have the offset of an IPv6 CIDR that the cloud provider assigns, such as "/64#1"
+			if _, cidr, err := net.ParseCIDR(subnet.IPv6CIDR); err == nil {
+				networkCIDRs = append(networkCIDRs, cidr)
+			}
+		}
+
+		for _, additionalIP := range apiserverAdditionalIPs {
+			ip := net.ParseIP(additionalIP)
+			if ip == nil {
+				continue
+			}
+			if ip.To4() == nil && cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+				// The only IPv6 addresses found on AWS are those of the network interfaces of the API load balancer,
+				// which belong to the VPC even when its IPv6 CIDR is assigned by AWS.
+				controlPlaneIPs = append(controlPlaneIPs, additionalIP)
+				continue
+			}
+			for _, cidr := range networkCIDRs {
+				if cidr.Contains(ip) {
+					controlPlaneIPs = append(controlPlaneIPs, additionalIP)
+					break
+				}
+			}
+		}
+
+	case kops.CloudProviderDO, kops.CloudProviderScaleway, kops.CloudProviderGCE, kops.CloudProviderAzure:
+		// Use any IP address that is found (including public ones)
+		for _, additionalIP := range apiserverAdditionalIPs {
+			controlPlaneIPs = append(controlPlaneIPs, additionalIP)
+		}
+	}
+
+	preferIPv6 := cluster.Spec.IsIPv6Only()
+	if len(cluster.Spec.NodeIPFamilies) > 0 {
+		preferIPv6 = cluster.Spec.NodeIPFamilies[0] == kops.IPFamilyIPv6
+	}
+	isPreferred := func(address string) bool {
+		ip := net.ParseIP(address)
+		return ip != nil && (ip.To4() == nil) == preferIPv6
+	}
+	sort.SliceStable(controlPlaneIPs, func(i, j int) bool {
+		return isPreferred(controlPlaneIPs[i]) && !isPreferred(controlPlaneIPs[j])
+	})
+
+	return controlPlaneIPs, nil
+}
+
 // BuildConfig returns the NodeUp config and auxiliary config.
 func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAdditionalIPs []string, keysets map[string]*fi.Keyset) (*nodeup.Config, *nodeup.BootConfig, error) {
 	cluster := n.cluster
@@ -1364,10 +1563,15 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 
 	config, bootConfig := nodeup.NewConfig(cluster, ig)
 
+	fileAssets := n.assets
+	if igAssets, found := n.instanceGroupAssets[ig.ObjectMeta.Name]; found {
+		fileAssets = igAssets
+	}
+
 	config.Assets = make(map[architectures.Architecture][]string)
 	for _, arch := range architectures.GetSupported() {
 		config.Assets[arch] = []string{}
-		for _, a := range n.assets[arch] {
+		for _, a := range fileAssets[arch] {
 			config.Assets[arch] = append(config.Assets[arch], a.CompactString())
 		}
 	}
@@ -1381,6 +1585,11 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 				return nil, nil, err
 			}
 		}
+		if keysets[kubeletca.KeysetName] != nil {
+			if err := loadCertificates(keysets, kubeletca.KeysetName, config, true); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		if isMaster {
 			if err := loadCertificates(keysets, "etcd-clients-ca", config, true); err != nil {
@@ -1452,28 +1661,9 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 		config.ApiserverAdditionalIPs = apiserverAdditionalIPs
 	}
 
-	// Set API server address to an IP from the cluster network CIDR
-	var controlPlaneIPs []string
-	switch cluster.Spec.GetCloudProvider() {
-	case kops.CloudProviderAWS, kops.CloudProviderHetzner, kops.CloudProviderOpenstack:
-		// Use a private IP address that belongs to the cluster network CIDR (some additional addresses may be FQDNs or public IPs)
-		for _, additionalIP := range apiserverAdditionalIPs {
-			for _, networkCIDR := range append(cluster.Spec.Networking.AdditionalNetworkCIDRs, cluster.Spec.Networking.NetworkCIDR) {
-				_, cidr, err := net.ParseCIDR(networkCIDR)
-				if err != nil {
-					return nil, nil, fmt.Errorf("failed to parse network CIDR %q: %w", networkCIDR, err)
-				}
-				if cidr.Contains(net.ParseIP(additionalIP)) {
-					controlPlaneIPs = append(controlPlaneIPs, additionalIP)
-				}
-			}
-		}
-
-	case kops.CloudProviderDO, kops.CloudProviderScaleway, kops.CloudProviderGCE, kops.CloudProviderAzure:
-		// Use any IP address that is found (including public ones)
-		for _, additionalIP := range apiserverAdditionalIPs {
-			controlPlaneIPs = append(controlPlaneIPs, additionalIP)
-		}
+	controlPlaneIPs, err := findControlPlaneIPs(cluster, apiserverAdditionalIPs)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if cluster.UsesNoneDNS() {
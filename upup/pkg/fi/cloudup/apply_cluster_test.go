@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func Test_FindControlPlaneIPs(t *testing.T) {
+	lbAddresses := []string{
+		"2001:db8:0:1::10",
+		"2001:db8:0:2::10",
+		"203.0.113.10",
+		"api-minimal-example-com-1234.elb.us-east-1.amazonaws.com",
+		"172.20.32.10",
+		"172.20.64.10",
+	}
+
+	tests := []struct {
+		name           string
+		cloud          kops.CloudProviderSpec
+		nodeIPFamilies []string
+		ipv6           bool
+		subnets        []kops.ClusterSubnetSpec
+		expected       []string
+	}{
+		{
+			name:     "ipv4",
+			cloud:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			expected: []string{"172.20.32.10", "172.20.64.10", "2001:db8:0:1::10", "2001:db8:0:2::10"},
+		},
+		{
+			name:           "dual-stack preferring ipv4",
+			cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			nodeIPFamilies: []string{"ipv4", "ipv6"},
+			expected:       []string{"172.20.32.10", "172.20.64.10", "2001:db8:0:1::10", "2001:db8:0:2::10"},
+		},
+		{
+			name:           "dual-stack preferring ipv6",
+			cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			nodeIPFamilies: []string{"ipv6", "ipv4"},
+			expected:       []string{"2001:db8:0:1::10", "2001:db8:0:2::10", "172.20.32.10", "172.20.64.10"},
+		},
+		{
+			name:     "ipv6",
+			cloud:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ipv6:     true,
+			expected: []string{"2001:db8:0:1::10", "2001:db8:0:2::10", "172.20.32.10", "172.20.64.10"},
+		},
+		{
+			name:  "openstack with ipv6 subnet",
+			cloud: kops.CloudProviderSpec{Openstack: &kops.OpenstackSpec{}},
+			ipv6:  true,
+			subnets: []kops.ClusterSubnetSpec{
+				{Name: "a", IPv6CIDR: "2001:db8:0:1::/64"},
+				{Name: "b", IPv6CIDR: "/64#2"},
+			},
+			expected: []string{"2001:db8:0:1::10", "172.20.32.10", "172.20.64.10"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider:  tc.cloud,
+					NodeIPFamilies: tc.nodeIPFamilies,
+					Networking: kops.NetworkingSpec{
+						NetworkCIDR: "172.20.0.0/16",
+						Subnets:     tc.subnets,
+					},
+				},
+			}
+			if tc.ipv6 {
+				cluster.Spec.Networking.NonMasqueradeCIDR = "::/0"
+			}
+
+			actual, err := findControlPlaneIPs(cluster, lbAddresses)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_BuildFileAssetsForInstanceGroup(t *testing.T) {
+	ctx := context.TODO()
+	vfs.Context.ResetMemfsContext(true)
+
+	// The hashes of the assets are read from the file repository
+	for _, arch := range architectures.GetSupported() {
+		for _, p := range []string{
+			"/release/v1.29.2/bin/linux/" + string(arch) + "/kubelet",
+			"/release/v1.29.2/bin/linux/" + string(arch) + "/kubectl",
+			"/release/v1.28.7/bin/linux/" + string(arch) + "/kubelet",
+			"/release/v1.28.7/bin/linux/" + string(arch) + "/kubectl",
+			"/k8s-artifacts-cni/release/v1.2.0/cni-plugins-linux-" + string(arch) + "-v1.2.0.tgz",
+			"/containerd/containerd/releases/download/v1.7.13/containerd-1.7.13-linux-" + string(arch) + ".tar.gz",
+			"/containerd/containerd/releases/download/v1.7.16/containerd-1.7.16-linux-" + string(arch) + ".tar.gz",
+		} {
+			hashFile, err := vfs.Context.BuildVfsPath("memfs://assets" + p + ".sha256")
+			if err != nil {
+				t.Fatalf("error building hash file path: %v", err)
+			}
+			hash := "0123456789012345678901234567890123456789012345678901234567890123"
+			if err := hashFile.WriteFile(ctx, bytes.NewReader([]byte(hash)), nil); err != nil {
+				t.Fatalf("error writing hash file: %v", err)
+			}
+		}
+	}
+
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			KubernetesVersion: "1.29.2",
+			CloudProvider: kops.CloudProviderSpec{
+				DO: &kops.DOSpec{},
+			},
+			Assets: &kops.AssetsSpec{
+				FileRepository: fi.PtrTo("memfs://assets"),
+			},
+			Containerd: &kops.ContainerdConfig{
+				Version: fi.PtrTo("1.7.13"),
+				Runc: &kops.Runc{
+					Version: fi.PtrTo("1.1.9"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		ig             kops.InstanceGroupSpec
+		expectOverride bool
+		expected       []string
+	}{
+		{
+			name:     "cluster versions",
+			expected: []string{"/release/v1.29.2/", "/containerd-1.7.13-", "/v1.1.9/runc."},
+		},
+		{
+			name: "containerd config without version",
+			ig: kops.InstanceGroupSpec{
+				Containerd: &kops.ContainerdConfig{
+					LogLevel: fi.PtrTo("debug"),
+				},
+			},
+			expected: []string{"/release/v1.29.2/", "/containerd-1.7.13-", "/v1.1.9/runc."},
+		},
+		{
+			name: "kubernetes version",
+			ig: kops.InstanceGroupSpec{
+				KubernetesVersion: "1.28.7",
+			},
+			expectOverride: true,
+			expected:       []string{"/release/v1.28.7/", "/containerd-1.7.13-", "/v1.1.9/runc."},
+		},
+		{
+			name: "containerd version",
+			ig: kops.InstanceGroupSpec{
+				Containerd: &kops.ContainerdConfig{
+					Version: fi.PtrTo("1.7.16"),
+				},
+			},
+			expectOverride: true,
+			expected:       []string{"/release/v1.29.2/", "/containerd-1.7.16-", "/v1.1.9/runc."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{Spec: tc.ig}
+			ig.Spec.Role = kops.InstanceGroupRoleNode
+
+			igCluster := clusterForInstanceGroup(cluster, ig)
+			if (igCluster != cluster) != tc.expectOverride {
+				t.Fatalf("unexpected override of the cluster: %v", igCluster != cluster)
+			}
+
+			assetBuilder := assets.NewAssetBuilder(vfs.Context, cluster.Spec.Assets, cluster.Spec.KubernetesVersion, false)
+			fileAssets, err := buildFileAssets(igCluster, []*kops.InstanceGroup{ig}, assetBuilder)
+			if err != nil {
+				t.Fatalf("error building file assets: %v", err)
+			}
+
+			for _, arch := range architectures.GetSupported() {
+				var urls []string
+				for _, asset := range fileAssets[arch] {
+					urls = append(urls, asset.Locations...)
+				}
+				for _, expected := range tc.expected {
+					found := false
+					for _, u := range urls {
+						if strings.Contains(u, expected) {
+							found = true
+						}
+					}
+					if !found {
+						t.Errorf("no %s asset matching %q in %v", arch, expected, urls)
+					}
+				}
+			}
+		})
+	}
+
+	if cluster.Spec.KubernetesVersion != "1.29.2" || fi.ValueOf(cluster.Spec.Containerd.Version) != "1.7.13" {
+		t.Errorf("cluster was modified: %v %v", cluster.Spec.KubernetesVersion, fi.ValueOf(cluster.Spec.Containerd.Version))
+	}
+}
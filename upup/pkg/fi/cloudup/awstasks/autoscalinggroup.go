@@ -42,6 +42,11 @@ const (
 	// https://docs.aws.amazon.com/autoscaling/ec2/userguide/ec2-auto-scaling-quotas.html
 	attachLoadBalancerTargetGroupsMaxItems = 10
 	detachLoadBalancerTargetGroupsMaxItems = 10
+
+	// AWS defaults of the instances distribution of a mixed instances policy
+	defaultMixedOnDemandBase           int64 = 0
+	defaultMixedOnDemandAboveBase      int64 = 100
+	defaultMixedSpotAllocationStrategy       = "lowest-price"
 )
 
 // AutoscalingGroup provides the definition for a autoscaling group in aws
@@ -52,6 +57,8 @@ type AutoscalingGroup struct {
 	// Lifecycle is the resource lifecycle
 	Lifecycle fi.Lifecycle
 
+	// AllowReplacement permits changes that require replacing all the instances of the group
+	AllowReplacement *bool
 	// Granularity specifys the granularity of the metrics
 	Granularity *string
 	// InstanceProtection makes new instances in an autoscaling group protected from scale in
@@ -62,6 +69,12 @@ type AutoscalingGroup struct {
 	LoadBalancers []*ClassicLoadBalancer
 	// MaxInstanceLifetime is the maximum amount of time, in seconds, that an instance can be in service.
 	MaxInstanceLifetime *int64
+	// MinHealthyPercentage is the percentage of the desired capacity that must remain healthy while instances are replaced,
+	// or -1 if the ASG has no instance maintenance policy
+	MinHealthyPercentage *int64
+	// MaxHealthyPercentage is the percentage of the desired capacity that may be running while instances are replaced,
+	// or -1 if the ASG has no instance maintenance policy
+	MaxHealthyPercentage *int64
 	// MaxSize is the max number of nodes in asg
 	MaxSize *int64
 	// Metrics is a collection of metrics to monitor
@@ -101,6 +114,12 @@ type AutoscalingGroup struct {
 	CapacityRebalance *bool
 	// WarmPool is the WarmPool config for the ASG
 	WarmPool *WarmPool
+	// ScheduledScaling is true when scheduled actions change the size of the ASG,
+	// in which case MinSize and MaxSize are only set when the ASG is created
+	ScheduledScaling *bool
+	// AutoscalerManaged is true when cluster-autoscaler changes the desired capacity of the ASG,
+	// in which case terraform ignores changes to the desired capacity
+	AutoscalerManaged *bool
 }
 
 var _ fi.CompareWithID = &AutoscalingGroup{}
@@ -151,6 +170,7 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 	}
 
 	actual := &AutoscalingGroup{
+		AllowReplacement:    e.AllowReplacement,
 		Name:                g.AutoScalingGroupName,
 		MaxSize:             g.MaxSize,
 		MinSize:             g.MinSize,
@@ -164,6 +184,15 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 		actual.MaxInstanceLifetime = g.MaxInstanceLifetime
 	}
 
+	// Use -1 when the ASG has no instance maintenance policy (same as model)
+	if g.InstanceMaintenancePolicy == nil {
+		actual.MinHealthyPercentage = fi.PtrTo(int64(-1))
+		actual.MaxHealthyPercentage = fi.PtrTo(int64(-1))
+	} else {
+		actual.MinHealthyPercentage = g.InstanceMaintenancePolicy.MinHealthyPercentage
+		actual.MaxHealthyPercentage = g.InstanceMaintenancePolicy.MaxHealthyPercentage
+	}
+
 	actual.LoadBalancers = []*ClassicLoadBalancer{}
 	for _, lb := range g.LoadBalancerNames {
 		actual.LoadBalancers = append(actual.LoadBalancers, &ClassicLoadBalancer{
@@ -267,6 +296,15 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 			if mpd.SpotMaxPrice == nil {
 				actual.MixedSpotMaxPrice = fi.PtrTo("")
 			}
+			if mpd.OnDemandBaseCapacity == nil {
+				actual.MixedOnDemandBase = fi.PtrTo(defaultMixedOnDemandBase)
+			}
+			if mpd.OnDemandPercentageAboveBaseCapacity == nil {
+				actual.MixedOnDemandAboveBase = fi.PtrTo(defaultMixedOnDemandAboveBase)
+			}
+			if mpd.SpotAllocationStrategy == nil {
+				actual.MixedSpotAllocationStrategy = fi.PtrTo(defaultMixedSpotAllocationStrategy)
+			}
 		}
 
 		if g.MixedInstancesPolicy.LaunchTemplate != nil {
@@ -281,6 +319,20 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 				actual.MixedInstanceOverrides = append(actual.MixedInstanceOverrides, fi.ValueOf(n.InstanceType))
 			}
 		}
+
+		// A distribution setting that is removed from the spec reverts to the AWS default,
+		// otherwise removing e.g. the spot allocation strategy would keep the previous one.
+		if e.UseMixedInstancesPolicy() {
+			if e.MixedOnDemandBase == nil {
+				e.MixedOnDemandBase = fi.PtrTo(defaultMixedOnDemandBase)
+			}
+			if e.MixedOnDemandAboveBase == nil {
+				e.MixedOnDemandAboveBase = fi.PtrTo(defaultMixedOnDemandAboveBase)
+			}
+			if e.MixedSpotAllocationStrategy == nil {
+				e.MixedSpotAllocationStrategy = fi.PtrTo(defaultMixedSpotAllocationStrategy)
+			}
+		}
 	}
 
 	ir, _ := findInstanceRequirements(g)
@@ -300,6 +352,14 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 	// Avoid spurious changes
 	actual.Lifecycle = e.Lifecycle
 
+	actual.AutoscalerManaged = e.AutoscalerManaged
+	actual.ScheduledScaling = e.ScheduledScaling
+	if fi.ValueOf(e.ScheduledScaling) {
+		// Leave the size to the scheduled actions
+		actual.MinSize = e.MinSize
+		actual.MaxSize = e.MaxSize
+	}
+
 	if g.NewInstancesProtectedFromScaleIn != nil {
 		actual.InstanceProtection = g.NewInstancesProtectedFromScaleIn
 	}
@@ -364,6 +424,11 @@ func (e *AutoscalingGroup) CheckChanges(a, ex, changes *AutoscalingGroup) error
 		if ex.Name == nil {
 			return fi.RequiredField("Name")
 		}
+
+		classes := classifyChanges(changes, autoscalingGroupChangeClasses, ChangeInPlace)
+		if err := checkReplacement("AutoscalingGroup", fi.ValueOf(ex.Name), classes, fi.ValueOf(ex.AllowReplacement)); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -394,6 +459,8 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 			request.MaxInstanceLifetime = e.MaxInstanceLifetime
 		}
 
+		request.InstanceMaintenancePolicy = e.instanceMaintenancePolicy()
+
 		for _, k := range e.LoadBalancers {
 			if k.LoadBalancerName == nil {
 				lbDesc, err := t.Cloud.FindELBByNameTag(fi.ValueOf(k.GetName()))
@@ -580,6 +647,16 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 			request.MaxInstanceLifetime = fi.PtrTo(int64(0))
 		}
 
+		if changes.MinHealthyPercentage != nil || changes.MaxHealthyPercentage != nil {
+			// Percentages of -1 remove the instance maintenance policy
+			request.InstanceMaintenancePolicy = &autoscaling.InstanceMaintenancePolicy{
+				MinHealthyPercentage: e.MinHealthyPercentage,
+				MaxHealthyPercentage: e.MaxHealthyPercentage,
+			}
+			changes.MinHealthyPercentage = nil
+			changes.MaxHealthyPercentage = nil
+		}
+
 		var updateTagsRequest *autoscaling.CreateOrUpdateTagsInput
 		var deleteTagsRequest *autoscaling.DeleteTagsInput
 		if changes.Tags != nil {
@@ -738,6 +815,17 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 	return nil
 }
 
+// instanceMaintenancePolicy returns the instance maintenance policy of the asg, or nil if it has none
+func (e *AutoscalingGroup) instanceMaintenancePolicy() *autoscaling.InstanceMaintenancePolicy {
+	if e.MinHealthyPercentage == nil || e.MaxHealthyPercentage == nil || *e.MinHealthyPercentage < 0 || *e.MaxHealthyPercentage < 0 {
+		return nil
+	}
+	return &autoscaling.InstanceMaintenancePolicy{
+		MinHealthyPercentage: e.MinHealthyPercentage,
+		MaxHealthyPercentage: e.MaxHealthyPercentage,
+	}
+}
+
 // UseMixedInstancesPolicy checks if we should add a mixed instances policy to the asg
 func (e *AutoscalingGroup) UseMixedInstancesPolicy() bool {
 	if e.LaunchTemplate == nil {
@@ -930,6 +1018,23 @@ type terraformAutoscalingMixedInstancesPolicyLaunchTemplateSpecification struct
 type terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride struct {
 	// InstanceType is the instance to use
 	InstanceType *string `cty:"instance_type"`
+	// InstanceRequirements are the attributes of the instance types to use
+	InstanceRequirements []*terraformAutoscalingInstanceRequirements `cty:"instance_requirements"`
+}
+
+type terraformAutoscalingInstanceRequirements struct {
+	VCPUCount             []*terraformAutoscalingMinMax `cty:"vcpu_count"`
+	MemoryMiB             []*terraformAutoscalingMinMax `cty:"memory_mib"`
+	AcceleratorCount      []*terraformAutoscalingMinMax `cty:"accelerator_count"`
+	AcceleratorTypes      []string                      `cty:"accelerator_types"`
+	BurstablePerformance  *string                       `cty:"burstable_performance"`
+	AllowedInstanceTypes  []string                      `cty:"allowed_instance_types"`
+	ExcludedInstanceTypes []string                      `cty:"excluded_instance_types"`
+}
+
+type terraformAutoscalingMinMax struct {
+	Min *int64 `cty:"min"`
+	Max *int64 `cty:"max"`
 }
 
 type terraformAutoscalingMixedInstancesPolicyLaunchTemplate struct {
@@ -962,28 +1067,40 @@ type terraformMixedInstancesPolicy struct {
 }
 
 type terraformWarmPool struct {
-	MinSize *int64 `cty:"min_size"`
-	MaxSize *int64 `cty:"max_group_prepared_capacity"`
+	MinSize             *int64                                `cty:"min_size"`
+	MaxSize             *int64                                `cty:"max_group_prepared_capacity"`
+	InstanceReusePolicy *terraformWarmPoolInstanceReusePolicy `cty:"instance_reuse_policy"`
+}
+
+type terraformWarmPoolInstanceReusePolicy struct {
+	ReuseOnScaleIn *bool `cty:"reuse_on_scale_in"`
+}
+
+type terraformInstanceMaintenancePolicy struct {
+	MinHealthyPercentage *int64 `cty:"min_healthy_percentage"`
+	MaxHealthyPercentage *int64 `cty:"max_healthy_percentage"`
 }
 
 type terraformAutoscalingGroup struct {
-	Name                    *string                                          `cty:"name"`
-	LaunchConfigurationName *terraformWriter.Literal                         `cty:"launch_configuration"`
-	LaunchTemplate          *terraformAutoscalingLaunchTemplateSpecification `cty:"launch_template"`
-	MaxSize                 *int64                                           `cty:"max_size"`
-	MinSize                 *int64                                           `cty:"min_size"`
-	MixedInstancesPolicy    []*terraformMixedInstancesPolicy                 `cty:"mixed_instances_policy"`
-	VPCZoneIdentifier       []*terraformWriter.Literal                       `cty:"vpc_zone_identifier"`
-	Tags                    []*terraformASGTag                               `cty:"tag"`
-	MetricsGranularity      *string                                          `cty:"metrics_granularity"`
-	EnabledMetrics          []*string                                        `cty:"enabled_metrics"`
-	SuspendedProcesses      []*string                                        `cty:"suspended_processes"`
-	InstanceProtection      *bool                                            `cty:"protect_from_scale_in"`
-	LoadBalancers           []*terraformWriter.Literal                       `cty:"load_balancers"`
-	TargetGroupARNs         []*terraformWriter.Literal                       `cty:"target_group_arns"`
-	MaxInstanceLifetime     *int64                                           `cty:"max_instance_lifetime"`
-	CapacityRebalance       *bool                                            `cty:"capacity_rebalance"`
-	WarmPool                *terraformWarmPool                               `cty:"warm_pool"`
+	Name                      *string                                          `cty:"name"`
+	LaunchConfigurationName   *terraformWriter.Literal                         `cty:"launch_configuration"`
+	LaunchTemplate            *terraformAutoscalingLaunchTemplateSpecification `cty:"launch_template"`
+	MaxSize                   *int64                                           `cty:"max_size"`
+	MinSize                   *int64                                           `cty:"min_size"`
+	MixedInstancesPolicy      []*terraformMixedInstancesPolicy                 `cty:"mixed_instances_policy"`
+	VPCZoneIdentifier         []*terraformWriter.Literal                       `cty:"vpc_zone_identifier"`
+	Tags                      []*terraformASGTag                               `cty:"tag"`
+	MetricsGranularity        *string                                          `cty:"metrics_granularity"`
+	EnabledMetrics            []*string                                        `cty:"enabled_metrics"`
+	SuspendedProcesses        []*string                                        `cty:"suspended_processes"`
+	InstanceProtection        *bool                                            `cty:"protect_from_scale_in"`
+	LoadBalancers             []*terraformWriter.Literal                       `cty:"load_balancers"`
+	TargetGroupARNs           []*terraformWriter.Literal                       `cty:"target_group_arns"`
+	MaxInstanceLifetime       *int64                                           `cty:"max_instance_lifetime"`
+	InstanceMaintenancePolicy *terraformInstanceMaintenancePolicy              `cty:"instance_maintenance_policy"`
+	CapacityRebalance         *bool                                            `cty:"capacity_rebalance"`
+	WarmPool                  *terraformWarmPool                               `cty:"warm_pool"`
+	Lifecycle                 *terraform.Lifecycle                             `cty:"lifecycle"`
 }
 
 // RenderTerraform is responsible for rendering the terraform codebase
@@ -999,6 +1116,28 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 		CapacityRebalance:   e.CapacityRebalance,
 	}
 
+	if policy := e.instanceMaintenancePolicy(); policy != nil {
+		tf.InstanceMaintenancePolicy = &terraformInstanceMaintenancePolicy{
+			MinHealthyPercentage: policy.MinHealthyPercentage,
+			MaxHealthyPercentage: policy.MaxHealthyPercentage,
+		}
+	}
+
+	var ignoreChanges []*terraformWriter.Literal
+	if fi.ValueOf(e.ScheduledScaling) {
+		// Leave the size to the scheduled actions
+		ignoreChanges = append(ignoreChanges, &terraformWriter.Literal{String: "min_size"}, &terraformWriter.Literal{String: "max_size"})
+	}
+	if fi.ValueOf(e.AutoscalerManaged) {
+		// Leave the desired capacity to cluster-autoscaler
+		ignoreChanges = append(ignoreChanges, &terraformWriter.Literal{String: "desired_capacity"})
+	}
+	if len(ignoreChanges) > 0 {
+		tf.Lifecycle = &terraform.Lifecycle{
+			IgnoreChanges: ignoreChanges,
+		}
+	}
+
 	for _, s := range e.Subnets {
 		tf.VPCZoneIdentifier = append(tf.VPCZoneIdentifier, s.TerraformLink())
 	}
@@ -1056,6 +1195,21 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 		for _, x := range e.MixedInstanceOverrides {
 			tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override = append(tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override, &terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride{InstanceType: fi.PtrTo(x)})
 		}
+		if e.InstanceRequirements != nil {
+			overrides := overridesFromInstanceRequirements(e.InstanceRequirements).InstanceRequirements
+			ir := &terraformAutoscalingInstanceRequirements{
+				VCPUCount:             []*terraformAutoscalingMinMax{{Min: overrides.VCpuCount.Min, Max: overrides.VCpuCount.Max}},
+				MemoryMiB:             []*terraformAutoscalingMinMax{{Min: overrides.MemoryMiB.Min, Max: overrides.MemoryMiB.Max}},
+				AcceleratorTypes:      aws.StringValueSlice(overrides.AcceleratorTypes),
+				BurstablePerformance:  overrides.BurstablePerformance,
+				AllowedInstanceTypes:  aws.StringValueSlice(overrides.AllowedInstanceTypes),
+				ExcludedInstanceTypes: aws.StringValueSlice(overrides.ExcludedInstanceTypes),
+			}
+			if overrides.AcceleratorCount != nil {
+				ir.AcceleratorCount = []*terraformAutoscalingMinMax{{Min: overrides.AcceleratorCount.Min, Max: overrides.AcceleratorCount.Max}}
+			}
+			tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override = append(tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override, &terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride{InstanceRequirements: []*terraformAutoscalingInstanceRequirements{ir}})
+		}
 	} else if e.LaunchTemplate != nil {
 		tf.LaunchTemplate = &terraformAutoscalingLaunchTemplateSpecification{
 			LaunchTemplateID: e.LaunchTemplate.TerraformLink(),
@@ -1112,6 +1266,11 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 			MinSize: &e.WarmPool.MinSize,
 			MaxSize: e.WarmPool.MaxSize,
 		}
+		if fi.ValueOf(e.WarmPool.ReuseOnScaleIn) {
+			tf.WarmPool.InstanceReusePolicy = &terraformWarmPoolInstanceReusePolicy{
+				ReuseOnScaleIn: e.WarmPool.ReuseOnScaleIn,
+			}
+		}
 	}
 
 	return t.RenderResource("aws_autoscaling_group", *e.Name, tf)
@@ -17,15 +17,21 @@ limitations under the License.
 package awstasks
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"sort"
 	"testing"
 
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/pkg/diff"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"sigs.k8s.io/yaml"
 )
 
@@ -246,6 +252,138 @@ resource "aws_autoscaling_group" "test" {
   vpc_zone_identifier = [aws_subnet.test-sg.id]
 }
 
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingGroup{
+				Name:                 fi.PtrTo("test"),
+				LaunchTemplate:       &LaunchTemplate{Name: fi.PtrTo("test_lc")},
+				MaxSize:              fi.PtrTo(int64(10)),
+				MinSize:              fi.PtrTo(int64(1)),
+				MinHealthyPercentage: fi.PtrTo(int64(90)),
+				MaxHealthyPercentage: fi.PtrTo(int64(120)),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("test-sg"),
+						ID:   fi.PtrTo("sg-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "test" {
+  instance_maintenance_policy {
+    max_healthy_percentage = 120
+    min_healthy_percentage = 90
+  }
+  launch_template {
+    id      = aws_launch_template.test_lc.id
+    version = aws_launch_template.test_lc.latest_version
+  }
+  max_size            = 10
+  min_size            = 1
+  name                = "test"
+  vpc_zone_identifier = [aws_subnet.test-sg.id]
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingGroup{
+				Name:             fi.PtrTo("test"),
+				LaunchTemplate:   &LaunchTemplate{Name: fi.PtrTo("test_lc")},
+				MaxSize:          fi.PtrTo(int64(10)),
+				MinSize:          fi.PtrTo(int64(1)),
+				ScheduledScaling: fi.PtrTo(true),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("test-sg"),
+						ID:   fi.PtrTo("sg-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "test" {
+  launch_template {
+    id      = aws_launch_template.test_lc.id
+    version = aws_launch_template.test_lc.latest_version
+  }
+  lifecycle {
+    ignore_changes = [min_size, max_size]
+  }
+  max_size            = 10
+  min_size            = 1
+  name                = "test"
+  vpc_zone_identifier = [aws_subnet.test-sg.id]
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingGroup{
+				Name:              fi.PtrTo("test"),
+				LaunchTemplate:    &LaunchTemplate{Name: fi.PtrTo("test_lc")},
+				MaxSize:           fi.PtrTo(int64(10)),
+				MinSize:           fi.PtrTo(int64(1)),
+				ScheduledScaling:  fi.PtrTo(true),
+				AutoscalerManaged: fi.PtrTo(true),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("test-sg"),
+						ID:   fi.PtrTo("sg-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "test" {
+  launch_template {
+    id      = aws_launch_template.test_lc.id
+    version = aws_launch_template.test_lc.latest_version
+  }
+  lifecycle {
+    ignore_changes = [min_size, max_size, desired_capacity]
+  }
+  max_size            = 10
+  min_size            = 1
+  name                = "test"
+  vpc_zone_identifier = [aws_subnet.test-sg.id]
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
@@ -403,6 +541,79 @@ resource "aws_autoscaling_group" "test1" {
   }
 }
 
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingGroup{
+				Name:           fi.PtrTo("test1"),
+				LaunchTemplate: &LaunchTemplate{Name: fi.PtrTo("test_lt")},
+				MaxSize:        fi.PtrTo(int64(10)),
+				MinSize:        fi.PtrTo(int64(5)),
+				InstanceRequirements: &InstanceRequirements{
+					CPUMin:               fi.PtrTo(int64(2)),
+					CPUMax:               fi.PtrTo(int64(8)),
+					MemoryMin:            fi.PtrTo(int64(4096)),
+					MemoryMax:            fi.PtrTo(int64(32768)),
+					GPUMax:               fi.PtrTo(int64(0)),
+					BurstablePerformance: fi.PtrTo("excluded"),
+					AllowedInstanceTypes: []string{"m5*", "c5*"},
+				},
+				MixedOnDemandAboveBase: fi.PtrTo(int64(0)),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("test-sg"),
+						ID:   fi.PtrTo("sg-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "test1" {
+  max_size = 10
+  min_size = 5
+  mixed_instances_policy {
+    instances_distribution {
+      on_demand_percentage_above_base_capacity = 0
+    }
+    launch_template {
+      launch_template_specification {
+        launch_template_id = aws_launch_template.test_lt.id
+        version            = aws_launch_template.test_lt.latest_version
+      }
+      override {
+        instance_requirements {
+          accelerator_count {
+            max = 0
+          }
+          allowed_instance_types = ["m5*", "c5*"]
+          burstable_performance  = "excluded"
+          memory_mib {
+            max = 32768
+            min = 4096
+          }
+          vcpu_count {
+            max = 8
+            min = 2
+          }
+        }
+      }
+    }
+  }
+  name                = "test1"
+  vpc_zone_identifier = [aws_subnet.test-sg.id]
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
@@ -499,3 +710,251 @@ func TestTGsARNsChunks(t *testing.T) {
 		}
 	}
 }
+
+func TestAutoscalingGroupInstanceMaintenancePolicy(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	mockEC2 := &mockec2.MockEC2{}
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = mockEC2
+	mockAutoscaling := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = mockAutoscaling
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(minHealthyPercentage, maxHealthyPercentage int64) map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:         s("nodes"),
+			Lifecycle:    fi.LifecycleSync,
+			ImageID:      s("ami-12345678"),
+			InstanceType: s("t3.medium"),
+		}
+		asg := &AutoscalingGroup{
+			Name:                 s("nodes"),
+			Lifecycle:            fi.LifecycleSync,
+			LaunchTemplate:       lt,
+			Granularity:          s("1Minute"),
+			Metrics:              []string{},
+			SuspendProcesses:     &[]string{},
+			InstanceProtection:   aws.Bool(false),
+			CapacityRebalance:    aws.Bool(false),
+			MinSize:              aws.Int64(1),
+			MaxSize:              aws.Int64(3),
+			MaxInstanceLifetime:  aws.Int64(0),
+			MinHealthyPercentage: aws.Int64(minHealthyPercentage),
+			MaxHealthyPercentage: aws.Int64(maxHealthyPercentage),
+			Tags:                 map[string]string{},
+		}
+		return map[string]fi.CloudupTask{
+			"nodes/lt":  lt,
+			"nodes/asg": asg,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) {
+		t.Helper()
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	grid := []struct {
+		Description          string
+		MinHealthyPercentage int64
+		MaxHealthyPercentage int64
+		Expected             *autoscaling.InstanceMaintenancePolicy
+	}{
+		{
+			Description:          "create with policy",
+			MinHealthyPercentage: 90,
+			MaxHealthyPercentage: 120,
+			Expected:             &autoscaling.InstanceMaintenancePolicy{MinHealthyPercentage: aws.Int64(90), MaxHealthyPercentage: aws.Int64(120)},
+		},
+		{
+			Description:          "update policy",
+			MinHealthyPercentage: 100,
+			MaxHealthyPercentage: 110,
+			Expected:             &autoscaling.InstanceMaintenancePolicy{MinHealthyPercentage: aws.Int64(100), MaxHealthyPercentage: aws.Int64(110)},
+		},
+		{
+			Description:          "remove policy",
+			MinHealthyPercentage: -1,
+			MaxHealthyPercentage: -1,
+		},
+	}
+
+	for _, g := range grid {
+		runTasks(buildTasks(g.MinHealthyPercentage, g.MaxHealthyPercentage))
+
+		group := mockAutoscaling.Groups["nodes"]
+		if group == nil {
+			t.Fatalf("%s: expected the autoscaling group to exist", g.Description)
+		}
+		if !reflect.DeepEqual(group.InstanceMaintenancePolicy, g.Expected) {
+			t.Errorf("%s: expected instance maintenance policy %v, got %v", g.Description, g.Expected, group.InstanceMaintenancePolicy)
+		}
+
+		// The mock does not model every ASG attribute, so only check that Find reports the policy we applied
+		tasks := buildTasks(g.MinHealthyPercentage, g.MaxHealthyPercentage)
+		context, err := fi.NewCloudupContext(ctx, &awsup.AWSAPITarget{Cloud: cloud}, nil, cloud, nil, nil, nil, tasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		actual, err := tasks["nodes/asg"].(*AutoscalingGroup).Find(context)
+		if err != nil {
+			t.Fatalf("%s: unexpected error during Find: %v", g.Description, err)
+		}
+		if fi.ValueOf(actual.MinHealthyPercentage) != g.MinHealthyPercentage || fi.ValueOf(actual.MaxHealthyPercentage) != g.MaxHealthyPercentage {
+			t.Errorf("%s: expected Find to return %d/%d, got %d/%d", g.Description, g.MinHealthyPercentage, g.MaxHealthyPercentage, fi.ValueOf(actual.MinHealthyPercentage), fi.ValueOf(actual.MaxHealthyPercentage))
+		}
+	}
+}
+
+func TestAutoscalingGroupMixedInstancesPolicyDefaults(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	mockEC2 := &mockec2.MockEC2{}
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = mockEC2
+	mockAutoscaling := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = mockAutoscaling
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(onDemandAboveBase *int64, spotAllocationStrategy *string) map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:         s("nodes"),
+			Lifecycle:    fi.LifecycleSync,
+			ImageID:      s("ami-12345678"),
+			InstanceType: s("t3.medium"),
+			SpotPrice:    s(""),
+		}
+		asg := &AutoscalingGroup{
+			Name:                        s("nodes"),
+			Lifecycle:                   fi.LifecycleSync,
+			LaunchTemplate:              lt,
+			Granularity:                 s("1Minute"),
+			Metrics:                     []string{},
+			SuspendProcesses:            &[]string{},
+			InstanceProtection:          aws.Bool(false),
+			CapacityRebalance:           aws.Bool(false),
+			MinSize:                     aws.Int64(1),
+			MaxSize:                     aws.Int64(3),
+			MaxInstanceLifetime:         aws.Int64(0),
+			MinHealthyPercentage:        aws.Int64(-1),
+			MaxHealthyPercentage:        aws.Int64(-1),
+			MixedInstanceOverrides:      []string{"t3.medium", "t3a.medium"},
+			MixedOnDemandAboveBase:      onDemandAboveBase,
+			MixedSpotAllocationStrategy: spotAllocationStrategy,
+			MixedSpotMaxPrice:           s(""),
+			Tags:                        map[string]string{},
+		}
+		return map[string]fi.CloudupTask{
+			"nodes/lt":  lt,
+			"nodes/asg": asg,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) {
+		t.Helper()
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	grid := []struct {
+		Description               string
+		OnDemandAboveBase         *int64
+		SpotAllocationStrategy    *string
+		ExpectedOnDemandAboveBase int64
+		ExpectedSpotStrategy      string
+	}{
+		{
+			Description:               "spot instances",
+			OnDemandAboveBase:         aws.Int64(0),
+			SpotAllocationStrategy:    s("capacity-optimized"),
+			ExpectedOnDemandAboveBase: 0,
+			ExpectedSpotStrategy:      "capacity-optimized",
+		},
+		{
+			Description:               "remove spot allocation strategy",
+			OnDemandAboveBase:         aws.Int64(0),
+			ExpectedOnDemandAboveBase: 0,
+			ExpectedSpotStrategy:      "lowest-price",
+		},
+		{
+			Description:               "remove on-demand percentage",
+			ExpectedOnDemandAboveBase: 100,
+			ExpectedSpotStrategy:      "lowest-price",
+		},
+	}
+
+	var group *autoscaling.Group
+	for _, g := range grid {
+		runTasks(buildTasks(g.OnDemandAboveBase, g.SpotAllocationStrategy))
+
+		if group == nil {
+			group = mockAutoscaling.Groups["nodes"]
+		}
+		if mockAutoscaling.Groups["nodes"] != group {
+			t.Fatalf("%s: expected the autoscaling group to be updated in place", g.Description)
+		}
+		if group.MixedInstancesPolicy == nil || group.MixedInstancesPolicy.InstancesDistribution == nil {
+			t.Fatalf("%s: expected the autoscaling group to have a mixed instances policy", g.Description)
+		}
+
+		distribution := group.MixedInstancesPolicy.InstancesDistribution
+		if actual := aws.Int64Value(distribution.OnDemandPercentageAboveBaseCapacity); actual != g.ExpectedOnDemandAboveBase {
+			t.Errorf("%s: expected on-demand percentage above base %d, got %d", g.Description, g.ExpectedOnDemandAboveBase, actual)
+		}
+		if actual := aws.StringValue(distribution.SpotAllocationStrategy); actual != g.ExpectedSpotStrategy {
+			t.Errorf("%s: expected spot allocation strategy %q, got %q", g.Description, g.ExpectedSpotStrategy, actual)
+		}
+
+		// The mock does not model every ASG attribute, so only check that Find reports the distribution we applied
+		tasks := buildTasks(g.OnDemandAboveBase, g.SpotAllocationStrategy)
+		context, err := fi.NewCloudupContext(ctx, &awsup.AWSAPITarget{Cloud: cloud}, nil, cloud, nil, nil, nil, tasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		e := tasks["nodes/asg"].(*AutoscalingGroup)
+		actual, err := e.Find(context)
+		if err != nil {
+			t.Fatalf("%s: unexpected error during Find: %v", g.Description, err)
+		}
+		if fi.ValueOf(actual.MixedOnDemandAboveBase) != fi.ValueOf(e.MixedOnDemandAboveBase) || fi.ValueOf(actual.MixedSpotAllocationStrategy) != fi.ValueOf(e.MixedSpotAllocationStrategy) {
+			t.Errorf("%s: expected Find to return %d/%s, got %d/%s", g.Description, fi.ValueOf(e.MixedOnDemandAboveBase), fi.ValueOf(e.MixedSpotAllocationStrategy), fi.ValueOf(actual.MixedOnDemandAboveBase), fi.ValueOf(actual.MixedSpotAllocationStrategy))
+		}
+		if fi.ValueOf(actual.MixedOnDemandAboveBase) != g.ExpectedOnDemandAboveBase || fi.ValueOf(actual.MixedSpotAllocationStrategy) != g.ExpectedSpotStrategy {
+			t.Errorf("%s: expected Find to return %d/%s, got %d/%s", g.Description, g.ExpectedOnDemandAboveBase, g.ExpectedSpotStrategy, fi.ValueOf(actual.MixedOnDemandAboveBase), fi.ValueOf(actual.MixedSpotAllocationStrategy))
+		}
+	}
+}
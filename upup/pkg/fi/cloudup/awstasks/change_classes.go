@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeClass describes how a change to a field of a task is applied to an existing resource.
+type ChangeClass string
+
+const (
+	// ChangeInPlace is applied by updating the existing resource.
+	ChangeInPlace ChangeClass = "InPlace"
+	// ChangeNewTemplateVersion is applied by creating a new version of the launch template,
+	// which is rolled out to the instances by a rolling update.
+	ChangeNewTemplateVersion ChangeClass = "NewTemplateVersion"
+	// ChangeReplacementRequired cannot be rolled out gradually, because the existing
+	// instances of the group cannot coexist with or be converted to the new configuration.
+	ChangeReplacementRequired ChangeClass = "ReplacementRequired"
+)
+
+// launchTemplateChangeClasses are the classes of the LaunchTemplate fields that are not ChangeNewTemplateVersion.
+var launchTemplateChangeClasses = map[string]ChangeClass{
+	// EC2 cannot move instances between shared and dedicated tenancy.
+	"Tenancy": ChangeReplacementRequired,
+}
+
+// autoscalingGroupChangeClasses are the classes of the AutoscalingGroup fields that are not ChangeInPlace.
+var autoscalingGroupChangeClasses = map[string]ChangeClass{}
+
+// classifyChanges returns the class of every field set in changes.
+func classifyChanges(changes interface{}, classes map[string]ChangeClass, defaultClass ChangeClass) map[string]ChangeClass {
+	result := make(map[string]ChangeClass)
+
+	v := reflect.ValueOf(changes).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		switch field.Name {
+		case "ID", "Name", "Lifecycle", "AllowReplacement":
+			// Identity and system fields
+			continue
+		}
+		if v.Field(i).IsZero() {
+			continue
+		}
+
+		class, found := classes[field.Name]
+		if !found {
+			class = defaultClass
+		}
+		result[field.Name] = class
+	}
+
+	return result
+}
+
+// checkReplacement returns an error listing the changed fields that require replacing
+// the instances of the group, unless replacement is allowed.
+func checkReplacement(kind string, name string, classes map[string]ChangeClass, allowReplacement bool) error {
+	if allowReplacement {
+		return nil
+	}
+
+	var fields []string
+	for field, class := range classes {
+		if class == ChangeReplacementRequired {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+
+	return fmt.Errorf("changing %s of %s %q requires replacing all of its instances; use --allow-replacement to apply it", strings.Join(fields, ", "), kind, name)
+}
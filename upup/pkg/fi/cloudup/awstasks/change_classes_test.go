@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestLaunchTemplateChangeClasses(t *testing.T) {
+	grid := []struct {
+		Description string
+		Changes     *LaunchTemplate
+		Expected    map[string]ChangeClass
+	}{
+		{
+			Description: "instance type",
+			Changes:     &LaunchTemplate{InstanceType: s("m5.large")},
+			Expected:    map[string]ChangeClass{"InstanceType": ChangeNewTemplateVersion},
+		},
+		{
+			Description: "image",
+			Changes:     &LaunchTemplate{ImageID: s("ami-87654321")},
+			Expected:    map[string]ChangeClass{"ImageID": ChangeNewTemplateVersion},
+		},
+		{
+			Description: "root volume",
+			Changes:     &LaunchTemplate{RootVolumeSize: aws.Int64(128), RootVolumeType: s(ec2.VolumeTypeGp3)},
+			Expected:    map[string]ChangeClass{"RootVolumeSize": ChangeNewTemplateVersion, "RootVolumeType": ChangeNewTemplateVersion},
+		},
+		{
+			Description: "tags",
+			Changes:     &LaunchTemplate{Tags: map[string]string{"team": "platform"}},
+			Expected:    map[string]ChangeClass{"Tags": ChangeNewTemplateVersion},
+		},
+		{
+			Description: "tenancy",
+			Changes:     &LaunchTemplate{Tenancy: s(ec2.TenancyDedicated), InstanceType: s("m5.large")},
+			Expected:    map[string]ChangeClass{"Tenancy": ChangeReplacementRequired, "InstanceType": ChangeNewTemplateVersion},
+		},
+		{
+			Description: "system fields",
+			Changes:     &LaunchTemplate{ID: s("lt-1"), Name: s("nodes"), Lifecycle: fi.LifecycleSync, AllowReplacement: fi.PtrTo(true)},
+			Expected:    map[string]ChangeClass{},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			actual := classifyChanges(g.Changes, launchTemplateChangeClasses, ChangeNewTemplateVersion)
+			if len(actual) != len(g.Expected) {
+				t.Fatalf("expected %v, got %v", g.Expected, actual)
+			}
+			for field, class := range g.Expected {
+				if actual[field] != class {
+					t.Errorf("expected %s to be %s, got %v", field, class, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestAutoscalingGroupChangeClasses(t *testing.T) {
+	changes := &AutoscalingGroup{
+		MinSize:       aws.Int64(3),
+		MaxSize:       aws.Int64(5),
+		Subnets:       []*Subnet{{Name: s("subnet-a")}},
+		Tags:          map[string]string{"team": "platform"},
+		LoadBalancers: []*ClassicLoadBalancer{{Name: s("api")}},
+	}
+	actual := classifyChanges(changes, autoscalingGroupChangeClasses, ChangeInPlace)
+	for _, field := range []string{"MinSize", "MaxSize", "Subnets", "Tags", "LoadBalancers"} {
+		if actual[field] != ChangeInPlace {
+			t.Errorf("expected %s to be %s, got %v", field, ChangeInPlace, actual)
+		}
+	}
+	if len(actual) != 5 {
+		t.Errorf("expected 5 classified fields, got %v", actual)
+	}
+}
+
+func TestLaunchTemplateCheckChangesReplacement(t *testing.T) {
+	grid := []struct {
+		Description      string
+		Changes          *LaunchTemplate
+		AllowReplacement *bool
+		ExpectError      string
+	}{
+		{
+			Description: "new template version",
+			Changes:     &LaunchTemplate{InstanceType: s("m5.large")},
+		},
+		{
+			Description: "tenancy",
+			Changes:     &LaunchTemplate{Tenancy: s(ec2.TenancyDedicated), InstanceType: s("m5.large")},
+			ExpectError: `changing Tenancy of LaunchTemplate "nodes" requires replacing all of its instances`,
+		},
+		{
+			Description:      "tenancy allowed",
+			Changes:          &LaunchTemplate{Tenancy: s(ec2.TenancyDedicated)},
+			AllowReplacement: fi.PtrTo(true),
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			a := &LaunchTemplate{Name: s("nodes"), ImageID: s("ami-12345678")}
+			e := &LaunchTemplate{Name: s("nodes"), ImageID: s("ami-12345678"), AllowReplacement: g.AllowReplacement}
+			err := e.CheckChanges(a, e, g.Changes)
+			if g.ExpectError == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if g.ExpectError != "" && (err == nil || !strings.Contains(err.Error(), g.ExpectError)) {
+				t.Errorf("expected error containing %q, got %v", g.ExpectError, err)
+			}
+		})
+	}
+}
+
+func TestLaunchTemplateTenancyReplacement(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	c.Images = append(c.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(tenancy string, allowReplacement bool) map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:         s("nodes"),
+			Lifecycle:    fi.LifecycleSync,
+			ImageID:      s("ami-12345678"),
+			InstanceType: s("t3.medium"),
+			Tenancy:      s(tenancy),
+		}
+		if allowReplacement {
+			lt.AllowReplacement = fi.PtrTo(true)
+		}
+		return map[string]fi.CloudupTask{
+			"nodes": lt,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) error {
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		return context.RunTasks(testRunTasksOptions)
+	}
+
+	latestTenancy := func() string {
+		output, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateName: s("nodes"),
+			Versions:           []*string{aws.String("$Latest")},
+		})
+		if err != nil {
+			t.Fatalf("error describing launch template versions: %v", err)
+		}
+		if len(output.LaunchTemplateVersions) != 1 {
+			t.Fatalf("expected a single launch template version, got %v", output.LaunchTemplateVersions)
+		}
+		return aws.StringValue(output.LaunchTemplateVersions[0].LaunchTemplateData.Placement.Tenancy)
+	}
+
+	{
+		if err := runTasks(buildTasks(ec2.TenancyDefault, false)); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	{
+		err := runTasks(buildTasks(ec2.TenancyDedicated, false))
+		if err == nil || !strings.Contains(err.Error(), "Tenancy") || !strings.Contains(err.Error(), "--allow-replacement") {
+			t.Fatalf("expected the tenancy change to be blocked, got %v", err)
+		}
+		if actual := latestTenancy(); actual != ec2.TenancyDefault {
+			t.Fatalf("expected tenancy to remain %q, got %q", ec2.TenancyDefault, actual)
+		}
+	}
+
+	{
+		if err := runTasks(buildTasks(ec2.TenancyDedicated, true)); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+		if actual := latestTenancy(); actual != ec2.TenancyDedicated {
+			t.Fatalf("expected tenancy to be %q, got %q", ec2.TenancyDedicated, actual)
+		}
+	}
+
+	{
+		checkNoChanges(t, ctx, cloud, buildTasks(ec2.TenancyDedicated, false))
+	}
+}
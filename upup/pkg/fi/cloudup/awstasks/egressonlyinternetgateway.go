@@ -69,18 +69,16 @@ func (e *EgressOnlyInternetGateway) Find(c *fi.CloudupContext) (*EgressOnlyInter
 	request := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
 
 	shared := fi.ValueOf(e.Shared)
-	if shared {
-		if fi.ValueOf(e.VPC.ID) == "" {
-			return nil, fmt.Errorf("VPC ID is required when EgressOnlyInternetGateway is shared")
+	if e.ID != nil {
+		request.EgressOnlyInternetGatewayIds = []*string{e.ID}
+	} else if shared {
+		if e.VPC == nil || fi.ValueOf(e.VPC.ID) == "" {
+			return nil, fmt.Errorf("VPC ID is required when EgressOnlyInternetGateway is shared and its ID is not set")
 		}
 
 		request.Filters = []*ec2.Filter{awsup.NewEC2Filter("attachment.vpc-id", *e.VPC.ID)}
 	} else {
-		if e.ID != nil {
-			request.EgressOnlyInternetGatewayIds = []*string{e.ID}
-		} else {
-			request.Filters = cloud.BuildFilters(e.Name)
-		}
+		request.Filters = cloud.BuildFilters(e.Name)
 	}
 
 	eigw, err := findEgressOnlyInternetGateway(cloud, request)
@@ -139,6 +137,9 @@ func (_ *EgressOnlyInternetGateway) RenderAWS(t *awsup.AWSAPITarget, a, e, chang
 	if shared {
 		// Verify the EgressOnlyInternetGateway was found and matches our required settings
 		if a == nil {
+			if e.ID != nil {
+				return fmt.Errorf("EgressOnlyInternetGateway %q was not found", *e.ID)
+			}
 			return fmt.Errorf("EgressOnlyInternetGateway for shared VPC was not found")
 		}
 
@@ -178,7 +179,10 @@ func (_ *EgressOnlyInternetGateway) RenderTerraform(t *terraform.TerraformTarget
 		// But ... attempt to discover the ID so TerraformLink works
 		if e.ID == nil {
 			request := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
-			vpcID := fi.ValueOf(e.VPC.ID)
+			var vpcID string
+			if e.VPC != nil {
+				vpcID = fi.ValueOf(e.VPC.ID)
+			}
 			if vpcID == "" {
 				return fmt.Errorf("VPC ID is required when EgressOnlyInternetGateway is shared")
 			}
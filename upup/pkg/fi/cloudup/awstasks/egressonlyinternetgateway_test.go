@@ -144,3 +144,61 @@ func TestSharedEgressOnlyInternetGatewayDoesNotRename(t *testing.T) {
 		checkNoChanges(t, ctx, cloud, allTasks)
 	}
 }
+
+func TestSharedEgressOnlyInternetGatewayFindByID(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	vpc, err := c.CreateVpc(&ec2.CreateVpcInput{
+		CidrBlock: aws.String("172.20.0.0/16"),
+	})
+	if err != nil {
+		t.Fatalf("error creating test VPC: %v", err)
+	}
+	internetGateway, err := c.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: vpc.Vpc.VpcId,
+	})
+	if err != nil {
+		t.Fatalf("error creating test eigw: %v", err)
+	}
+
+	// A subnet egress or an additional route only knows the ID of the egress-only internet gateway, not its VPC
+	buildTasks := func(id string) map[string]fi.CloudupTask {
+		return map[string]fi.CloudupTask{
+			"eigw1": &EgressOnlyInternetGateway{
+				Name:      s(id),
+				Lifecycle: fi.LifecycleSync,
+				Shared:    fi.PtrTo(true),
+				ID:        s(id),
+			},
+		}
+	}
+
+	{
+		allTasks := buildTasks(aws.StringValue(internetGateway.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId))
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks("eigw-0123456789abcdef0")
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		if err := context.RunTasks(testRunTasksOptions); err == nil {
+			t.Fatalf("expected an error for a missing shared EgressOnlyInternetGateway")
+		}
+		if len(c.EgressOnlyInternetGatewayIds()) != 1 {
+			t.Fatalf("Expected exactly one EgressOnlyInternetGateway; found %v", c.EgressOnlyInternetGatewayIds())
+		}
+	}
+}
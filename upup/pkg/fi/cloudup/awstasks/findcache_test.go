@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/timings"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// TestFindCacheSecurityGroups checks that the security group tasks share their listings
+// when the find cache is enabled, for a model with ten security groups.
+func TestFindCacheSecurityGroups(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	const securityGroupCount = 10
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		allTasks := map[string]fi.CloudupTask{
+			"vpc1": vpc1,
+		}
+		for i := 0; i < securityGroupCount; i++ {
+			name := fmt.Sprintf("sg%d", i)
+			sg := &SecurityGroup{
+				Name:        s(name),
+				Lifecycle:   fi.LifecycleSync,
+				Description: s("Description"),
+				VPC:         vpc1,
+				Tags:        map[string]string{"Name": name},
+			}
+			allTasks[name] = sg
+			for _, port := range []int64{22, 443} {
+				ruleName := fmt.Sprintf("%s-%d", name, port)
+				allTasks[ruleName] = &SecurityGroupRule{
+					Name:          s(ruleName),
+					Lifecycle:     fi.LifecycleSync,
+					SecurityGroup: sg,
+					CIDR:          s("10.0.0.0/8"),
+					Protocol:      s("tcp"),
+					FromPort:      aws.Int64(port),
+					ToPort:        aws.Int64(port),
+				}
+			}
+		}
+		return allTasks
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) map[string]int64 {
+		stopFindCache := cloud.StartFindCache()
+		defer stopFindCache()
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		recorder := timings.NewRecorder()
+		stopRecording := timings.Start(recorder)
+		err = context.RunTasks(testRunTasksOptions)
+		stopRecording()
+		if err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+		return recorder.APICalls("ec2")
+	}
+
+	{
+		runTasks(buildTasks())
+
+		if len(c.SecurityGroups) != securityGroupCount {
+			t.Fatalf("Expected %d SecurityGroups; found %d", securityGroupCount, len(c.SecurityGroups))
+		}
+	}
+
+	{
+		calls := runTasks(buildTasks())
+
+		if calls["DescribeSecurityGroups"] != 1 {
+			t.Errorf("expected security groups to be listed once, got %d DescribeSecurityGroups calls", calls["DescribeSecurityGroups"])
+		}
+		if calls["DescribeSecurityGroupRules"] != securityGroupCount {
+			t.Errorf("expected rules to be listed once per security group, got %d DescribeSecurityGroupRules calls", calls["DescribeSecurityGroupRules"])
+		}
+	}
+
+	{
+		t.Setenv(awsup.DisableFindCacheEnvVar, "true")
+		calls := runTasks(buildTasks())
+
+		if calls["DescribeSecurityGroups"] != securityGroupCount {
+			t.Errorf("expected one DescribeSecurityGroups call per security group with the cache disabled, got %d", calls["DescribeSecurityGroups"])
+		}
+		if calls["DescribeSecurityGroupRules"] != 2*securityGroupCount {
+			t.Errorf("expected one DescribeSecurityGroupRules call per rule with the cache disabled, got %d", calls["DescribeSecurityGroupRules"])
+		}
+	}
+
+	{
+		checkNoChanges(t, ctx, cloud, buildTasks())
+	}
+}
@@ -91,6 +91,11 @@ func (e *IAMInstanceProfile) Find(c *fi.CloudupContext) (*IAMInstanceProfile, er
 	actual.Lifecycle = e.Lifecycle
 	actual.Shared = e.Shared
 
+	// Shared instance profiles are never mutated, so ignore their tags
+	if fi.ValueOf(e.Shared) {
+		actual.Tags = e.Tags
+	}
+
 	return actual, nil
 }
 
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"k8s.io/kops/cloudmock/aws/mockiam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestIAMRolePermissionsBoundaryDrift(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockiam.MockIAM{}
+	cloud.MockIAM = c
+
+	const roleName = "my-sa.my-ns.sa.minimal.example.com"
+	const boundary = "arn:aws:iam::123456789012:policy/boundaries"
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		role := &IAMRole{
+			Name:                s(roleName),
+			Lifecycle:           fi.LifecycleSync,
+			RolePolicyDocument:  fi.NewStringResource(testAssumeRolePolicy),
+			PermissionsBoundary: s(boundary),
+			Tags:                map[string]string{},
+		}
+		return map[string]fi.CloudupTask{
+			"role": role,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) {
+		t.Helper()
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	findBoundary := func() string {
+		t.Helper()
+
+		response, err := c.GetRole(&iam.GetRoleInput{RoleName: s(roleName)})
+		if err != nil {
+			t.Fatalf("error getting role: %v", err)
+		}
+		if response.Role.PermissionsBoundary == nil {
+			return ""
+		}
+		return aws.StringValue(response.Role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+
+	runTasks(buildTasks())
+	if actual := findBoundary(); actual != boundary {
+		t.Errorf("expected permissions boundary %q, got %q", boundary, actual)
+	}
+	checkNoChanges(t, ctx, cloud, buildTasks())
+
+	// A boundary changed out-of-band is restored
+	if _, err := c.PutRolePermissionsBoundary(&iam.PutRolePermissionsBoundaryInput{
+		RoleName:            s(roleName),
+		PermissionsBoundary: s("arn:aws:iam::123456789012:policy/other"),
+	}); err != nil {
+		t.Fatalf("error changing permissions boundary: %v", err)
+	}
+	runTasks(buildTasks())
+	if actual := findBoundary(); actual != boundary {
+		t.Errorf("expected permissions boundary %q after change, got %q", boundary, actual)
+	}
+	checkNoChanges(t, ctx, cloud, buildTasks())
+
+	// A boundary removed out-of-band is restored
+	if _, err := c.DeleteRolePermissionsBoundary(&iam.DeleteRolePermissionsBoundaryInput{
+		RoleName: s(roleName),
+	}); err != nil {
+		t.Fatalf("error removing permissions boundary: %v", err)
+	}
+	runTasks(buildTasks())
+	if actual := findBoundary(); actual != boundary {
+		t.Errorf("expected permissions boundary %q after removal, got %q", boundary, actual)
+	}
+	checkNoChanges(t, ctx, cloud, buildTasks())
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"k8s.io/kops/cloudmock/aws/mockiam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const testAssumeRolePolicy = `{
+  "Statement": [
+    {
+      "Action": "sts:AssumeRoleWithWebIdentity",
+      "Effect": "Allow",
+      "Principal": {
+        "Federated": "arn:aws:iam::123456789012:oidc-provider/discovery.example.com/minimal.example.com"
+      }
+    }
+  ],
+  "Version": "2012-10-17"
+}`
+
+func TestIAMRolePolicyInlinePolicyDrift(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockiam.MockIAM{}
+	cloud.MockIAM = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(action string) map[string]fi.CloudupTask {
+		role := &IAMRole{
+			Name:               s("my-sa.my-ns.sa.minimal.example.com"),
+			Lifecycle:          fi.LifecycleSync,
+			RolePolicyDocument: fi.NewStringResource(testAssumeRolePolicy),
+			Tags:               map[string]string{},
+		}
+		policy := &IAMRolePolicy{
+			Name:      s("my-sa.my-ns.sa.minimal.example.com"),
+			Lifecycle: fi.LifecycleSync,
+			Role:      role,
+			PolicyDocument: fi.NewStringResource(`{
+  "Statement": [
+    {
+      "Action": "` + action + `",
+      "Effect": "Allow",
+      "Resource": "arn:aws:sqs:us-east-1:123456789012:my-queue"
+    }
+  ],
+  "Version": "2012-10-17"
+}`),
+		}
+		return map[string]fi.CloudupTask{
+			"role":   role,
+			"policy": policy,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) {
+		t.Helper()
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	findAction := func() interface{} {
+		t.Helper()
+
+		response, err := c.GetRolePolicy(&iam.GetRolePolicyInput{
+			RoleName:   s("my-sa.my-ns.sa.minimal.example.com"),
+			PolicyName: s("my-sa.my-ns.sa.minimal.example.com"),
+		})
+		if err != nil {
+			t.Fatalf("error getting role policy: %v", err)
+		}
+		document, err := url.QueryUnescape(aws.StringValue(response.PolicyDocument))
+		if err != nil {
+			t.Fatalf("error decoding role policy: %v", err)
+		}
+		var policy struct {
+			Statement []struct {
+				Action interface{}
+			}
+		}
+		if err := json.Unmarshal([]byte(document), &policy); err != nil {
+			t.Fatalf("error parsing role policy: %v", err)
+		}
+		if len(policy.Statement) != 1 {
+			t.Fatalf("expected a single statement, got %s", document)
+		}
+		return policy.Statement[0].Action
+	}
+
+	runTasks(buildTasks("sqs:ReceiveMessage"))
+	if action := findAction(); !reflect.DeepEqual(action, "sqs:ReceiveMessage") {
+		t.Errorf("expected action sqs:ReceiveMessage, got %v", action)
+	}
+	role := c.Roles["my-sa.my-ns.sa.minimal.example.com"]
+	if role == nil {
+		t.Fatalf("expected the role to exist")
+	}
+	checkNoChanges(t, ctx, cloud, buildTasks("sqs:ReceiveMessage"))
+
+	// Changing the inline policy updates the policy of the existing role
+	runTasks(buildTasks("sqs:DeleteMessage"))
+	if action := findAction(); !reflect.DeepEqual(action, "sqs:DeleteMessage") {
+		t.Errorf("expected action sqs:DeleteMessage, got %v", action)
+	}
+	if c.Roles["my-sa.my-ns.sa.minimal.example.com"] != role {
+		t.Errorf("expected the role to be kept")
+	}
+	checkNoChanges(t, ctx, cloud, buildTasks("sqs:DeleteMessage"))
+}
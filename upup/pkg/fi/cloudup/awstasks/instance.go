@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
@@ -54,6 +55,8 @@ type Instance struct {
 	SecurityGroups     []*SecurityGroup
 	AssociatePublicIP  *bool
 	IAMInstanceProfile *IAMInstanceProfile
+	// SourceDestCheck must be disabled for instances that route traffic for other instances, such as NAT instances
+	SourceDestCheck *bool
 }
 
 var _ fi.CompareWithID = &Instance{}
@@ -113,6 +116,7 @@ func (e *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 		InstanceType:     i.InstanceType,
 		ImageID:          i.ImageId,
 		Name:             findNameTag(i.Tags),
+		SourceDestCheck:  i.SourceDestCheck,
 	}
 
 	// Fetch instance UserData
@@ -146,7 +150,7 @@ func (e *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 
 	associatePublicIpAddress := false
 	for _, ni := range i.NetworkInterfaces {
-		if aws.StringValue(ni.Association.PublicIp) != "" {
+		if ni.Association != nil && aws.StringValue(ni.Association.PublicIp) != "" {
 			associatePublicIpAddress = true
 		}
 	}
@@ -224,10 +228,11 @@ func (_ *Instance) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Instance) err
 
 		klog.V(2).Infof("Creating Instance with Name:%q", fi.ValueOf(e.Name))
 		request := &ec2.RunInstancesInput{
-			ImageId:      image.ImageId,
-			InstanceType: e.InstanceType,
-			MinCount:     aws.Int64(1),
-			MaxCount:     aws.Int64(1),
+			ImageId:           image.ImageId,
+			InstanceType:      e.InstanceType,
+			MinCount:          aws.Int64(1),
+			MaxCount:          aws.Int64(1),
+			TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeInstance, e.Tags),
 		}
 
 		if e.SSHKey != nil {
@@ -293,9 +298,86 @@ func (_ *Instance) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Instance) err
 		e.ID = response.Instances[0].InstanceId
 	}
 
+	if !fi.ValueOf(e.Shared) && e.SourceDestCheck != nil && (a == nil || changes.SourceDestCheck != nil) {
+		klog.V(2).Infof("Setting SourceDestCheck of Instance %q to %v", fi.ValueOf(e.ID), fi.ValueOf(e.SourceDestCheck))
+		request := &ec2.ModifyInstanceAttributeInput{
+			InstanceId:      e.ID,
+			SourceDestCheck: &ec2.AttributeBooleanValue{Value: e.SourceDestCheck},
+		}
+		if _, err := t.Cloud.EC2().ModifyInstanceAttribute(request); err != nil {
+			return fmt.Errorf("error setting SourceDestCheck of Instance %q: %v", fi.ValueOf(e.ID), err)
+		}
+	}
+
 	return t.AddAWSTags(*e.ID, e.Tags)
 }
 
+type terraformInstance struct {
+	AMI                      *string                    `cty:"ami"`
+	InstanceType             *string                    `cty:"instance_type"`
+	SubnetID                 *terraformWriter.Literal   `cty:"subnet_id"`
+	PrivateIP                *string                    `cty:"private_ip"`
+	AssociatePublicIPAddress *bool                      `cty:"associate_public_ip_address"`
+	SecurityGroupIDs         []*terraformWriter.Literal `cty:"vpc_security_group_ids"`
+	SourceDestCheck          *bool                      `cty:"source_dest_check"`
+	KeyName                  *terraformWriter.Literal   `cty:"key_name"`
+	IAMInstanceProfile       *terraformWriter.Literal   `cty:"iam_instance_profile"`
+	UserData                 *terraformWriter.Literal   `cty:"user_data"`
+	Tags                     map[string]string          `cty:"tags"`
+}
+
+func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Instance) error {
+	if fi.ValueOf(e.Shared) {
+		if e.ID == nil {
+			return fmt.Errorf("ID must be set, if NAT Instance is shared: %s", e)
+		}
+
+		klog.V(4).Infof("reusing existing NAT Instance with id %q", *e.ID)
+		return nil
+	}
+
+	if e.ImageID == nil {
+		return fi.RequiredField("ImageID")
+	}
+	image, err := t.Cloud.(awsup.AWSCloud).ResolveImage(fi.ValueOf(e.ImageID))
+	if err != nil {
+		return err
+	}
+
+	tf := &terraformInstance{
+		AMI:                      image.ImageId,
+		InstanceType:             e.InstanceType,
+		SubnetID:                 e.Subnet.TerraformLink(),
+		PrivateIP:                e.PrivateIPAddress,
+		AssociatePublicIPAddress: e.AssociatePublicIP,
+		SourceDestCheck:          e.SourceDestCheck,
+		Tags:                     e.Tags,
+	}
+	for _, sg := range e.SecurityGroups {
+		tf.SecurityGroupIDs = append(tf.SecurityGroupIDs, sg.TerraformLink())
+	}
+	if e.SSHKey != nil {
+		tf.KeyName = e.SSHKey.TerraformLink()
+	}
+	if e.IAMInstanceProfile != nil {
+		tf.IAMInstanceProfile = e.IAMInstanceProfile.TerraformLink()
+	}
+	if e.UserData != nil {
+		d, err := fi.ResourceAsBytes(e.UserData)
+		if err != nil {
+			return fmt.Errorf("error rendering Instance UserData: %v", err)
+		}
+		if d != nil {
+			tf.UserData, err = t.AddFileBytes("aws_instance", *e.Name, "user_data", d, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return t.RenderResource("aws_instance", *e.Name, tf)
+}
+
 func (e *Instance) TerraformLink() *terraformWriter.Literal {
 	if fi.ValueOf(e.Shared) {
 		if e.ID == nil {
@@ -305,5 +387,5 @@ func (e *Instance) TerraformLink() *terraformWriter.Literal {
 		return terraformWriter.LiteralFromStringValue(*e.ID)
 	}
 
-	return terraformWriter.LiteralSelfLink("aws_instance", *e.Name)
+	return terraformWriter.LiteralProperty("aws_instance", *e.Name, "id")
 }
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestNATInstanceCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	c.Images = append(c.Images, &ec2.Image{
+		CreationDate:   aws.String("2024-01-01T00:00:00.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("al2023-ami-2023.3.20240101.0-kernel-6.1-x86_64"),
+		OwnerId:        aws.String(awsup.WellKnownAccountAmazonLinux2),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		subnet1 := &Subnet{
+			Name:      s("subnet1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			CIDR:      s("172.20.1.0/24"),
+			Tags:      map[string]string{"Name": "subnet1"},
+		}
+		nat1 := &Instance{
+			Name:              s("nat1"),
+			Lifecycle:         fi.LifecycleSync,
+			Subnet:            subnet1,
+			ImageID:           s("ami-12345678"),
+			InstanceType:      s("t3.nano"),
+			AssociatePublicIP: fi.PtrTo(true),
+			SourceDestCheck:   fi.PtrTo(false),
+			UserData:          fi.NewStringResource("#!/bin/bash\n"),
+			Tags:              map[string]string{"Name": "nat1"},
+		}
+
+		return map[string]fi.CloudupTask{
+			"vpc1":    vpc1,
+			"subnet1": subnet1,
+			"nat1":    nat1,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		nat1 := allTasks["nat1"].(*Instance)
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		if fi.ValueOf(nat1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+
+		if len(c.Instances) != 1 {
+			t.Fatalf("Expected exactly one Instance; found %v", c.Instances)
+		}
+
+		response, err := c.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: []*string{nat1.ID}})
+		if err != nil {
+			t.Fatalf("error describing instances: %v", err)
+		}
+		instance := response.Reservations[0].Instances[0]
+		if aws.BoolValue(instance.SourceDestCheck) {
+			t.Errorf("expected SourceDestCheck to be disabled")
+		}
+		if aws.StringValue(instance.SubnetId) != "subnet-1" {
+			t.Errorf("unexpected subnet: %v", aws.StringValue(instance.SubnetId))
+		}
+		if aws.StringValue(instance.InstanceType) != "t3.nano" {
+			t.Errorf("unexpected instance type: %v", aws.StringValue(instance.InstanceType))
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
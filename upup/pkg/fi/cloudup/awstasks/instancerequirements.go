@@ -17,17 +17,23 @@ limitations under the License.
 package awstasks
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 
 	"k8s.io/kops/upup/pkg/fi"
 )
 
 type InstanceRequirements struct {
-	Architecture *string
-	CPUMin       *int64
-	CPUMax       *int64
-	MemoryMin    *int64
-	MemoryMax    *int64
+	Architecture          *string
+	CPUMin                *int64
+	CPUMax                *int64
+	MemoryMin             *int64
+	MemoryMax             *int64
+	GPUMin                *int64
+	GPUMax                *int64
+	BurstablePerformance  *string
+	AllowedInstanceTypes  []string
+	ExcludedInstanceTypes []string
 }
 
 var _ fi.CloudupHasDependencies = &InstanceRequirements{}
@@ -47,8 +53,15 @@ func findInstanceRequirements(asg *autoscaling.Group) (*InstanceRequirements, er
 				}
 				if override.InstanceRequirements.MemoryMiB != nil {
 					actual.MemoryMax = override.InstanceRequirements.MemoryMiB.Max
-					actual.MemoryMax = override.InstanceRequirements.MemoryMiB.Min
+					actual.MemoryMin = override.InstanceRequirements.MemoryMiB.Min
 				}
+				if override.InstanceRequirements.AcceleratorCount != nil {
+					actual.GPUMax = override.InstanceRequirements.AcceleratorCount.Max
+					actual.GPUMin = override.InstanceRequirements.AcceleratorCount.Min
+				}
+				actual.BurstablePerformance = override.InstanceRequirements.BurstablePerformance
+				actual.AllowedInstanceTypes = aws.StringValueSlice(override.InstanceRequirements.AllowedInstanceTypes)
+				actual.ExcludedInstanceTypes = aws.StringValueSlice(override.InstanceRequirements.ExcludedInstanceTypes)
 				return actual, nil
 			}
 		}
@@ -57,17 +70,33 @@ func findInstanceRequirements(asg *autoscaling.Group) (*InstanceRequirements, er
 }
 
 func overridesFromInstanceRequirements(ir *InstanceRequirements) *autoscaling.LaunchTemplateOverrides {
-	return &autoscaling.LaunchTemplateOverrides{
-		InstanceRequirements: &autoscaling.InstanceRequirements{
-			VCpuCount: &autoscaling.VCpuCountRequest{
-				Max: ir.CPUMax,
-				Min: ir.CPUMin,
-			},
-			MemoryMiB: &autoscaling.MemoryMiBRequest{
-				Max: ir.MemoryMax,
-				Min: ir.MemoryMin,
-			},
-			BurstablePerformance: fi.PtrTo("included"),
+	requirements := &autoscaling.InstanceRequirements{
+		VCpuCount: &autoscaling.VCpuCountRequest{
+			Max: ir.CPUMax,
+			Min: ir.CPUMin,
+		},
+		MemoryMiB: &autoscaling.MemoryMiBRequest{
+			Max: ir.MemoryMax,
+			Min: ir.MemoryMin,
 		},
+		BurstablePerformance: ir.BurstablePerformance,
+	}
+	if ir.GPUMin != nil || ir.GPUMax != nil {
+		requirements.AcceleratorCount = &autoscaling.AcceleratorCountRequest{
+			Max: ir.GPUMax,
+			Min: ir.GPUMin,
+		}
+		if fi.ValueOf(ir.GPUMin) > 0 {
+			requirements.AcceleratorTypes = aws.StringSlice([]string{autoscaling.AcceleratorTypeGpu})
+		}
+	}
+	if len(ir.AllowedInstanceTypes) > 0 {
+		requirements.AllowedInstanceTypes = aws.StringSlice(ir.AllowedInstanceTypes)
+	}
+	if len(ir.ExcludedInstanceTypes) > 0 {
+		requirements.ExcludedInstanceTypes = aws.StringSlice(ir.ExcludedInstanceTypes)
+	}
+	return &autoscaling.LaunchTemplateOverrides{
+		InstanceRequirements: requirements,
 	}
 }
@@ -21,6 +21,7 @@ import (
 	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
@@ -35,12 +36,22 @@ type LaunchTemplate struct {
 	// Lifecycle is the resource lifecycle
 	Lifecycle fi.Lifecycle
 
+	// AllowReplacement permits changes that require replacing all the instances of the group
+	AllowReplacement *bool
 	// AssociatePublicIP indicates if a public ip address is assigned to instances
 	AssociatePublicIP *bool
 	// BlockDeviceMappings is a block device mappings
 	BlockDeviceMappings []*BlockDeviceMapping
 	// CPUCredits is the credit option for CPU Usage on some instance types
 	CPUCredits *string
+	// CapacityReservationID is the ID of the capacity reservation the instances are launched into
+	CapacityReservationID *string
+	// CapacityReservationResourceGroupARN is the ARN of the resource group of capacity reservations the instances are launched into
+	CapacityReservationResourceGroupARN *string
+	// ENASRDEnabled enables ENA Express on the primary network interface
+	ENASRDEnabled *bool
+	// ENASRDUDPEnabled enables ENA Express for UDP traffic on the primary network interface
+	ENASRDUDPEnabled *bool
 	// HTTPPutResponseHopLimit is the desired HTTP PUT response hop limit for instance metadata requests.
 	HTTPPutResponseHopLimit *int64
 	// HTTPTokens is the state of token usage for your instance metadata requests.
@@ -60,6 +71,8 @@ type LaunchTemplate struct {
 	InstanceType *string
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	IPv6AddressCount *int64
+	// PlacementGroup is the placement group of the instances
+	PlacementGroup *PlacementGroup
 	// RootVolumeIops is the provisioned IOPS when the volume type is io1, io2 or gp3
 	RootVolumeIops *int64
 	// RootVolumeOptimization enables EBS optimization for an instance
@@ -84,8 +97,14 @@ type LaunchTemplate struct {
 	SpotDurationInMinutes *int64
 	// Tags are the keypairs to apply to the instance and volume on launch as well as the launch template itself.
 	Tags map[string]string
-	// Tenancy. Can be either default or dedicated.
+	// InstanceTags, if set, are applied to the instance on launch instead of Tags.
+	InstanceTags map[string]string
+	// VolumeTags, if set, are applied to the volumes on launch instead of Tags.
+	VolumeTags map[string]string
+	// Tenancy. Can be default, dedicated or host.
 	Tenancy *string
+	// HostResourceGroupARN is the ARN of the host resource group the instances are launched into, with the host tenancy
+	HostResourceGroupARN *string
 	// UserData is the user data configuration
 	UserData fi.Resource
 }
@@ -138,9 +157,28 @@ func (t *LaunchTemplate) buildRootDevice(cloud awsup.AWSCloud) (map[string]*Bloc
 
 func (t *LaunchTemplate) Normalize(c *fi.CloudupContext) error {
 	sort.Stable(OrderSecurityGroupsById(t.SecurityGroups))
+	// Find always reports the tags of the instances and volumes separately
+	t.InstanceTags = t.instanceTags()
+	t.VolumeTags = t.volumeTags()
 	return nil
 }
 
+// instanceTags returns the tags to apply to the instance on launch
+func (t *LaunchTemplate) instanceTags() map[string]string {
+	if t.InstanceTags != nil {
+		return t.InstanceTags
+	}
+	return t.Tags
+}
+
+// volumeTags returns the tags to apply to the volumes on launch
+func (t *LaunchTemplate) volumeTags() map[string]string {
+	if t.VolumeTags != nil {
+		return t.VolumeTags
+	}
+	return t.Tags
+}
+
 // Run is responsible for
 func (t *LaunchTemplate) Run(c *fi.CloudupContext) error {
 	return fi.CloudupDefaultDeltaRunMethod(t, c)
@@ -156,10 +194,27 @@ func (t *LaunchTemplate) CheckChanges(a, e, changes *LaunchTemplate) error {
 		if e.Name == nil {
 			return fi.RequiredField("Name")
 		}
+
+		classes := classifyChanges(changes, launchTemplateChangeClasses, ChangeNewTemplateVersion)
+		if err := checkReplacement("LaunchTemplate", fi.ValueOf(e.Name), classes, fi.ValueOf(e.AllowReplacement)); err != nil {
+			return err
+		}
+
+		if from, to := a.capacityType(), e.capacityType(); from != to {
+			klog.Infof("LaunchTemplate %q will switch from %s to %s instances; existing instances are replaced by a rolling update", fi.ValueOf(e.Name), from, to)
+		}
 	}
 	return nil
 }
 
+// capacityType returns the purchasing option of the instances launched from the template.
+func (t *LaunchTemplate) capacityType() string {
+	if fi.ValueOf(t.SpotPrice) != "" {
+		return "spot"
+	}
+	return "on-demand"
+}
+
 // FindDeletions is responsible for finding launch templates which can be deleted
 func (t *LaunchTemplate) FindDeletions(c *fi.CloudupContext) ([]fi.CloudupDeletion, error) {
 	var removals []fi.CloudupDeletion
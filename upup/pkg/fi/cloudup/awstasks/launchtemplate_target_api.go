@@ -59,6 +59,15 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 		},
 	}
 
+	if fi.ValueOf(t.ENASRDEnabled) {
+		data.NetworkInterfaces[0].EnaSrdSpecification = &ec2.EnaSrdSpecificationRequest{
+			EnaSrdEnabled: t.ENASRDEnabled,
+			EnaSrdUdpSpecification: &ec2.EnaSrdUdpSpecificationRequest{
+				EnaSrdUdpEnabled: fi.PtrTo(fi.ValueOf(t.ENASRDUDPEnabled)),
+			},
+		}
+	}
+
 	// @step: add the actual block device mappings
 	rootDevices, err := t.buildRootDevice(c.Cloud)
 	if err != nil {
@@ -86,9 +95,12 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 	for _, sg := range t.SecurityGroups {
 		data.NetworkInterfaces[0].Groups = append(data.NetworkInterfaces[0].Groups, sg.ID)
 	}
-	// @step: add any tenancy details
-	if t.Tenancy != nil {
-		data.Placement = &ec2.LaunchTemplatePlacementRequest{Tenancy: t.Tenancy}
+	// @step: add any tenancy and placement group details
+	if t.Tenancy != nil || t.PlacementGroup != nil {
+		data.Placement = &ec2.LaunchTemplatePlacementRequest{Tenancy: t.Tenancy, HostResourceGroupArn: t.HostResourceGroupARN}
+		if t.PlacementGroup != nil {
+			data.Placement.GroupName = t.PlacementGroup.Name
+		}
 	}
 	// @step: set the instance monitoring
 	data.Monitoring = &ec2.LaunchTemplatesMonitoringRequest{Enabled: fi.PtrTo(false)}
@@ -102,21 +114,16 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 		}
 	}
 	// @step: add the tags
-	var tags []*ec2.Tag
-	if len(t.Tags) > 0 {
-		for k, v := range t.Tags {
-			tags = append(tags, &ec2.Tag{
-				Key:   aws.String(k),
-				Value: aws.String(v),
-			})
-		}
+	if tags := t.instanceTags(); len(tags) > 0 {
 		data.TagSpecifications = append(data.TagSpecifications, &ec2.LaunchTemplateTagSpecificationRequest{
 			ResourceType: aws.String(ec2.ResourceTypeInstance),
-			Tags:         tags,
+			Tags:         mapToEC2Tags(tags),
 		})
+	}
+	if tags := t.volumeTags(); len(tags) > 0 {
 		data.TagSpecifications = append(data.TagSpecifications, &ec2.LaunchTemplateTagSpecificationRequest{
 			ResourceType: aws.String(ec2.ResourceTypeVolume),
-			Tags:         tags,
+			Tags:         mapToEC2Tags(tags),
 		})
 	}
 	// @step: add the userdata
@@ -144,6 +151,19 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 			CpuCredits: t.CPUCredits,
 		}
 	}
+	// @step: add the capacity reservation target
+	if fi.ValueOf(t.CapacityReservationID) != "" || fi.ValueOf(t.CapacityReservationResourceGroupARN) != "" {
+		target := &ec2.CapacityReservationTarget{}
+		if fi.ValueOf(t.CapacityReservationID) != "" {
+			target.CapacityReservationId = t.CapacityReservationID
+		}
+		if fi.ValueOf(t.CapacityReservationResourceGroupARN) != "" {
+			target.CapacityReservationResourceGroupArn = t.CapacityReservationResourceGroupARN
+		}
+		data.CapacityReservationSpecification = &ec2.LaunchTemplateCapacityReservationSpecificationRequest{
+			CapacityReservationTarget: target,
+		}
+	}
 	// @step: attempt to create the launch template
 	if a == nil {
 		input := &ec2.CreateLaunchTemplateInput{
@@ -152,7 +172,7 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 			TagSpecifications: []*ec2.TagSpecification{
 				{
 					ResourceType: aws.String(ec2.ResourceTypeLaunchTemplate),
-					Tags:         tags,
+					Tags:         mapToEC2Tags(t.Tags),
 				},
 			},
 		}
@@ -210,7 +230,10 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	klog.V(3).Infof("found existing LaunchTemplate: %s", fi.ValueOf(lt.LaunchTemplateName))
 
 	actual := &LaunchTemplate{
+		AllowReplacement:       t.AllowReplacement,
 		AssociatePublicIP:      fi.PtrTo(false),
+		ENASRDEnabled:          fi.PtrTo(false),
+		ENASRDUDPEnabled:       fi.PtrTo(false),
 		ID:                     lt.LaunchTemplateId,
 		ImageID:                lt.LaunchTemplateData.ImageId,
 		InstanceMonitoring:     fi.PtrTo(false),
@@ -229,6 +252,12 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 			actual.SecurityGroups = append(actual.SecurityGroups, &SecurityGroup{ID: id})
 		}
 		actual.IPv6AddressCount = x.Ipv6AddressCount
+		if x.EnaSrdSpecification != nil {
+			actual.ENASRDEnabled = fi.PtrTo(aws.BoolValue(x.EnaSrdSpecification.EnaSrdEnabled))
+			if x.EnaSrdSpecification.EnaSrdUdpSpecification != nil {
+				actual.ENASRDUDPEnabled = fi.PtrTo(aws.BoolValue(x.EnaSrdSpecification.EnaSrdUdpSpecification.EnaSrdUdpEnabled))
+			}
+		}
 	}
 	// In older Kops versions, security groups were added to LaunchTemplateData.SecurityGroupIds
 	for _, id := range lt.LaunchTemplateData.SecurityGroupIds {
@@ -245,9 +274,13 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	if lt.LaunchTemplateData.Monitoring != nil {
 		actual.InstanceMonitoring = lt.LaunchTemplateData.Monitoring.Enabled
 	}
-	// @step: add the tenancy
+	// @step: add the tenancy and placement group
 	if lt.LaunchTemplateData.Placement != nil {
 		actual.Tenancy = lt.LaunchTemplateData.Placement.Tenancy
+		actual.HostResourceGroupARN = lt.LaunchTemplateData.Placement.HostResourceGroupArn
+		if groupName := lt.LaunchTemplateData.Placement.GroupName; aws.StringValue(groupName) != "" {
+			actual.PlacementGroup = &PlacementGroup{Name: groupName}
+		}
 	}
 	// @step: add the ssh if there is one
 	if lt.LaunchTemplateData.KeyName != nil {
@@ -257,6 +290,17 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	if lt.LaunchTemplateData.IamInstanceProfile != nil {
 		actual.IAMInstanceProfile = &IAMInstanceProfile{Name: lt.LaunchTemplateData.IamInstanceProfile.Name}
 	}
+	// @step: add the capacity reservation target if there is one
+	actual.CapacityReservationID = aws.String("")
+	actual.CapacityReservationResourceGroupARN = aws.String("")
+	if crs := lt.LaunchTemplateData.CapacityReservationSpecification; crs != nil && crs.CapacityReservationTarget != nil {
+		if crs.CapacityReservationTarget.CapacityReservationId != nil {
+			actual.CapacityReservationID = crs.CapacityReservationTarget.CapacityReservationId
+		}
+		if crs.CapacityReservationTarget.CapacityReservationResourceGroupArn != nil {
+			actual.CapacityReservationResourceGroupARN = crs.CapacityReservationTarget.CapacityReservationResourceGroupArn
+		}
+	}
 	// @step: add InstanceMarketOptions if there are any
 	imo := lt.LaunchTemplateData.InstanceMarketOptions
 	if imo != nil && imo.SpotOptions != nil && aws.StringValue(imo.SpotOptions.MaxPrice) != "" {
@@ -305,13 +349,21 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	}
 
 	// @step: add tags
-	if len(lt.LaunchTemplateData.TagSpecifications) > 0 {
-		ts := lt.LaunchTemplateData.TagSpecifications[0]
-		if ts.Tags != nil {
-			tags := mapEC2TagsToMap(ts.Tags)
-			actual.Tags = tags
+	for _, ts := range lt.LaunchTemplateData.TagSpecifications {
+		switch aws.StringValue(ts.ResourceType) {
+		case ec2.ResourceTypeInstance:
+			actual.InstanceTags = mapEC2TagsToMap(ts.Tags)
+		case ec2.ResourceTypeVolume:
+			actual.VolumeTags = mapEC2TagsToMap(ts.Tags)
 		}
 	}
+	tags, err := cloud.GetTags(aws.StringValue(lt.LaunchTemplateId))
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags of LaunchTemplate %q: %w", aws.StringValue(lt.LaunchTemplateName), err)
+	}
+	if len(tags) != 0 {
+		actual.Tags = tags
+	}
 
 	// @step: add instance metadata options
 	if options := lt.LaunchTemplateData.MetadataOptions; options != nil {
@@ -0,0 +1,549 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/timings"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestLaunchTemplateRootVolumeDrift(t *testing.T) {
+	grid := []struct {
+		Description string
+		Mutate      func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest)
+	}{
+		{
+			Description: "volume size",
+			Mutate:      func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest) { ebs.VolumeSize = aws.Int64(200) },
+		},
+		{
+			Description: "volume type",
+			Mutate:      func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest) { ebs.VolumeType = aws.String(ec2.VolumeTypeGp2) },
+		},
+		{
+			Description: "iops",
+			Mutate:      func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest) { ebs.Iops = aws.Int64(6000) },
+		},
+		{
+			Description: "throughput",
+			Mutate:      func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest) { ebs.Throughput = aws.Int64(250) },
+		},
+		{
+			Description: "encryption",
+			Mutate: func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest) {
+				ebs.Encrypted = aws.Bool(false)
+				ebs.KmsKeyId = nil
+			},
+		},
+		{
+			Description: "kms key",
+			Mutate: func(ebs *ec2.LaunchTemplateEbsBlockDeviceRequest) {
+				ebs.KmsKeyId = aws.String("arn:aws:kms:us-east-1:123456789012:key/other")
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			ctx := context.TODO()
+
+			cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+			c := &mockec2.MockEC2{}
+			c.Images = append(c.Images, &ec2.Image{
+				CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+				ImageId:        aws.String("ami-12345678"),
+				Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+				OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+				RootDeviceName: aws.String("/dev/xvda"),
+			})
+			cloud.MockEC2 = c
+
+			// We define a function so we can rebuild the tasks, because we modify in-place when running
+			buildTasks := func() map[string]fi.CloudupTask {
+				lt := &LaunchTemplate{
+					Name:                 s("nodes"),
+					Lifecycle:            fi.LifecycleSync,
+					ImageID:              s("ami-12345678"),
+					InstanceType:         s("t3.medium"),
+					RootVolumeSize:       aws.Int64(64),
+					RootVolumeType:       s(ec2.VolumeTypeGp3),
+					RootVolumeIops:       aws.Int64(3000),
+					RootVolumeThroughput: aws.Int64(125),
+					RootVolumeEncryption: fi.PtrTo(true),
+					RootVolumeKmsKey:     s("arn:aws:kms:us-east-1:123456789012:key/kops"),
+				}
+				return map[string]fi.CloudupTask{
+					"nodes": lt,
+				}
+			}
+
+			runTasks := func(allTasks map[string]fi.CloudupTask) map[string]int64 {
+				target := &awsup.AWSAPITarget{
+					Cloud: cloud,
+				}
+
+				context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+				if err != nil {
+					t.Fatalf("error building context: %v", err)
+				}
+
+				recorder := timings.NewRecorder()
+				stopRecording := timings.Start(recorder)
+				err = context.RunTasks(testRunTasksOptions)
+				stopRecording()
+				if err != nil {
+					t.Fatalf("unexpected error during Run: %v", err)
+				}
+				return recorder.APICalls("ec2")
+			}
+
+			findLatest := func() *ec2.LaunchTemplateVersion {
+				output, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: s("nodes"),
+					Versions:           []*string{aws.String("$Latest")},
+				})
+				if err != nil {
+					t.Fatalf("error describing launch template versions: %v", err)
+				}
+				if len(output.LaunchTemplateVersions) != 1 {
+					t.Fatalf("expected a single launch template version, got %v", output.LaunchTemplateVersions)
+				}
+				return output.LaunchTemplateVersions[0]
+			}
+
+			findRootVolume := func(version *ec2.LaunchTemplateVersion) *ec2.LaunchTemplateEbsBlockDevice {
+				for _, b := range version.LaunchTemplateData.BlockDeviceMappings {
+					if aws.StringValue(b.DeviceName) == "/dev/xvda" && b.Ebs != nil {
+						return b.Ebs
+					}
+				}
+				t.Fatalf("root volume not found in launch template version %d", aws.Int64Value(version.VersionNumber))
+				return nil
+			}
+
+			{
+				runTasks(buildTasks())
+				checkNoChanges(t, ctx, cloud, buildTasks())
+			}
+
+			{
+				// Simulate an edit of the root volume in the console, which creates a new version from the latest one
+				ebs := &ec2.LaunchTemplateEbsBlockDeviceRequest{
+					DeleteOnTermination: aws.Bool(true),
+					Encrypted:           aws.Bool(true),
+					Iops:                aws.Int64(3000),
+					KmsKeyId:            aws.String("arn:aws:kms:us-east-1:123456789012:key/kops"),
+					Throughput:          aws.Int64(125),
+					VolumeSize:          aws.Int64(64),
+					VolumeType:          aws.String(ec2.VolumeTypeGp3),
+				}
+				g.Mutate(ebs)
+				if _, err := c.CreateLaunchTemplateVersion(&ec2.CreateLaunchTemplateVersionInput{
+					LaunchTemplateName: s("nodes"),
+					SourceVersion:      aws.String("$Latest"),
+					LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+						BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMappingRequest{
+							{DeviceName: aws.String("/dev/xvda"), Ebs: ebs},
+						},
+					},
+				}); err != nil {
+					t.Fatalf("error creating launch template version: %v", err)
+				}
+				if version := aws.Int64Value(findLatest().VersionNumber); version != 2 {
+					t.Fatalf("expected version 2 after the edit, got %d", version)
+				}
+			}
+
+			{
+				calls := runTasks(buildTasks())
+				if calls["CreateLaunchTemplateVersion"] != 1 {
+					t.Errorf("expected drift to be reconciled with a new launch template version, got EC2 API calls: %v", calls)
+				}
+
+				latest := findLatest()
+				if version := aws.Int64Value(latest.VersionNumber); version != 3 {
+					t.Errorf("expected version 3 after reconciling, got %d", version)
+				}
+				ebs := findRootVolume(latest)
+				if aws.Int64Value(ebs.VolumeSize) != 64 || aws.StringValue(ebs.VolumeType) != ec2.VolumeTypeGp3 ||
+					aws.Int64Value(ebs.Iops) != 3000 || aws.Int64Value(ebs.Throughput) != 125 ||
+					!aws.BoolValue(ebs.Encrypted) || aws.StringValue(ebs.KmsKeyId) != "arn:aws:kms:us-east-1:123456789012:key/kops" {
+					t.Errorf("expected root volume to be restored, got %v", ebs)
+				}
+			}
+
+			{
+				checkNoChanges(t, ctx, cloud, buildTasks())
+			}
+		})
+	}
+}
+
+func TestLaunchTemplateENAExpress(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	c.Images = append(c.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(enaSRD, enaSRDUDP bool) map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:             s("nodes"),
+			Lifecycle:        fi.LifecycleSync,
+			ImageID:          s("ami-12345678"),
+			InstanceType:     s("c6in.32xlarge"),
+			ENASRDEnabled:    fi.PtrTo(enaSRD),
+			ENASRDUDPEnabled: fi.PtrTo(enaSRDUDP),
+		}
+		return map[string]fi.CloudupTask{
+			"nodes": lt,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) map[string]int64 {
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		recorder := timings.NewRecorder()
+		stopRecording := timings.Start(recorder)
+		err = context.RunTasks(testRunTasksOptions)
+		stopRecording()
+		if err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+		return recorder.APICalls("ec2")
+	}
+
+	findENASRDSpecification := func() *ec2.LaunchTemplateEnaSrdSpecification {
+		output, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateName: s("nodes"),
+			Versions:           []*string{aws.String("$Latest")},
+		})
+		if err != nil {
+			t.Fatalf("error describing launch template versions: %v", err)
+		}
+		if len(output.LaunchTemplateVersions) != 1 {
+			t.Fatalf("expected a single launch template version, got %v", output.LaunchTemplateVersions)
+		}
+		return output.LaunchTemplateVersions[0].LaunchTemplateData.NetworkInterfaces[0].EnaSrdSpecification
+	}
+
+	{
+		runTasks(buildTasks(false, false))
+		if spec := findENASRDSpecification(); spec != nil {
+			t.Errorf("expected ENA Express not to be configured, got %v", spec)
+		}
+		checkNoChanges(t, ctx, cloud, buildTasks(false, false))
+	}
+
+	{
+		calls := runTasks(buildTasks(true, true))
+		if calls["CreateLaunchTemplateVersion"] != 1 {
+			t.Errorf("expected enabling ENA Express to create a new launch template version, got EC2 API calls: %v", calls)
+		}
+		spec := findENASRDSpecification()
+		if spec == nil || !aws.BoolValue(spec.EnaSrdEnabled) || spec.EnaSrdUdpSpecification == nil || !aws.BoolValue(spec.EnaSrdUdpSpecification.EnaSrdUdpEnabled) {
+			t.Errorf("expected ENA Express to be enabled for TCP and UDP, got %v", spec)
+		}
+		checkNoChanges(t, ctx, cloud, buildTasks(true, true))
+	}
+
+	{
+		calls := runTasks(buildTasks(false, false))
+		if calls["CreateLaunchTemplateVersion"] != 1 {
+			t.Errorf("expected disabling ENA Express to create a new launch template version, got EC2 API calls: %v", calls)
+		}
+		if spec := findENASRDSpecification(); spec != nil {
+			t.Errorf("expected ENA Express not to be configured, got %v", spec)
+		}
+		checkNoChanges(t, ctx, cloud, buildTasks(false, false))
+	}
+}
+
+func TestLaunchTemplateHostTenancy(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	c.Images = append(c.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = c
+
+	hostResourceGroupARN := "arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:                 s("nodes"),
+			Lifecycle:            fi.LifecycleSync,
+			ImageID:              s("ami-12345678"),
+			InstanceType:         s("m5.large"),
+			Tenancy:              s("host"),
+			HostResourceGroupARN: s(hostResourceGroupARN),
+		}
+		return map[string]fi.CloudupTask{
+			"nodes": lt,
+		}
+	}
+
+	{
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, buildTasks())
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	output, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: s("nodes"),
+		Versions:           []*string{aws.String("$Latest")},
+	})
+	if err != nil {
+		t.Fatalf("error describing launch template versions: %v", err)
+	}
+	if len(output.LaunchTemplateVersions) != 1 {
+		t.Fatalf("expected a single launch template version, got %v", output.LaunchTemplateVersions)
+	}
+	placement := output.LaunchTemplateVersions[0].LaunchTemplateData.Placement
+	if placement == nil || aws.StringValue(placement.Tenancy) != "host" || aws.StringValue(placement.HostResourceGroupArn) != hostResourceGroupARN {
+		t.Errorf("expected the host tenancy in the host resource group, got placement %v", placement)
+	}
+
+	checkNoChanges(t, ctx, cloud, buildTasks())
+}
+
+func TestLaunchTemplateTagScopes(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	c.Images = append(c.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:         s("nodes"),
+			Lifecycle:    fi.LifecycleSync,
+			ImageID:      s("ami-12345678"),
+			InstanceType: s("t3.medium"),
+			Tags:         map[string]string{"Name": "nodes"},
+			InstanceTags: map[string]string{"Name": "nodes", "team": "platform"},
+			VolumeTags:   map[string]string{"Name": "nodes", "map-migrated": "mig123"},
+		}
+		return map[string]fi.CloudupTask{
+			"nodes": lt,
+		}
+	}
+
+	{
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, buildTasks())
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	output, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: s("nodes"),
+		Versions:           []*string{aws.String("$Latest")},
+	})
+	if err != nil {
+		t.Fatalf("error describing launch template versions: %v", err)
+	}
+	if len(output.LaunchTemplateVersions) != 1 {
+		t.Fatalf("expected a single launch template version, got %v", output.LaunchTemplateVersions)
+	}
+	specifications := make(map[string]map[string]string)
+	for _, ts := range output.LaunchTemplateVersions[0].LaunchTemplateData.TagSpecifications {
+		specifications[aws.StringValue(ts.ResourceType)] = mapEC2TagsToMap(ts.Tags)
+	}
+	if tags := specifications[ec2.ResourceTypeInstance]; len(tags) != 2 || tags["team"] != "platform" {
+		t.Errorf("unexpected instance tags %v", tags)
+	}
+	if tags := specifications[ec2.ResourceTypeVolume]; len(tags) != 2 || tags["map-migrated"] != "mig123" {
+		t.Errorf("unexpected volume tags %v", tags)
+	}
+
+	checkNoChanges(t, ctx, cloud, buildTasks())
+}
+
+func TestLaunchTemplateSpotTransitions(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	c.Images = append(c.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = c
+	mockAutoscaling := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = mockAutoscaling
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(spotPrice string) map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:         s("nodes"),
+			Lifecycle:    fi.LifecycleSync,
+			ImageID:      s("ami-12345678"),
+			InstanceType: s("t3.medium"),
+			SpotPrice:    s(spotPrice),
+		}
+		if spotPrice != "" {
+			lt.InstanceInterruptionBehavior = s(ec2.InstanceInterruptionBehaviorHibernate)
+		}
+		asg := &AutoscalingGroup{
+			Name:                 s("nodes"),
+			Lifecycle:            fi.LifecycleSync,
+			LaunchTemplate:       lt,
+			Granularity:          s("1Minute"),
+			Metrics:              []string{},
+			SuspendProcesses:     &[]string{},
+			InstanceProtection:   aws.Bool(false),
+			CapacityRebalance:    aws.Bool(false),
+			MinSize:              aws.Int64(1),
+			MaxSize:              aws.Int64(3),
+			MaxInstanceLifetime:  aws.Int64(0),
+			MinHealthyPercentage: aws.Int64(-1),
+			MaxHealthyPercentage: aws.Int64(-1),
+			Tags:                 map[string]string{},
+		}
+		return map[string]fi.CloudupTask{
+			"nodes/lt":  lt,
+			"nodes/asg": asg,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) map[string]int64 {
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		recorder := timings.NewRecorder()
+		stopRecording := timings.Start(recorder)
+		err = context.RunTasks(testRunTasksOptions)
+		stopRecording()
+		if err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+		return recorder.APICalls("ec2")
+	}
+
+	findInstanceMarketOptions := func() *ec2.LaunchTemplateInstanceMarketOptions {
+		output, err := c.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateName: s("nodes"),
+			Versions:           []*string{aws.String("$Latest")},
+		})
+		if err != nil {
+			t.Fatalf("error describing launch template versions: %v", err)
+		}
+		if len(output.LaunchTemplateVersions) != 1 {
+			t.Fatalf("expected a single launch template version, got %v", output.LaunchTemplateVersions)
+		}
+		return output.LaunchTemplateVersions[0].LaunchTemplateData.InstanceMarketOptions
+	}
+
+	runTasks(buildTasks(""))
+	if options := findInstanceMarketOptions(); options != nil {
+		t.Errorf("expected on-demand instances, got market options %v", options)
+	}
+	group := mockAutoscaling.Groups["nodes"]
+	if group == nil {
+		t.Fatalf("expected the autoscaling group to exist")
+	}
+	checkLaunchTemplateNoChanges := func(spotPrice string) {
+		// The mock does not model every ASG attribute, so only check the launch template
+		checkNoChanges(t, ctx, cloud, map[string]fi.CloudupTask{
+			"nodes/lt": buildTasks(spotPrice)["nodes/lt"],
+		})
+	}
+	checkLaunchTemplateNoChanges("")
+
+	for _, spotPrice := range []string{"0.1", ""} {
+		calls := runTasks(buildTasks(spotPrice))
+		if calls["CreateLaunchTemplateVersion"] != 1 {
+			t.Errorf("expected switching to spot price %q to create a new launch template version, got EC2 API calls: %v", spotPrice, calls)
+		}
+
+		options := findInstanceMarketOptions()
+		if spotPrice == "" && options != nil {
+			t.Errorf("expected the market options to be removed, got %v", options)
+		}
+		if spotPrice != "" && (options == nil || options.SpotOptions == nil || aws.StringValue(options.SpotOptions.MaxPrice) != spotPrice) {
+			t.Errorf("expected spot instances with max price %q, got %v", spotPrice, options)
+		}
+
+		if mockAutoscaling.Groups["nodes"] != group {
+			t.Errorf("expected switching to spot price %q to keep the autoscaling group", spotPrice)
+		}
+		checkLaunchTemplateNoChanges(spotPrice)
+	}
+}
@@ -28,12 +28,26 @@ type terraformLaunchTemplateNetworkInterface struct {
 	AssociatePublicIPAddress *bool `cty:"associate_public_ip_address"`
 	// DeleteOnTermination indicates whether the network interface should be destroyed on instance termination.
 	DeleteOnTermination *bool `cty:"delete_on_termination"`
+	// ENASRDSpecification configures ENA Express for the network interface.
+	ENASRDSpecification *terraformLaunchTemplateENASRDSpecification `cty:"ena_srd_specification"`
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	Ipv6AddressCount *int64 `cty:"ipv6_address_count"`
 	// SecurityGroups is a list of security group ids.
 	SecurityGroups []*terraformWriter.Literal `cty:"security_groups"`
 }
 
+type terraformLaunchTemplateENASRDSpecification struct {
+	// ENASRDEnabled enables ENA Express for TCP traffic.
+	ENASRDEnabled *bool `cty:"ena_srd_enabled"`
+	// ENASRDUDPSpecification configures ENA Express for UDP traffic.
+	ENASRDUDPSpecification *terraformLaunchTemplateENASRDUDPSpecification `cty:"ena_srd_udp_specification"`
+}
+
+type terraformLaunchTemplateENASRDUDPSpecification struct {
+	// ENASRDUDPEnabled enables ENA Express for UDP traffic.
+	ENASRDUDPEnabled *bool `cty:"ena_srd_udp_enabled"`
+}
+
 type terraformLaunchTemplateMonitoring struct {
 	// Enabled indicates that monitoring is enabled
 	Enabled *bool `cty:"enabled"`
@@ -45,9 +59,11 @@ type terraformLaunchTemplatePlacement struct {
 	// AvailabilityZone is the Availability Zone for the instance.
 	AvailabilityZone *string `cty:"availability_zone"`
 	// GroupName is the name of the placement group for the instance.
-	GroupName *string `cty:"group_name"`
+	GroupName *terraformWriter.Literal `cty:"group_name"`
 	// HostID is the ID of the Dedicated Host for the instance.
 	HostID *string `cty:"host_id"`
+	// HostResourceGroupARN is the ARN of the host resource group in which to launch the instance.
+	HostResourceGroupARN *string `cty:"host_resource_group_arn"`
 	// SpreadDomain are reserved for future use.
 	SpreadDomain *string `cty:"spread_domain"`
 	// Tenancy ist he tenancy of the instance. Can be default, dedicated, or host.
@@ -105,6 +121,18 @@ type terraformLaunchTemplateBlockDevice struct {
 	EBS []*terraformLaunchTemplateBlockDeviceEBS `cty:"ebs"`
 }
 
+type terraformLaunchTemplateCapacityReservationTarget struct {
+	// CapacityReservationID is the ID of the capacity reservation.
+	CapacityReservationID *string `cty:"capacity_reservation_id"`
+	// CapacityReservationResourceGroupARN is the ARN of the resource group of capacity reservations.
+	CapacityReservationResourceGroupARN *string `cty:"capacity_reservation_resource_group_arn"`
+}
+
+type terraformLaunchTemplateCapacityReservationSpecification struct {
+	// CapacityReservationTarget is the capacity reservation the instances are launched into.
+	CapacityReservationTarget []*terraformLaunchTemplateCapacityReservationTarget `cty:"capacity_reservation_target"`
+}
+
 type terraformLaunchTemplateCreditSpecification struct {
 	CPUCredits *string `cty:"cpu_credits"`
 }
@@ -135,6 +163,8 @@ type terraformLaunchTemplate struct {
 
 	// BlockDeviceMappings is the device mappings
 	BlockDeviceMappings []*terraformLaunchTemplateBlockDevice `cty:"block_device_mappings"`
+	// CapacityReservationSpecification is the capacity reservation the instances are launched into
+	CapacityReservationSpecification []*terraformLaunchTemplateCapacityReservationSpecification `cty:"capacity_reservation_specification"`
 	// CreditSpecification is the credit option for CPU Usage on some instance types
 	CreditSpecification *terraformLaunchTemplateCreditSpecification `cty:"credit_specification"`
 	// EBSOptimized indicates if the root device is ebs optimized
@@ -212,6 +242,15 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 		},
 	}
 
+	if fi.ValueOf(e.ENASRDEnabled) {
+		tf.NetworkInterfaces[0].ENASRDSpecification = &terraformLaunchTemplateENASRDSpecification{
+			ENASRDEnabled: e.ENASRDEnabled,
+			ENASRDUDPSpecification: &terraformLaunchTemplateENASRDUDPSpecification{
+				ENASRDUDPEnabled: fi.PtrTo(fi.ValueOf(e.ENASRDUDPEnabled)),
+			},
+		}
+	}
+
 	if fi.ValueOf(e.SpotPrice) != "" {
 		marketSpotOptions := terraformLaunchTemplateMarketOptionsSpotOptions{MaxPrice: e.SpotPrice}
 		if e.SpotDurationInMinutes != nil {
@@ -232,14 +271,30 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 			CPUCredits: e.CPUCredits,
 		}
 	}
+	if fi.ValueOf(e.CapacityReservationID) != "" || fi.ValueOf(e.CapacityReservationResourceGroupARN) != "" {
+		target := &terraformLaunchTemplateCapacityReservationTarget{}
+		if fi.ValueOf(e.CapacityReservationID) != "" {
+			target.CapacityReservationID = e.CapacityReservationID
+		}
+		if fi.ValueOf(e.CapacityReservationResourceGroupARN) != "" {
+			target.CapacityReservationResourceGroupARN = e.CapacityReservationResourceGroupARN
+		}
+		tf.CapacityReservationSpecification = []*terraformLaunchTemplateCapacityReservationSpecification{
+			{CapacityReservationTarget: []*terraformLaunchTemplateCapacityReservationTarget{target}},
+		}
+	}
 	for _, x := range e.SecurityGroups {
 		tf.NetworkInterfaces[0].SecurityGroups = append(tf.NetworkInterfaces[0].SecurityGroups, x.TerraformLink())
 	}
 	if e.SSHKey != nil {
 		tf.KeyName = e.SSHKey.TerraformLink()
 	}
-	if e.Tenancy != nil {
-		tf.Placement = []*terraformLaunchTemplatePlacement{{Tenancy: e.Tenancy}}
+	if e.Tenancy != nil || e.PlacementGroup != nil {
+		placement := &terraformLaunchTemplatePlacement{Tenancy: e.Tenancy, HostResourceGroupARN: e.HostResourceGroupARN}
+		if e.PlacementGroup != nil {
+			placement.GroupName = e.PlacementGroup.TerraformLink()
+		}
+		tf.Placement = []*terraformLaunchTemplatePlacement{placement}
 	}
 	if e.InstanceMonitoring != nil {
 		tf.Monitoring = []*terraformLaunchTemplateMonitoring{
@@ -315,15 +370,19 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 		})
 	}
 
-	if e.Tags != nil {
+	if tags := e.instanceTags(); tags != nil {
 		tf.TagSpecifications = append(tf.TagSpecifications, &terraformLaunchTemplateTagSpecification{
 			ResourceType: fi.PtrTo("instance"),
-			Tags:         e.Tags,
+			Tags:         tags,
 		})
+	}
+	if tags := e.volumeTags(); tags != nil {
 		tf.TagSpecifications = append(tf.TagSpecifications, &terraformLaunchTemplateTagSpecification{
 			ResourceType: fi.PtrTo("volume"),
-			Tags:         e.Tags,
+			Tags:         tags,
 		})
+	}
+	if e.Tags != nil {
 		tf.Tags = e.Tags
 	}
 
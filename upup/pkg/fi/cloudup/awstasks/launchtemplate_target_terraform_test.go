@@ -120,12 +120,16 @@ terraform {
 						EbsEncrypted:           fi.PtrTo(true),
 					},
 				},
+				CapacityReservationID:  fi.PtrTo("cr-0123456789abcdef0"),
 				ID:                     fi.PtrTo("test-11"),
 				InstanceMonitoring:     fi.PtrTo(true),
 				InstanceType:           fi.PtrTo("t2.medium"),
 				RootVolumeOptimization: fi.PtrTo(true),
 				RootVolumeIops:         fi.PtrTo(int64(100)),
 				RootVolumeSize:         fi.PtrTo(int64(64)),
+				PlacementGroup: &PlacementGroup{
+					Name: fi.PtrTo("nodes.example.com"),
+				},
 				SSHKey: &SSHKey{
 					Name: fi.PtrTo("mykey"),
 				},
@@ -151,6 +155,11 @@ resource "aws_launch_template" "test" {
       volume_type           = "gp2"
     }
   }
+  capacity_reservation_specification {
+    capacity_reservation_target {
+      capacity_reservation_id = "cr-0123456789abcdef0"
+    }
+  }
   ebs_optimized = true
   iam_instance_profile {
     name = aws_iam_instance_profile.nodes.id
@@ -175,7 +184,90 @@ resource "aws_launch_template" "test" {
     security_groups             = [aws_security_group.nodes-1.id, aws_security_group.nodes-2.id]
   }
   placement {
-    tenancy = "dedicated"
+    group_name = aws_placement_group.nodes-example-com.name
+    tenancy    = "dedicated"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name:             fi.PtrTo("test"),
+				InstanceType:     fi.PtrTo("c6in.32xlarge"),
+				ENASRDEnabled:    fi.PtrTo(true),
+				ENASRDUDPEnabled: fi.PtrTo(false),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  instance_type = "c6in.32xlarge"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint = "enabled"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+    ena_srd_specification {
+      ena_srd_enabled = true
+      ena_srd_udp_specification {
+        ena_srd_udp_enabled = false
+      }
+    }
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name:                 fi.PtrTo("test"),
+				InstanceType:         fi.PtrTo("m5.large"),
+				Tenancy:              fi.PtrTo("host"),
+				HostResourceGroupARN: fi.PtrTo("arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  instance_type = "m5.large"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint = "enabled"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+  }
+  placement {
+    host_resource_group_arn = "arn:aws:resource-groups:us-east-1:123456789012:group/my-hosts"
+    tenancy                 = "host"
   }
 }
 
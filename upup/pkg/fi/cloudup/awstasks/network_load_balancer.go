@@ -467,6 +467,11 @@ func (e *NetworkLoadBalancer) FindAddresses(context *fi.CloudupContext) ([]strin
 			if fi.ValueOf(ni.PrivateIpAddress) != "" {
 				addresses = append(addresses, fi.ValueOf(ni.PrivateIpAddress))
 			}
+			for _, ipv6Address := range ni.Ipv6Addresses {
+				if fi.ValueOf(ipv6Address.Ipv6Address) != "" {
+					addresses = append(addresses, fi.ValueOf(ipv6Address.Ipv6Address))
+				}
+			}
 		}
 	}
 
@@ -485,11 +490,14 @@ func (e *NetworkLoadBalancer) Normalize(c *fi.CloudupContext) error {
 	sort.Stable(OrderListenersByPort(e.Listeners))
 	sort.Stable(OrderTargetGroupsByName(e.TargetGroups))
 
-	e.IpAddressType = fi.PtrTo("dualstack")
-	for _, subnet := range e.SubnetMappings {
-		for _, clusterSubnet := range c.T.Cluster.Spec.Networking.Subnets {
-			if clusterSubnet.Name == fi.ValueOf(subnet.Subnet.ShortName) && clusterSubnet.IPv6CIDR == "" {
-				e.IpAddressType = fi.PtrTo("ipv4")
+	// Unless it was set explicitly, use dualstack when all the subnets have IPv6 CIDRs
+	if e.IpAddressType == nil {
+		e.IpAddressType = fi.PtrTo("dualstack")
+		for _, subnet := range e.SubnetMappings {
+			for _, clusterSubnet := range c.T.Cluster.Spec.Networking.Subnets {
+				if clusterSubnet.Name == fi.ValueOf(subnet.Subnet.ShortName) && clusterSubnet.IPv6CIDR == "" {
+					e.IpAddressType = fi.PtrTo("ipv4")
+				}
 			}
 		}
 	}
@@ -523,6 +531,13 @@ func (*NetworkLoadBalancer) CheckChanges(a, e, changes *NetworkLoadBalancer) err
 			}
 		}
 	} else {
+		if len(a.SecurityGroups) == 0 && len(e.SecurityGroups) != 0 {
+			if e.ForAPIServer {
+				return fmt.Errorf("network load balancer %q was created without security groups, which can only be attached at creation: delete it so that it is recreated, or set spec.api.loadBalancer.useSecurityGroups to false", fi.ValueOf(a.LoadBalancerName))
+			}
+			return fmt.Errorf("network load balancer %q was created without security groups, which can only be attached at creation: delete it so that it is recreated", fi.ValueOf(a.LoadBalancerName))
+		}
+
 		if len(changes.SubnetMappings) > 0 {
 			expectedSubnets := make(map[string]*string)
 			for _, s := range e.SubnetMappings {
@@ -802,9 +817,7 @@ func (_ *NetworkLoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e
 		Type:                   elbv2.LoadBalancerTypeEnumNetwork,
 		Tags:                   e.Tags,
 		CrossZoneLoadBalancing: fi.ValueOf(e.CrossZoneLoadBalancing),
-	}
-	if fi.ValueOf(e.IpAddressType) == "dualstack" {
-		nlbTF.IPAddressType = e.IpAddressType
+		IPAddressType:          e.IpAddressType,
 	}
 
 	for _, subnetMapping := range e.SubnetMappings {
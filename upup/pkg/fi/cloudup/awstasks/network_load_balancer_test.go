@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNetworkLoadBalancerCheckChangesSecurityGroups(t *testing.T) {
+	grid := []struct {
+		name          string
+		actualSGs     []*SecurityGroup
+		expectedSGs   []*SecurityGroup
+		forAPIServer  bool
+		expectedError string
+	}{
+		{
+			name: "no security groups",
+		},
+		{
+			name:        "existing security groups",
+			actualSGs:   []*SecurityGroup{{ID: s("sg-1")}},
+			expectedSGs: []*SecurityGroup{{ID: s("sg-1")}, {ID: s("sg-2")}},
+		},
+		{
+			name:          "adding security groups",
+			expectedSGs:   []*SecurityGroup{{ID: s("sg-1")}},
+			expectedError: "delete it so that it is recreated",
+		},
+		{
+			name:          "adding security groups to api",
+			expectedSGs:   []*SecurityGroup{{ID: s("sg-1")}},
+			forAPIServer:  true,
+			expectedError: "spec.api.loadBalancer.useSecurityGroups",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			a := &NetworkLoadBalancer{LoadBalancerName: s("api-nlb"), SecurityGroups: g.actualSGs}
+			e := &NetworkLoadBalancer{LoadBalancerName: s("api-nlb"), SecurityGroups: g.expectedSGs, ForAPIServer: g.forAPIServer}
+			changes := &NetworkLoadBalancer{SecurityGroups: g.expectedSGs}
+
+			err := e.CheckChanges(a, e, changes)
+			if g.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+				t.Errorf("expected error containing %q, got %v", g.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// PlacementGroup is an EC2 placement group, which controls how instances are placed on the underlying hardware.
+// +kops:fitask
+type PlacementGroup struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// ID is the id of the placement group
+	ID *string
+	// Strategy is one of cluster, partition or spread
+	Strategy *string
+	// PartitionCount is the number of partitions, when the strategy is partition
+	PartitionCount *int64
+
+	// Shared is set if this is an existing placement group, which we don't create or own
+	Shared *bool
+
+	Tags map[string]string
+}
+
+var _ fi.CompareWithID = &PlacementGroup{}
+
+// CompareWithID uses the name, which is unique in a region and is how launch templates refer to placement groups
+func (e *PlacementGroup) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *PlacementGroup) Find(c *fi.CloudupContext) (*PlacementGroup, error) {
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	request := &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{awsup.NewEC2Filter("group-name", fi.ValueOf(e.Name))},
+	}
+	response, err := cloud.EC2().DescribePlacementGroups(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing placement groups: %w", err)
+	}
+	if response == nil || len(response.PlacementGroups) == 0 {
+		if fi.ValueOf(e.Shared) {
+			return nil, fmt.Errorf("placement group %q not found", fi.ValueOf(e.Name))
+		}
+		return nil, nil
+	}
+	if len(response.PlacementGroups) != 1 {
+		return nil, fmt.Errorf("found multiple placement groups with name %q", fi.ValueOf(e.Name))
+	}
+
+	pg := response.PlacementGroups[0]
+	e.ID = pg.GroupId
+
+	if fi.ValueOf(e.Shared) {
+		// We only use shared placement groups, so we ignore their strategy and tags
+		actual := &PlacementGroup{
+			Name:      pg.GroupName,
+			Lifecycle: e.Lifecycle,
+			ID:        pg.GroupId,
+			Shared:    e.Shared,
+		}
+		return actual, nil
+	}
+
+	actual := &PlacementGroup{
+		Name:           pg.GroupName,
+		Lifecycle:      e.Lifecycle,
+		ID:             pg.GroupId,
+		Strategy:       pg.Strategy,
+		PartitionCount: pg.PartitionCount,
+		Tags:           mapEC2TagsToMap(pg.Tags),
+	}
+
+	// AWS reports the partition count only for partition placement groups
+	if fi.ValueOf(actual.Strategy) != ec2.PlacementStrategyPartition {
+		actual.PartitionCount = e.PartitionCount
+	}
+
+	return actual, nil
+}
+
+func (e *PlacementGroup) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *PlacementGroup) ShouldCreate(a, e, changes *PlacementGroup) (bool, error) {
+	if fi.ValueOf(e.Shared) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (_ *PlacementGroup) CheckChanges(a, e, changes *PlacementGroup) error {
+	if fi.ValueOf(e.Shared) {
+		if e.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		return nil
+	}
+	if a == nil {
+		if e.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if e.Strategy == nil {
+			return fi.RequiredField("Strategy")
+		}
+	} else {
+		// Placement groups cannot be modified, only replaced, which requires that no instances use them
+		if changes.Strategy != nil {
+			return fi.CannotChangeField("Strategy")
+		}
+		if changes.PartitionCount != nil {
+			return fi.CannotChangeField("PartitionCount")
+		}
+	}
+	return nil
+}
+
+func (_ *PlacementGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *PlacementGroup) error {
+	if fi.ValueOf(e.Shared) {
+		// Not owned by kOps; Find checked that it exists
+		return nil
+	}
+
+	if a == nil {
+		klog.V(2).Infof("Creating placement group %q with strategy %q", fi.ValueOf(e.Name), fi.ValueOf(e.Strategy))
+
+		request := &ec2.CreatePlacementGroupInput{
+			GroupName:         e.Name,
+			Strategy:          e.Strategy,
+			TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypePlacementGroup, e.Tags),
+		}
+		if fi.ValueOf(e.Strategy) == ec2.PlacementStrategyPartition {
+			request.PartitionCount = e.PartitionCount
+		}
+
+		response, err := t.Cloud.EC2().CreatePlacementGroup(request)
+		if err != nil {
+			return fmt.Errorf("error creating placement group %q: %w", fi.ValueOf(e.Name), err)
+		}
+		if response.PlacementGroup != nil {
+			e.ID = response.PlacementGroup.GroupId
+		}
+		return nil
+	}
+
+	return t.AddAWSTags(aws.StringValue(e.ID), e.Tags)
+}
+
+type terraformPlacementGroup struct {
+	Name           *string           `cty:"name"`
+	Strategy       *string           `cty:"strategy"`
+	PartitionCount *int64            `cty:"partition_count"`
+	Tags           map[string]string `cty:"tags"`
+}
+
+func (_ *PlacementGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *PlacementGroup) error {
+	if fi.ValueOf(e.Shared) {
+		// Not terraform owned / managed
+		return nil
+	}
+
+	tf := &terraformPlacementGroup{
+		Name:     e.Name,
+		Strategy: e.Strategy,
+		Tags:     e.Tags,
+	}
+	if fi.ValueOf(e.Strategy) == ec2.PlacementStrategyPartition {
+		tf.PartitionCount = e.PartitionCount
+	}
+
+	return t.RenderResource("aws_placement_group", fi.ValueOf(e.Name), tf)
+}
+
+func (e *PlacementGroup) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(e.Shared) {
+		// Not terraform owned / managed
+		return terraformWriter.LiteralFromStringValue(fi.ValueOf(e.Name))
+	}
+	return terraformWriter.LiteralProperty("aws_placement_group", fi.ValueOf(e.Name), "name")
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestPlacementGroupCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(strategy string, partitionCount *int64) map[string]fi.CloudupTask {
+		pg1 := &PlacementGroup{
+			Name:           s("nodes.cluster.example.com"),
+			Lifecycle:      fi.LifecycleSync,
+			Strategy:       s(strategy),
+			PartitionCount: partitionCount,
+			Tags:           map[string]string{"kubernetes.io/cluster/cluster.example.com": "owned"},
+		}
+		return map[string]fi.CloudupTask{
+			"pg1": pg1,
+		}
+	}
+
+	{
+		allTasks := buildTasks(ec2.PlacementStrategyPartition, fi.PtrTo(int64(3)))
+		pg1 := allTasks["pg1"].(*PlacementGroup)
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		if fi.ValueOf(pg1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+		if len(c.PlacementGroups) != 1 {
+			t.Fatalf("Expected exactly one PlacementGroup; found %v", c.PlacementGroups)
+		}
+		actual := c.PlacementGroups[*pg1.ID]
+		if aws.StringValue(actual.Strategy) != ec2.PlacementStrategyPartition || aws.Int64Value(actual.PartitionCount) != 3 {
+			t.Fatalf("Unexpected PlacementGroup: %v", actual)
+		}
+	}
+
+	{
+		allTasks := buildTasks(ec2.PlacementStrategyPartition, fi.PtrTo(int64(3)))
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		// Placement groups cannot be modified
+		allTasks := buildTasks(ec2.PlacementStrategySpread, nil)
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err == nil {
+			t.Fatalf("expected error changing the strategy of a placement group")
+		}
+	}
+}
+
+func TestSharedPlacementGroup(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	existing, err := c.CreatePlacementGroup(&ec2.CreatePlacementGroupInput{
+		GroupName: s("shared-pg"),
+		Strategy:  s(ec2.PlacementStrategyCluster),
+	})
+	if err != nil {
+		t.Fatalf("error creating placement group: %v", err)
+	}
+
+	buildTasks := func(name string) map[string]fi.CloudupTask {
+		pg1 := &PlacementGroup{
+			Name:      s(name),
+			Lifecycle: fi.LifecycleSync,
+			Shared:    fi.PtrTo(true),
+		}
+		return map[string]fi.CloudupTask{
+			"pg1": pg1,
+		}
+	}
+
+	{
+		allTasks := buildTasks("shared-pg")
+		pg1 := allTasks["pg1"].(*PlacementGroup)
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		if fi.ValueOf(pg1.ID) != aws.StringValue(existing.PlacementGroup.GroupId) {
+			t.Fatalf("Expected ID %q, got %q", aws.StringValue(existing.PlacementGroup.GroupId), fi.ValueOf(pg1.ID))
+		}
+		if len(c.PlacementGroups) != 1 {
+			t.Fatalf("Expected exactly one PlacementGroup; found %v", c.PlacementGroups)
+		}
+	}
+
+	{
+		allTasks := buildTasks("shared-pg")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		// Shared placement groups are not created
+		allTasks := buildTasks("missing-pg")
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err == nil {
+			t.Fatalf("expected error using a missing shared placement group")
+		}
+		if len(c.PlacementGroups) != 1 {
+			t.Fatalf("Expected exactly one PlacementGroup; found %v", c.PlacementGroups)
+		}
+	}
+}
+
+func TestPlacementGroupTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &PlacementGroup{
+				Name:           fi.PtrTo("nodes.example.com"),
+				Strategy:       fi.PtrTo("partition"),
+				PartitionCount: fi.PtrTo(int64(3)),
+				Tags: map[string]string{
+					"KubernetesCluster": "example.com",
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_placement_group" "nodes-example-com" {
+  name            = "nodes.example.com"
+  partition_count = 3
+  strategy        = "partition"
+  tags = {
+    "KubernetesCluster" = "example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
@@ -184,6 +184,9 @@ func (s *Route) CheckChanges(a, e, changes *Route) error {
 }
 
 func (_ *Route) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Route) error {
+	// Routes are reported as part of their route table
+	defer t.Cloud.FindCache().Invalidate(awsup.FindCacheRouteTables)
+
 	if a == nil {
 		request := &ec2.CreateRouteInput{}
 		request.RouteTableId = checkNotNil(e.RouteTable.ID)
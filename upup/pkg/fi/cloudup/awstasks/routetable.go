@@ -61,9 +61,14 @@ func (e *RouteTable) Find(c *fi.CloudupContext) (*RouteTable, error) {
 		}
 	}
 
+	var vpcID string
+	if e.VPC != nil {
+		vpcID = fi.ValueOf(e.VPC.ID)
+	}
+
 	// Try finding by name
 	if rt == nil && e.Tags["Name"] != "" {
-		rt, err = findRouteTableByFilters(cloud, cloud.BuildFilters(e.Name))
+		rt, err = findRouteTableByFilters(cloud, vpcID, cloud.BuildFilters(e.Name))
 		if err != nil {
 			return nil, err
 		}
@@ -81,7 +86,7 @@ func (e *RouteTable) Find(c *fi.CloudupContext) (*RouteTable, error) {
 			Values: aws.StringSlice([]string{e.Tags[awsup.TagNameKopsRole]}),
 		})
 
-		rt, err = findRouteTableByFilters(cloud, filters)
+		rt, err = findRouteTableByFilters(cloud, vpcID, filters)
 		if err != nil {
 			return nil, err
 		}
@@ -111,38 +116,53 @@ func findRouteTableByID(cloud awsup.AWSCloud, id string) (*ec2.RouteTable, error
 	request := &ec2.DescribeRouteTablesInput{}
 	request.RouteTableIds = []*string{&id}
 
-	response, err := cloud.EC2().DescribeRouteTables(request)
+	routeTables, err := cloud.FindCache().DescribeRouteTables(cloud, request)
 	if err != nil {
 		return nil, fmt.Errorf("error listing RouteTables: %v", err)
 	}
-	if response == nil || len(response.RouteTables) == 0 {
+	if len(routeTables) == 0 {
 		return nil, nil
 	}
 
-	if len(response.RouteTables) != 1 {
+	if len(routeTables) != 1 {
 		return nil, fmt.Errorf("found multiple RouteTables matching ID")
 	}
-	rt := response.RouteTables[0]
+	rt := routeTables[0]
 
 	return rt, nil
 }
 
-func findRouteTableByFilters(cloud awsup.AWSCloud, filters []*ec2.Filter) (*ec2.RouteTable, error) {
+// findRouteTableByFilters finds the route table matching the tag filters.
+// If the VPC is known, the filters are matched against the route tables of the VPC,
+// which the other route tables of the cluster share.
+func findRouteTableByFilters(cloud awsup.AWSCloud, vpcID string, filters []*ec2.Filter) (*ec2.RouteTable, error) {
 	request := &ec2.DescribeRouteTablesInput{}
-	request.Filters = filters
+	if vpcID != "" {
+		request.Filters = []*ec2.Filter{awsup.NewEC2Filter("vpc-id", vpcID)}
+	} else {
+		request.Filters = filters
+	}
 
-	response, err := cloud.EC2().DescribeRouteTables(request)
+	routeTables, err := cloud.FindCache().DescribeRouteTables(cloud, request)
 	if err != nil {
 		return nil, fmt.Errorf("error listing RouteTables: %v", err)
 	}
-	if response == nil || len(response.RouteTables) == 0 {
+
+	var matches []*ec2.RouteTable
+	for _, rt := range routeTables {
+		if vpcID != "" && !matchesTagFilters(rt.Tags, filters) {
+			continue
+		}
+		matches = append(matches, rt)
+	}
+	if len(matches) == 0 {
 		return nil, nil
 	}
 
-	if len(response.RouteTables) != 1 {
+	if len(matches) != 1 {
 		return nil, fmt.Errorf("found multiple RouteTables matching tags")
 	}
-	rt := response.RouteTables[0]
+	rt := matches[0]
 	return rt, nil
 }
 
@@ -165,6 +185,8 @@ func (s *RouteTable) CheckChanges(a, e, changes *RouteTable) error {
 }
 
 func (_ *RouteTable) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *RouteTable) error {
+	defer t.Cloud.FindCache().Invalidate(awsup.FindCacheRouteTables)
+
 	if a == nil {
 		vpcID := e.VPC.ID
 		if vpcID == nil {
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// ScheduledActionPrefix prefixes the names of the scheduled actions managed by kOps.
+// Scheduled actions with other names are left alone.
+const ScheduledActionPrefix = "kops-scheduled-scaling-"
+
+// ScheduledScaling manages the scheduled actions that change the size of an ASG at recurring times.
+// +kops:fitask
+type ScheduledScaling struct {
+	// Name is the name of the task.
+	Name *string
+
+	// Lifecycle is the resource lifecycle.
+	Lifecycle fi.Lifecycle
+
+	AutoscalingGroup *AutoscalingGroup
+
+	// Actions are the scheduled actions of the ASG, sorted by name.
+	// Scheduled actions managed by kOps that are not listed are deleted.
+	Actions []*ScheduledAction
+}
+
+// ScheduledAction is a scheduled action of an ASG.
+type ScheduledAction struct {
+	// Name is the name of the scheduled action, which must start with ScheduledActionPrefix.
+	Name *string
+	// Recurrence is the schedule of the action, in cron format.
+	Recurrence *string
+	// MinSize is the minimum size the ASG is set to, if set.
+	MinSize *int64
+	// MaxSize is the maximum size the ASG is set to, if set.
+	MaxSize *int64
+	// TimeZone is the time zone of the recurrence, if not UTC.
+	TimeZone *string
+}
+
+var _ fi.CloudupHasDependencies = &ScheduledAction{}
+
+func (e *ScheduledAction) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	return nil
+}
+
+// Find is used to discover the scheduled actions of the ASG in the cloud provider.
+func (e *ScheduledScaling) Find(c *fi.CloudupContext) (*ScheduledScaling, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	actual := &ScheduledScaling{
+		Name:             e.Name,
+		Lifecycle:        e.Lifecycle,
+		AutoscalingGroup: &AutoscalingGroup{Name: e.AutoscalingGroup.Name},
+	}
+
+	request := &autoscaling.DescribeScheduledActionsInput{
+		AutoScalingGroupName: e.AutoscalingGroup.Name,
+	}
+	err := cloud.Autoscaling().DescribeScheduledActionsPagesWithContext(ctx, request, func(page *autoscaling.DescribeScheduledActionsOutput, lastPage bool) bool {
+		for _, action := range page.ScheduledUpdateGroupActions {
+			if !strings.HasPrefix(aws.StringValue(action.ScheduledActionName), ScheduledActionPrefix) {
+				continue
+			}
+			actual.Actions = append(actual.Actions, &ScheduledAction{
+				Name:       action.ScheduledActionName,
+				Recurrence: action.Recurrence,
+				MinSize:    action.MinSize,
+				MaxSize:    action.MaxSize,
+				TimeZone:   action.TimeZone,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		if awsup.AWSErrorCode(err) == "ValidationError" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing scheduled actions of ASG %q: %w", aws.StringValue(e.AutoscalingGroup.Name), err)
+	}
+	sort.Sort(OrderScheduledActionsByName(actual.Actions))
+
+	return actual, nil
+}
+
+func (e *ScheduledScaling) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (*ScheduledScaling) CheckChanges(a, e, changes *ScheduledScaling) error {
+	if e.AutoscalingGroup == nil {
+		return field.Required(field.NewPath("AutoscalingGroup"), "")
+	}
+	for _, action := range e.Actions {
+		if !strings.HasPrefix(aws.StringValue(action.Name), ScheduledActionPrefix) {
+			return field.Invalid(field.NewPath("Actions").Child("Name"), aws.StringValue(action.Name), fmt.Sprintf("must start with %q", ScheduledActionPrefix))
+		}
+	}
+	return nil
+}
+
+func (*ScheduledScaling) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *ScheduledScaling) error {
+	ctx := context.TODO()
+	svc := t.Cloud.Autoscaling()
+
+	existing := make(map[string]*ScheduledAction)
+	if a != nil {
+		for _, action := range a.Actions {
+			existing[aws.StringValue(action.Name)] = action
+		}
+	}
+
+	for _, action := range e.Actions {
+		name := aws.StringValue(action.Name)
+		current := existing[name]
+		delete(existing, name)
+		if reflect.DeepEqual(current, action) {
+			continue
+		}
+
+		request := &autoscaling.PutScheduledUpdateGroupActionInput{
+			AutoScalingGroupName: e.AutoscalingGroup.Name,
+			ScheduledActionName:  action.Name,
+			Recurrence:           action.Recurrence,
+			MinSize:              action.MinSize,
+			MaxSize:              action.MaxSize,
+			TimeZone:             action.TimeZone,
+		}
+		if _, err := svc.PutScheduledUpdateGroupActionWithContext(ctx, request); err != nil {
+			if awsup.AWSErrorCode(err) == "ValidationError" && a == nil {
+				return fi.NewTryAgainLaterError("waiting for ASG to become ready").WithError(err)
+			}
+			return fmt.Errorf("error putting scheduled action %q: %w", name, err)
+		}
+	}
+
+	var removed []string
+	for name := range existing {
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		request := &autoscaling.DeleteScheduledActionInput{
+			AutoScalingGroupName: e.AutoscalingGroup.Name,
+			ScheduledActionName:  aws.String(name),
+		}
+		if _, err := svc.DeleteScheduledActionWithContext(ctx, request); err != nil {
+			return fmt.Errorf("error deleting scheduled action %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+type terraformASGSchedule struct {
+	ScheduledActionName  *string                  `cty:"scheduled_action_name"`
+	AutoScalingGroupName *terraformWriter.Literal `cty:"autoscaling_group_name"`
+	Recurrence           *string                  `cty:"recurrence"`
+	MinSize              *int64                   `cty:"min_size"`
+	MaxSize              *int64                   `cty:"max_size"`
+	DesiredCapacity      *int64                   `cty:"desired_capacity"`
+	TimeZone             *string                  `cty:"time_zone"`
+}
+
+func (_ *ScheduledScaling) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *ScheduledScaling) error {
+	// Terraform sets the sizes that are not specified to 0, unless they are -1
+	unchanged := func(v *int64) *int64 {
+		if v == nil {
+			return fi.PtrTo(int64(-1))
+		}
+		return v
+	}
+
+	for _, action := range e.Actions {
+		tf := &terraformASGSchedule{
+			ScheduledActionName:  action.Name,
+			AutoScalingGroupName: e.AutoscalingGroup.TerraformLink(),
+			Recurrence:           action.Recurrence,
+			MinSize:              unchanged(action.MinSize),
+			MaxSize:              unchanged(action.MaxSize),
+			DesiredCapacity:      unchanged(nil),
+			TimeZone:             action.TimeZone,
+		}
+		resourceName := *e.Name + "-" + strings.TrimPrefix(aws.StringValue(action.Name), ScheduledActionPrefix)
+		if err := t.RenderResource("aws_autoscaling_schedule", resourceName, tf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OrderScheduledActionsByName implements sort.Interface for []*ScheduledAction, based on name
+type OrderScheduledActionsByName []*ScheduledAction
+
+func (a OrderScheduledActionsByName) Len() int      { return len(a) }
+func (a OrderScheduledActionsByName) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a OrderScheduledActionsByName) Less(i, j int) bool {
+	return aws.StringValue(a[i].Name) < aws.StringValue(a[j].Name)
+}
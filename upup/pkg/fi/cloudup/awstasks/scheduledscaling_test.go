@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestScheduledScalingReconcile(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = c
+
+	c.CreateAutoScalingGroupWithContext(ctx, &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: s("nodes.cluster.example.com"),
+		MinSize:              aws.Int64(1),
+		MaxSize:              aws.Int64(5),
+	})
+	// Scheduled actions not managed by kOps are left alone
+	c.PutScheduledUpdateGroupActionWithContext(ctx, &autoscaling.PutScheduledUpdateGroupActionInput{
+		AutoScalingGroupName: s("nodes.cluster.example.com"),
+		ScheduledActionName:  s("external"),
+		Recurrence:           s("0 12 * * *"),
+		MinSize:              aws.Int64(3),
+	})
+
+	run := func(actions ...*ScheduledAction) {
+		t.Helper()
+
+		e := &ScheduledScaling{
+			Name:             s("nodes.cluster.example.com"),
+			Lifecycle:        fi.LifecycleSync,
+			AutoscalingGroup: &AutoscalingGroup{Name: s("nodes.cluster.example.com")},
+			Actions:          actions,
+		}
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, map[string]fi.CloudupTask{})
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		a, err := e.Find(context)
+		if err != nil {
+			t.Fatalf("unexpected error during Find: %v", err)
+		}
+		if err := e.RenderAWS(target, a, e, nil); err != nil {
+			t.Fatalf("unexpected error during RenderAWS: %v", err)
+		}
+
+		a, err = e.Find(context)
+		if err != nil {
+			t.Fatalf("unexpected error during Find: %v", err)
+		}
+		if !reflect.DeepEqual(a.Actions, e.Actions) {
+			t.Fatalf("expected actions %v, got %v", e.Actions, a.Actions)
+		}
+	}
+
+	run(
+		&ScheduledAction{Name: s("kops-scheduled-scaling-0"), Recurrence: s("0 20 * * *"), MinSize: aws.Int64(0), MaxSize: aws.Int64(0)},
+		&ScheduledAction{Name: s("kops-scheduled-scaling-1"), Recurrence: s("0 7 * * *"), MinSize: aws.Int64(1), TimeZone: s("Europe/Berlin")},
+	)
+	if len(c.ScheduledActions) != 3 {
+		t.Fatalf("expected 3 scheduled actions, got %v", c.ScheduledActions)
+	}
+
+	run(
+		&ScheduledAction{Name: s("kops-scheduled-scaling-0"), Recurrence: s("0 21 * * *"), MinSize: aws.Int64(0), MaxSize: aws.Int64(0)},
+	)
+	if len(c.ScheduledActions) != 2 {
+		t.Fatalf("expected 2 scheduled actions, got %v", c.ScheduledActions)
+	}
+
+	run()
+	if len(c.ScheduledActions) != 1 {
+		t.Fatalf("expected only the external scheduled action, got %v", c.ScheduledActions)
+	}
+}
+
+func TestScheduledScalingTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &ScheduledScaling{
+				Name:             fi.PtrTo("nodes.example.com"),
+				AutoscalingGroup: &AutoscalingGroup{Name: fi.PtrTo("nodes.example.com")},
+				Actions: []*ScheduledAction{
+					{
+						Name:       fi.PtrTo("kops-scheduled-scaling-0"),
+						Recurrence: fi.PtrTo("0 20 * * MON-FRI"),
+						MinSize:    fi.PtrTo(int64(0)),
+						MaxSize:    fi.PtrTo(int64(0)),
+						TimeZone:   fi.PtrTo("Europe/Berlin"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_schedule" "nodes-example-com-0" {
+  autoscaling_group_name = aws_autoscaling_group.nodes-example-com.id
+  desired_capacity       = -1
+  max_size               = 0
+  min_size               = 0
+  recurrence             = "0 20 * * MON-FRI"
+  scheduled_action_name  = "kops-scheduled-scaling-0"
+  time_zone              = "Europe/Berlin"
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
@@ -18,6 +18,7 @@ package awstasks
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -41,6 +42,10 @@ type SecurityGroup struct {
 
 	RemoveExtraRules []string
 
+	// RemoveDefaultEgress is set if the allow-all egress rule that AWS adds to new security groups
+	// should be revoked, so that only the egress SecurityGroupRules in the task graph allow traffic.
+	RemoveDefaultEgress bool
+
 	// Shared is set if this is a shared security group (one we don't create or own)
 	Shared *bool
 
@@ -86,6 +91,12 @@ func (e *SecurityGroup) Find(c *fi.CloudupContext) (*SecurityGroup, error) {
 
 	actual.RemoveExtraRules = e.RemoveExtraRules
 
+	// Only report a change when the default egress rule still exists and no task manages an identical rule
+	actual.RemoveDefaultEgress = e.RemoveDefaultEgress
+	if e.RemoveDefaultEgress && hasDefaultEgress(sg) && !e.managesDefaultEgress(c) {
+		actual.RemoveDefaultEgress = false
+	}
+
 	// Prevent spurious comparison failures
 	actual.Shared = e.Shared
 	actual.Lifecycle = e.Lifecycle
@@ -100,36 +111,82 @@ func (e *SecurityGroup) findEc2(c *fi.CloudupContext) (*ec2.SecurityGroup, error
 	cloud := c.T.Cloud.(awsup.AWSCloud)
 	request := &ec2.DescribeSecurityGroupsInput{}
 
+	var filters []*ec2.Filter
 	if fi.ValueOf(e.ID) != "" {
 		// Find by ID.
 		request.GroupIds = []*string{e.ID}
 	} else if fi.ValueOf(e.Name) != "" && e.VPC != nil && e.VPC.ID != nil {
-		// Find by filters (name and VPC ID).
-		filters := cloud.BuildFilters(e.Name)
-		filters = append(filters, awsup.NewEC2Filter("vpc-id", *e.VPC.ID))
-		filters = append(filters, awsup.NewEC2Filter("group-name", *e.Name))
-		request.Filters = filters
-
+		// Find by filters (name and VPC ID), matching against the security groups of the VPC,
+		// which the other security groups of the cluster share.
+		request.Filters = []*ec2.Filter{awsup.NewEC2Filter("vpc-id", *e.VPC.ID)}
+		filters = cloud.BuildFilters(e.Name)
 	} else {
 		// No reason to try.
 		return nil, nil
 	}
 
-	response, err := cloud.EC2().DescribeSecurityGroups(request)
+	securityGroups, err := cloud.FindCache().DescribeSecurityGroups(cloud, request)
 	if err != nil {
 		return nil, fmt.Errorf("error listing SecurityGroups: %v", err)
 	}
-	if response == nil || len(response.SecurityGroups) == 0 {
+
+	var matches []*ec2.SecurityGroup
+	for _, sg := range securityGroups {
+		if filters != nil && (aws.StringValue(sg.GroupName) != *e.Name || !matchesTagFilters(sg.Tags, filters)) {
+			continue
+		}
+		matches = append(matches, sg)
+	}
+	if len(matches) == 0 {
 		return nil, nil
 	}
 
-	if len(response.SecurityGroups) != 1 {
+	if len(matches) != 1 {
 		return nil, fmt.Errorf("found multiple SecurityGroups matching tags")
 	}
-	sg := response.SecurityGroups[0]
+	sg := matches[0]
 	return sg, nil
 }
 
+// defaultEgressCIDR is the destination of the egress rule that AWS adds to new security groups.
+const defaultEgressCIDR = "0.0.0.0/0"
+
+// hasDefaultEgress returns true if the security group allows all egress traffic to defaultEgressCIDR.
+func hasDefaultEgress(sg *ec2.SecurityGroup) bool {
+	for _, permission := range sg.IpPermissionsEgress {
+		if aws.StringValue(permission.IpProtocol) != "-1" {
+			continue
+		}
+		for _, ipRange := range permission.IpRanges {
+			if aws.StringValue(ipRange.CidrIp) == defaultEgressCIDR {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// managesDefaultEgress returns true if a SecurityGroupRule task defines the same rule as the default egress rule.
+func (e *SecurityGroup) managesDefaultEgress(c *fi.CloudupContext) bool {
+	defaultEgress := &ec2.SecurityGroupRule{
+		FromPort:   aws.Int64(-1),
+		ToPort:     aws.Int64(-1),
+		IpProtocol: aws.String("-1"),
+		CidrIpv4:   aws.String(defaultEgressCIDR),
+		IsEgress:   aws.Bool(true),
+	}
+	for _, t := range c.AllTasks() {
+		rule, ok := t.(*SecurityGroupRule)
+		if !ok || rule.SecurityGroup != e || !fi.ValueOf(rule.Egress) {
+			continue
+		}
+		if rule.matches(defaultEgress) {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *SecurityGroup) Run(c *fi.CloudupContext) error {
 	return fi.CloudupDefaultDeltaRunMethod(e, c)
 }
@@ -163,6 +220,9 @@ func (_ *SecurityGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Security
 		return nil
 	}
 
+	// Creating the group or revoking its default egress rule changes both listings
+	defer t.Cloud.FindCache().Invalidate(awsup.FindCacheSecurityGroups, awsup.FindCacheSecurityGroupRules)
+
 	if a == nil {
 		klog.V(2).Infof("Creating SecurityGroup with Name:%q VPC:%q", *e.Name, *e.VPC.ID)
 
@@ -181,6 +241,23 @@ func (_ *SecurityGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Security
 		e.ID = response.GroupId
 	}
 
+	if e.RemoveDefaultEgress && (a == nil || !a.RemoveDefaultEgress) {
+		klog.V(2).Infof("Revoking default egress rule of SecurityGroup %q", *e.ID)
+
+		request := &ec2.RevokeSecurityGroupEgressInput{
+			GroupId: e.ID,
+			IpPermissions: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("-1"),
+					IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(defaultEgressCIDR)}},
+				},
+			},
+		}
+		if _, err := t.Cloud.EC2().RevokeSecurityGroupEgress(request); err != nil {
+			return fmt.Errorf("error revoking default egress of SecurityGroup: %v", err)
+		}
+	}
+
 	return t.AddAWSTags(*e.ID, e.Tags)
 }
 
@@ -198,6 +275,8 @@ func (_ *SecurityGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, chan
 		return nil
 	}
 
+	// Terraform always removes the default egress rule, so RemoveDefaultEgress needs no rendering
+
 	tf := &terraformSecurityGroup{
 		Name:        e.Name,
 		VPCID:       e.VPC.TerraformLink(),
@@ -236,6 +315,8 @@ func (d *deleteSecurityGroupRule) Delete(t fi.CloudupTarget) error {
 		return fmt.Errorf("unexpected target type for deletion: %T", t)
 	}
 
+	defer awsTarget.Cloud.FindCache().Invalidate(awsup.FindCacheSecurityGroups, awsup.FindCacheSecurityGroupRules)
+
 	if fi.ValueOf(d.rule.IsEgress) {
 		request := &ec2.RevokeSecurityGroupEgressInput{
 			GroupId:              d.rule.GroupId,
@@ -377,16 +458,33 @@ type RemovalRule interface {
 // ParseRemovalRule parses our removal rule DSL into a RemovalRule
 func ParseRemovalRule(rule string) (RemovalRule, error) {
 	rule = strings.TrimSpace(rule)
-	tokens := strings.Split(rule, "=")
 
 	// Simple little language:
 	//   port=N matches rules that filter (only) by port=N
+	//   port=N:M matches rules that filter by the port range N-M
+	//   protocol=P matches rules for protocol P (e.g. tcp, udp, or -1 for all protocols)
+	//   cidr=C matches rules for the IPv4 or IPv6 CIDR C
+	// Clauses can be combined with commas, e.g. port=22,protocol=tcp,cidr=0.0.0.0/0,
+	// in which case all of them must match.
 	//
 	// Note this language is internal, so isn't required to be stable
 
-	if len(tokens) == 2 {
-		if tokens[0] == "port" {
-			ports := strings.SplitN(tokens[1], ":", 2)
+	var rules []RemovalRule
+	seen := make(map[string]bool)
+	for _, clause := range strings.Split(rule, ",") {
+		tokens := strings.Split(strings.TrimSpace(clause), "=")
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("cannot parse rule %q", rule)
+		}
+		key, value := tokens[0], tokens[1]
+		if seen[key] {
+			return nil, fmt.Errorf("cannot parse rule %q: %s specified more than once", rule, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "port":
+			ports := strings.SplitN(value, ":", 2)
 			fromPort, err := strconv.Atoi(ports[0])
 			if err != nil {
 				return nil, fmt.Errorf("cannot parse rule %q", rule)
@@ -399,15 +497,41 @@ func ParseRemovalRule(rule string) (RemovalRule, error) {
 				}
 			}
 
-			return &PortRemovalRule{
+			rules = append(rules, &PortRemovalRule{
 				FromPort: fromPort,
 				ToPort:   toPort,
-			}, nil
-		} else {
+			})
+		case "protocol":
+			if value == "" || strings.ContainsAny(value, " :") {
+				return nil, fmt.Errorf("cannot parse rule %q", rule)
+			}
+			rules = append(rules, &ProtocolRemovalRule{
+				Protocol: value,
+			})
+		case "cidr":
+			_, cidr, err := net.ParseCIDR(value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse rule %q: %v", rule, err)
+			}
+			rules = append(rules, &CidrRemovalRule{
+				CIDR: cidr.String(),
+			})
+		default:
 			return nil, fmt.Errorf("cannot parse rule %q", rule)
 		}
 	}
-	return nil, fmt.Errorf("cannot parse rule %q", rule)
+
+	// Rules for all protocols don't have ports
+	for _, r := range rules {
+		if protocolRule, ok := r.(*ProtocolRemovalRule); ok && protocolRule.Protocol == "-1" && seen["port"] {
+			return nil, fmt.Errorf("cannot parse rule %q: port cannot be combined with protocol=-1", rule)
+		}
+	}
+
+	if len(rules) == 1 {
+		return rules[0], nil
+	}
+	return &CompositeRemovalRule{Rules: rules}, nil
 }
 
 type PortRemovalRule struct {
@@ -431,3 +555,51 @@ func (r *PortRemovalRule) Matches(permission *ec2.SecurityGroupRule) bool {
 	}
 	return true
 }
+
+type ProtocolRemovalRule struct {
+	Protocol string
+}
+
+var _ RemovalRule = &ProtocolRemovalRule{}
+
+func (r *ProtocolRemovalRule) String() string {
+	return fi.DebugAsJsonString(r)
+}
+
+func (r *ProtocolRemovalRule) Matches(permission *ec2.SecurityGroupRule) bool {
+	return aws.StringValue(permission.IpProtocol) == r.Protocol
+}
+
+type CidrRemovalRule struct {
+	CIDR string
+}
+
+var _ RemovalRule = &CidrRemovalRule{}
+
+func (r *CidrRemovalRule) String() string {
+	return fi.DebugAsJsonString(r)
+}
+
+func (r *CidrRemovalRule) Matches(permission *ec2.SecurityGroupRule) bool {
+	return aws.StringValue(permission.CidrIpv4) == r.CIDR || aws.StringValue(permission.CidrIpv6) == r.CIDR
+}
+
+// CompositeRemovalRule matches a permission only if all of its rules match
+type CompositeRemovalRule struct {
+	Rules []RemovalRule
+}
+
+var _ RemovalRule = &CompositeRemovalRule{}
+
+func (r *CompositeRemovalRule) String() string {
+	return fi.DebugAsJsonString(r)
+}
+
+func (r *CompositeRemovalRule) Matches(permission *ec2.SecurityGroupRule) bool {
+	for _, rule := range r.Rules {
+		if !rule.Matches(permission) {
+			return false
+		}
+	}
+	return true
+}
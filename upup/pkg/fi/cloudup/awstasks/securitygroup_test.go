@@ -17,15 +17,23 @@ limitations under the License.
 package awstasks
 
 import (
+	"bytes"
 	"context"
+	"os"
 	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/timings"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func TestParseRemovalRule(t *testing.T) {
@@ -38,6 +46,37 @@ func TestParseRemovalRule(t *testing.T) {
 	testParsesAsPort(t, "port=443", 443, 443)
 	testParsesAsPort(t, "port=22:23", 22, 23)
 	testParsesAsPort(t, "port=-1", -1, -1)
+
+	testNotParse(t, "port=22,")
+	testNotParse(t, "port=22,port=23")
+	testNotParse(t, "port=22,protocol=")
+	testNotParse(t, "port=22,protocol=-1")
+	testNotParse(t, "protocol=tcp,cidr=0.0.0.0")
+	testNotParse(t, "protocol=tcp;cidr=0.0.0.0/0")
+	testNotParse(t, "cidr=0.0.0.0/0,cidr=::/0")
+	testNotParse(t, "port=22,source=sg-1")
+
+	testParsesAs(t, "protocol=udp", &ProtocolRemovalRule{Protocol: "udp"})
+	testParsesAs(t, "cidr=::/0", &CidrRemovalRule{CIDR: "::/0"})
+	testParsesAs(t, "protocol=-1,cidr=0.0.0.0/0", &CompositeRemovalRule{Rules: []RemovalRule{
+		&ProtocolRemovalRule{Protocol: "-1"},
+		&CidrRemovalRule{CIDR: "0.0.0.0/0"},
+	}})
+	testParsesAs(t, "port=22, protocol=tcp, cidr=10.0.0.0/8", &CompositeRemovalRule{Rules: []RemovalRule{
+		&PortRemovalRule{FromPort: 22, ToPort: 22},
+		&ProtocolRemovalRule{Protocol: "tcp"},
+		&CidrRemovalRule{CIDR: "10.0.0.0/8"},
+	}})
+}
+
+func testParsesAs(t *testing.T, rule string, expected RemovalRule) {
+	r, err := ParseRemovalRule(rule)
+	if err != nil {
+		t.Fatalf("unexpected failure to parse rule %q: %v", rule, err)
+	}
+	if !reflect.DeepEqual(r, expected) {
+		t.Fatalf("unexpected rule for %q, expecting %v, got %v", rule, expected, r)
+	}
 }
 
 func testNotParse(t *testing.T, rule string) {
@@ -91,13 +130,31 @@ func TestPortRemovalRule_Zero(t *testing.T) {
 	testNotMatches(t, r, &ec2.SecurityGroupRule{})
 }
 
-func testMatches(t *testing.T, rule *PortRemovalRule, permission *ec2.SecurityGroupRule) {
+func TestCompositeRemovalRule(t *testing.T) {
+	r, err := ParseRemovalRule("port=53,protocol=udp,cidr=0.0.0.0/0")
+	if err != nil {
+		t.Fatalf("unexpected failure to parse rule: %v", err)
+	}
+	testMatches(t, r, &ec2.SecurityGroupRule{FromPort: aws.Int64(53), ToPort: aws.Int64(53), IpProtocol: aws.String("udp"), CidrIpv4: aws.String("0.0.0.0/0")})
+
+	testNotMatches(t, r, &ec2.SecurityGroupRule{FromPort: aws.Int64(53), ToPort: aws.Int64(53), IpProtocol: aws.String("tcp"), CidrIpv4: aws.String("0.0.0.0/0")})
+	testNotMatches(t, r, &ec2.SecurityGroupRule{FromPort: aws.Int64(53), ToPort: aws.Int64(53), IpProtocol: aws.String("udp"), CidrIpv4: aws.String("10.0.0.0/8")})
+	testNotMatches(t, r, &ec2.SecurityGroupRule{FromPort: aws.Int64(53), ToPort: aws.Int64(53), IpProtocol: aws.String("udp"), CidrIpv6: aws.String("::/0")})
+	testNotMatches(t, r, &ec2.SecurityGroupRule{FromPort: aws.Int64(22), ToPort: aws.Int64(22), IpProtocol: aws.String("udp"), CidrIpv4: aws.String("0.0.0.0/0")})
+	testNotMatches(t, r, &ec2.SecurityGroupRule{})
+
+	r = &CidrRemovalRule{CIDR: "::/0"}
+	testMatches(t, r, &ec2.SecurityGroupRule{IpProtocol: aws.String("-1"), CidrIpv6: aws.String("::/0")})
+	testNotMatches(t, r, &ec2.SecurityGroupRule{IpProtocol: aws.String("-1"), CidrIpv4: aws.String("0.0.0.0/0")})
+}
+
+func testMatches(t *testing.T, rule RemovalRule, permission *ec2.SecurityGroupRule) {
 	if !rule.Matches(permission) {
 		t.Fatalf("rule %q failed to match permission %q", rule, permission)
 	}
 }
 
-func testNotMatches(t *testing.T, rule *PortRemovalRule, permission *ec2.SecurityGroupRule) {
+func testNotMatches(t *testing.T, rule RemovalRule, permission *ec2.SecurityGroupRule) {
 	if rule.Matches(permission) {
 		t.Fatalf("rule %q unexpectedly matched permission %q", rule, permission)
 	}
@@ -146,7 +203,11 @@ func TestSecurityGroupCreate(t *testing.T) {
 			t.Fatalf("error building context: %v", err)
 		}
 
-		if err := context.RunTasks(testRunTasksOptions); err != nil {
+		recorder := timings.NewRecorder()
+		stopRecording := timings.Start(recorder)
+		err = context.RunTasks(testRunTasksOptions)
+		stopRecording()
+		if err != nil {
 			t.Fatalf("unexpected error during Run: %v", err)
 		}
 
@@ -154,6 +215,26 @@ func TestSecurityGroupCreate(t *testing.T) {
 			t.Fatalf("ID not set after create")
 		}
 
+		// Guard against tasks making more calls than they need to
+		expectedCalls := map[string]int64{
+			"CreateSecurityGroup":    1,
+			"CreateVpc":              1,
+			"DescribeSecurityGroups": 1,
+			"DescribeTags":           2,
+			"DescribeVpcs":           2,
+		}
+		if actualCalls := recorder.APICalls("ec2"); !reflect.DeepEqual(actualCalls, expectedCalls) {
+			t.Errorf("unexpected EC2 API calls: expected=%v actual=%v", expectedCalls, actualCalls)
+		}
+
+		recordedTasks := sets.NewString()
+		for _, task := range recorder.Summary().Tasks {
+			recordedTasks.Insert(task.Name)
+		}
+		if !recordedTasks.Equal(sets.NewString("sg1", "vpc1")) {
+			t.Errorf("unexpected task timings: %v", recordedTasks.List())
+		}
+
 		if len(c.SecurityGroups) != 1 {
 			t.Fatalf("Expected exactly one SecurityGroup; found %v", c.SecurityGroups)
 		}
@@ -169,6 +250,12 @@ func TestSecurityGroupCreate(t *testing.T) {
 				},
 			},
 			GroupName: s("sg1"),
+			IpPermissionsEgress: []*ec2.IpPermission{
+				{
+					IpProtocol: s("-1"),
+					IpRanges:   []*ec2.IpRange{{CidrIp: s("0.0.0.0/0")}},
+				},
+			},
 		}
 		actual := c.SecurityGroups[*sg1.ID]
 		if !reflect.DeepEqual(actual, expected) {
@@ -181,3 +268,236 @@ func TestSecurityGroupCreate(t *testing.T) {
 		checkNoChanges(t, ctx, cloud, allTasks)
 	}
 }
+
+func TestSecurityGroupRemoveDefaultEgress(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		sg1 := &SecurityGroup{
+			Name:                s("sg1"),
+			Lifecycle:           fi.LifecycleSync,
+			Description:         s("Description"),
+			VPC:                 vpc1,
+			RemoveDefaultEgress: true,
+			Tags:                map[string]string{"Name": "sg1"},
+		}
+		egress := &SecurityGroupRule{
+			Name:          s("egress-https"),
+			Lifecycle:     fi.LifecycleSync,
+			SecurityGroup: sg1,
+			CIDR:          s("0.0.0.0/0"),
+			Protocol:      s("tcp"),
+			FromPort:      aws.Int64(443),
+			ToPort:        aws.Int64(443),
+			Egress:        fi.PtrTo(true),
+		}
+
+		return map[string]fi.CloudupTask{
+			"sg1":          sg1,
+			"vpc1":         vpc1,
+			"egress-https": egress,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		sg1 := allTasks["sg1"].(*SecurityGroup)
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		expected := []*ec2.IpPermission{
+			{
+				FromPort:   aws.Int64(443),
+				ToPort:     aws.Int64(443),
+				IpProtocol: s("tcp"),
+				IpRanges:   []*ec2.IpRange{{CidrIp: s("0.0.0.0/0")}},
+			},
+		}
+		actual := c.SecurityGroups[*sg1.ID].IpPermissionsEgress
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected egress permissions: expected=%v actual=%v", expected, actual)
+		}
+
+		var rules []*ec2.SecurityGroupRule
+		for _, rule := range c.SecurityGroupRules {
+			if aws.StringValue(rule.GroupId) == *sg1.ID {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) != 1 || aws.StringValue(rules[0].IpProtocol) != "tcp" || aws.Int64Value(rules[0].FromPort) != 443 {
+			t.Fatalf("Expected exactly one egress rule for port 443; found %v", rules)
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestSecurityGroupDryRunPlan(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(team string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		sg1 := &SecurityGroup{
+			Name:        s("sg1"),
+			Lifecycle:   fi.LifecycleSync,
+			Description: s("Description"),
+			VPC:         vpc1,
+			Tags:        map[string]string{"Name": "sg1", "team": team},
+		}
+
+		return map[string]fi.CloudupTask{
+			"sg1":  sg1,
+			"vpc1": vpc1,
+		}
+	}
+
+	{
+		plan := dryRunPlan(t, ctx, cloud, buildTasks("a"))
+		expected := `{
+  "changes": [
+    {
+      "type": "SecurityGroup",
+      "name": "sg1",
+      "action": "create",
+      "fields": [
+        {
+          "field": "Description",
+          "after": "Description"
+        },
+        {
+          "field": "VPC",
+          "after": "name:vpc1"
+        },
+        {
+          "field": "RemoveDefaultEgress",
+          "after": "false"
+        },
+        {
+          "field": "Tags",
+          "after": "{Name: sg1, team: a}"
+        }
+      ]
+    },
+    {
+      "type": "VPC",
+      "name": "vpc1",
+      "action": "create",
+      "fields": [
+        {
+          "field": "CIDR",
+          "after": "172.20.0.0/16"
+        },
+        {
+          "field": "Tags",
+          "after": "{Name: vpc1}"
+        }
+      ]
+    }
+  ],
+  "deletions": []
+}
+`
+		if plan != expected {
+			t.Errorf("unexpected plan for create:\n%s", diff.FormatDiff(expected, plan))
+		}
+	}
+
+	{
+		allTasks := buildTasks("a")
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	{
+		plan := dryRunPlan(t, ctx, cloud, buildTasks("b"))
+		expected := `{
+  "changes": [
+    {
+      "type": "SecurityGroup",
+      "name": "sg1",
+      "action": "modify",
+      "fields": [
+        {
+          "field": "Tags",
+          "before": "{Name: sg1, team: a}",
+          "after": "{Name: sg1, team: b}"
+        }
+      ]
+    }
+  ],
+  "deletions": []
+}
+`
+		if plan != expected {
+			t.Errorf("unexpected plan for modify:\n%s", diff.FormatDiff(expected, plan))
+		}
+	}
+}
+
+// dryRunPlan runs the tasks against a dry run target, and returns the planned changes as JSON
+func dryRunPlan(t *testing.T, ctx context.Context, cloud fi.Cloud, allTasks map[string]fi.CloudupTask) string {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			KubernetesVersion: "v1.9.0",
+		},
+	}
+	assetBuilder := assets.NewAssetBuilder(vfs.Context, cluster.Spec.Assets, cluster.Spec.KubernetesVersion, false)
+	target := fi.NewCloudupDryRunTarget(assetBuilder, os.Stderr)
+	context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	if err := context.RunTasks(testRunTasksOptions); err != nil {
+		t.Fatalf("unexpected error during Run: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := target.WritePlan(allTasks, &b, "json"); err != nil {
+		t.Fatalf("error writing plan: %v", err)
+	}
+	return b.String()
+}
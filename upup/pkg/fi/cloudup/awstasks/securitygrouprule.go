@@ -51,6 +51,9 @@ type SecurityGroupRule struct {
 
 	Egress *bool
 
+	// Description explains why the rule exists.
+	Description *string
+
 	Tags map[string]string
 }
 
@@ -72,18 +75,19 @@ func (e *SecurityGroupRule) Find(c *fi.CloudupContext) (*SecurityGroupRule, erro
 		},
 	}
 
-	response, err := cloud.EC2().DescribeSecurityGroupRules(request)
+	// The rules of a group are listed once and shared by all the tasks for that group
+	securityGroupRules, err := cloud.FindCache().DescribeSecurityGroupRules(cloud, request)
 	if err != nil {
 		return nil, fmt.Errorf("error listing SecurityGroup: %v", err)
 	}
 
-	if response == nil || len(response.SecurityGroupRules) == 0 {
+	if len(securityGroupRules) == 0 {
 		return nil, nil
 	}
 
 	var foundRule *ec2.SecurityGroupRule
 
-	for _, rule := range response.SecurityGroupRules {
+	for _, rule := range securityGroupRules {
 		if e.matches(rule) {
 			foundRule = rule
 			break
@@ -99,6 +103,7 @@ func (e *SecurityGroupRule) Find(c *fi.CloudupContext) (*SecurityGroupRule, erro
 			ToPort:        foundRule.ToPort,
 			Protocol:      foundRule.IpProtocol,
 			Egress:        e.Egress,
+			Description:   foundRule.Description,
 
 			Tags: intersectTags(foundRule.Tags, e.Tags),
 		}
@@ -212,6 +217,9 @@ func (_ *SecurityGroupRule) CheckChanges(a, e, changes *SecurityGroupRule) error
 		if e.PrefixList != nil && (e.CIDR != nil || e.IPv6CIDR != nil) {
 			return field.Forbidden(field.NewPath("PrefixList"), "Cannot set PrefixList when CIDR or IPv6CIDR is set")
 		}
+		if e.SourceGroup != nil && (e.CIDR != nil || e.IPv6CIDR != nil || e.PrefixList != nil) {
+			return field.Forbidden(field.NewPath("SourceGroup"), "Cannot set SourceGroup when CIDR, IPv6CIDR or PrefixList is set")
+		}
 	}
 
 	if e.FromPort != nil && e.Protocol == nil {
@@ -224,8 +232,8 @@ func (_ *SecurityGroupRule) CheckChanges(a, e, changes *SecurityGroupRule) error
 	return nil
 }
 
-// Description returns a human readable summary of the security group rule
-func (e *SecurityGroupRule) Description() string {
+// summary returns a human readable summary of the security group rule
+func (e *SecurityGroupRule) summary() string {
 	var description []string
 
 	if e.Protocol != nil {
@@ -262,6 +270,9 @@ func (e *SecurityGroupRule) Description() string {
 func (_ *SecurityGroupRule) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *SecurityGroupRule) error {
 	name := fi.ValueOf(e.Name)
 
+	// Rules are also reported in the permissions of their security group
+	defer t.Cloud.FindCache().Invalidate(awsup.FindCacheSecurityGroups, awsup.FindCacheSecurityGroupRules)
+
 	if a == nil {
 		protocol := e.Protocol
 		if protocol == nil {
@@ -277,31 +288,32 @@ func (_ *SecurityGroupRule) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Secu
 		if e.SourceGroup != nil {
 			ipPermission.UserIdGroupPairs = []*ec2.UserIdGroupPair{
 				{
-					GroupId: e.SourceGroup.ID,
+					GroupId:     e.SourceGroup.ID,
+					Description: e.Description,
 				},
 			}
 		} else if e.IPv6CIDR != nil {
 			IPv6CIDR := e.IPv6CIDR
 			ipPermission.Ipv6Ranges = []*ec2.Ipv6Range{
-				{CidrIpv6: IPv6CIDR},
+				{CidrIpv6: IPv6CIDR, Description: e.Description},
 			}
 		} else if e.CIDR != nil {
 			CIDR := e.CIDR
 			ipPermission.IpRanges = []*ec2.IpRange{
-				{CidrIp: CIDR},
+				{CidrIp: CIDR, Description: e.Description},
 			}
 		} else if e.PrefixList != nil {
 			PrefixList := e.PrefixList
 			ipPermission.PrefixListIds = []*ec2.PrefixListId{
-				{PrefixListId: PrefixList},
+				{PrefixListId: PrefixList, Description: e.Description},
 			}
 		} else {
 			ipPermission.IpRanges = []*ec2.IpRange{
-				{CidrIp: aws.String("0.0.0.0/0")},
+				{CidrIp: aws.String("0.0.0.0/0"), Description: e.Description},
 			}
 		}
 
-		description := e.Description()
+		description := e.summary()
 
 		if fi.ValueOf(e.Egress) {
 			request := &ec2.AuthorizeSecurityGroupEgressInput{
@@ -329,8 +341,39 @@ func (_ *SecurityGroupRule) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Secu
 			}
 		}
 
-	} else if changes.Tags != nil {
-		return t.AddAWSTags(*a.ID, e.Tags)
+	} else {
+		if changes.Description != nil {
+			// Descriptions can be changed in place, without revoking the rule
+			descriptions := []*ec2.SecurityGroupRuleDescription{
+				{
+					SecurityGroupRuleId: a.ID,
+					Description:         e.Description,
+				},
+			}
+			if fi.ValueOf(e.Egress) {
+				klog.V(2).Infof("%s: Calling EC2 UpdateSecurityGroupRuleDescriptionsEgress", name)
+				_, err := t.Cloud.EC2().UpdateSecurityGroupRuleDescriptionsEgress(&ec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+					GroupId:                       e.SecurityGroup.ID,
+					SecurityGroupRuleDescriptions: descriptions,
+				})
+				if err != nil {
+					return fmt.Errorf("error updating SecurityGroupEgress description: %v", err)
+				}
+			} else {
+				klog.V(2).Infof("%s: Calling EC2 UpdateSecurityGroupRuleDescriptionsIngress", name)
+				_, err := t.Cloud.EC2().UpdateSecurityGroupRuleDescriptionsIngress(&ec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+					GroupId:                       e.SecurityGroup.ID,
+					SecurityGroupRuleDescriptions: descriptions,
+				})
+				if err != nil {
+					return fmt.Errorf("error updating SecurityGroupIngress description: %v", err)
+				}
+			}
+		}
+
+		if changes.Tags != nil {
+			return t.AddAWSTags(*a.ID, e.Tags)
+		}
 	}
 
 	// No tags on security group rules (there are tags on the group though)
@@ -351,6 +394,8 @@ type terraformSecurityGroupIngress struct {
 	CIDRBlocks     []string `cty:"cidr_blocks"`
 	IPv6CIDRBlocks []string `cty:"ipv6_cidr_blocks"`
 	PrefixListIDs  []string `cty:"prefix_list_ids"`
+
+	Description *string `cty:"description"`
 }
 
 func (_ *SecurityGroupRule) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *SecurityGroupRule) error {
@@ -360,6 +405,7 @@ func (_ *SecurityGroupRule) RenderTerraform(t *terraform.TerraformTarget, a, e,
 		FromPort:      e.FromPort,
 		ToPort:        e.ToPort,
 		Protocol:      e.Protocol,
+		Description:   e.Description,
 	}
 	if fi.ValueOf(e.Egress) {
 		tf.Type = fi.PtrTo("egress")
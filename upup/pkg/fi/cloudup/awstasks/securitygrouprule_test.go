@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/timings"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestSecurityGroupRuleDescription(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(description string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		sg1 := &SecurityGroup{
+			Name:        s("sg1"),
+			Lifecycle:   fi.LifecycleSync,
+			Description: s("Description"),
+			VPC:         vpc1,
+			Tags:        map[string]string{"Name": "sg1"},
+		}
+		ssh := &SecurityGroupRule{
+			Name:          s("ssh"),
+			Lifecycle:     fi.LifecycleSync,
+			SecurityGroup: sg1,
+			CIDR:          s("10.0.0.0/8"),
+			Protocol:      s("tcp"),
+			FromPort:      aws.Int64(22),
+			ToPort:        aws.Int64(22),
+			Description:   s(description),
+		}
+
+		return map[string]fi.CloudupTask{
+			"sg1":  sg1,
+			"vpc1": vpc1,
+			"ssh":  ssh,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) map[string]int64 {
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		recorder := timings.NewRecorder()
+		stopRecording := timings.Start(recorder)
+		err = context.RunTasks(testRunTasksOptions)
+		stopRecording()
+		if err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+		return recorder.APICalls("ec2")
+	}
+
+	findRule := func() *ec2.SecurityGroupRule {
+		for _, rule := range c.SecurityGroupRules {
+			if aws.Int64Value(rule.FromPort) == 22 && !aws.BoolValue(rule.IsEgress) {
+				return rule
+			}
+		}
+		t.Fatalf("SecurityGroupRule for port 22 not found")
+		return nil
+	}
+
+	{
+		allTasks := buildTasks("SSH from admin CIDR")
+		runTasks(allTasks)
+
+		if actual := aws.StringValue(findRule().Description); actual != "SSH from admin CIDR" {
+			t.Fatalf("unexpected description after create: %q", actual)
+		}
+	}
+
+	{
+		allTasks := buildTasks("SSH from VPN")
+		calls := runTasks(allTasks)
+
+		if calls["UpdateSecurityGroupRuleDescriptionsIngress"] != 1 {
+			t.Errorf("expected description to be updated in place, got EC2 API calls: %v", calls)
+		}
+		if calls["AuthorizeSecurityGroupIngress"] != 0 || calls["RevokeSecurityGroupIngress"] != 0 {
+			t.Errorf("expected rule not to be recreated, got EC2 API calls: %v", calls)
+		}
+		if actual := aws.StringValue(findRule().Description); actual != "SSH from VPN" {
+			t.Fatalf("unexpected description after update: %q", actual)
+		}
+	}
+
+	{
+		allTasks := buildTasks("SSH from VPN")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestSecurityGroupRulePrefixList(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		sg1 := &SecurityGroup{
+			Name:        s("sg1"),
+			Lifecycle:   fi.LifecycleSync,
+			Description: s("Description"),
+			VPC:         vpc1,
+			Tags:        map[string]string{"Name": "sg1"},
+		}
+		ingress := &SecurityGroupRule{
+			Name:          s("ingress"),
+			Lifecycle:     fi.LifecycleSync,
+			SecurityGroup: sg1,
+			Protocol:      s("tcp"),
+			FromPort:      aws.Int64(443),
+			ToPort:        aws.Int64(443),
+		}
+		ingress.SetCidrOrPrefix("pl-12345678")
+		egress := &SecurityGroupRule{
+			Name:          s("egress"),
+			Lifecycle:     fi.LifecycleSync,
+			SecurityGroup: sg1,
+			Egress:        fi.PtrTo(true),
+		}
+		egress.SetCidrOrPrefix("pl-0123456789abcdef0")
+
+		return map[string]fi.CloudupTask{
+			"sg1":     sg1,
+			"vpc1":    vpc1,
+			"ingress": ingress,
+			"egress":  egress,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		prefixLists := make(map[string]bool)
+		for _, rule := range c.SecurityGroupRules {
+			if rule.PrefixListId != nil {
+				prefixLists[aws.StringValue(rule.PrefixListId)] = aws.BoolValue(rule.IsEgress)
+			}
+		}
+		if egress, found := prefixLists["pl-12345678"]; !found || egress {
+			t.Errorf("expected an ingress rule for pl-12345678, got %v", prefixLists)
+		}
+		if egress, found := prefixLists["pl-0123456789abcdef0"]; !found || !egress {
+			t.Errorf("expected an egress rule for pl-0123456789abcdef0, got %v", prefixLists)
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestSecurityGroupRuleCheckChanges(t *testing.T) {
+	sg := &SecurityGroup{Name: s("sg")}
+	grid := []struct {
+		Description string
+		Rule        *SecurityGroupRule
+		ExpectError bool
+	}{
+		{
+			Description: "prefix list",
+			Rule:        &SecurityGroupRule{SecurityGroup: sg, PrefixList: s("pl-12345678")},
+		},
+		{
+			Description: "source group",
+			Rule:        &SecurityGroupRule{SecurityGroup: sg, SourceGroup: sg},
+		},
+		{
+			Description: "prefix list and CIDR",
+			Rule:        &SecurityGroupRule{SecurityGroup: sg, PrefixList: s("pl-12345678"), CIDR: s("10.0.0.0/8")},
+			ExpectError: true,
+		},
+		{
+			Description: "prefix list and source group",
+			Rule:        &SecurityGroupRule{SecurityGroup: sg, PrefixList: s("pl-12345678"), SourceGroup: sg},
+			ExpectError: true,
+		},
+		{
+			Description: "CIDR and source group",
+			Rule:        &SecurityGroupRule{SecurityGroup: sg, CIDR: s("10.0.0.0/8"), SourceGroup: sg},
+			ExpectError: true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			err := (&SecurityGroupRule{}).CheckChanges(nil, g.Rule, g.Rule)
+			if g.ExpectError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !g.ExpectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupRulePrefixListTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &SecurityGroupRule{
+				Name:          s("https-from-office"),
+				SecurityGroup: &SecurityGroup{Name: s("masters.example.com")},
+				Protocol:      s("tcp"),
+				FromPort:      aws.Int64(443),
+				ToPort:        aws.Int64(443),
+				PrefixList:    s("pl-12345678"),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_security_group_rule" "https-from-office" {
+  from_port         = 443
+  prefix_list_ids   = ["pl-12345678"]
+  protocol          = "tcp"
+  security_group_id = aws_security_group.masters-example-com.id
+  to_port           = 443
+  type              = "ingress"
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"k8s.io/kops/cloudmock/aws/mockeventbridge"
+	"k8s.io/kops/cloudmock/aws/mocksqs"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const testNTHQueuePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": ["events.amazonaws.com", "sqs.amazonaws.com"]},
+    "Action": "sqs:SendMessage",
+    "Resource": "arn:aws-test:sqs:us-east-1:123456789012:cluster-example-com-nth"
+  }]
+}`
+
+// TestNodeTerminationHandlerQueueCreate checks that the queue-processor resources
+// (SQS queue, EventBridge rule and target) are created, tagged and converge.
+func TestNodeTerminationHandlerQueueCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	sqsMock := &mocksqs.MockSQS{}
+	cloud.MockSQS = sqsMock
+	eventBridgeMock := &mockeventbridge.MockEventBridge{}
+	cloud.MockEventBridge = eventBridgeMock
+
+	ownershipTags := map[string]string{"kubernetes.io/cluster/cluster.example.com": "owned"}
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		queue := &SQS{
+			Name:                   s("cluster-example-com-nth"),
+			Lifecycle:              fi.LifecycleSync,
+			Policy:                 fi.NewStringResource(testNTHQueuePolicy),
+			MessageRetentionPeriod: 300,
+			Tags:                   ownershipTags,
+		}
+		rule := &EventBridgeRule{
+			Name:         s("cluster.example.com-SpotInterruption"),
+			Lifecycle:    fi.LifecycleSync,
+			EventPattern: s(`{"source": ["aws.ec2"],"detail-type": ["EC2 Spot Instance Interruption Warning"]}`),
+			SQSQueue:     queue,
+			Tags:         ownershipTags,
+		}
+		target := &EventBridgeTarget{
+			Name:      s("cluster.example.com-SpotInterruption-Target"),
+			Lifecycle: fi.LifecycleSync,
+			Rule:      rule,
+			SQSQueue:  queue,
+		}
+		return map[string]fi.CloudupTask{
+			"queue":  queue,
+			"rule":   rule,
+			"target": target,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		queue := allTasks["queue"].(*SQS)
+
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		if fi.ValueOf(queue.ARN) == "" {
+			t.Fatalf("ARN not set after create")
+		}
+		if len(sqsMock.Queues) != 1 {
+			t.Fatalf("Expected exactly one SQS queue; found %v", sqsMock.Queues)
+		}
+
+		rule := eventBridgeMock.Rules["cluster.example.com-SpotInterruption"]
+		if rule == nil {
+			t.Fatalf("EventBridge rule not created; found %v", eventBridgeMock.Rules)
+		}
+		tags := eventBridgeMock.TagsByArn[aws.StringValue(rule.Arn)]
+		if len(tags) != 1 || aws.StringValue(tags[0].Key) != "kubernetes.io/cluster/cluster.example.com" || aws.StringValue(tags[0].Value) != "owned" {
+			t.Fatalf("EventBridge rule is not tagged as owned by the cluster: %v", tags)
+		}
+
+		targets := eventBridgeMock.TargetsByRule["cluster.example.com-SpotInterruption"]
+		if len(targets) != 1 || aws.StringValue(targets[0].Arn) != fi.ValueOf(queue.ARN) {
+			t.Fatalf("Expected the EventBridge rule to target the queue %q; found %v", fi.ValueOf(queue.ARN), targets)
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
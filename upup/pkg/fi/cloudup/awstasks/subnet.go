@@ -51,7 +51,10 @@ type Subnet struct {
 	IPv6CIDR                    *string
 	ResourceBasedNaming         *bool
 	AssignIPv6AddressOnCreation *bool
-	Shared                      *bool
+	// MapPublicIPOnLaunch is whether instances launched in the subnet get a public IPv4 address by default.
+	// If nil, the attribute of the subnet is left unchanged.
+	MapPublicIPOnLaunch *bool
+	Shared              *bool
 
 	Tags map[string]string
 }
@@ -108,6 +111,7 @@ func (e *Subnet) Find(c *fi.CloudupContext) (*Subnet, error) {
 	}
 
 	actual.AssignIPv6AddressOnCreation = subnet.AssignIpv6AddressOnCreation
+	actual.MapPublicIPOnLaunch = subnet.MapPublicIpOnLaunch
 
 	actual.ResourceBasedNaming = fi.PtrTo(aws.StringValue(subnet.PrivateDnsNameOptionsOnLaunch.HostnameType) == ec2.HostnameTypeResourceName)
 	if *actual.ResourceBasedNaming {
@@ -146,25 +150,38 @@ func (e *Subnet) findEc2Subnet(c *fi.CloudupContext) (*ec2.Subnet, error) {
 	cloud := c.T.Cloud.(awsup.AWSCloud)
 
 	request := &ec2.DescribeSubnetsInput{}
+	var filters []*ec2.Filter
 	if e.ID != nil {
 		request.SubnetIds = []*string{e.ID}
+	} else if e.VPC != nil && e.VPC.ID != nil {
+		// Match against the subnets of the VPC, which the other subnets of the cluster share
+		request.Filters = []*ec2.Filter{awsup.NewEC2Filter("vpc-id", *e.VPC.ID)}
+		filters = cloud.BuildFilters(e.Name)
 	} else {
 		request.Filters = cloud.BuildFilters(e.Name)
 	}
 
-	response, err := cloud.EC2().DescribeSubnets(request)
+	subnets, err := cloud.FindCache().DescribeSubnets(cloud, request)
 	if err != nil {
 		return nil, fmt.Errorf("error listing Subnets: %v", err)
 	}
-	if response == nil || len(response.Subnets) == 0 {
+
+	var matches []*ec2.Subnet
+	for _, subnet := range subnets {
+		if filters != nil && !matchesTagFilters(subnet.Tags, filters) {
+			continue
+		}
+		matches = append(matches, subnet)
+	}
+	if len(matches) == 0 {
 		return nil, nil
 	}
 
-	if len(response.Subnets) != 1 {
+	if len(matches) != 1 {
 		klog.Fatalf("found multiple Subnets matching tags")
 	}
 
-	subnet := response.Subnets[0]
+	subnet := matches[0]
 	return subnet, nil
 }
 
@@ -226,6 +243,7 @@ func (s *Subnet) CheckChanges(a, e, changes *Subnet) error {
 func (_ *Subnet) ShouldCreate(a, e, changes *Subnet) (bool, error) {
 	if fi.ValueOf(e.Shared) {
 		changes.ResourceBasedNaming = nil
+		changes.MapPublicIPOnLaunch = nil
 		return changes.Tags != nil, nil
 	}
 	return true, nil
@@ -240,6 +258,8 @@ func (_ *Subnet) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Subnet) error {
 		}
 	}
 
+	defer t.Cloud.FindCache().Invalidate(awsup.FindCacheSubnets)
+
 	if strings.HasPrefix(aws.StringValue(e.IPv6CIDR), "/") {
 		vpcIPv6CIDR := e.VPC.IPv6CIDR
 		if vpcIPv6CIDR == nil {
@@ -306,6 +326,17 @@ func (_ *Subnet) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Subnet) error {
 		}
 	}
 
+	if e.MapPublicIPOnLaunch != nil && (a == nil || changes.MapPublicIPOnLaunch != nil) {
+		request := &ec2.ModifySubnetAttributeInput{
+			SubnetId:            e.ID,
+			MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{Value: e.MapPublicIPOnLaunch},
+		}
+		_, err := t.Cloud.EC2().ModifySubnetAttribute(request)
+		if err != nil {
+			return fmt.Errorf("error modifying MapPublicIPOnLaunch: %w", err)
+		}
+	}
+
 	if changes.ResourceBasedNaming != nil {
 		hostnameType := ec2.HostnameTypeIpName
 		if *changes.ResourceBasedNaming {
@@ -381,6 +412,7 @@ type terraformSubnet struct {
 	EnableDNS64                             *bool                    `cty:"enable_dns64"`
 	EnableResourceNameDNSAAAARecordOnLaunch *bool                    `cty:"enable_resource_name_dns_aaaa_record_on_launch"`
 	EnableResourceNameDNSARecordOnLaunch    *bool                    `cty:"enable_resource_name_dns_a_record_on_launch"`
+	MapPublicIPOnLaunch                     *bool                    `cty:"map_public_ip_on_launch"`
 	PrivateDNSHostnameTypeOnLaunch          *string                  `cty:"private_dns_hostname_type_on_launch"`
 	Tags                                    map[string]string        `cty:"tags"`
 }
@@ -426,11 +458,12 @@ func (_ *Subnet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Su
 	}
 
 	tf := &terraformSubnet{
-		VPCID:            e.VPC.TerraformLink(),
-		CIDR:             e.CIDR,
-		IPv6CIDR:         ipv6CIDR,
-		AvailabilityZone: e.AvailabilityZone,
-		Tags:             e.Tags,
+		VPCID:               e.VPC.TerraformLink(),
+		CIDR:                e.CIDR,
+		IPv6CIDR:            ipv6CIDR,
+		AvailabilityZone:    e.AvailabilityZone,
+		MapPublicIPOnLaunch: e.MapPublicIPOnLaunch,
+		Tags:                e.Tags,
 	}
 	if fi.ValueOf(e.CIDR) == "" {
 		tf.EnableDNS64 = fi.PtrTo(true)
@@ -526,6 +559,8 @@ func (d *deleteSubnetIPv6CIDRBlock) Delete(t fi.CloudupTarget) error {
 		return fmt.Errorf("unexpected target type for deletion: %T", t)
 	}
 
+	defer awsTarget.Cloud.FindCache().Invalidate(awsup.FindCacheSubnets)
+
 	request := &ec2.DisassociateSubnetCidrBlockInput{
 		AssociationId: d.associationID,
 	}
@@ -148,6 +148,85 @@ func TestSubnetCreate(t *testing.T) {
 	}
 }
 
+func TestSubnetMapPublicIPOnLaunch(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(mapPublicIPOnLaunch *bool) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		subnet1 := &Subnet{
+			Name:                s("subnet1"),
+			Lifecycle:           fi.LifecycleSync,
+			VPC:                 vpc1,
+			CIDR:                s("172.20.1.0/24"),
+			MapPublicIPOnLaunch: mapPublicIPOnLaunch,
+			Tags:                map[string]string{"Name": "subnet1"},
+		}
+
+		return map[string]fi.CloudupTask{
+			"subnet1": subnet1,
+			"vpc1":    vpc1,
+		}
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) {
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	{
+		allTasks := buildTasks(fi.PtrTo(true))
+		runTasks(allTasks)
+
+		subnet1 := allTasks["subnet1"].(*Subnet)
+		actual := c.FindSubnet(fi.ValueOf(subnet1.ID))
+		if actual == nil {
+			t.Fatalf("Subnet created but then not found")
+		}
+		if !aws.BoolValue(actual.MapPublicIpOnLaunch) {
+			t.Fatalf("Expected MapPublicIpOnLaunch to be set on create: %v", actual)
+		}
+	}
+
+	{
+		// Existing subnets are migrated in place
+		allTasks := buildTasks(fi.PtrTo(false))
+		runTasks(allTasks)
+
+		subnet1 := allTasks["subnet1"].(*Subnet)
+		actual := c.FindSubnet(fi.ValueOf(subnet1.ID))
+		if actual.MapPublicIpOnLaunch == nil || aws.BoolValue(actual.MapPublicIpOnLaunch) {
+			t.Fatalf("Expected MapPublicIpOnLaunch to be disabled: %v", actual)
+		}
+
+		checkNoChanges(t, ctx, cloud, buildTasks(fi.PtrTo(false)))
+	}
+
+	{
+		// The attribute is left alone when not specified
+		checkNoChanges(t, ctx, cloud, buildTasks(nil))
+	}
+}
+
 func TestSubnetCreateIPv6(t *testing.T) {
 	ctx := context.TODO()
 
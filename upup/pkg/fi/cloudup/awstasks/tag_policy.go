@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// The tasks whose tags are subject to the cluster's tag policy.
+// Their resource types must match awsup.TagPolicyResourceTypes.
+var (
+	_ awsup.TagPolicyResource = &AutoscalingGroup{}
+	_ awsup.TagPolicyResource = &ClassicLoadBalancer{}
+	_ awsup.TagPolicyResource = &DHCPOptions{}
+	_ awsup.TagPolicyResource = &EBSVolume{}
+	_ awsup.TagPolicyResource = &EgressOnlyInternetGateway{}
+	_ awsup.TagPolicyResource = &ElasticIP{}
+	_ awsup.TagPolicyResource = &EventBridgeRule{}
+	_ awsup.TagPolicyResource = &IAMInstanceProfile{}
+	_ awsup.TagPolicyResource = &IAMOIDCProvider{}
+	_ awsup.TagPolicyResource = &IAMRole{}
+	_ awsup.TagPolicyResource = &Instance{}
+	_ awsup.TagPolicyResource = &InternetGateway{}
+	_ awsup.TagPolicyResource = &LaunchTemplate{}
+	_ awsup.TagPolicyResource = &NatGateway{}
+	_ awsup.TagPolicyResource = &NetworkLoadBalancer{}
+	_ awsup.TagPolicyResource = &RouteTable{}
+	_ awsup.TagPolicyResource = &SQS{}
+	_ awsup.TagPolicyResource = &SSHKey{}
+	_ awsup.TagPolicyResource = &SecurityGroup{}
+	_ awsup.TagPolicyResource = &SecurityGroupRule{}
+	_ awsup.TagPolicyResource = &Subnet{}
+	_ awsup.TagPolicyResource = &TargetGroup{}
+	_ awsup.TagPolicyResource = &VPC{}
+)
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *AutoscalingGroup) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("AutoscalingGroup", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *ClassicLoadBalancer) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("ClassicLoadBalancer", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *DHCPOptions) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("DHCPOptions", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *EBSVolume) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("EBSVolume", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *EgressOnlyInternetGateway) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("EgressOnlyInternetGateway", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *ElasticIP) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("ElasticIP", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *EventBridgeRule) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("EventBridgeRule", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *IAMInstanceProfile) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("IAMInstanceProfile", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *IAMOIDCProvider) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("IAMOIDCProvider", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *IAMRole) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("IAMRole", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *Instance) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("Instance", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *InternetGateway) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("InternetGateway", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+// The tags of the instances and volumes are filtered separately from the tags of the launch template itself.
+func (e *LaunchTemplate) FilterTags(filter awsup.TagFilter) {
+	instanceTags := filter(awsup.TagPolicyLaunchTemplateInstance, e.instanceTags())
+	volumeTags := filter(awsup.TagPolicyLaunchTemplateVolume, e.volumeTags())
+	e.Tags = filter("LaunchTemplate", e.Tags)
+	e.InstanceTags = instanceTags
+	e.VolumeTags = volumeTags
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *NatGateway) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("NatGateway", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *NetworkLoadBalancer) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("NetworkLoadBalancer", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *RouteTable) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("RouteTable", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *SQS) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("SQS", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *SSHKey) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("SSHKey", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *SecurityGroup) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("SecurityGroup", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *SecurityGroupRule) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("SecurityGroupRule", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *Subnet) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("Subnet", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *TargetGroup) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("TargetGroup", e.Tags)
+}
+
+// FilterTags implements awsup.TagPolicyResource.
+func (e *VPC) FilterTags(filter awsup.TagFilter) {
+	e.Tags = filter("VPC", e.Tags)
+}
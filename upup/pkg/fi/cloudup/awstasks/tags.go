@@ -39,6 +39,20 @@ func mapEC2TagsToMap(tags []*ec2.Tag) map[string]string {
 	return m
 }
 
+func mapToEC2Tags(tags map[string]string) []*ec2.Tag {
+	if tags == nil {
+		return nil
+	}
+	m := make([]*ec2.Tag, 0)
+	for k, v := range tags {
+		m = append(m, &ec2.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return m
+}
+
 func mapIAMTagsToMap(tags []*iam.Tag) map[string]string {
 	if tags == nil {
 		return nil
@@ -111,3 +125,35 @@ func intersectTags(tags []*ec2.Tag, desired map[string]string) map[string]string
 	}
 	return actual
 }
+
+// matchesTagFilters returns true if the tags satisfy all the "tag:" and "tag-key" filters,
+// so that resources from a shared listing can be matched as if the filters had been sent to AWS.
+func matchesTagFilters(tags []*ec2.Tag, filters []*ec2.Filter) bool {
+	for _, filter := range filters {
+		name := aws.StringValue(filter.Name)
+		match := false
+		for _, tag := range tags {
+			var tagValue string
+			switch {
+			case strings.HasPrefix(name, "tag:"):
+				if aws.StringValue(tag.Key) != strings.TrimPrefix(name, "tag:") {
+					continue
+				}
+				tagValue = aws.StringValue(tag.Value)
+			case name == "tag-key":
+				tagValue = aws.StringValue(tag.Key)
+			default:
+				return false
+			}
+			for _, v := range filter.Values {
+				if aws.StringValue(v) == tagValue {
+					match = true
+				}
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/slice"
+)
+
+// +kops:fitask
+type VPCEndpoint struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID  *string
+	VPC *VPC
+
+	// ServiceName is the name of the endpoint service, such as com.amazonaws.us-east-1.s3
+	ServiceName *string
+	// VPCEndpointType is either Gateway or Interface
+	VPCEndpointType *string
+
+	// RouteTables are the route tables a Gateway endpoint is added to
+	RouteTables []*RouteTable
+	// Subnets are the subnets an Interface endpoint creates a network interface in
+	Subnets []*Subnet
+	// SecurityGroups are the security groups of the network interfaces of an Interface endpoint
+	SecurityGroups []*SecurityGroup
+	// PrivateDNSEnabled associates a private hosted zone with an Interface endpoint
+	PrivateDNSEnabled *bool
+
+	// Tags is a map of aws tags that are added to the VPCEndpoint
+	Tags map[string]string
+}
+
+var _ fi.CompareWithID = &VPCEndpoint{}
+
+func (e *VPCEndpoint) CompareWithID() *string {
+	return e.ID
+}
+
+func (e *VPCEndpoint) Find(c *fi.CloudupContext) (*VPCEndpoint, error) {
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	request := &ec2.DescribeVpcEndpointsInput{}
+	if e.ID != nil {
+		request.VpcEndpointIds = []*string{e.ID}
+	} else {
+		request.Filters = cloud.BuildFilters(e.Name)
+		if e.VPC != nil && e.VPC.ID != nil {
+			request.Filters = append(request.Filters, awsup.NewEC2Filter("vpc-id", *e.VPC.ID))
+		}
+	}
+
+	response, err := cloud.EC2().DescribeVpcEndpoints(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPCEndpoints: %v", err)
+	}
+	if response == nil || len(response.VpcEndpoints) == 0 {
+		return nil, nil
+	}
+	if len(response.VpcEndpoints) != 1 {
+		return nil, fmt.Errorf("found multiple VPCEndpoints matching %q", fi.ValueOf(e.Name))
+	}
+	vpce := response.VpcEndpoints[0]
+
+	actual := &VPCEndpoint{
+		ID:              vpce.VpcEndpointId,
+		Name:            findNameTag(vpce.Tags),
+		VPC:             &VPC{ID: vpce.VpcId},
+		ServiceName:     vpce.ServiceName,
+		VPCEndpointType: vpce.VpcEndpointType,
+		Tags:            intersectTags(vpce.Tags, e.Tags),
+	}
+	if aws.StringValue(vpce.VpcEndpointType) == ec2.VpcEndpointTypeInterface {
+		actual.PrivateDNSEnabled = vpce.PrivateDnsEnabled
+	}
+	for _, id := range vpce.RouteTableIds {
+		actual.RouteTables = append(actual.RouteTables, &RouteTable{ID: id})
+	}
+	for _, id := range vpce.SubnetIds {
+		actual.Subnets = append(actual.Subnets, &Subnet{ID: id})
+	}
+	for _, group := range vpce.Groups {
+		actual.SecurityGroups = append(actual.SecurityGroups, &SecurityGroup{ID: group.GroupId})
+	}
+
+	klog.V(2).Infof("found matching VPCEndpoint %q", *actual.ID)
+
+	// Prevent spurious changes when only the order differs
+	if utils.StringSlicesEqualIgnoreOrder(routeTableIDs(actual.RouteTables), routeTableIDs(e.RouteTables)) {
+		actual.RouteTables = e.RouteTables
+	}
+	if utils.StringSlicesEqualIgnoreOrder(subnetIDs(actual.Subnets), subnetIDs(e.Subnets)) {
+		actual.Subnets = e.Subnets
+	}
+	if utils.StringSlicesEqualIgnoreOrder(securityGroupIDs(actual.SecurityGroups), securityGroupIDs(e.SecurityGroups)) {
+		actual.SecurityGroups = e.SecurityGroups
+	}
+
+	// Prevent spurious comparison failures
+	actual.Lifecycle = e.Lifecycle
+	if e.ID == nil {
+		e.ID = actual.ID
+	}
+
+	return actual, nil
+}
+
+func routeTableIDs(routeTables []*RouteTable) []string {
+	var ids []string
+	for _, rt := range routeTables {
+		ids = append(ids, fi.ValueOf(rt.ID))
+	}
+	return ids
+}
+
+func subnetIDs(subnets []*Subnet) []string {
+	var ids []string
+	for _, subnet := range subnets {
+		ids = append(ids, fi.ValueOf(subnet.ID))
+	}
+	return ids
+}
+
+func securityGroupIDs(securityGroups []*SecurityGroup) []string {
+	var ids []string
+	for _, sg := range securityGroups {
+		ids = append(ids, fi.ValueOf(sg.ID))
+	}
+	return ids
+}
+
+func (e *VPCEndpoint) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (s *VPCEndpoint) CheckChanges(a, e, changes *VPCEndpoint) error {
+	if a == nil {
+		if e.VPC == nil {
+			return fi.RequiredField("VPC")
+		}
+		if fi.ValueOf(e.ServiceName) == "" {
+			return fi.RequiredField("ServiceName")
+		}
+		switch fi.ValueOf(e.VPCEndpointType) {
+		case ec2.VpcEndpointTypeGateway:
+			if len(e.Subnets) != 0 || len(e.SecurityGroups) != 0 {
+				return fmt.Errorf("subnets and security groups are not supported for Gateway VPC endpoints")
+			}
+		case ec2.VpcEndpointTypeInterface:
+			if len(e.RouteTables) != 0 {
+				return fmt.Errorf("route tables are not supported for Interface VPC endpoints")
+			}
+			if len(e.Subnets) == 0 {
+				return fi.RequiredField("Subnets")
+			}
+		default:
+			return fmt.Errorf("unsupported VPC endpoint type %q", fi.ValueOf(e.VPCEndpointType))
+		}
+	}
+
+	if a != nil {
+		if changes.VPC != nil {
+			return fi.CannotChangeField("VPC")
+		}
+		if changes.ServiceName != nil {
+			return fi.CannotChangeField("ServiceName")
+		}
+		if changes.VPCEndpointType != nil {
+			return fi.CannotChangeField("VPCEndpointType")
+		}
+	}
+
+	return nil
+}
+
+func (_ *VPCEndpoint) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *VPCEndpoint) error {
+	if a == nil {
+		klog.V(2).Infof("Creating VPCEndpoint for %q", fi.ValueOf(e.ServiceName))
+
+		request := &ec2.CreateVpcEndpointInput{
+			VpcId:             e.VPC.ID,
+			ServiceName:       e.ServiceName,
+			VpcEndpointType:   e.VPCEndpointType,
+			TagSpecifications: awsup.EC2TagSpecification(ec2.ResourceTypeVpcEndpoint, e.Tags),
+		}
+		for _, rt := range e.RouteTables {
+			request.RouteTableIds = append(request.RouteTableIds, rt.ID)
+		}
+		for _, subnet := range e.Subnets {
+			request.SubnetIds = append(request.SubnetIds, subnet.ID)
+		}
+		for _, sg := range e.SecurityGroups {
+			request.SecurityGroupIds = append(request.SecurityGroupIds, sg.ID)
+		}
+		if fi.ValueOf(e.VPCEndpointType) == ec2.VpcEndpointTypeInterface {
+			request.PrivateDnsEnabled = e.PrivateDNSEnabled
+		}
+
+		response, err := t.Cloud.EC2().CreateVpcEndpoint(request)
+		if err != nil {
+			return fmt.Errorf("error creating VPCEndpoint: %v", err)
+		}
+
+		e.ID = response.VpcEndpoint.VpcEndpointId
+		return nil
+	}
+
+	if changes.RouteTables != nil || changes.Subnets != nil || changes.SecurityGroups != nil || changes.PrivateDNSEnabled != nil {
+		request := &ec2.ModifyVpcEndpointInput{
+			VpcEndpointId: a.ID,
+		}
+		if changes.RouteTables != nil {
+			request.AddRouteTableIds = aws.StringSlice(slice.GetUniqueStrings(routeTableIDs(a.RouteTables), routeTableIDs(e.RouteTables)))
+			request.RemoveRouteTableIds = aws.StringSlice(slice.GetUniqueStrings(routeTableIDs(e.RouteTables), routeTableIDs(a.RouteTables)))
+		}
+		if changes.Subnets != nil {
+			request.AddSubnetIds = aws.StringSlice(slice.GetUniqueStrings(subnetIDs(a.Subnets), subnetIDs(e.Subnets)))
+			request.RemoveSubnetIds = aws.StringSlice(slice.GetUniqueStrings(subnetIDs(e.Subnets), subnetIDs(a.Subnets)))
+		}
+		if changes.SecurityGroups != nil {
+			request.AddSecurityGroupIds = aws.StringSlice(slice.GetUniqueStrings(securityGroupIDs(a.SecurityGroups), securityGroupIDs(e.SecurityGroups)))
+			request.RemoveSecurityGroupIds = aws.StringSlice(slice.GetUniqueStrings(securityGroupIDs(e.SecurityGroups), securityGroupIDs(a.SecurityGroups)))
+		}
+		if changes.PrivateDNSEnabled != nil {
+			request.PrivateDnsEnabled = e.PrivateDNSEnabled
+		}
+
+		klog.V(2).Infof("Modifying VPCEndpoint %q", fi.ValueOf(a.ID))
+		if _, err := t.Cloud.EC2().ModifyVpcEndpoint(request); err != nil {
+			return fmt.Errorf("error modifying VPCEndpoint %q: %v", fi.ValueOf(a.ID), err)
+		}
+	}
+
+	return t.UpdateTags(*e.ID, e.Tags)
+}
+
+type terraformVPCEndpoint struct {
+	VPCID             *terraformWriter.Literal   `cty:"vpc_id"`
+	ServiceName       *string                    `cty:"service_name"`
+	VPCEndpointType   *string                    `cty:"vpc_endpoint_type"`
+	RouteTableIDs     []*terraformWriter.Literal `cty:"route_table_ids"`
+	SubnetIDs         []*terraformWriter.Literal `cty:"subnet_ids"`
+	SecurityGroupIDs  []*terraformWriter.Literal `cty:"security_group_ids"`
+	PrivateDNSEnabled *bool                      `cty:"private_dns_enabled"`
+	Tags              map[string]string          `cty:"tags"`
+}
+
+func (_ *VPCEndpoint) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *VPCEndpoint) error {
+	tf := &terraformVPCEndpoint{
+		VPCID:           e.VPC.TerraformLink(),
+		ServiceName:     e.ServiceName,
+		VPCEndpointType: e.VPCEndpointType,
+		Tags:            e.Tags,
+	}
+	for _, rt := range e.RouteTables {
+		tf.RouteTableIDs = append(tf.RouteTableIDs, rt.TerraformLink())
+	}
+	terraformWriter.SortLiterals(tf.RouteTableIDs)
+	for _, subnet := range e.Subnets {
+		tf.SubnetIDs = append(tf.SubnetIDs, subnet.TerraformLink())
+	}
+	terraformWriter.SortLiterals(tf.SubnetIDs)
+	for _, sg := range e.SecurityGroups {
+		tf.SecurityGroupIDs = append(tf.SecurityGroupIDs, sg.TerraformLink())
+	}
+	terraformWriter.SortLiterals(tf.SecurityGroupIDs)
+	if fi.ValueOf(e.VPCEndpointType) == ec2.VpcEndpointTypeInterface {
+		tf.PrivateDNSEnabled = e.PrivateDNSEnabled
+	}
+
+	return t.RenderResource("aws_vpc_endpoint", *e.Name, tf)
+}
+
+func (e *VPCEndpoint) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("aws_vpc_endpoint", *e.Name, "id")
+}
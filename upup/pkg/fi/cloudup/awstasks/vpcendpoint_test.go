@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestVPCEndpointGatewayCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(routeTableNames ...string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		vpce1 := &VPCEndpoint{
+			Name:            s("vpce1"),
+			Lifecycle:       fi.LifecycleSync,
+			VPC:             vpc1,
+			ServiceName:     s("com.amazonaws.us-east-1.s3"),
+			VPCEndpointType: s(ec2.VpcEndpointTypeGateway),
+			Tags:            map[string]string{"Name": "vpce1"},
+		}
+
+		allTasks := map[string]fi.CloudupTask{
+			"vpc1":  vpc1,
+			"vpce1": vpce1,
+		}
+		for _, name := range routeTableNames {
+			rt := &RouteTable{
+				Name:      s(name),
+				Lifecycle: fi.LifecycleSync,
+				VPC:       vpc1,
+				Tags:      map[string]string{"Name": name},
+			}
+			vpce1.RouteTables = append(vpce1.RouteTables, rt)
+			allTasks[name] = rt
+		}
+		return allTasks
+	}
+
+	runTasks := func(allTasks map[string]fi.CloudupTask) {
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+	}
+
+	findEndpoint := func() *ec2.VpcEndpoint {
+		response, err := c.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{})
+		if err != nil {
+			t.Fatalf("error listing VPC endpoints: %v", err)
+		}
+		if len(response.VpcEndpoints) != 1 {
+			t.Fatalf("Expected exactly one VpcEndpoint; found %v", response.VpcEndpoints)
+		}
+		return response.VpcEndpoints[0]
+	}
+
+	{
+		allTasks := buildTasks("rt1")
+		vpce1 := allTasks["vpce1"].(*VPCEndpoint)
+
+		runTasks(allTasks)
+
+		if fi.ValueOf(vpce1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+
+		expected := &ec2.VpcEndpoint{
+			VpcEndpointId:     aws.String("vpce-1"),
+			VpcId:             aws.String("vpc-1"),
+			ServiceName:       aws.String("com.amazonaws.us-east-1.s3"),
+			VpcEndpointType:   aws.String(ec2.VpcEndpointTypeGateway),
+			RouteTableIds:     aws.StringSlice([]string{"rtb-1"}),
+			PrivateDnsEnabled: aws.Bool(false),
+			State:             aws.String("available"),
+			Tags: buildTags(map[string]string{
+				"Name": "vpce1",
+			}),
+		}
+		actual := findEndpoint()
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected VpcEndpoint: expected=%v actual=%v", expected, actual)
+		}
+	}
+
+	{
+		allTasks := buildTasks("rt1")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		runTasks(buildTasks("rt1", "rt2"))
+
+		actual := aws.StringValueSlice(findEndpoint().RouteTableIds)
+		sort.Strings(actual)
+		expected := []string{"rtb-1", "rtb-2"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected route tables: expected=%v actual=%v", expected, actual)
+		}
+	}
+
+	{
+		allTasks := buildTasks("rt2", "rt1")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestVPCEndpointCheckChanges(t *testing.T) {
+	grid := []struct {
+		Name    string
+		E       *VPCEndpoint
+		Success bool
+	}{
+		{
+			Name: "gateway with route tables",
+			E: &VPCEndpoint{
+				VPC:             &VPC{},
+				ServiceName:     s("com.amazonaws.us-east-1.s3"),
+				VPCEndpointType: s(ec2.VpcEndpointTypeGateway),
+				RouteTables:     []*RouteTable{{}},
+			},
+			Success: true,
+		},
+		{
+			Name: "gateway with subnets",
+			E: &VPCEndpoint{
+				VPC:             &VPC{},
+				ServiceName:     s("com.amazonaws.us-east-1.s3"),
+				VPCEndpointType: s(ec2.VpcEndpointTypeGateway),
+				Subnets:         []*Subnet{{}},
+			},
+		},
+		{
+			Name: "interface with subnets",
+			E: &VPCEndpoint{
+				VPC:             &VPC{},
+				ServiceName:     s("com.amazonaws.us-east-1.ecr.api"),
+				VPCEndpointType: s(ec2.VpcEndpointTypeInterface),
+				Subnets:         []*Subnet{{}},
+			},
+			Success: true,
+		},
+		{
+			Name: "interface without subnets",
+			E: &VPCEndpoint{
+				VPC:             &VPC{},
+				ServiceName:     s("com.amazonaws.us-east-1.ecr.api"),
+				VPCEndpointType: s(ec2.VpcEndpointTypeInterface),
+			},
+		},
+		{
+			Name: "unknown type",
+			E: &VPCEndpoint{
+				VPC:             &VPC{},
+				ServiceName:     s("com.amazonaws.us-east-1.ecr.api"),
+				VPCEndpointType: s("GatewayLoadBalancer"),
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			err := (&VPCEndpoint{}).CheckChanges(nil, g.E, g.E)
+			if g.Success && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !g.Success && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -40,6 +40,8 @@ type WarmPool struct {
 	MaxSize *int64
 	// MinSize is the smallest number of nodes in the warm pool.
 	MinSize int64
+	// ReuseOnScaleIn returns instances to the warm pool on scale in, instead of terminating them.
+	ReuseOnScaleIn *bool
 
 	AutoscalingGroup *AutoscalingGroup
 }
@@ -74,6 +76,10 @@ func (e *WarmPool) Find(c *fi.CloudupContext) (*WarmPool, error) {
 		AutoscalingGroup: &AutoscalingGroup{Name: e.AutoscalingGroup.Name},
 		MaxSize:          warmPool.WarmPoolConfiguration.MaxGroupPreparedCapacity,
 		MinSize:          fi.ValueOf(warmPool.WarmPoolConfiguration.MinSize),
+		ReuseOnScaleIn:   fi.PtrTo(false),
+	}
+	if policy := warmPool.WarmPoolConfiguration.InstanceReusePolicy; policy != nil {
+		actual.ReuseOnScaleIn = fi.PtrTo(fi.ValueOf(policy.ReuseOnScaleIn))
 	}
 	return actual, nil
 }
@@ -101,6 +107,11 @@ func (*WarmPool) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *WarmPool) error
 				MaxGroupPreparedCapacity: maxSize,
 				MinSize:                  fi.PtrTo(minSize),
 			}
+			if e.ReuseOnScaleIn != nil {
+				request.InstanceReusePolicy = &autoscaling.InstanceReusePolicy{
+					ReuseOnScaleIn: e.ReuseOnScaleIn,
+				}
+			}
 
 			_, err := svc.PutWarmPoolWithContext(ctx, request)
 			if err != nil {
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestWarmPoolReuseOnScaleIn(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	mockEC2 := &mockec2.MockEC2{}
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-12345678"),
+		Name:           aws.String("k8s-1.4-debian-jessie-amd64-hvm-ebs-2016-10-21"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+	})
+	cloud.MockEC2 = mockEC2
+	mockAutoscaling := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = mockAutoscaling
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(reuseOnScaleIn bool) map[string]fi.CloudupTask {
+		lt := &LaunchTemplate{
+			Name:         s("nodes"),
+			Lifecycle:    fi.LifecycleSync,
+			ImageID:      s("ami-12345678"),
+			InstanceType: s("t3.medium"),
+		}
+		asg := &AutoscalingGroup{
+			Name:                s("nodes"),
+			Lifecycle:           fi.LifecycleSync,
+			LaunchTemplate:      lt,
+			Granularity:         s("1Minute"),
+			Metrics:             []string{},
+			SuspendProcesses:    &[]string{},
+			InstanceProtection:  aws.Bool(false),
+			CapacityRebalance:   aws.Bool(false),
+			MinSize:             aws.Int64(1),
+			MaxSize:             aws.Int64(3),
+			MaxInstanceLifetime: aws.Int64(0),
+			Tags:                map[string]string{},
+		}
+		warmPool := &WarmPool{
+			Name:             s("nodes"),
+			Lifecycle:        fi.LifecycleSync,
+			Enabled:          aws.Bool(true),
+			MinSize:          1,
+			MaxSize:          aws.Int64(5),
+			ReuseOnScaleIn:   aws.Bool(reuseOnScaleIn),
+			AutoscalingGroup: asg,
+		}
+		asg.WarmPool = warmPool
+		return map[string]fi.CloudupTask{
+			"nodes/lt":       lt,
+			"nodes/asg":      asg,
+			"nodes/warmpool": warmPool,
+		}
+	}
+
+	for _, reuseOnScaleIn := range []bool{true, false} {
+		allTasks := buildTasks(reuseOnScaleIn)
+		target := &awsup.AWSAPITarget{
+			Cloud: cloud,
+		}
+		context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, allTasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(testRunTasksOptions); err != nil {
+			t.Fatalf("unexpected error during Run: %v", err)
+		}
+
+		config := mockAutoscaling.WarmPools["nodes"]
+		if config == nil {
+			t.Fatalf("expected the warm pool to exist")
+		}
+		if aws.Int64Value(config.MinSize) != 1 || aws.Int64Value(config.MaxGroupPreparedCapacity) != 5 {
+			t.Errorf("unexpected warm pool size: %v", config)
+		}
+		if config.InstanceReusePolicy == nil || aws.BoolValue(config.InstanceReusePolicy.ReuseOnScaleIn) != reuseOnScaleIn {
+			t.Errorf("expected reuseOnScaleIn=%v, got %v", reuseOnScaleIn, config.InstanceReusePolicy)
+		}
+
+		// Find must report what we applied, so that there is no drift on the next update
+		tasks := buildTasks(reuseOnScaleIn)
+		context, err = fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, tasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		expected := tasks["nodes/warmpool"].(*WarmPool)
+		actual, err := expected.Find(context)
+		if err != nil {
+			t.Fatalf("unexpected error during Find: %v", err)
+		}
+		changes := &WarmPool{}
+		if changed := fi.BuildChanges(actual, expected, changes); changed {
+			t.Errorf("expected no changes for reuseOnScaleIn=%v, got %+v", reuseOnScaleIn, changes)
+		}
+	}
+
+	// Drift made outside of kops is detected
+	mockAutoscaling.WarmPools["nodes"].InstanceReusePolicy = nil
+	tasks := buildTasks(true)
+	context, err := fi.NewCloudupContext(ctx, &awsup.AWSAPITarget{Cloud: cloud}, nil, cloud, nil, nil, nil, tasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	expected := tasks["nodes/warmpool"].(*WarmPool)
+	actual, err := expected.Find(context)
+	if err != nil {
+		t.Fatalf("unexpected error during Find: %v", err)
+	}
+	changes := &WarmPool{}
+	if changed := fi.BuildChanges(actual, expected, changes); !changed || !aws.BoolValue(changes.ReuseOnScaleIn) {
+		t.Errorf("expected a change to reuseOnScaleIn, got %+v", changes)
+	}
+}
@@ -22,19 +22,22 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/bootstrap"
 )
 
 const AWSAuthenticationTokenPrefix = "x-aws-sts "
 
 type awsAuthenticator struct {
-	sts *sts.STS
+	sts      *sts.STS
+	metadata *ec2metadata.EC2Metadata
 }
 
 var _ bootstrap.Authenticator = &awsAuthenticator{}
@@ -67,7 +70,8 @@ func NewAWSAuthenticator(region string) (bootstrap.Authenticator, error) {
 		return nil, err
 	}
 	return &awsAuthenticator{
-		sts: sts.New(sess, config),
+		sts:      sts.New(sess, config),
+		metadata: ec2metadata.New(sess, config),
 	}, nil
 }
 
@@ -83,6 +87,15 @@ func (a *awsAuthenticator) CreateToken(body []byte) (string, error) {
 		return "", err
 	}
 
+	// Pass along the signed instance identity document, so the verifier can check it without calling EC2.
+	// This header is not part of the STS signature; the verifier removes it before calling STS.
+	signature, err := a.metadata.GetDynamicData("instance-identity/rsa2048")
+	if err != nil {
+		klog.Warningf("unable to get instance identity document from metadata: %v", err)
+	} else {
+		stsRequest.HTTPRequest.Header.Set(identityDocumentHeader, strings.ReplaceAll(signature, "\n", ""))
+	}
+
 	headers, _ := json.Marshal(stsRequest.HTTPRequest.Header)
 	return AWSAuthenticationTokenPrefix + base64.StdEncoding.EncodeToString(headers), nil
 }
@@ -27,6 +27,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/eventbridge"
 	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"golang.org/x/sync/errgroup"
@@ -66,6 +68,7 @@ import (
 	"k8s.io/kops/pkg/featureflag"
 	identity_aws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/pkg/resources/spotinst"
+	"k8s.io/kops/pkg/timings"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
@@ -136,6 +139,7 @@ type AWSCloud interface {
 	SQS() sqsiface.SQSAPI
 	EventBridge() eventbridgeiface.EventBridgeAPI
 	SSM() ssmiface.SSMAPI
+	ResourceGroupsTagging() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 
 	// TODO: Document and rationalize these tags/filters methods
 	AddTags(name *string, tags map[string]string)
@@ -190,8 +194,16 @@ type AWSCloud interface {
 	// DescribeInstanceType calls ec2.DescribeInstanceType to get information for a particular instance type
 	DescribeInstanceType(instanceType string) (*ec2.InstanceTypeInfo, error)
 
+	// InstanceTypesOfferedInZone returns the instance types offered in an availability zone or Local Zone
+	InstanceTypesOfferedInZone(zone string) (sets.Set[string], error)
+
 	// AccountInfo returns the AWS account ID and AWS partition that we are deploying into
 	AccountInfo() (string, string, error)
+
+	// FindCache returns the cache of listings shared by the tasks of the run in progress, or nil if there is none
+	FindCache() *FindCache
+	// StartFindCache enables a new FindCache for a run, and returns a function that disables it
+	StartFindCache() func()
 }
 
 type awsCloudImplementation struct {
@@ -206,6 +218,7 @@ type awsCloudImplementation struct {
 	sqs         *sqs.SQS
 	eventbridge *eventbridge.EventBridge
 	ssm         *ssm.SSM
+	tagging     *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
 
 	region string
 
@@ -214,6 +227,8 @@ type awsCloudImplementation struct {
 	regionDelayers *RegionDelayers
 
 	instanceTypes *instanceTypes
+
+	findCache *findCacheHolder
 }
 
 type RegionDelayers struct {
@@ -292,6 +307,7 @@ func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 			instanceTypes: &instanceTypes{
 				typeMap: make(map[string]*ec2.InstanceTypeInfo),
 			},
+			findCache: &findCacheHolder{},
 		}
 
 		config := aws.NewConfig().WithRegion(region)
@@ -425,6 +441,17 @@ func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 		c.ssm.Handlers.Send.PushFront(requestLogger)
 		c.addHandlers(region, &c.ssm.Handlers)
 
+		sess, err = session.NewSessionWithOptions(session.Options{
+			Config:            *config,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return c, err
+		}
+		c.tagging = resourcegroupstaggingapi.New(sess, config)
+		c.tagging.Handlers.Send.PushFront(requestLogger)
+		c.addHandlers(region, &c.tagging.Handlers)
+
 		updateAwsCloudInstances(region, c)
 
 		raw = c
@@ -452,6 +479,15 @@ func (c *awsCloudImplementation) Session() (*session.Session, error) {
 }
 
 func (c *awsCloudImplementation) addHandlers(regionName string, h *request.Handlers) {
+	addTracingHandlers(h)
+
+	h.Send.PushFrontNamed(request.NamedHandler{
+		Name: "kops/timings",
+		Fn: func(r *request.Request) {
+			timings.RecordAPICall(r.ClientInfo.ServiceName, r.Operation.Name)
+		},
+	})
+
 	delayer := c.getCrossRequestRetryDelay(regionName)
 	if delayer != nil {
 		h.Sign.PushFrontNamed(request.NamedHandler{
@@ -1352,6 +1388,14 @@ func (c *awsCloudImplementation) Tags() map[string]string {
 	return tags
 }
 
+func (c *awsCloudImplementation) FindCache() *FindCache {
+	return c.findCache.get()
+}
+
+func (c *awsCloudImplementation) StartFindCache() func() {
+	return c.findCache.start()
+}
+
 func (c *awsCloudImplementation) WithTags(tags map[string]string) AWSCloud {
 	i := &awsCloudImplementation{}
 	*i = *c
@@ -2272,6 +2316,10 @@ func (c *awsCloudImplementation) SSM() ssmiface.SSMAPI {
 	return c.ssm
 }
 
+func (c *awsCloudImplementation) ResourceGroupsTagging() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	return c.tagging
+}
+
 func (c *awsCloudImplementation) FindVPCInfo(vpcID string) (*fi.VPCInfo, error) {
 	return findVPCInfo(c, vpcID)
 }
@@ -2461,6 +2509,29 @@ func (c *awsCloudImplementation) DescribeInstanceType(instanceType string) (*ec2
 	return info, nil
 }
 
+// InstanceTypesOfferedInZone uses the DescribeInstanceTypeOfferings API call to list the instance types offered in an availability zone or Local Zone
+func (c *awsCloudImplementation) InstanceTypesOfferedInZone(zone string) (sets.Set[string], error) {
+	klog.V(4).Infof("listing the instance types offered in zone %q", zone)
+	request := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{
+			NewEC2Filter("location", zone),
+		},
+	}
+
+	instanceTypes := sets.New[string]()
+	err := c.ec2.DescribeInstanceTypeOfferingsPages(request, func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+		for _, offering := range page.InstanceTypeOfferings {
+			instanceTypes.Insert(aws.StringValue(offering.InstanceType))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the instance types offered in zone %q: %w", zone, err)
+	}
+	return instanceTypes, nil
+}
+
 func describeInstanceType(c AWSCloud, instanceType string) (*ec2.InstanceTypeInfo, error) {
 	req := &ec2.DescribeInstanceTypesInput{
 		InstanceTypes: aws.StringSlice([]string{instanceType}),
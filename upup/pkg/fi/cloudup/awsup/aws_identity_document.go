@@ -0,0 +1,420 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"embed"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// identityDocumentHeader carries the base64 encoded PKCS7 signature of the EC2 instance identity document
+// in the bootstrap token. The signature includes the document.
+const identityDocumentHeader = "X-Kops-Identity-PKCS7"
+
+// identityDocumentCertificateFiles holds the certificates AWS publishes for verifying the RSA-2048 PKCS7 signature
+// of instance identity documents, in one file per region named after the region. Each file cites its source.
+//
+//go:embed identitydocument/*.pem
+var identityDocumentCertificateFiles embed.FS
+
+// errIdentityDocumentUnverifiable is returned when there is no certificate to verify the identity document with.
+var errIdentityDocumentUnverifiable = errors.New("no certificate to verify the instance identity document")
+
+var (
+	verifierCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kops_controller_aws_verifier_cache_hits_total",
+		Help: "Number of bootstrap requests verified using a cached instance.",
+	})
+	verifierDescribeInstances = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kops_controller_aws_verifier_describe_instances_total",
+		Help: "Number of DescribeInstances calls made to verify bootstrap requests.",
+	})
+	verifierIdentityDocuments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kops_controller_aws_verifier_identity_documents_total",
+		Help: "Number of instance identity documents checked, by result.",
+	}, []string{"result"})
+)
+
+// RegisterAWSVerifierMetrics registers the metrics of the AWS bootstrap verifier.
+func RegisterAWSVerifierMetrics(registerer prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{verifierCacheHits, verifierDescribeInstances, verifierIdentityDocuments} {
+		if err := registerer.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// instanceIdentityDocument holds the fields of the EC2 instance identity document that we check.
+type instanceIdentityDocument struct {
+	AccountID        string `json:"accountId"`
+	InstanceID       string `json:"instanceId"`
+	Region           string `json:"region"`
+	AvailabilityZone string `json:"availabilityZone"`
+	PrivateIP        string `json:"privateIp"`
+}
+
+// maxPKCS7Size bounds the size of the PKCS7 signature of an instance identity document, which is a few KiB.
+const maxPKCS7Size = 64 * 1024
+
+// maxBERDepth bounds the nesting of BER elements in the PKCS7 signature of an instance identity document.
+const maxBERDepth = 32
+
+// readIdentityDocumentCertificates returns the embedded PEM encoded certificates for verifying instance identity documents,
+// by region. Regions without a certificate, such as China, GovCloud and regions whose certificate has not been added yet,
+// fall back to verifying the instance with DescribeInstances only.
+func readIdentityDocumentCertificates() (map[string]string, error) {
+	entries, err := fs.ReadDir(identityDocumentCertificateFiles, "identitydocument")
+	if err != nil {
+		return nil, err
+	}
+	certificates := make(map[string]string)
+	for _, entry := range entries {
+		data, err := fs.ReadFile(identityDocumentCertificateFiles, path.Join("identitydocument", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		certificates[strings.TrimSuffix(entry.Name(), ".pem")] = string(data)
+	}
+	return certificates, nil
+}
+
+// parseIdentityDocumentCertificates parses the PEM encoded certificates for each region.
+func parseIdentityDocumentCertificates(certificates map[string]string) (map[string]*x509.Certificate, error) {
+	parsed := make(map[string]*x509.Certificate)
+	for region, data := range certificates {
+		block, _ := pem.Decode([]byte(data))
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data in identity document certificate for region %q", region)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing identity document certificate for region %q: %w", region, err)
+		}
+		parsed[region] = cert
+	}
+	return parsed, nil
+}
+
+// verifyIdentityDocument checks the PKCS7 signature of an instance identity document with the certificate for the region,
+// and that the document belongs to the region and account.
+func verifyIdentityDocument(certificates map[string]*x509.Certificate, region string, accountID string, encodedPKCS7 string) (*instanceIdentityDocument, error) {
+	cert := certificates[region]
+	if cert == nil {
+		return nil, errIdentityDocumentUnverifiable
+	}
+
+	if base64.StdEncoding.DecodedLen(len(encodedPKCS7)) > maxPKCS7Size {
+		return nil, fmt.Errorf("instance identity signature is larger than %d bytes", maxPKCS7Size)
+	}
+	signed, err := base64.StdEncoding.DecodeString(encodedPKCS7)
+	if err != nil {
+		return nil, fmt.Errorf("decoding instance identity signature: %w", err)
+	}
+	document, err := verifyPKCS7(cert, signed)
+	if err != nil {
+		return nil, fmt.Errorf("verifying instance identity signature: %w", err)
+	}
+
+	identity := &instanceIdentityDocument{}
+	if err := json.Unmarshal(document, identity); err != nil {
+		return nil, fmt.Errorf("parsing instance identity document: %w", err)
+	}
+	if identity.Region != region {
+		return nil, fmt.Errorf("instance identity document is for region %q, not %q", identity.Region, region)
+	}
+	if identity.AccountID != accountID {
+		return nil, fmt.Errorf("instance identity document is for account %q", identity.AccountID)
+	}
+	return identity, nil
+}
+
+var (
+	oidPKCS7Data              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidPKCS7SignedData        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidAttributeMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidEncryptionRSA          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidEncryptionSHA256RSA    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+)
+
+// pkcs7DigestAlgorithms are the digest algorithms we accept in PKCS7 signatures.
+var pkcs7DigestAlgorithms = map[string]crypto.Hash{
+	"1.3.14.3.2.26":          crypto.SHA1,
+	"2.16.840.1.101.3.4.2.1": crypto.SHA256,
+}
+
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	// Content is the [0] EXPLICIT wrapper of the content
+	Content asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      pkcs7ContentInfo
+	Certificates     asn1.RawValue     `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue     `asn1:"optional,tag:1"`
+	SignerInfos      []pkcs7SignerInfo `asn1:"set"`
+}
+
+type pkcs7SignerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     asn1.RawValue
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+	UnauthenticatedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type pkcs7Attribute struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+// verifyPKCS7 checks that a PKCS7 SignedData structure is signed by the certificate, returning the signed content.
+func verifyPKCS7(cert *x509.Certificate, data []byte) ([]byte, error) {
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+
+	der, err := berToDER(data)
+	if err != nil {
+		return nil, err
+	}
+	var contentInfo pkcs7ContentInfo
+	if rest, err := asn1.Unmarshal(der, &contentInfo); err != nil {
+		return nil, fmt.Errorf("parsing PKCS7: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after PKCS7")
+	}
+	if !contentInfo.ContentType.Equal(oidPKCS7SignedData) {
+		return nil, fmt.Errorf("unexpected PKCS7 content type %v", contentInfo.ContentType)
+	}
+	var signedData pkcs7SignedData
+	if _, err := asn1.Unmarshal(contentInfo.Content.Bytes, &signedData); err != nil {
+		return nil, fmt.Errorf("parsing PKCS7 signed data: %w", err)
+	}
+	if !signedData.ContentInfo.ContentType.Equal(oidPKCS7Data) {
+		return nil, fmt.Errorf("unexpected PKCS7 signed content type %v", signedData.ContentInfo.ContentType)
+	}
+	var content []byte
+	if _, err := asn1.Unmarshal(signedData.ContentInfo.Content.Bytes, &content); err != nil {
+		return nil, fmt.Errorf("parsing PKCS7 signed content: %w", err)
+	}
+	if len(signedData.SignerInfos) != 1 {
+		return nil, fmt.Errorf("expected one PKCS7 signer, found %d", len(signedData.SignerInfos))
+	}
+	signer := signedData.SignerInfos[0]
+
+	hash, ok := pkcs7DigestAlgorithms[signer.DigestAlgorithm.Algorithm.String()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported PKCS7 digest algorithm %v", signer.DigestAlgorithm.Algorithm)
+	}
+	if alg := signer.DigestEncryptionAlgorithm.Algorithm; !alg.Equal(oidEncryptionRSA) && !alg.Equal(oidEncryptionSHA256RSA) {
+		return nil, fmt.Errorf("unsupported PKCS7 signature algorithm %v", alg)
+	}
+
+	signedBytes := content
+	if len(signer.AuthenticatedAttributes.FullBytes) != 0 {
+		// The attributes are signed instead of the content, and must include the digest of the content.
+		messageDigest, err := pkcs7MessageDigest(signer.AuthenticatedAttributes.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		h := hash.New()
+		h.Write(content)
+		if !bytes.Equal(h.Sum(nil), messageDigest) {
+			return nil, errors.New("PKCS7 message digest does not match content")
+		}
+		// The signature covers the attributes encoded as a SET OF, not with their implicit tag.
+		signedBytes = append([]byte{0x31}, signer.AuthenticatedAttributes.FullBytes[1:]...)
+	}
+
+	h := hash.New()
+	h.Write(signedBytes)
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, h.Sum(nil), signer.EncryptedDigest); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// pkcs7MessageDigest returns the value of the message digest attribute.
+func pkcs7MessageDigest(attributes []byte) ([]byte, error) {
+	for len(attributes) > 0 {
+		var attribute pkcs7Attribute
+		rest, err := asn1.Unmarshal(attributes, &attribute)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PKCS7 attributes: %w", err)
+		}
+		attributes = rest
+		if !attribute.Type.Equal(oidAttributeMessageDigest) {
+			continue
+		}
+		var digest []byte
+		if _, err := asn1.Unmarshal(attribute.Value.Bytes, &digest); err != nil {
+			return nil, fmt.Errorf("parsing PKCS7 message digest: %w", err)
+		}
+		return digest, nil
+	}
+	return nil, errors.New("PKCS7 signed attributes have no message digest")
+}
+
+// berToDER re-encodes the indefinite lengths and constructed octet strings that BER allows, and streaming
+// PKCS7 encoders use, in the DER form that encoding/asn1 requires.
+func berToDER(data []byte) ([]byte, error) {
+	if len(data) > maxPKCS7Size {
+		return nil, fmt.Errorf("PKCS7 is larger than %d bytes", maxPKCS7Size)
+	}
+	der, rest, err := berElementToDER(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after PKCS7")
+	}
+	return der, nil
+}
+
+func berElementToDER(data []byte, depth int) ([]byte, []byte, error) {
+	errTruncated := errors.New("truncated PKCS7")
+
+	if depth > maxBERDepth {
+		return nil, nil, fmt.Errorf("PKCS7 is nested more than %d levels deep", maxBERDepth)
+	}
+
+	i := 1
+	if len(data) < 2 {
+		return nil, nil, errTruncated
+	}
+	if data[0]&0x1f == 0x1f {
+		for ; i < len(data) && data[i]&0x80 != 0; i++ {
+		}
+		i++
+	}
+	if i >= len(data) {
+		return nil, nil, errTruncated
+	}
+	tag := data[:i]
+	constructed := data[0]&0x20 != 0
+
+	lengthByte := data[i]
+	i++
+	var children []byte
+	var rest []byte
+	switch {
+	case lengthByte == 0x80:
+		if !constructed {
+			return nil, nil, errors.New("indefinite length primitive in PKCS7")
+		}
+		rest = data[i:]
+		for {
+			if len(rest) < 2 {
+				return nil, nil, errTruncated
+			}
+			if rest[0] == 0 && rest[1] == 0 {
+				rest = rest[2:]
+				break
+			}
+			child, remaining, err := berElementToDER(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			children = append(children, child...)
+			rest = remaining
+		}
+	default:
+		length := int(lengthByte)
+		if lengthByte&0x80 != 0 {
+			n := int(lengthByte & 0x7f)
+			if n > 4 || i+n > len(data) {
+				return nil, nil, errTruncated
+			}
+			length = 0
+			for _, b := range data[i : i+n] {
+				length = length<<8 | int(b)
+			}
+			i += n
+		}
+		if length < 0 || length > len(data)-i {
+			return nil, nil, errTruncated
+		}
+		children = data[i : i+length]
+		rest = data[i+length:]
+		if constructed {
+			content := children
+			children = nil
+			for len(content) > 0 {
+				child, remaining, err := berElementToDER(content, depth+1)
+				if err != nil {
+					return nil, nil, err
+				}
+				children = append(children, child...)
+				content = remaining
+			}
+		}
+	}
+
+	if tag[0] == 0x24 {
+		// A constructed octet string holds its value in chunks.
+		var value []byte
+		for len(children) > 0 {
+			var chunk []byte
+			remaining, err := asn1.Unmarshal(children, &chunk)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing PKCS7 octet string: %w", err)
+			}
+			value = append(value, chunk...)
+			children = remaining
+		}
+		return derElement([]byte{0x04}, value), rest, nil
+	}
+	return derElement(tag, children), rest, nil
+}
+
+// derElement encodes an element with the definite length form.
+func derElement(tag []byte, content []byte) []byte {
+	out := append([]byte{}, tag...)
+	if n := len(content); n < 0x80 {
+		out = append(out, byte(n))
+	} else {
+		var length []byte
+		for ; n > 0; n >>= 8 {
+			length = append([]byte{byte(n)}, length...)
+		}
+		out = append(out, 0x80|byte(len(length)))
+		out = append(out, length...)
+	}
+	return append(out, content...)
+}
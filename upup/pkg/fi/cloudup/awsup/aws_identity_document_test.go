@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readIdentityDocumentTestData(t *testing.T, name string) string {
+	b, err := os.ReadFile(filepath.Join("testdata", "identitydocument", name))
+	if err != nil {
+		t.Fatalf("error reading test data: %v", err)
+	}
+	return string(b)
+}
+
+func TestVerifyIdentityDocument(t *testing.T) {
+	certificates, err := parseIdentityDocumentCertificates(map[string]string{
+		"us-test-1": readIdentityDocumentTestData(t, "us-test-1.pem"),
+		"us-test-2": readIdentityDocumentTestData(t, "us-test-2.pem"),
+	})
+	if err != nil {
+		t.Fatalf("error parsing certificates: %v", err)
+	}
+
+	grid := []struct {
+		name          string
+		region        string
+		accountID     string
+		signature     string
+		expectedError string
+	}{
+		{
+			name:      "valid",
+			region:    "us-test-1",
+			accountID: "123456789012",
+			signature: "valid.p7",
+		},
+		{
+			name:      "valid with indefinite lengths",
+			region:    "us-test-1",
+			accountID: "123456789012",
+			signature: "valid-ber.p7",
+		},
+		{
+			name:          "tampered",
+			region:        "us-test-1",
+			accountID:     "123456789012",
+			signature:     "tampered.p7",
+			expectedError: "PKCS7 message digest does not match content",
+		},
+		{
+			name:          "wrong region in document",
+			region:        "us-test-1",
+			accountID:     "123456789012",
+			signature:     "wrong-region.p7",
+			expectedError: `is for region "us-test-2"`,
+		},
+		{
+			name:          "wrong region certificate",
+			region:        "us-test-2",
+			accountID:     "123456789012",
+			signature:     "valid.p7",
+			expectedError: "verifying instance identity signature",
+		},
+		{
+			name:          "wrong account",
+			region:        "us-test-1",
+			accountID:     "210987654321",
+			signature:     "valid.p7",
+			expectedError: `is for account "123456789012"`,
+		},
+		{
+			name:          "no certificate",
+			region:        "us-test-3",
+			accountID:     "123456789012",
+			signature:     "valid.p7",
+			expectedError: errIdentityDocumentUnverifiable.Error(),
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			signature := readIdentityDocumentTestData(t, g.signature)
+
+			identity, err := verifyIdentityDocument(certificates, g.region, g.accountID, signature)
+			if g.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", g.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.InstanceID != "i-0123456789abcdef0" || identity.PrivateIP != "172.20.32.10" {
+				t.Errorf("unexpected identity document: %+v", identity)
+			}
+		})
+	}
+}
+
+func TestIdentityDocumentCertificates(t *testing.T) {
+	certificateData, err := readIdentityDocumentCertificates()
+	if err != nil {
+		t.Fatalf("error reading certificates: %v", err)
+	}
+	for region, data := range certificateData {
+		if !strings.Contains(data, "https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/regions-certs.html") {
+			t.Errorf("certificate for region %q does not cite its source", region)
+		}
+	}
+	certificates, err := parseIdentityDocumentCertificates(certificateData)
+	if err != nil {
+		t.Fatalf("error parsing certificates: %v", err)
+	}
+	for region, cert := range certificates {
+		// The AWS certificates are self-signed, which catches any corruption of the embedded data.
+		if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+			t.Errorf("certificate for region %q: %v", region, err)
+		}
+		if _, ok := cert.PublicKey.(*rsa.PublicKey); !ok {
+			t.Errorf("certificate for region %q has unexpected key type %T", region, cert.PublicKey)
+		}
+	}
+	if certificates["us-east-1"] == nil {
+		t.Errorf("expected a certificate for us-east-1")
+	}
+}
+
+func TestBERToDERLimits(t *testing.T) {
+	grid := []struct {
+		name          string
+		data          []byte
+		expectedError string
+	}{
+		{
+			name:          "deeply nested",
+			data:          append(bytes.Repeat([]byte{0x30, 0x80}, 1000), bytes.Repeat([]byte{0x00, 0x00}, 1000)...),
+			expectedError: "nested more than",
+		},
+		{
+			name:          "too large",
+			data:          append([]byte{0x04, 0x83, 0x01, 0x00, 0x00}, make([]byte, 0x10000)...),
+			expectedError: "larger than",
+		},
+		{
+			name: "nested within the limit",
+			data: append(bytes.Repeat([]byte{0x30, 0x80}, maxBERDepth), bytes.Repeat([]byte{0x00, 0x00}, maxBERDepth)...),
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			_, err := berToDER(g.data)
+			if g.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", g.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package awsup
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -106,6 +107,31 @@ func ValidateRegion(region string) error {
 	return fmt.Errorf("Region is not a recognized EC2 region: %q (check you have specified valid zones?)", region)
 }
 
+var (
+	// availabilityZoneRegex matches the names of availability zones, such as "us-east-1a"
+	availabilityZoneRegex = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-[0-9]+[a-z]$`)
+	// localZoneRegex matches the names of Local Zones, such as "us-east-1-bos-1a"; the first group is the parent region
+	localZoneRegex = regexp.MustCompile(`^([a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-[0-9]+)-[a-z]{3}-[0-9]+[a-z]$`)
+)
+
+// IsAvailabilityZone checks if a zone name is the name of an availability zone of a region
+func IsAvailabilityZone(zone string) bool {
+	return availabilityZoneRegex.MatchString(zone)
+}
+
+// IsLocalZone checks if a zone name is the name of a Local Zone, such as "us-east-1-bos-1a"
+func IsLocalZone(zone string) bool {
+	return localZoneRegex.MatchString(zone)
+}
+
+// RegionFromZone returns the region of an availability zone or Local Zone
+func RegionFromZone(zone string) string {
+	if match := localZoneRegex.FindStringSubmatch(zone); match != nil {
+		return match[1]
+	}
+	return zone[:len(zone)-1]
+}
+
 // FindRegion determines the region from the zones specified in the cluster
 func FindRegion(cluster *kops.Cluster) (string, error) {
 	region := ""
@@ -118,7 +144,7 @@ func FindRegion(cluster *kops.Cluster) (string, error) {
 
 		nodeZones[subnet.Zone] = true
 
-		zoneRegion := subnet.Zone[:len(subnet.Zone)-1]
+		zoneRegion := RegionFromZone(subnet.Zone)
 		if region != "" && zoneRegion != region {
 			return "", fmt.Errorf("error Clusters cannot span multiple regions (found zone %q, but region is %q)", subnet.Zone, region)
 		}
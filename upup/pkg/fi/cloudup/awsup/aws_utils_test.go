@@ -66,6 +66,54 @@ func TestFindRegion(t *testing.T) {
 	}
 }
 
+func TestFindRegionLocalZone(t *testing.T) {
+	c := &kops.Cluster{}
+	c.Spec.Networking.Subnets = []kops.ClusterSubnetSpec{
+		{Name: "us-east-1a", Zone: "us-east-1a"},
+		{Name: "us-east-1-bos-1a", Zone: "us-east-1-bos-1a"},
+	}
+
+	region, err := FindRegion(c)
+	if err != nil {
+		t.Fatalf("unexpected error finding region: %v", err)
+	}
+	if region != "us-east-1" {
+		t.Fatalf("unexpected region %q, expected %q", region, "us-east-1")
+	}
+}
+
+func TestZoneNames(t *testing.T) {
+	grid := []struct {
+		zone             string
+		availabilityZone bool
+		localZone        bool
+		region           string
+	}{
+		{zone: "us-east-1a", availabilityZone: true, region: "us-east-1"},
+		{zone: "us-gov-west-1b", availabilityZone: true, region: "us-gov-west-1"},
+		{zone: "ap-southeast-1c", availabilityZone: true, region: "ap-southeast-1"},
+		{zone: "us-east-1-bos-1a", localZone: true, region: "us-east-1"},
+		{zone: "us-west-2-lax-1b", localZone: true, region: "us-west-2"},
+		{zone: "ap-northeast-1-tpe-1a", localZone: true, region: "ap-northeast-1"},
+		{zone: "us-east-1-wl1-bos-wlz-1"},
+		{zone: "us-east-1"},
+	}
+	for _, g := range grid {
+		if actual := IsAvailabilityZone(g.zone); actual != g.availabilityZone {
+			t.Errorf("IsAvailabilityZone(%q): expected %v, got %v", g.zone, g.availabilityZone, actual)
+		}
+		if actual := IsLocalZone(g.zone); actual != g.localZone {
+			t.Errorf("IsLocalZone(%q): expected %v, got %v", g.zone, g.localZone, actual)
+		}
+		if g.region == "" {
+			continue
+		}
+		if actual := RegionFromZone(g.zone); actual != g.region {
+			t.Errorf("RegionFromZone(%q): expected %q, got %q", g.zone, g.region, actual)
+		}
+	}
+}
+
 func TestEC2TagSpecification(t *testing.T) {
 	cases := []struct {
 		Name          string
@@ -20,22 +20,28 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/kops/pkg/bootstrap"
 	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/pkg/wellknownports"
@@ -48,14 +54,32 @@ type AWSVerifierOptions struct {
 	Region string
 }
 
+const (
+	// instanceCacheSize is the number of verified instances we remember.
+	instanceCacheSize = 1024
+	// instanceCacheTTL is how long we remember a verified instance, avoiding repeated DescribeInstances calls.
+	instanceCacheTTL = 10 * time.Minute
+	// instanceBatchWindow is how long we collect verified instances for a single DescribeInstances call.
+	instanceBatchWindow = 100 * time.Millisecond
+	// instanceBatchSize is the most instances we describe in a single DescribeInstances call.
+	instanceBatchSize = 100
+)
+
 type awsVerifier struct {
 	accountId string
 	partition string
 	opt       AWSVerifierOptions
 
-	ec2    *ec2.EC2
+	ec2    ec2iface.EC2API
 	sts    *sts.STS
 	client http.Client
+
+	// certificates verify the instance identity documents, by region
+	certificates map[string]*x509.Certificate
+	// instances caches the results of DescribeInstances, by instance ID
+	instances *cache.LRUExpireCache
+	// batcher describes instances with verified identity documents together
+	batcher *instanceBatcher
 }
 
 var _ bootstrap.Verifier = &awsVerifier{}
@@ -80,6 +104,15 @@ func NewAWSVerifier(opt *AWSVerifierOptions) (bootstrap.Verifier, error) {
 
 	ec2Client := ec2.New(sess, config)
 
+	certificateData, err := readIdentityDocumentCertificates()
+	if err != nil {
+		return nil, err
+	}
+	certificates, err := parseIdentityDocumentCertificates(certificateData)
+	if err != nil {
+		return nil, err
+	}
+
 	return &awsVerifier{
 		accountId: aws.StringValue(identity.Account),
 		partition: partition,
@@ -102,6 +135,9 @@ func NewAWSVerifier(opt *AWSVerifierOptions) (bootstrap.Verifier, error) {
 				return http.ErrUseLastResponse
 			},
 		},
+		certificates: certificates,
+		instances:    cache.NewLRUExpireCache(instanceCacheSize),
+		batcher:      &instanceBatcher{ec2: ec2Client},
 	}, nil
 }
 
@@ -144,6 +180,10 @@ func (a awsVerifier) VerifyToken(ctx context.Context, rawRequest *http.Request,
 		return nil, fmt.Errorf("unmarshalling authorization token: %v", err)
 	}
 
+	// The identity document is not part of the STS request.
+	encodedDocument := stsRequest.HTTPRequest.Header.Get(identityDocumentHeader)
+	stsRequest.HTTPRequest.Header.Del(identityDocumentHeader)
+
 	// Verify the token has signed the body content.
 	sha := sha256.Sum256(body)
 	if stsRequest.HTTPRequest.Header.Get("X-Kops-Request-SHA") != base64.RawStdEncoding.EncodeToString(sha[:]) {
@@ -217,23 +257,53 @@ func (a awsVerifier) VerifyToken(ctx context.Context, rawRequest *http.Request,
 	}
 
 	instanceID := resource[2]
-	instances, err := a.ec2.DescribeInstances(&ec2.DescribeInstancesInput{
-		InstanceIds: aws.StringSlice([]string{instanceID}),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("describing instance for arn %q", arn)
-	}
 
-	if len(instances.Reservations) <= 0 || len(instances.Reservations[0].Instances) <= 0 {
-		return nil, fmt.Errorf("missing instance id: %s", instanceID)
+	verified := false
+	if encodedDocument != "" {
+		identity, err := verifyIdentityDocument(a.certificates, a.opt.Region, a.accountId, encodedDocument)
+		switch {
+		case errors.Is(err, errIdentityDocumentUnverifiable):
+			verifierIdentityDocuments.WithLabelValues("unverifiable").Inc()
+		case err != nil:
+			verifierIdentityDocuments.WithLabelValues("invalid").Inc()
+			return nil, fmt.Errorf("instance identity document for arn %q: %v", arn, err)
+		case identity.InstanceID != instanceID:
+			verifierIdentityDocuments.WithLabelValues("invalid").Inc()
+			return nil, fmt.Errorf("instance identity document is for instance %q, not arn %q", identity.InstanceID, arn)
+		default:
+			verifierIdentityDocuments.WithLabelValues("verified").Inc()
+			verified = true
+		}
 	}
-	if len(instances.Reservations[0].Instances) > 1 {
-		return nil, fmt.Errorf("found multiple instances with instance id: %s", instanceID)
+
+	return a.verifyInstance(instanceID, verified)
+}
+
+// verifyInstance builds the result for an instance, using the instance group membership and addresses
+// from DescribeInstances, which are not covered by the instance identity document.
+// Instances with a verified identity document are described in batches with other instances.
+func (a awsVerifier) verifyInstance(instanceID string, verified bool) (*bootstrap.VerifyResult, error) {
+	if cached, found := a.instances.Get(instanceID); found {
+		verifierCacheHits.Inc()
+		result := *cached.(*bootstrap.VerifyResult)
+		return &result, nil
 	}
 
-	instance := instances.Reservations[0].Instances[0]
+	var instance *ec2.Instance
+	var err error
+	if verified {
+		instance, err = a.batcher.describe(instanceID)
+	} else {
+		// An unverified instance ID may not exist, which would fail the DescribeInstances call for the whole batch.
+		instance, err = describeInstance(a.ec2, instanceID)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	addrs, err := GetInstanceCertificateNames(instances)
+	addrs, err := GetInstanceCertificateNames(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{instance}}},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -273,5 +343,110 @@ func (a awsVerifier) VerifyToken(ctx context.Context, rawRequest *http.Request,
 		}
 	}
 
-	return result, nil
+	a.instances.Add(instanceID, result, instanceCacheTTL)
+	cached := *result
+	return &cached, nil
+}
+
+// describeInstance calls DescribeInstances for a single instance.
+func describeInstance(ec2Client ec2iface.EC2API, instanceID string) (*ec2.Instance, error) {
+	verifierDescribeInstances.Inc()
+	instances, err := ec2Client.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing instance %q: %v", instanceID, err)
+	}
+
+	if len(instances.Reservations) <= 0 || len(instances.Reservations[0].Instances) <= 0 {
+		return nil, fmt.Errorf("missing instance id: %s", instanceID)
+	}
+	if len(instances.Reservations) > 1 || len(instances.Reservations[0].Instances) > 1 {
+		return nil, fmt.Errorf("found multiple instances with instance id: %s", instanceID)
+	}
+	return instances.Reservations[0].Instances[0], nil
+}
+
+// instanceBatcher combines the DescribeInstances calls for instances that bootstrap at about the same time,
+// such as during a large scale up.
+type instanceBatcher struct {
+	ec2 ec2iface.EC2API
+
+	mutex   sync.Mutex
+	pending *instanceBatch
+}
+
+// instanceBatch is a set of instances described by a single DescribeInstances call.
+type instanceBatch struct {
+	instanceIDs []string
+	done        chan struct{}
+
+	instances map[string]*ec2.Instance
+	err       error
+}
+
+// describe adds the instance to the pending batch and waits for the batch to be described.
+func (b *instanceBatcher) describe(instanceID string) (*ec2.Instance, error) {
+	b.mutex.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &instanceBatch{done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(instanceBatchWindow, func() { b.flush(batch) })
+	}
+	if !slices.Contains(batch.instanceIDs, instanceID) {
+		batch.instanceIDs = append(batch.instanceIDs, instanceID)
+	}
+	if len(batch.instanceIDs) >= instanceBatchSize {
+		b.pending = nil
+		go b.run(batch)
+	}
+	b.mutex.Unlock()
+
+	<-batch.done
+	if batch.err != nil {
+		if len(batch.instanceIDs) > 1 {
+			// A single missing instance fails the whole call, so describe this instance on its own.
+			return describeInstance(b.ec2, instanceID)
+		}
+		return nil, batch.err
+	}
+	instance := batch.instances[instanceID]
+	if instance == nil {
+		return nil, fmt.Errorf("missing instance id: %s", instanceID)
+	}
+	return instance, nil
+}
+
+// flush describes the batch, unless it was already started because it is full.
+func (b *instanceBatcher) flush(batch *instanceBatch) {
+	b.mutex.Lock()
+	if b.pending != batch {
+		b.mutex.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mutex.Unlock()
+
+	b.run(batch)
+}
+
+func (b *instanceBatcher) run(batch *instanceBatch) {
+	defer close(batch.done)
+
+	verifierDescribeInstances.Inc()
+	instances, err := b.ec2.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice(batch.instanceIDs),
+	})
+	if err != nil {
+		batch.err = fmt.Errorf("describing instances %v: %v", batch.instanceIDs, err)
+		return
+	}
+
+	batch.instances = make(map[string]*ec2.Instance)
+	for _, reservation := range instances.Reservations {
+		for _, instance := range reservation.Instances {
+			batch.instances[aws.StringValue(instance.InstanceId)] = instance
+		}
+	}
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"k8s.io/apimachinery/pkg/util/cache"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
+)
+
+// countingEC2 returns the requested instances from DescribeInstances, counting the calls.
+type countingEC2 struct {
+	ec2iface.EC2API
+
+	mutex sync.Mutex
+	calls int
+	// missing are instance IDs that fail the call, as EC2 does for instances that do not exist
+	missing []string
+}
+
+func (c *countingEC2) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls++
+
+	reservation := &ec2.Reservation{}
+	for _, instanceID := range aws.StringValueSlice(input.InstanceIds) {
+		for _, missing := range c.missing {
+			if instanceID == missing {
+				return nil, fmt.Errorf("InvalidInstanceID.NotFound: The instance ID '%s' does not exist", instanceID)
+			}
+		}
+		reservation.Instances = append(reservation.Instances, &ec2.Instance{
+			InstanceId:     aws.String(instanceID),
+			PrivateDnsName: aws.String("ip-172-20-32-10.us-test-1.compute.internal"),
+			NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+				{
+					Attachment:       &ec2.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int64(0)},
+					PrivateIpAddress: aws.String("172.20.32.10"),
+				},
+			},
+			Tags: []*ec2.Tag{
+				{Key: aws.String(nodeidentityaws.CloudTagInstanceGroupName), Value: aws.String("nodes")},
+			},
+		})
+	}
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{reservation},
+	}, nil
+}
+
+func TestVerifyInstanceCache(t *testing.T) {
+	ec2Client := &countingEC2{}
+	verifier := awsVerifier{
+		ec2:       ec2Client,
+		instances: cache.NewLRUExpireCache(instanceCacheSize),
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := verifier.verifyInstance("i-0123456789abcdef0", false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NodeName != "i-0123456789abcdef0" || result.InstanceGroupName != "nodes" || result.ChallengeEndpoint != "172.20.32.10:3987" {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		// Changes by the caller must not leak into the cache
+		result.InstanceGroupName = "modified"
+	}
+	if ec2Client.calls != 1 {
+		t.Errorf("expected 1 DescribeInstances call, got %d", ec2Client.calls)
+	}
+
+	if _, err := verifier.verifyInstance("i-0fedcba9876543210", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ec2Client.calls != 2 {
+		t.Errorf("expected 2 DescribeInstances calls, got %d", ec2Client.calls)
+	}
+}
+
+func TestVerifyInstanceBatch(t *testing.T) {
+	grid := []struct {
+		name          string
+		missing       []string
+		expectedCalls int
+		expectedError string
+	}{
+		{
+			name:          "all instances found",
+			expectedCalls: 1,
+		},
+		{
+			name:          "missing instance",
+			missing:       []string{"i-00000000000000002"},
+			expectedCalls: 5,
+			expectedError: "describing instance \"i-00000000000000002\"",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ec2Client := &countingEC2{missing: g.missing}
+			verifier := awsVerifier{
+				ec2:       ec2Client,
+				instances: cache.NewLRUExpireCache(instanceCacheSize),
+				batcher:   &instanceBatcher{ec2: ec2Client},
+			}
+
+			// Instances with verified identity documents bootstrapping together are described in one call.
+			// If an instance is missing, the others are described on their own.
+			errs := make([]error, 4)
+			var wg sync.WaitGroup
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					instanceID := fmt.Sprintf("i-%017d", i)
+					result, err := verifier.verifyInstance(instanceID, true)
+					if err == nil && (result.NodeName != instanceID || result.InstanceGroupName != "nodes") {
+						err = fmt.Errorf("unexpected result: %+v", result)
+					}
+					errs[i] = err
+				}(i)
+			}
+			wg.Wait()
+
+			for i, err := range errs {
+				if g.expectedError != "" && i == 2 {
+					if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+						t.Errorf("expected error containing %q, got %v", g.expectedError, err)
+					}
+				} else if err != nil {
+					t.Errorf("unexpected error for instance %d: %v", i, err)
+				}
+			}
+			if ec2Client.calls != g.expectedCalls {
+				t.Errorf("expected %d DescribeInstances calls, got %d", g.expectedCalls, ec2Client.calls)
+			}
+		})
+	}
+}
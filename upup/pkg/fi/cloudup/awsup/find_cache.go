@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/klog/v2"
+)
+
+// DisableFindCacheEnvVar turns off the find cache when set to any non-empty value, to help debugging.
+const DisableFindCacheEnvVar = "KOPS_AWS_DISABLE_FIND_CACHE"
+
+// Resource types used to invalidate the find cache; a task that mutates a resource
+// invalidates every cached listing of that type.
+const (
+	FindCacheRouteTables        = "RouteTables"
+	FindCacheSecurityGroups     = "SecurityGroups"
+	FindCacheSecurityGroupRules = "SecurityGroupRules"
+	FindCacheSubnets            = "Subnets"
+)
+
+// FindCache is a read-through cache of cloud listings, shared by the tasks of a single run
+// so that tasks issuing an identical Describe call only make it once.
+// Results are shared between callers and must not be modified.
+// A nil FindCache is valid, and calls straight through to the cloud.
+type FindCache struct {
+	mutex   sync.Mutex
+	entries map[string]map[string]*findCacheEntry
+}
+
+type findCacheEntry struct {
+	done   chan struct{}
+	result interface{}
+	err    error
+}
+
+// NewFindCache builds an empty FindCache.
+func NewFindCache() *FindCache {
+	return &FindCache{
+		entries: make(map[string]map[string]*findCacheEntry),
+	}
+}
+
+// get returns the cached result for the key, calling fetch if there is none.
+// Concurrent callers for the same key wait for a single fetch; errors are not cached.
+func (c *FindCache) get(resourceType string, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mutex.Lock()
+	entries := c.entries[resourceType]
+	if entries == nil {
+		entries = make(map[string]*findCacheEntry)
+		c.entries[resourceType] = entries
+	}
+	entry := entries[key]
+	if entry != nil {
+		c.mutex.Unlock()
+		<-entry.done
+		return entry.result, entry.err
+	}
+	entry = &findCacheEntry{done: make(chan struct{})}
+	entries[key] = entry
+	c.mutex.Unlock()
+
+	entry.result, entry.err = fetch()
+	close(entry.done)
+
+	if entry.err != nil {
+		c.mutex.Lock()
+		if c.entries[resourceType][key] == entry {
+			delete(c.entries[resourceType], key)
+		}
+		c.mutex.Unlock()
+	}
+	return entry.result, entry.err
+}
+
+// Invalidate drops the cached listings of a resource type, after it has been mutated.
+func (c *FindCache) Invalidate(resourceTypes ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, resourceType := range resourceTypes {
+		delete(c.entries, resourceType)
+	}
+}
+
+// DescribeSecurityGroups returns the security groups matching the request.
+func (c *FindCache) DescribeSecurityGroups(cloud AWSCloud, request *ec2.DescribeSecurityGroupsInput) ([]*ec2.SecurityGroup, error) {
+	result, err := c.get(FindCacheSecurityGroups, "DescribeSecurityGroups:"+request.String(), func() (interface{}, error) {
+		response, err := cloud.EC2().DescribeSecurityGroups(request)
+		if err != nil {
+			return nil, err
+		}
+		return response.SecurityGroups, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*ec2.SecurityGroup), nil
+}
+
+// DescribeSecurityGroupRules returns the security group rules matching the request.
+func (c *FindCache) DescribeSecurityGroupRules(cloud AWSCloud, request *ec2.DescribeSecurityGroupRulesInput) ([]*ec2.SecurityGroupRule, error) {
+	result, err := c.get(FindCacheSecurityGroupRules, "DescribeSecurityGroupRules:"+request.String(), func() (interface{}, error) {
+		response, err := cloud.EC2().DescribeSecurityGroupRules(request)
+		if err != nil {
+			return nil, err
+		}
+		return response.SecurityGroupRules, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*ec2.SecurityGroupRule), nil
+}
+
+// DescribeSubnets returns the subnets matching the request.
+func (c *FindCache) DescribeSubnets(cloud AWSCloud, request *ec2.DescribeSubnetsInput) ([]*ec2.Subnet, error) {
+	result, err := c.get(FindCacheSubnets, "DescribeSubnets:"+request.String(), func() (interface{}, error) {
+		response, err := cloud.EC2().DescribeSubnets(request)
+		if err != nil {
+			return nil, err
+		}
+		return response.Subnets, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*ec2.Subnet), nil
+}
+
+// DescribeRouteTables returns the route tables matching the request.
+func (c *FindCache) DescribeRouteTables(cloud AWSCloud, request *ec2.DescribeRouteTablesInput) ([]*ec2.RouteTable, error) {
+	result, err := c.get(FindCacheRouteTables, "DescribeRouteTables:"+request.String(), func() (interface{}, error) {
+		response, err := cloud.EC2().DescribeRouteTables(request)
+		if err != nil {
+			return nil, err
+		}
+		return response.RouteTables, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*ec2.RouteTable), nil
+}
+
+// findCacheHolder holds the FindCache of the run in progress, if any.
+// It is shared by the copies of a cloud made by WithTags.
+type findCacheHolder struct {
+	active atomic.Pointer[FindCache]
+}
+
+// get returns the FindCache of the run in progress, or nil if caching is not enabled.
+func (h *findCacheHolder) get() *FindCache {
+	if h == nil {
+		return nil
+	}
+	return h.active.Load()
+}
+
+// start enables a new FindCache, and returns a function that disables it.
+func (h *findCacheHolder) start() func() {
+	if h == nil {
+		return func() {}
+	}
+	if os.Getenv(DisableFindCacheEnvVar) != "" {
+		klog.Infof("find cache disabled by %s", DisableFindCacheEnvVar)
+		return func() {}
+	}
+
+	cache := NewFindCache()
+	h.active.Store(cache)
+	return func() {
+		h.active.CompareAndSwap(cache, nil)
+	}
+}
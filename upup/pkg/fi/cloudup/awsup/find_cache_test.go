@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFindCache(t *testing.T) {
+	cache := NewFindCache()
+
+	var fetches atomic.Int64
+	fetch := func() (interface{}, error) {
+		fetches.Add(1)
+		return "result", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := cache.get(FindCacheSubnets, "key", fetch)
+			if err != nil || result != "result" {
+				t.Errorf("unexpected result %v, %v", result, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if fetches.Load() != 1 {
+		t.Fatalf("expected concurrent callers to share a single fetch, got %d", fetches.Load())
+	}
+
+	// Other resource types are not affected by invalidation
+	cache.Invalidate(FindCacheRouteTables)
+	if _, err := cache.get(FindCacheSubnets, "key", fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches.Load() != 1 {
+		t.Fatalf("expected cached result after invalidating another resource type, got %d fetches", fetches.Load())
+	}
+
+	cache.Invalidate(FindCacheSubnets)
+	if _, err := cache.get(FindCacheSubnets, "key", fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches.Load() != 2 {
+		t.Fatalf("expected a new fetch after invalidation, got %d fetches", fetches.Load())
+	}
+
+	// Errors are not cached
+	failures := 0
+	fail := func() (interface{}, error) {
+		failures++
+		return nil, errors.New("throttled")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get(FindCacheSecurityGroups, "key", fail); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+	if failures != 2 {
+		t.Fatalf("expected errors not to be cached, got %d fetches", failures)
+	}
+}
+
+func TestFindCacheNil(t *testing.T) {
+	var cache *FindCache
+
+	fetches := 0
+	fetch := func() (interface{}, error) {
+		fetches++
+		return "result", nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get(FindCacheSubnets, "key", fetch); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cache.Invalidate(FindCacheSubnets)
+	if fetches != 2 {
+		t.Fatalf("expected a nil cache to call through, got %d fetches", fetches)
+	}
+}
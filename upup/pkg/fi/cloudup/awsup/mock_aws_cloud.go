@@ -19,6 +19,7 @@ package awsup
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -31,10 +32,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
 	dnsproviderroute53 "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53"
@@ -50,6 +53,8 @@ type MockAWSCloud struct {
 	tags   map[string]string
 
 	zones []*ec2.AvailabilityZone
+
+	findCache *findCacheHolder
 }
 
 var _ fi.Cloud = (*MockAWSCloud)(nil)
@@ -64,7 +69,7 @@ func InstallMockAWSCloud(region string, zoneLetters string) *MockAWSCloud {
 }
 
 func BuildMockAWSCloud(region string, zoneLetters string) *MockAWSCloud {
-	i := &MockAWSCloud{region: region}
+	i := &MockAWSCloud{region: region, findCache: &findCacheHolder{}}
 	for _, c := range zoneLetters {
 		azName := fmt.Sprintf("%s%c", region, c)
 		az := &ec2.AvailabilityZone{
@@ -88,6 +93,8 @@ type MockCloud struct {
 	MockSQS         sqsiface.SQSAPI
 	MockEventBridge eventbridgeiface.EventBridgeAPI
 	MockSSM         ssmiface.SSMAPI
+
+	MockResourceGroupsTagging resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 }
 
 func (c *MockAWSCloud) DeleteGroup(g *cloudinstances.CloudInstanceGroup) error {
@@ -235,6 +242,14 @@ func (c *MockAWSCloud) ResolveImage(name string) (*ec2.Image, error) {
 	return resolveImage(c.MockSSM, c.MockEC2, name)
 }
 
+func (c *MockAWSCloud) FindCache() *FindCache {
+	return c.findCache.get()
+}
+
+func (c *MockAWSCloud) StartFindCache() func() {
+	return c.findCache.start()
+}
+
 func (c *MockAWSCloud) WithTags(tags map[string]string) AWSCloud {
 	m := &MockAWSCloud{}
 	*m = *c
@@ -312,6 +327,13 @@ func (c *MockAWSCloud) SSM() ssmiface.SSMAPI {
 	return c.MockSSM
 }
 
+func (c *MockAWSCloud) ResourceGroupsTagging() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	if c.MockResourceGroupsTagging == nil {
+		klog.Fatalf("MockResourceGroupsTagging not set")
+	}
+	return c.MockResourceGroupsTagging
+}
+
 func (c *MockAWSCloud) FindVPCInfo(id string) (*fi.VPCInfo, error) {
 	return findVPCInfo(c, id)
 }
@@ -334,6 +356,19 @@ func (c *MockAWSCloud) DefaultInstanceType(cluster *kops.Cluster, ig *kops.Insta
 	}
 }
 
+// InstanceTypesOfferedInZone returns the instance types offered in a zone.
+// Local Zones only offer a few instance types, all the mocked instance types are offered in availability zones.
+func (c *MockAWSCloud) InstanceTypesOfferedInZone(zone string) (sets.Set[string], error) {
+	if IsLocalZone(zone) {
+		return sets.New("t3.medium", "t3.large", "c5.large", "m5.large", "m5.xlarge", "g4dn.xlarge"), nil
+	}
+	return sets.New(
+		"a1.large", "c4.large", "c5.large", "c6in.32xlarge", "g4ad.16xlarge", "g4dn.xlarge", "m3.medium", "m4.large", "m5.large", "m5.xlarge",
+		"m6g.large", "m6g.xlarge", "p4d.24xlarge", "p5.48xlarge", "t2.medium", "t2.micro", "t3.large", "t3.medium", "t3.micro",
+		"t4g.medium", "t4g.micro",
+	), nil
+}
+
 // DescribeInstanceType calls ec2.DescribeInstanceType to get information for a particular instance type
 func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2.InstanceTypeInfo, error) {
 	if instanceType == "t2.invalidType" {
@@ -350,6 +385,13 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2.InstanceT
 		VCpuInfo: &ec2.VCpuInfo{
 			DefaultVCpus: aws.Int64(2),
 		},
+		PlacementGroupInfo: &ec2.PlacementGroupInfo{
+			SupportedStrategies: aws.StringSlice([]string{ec2.PlacementGroupStrategyCluster, ec2.PlacementGroupStrategyPartition, ec2.PlacementGroupStrategySpread}),
+		},
+	}
+	if strings.HasPrefix(instanceType, "t2.") {
+		// Previous generation burstable instances cannot be placed in cluster placement groups
+		info.PlacementGroupInfo.SupportedStrategies = aws.StringSlice([]string{ec2.PlacementGroupStrategyPartition, ec2.PlacementGroupStrategySpread})
 	}
 	if instanceType == "m3.medium" {
 		info.InstanceStorageInfo = &ec2.InstanceStorageInfo{
@@ -369,7 +411,7 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2.InstanceT
 				aws.String(ec2.ArchitectureTypeX8664),
 			},
 		}
-	case "a1.large", "m6g.xlarge":
+	case "a1.large", "m6g.large", "m6g.xlarge", "t4g.medium", "t4g.micro":
 		info.ProcessorInfo = &ec2.ProcessorInfo{
 			SupportedArchitectures: []*string{
 				aws.String(ec2.ArchitectureTypeArm64),
@@ -389,6 +431,41 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2.InstanceT
 			},
 		}
 		info.GpuInfo = &ec2.GpuInfo{}
+		if instanceType == "g4dn.xlarge" {
+			info.GpuInfo.Gpus = []*ec2.GpuDeviceInfo{
+				{
+					Count:        aws.Int64(1),
+					Manufacturer: aws.String("NVIDIA"),
+					Name:         aws.String("T4"),
+					MemoryInfo:   &ec2.GpuDeviceMemoryInfo{SizeInMiB: aws.Int64(16384)},
+				},
+			}
+		}
+	case "c6in.32xlarge":
+		info.ProcessorInfo = &ec2.ProcessorInfo{
+			SupportedArchitectures: []*string{
+				aws.String(ec2.ArchitectureTypeX8664),
+			},
+		}
+		info.NetworkInfo.EnaSrdSupported = aws.Bool(true)
+	case "p4d.24xlarge", "p5.48xlarge":
+		info.NetworkInfo.EnaSrdSupported = aws.Bool(true)
+		info.ProcessorInfo = &ec2.ProcessorInfo{
+			SupportedArchitectures: []*string{
+				aws.String(ec2.ArchitectureTypeX8664),
+			},
+		}
+		gpu := &ec2.GpuDeviceInfo{
+			Count:        aws.Int64(8),
+			Manufacturer: aws.String("NVIDIA"),
+			Name:         aws.String("A100"),
+			MemoryInfo:   &ec2.GpuDeviceMemoryInfo{SizeInMiB: aws.Int64(40960)},
+		}
+		if instanceType == "p5.48xlarge" {
+			gpu.Name = aws.String("H100")
+			gpu.MemoryInfo.SizeInMiB = aws.Int64(81920)
+		}
+		info.GpuInfo = &ec2.GpuInfo{Gpus: []*ec2.GpuDeviceInfo{gpu}}
 	}
 
 	return info, nil
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// addTracingHandlers records a span for every AWS request, covering all of its retries.
+// The span is a child of the span in the context of the request, if the request has one.
+func addTracingHandlers(h *request.Handlers) {
+	h.Validate.PushFrontNamed(request.NamedHandler{
+		Name: "kops/tracing-start",
+		Fn:   startRequestSpan,
+	})
+	h.Complete.PushBackNamed(request.NamedHandler{
+		Name: "kops/tracing-end",
+		Fn:   endRequestSpan,
+	})
+}
+
+func startRequestSpan(r *request.Request) {
+	service := r.ClientInfo.ServiceName
+	operation := "?"
+	if r.Operation != nil {
+		operation = r.Operation.Name
+	}
+
+	ctx, _ := tracer.Start(r.Context(), "AWS "+service+"/"+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("aws.service", service),
+			attribute.String("aws.operation", operation),
+			attribute.String("aws.region", aws.StringValue(r.Config.Region)),
+		))
+	r.SetContext(ctx)
+}
+
+func endRequestSpan(r *request.Request) {
+	span := trace.SpanFromContext(r.Context())
+	span.SetAttributes(attribute.Int("aws.retries", r.RetryCount))
+	if r.RequestID != "" {
+		span.SetAttributes(attribute.String("aws.request_id", r.RequestID))
+	}
+	if r.Error != nil {
+		span.RecordError(r.Error)
+		span.SetStatus(codes.Error, r.Error.Error())
+	}
+	span.End()
+}
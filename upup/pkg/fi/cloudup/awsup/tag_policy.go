@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// TagPolicyAllTags matches all cloudLabels in a tag policy rule
+const TagPolicyAllTags = "*"
+
+// Tag policy resource types for the tags that a LaunchTemplate applies to the instances and volumes it launches.
+// A rule that lists LaunchTemplate matches the launch template itself as well as these.
+const (
+	TagPolicyLaunchTemplateInstance = "LaunchTemplate/instance"
+	TagPolicyLaunchTemplateVolume   = "LaunchTemplate/volume"
+)
+
+// TagPolicyResourceTypes are the resource types a tag policy can refer to.
+// They are named after the tasks that manage the resources.
+var TagPolicyResourceTypes = []string{
+	"AutoscalingGroup",
+	"ClassicLoadBalancer",
+	"DHCPOptions",
+	"EBSVolume",
+	"EgressOnlyInternetGateway",
+	"ElasticIP",
+	"EventBridgeRule",
+	"IAMInstanceProfile",
+	"IAMOIDCProvider",
+	"IAMRole",
+	"Instance",
+	"InternetGateway",
+	"LaunchTemplate",
+	TagPolicyLaunchTemplateInstance,
+	TagPolicyLaunchTemplateVolume,
+	"NatGateway",
+	"NetworkLoadBalancer",
+	"RouteTable",
+	"SQS",
+	"SSHKey",
+	"SecurityGroup",
+	"SecurityGroupRule",
+	"Subnet",
+	"TargetGroup",
+	"VPC",
+}
+
+// TagFilter returns the tags that are allowed on a resource of the given tag policy resource type.
+type TagFilter func(resourceType string, tags map[string]string) map[string]string
+
+// TagPolicyResource is implemented by the tasks whose tags are subject to the cluster's tag policy.
+type TagPolicyResource interface {
+	// FilterTags replaces each set of tags of the task with the tags that the filter allows.
+	FilterTags(filter TagFilter)
+}
+
+// ApplyTagPolicy filters the tags of the resource by the tag policy,
+// and returns the sorted keys of the removed tags by resource type.
+func ApplyTagPolicy(policy *kops.TagPolicySpec, resource TagPolicyResource, cloudLabels map[string]string) map[string][]string {
+	removals := make(map[string][]string)
+	resource.FilterTags(func(resourceType string, tags map[string]string) map[string]string {
+		filtered, removed := FilterTagsByPolicy(policy, resourceType, tags, cloudLabels)
+		if len(removed) != 0 {
+			removals[resourceType] = removed
+		}
+		return filtered
+	})
+	return removals
+}
+
+// FilterTagsByPolicy returns the tags that the tag policy allows on a resource of the given type,
+// along with the sorted keys of the tags that were removed.
+// Only tags whose key is one of the cloudLabels are subject to the policy.
+// The tags map is not modified.
+func FilterTagsByPolicy(policy *kops.TagPolicySpec, resourceType string, tags map[string]string, cloudLabels map[string]string) (map[string]string, []string) {
+	if policy == nil || len(policy.Rules) == 0 {
+		return tags, nil
+	}
+
+	var removed []string
+	filtered := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if _, isCloudLabel := cloudLabels[k]; isCloudLabel && !tagAllowedByPolicy(policy, resourceType, k) {
+			removed = append(removed, k)
+			continue
+		}
+		filtered[k] = v
+	}
+
+	if len(removed) == 0 {
+		return tags, nil
+	}
+	sort.Strings(removed)
+	return filtered, removed
+}
+
+// tagAllowedByPolicy returns false if any rule matching the tag excludes it from the resource type.
+func tagAllowedByPolicy(policy *kops.TagPolicySpec, resourceType string, key string) bool {
+	for _, rule := range policy.Rules {
+		if !slices.Contains(rule.Tags, key) && !slices.Contains(rule.Tags, TagPolicyAllTags) {
+			continue
+		}
+		if len(rule.Include) != 0 && !matchesResourceType(rule.Include, resourceType) {
+			return false
+		}
+		if matchesResourceType(rule.Exclude, resourceType) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesResourceType returns true if the list contains the resource type or the type of the task it belongs to.
+func matchesResourceType(list []string, resourceType string) bool {
+	if slices.Contains(list, resourceType) {
+		return true
+	}
+	task, _, found := strings.Cut(resourceType, "/")
+	return found && slices.Contains(list, task)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestFilterTagsByPolicy(t *testing.T) {
+	cloudLabels := map[string]string{
+		"map-migrated": "mig123",
+		"compliance":   "pci",
+		"team":         "platform",
+	}
+	tags := map[string]string{
+		"Name":                              "nodes.example.com",
+		"KubernetesCluster":                 "example.com",
+		"kubernetes.io/cluster/example.com": "owned",
+		"map-migrated":                      "mig123",
+		"compliance":                        "pci",
+		"team":                              "platform",
+	}
+
+	includeMapMigrated := kops.TagPolicyRule{
+		Tags:    []string{"map-migrated"},
+		Include: []string{"LaunchTemplate", "EBSVolume"},
+	}
+	excludeCompliance := kops.TagPolicyRule{
+		Tags:    []string{"compliance"},
+		Exclude: []string{"SecurityGroup"},
+	}
+	excludeAll := kops.TagPolicyRule{
+		Tags:    []string{TagPolicyAllTags},
+		Exclude: []string{"Subnet"},
+	}
+
+	grid := []struct {
+		name         string
+		rules        []kops.TagPolicyRule
+		resourceType string
+		expected     []string
+	}{
+		{
+			name:         "no rules",
+			resourceType: "SecurityGroup",
+		},
+		{
+			name:         "include matches",
+			rules:        []kops.TagPolicyRule{includeMapMigrated},
+			resourceType: "LaunchTemplate",
+		},
+		{
+			name:         "include does not match",
+			rules:        []kops.TagPolicyRule{includeMapMigrated},
+			resourceType: "SecurityGroup",
+			expected:     []string{"map-migrated"},
+		},
+		{
+			name:         "exclude matches",
+			rules:        []kops.TagPolicyRule{excludeCompliance},
+			resourceType: "SecurityGroup",
+			expected:     []string{"compliance"},
+		},
+		{
+			name:         "exclude does not match",
+			rules:        []kops.TagPolicyRule{excludeCompliance},
+			resourceType: "EBSVolume",
+		},
+		{
+			name:         "wildcard removes all cloudLabels",
+			rules:        []kops.TagPolicyRule{excludeAll},
+			resourceType: "Subnet",
+			expected:     []string{"compliance", "map-migrated", "team"},
+		},
+		{
+			name:         "wildcard does not match",
+			rules:        []kops.TagPolicyRule{excludeAll},
+			resourceType: "VPC",
+		},
+		{
+			name:         "rules are combined",
+			rules:        []kops.TagPolicyRule{includeMapMigrated, excludeCompliance},
+			resourceType: "SecurityGroup",
+			expected:     []string{"compliance", "map-migrated"},
+		},
+		{
+			name: "include and exclude in one rule",
+			rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"team"},
+					Include: []string{"LaunchTemplate", "EBSVolume"},
+					Exclude: []string{"EBSVolume"},
+				},
+			},
+			resourceType: "EBSVolume",
+			expected:     []string{"team"},
+		},
+		{
+			name:         "task type matches its scopes",
+			rules:        []kops.TagPolicyRule{includeMapMigrated},
+			resourceType: TagPolicyLaunchTemplateVolume,
+		},
+		{
+			name: "scope does not match the task type",
+			rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"map-migrated"},
+					Include: []string{TagPolicyLaunchTemplateVolume},
+				},
+			},
+			resourceType: "LaunchTemplate",
+			expected:     []string{"map-migrated"},
+		},
+		{
+			name: "scope does not match other scopes",
+			rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"compliance"},
+					Exclude: []string{TagPolicyLaunchTemplateVolume},
+				},
+			},
+			resourceType: TagPolicyLaunchTemplateInstance,
+		},
+		{
+			name: "kOps tags are never removed",
+			rules: []kops.TagPolicyRule{
+				{
+					Tags:    []string{"Name", "KubernetesCluster", "kubernetes.io/cluster/example.com"},
+					Exclude: []string{"SecurityGroup"},
+				},
+			},
+			resourceType: "SecurityGroup",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			policy := &kops.TagPolicySpec{Rules: g.rules}
+			filtered, removed := FilterTagsByPolicy(policy, g.resourceType, tags, cloudLabels)
+
+			if !reflect.DeepEqual(removed, g.expected) {
+				t.Errorf("unexpected removed tags: expected %v, got %v", g.expected, removed)
+			}
+			if len(filtered) != len(tags)-len(g.expected) {
+				t.Errorf("expected %d tags, got %v", len(tags)-len(g.expected), filtered)
+			}
+			for _, k := range g.expected {
+				if _, found := filtered[k]; found {
+					t.Errorf("tag %q was not removed", k)
+				}
+			}
+			if len(tags) != 6 {
+				t.Errorf("input tags were modified: %v", tags)
+			}
+		})
+	}
+}
+
+type testTagPolicyResource struct {
+	tags       map[string]string
+	volumeTags map[string]string
+}
+
+func (r *testTagPolicyResource) FilterTags(filter TagFilter) {
+	r.tags = filter("LaunchTemplate", r.tags)
+	r.volumeTags = filter(TagPolicyLaunchTemplateVolume, r.volumeTags)
+}
+
+func TestApplyTagPolicy(t *testing.T) {
+	cloudLabels := map[string]string{
+		"map-migrated": "mig123",
+	}
+	tags := map[string]string{
+		"Name":         "nodes.example.com",
+		"map-migrated": "mig123",
+	}
+	policy := &kops.TagPolicySpec{
+		Rules: []kops.TagPolicyRule{
+			{
+				Tags:    []string{"map-migrated"},
+				Include: []string{TagPolicyLaunchTemplateVolume},
+			},
+		},
+	}
+
+	resource := &testTagPolicyResource{tags: tags, volumeTags: tags}
+	removals := ApplyTagPolicy(policy, resource, cloudLabels)
+
+	expectedRemovals := map[string][]string{
+		"LaunchTemplate": {"map-migrated"},
+	}
+	if !reflect.DeepEqual(removals, expectedRemovals) {
+		t.Errorf("unexpected removals: expected %v, got %v", expectedRemovals, removals)
+	}
+	if !reflect.DeepEqual(resource.tags, map[string]string{"Name": "nodes.example.com"}) {
+		t.Errorf("unexpected tags: %v", resource.tags)
+	}
+	if !reflect.DeepEqual(resource.volumeTags, tags) {
+		t.Errorf("unexpected volume tags: %v", resource.volumeTags)
+	}
+}
@@ -562,6 +562,42 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 		}
 	}
 
+	if b.Cluster.Spec.Metrics != nil && b.Cluster.Spec.Metrics.KubeStateMetrics != nil && fi.ValueOf(b.Cluster.Spec.Metrics.KubeStateMetrics.Enabled) {
+		{
+			key := "kube-state-metrics.addons.k8s.io"
+
+			{
+				location := key + "/k8s-1.20.yaml"
+				id := "k8s-1.20"
+
+				addons.Add(&channelsapi.AddonSpec{
+					Name:     fi.PtrTo(key),
+					Selector: map[string]string{"k8s-addon": key},
+					Manifest: fi.PtrTo(location),
+					Id:       id,
+				})
+			}
+		}
+	}
+
+	if fi.ValueOf(b.Cluster.Spec.Networking.DefaultComponentNetworkPolicies) {
+		{
+			key := "network-policies.addons.k8s.io"
+
+			{
+				location := key + "/k8s-1.20.yaml"
+				id := "k8s-1.20"
+
+				addons.Add(&channelsapi.AddonSpec{
+					Name:     fi.PtrTo(key),
+					Selector: map[string]string{"k8s-addon": key},
+					Manifest: fi.PtrTo(location),
+					Id:       id,
+				})
+			}
+		}
+	}
+
 	if b.Cluster.Spec.CertManager != nil && fi.ValueOf(b.Cluster.Spec.CertManager.Enabled) && (b.Cluster.Spec.CertManager.Managed == nil || fi.ValueOf(b.Cluster.Spec.CertManager.Managed)) {
 		{
 			key := "certmanager.io"
@@ -1193,6 +1229,20 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 			})
 		}
 	}
+	if b.Cluster.Spec.Snapshots != nil {
+		key := "snapshot-scheduler.addons.k8s.io"
+
+		{
+			id := "k8s-1.20"
+			location := key + "/" + id + ".yaml"
+			addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.PtrTo(key),
+				Manifest: fi.PtrTo(location),
+				Selector: map[string]string{"k8s-addon": key},
+				Id:       id,
+			})
+		}
+	}
 	if b.Cluster.Spec.Karpenter != nil && b.Cluster.Spec.Karpenter.Enabled {
 		key := "karpenter.sh"
 
@@ -50,11 +50,16 @@ func TestBootstrapChannelBuilder_BuildTasks(t *testing.T) {
 	runChannelBuilderTest(t, "cilium", []string{"kops-controller.addons.k8s.io-k8s-1.16"})
 	runChannelBuilderTest(t, "amazonvpc", []string{"networking.amazon-vpc-routed-eni-k8s-1.16"})
 	runChannelBuilderTest(t, "amazonvpc-containerd", []string{"networking.amazon-vpc-routed-eni-k8s-1.16"})
+	runChannelBuilderTest(t, "amazonvpc-networkpolicy", []string{"networking.amazon-vpc-routed-eni-k8s-1.16"})
 	runChannelBuilderTest(t, "awsiamauthenticator/crd", []string{"authentication.aws-k8s-1.12"})
 	runChannelBuilderTest(t, "awsiamauthenticator/mappings", []string{"authentication.aws-k8s-1.12"})
 	runChannelBuilderTest(t, "metrics-server/insecure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "metrics-server/secure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "coredns", []string{"coredns.addons.k8s.io-k8s-1.12"})
+	runChannelBuilderTest(t, "snapshots", []string{"snapshot-scheduler.addons.k8s.io-k8s-1.20"})
+	runChannelBuilderTest(t, "kube-state-metrics", []string{"kube-state-metrics.addons.k8s.io-k8s-1.20"})
+	runChannelBuilderTest(t, "network-policies", []string{"network-policies.addons.k8s.io-k8s-1.20"})
+	runChannelBuilderTest(t, "addondefaults", []string{"coredns.addons.k8s.io-k8s-1.12", "dns-controller.addons.k8s.io-k8s-1.12", "kops-controller.addons.k8s.io-k8s-1.16"})
 }
 
 func TestBootstrapChannelBuilder_ServiceAccountIAM(t *testing.T) {
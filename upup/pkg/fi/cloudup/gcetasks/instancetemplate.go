@@ -39,6 +39,11 @@ const (
 	InstanceTemplateNamePrefixMaxLength = 32
 
 	accessConfigOneToOneNAT = "ONE_TO_ONE_NAT"
+
+	// localSSDDiskType is the type of the attached disks that are local SSDs
+	localSSDDiskType = "SCRATCH"
+	// localSSDSizeGB is the fixed size of a local SSD
+	localSSDSizeGB = 375
 )
 
 // InstanceTemplate represents a GCE InstanceTemplate
@@ -61,6 +66,11 @@ type InstanceTemplate struct {
 	BootDiskSizeGB *int64
 	BootDiskType   *string
 
+	// LocalSSDCount is the number of local SSDs attached to the instances.
+	LocalSSDCount *int64
+	// LocalSSDInterface is the interface of the local SSDs (NVME or SCSI).
+	LocalSSDInterface *string
+
 	CanIPForward  *bool
 	Subnet        *Subnet
 	AliasIPRanges map[string]string
@@ -134,6 +144,14 @@ func (e *InstanceTemplate) Find(c *fi.CloudupContext) (*InstanceTemplate, error)
 		actual.BootDiskType = &p.Disks[0].InitializeParams.DiskType
 		actual.BootDiskSizeGB = &p.Disks[0].InitializeParams.DiskSizeGb
 
+		actual.LocalSSDCount = fi.PtrTo(int64(0))
+		for _, disk := range p.Disks[1:] {
+			if disk.Type == localSSDDiskType {
+				actual.LocalSSDCount = fi.PtrTo(fi.ValueOf(actual.LocalSSDCount) + 1)
+				actual.LocalSSDInterface = fi.PtrTo(disk.Interface)
+			}
+		}
+
 		if p.Scheduling != nil {
 			actual.Preemptible = &p.Scheduling.Preemptible
 			actual.GCPProvisioningModel = &p.Scheduling.ProvisioningModel
@@ -291,6 +309,22 @@ func (e *InstanceTemplate) mapToGCE(project string, region string) (*compute.Ins
 		Type:       "PERSISTENT",
 	})
 
+	for i := int64(0); i < fi.ValueOf(e.LocalSSDCount); i++ {
+		disks = append(disks, &compute.AttachedDisk{
+			Kind: "compute#attachedDisk",
+			InitializeParams: &compute.AttachedDiskInitializeParams{
+				DiskSizeGb: localSSDSizeGB,
+				DiskType:   "local-ssd",
+			},
+			DeviceName: fmt.Sprintf("local-ssd-%d", i),
+			Index:      i + 1,
+			AutoDelete: true,
+			Interface:  fi.ValueOf(e.LocalSSDInterface),
+			Mode:       "READ_WRITE",
+			Type:       localSSDDiskType,
+		})
+	}
+
 	var tags *compute.Tags
 	if e.Tags != nil {
 		tags = &compute.Tags{
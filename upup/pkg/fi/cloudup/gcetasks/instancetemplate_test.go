@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetasks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gcemock "k8s.io/kops/cloudmock/gce"
+	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+)
+
+func buildTestInstanceTemplate(localSSDCount int64, localSSDInterface string, bootDiskType string) *InstanceTemplate {
+	t := &InstanceTemplate{
+		Name:       fi.PtrTo("nodes-test-example-com"),
+		NamePrefix: fi.PtrTo("nodes-test-example-com"),
+		Lifecycle:  fi.LifecycleSync,
+
+		Network: &Network{
+			Name:      fi.PtrTo("test"),
+			Lifecycle: fi.LifecycleSync,
+			Mode:      "custom",
+		},
+		Tags:   []string{"test-example-com-k8s-io-role-node"},
+		Labels: map[string]string{"k8s-io-role-node": ""},
+
+		BootDiskImage:  fi.PtrTo("ubuntu-os-cloud/ubuntu-2204-jammy-v20240207"),
+		BootDiskSizeGB: fi.PtrTo(int64(128)),
+		BootDiskType:   fi.PtrTo(bootDiskType),
+
+		LocalSSDCount: fi.PtrTo(localSSDCount),
+
+		CanIPForward:  fi.PtrTo(true),
+		HasExternalIP: fi.PtrTo(false),
+		MachineType:   fi.PtrTo("n2-standard-8"),
+		Preemptible:   fi.PtrTo(false),
+
+		GCPProvisioningModel: fi.PtrTo("STANDARD"),
+		GuestAccelerators:    []AcceleratorConfig{},
+
+		Scopes: []string{"compute-rw"},
+		ServiceAccounts: []*ServiceAccount{
+			{
+				Name:      fi.PtrTo("default"),
+				Lifecycle: fi.LifecycleSync,
+				Email:     fi.PtrTo("default"),
+				Shared:    fi.PtrTo(true),
+			},
+		},
+
+		Metadata: map[string]fi.Resource{
+			"startup-script": fi.NewStringResource("#!/bin/bash"),
+		},
+	}
+	if localSSDInterface != "" {
+		t.LocalSSDInterface = fi.PtrTo(localSSDInterface)
+	}
+	return t
+}
+
+func TestInstanceTemplateLocalSSDsTerraform(t *testing.T) {
+	grid := []struct {
+		name              string
+		localSSDCount     int64
+		localSSDInterface string
+		bootDiskType      string
+	}{
+		{
+			name:         "no-local-ssds",
+			bootDiskType: "pd-standard",
+		},
+		{
+			name:              "local-ssds-nvme",
+			localSSDCount:     2,
+			localSSDInterface: "NVME",
+			bootDiskType:      "pd-balanced",
+		},
+		{
+			name:              "local-ssds-scsi",
+			localSSDCount:     4,
+			localSSDInterface: "SCSI",
+			bootDiskType:      "pd-ssd",
+		},
+		{
+			name:         "hyperdisk-boot-disk",
+			bootDiskType: "hyperdisk-balanced",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cloud := gcemock.InstallMockGCECloud("us-test1", "testproject")
+			outdir := t.TempDir()
+			target := terraform.NewTerraformTarget(cloud, "testproject", outdir, nil)
+
+			e := buildTestInstanceTemplate(g.localSSDCount, g.localSSDInterface, g.bootDiskType)
+			if err := e.RenderTerraform(target, nil, e, e); err != nil {
+				t.Fatalf("unexpected error from RenderTerraform: %v", err)
+			}
+			if err := target.Finish(map[string]fi.CloudupTask{}); err != nil {
+				t.Fatalf("unexpected error from Finish: %v", err)
+			}
+
+			actual, err := os.ReadFile(filepath.Join(outdir, "kubernetes.tf"))
+			if err != nil {
+				t.Fatalf("error reading terraform output: %v", err)
+			}
+			golden.AssertMatchesFile(t, string(actual), filepath.Join("tests", "instancetemplate", g.name+".tf"))
+		})
+	}
+}
+
+func TestInstanceTemplateLocalSSDs(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := gcemock.InstallMockGCECloud("us-test1", "testproject")
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(localSSDCount int64) map[string]fi.CloudupTask {
+		instanceTemplate := buildTestInstanceTemplate(localSSDCount, "NVME", "pd-balanced")
+		return map[string]fi.CloudupTask{
+			*instanceTemplate.Name:         instanceTemplate,
+			*instanceTemplate.Network.Name: instanceTemplate.Network,
+			"ServiceAccount/default":       instanceTemplate.ServiceAccounts[0],
+		}
+	}
+
+	{
+		allTasks := buildTasks(2)
+		checkHasChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks(2)
+		runTasks(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks(2)
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks(4)
+		checkHasChanges(t, ctx, cloud, allTasks)
+	}
+}
@@ -0,0 +1,82 @@
+provider "google" {
+  project = "testproject"
+  region  = "us-test1"
+}
+
+resource "google_compute_instance_template" "nodes-test-example-com" {
+  can_ip_forward = true
+  disk {
+    auto_delete  = true
+    boot         = true
+    device_name  = "persistent-disks-0"
+    disk_name    = ""
+    disk_size_gb = 128
+    disk_type    = "pd-balanced"
+    interface    = ""
+    mode         = "READ_WRITE"
+    source       = ""
+    source_image = "https://www.googleapis.com/compute/v1/projects/ubuntu-os-cloud/global/images/ubuntu-2204-jammy-v20240207"
+    type         = "PERSISTENT"
+  }
+  disk {
+    auto_delete  = true
+    boot         = false
+    device_name  = "local-ssd-0"
+    disk_name    = ""
+    disk_size_gb = 375
+    disk_type    = "local-ssd"
+    interface    = "NVME"
+    mode         = "READ_WRITE"
+    source       = ""
+    source_image = ""
+    type         = "SCRATCH"
+  }
+  disk {
+    auto_delete  = true
+    boot         = false
+    device_name  = "local-ssd-1"
+    disk_name    = ""
+    disk_size_gb = 375
+    disk_type    = "local-ssd"
+    interface    = "NVME"
+    mode         = "READ_WRITE"
+    source       = ""
+    source_image = ""
+    type         = "SCRATCH"
+  }
+  labels = {
+    "k8s-io-role-node" = ""
+  }
+  lifecycle {
+    create_before_destroy = true
+  }
+  machine_type = "n2-standard-8"
+  metadata = {
+    "startup-script" = "#!/bin/bash"
+  }
+  name_prefix = "nodes-test-example-com-"
+  network_interface {
+    network = google_compute_network.test.name
+  }
+  scheduling {
+    automatic_restart   = true
+    on_host_maintenance = "MIGRATE"
+    preemptible         = false
+    provisioning_model  = "STANDARD"
+  }
+  service_account {
+    email  = "default"
+    scopes = ["https://www.googleapis.com/auth/compute"]
+  }
+  tags = ["test-example-com-k8s-io-role-node"]
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    google = {
+      "source"  = "hashicorp/google"
+      "version" = ">= 2.19.0"
+    }
+  }
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// instanceGroupTask is implemented by tasks that belong to a single instance group,
+// when they are not tagged with it.
+type instanceGroupTask interface {
+	InstanceGroupName() string
+}
+
+// instanceGroupTargets are the tasks of an update that targets some of the instance groups.
+type instanceGroupTargets struct {
+	// Updated are the keys of the tasks that belong to the targeted instance groups.
+	Updated []string
+	// Shared are the keys of the tasks that the targeted instance groups depend on;
+	// they are checked, but not changed.
+	Shared []string
+	// Skipped are the keys of all the other tasks.
+	Skipped []string
+}
+
+// targetInstanceGroups restricts the tasks to the ones that belong to the named instance groups.
+// Their dependencies, as returned by fi.FindTaskDependencies, are only checked, and all the other tasks are ignored.
+func targetInstanceGroups(taskMap map[string]fi.CloudupTask, dependencies map[string][]string, instanceGroups []string) (*instanceGroupTargets, error) {
+	owners := make(map[string]string)
+	for key, task := range taskMap {
+		if name := taskInstanceGroup(task); name != "" {
+			owners[key] = name
+		}
+	}
+
+	// Tasks that only depend on the tasks of one instance group, such as warm pools,
+	// lifecycle hooks or the nodeup config, belong to that instance group too.
+	visited := make(map[string]bool)
+	var resolveOwner func(key string) string
+	resolveOwner = func(key string) string {
+		if visited[key] {
+			return owners[key]
+		}
+		visited[key] = true
+
+		owner := ""
+		for _, dep := range dependencies[key] {
+			depOwner := resolveOwner(dep)
+			if depOwner == "" {
+				continue
+			}
+			if owner != "" && owner != depOwner {
+				return ""
+			}
+			owner = depOwner
+		}
+		if owner != "" {
+			owners[key] = owner
+		}
+		return owner
+	}
+	for key := range taskMap {
+		if _, found := owners[key]; !found {
+			resolveOwner(key)
+		}
+	}
+
+	selected := sets.New(instanceGroups...)
+	matched := sets.New[string]()
+	for _, owner := range owners {
+		if selected.Has(owner) {
+			matched.Insert(owner)
+		}
+	}
+	if missing := selected.Difference(matched); missing.Len() != 0 {
+		return nil, fmt.Errorf("no tasks found for instance groups %s", strings.Join(sets.List(missing), ", "))
+	}
+
+	shared := make(map[string]bool)
+	var addShared func(key string)
+	addShared = func(key string) {
+		for _, dep := range dependencies[key] {
+			if selected.Has(owners[dep]) || shared[dep] {
+				continue
+			}
+			shared[dep] = true
+			addShared(dep)
+		}
+	}
+	for key := range taskMap {
+		if selected.Has(owners[key]) {
+			addShared(key)
+		}
+	}
+
+	var keys []string
+	for key := range taskMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	targets := &instanceGroupTargets{}
+	for _, key := range keys {
+		hl, ok := taskMap[key].(fi.HasLifecycle)
+		switch {
+		case selected.Has(owners[key]):
+			targets.Updated = append(targets.Updated, key)
+		case !ok:
+			klog.Warningf("task %s does not have a lifecycle; it cannot be skipped", key)
+			targets.Updated = append(targets.Updated, key)
+		case shared[key]:
+			switch hl.GetLifecycle() {
+			case fi.LifecycleSync, fi.LifecycleWarnIfInsufficientAccess:
+				hl.SetLifecycle(fi.LifecycleExistsAndWarnIfChanges)
+			}
+			targets.Shared = append(targets.Shared, key)
+		default:
+			hl.SetLifecycle(fi.LifecycleIgnore)
+			targets.Skipped = append(targets.Skipped, key)
+		}
+	}
+
+	return targets, nil
+}
+
+// taskInstanceGroup returns the name of the instance group that the task belongs to,
+// from its instance group tag, or empty if there isn't one.
+func taskInstanceGroup(task fi.CloudupTask) string {
+	if igt, ok := task.(instanceGroupTask); ok {
+		return igt.InstanceGroupName()
+	}
+
+	v := reflect.ValueOf(task)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ""
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := v.FieldByName("Tags")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(map[string]string{}) {
+		return ""
+	}
+	return field.Interface().(map[string]string)[nodeidentityaws.CloudTagInstanceGroupName]
+}
+
+// hasPendingClusterChanges returns true when the completed cluster spec differs from the one
+// written by the last update, so the cluster has changes that only a full update would apply.
+func hasPendingClusterChanges(ctx context.Context, configBase vfs.Path, taskMap map[string]fi.CloudupTask) (bool, error) {
+	task, ok := taskMap["ManagedFile/"+registry.PathClusterCompleted].(*fitasks.ManagedFile)
+	if !ok {
+		return false, fmt.Errorf("task for %s not found", registry.PathClusterCompleted)
+	}
+	contents, err := fi.ResourceAsBytes(task.Contents)
+	if err != nil {
+		return false, fmt.Errorf("reading completed cluster spec: %w", err)
+	}
+
+	existing, err := configBase.Join(registry.PathClusterCompleted).ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("reading %s: %w", registry.PathClusterCompleted, err)
+	}
+
+	return !bytes.Equal(existing, contents), nil
+}
+
+// printInstanceGroupTargets prints the number of tasks of each kind for a targeted update.
+func printInstanceGroupTargets(out io.Writer, instanceGroups []string, targets *instanceGroupTargets) {
+	fmt.Fprintf(out, "Targeting instance groups: %s\n", strings.Join(instanceGroups, ", "))
+	fmt.Fprintf(out, "  Tasks of the targeted instance groups:\t%d\n", len(targets.Updated))
+	fmt.Fprintf(out, "  Shared tasks, checked but not changed:\t%d\n", len(targets.Shared))
+	fmt.Fprintf(out, "  Tasks skipped:\t%d\n", len(targets.Skipped))
+	fmt.Fprintf(out, "\n")
+}
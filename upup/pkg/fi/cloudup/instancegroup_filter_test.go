@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func buildInstanceGroupTasks() map[string]fi.CloudupTask {
+	vpc := &awstasks.VPC{
+		Name:      fi.PtrTo("example"),
+		Lifecycle: fi.LifecycleSync,
+		CIDR:      fi.PtrTo("172.20.0.0/16"),
+	}
+	subnet := &awstasks.Subnet{
+		Name:             fi.PtrTo("us-test-1a"),
+		Lifecycle:        fi.LifecycleSync,
+		VPC:              vpc,
+		AvailabilityZone: fi.PtrTo("us-test-1a"),
+		CIDR:             fi.PtrTo("172.20.32.0/19"),
+	}
+	securityGroup := &awstasks.SecurityGroup{
+		Name:        fi.PtrTo("nodes"),
+		Lifecycle:   fi.LifecycleSync,
+		VPC:         vpc,
+		Description: fi.PtrTo("Security group for nodes"),
+	}
+
+	taskMap := map[string]fi.CloudupTask{
+		"VPC/example":          vpc,
+		"Subnet/us-test-1a":    subnet,
+		"SecurityGroup/nodes":  securityGroup,
+		"ManagedFile/manifest": &fitasks.ManagedFile{Name: fi.PtrTo("manifest"), Lifecycle: fi.LifecycleSync},
+	}
+	for _, name := range []string{"nodes-a", "nodes-b"} {
+		tags := map[string]string{
+			"kops.k8s.io/instancegroup": name,
+		}
+		launchTemplate := &awstasks.LaunchTemplate{
+			Name:           fi.PtrTo(name),
+			Lifecycle:      fi.LifecycleSync,
+			ImageID:        fi.PtrTo("ami-12345678"),
+			InstanceType:   fi.PtrTo("t3.medium"),
+			SecurityGroups: []*awstasks.SecurityGroup{securityGroup},
+			Tags:           tags,
+		}
+		autoscalingGroup := &awstasks.AutoscalingGroup{
+			Name:           fi.PtrTo(name),
+			Lifecycle:      fi.LifecycleSync,
+			LaunchTemplate: launchTemplate,
+			MinSize:        fi.PtrTo(int64(1)),
+			MaxSize:        fi.PtrTo(int64(3)),
+			Subnets:        []*awstasks.Subnet{subnet},
+			Tags:           tags,
+		}
+		taskMap["LaunchTemplate/"+name] = launchTemplate
+		taskMap["AutoscalingGroup/"+name] = autoscalingGroup
+		taskMap["WarmPool/"+name] = &awstasks.WarmPool{
+			Name:             fi.PtrTo(name),
+			Lifecycle:        fi.LifecycleSync,
+			AutoscalingGroup: autoscalingGroup,
+		}
+	}
+	return taskMap
+}
+
+func TestTargetInstanceGroups(t *testing.T) {
+	taskMap := buildInstanceGroupTasks()
+
+	targets, err := targetInstanceGroups(taskMap, fi.FindTaskDependencies(taskMap), []string{"nodes-a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &instanceGroupTargets{
+		Updated: []string{"AutoscalingGroup/nodes-a", "LaunchTemplate/nodes-a", "WarmPool/nodes-a"},
+		Shared:  []string{"SecurityGroup/nodes", "Subnet/us-test-1a", "VPC/example"},
+		Skipped: []string{"AutoscalingGroup/nodes-b", "LaunchTemplate/nodes-b", "ManagedFile/manifest", "WarmPool/nodes-b"},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("unexpected targets: expected %+v, got %+v", expected, targets)
+	}
+
+	expectedLifecycles := map[string]fi.Lifecycle{
+		"AutoscalingGroup/nodes-a": fi.LifecycleSync,
+		"LaunchTemplate/nodes-a":   fi.LifecycleSync,
+		"WarmPool/nodes-a":         fi.LifecycleSync,
+		"SecurityGroup/nodes":      fi.LifecycleExistsAndWarnIfChanges,
+		"Subnet/us-test-1a":        fi.LifecycleExistsAndWarnIfChanges,
+		"VPC/example":              fi.LifecycleExistsAndWarnIfChanges,
+		"AutoscalingGroup/nodes-b": fi.LifecycleIgnore,
+		"LaunchTemplate/nodes-b":   fi.LifecycleIgnore,
+		"ManagedFile/manifest":     fi.LifecycleIgnore,
+		"WarmPool/nodes-b":         fi.LifecycleIgnore,
+	}
+	for key, expected := range expectedLifecycles {
+		if lifecycle := taskMap[key].(fi.HasLifecycle).GetLifecycle(); lifecycle != expected {
+			t.Errorf("unexpected lifecycle for %s: expected %q, got %q", key, expected, lifecycle)
+		}
+	}
+
+	var out bytes.Buffer
+	printInstanceGroupTargets(&out, []string{"nodes-a"}, targets)
+	expectedOutput := "Targeting instance groups: nodes-a\n" +
+		"  Tasks of the targeted instance groups:\t3\n" +
+		"  Shared tasks, checked but not changed:\t3\n" +
+		"  Tasks skipped:\t4\n" +
+		"\n"
+	if out.String() != expectedOutput {
+		t.Errorf("unexpected output: expected %q, got %q", expectedOutput, out.String())
+	}
+}
+
+func TestTargetInstanceGroupsPlan(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-test-1", "a")
+	cloud.MockEC2 = &mockec2.MockEC2{}
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{}
+
+	taskMap := buildInstanceGroupTasks()
+	if _, err := targetInstanceGroups(taskMap, fi.FindTaskDependencies(taskMap), []string{"nodes-a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			KubernetesVersion: "v1.30.0",
+		},
+	}
+	assetBuilder := assets.NewAssetBuilder(vfs.Context, cluster.Spec.Assets, cluster.Spec.KubernetesVersion, false)
+	target := fi.NewCloudupDryRunTarget(assetBuilder, io.Discard)
+	context, err := fi.NewCloudupContext(ctx, target, nil, cloud, nil, nil, nil, taskMap)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	options := fi.RunTasksOptions{
+		MaxTaskDuration:         2 * time.Second,
+		WaitAfterAllTasksFailed: 500 * time.Millisecond,
+	}
+	if err := context.RunTasks(options); err != nil {
+		t.Fatalf("unexpected error during Run: %v", err)
+	}
+
+	plan, err := target.Plan(taskMap)
+	if err != nil {
+		t.Fatalf("error building plan: %v", err)
+	}
+	var planned []string
+	for _, change := range plan.Changes {
+		planned = append(planned, change.Type+"/"+change.Name)
+	}
+	sort.Strings(planned)
+	expected := []string{"AutoscalingGroup/nodes-a", "LaunchTemplate/nodes-a"}
+	if !reflect.DeepEqual(planned, expected) {
+		t.Errorf("unexpected planned changes: expected %v, got %v", expected, planned)
+	}
+}
+
+func TestTargetInstanceGroupsNotFound(t *testing.T) {
+	taskMap := buildInstanceGroupTasks()
+	_, err := targetInstanceGroups(taskMap, fi.FindTaskDependencies(taskMap), []string{"nodes-a", "nodes-c"})
+	if err == nil || err.Error() != "no tasks found for instance groups nodes-c" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -48,6 +48,9 @@ const (
 	AuthorizationFlagRBAC        = "RBAC"
 )
 
+// ArchitectureMulti is the --architecture value for an amd64 control plane with both amd64 and arm64 nodes.
+const ArchitectureMulti = "multi"
+
 type NewClusterOptions struct {
 	// ClusterName is the name of the cluster to initialize.
 	ClusterName string
@@ -160,6 +163,10 @@ type NewClusterOptions struct {
 	// InstanceManager specifies which manager to use for managing instances.
 	InstanceManager string
 
+	// Architecture is the CPU architecture of the instances: "amd64", "arm64" or "multi".
+	// Defaults to the architecture of the machine types.
+	Architecture string
+
 	Image             string
 	NodeImage         string
 	ControlPlaneImage string
@@ -308,7 +315,7 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 	case api.CloudProviderAWS:
 		cluster.Spec.CloudProvider.AWS = &api.AWSSpec{}
 		cloudTags := map[string]string{}
-		awsCloud, err := awsup.NewAWSCloud(opt.Zones[0][:len(opt.Zones[0])-1], cloudTags)
+		awsCloud, err := awsup.NewAWSCloud(awsup.RegionFromZone(opt.Zones[0]), cloudTags)
 		if err != nil {
 			return nil, err
 		}
@@ -356,6 +363,19 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 		return nil, fmt.Errorf("unsupported cloud provider %s", opt.CloudProvider)
 	}
 
+	switch opt.Architecture {
+	case "", string(architectures.ArchitectureAmd64):
+	case string(architectures.ArchitectureArm64), ArchitectureMulti:
+		if cluster.Spec.GetCloudProvider() != api.CloudProviderAWS {
+			return nil, fmt.Errorf("--architecture %s is only supported on AWS", opt.Architecture)
+		}
+		if opt.Architecture == ArchitectureMulti && (opt.Image != "" || opt.NodeImage != "") {
+			return nil, fmt.Errorf("--architecture %s cannot be used with --image or --node-image", opt.Architecture)
+		}
+	default:
+		return nil, fmt.Errorf("invalid value %q for --architecture", opt.Architecture)
+	}
+
 	if opt.DiscoveryStore != "" {
 		discoveryPath, err := clientset.VFSContext().BuildVfsPath(opt.DiscoveryStore)
 		if err != nil {
@@ -390,11 +410,16 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 		return nil, err
 	}
 
+	setupAccess(cluster)
+
 	controlPlanes, err := setupControlPlane(opt, cluster, zoneToSubnetsMap)
 	if err != nil {
 		return nil, err
 	}
 
+	// igArchitectures holds the architecture of the instance groups that must use a specific architecture
+	igArchitectures := make(map[*api.InstanceGroup]architectures.Architecture)
+
 	var nodes []*api.InstanceGroup
 
 	switch opt.InstanceManager {
@@ -410,7 +435,7 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 			return nil, err
 		}
 	case "cloudgroups":
-		nodes, err = setupNodes(opt, cluster, zoneToSubnetsMap)
+		nodes, err = setupNodes(opt, cluster, zoneToSubnetsMap, igArchitectures)
 		if err != nil {
 			return nil, err
 		}
@@ -433,6 +458,19 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 	instanceGroups = append(instanceGroups, nodes...)
 	instanceGroups = append(instanceGroups, bastions...)
 
+	for _, instanceGroup := range instanceGroups {
+		if _, found := igArchitectures[instanceGroup]; found {
+			continue
+		}
+		switch opt.Architecture {
+		case string(architectures.ArchitectureAmd64), string(architectures.ArchitectureArm64):
+			igArchitectures[instanceGroup] = architectures.Architecture(opt.Architecture)
+		case ArchitectureMulti:
+			// Only the nodes use both architectures
+			igArchitectures[instanceGroup] = architectures.ArchitectureAmd64
+		}
+	}
+
 	for _, instanceGroup := range instanceGroups {
 		g := instanceGroup
 		ig := g
@@ -440,9 +478,12 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 			if opt.Image != "" {
 				instanceGroup.Spec.Image = opt.Image
 			} else {
-				architecture, err := MachineArchitecture(cloud, instanceGroup.Spec.MachineType)
-				if err != nil {
-					return nil, err
+				architecture, found := igArchitectures[instanceGroup]
+				if !found {
+					architecture, err = MachineArchitecture(cloud, instanceGroup.Spec.MachineType)
+					if err != nil {
+						return nil, err
+					}
 				}
 				instanceGroup.Spec.Image, err = defaultImage(cluster, channel, architecture)
 				if err != nil {
@@ -451,6 +492,13 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 			}
 		}
 
+		if g.Spec.MachineType == "" && igArchitectures[g] == architectures.ArchitectureArm64 {
+			g.Spec.MachineType, err = defaultArm64MachineType(cloud, cluster, g)
+			if err != nil {
+				return nil, fmt.Errorf("error assigning default arm64 machine type: %v", err)
+			}
+		}
+
 		// TODO: Clean up
 		if g.IsControlPlane() {
 			if g.Spec.MachineType == "" {
@@ -480,6 +528,16 @@ func NewCluster(opt *NewClusterOptions, clientset simple.Clientset) (*NewCluster
 
 		}
 
+		if architecture, found := igArchitectures[g]; found && g.Spec.MachineType != "" {
+			machineArchitecture, err := MachineArchitecture(cloud, g.Spec.MachineType)
+			if err != nil {
+				return nil, err
+			}
+			if machineArchitecture != architecture {
+				return nil, fmt.Errorf("machine type %q of instance group %q is %s, but %s is required by --architecture", g.Spec.MachineType, g.ObjectMeta.Name, machineArchitecture, architecture)
+			}
+		}
+
 		if ig.Spec.Tenancy != "" && ig.Spec.Tenancy != "default" {
 			switch cluster.Spec.GetCloudProvider() {
 			case api.CloudProviderAWS:
@@ -713,7 +771,7 @@ func setupZones(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.Stri
 
 	case api.CloudProviderAWS:
 		if len(opt.Zones) > 0 && len(opt.SubnetIDs) > 0 {
-			zoneToSubnetProviderID, err = getAWSZoneToSubnetProviderID(cluster.Spec.Networking.NetworkID, opt.Zones[0][:len(opt.Zones[0])-1], opt.SubnetIDs)
+			zoneToSubnetProviderID, err = getAWSZoneToSubnetProviderID(cluster.Spec.Networking.NetworkID, awsup.RegionFromZone(opt.Zones[0]), opt.SubnetIDs)
 			if err != nil {
 				return nil, err
 			}
@@ -1013,24 +1071,35 @@ func trimCommonPrefix(names []string) []string {
 	return names
 }
 
-func setupNodes(opt *NewClusterOptions, cluster *api.Cluster, zoneToSubnetsMap map[string][]*api.ClusterSubnetSpec) ([]*api.InstanceGroup, error) {
+func setupNodes(opt *NewClusterOptions, cluster *api.Cluster, zoneToSubnetsMap map[string][]*api.ClusterSubnetSpec, igArchitectures map[*api.InstanceGroup]architectures.Architecture) ([]*api.InstanceGroup, error) {
 	cloudProvider := cluster.Spec.GetCloudProvider()
 
 	var nodes []*api.InstanceGroup
 
-	// The node count is the number of zones unless explicitly set
-	// We then divvy up amongst the zones
-	numZones := len(opt.Zones)
+	// With multiple architectures, every zone gets a node instance group per architecture
+	var nodeArchitectures []architectures.Architecture
+	if opt.Architecture == ArchitectureMulti {
+		nodeArchitectures = []architectures.Architecture{architectures.ArchitectureAmd64, architectures.ArchitectureArm64}
+	}
+	igsPerZone := len(nodeArchitectures)
+	if igsPerZone == 0 {
+		igsPerZone = 1
+	}
+
+	// The node count is the number of instance groups unless explicitly set
+	// We then divvy up amongst the instance groups
+	numIGs := len(opt.Zones) * igsPerZone
 	nodeCount := opt.NodeCount
 	if nodeCount == 0 {
-		// If node count is not specified, default to the number of zones
-		nodeCount = int32(numZones)
+		// If node count is not specified, default to one node per instance group
+		nodeCount = int32(numIGs)
 	}
 
-	countPerIG := nodeCount / int32(numZones)
-	remainder := int(nodeCount) % numZones
+	countPerIG := nodeCount / int32(numIGs)
+	remainder := int(nodeCount) % numIGs
 
-	for i, zone := range opt.Zones {
+	for i := 0; i < numIGs; i++ {
+		zone := opt.Zones[i%len(opt.Zones)]
 		count := countPerIG
 		if i < remainder {
 			count++
@@ -1042,6 +1111,13 @@ func setupNodes(opt *NewClusterOptions, cluster *api.Cluster, zoneToSubnetsMap m
 		g.Spec.MaxSize = fi.PtrTo(count)
 		g.ObjectMeta.Name = "nodes-" + zone
 
+		var architecture architectures.Architecture
+		if len(nodeArchitectures) != 0 {
+			architecture = nodeArchitectures[i/len(opt.Zones)]
+			igArchitectures[g] = architecture
+			g.ObjectMeta.Name = "nodes-" + string(architecture) + "-" + zone
+		}
+
 		subnets := zoneToSubnetsMap[zone]
 		switch len(subnets) {
 		case 0:
@@ -1077,16 +1153,19 @@ func setupNodes(opt *NewClusterOptions, cluster *api.Cluster, zoneToSubnetsMap m
 			}
 		}
 
-		for i, size := range opt.NodeSizes {
-			if i == 0 {
-				g.Spec.MachineType = size
-			}
-			if cloudProvider == api.CloudProviderAWS && len(opt.NodeSizes) > 1 {
-				if g.Spec.MixedInstancesPolicy == nil {
-					g.Spec.MixedInstancesPolicy = &api.MixedInstancesPolicySpec{}
+		// With multiple architectures, the node sizes are for the amd64 nodes; the arm64 nodes use the default machine type
+		if architecture != architectures.ArchitectureArm64 {
+			for i, size := range opt.NodeSizes {
+				if i == 0 {
+					g.Spec.MachineType = size
+				}
+				if cloudProvider == api.CloudProviderAWS && len(opt.NodeSizes) > 1 {
+					if g.Spec.MixedInstancesPolicy == nil {
+						g.Spec.MixedInstancesPolicy = &api.MixedInstancesPolicySpec{}
 
+					}
+					g.Spec.MixedInstancesPolicy.Instances = append(g.Spec.MixedInstancesPolicy.Instances, size)
 				}
-				g.Spec.MixedInstancesPolicy.Instances = append(g.Spec.MixedInstancesPolicy.Instances, size)
 			}
 		}
 		g.Spec.Image = opt.NodeImage
@@ -1218,6 +1297,27 @@ func setupNetworking(opt *NewClusterOptions, cluster *api.Cluster) error {
 	return nil
 }
 
+// setupAccess removes the IPv6 allow-all CIDR from the access lists of clusters without IPv6.
+// Shared VPCs are not associated with an IPv6 CIDR block by kOps, so it would be rejected by validation.
+func setupAccess(cluster *api.Cluster) {
+	if cluster.Spec.GetCloudProvider() != api.CloudProviderAWS || cluster.Spec.Networking.NetworkID == "" || cluster.Spec.IsIPv6Only() {
+		return
+	}
+	withoutIPv6AllowAll := func(cidrs []string) []string {
+		var result []string
+		for _, cidr := range cidrs {
+			if cidr == "::/0" {
+				klog.Infof("not allowing access from %q, as shared VPC %q has no IPv6", cidr, cluster.Spec.Networking.NetworkID)
+				continue
+			}
+			result = append(result, cidr)
+		}
+		return result
+	}
+	cluster.Spec.API.Access = withoutIPv6AllowAll(cluster.Spec.API.Access)
+	cluster.Spec.SSHAccess = withoutIPv6AllowAll(cluster.Spec.SSHAccess)
+}
+
 func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.String) ([]*api.InstanceGroup, error) {
 	var bastions []*api.InstanceGroup
 
@@ -1237,6 +1337,10 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 			return nil, fmt.Errorf("bastion supports --topology='private' only")
 		}
 
+		if opt.Egress != "" {
+			return nil, fmt.Errorf("egress supports --topology='private' only")
+		}
+
 		for i := range cluster.Spec.Networking.Subnets {
 			cluster.Spec.Networking.Subnets[i].Type = api.SubnetTypePublic
 		}
@@ -1246,6 +1350,10 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 			return nil, fmt.Errorf("invalid networking option %s. Kubenet does not support private topology", opt.Networking)
 		}
 
+		if opt.Egress != "" && cluster.Spec.GetCloudProvider() != api.CloudProviderAWS {
+			return nil, fmt.Errorf("egress is only supported on AWS")
+		}
+
 		for i := range cluster.Spec.Networking.Subnets {
 			cluster.Spec.Networking.Subnets[i].Type = api.SubnetTypePrivate
 		}
@@ -1255,7 +1363,7 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 		if len(opt.Zones) > 0 && len(opt.UtilitySubnetIDs) > 0 {
 			switch cluster.Spec.GetCloudProvider() {
 			case api.CloudProviderAWS:
-				zoneToSubnetProviderID, err = getAWSZoneToSubnetProviderID(cluster.Spec.Networking.NetworkID, opt.Zones[0][:len(opt.Zones[0])-1], opt.UtilitySubnetIDs)
+				zoneToSubnetProviderID, err = getAWSZoneToSubnetProviderID(cluster.Spec.Networking.NetworkID, awsup.RegionFromZone(opt.Zones[0]), opt.UtilitySubnetIDs)
 				if err != nil {
 					return nil, err
 				}
@@ -1279,6 +1387,7 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 					Zone:   s.Zone,
 					Type:   api.SubnetTypeDualStack,
 					Region: s.Region,
+					Egress: s.Egress,
 				}
 				if subnetID, ok := zoneToSubnetProviderID[s.Zone]; ok {
 					subnet.ID = subnetID
@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/kops/validation"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/nodelabels"
@@ -153,6 +154,18 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 		}
 	}
 
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		ig.Spec.InstanceMetadata = cluster.Spec.CloudProvider.AWS.InstanceMetadata.ResolveDefaults(ig)
+	}
+
+	if len(ig.Spec.Subnets) == 0 && len(ig.Spec.SubnetSelectors) != 0 {
+		ig.Spec.Subnets = ig.ResolvedSubnets(cluster)
+		if len(ig.Spec.Subnets) == 0 {
+			return nil, fmt.Errorf("subnetSelectors of InstanceGroup %s did not match any Subnets", ig.ObjectMeta.Name)
+		}
+		ig.Spec.SubnetSelectors = nil
+	}
+
 	if ig.IsControlPlane() {
 		if len(ig.Spec.Subnets) == 0 {
 			return nil, fmt.Errorf("control-plane InstanceGroup %s did not specify any Subnets", ig.ObjectMeta.Name)
@@ -292,6 +305,12 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 
 	igKubeletConfig.Taints = taints.List()
 
+	if ig.Spec.Swap != nil {
+		// The kubelet refuses to start on a node with swap unless told otherwise
+		igKubeletConfig.FailSwapOn = fi.PtrTo(false)
+		igKubeletConfig.MemorySwapBehavior = ig.Spec.Swap.ResolvedBehavior()
+	}
+
 	if useSecureKubelet {
 		igKubeletConfig.AnonymousAuth = fi.PtrTo(false)
 	}
@@ -381,3 +400,54 @@ func defaultMachineType(cloud fi.Cloud, cluster *kops.Cluster, ig *kops.Instance
 	klog.V(2).Infof("Cannot set default MachineType for CloudProvider=%q, Role=%q", cluster.Spec.GetCloudProvider(), ig.Spec.Role)
 	return "", nil
 }
+
+// defaultArm64MachineType returns the default arm64 MachineType for the instance group.
+// Only AWS is supported; the first candidate offered in all the zones of the instance group is used.
+func defaultArm64MachineType(cloud fi.Cloud, cluster *kops.Cluster, ig *kops.InstanceGroup) (string, error) {
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return "", fmt.Errorf("arm64 machine types are only supported on AWS")
+	}
+	if ig.Spec.Manager == kops.InstanceManagerKarpenter {
+		return "", nil
+	}
+
+	var candidates []string
+	switch ig.Spec.Role {
+	case kops.InstanceGroupRoleControlPlane, kops.InstanceGroupRoleNode, kops.InstanceGroupRoleAPIServer:
+		// t4g.medium is the arm64 counterpart of t3.medium, m6g.large and c6g.large are for zones that don't offer it
+		candidates = []string{"t4g.medium", "m6g.large", "c6g.large"}
+	case kops.InstanceGroupRoleBastion:
+		candidates = []string{"t4g.micro", "t4g.small"}
+	default:
+		return "", fmt.Errorf("unhandled role %q", ig.Spec.Role)
+	}
+
+	zones, err := model.FindZonesForInstanceGroup(cluster, ig)
+	if err != nil {
+		return "", err
+	}
+
+	offered := make(map[string]sets.Set[string])
+	for _, zone := range zones {
+		instanceTypes, err := cloud.(awsup.AWSCloud).InstanceTypesOfferedInZone(zone)
+		if err != nil {
+			return "", err
+		}
+		offered[zone] = instanceTypes
+	}
+
+	for _, candidate := range candidates {
+		inAllZones := true
+		for _, zone := range zones {
+			if !offered[zone].Has(candidate) {
+				inAllZones = false
+				break
+			}
+		}
+		if inAllZones {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("none of the arm64 instance types %v is offered in all the zones %v of instance group %q", candidates, zones, ig.ObjectMeta.Name)
+}
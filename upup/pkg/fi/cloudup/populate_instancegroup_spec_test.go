@@ -235,6 +235,105 @@ func TestPopulateInstanceGroup_AddTaints(t *testing.T) {
 	}
 }
 
+func TestPopulateInstanceGroup_InstanceMetadata(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	cluster.Spec.CloudProvider.AWS.InstanceMetadata = &kopsapi.InstanceMetadataOptions{
+		HTTPPutResponseHopLimit: fi.PtrTo(int64(1)),
+		HTTPTokens:              fi.PtrTo("required"),
+	}
+
+	channel := &kopsapi.Channel{}
+
+	cloud, err := BuildCloud(cluster)
+	if err != nil {
+		t.Fatalf("error from BuildCloud: %v", err)
+	}
+
+	inherited := buildMinimalNodeInstanceGroup()
+	output, err := PopulateInstanceGroupSpec(cluster, inherited, cloud, channel)
+	if err != nil {
+		t.Fatalf("error from PopulateInstanceGroupSpec: %v", err)
+	}
+	if fi.ValueOf(output.Spec.InstanceMetadata.HTTPTokens) != "required" || fi.ValueOf(output.Spec.InstanceMetadata.HTTPPutResponseHopLimit) != 1 {
+		t.Errorf("Unexpected inherited instance metadata %+v", *output.Spec.InstanceMetadata)
+	}
+
+	override := buildMinimalNodeInstanceGroup()
+	override.Spec.InstanceMetadata = &kopsapi.InstanceMetadataOptions{
+		HTTPPutResponseHopLimit: fi.PtrTo(int64(3)),
+	}
+	output, err = PopulateInstanceGroupSpec(cluster, override, cloud, channel)
+	if err != nil {
+		t.Fatalf("error from PopulateInstanceGroupSpec: %v", err)
+	}
+	if fi.ValueOf(output.Spec.InstanceMetadata.HTTPTokens) != "required" || fi.ValueOf(output.Spec.InstanceMetadata.HTTPPutResponseHopLimit) != 3 {
+		t.Errorf("Unexpected overridden instance metadata %+v", *output.Spec.InstanceMetadata)
+	}
+	if fi.ValueOf(override.Spec.InstanceMetadata.HTTPTokens) != "" {
+		t.Errorf("PopulateInstanceGroupSpec modified its input")
+	}
+}
+
+func TestPopulateInstanceGroup_Swap(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	input := buildMinimalNodeInstanceGroup()
+	input.Spec.Swap = &kopsapi.SwapSpec{SizeMB: fi.PtrTo(int32(1024))}
+
+	channel := &kopsapi.Channel{}
+
+	cloud, err := BuildCloud(cluster)
+	if err != nil {
+		t.Fatalf("error from BuildCloud: %v", err)
+	}
+	output, err := PopulateInstanceGroupSpec(cluster, input, cloud, channel)
+	if err != nil {
+		t.Fatalf("error from PopulateInstanceGroupSpec: %v", err)
+	}
+	if output.Spec.Kubelet.FailSwapOn == nil || *output.Spec.Kubelet.FailSwapOn {
+		t.Errorf("Expected failSwapOn to be false, got %v", output.Spec.Kubelet.FailSwapOn)
+	}
+	if output.Spec.Kubelet.MemorySwapBehavior != kopsapi.SwapBehaviorLimitedSwap {
+		t.Errorf("Unexpected memorySwapBehavior %q", output.Spec.Kubelet.MemorySwapBehavior)
+	}
+}
+
+func TestPopulateInstanceGroup_SubnetSelectors(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	input := buildMinimalNodeInstanceGroup()
+	input.Spec.SubnetSelectors = []kopsapi.SubnetSelectorSpec{
+		{Type: kopsapi.SubnetTypePublic, Zones: []string{"us-test-1a", "us-test-1c"}},
+	}
+
+	channel := &kopsapi.Channel{}
+
+	cloud, err := BuildCloud(cluster)
+	if err != nil {
+		t.Fatalf("error from BuildCloud: %v", err)
+	}
+	output, err := PopulateInstanceGroupSpec(cluster, input, cloud, channel)
+	if err != nil {
+		t.Fatalf("error from PopulateInstanceGroupSpec: %v", err)
+	}
+	if actual := strings.Join(output.Spec.Subnets, ","); actual != "subnet-us-test-1a,subnet-us-test-1c" {
+		t.Errorf("Unexpected subnets %q", actual)
+	}
+	if output.Spec.SubnetSelectors != nil {
+		t.Errorf("Expected subnetSelectors to be cleared, got %v", output.Spec.SubnetSelectors)
+	}
+}
+
+func TestPopulateInstanceGroup_SubnetSelectorsNoMatch(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	input := buildMinimalNodeInstanceGroup()
+	input.Spec.SubnetSelectors = []kopsapi.SubnetSelectorSpec{
+		{Type: kopsapi.SubnetTypePrivate},
+	}
+
+	channel := &kopsapi.Channel{}
+
+	expectErrorFromPopulateInstanceGroup(t, cluster, input, channel, "did not match any Subnets")
+}
+
 func expectErrorFromPopulateInstanceGroup(t *testing.T, cluster *kopsapi.Cluster, g *kopsapi.InstanceGroup, channel *kopsapi.Channel, message string) {
 	cloud, err := BuildCloud(cluster)
 	if err != nil {
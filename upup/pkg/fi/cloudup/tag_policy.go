@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// applyTagPolicy removes the tags that the cluster's tag policy does not allow from the tasks,
+// and returns the keys of the removed tags by task key.
+func applyTagPolicy(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, taskMap map[string]fi.CloudupTask) map[string][]string {
+	policy := cluster.Spec.TagPolicy
+	if policy == nil || len(policy.Rules) == 0 {
+		return nil
+	}
+
+	// Instance groups can add cloudLabels of their own
+	cloudLabels := make(map[string]string)
+	for k, v := range cluster.Spec.CloudLabels {
+		cloudLabels[k] = v
+	}
+	for _, ig := range instanceGroups {
+		for k, v := range ig.Spec.CloudLabels {
+			cloudLabels[k] = v
+		}
+	}
+
+	removals := make(map[string][]string)
+	for key, task := range taskMap {
+		resource, ok := task.(awsup.TagPolicyResource)
+		if !ok {
+			continue
+		}
+
+		for resourceType, removed := range awsup.ApplyTagPolicy(policy, resource, cloudLabels) {
+			// Tasks whose tags are filtered in more than one scope, such as launch templates, report each of them
+			removalKey := key
+			if _, scope, found := strings.Cut(resourceType, "/"); found {
+				removalKey = key + " (" + scope + ")"
+			}
+			klog.V(2).Infof("tag policy removed tags %v from %s", removed, removalKey)
+			removals[removalKey] = removed
+		}
+	}
+
+	return removals
+}
+
+// printTagPolicyRemovals prints the tags that the tag policy removed, for the dry-run report.
+func printTagPolicyRemovals(out io.Writer, removals map[string][]string) {
+	if len(removals) == 0 {
+		return
+	}
+
+	var keys []string
+	for key := range removals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(out, "Tags filtered by tagPolicy:\n")
+	for _, key := range keys {
+		fmt.Fprintf(out, "  %s\t%s\n", key, strings.Join(removals[key], ", "))
+	}
+	fmt.Fprintf(out, "\n")
+}
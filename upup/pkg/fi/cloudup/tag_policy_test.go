@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+func TestApplyTagPolicy(t *testing.T) {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			CloudLabels: map[string]string{
+				"map-migrated": "mig123",
+			},
+			TagPolicy: &kops.TagPolicySpec{
+				Rules: []kops.TagPolicyRule{
+					{
+						Tags:    []string{"map-migrated"},
+						Include: []string{"LaunchTemplate/volume"},
+					},
+					{
+						Tags:    []string{"*"},
+						Exclude: []string{"Subnet"},
+					},
+				},
+			},
+		},
+	}
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			Spec: kops.InstanceGroupSpec{
+				CloudLabels: map[string]string{
+					"team": "platform",
+				},
+			},
+		},
+	}
+
+	tags := map[string]string{
+		"Name":         "example",
+		"map-migrated": "mig123",
+		"team":         "platform",
+	}
+	securityGroup := &awstasks.SecurityGroup{Name: fi.PtrTo("nodes"), Tags: tags}
+	launchTemplate := &awstasks.LaunchTemplate{Name: fi.PtrTo("nodes"), Tags: tags}
+	subnet := &awstasks.Subnet{Name: fi.PtrTo("us-east-1a"), Tags: tags}
+	taskMap := map[string]fi.CloudupTask{
+		"SecurityGroup/nodes":  securityGroup,
+		"LaunchTemplate/nodes": launchTemplate,
+		"Subnet/us-east-1a":    subnet,
+	}
+
+	removals := applyTagPolicy(cluster, instanceGroups, taskMap)
+
+	expectedRemovals := map[string][]string{
+		"LaunchTemplate/nodes":            {"map-migrated"},
+		"LaunchTemplate/nodes (instance)": {"map-migrated"},
+		"SecurityGroup/nodes":             {"map-migrated"},
+		"Subnet/us-east-1a":               {"map-migrated", "team"},
+	}
+	if !reflect.DeepEqual(removals, expectedRemovals) {
+		t.Errorf("unexpected removals: expected %v, got %v", expectedRemovals, removals)
+	}
+
+	if !reflect.DeepEqual(securityGroup.Tags, map[string]string{"Name": "example", "team": "platform"}) {
+		t.Errorf("unexpected SecurityGroup tags: %v", securityGroup.Tags)
+	}
+	if !reflect.DeepEqual(launchTemplate.Tags, map[string]string{"Name": "example", "team": "platform"}) {
+		t.Errorf("unexpected LaunchTemplate tags: %v", launchTemplate.Tags)
+	}
+	if !reflect.DeepEqual(launchTemplate.InstanceTags, map[string]string{"Name": "example", "team": "platform"}) {
+		t.Errorf("unexpected LaunchTemplate instance tags: %v", launchTemplate.InstanceTags)
+	}
+	if !reflect.DeepEqual(launchTemplate.VolumeTags, tags) {
+		t.Errorf("unexpected LaunchTemplate volume tags: %v", launchTemplate.VolumeTags)
+	}
+	if !reflect.DeepEqual(subnet.Tags, map[string]string{"Name": "example"}) {
+		t.Errorf("unexpected Subnet tags: %v", subnet.Tags)
+	}
+
+	var out bytes.Buffer
+	printTagPolicyRemovals(&out, removals)
+	expectedOutput := "Tags filtered by tagPolicy:\n" +
+		"  LaunchTemplate/nodes\tmap-migrated\n" +
+		"  LaunchTemplate/nodes (instance)\tmap-migrated\n" +
+		"  SecurityGroup/nodes\tmap-migrated\n" +
+		"  Subnet/us-east-1a\tmap-migrated, team\n" +
+		"\n"
+	if out.String() != expectedOutput {
+		t.Errorf("unexpected output: expected %q, got %q", expectedOutput, out.String())
+	}
+}
@@ -56,6 +56,8 @@ import (
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/components"
+	"k8s.io/kops/pkg/model/components/etcdmanager"
 	"k8s.io/kops/pkg/model/components/kopscontroller"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/resources/spotinst"
@@ -134,6 +136,9 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 		}
 		return false
 	}
+	dest["EtcdStaticDiscoveryEnabled"] = func() bool {
+		return len(etcdStaticDiscoveryMembers(cluster)) != 0
+	}
 	dest["GossipName"] = func() bool {
 		if dns.IsGossipClusterName(cluster.Name) {
 			return true
@@ -151,6 +156,12 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 		return fmt.Sprintf("%d", wellknownports.NodeLocalDNSHealthCheck)
 	}
 
+	dest["KopsControllerPort"] = func() int {
+		return wellknownports.KopsControllerPort
+	}
+	dest["DefaultComponentNetworkPolicyPodCIDRs"] = tf.defaultComponentNetworkPolicyPodCIDRs
+	dest["DefaultComponentNetworkPolicyNodeCIDRs"] = tf.defaultComponentNetworkPolicyNodeCIDRs
+
 	dest["KopsControllerArgv"] = tf.KopsControllerArgv
 	dest["KopsControllerConfig"] = tf.KopsControllerConfig
 	kopscontroller.AddTemplateFunctions(cluster, dest)
@@ -230,6 +241,17 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 
 	if cluster.Spec.Networking.AmazonVPC != nil {
 		c := cluster.Spec.Networking.AmazonVPC
+		networkPolicyAgentEnabled := c.NetworkPolicyAgent != nil && fi.ValueOf(c.NetworkPolicyAgent.Enabled)
+		dest["AmazonVpcNetworkPolicyAgentEnabled"] = func() bool {
+			return networkPolicyAgentEnabled
+		}
+		dest["AmazonVpcVersion"] = func() string {
+			// The network policy agent was introduced in v1.14
+			if networkPolicyAgentEnabled {
+				return "v1.14.1"
+			}
+			return "v1.13.4"
+		}
 		dest["AmazonVpcEnvVars"] = func() map[string]string {
 			envVars := map[string]string{
 				// Use defaults from the official AWS VPC CNI Helm chart:
@@ -254,6 +276,18 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 				"WARM_PREFIX_TARGET":                    "1",
 				"DISABLE_NETWORK_RESOURCE_PROVISIONING": "false",
 			}
+			if t := c.PrefixTargets; t != nil {
+				envVars["ENABLE_PREFIX_DELEGATION"] = "true"
+				if t.WarmPrefixTarget != nil {
+					envVars["WARM_PREFIX_TARGET"] = strconv.Itoa(int(*t.WarmPrefixTarget))
+				}
+				if t.WarmIPTarget != nil {
+					envVars["WARM_IP_TARGET"] = strconv.Itoa(int(*t.WarmIPTarget))
+				}
+				if t.MinimumIPTarget != nil {
+					envVars["MINIMUM_IP_TARGET"] = strconv.Itoa(int(*t.MinimumIPTarget))
+				}
+			}
 			for _, e := range c.Env {
 				envVars[e.Name] = e.Value
 			}
@@ -388,6 +422,13 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 		return sc != nil && fi.ValueOf(sc.Enabled)
 	}
 
+	dest["KubeStateMetricsAPIGroups"] = func() map[string][]string {
+		if cluster.Spec.Metrics == nil || cluster.Spec.Metrics.KubeStateMetrics == nil {
+			return nil
+		}
+		return components.KubeStateMetricsAPIGroups(cluster.Spec.Metrics.KubeStateMetrics.Resources)
+	}
+
 	dest["IsKubernetesGTE"] = tf.IsKubernetesGTE
 	dest["IsKubernetesLT"] = tf.IsKubernetesLT
 
@@ -661,6 +702,15 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		config.CacheNodeidentityInfo = true
 	}
 
+	if cluster.Spec.KopsController != nil && cluster.Spec.KopsController.LeaderElection != nil {
+		leaderElection := cluster.Spec.KopsController.LeaderElection
+		config.LeaderElection = &kopscontrollerconfig.LeaderElectionOptions{
+			LeaseDuration: leaderElection.LeaderElectLeaseDuration,
+			RenewDeadline: leaderElection.LeaderElectRenewDeadlineDuration,
+			RetryPeriod:   leaderElection.LeaderElectRetryPeriod,
+		}
+	}
+
 	{
 		certNames := []string{"kubelet", "kubelet-server"}
 		signingCAs := []string{fi.CertificateIDCA}
@@ -757,6 +807,17 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		config.EnableCloudIPAM = true
 	}
 
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && cluster.Spec.API.PublicIPs != nil {
+		config.ControlPlaneElasticIPs = cluster.Spec.API.PublicIPs.AllocationIDs
+	}
+
+	if members := etcdStaticDiscoveryMembers(cluster); len(members) != 0 {
+		config.EtcdDiscovery = &kopscontrollerconfig.EtcdDiscoveryOptions{
+			ConfigMapName: etcdmanager.DiscoveryConfigMapName,
+			Members:       members,
+		}
+	}
+
 	if cluster.UsesLegacyGossip() {
 		config.Discovery = &kopscontrollerconfig.DiscoveryOptions{
 			Enabled: true,
@@ -772,6 +833,21 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 	return string(b), nil
 }
 
+// etcdStaticDiscoveryMembers maps the DNS name of each member of the etcd clusters using static discovery
+// to the instance group that runs it.
+func etcdStaticDiscoveryMembers(cluster *kops.Cluster) map[string]string {
+	members := make(map[string]string)
+	for _, etcdCluster := range cluster.Spec.EtcdClusters {
+		if etcdCluster.Manager == nil || etcdCluster.Manager.DiscoveryMode != kops.EtcdManagerDiscoveryModeStatic {
+			continue
+		}
+		for _, member := range etcdCluster.Members {
+			members[etcdmanager.MemberDNSName(cluster.Name, etcdCluster, member)] = fi.ValueOf(member.InstanceGroup)
+		}
+	}
+	return members
+}
+
 // KopsControllerArgv returns the args to kops-controller
 func (tf *TemplateFunctions) KopsControllerArgv() ([]string, error) {
 	var argv []string
@@ -1108,3 +1184,30 @@ func (tf *TemplateFunctions) kopsFeatureEnabled(featureName string) (bool, error
 	}
 	return f.Enabled(), nil
 }
+
+// defaultComponentNetworkPolicyNodeCIDRs returns the CIDRs of the subnets the nodes of the cluster run in.
+func (tf *TemplateFunctions) defaultComponentNetworkPolicyNodeCIDRs() []string {
+	var cidrs []string
+	seen := sets.New[string]()
+	for _, subnet := range tf.Cluster.Spec.Networking.Subnets {
+		if subnet.CIDR == "" || seen.Has(subnet.CIDR) {
+			continue
+		}
+		seen.Insert(subnet.CIDR)
+		cidrs = append(cidrs, subnet.CIDR)
+	}
+	return cidrs
+}
+
+// defaultComponentNetworkPolicyPodCIDRs returns the CIDRs that pods of the cluster get their IPs from.
+func (tf *TemplateFunctions) defaultComponentNetworkPolicyPodCIDRs() []string {
+	networking := &tf.Cluster.Spec.Networking
+	if networking.Cilium != nil && networking.Cilium.IPAM == kops.CiliumIpamEni {
+		// Pods get their IPs from the subnets of the nodes
+		return tf.defaultComponentNetworkPolicyNodeCIDRs()
+	}
+	if networking.PodCIDR != "" {
+		return []string{networking.PodCIDR}
+	}
+	return []string{networking.NonMasqueradeCIDR}
+}
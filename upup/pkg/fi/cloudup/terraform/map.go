@@ -34,7 +34,7 @@ func (m *mapStringLiteral) IsSingleValue() bool {
 	return false
 }
 
-func (m *mapStringLiteral) ToObject() element {
+func (m *mapStringLiteral) ToObject() *object {
 	o := &object{field: make(map[string]element, len(m.members))}
 	for k, v := range m.members {
 		o.field[k] = v
@@ -17,9 +17,15 @@ limitations under the License.
 package terraform
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"sort"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -34,6 +40,9 @@ type TerraformTarget struct {
 
 	ClusterName string
 
+	// ForceOverwrite permits overwriting output files that were modified since they were generated
+	ForceOverwrite bool
+
 	outDir string
 	// extra config to add to the provider block
 	clusterSpecTarget *kops.TargetSpec
@@ -72,6 +81,14 @@ func (t *TerraformTarget) DefaultCheckExisting() bool {
 	return false
 }
 
+// tfGetTerraformSpec is a helper function to get the terraform config with safety checks on the pointers.
+func tfGetTerraformSpec(c *kops.TargetSpec) *kops.TerraformSpec {
+	if c != nil {
+		return c.Terraform
+	}
+	return nil
+}
+
 // tfGetProviderExtraConfig is a helper function to get extra config with safety checks on the pointers.
 func tfGetProviderExtraConfig(c *kops.TargetSpec) map[string]string {
 	if c != nil &&
@@ -95,6 +112,16 @@ func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
 		return err
 	}
 
+	if !t.ForceOverwrite {
+		drifted, err := t.findDriftedFiles()
+		if err != nil {
+			return err
+		}
+		if len(drifted) != 0 {
+			return fmt.Errorf("terraform output files in %s have been modified since they were generated: %s (use --force-overwrite to overwrite them)", t.outDir, strings.Join(drifted, ", "))
+		}
+	}
+
 	for relativePath, contents := range t.Files {
 		p := path.Join(t.outDir, relativePath)
 
@@ -108,7 +135,89 @@ func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
 			return fmt.Errorf("error writing terraform data to output file %q: %v", p, err)
 		}
 	}
+	if err := t.writeManifest(); err != nil {
+		return err
+	}
 	klog.Infof("Terraform output is in %s", t.outDir)
 
 	return nil
 }
+
+// ManifestFileName is the name of the file, in the output directory, recording the hashes of the generated files
+const ManifestFileName = "kops-manifest.json"
+
+// outputManifest records the files we generated, so that we can detect manual edits before overwriting them
+type outputManifest struct {
+	Files []outputManifestFile `json:"files"`
+}
+
+type outputManifestFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// findDriftedFiles returns the files we are about to overwrite whose contents no longer match the previous manifest
+func (t *TerraformTarget) findDriftedFiles() ([]string, error) {
+	p := path.Join(t.outDir, ManifestFileName)
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading terraform manifest %q: %v", p, err)
+	}
+
+	manifest := &outputManifest{}
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("error parsing terraform manifest %q: %v", p, err)
+	}
+
+	var drifted []string
+	for _, f := range manifest.Files {
+		if _, found := t.Files[f.Path]; !found {
+			continue
+		}
+		contents, err := os.ReadFile(path.Join(t.outDir, f.Path))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading terraform output file %q: %v", f.Path, err)
+		}
+		if sha256Hex(contents) != f.SHA256 {
+			drifted = append(drifted, f.Path)
+		}
+	}
+	sort.Strings(drifted)
+	return drifted, nil
+}
+
+// writeManifest records the hashes of the files we generated
+func (t *TerraformTarget) writeManifest() error {
+	manifest := &outputManifest{}
+	for relativePath, contents := range t.Files {
+		manifest.Files = append(manifest.Files, outputManifestFile{
+			Path:   relativePath,
+			SHA256: sha256Hex(contents),
+		})
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error building terraform manifest: %v", err)
+	}
+
+	p := path.Join(t.outDir, ManifestFileName)
+	if err := os.WriteFile(p, append(b, '\n'), os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("error writing terraform manifest %q: %v", p, err)
+	}
+	return nil
+}
@@ -20,10 +20,12 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -37,23 +39,24 @@ func (t *TerraformTarget) finishHCL2() error {
 	}
 	writeLocalsOutputs(buf, outputs)
 
-	t.writeProviders(buf)
+	provider := t.cloudProvider()
+	t.writeProviders(buf, provider)
 
 	resourcesByType, err := t.GetResourcesByType()
 	if err != nil {
 		return err
 	}
 
-	t.writeResources(buf, resourcesByType)
+	t.writeResources(buf, provider, resourcesByType)
 
 	dataSourcesByType, err := t.GetDataSourcesByType()
 	if err != nil {
 		return err
 	}
 
-	t.writeDataSources(buf, dataSourcesByType)
+	t.writeDataSources(buf, provider, dataSourcesByType)
 
-	t.writeTerraform(buf)
+	t.writeTerraform(buf, provider)
 
 	t.Files["kubernetes.tf"] = buf.Bytes()
 
@@ -111,51 +114,118 @@ func writeLocalsOutputs(buf *bytes.Buffer, outputs map[string]terraformWriter.Ou
 	return
 }
 
-func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
-	providerName := string(t.Cloud.ProviderID())
+// cloudProvider returns the definition of the provider for the cloud of the cluster
+func (t *TerraformTarget) cloudProvider() *terraformWriter.TerraformProvider {
+	provider := &terraformWriter.TerraformProvider{
+		Name:      string(t.Cloud.ProviderID()),
+		Arguments: map[string]string{},
+	}
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
-		providerName = "google"
+		provider.Name = "google"
 	}
 	if t.Cloud.ProviderID() == kops.CloudProviderHetzner {
-		providerName = "hcloud"
+		provider.Name = "hcloud"
 	}
-	providerBody := map[string]string{}
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
-		providerBody["project"] = t.Project
+		provider.Arguments["project"] = t.Project
 	}
 	if t.Cloud.ProviderID() != kops.CloudProviderHetzner && t.Cloud.ProviderID() != kops.CloudProviderDO {
-		providerBody["region"] = t.Cloud.Region()
+		provider.Arguments["region"] = t.Cloud.Region()
 	}
 	if t.Cloud.ProviderID() == kops.CloudProviderScaleway {
-		providerBody["zone"] = t.Cloud.(scaleway.ScwCloud).Zone()
+		provider.Arguments["zone"] = t.Cloud.(scaleway.ScwCloud).Zone()
 	}
 	for k, v := range tfGetProviderExtraConfig(t.clusterSpecTarget) {
-		providerBody[k] = v
+		provider.Arguments[k] = v
 	}
-	mapToElement(providerBody).
-		ToObject().
-		Write(buf, 0, fmt.Sprintf("provider %q", providerName))
+
+	if tf := tfGetTerraformSpec(t.clusterSpecTarget); tf != nil {
+		provider.Alias = tf.ProviderAlias
+		if tf.AssumeRole != nil {
+			provider.AssumeRole = &terraformWriter.TerraformProviderAssumeRole{
+				RoleARN: fi.PtrTo(tf.AssumeRole.RoleARN),
+			}
+			if tf.AssumeRole.SessionName != "" {
+				provider.AssumeRole.SessionName = fi.PtrTo(tf.AssumeRole.SessionName)
+			}
+			if tf.AssumeRole.ExternalID != "" {
+				provider.AssumeRole.ExternalID = fi.PtrTo(tf.AssumeRole.ExternalID)
+			}
+		}
+		provider.DefaultTags = tf.DefaultTags
+	}
+
+	return provider
+}
+
+type terraformProviderDefaultTags struct {
+	Tags map[string]string `cty:"tags"`
+}
+
+// writeProvider writes the definition of a provider
+// Example:
+//
+//	provider "aws" {
+//	  alias = "cluster"
+//	  assume_role {
+//	    role_arn = "arn:aws:iam::123456789012:role/kops"
+//	  }
+//	  region = "us-test-1"
+//	}
+func writeProvider(buf *bytes.Buffer, provider *terraformWriter.TerraformProvider) {
+	o := mapToElement(provider.Arguments).ToObject()
+	if provider.Alias != "" {
+		o.field["alias"] = terraformWriter.LiteralFromStringValue(provider.Alias)
+	}
+	if provider.AssumeRole != nil {
+		o.field["assume_role"] = toElement(provider.AssumeRole)
+	}
+	if len(provider.DefaultTags) != 0 {
+		o.field["default_tags"] = toElement(&terraformProviderDefaultTags{Tags: provider.DefaultTags})
+	}
+	o.Write(buf, 0, fmt.Sprintf("provider %q", provider.Name))
 	buf.WriteString("\n")
+}
+
+func (t *TerraformTarget) writeProviders(buf *bytes.Buffer, cloudProvider *terraformWriter.TerraformProvider) {
+	writeProvider(buf, cloudProvider)
 
 	// Add any additional provider definition for managed files
 	keys := sortedKeysForMap(t.TerraformWriter.Providers)
 	for _, key := range keys {
 		provider := t.TerraformWriter.Providers[key]
-		providerBody := map[string]string{}
-		providerBody["alias"] = "files"
+		arguments := map[string]string{}
 		for k, v := range provider.Arguments {
-			providerBody[k] = v
+			arguments[k] = v
 		}
 		for k, v := range tfGetFilesProviderExtraConfig(t.clusterSpecTarget) {
-			providerBody[k] = v
+			arguments[k] = v
 		}
-		mapToElement(providerBody).
-			ToObject().
-			Write(buf, 0, fmt.Sprintf("provider %q", provider.Name))
-		buf.WriteString("\n")
+		writeProvider(buf, &terraformWriter.TerraformProvider{
+			Name:      provider.Name,
+			Alias:     "files",
+			Arguments: arguments,
+		})
 	}
 }
 
+// withProvider makes a resource or data source reference the cloud provider, if that provider is aliased.
+// Items that already select a provider, such as managed files, are left alone.
+func withProvider(e element, itemType string, provider *terraformWriter.TerraformProvider) element {
+	ref := provider.Reference()
+	if ref == nil || !strings.HasPrefix(itemType, provider.Name+"_") {
+		return e
+	}
+	o, ok := e.(*object)
+	if !ok {
+		return e
+	}
+	if _, found := o.field["provider"]; !found {
+		o.field["provider"] = ref
+	}
+	return o
+}
+
 func sortedKeysForMap[K ~string, V any](m map[K]V) []K {
 	var keys []K
 	for k := range m {
@@ -165,7 +235,7 @@ func sortedKeysForMap[K ~string, V any](m map[K]V) []K {
 	return keys
 }
 
-func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[string]map[string]interface{}) {
+func (t *TerraformTarget) writeResources(buf *bytes.Buffer, provider *terraformWriter.TerraformProvider, resourcesByType map[string]map[string]interface{}) {
 	resourceTypes := make([]string, 0, len(resourcesByType))
 	for resourceType := range resourcesByType {
 		resourceTypes = append(resourceTypes, resourceType)
@@ -179,14 +249,14 @@ func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[
 		}
 		sort.Strings(resourceNames)
 		for _, resourceName := range resourceNames {
-			toElement(resources[resourceName]).
+			withProvider(toElement(resources[resourceName]), resourceType, provider).
 				Write(buf, 0, fmt.Sprintf("resource %q %q", resourceType, resourceName))
 			buf.WriteString("\n")
 		}
 	}
 }
 
-func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType map[string]map[string]interface{}) {
+func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, provider *terraformWriter.TerraformProvider, dataSourcesByType map[string]map[string]interface{}) {
 	dataSourceTypes := make([]string, 0, len(dataSourcesByType))
 	for dataSourceType := range dataSourcesByType {
 		dataSourceTypes = append(dataSourceTypes, dataSourceType)
@@ -200,14 +270,14 @@ func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType
 		}
 		sort.Strings(dataSourceNames)
 		for _, dataSourceName := range dataSourceNames {
-			toElement(dataSources[dataSourceName]).
+			withProvider(toElement(dataSources[dataSourceName]), dataSourceType, provider).
 				Write(buf, 0, fmt.Sprintf("data %q %q", dataSourceType, dataSourceName))
 			buf.WriteString("\n")
 		}
 	}
 }
 
-func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
+func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer, cloudProvider *terraformWriter.TerraformProvider) {
 	buf.WriteString("terraform {\n")
 	buf.WriteString("  required_version = \">= 0.15.0\"\n")
 	buf.WriteString("  required_providers {\n")
@@ -229,6 +299,9 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 		providers["digitalocean"] = true
 	}
 
+	if cloudProvider.Alias != "" {
+		providerAliases[cloudProvider.Name] = append(providerAliases[cloudProvider.Name], cloudProvider.Alias)
+	}
 	for _, tfProvider := range t.TerraformWriter.Providers {
 		providers[tfProvider.Name] = true
 		providerAliases[tfProvider.Name] = append(providerAliases[tfProvider.Name], "files")
@@ -21,7 +21,11 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
@@ -85,3 +89,55 @@ output "key1" {
 		})
 	}
 }
+
+type testResource struct {
+	Name     *string                  `cty:"name"`
+	Provider *terraformWriter.Literal `cty:"provider"`
+}
+
+func TestFinishHCL2Providers(t *testing.T) {
+	cases := []struct {
+		name   string
+		target *kops.TargetSpec
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "alias",
+			target: &kops.TargetSpec{
+				Terraform: &kops.TerraformSpec{
+					ProviderExtraConfig: map[string]string{"profile": "kops"},
+					ProviderAlias:       "cluster",
+					AssumeRole: &kops.TerraformAssumeRoleSpec{
+						RoleARN:     "arn:aws:iam::123456789012:role/kops",
+						SessionName: "kops",
+					},
+					DefaultTags: map[string]string{"team": "platform"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := awsup.BuildMockAWSCloud("us-test-1", "a")
+			target := NewTerraformTarget(cloud, "", t.TempDir(), tc.target)
+			target.EnsureTerraformProvider("aws", map[string]string{"region": "us-test-1"})
+
+			if err := target.RenderResource("aws_vpc", "cluster", &testResource{Name: fi.PtrTo("cluster")}); err != nil {
+				t.Fatalf("unexpected error rendering resource: %v", err)
+			}
+			if err := target.RenderResource("aws_s3_object", "spec", &testResource{Name: fi.PtrTo("spec"), Provider: terraformWriter.LiteralTokens("aws", "files")}); err != nil {
+				t.Fatalf("unexpected error rendering resource: %v", err)
+			}
+			if err := target.RenderDataSource("aws_iam_policy_document", "cluster", &testResource{Name: fi.PtrTo("cluster")}); err != nil {
+				t.Fatalf("unexpected error rendering data source: %v", err)
+			}
+			if err := target.finishHCL2(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			golden.AssertMatchesFile(t, string(target.Files["kubernetes.tf"]), "testdata/providers_"+tc.name+".tf")
+		})
+	}
+}
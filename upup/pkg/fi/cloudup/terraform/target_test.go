@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func renderTestTarget(t *testing.T, outDir string, userData string, forceOverwrite bool) error {
+	cloud := awsup.BuildMockAWSCloud("us-test-1", "a")
+	target := NewTerraformTarget(cloud, "", outDir, nil)
+	target.ForceOverwrite = forceOverwrite
+
+	if _, err := target.AddFileBytes("aws_launch_template", "nodes", "user_data", []byte(userData), false); err != nil {
+		t.Fatalf("unexpected error adding file: %v", err)
+	}
+	return target.Finish(map[string]fi.CloudupTask{})
+}
+
+func readTestFile(t *testing.T, p string) string {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("error reading %q: %v", p, err)
+	}
+	return string(b)
+}
+
+func TestFinishWritesManifest(t *testing.T) {
+	outDir := t.TempDir()
+
+	if err := renderTestTarget(t, outDir, "first", false); err != nil {
+		t.Fatalf("unexpected error from first render: %v", err)
+	}
+
+	manifest := readTestFile(t, filepath.Join(outDir, ManifestFileName))
+	for _, expected := range []string{`"path": "kubernetes.tf"`, `"path": "data/aws_launch_template_nodes_user_data"`} {
+		if !strings.Contains(manifest, expected) {
+			t.Errorf("expected manifest to contain %s, was:\n%s", expected, manifest)
+		}
+	}
+
+	// Regenerating unmodified output should succeed, and pick up the new contents
+	if err := renderTestTarget(t, outDir, "second", false); err != nil {
+		t.Fatalf("unexpected error from clean regeneration: %v", err)
+	}
+	if actual := readTestFile(t, filepath.Join(outDir, "data", "aws_launch_template_nodes_user_data")); actual != "second" {
+		t.Errorf("expected regenerated file to contain %q, was %q", "second", actual)
+	}
+}
+
+func TestFinishDetectsDrift(t *testing.T) {
+	outDir := t.TempDir()
+
+	if err := renderTestTarget(t, outDir, "first", false); err != nil {
+		t.Fatalf("unexpected error from first render: %v", err)
+	}
+
+	dataFile := filepath.Join(outDir, "data", "aws_launch_template_nodes_user_data")
+	if err := os.WriteFile(dataFile, []byte("edited by hand"), 0o644); err != nil {
+		t.Fatalf("error modifying output file: %v", err)
+	}
+
+	err := renderTestTarget(t, outDir, "second", false)
+	if err == nil {
+		t.Fatalf("expected error when output was modified")
+	}
+	if !strings.Contains(err.Error(), "data/aws_launch_template_nodes_user_data") {
+		t.Errorf("expected error to list the modified file, was %v", err)
+	}
+	if strings.Contains(err.Error(), "kubernetes.tf") {
+		t.Errorf("expected error not to list unmodified files, was %v", err)
+	}
+	if actual := readTestFile(t, dataFile); actual != "edited by hand" {
+		t.Errorf("expected modified file to be left alone, was %q", actual)
+	}
+
+	if err := renderTestTarget(t, outDir, "second", true); err != nil {
+		t.Fatalf("unexpected error with force overwrite: %v", err)
+	}
+	if actual := readTestFile(t, dataFile); actual != "second" {
+		t.Errorf("expected forced file to contain %q, was %q", "second", actual)
+	}
+
+	// The manifest was rewritten, so the next regeneration is clean again
+	if err := renderTestTarget(t, outDir, "third", false); err != nil {
+		t.Fatalf("unexpected error after force overwrite: %v", err)
+	}
+}
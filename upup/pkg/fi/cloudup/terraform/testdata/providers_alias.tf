@@ -0,0 +1,45 @@
+provider "aws" {
+  alias = "cluster"
+  assume_role {
+    role_arn     = "arn:aws:iam::123456789012:role/kops"
+    session_name = "kops"
+  }
+  default_tags {
+    tags = {
+      "team" = "platform"
+    }
+  }
+  profile = "kops"
+  region  = "us-test-1"
+}
+
+provider "aws" {
+  alias  = "files"
+  region = "us-test-1"
+}
+
+resource "aws_s3_object" "spec" {
+  name     = "spec"
+  provider = aws.files
+}
+
+resource "aws_vpc" "cluster" {
+  name     = "cluster"
+  provider = aws.cluster
+}
+
+data "aws_iam_policy_document" "cluster" {
+  name     = "cluster"
+  provider = aws.cluster
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.cluster, aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 4.0.0"
+    }
+  }
+}
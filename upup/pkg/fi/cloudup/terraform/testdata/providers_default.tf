@@ -0,0 +1,32 @@
+provider "aws" {
+  region = "us-test-1"
+}
+
+provider "aws" {
+  alias  = "files"
+  region = "us-test-1"
+}
+
+resource "aws_s3_object" "spec" {
+  name     = "spec"
+  provider = aws.files
+}
+
+resource "aws_vpc" "cluster" {
+  name = "cluster"
+}
+
+data "aws_iam_policy_document" "cluster" {
+  name = "cluster"
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 4.0.0"
+    }
+  }
+}
@@ -16,10 +16,33 @@ limitations under the License.
 
 package terraformWriter
 
-// TerraformProvider is a provider definition for a terraform file written to cloud storage (S3, GCS, etc)
+// TerraformProvider is a provider definition for the terraform output,
+// either the main cloud provider or one for a terraform file written to cloud storage (S3, GCS, etc)
 type TerraformProvider struct {
 	// Name is the name of the terraform provider
 	Name string
+	// Alias is the alias of the provider definition, if any
+	Alias string
 	// Arguments are additional settings used in the provider definition
 	Arguments map[string]string
+	// AssumeRole is the IAM role the provider assumes, if any
+	AssumeRole *TerraformProviderAssumeRole
+	// DefaultTags are tags the provider adds to all resources it manages
+	DefaultTags map[string]string
+}
+
+// TerraformProviderAssumeRole is the assume_role block of an AWS provider definition
+type TerraformProviderAssumeRole struct {
+	RoleARN     *string `cty:"role_arn"`
+	SessionName *string `cty:"session_name"`
+	ExternalID  *string `cty:"external_id"`
+}
+
+// Reference returns the expression that resources use to select the provider definition,
+// or nil if the provider has no alias and is used by default.
+func (p *TerraformProvider) Reference() *Literal {
+	if p.Alias == "" {
+		return nil
+	}
+	return LiteralTokens(p.Name, p.Alias)
 }
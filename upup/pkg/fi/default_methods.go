@@ -104,7 +104,10 @@ func defaultDeltaRunMethod[T SubContext](e Task[T], c *Context[T]) error {
 		}
 	}
 
-	if producesDeletions, ok := e.(ProducesDeletions[T]); ok && c.Target.ProcessDeletions() {
+	// Tasks that only check an existing object must not change it
+	readOnly := lifecycle == LifecycleExistsAndValidates || lifecycle == LifecycleExistsAndWarnIfChanges
+
+	if producesDeletions, ok := e.(ProducesDeletions[T]); ok && c.Target.ProcessDeletions() && !readOnly {
 		var deletions []Deletion[T]
 		deletions, err = producesDeletions.FindDeletions(c)
 		if err != nil {
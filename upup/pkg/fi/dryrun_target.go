@@ -18,6 +18,7 @@ package fi
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -28,7 +29,9 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/secretscan"
 	"k8s.io/kops/util/pkg/reflectutils"
+	"sigs.k8s.io/yaml"
 )
 
 // DryRunTarget is a special Target that does not execute anything, but instead tracks all changes.
@@ -42,6 +45,8 @@ type DryRunTarget[T SubContext] struct {
 
 	// The destination to which the final report will be printed on Finish()
 	out io.Writer
+	// outputFormat is the format of the final report: empty for human-readable text, or "json" or "yaml" for a DryRunPlan
+	outputFormat string
 
 	// assetBuilder records all assets used
 	assetBuilder *assets.AssetBuilder
@@ -92,6 +97,12 @@ func NewNodeupDryRunTarget(assetBuilder *assets.AssetBuilder, out io.Writer) *No
 	return newDryRunTarget[NodeupSubContext](assetBuilder, out)
 }
 
+// SetOutputFormat sets the format of the report printed on Finish().
+// The empty string prints human-readable text, "json" and "yaml" print a DryRunPlan.
+func (t *DryRunTarget[T]) SetOutputFormat(format string) {
+	t.outputFormat = format
+}
+
 func (t *DryRunTarget[T]) ProcessDeletions() bool {
 	// We display deletions
 	return true
@@ -141,24 +152,31 @@ func idForTask[T SubContext](taskMap map[string]Task[T], t Task[T]) string {
 	return "?"
 }
 
+// sortedChanges returns the tasks that would be created and modified, in a consistent order
+func (t *DryRunTarget[T]) sortedChanges() ([]*render[T], []*render[T]) {
+	var creates []*render[T]
+	var updates []*render[T]
+
+	for _, r := range t.changes {
+		if r.aIsNil {
+			creates = append(creates, r)
+		} else {
+			updates = append(updates, r)
+		}
+	}
+
+	// Give everything a consistent ordering
+	sort.Sort(ByTaskKey[T](creates))
+	sort.Sort(ByTaskKey[T](updates))
+
+	return creates, updates
+}
+
 func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer) error {
 	b := &bytes.Buffer{}
 
 	if len(t.changes) != 0 {
-		var creates []*render[T]
-		var updates []*render[T]
-
-		for _, r := range t.changes {
-			if r.aIsNil {
-				creates = append(creates, r)
-			} else {
-				updates = append(updates, r)
-			}
-		}
-
-		// Give everything a consistent ordering
-		sort.Sort(ByTaskKey[T](creates))
-		sort.Sort(ByTaskKey[T](updates))
+		creates, updates := t.sortedChanges()
 
 		if len(creates) != 0 {
 			fmt.Fprintf(b, "Will create resources:\n")
@@ -166,56 +184,8 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 				taskName := getTaskName(r.changes)
 				fmt.Fprintf(b, "  %s/%s\n", taskName, idForTask(taskMap, r.e))
 
-				changes := reflect.ValueOf(r.changes)
-				if changes.Kind() == reflect.Ptr && !changes.IsNil() {
-					changes = changes.Elem()
-				}
-
-				if changes.Kind() == reflect.Struct {
-					for i := 0; i < changes.NumField(); i++ {
-
-						field := changes.Field(i)
-
-						fieldName := changes.Type().Field(i).Name
-						if changes.Type().Field(i).PkgPath != "" {
-							// Not exported
-							continue
-						}
-
-						fieldValue := reflectutils.ValueAsString(field)
-
-						shouldPrint := true
-						if fieldName == "Name" {
-							// The field name is already printed above, no need to repeat it.
-							shouldPrint = false
-						}
-						if fieldName == "Lifecycle" {
-							// Lifecycle is a "system" field; no need to show it
-							shouldPrint = false
-						}
-						if fieldValue == "<nil>" || fieldValue == "<resource>" {
-							// Uninformative
-							shouldPrint = false
-						}
-						if fieldValue == "id:<nil>" {
-							// Uninformative, but we can often print the name instead
-							name := ""
-							if field.CanInterface() {
-								hasName, ok := field.Interface().(HasName)
-								if ok {
-									name = ValueOf(hasName.GetName())
-								}
-							}
-							if name != "" {
-								fieldValue = "name:" + name
-							} else {
-								shouldPrint = false
-							}
-						}
-						if shouldPrint {
-							fmt.Fprintf(b, "  \t%-20s\t%s\n", fieldName, fieldValue)
-						}
-					}
+				for _, change := range buildCreateList(r.changes) {
+					fmt.Fprintf(b, "  \t%-20s\t%s\n", change.FieldName, change.Description)
 				}
 
 				fmt.Fprintf(b, "\n")
@@ -267,6 +237,13 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 		}
 	}
 
+	t.logAssets()
+
+	_, err := out.Write(b.Bytes())
+	return err
+}
+
+func (t *DryRunTarget[T]) logAssets() {
 	if len(t.assetBuilder.ImageAssets) != 0 {
 		klog.V(4).Infof("ImageAssets:")
 		for _, a := range t.assetBuilder.ImageAssets {
@@ -282,14 +259,196 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 			}
 		}
 	}
+}
 
-	_, err := out.Write(b.Bytes())
+// DryRunPlan is a machine-readable representation of the changes found by a dry run
+type DryRunPlan struct {
+	// Changes are the tasks that would be created or modified
+	Changes []PlannedChange `json:"changes"`
+	// Deletions are the items that would be deleted
+	Deletions []PlannedDeletion `json:"deletions"`
+}
+
+// PlannedAction is what would happen to a task
+type PlannedAction string
+
+const (
+	PlannedActionCreate PlannedAction = "create"
+	PlannedActionModify PlannedAction = "modify"
+)
+
+// PlannedChange is a task that would be created or modified
+type PlannedChange struct {
+	// Type is the type of the task, e.g. SecurityGroup
+	Type string `json:"type"`
+	// Name is the name of the task
+	Name string `json:"name"`
+	// Action is whether the task would be created or modified
+	Action PlannedAction `json:"action"`
+	// Fields are the fields of the task that would change
+	Fields []PlannedFieldChange `json:"fields,omitempty"`
+}
+
+// PlannedFieldChange is a field of a task that would change
+type PlannedFieldChange struct {
+	// Field is the name of the field of the task
+	Field string `json:"field"`
+	// Before is the current value of the field, unset when the task would be created
+	Before string `json:"before,omitempty"`
+	// After is the value the field would have
+	After string `json:"after"`
+}
+
+// PlannedDeletion is an item that would be deleted
+type PlannedDeletion struct {
+	// Type is the type of the task the item belongs to
+	Type string `json:"type"`
+	// Item describes the item
+	Item string `json:"item"`
+}
+
+// Plan returns the changes found by the dry run, in the same order as PrintReport
+func (t *DryRunTarget[T]) Plan(taskMap map[string]Task[T]) (*DryRunPlan, error) {
+	plan := &DryRunPlan{
+		Changes:   []PlannedChange{},
+		Deletions: []PlannedDeletion{},
+	}
+
+	creates, updates := t.sortedChanges()
+	for _, r := range creates {
+		planned := PlannedChange{
+			Type:   getTaskName(r.changes),
+			Name:   idForTask(taskMap, r.e),
+			Action: PlannedActionCreate,
+		}
+		for _, change := range buildCreateList(r.changes) {
+			planned.Fields = append(planned.Fields, PlannedFieldChange{Field: change.FieldName, After: change.After})
+		}
+		plan.Changes = append(plan.Changes, planned)
+	}
+	for _, r := range updates {
+		changeList, err := buildChangeList(r.a, r.e, r.changes)
+		if err != nil {
+			return nil, err
+		}
+		planned := PlannedChange{
+			Type:   getTaskName(r.changes),
+			Name:   idForTask(taskMap, r.e),
+			Action: PlannedActionModify,
+		}
+		for _, change := range changeList {
+			if change.Before == change.After {
+				// Fields that cannot be nil, such as booleans, are always reported as changed
+				continue
+			}
+			planned.Fields = append(planned.Fields, PlannedFieldChange{Field: change.FieldName, Before: change.Before, After: change.After})
+		}
+		plan.Changes = append(plan.Changes, planned)
+	}
+
+	sort.Sort(DeletionByTaskName[T](t.deletions))
+	for _, d := range t.deletions {
+		plan.Deletions = append(plan.Deletions, PlannedDeletion{Type: d.TaskName(), Item: d.Item()})
+	}
+
+	return plan, nil
+}
+
+// WritePlan writes the changes found by the dry run as a DryRunPlan, in the given format ("json" or "yaml")
+func (t *DryRunTarget[T]) WritePlan(taskMap map[string]Task[T], out io.Writer, format string) error {
+	plan, err := t.Plan(taskMap)
+	if err != nil {
+		return err
+	}
+
+	t.logAssets()
+
+	var b []byte
+	switch format {
+	case "json":
+		b, err = json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling plan to json: %w", err)
+		}
+		b = append(b, '\n')
+	case "yaml":
+		b, err = yaml.Marshal(plan)
+		if err != nil {
+			return fmt.Errorf("error marshaling plan to yaml: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+
+	_, err = out.Write(b)
 	return err
 }
 
+// buildCreateList returns the fields that are worth reporting for a task that would be created
+func buildCreateList[T SubContext](changes Task[T]) []change {
+	var changeList []change
+
+	valC := reflect.ValueOf(changes)
+	if valC.Kind() == reflect.Ptr && !valC.IsNil() {
+		valC = valC.Elem()
+	}
+	if valC.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < valC.NumField(); i++ {
+		field := valC.Field(i)
+
+		fieldName := valC.Type().Field(i).Name
+		if valC.Type().Field(i).PkgPath != "" {
+			// Not exported
+			continue
+		}
+
+		fieldValue := reflectutils.ValueAsString(field)
+
+		shouldPrint := true
+		if fieldName == "Name" {
+			// The field name is already printed above, no need to repeat it.
+			shouldPrint = false
+		}
+		if fieldName == "Lifecycle" {
+			// Lifecycle is a "system" field; no need to show it
+			shouldPrint = false
+		}
+		if fieldValue == "<nil>" || fieldValue == "<resource>" {
+			// Uninformative
+			shouldPrint = false
+		}
+		if fieldValue == "id:<nil>" {
+			// Uninformative, but we can often print the name instead
+			name := ""
+			if field.CanInterface() {
+				hasName, ok := field.Interface().(HasName)
+				if ok {
+					name = ValueOf(hasName.GetName())
+				}
+			}
+			if name != "" {
+				fieldValue = "name:" + name
+			} else {
+				shouldPrint = false
+			}
+		}
+		if shouldPrint {
+			changeList = append(changeList, change{FieldName: fieldName, Description: fieldValue, After: fieldValue})
+		}
+	}
+
+	return changeList
+}
+
 type change struct {
 	FieldName   string
 	Description string
+	// Before and After are the current and expected values of the field
+	Before string
+	After  string
 }
 
 func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
@@ -334,6 +493,8 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 			}
 
 			description := ""
+			before := ""
+			after := ""
 			ignored := false
 			if fieldValE.CanInterface() {
 
@@ -345,17 +506,21 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 					resE, okE := tryResourceAsString(fieldValE)
 					if okA && okE {
 						description = diff.FormatDiff(resA, resE)
+						before = resA
+						after = resE
 					}
 				}
 
 				if !ignored && description == "" {
-					description = fmt.Sprintf(" %v -> %v", reflectutils.ValueAsString(fieldValA), reflectutils.ValueAsString(fieldValE))
+					before = reflectutils.ValueAsString(fieldValA)
+					after = reflectutils.ValueAsString(fieldValE)
+					description = fmt.Sprintf(" %v -> %v", before, after)
 				}
 			}
 			if ignored {
 				continue
 			}
-			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description})
+			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description, Before: before, After: after})
 		}
 	} else {
 		return nil, fmt.Errorf("unhandled change type: %v", valC.Type())
@@ -387,7 +552,8 @@ func tryResourceAsString(v reflect.Value) (string, bool) {
 			klog.Warningf("error converting to resource: %v", err)
 			return "", false
 		}
-		return s, true
+		// Resources such as user data may contain secrets, which should not be printed
+		return secretscan.Redact(s), true
 	}
 	return "", false
 }
@@ -403,6 +569,9 @@ func getTaskName[T SubContext](t Task[T]) string {
 
 // Finish is called at the end of a run, and prints a list of changes to the configured Writer
 func (t *DryRunTarget[T]) Finish(taskMap map[string]Task[T]) error {
+	if t.outputFormat != "" {
+		return t.WritePlan(taskMap, t.out, t.outputFormat)
+	}
 	return t.PrintReport(taskMap, t.out)
 }
 
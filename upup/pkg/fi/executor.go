@@ -24,7 +24,11 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/timings"
 )
 
 type executor[T SubContext] struct {
@@ -45,6 +49,10 @@ type taskState[T SubContext] struct {
 type RunTasksOptions struct {
 	MaxTaskDuration         time.Duration
 	WaitAfterAllTasksFailed time.Duration
+
+	// Dependencies are the dependencies of the tasks, as returned by FindTaskDependencies,
+	// when they have already been found. Otherwise they are found when the tasks are run.
+	Dependencies map[string][]string
 }
 
 func (o *RunTasksOptions) InitDefaults() {
@@ -55,7 +63,14 @@ func (o *RunTasksOptions) InitDefaults() {
 // RunTasks executes all the tasks, considering their dependencies
 // It will perform some re-execution on error, retrying as long as progress is still being made
 func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T]) error {
-	dependencies := FindTaskDependencies(taskMap)
+	// The spans of the tasks are children of this span, even though they run in their own goroutines
+	ctx, span := tracer.Start(ctx, "RunTasks", trace.WithAttributes(attribute.Int("tasks", len(taskMap))))
+	defer span.End()
+
+	dependencies := e.options.Dependencies
+	if dependencies == nil {
+		dependencies = FindTaskDependencies(taskMap)
+	}
 
 	for _, task := range taskMap {
 		if taskPreRun, ok := task.(TaskPreRun[T]); ok {
@@ -204,7 +219,10 @@ func (e *executor[T]) forkJoin(ctx context.Context, tasks []*taskState[T]) []err
 		go func(ts *taskState[T], index int) {
 			defer wg.Done()
 
-			_, span := tracer.Start(ctx, "task-"+ts.key)
+			_, span := tracer.Start(ctx, "task-"+ts.key, trace.WithAttributes(
+				attribute.String("task.name", ts.key),
+				attribute.String("task.type", fmt.Sprintf("%T", ts.task)),
+			))
 			defer span.End()
 
 			resultsMutex.Lock()
@@ -213,6 +231,11 @@ func (e *executor[T]) forkJoin(ctx context.Context, tasks []*taskState[T]) []err
 
 			klog.V(2).Infof("Executing task %q: %v\n", ts.key, ts.task)
 
+			start := time.Now()
+			defer func() {
+				timings.RecordTask(ts.key, time.Since(start))
+			}()
+
 			if taskNormalize, ok := ts.task.(TaskNormalize[T]); ok {
 				if err := taskNormalize.Normalize(e.context); err != nil {
 					results[index] = err
@@ -221,6 +244,10 @@ func (e *executor[T]) forkJoin(ctx context.Context, tasks []*taskState[T]) []err
 			}
 
 			result := ts.task.Run(e.context)
+			if result != nil {
+				span.RecordError(result)
+				span.SetStatus(codes.Error, result.Error())
+			}
 
 			resultsMutex.Lock()
 			results[index] = result
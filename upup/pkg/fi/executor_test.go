@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// tracingTestTask is a task that only records that it ran
+type tracingTestTask struct {
+	dependencies []*tracingTestTask
+}
+
+var _ HasDependencies[CloudupSubContext] = &tracingTestTask{}
+
+func (t *tracingTestTask) Run(c *CloudupContext) error {
+	return nil
+}
+
+func (t *tracingTestTask) GetDependencies(tasks map[string]CloudupTask) []CloudupTask {
+	var deps []CloudupTask
+	for _, dep := range t.dependencies {
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+func TestRunTasksTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tracerProvider)
+	defer tracerProvider.Shutdown(context.Background())
+
+	vpc := &tracingTestTask{}
+	subnetA := &tracingTestTask{dependencies: []*tracingTestTask{vpc}}
+	subnetB := &tracingTestTask{dependencies: []*tracingTestTask{vpc}}
+	tasks := map[string]CloudupTask{
+		"VPC/main":    vpc,
+		"Subnet/a":    subnetA,
+		"Subnet/b":    subnetB,
+		"Instance/ab": &tracingTestTask{dependencies: []*tracingTestTask{subnetA, subnetB}},
+	}
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "apply")
+	c, err := NewCloudupContext(ctx, nil, nil, nil, nil, nil, nil, tasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	options := RunTasksOptions{}
+	options.InitDefaults()
+	if err := c.RunTasks(options); err != nil {
+		t.Fatalf("unexpected error running tasks: %v", err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	runTasks, found := byName["RunTasks"]
+	if !found {
+		t.Fatalf("RunTasks span not found in %v", spanNames(spans))
+	}
+	if runTasks.Parent.SpanID() != byName["apply"].SpanContext.SpanID() {
+		t.Errorf("expected RunTasks span to be a child of the caller span")
+	}
+
+	var taskSpans []string
+	for _, span := range spans {
+		if span.Name == "RunTasks" || span.Name == "apply" {
+			continue
+		}
+		taskSpans = append(taskSpans, span.Name)
+		if span.Parent.SpanID() != runTasks.SpanContext.SpanID() {
+			t.Errorf("expected span %q to be a child of the RunTasks span", span.Name)
+		}
+		if span.SpanContext.TraceID() != runTasks.SpanContext.TraceID() {
+			t.Errorf("expected span %q to be in the trace of the RunTasks span", span.Name)
+		}
+
+		taskName := ""
+		for _, attr := range span.Attributes {
+			if attr.Key == "task.name" {
+				taskName = attr.Value.AsString()
+			}
+		}
+		if "task-"+taskName != span.Name {
+			t.Errorf("expected span %q to have task.name attribute, got %q", span.Name, taskName)
+		}
+	}
+
+	sort.Strings(taskSpans)
+	expected := []string{"task-Instance/ab", "task-Subnet/a", "task-Subnet/b", "task-VPC/main"}
+	if len(taskSpans) != len(expected) {
+		t.Fatalf("expected task spans %v, got %v", expected, taskSpans)
+	}
+	for i := range expected {
+		if taskSpans[i] != expected[i] {
+			t.Errorf("expected task spans %v, got %v", expected, taskSpans)
+			break
+		}
+	}
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+// orderTestTask is a task that records the order in which the tasks ran
+type orderTestTask struct {
+	name            string
+	ran             *[]string
+	dependencyCalls int
+}
+
+var _ HasDependencies[CloudupSubContext] = &orderTestTask{}
+
+func (t *orderTestTask) Run(c *CloudupContext) error {
+	*t.ran = append(*t.ran, t.name)
+	return nil
+}
+
+func (t *orderTestTask) GetDependencies(tasks map[string]CloudupTask) []CloudupTask {
+	t.dependencyCalls++
+	return nil
+}
+
+func TestRunTasksWithDependencies(t *testing.T) {
+	var ran []string
+	vpc := &orderTestTask{name: "VPC/main", ran: &ran}
+	subnet := &orderTestTask{name: "Subnet/a", ran: &ran}
+	tasks := map[string]CloudupTask{
+		"VPC/main": vpc,
+		"Subnet/a": subnet,
+	}
+
+	c, err := NewCloudupContext(context.TODO(), nil, nil, nil, nil, nil, nil, tasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	options := RunTasksOptions{}
+	options.InitDefaults()
+	options.Dependencies = map[string][]string{
+		"Subnet/a": {"VPC/main"},
+	}
+	if err := c.RunTasks(options); err != nil {
+		t.Fatalf("unexpected error running tasks: %v", err)
+	}
+
+	if vpc.dependencyCalls != 0 || subnet.dependencyCalls != 0 {
+		t.Errorf("expected the given dependencies to be used, GetDependencies was called")
+	}
+	if len(ran) != 2 || ran[0] != "VPC/main" || ran[1] != "Subnet/a" {
+		t.Errorf("expected the tasks to run in dependency order, got %v", ran)
+	}
+}
@@ -23,7 +23,7 @@ import (
 
 	"k8s.io/kops/pkg/featureflag"
 
-	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
@@ -41,6 +41,7 @@ type ManagedFile struct {
 	// Location is the relative path of the managed file
 	Location *string
 
+	// Contents is the content of the managed file. If nil, the file is deleted.
 	Contents fi.Resource
 
 	// PublicACL controls whether the _object_ has an ACL which grants world-readable status.
@@ -66,6 +67,16 @@ func (e *ManagedFile) Find(c *fi.CloudupContext) (*ManagedFile, error) {
 	existingData, err := filePath.ReadFile(ctx)
 	if err != nil {
 		if os.IsNotExist(err) {
+			if e.Contents == nil {
+				// Already deleted
+				return &ManagedFile{
+					Name:      e.Name,
+					Lifecycle: e.Lifecycle,
+					Base:      e.Base,
+					Location:  e.Location,
+					PublicACL: e.PublicACL,
+				}, nil
+			}
 			return nil, nil
 		}
 		return nil, err
@@ -118,9 +129,6 @@ func (s *ManagedFile) CheckChanges(a, e, changes *ManagedFile) error {
 			return fi.CannotChangeField("Name")
 		}
 	}
-	if e.Contents == nil {
-		return field.Required(field.NewPath("Contents"), "")
-	}
 	return nil
 }
 
@@ -158,17 +166,25 @@ func (_ *ManagedFile) Render(c *fi.CloudupContext, a, e, changes *ManagedFile) e
 		return fi.RequiredField("Location")
 	}
 
-	data, err := fi.ResourceAsBytes(e.Contents)
-	if err != nil {
-		return fmt.Errorf("error reading contents of ManagedFile: %v", err)
-	}
-
 	p, err := getBasePath(c, e)
 	if err != nil {
 		return err
 	}
 	p = p.Join(location)
 
+	if e.Contents == nil {
+		klog.V(2).Infof("Deleting ManagedFile %q", location)
+		if err := p.Remove(ctx); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error deleting ManagedFile %q: %v", location, err)
+		}
+		return nil
+	}
+
+	data, err := fi.ResourceAsBytes(e.Contents)
+	if err != nil {
+		return fmt.Errorf("error reading contents of ManagedFile: %v", err)
+	}
+
 	acl, err := e.getACL(c, p)
 	if err != nil {
 		return err
@@ -206,6 +222,11 @@ func (f *ManagedFile) RenderTerraform(c *fi.CloudupContext, t *terraform.Terrafo
 		return fi.RequiredField("Location")
 	}
 
+	if e.Contents == nil {
+		// The file is deleted by no longer rendering it
+		return nil
+	}
+
 	p, err := getBasePath(c, e)
 	if err != nil {
 		return err
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fitasks
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestManagedFileDelete(t *testing.T) {
+	ctx := context.TODO()
+
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "state")
+	p := base.Join("igconfig/node/nodes/bootconfig.yaml")
+	if err := p.WriteFile(ctx, bytes.NewReader([]byte("stale")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, nil, &kops.Cluster{}, nil, nil, nil, base, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := &ManagedFile{
+		Name:     fi.PtrTo("bootconfig-nodes"),
+		Location: fi.PtrTo("igconfig/node/nodes/bootconfig.yaml"),
+	}
+
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("error finding file: %v", err)
+	}
+	changes := &ManagedFile{}
+	if !fi.BuildChanges(a, e, changes) {
+		t.Fatalf("expected changes when the file exists")
+	}
+	if err := e.Render(c, a, e, changes); err != nil {
+		t.Fatalf("error deleting file: %v", err)
+	}
+	if _, err := p.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected file to be deleted, got %v", err)
+	}
+
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("error finding file: %v", err)
+	}
+	if fi.BuildChanges(a, e, &ManagedFile{}) {
+		t.Errorf("expected no changes once the file is deleted")
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/kops/pkg/pki"
+)
+
+// KeypairMetadata describes a keypair of a Keyset, without exposing its private key.
+type KeypairMetadata struct {
+	// Keyset is the name of the Keyset holding the keypair.
+	Keyset string
+	// Id is the identifier of the keypair.
+	Id string
+	// IsPrimary is true if the keypair is the primary keypair of its Keyset.
+	IsPrimary bool
+	// DistrustTimestamp is the time at which the keypair was distrusted, if it is distrusted.
+	DistrustTimestamp *time.Time
+	// Certificate is the keypair's certificate, if it has one.
+	Certificate *pki.Certificate
+	// HasPrivateKey is true if the private key of the keypair is in the store.
+	HasPrivateKey bool
+	// UsedBy lists the components that consume the Keyset.
+	UsedBy []string
+}
+
+// NotAfter returns the expiry time of the keypair's certificate, or nil if it has no certificate.
+func (m *KeypairMetadata) NotAfter() *time.Time {
+	if m.Certificate == nil || m.Certificate.Certificate == nil {
+		return nil
+	}
+	t := m.Certificate.Certificate.NotAfter.UTC()
+	return &t
+}
+
+// ListKeypairMetadata lists the keypairs of all the Keysets in a CAStore, sorted by Keyset name and then by age.
+func ListKeypairMetadata(store CAStore) ([]*KeypairMetadata, error) {
+	keysets, err := store.ListKeysets()
+	if err != nil {
+		return nil, fmt.Errorf("error listing Keysets: %w", err)
+	}
+
+	var keypairs []*KeypairMetadata
+	for name, keyset := range keysets {
+		usedBy := KeysetConsumers(name)
+		for _, item := range keyset.Items {
+			keypairs = append(keypairs, &KeypairMetadata{
+				Keyset:            name,
+				Id:                item.Id,
+				IsPrimary:         keyset.Primary != nil && item.Id == keyset.Primary.Id,
+				DistrustTimestamp: item.DistrustTimestamp,
+				Certificate:       item.Certificate,
+				HasPrivateKey:     item.PrivateKey != nil,
+				UsedBy:            usedBy,
+			})
+		}
+	}
+
+	sort.Slice(keypairs, func(i, j int) bool {
+		if keypairs[i].Keyset != keypairs[j].Keyset {
+			return keypairs[i].Keyset < keypairs[j].Keyset
+		}
+		return KeysetItemIdOlder(keypairs[i].Id, keypairs[j].Id)
+	})
+	return keypairs, nil
+}
+
+// keysetConsumers maps the names of the Keysets kops manages to the components that consume them.
+var keysetConsumers = map[string][]string{
+	CertificateIDCA:           {"kube-apiserver", "kube-controller-manager", "kubelet", "kops-controller"},
+	"apiserver-aggregator-ca": {"kube-apiserver"},
+	"service-account":         {"kube-apiserver", "kube-controller-manager"},
+	"etcd-clients-ca":         {"etcd-manager", "kube-apiserver"},
+	"etcd-clients-ca-cilium":  {"etcd-manager", "cilium"},
+	"kubelet-ca":              {"kube-apiserver", "kubelet", "kops-controller"},
+}
+
+// KeysetConsumers returns the components that consume a Keyset, or nil if the Keyset is not one kops manages.
+func KeysetConsumers(name string) []string {
+	if consumers, found := keysetConsumers[name]; found {
+		return consumers
+	}
+	switch {
+	case strings.HasPrefix(name, "etcd-manager-ca-"), strings.HasPrefix(name, "etcd-peers-ca-"):
+		return []string{"etcd-manager"}
+	}
+	return nil
+}
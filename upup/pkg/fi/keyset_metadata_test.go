@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func issueTestCA(t *testing.T, name string, serial int64, validity time.Duration) (*pki.Certificate, *pki.PrivateKey) {
+	cert, key, _, err := pki.IssueCert(context.TODO(), &pki.IssueCertRequest{
+		Type:     "ca",
+		Subject:  pkix.Name{CommonName: name},
+		Validity: validity,
+		Serial:   big.NewInt(serial),
+	}, nil)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	return cert, key
+}
+
+func TestListKeypairMetadata(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+	store := &VFSCAStore{
+		VFSKeystoreReader: VFSKeystoreReader{
+			basedir: basePath,
+		},
+	}
+
+	{
+		cert, key := issueTestCA(t, "kubernetes-ca", 10, 10*24*time.Hour)
+		keyset, err := NewKeyset(cert, key)
+		if err != nil {
+			t.Fatalf("error building keyset: %v", err)
+		}
+		// The newer keypair is staged but not yet promoted
+		cert, key = issueTestCA(t, "kubernetes-ca", 20, 3650*24*time.Hour)
+		if _, err := keyset.AddItem(cert, key, false); err != nil {
+			t.Fatalf("error adding keypair: %v", err)
+		}
+		if err := store.StoreKeyset(ctx, "kubernetes-ca", keyset); err != nil {
+			t.Fatalf("error storing keyset: %v", err)
+		}
+	}
+	{
+		cert, key := issueTestCA(t, "etcd-manager-ca-main", 30, 100*24*time.Hour)
+		keyset, err := NewKeyset(cert, key)
+		if err != nil {
+			t.Fatalf("error building keyset: %v", err)
+		}
+		if err := store.StoreKeyset(ctx, "etcd-manager-ca-main", keyset); err != nil {
+			t.Fatalf("error storing keyset: %v", err)
+		}
+	}
+
+	keypairs, err := ListKeypairMetadata(store)
+	if err != nil {
+		t.Fatalf("error listing keypairs: %v", err)
+	}
+
+	type summary struct {
+		Keyset        string
+		Id            string
+		IsPrimary     bool
+		HasPrivateKey bool
+		UsedBy        []string
+		ExpiresInDays int
+	}
+	var actual []summary
+	for _, keypair := range keypairs {
+		actual = append(actual, summary{
+			Keyset:        keypair.Keyset,
+			Id:            keypair.Id,
+			IsPrimary:     keypair.IsPrimary,
+			HasPrivateKey: keypair.HasPrivateKey,
+			UsedBy:        keypair.UsedBy,
+			ExpiresInDays: int(math.Round(time.Until(*keypair.NotAfter()).Hours() / 24)),
+		})
+	}
+	expected := []summary{
+		{Keyset: "etcd-manager-ca-main", Id: "30", IsPrimary: true, HasPrivateKey: true, UsedBy: []string{"etcd-manager"}, ExpiresInDays: 100},
+		{Keyset: "kubernetes-ca", Id: "10", IsPrimary: true, HasPrivateKey: true, UsedBy: []string{"kube-apiserver", "kube-controller-manager", "kubelet", "kops-controller"}, ExpiresInDays: 10},
+		{Keyset: "kubernetes-ca", Id: "20", HasPrivateKey: true, UsedBy: []string{"kube-apiserver", "kube-controller-manager", "kubelet", "kops-controller"}, ExpiresInDays: 3650},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected keypairs:\nexpected: %+v\nactual:   %+v", expected, actual)
+	}
+}
+
+func TestKeysetConsumers(t *testing.T) {
+	grid := map[string][]string{
+		"service-account":      {"kube-apiserver", "kube-controller-manager"},
+		"etcd-peers-ca-events": {"etcd-manager"},
+		"etcd-clients-ca":      {"etcd-manager", "kube-apiserver"},
+		"my-custom-keyset":     nil,
+	}
+	for name, expected := range grid {
+		if actual := KeysetConsumers(name); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("unexpected consumers for %q: expected %v, got %v", name, expected, actual)
+		}
+	}
+}
@@ -95,6 +95,21 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		return fmt.Errorf("ConfigLocation is required")
 	}
 
+	if bootConfig.BootConfigHash != "" {
+		if fi.ValueOf(bootConfig.ConfigBase) == "" {
+			return fmt.Errorf("ConfigBase is required to load the boot config")
+		}
+		configBase, err := vfs.Context.BuildVfsPath(*bootConfig.ConfigBase)
+		if err != nil {
+			return fmt.Errorf("cannot parse ConfigBase %q: %v", *bootConfig.ConfigBase, err)
+		}
+		fullBootConfig, err := loadBootConfig(ctx, configBase, &bootConfig)
+		if err != nil {
+			return err
+		}
+		bootConfig = *fullBootConfig
+	}
+
 	if c.CacheDir == "" {
 		return fmt.Errorf("CacheDir is required")
 	}
@@ -287,6 +302,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &model.NTPBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.DirectoryBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.UpdateServiceBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.RebootRequiredBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.VolumesBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.ContainerdBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.ProtokubeBuilder{NodeupModelContext: modelContext})
@@ -296,9 +312,11 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &model.KubeletBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KubectlBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.LogrotateBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.JournaldBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.ManifestsBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.PackagesBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.NvidiaBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.SwapBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.FirewallBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.SysctlBuilder{NodeupModelContext: modelContext})
@@ -553,6 +571,28 @@ func loadKernelModules(context *model.NodeupModelContext) error {
 	return nil
 }
 
+// loadBootConfig reads the boot config that was written to the state store because it did not fit in the user-data,
+// checking it against the hash in the user-data.
+func loadBootConfig(ctx context.Context, configBase vfs.Path, stub *nodeup.BootConfig) (*nodeup.BootConfig, error) {
+	bootConfigLocation := configBase.Join("igconfig", stub.InstanceGroupRole.ToLowerString(), stub.InstanceGroupName, "bootconfig.yaml")
+
+	b, err := bootConfigLocation.ReadFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading BootConfig %q: %v", bootConfigLocation, err)
+	}
+
+	sum256 := sha256.Sum256(b)
+	if got := base64.StdEncoding.EncodeToString(sum256[:]); got != stub.BootConfigHash {
+		return nil, fmt.Errorf("boot config hash mismatch (was %q, expected %q)", got, stub.BootConfigHash)
+	}
+
+	bootConfig := &nodeup.BootConfig{}
+	if err := utils.YamlUnmarshal(b, bootConfig); err != nil {
+		return nil, fmt.Errorf("error parsing BootConfig %q: %v", bootConfigLocation, err)
+	}
+	return bootConfig, nil
+}
+
 // getRegion queries the cloud provider for the region.
 func getRegion(ctx context.Context, bootConfig *nodeup.BootConfig) (string, error) {
 	switch bootConfig.CloudProvider {
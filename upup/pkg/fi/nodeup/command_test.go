@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestLoadBootConfig(t *testing.T) {
+	ctx := context.TODO()
+	configBase := vfs.NewMemFSPath(vfs.NewMemFSContext(), "cluster")
+
+	bootConfig := &nodeup.BootConfig{
+		CloudProvider:     kops.CloudProviderAWS,
+		ConfigBase:        fi.PtrTo("memfs://cluster"),
+		ClusterName:       "minimal.example.com",
+		InstanceGroupName: "nodes",
+		InstanceGroupRole: kops.InstanceGroupRoleNode,
+		APIServerIPs:      []string{"10.0.0.1"},
+		NodeupConfigHash:  "nodeup-config-hash",
+	}
+	data, err := utils.YamlMarshal(bootConfig)
+	if err != nil {
+		t.Fatalf("error marshaling boot config: %v", err)
+	}
+	if err := configBase.Join("igconfig", "node", "nodes", "bootconfig.yaml").WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("error writing boot config: %v", err)
+	}
+	sum256 := sha256.Sum256(data)
+
+	stub := &nodeup.BootConfig{
+		CloudProvider:     kops.CloudProviderAWS,
+		ConfigBase:        fi.PtrTo("memfs://cluster"),
+		ClusterName:       "minimal.example.com",
+		InstanceGroupName: "nodes",
+		InstanceGroupRole: kops.InstanceGroupRoleNode,
+		BootConfigHash:    base64.StdEncoding.EncodeToString(sum256[:]),
+	}
+	actual, err := loadBootConfig(ctx, configBase, stub)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual.NodeupConfigHash != bootConfig.NodeupConfigHash || len(actual.APIServerIPs) != 1 || actual.APIServerIPs[0] != "10.0.0.1" {
+		t.Errorf("unexpected boot config: %+v", actual)
+	}
+
+	stub.BootConfigHash = base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	if _, err := loadBootConfig(ctx, configBase, stub); err == nil || !strings.Contains(err.Error(), "boot config hash mismatch") {
+		t.Errorf("expected a hash mismatch, got %v", err)
+	}
+
+	stub.InstanceGroupName = "missing"
+	if _, err := loadBootConfig(ctx, configBase, stub); err == nil || !strings.Contains(err.Error(), "error loading BootConfig") {
+		t.Errorf("expected a load error, got %v", err)
+	}
+}
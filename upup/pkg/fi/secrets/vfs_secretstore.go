@@ -87,12 +87,30 @@ func (c *VFSSecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 	return nil
 }
 
+// FindSecret implements fi.SecretStoreReader FindSecret, explaining why the state store cannot be used when reading fails.
+func (c *VFSSecretStore) FindSecret(id string) (*fi.Secret, error) {
+	s, err := c.VFSSecretStoreReader.FindSecret(id)
+	if err != nil {
+		return nil, c.explainError(context.TODO(), err)
+	}
+	return s, nil
+}
+
+// explainError is called after an operation on the secret store failed with err, and returns
+// why the state store cannot be used when that is the cause.
+func (c *VFSSecretStore) explainError(ctx context.Context, err error) error {
+	return vfs.ExplainStateStoreError(ctx, c.basedir, err)
+}
+
 // DeleteSecret implements fi.SecretStore DeleteSecret
 func (c *VFSSecretStore) DeleteSecret(name string) error {
 	ctx := context.TODO()
 
 	p := c.buildSecretPath(name)
-	return p.Remove(ctx)
+	if err := p.Remove(ctx); err != nil {
+		return c.explainError(ctx, err)
+	}
+	return nil
 }
 
 func (c *VFSSecretStore) ListSecrets() ([]string, error) {
@@ -102,7 +120,7 @@ func (c *VFSSecretStore) ListSecrets() ([]string, error) {
 		return ids, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error listing secrets directory: %v", err)
+		return nil, fmt.Errorf("error listing secrets directory: %v", c.explainError(context.TODO(), err))
 	}
 	for _, f := range files {
 		id := f.Base()
@@ -135,7 +153,7 @@ func (c *VFSSecretStore) GetOrCreateSecret(ctx context.Context, id string, secre
 				klog.Infof("Got already-exists error when writing secret; likely due to concurrent creation.  Will retry")
 				continue
 			} else {
-				return nil, false, err
+				return nil, false, c.explainError(ctx, err)
 			}
 		}
 
@@ -165,7 +183,7 @@ func (c *VFSSecretStore) ReplaceSecret(id string, secret *fi.Secret) (*fi.Secret
 
 	err = createSecret(ctx, secret, p, acl, true)
 	if err != nil {
-		return nil, fmt.Errorf("unable to write secret: %v", err)
+		return nil, fmt.Errorf("unable to write secret: %v", c.explainError(ctx, err))
 	}
 
 	// Confirm the secret exists
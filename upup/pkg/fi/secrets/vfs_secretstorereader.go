@@ -79,6 +79,7 @@ func (c *VFSSecretStoreReader) loadSecret(ctx context.Context, p vfs.Path) (*fi.
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
+		return nil, fmt.Errorf("reading secret from %q: %v", p, err)
 	}
 	s := &fi.Secret{}
 	err = json.Unmarshal(data, s)
@@ -155,6 +155,21 @@ func serializeKeysetBundle(o *kops.Keyset) ([]byte, error) {
 	return objectData.Bytes(), nil
 }
 
+// FindKeyset implements KeystoreReader::FindKeyset, explaining why the state store cannot be used when reading fails.
+func (c *VFSCAStore) FindKeyset(ctx context.Context, id string) (*Keyset, error) {
+	keyset, err := c.VFSKeystoreReader.FindKeyset(ctx, id)
+	if err != nil {
+		return nil, c.explainError(ctx, err)
+	}
+	return keyset, nil
+}
+
+// explainError is called after an operation on the keystore failed with err, and returns
+// why the state store cannot be used when that is the cause.
+func (c *VFSCAStore) explainError(ctx context.Context, err error) error {
+	return vfs.ExplainStateStoreError(ctx, c.basedir, err)
+}
+
 // ListKeysets implements CAStore::ListKeysets
 func (c *VFSCAStore) ListKeysets() (map[string]*Keyset, error) {
 	ctx := context.TODO()
@@ -162,7 +177,7 @@ func (c *VFSCAStore) ListKeysets() (map[string]*Keyset, error) {
 	baseDir := c.basedir.Join("private")
 	files, err := baseDir.ReadTree(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error reading directory %q: %v", baseDir, err)
+		return nil, fmt.Errorf("error reading directory %q: %v", baseDir, c.explainError(ctx, err))
 	}
 
 	keysets := map[string]*Keyset{}
@@ -276,7 +291,7 @@ func (c *VFSCAStore) StoreKeyset(ctx context.Context, name string, keyset *Keyse
 	{
 		p := c.buildPrivateKeyPoolPath(name)
 		if err := writeKeysetBundle(ctx, c.cluster, p, name, keyset); err != nil {
-			return fmt.Errorf("writing private bundle: %v", err)
+			return fmt.Errorf("writing private bundle: %v", c.explainError(ctx, err))
 		}
 	}
 
@@ -297,7 +312,10 @@ func (c *VFSCAStore) AddSSHPublicKey(ctx context.Context, pubkey []byte) error {
 		return err
 	}
 
-	return p.WriteFile(ctx, bytes.NewReader(pubkey), acl)
+	if err := p.WriteFile(ctx, bytes.NewReader(pubkey), acl); err != nil {
+		return c.explainError(ctx, err)
+	}
+	return nil
 }
 
 func (c *VFSCAStore) buildSSHPublicKeyPath(id string) vfs.Path {
@@ -316,7 +334,7 @@ func (c *VFSCAStore) FindSSHPublicKeys() ([]*kops.SSHCredential, error) {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
-		return nil, err
+		return nil, c.explainError(ctx, err)
 	}
 
 	var items []*kops.SSHCredential
@@ -328,7 +346,7 @@ func (c *VFSCAStore) FindSSHPublicKeys() ([]*kops.SSHCredential, error) {
 				klog.V(2).Infof("Ignoring not-found issue reading %q", f)
 				continue
 			}
-			return nil, fmt.Errorf("error loading SSH item %q: %v", f, err)
+			return nil, fmt.Errorf("error loading SSH item %q: %v", f, c.explainError(ctx, err))
 		}
 
 		item := &kops.SSHCredential{}
@@ -350,11 +368,11 @@ func (c *VFSCAStore) DeleteSSHCredential() error {
 		if os.IsNotExist(err) {
 			return nil
 		}
-		return err
+		return c.explainError(ctx, err)
 	}
 	for _, f := range files {
 		if err := f.Remove(ctx); err != nil {
-			return err
+			return c.explainError(ctx, err)
 		}
 	}
 	return nil
@@ -18,6 +18,7 @@ package fi
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"math/rand"
 	"strings"
@@ -158,3 +159,31 @@ spec:
 		}
 	}
 }
+
+// unusableStatePath is a state store path whose reads fail, and whose preflight explains why.
+type unusableStatePath struct {
+	*vfs.MemFSPath
+}
+
+func (p *unusableStatePath) Join(relativePath ...string) vfs.Path {
+	return &unusableStatePath{MemFSPath: p.MemFSPath.Join(relativePath...).(*vfs.MemFSPath)}
+}
+
+func (p *unusableStatePath) ReadFile(ctx context.Context) ([]byte, error) {
+	return nil, errors.New("AccessDenied: Access Denied")
+}
+
+func (p *unusableStatePath) PreflightStateStore(ctx context.Context) error {
+	return &vfs.StateStoreError{Reason: vfs.StateStoreBucketNotFound, Path: "s3://my-bucket", Bucket: "my-bucket"}
+}
+
+func TestVFSCAStoreExplainsStateStoreErrors(t *testing.T) {
+	ctx := context.TODO()
+	s := NewVFSCAStore(nil, &unusableStatePath{MemFSPath: vfs.NewMemFSPath(vfs.NewMemFSContext(), "state/pki")})
+
+	_, err := s.FindKeyset(ctx, CertificateIDCA)
+	var stateStoreError *vfs.StateStoreError
+	if !errors.As(err, &stateStoreError) {
+		t.Fatalf("expected a state store error, got %v", err)
+	}
+}
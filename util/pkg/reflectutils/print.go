@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"k8s.io/klog/v2"
 
@@ -75,6 +76,9 @@ func ValueAsString(value reflect.Value) string {
 
 		case reflect.Map:
 			keys := v.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return ValueAsString(keys[i]) < ValueAsString(keys[j])
+			})
 			fmt.Fprintf(b, "{")
 			for i, key := range keys {
 				mv := v.MapIndex(key)
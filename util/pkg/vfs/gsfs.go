@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -528,3 +529,69 @@ func isGCSNotFound(err error) bool {
 func (p *GSPath) getStorageClient(ctx context.Context) (*storage.Service, error) {
 	return p.vfsContext.getGCSClient(ctx)
 }
+
+var _ HasStateStorePreflight = &GSPath{}
+
+// PreflightStateStore implements HasStateStorePreflight
+func (p *GSPath) PreflightStateStore(ctx context.Context) error {
+	client, err := p.getStorageClient(ctx)
+	if err != nil {
+		return classifyGCSError(p.String(), p.bucket, "storage.objects.list", err)
+	}
+	return preflightGCS(ctx, client, p.String(), p.bucket, p.key)
+}
+
+// preflightGCS lists at most one object of the state store, which needs the storage.objects.list permission.
+func preflightGCS(ctx context.Context, client *storage.Service, path string, bucket string, key string) error {
+	request := client.Objects.List(bucket).Context(ctx).MaxResults(1)
+	if key != "" {
+		request = request.Prefix(strings.TrimSuffix(key, "/") + "/")
+	}
+	if _, err := request.Do(); err != nil {
+		return classifyGCSError(path, bucket, "storage.objects.list", err)
+	}
+	return nil
+}
+
+// classifyGCSError turns the GCS errors that have an obvious cause into a *StateStoreError.
+// permission is the permission needed by the failed request, used when the error does not name it.
+func classifyGCSError(path string, bucket string, permission string, err error) error {
+	stateStoreErr := &StateStoreError{
+		Path:   path,
+		Bucket: bucket,
+		Err:    err,
+	}
+
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		// Building the client fails when application default credentials are not configured
+		if strings.Contains(err.Error(), "could not find default credentials") {
+			stateStoreErr.Reason = StateStoreAnonymousCredentials
+			stateStoreErr.Suggestion = "Configure Google Cloud credentials, for example by running \"gcloud auth application-default login\" or setting GOOGLE_APPLICATION_CREDENTIALS."
+			return stateStoreErr
+		}
+		return err
+	}
+
+	switch {
+	case apiErr.Code == http.StatusUnauthorized || strings.Contains(apiErr.Message, "Anonymous caller"):
+		stateStoreErr.Reason = StateStoreAnonymousCredentials
+		stateStoreErr.Suggestion = "Configure Google Cloud credentials, for example by running \"gcloud auth application-default login\" or setting GOOGLE_APPLICATION_CREDENTIALS."
+
+	case apiErr.Code == http.StatusNotFound:
+		stateStoreErr.Reason = StateStoreBucketNotFound
+		stateStoreErr.Suggestion = stateStoreBucketNotFoundSuggestion
+
+	case apiErr.Code == http.StatusForbidden:
+		stateStoreErr.Reason = StateStoreAccessDenied
+		stateStoreErr.Action = missingPermission(apiErr.Message)
+		if stateStoreErr.Action == "" {
+			stateStoreErr.Action = permission
+		}
+		stateStoreErr.Suggestion = fmt.Sprintf("Grant %s on bucket %q to the Google Cloud credentials in use, and check that the intended account is active.", stateStoreErr.Action, bucket)
+
+	default:
+		return err
+	}
+	return stateStoreErr
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+func TestPreflightGCS(t *testing.T) {
+	grid := []struct {
+		name           string
+		statusCode     int
+		message        string
+		expectedReason StateStoreErrorReason
+		expectedAction string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:           "bucket not found",
+			statusCode:     http.StatusNotFound,
+			message:        "The specified bucket does not exist.",
+			expectedReason: StateStoreBucketNotFound,
+		},
+		{
+			name:           "access denied with permission",
+			statusCode:     http.StatusForbidden,
+			message:        "kops@example.iam.gserviceaccount.com does not have storage.objects.list access to the Google Cloud Storage bucket. Permission 'storage.objects.list' denied on resource (or it may not exist).",
+			expectedReason: StateStoreAccessDenied,
+			expectedAction: "storage.objects.list",
+		},
+		{
+			name:           "access denied without permission",
+			statusCode:     http.StatusForbidden,
+			message:        "Forbidden",
+			expectedReason: StateStoreAccessDenied,
+			expectedAction: "storage.objects.list",
+		},
+		{
+			name:           "anonymous caller",
+			statusCode:     http.StatusUnauthorized,
+			message:        "Anonymous caller does not have storage.objects.list access to the Google Cloud Storage bucket.",
+			expectedReason: StateStoreAnonymousCredentials,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(g.statusCode)
+				if g.statusCode == http.StatusOK {
+					fmt.Fprint(w, `{"kind": "storage#objects"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"error": {"code": %d, "message": %q, "errors": [{"message": %q, "reason": "error"}]}}`, g.statusCode, g.message, g.message)
+			}))
+			defer server.Close()
+
+			ctx := context.TODO()
+			client, err := storage.NewService(ctx, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatalf("error building client: %v", err)
+			}
+
+			err = preflightGCS(ctx, client, "gs://my-bucket/clusters", "my-bucket", "clusters")
+			if query == "" {
+				t.Fatalf("expected the objects to be listed")
+			}
+			if g.expectedReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var stateStoreErr *StateStoreError
+			if !errors.As(err, &stateStoreErr) {
+				t.Fatalf("expected a StateStoreError, got %v", err)
+			}
+			if stateStoreErr.Reason != g.expectedReason {
+				t.Errorf("expected reason %q, got %q", g.expectedReason, stateStoreErr.Reason)
+			}
+			if stateStoreErr.Action != g.expectedAction {
+				t.Errorf("expected action %q, got %q", g.expectedAction, stateStoreErr.Action)
+			}
+			if stateStoreErr.Path != "gs://my-bucket/clusters" || stateStoreErr.Bucket != "my-bucket" {
+				t.Errorf("unexpected path %q or bucket %q", stateStoreErr.Path, stateStoreErr.Bucket)
+			}
+		})
+	}
+}
+
+func TestClassifyGCSErrorNoCredentials(t *testing.T) {
+	err := fmt.Errorf("error building GCS client: %v", errors.New("google: could not find default credentials. See https://cloud.google.com/docs/authentication/external/set-up-adc for more information"))
+
+	var stateStoreErr *StateStoreError
+	if !errors.As(classifyGCSError("gs://my-bucket", "my-bucket", "storage.objects.list", err), &stateStoreErr) {
+		t.Fatalf("expected a StateStoreError")
+	}
+	if stateStoreErr.Reason != StateStoreAnonymousCredentials {
+		t.Errorf("expected reason %q, got %q", StateStoreAnonymousCredentials, stateStoreErr.Reason)
+	}
+}
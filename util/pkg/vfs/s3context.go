@@ -18,6 +18,7 @@ package vfs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -28,11 +29,13 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"k8s.io/klog/v2"
 )
 
@@ -197,11 +200,12 @@ func (s *S3Context) getDetailsForBucket(ctx context.Context, bucket string) (*S3
 	// and fallback to brute-forcing if it fails
 	if err != nil {
 		klog.V(2).Infof("unable to get bucket location from region %q; scanning all regions: %v", awsRegion, err)
-		response, err = bruteforceBucketLocation(ctx, &awsRegion, request)
-	}
-
-	if err != nil {
-		return bucketDetails, err
+		var bruteforceErr error
+		response, bruteforceErr = bruteforceBucketLocation(ctx, &awsRegion, request)
+		if bruteforceErr != nil {
+			// The error from the normal call explains best why the bucket cannot be used
+			return bucketDetails, fmt.Errorf("%v: %w", bruteforceErr, err)
+		}
 	}
 
 	if response.LocationConstraint == nil {
@@ -405,3 +409,102 @@ func VFSPath(url string) (string, error) {
 	}
 	return fmt.Sprintf("s3://%s%s", bucket, path), nil
 }
+
+var _ HasStateStorePreflight = &S3Path{}
+
+// PreflightStateStore implements HasStateStorePreflight
+func (p *S3Path) PreflightStateStore(ctx context.Context) error {
+	client, err := p.client(ctx)
+	if err != nil {
+		return classifyS3Error(p.String(), p.bucket, "s3:GetBucketLocation", false, err)
+	}
+	anonymous := client.Config.Credentials == credentials.AnonymousCredentials
+	return preflightS3(ctx, client, p.String(), p.bucket, p.key, anonymous)
+}
+
+// preflightS3 lists at most one object of the state store, which needs the s3:ListBucket permission.
+func preflightS3(ctx context.Context, client s3iface.S3API, path string, bucket string, key string, anonymous bool) error {
+	request := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int64(1),
+	}
+	if key != "" {
+		request.Prefix = aws.String(strings.TrimSuffix(key, "/") + "/")
+	}
+	if _, err := client.ListObjectsV2WithContext(ctx, request); err != nil {
+		return classifyS3Error(path, bucket, "s3:ListBucket", anonymous, err)
+	}
+	return nil
+}
+
+var (
+	// s3BucketRegionErrorRegexp matches the region the SDK extracts from the x-amz-bucket-region header of a redirect
+	s3BucketRegionErrorRegexp = regexp.MustCompile(`bucket is in '([a-z0-9-]+)' region`)
+	// s3AuthorizationHeaderRegexp matches the region of an AuthorizationHeaderMalformed error
+	s3AuthorizationHeaderRegexp = regexp.MustCompile(`expecting '([a-z0-9-]+)'`)
+)
+
+// classifyS3Error turns the S3 errors that have an obvious cause into a *StateStoreError.
+// action is the permission needed by the failed request, used when the error does not name it.
+func classifyS3Error(path string, bucket string, action string, anonymous bool, err error) error {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return err
+	}
+	statusCode := 0
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) {
+		statusCode = requestFailure.StatusCode()
+	}
+
+	stateStoreErr := &StateStoreError{
+		Path:   path,
+		Bucket: bucket,
+		Err:    err,
+	}
+	code := awsErr.Code()
+	switch {
+	case code == "NoCredentialProviders" || (anonymous && (code == "AccessDenied" || statusCode == http.StatusForbidden)):
+		stateStoreErr.Reason = StateStoreAnonymousCredentials
+		stateStoreErr.Suggestion = "Configure AWS credentials, for example by setting AWS_PROFILE, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY."
+
+	case code == s3.ErrCodeNoSuchBucket || (code == "NotFound" && statusCode == http.StatusNotFound):
+		stateStoreErr.Reason = StateStoreBucketNotFound
+		stateStoreErr.Suggestion = stateStoreBucketNotFoundSuggestion
+
+	case code == "BucketRegionError" || code == "PermanentRedirect" || code == "AuthorizationHeaderMalformed" || statusCode == http.StatusMovedPermanently:
+		var region string
+		for _, re := range []*regexp.Regexp{s3BucketRegionErrorRegexp, s3AuthorizationHeaderRegexp} {
+			if match := re.FindStringSubmatch(awsErr.Message()); match != nil {
+				region = match[1]
+				break
+			}
+		}
+		if region == "" && code == "AuthorizationHeaderMalformed" {
+			return err
+		}
+		stateStoreErr.Reason = StateStoreWrongRegion
+		stateStoreErr.Region = region
+		regionVariable := "AWS_REGION"
+		if os.Getenv("S3_ENDPOINT") != "" {
+			regionVariable = "S3_REGION"
+		}
+		if region != "" {
+			stateStoreErr.Suggestion = fmt.Sprintf("Set %s=%s and retry.", regionVariable, region)
+		} else {
+			stateStoreErr.Suggestion = fmt.Sprintf("Set %s to the region of the bucket and retry.", regionVariable)
+		}
+
+	case code == "AccessDenied" || statusCode == http.StatusForbidden:
+		stateStoreErr.Reason = StateStoreAccessDenied
+		stateStoreErr.Action = missingPermission(awsErr.Message())
+		if stateStoreErr.Action == "" {
+			stateStoreErr.Action = action
+		}
+		stateStoreErr.Suggestion = fmt.Sprintf("Allow %s on bucket %q for the AWS credentials in use, and check that the intended AWS profile is selected.", stateStoreErr.Action, bucket)
+
+	default:
+		return err
+	}
+	return stateStoreErr
+}
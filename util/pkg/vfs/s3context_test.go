@@ -16,7 +16,19 @@ limitations under the License.
 
 package vfs
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
 
 func Test_VFSPath(t *testing.T) {
 	grid := []struct {
@@ -191,3 +203,237 @@ func Test_VFSPath(t *testing.T) {
 		}
 	}
 }
+
+// mockListObjectsS3 is an S3 client whose ListObjectsV2 calls return a fixed error
+type mockListObjectsS3 struct {
+	s3iface.S3API
+	err     error
+	request *s3.ListObjectsV2Input
+}
+
+func (m *mockListObjectsS3) ListObjectsV2WithContext(ctx aws.Context, input *s3.ListObjectsV2Input, options ...request.Option) (*s3.ListObjectsV2Output, error) {
+	m.request = input
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &s3.ListObjectsV2Output{}, nil
+}
+
+func TestPreflightS3(t *testing.T) {
+	grid := []struct {
+		name      string
+		err       error
+		anonymous bool
+		s3Region  bool
+		expected  *StateStoreError
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "bucket not found",
+			err:  awserr.NewRequestFailure(awserr.New("NoSuchBucket", "The specified bucket does not exist", nil), 404, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreBucketNotFound,
+				Suggestion: stateStoreBucketNotFoundSuggestion,
+			},
+		},
+		{
+			name: "access denied without action",
+			err:  awserr.NewRequestFailure(awserr.New("AccessDenied", "Access Denied", nil), 403, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreAccessDenied,
+				Action:     "s3:ListBucket",
+				Suggestion: `Allow s3:ListBucket on bucket "my-bucket" for the AWS credentials in use, and check that the intended AWS profile is selected.`,
+			},
+		},
+		{
+			name: "access denied with action",
+			err: awserr.NewRequestFailure(awserr.New("AccessDenied",
+				"User: arn:aws:iam::123456789012:user/kops is not authorized to perform: s3:ListBucket on resource: \"arn:aws:s3:::my-bucket\" because no identity-based policy allows the s3:ListBucket action", nil), 403, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreAccessDenied,
+				Action:     "s3:ListBucket",
+				Suggestion: `Allow s3:ListBucket on bucket "my-bucket" for the AWS credentials in use, and check that the intended AWS profile is selected.`,
+			},
+		},
+		{
+			name: "region redirect",
+			err: awserr.NewRequestFailure(awserr.New("BucketRegionError",
+				"incorrect region, the bucket is not in 'us-east-1' region at endpoint '', bucket is in 'eu-west-2' region", nil), 301, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreWrongRegion,
+				Region:     "eu-west-2",
+				Suggestion: "Set AWS_REGION=eu-west-2 and retry.",
+			},
+		},
+		{
+			name:     "region redirect with custom endpoint",
+			s3Region: true,
+			err: awserr.NewRequestFailure(awserr.New("AuthorizationHeaderMalformed",
+				"The authorization header is malformed; the region 'us-east-1' is wrong; expecting 'ap-south-1'", nil), 400, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreWrongRegion,
+				Region:     "ap-south-1",
+				Suggestion: "Set S3_REGION=ap-south-1 and retry.",
+			},
+		},
+		{
+			name: "region redirect without region",
+			err:  awserr.NewRequestFailure(awserr.New("PermanentRedirect", "The bucket you are attempting to access must be addressed using the specified endpoint.", nil), 301, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreWrongRegion,
+				Suggestion: "Set AWS_REGION to the region of the bucket and retry.",
+			},
+		},
+		{
+			name: "no credentials",
+			err:  awserr.New("NoCredentialProviders", "no valid providers in chain", nil),
+			expected: &StateStoreError{
+				Reason:     StateStoreAnonymousCredentials,
+				Suggestion: "Configure AWS credentials, for example by setting AWS_PROFILE, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.",
+			},
+		},
+		{
+			name:      "anonymous credentials",
+			anonymous: true,
+			err:       awserr.NewRequestFailure(awserr.New("AccessDenied", "Access Denied", nil), 403, "request-id"),
+			expected: &StateStoreError{
+				Reason:     StateStoreAnonymousCredentials,
+				Suggestion: "Configure AWS credentials, for example by setting AWS_PROFILE, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			if g.s3Region {
+				t.Setenv("S3_ENDPOINT", "https://s3.example.com")
+			} else {
+				t.Setenv("S3_ENDPOINT", "")
+			}
+
+			client := &mockListObjectsS3{err: g.err}
+			err := preflightS3(context.TODO(), client, "s3://my-bucket/clusters", "my-bucket", "clusters", g.anonymous)
+
+			if aws.StringValue(client.request.Prefix) != "clusters/" || aws.Int64Value(client.request.MaxKeys) != 1 {
+				t.Errorf("unexpected request: %v", client.request)
+			}
+			if g.expected == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var stateStoreErr *StateStoreError
+			if !errors.As(err, &stateStoreErr) {
+				t.Fatalf("expected a StateStoreError, got %v", err)
+			}
+			g.expected.Path = "s3://my-bucket/clusters"
+			g.expected.Bucket = "my-bucket"
+			g.expected.Err = g.err
+			if !reflect.DeepEqual(stateStoreErr, g.expected) {
+				t.Errorf("unexpected error:\nexpected: %#v\nactual:   %#v", g.expected, stateStoreErr)
+			}
+		})
+	}
+}
+
+func TestPreflightS3UnknownError(t *testing.T) {
+	t.Setenv("S3_ENDPOINT", "")
+
+	err := awserr.NewRequestFailure(awserr.New("InternalError", "We encountered an internal error", nil), 500, "request-id")
+	client := &mockListObjectsS3{err: err}
+	if actual := preflightS3(context.TODO(), client, "s3://my-bucket", "my-bucket", "", false); actual != err {
+		t.Errorf("expected the error to be returned unchanged, got %v", actual)
+	}
+	if client.request.Prefix != nil {
+		t.Errorf("expected no prefix, got %q", aws.StringValue(client.request.Prefix))
+	}
+}
+
+func TestStateStoreErrorMessage(t *testing.T) {
+	err := &StateStoreError{
+		Reason:     StateStoreAccessDenied,
+		Path:       "s3://my-bucket",
+		Bucket:     "my-bucket",
+		Action:     "s3:ListBucket",
+		Suggestion: "Allow s3:ListBucket.",
+	}
+	expected := "state store \"s3://my-bucket\" cannot be used: access to bucket \"my-bucket\" was denied, the \"s3:ListBucket\" permission is missing\nAllow s3:ListBucket."
+	if err.Error() != expected {
+		t.Errorf("unexpected message:\nexpected: %q\nactual:   %q", expected, err.Error())
+	}
+}
+
+// preflightPath is a Path whose preflight check returns a fixed error.
+type preflightPath struct {
+	*MemFSPath
+	err       error
+	preflight int
+}
+
+func (p *preflightPath) PreflightStateStore(ctx context.Context) error {
+	p.preflight++
+	return p.err
+}
+
+func TestExplainStateStoreError(t *testing.T) {
+	stateStoreErr := &StateStoreError{Reason: StateStoreBucketNotFound, Path: "s3://my-bucket", Bucket: "my-bucket"}
+	readErr := errors.New("error reading state store: NoSuchBucket")
+
+	grid := []struct {
+		name            string
+		err             error
+		preflightErr    error
+		expected        error
+		expectPreflight bool
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:     "not found",
+			err:      os.ErrNotExist,
+			expected: os.ErrNotExist,
+		},
+		{
+			name:            "state store cannot be used",
+			err:             readErr,
+			preflightErr:    stateStoreErr,
+			expected:        stateStoreErr,
+			expectPreflight: true,
+		},
+		{
+			name:            "state store is usable",
+			err:             readErr,
+			expected:        readErr,
+			expectPreflight: true,
+		},
+		{
+			name:            "preflight fails with an unknown error",
+			err:             readErr,
+			preflightErr:    errors.New("InternalError"),
+			expected:        readErr,
+			expectPreflight: true,
+		},
+		{
+			name:     "already explained",
+			err:      stateStoreErr,
+			expected: stateStoreErr,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			p := &preflightPath{MemFSPath: NewMemFSPath(NewMemFSContext(), "state"), err: g.preflightErr}
+			actual := ExplainStateStoreError(context.TODO(), p, g.err)
+			if actual != g.expected {
+				t.Errorf("unexpected error: expected %v, got %v", g.expected, actual)
+			}
+			if ran := p.preflight != 0; ran != g.expectPreflight {
+				t.Errorf("expected preflight to run=%v, ran %d times", g.expectPreflight, p.preflight)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StateStoreErrorReason is the reason a state store cannot be used.
+type StateStoreErrorReason string
+
+const (
+	// StateStoreBucketNotFound means the bucket of the state store does not exist.
+	StateStoreBucketNotFound StateStoreErrorReason = "BucketNotFound"
+	// StateStoreAccessDenied means the credentials are not allowed to use the state store.
+	StateStoreAccessDenied StateStoreErrorReason = "AccessDenied"
+	// StateStoreWrongRegion means the bucket of the state store is in another region than the one used to access it.
+	StateStoreWrongRegion StateStoreErrorReason = "WrongRegion"
+	// StateStoreAnonymousCredentials means no credentials were found, so the state store was accessed anonymously.
+	StateStoreAnonymousCredentials StateStoreErrorReason = "AnonymousCredentials"
+)
+
+// stateStoreBucketNotFoundSuggestion is the suggestion for StateStoreBucketNotFound, which is the same for all providers.
+const stateStoreBucketNotFoundSuggestion = "Check the --state flag or the KOPS_STATE_STORE environment variable for typos, or create the bucket."
+
+// StateStoreError is an actionable error about a state store that cannot be used.
+type StateStoreError struct {
+	Reason StateStoreErrorReason
+	// Path is the state store
+	Path string
+	// Bucket is the bucket of the state store
+	Bucket string
+	// Action is the permission that was missing, if known
+	Action string
+	// Region is the region the bucket is in, if known
+	Region string
+	// Suggestion explains how to fix the problem
+	Suggestion string
+	// Err is the error returned by the storage API
+	Err error
+}
+
+func (e *StateStoreError) Error() string {
+	var s string
+	switch e.Reason {
+	case StateStoreBucketNotFound:
+		s = fmt.Sprintf("state store %q cannot be used: bucket %q does not exist", e.Path, e.Bucket)
+	case StateStoreAccessDenied:
+		if e.Action != "" {
+			s = fmt.Sprintf("state store %q cannot be used: access to bucket %q was denied, the %q permission is missing", e.Path, e.Bucket, e.Action)
+		} else {
+			s = fmt.Sprintf("state store %q cannot be used: access to bucket %q was denied", e.Path, e.Bucket)
+		}
+	case StateStoreWrongRegion:
+		if e.Region != "" {
+			s = fmt.Sprintf("state store %q cannot be used: bucket %q is in region %q", e.Path, e.Bucket, e.Region)
+		} else {
+			s = fmt.Sprintf("state store %q cannot be used: bucket %q is in another region", e.Path, e.Bucket)
+		}
+	case StateStoreAnonymousCredentials:
+		s = fmt.Sprintf("state store %q cannot be used: no credentials were found", e.Path)
+	default:
+		s = fmt.Sprintf("state store %q cannot be used: %v", e.Path, e.Err)
+	}
+	if e.Suggestion != "" {
+		s += "\n" + e.Suggestion
+	}
+	return s
+}
+
+func (e *StateStoreError) Unwrap() error {
+	return e.Err
+}
+
+// HasStateStorePreflight is implemented by paths that can check whether they are usable as a state store.
+type HasStateStorePreflight interface {
+	// PreflightStateStore checks that the path can be listed, returning a *StateStoreError
+	// when the failure is one the user can act on.
+	PreflightStateStore(ctx context.Context) error
+}
+
+// PreflightStateStore checks that a state store can be used, returning a *StateStoreError
+// when a missing bucket or missing permissions are the cause.
+// Paths that do not support the check are assumed to be usable.
+func PreflightStateStore(ctx context.Context, p Path) error {
+	if hp, ok := p.(HasStateStorePreflight); ok {
+		return hp.PreflightStateStore(ctx)
+	}
+	return nil
+}
+
+// ExplainStateStoreError is called after an operation on the state store p failed with err.
+// It runs PreflightStateStore and returns the resulting *StateStoreError if the state store
+// cannot be used, so the user sees why; otherwise it returns err unchanged.
+func ExplainStateStoreError(ctx context.Context, p Path, err error) error {
+	if err == nil || errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrExist) {
+		return err
+	}
+	var stateStoreError *StateStoreError
+	if errors.As(err, &stateStoreError) {
+		return err
+	}
+	if preflightErr := PreflightStateStore(ctx, p); errors.As(preflightErr, &stateStoreError) {
+		return stateStoreError
+	}
+	return err
+}
+
+// missingPermission extracts the permission named in a message like
+// "... is not authorized to perform: s3:ListBucket on resource ..."
+// or "... does not have storage.objects.list access to ...".
+func missingPermission(message string) string {
+	for _, marker := range []string{"not authorized to perform: ", "does not have "} {
+		i := strings.Index(message, marker)
+		if i == -1 {
+			continue
+		}
+		fields := strings.Fields(message[i+len(marker):])
+		if len(fields) != 0 {
+			return strings.TrimRight(fields[0], ".,;")
+		}
+	}
+	return ""
+}